@@ -0,0 +1,29 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin"
+)
+
+const botActionsAPIPrefix = "/api/v2/bot/"
+
+func isBotActionRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, botActionsAPIPrefix)
+}
+
+// isAccessTokenSession returns true if the request was authenticated with
+// a personal access token, which includes the tokens of bots.
+func (p *Plugin) isAccessTokenSession(c *plugin.Context) bool {
+	if c == nil || c.SessionId == "" {
+		return false
+	}
+
+	session, appErr := p.API.GetSession(c.SessionId)
+	if appErr != nil || session == nil {
+		return false
+	}
+	return session.Props[mmModel.SessionPropType] == mmModel.SessionTypeUserAccessToken
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin"
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBotActionRequest(t *testing.T) {
+	assert.True(t, isBotActionRequest(httptest.NewRequest(http.MethodPost, "/api/v2/bot/boards/board-id/cards", nil)))
+	assert.False(t, isBotActionRequest(httptest.NewRequest(http.MethodPost, "/api/v2/boards/board-id/blocks", nil)))
+}
+
+func TestIsAccessTokenSession(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("GetSession", "token-session").Return(&mmModel.Session{
+		Id:    "token-session",
+		Props: mmModel.StringMap{mmModel.SessionPropType: mmModel.SessionTypeUserAccessToken},
+	}, nil)
+	api.On("GetSession", "web-session").Return(&mmModel.Session{Id: "web-session", Props: mmModel.StringMap{}}, nil)
+	api.On("GetSession", "missing-session").Return(nil, mmModel.NewAppError("GetSession", "not_found", nil, "", http.StatusNotFound))
+
+	p := Plugin{}
+	p.SetAPI(api)
+
+	assert.True(t, p.isAccessTokenSession(&plugin.Context{SessionId: "token-session"}))
+	assert.False(t, p.isAccessTokenSession(&plugin.Context{SessionId: "web-session"}))
+	assert.False(t, p.isAccessTokenSession(&plugin.Context{SessionId: "missing-session"}))
+	assert.False(t, p.isAccessTokenSession(&plugin.Context{}))
+	assert.False(t, p.isAccessTokenSession(nil))
+}
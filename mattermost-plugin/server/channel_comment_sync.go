@@ -0,0 +1,86 @@
+package main
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify/notifychannelsync"
+	"github.com/mattermost/focalboard/server/utils"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// MessageHasBeenPosted syncs the replies on the threads of the cards of
+// the boards linked to the channel back into card comments.
+func (p *Plugin) MessageHasBeenPosted(_ *plugin.Context, post *mmModel.Post) {
+	if p.channelSync == nil || !isChannelReply(post, p.channelSync.BotID()) {
+		return
+	}
+
+	root, appErr := p.API.GetPost(post.RootId)
+	if appErr != nil {
+		p.API.LogError("cannot get root of reply", "postID", post.Id, "rootID", post.RootId, "error", appErr.Error())
+		return
+	}
+
+	boardID, _ := root.GetProp(notifychannelsync.PostPropBoardID).(string)
+	cardID, _ := root.GetProp(notifychannelsync.PostPropCardID).(string)
+	if boardID == "" || cardID == "" {
+		return
+	}
+
+	board, err := p.server.App().GetBoard(boardID)
+	if err != nil || board == nil {
+		return
+	}
+	if !board.SyncsChannelComments() || board.ChannelID != post.ChannelId {
+		return
+	}
+
+	if !p.permissions.HasPermissionToBoard(post.UserId, board.ID, model.PermissionManageBoardCards) {
+		p.server.Logger().Debug("skipping reply sync; user cannot comment on the board",
+			mlog.String("userID", post.UserId),
+			mlog.String("boardID", board.ID),
+		)
+		return
+	}
+
+	comment := commentFromPost(post, board.ID, cardID)
+	if err := p.server.App().InsertBlock(comment, post.UserId); err != nil {
+		p.server.Logger().Error("cannot create comment from reply",
+			mlog.String("postID", post.Id),
+			mlog.String("cardID", cardID),
+			mlog.Err(err),
+		)
+	}
+}
+
+// isChannelReply returns true if the post is a reply written by a user,
+// not one posted by the sync itself.
+func isChannelReply(post *mmModel.Post, botID string) bool {
+	if post.RootId == "" || post.UserId == botID || post.Type != "" {
+		return false
+	}
+	fromBoards, _ := post.GetProp(notifychannelsync.PostPropFromBoards).(bool)
+	return !fromBoards
+}
+
+// commentFromPost returns the card comment for a reply, marked with the
+// ID of the post so it is not synced back into the channel.
+func commentFromPost(post *mmModel.Post, boardID, cardID string) model.Block {
+	now := utils.GetMillis()
+	return model.Block{
+		ID:         utils.NewID(utils.IDTypeBlock),
+		ParentID:   cardID,
+		BoardID:    boardID,
+		Type:       model.TypeComment,
+		Title:      post.Message,
+		CreatedBy:  post.UserId,
+		ModifiedBy: post.UserId,
+		CreateAt:   now,
+		UpdateAt:   now,
+		Fields: map[string]interface{}{
+			notifychannelsync.CommentFieldChannelPostID: post.Id,
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify/notifychannelsync"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsChannelReply(t *testing.T) {
+	reply := &mmModel.Post{Id: "post-id", UserId: "user-id", RootId: "root-id"}
+	assert.True(t, isChannelReply(reply, "bot-id"))
+
+	assert.False(t, isChannelReply(&mmModel.Post{UserId: "user-id"}, "bot-id"), "root posts")
+	assert.False(t, isChannelReply(&mmModel.Post{UserId: "bot-id", RootId: "root-id"}, "bot-id"), "bot posts")
+	assert.False(t, isChannelReply(&mmModel.Post{UserId: "user-id", RootId: "root-id", Type: mmModel.PostTypeJoinChannel}, "bot-id"), "system posts")
+
+	synced := &mmModel.Post{UserId: "user-id", RootId: "root-id"}
+	synced.AddProp(notifychannelsync.PostPropFromBoards, true)
+	assert.False(t, isChannelReply(synced, "bot-id"), "posts created by the sync")
+}
+
+func TestCommentFromPost(t *testing.T) {
+	post := &mmModel.Post{Id: "post-id", UserId: "user-id", Message: "on it"}
+	comment := commentFromPost(post, "board-id", "card-id")
+
+	assert.Equal(t, model.TypeComment, comment.Type)
+	assert.Equal(t, "card-id", comment.ParentID)
+	assert.Equal(t, "board-id", comment.BoardID)
+	assert.Equal(t, "on it", comment.Title)
+	assert.Equal(t, "user-id", comment.CreatedBy)
+	assert.Equal(t, "post-id", comment.Fields[notifychannelsync.CommentFieldChannelPostID])
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	commandTrigger = "focalboard"
+
+	// myCardsLimit is the maximum number of cards listed by the my-cards
+	// command.
+	myCardsLimit = 10
+)
+
+const commandHelp = "Available commands:\n" +
+	"- `/focalboard card create <board> <title>`: creates a card on a board. Use quotes for board names with spaces\n" +
+	"- `/focalboard my-cards`: lists the cards assigned to you"
+
+func getCommand() *mmModel.Command {
+	autocomplete := mmModel.NewAutocompleteData(commandTrigger, "[command]", "Available commands: card create, my-cards")
+
+	card := mmModel.NewAutocompleteData("card", "[command]", "Manage cards")
+	create := mmModel.NewAutocompleteData("create", "<board> <title>", "Create a card on a board")
+	create.AddTextArgument("Name or ID of the board", "<board>", "")
+	create.AddTextArgument("Title of the card", "<title>", "")
+	card.AddCommand(create)
+	autocomplete.AddCommand(card)
+
+	autocomplete.AddCommand(mmModel.NewAutocompleteData("my-cards", "", "List the cards assigned to you"))
+
+	return &mmModel.Command{
+		Trigger:          commandTrigger,
+		DisplayName:      "Boards",
+		Description:      "Create and find cards of your boards",
+		AutoComplete:     true,
+		AutoCompleteDesc: "Available commands: card create, my-cards",
+		AutoCompleteHint: "[command]",
+		AutocompleteData: autocomplete,
+	}
+}
+
+func (p *Plugin) ExecuteCommand(_ *plugin.Context, args *mmModel.CommandArgs) (*mmModel.CommandResponse, *mmModel.AppError) {
+	fields := splitCommandArgs(args.Command)
+	if len(fields) < 2 {
+		return ephemeralResponse(commandHelp), nil
+	}
+
+	var text string
+	switch {
+	case fields[1] == "card" && len(fields) > 2 && fields[2] == "create":
+		text = p.executeCreateCard(args, fields[3:])
+	case fields[1] == "my-cards":
+		text = p.executeMyCards(args)
+	default:
+		text = commandHelp
+	}
+
+	return ephemeralResponse(text), nil
+}
+
+func (p *Plugin) executeCreateCard(args *mmModel.CommandArgs, params []string) string {
+	if len(params) < 2 {
+		return "Usage: `/focalboard card create <board> <title>`"
+	}
+	boardName := params[0]
+	title := strings.Join(params[1:], " ")
+
+	board, err := p.findBoardForUser(args.UserId, args.TeamId, boardName)
+	if err != nil {
+		p.server.Logger().Error("cannot get the boards of the user", mlog.String("userID", args.UserId), mlog.Err(err))
+		return "An error occurred while looking for the board."
+	}
+	if board == nil {
+		return fmt.Sprintf("Board %q not found.", boardName)
+	}
+
+	if !p.permissions.HasPermissionToBoard(args.UserId, board.ID, model.PermissionManageBoardCards) {
+		return fmt.Sprintf("You don't have permission to create cards on board %q.", board.Title)
+	}
+
+	card, err := p.server.App().CreateCard(board.ID, title, "", args.UserId)
+	if err != nil {
+		p.server.Logger().Error("cannot create card from command", mlog.String("boardID", board.ID), mlog.Err(err))
+		return "An error occurred while creating the card."
+	}
+
+	link := utils.MakeCardLink(getBoardsURL(args.SiteURL), board.TeamID, board.ID, card.ID)
+	return fmt.Sprintf("Created card [%s](%s) on board **%s**.", card.Title, link, board.Title)
+}
+
+func (p *Plugin) executeMyCards(args *mmModel.CommandArgs) string {
+	cards, err := p.server.App().GetMyWork(args.UserId, args.TeamId, model.MyWorkQuery{})
+	if err != nil {
+		p.server.Logger().Error("cannot get the cards of the user", mlog.String("userID", args.UserId), mlog.Err(err))
+		return "An error occurred while getting your cards."
+	}
+	if len(cards) == 0 {
+		return "You have no cards assigned in this team."
+	}
+
+	boardsURL := getBoardsURL(args.SiteURL)
+	lines := []string{"#### Cards assigned to you"}
+	for i, card := range cards {
+		if i == myCardsLimit {
+			lines = append(lines, fmt.Sprintf("And %d more.", len(cards)-myCardsLimit))
+			break
+		}
+		link := utils.MakeCardLink(boardsURL, args.TeamId, card.Card.BoardID, card.Card.ID)
+		line := fmt.Sprintf("- [%s](%s) in **%s**", card.Card.Title, link, card.BoardTitle)
+		if card.Status != "" {
+			line += fmt.Sprintf(" · %s", card.Status)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// findBoardForUser returns the board of the team the user is a member of
+// with the given ID, or else with the given title.
+func (p *Plugin) findBoardForUser(userID, teamID, nameOrID string) (*model.Board, error) {
+	boards, err := p.server.App().GetBoardsForUserAndTeam(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *model.Board
+	for _, board := range boards {
+		if board.IsTemplate {
+			continue
+		}
+		if board.ID == nameOrID {
+			return board, nil
+		}
+		if found == nil && strings.EqualFold(board.Title, nameOrID) {
+			found = board
+		}
+	}
+	return found, nil
+}
+
+func getBoardsURL(siteURL string) string {
+	return strings.TrimSuffix(siteURL, "/") + "/boards"
+}
+
+func ephemeralResponse(text string) *mmModel.CommandResponse {
+	return &mmModel.CommandResponse{
+		ResponseType: mmModel.CommandResponseTypeEphemeral,
+		Text:         text,
+	}
+}
+
+// splitCommandArgs splits the command by spaces, keeping the text
+// between double quotes as a single argument.
+func splitCommandArgs(command string) []string {
+	fields := []string{}
+	var current strings.Builder
+	inQuotes := false
+	hasField := false
+
+	for _, r := range command {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasField = true
+		case r == ' ' && !inQuotes:
+			if hasField {
+				fields = append(fields, current.String())
+				current.Reset()
+				hasField = false
+			}
+		default:
+			current.WriteRune(r)
+			hasField = true
+		}
+	}
+	if hasField {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
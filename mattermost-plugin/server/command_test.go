@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCommandArgs(t *testing.T) {
+	assert.Equal(t, []string{"/focalboard", "my-cards"}, splitCommandArgs("/focalboard  my-cards "))
+	assert.Equal(t,
+		[]string{"/focalboard", "card", "create", "Sprint board", "Fix", "login"},
+		splitCommandArgs(`/focalboard card create "Sprint board" Fix login`),
+	)
+	assert.Equal(t, []string{"a", ""}, splitCommandArgs(`a ""`))
+}
+
+func TestExecuteCommand(t *testing.T) {
+	th := SetupTestHelper(t)
+	plugin := Plugin{server: th.Server}
+
+	board := &model.Board{ID: "board-id", TeamID: "team-id", Title: "Sprint board"}
+
+	execute := func(command string) string {
+		resp, appErr := plugin.ExecuteCommand(nil, &mmModel.CommandArgs{
+			UserId:  "user-id",
+			TeamId:  "team-id",
+			SiteURL: "http://localhost:8065",
+			Command: command,
+		})
+		require.Nil(t, appErr)
+		require.Equal(t, mmModel.CommandResponseTypeEphemeral, resp.ResponseType)
+		return resp.Text
+	}
+
+	t.Run("shows the help", func(t *testing.T) {
+		assert.Equal(t, commandHelp, execute("/focalboard"))
+		assert.Equal(t, commandHelp, execute("/focalboard unknown"))
+	})
+
+	t.Run("card create usage", func(t *testing.T) {
+		assert.Contains(t, execute("/focalboard card create board-only"), "Usage")
+	})
+
+	t.Run("card create with an unknown board", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id").Return([]*model.Board{board}, nil)
+
+		assert.Equal(t, `Board "Other board" not found.`, execute(`/focalboard card create "Other board" New card`))
+	})
+
+	t.Run("my-cards without cards", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id").Return([]*model.Board{board}, nil)
+		th.Store.EXPECT().GetCardsWithFieldValue([]string{}, "user-id").Return([]model.Block{}, nil)
+
+		assert.Equal(t, "You have no cards assigned in this team.", execute("/focalboard my-cards"))
+	})
+}
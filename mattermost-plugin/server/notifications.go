@@ -3,19 +3,26 @@ package main
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/notify/notifyassignments"
+	"github.com/mattermost/focalboard/server/services/notify/notifychannelsync"
 	"github.com/mattermost/focalboard/server/services/notify/notifymentions"
+	"github.com/mattermost/focalboard/server/services/notify/notifyplugins"
 	"github.com/mattermost/focalboard/server/services/notify/notifysubscriptions"
 	"github.com/mattermost/focalboard/server/services/notify/plugindelivery"
 	"github.com/mattermost/focalboard/server/services/permissions"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/services/webhook"
 	"github.com/mattermost/focalboard/server/ws"
 
 	pluginapi "github.com/mattermost/mattermost-plugin-api"
 	apierrors "github.com/mattermost/mattermost-plugin-api/errors"
 
 	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -34,10 +41,11 @@ type notifyBackendParams struct {
 	wsAdapter   ws.Adapter
 	serverRoot  string
 	logger      *mlog.Logger
+	router      *notify.Router
 }
 
 func createMentionsNotifyBackend(params notifyBackendParams) (*notifymentions.Backend, error) {
-	delivery, err := createDelivery(params.client, params.serverRoot)
+	delivery, err := createDelivery(params.client, params.serverRoot, params.router)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +64,7 @@ func createMentionsNotifyBackend(params notifyBackendParams) (*notifymentions.Ba
 }
 
 func createSubscriptionsNotifyBackend(params notifyBackendParams) (*notifysubscriptions.Backend, error) {
-	delivery, err := createDelivery(params.client, params.serverRoot)
+	delivery, err := createDelivery(params.client, params.serverRoot, params.router)
 	if err != nil {
 		return nil, err
 	}
@@ -70,13 +78,104 @@ func createSubscriptionsNotifyBackend(params notifyBackendParams) (*notifysubscr
 		Logger:                 params.logger,
 		NotifyFreqCardSeconds:  params.cfg.NotifyFreqCardSeconds,
 		NotifyFreqBoardSeconds: params.cfg.NotifyFreqBoardSeconds,
+		Router:                 params.router,
 	}
 	backend := notifysubscriptions.New(backendParams)
 
 	return backend, nil
 }
 
-func createDelivery(client *pluginapi.Client, serverRoot string) (*plugindelivery.PluginDelivery, error) {
+func createAssignmentsNotifyBackend(params notifyBackendParams) (*notifyassignments.Backend, error) {
+	delivery, err := createDelivery(params.client, params.serverRoot, params.router)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifyassignments.BackendParams{
+		Store:       params.store,
+		Permissions: params.permissions,
+		Delivery:    delivery,
+		Logger:      params.logger,
+	}
+	backend := notifyassignments.New(backendParams)
+
+	return backend, nil
+}
+
+func createChannelSyncNotifyBackend(params notifyBackendParams) (*notifychannelsync.Backend, error) {
+	botID, err := ensureBot(params.client)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifychannelsync.BackendParams{
+		ServerRoot: params.serverRoot,
+		BotID:      botID,
+		Store:      params.store,
+		Posts:      &pluginAPIAdapter{client: params.client},
+		Logger:     params.logger,
+	}
+	backend := notifychannelsync.New(backendParams)
+
+	return backend, nil
+}
+
+// createPluginsNotifyBackend creates the backend that delivers the board
+// events to the subscribed plugins.
+func createPluginsNotifyBackend(api plugin.API, logger *mlog.Logger) *notifyplugins.Backend {
+	backendParams := notifyplugins.BackendParams{
+		Store:  &pluginEventSubscriptionStore{api: api},
+		API:    api,
+		Logger: logger,
+	}
+	return notifyplugins.New(backendParams)
+}
+
+// createNotificationRouter creates the router that delivers the mention and
+// assignment notifications over the channels selected by each user, holding
+// them back during the quiet hours and Do Not Disturb status of the users.
+// The router also makes a change send a single notification to each user
+// across the mention, assignment and subscription backends. Its window
+// covers the batching of the card subscription notifications, which are
+// sent last.
+func createNotificationRouter(params notifyBackendParams) (*notify.Router, error) {
+	router := notify.NewRouter(params.store, params.logger)
+	router.SetChangeWindow(notify.DefaultChangeWindow + time.Duration(params.cfg.NotifyFreqCardSeconds)*time.Second)
+
+	dmDelivery, err := createDelivery(params.client, params.serverRoot, nil)
+	if err != nil {
+		return nil, err
+	}
+	router.AddChannel(dmDelivery)
+
+	router.AddChannel(plugindelivery.NewEmailChannel(&pluginAPIAdapter{client: params.client}))
+
+	if webhookChannel := webhook.NewClient(params.cfg, params.store, params.logger).NotificationChannel(); webhookChannel != nil {
+		router.AddChannel(webhookChannel)
+	}
+
+	router.SetDeferral(params.store, &pluginAPIAdapter{client: params.client})
+	router.StartDeferredDelivery()
+
+	return router, nil
+}
+
+func createDelivery(client *pluginapi.Client, serverRoot string, router *notify.Router) (*plugindelivery.PluginDelivery, error) {
+	botID, err := ensureBot(client)
+	if err != nil {
+		return nil, err
+	}
+
+	pluginAPI := &pluginAPIAdapter{client: client}
+
+	delivery := plugindelivery.New(botID, serverRoot, pluginAPI)
+	if router != nil {
+		delivery.SetRouter(router)
+	}
+	return delivery, nil
+}
+
+func ensureBot(client *pluginapi.Client) (string, error) {
 	bot := &model.Bot{
 		Username:    botUsername,
 		DisplayName: botDisplayname,
@@ -84,12 +183,9 @@ func createDelivery(client *pluginapi.Client, serverRoot string) (*plugindeliver
 	}
 	botID, err := client.Bot.EnsureBot(bot)
 	if err != nil {
-		return nil, fmt.Errorf("failed to ensure %s bot: %w", botDisplayname, err)
+		return "", fmt.Errorf("failed to ensure %s bot: %w", botDisplayname, err)
 	}
-
-	pluginAPI := &pluginAPIAdapter{client: client}
-
-	return plugindelivery.New(botID, serverRoot, pluginAPI), nil
+	return botID, nil
 }
 
 type pluginAPIAdapter struct {
@@ -124,6 +220,26 @@ func (da *pluginAPIAdapter) GetChannelMember(channelID string, userID string) (*
 	return da.client.Channel.GetMember(channelID, userID)
 }
 
+// IsConfigured reports whether the Mattermost server has an SMTP server
+// to send the emails with.
+func (da *pluginAPIAdapter) IsConfigured() bool {
+	smtpServer := da.client.Configuration.GetConfig().EmailSettings.SMTPServer
+	return smtpServer != nil && *smtpServer != ""
+}
+
+func (da *pluginAPIAdapter) SendMail(to, subject, htmlBody string) error {
+	return da.client.Mail.Send(to, subject, htmlBody)
+}
+
 func (da *pluginAPIAdapter) IsErrNotFound(err error) bool {
 	return errors.Is(err, apierrors.ErrNotFound)
 }
+
+// IsUserAvailable returns false if the user set the Do Not Disturb status.
+func (da *pluginAPIAdapter) IsUserAvailable(userID string) (bool, error) {
+	status, err := da.client.User.GetStatus(userID)
+	if err != nil {
+		return false, err
+	}
+	return status.Status != model.StatusDnd, nil
+}
@@ -15,7 +15,10 @@ import (
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/server"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/entitlements"
 	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/notify/notifychannelsync"
+	"github.com/mattermost/focalboard/server/services/permissions"
 	"github.com/mattermost/focalboard/server/services/permissions/mmpermissions"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/services/store/mattermostauthlayer"
@@ -38,6 +41,7 @@ const (
 
 	notifyFreqCardSecondsKey  = "notify_freq_card_seconds"
 	notifyFreqBoardSecondsKey = "notify_freq_board_seconds"
+	notifyWebhooksKey         = "notify_webhooks"
 )
 
 var ErrInsufficientLicense = errors.New("appropriate license required")
@@ -64,6 +68,9 @@ type Plugin struct {
 
 	server          *server.Server
 	wsPluginAdapter ws.PluginAdapterInterface
+	permissions     permissions.PermissionsService
+	channelSync     *notifychannelsync.Backend
+	router          *notify.Router
 }
 
 func (p *Plugin) OnActivate() error {
@@ -120,6 +127,7 @@ func (p *Plugin) OnActivate() error {
 	}
 
 	permissionsService := mmpermissions.New(db, p.API)
+	p.permissions = permissionsService
 
 	p.wsPluginAdapter = ws.NewPluginAdapter(p.API, auth.New(cfg, db, permissionsService), db, logger)
 
@@ -133,6 +141,13 @@ func (p *Plugin) OnActivate() error {
 		logger:      logger,
 	}
 
+	router, err := createNotificationRouter(backendParams)
+	if err != nil {
+		return fmt.Errorf("error creating notification router: %w", err)
+	}
+	backendParams.router = router
+	p.router = router
+
 	var notifyBackends []notify.Backend
 
 	mentionsBackend, err := createMentionsNotifyBackend(backendParams)
@@ -148,6 +163,22 @@ func (p *Plugin) OnActivate() error {
 	notifyBackends = append(notifyBackends, subscriptionsBackend)
 	mentionsBackend.AddListener(subscriptionsBackend)
 
+	assignmentsBackend, err := createAssignmentsNotifyBackend(backendParams)
+	if err != nil {
+		return fmt.Errorf("error creating assignment notifications backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, assignmentsBackend)
+
+	channelSyncBackend, err := createChannelSyncNotifyBackend(backendParams)
+	if err != nil {
+		return fmt.Errorf("error creating channel sync notifications backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, channelSyncBackend)
+	p.channelSync = channelSyncBackend
+
+	pluginsBackend := createPluginsNotifyBackend(p.API, logger)
+	notifyBackends = append(notifyBackends, pluginsBackend)
+
 	params := server.Params{
 		Cfg:                cfg,
 		SingleUserToken:    "",
@@ -157,6 +188,7 @@ func (p *Plugin) OnActivate() error {
 		WSAdapter:          p.wsPluginAdapter,
 		NotifyBackends:     notifyBackends,
 		PermissionsService: permissionsService,
+		MailSender:         &pluginAPIAdapter{client: client},
 	}
 
 	server, err := server.New(params)
@@ -166,7 +198,14 @@ func (p *Plugin) OnActivate() error {
 	}
 
 	p.server = server
-	return server.Start()
+	if err := server.Start(); err != nil {
+		return err
+	}
+
+	if err := p.API.RegisterCommand(getCommand()); err != nil {
+		return fmt.Errorf("error registering the %s command: %w", commandTrigger, err)
+	}
+	return nil
 }
 
 func (p *Plugin) createBoardsConfig(mmconfig mmModel.Config, baseURL string, serverID string) *config.Configuration {
@@ -230,6 +269,7 @@ func (p *Plugin) createBoardsConfig(mmconfig mmModel.Config, baseURL string, ser
 		Telemetry:                enableTelemetry,
 		TelemetryID:              serverID,
 		WebhookUpdate:            []string{},
+		NotifyWebhooks:           getPluginSettingList(mmconfig, notifyWebhooksKey),
 		SessionExpireTime:        2592000,
 		SessionRefreshTime:       18000,
 		LocalOnly:                false,
@@ -270,6 +310,25 @@ func getPluginSettingInt(mmConfig mmModel.Config, key string, def int) int {
 	return int(math.Round(valFloat))
 }
 
+// getPluginSettingList returns the values of a comma separated setting.
+func getPluginSettingList(mmConfig mmModel.Config, key string) []string {
+	list := []string{}
+	val, ok := getPluginSetting(mmConfig, key)
+	if !ok {
+		return list
+	}
+	valStr, ok := val.(string)
+	if !ok {
+		return list
+	}
+	for _, item := range strings.Split(valStr, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func parseFeatureFlags(configFeatureFlags map[string]string) map[string]string {
 	featureFlags := make(map[string]string)
 	for key, value := range configFeatureFlags {
@@ -298,6 +357,9 @@ func (p *Plugin) WebSocketMessageHasBeenPosted(webConnID, userID string, req *mm
 }
 
 func (p *Plugin) OnDeactivate() error {
+	if p.router != nil {
+		p.router.Shutdown()
+	}
 	return p.server.Shutdown()
 }
 
@@ -305,8 +367,73 @@ func (p *Plugin) OnPluginClusterEvent(_ *plugin.Context, ev mmModel.PluginCluste
 	p.wsPluginAdapter.HandleClusterEvent(ev)
 }
 
+// UserHasBeenDeactivated marks the board memberships of the deactivated
+// user as inactive. Only invoked by servers that support the hook.
+func (p *Plugin) UserHasBeenDeactivated(_ *plugin.Context, user *mmModel.User) {
+	if err := p.server.App().OnUserDeactivated(user.Id, ""); err != nil {
+		p.server.Logger().Error("cannot update the boards of the deactivated user",
+			mlog.String("user_id", user.Id),
+			mlog.Err(err),
+		)
+	}
+}
+
+// UserHasLoggedIn restores the board memberships of users that were
+// deactivated and have been reactivated since.
+func (p *Plugin) UserHasLoggedIn(_ *plugin.Context, user *mmModel.User) {
+	if err := p.server.App().OnUserActivated(user.Id); err != nil {
+		p.server.Logger().Error("cannot restore the boards of the reactivated user",
+			mlog.String("user_id", user.Id),
+			mlog.Err(err),
+		)
+	}
+}
+
+// UserHasJoinedChannel adds the user to the boards that keep their
+// members in sync with the channel.
+func (p *Plugin) UserHasJoinedChannel(_ *plugin.Context, channelMember *mmModel.ChannelMember, _ *mmModel.User) {
+	if err := p.server.App().OnChannelMemberJoined(channelMember.ChannelId, channelMember.UserId); err != nil {
+		p.server.Logger().Error("cannot sync the boards of the channel with the new member",
+			mlog.String("channel_id", channelMember.ChannelId),
+			mlog.String("user_id", channelMember.UserId),
+			mlog.Err(err),
+		)
+	}
+}
+
+// UserHasLeftChannel removes the user from the boards that keep their
+// members in sync with the channel.
+func (p *Plugin) UserHasLeftChannel(_ *plugin.Context, channelMember *mmModel.ChannelMember, _ *mmModel.User) {
+	if err := p.server.App().OnChannelMemberLeft(channelMember.ChannelId, channelMember.UserId); err != nil {
+		p.server.Logger().Error("cannot sync the boards of the channel with the removed member",
+			mlog.String("channel_id", channelMember.ChannelId),
+			mlog.String("user_id", channelMember.UserId),
+			mlog.Err(err),
+		)
+	}
+}
+
 // ServeHTTP demonstrates a plugin that handles HTTP requests by greeting the world.
-func (p *Plugin) ServeHTTP(_ *plugin.Context, w http.ResponseWriter, r *http.Request) {
+func (p *Plugin) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
+	// the bot action API is only for integrations, so it's restricted to
+	// bot and personal access tokens
+	if isBotActionRequest(r) && !p.isAccessTokenSession(c) {
+		writeError(w, http.StatusUnauthorized, "a bot or personal access token is required")
+		return
+	}
+
+	// creating cards from posts needs the plugin API, so it's handled
+	// here instead of in the boards API
+	if postID, ok := matchCreateCardFromPost(r); ok {
+		p.handleCreateCardFromPost(w, r, postID)
+		return
+	}
+
+	if isPluginEventSubscriptionsRequest(r) {
+		p.handlePluginEventSubscriptions(w, r)
+		return
+	}
+
 	router := p.server.GetRootRouter()
 	router.ServeHTTP(w, r)
 }
@@ -511,8 +638,7 @@ func isBoardsLink(link string) bool {
 
 func (p *Plugin) RunDataRetention(nowTime, batchSize int64) (int64, error) {
 	p.server.Logger().Debug("Boards RunDataRetention")
-	license := p.server.Store().GetLicense()
-	if license == nil || !(*license.Features.DataRetention) {
+	if !p.server.App().IsFeatureEnabled(entitlements.FeatureDataRetention) {
 		return 0, ErrInsufficientLicense
 	}
 
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/plugin"
+)
+
+const (
+	pluginEventSubscriptionsPath = "/api/v2/plugin-events/subscriptions"
+	pluginEventSubscriptionsKey  = "plugin_event_subscriptions"
+
+	// maxSubscriptionUpdateAttempts is the number of times an update of
+	// the subscriptions is retried when they are changed concurrently.
+	maxSubscriptionUpdateAttempts = 5
+)
+
+var errSubscriptionsChanged = errors.New("plugin event subscriptions changed concurrently")
+
+// pluginEventSubscriptionStore keeps the subscriptions of other plugins
+// to the board events in the key value store, shared by the cluster.
+type pluginEventSubscriptionStore struct {
+	api plugin.API
+}
+
+func (s *pluginEventSubscriptionStore) GetPluginEventSubscriptions() ([]*model.PluginEventSubscription, error) {
+	subscriptions, _, err := s.get()
+	return subscriptions, err
+}
+
+func (s *pluginEventSubscriptionStore) get() ([]*model.PluginEventSubscription, []byte, error) {
+	data, appErr := s.api.KVGet(pluginEventSubscriptionsKey)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	subscriptions := []*model.PluginEventSubscription{}
+	if len(data) == 0 {
+		return subscriptions, nil, nil
+	}
+	if err := json.Unmarshal(data, &subscriptions); err != nil {
+		return nil, nil, err
+	}
+	return subscriptions, data, nil
+}
+
+// update applies the change to the subscriptions, retrying if they are
+// changed by another request in the meantime.
+func (s *pluginEventSubscriptionStore) update(change func([]*model.PluginEventSubscription) []*model.PluginEventSubscription) error {
+	for i := 0; i < maxSubscriptionUpdateAttempts; i++ {
+		subscriptions, oldData, err := s.get()
+		if err != nil {
+			return err
+		}
+
+		newData, err := json.Marshal(change(subscriptions))
+		if err != nil {
+			return err
+		}
+
+		ok, appErr := s.api.KVCompareAndSet(pluginEventSubscriptionsKey, oldData, newData)
+		if appErr != nil {
+			return appErr
+		}
+		if ok {
+			return nil
+		}
+	}
+	return errSubscriptionsChanged
+}
+
+// Subscribe adds the subscription, replacing the one of the plugin for
+// the same callback path.
+func (s *pluginEventSubscriptionStore) Subscribe(subscription *model.PluginEventSubscription) error {
+	return s.update(func(subscriptions []*model.PluginEventSubscription) []*model.PluginEventSubscription {
+		updated := []*model.PluginEventSubscription{subscription}
+		for _, existing := range subscriptions {
+			if existing.PluginID != subscription.PluginID || existing.CallbackPath != subscription.CallbackPath {
+				updated = append(updated, existing)
+			}
+		}
+		return updated
+	})
+}
+
+// Unsubscribe removes all the subscriptions of the plugin.
+func (s *pluginEventSubscriptionStore) Unsubscribe(pluginID string) error {
+	return s.update(func(subscriptions []*model.PluginEventSubscription) []*model.PluginEventSubscription {
+		updated := []*model.PluginEventSubscription{}
+		for _, existing := range subscriptions {
+			if existing.PluginID != pluginID {
+				updated = append(updated, existing)
+			}
+		}
+		return updated
+	})
+}
+
+func isPluginEventSubscriptionsRequest(r *http.Request) bool {
+	return r.URL.Path == pluginEventSubscriptionsPath
+}
+
+// handlePluginEventSubscriptions subscribes, with POST, or unsubscribes,
+// with DELETE, the calling plugin to the board events. It is only
+// available through inter-plugin requests, for which the server sets the
+// ID of the calling plugin.
+func (p *Plugin) handlePluginEventSubscriptions(w http.ResponseWriter, r *http.Request) {
+	pluginID := r.Header.Get("Mattermost-Plugin-ID")
+	if pluginID == "" {
+		writeError(w, http.StatusForbidden, "only available to other plugins")
+		return
+	}
+
+	store := &pluginEventSubscriptionStore{api: p.API}
+
+	switch r.Method {
+	case http.MethodPost:
+		subscription, err := model.PluginEventSubscriptionFromJSON(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		subscription.PluginID = pluginID
+		if err := subscription.IsValid(); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if err := store.Subscribe(subscription); err != nil {
+			p.API.LogError("cannot save plugin event subscription", "pluginID", pluginID, "error", err.Error())
+			writeError(w, http.StatusInternalServerError, "cannot save the subscription")
+			return
+		}
+		writeJSON(w, subscription)
+	case http.MethodDelete:
+		if err := store.Unsubscribe(pluginID); err != nil {
+			p.API.LogError("cannot remove plugin event subscriptions", "pluginID", pluginID, "error", err.Error())
+			writeError(w, http.StatusInternalServerError, "cannot remove the subscriptions")
+			return
+		}
+		writeJSON(w, struct{}{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
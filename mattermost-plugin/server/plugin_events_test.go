@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest"
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginEventSubscriptionStore(t *testing.T) {
+	existing := []*model.PluginEventSubscription{
+		{PluginID: "playbooks", CallbackPath: "/events"},
+		{PluginID: "other", CallbackPath: "/boards"},
+	}
+	existingData, err := json.Marshal(existing)
+	require.NoError(t, err)
+
+	t.Run("subscribe replaces the subscription of the same path", func(t *testing.T) {
+		var saved []*model.PluginEventSubscription
+		api := &plugintest.API{}
+		api.On("KVGet", pluginEventSubscriptionsKey).Return(existingData, nil)
+		api.On("KVCompareAndSet", pluginEventSubscriptionsKey, existingData, mock.Anything).Run(func(args mock.Arguments) {
+			require.NoError(t, json.Unmarshal(args.Get(2).([]byte), &saved))
+		}).Return(true, nil)
+
+		store := &pluginEventSubscriptionStore{api: api}
+		err := store.Subscribe(&model.PluginEventSubscription{
+			PluginID:     "playbooks",
+			CallbackPath: "/events",
+			Events:       []model.PluginEventType{model.PluginEventCardCreated},
+		})
+		require.NoError(t, err)
+		require.Len(t, saved, 2)
+		assert.Equal(t, []model.PluginEventType{model.PluginEventCardCreated}, saved[0].Events)
+		assert.Equal(t, "other", saved[1].PluginID)
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		var saved []*model.PluginEventSubscription
+		api := &plugintest.API{}
+		api.On("KVGet", pluginEventSubscriptionsKey).Return(existingData, nil)
+		api.On("KVCompareAndSet", pluginEventSubscriptionsKey, existingData, mock.Anything).Run(func(args mock.Arguments) {
+			require.NoError(t, json.Unmarshal(args.Get(2).([]byte), &saved))
+		}).Return(true, nil)
+
+		store := &pluginEventSubscriptionStore{api: api}
+		require.NoError(t, store.Unsubscribe("playbooks"))
+		require.Len(t, saved, 1)
+		assert.Equal(t, "other", saved[0].PluginID)
+	})
+
+	t.Run("concurrent changes are retried", func(t *testing.T) {
+		api := &plugintest.API{}
+		api.On("KVGet", pluginEventSubscriptionsKey).Return(existingData, nil)
+		api.On("KVCompareAndSet", pluginEventSubscriptionsKey, existingData, mock.Anything).Return(false, nil)
+
+		store := &pluginEventSubscriptionStore{api: api}
+		require.ErrorIs(t, store.Unsubscribe("playbooks"), errSubscriptionsChanged)
+		api.AssertNumberOfCalls(t, "KVCompareAndSet", maxSubscriptionUpdateAttempts)
+	})
+}
+
+func TestHandlePluginEventSubscriptions(t *testing.T) {
+	t.Run("not an inter-plugin request", func(t *testing.T) {
+		plugin := Plugin{}
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, pluginEventSubscriptionsPath, strings.NewReader(`{"callbackPath": "/events"}`))
+		plugin.handlePluginEventSubscriptions(w, r)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("invalid subscription", func(t *testing.T) {
+		plugin := Plugin{}
+		plugin.SetAPI(&plugintest.API{})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, pluginEventSubscriptionsPath, strings.NewReader(`{"callbackPath": "events"}`))
+		r.Header.Set("Mattermost-Plugin-ID", "playbooks")
+		plugin.handlePluginEventSubscriptions(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
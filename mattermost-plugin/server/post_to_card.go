@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	postsAPIPrefix = "/api/v2/posts/"
+	postCardSuffix = "/card"
+
+	// maxCardTitleLength is the maximum length, in characters, of the
+	// title of the cards created from posts.
+	maxCardTitleLength = 255
+)
+
+// CreateCardFromPostRequest is the body of a request to create a card
+// from a post.
+type CreateCardFromPostRequest struct {
+	// The ID of the board to create the card on
+	BoardID string `json:"boardId"`
+}
+
+// CreateCardFromPostResponse is returned after a card is created from a
+// post.
+type CreateCardFromPostResponse struct {
+	// The created card
+	Card *model.Block `json:"card"`
+
+	// The link to the created card
+	Link string `json:"link"`
+}
+
+// matchCreateCardFromPost returns the post ID if the request is for the
+// create card from post endpoint, POST /api/v2/posts/{postID}/card.
+func matchCreateCardFromPost(r *http.Request) (string, bool) {
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, postsAPIPrefix) || !strings.HasSuffix(r.URL.Path, postCardSuffix) {
+		return "", false
+	}
+	postID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, postsAPIPrefix), postCardSuffix)
+	if !mmModel.IsValidId(postID) {
+		return "", false
+	}
+	return postID, true
+}
+
+func (p *Plugin) handleCreateCardFromPost(w http.ResponseWriter, r *http.Request, postID string) {
+	userID := r.Header.Get("Mattermost-User-Id")
+	if userID == "" {
+		writeError(w, http.StatusUnauthorized, "not authenticated")
+		return
+	}
+
+	// the same CSRF check as the rest of the API
+	if r.Header.Get("X-Requested-With") != "XMLHttpRequest" {
+		writeError(w, http.StatusBadRequest, "checkCSRFToken FAILED")
+		return
+	}
+
+	var request CreateCardFromPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.BoardID == "" {
+		writeError(w, http.StatusBadRequest, "boardId is required")
+		return
+	}
+
+	post, appErr := p.API.GetPost(postID)
+	if appErr != nil {
+		writeError(w, http.StatusNotFound, "post not found")
+		return
+	}
+
+	if !p.API.HasPermissionToChannel(userID, post.ChannelId, mmModel.PermissionReadChannel) {
+		writeError(w, http.StatusForbidden, "access denied to post")
+		return
+	}
+
+	if !p.permissions.HasPermissionToBoard(userID, request.BoardID, model.PermissionManageBoardCards) {
+		writeError(w, http.StatusForbidden, "access denied to make board changes")
+		return
+	}
+
+	board, err := p.server.App().GetBoard(request.BoardID)
+	if err != nil || board == nil {
+		writeError(w, http.StatusNotFound, "board not found")
+		return
+	}
+
+	siteURL := ""
+	if config := p.API.GetConfig(); config != nil && config.ServiceSettings.SiteURL != nil {
+		siteURL = *config.ServiceSettings.SiteURL
+	}
+
+	title, description := cardContentFromPost(post.Message, getPostPermalink(siteURL, post.Id))
+	card, err := p.server.App().CreateCard(board.ID, title, description, userID)
+	if err != nil {
+		p.server.Logger().Error("cannot create card from post",
+			mlog.String("postID", postID),
+			mlog.String("boardID", board.ID),
+			mlog.Err(err),
+		)
+		writeError(w, http.StatusInternalServerError, "cannot create the card")
+		return
+	}
+
+	writeJSON(w, CreateCardFromPostResponse{
+		Card: card,
+		Link: utils.MakeCardLink(getBoardsURL(siteURL), board.TeamID, board.ID, card.ID),
+	})
+}
+
+// cardContentFromPost returns the title and the description of a card
+// created from a post: the first line of the message is the title, and
+// the rest of it the description, followed by a link to the post.
+func cardContentFromPost(message, permalink string) (title, description string) {
+	message = strings.TrimSpace(message)
+	body := ""
+	if i := strings.Index(message, "\n"); i != -1 {
+		title, body = message[:i], strings.TrimSpace(message[i+1:])
+	} else {
+		title = message
+	}
+
+	title = strings.TrimSpace(title)
+	if runes := []rune(title); len(runes) > maxCardTitleLength {
+		title = string(runes[:maxCardTitleLength])
+	}
+
+	description = "[Original post](" + permalink + ")"
+	if body != "" {
+		description = body + "\n\n" + description
+	}
+	return title, description
+}
+
+func getPostPermalink(siteURL, postID string) string {
+	return strings.TrimSuffix(siteURL, "/") + "/_redirect/pl/" + postID
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	data, _ := json.Marshal(model.ErrorResponse{Error: message, ErrorCode: code})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardContentFromPost(t *testing.T) {
+	t.Run("single line message", func(t *testing.T) {
+		title, description := cardContentFromPost("  Fix the login  ", "http://localhost/_redirect/pl/post-id")
+		assert.Equal(t, "Fix the login", title)
+		assert.Equal(t, "[Original post](http://localhost/_redirect/pl/post-id)", description)
+	})
+
+	t.Run("multiline message", func(t *testing.T) {
+		title, description := cardContentFromPost("Fix the login\n\nIt fails with SSO\non mobile", "http://localhost/_redirect/pl/post-id")
+		assert.Equal(t, "Fix the login", title)
+		assert.Equal(t, "It fails with SSO\non mobile\n\n[Original post](http://localhost/_redirect/pl/post-id)", description)
+	})
+
+	t.Run("long titles are truncated", func(t *testing.T) {
+		title, _ := cardContentFromPost(strings.Repeat("a", maxCardTitleLength+10), "")
+		assert.Len(t, title, maxCardTitleLength)
+	})
+}
+
+func TestMatchCreateCardFromPost(t *testing.T) {
+	postID := mmModel.NewId()
+
+	id, ok := matchCreateCardFromPost(httptest.NewRequest(http.MethodPost, "/api/v2/posts/"+postID+"/card", nil))
+	assert.True(t, ok)
+	assert.Equal(t, postID, id)
+
+	_, ok = matchCreateCardFromPost(httptest.NewRequest(http.MethodGet, "/api/v2/posts/"+postID+"/card", nil))
+	assert.False(t, ok)
+
+	_, ok = matchCreateCardFromPost(httptest.NewRequest(http.MethodPost, "/api/v2/posts/invalid/card", nil))
+	assert.False(t, ok)
+
+	_, ok = matchCreateCardFromPost(httptest.NewRequest(http.MethodPost, "/api/v2/boards/"+postID+"/card", nil))
+	assert.False(t, ok)
+}
+
+func TestHandleCreateCardFromPost(t *testing.T) {
+	postID := mmModel.NewId()
+
+	newRequest := func(userID string, csrf bool) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/api/v2/posts/"+postID+"/card", strings.NewReader(`{"boardId": "board-id"}`))
+		if userID != "" {
+			r.Header.Set("Mattermost-User-Id", userID)
+		}
+		if csrf {
+			r.Header.Set("X-Requested-With", "XMLHttpRequest")
+		}
+		return r
+	}
+
+	t.Run("not authenticated", func(t *testing.T) {
+		plugin := Plugin{}
+		w := httptest.NewRecorder()
+		plugin.handleCreateCardFromPost(w, newRequest("", true), postID)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("missing CSRF header", func(t *testing.T) {
+		plugin := Plugin{}
+		w := httptest.NewRecorder()
+		plugin.handleCreateCardFromPost(w, newRequest("user-id", false), postID)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("post not found", func(t *testing.T) {
+		api := &plugintest.API{}
+		api.On("GetPost", postID).Return(nil, mmModel.NewAppError("GetPost", "not_found", nil, "", http.StatusNotFound))
+		plugin := Plugin{}
+		plugin.SetAPI(api)
+
+		w := httptest.NewRecorder()
+		plugin.handleCreateCardFromPost(w, newRequest("user-id", true), postID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("no access to the channel of the post", func(t *testing.T) {
+		api := &plugintest.API{}
+		api.On("GetPost", postID).Return(&mmModel.Post{Id: postID, ChannelId: "channel-id"}, nil)
+		api.On("HasPermissionToChannel", "user-id", "channel-id", mmModel.PermissionReadChannel).Return(false)
+		plugin := Plugin{}
+		plugin.SetAPI(api)
+
+		w := httptest.NewRecorder()
+		plugin.handleCreateCardFromPost(w, newRequest("user-id", true), postID)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
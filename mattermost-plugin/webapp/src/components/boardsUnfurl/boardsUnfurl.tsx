@@ -9,7 +9,7 @@ import {getCurrentUserLocale} from 'mattermost-redux/selectors/entities/i18n'
 
 import {getMessages} from './../../../../../webapp/src/i18n'
 import {Utils} from './../../../../../webapp/src/utils'
-import {Card} from './../../../../../webapp/src/blocks/card'
+import {Card, CardPreview} from './../../../../../webapp/src/blocks/card'
 import {Board} from './../../../../../webapp/src/blocks/board'
 import {ContentBlock} from './../../../../../webapp/src/blocks/contentBlock'
 import octoClient from './../../../../../webapp/src/octoClient'
@@ -70,21 +70,25 @@ const BoardsUnfurl = (props: Props): JSX.Element => {
     const [card, setCard] = useState<Card>()
     const [content, setContent] = useState<ContentBlock>()
     const [board, setBoard] = useState<Board>()
+    const [preview, setPreview] = useState<CardPreview>()
     const [loading, setLoading] = useState(true)
 
     useEffect(() => {
         const fetchData = async () => {
-            const [cards, fetchedBoard] = await Promise.all(
+            // The preview is only returned if the viewer can see the board of the card
+            const [fetchedPreview, cards, fetchedBoard] = await Promise.all(
                 [
+                    octoClient.getCardPreview(cardID, readToken),
                     octoClient.getBlocksWithBlockID(cardID, boardID, readToken),
                     octoClient.getBoard(boardID),
                 ],
             )
             const [firstCard] = cards as Card[]
-            if (!firstCard || !fetchedBoard) {
+            if (!fetchedPreview || !firstCard || !fetchedBoard) {
                 setLoading(false)
                 return null
             }
+            setPreview(fetchedPreview)
             setCard(firstCard)
             setBoard(fetchedBoard)
 
@@ -113,7 +117,15 @@ const BoardsUnfurl = (props: Props): JSX.Element => {
     let remainder = 0
     let html = ''
     const propertiesToDisplay: Array<Record<string, string>> = []
-    if (card && board) {
+    if (card && board && preview) {
+        if (preview.status) {
+            propertiesToDisplay.push({
+                optionName: preview.statusName || '',
+                optionValue: preview.status,
+                optionValueColour: preview.statusColor || '',
+            })
+        }
+
         // Checkboxes need to be accounted for if they are off or on, if they are on they show up in the card properties so we don't want to count it twice
         // Therefore we keep track how many checkboxes there are and subtract it at the end
         let totalNumberOfCheckBoxes = 0
@@ -134,7 +146,8 @@ const BoardsUnfurl = (props: Props): JSX.Element => {
             }
 
             // Check to see if this property is set in the Card or if we have max properties to display
-            if (propertiesToDisplay.length === 3 || !valueToLookUp) {
+            // The status is already displayed first
+            if (propertiesToDisplay.length === 3 || !valueToLookUp || (preview.status && optionInBoard.name === preview.statusName)) {
                 continue
             }
 
@@ -163,8 +176,8 @@ const BoardsUnfurl = (props: Props): JSX.Element => {
             messages={getMessages(locale)}
             locale={locale}
         >
-            {!loading && (!card || !board) && <></>}
-            {!loading && card && board &&
+            {!loading && (!card || !board || !preview) && <></>}
+            {!loading && card && board && preview &&
                 <a
                     className='FocalboardUnfurl'
                     href={`${baseURL}${originalPath}`}
@@ -179,6 +192,18 @@ const BoardsUnfurl = (props: Props): JSX.Element => {
                             <span className='card_title'>{card.title}</span>
                             <span className='board_title'>{board.title}</span>
                         </div>
+                        {preview.assignees.length > 0 &&
+                            <div className='assignees'>
+                                {preview.assignees.slice(0, 3).map((userID) => (
+                                    <Avatar
+                                        key={userID}
+                                        size={'sm'}
+                                        url={imageURLForUser(userID)}
+                                        className={'avatar-post-preview'}
+                                    />
+                                ))}
+                            </div>
+                        }
                     </div>
 
                     {/* Body of the Card*/}
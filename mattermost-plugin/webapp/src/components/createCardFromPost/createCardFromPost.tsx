@@ -0,0 +1,184 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+import React, {useState, useEffect} from 'react'
+import {IntlProvider, FormattedMessage} from 'react-intl'
+import {connect} from 'react-redux'
+
+import {GlobalState} from 'mattermost-redux/types/store'
+import {getCurrentUserLocale} from 'mattermost-redux/selectors/entities/i18n'
+import {getCurrentTeamId} from 'mattermost-redux/selectors/entities/teams'
+
+import {getMessages} from './../../../../../webapp/src/i18n'
+import {Board} from './../../../../../webapp/src/blocks/board'
+import octoClient from './../../../../../webapp/src/octoClient'
+import Dialog from './../../../../../webapp/src/components/dialog'
+import Button from './../../../../../webapp/src/widgets/buttons/button'
+
+import './createCardFromPost.scss'
+
+type Props = {
+    locale: string,
+    teamId: string,
+}
+
+function mapStateToProps(state: GlobalState) {
+    return {
+        locale: getCurrentUserLocale(state),
+        teamId: getCurrentTeamId(state),
+    }
+}
+
+let openDialog: ((postId: string) => void) | undefined
+
+// openCreateCardFromPost opens the dialog to create a card from the given post.
+export function openCreateCardFromPost(postId: string): void {
+    openDialog?.(postId)
+}
+
+const CreateCardFromPost = (props: Props): JSX.Element => {
+    const [postId, setPostId] = useState('')
+    const [boards, setBoards] = useState<Board[]>([])
+    const [boardId, setBoardId] = useState('')
+    const [link, setLink] = useState('')
+    const [error, setError] = useState(false)
+    const [saving, setSaving] = useState(false)
+
+    useEffect(() => {
+        openDialog = setPostId
+        return () => {
+            openDialog = undefined
+        }
+    }, [])
+
+    useEffect(() => {
+        if (!postId) {
+            return
+        }
+        const loadBoards = async () => {
+            const allBoards = await octoClient.getBoards(props.teamId)
+            const nonTemplates = allBoards.filter((b) => !b.isTemplate)
+            setBoards(nonTemplates)
+            setBoardId(nonTemplates.length > 0 ? nonTemplates[0].id : '')
+        }
+        loadBoards()
+    }, [postId, props.teamId])
+
+    if (!postId) {
+        return <></>
+    }
+
+    const close = () => {
+        setPostId('')
+        setBoards([])
+        setBoardId('')
+        setLink('')
+        setError(false)
+        setSaving(false)
+    }
+
+    const create = async () => {
+        setSaving(true)
+        setError(false)
+        const result = await octoClient.createCardFromPost(postId, boardId)
+        setSaving(false)
+        if (result) {
+            setLink(result.link)
+        } else {
+            setError(true)
+        }
+    }
+
+    return (
+        <IntlProvider
+            locale={props.locale.split(/[_]/)[0]}
+            messages={getMessages(props.locale)}
+        >
+            <Dialog
+                className='CreateCardFromPost'
+                onClose={close}
+            >
+                <div className='CreateCardFromPost__content'>
+                    <h3>
+                        <FormattedMessage
+                            id='CreateCardFromPost.title'
+                            defaultMessage='Create card from message'
+                        />
+                    </h3>
+                    {link &&
+                        <p>
+                            <FormattedMessage
+                                id='CreateCardFromPost.created'
+                                defaultMessage='Card created.'
+                            />
+                            {' '}
+                            <a href={link}>
+                                <FormattedMessage
+                                    id='CreateCardFromPost.open'
+                                    defaultMessage='Open card'
+                                />
+                            </a>
+                        </p>}
+                    {!link && boards.length === 0 &&
+                        <p>
+                            <FormattedMessage
+                                id='CreateCardFromPost.no-boards'
+                                defaultMessage='You are not a member of any board in this team.'
+                            />
+                        </p>}
+                    {!link && boards.length > 0 &&
+                        <label>
+                            <FormattedMessage
+                                id='CreateCardFromPost.board'
+                                defaultMessage='Board'
+                            />
+                            <select
+                                value={boardId}
+                                onChange={(e) => setBoardId(e.target.value)}
+                            >
+                                {boards.map((b) => (
+                                    <option
+                                        key={b.id}
+                                        value={b.id}
+                                    >
+                                        {b.icon ? `${b.icon} ${b.title}` : b.title}
+                                    </option>
+                                ))}
+                            </select>
+                        </label>}
+                    {error &&
+                        <p className='CreateCardFromPost__error'>
+                            <FormattedMessage
+                                id='CreateCardFromPost.error'
+                                defaultMessage='The card could not be created.'
+                            />
+                        </p>}
+                    <div className='CreateCardFromPost__footer'>
+                        <Button
+                            size='medium'
+                            onClick={close}
+                        >
+                            <FormattedMessage
+                                id='CreateCardFromPost.cancel'
+                                defaultMessage='Cancel'
+                            />
+                        </Button>
+                        {!link &&
+                            <Button
+                                size='medium'
+                                filled={true}
+                                disabled={!boardId || saving}
+                                onClick={create}
+                            >
+                                <FormattedMessage
+                                    id='CreateCardFromPost.create'
+                                    defaultMessage='Create'
+                                />
+                            </Button>}
+                    </div>
+                </div>
+            </Dialog>
+        </IntlProvider>
+    )
+}
+
+export default connect(mapStateToProps)(CreateCardFromPost)
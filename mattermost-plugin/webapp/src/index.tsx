@@ -34,6 +34,7 @@ import '../../../webapp/src/styles/labels.scss'
 import octoClient from '../../../webapp/src/octoClient'
 
 import BoardsUnfurl from './components/boardsUnfurl/boardsUnfurl'
+import CreateCardFromPost, {openCreateCardFromPost} from './components/createCardFromPost/createCardFromPost'
 import wsClient, {
     MMWebSocketClient,
     ACTION_UPDATE_BLOCK,
@@ -233,6 +234,11 @@ export default class Plugin {
             }
 
             this.registry.registerPostWillRenderEmbedComponent((embed) => embed.type === 'boards', BoardsUnfurl, false)
+
+            if (this.registry.registerPostDropdownMenuAction) {
+                this.registry.registerRootComponent(CreateCardFromPost)
+                this.registry.registerPostDropdownMenuAction('Create card', openCreateCardFromPost)
+            }
         }
 
         const config = await octoClient.getClientConfig()
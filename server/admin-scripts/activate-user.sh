@@ -0,0 +1,8 @@
+#!/bin/bash
+
+if [[ $# < 1 ]] ; then
+    echo 'activate-user.sh <username>'
+    exit 1
+fi
+
+curl --unix-socket /var/tmp/focalboard_local.socket http://localhost/api/v2/admin/users/$1/activate -X POST
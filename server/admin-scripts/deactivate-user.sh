@@ -0,0 +1,8 @@
+#!/bin/bash
+
+if [[ $# < 1 ]] ; then
+    echo 'deactivate-user.sh <username> [<username to reassign the cards to>]'
+    exit 1
+fi
+
+curl --unix-socket /var/tmp/focalboard_local.socket http://localhost/api/v2/admin/users/$1/deactivate -X POST -H 'Content-Type: application/json' -d '{ "reassignTo": "'$2'" }'
@@ -0,0 +1,7 @@
+#!/bin/bash
+
+if [[ "$1" == "--dry-run" ]]; then
+	curl --unix-socket /var/tmp/focalboard_local.socket "http://localhost/api/v2/admin/migrations/status?dryRun=true"
+else
+	curl --unix-socket /var/tmp/focalboard_local.socket http://localhost/api/v2/admin/migrations/status
+fi
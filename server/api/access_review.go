@@ -0,0 +1,79 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleAdminGetAccessReviewReport(w http.ResponseWriter, r *http.Request) {
+	a.writeAccessReviewReport(w, r)
+}
+
+func (a *API) handleGetAccessReviewReport(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/access-review getAccessReviewReport
+	//
+	// Returns the access review report of a team as CSV: a row per member
+	// of each board of the team, with their role and the last time they
+	// viewed and edited the board. Requires the manage system permission
+	//
+	// ---
+	// produces:
+	// - text/csv
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     content:
+	//       text/csv:
+	//         type: string
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to the access review report"})
+		return
+	}
+
+	a.writeAccessReviewReport(w, r)
+}
+
+func (a *API) writeAccessReviewReport(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["teamID"]
+
+	auditRec := a.makeAuditRecord(r, "getAccessReviewReport", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	// the report is streamed, so the errors after the first write can only
+	// be logged
+	filename := fmt.Sprintf("access-review-%s-%s.csv", teamID, time.Now().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.WriteHeader(http.StatusOK)
+
+	if err := a.appFor(r).WriteAccessReviewReport(w, teamID); err != nil {
+		a.logger.Error("cannot write the access review report", mlog.String("teamID", teamID), mlog.Err(err))
+		return
+	}
+
+	a.logger.Debug("GetAccessReviewReport", mlog.String("teamID", teamID))
+
+	auditRec.Success()
+}
@@ -2,11 +2,14 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
+	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -42,7 +45,7 @@ func (a *API) handleAdminSetPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = a.app.UpdateUserPassword(username, requestData.Password)
+	err = a.appFor(r).UpdateUserPassword(username, requestData.Password)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -53,3 +56,505 @@ func (a *API) handleAdminSetPassword(w http.ResponseWriter, r *http.Request) {
 	jsonStringResponse(w, http.StatusOK, "{}")
 	auditRec.Success()
 }
+
+type AdminDeactivateUserData struct {
+	// Username of the user to reassign the cards of the deactivated user to
+	ReassignTo string `json:"reassignTo"`
+}
+
+func (a *API) handleAdminDeactivateUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var requestData AdminDeactivateUserData
+	if len(requestBody) > 0 {
+		if err = json.Unmarshal(requestBody, &requestData); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminDeactivateUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("username", username)
+	auditRec.AddMeta("reassignTo", requestData.ReassignTo)
+
+	err = a.appFor(r).DeactivateUser(username, requestData.ReassignTo)
+	if errors.Is(err, app.ErrUserNotFound) || errors.Is(err, app.ErrReassignToUserNotFound) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, err.Error(), err)
+		return
+	}
+	if errors.Is(err, app.ErrReassignToSameUser) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("AdminDeactivateUser", mlog.String("username", username))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminActivateUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	auditRec := a.makeAuditRecord(r, "adminActivateUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("username", username)
+
+	err := a.appFor(r).ActivateUser(username)
+	if errors.Is(err, app.ErrUserNotFound) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("AdminActivateUser", mlog.String("username", username))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminReseedTemplates(w http.ResponseWriter, r *http.Request) {
+	auditRec := a.makeAuditRecord(r, "adminReseedTemplates", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	if err := a.appFor(r).ReseedTemplates(); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("AdminReseedTemplates")
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminStatistics(w http.ResponseWriter, r *http.Request) {
+	a.writeStatistics(w, r)
+}
+
+func (a *API) handleStatistics(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /statistics getStatistics
+	//
+	// Returns the server statistics. Requires the manage system permission
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ServerStats"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to server statistics"})
+		return
+	}
+
+	a.writeStatistics(w, r)
+}
+
+func (a *API) writeStatistics(w http.ResponseWriter, r *http.Request) {
+	auditRec := a.makeAuditRecord(r, "getStatistics", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	stats, err := a.appFor(r).GetServerStats()
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetStatistics")
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminMigrationStatus(w http.ResponseWriter, r *http.Request) {
+	a.writeMigrationStatus(w, r)
+}
+
+func (a *API) handleMigrationStatus(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /migrations/status getMigrationStatus
+	//
+	// Returns the schema version of the database and the migrations that
+	// are pending. Requires the manage system permission
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: dryRun
+	//   in: query
+	//   description: Include the SQL each pending migration would execute
+	//   required: false
+	//   type: boolean
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/MigrationStatus"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to migration status"})
+		return
+	}
+
+	a.writeMigrationStatus(w, r)
+}
+
+func (a *API) writeMigrationStatus(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	auditRec := a.makeAuditRecord(r, "getMigrationStatus", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("dryRun", dryRun)
+
+	status, err := a.appFor(r).GetMigrationStatus(dryRun)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetMigrationStatus",
+		mlog.Int("current_version", int(status.CurrentVersion)),
+		mlog.Int("pending_count", len(status.Pending)),
+	)
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminRunMaintenance(w http.ResponseWriter, r *http.Request) {
+	a.writeMaintenance(w, r)
+}
+
+func (a *API) handleRunMaintenance(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /maintenance runMaintenance
+	//
+	// Runs the database maintenance: deletes the orphan rows, then
+	// optimizes the tables and refreshes their statistics, with VACUUM
+	// ANALYZE on Postgres, OPTIMIZE TABLE on MySQL and VACUUM on SQLite.
+	// The tables can be locked while it runs. Requires the manage system
+	// permission
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/MaintenanceReport"
+	//   '403':
+	//     description: access denied
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to database maintenance"})
+		return
+	}
+
+	a.writeMaintenance(w, r)
+}
+
+func (a *API) writeMaintenance(w http.ResponseWriter, r *http.Request) {
+	auditRec := a.makeAuditRecord(r, "runMaintenance", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	report, err := a.appFor(r).RunDatabaseMaintenance()
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("RunMaintenance",
+		mlog.Int("statement_count", len(report.Statements)),
+	)
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminMigratePersonalBoards(w http.ResponseWriter, r *http.Request) {
+	a.writeMigratePersonalBoards(w, r)
+}
+
+func (a *API) handleMigratePersonalBoards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /personal-boards/migrate migratePersonalBoards
+	//
+	// Moves boards from the personal team into a team, with their
+	// history, mentions and files. Each board is moved in its own
+	// transaction, and the report tells which boards were moved, skipped
+	// or failed. Requires the manage system permission
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: the destination team and the boards to move
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/PersonalBoardsMigrationRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/PersonalBoardsMigrationReport"
+	//   '400':
+	//     description: invalid request
+	//   '403':
+	//     description: access denied
+	//   '404':
+	//     description: team not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to migrate personal boards"})
+		return
+	}
+
+	a.writeMigratePersonalBoards(w, r)
+}
+
+func (a *API) writeMigratePersonalBoards(w http.ResponseWriter, r *http.Request) {
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var request *model.PersonalBoardsMigrationRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil || request == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	if err = request.IsValid(); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "migratePersonalBoards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", request.TeamID)
+	auditRec.AddMeta("boardCount", len(request.BoardIDs))
+
+	report, err := a.appFor(r).MigratePersonalBoards(request)
+	if _, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("MigratePersonalBoards",
+		mlog.String("teamID", report.TeamID),
+		mlog.Int("migrated", report.Migrated),
+		mlog.Int("failed", report.Failed),
+	)
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("migrated", report.Migrated)
+	auditRec.AddMeta("failed", report.Failed)
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardAdminView(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/admin-view getBoardAdminView
+	//
+	// Returns a read-only copy of any board, private ones included, with
+	// its blocks and members, for moderation and legal needs. The user
+	// doesn't become a member of the board, and every access is recorded
+	// in the audit log. Requires the manage system permission
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: notify
+	//   in: query
+	//   description: If true, the admins of the board are told about the access
+	//   required: false
+	//   type: boolean
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardAdminView"
+	//   '403':
+	//     description: access denied
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	notifyAdmins := r.URL.Query().Get("notify") == "true"
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to admin view"})
+		return
+	}
+
+	// the access is recorded even if it fails, as an attempt
+	auditRec := a.makeAuditRecord(r, "getBoardAdminView", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("notifyAdmins", notifyAdmins)
+
+	view, err := a.appFor(r).GetBoardAdminView(boardID, userID, notifyAdmins)
+	if model.IsErrBoardNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	auditRec.AddMeta("boardType", view.Board.Type)
+	auditRec.AddMeta("teamID", view.Board.TeamID)
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSendTestNotification(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /notifications/test sendTestNotification
+	//
+	// Sends a test notification to the current user through each configured
+	// notification backend, and reports which deliveries succeeded. Requires
+	// the manage system permission
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/TestNotificationResult"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to test notifications"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "sendTestNotification", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	results := a.appFor(r).SendTestNotification(userID)
+
+	a.logger.Debug("SendTestNotification",
+		mlog.String("user_id", userID),
+		mlog.Int("backend_count", len(results)),
+	)
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,6 +20,7 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -26,7 +29,10 @@ import (
 const (
 	HeaderRequestedWith    = "X-Requested-With"
 	HeaderRequestedWithXML = "XMLHttpRequest"
+	HeaderRequestID        = "X-Request-ID"
 	UploadFormFileKey      = "file"
+
+	maxRequestIDLength = 64
 )
 
 const (
@@ -68,7 +74,13 @@ func NewAPI(app *app.App, singleUserToken string, authService string, permission
 }
 
 func (a *API) RegisterRoutes(r *mux.Router) {
+	// registered first, so the webhooks don't go through the CSRF check
+	a.registerWebhookRoutes(r)
+	// the integration API is authenticated with API keys, not sessions
+	a.registerIntegrationRoutes(r)
+
 	apiv2 := r.PathPrefix("/api/v2").Subrouter()
+	apiv2.Use(a.requestIDHandler)
 	apiv2.Use(a.panicHandler)
 	apiv2.Use(a.requireCSRFToken)
 
@@ -76,6 +88,7 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	apiv2.HandleFunc("/teams/{teamID}/boards", a.sessionRequired(a.handleGetBoards)).Methods("GET")
 	apiv2.HandleFunc("/teams/{teamID}/boards/search", a.sessionRequired(a.handleSearchBoards)).Methods("GET")
 	apiv2.HandleFunc("/teams/{teamID}/templates", a.sessionRequired(a.handleGetTemplates)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/workload", a.sessionRequired(a.handleGetTeamWorkload)).Methods("GET")
 	apiv2.HandleFunc("/boards", a.sessionRequired(a.handleCreateBoard)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}", a.attachSession(a.handleGetBoard, false)).Methods("GET")
 	apiv2.HandleFunc("/boards/{boardID}", a.sessionRequired(a.handlePatchBoard)).Methods("PATCH")
@@ -89,16 +102,84 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}", a.sessionRequired(a.handlePatchBlock)).Methods("PATCH")
 	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}/undelete", a.sessionRequired(a.handleUndeleteBlock)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}/duplicate", a.sessionRequired(a.handleDuplicateBlock)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}/lock", a.sessionRequired(a.handleAcquireBlockLock)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}/lock", a.sessionRequired(a.handleReleaseBlockLock)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}/text-edits", a.sessionRequired(a.handleEditBlockText)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/locks", a.sessionRequired(a.handleGetBlockLocks)).Methods("GET")
 	apiv2.HandleFunc("/boards/{boardID}/metadata", a.sessionRequired(a.handleGetBoardMetadata)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleGetCards)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/cards/bulk", a.sessionRequired(a.handleCreateCardsInBulk)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/cards/resolve", a.sessionRequired(a.handleResolveCards)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/card-groups", a.sessionRequired(a.handleGetCardGroups)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/swimlanes", a.sessionRequired(a.handleGetSwimlanes)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/stale-cards", a.sessionRequired(a.handleGetStaleCards)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/workload", a.sessionRequired(a.handleGetBoardWorkload)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/snapshots", a.sessionRequired(a.handleGetBoardSnapshots)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/views/{viewID}/cards", a.sessionRequired(a.handleGetViewCards)).Methods("GET")
+
+	// Card APIs
+	apiv2.HandleFunc("/cards/{cardID}/move", a.sessionRequired(a.handleMoveCard)).Methods("POST")
+	apiv2.HandleFunc("/cards/{cardID}/duplicate", a.sessionRequired(a.handleDuplicateCard)).Methods("POST")
+	apiv2.HandleFunc("/cards/{cardID}/preview", a.attachSession(a.handleGetCardPreview, false)).Methods("GET")
 
 	// Member APIs
 	apiv2.HandleFunc("/boards/{boardID}/members", a.sessionRequired(a.handleGetMembersForBoard)).Methods("GET")
 	apiv2.HandleFunc("/boards/{boardID}/members", a.sessionRequired(a.handleAddMember)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/members/roles", a.sessionRequired(a.handleUpdateMembersRoles)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/members/deleted", a.sessionRequired(a.handleGetDeletedMembersForBoard)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/members/{userID}/restore", a.sessionRequired(a.handleRestoreMember)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/members/{userID}/custom-fields", a.sessionRequired(a.handlePatchMemberCustomFields)).Methods("PATCH")
 	apiv2.HandleFunc("/boards/{boardID}/members/{userID}", a.sessionRequired(a.handleUpdateMember)).Methods("PUT")
 	apiv2.HandleFunc("/boards/{boardID}/members/{userID}", a.sessionRequired(a.handleDeleteMember)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/permissions", a.sessionRequired(a.handleGetBoardPermissions)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/permissions", a.sessionRequired(a.handleApplyBoardPermissions)).Methods("PUT")
+	apiv2.HandleFunc("/boards/{boardID}/freeze-schedule", a.sessionRequired(a.handleGetBoardFreezeSchedule)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/freeze-schedule", a.sessionRequired(a.handleSetBoardFreezeSchedule)).Methods("PUT")
+	apiv2.HandleFunc("/boards/{boardID}/calendar/import", a.sessionRequired(a.handleImportCalendar)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/calendar/subscription", a.sessionRequired(a.handleGetCalendarSubscription)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/calendar/subscription", a.sessionRequired(a.handleSetCalendarSubscription)).Methods("PUT")
+	apiv2.HandleFunc("/boards/{boardID}/calendar/subscription", a.sessionRequired(a.handleDeleteCalendarSubscription)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/github", a.sessionRequired(a.handleGetGitHubIntegration)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/github", a.sessionRequired(a.handleSetGitHubIntegration)).Methods("PUT")
+	apiv2.HandleFunc("/boards/{boardID}/github", a.sessionRequired(a.handleDeleteGitHubIntegration)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/github/sync", a.sessionRequired(a.handleSyncGitHubIssues)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/content", a.sessionRequired(a.handleGetBoardContent)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/content", a.sessionRequired(a.handleSetBoardContent)).Methods("PUT")
+	apiv2.HandleFunc("/boards/{boardID}/content/history", a.sessionRequired(a.handleGetBoardContentHistory)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/mentions/validate", a.sessionRequired(a.handleValidateMentions)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/admin-view", a.sessionRequired(a.handleGetBoardAdminView)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/key", a.sessionRequired(a.handleGetBoardKey)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/key", a.sessionRequired(a.handleSetBoardKey)).Methods("PUT")
+	apiv2.HandleFunc("/boards/{boardKey}/cards/{number}", a.sessionRequired(a.handleGetCardPermalink)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/teams", a.sessionRequired(a.handleGetBoardTeams)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/teams/{teamID}", a.sessionRequired(a.handleShareBoardWithTeam)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/teams/{teamID}", a.sessionRequired(a.handleUnshareBoardFromTeam)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/webhooks", a.sessionRequired(a.handleGetBoardWebhooks)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/webhooks", a.sessionRequired(a.handleCreateBoardWebhook)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/webhooks/{webhookID}", a.sessionRequired(a.handlePatchBoardWebhook)).Methods("PATCH")
+	apiv2.HandleFunc("/boards/{boardID}/webhooks/{webhookID}", a.sessionRequired(a.handleDeleteBoardWebhook)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/invitations", a.sessionRequired(a.handleGetBoardInvitations)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/invitations", a.sessionRequired(a.handleCreateBoardInvitation)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/invitations/accept", a.sessionRequired(a.handleAcceptBoardInvitation)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/invitations/decline", a.sessionRequired(a.handleDeclineBoardInvitation)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/invitations/{userID}", a.sessionRequired(a.handleCancelBoardInvitation)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/access-requests", a.sessionRequired(a.handleGetBoardAccessRequests)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/access-requests", a.sessionRequired(a.handleRequestBoardAccess)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/access-requests/{userID}/approve", a.sessionRequired(a.handleApproveBoardAccessRequest)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/access-requests/{userID}/deny", a.sessionRequired(a.handleDenyBoardAccessRequest)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}/join", a.sessionRequired(a.handleJoinBoard)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}/leave", a.sessionRequired(a.handleLeaveBoard)).Methods("POST")
 
+	// Channel APIs
+	apiv2.HandleFunc("/boards/{boardID}/channel", a.sessionRequired(a.handleLinkBoardToChannel)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/channel", a.sessionRequired(a.handleUnlinkBoardFromChannel)).Methods("DELETE")
+	apiv2.HandleFunc("/channels/{channelID}/boards", a.sessionRequired(a.handleGetBoardsForChannel)).Methods("GET")
+
+	// Bot action APIs
+	apiv2.HandleFunc("/bot/boards/{boardID}/cards", a.sessionRequired(a.handleBotCreateCard)).Methods("POST")
+	apiv2.HandleFunc("/bot/cards/{cardID}/comments", a.sessionRequired(a.handleBotAddComment)).Methods("POST")
+	apiv2.HandleFunc("/bot/cards/{cardID}/properties", a.sessionRequired(a.handleBotSetCardProperties)).Methods("PATCH")
+
 	// Sharing APIs
 	apiv2.HandleFunc("/boards/{boardID}/sharing", a.sessionRequired(a.handlePostSharing)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}/sharing", a.sessionRequired(a.handleGetSharing)).Methods("GET")
@@ -114,9 +195,25 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	// User APIs
 	apiv2.HandleFunc("/users/me", a.sessionRequired(a.handleGetMe)).Methods("GET")
 	apiv2.HandleFunc("/users/me/memberships", a.sessionRequired(a.handleGetMyMemberships)).Methods("GET")
+	apiv2.HandleFunc("/users/me/invitations", a.sessionRequired(a.handleGetMyInvitations)).Methods("GET")
+	apiv2.HandleFunc("/invitations/email", a.sessionRequired(a.handleSendEmailInvitation)).Methods("POST")
+	apiv2.HandleFunc("/users/me/boards/recent", a.sessionRequired(a.handleGetRecentBoards)).Methods("GET")
+	apiv2.HandleFunc("/users/me/cards", a.sessionRequired(a.handleGetMyWork)).Methods("GET")
+	apiv2.HandleFunc("/users/me/mentions", a.sessionRequired(a.handleGetMyMentions)).Methods("GET")
+	apiv2.HandleFunc("/users/me/mentions/read", a.sessionRequired(a.handleMarkMentionsRead)).Methods("POST")
 	apiv2.HandleFunc("/users/{userID}", a.sessionRequired(a.handleGetUser)).Methods("GET")
 	apiv2.HandleFunc("/users/{userID}/changepassword", a.sessionRequired(a.handleChangePassword)).Methods("POST")
 	apiv2.HandleFunc("/users/{userID}/config", a.sessionRequired(a.handleUpdateUserConfig)).Methods(http.MethodPut)
+	apiv2.HandleFunc("/users/me/notifications/preferences", a.sessionRequired(a.handleGetNotificationPreferences)).Methods(http.MethodGet)
+	apiv2.HandleFunc("/users/me/notifications/preferences", a.sessionRequired(a.handleUpdateNotificationPreferences)).Methods(http.MethodPut)
+	apiv2.HandleFunc("/users/me/quiet-hours", a.sessionRequired(a.handleGetQuietHours)).Methods(http.MethodGet)
+	apiv2.HandleFunc("/users/me/quiet-hours", a.sessionRequired(a.handleUpdateQuietHours)).Methods(http.MethodPut)
+	apiv2.HandleFunc("/users/me/onboarding", a.sessionRequired(a.handleGetOnboardingState)).Methods(http.MethodGet)
+	apiv2.HandleFunc("/users/me/onboarding", a.sessionRequired(a.handlePatchOnboardingState)).Methods(http.MethodPatch)
+	apiv2.HandleFunc("/users/me/due-digest", a.sessionRequired(a.handleGetDueDigestSettings)).Methods(http.MethodGet)
+	apiv2.HandleFunc("/users/me/due-digest", a.sessionRequired(a.handleUpdateDueDigestSettings)).Methods(http.MethodPut)
+	apiv2.HandleFunc("/users/me/stale-digest", a.sessionRequired(a.handleGetStaleDigestSettings)).Methods(http.MethodGet)
+	apiv2.HandleFunc("/users/me/stale-digest", a.sessionRequired(a.handleUpdateStaleDigestSettings)).Methods(http.MethodPut)
 
 	// BoardsAndBlocks APIs
 	apiv2.HandleFunc("/boards-and-blocks", a.sessionRequired(a.handleCreateBoardsAndBlocks)).Methods("POST")
@@ -131,13 +228,20 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 
 	// Category APIs
 	apiv2.HandleFunc("/teams/{teamID}/categories", a.sessionRequired(a.handleCreateCategory)).Methods(http.MethodPost)
+	apiv2.HandleFunc("/teams/{teamID}/categories/reorder", a.sessionRequired(a.handleReorderCategories)).Methods(http.MethodPut)
 	apiv2.HandleFunc("/teams/{teamID}/categories/{categoryID}", a.sessionRequired(a.handleUpdateCategory)).Methods(http.MethodPut)
 	apiv2.HandleFunc("/teams/{teamID}/categories/{categoryID}", a.sessionRequired(a.handleDeleteCategory)).Methods(http.MethodDelete)
 
 	// Category Block APIs
 	apiv2.HandleFunc("/teams/{teamID}/categories", a.sessionRequired(a.handleGetUserCategoryBoards)).Methods(http.MethodGet)
+	apiv2.HandleFunc("/teams/{teamID}/categories/{categoryID}/boards", a.sessionRequired(a.handleUpdateCategoryBoards)).Methods(http.MethodPost)
 	apiv2.HandleFunc("/teams/{teamID}/categories/{categoryID}/boards/{boardID}", a.sessionRequired(a.handleUpdateCategoryBoard)).Methods(http.MethodPost)
 
+	// Category Rule APIs
+	apiv2.HandleFunc("/teams/{teamID}/category-rules", a.sessionRequired(a.handleGetCategoryRules)).Methods(http.MethodGet)
+	apiv2.HandleFunc("/teams/{teamID}/category-rules", a.sessionRequired(a.handleCreateCategoryRule)).Methods(http.MethodPost)
+	apiv2.HandleFunc("/teams/{teamID}/category-rules/{ruleID}", a.sessionRequired(a.handleDeleteCategoryRule)).Methods(http.MethodDelete)
+
 	// Get Files API
 	apiv2.HandleFunc("/files/teams/{teamID}/{boardID}/{filename}", a.attachSession(a.handleServeFile, false)).Methods("GET")
 
@@ -149,13 +253,45 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	// onboarding tour endpoints
 	apiv2.HandleFunc("/teams/{teamID}/onboard", a.sessionRequired(a.handleOnboard)).Methods(http.MethodPost)
 
+	// statistics
+	apiv2.HandleFunc("/statistics", a.sessionRequired(a.handleStatistics)).Methods("GET")
+
+	// migrations
+	apiv2.HandleFunc("/migrations/status", a.sessionRequired(a.handleMigrationStatus)).Methods("GET")
+	apiv2.HandleFunc("/maintenance", a.sessionRequired(a.handleRunMaintenance)).Methods("POST")
+	apiv2.HandleFunc("/personal-boards/migrate", a.sessionRequired(a.handleMigratePersonalBoards)).Methods("POST")
+	apiv2.HandleFunc("/webhooks/deliveries", a.sessionRequired(a.handleGetDeadWebhookDeliveries)).Methods("GET")
+	apiv2.HandleFunc("/webhooks/deliveries/{deliveryID}/retry", a.sessionRequired(a.handleRetryWebhookDelivery)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/access-review", a.sessionRequired(a.handleGetAccessReviewReport)).Methods("GET")
+
+	// change stream
+	apiv2.HandleFunc("/teams/{teamID}/changes", a.sessionRequired(a.handleGetChanges)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/poll", a.sessionRequired(a.handleLongPoll)).Methods("GET")
+	apiv2.HandleFunc("/sync", a.sessionRequired(a.handleSync)).Methods("POST")
+
+	// notifications
+	apiv2.HandleFunc("/notifications/test", a.sessionRequired(a.handleSendTestNotification)).Methods("POST")
+
 	// archives
 	apiv2.HandleFunc("/boards/{boardID}/archive/export", a.sessionRequired(a.handleArchiveExportBoard)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/archive/copy", a.sessionRequired(a.handleArchiveCopyBoard)).Methods("POST")
 	apiv2.HandleFunc("/teams/{teamID}/archive/import", a.sessionRequired(a.handleArchiveImport)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/import/{importer}", a.sessionRequired(a.handleImport)).Methods("POST")
 }
 
 func (a *API) RegisterAdminRoutes(r *mux.Router) {
 	r.HandleFunc("/api/v2/admin/users/{username}/password", a.adminRequired(a.handleAdminSetPassword)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/users/{username}/deactivate", a.adminRequired(a.handleAdminDeactivateUser)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/users/{username}/activate", a.adminRequired(a.handleAdminActivateUser)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/templates/reseed", a.adminRequired(a.handleAdminReseedTemplates)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/statistics", a.adminRequired(a.handleAdminStatistics)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/migrations/status", a.adminRequired(a.handleAdminMigrationStatus)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/maintenance", a.adminRequired(a.handleAdminRunMaintenance)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/personal-boards/migrate", a.adminRequired(a.handleAdminMigratePersonalBoards)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/boards/{boardID}/archive/copy", a.adminRequired(a.handleAdminArchiveCopyBoard)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/webhooks/deliveries", a.adminRequired(a.handleAdminGetDeadWebhookDeliveries)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/webhooks/deliveries/{deliveryID}/retry", a.adminRequired(a.handleAdminRetryWebhookDelivery)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/teams/{teamID}/access-review", a.adminRequired(a.handleAdminGetAccessReviewReport)).Methods("GET")
 }
 
 func getUserID(r *http.Request) string {
@@ -167,6 +303,53 @@ func getUserID(r *http.Request) string {
 	return session.UserID
 }
 
+// requestIDHandler accepts the request ID sent by the client, or
+// generates a new one, and makes it available to the rest of the
+// request lifecycle. The ID is echoed back in the response headers.
+func (a *API) requestIDHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(HeaderRequestID)
+		if !isValidRequestID(requestID) {
+			requestID = utils.NewID(utils.IDTypeNone)
+		}
+
+		w.Header().Set(HeaderRequestID, requestID)
+
+		// the store queries of the request are cancelled if the client
+		// goes away. The work queued by the request isn't, see App.enqueue.
+		ctx := context.WithValue(r.Context(), requestIDContextKey, requestID)
+		ctx = context.WithValue(ctx, appContextKey, a.app.WithRequestID(requestID).WithContext(r.Context()))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range requestID {
+		isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlphanumeric && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func getRequestID(r *http.Request) string {
+	requestID, _ := r.Context().Value(requestIDContextKey).(string)
+	return requestID
+}
+
+// appFor returns the app scoped to the request, so its logs and
+// broadcasts carry the request ID.
+func (a *API) appFor(r *http.Request) *app.App {
+	if scoped, ok := r.Context().Value(appContextKey).(*app.App); ok {
+		return scoped
+	}
+	return a.app
+}
+
 func (a *API) panicHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
@@ -175,6 +358,7 @@ func (a *API) panicHandler(next http.Handler) http.Handler {
 					mlog.Any("panic", p),
 					mlog.String("stack", string(debug.Stack())),
 					mlog.String("uri", r.URL.Path),
+					mlog.String("request_id", getRequestID(r)),
 				)
 				a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", nil)
 			}
@@ -214,7 +398,7 @@ func (a *API) getClientConfig(w http.ResponseWriter, r *http.Request) {
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	clientConfig := a.app.GetClientConfig()
+	clientConfig := a.appFor(r).GetClientConfig()
 
 	configData, err := json.Marshal(clientConfig)
 	if err != nil {
@@ -237,7 +421,7 @@ func (a *API) hasValidReadTokenForBoard(r *http.Request, boardID string) bool {
 		return false
 	}
 
-	isValid, err := a.app.IsValidReadToken(boardID, readToken)
+	isValid, err := a.appFor(r).IsValidReadToken(boardID, readToken)
 	if err != nil {
 		a.logger.Error("IsValidReadTokenForBoard ERROR", mlog.Err(err))
 		return false
@@ -246,6 +430,33 @@ func (a *API) hasValidReadTokenForBoard(r *http.Request, boardID string) bool {
 	return isValid
 }
 
+// hasPermissionToCreateBoardsInTeam checks that the user can add boards
+// to a team. The boards of the organization are visible to everyone, so
+// the permission to see that scope is not enough to add boards to it.
+func (a *API) hasPermissionToCreateBoardsInTeam(userID, teamID string) bool {
+	if teamID == model.OrganizationTeamID {
+		return a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionCreatePublicChannel)
+	}
+	return a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam)
+}
+
+// filterOrganizationBoards removes the boards of the organization from
+// a team listing if the user is not allowed to see them, as guests are
+// not.
+func (a *API) filterOrganizationBoards(userID string, boards []*model.Board) []*model.Board {
+	if a.permissions.HasPermissionToTeam(userID, model.OrganizationTeamID, model.PermissionViewTeam) {
+		return boards
+	}
+
+	results := []*model.Board{}
+	for _, board := range boards {
+		if !board.IsOrganizationBoard() {
+			results = append(results, board)
+		}
+	}
+	return results
+}
+
 func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /boards/{boardID}/blocks getBlocks
 	//
@@ -270,6 +481,16 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	//   description: Type of blocks to return, omit to specify all types
 	//   required: false
 	//   type: string
+	// - name: block_id
+	//   in: query
+	//   description: ID of the block to return
+	//   required: false
+	//   type: string
+	// - name: depth
+	//   in: query
+	//   description: Levels of descendants to return with block_id, omit to return only the block
+	//   required: false
+	//   type: integer
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -279,6 +500,8 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	//       type: array
 	//       items:
 	//         "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid depth
 	//   '404':
 	//     description: board not found
 	//   default:
@@ -293,6 +516,16 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	blockID := query.Get("block_id")
 	boardID := mux.Vars(r)["boardID"]
 
+	depth := 0
+	if depthStr := query.Get("depth"); depthStr != "" {
+		var err error
+		depth, err = strconv.Atoi(depthStr)
+		if err != nil || depth < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid depth", err)
+			return
+		}
+	}
+
 	userID := getUserID(r)
 
 	hasValidReadToken := a.hasValidReadTokenForBoard(r, boardID)
@@ -301,13 +534,13 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "Board not found", nil)
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
 		return
 	}
 
@@ -332,32 +565,41 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("blockType", blockType)
 	auditRec.AddMeta("all", all)
 	auditRec.AddMeta("blockID", blockID)
+	auditRec.AddMeta("depth", depth)
 
 	var blocks []model.Block
 	var block *model.Block
 	switch {
 	case all != "":
-		blocks, err = a.app.GetBlocksForBoard(boardID)
+		blocks, err = a.appFor(r).GetBlocksForBoard(boardID)
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
 		}
 	case blockID != "":
-		block, err = a.app.GetBlockByID(blockID)
+		block, err = a.appFor(r).GetBlockByID(blockID)
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
 		}
 		if block != nil {
 			if block.BoardID != boardID {
-				a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+				a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(blockID))
 				return
 			}
 
-			blocks = append(blocks, *block)
+			if depth == 0 {
+				blocks = append(blocks, *block)
+			} else {
+				blocks, err = a.appFor(r).GetSubTree(boardID, blockID, depth)
+				if err != nil {
+					a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+					return
+				}
+			}
 		}
 	default:
-		blocks, err = a.app.GetBlocks(boardID, parentID, blockType)
+		blocks, err = a.appFor(r).GetBlocks(boardID, parentID, blockType)
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
@@ -369,6 +611,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 		mlog.String("parentID", parentID),
 		mlog.String("blockType", blockType),
 		mlog.String("blockID", blockID),
+		mlog.Int("depth", depth),
 		mlog.Int("block_count", len(blocks)),
 	)
 
@@ -462,7 +705,7 @@ func (a *API) handleCreateCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	createdCategory, err := a.app.CreateCategory(&category)
+	createdCategory, err := a.appFor(r).CreateCategory(&category)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -561,7 +804,7 @@ func (a *API) handleUpdateCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedCategory, err := a.app.UpdateCategory(&category)
+	updatedCategory, err := a.appFor(r).UpdateCategory(&category)
 	if err != nil {
 		switch {
 		case errors.Is(err, app.ErrorCategoryDeleted):
@@ -626,7 +869,7 @@ func (a *API) handleDeleteCategory(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "deleteCategory", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 
-	deletedCategory, err := a.app.DeleteCategory(categoryID, userID, teamID)
+	deletedCategory, err := a.appFor(r).DeleteCategory(categoryID, userID, teamID)
 	if err != nil {
 		switch {
 		case errors.Is(err, app.ErrorInvalidCategory):
@@ -689,7 +932,7 @@ func (a *API) handleGetUserCategoryBoards(w http.ResponseWriter, r *http.Request
 	auditRec := a.makeAuditRecord(r, "getUserCategoryBoards", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 
-	categoryBlocks, err := a.app.GetUserCategoryBoards(userID, teamID)
+	categoryBlocks, err := a.appFor(r).GetUserCategoryBoards(userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -751,17 +994,181 @@ func (a *API) handleUpdateCategoryBoard(w http.ResponseWriter, r *http.Request)
 	session := ctx.Value(sessionContextKey).(*model.Session)
 	userID := session.UserID
 
-	// TODO: Check the category and the team matches
-	err := a.app.AddUpdateUserCategoryBoard(teamID, userID, categoryID, boardID)
+	err := a.appFor(r).AddUpdateUserCategoryBoard(teamID, userID, categoryID, boardID)
+	if err != nil {
+		a.categoryBoardsErrorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, []byte("ok"))
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateCategoryBoards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/categories/{categoryID}/boards updateCategoryBoards
+	//
+	// Move boards into a category, or out of the categories of the user
+	// if the category ID is 0. The boards are moved in a single
+	// transaction.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: categoryID
+	//   in: path
+	//   description: Category ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the boards to move
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CategoryBoardsUpdate"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	requestBody, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
+	var update model.CategoryBoardsUpdate
+	if err = json.Unmarshal(requestBody, &update); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "updateCategoryBoards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	vars := mux.Vars(r)
+	categoryID := vars["categoryID"]
+	teamID := vars["teamID"]
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	userID := session.UserID
+
+	auditRec.AddMeta("categoryID", categoryID)
+	auditRec.AddMeta("boardCount", len(update.BoardIDs))
+
+	err = a.appFor(r).AddUpdateUserCategoryBoards(teamID, userID, categoryID, update.BoardIDs)
+	if err != nil {
+		a.categoryBoardsErrorResponse(w, r, err)
+		return
+	}
+
 	jsonBytesResponse(w, http.StatusOK, []byte("ok"))
 	auditRec.Success()
 }
 
+func (a *API) handleReorderCategories(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /teams/{teamID}/categories/reorder reorderCategories
+	//
+	// Set the order of the user's board categories
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the IDs of all the categories of the user, in their new order
+	//   required: true
+	//   schema:
+	//     type: array
+	//     items:
+	//       type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         type: string
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var newCategoryOrder []string
+	if err = json.Unmarshal(requestBody, &newCategoryOrder); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "reorderCategories", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	userID := session.UserID
+
+	categoryOrder, err := a.appFor(r).ReorderCategories(userID, teamID, newCategoryOrder)
+	if err != nil {
+		a.categoryBoardsErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(categoryOrder)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) categoryBoardsErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+		return
+	}
+
+	switch {
+	case errors.Is(err, app.ErrorInvalidCategory):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+	case errors.Is(err, app.ErrorCategoryDeleted), store.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	case errors.Is(err, app.ErrorCategoryPermissionDenied):
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
+
 func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /boards/{boardID}/blocks updateBlocks
 	//
@@ -786,11 +1193,16 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 	//     type: array
 	//     items:
 	//       "$ref": "#/definitions/Block"
+	// - name: detectDuplicates
+	//   in: query
+	//   description: If true, the response includes the existing cards similar to the created ones, for boards that enabled duplicate detection
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
-	//     description: success
+	//     description: success, or a BlocksWithDuplicates object if detectDuplicates is true
 	//     schema:
 	//       items:
 	//         $ref: '#/definitions/Block'
@@ -864,13 +1276,21 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 	// this query param exists when creating template from board, or board from template
 	sourceBoardID := r.URL.Query().Get("sourceBoardID")
 	if sourceBoardID != "" {
-		if updateFileIDsErr := a.app.CopyCardFiles(sourceBoardID, blocks); updateFileIDsErr != nil {
+		if updateFileIDsErr := a.appFor(r).CopyCardFiles(sourceBoardID, blocks); updateFileIDsErr != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", updateFileIDsErr)
 			return
 		}
 	}
 
-	newBlocks, err := a.app.InsertBlocks(blocks, session.UserID, true)
+	newBlocks, err := a.appFor(r).InsertBlocks(blocks, session.UserID, true)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if model.IsErrContentRejected(err) || model.IsErrBadRequest(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -878,7 +1298,17 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 
 	a.logger.Debug("POST Blocks", mlog.Int("block_count", len(blocks)))
 
-	json, err := json.Marshal(newBlocks)
+	var response interface{} = newBlocks
+	if r.URL.Query().Get("detectDuplicates") == "true" {
+		duplicates, dupErr := a.appFor(r).FindDuplicateCards(boardID, newBlocks)
+		if dupErr != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", dupErr)
+			return
+		}
+		response = model.BlocksWithDuplicates{Blocks: newBlocks, Duplicates: duplicates}
+	}
+
+	json, err := json.Marshal(response)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -948,7 +1378,7 @@ func (a *API) handleUpdateUserConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedConfig, err := a.app.UpdateUserConfig(userID, *patch)
+	updatedConfig, err := a.appFor(r).UpdateUserConfig(userID, *patch)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -964,70 +1394,72 @@ func (a *API) handleUpdateUserConfig(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
-func (a *API) handleGetUser(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /users/{userID} getUser
+func (a *API) handleGetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/notifications/preferences getNotificationPreferences
 	//
-	// Returns a user
+	// Returns the channels the current user is notified through, by event type.
+	// Event types without preferences use direct messages.
 	//
 	// ---
 	// produces:
 	// - application/json
-	// parameters:
-	// - name: userID
-	//   in: path
-	//   description: User ID
-	//   required: true
-	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       "$ref": "#/definitions/User"
+	//       "$ref": "#/definitions/NotificationPreferences"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	vars := mux.Vars(r)
-	userID := vars["userID"]
+	userID := getUserID(r)
 
-	auditRec := a.makeAuditRecord(r, "postBlocks", audit.Fail)
+	auditRec := a.makeAuditRecord(r, "getNotificationPreferences", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
-	auditRec.AddMeta("userID", userID)
 
-	user, err := a.app.GetUser(userID)
+	prefs, err := a.appFor(r).GetNotificationPreferences(userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	userData, err := json.Marshal(user)
+	data, err := json.Marshal(prefs)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonBytesResponse(w, http.StatusOK, userData)
+	jsonBytesResponse(w, http.StatusOK, data)
 	auditRec.Success()
 }
 
-func (a *API) handleGetMe(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /users/me getMe
+func (a *API) handleUpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /users/me/notifications/preferences updateNotificationPreferences
 	//
-	// Returns the currently logged-in user
+	// Replaces the channels the current user is notified through. Each event
+	// type (mention, assignment) maps to a list of channels (dm, email,
+	// webhook); an empty list turns that type of notification off.
 	//
 	// ---
 	// produces:
 	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: The notification preferences
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/NotificationPreferences"
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       "$ref": "#/definitions/User"
+	//       "$ref": "#/definitions/NotificationPreferences"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -1035,45 +1467,41 @@ func (a *API) handleGetMe(w http.ResponseWriter, r *http.Request) {
 
 	userID := getUserID(r)
 
-	var user *model.User
-	var err error
-
-	auditRec := a.makeAuditRecord(r, "getMe", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	prefs, err := model.NotificationPreferencesFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
 
-	if userID == model.SingleUser {
-		now := utils.GetMillis()
-		user = &model.User{
-			ID:       model.SingleUser,
-			Username: model.SingleUser,
-			Email:    model.SingleUser,
-			CreateAt: now,
-			UpdateAt: now,
-		}
-	} else {
-		user, err = a.app.GetUser(userID)
-		if err != nil {
-			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-			return
-		}
+	if err = prefs.IsValid(); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
 	}
 
-	userData, err := json.Marshal(user)
+	auditRec := a.makeAuditRecord(r, "updateNotificationPreferences", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	prefs, err = a.appFor(r).UpdateNotificationPreferences(userID, prefs)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonBytesResponse(w, http.StatusOK, userData)
+	data, err := json.Marshal(prefs)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
 
-	auditRec.AddMeta("userID", user.ID)
+	jsonBytesResponse(w, http.StatusOK, data)
 	auditRec.Success()
 }
 
-func (a *API) handleGetMyMemberships(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /users/me/memberships getMyMemberships
+func (a *API) handleGetQuietHours(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/quiet-hours getQuietHours
 	//
-	// Returns the currently users board memberships
+	// Returns the quiet hours of the current user, during which the
+	// notifications are held back, or null if the user has none.
 	//
 	// ---
 	// produces:
@@ -1084,9 +1512,7 @@ func (a *API) handleGetMyMemberships(w http.ResponseWriter, r *http.Request) {
 	//   '200':
 	//     description: success
 	//     schema:
-	//       type: array
-	//       items:
-	//         "$ref": "#/definitions/BoardMember"
+	//       "$ref": "#/definitions/QuietHours"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -1094,363 +1520,367 @@ func (a *API) handleGetMyMemberships(w http.ResponseWriter, r *http.Request) {
 
 	userID := getUserID(r)
 
-	auditRec := a.makeAuditRecord(r, "getMyBoardMemberships", audit.Fail)
-	auditRec.AddMeta("userID", userID)
+	auditRec := a.makeAuditRecord(r, "getQuietHours", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
-	members, err := a.app.GetMembersForUser(userID)
+	quietHours, err := a.appFor(r).GetQuietHours(userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	membersData, err := json.Marshal(members)
+	data, err := json.Marshal(quietHours)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonBytesResponse(w, http.StatusOK, membersData)
-
+	jsonBytesResponse(w, http.StatusOK, data)
 	auditRec.Success()
 }
 
-func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation DELETE /boards/{boardID}/blocks/{blockID} deleteBlock
+func (a *API) handleUpdateQuietHours(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /users/me/quiet-hours updateQuietHours
 	//
-	// Deletes a block
+	// Replaces the quiet hours of the current user, in the timezone of the
+	// user. The notifications received during the quiet hours, or while the
+	// user is on Do Not Disturb, are delivered once they are over. A null
+	// body clears the quiet hours.
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
-	// - name: boardID
-	//   in: path
-	//   description: Board ID
-	//   required: true
-	//   type: string
-	// - name: blockID
-	//   in: path
-	//   description: ID of block to delete
+	// - name: Body
+	//   in: body
+	//   description: The quiet hours
 	//   required: true
-	//   type: string
+	//   schema:
+	//     "$ref": "#/definitions/QuietHours"
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
-	//   '404':
-	//     description: block not found
+	//     schema:
+	//       "$ref": "#/definitions/QuietHours"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
 	userID := getUserID(r)
-	vars := mux.Vars(r)
-	boardID := vars["boardID"]
-	blockID := vars["blockID"]
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+	quietHours, err := model.QuietHoursFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
 		return
 	}
 
-	block, err := a.app.GetBlockByID(blockID)
+	auditRec := a.makeAuditRecord(r, "updateQuietHours", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	quietHours, err = a.appFor(r).UpdateQuietHours(userID, quietHours)
 	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+			return
+		}
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-	if block == nil || block.BoardID != boardID {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
-		return
-	}
-
-	auditRec := a.makeAuditRecord(r, "deleteBlock", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	auditRec.AddMeta("boardID", boardID)
-	auditRec.AddMeta("blockID", blockID)
 
-	err = a.app.DeleteBlock(blockID, userID)
+	data, err := json.Marshal(quietHours)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("DELETE Block", mlog.String("boardID", boardID), mlog.String("blockID", blockID))
-	jsonStringResponse(w, http.StatusOK, "{}")
-
+	jsonBytesResponse(w, http.StatusOK, data)
 	auditRec.Success()
 }
 
-func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /boards/{boardID}/blocks/{blockID}/undelete undeleteBlock
+func (a *API) handleGetOnboardingState(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/onboarding getOnboardingState
 	//
-	// Undeletes a block
+	// Returns the progress of the current user through the onboarding tour
 	//
 	// ---
 	// produces:
 	// - application/json
-	// parameters:
-	// - name: boardID
-	//   in: path
-	//   description: Board ID
-	//   required: true
-	//   type: string
-	// - name: blockID
-	//   in: path
-	//   description: ID of block to undelete
-	//   required: true
-	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       "$ref": "#/definitions/BlockPatch"
-	//   '404':
-	//     description: block not found
+	//       "$ref": "#/definitions/OnboardingState"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	ctx := r.Context()
-	session := ctx.Value(sessionContextKey).(*model.Session)
-	userID := session.UserID
-
-	vars := mux.Vars(r)
-	blockID := vars["blockID"]
-	boardID := vars["boardID"]
+	userID := getUserID(r)
 
-	board, err := a.app.GetBoard(boardID)
+	state, err := a.appFor(r).GetOnboardingState(userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
-		return
-	}
 
-	block, err := a.app.GetLastBlockHistoryEntry(blockID)
+	data, err := json.Marshal(state)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-	if block == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
-		return
-	}
 
-	if board.ID != block.BoardID {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handlePatchOnboardingState(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /users/me/onboarding patchOnboardingState
+	//
+	// Updates the progress of the current user through the onboarding
+	// tour, so it resumes where it was left on any device
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: the fields of the onboarding state to update
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/OnboardingStatePatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/OnboardingState"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modify board members"})
+	var patch *model.OnboardingStatePatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "undeleteBlock", audit.Fail)
+	auditRec := a.makeAuditRecord(r, "patchOnboardingState", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	auditRec.AddMeta("blockID", blockID)
 
-	undeletedBlock, err := a.app.UndeleteBlock(blockID, userID)
+	state, err := a.appFor(r).PatchOnboardingState(userID, patch)
 	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+			return
+		}
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	undeletedBlockData, err := json.Marshal(undeletedBlock)
+	data, err := json.Marshal(state)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("UNDELETE Block", mlog.String("blockID", blockID))
-	jsonBytesResponse(w, http.StatusOK, undeletedBlockData)
-
+	jsonBytesResponse(w, http.StatusOK, data)
 	auditRec.Success()
 }
 
-func (a *API) handleUndeleteBoard(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /boards/{boardID}/undelete undeleteBoard
+func (a *API) handleGetDueDigestSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/due-digest getDueDigestSettings
 	//
-	// Undeletes a board
+	// Returns the settings of the daily message listing the cards assigned
+	// to the current user that are due today or overdue
 	//
 	// ---
 	// produces:
 	// - application/json
-	// parameters:
-	// - name: boardID
-	//   in: path
-	//   description: ID of board to undelete
-	//   required: true
-	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/DueDigestSettings"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	ctx := r.Context()
-	session := ctx.Value(sessionContextKey).(*model.Session)
-	userID := session.UserID
-
-	vars := mux.Vars(r)
-	boardID := vars["boardID"]
-
-	auditRec := a.makeAuditRecord(r, "undeleteBoard", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	auditRec.AddMeta("boardID", boardID)
+	userID := getUserID(r)
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionDeleteBoard) {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to undelete board"})
+	settings, err := a.appFor(r).GetDueDigestSettings(userID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	err := a.app.UndeleteBoard(boardID, userID)
+	data, err := json.Marshal(settings)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("UNDELETE Board", mlog.String("boardID", boardID))
-	jsonStringResponse(w, http.StatusOK, "{}")
-
-	auditRec.Success()
+	jsonBytesResponse(w, http.StatusOK, data)
 }
 
-func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation PATCH /boards/{boardID}/blocks/{blockID} patchBlock
+func (a *API) handleUpdateDueDigestSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /users/me/due-digest updateDueDigestSettings
 	//
-	// Partially updates a block
+	// Enables or disables the daily message listing the cards assigned to
+	// the current user that are due today or overdue, and sets the hour of
+	// the day it is sent at in the timezone of the user
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
-	// - name: boardID
-	//   in: path
-	//   description: Board ID
-	//   required: true
-	//   type: string
-	// - name: blockID
-	//   in: path
-	//   description: ID of block to patch
-	//   required: true
-	//   type: string
 	// - name: Body
 	//   in: body
-	//   description: block patch to apply
+	//   description: the due digest settings
 	//   required: true
 	//   schema:
-	//     "$ref": "#/definitions/BlockPatch"
+	//     "$ref": "#/definitions/DueDigestSettings"
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
-	//   '404':
-	//     description: block not found
+	//     schema:
+	//       "$ref": "#/definitions/DueDigestSettings"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
 	userID := getUserID(r)
-	vars := mux.Vars(r)
-	boardID := vars["boardID"]
-	blockID := vars["blockID"]
-
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
-		return
-	}
 
-	block, err := a.app.GetBlockByID(blockID)
+	requestBody, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-	if block == nil || block.BoardID != boardID {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+
+	var settings model.DueDigestSettings
+	if err = json.Unmarshal(requestBody, &settings); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
 		return
 	}
 
-	requestBody, err := ioutil.ReadAll(r.Body)
+	// the settings always belong to the current user
+	settings.UserID = userID
+
+	auditRec := a.makeAuditRecord(r, "updateDueDigestSettings", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("enabled", settings.Enabled)
+
+	updated, err := a.appFor(r).UpdateDueDigestSettings(&settings)
 	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+			return
+		}
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	var patch *model.BlockPatch
-	err = json.Unmarshal(requestBody, &patch)
+	data, err := json.Marshal(updated)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "patchBlock", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	auditRec.AddMeta("boardID", boardID)
-	auditRec.AddMeta("blockID", blockID)
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleGetStaleDigestSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/stale-digest getStaleDigestSettings
+	//
+	// Returns the settings of the weekly message listing the stale cards
+	// of the boards the current user administers
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/StaleDigestSettings"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
 
-	err = a.app.PatchBlock(blockID, patch, userID)
+	settings, err := a.appFor(r).GetStaleDigestSettings(userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("PATCH Block", mlog.String("boardID", boardID), mlog.String("blockID", blockID))
-	jsonStringResponse(w, http.StatusOK, "{}")
+	data, err := json.Marshal(settings)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
 
-	auditRec.Success()
+	jsonBytesResponse(w, http.StatusOK, data)
 }
 
-func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation PATCH /boards/{boardID}/blocks/ patchBlocks
+func (a *API) handleUpdateStaleDigestSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /users/me/stale-digest updateStaleDigestSettings
 	//
-	// Partially updates batch of blocks
+	// Enables or disables the weekly message listing the stale cards of the
+	// boards the current user administers, and sets after how many days
+	// without updates a card is stale, and the day of the week and hour of
+	// the day it is sent at in the timezone of the user
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
-	// - name: boardID
-	//   in: path
-	//   description: Workspace ID
-	//   required: true
-	//   type: string
 	// - name: Body
 	//   in: body
-	//   description: block Ids and block patches to apply
+	//   description: the stale digest settings
 	//   required: true
 	//   schema:
-	//     "$ref": "#/definitions/BlockPatchBatch"
+	//     "$ref": "#/definitions/StaleDigestSettings"
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/StaleDigestSettings"
+	//   '400':
+	//     description: invalid settings
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	ctx := r.Context()
-	session := ctx.Value(sessionContextKey).(*model.Session)
-	userID := session.UserID
-
-	vars := mux.Vars(r)
-	teamID := vars["teamID"]
+	userID := getUserID(r)
 
 	requestBody, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -1458,58 +1888,51 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var patches *model.BlockPatchBatch
-	err = json.Unmarshal(requestBody, &patches)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	var settings model.StaleDigestSettings
+	if err = json.Unmarshal(requestBody, &settings); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "patchBlocks", audit.Fail)
+	// the settings always belong to the current user
+	settings.UserID = userID
+
+	auditRec := a.makeAuditRecord(r, "updateStaleDigestSettings", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	for i := range patches.BlockIDs {
-		auditRec.AddMeta("block_"+strconv.FormatInt(int64(i), 10), patches.BlockIDs[i])
-	}
+	auditRec.AddMeta("enabled", settings.Enabled)
 
-	for _, blockID := range patches.BlockIDs {
-		var block *model.Block
-		block, err = a.app.GetBlockByID(blockID)
-		if err != nil {
-			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
-			return
-		}
-		if !a.permissions.HasPermissionToBoard(userID, block.BoardID, model.PermissionManageBoardCards) {
-			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+	updated, err := a.appFor(r).UpdateStaleDigestSettings(&settings)
+	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
 			return
 		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
 	}
 
-	err = a.app.PatchBlocks(teamID, patches, userID)
+	data, err := json.Marshal(updated)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("PATCH Blocks", mlog.String("patches", strconv.Itoa(len(patches.BlockIDs))))
-	jsonStringResponse(w, http.StatusOK, "{}")
-
+	jsonBytesResponse(w, http.StatusOK, data)
 	auditRec.Success()
 }
 
-// Sharing
-
-func (a *API) handleGetSharing(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /boards/{boardID}/sharing getSharing
+func (a *API) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/{userID} getUser
 	//
-	// Returns sharing information for a board
+	// Returns a user
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
-	// - name: boardID
+	// - name: userID
 	//   in: path
-	//   description: Board ID
+	//   description: User ID
 	//   required: true
 	//   type: string
 	// security:
@@ -1518,154 +1941,96 @@ func (a *API) handleGetSharing(w http.ResponseWriter, r *http.Request) {
 	//   '200':
 	//     description: success
 	//     schema:
-	//       "$ref": "#/definitions/Sharing"
-	//   '404':
-	//     description: board not found
+	//       "$ref": "#/definitions/User"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
 	vars := mux.Vars(r)
-	boardID := vars["boardID"]
-
-	userID := getUserID(r)
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to sharing the board"})
-		return
-	}
+	userID := vars["userID"]
 
-	auditRec := a.makeAuditRecord(r, "getSharing", audit.Fail)
+	auditRec := a.makeAuditRecord(r, "postBlocks", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
-	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("userID", userID)
 
-	sharing, err := a.app.GetSharing(boardID)
+	user, err := a.appFor(r).GetUser(userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-	if sharing == nil {
-		jsonStringResponse(w, http.StatusOK, "{}")
-		return
-	}
 
-	sharingData, err := json.Marshal(sharing)
+	userData, err := json.Marshal(user)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonBytesResponse(w, http.StatusOK, sharingData)
-
-	a.logger.Debug("GET sharing",
-		mlog.String("boardID", boardID),
-		mlog.String("shareID", sharing.ID),
-		mlog.Bool("enabled", sharing.Enabled),
-	)
-	auditRec.AddMeta("shareID", sharing.ID)
-	auditRec.AddMeta("enabled", sharing.Enabled)
+	jsonBytesResponse(w, http.StatusOK, userData)
 	auditRec.Success()
 }
 
-func (a *API) handlePostSharing(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /boards/{boardID}/sharing postSharing
+func (a *API) handleGetMe(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me getMe
 	//
-	// Sets sharing information for a board
+	// Returns the currently logged-in user
 	//
 	// ---
 	// produces:
 	// - application/json
-	// parameters:
-	// - name: boardID
-	//   in: path
-	//   description: Board ID
-	//   required: true
-	//   type: string
-	// - name: Body
-	//   in: body
-	//   description: sharing information for a root block
-	//   required: true
-	//   schema:
-	//     "$ref": "#/definitions/Sharing"
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/User"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	boardID := mux.Vars(r)["boardID"]
-
 	userID := getUserID(r)
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to sharing the board"})
-		return
-	}
-
-	requestBody, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		return
-	}
-
-	var sharing model.Sharing
-	err = json.Unmarshal(requestBody, &sharing)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		return
-	}
-
-	// Stamp boardID from the URL
-	sharing.ID = boardID
 
-	auditRec := a.makeAuditRecord(r, "postSharing", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	auditRec.AddMeta("shareID", sharing.ID)
-	auditRec.AddMeta("enabled", sharing.Enabled)
+	var user *model.User
+	var err error
 
-	// Stamp ModifiedBy
-	modifiedBy := userID
-	if userID == model.SingleUser {
-		modifiedBy = ""
-	}
-	sharing.ModifiedBy = modifiedBy
+	auditRec := a.makeAuditRecord(r, "getMe", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
 	if userID == model.SingleUser {
-		userID = ""
-	}
-
-	if !a.app.GetClientConfig().EnablePublicSharedBoards {
-		a.logger.Warn(
-			"Attempt to turn on sharing for board via API failed, sharing off in configuration.",
-			mlog.String("boardID", sharing.ID),
-			mlog.String("userID", userID))
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "Turning on sharing for board failed, see log for details.", nil)
-		return
+		now := utils.GetMillis()
+		user = &model.User{
+			ID:       model.SingleUser,
+			Username: model.SingleUser,
+			Email:    model.SingleUser,
+			CreateAt: now,
+			UpdateAt: now,
+		}
+	} else {
+		user, err = a.appFor(r).GetUser(userID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
 	}
 
-	sharing.ModifiedBy = userID
-
-	err = a.app.UpsertSharing(sharing)
+	userData, err := json.Marshal(user)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonStringResponse(w, http.StatusOK, "{}")
+	jsonBytesResponse(w, http.StatusOK, userData)
 
-	a.logger.Debug("POST sharing", mlog.String("sharingID", sharing.ID))
+	auditRec.AddMeta("userID", user.ID)
 	auditRec.Success()
 }
 
-// Team
-
-func (a *API) handleGetTeams(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /teams getTeams
+func (a *API) handleGetMyMemberships(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/memberships getMyMemberships
 	//
-	// Returns information of all the teams
+	// Returns the currently users board memberships
 	//
 	// ---
 	// produces:
@@ -1678,7 +2043,7 @@ func (a *API) handleGetTeams(w http.ResponseWriter, r *http.Request) {
 	//     schema:
 	//       type: array
 	//       items:
-	//         "$ref": "#/definitions/Team"
+	//         "$ref": "#/definitions/BoardMember"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -1686,142 +2051,1187 @@ func (a *API) handleGetTeams(w http.ResponseWriter, r *http.Request) {
 
 	userID := getUserID(r)
 
-	teams, err := a.app.GetTeamsForUser(userID)
+	auditRec := a.makeAuditRecord(r, "getMyBoardMemberships", audit.Fail)
+	auditRec.AddMeta("userID", userID)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	members, err := a.appFor(r).GetMembersForUser(userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "getTeams", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelRead, auditRec)
-	auditRec.AddMeta("teamCount", len(teams))
-
-	data, err := json.Marshal(teams)
+	membersData, err := json.Marshal(members)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonBytesResponse(w, http.StatusOK, data)
+	jsonBytesResponse(w, http.StatusOK, membersData)
+
 	auditRec.Success()
 }
 
-func (a *API) handleGetTeam(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /teams/{teamID} getTeam
+func (a *API) handleGetRecentBoards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/boards/recent getRecentBoards
 	//
-	// Returns information of the root team
+	// Returns the boards the current user visited most recently
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
 	// - name: teamID
-	//   in: path
-	//   description: Team ID
-	//   required: true
+	//   in: query
+	//   description: Team ID, to only return the boards of a team
+	//   required: false
 	//   type: string
+	// - name: limit
+	//   in: query
+	//   description: Maximum number of boards to return, 10 by default and at most 50
+	//   required: false
+	//   type: integer
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       "$ref": "#/definitions/Team"
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Board"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	vars := mux.Vars(r)
-	teamID := vars["teamID"]
 	userID := getUserID(r)
+	query := r.URL.Query()
+	teamID := query.Get("teamID")
+
+	limit := model.DefaultRecentBoardsLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid limit", err)
+			return
+		}
+		if limit > model.MaxRecentBoardsLimit {
+			limit = model.MaxRecentBoardsLimit
+		}
+	}
 
-	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+	if teamID != "" && !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
 		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
 		return
 	}
 
-	var team *model.Team
-	var err error
+	auditRec := a.makeAuditRecord(r, "getRecentBoards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
 
-	if a.MattermostAuth {
-		team, err = a.app.GetTeam(teamID)
-		if err != nil {
-			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		}
-		if team == nil {
-			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "invalid team", nil)
-			return
-		}
-	} else {
-		team, err = a.app.GetRootTeam()
-		if err != nil {
-			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-			return
+	boards, err := a.appFor(r).GetRecentBoards(userID, teamID, limit)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	// the user may have lost access to some boards since the visit
+	visible := make([]*model.Board, 0, len(boards))
+	for _, board := range boards {
+		if board.Type == model.BoardTypePrivate {
+			if !a.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionViewBoard) {
+				continue
+			}
+		} else if !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
+			continue
 		}
+		visible = append(visible, board)
 	}
 
-	auditRec := a.makeAuditRecord(r, "getTeam", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelRead, auditRec)
-	auditRec.AddMeta("resultTeamID", team.ID)
+	a.logger.Debug("GetRecentBoards",
+		mlog.String("teamID", teamID),
+		mlog.Int("boardsCount", len(visible)),
+	)
 
-	data, err := json.Marshal(team)
+	data, err := json.Marshal(visible)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
 	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("boardsCount", len(visible))
 	auditRec.Success()
 }
 
-func (a *API) handlePostTeamRegenerateSignupToken(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /teams/{teamID}/regenerate_signup_token regenerateSignupToken
+func (a *API) handleGetMyWork(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/cards getMyWork
 	//
-	// Regenerates the signup token for the root team
+	// Returns the cards of a team assigned to the current user through a
+	// person property, sorted by due date
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
 	// - name: teamID
-	//   in: path
+	//   in: query
 	//   description: Team ID
 	//   required: true
 	//   type: string
+	// - name: dueBefore
+	//   in: query
+	//   description: Only return cards due before this time, in miliseconds
+	//   required: false
+	//   type: integer
+	// - name: dueAfter
+	//   in: query
+	//   description: Only return cards due after this time, in miliseconds
+	//   required: false
+	//   type: integer
+	// - name: status
+	//   in: query
+	//   description: Only return cards with this status, can be repeated
+	//   required: false
+	//   type: array
+	//   items:
+	//     type: string
+	// - name: dueToday
+	//   in: query
+	//   description: Only return cards due today in the timezone of the user
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/MyWorkCard"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
-	if a.MattermostAuth {
-		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "not permitted in plugin mode", nil)
+
+	userID := getUserID(r)
+	values := r.URL.Query()
+	teamID := values.Get("teamID")
+	if teamID == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "teamID is required", nil)
 		return
 	}
 
-	team, err := a.app.GetRootTeam()
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		return
+	query := model.MyWorkQuery{Statuses: values["status"], DueToday: values.Get("dueToday") == "true"}
+	for param, target := range map[string]*int64{"dueBefore": &query.DueBefore, "dueAfter": &query.DueAfter} {
+		if value := values.Get(param); value != "" {
+			millis, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid "+param, err)
+				return
+			}
+			*target = millis
+		}
 	}
 
-	auditRec := a.makeAuditRecord(r, "regenerateSignupToken", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
 
-	team.SignupToken = utils.NewID(utils.IDTypeToken)
+	auditRec := a.makeAuditRecord(r, "getMyWork", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
 
-	err = a.app.UpsertTeamSignupToken(*team)
+	cards, err := a.appFor(r).GetMyWork(userID, teamID, query)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonStringResponse(w, http.StatusOK, "{}")
-	auditRec.Success()
-}
+	a.logger.Debug("GetMyWork",
+		mlog.String("teamID", teamID),
+		mlog.Int("cardsCount", len(cards)),
+	)
+
+	data, err := json.Marshal(cards)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("cardsCount", len(cards))
+	auditRec.Success()
+}
+
+func (a *API) handleGetMyMentions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/mentions getMyMentions
+	//
+	// Returns the mentions of the current user, newest first
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: unread
+	//   in: query
+	//   description: Only return the mentions that haven't been read
+	//   required: false
+	//   type: boolean
+	// - name: before
+	//   in: query
+	//   description: Only return the mentions created before this time, in miliseconds
+	//   required: false
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: Maximum number of mentions to return, 50 by default and at most 200
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Mention"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	query := r.URL.Query()
+
+	opts := model.QueryMentionsOptions{
+		UnreadOnly: query.Get("unread") == "true",
+		Limit:      model.DefaultMentionsLimit,
+	}
+
+	if beforeStr := query.Get("before"); beforeStr != "" {
+		before, err := strconv.ParseInt(beforeStr, 10, 64)
+		if err != nil || before < 1 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid before", err)
+			return
+		}
+		opts.BeforeCreateAt = before
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.ParseUint(limitStr, 10, 64)
+		if err != nil || limit < 1 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid limit", err)
+			return
+		}
+		if limit > model.MaxMentionsLimit {
+			limit = model.MaxMentionsLimit
+		}
+		opts.Limit = limit
+	}
+
+	auditRec := a.makeAuditRecord(r, "getMyMentions", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	mentions, err := a.appFor(r).GetMentionsForUser(userID, opts)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	// the user may have lost access to some boards since the mention
+	visible := make([]*model.Mention, 0, len(mentions))
+	for _, mention := range mentions {
+		if a.permissions.HasPermissionToBoard(userID, mention.BoardID, model.PermissionViewBoard) {
+			visible = append(visible, mention)
+		}
+	}
+
+	a.logger.Debug("GetMyMentions",
+		mlog.String("userID", userID),
+		mlog.Int("mentionsCount", len(visible)),
+	)
+
+	data, err := json.Marshal(visible)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("mentionsCount", len(visible))
+	auditRec.Success()
+}
+
+func (a *API) handleMarkMentionsRead(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/mentions/read markMentionsRead
+	//
+	// Marks mentions of the current user as read
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: The mentions to mark as read, all of them if no IDs are given
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MarkMentionsReadRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	request, err := model.MarkMentionsReadRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "markMentionsRead", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("mentionsCount", len(request.MentionIDs))
+
+	if err := a.appFor(r).MarkMentionsRead(userID, request.MentionIDs); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("MarkMentionsRead",
+		mlog.String("userID", userID),
+		mlog.Int("mentionsCount", len(request.MentionIDs)),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/blocks/{blockID} deleteBlock
+	//
+	// Deletes a block
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of block to delete
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: block not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	blockID := vars["blockID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	block, err := a.appFor(r).GetBlockByID(blockID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if block == nil || block.BoardID != boardID {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(blockID))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBlock", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("blockID", blockID)
+
+	err = a.appFor(r).DeleteBlock(blockID, userID)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("DELETE Block", mlog.String("boardID", boardID), mlog.String("blockID", blockID))
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/blocks/{blockID}/undelete undeleteBlock
+	//
+	// Undeletes a block
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of block to undelete
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BlockPatch"
+	//   '404':
+	//     description: block not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	userID := session.UserID
+
+	vars := mux.Vars(r)
+	blockID := vars["blockID"]
+	boardID := vars["boardID"]
+
+	board, err := a.appFor(r).GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if board == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
+		return
+	}
+
+	block, err := a.appFor(r).GetLastBlockHistoryEntry(blockID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if block == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(blockID))
+		return
+	}
+
+	if board.ID != block.BoardID {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(blockID))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modify board members"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "undeleteBlock", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("blockID", blockID)
+
+	undeletedBlock, err := a.appFor(r).UndeleteBlock(blockID, userID)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	undeletedBlockData, err := json.Marshal(undeletedBlock)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("UNDELETE Block", mlog.String("blockID", blockID))
+	jsonBytesResponse(w, http.StatusOK, undeletedBlockData)
+
+	auditRec.Success()
+}
+
+func (a *API) handleUndeleteBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/undelete undeleteBoard
+	//
+	// Undeletes a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: ID of board to undelete
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	userID := session.UserID
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+
+	auditRec := a.makeAuditRecord(r, "undeleteBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionDeleteBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to undelete board"})
+		return
+	}
+
+	err := a.appFor(r).UndeleteBoard(boardID, userID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("UNDELETE Board", mlog.String("boardID", boardID))
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/blocks/{blockID} patchBlock
+	//
+	// Partially updates a block
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of block to patch
+	//   required: true
+	//   type: string
+	// - name: force
+	//   in: query
+	//   description: Apply the patch even if the block is locked by another user
+	//   required: false
+	//   type: boolean
+	// - name: Body
+	//   in: body
+	//   description: block patch to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BlockPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, with the soft WIP limits the patch exceeded
+	//     schema:
+	//       "$ref": "#/definitions/BlockPatchResult"
+	//   '404':
+	//     description: block not found
+	//   '409':
+	//     description: the block was modified since the given version, or a hard WIP limit would be exceeded
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '423':
+	//     description: the block is being edited by another user
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	blockID := vars["blockID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	block, err := a.appFor(r).GetBlockByID(blockID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if block == nil || block.BoardID != boardID {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(blockID))
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var patch *model.BlockPatch
+	err = json.Unmarshal(requestBody, &patch)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchBlock", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("blockID", blockID)
+
+	if err = a.checkBlockLocks(r, userID, blockID); err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	exceeded, err := a.appFor(r).CheckWIPLimits([]model.Block{*block}, []model.BlockPatch{*patch})
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	err = a.appFor(r).PatchBlock(blockID, patch, userID)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if model.IsErrVersionConflict(err) || model.IsErrWIPLimitExceeded(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusConflict, "", err)
+		return
+	}
+	if model.IsErrContentRejected(err) || model.IsErrBadRequest(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(model.NewBlockPatchResult(exceeded))
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("PATCH Block", mlog.String("boardID", boardID), mlog.String("blockID", blockID))
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/blocks/ patchBlocks
+	//
+	// Partially updates batch of blocks
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: force
+	//   in: query
+	//   description: Apply the patches even if a block is locked by another user
+	//   required: false
+	//   type: boolean
+	// - name: Body
+	//   in: body
+	//   description: block Ids and block patches to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BlockPatchBatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, with the soft WIP limits the patches exceeded
+	//     schema:
+	//       "$ref": "#/definitions/BlockPatchResult"
+	//   '409':
+	//     description: a block was modified since the given version, or a hard WIP limit would be exceeded
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '423':
+	//     description: a block is being edited by another user
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	userID := session.UserID
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var patches *model.BlockPatchBatch
+	err = json.Unmarshal(requestBody, &patches)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchBlocks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	for i := range patches.BlockIDs {
+		auditRec.AddMeta("block_"+strconv.FormatInt(int64(i), 10), patches.BlockIDs[i])
+	}
+
+	blocks := make([]model.Block, 0, len(patches.BlockIDs))
+	for _, blockID := range patches.BlockIDs {
+		var block *model.Block
+		block, err = a.appFor(r).GetBlockByID(blockID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+			return
+		}
+		if !a.permissions.HasPermissionToBoard(userID, block.BoardID, model.PermissionManageBoardCards) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+			return
+		}
+		blocks = append(blocks, *block)
+	}
+
+	if err = a.checkBlockLocks(r, userID, patches.BlockIDs...); err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	exceeded, err := a.appFor(r).CheckWIPLimits(blocks, patches.BlockPatches)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	err = a.appFor(r).PatchBlocks(teamID, patches, userID)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if model.IsErrVersionConflict(err) || model.IsErrWIPLimitExceeded(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusConflict, "", err)
+		return
+	}
+	if model.IsErrContentRejected(err) || model.IsErrBadRequest(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(model.NewBlockPatchResult(exceeded))
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("PATCH Blocks", mlog.String("patches", strconv.Itoa(len(patches.BlockIDs))))
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+// Sharing
+
+func (a *API) handleGetSharing(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/sharing getSharing
+	//
+	// Returns sharing information for a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Sharing"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to sharing the board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getSharing", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	sharing, err := a.appFor(r).GetSharing(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if sharing == nil {
+		jsonStringResponse(w, http.StatusOK, "{}")
+		return
+	}
+
+	sharingData, err := json.Marshal(sharing)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, sharingData)
+
+	a.logger.Debug("GET sharing",
+		mlog.String("boardID", boardID),
+		mlog.String("shareID", sharing.ID),
+		mlog.Bool("enabled", sharing.Enabled),
+	)
+	auditRec.AddMeta("shareID", sharing.ID)
+	auditRec.AddMeta("enabled", sharing.Enabled)
+	auditRec.Success()
+}
+
+func (a *API) handlePostSharing(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/sharing postSharing
+	//
+	// Sets sharing information for a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: sharing information for a root block
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Sharing"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to sharing the board"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var sharing model.Sharing
+	err = json.Unmarshal(requestBody, &sharing)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	// Stamp boardID from the URL
+	sharing.ID = boardID
+
+	auditRec := a.makeAuditRecord(r, "postSharing", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("shareID", sharing.ID)
+	auditRec.AddMeta("enabled", sharing.Enabled)
+
+	// Stamp ModifiedBy
+	modifiedBy := userID
+	if userID == model.SingleUser {
+		modifiedBy = ""
+	}
+	sharing.ModifiedBy = modifiedBy
+
+	if userID == model.SingleUser {
+		userID = ""
+	}
+
+	if !a.appFor(r).GetClientConfig().EnablePublicSharedBoards {
+		a.logger.Warn(
+			"Attempt to turn on sharing for board via API failed, sharing off in configuration.",
+			mlog.String("boardID", sharing.ID),
+			mlog.String("userID", userID))
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "Turning on sharing for board failed, see log for details.", nil)
+		return
+	}
+
+	sharing.ModifiedBy = userID
+
+	err = a.appFor(r).UpsertSharing(sharing)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	a.logger.Debug("POST sharing", mlog.String("sharingID", sharing.ID))
+	auditRec.Success()
+}
+
+// Team
+
+func (a *API) handleGetTeams(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams getTeams
+	//
+	// Returns information of all the teams
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Team"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	teams, err := a.appFor(r).GetTeamsForUser(userID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+
+	auditRec := a.makeAuditRecord(r, "getTeams", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamCount", len(teams))
+
+	data, err := json.Marshal(teams)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleGetTeam(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID} getTeam
+	//
+	// Returns information of the root team
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Team"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+
+	var team *model.Team
+	var err error
+
+	if a.MattermostAuth {
+		team, err = a.appFor(r).GetTeam(teamID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		}
+		if team == nil {
+			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "invalid team", nil)
+			return
+		}
+	} else {
+		team, err = a.appFor(r).GetRootTeam()
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "getTeam", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("resultTeamID", team.ID)
+
+	data, err := json.Marshal(team)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handlePostTeamRegenerateSignupToken(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/regenerate_signup_token regenerateSignupToken
+	//
+	// Regenerates the signup token for the root team
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	if a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "not permitted in plugin mode", nil)
+		return
+	}
+
+	team, err := a.appFor(r).GetRootTeam()
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "regenerateSignupToken", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	team.SignupToken = utils.NewID(utils.IDTypeToken)
+
+	err = a.appFor(r).UpsertTeamSignupToken(*team)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
 
 // File upload
 
@@ -1880,13 +3290,13 @@ func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
 		return
 	}
 
@@ -1909,7 +3319,7 @@ func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", contentType)
 
-	fileReader, err := a.app.GetFileReader(board.TeamID, boardID, filename)
+	fileReader, err := a.appFor(r).GetFileReader(board.TeamID, boardID, filename)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1984,18 +3394,18 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
 		return
 	}
 
-	if a.app.GetConfig().MaxFileSize > 0 {
-		r.Body = http.MaxBytesReader(w, r.Body, a.app.GetConfig().MaxFileSize)
+	if a.appFor(r).GetConfig().MaxFileSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, a.appFor(r).GetConfig().MaxFileSize)
 	}
 
 	file, handle, err := r.FormFile(UploadFormFileKey)
@@ -2015,7 +3425,7 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("teamID", board.TeamID)
 	auditRec.AddMeta("filename", handle.Filename)
 
-	fileID, err := a.app.SaveFile(file, board.TeamID, boardID, handle.Filename)
+	fileID, err := a.appFor(r).SaveFile(file, board.TeamID, boardID, handle.Filename)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -2084,7 +3494,7 @@ func (a *API) handleGetTeamUsers(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "getUsers", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
-	users, err := a.app.SearchTeamUsers(teamID, searchQuery)
+	users, err := a.appFor(r).SearchTeamUsers(teamID, searchQuery)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "searchQuery="+searchQuery, err)
 		return
@@ -2143,11 +3553,12 @@ func (a *API) handleGetBoards(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("teamID", teamID)
 
 	// retrieve boards list
-	boards, err := a.app.GetBoardsForUserAndTeam(userID, teamID)
+	boards, err := a.appFor(r).GetBoardsForUserAndTeam(userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
+	boards = a.filterOrganizationBoards(userID, boards)
 
 	a.logger.Debug("GetBoards",
 		mlog.String("teamID", teamID),
@@ -2208,7 +3619,7 @@ func (a *API) handleGetTemplates(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("teamID", teamID)
 
 	// retrieve boards list
-	boards, err := a.app.GetTemplateBoards(teamID, userID)
+	boards, err := a.appFor(r).GetTemplateBoards(teamID, userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -2303,50 +3714,290 @@ func (a *API) handleCreateSubscription(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// check for valid block
-	block, err := a.app.GetBlockByID(sub.BlockID)
+	block, err := a.appFor(r).GetBlockByID(sub.BlockID)
 	if err != nil || block == nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid blockID", err)
 		return
 	}
 
-	subNew, err := a.app.CreateSubscription(&sub)
+	subNew, err := a.appFor(r).CreateSubscription(&sub)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("CREATE subscription",
+		mlog.String("subscriber_id", subNew.SubscriberID),
+		mlog.String("block_id", subNew.BlockID),
+	)
+
+	json, err := json.Marshal(subNew)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, json)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /subscriptions/{blockID}/{subscriberID} deleteSubscription
+	//
+	// Deletes a subscription a user has for a a block. The user will no longer receive change notifications for the block.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: blockID
+	//   in: path
+	//   description: Block ID
+	//   required: true
+	//   type: string
+	// - name: subscriberID
+	//   in: path
+	//   description: Subscriber ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	vars := mux.Vars(r)
+	blockID := vars["blockID"]
+	subscriberID := vars["subscriberID"]
+
+	auditRec := a.makeAuditRecord(r, "deleteSubscription", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("block_id", blockID)
+	auditRec.AddMeta("subscriber_id", subscriberID)
+
+	// User can only delete subscriptions for themselves
+	if session.UserID != subscriberID {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "access denied", nil)
+		return
+	}
+
+	_, err := a.appFor(r).DeleteSubscription(blockID, subscriberID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("DELETE subscription",
+		mlog.String("blockID", blockID),
+		mlog.String("subscriberID", subscriberID),
+	)
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /subscriptions/{subscriberID} getSubscriptions
+	//
+	// Gets subscriptions for a user.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: subscriberID
+	//   in: path
+	//   description: Subscriber ID
+	//   required: true
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: Zero-based page number, used with per_page
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: Number of subscriptions per page, all of them if not set
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Subscription"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	vars := mux.Vars(r)
+	subscriberID := vars["subscriberID"]
+
+	query := r.URL.Query()
+	opts := model.QuerySubscriptionsOptions{}
+	if pageStr := query.Get("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid page", err)
+			return
+		}
+		opts.Page = page
+	}
+	if perPageStr := query.Get("per_page"); perPageStr != "" {
+		perPage, err := strconv.Atoi(perPageStr)
+		if err != nil || perPage < 1 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid per_page", err)
+			return
+		}
+		opts.PerPage = perPage
+	}
+
+	auditRec := a.makeAuditRecord(r, "getSubscriptions", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("subscriber_id", subscriberID)
+
+	// User can only get subscriptions for themselves (for now)
+	if session.UserID != subscriberID {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "access denied", nil)
+		return
+	}
+
+	subs, err := a.appFor(r).GetSubscriptions(subscriberID, opts)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GET subscriptions",
+		mlog.String("subscriberID", subscriberID),
+		mlog.Int("count", len(subs)),
+	)
+
+	json, err := json.Marshal(subs)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	jsonBytesResponse(w, http.StatusOK, json)
+
+	auditRec.AddMeta("subscription_count", len(subs))
+	auditRec.Success()
+}
+
+func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards createBoard
+	//
+	// Creates a new board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: the board to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Board"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Board'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var newBoard *model.Board
+	if err = json.Unmarshal(requestBody, &newBoard); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	if newBoard.Type == model.BoardTypeOpen {
+		if !a.permissions.HasPermissionToTeam(userID, newBoard.TeamID, model.PermissionCreatePublicChannel) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create public boards"})
+			return
+		}
+	} else {
+		if !a.permissions.HasPermissionToTeam(userID, newBoard.TeamID, model.PermissionCreatePrivateChannel) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create private boards"})
+			return
+		}
+	}
+
+	if err = newBoard.IsValid(); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", newBoard.TeamID)
+	auditRec.AddMeta("boardType", newBoard.Type)
+
+	// create board
+	board, err := a.appFor(r).CreateBoard(newBoard, userID, true)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("CREATE subscription",
-		mlog.String("subscriber_id", subNew.SubscriberID),
-		mlog.String("block_id", subNew.BlockID),
+	a.logger.Debug("CreateBoard",
+		mlog.String("teamID", board.TeamID),
+		mlog.String("boardID", board.ID),
+		mlog.String("boardType", string(board.Type)),
+		mlog.String("userID", userID),
 	)
 
-	json, err := json.Marshal(subNew)
+	data, err := json.Marshal(board)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonBytesResponse(w, http.StatusOK, json)
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
 	auditRec.Success()
 }
 
-func (a *API) handleDeleteSubscription(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation DELETE /subscriptions/{blockID}/{subscriberID} deleteSubscription
+func (a *API) handleOnboard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /team/{teamID}/onboard onboard
 	//
-	// Deletes a subscription a user has for a a block. The user will no longer receive change notifications for the block.
+	// Onboards a user on Boards.
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
-	// - name: blockID
-	//   in: path
-	//   description: Block ID
-	//   required: true
-	//   type: string
-	// - name: subscriberID
+	// - name: teamID
 	//   in: path
-	//   description: Subscriber ID
+	//   description: Team ID
 	//   required: true
 	//   type: string
 	// security:
@@ -2354,56 +4005,51 @@ func (a *API) handleDeleteSubscription(w http.ResponseWriter, r *http.Request) {
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//         "$ref": "#/definitions/OnboardingResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
 
-	ctx := r.Context()
-	session := ctx.Value(sessionContextKey).(*model.Session)
-
-	vars := mux.Vars(r)
-	blockID := vars["blockID"]
-	subscriberID := vars["subscriberID"]
-
-	auditRec := a.makeAuditRecord(r, "deleteSubscription", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	auditRec.AddMeta("block_id", blockID)
-	auditRec.AddMeta("subscriber_id", subscriberID)
-
-	// User can only delete subscriptions for themselves
-	if session.UserID != subscriberID {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "access denied", nil)
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create board"})
 		return
 	}
 
-	_, err := a.app.DeleteSubscription(blockID, subscriberID)
+	teamID, boardID, err := a.appFor(r).PrepareOnboardingTour(userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("DELETE subscription",
-		mlog.String("blockID", blockID),
-		mlog.String("subscriberID", subscriberID),
-	)
-	jsonStringResponse(w, http.StatusOK, "{}")
+	response := map[string]string{
+		"teamID":  teamID,
+		"boardID": boardID,
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
 
-	auditRec.Success()
+	jsonBytesResponse(w, http.StatusOK, data)
 }
 
-func (a *API) handleGetSubscriptions(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /subscriptions/{subscriberID} getSubscriptions
+func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID} getBoard
 	//
-	// Gets subscriptions for a user.
+	// Returns a board
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
-	// - name: subscriberID
+	// - name: boardID
 	//   in: path
-	//   description: Subscriber ID
+	//   description: Board ID
 	//   required: true
 	//   type: string
 	// security:
@@ -2412,66 +4058,91 @@ func (a *API) handleGetSubscriptions(w http.ResponseWriter, r *http.Request) {
 	//   '200':
 	//     description: success
 	//     schema:
-	//       type: array
-	//       items:
-	//         "$ref": "#/definitions/User"
+	//       "$ref": "#/definitions/Board"
+	//   '404':
+	//     description: board not found
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
-	ctx := r.Context()
-	session := ctx.Value(sessionContextKey).(*model.Session)
 
-	vars := mux.Vars(r)
-	subscriberID := vars["subscriberID"]
-
-	auditRec := a.makeAuditRecord(r, "getSubscriptions", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelRead, auditRec)
-	auditRec.AddMeta("subscriber_id", subscriberID)
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
 
-	// User can only get subscriptions for themselves (for now)
-	if session.UserID != subscriberID {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "access denied", nil)
+	hasValidReadToken := a.hasValidReadTokenForBoard(r, boardID)
+	if userID == "" && !hasValidReadToken {
+		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", PermissionError{"access denied to board"})
 		return
 	}
 
-	subs, err := a.app.GetSubscriptions(subscriberID)
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
+	if board == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
+		return
+	}
 
-	a.logger.Debug("GET subscriptions",
-		mlog.String("subscriberID", subscriberID),
-		mlog.Int("count", len(subs)),
+	if !hasValidReadToken {
+		if board.Type == model.BoardTypePrivate {
+			if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+				a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+				return
+			}
+		} else {
+			if !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
+				a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+				return
+			}
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	a.logger.Debug("GetBoard",
+		mlog.String("boardID", boardID),
 	)
 
-	json, err := json.Marshal(subs)
+	if userID != "" {
+		a.appFor(r).RecordBoardVisit(userID, boardID)
+	}
+
+	data, err := json.Marshal(board)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-	jsonBytesResponse(w, http.StatusOK, json)
 
-	auditRec.AddMeta("subscription_count", len(subs))
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
 	auditRec.Success()
 }
 
-func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /boards createBoard
+func (a *API) handlePatchBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID} patchBoard
 	//
-	// Creates a new board
+	// Partially updates a board
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
 	// - name: Body
 	//   in: body
-	//   description: the board to create
+	//   description: board patch to apply
 	//   required: true
 	//   schema:
-	//     "$ref": "#/definitions/Board"
+	//     "$ref": "#/definitions/BoardPatch"
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -2479,11 +4150,24 @@ func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 	//     description: success
 	//     schema:
 	//       $ref: '#/definitions/Board'
+	//   '404':
+	//     description: board not found
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
+	boardID := mux.Vars(r)["boardID"]
+	board, err := a.appFor(r).GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if board == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
+		return
+	}
+
 	userID := getUserID(r)
 
 	requestBody, err := ioutil.ReadAll(r.Body)
@@ -2492,49 +4176,73 @@ func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var newBoard *model.Board
-	if err = json.Unmarshal(requestBody, &newBoard); err != nil {
+	var patch *model.BoardPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
 		return
 	}
 
-	if newBoard.Type == model.BoardTypeOpen {
-		if !a.permissions.HasPermissionToTeam(userID, newBoard.TeamID, model.PermissionCreatePublicChannel) {
-			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create public boards"})
+	if err = patch.IsValid(); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board properties"})
+		return
+	}
+
+	if patch.Type != nil {
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardType) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board type"})
 			return
 		}
-	} else {
-		if !a.permissions.HasPermissionToTeam(userID, newBoard.TeamID, model.PermissionCreatePrivateChannel) {
-			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create private boards"})
+	}
+
+	if patch.ChangesMembershipProperties() {
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board membership settings"})
 			return
 		}
 	}
 
-	if err = newBoard.IsValid(); err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
-		return
+	if patch.ChangesReadOnly() {
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to freezing the board"})
+			return
+		}
 	}
 
-	auditRec := a.makeAuditRecord(r, "createBoard", audit.Fail)
+	auditRec := a.makeAuditRecord(r, "patchBoard", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	auditRec.AddMeta("teamID", newBoard.TeamID)
-	auditRec.AddMeta("boardType", newBoard.Type)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("userID", userID)
 
-	// create board
-	board, err := a.app.CreateBoard(newBoard, userID, true)
+	// patch board
+	updatedBoard, err := a.appFor(r).PatchBoard(patch, boardID, userID)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if model.IsErrVersionConflict(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusConflict, "", err)
+		return
+	}
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("CreateBoard",
-		mlog.String("teamID", board.TeamID),
-		mlog.String("boardID", board.ID),
-		mlog.String("boardType", string(board.Type)),
+	a.logger.Debug("PatchBoard",
+		mlog.String("boardID", boardID),
 		mlog.String("userID", userID),
 	)
 
-	data, err := json.Marshal(board)
+	data, err := json.Marshal(updatedBoard)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -2546,18 +4254,18 @@ func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
-func (a *API) handleOnboard(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /team/{teamID}/onboard onboard
+func (a *API) handleDeleteBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID} deleteBoard
 	//
-	// Onboards a user on Boards.
+	// Removes a board
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
-	// - name: teamID
+	// - name: boardID
 	//   in: path
-	//   description: Team ID
+	//   description: Board ID
 	//   required: true
 	//   type: string
 	// security:
@@ -2565,43 +4273,55 @@ func (a *API) handleOnboard(w http.ResponseWriter, r *http.Request) {
 	// responses:
 	//   '200':
 	//     description: success
-	//     schema:
-	//         "$ref": "#/definitions/OnboardingResponse"
+	//   '404':
+	//     description: board not found
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
-	teamID := mux.Vars(r)["teamID"]
-	userID := getUserID(r)
 
-	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create board"})
-		return
-	}
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
 
-	teamID, boardID, err := a.app.PrepareOnboardingTour(userID, teamID)
+	// Check if board exists
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
+	if board == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
+		return
+	}
 
-	response := map[string]string{
-		"teamID":  teamID,
-		"boardID": boardID,
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionDeleteBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to delete board"})
+		return
 	}
-	data, err := json.Marshal(response)
-	if err != nil {
+
+	auditRec := a.makeAuditRecord(r, "deleteBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.appFor(r).DeleteBoard(boardID, userID); err != nil {
+		if model.IsErrBoardReadOnly(err) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+			return
+		}
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonBytesResponse(w, http.StatusOK, data)
+	a.logger.Debug("DELETE Board", mlog.String("boardID", boardID))
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
 }
 
-func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /boards/{boardID} getBoard
+func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/duplicate duplicateBoard
 	//
-	// Returns a board
+	// Returns the new created board and all the blocks
 	//
 	// ---
 	// produces:
@@ -2612,13 +4332,26 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 	//   description: Board ID
 	//   required: true
 	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the values of the template placeholders, e.g. {"variables":{"sprint_number":"12"}}, and the users that replace the users of the board in the copy, e.g. {"userMapping":{"old-user-id":"new-user-id","dropped-user-id":""}}
+	//   required: false
+	//   schema:
+	//     type: object
+	//     properties:
+	//       variables:
+	//         "$ref": "#/definitions/TemplateVariables"
+	//       userMapping:
+	//         "$ref": "#/definitions/UserMapping"
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       "$ref": "#/definitions/Board"
+	//       $ref: '#/definitions/BoardsAndBlocks'
+	//   '400':
+	//     description: invalid user mapping
 	//   '404':
 	//     description: board not found
 	//   default:
@@ -2628,46 +4361,103 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 
 	boardID := mux.Vars(r)["boardID"]
 	userID := getUserID(r)
+	query := r.URL.Query()
+	asTemplate := query.Get("asTemplate")
+	toTeam := query.Get("toTeam")
 
-	hasValidReadToken := a.hasValidReadTokenForBoard(r, boardID)
-	if userID == "" && !hasValidReadToken {
+	if userID == "" {
 		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", PermissionError{"access denied to board"})
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var variables model.TemplateVariables
+	var userMapping model.UserMapping
+	if len(bytes.TrimSpace(requestBody)) > 0 {
+		variables, err = model.TemplateVariablesFromJSON(requestBody)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
+		userMapping, err = model.UserMappingFromJSON(requestBody)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
+	}
+
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
 		return
 	}
 
-	if !hasValidReadToken {
-		if board.Type == model.BoardTypePrivate {
-			if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
-				a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
-				return
-			}
-		} else {
-			if !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
-				a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
-				return
-			}
+	if toTeam == "" && !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+
+	if toTeam == model.OrganizationTeamID && !a.hasPermissionToCreateBoardsInTeam(userID, toTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to organization"})
+		return
+	}
+
+	if board.IsTemplate && board.Type == model.BoardTypeOpen {
+		if board.TeamID != model.GlobalTeamID && !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+			return
+		}
+	} else {
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+			return
 		}
 	}
 
-	auditRec := a.makeAuditRecord(r, "getBoard", audit.Fail)
+	// the users of the mapping must be able to open the boards of the
+	// team of the copy
+	targetTeamID := board.TeamID
+	if toTeam != "" {
+		targetTeamID = toTeam
+	}
+	for _, mappedUserID := range userMapping.TargetUserIDs() {
+		if !a.permissions.HasPermissionToTeam(mappedUserID, targetTeamID, model.PermissionViewTeam) {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", model.NewCodedError(model.ErrCodeBadRequest,
+				"the users of the mapping must be members of the team of the copy", map[string]interface{}{"userId": mappedUserID}))
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "duplicateBoard", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("mappedUsersCount", len(userMapping))
 
-	a.logger.Debug("GetBoard",
+	a.logger.Debug("DuplicateBoard",
 		mlog.String("boardID", boardID),
+		mlog.String("toTeam", toTeam),
 	)
 
-	data, err := json.Marshal(board)
+	boardsAndBlocks, _, err := a.appFor(r).DuplicateBoard(boardID, userID, toTeam, asTemplate == "true", variables, userMapping)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, err.Error(), err)
+		return
+	}
+
+	data, err := json.Marshal(boardsAndBlocks)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -2679,10 +4469,10 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
-func (a *API) handlePatchBoard(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation PATCH /boards/{boardID} patchBoard
+func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/blocks/{blockID}/duplicate duplicateBlock
 	//
-	// Partially updates a board
+	// Returns the new created blocks
 	//
 	// ---
 	// produces:
@@ -2693,86 +4483,89 @@ func (a *API) handlePatchBoard(w http.ResponseWriter, r *http.Request) {
 	//   description: Board ID
 	//   required: true
 	//   type: string
-	// - name: Body
-	//   in: body
-	//   description: board patch to apply
+	// - name: blockID
+	//   in: path
+	//   description: Block ID
 	//   required: true
-	//   schema:
-	//     "$ref": "#/definitions/BoardPatch"
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       $ref: '#/definitions/Board'
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
 	//   '404':
-	//     description: board not found
+	//     description: board or block not found
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
 	boardID := mux.Vars(r)["boardID"]
-	board, err := a.app.GetBoard(boardID)
+	blockID := mux.Vars(r)["blockID"]
+	userID := getUserID(r)
+	query := r.URL.Query()
+	asTemplate := query.Get("asTemplate")
+
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
 		return
 	}
 
-	userID := getUserID(r)
+	if userID == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", PermissionError{"access denied to board"})
+		return
+	}
 
-	requestBody, err := ioutil.ReadAll(r.Body)
+	block, err := a.appFor(r).GetBlockByID(blockID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-
-	var patch *model.BoardPatch
-	if err = json.Unmarshal(requestBody, &patch); err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+	if block == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(blockID))
 		return
 	}
 
-	if err = patch.IsValid(); err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	if board.ID != block.BoardID {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(blockID))
 		return
 	}
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board properties"})
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modify board members"})
 		return
 	}
 
-	if patch.Type != nil {
-		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardType) {
-			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board type"})
-			return
-		}
-	}
-
-	auditRec := a.makeAuditRecord(r, "patchBoard", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec := a.makeAuditRecord(r, "duplicateBlock", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("boardID", boardID)
-	auditRec.AddMeta("userID", userID)
+	auditRec.AddMeta("blockID", blockID)
 
-	// patch board
-	updatedBoard, err := a.app.PatchBoard(patch, boardID, userID)
+	a.logger.Debug("DuplicateBlock",
+		mlog.String("boardID", boardID),
+		mlog.String("blockID", blockID),
+	)
+
+	blocks, err := a.appFor(r).DuplicateBlock(boardID, blockID, userID, asTemplate == "true")
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
 	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, err.Error(), err)
 		return
 	}
 
-	a.logger.Debug("PatchBoard",
-		mlog.String("boardID", boardID),
-		mlog.String("userID", userID),
-	)
-
-	data, err := json.Marshal(updatedBoard)
+	data, err := json.Marshal(blocks)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -2784,177 +4577,215 @@ func (a *API) handlePatchBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
-func (a *API) handleDeleteBoard(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation DELETE /boards/{boardID} deleteBoard
+func (a *API) handleGetCardPreview(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/preview getCardPreview
 	//
-	// Removes a board
+	// Returns the summary of a card shown when a link to the card is posted
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
-	// - name: boardID
+	// - name: cardID
 	//   in: path
-	//   description: Board ID
+	//   description: Card ID
 	//   required: true
 	//   type: string
+	// - name: read_token
+	//   in: query
+	//   description: Share token for read access to the board of the card
+	//   required: false
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardPreview"
 	//   '404':
-	//     description: board not found
+	//     description: card not found
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	boardID := mux.Vars(r)["boardID"]
+	cardID := mux.Vars(r)["cardID"]
 	userID := getUserID(r)
 
-	// Check if board exists
-	board, err := a.app.GetBoard(boardID)
+	card, err := a.appFor(r).GetBlockByID(cardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+	if card == nil || card.Type != model.TypeCard {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(cardID))
 		return
 	}
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionDeleteBoard) {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to delete board"})
+	hasValidReadToken := a.hasValidReadTokenForBoard(r, card.BoardID)
+	if userID == "" && !hasValidReadToken {
+		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", PermissionError{"access denied to board"})
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "deleteBoard", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	auditRec.AddMeta("boardID", boardID)
+	if !hasValidReadToken && !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardPreview", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("boardID", card.BoardID)
 
-	if err := a.app.DeleteBoard(boardID, userID); err != nil {
+	preview, err := a.appFor(r).GetCardPreview(card)
+	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("DELETE Board", mlog.String("boardID", boardID))
-	jsonStringResponse(w, http.StatusOK, "{}")
+	data, err := json.Marshal(preview)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
 
+	jsonBytesResponse(w, http.StatusOK, data)
 	auditRec.Success()
 }
 
-func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /boards/{boardID}/duplicate duplicateBoard
+func (a *API) handleMoveCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/move moveCard
 	//
-	// Returns the new created board and all the blocks
+	// Moves a card, with its content and comments, to another board
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
-	// - name: boardID
+	// - name: cardID
 	//   in: path
-	//   description: Board ID
+	//   description: Card ID
 	//   required: true
 	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the destination board
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MoveCardRequest"
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       $ref: '#/definitions/BoardsAndBlocks'
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
 	//   '404':
-	//     description: board not found
+	//     description: card or board not found
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	boardID := mux.Vars(r)["boardID"]
+	cardID := mux.Vars(r)["cardID"]
 	userID := getUserID(r)
-	query := r.URL.Query()
-	asTemplate := query.Get("asTemplate")
-	toTeam := query.Get("toTeam")
 
-	if userID == "" {
-		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", PermissionError{"access denied to board"})
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var request model.MoveCardRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if request.BoardID == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "boardId is required", nil)
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	card, err := a.appFor(r).GetBlockByID(cardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+	if card == nil || card.Type != model.TypeCard {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(cardID))
 		return
 	}
 
-	if toTeam == "" && !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
 		return
 	}
 
-	if board.IsTemplate && board.Type == model.BoardTypeOpen {
-		if board.TeamID != model.GlobalTeamID && !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
-			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
-			return
-		}
-	} else {
-		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
-			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
-			return
-		}
+	if !a.permissions.HasPermissionToBoard(userID, request.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "duplicateBoard", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelRead, auditRec)
-	auditRec.AddMeta("boardID", boardID)
-
-	a.logger.Debug("DuplicateBoard",
-		mlog.String("boardID", boardID),
-	)
+	auditRec := a.makeAuditRecord(r, "moveCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("fromBoardID", card.BoardID)
+	auditRec.AddMeta("toBoardID", request.BoardID)
 
-	boardsAndBlocks, _, err := a.app.DuplicateBoard(boardID, userID, toTeam, asTemplate == "true")
+	blocks, err := a.appFor(r).MoveCard(cardID, request.BoardID, userID)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if errors.Is(err, app.ErrCardAlreadyInBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if _, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
 	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, err.Error(), err)
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	data, err := json.Marshal(boardsAndBlocks)
+	data, err := json.Marshal(blocks)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	// response
 	jsonBytesResponse(w, http.StatusOK, data)
 
+	auditRec.AddMeta("blockCount", len(blocks))
 	auditRec.Success()
 }
 
-func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /boards/{boardID}/blocks/{blockID}/duplicate duplicateBlock
+func (a *API) handleDuplicateCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/duplicate duplicateCard
 	//
-	// Returns the new created blocks
+	// Duplicates a card and its content, returning the new blocks
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
-	// - name: boardID
-	//   in: path
-	//   description: Board ID
-	//   required: true
-	//   type: string
-	// - name: blockID
+	// - name: cardID
 	//   in: path
-	//   description: Block ID
+	//   description: Card ID
 	//   required: true
 	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the duplication options
+	//   required: false
+	//   schema:
+	//     "$ref": "#/definitions/DuplicateCardOptions"
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -2965,65 +4796,58 @@ func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
 	//       items:
 	//         "$ref": "#/definitions/Block"
 	//   '404':
-	//     description: board or block not found
+	//     description: card not found
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	boardID := mux.Vars(r)["boardID"]
-	blockID := mux.Vars(r)["blockID"]
+	cardID := mux.Vars(r)["cardID"]
 	userID := getUserID(r)
-	query := r.URL.Query()
-	asTemplate := query.Get("asTemplate")
 
-	board, err := a.app.GetBoard(boardID)
+	requestBody, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
-	}
 
-	if userID == "" {
-		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", PermissionError{"access denied to board"})
-		return
+	var opts model.DuplicateCardOptions
+	if len(bytes.TrimSpace(requestBody)) > 0 {
+		if err = json.Unmarshal(requestBody, &opts); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
 	}
 
-	block, err := a.app.GetBlockByID(blockID)
+	card, err := a.appFor(r).GetBlockByID(cardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-	if block == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+	if card == nil || card.Type != model.TypeCard {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(cardID))
 		return
 	}
 
-	if board.ID != block.BoardID {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
 		return
 	}
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modify board members"})
+	auditRec := a.makeAuditRecord(r, "duplicateCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("includeComments", opts.IncludeComments)
+	auditRec.AddMeta("includeAttachments", opts.IncludeAttachments)
+
+	blocks, err := a.appFor(r).DuplicateCard(card.BoardID, cardID, userID, opts)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
 		return
 	}
-
-	auditRec := a.makeAuditRecord(r, "duplicateBlock", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelRead, auditRec)
-	auditRec.AddMeta("boardID", boardID)
-	auditRec.AddMeta("blockID", blockID)
-
-	a.logger.Debug("DuplicateBlock",
-		mlog.String("boardID", boardID),
-		mlog.String("blockID", blockID),
-	)
-
-	blocks, err := a.app.DuplicateBlock(boardID, blockID, userID, asTemplate == "true")
 	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, err.Error(), err)
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
@@ -3033,9 +4857,9 @@ func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// response
 	jsonBytesResponse(w, http.StatusOK, data)
 
+	auditRec.AddMeta("blockCount", len(blocks))
 	auditRec.Success()
 }
 
@@ -3072,7 +4896,7 @@ func (a *API) handleGetBoardMetadata(w http.ResponseWriter, r *http.Request) {
 	boardID := mux.Vars(r)["boardID"]
 	userID := getUserID(r)
 
-	board, boardMetadata, err := a.app.GetBoardMetadata(boardID)
+	board, boardMetadata, err := a.appFor(r).GetBoardMetadata(boardID)
 	if errors.Is(err, app.ErrInsufficientLicense) {
 		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "", err)
 		return
@@ -3082,7 +4906,7 @@ func (a *API) handleGetBoardMetadata(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if board == nil || boardMetadata == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
 		return
 	}
 
@@ -3166,11 +4990,12 @@ func (a *API) handleSearchBoards(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("teamID", teamID)
 
 	// retrieve boards list
-	boards, err := a.app.SearchBoardsForUserAndTeam(term, userID, teamID)
+	boards, err := a.appFor(r).SearchBoardsForUserAndTeam(term, userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
+	boards = a.filterOrganizationBoards(userID, boards)
 
 	a.logger.Debug("SearchBoards",
 		mlog.String("teamID", teamID),
@@ -3193,7 +5018,8 @@ func (a *API) handleSearchBoards(w http.ResponseWriter, r *http.Request) {
 func (a *API) handleGetMembersForBoard(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /boards/{boardID}/members getMembersForBoard
 	//
-	// Returns the members of the board
+	// Returns the members of the board, with the last time each one viewed
+	// and edited it
 	//
 	// ---
 	// produces:
@@ -3230,7 +5056,7 @@ func (a *API) handleGetMembersForBoard(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 
-	members, err := a.app.GetMembersForBoard(boardID)
+	members, err := a.appFor(r).GetMembersWithActivityForBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -3253,6 +5079,88 @@ func (a *API) handleGetMembersForBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleValidateMentions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/mentions/validate validateMentions
+	//
+	// Returns the outcome the mentions of a draft text will have when the
+	// text is saved on the board: notified, added to the board, rejected
+	// or unknown. Nothing is changed.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the draft text
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MentionValidationRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/MentionValidation"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var request model.MentionValidationRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	results, err := a.appFor(r).ValidateMentions(boardID, userID, request.Text)
+	if model.IsErrBoardNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("ValidateMentions",
+		mlog.String("boardID", boardID),
+		mlog.Int("mentionsCount", len(results)),
+	)
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
 func (a *API) handleAddMember(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /boards/{boardID}/members addMember
 	//
@@ -3290,13 +5198,13 @@ func (a *API) handleAddMember(w http.ResponseWriter, r *http.Request) {
 	boardID := mux.Vars(r)["boardID"]
 	userID := getUserID(r)
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
 		return
 	}
 
@@ -3322,19 +5230,14 @@ func (a *API) handleAddMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// currently all memberships are created as editors by default
-	newBoardMember := &model.BoardMember{
-		UserID:       reqBoardMember.UserID,
-		BoardID:      boardID,
-		SchemeEditor: true,
-	}
+	newBoardMember := board.NewDefaultMember(reqBoardMember.UserID)
 
 	auditRec := a.makeAuditRecord(r, "addMember", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", reqBoardMember.UserID)
 
-	member, err := a.app.AddMemberToBoard(newBoardMember)
+	member, err := a.appFor(r).AddMemberToBoard(newBoardMember)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -3360,7 +5263,7 @@ func (a *API) handleAddMember(w http.ResponseWriter, r *http.Request) {
 func (a *API) handleJoinBoard(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /boards/{boardID}/join joinBoard
 	//
-	// Become a member of a board
+	// Become a member of an open board, with the default role of its members
 	//
 	// ---
 	// produces:
@@ -3394,39 +5297,44 @@ func (a *API) handleJoinBoard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	boardID := mux.Vars(r)["boardID"]
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
 		return
 	}
-	if board.Type != model.BoardTypeOpen {
-		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", nil)
+	// the board can be joined from its own team or from any of the
+	// teams it is shared with
+	teamIDs, err := a.appFor(r).GetBoardTeamIDs(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-
-	if !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
+	canJoin := false
+	for _, teamID := range teamIDs {
+		if a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+			canJoin = true
+			break
+		}
+	}
+	if !canJoin {
 		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", nil)
 		return
 	}
 
-	// currently all memberships are created as editors by default
-	// TODO: Support different public roles
-	newBoardMember := &model.BoardMember{
-		UserID:       userID,
-		BoardID:      boardID,
-		SchemeEditor: true,
-	}
-
 	auditRec := a.makeAuditRecord(r, "joinBoard", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", userID)
 
-	member, err := a.app.AddMemberToBoard(newBoardMember)
+	member, err := a.appFor(r).JoinBoard(boardID, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -3490,13 +5398,13 @@ func (a *API) handleLeaveBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
 		return
 	}
 
@@ -3505,7 +5413,7 @@ func (a *API) handleLeaveBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", userID)
 
-	err = a.app.DeleteBoardMember(boardID, userID)
+	err = a.appFor(r).DeleteBoardMember(boardID, userID)
 	if errors.Is(err, app.ErrBoardMemberIsLastAdmin) {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
 		return
@@ -3585,6 +5493,7 @@ func (a *API) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
 		SchemeEditor:    reqBoardMember.SchemeEditor,
 		SchemeCommenter: reqBoardMember.SchemeCommenter,
 		SchemeViewer:    reqBoardMember.SchemeViewer,
+		Roles:           reqBoardMember.Roles,
 	}
 
 	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
@@ -3597,7 +5506,11 @@ func (a *API) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("patchedUserID", paramsUserID)
 
-	member, err := a.app.UpdateBoardMember(newBoardMember)
+	member, err := a.appFor(r).UpdateBoardMember(newBoardMember)
+	if errors.Is(err, app.ErrInsufficientLicense) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "", err)
+		return
+	}
 	if errors.Is(err, app.ErrBoardMemberIsLastAdmin) {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
 		return
@@ -3624,6 +5537,103 @@ func (a *API) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleUpdateMembersRoles(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/members/roles updateMembersRoles
+	//
+	// Changes the roles of many members of a board at once, recording the
+	// reason of the change in the board members history
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the new roles of the members and the reason of the change
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardMembersRolesUpdate"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardMember"
+	//   '400':
+	//     description: invalid update, a user that is not a member or no admin left
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modify board members"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var update model.BoardMembersRolesUpdate
+	if err = json.Unmarshal(requestBody, &update); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "updateMembersRoles", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("membersCount", len(update.Members))
+	auditRec.AddMeta("reason", update.Reason)
+
+	members, err := a.appFor(r).UpdateBoardMembersRoles(boardID, &update)
+	if errors.Is(err, app.ErrInsufficientLicense) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "", err)
+		return
+	}
+	if errors.Is(err, app.ErrBoardMemberIsLastAdmin) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("UpdateMembersRoles",
+		mlog.String("boardID", boardID),
+		mlog.Int("membersCount", len(members)),
+	)
+
+	data, err := json.Marshal(members)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
 func (a *API) handleDeleteMember(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation DELETE /boards/{boardID}/members/{userID} deleteMember
 	//
@@ -3659,13 +5669,13 @@ func (a *API) handleDeleteMember(w http.ResponseWriter, r *http.Request) {
 	paramsUserID := mux.Vars(r)["userID"]
 	userID := getUserID(r)
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
 		return
 	}
 
@@ -3679,7 +5689,7 @@ func (a *API) handleDeleteMember(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", paramsUserID)
 
-	deleteErr := a.app.DeleteBoardMember(boardID, paramsUserID)
+	deleteErr := a.appFor(r).DeleteBoardMember(boardID, paramsUserID)
 	if errors.Is(deleteErr, app.ErrBoardMemberIsLastAdmin) {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", deleteErr)
 		return
@@ -3771,7 +5781,7 @@ func (a *API) handleCreateBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 		}
 	}
 
-	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+	if !a.hasPermissionToCreateBoardsInTeam(userID, teamID) {
 		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board template"})
 		return
 	}
@@ -3819,7 +5829,11 @@ func (a *API) handleCreateBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	auditRec.AddMeta("blocksCount", len(newBab.Blocks))
 
 	// create boards and blocks
-	bab, err := a.app.CreateBoardsAndBlocks(newBab, userID, true)
+	bab, err := a.appFor(r).CreateBoardsAndBlocks(newBab, userID, true)
+	if model.IsErrContentRejected(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, err.Error(), err)
 		return
@@ -3913,7 +5927,21 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 			}
 		}
 
-		board, err2 := a.app.GetBoard(boardID)
+		if patch.ChangesMembershipProperties() {
+			if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+				a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board membership settings"})
+				return
+			}
+		}
+
+		if patch.ChangesReadOnly() {
+			if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+				a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to freezing the board"})
+				return
+			}
+		}
+
+		board, err2 := a.appFor(r).GetBoard(boardID)
 		if err2 != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err2)
 			return
@@ -3933,7 +5961,7 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 	}
 
 	for _, blockID := range pbab.BlockIDs {
-		block, err2 := a.app.GetBlockByID(blockID)
+		block, err2 := a.appFor(r).GetBlockByID(blockID)
 		if err2 != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err2)
 			return
@@ -3959,7 +5987,15 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 	auditRec.AddMeta("boardsCount", len(pbab.BoardIDs))
 	auditRec.AddMeta("blocksCount", len(pbab.BlockIDs))
 
-	bab, err := a.app.PatchBoardsAndBlocks(pbab, userID)
+	bab, err := a.appFor(r).PatchBoardsAndBlocks(pbab, userID)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if model.IsErrContentRejected(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -4028,7 +6064,7 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	for _, boardID := range dbab.Boards {
 		boardIDMap[boardID] = true
 		// all boards in the request should belong to the same team
-		board, err := a.app.GetBoard(boardID)
+		board, err := a.appFor(r).GetBoard(boardID)
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
@@ -4053,7 +6089,7 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	}
 
 	for _, blockID := range dbab.Blocks {
-		block, err2 := a.app.GetBlockByID(blockID)
+		block, err2 := a.appFor(r).GetBlockByID(blockID)
 		if err2 != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err2)
 			return
@@ -4084,7 +6120,11 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	auditRec.AddMeta("boardsCount", len(dbab.Boards))
 	auditRec.AddMeta("blocksCount", len(dbab.Blocks))
 
-	if err := a.app.DeleteBoardsAndBlocks(dbab, userID); err != nil {
+	if err := a.appFor(r).DeleteBoardsAndBlocks(dbab, userID); err != nil {
+		if model.IsErrBoardReadOnly(err) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+			return
+		}
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
@@ -4102,12 +6142,17 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 // Response helpers
 
 func (a *API) errorResponse(w http.ResponseWriter, api string, code int, message string, sourceError error) {
+	// the request ID header is set by requestIDHandler before any
+	// handler runs
+	requestID := w.Header().Get(HeaderRequestID)
+
 	if code == http.StatusUnauthorized || code == http.StatusForbidden {
 		a.logger.Debug("API DEBUG",
 			mlog.Int("code", code),
 			mlog.Err(sourceError),
 			mlog.String("msg", message),
 			mlog.String("api", api),
+			mlog.String("request_id", requestID),
 		)
 	} else {
 		a.logger.Error("API ERROR",
@@ -4115,11 +6160,25 @@ func (a *API) errorResponse(w http.ResponseWriter, api string, code int, message
 			mlog.Err(sourceError),
 			mlog.String("msg", message),
 			mlog.String("api", api),
+			mlog.String("request_id", requestID),
 		)
 	}
 
+	errCode, params := errorCodeFor(code, sourceError)
+	if message == "" {
+		if ce, ok := model.AsCodedError(sourceError); ok {
+			message = ce.Message
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	data, err := json.Marshal(model.ErrorResponse{Error: message, ErrorCode: code})
+	data, err := json.Marshal(model.ErrorResponse{
+		Error:     message,
+		ErrorCode: code,
+		Code:      errCode,
+		Params:    params,
+		RequestID: requestID,
+	})
 	if err != nil {
 		data = []byte("{}")
 	}
@@ -4127,6 +6186,24 @@ func (a *API) errorResponse(w http.ResponseWriter, api string, code int, message
 	_, _ = w.Write(data)
 }
 
+// errorCodeFor returns the machine readable code and parameters for an
+// error response, preferring the ones carried by the source error and
+// falling back to the generic code for the HTTP status.
+func errorCodeFor(status int, sourceError error) (string, map[string]interface{}) {
+	if ce, ok := model.AsCodedError(sourceError); ok {
+		return ce.Code, ce.Params
+	}
+
+	switch {
+	case errors.Is(sourceError, app.ErrInsufficientLicense):
+		return model.ErrCodeInsufficientLicense, nil
+	case errors.Is(sourceError, app.ErrorCategoryDeleted):
+		return model.ErrCodeCategoryNotFound, nil
+	}
+
+	return model.ErrorCodeForStatus(status), nil
+}
+
 func jsonStringResponse(w http.ResponseWriter, code int, message string) { //nolint:unparam
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
@@ -1,11 +1,16 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 
@@ -19,7 +24,9 @@ const (
 func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /boards/{boardID}/archive/export archiveExportBoard
 	//
-	// Exports an archive of all blocks for one boards.
+	// Exports an archive of all blocks for one boards, or its cards as a
+	// CSV or markdown table. The tables can be limited to the cards of a
+	// view or the cards that meet a filter.
 	//
 	// ---
 	// produces:
@@ -30,6 +37,26 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	//   description: Id of board to export
 	//   required: true
 	//   type: string
+	// - name: format
+	//   in: query
+	//   description: archive (default), csv or markdown
+	//   required: false
+	//   type: string
+	// - name: view_id
+	//   in: query
+	//   description: the view whose filter, order and visible properties the csv and markdown exports apply
+	//   required: false
+	//   type: string
+	// - name: filter
+	//   in: query
+	//   description: a JSON filter group, in the format of the view filters, the exported cards must meet
+	//   required: false
+	//   type: string
+	// - name: permissions
+	//   in: query
+	//   description: if true, the archive includes the permissions of the board, which requires the manage board roles permission
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -39,6 +66,10 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	//       application-octet-stream:
 	//         type: string
 	//         format: binary
+	//   '400':
+	//     description: invalid format or filter
+	//   '404':
+	//     description: board or view not found
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -57,19 +88,47 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("BoardID", boardID)
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.appFor(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 	if board == nil {
-		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
 		return
 	}
 
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = model.ExportFormatArchive
+	}
+	auditRec.AddMeta("format", format)
+
+	if model.IsTableExportFormat(format) {
+		a.exportBoardCards(w, r, board, format, auditRec)
+		return
+	}
+	if format != model.ExportFormatArchive {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid export format", nil)
+		return
+	}
+	if query.Get("view_id") != "" || query.Get("filter") != "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "only the csv and markdown exports can be filtered", nil)
+		return
+	}
+
+	includePermissions := query.Get("permissions") == "true"
+	if includePermissions && !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board permissions"})
+		return
+	}
+	auditRec.AddMeta("permissions", includePermissions)
+
 	opts := model.ExportArchiveOptions{
-		TeamID:   board.TeamID,
-		BoardIDs: []string{board.ID},
+		TeamID:             board.TeamID,
+		BoardIDs:           []string{board.ID},
+		IncludePermissions: includePermissions,
 	}
 
 	filename := fmt.Sprintf("archive-%s%s", time.Now().Format("2006-01-02"), archiveExtension)
@@ -77,10 +136,54 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
 	w.Header().Set("Content-Transfer-Encoding", "binary")
 
-	if err := a.app.ExportArchive(w, opts); err != nil {
+	if err := a.appFor(r).ExportArchive(w, opts); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+
+	auditRec.Success()
+}
+
+// exportBoardCards writes the cards of the board selected by the view and
+// filter of the request as a CSV or markdown table.
+func (a *API) exportBoardCards(w http.ResponseWriter, r *http.Request, board *model.Board, format string, auditRec *audit.Record) {
+	query := r.URL.Query()
+	opts := model.ExportCardsOptions{
+		BoardID: board.ID,
+		Format:  format,
+		ViewID:  query.Get("view_id"),
+	}
+	if filter := query.Get("filter"); filter != "" {
+		cardFilter, err := model.ParseCardFilter(filter)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
+		opts.Filter = cardFilter
+	}
+	auditRec.AddMeta("viewID", opts.ViewID)
+
+	// the table is written to a buffer, so errors can still be returned
+	var buf bytes.Buffer
+	err := a.appFor(r).ExportCards(&buf, opts)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
 	}
 
+	contentType, extension := "text/csv", ".csv"
+	if format == model.ExportFormatMarkdown {
+		contentType, extension = "text/markdown", ".md"
+	}
+	filename := fmt.Sprintf("cards-%s%s", time.Now().Format("2006-01-02"), extension)
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+
 	auditRec.Success()
 }
 
@@ -126,11 +229,12 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("TeamID", teamID)
 
-	boards, err := a.app.GetBoardsForUserAndTeam(userID, teamID)
+	boards, err := a.appFor(r).GetBoardsForUserAndTeam(userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
+	boards = a.filterOrganizationBoards(userID, boards)
 	ids := []string{}
 	for _, board := range boards {
 		ids = append(ids, board.ID)
@@ -146,7 +250,7 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
 	w.Header().Set("Content-Transfer-Encoding", "binary")
 
-	if err := a.app.ExportArchive(w, opts); err != nil {
+	if err := a.appFor(r).ExportArchive(w, opts); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 	}
 
@@ -174,11 +278,18 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 	//   description: archive file to import
 	//   required: true
 	//   type: file
+	// - name: permissions
+	//   in: query
+	//   description: if true, the permissions of the boards in the archive are applied to the imported boards
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
+	//   '400':
+	//     description: the archive version is not supported
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -191,7 +302,7 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	teamID := vars["teamID"]
 
-	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+	if !a.hasPermissionToCreateBoardsInTeam(userID, teamID) {
 		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create board"})
 		return
 	}
@@ -209,19 +320,145 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("size", handle.Size)
 
 	opt := model.ImportArchiveOptions{
-		TeamID:     teamID,
-		ModifiedBy: userID,
+		TeamID:           teamID,
+		ModifiedBy:       userID,
+		ApplyPermissions: r.URL.Query().Get("permissions") == "true",
 	}
+	auditRec.AddMeta("permissions", opt.ApplyPermissions)
 
-	if err := a.app.ImportArchive(file, opt); err != nil {
+	boardIDs, err := a.appFor(r).ImportArchiveBoards(file, opt)
+	if err != nil {
 		a.logger.Debug("Error importing archive",
 			mlog.String("team_id", teamID),
 			mlog.Err(err),
 		)
+		var errVersion model.ErrUnsupportedArchiveVersion
+		if errors.As(err, &errVersion) {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, errVersion.Error(), err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(model.ImportArchiveResult{BoardIDs: boardIDs})
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminArchiveCopyBoard(w http.ResponseWriter, r *http.Request) {
+	a.writeArchiveCopyBoard(w, r)
+}
+
+func (a *API) handleArchiveCopyBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/archive/copy archiveCopyBoard
+	//
+	// Copies a board with its files to a team of another server, by
+	// exporting it and importing the archive with the archive import API
+	// of the other server. The copy gets new IDs. Requires the manage
+	// system permission
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: ID of the board to copy
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the server and team to copy the board to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/RemoteBoardCopyRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/RemoteBoardCopyResult"
+	//   '400':
+	//     description: invalid request
+	//   '403':
+	//     description: access denied
+	//   '404':
+	//     description: board not found
+	//   '502':
+	//     description: the other server didn't accept the copy
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to copy boards to other servers"})
+		return
+	}
+
+	a.writeArchiveCopyBoard(w, r)
+}
+
+func (a *API) writeArchiveCopyBoard(w http.ResponseWriter, r *http.Request) {
+	boardID := mux.Vars(r)["boardID"]
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var request *model.RemoteBoardCopyRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil || request == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	if err = request.IsValid(); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "archiveCopyBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("serverURL", request.ServerURL)
+	auditRec.AddMeta("remoteTeamID", request.TeamID)
+
+	result, err := a.appFor(r).CopyBoardToRemote(boardID, request)
+	if errors.Is(err, app.ErrRemoteCopyFailed) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadGateway, err.Error(), err)
+		return
+	}
+	if _, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("ArchiveCopyBoard",
+		mlog.String("boardID", boardID),
+		mlog.String("remoteBoardID", result.BoardID),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonStringResponse(w, http.StatusOK, "{}")
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("remoteBoardID", result.BoardID)
 	auditRec.Success()
 }
@@ -3,6 +3,7 @@ package api
 import (
 	"net/http"
 
+	"github.com/gorilla/mux"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 )
@@ -26,8 +27,33 @@ func (a *API) makeAuditRecord(r *http.Request, event string, initialStatus strin
 		SessionID: sessionID,
 		Client:    r.UserAgent(),
 		IPAddress: r.RemoteAddr,
-		Meta:      []audit.Meta{{K: audit.KeyTeamID, V: teamID}},
+		Meta: []audit.Meta{
+			{K: audit.KeyTeamID, V: teamID},
+			{K: audit.KeyRequestID, V: getRequestID(r)},
+		},
+	}
+
+	// the most specific entity in the route is the object of the action
+	vars := mux.Vars(r)
+	for _, obj := range auditObjectVars {
+		if id, ok := vars[obj.key]; ok && id != "" {
+			rec.SetObject(obj.objectType, id)
+			break
+		}
 	}
 
 	return rec
 }
+
+var auditObjectVars = []struct {
+	key        string
+	objectType string
+}{
+	{"blockID", "block"},
+	{"categoryID", "category"},
+	{"boardID", "board"},
+	{"subscriberID", "subscriber"},
+	{"userID", "user"},
+	{"username", "user"},
+	{"teamID", "team"},
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/mattermost/focalboard/server/services/entitlements"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -87,7 +88,8 @@ type RegisterRequest struct {
 	// required: true
 	Password string `json:"password"`
 
-	// Registration authorization token
+	// Registration authorization token, either the signup token of the
+	// team or the token of an email invitation
 	// required: true
 	Token string `json:"token"`
 }
@@ -200,7 +202,7 @@ func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("type", loginData.Type)
 
 	if loginData.Type == "normal" {
-		token, err := a.app.Login(loginData.Username, loginData.Email, loginData.Password, loginData.MfaToken)
+		token, err := a.appFor(r).Login(loginData.Username, loginData.Email, loginData.Password, loginData.MfaToken)
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "incorrect login", err)
 			return
@@ -259,7 +261,7 @@ func (a *API) handleLogout(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 	auditRec.AddMeta("userID", session.UserID)
 
-	if err := a.app.Logout(session.ID); err != nil {
+	if err := a.appFor(r).Logout(session.ID); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "incorrect login", err)
 		return
 	}
@@ -325,20 +327,26 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	registerData.Username = strings.TrimSpace(registerData.Username)
 
 	// Validate token
+	var emailInvitationToken string
 	if len(registerData.Token) > 0 {
-		team, err2 := a.app.GetRootTeam()
+		team, err2 := a.appFor(r).GetRootTeam()
 		if err2 != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err2)
 			return
 		}
 
 		if registerData.Token != team.SignupToken {
-			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "invalid token", nil)
-			return
+			// email invitations carry their own signed token, only
+			// valid for the invited email address
+			if err2 = a.appFor(r).ValidateEmailInvitation(registerData.Token, registerData.Email); err2 != nil {
+				a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "invalid token", nil)
+				return
+			}
+			emailInvitationToken = registerData.Token
 		}
 	} else {
 		// No signup token, check if no active users
-		userCount, err2 := a.app.GetRegisteredUserCount()
+		userCount, err2 := a.appFor(r).GetRegisteredUserCount()
 		if err2 != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err2)
 			return
@@ -358,7 +366,11 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 	auditRec.AddMeta("username", registerData.Username)
 
-	err = a.app.RegisterUser(registerData.Username, registerData.Email, registerData.Password)
+	if emailInvitationToken != "" {
+		err = a.appFor(r).RegisterUserWithEmailInvitation(registerData.Username, registerData.Email, registerData.Password, emailInvitationToken)
+	} else {
+		err = a.appFor(r).RegisterUser(registerData.Username, registerData.Email, registerData.Password)
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
 		return
@@ -439,7 +451,7 @@ func (a *API) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "changePassword", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 
-	if err = a.app.ChangePassword(userID, requestData.OldPassword, requestData.NewPassword); err != nil {
+	if err = a.appFor(r).ChangePassword(userID, requestData.OldPassword, requestData.NewPassword); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
 		return
 	}
@@ -491,13 +503,13 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 				UpdateAt:    now,
 			}
 
-			user, err := a.app.GetUser(userID)
+			user, err := a.appFor(r).GetUser(userID)
 			if err != nil {
 				a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", err)
 				return
 			}
 
-			if user.IsGuest {
+			if user.IsGuest && !a.appFor(r).IsFeatureEnabled(entitlements.FeatureGuestAccounts) {
 				a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "guests not supported", nil)
 				return
 			}
@@ -507,7 +519,7 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
-		session, err := a.app.GetSession(token)
+		session, err := a.appFor(r).GetSession(token)
 		if err != nil {
 			if required {
 				a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", err)
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleAcquireBlockLock(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/blocks/{blockID}/lock acquireBlockLock
+	//
+	// Locks a block while the user edits it, so that the other users are
+	// warned before overwriting their changes. The lock is advisory and
+	// expires unless it is renewed by acquiring it again
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of the block to lock
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the time to hold the lock for
+	//   required: false
+	//   schema:
+	//     "$ref": "#/definitions/BlockLockRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BlockLock"
+	//   '400':
+	//     description: invalid ttl
+	//   '404':
+	//     description: block not found
+	//   '423':
+	//     description: the block is locked by another user
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	blockID := vars["blockID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	if !a.checkBlockInBoard(w, r, boardID, blockID) {
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	request := &model.BlockLockRequest{}
+	if len(requestBody) > 0 {
+		if err = json.Unmarshal(requestBody, request); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
+	}
+
+	lock, err := a.appFor(r).AcquireBlockLock(blockID, request.TTL, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("AcquireBlockLock",
+		mlog.String("boardID", boardID),
+		mlog.String("blockID", blockID),
+		mlog.Int64("expiresAt", lock.ExpiresAt),
+	)
+
+	data, err := json.Marshal(lock)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleReleaseBlockLock(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/blocks/{blockID}/lock releaseBlockLock
+	//
+	// Releases the lock the user holds on a block
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of the locked block
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: block not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	blockID := vars["blockID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	if !a.checkBlockInBoard(w, r, boardID, blockID) {
+		return
+	}
+
+	if err := a.appFor(r).ReleaseBlockLock(blockID, userID); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("ReleaseBlockLock",
+		mlog.String("boardID", boardID),
+		mlog.String("blockID", blockID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+}
+
+func (a *API) handleGetBlockLocks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/locks getBlockLocks
+	//
+	// Returns the active edit locks of the blocks of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BlockLock"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	locks, err := a.appFor(r).GetBlockLocks(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetBlockLocks",
+		mlog.String("boardID", boardID),
+		mlog.Int("lockCount", len(locks)),
+	)
+
+	data, err := json.Marshal(locks)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+// checkBlockInBoard writes a not found response and returns false if the
+// block doesn't exist or isn't in the board.
+func (a *API) checkBlockInBoard(w http.ResponseWriter, r *http.Request, boardID, blockID string) bool {
+	block, err := a.appFor(r).GetBlockByID(blockID)
+	if err != nil && !store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return false
+	}
+	if block == nil || block.BoardID != boardID {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(blockID))
+		return false
+	}
+	return true
+}
+
+// checkBlockLocks returns a block locked error if one of the blocks is
+// locked by another user. The locks are advisory, so the check is
+// skipped when the request is forced.
+func (a *API) checkBlockLocks(r *http.Request, userID string, blockIDs ...string) error {
+	if r.URL.Query().Get("force") == "true" {
+		return nil
+	}
+	for _, blockID := range blockIDs {
+		if err := a.appFor(r).CheckBlockLock(blockID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,345 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleRequestBoardAccess(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/access-requests requestBoardAccess
+	//
+	// Requests access to a private board the user can't open. The admins
+	// of the board are notified, and the user becomes a member of the
+	// board once one of them approves the request
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the message to the admins of the board
+	//   required: false
+	//   schema:
+	//     "$ref": "#/definitions/BoardAccessRequestBody"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardAccessRequest"
+	//   '400':
+	//     description: the board is not private or the user is already a member
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	board, err := a.appFor(r).GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if board == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
+		return
+	}
+
+	// the access can be requested from the team of the board or from any
+	// of the teams it is shared with
+	teamIDs, err := a.appFor(r).GetBoardTeamIDs(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	canRequest := false
+	for _, teamID := range teamIDs {
+		if a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+			canRequest = true
+			break
+		}
+	}
+	if !canRequest {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var body model.BoardAccessRequestBody
+	if len(requestBody) != 0 {
+		if err = json.Unmarshal(requestBody, &body); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "requestBoardAccess", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	request, err := a.appFor(r).RequestBoardAccess(boardID, userID, body.Message)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("RequestBoardAccess",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardAccessRequests(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/access-requests getBoardAccessRequests
+	//
+	// Returns the pending access requests of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardAccessRequest"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board access requests"})
+		return
+	}
+
+	requests, err := a.appFor(r).GetAccessRequestsForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetBoardAccessRequests",
+		mlog.String("boardID", boardID),
+		mlog.Int("request_count", len(requests)),
+	)
+
+	data, err := json.Marshal(requests)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleApproveBoardAccessRequest(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/access-requests/{userID}/approve approveBoardAccessRequest
+	//
+	// Approves the access request of a user, who becomes a member of the
+	// board with the role of the approval, or the default role of the
+	// board members
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: userID
+	//   in: path
+	//   description: ID of the user that requested the access
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the role to grant
+	//   required: false
+	//   schema:
+	//     "$ref": "#/definitions/BoardAccessApproval"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardMember"
+	//   '404':
+	//     description: board or access request not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	requesterID := vars["userID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modify board members"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var approval *model.BoardAccessApproval
+	if len(requestBody) != 0 {
+		if err = json.Unmarshal(requestBody, &approval); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "approveBoardAccessRequest", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("requesterID", requesterID)
+
+	member, err := a.appFor(r).ApproveBoardAccessRequest(boardID, requesterID, approval)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("ApproveBoardAccessRequest",
+		mlog.String("boardID", boardID),
+		mlog.String("requesterID", requesterID),
+	)
+
+	data, err := json.Marshal(member)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDenyBoardAccessRequest(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/access-requests/{userID}/deny denyBoardAccessRequest
+	//
+	// Denies the access request of a user, without creating a membership
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: userID
+	//   in: path
+	//   description: ID of the user that requested the access
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: access request not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	requesterID := vars["userID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modify board members"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "denyBoardAccessRequest", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("requesterID", requesterID)
+
+	err := a.appFor(r).DenyBoardAccessRequest(boardID, requesterID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("DenyBoardAccessRequest",
+		mlog.String("boardID", boardID),
+		mlog.String("requesterID", requesterID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetBoardFreezeSchedule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/freeze-schedule getBoardFreezeSchedule
+	//
+	// Returns the times at which the board is scheduled to be frozen,
+	// made read-only, and unfrozen
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardFreezeSchedule"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board freeze schedule"})
+		return
+	}
+
+	schedule, err := a.appFor(r).GetBoardFreezeSchedule(boardID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetBoardFreezeSchedule",
+		mlog.String("boardID", boardID),
+	)
+
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleSetBoardFreezeSchedule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/freeze-schedule setBoardFreezeSchedule
+	//
+	// Schedules the freeze and unfreeze of a board, e.g. at the end of a
+	// sprint. The members of the board are notified when they take
+	// effect. A schedule without times cancels them
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the times of the freeze and unfreeze
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardFreezeSchedule"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardFreezeSchedule"
+	//   '400':
+	//     description: invalid schedule
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to schedule the board freeze"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var schedule *model.BoardFreezeSchedule
+	if err = json.Unmarshal(requestBody, &schedule); err != nil || schedule == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setBoardFreezeSchedule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("freezeAt", schedule.FreezeAt)
+	auditRec.AddMeta("unfreezeAt", schedule.UnfreezeAt)
+
+	schedule, err = a.appFor(r).SetBoardFreezeSchedule(boardID, schedule, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("SetBoardFreezeSchedule",
+		mlog.String("boardID", boardID),
+		mlog.Int64("freezeAt", schedule.FreezeAt),
+		mlog.Int64("unfreezeAt", schedule.UnfreezeAt),
+	)
+
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
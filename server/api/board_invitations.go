@@ -0,0 +1,452 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleCreateBoardInvitation(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/invitations createBoardInvitation
+	//
+	// Invites a user to a board. The user becomes a member of the board
+	// once the invitation is accepted
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the user to invite and the roles to grant
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardInvitationRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardInvitation"
+	//   '404':
+	//     description: board or user not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to invite board members"})
+		return
+	}
+
+	request, err := model.BoardInvitationRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	if err = request.IsValid(); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createBoardInvitation", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	invitation, err := a.appFor(r).InviteToBoard(boardID, userID, request)
+	if errors.Is(err, app.ErrInvitedUserNotFound) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if errors.Is(err, app.ErrInvitedUserAlreadyMember) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if _, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("CreateBoardInvitation",
+		mlog.String("boardID", boardID),
+		mlog.String("invitedUserID", invitation.UserID),
+	)
+
+	data, err := json.Marshal(invitation)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("invitedUserID", invitation.UserID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardInvitations(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/invitations getBoardInvitations
+	//
+	// Returns the pending invitations of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardInvitation"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board invitations"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardInvitations", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	invitations, err := a.appFor(r).GetInvitationsForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(invitations)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("invitationCount", len(invitations))
+	auditRec.Success()
+}
+
+func (a *API) handleCancelBoardInvitation(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/invitations/{userID} cancelBoardInvitation
+	//
+	// Cancels the pending invitation of a user to a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: userID
+	//   in: path
+	//   description: ID of the invited user
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: invitation not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	invitedUserID := mux.Vars(r)["userID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to cancel board invitations"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "cancelBoardInvitation", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("invitedUserID", invitedUserID)
+
+	if !a.writeBoardInvitationResponse(w, r, a.appFor(r).CancelBoardInvitation(boardID, invitedUserID)) {
+		return
+	}
+	auditRec.Success()
+}
+
+func (a *API) handleAcceptBoardInvitation(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/invitations/accept acceptBoardInvitation
+	//
+	// Accepts the pending invitation of the current user to a board,
+	// making the user a member of the board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardMember"
+	//   '404':
+	//     description: invitation not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	auditRec := a.makeAuditRecord(r, "acceptBoardInvitation", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	member, err := a.appFor(r).AcceptBoardInvitation(boardID, userID)
+	if errors.Is(err, app.ErrBoardInvitationNotFound) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("AcceptBoardInvitation",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(member)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeclineBoardInvitation(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/invitations/decline declineBoardInvitation
+	//
+	// Declines the pending invitation of the current user to a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: invitation not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	auditRec := a.makeAuditRecord(r, "declineBoardInvitation", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if !a.writeBoardInvitationResponse(w, r, a.appFor(r).DeclineBoardInvitation(boardID, userID)) {
+		return
+	}
+	auditRec.Success()
+}
+
+// writeBoardInvitationResponse writes the response of the requests that
+// close an invitation, and returns whether they succeeded.
+func (a *API) writeBoardInvitationResponse(w http.ResponseWriter, r *http.Request, err error) bool {
+	if errors.Is(err, app.ErrBoardInvitationNotFound) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return false
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return false
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	return true
+}
+
+func (a *API) handleGetMyInvitations(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/invitations getMyInvitations
+	//
+	// Returns the pending board invitations of the current user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardInvitation"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	auditRec := a.makeAuditRecord(r, "getMyInvitations", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("userID", userID)
+
+	invitations, err := a.appFor(r).GetInvitationsForUser(userID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(invitations)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSendEmailInvitation(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /invitations/email sendEmailInvitation
+	//
+	// Emails a signup link to someone without an account. If a board is
+	// set, the account created with the link becomes a member of the
+	// board. Only available on the standalone server
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: the email to invite and the board to add the user to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/EmailInvitationRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board not found
+	//   '501':
+	//     description: email invitations are not available
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "not permitted in plugin mode", nil)
+		return
+	}
+
+	userID := getUserID(r)
+
+	request, err := model.EmailInvitationRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	if err = request.IsValid(); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	if request.BoardID != "" && !a.permissions.HasPermissionToBoard(userID, request.BoardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to invite board members"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "sendEmailInvitation", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", request.BoardID)
+
+	err = a.appFor(r).SendEmailInvitation(userID, request)
+	if errors.Is(err, app.ErrEmailNotConfigured) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, err.Error(), err)
+		return
+	}
+	if _, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetBoardPermissions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/permissions getBoardPermissions
+	//
+	// Returns the access configuration of a board, its type, default
+	// member role and the roles of its members, to apply it to other
+	// boards
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardPermissions"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board permissions"})
+		return
+	}
+
+	permissions, err := a.appFor(r).GetBoardPermissions(boardID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetBoardPermissions",
+		mlog.String("boardID", boardID),
+		mlog.Int("member_count", len(permissions.Members)),
+	)
+
+	data, err := json.Marshal(permissions)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleApplyBoardPermissions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/permissions applyBoardPermissions
+	//
+	// Applies the access configuration exported from a board to this
+	// board: its type, default member role and the roles of the members.
+	// The members whose user doesn't exist are skipped
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: replace
+	//   in: query
+	//   description: If true, the members of the board missing from the configuration are removed
+	//   required: false
+	//   type: boolean
+	// - name: Body
+	//   in: body
+	//   description: the access configuration to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardPermissions"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, with the members of the configuration
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardMember"
+	//   '400':
+	//     description: invalid configuration
+	//   '404':
+	//     description: board not found
+	//   '501':
+	//     description: the configuration has custom roles, which the license doesn't allow
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+	replace := r.URL.Query().Get("replace") == "true"
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modify board members"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var permissions *model.BoardPermissions
+	if err = json.Unmarshal(requestBody, &permissions); err != nil || permissions == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "applyBoardPermissions", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("replace", replace)
+	auditRec.AddMeta("memberCount", len(permissions.Members))
+
+	members, err := a.appFor(r).ApplyBoardPermissions(boardID, permissions, replace, userID)
+	if errors.Is(err, app.ErrInsufficientLicense) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "", err)
+		return
+	}
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("ApplyBoardPermissions",
+		mlog.String("boardID", boardID),
+		mlog.Bool("replace", replace),
+		mlog.Int("member_count", len(members)),
+	)
+
+	data, err := json.Marshal(members)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
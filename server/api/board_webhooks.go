@@ -0,0 +1,297 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+// The board webhooks are outgoing webhooks configured by the board
+// admins. The changes of the blocks of the board are posted to them,
+// signed with their secret, for the events they subscribe to.
+
+func (a *API) handleGetBoardWebhooks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/webhooks getBoardWebhooks
+	//
+	// Returns the outgoing webhooks of a board. Their secrets are not
+	// returned
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board webhooks"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardWebhooks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	webhooks, err := a.appFor(r).GetBoardWebhooks(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(webhooks)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateBoardWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/webhooks createBoardWebhook
+	//
+	// Adds an outgoing webhook to a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the webhook to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardWebhook"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardWebhook"
+	//   '400':
+	//     description: invalid webhook
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board webhooks"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var webhook model.BoardWebhook
+	if err = json.Unmarshal(requestBody, &webhook); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	webhook.BoardID = boardID
+	webhook.CreatedBy = userID
+
+	auditRec := a.makeAuditRecord(r, "createBoardWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	created, err := a.appFor(r).CreateBoardWebhook(&webhook)
+	if err != nil {
+		a.boardWebhookErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(created)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("webhookID", created.ID)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchBoardWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/webhooks/{webhookID} patchBoardWebhook
+	//
+	// Modifies an outgoing webhook of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the webhook patch
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardWebhookPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardWebhook"
+	//   '400':
+	//     description: invalid webhook
+	//   '404':
+	//     description: webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board webhooks"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var patch model.BoardWebhookPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchBoardWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	patched, err := a.appFor(r).PatchBoardWebhook(boardID, webhookID, &patch)
+	if err != nil {
+		a.boardWebhookErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(patched)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBoardWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/webhooks/{webhookID} deleteBoardWebhook
+	//
+	// Removes an outgoing webhook of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board webhooks"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBoardWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	if err := a.appFor(r).DeleteBoardWebhook(boardID, webhookID); err != nil {
+		a.boardWebhookErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) boardWebhookErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+}
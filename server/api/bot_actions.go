@@ -0,0 +1,376 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// The bot action API is a small set of endpoints for chatops and scripts,
+// authenticated with the token of a bot or a personal access token. The
+// plugin rejects the requests made with other kinds of sessions.
+
+func (a *API) handleBotCreateCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /bot/boards/{boardID}/cards botCreateCard
+	//
+	// Creates a card on a board. Only available when running as a plugin,
+	// with a bot or personal access token
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: X-Integration-Name
+	//   in: header
+	//   description: name of the integration making the request, recorded in the audit log
+	//   required: false
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the card to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BotCreateCardRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid request
+	//   '404':
+	//     description: board not found
+	//   '501':
+	//     description: not available in standalone mode
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if !a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "not permitted in standalone mode", nil)
+		return
+	}
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	request, err := model.BotCreateCardRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if request.Title == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "missing card title", nil)
+		return
+	}
+
+	board, err := a.appFor(r).GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if board == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "botCreateCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	addIntegrationMeta(auditRec, r)
+	auditRec.AddMeta("boardID", boardID)
+
+	// validate the properties before creating the card, so invalid
+	// requests don't leave a card behind
+	if len(request.Properties) > 0 {
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		if _, err := schema.ResolvePropertyValues(request.Properties); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
+	}
+
+	card, err := a.appFor(r).CreateCard(board.ID, request.Title, request.Description, userID)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if model.IsErrContentRejected(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	if len(request.Properties) > 0 {
+		card, err = a.appFor(r).SetCardProperties(card.ID, request.Properties, userID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+	}
+
+	a.logger.Debug("BotCreateCard",
+		mlog.String("boardID", boardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleBotAddComment(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /bot/cards/{cardID}/comments botAddComment
+	//
+	// Adds a comment to a card. Only available when running as a plugin,
+	// with a bot or personal access token
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: X-Integration-Name
+	//   in: header
+	//   description: name of the integration making the request, recorded in the audit log
+	//   required: false
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the comment to add
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BotCommentRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid request
+	//   '404':
+	//     description: card not found
+	//   '501':
+	//     description: not available in standalone mode
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if !a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "not permitted in standalone mode", nil)
+		return
+	}
+
+	card, ok := a.getCardForBotAction(w, r)
+	if !ok {
+		return
+	}
+	userID := getUserID(r)
+
+	request, err := model.BotCommentRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if request.Text == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "missing comment text", nil)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "botAddComment", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	addIntegrationMeta(auditRec, r)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	comment, err := a.appFor(r).AddComment(card.ID, request.Text, userID)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if model.IsErrContentRejected(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(comment)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("commentID", comment.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleBotSetCardProperties(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /bot/cards/{cardID}/properties botSetCardProperties
+	//
+	// Sets property values of a card. Only available when running as a
+	// plugin, with a bot or personal access token
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: X-Integration-Name
+	//   in: header
+	//   description: name of the integration making the request, recorded in the audit log
+	//   required: false
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the property values to set
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BotSetPropertiesRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: unknown property or option
+	//   '404':
+	//     description: card not found
+	//   '501':
+	//     description: not available in standalone mode
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if !a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "not permitted in standalone mode", nil)
+		return
+	}
+
+	card, ok := a.getCardForBotAction(w, r)
+	if !ok {
+		return
+	}
+	userID := getUserID(r)
+
+	request, err := model.BotSetPropertiesRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if len(request.Properties) == 0 {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "missing properties", nil)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "botSetCardProperties", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	addIntegrationMeta(auditRec, r)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	updated, err := a.appFor(r).SetCardProperties(card.ID, request.Properties, userID)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if errors.Is(err, model.ErrInvalidProperty) || errors.Is(err, model.ErrInvalidPropertyValue) || model.IsErrBadRequest(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(updated)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+// getCardForBotAction returns the card of the request, writing the error
+// response if it doesn't exist or the user cannot change it.
+func (a *API) getCardForBotAction(w http.ResponseWriter, r *http.Request) (*model.Block, bool) {
+	cardID := mux.Vars(r)["cardID"]
+	userID := getUserID(r)
+
+	card, err := a.appFor(r).GetBlockByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return nil, false
+	}
+	if card == nil || card.Type != model.TypeCard {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(cardID))
+		return nil, false
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return nil, false
+	}
+	return card, true
+}
+
+// addIntegrationMeta records the integration acting through the bot.
+func addIntegrationMeta(auditRec *audit.Record, r *http.Request) {
+	if name := r.Header.Get(model.HeaderIntegrationName); name != "" {
+		auditRec.AddMeta("integration", name)
+	}
+}
@@ -0,0 +1,314 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleImportCalendar(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/calendar/import importCalendar
+	//
+	// Imports the events of an iCalendar (.ics) file as cards of the
+	// board, with their dates in a date property. The cards of the events
+	// imported before are updated
+	//
+	// ---
+	// produces:
+	// - application/json
+	// consumes:
+	// - multipart/form-data
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: file
+	//   in: formData
+	//   description: the .ics file to import
+	//   required: true
+	//   type: file
+	// - name: dateProperty
+	//   in: query
+	//   description: Date property, by ID or name, the events are imported in; the first date property of the board by default
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CalendarImportResult"
+	//   '400':
+	//     description: invalid calendar or date property
+	//   '404':
+	//     description: board not found
+	//   '413':
+	//     description: calendar too large
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+	dateProperty := r.URL.Query().Get("dateProperty")
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, model.MaxCalendarFileSize+1024*1024)
+	file, handle, err := r.FormFile(UploadFormFileKey)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "http: request body too large") {
+			a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, "", err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	defer file.Close()
+
+	auditRec := a.makeAuditRecord(r, "importCalendar", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("filename", handle.Filename)
+	auditRec.AddMeta("size", handle.Size)
+
+	result, err := a.appFor(r).ImportCalendar(boardID, file, dateProperty, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("ImportCalendar",
+		mlog.String("boardID", boardID),
+		mlog.Int("created", result.Created),
+		mlog.Int("updated", result.Updated),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("created", result.Created)
+	auditRec.AddMeta("updated", result.Updated)
+	auditRec.Success()
+}
+
+func (a *API) handleGetCalendarSubscription(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/calendar/subscription getCalendarSubscription
+	//
+	// Returns the calendar the board is subscribed to, with the result of
+	// its last import
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CalendarSubscription"
+	//   '404':
+	//     description: the board has no calendar subscription
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board calendar subscription"})
+		return
+	}
+
+	subscription, err := a.appFor(r).GetCalendarSubscription(boardID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetCalendarSubscription",
+		mlog.String("boardID", boardID),
+	)
+
+	data, err := json.Marshal(subscription)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleSetCalendarSubscription(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/calendar/subscription setCalendarSubscription
+	//
+	// Subscribes the board to a calendar, whose events are then imported
+	// periodically as cards of the board. Replaces the previous
+	// subscription of the board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the URL of the calendar and the date property its events are imported in
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CalendarSubscription"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CalendarSubscription"
+	//   '400':
+	//     description: invalid URL or date property
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board calendar subscription"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var subscription *model.CalendarSubscription
+	if err = json.Unmarshal(requestBody, &subscription); err != nil || subscription == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setCalendarSubscription", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("url", subscription.URL)
+
+	subscription, err = a.appFor(r).SetCalendarSubscription(boardID, subscription, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("SetCalendarSubscription",
+		mlog.String("boardID", boardID),
+	)
+
+	data, err := json.Marshal(subscription)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCalendarSubscription(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/calendar/subscription deleteCalendarSubscription
+	//
+	// Unsubscribes the board from its calendar. The cards imported from
+	// the calendar are kept
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board calendar subscription"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCalendarSubscription", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.appFor(r).DeleteCalendarSubscription(boardID); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("DeleteCalendarSubscription",
+		mlog.String("boardID", boardID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetBoardKey(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/key getBoardKey
+	//
+	// Returns the key the cards of a board are referenced with, as in
+	// PROJ-123. The key is empty if the board has none
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardKeyRequest"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	key, err := a.appFor(r).GetBoardKey(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(model.BoardKeyRequest{Key: key})
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleSetBoardKey(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/key setBoardKey
+	//
+	// Sets the key the cards of a board are referenced with, as in
+	// PROJ-123. The cards of the board are numbered in the order they
+	// were created, and keep their numbers if the key changes
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the key, 2 to 10 letters and digits
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardKeyRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '400':
+	//     description: invalid key
+	//   '409':
+	//     description: the key is used by another board
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modify board properties"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var request model.BoardKeyRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setBoardKey", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("key", request.Key)
+
+	if err = a.appFor(r).SetBoardKey(boardID, request.Key); err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("SetBoardKey", mlog.String("boardID", boardID), mlog.String("key", request.Key))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardPermalink(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardKey}/cards/{number} getCardPermalink
+	//
+	// Resolves a card reference, such as PROJ-123, to the card and its
+	// canonical URL
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardKey
+	//   in: path
+	//   description: Board key
+	//   required: true
+	//   type: string
+	// - name: number
+	//   in: path
+	//   description: Card number
+	//   required: true
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardPermalink"
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardKey := vars["boardKey"]
+	userID := getUserID(r)
+
+	number, err := strconv.ParseInt(vars["number"], 10, 64)
+	if err != nil || number < 1 {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid card number", nil)
+		return
+	}
+
+	permalink, err := a.appFor(r).GetCardPermalink(boardKey, number)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, permalink.Card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	data, err := json.Marshal(permalink)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
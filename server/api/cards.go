@@ -0,0 +1,667 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/cards getCards
+	//
+	// Returns the cards of a board whose card property takes one of the
+	// values. The property is indexed the first time the cards of the
+	// board are filtered by it
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: property_id
+	//   in: query
+	//   description: ID of the card property to filter by
+	//   required: true
+	//   type: string
+	// - name: value
+	//   in: query
+	//   description: Value of the card property, repeat it to match any of several values
+	//   required: true
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid property
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+	query := r.URL.Query()
+	propertyID := query.Get("property_id")
+	values := query["value"]
+
+	if propertyID == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "property_id is required", nil)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", propertyID)
+
+	cards, err := a.appFor(r).GetCardsWithPropertyValues(boardID, propertyID, values)
+	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetCards",
+		mlog.String("boardID", boardID),
+		mlog.String("propertyID", propertyID),
+		mlog.Int("card_count", len(cards)),
+	)
+
+	data, err := json.Marshal(cards)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("cardCount", len(cards))
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardGroups(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/card-groups getCardGroups
+	//
+	// Returns the number of cards of a board for each value of a select
+	// or person property, as the lanes of a kanban view group them, with
+	// the optional sum of a number property
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: group_by
+	//   in: query
+	//   description: ID of the select or person property to group the cards by, required without view_id
+	//   required: false
+	//   type: string
+	// - name: sum
+	//   in: query
+	//   description: ID of the number property to sum over each group
+	//   required: false
+	//   type: string
+	// - name: view_id
+	//   in: query
+	//   description: ID of the view whose WIP limits the groups are checked against, and whose grouping property is used without group_by
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardGroup"
+	//   '400':
+	//     description: invalid property
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+	query := r.URL.Query()
+	groupBy := query.Get("group_by")
+	sum := query.Get("sum")
+	viewID := query.Get("view_id")
+
+	if groupBy == "" && viewID == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "group_by or view_id is required", nil)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	groups, err := a.appFor(r).GetCardGroups(boardID, groupBy, sum, viewID)
+	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetCardGroups",
+		mlog.String("boardID", boardID),
+		mlog.String("groupBy", groupBy),
+		mlog.String("viewID", viewID),
+		mlog.Int("group_count", len(groups)),
+	)
+
+	data, err := json.Marshal(groups)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleGetSwimlanes(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/swimlanes getSwimlanes
+	//
+	// Returns the cards of a board grouped by two select or person
+	// properties at once, as the rows and columns of a swimlane board view,
+	// with the IDs and the number of cards of each cell
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: row_by
+	//   in: query
+	//   description: ID of the select or person property of the rows
+	//   required: true
+	//   type: string
+	// - name: column_by
+	//   in: query
+	//   description: ID of the select or person property of the columns
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/SwimlaneMatrix"
+	//   '400':
+	//     description: invalid property
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+	query := r.URL.Query()
+	rowBy := query.Get("row_by")
+	columnBy := query.Get("column_by")
+
+	if rowBy == "" || columnBy == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "row_by and column_by are required", nil)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	matrix, err := a.appFor(r).GetSwimlanes(boardID, rowBy, columnBy)
+	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetSwimlanes",
+		mlog.String("boardID", boardID),
+		mlog.String("rowBy", rowBy),
+		mlog.String("columnBy", columnBy),
+		mlog.Int("row_count", len(matrix.Rows)),
+		mlog.Int("column_count", len(matrix.Columns)),
+	)
+
+	data, err := json.Marshal(matrix)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleGetStaleCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/stale-cards getStaleCards
+	//
+	// Returns the cards of a board that weren't updated for a number of
+	// days, the least recently updated first, to help groom the board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: days
+	//   in: query
+	//   description: Number of days without updates after which a card is stale, 14 by default
+	//   required: false
+	//   type: integer
+	// - name: exclude
+	//   in: query
+	//   description: Property value, as property:value, of the cards to leave out, like Status:Done. The exclusions stored in the board properties apply if not set
+	//   required: false
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/StaleCard"
+	//   '400':
+	//     description: invalid days or exclusion
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+	query := r.URL.Query()
+
+	staleQuery := model.StaleCardsQuery{
+		Days:    model.DefaultStaleCardDays,
+		Exclude: query["exclude"],
+	}
+	if days := query.Get("days"); days != "" {
+		var err error
+		if staleQuery.Days, err = strconv.Atoi(days); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid days", err)
+			return
+		}
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	cards, err := a.appFor(r).GetStaleCards(boardID, staleQuery)
+	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetStaleCards",
+		mlog.String("boardID", boardID),
+		mlog.Int("days", staleQuery.Days),
+		mlog.Int("card_count", len(cards)),
+	)
+
+	data, err := json.Marshal(cards)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleGetBoardSnapshots(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/snapshots getBoardSnapshots
+	//
+	// Returns the daily snapshots of the number of cards of a board by
+	// status, to show their trend over time as a cumulative flow diagram.
+	// The boards without a status property have no snapshots
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: from
+	//   in: query
+	//   description: First day of the snapshots as YYYY-MM-DD in UTC, 30 days before the last one by default
+	//   required: false
+	//   type: string
+	// - name: to
+	//   in: query
+	//   description: Last day of the snapshots as YYYY-MM-DD in UTC, today by default
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardSnapshot"
+	//   '400':
+	//     description: invalid days
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+	query := r.URL.Query()
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	snapshotsQuery := model.NewBoardSnapshotsQuery(query.Get("from"), query.Get("to"), time.Now())
+	snapshots, err := a.appFor(r).GetBoardSnapshots(boardID, snapshotsQuery)
+	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetBoardSnapshots",
+		mlog.String("boardID", boardID),
+		mlog.String("from", snapshotsQuery.From),
+		mlog.String("to", snapshotsQuery.To),
+		mlog.Int("snapshot_count", len(snapshots)),
+	)
+
+	data, err := json.Marshal(snapshots)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleGetViewCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/views/{viewID}/cards getViewCards
+	//
+	// Returns a window of the cards of a board in the order of a view,
+	// to load the cards of large boards as they are scrolled into view,
+	// with how long they have been in their current status. The card
+	// templates are left out, and the view filters are not applied
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: viewID
+	//   in: path
+	//   description: View ID
+	//   required: true
+	//   type: string
+	// - name: offset
+	//   in: query
+	//   description: Position of the first card to return, defaults to 0
+	//   required: false
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: Number of cards to return, defaults to 100, at most 1000
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ViewCards"
+	//   '400':
+	//     description: invalid offset or limit
+	//   '404':
+	//     description: board or view not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	viewID := vars["viewID"]
+	userID := getUserID(r)
+	query := r.URL.Query()
+
+	offset := 0
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		var err error
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid offset", err)
+			return
+		}
+	}
+
+	limit := model.DefaultViewCardsLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid limit", err)
+			return
+		}
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	viewCards, err := a.appFor(r).GetViewCards(boardID, viewID, offset, limit)
+	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), "", err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetViewCards",
+		mlog.String("boardID", boardID),
+		mlog.String("viewID", viewID),
+		mlog.Int("offset", offset),
+		mlog.Int("card_count", len(viewCards.Cards)),
+	)
+
+	data, err := json.Marshal(viewCards)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleCreateCardsInBulk(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/bulk createCardsInBulk
+	//
+	// Creates several cards of a board, with their properties and content
+	// blocks, at once. Either all the cards are created or none of them
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the cards to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BulkCardsRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, the created cards, each followed by its content blocks
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid request
+	//   '403':
+	//     description: access denied
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	request, err := model.BulkCardsRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	board, err := a.appFor(r).GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if board == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(boardID))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCardsInBulk", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardCount", len(request.Cards))
+
+	blocks, err := a.appFor(r).CreateCardsInBulk(boardID, request, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if model.IsErrContentRejected(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("CreateCardsInBulk",
+		mlog.String("boardID", boardID),
+		mlog.Int("cardCount", len(request.Cards)),
+		mlog.Int("blockCount", len(blocks)),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("blockCount", len(blocks))
+	auditRec.Success()
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleGetCategoryRules(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/category-rules getCategoryRules
+	//
+	// Returns the user's rules moving the new boards into their
+	// categories, in the order they are applied
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CategoryRule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	teamID := mux.Vars(r)["teamID"]
+
+	rules, err := a.appFor(r).GetCategoryRules(session.UserID, teamID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleCreateCategoryRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/category-rules createCategoryRule
+	//
+	// Creates a rule moving the boards whose title matches a pattern into
+	// one of the user's categories, when they are created or shared with
+	// the user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the rule to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CategoryRule"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CategoryRule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var rule model.CategoryRule
+	if err = json.Unmarshal(requestBody, &rule); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCategoryRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	// the rules always belong to the user creating them
+	rule.UserID = session.UserID
+	rule.TeamID = mux.Vars(r)["teamID"]
+
+	createdRule, err := a.appFor(r).CreateCategoryRule(&rule)
+	if err != nil {
+		a.categoryBoardsErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(createdRule)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("categoryID", createdRule.CategoryID)
+	auditRec.AddMeta("ruleID", createdRule.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCategoryRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /teams/{teamID}/category-rules/{ruleID} deleteCategoryRule
+	//
+	// Deletes one of the user's category rules
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: ruleID
+	//   in: path
+	//   description: Rule ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: rule not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	ruleID := mux.Vars(r)["ruleID"]
+
+	auditRec := a.makeAuditRecord(r, "deleteCategoryRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("ruleID", ruleID)
+
+	if err := a.appFor(r).DeleteCategoryRule(ruleID, session.UserID); err != nil {
+		a.categoryBoardsErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/changestream"
+	"github.com/mattermost/focalboard/server/services/permissions"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	HeaderLastEventID = "Last-Event-ID"
+
+	// changesKeepAliveInterval is how often a comment is sent on idle
+	// streams, so proxies don't close them.
+	changesKeepAliveInterval = 30 * time.Second
+
+	// ChangesEventReset is sent when a stream can't be resumed.
+	ChangesEventReset = "reset"
+)
+
+func (a *API) handleGetChanges(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/changes getChanges
+	//
+	// Streams the board, block and member changes of the boards of the
+	// team the user can see, as Server-Sent Events. Each event carries a
+	// ChangeEvent as data. After a disconnection, the stream resumes
+	// after the event given in the Last-Event-ID header; if the changes
+	// that followed it are no longer available, a "reset" event is sent
+	// first and the client should reload the boards it follows
+	//
+	// ---
+	// produces:
+	// - text/event-stream
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Last-Event-ID
+	//   in: header
+	//   description: ID of the last event received, to resume the stream
+	//   required: false
+	//   type: string
+	// - name: lastEventId
+	//   in: query
+	//   description: Same as the Last-Event-ID header, for clients that can't set it
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ChangeEvent"
+	//   '403':
+	//     description: access denied to the team
+	//   '501':
+	//     description: streaming not supported by the server
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "streaming not supported", nil)
+		return
+	}
+
+	lastEventID := r.Header.Get(HeaderLastEventID)
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+
+	auditRec := a.makeAuditRecord(r, "getChanges", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	sub, missed, resumed := a.appFor(r).SubscribeToChanges(lastEventID)
+	defer sub.Cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// stops nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	auditRec.Success()
+
+	a.logger.Debug("GetChanges stream opened",
+		mlog.String("teamID", teamID),
+		mlog.String("userID", userID),
+		mlog.Bool("resumed", resumed),
+	)
+
+	filter := newChangeFilter(a.permissions, userID, teamID)
+	if !resumed {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", ChangesEventReset)
+	}
+	for _, event := range missed {
+		a.writeChangeEvent(w, filter, event)
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(changesKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				// the client fell behind, it resumes from its last event
+				// when it reconnects
+				return
+			}
+			a.writeChangeEvent(w, filter, event)
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		flusher.Flush()
+	}
+}
+
+func (a *API) writeChangeEvent(w http.ResponseWriter, filter *changeFilter, event *changestream.Event) {
+	if !filter.allowed(event.Change) {
+		return
+	}
+
+	data, err := json.Marshal(event.Change)
+	if err != nil {
+		a.logger.Error("Cannot marshal change event", mlog.Err(err))
+		return
+	}
+	fmt.Fprintf(w, "id: %s\ndata: %s\n\n", event.ID, data)
+}
+
+// changeFilter selects the changes of the boards of a team that a user
+// can see. The permissions are cached per board for the life of the
+// stream, and refreshed when the memberships of the user change.
+type changeFilter struct {
+	permissions permissions.PermissionsService
+	userID      string
+	teamID      string
+	visible     map[string]bool
+}
+
+func newChangeFilter(perms permissions.PermissionsService, userID, teamID string) *changeFilter {
+	return &changeFilter{
+		permissions: perms,
+		userID:      userID,
+		teamID:      teamID,
+		visible:     map[string]bool{},
+	}
+}
+
+func (f *changeFilter) allowed(change *model.ChangeEvent) bool {
+	if change.TeamID != f.teamID {
+		return false
+	}
+
+	if change.UserID == f.userID {
+		switch change.Type {
+		case model.ChangeEventMemberDeleted:
+			// users removed from a board are told about it, even though
+			// they can't see the board anymore
+			f.visible[change.BoardID] = false
+			return true
+		case model.ChangeEventMemberChanged:
+			delete(f.visible, change.BoardID)
+		}
+	}
+
+	visible, ok := f.visible[change.BoardID]
+	if !ok {
+		// deleted boards can't be checked anymore, so their deletion is
+		// only sent if the board was seen before
+		if change.Type == model.ChangeEventBoardDeleted {
+			return false
+		}
+		visible = f.permissions.HasPermissionToBoard(f.userID, change.BoardID, model.PermissionViewBoard)
+		f.visible[change.BoardID] = visible
+	}
+	return visible
+}
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleLinkBoardToChannel(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/channel linkBoardToChannel
+	//
+	// Links a board to a channel of its team. Only available when running
+	// as a plugin
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the channel to link the board to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ChannelLinkRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Board"
+	//   '404':
+	//     description: board or channel not found
+	//   '501':
+	//     description: not available in standalone mode
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if !a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "not permitted in standalone mode", nil)
+		return
+	}
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to linking the board to a channel"})
+		return
+	}
+
+	request, err := model.ChannelLinkRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	if request.ChannelID == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "missing channel ID", nil)
+		return
+	}
+
+	if !a.permissions.HasPermissionToChannel(userID, request.ChannelID, model.PermissionCreatePost) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to channel"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "linkBoardToChannel", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("channelID", request.ChannelID)
+
+	board, err := a.appFor(r).LinkBoardToChannel(boardID, request.ChannelID, userID)
+	if errors.Is(err, app.ErrChannelNotFound) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if errors.Is(err, app.ErrChannelOnAnotherTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("LinkBoardToChannel",
+		mlog.String("boardID", boardID),
+		mlog.String("channelID", request.ChannelID),
+	)
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleUnlinkBoardFromChannel(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/channel unlinkBoardFromChannel
+	//
+	// Removes the link between a board and its channel. Only available
+	// when running as a plugin
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Board"
+	//   '501':
+	//     description: not available in standalone mode
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if !a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "not permitted in standalone mode", nil)
+		return
+	}
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to unlinking the board from its channel"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "unlinkBoardFromChannel", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	board, err := a.appFor(r).UnlinkBoardFromChannel(boardID, userID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("UnlinkBoardFromChannel", mlog.String("boardID", boardID))
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardsForChannel(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /channels/{channelID}/boards getBoardsForChannel
+	//
+	// Returns the boards linked to a channel that the user can see. Only
+	// available when running as a plugin
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: channelID
+	//   in: path
+	//   description: Channel ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Board"
+	//   '501':
+	//     description: not available in standalone mode
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if !a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "not permitted in standalone mode", nil)
+		return
+	}
+
+	channelID := mux.Vars(r)["channelID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToChannel(userID, channelID, model.PermissionReadChannel) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to channel"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardsForChannel", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("channelID", channelID)
+
+	boards, err := a.appFor(r).GetBoardsForChannel(channelID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	results := []*model.Board{}
+	for _, board := range boards {
+		if board.Type == model.BoardTypeOpen && a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
+			results = append(results, board)
+		} else if a.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionViewBoard) {
+			results = append(results, board)
+		}
+	}
+
+	a.logger.Debug("GetBoardsForChannel",
+		mlog.String("channelID", channelID),
+		mlog.Int("boardsCount", len(results)),
+	)
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("boardsCount", len(results))
+	auditRec.Success()
+}
@@ -11,6 +11,8 @@ type contextKey int
 const (
 	httpConnContextKey contextKey = iota
 	sessionContextKey
+	requestIDContextKey
+	appContextKey
 )
 
 // SetContextConn stores the connection in the request context.
@@ -0,0 +1,392 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/github"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetGitHubIntegration(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/github getGitHubIntegration
+	//
+	// Returns the GitHub integration of a board, without its token
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/GitHubIntegration"
+	//   '404':
+	//     description: the board has no GitHub integration
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board GitHub integration"})
+		return
+	}
+
+	integration, err := a.appFor(r).GetGitHubIntegration(boardID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetGitHubIntegration",
+		mlog.String("boardID", boardID),
+	)
+
+	data, err := json.Marshal(integration)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleSetGitHubIntegration(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/github setGitHubIntegration
+	//
+	// Connects the board to a GitHub repository, replacing its previous
+	// integration. The returned webhook secret is used to add a webhook
+	// for the "issues" events to the repository
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the repository, its access token and the properties the issues are synced to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/GitHubIntegration"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/GitHubIntegration"
+	//   '400':
+	//     description: invalid repository or properties
+	//   '404':
+	//     description: board not found
+	//   '501':
+	//     description: the GitHub integration is not configured on the server
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board GitHub integration"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var integration *model.GitHubIntegration
+	if err = json.Unmarshal(requestBody, &integration); err != nil || integration == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setGitHubIntegration", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("repository", integration.Repository)
+
+	integration, err = a.appFor(r).SetGitHubIntegration(boardID, integration, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("SetGitHubIntegration",
+		mlog.String("boardID", boardID),
+		mlog.String("repository", integration.Repository),
+	)
+
+	data, err := json.Marshal(integration)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteGitHubIntegration(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/github deleteGitHubIntegration
+	//
+	// Disconnects the board from its GitHub repository. The cards imported
+	// from the repository are kept
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board GitHub integration"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteGitHubIntegration", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.appFor(r).DeleteGitHubIntegration(boardID); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("DeleteGitHubIntegration",
+		mlog.String("boardID", boardID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleSyncGitHubIssues(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/github/sync syncGitHubIssues
+	//
+	// Imports the issues of the GitHub repository of a board as cards,
+	// updating the cards of the issues imported before
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/GitHubSyncResult"
+	//   '400':
+	//     description: the issues cannot be read from GitHub
+	//   '404':
+	//     description: the board has no GitHub integration
+	//   '501':
+	//     description: the GitHub integration is not configured on the server
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "syncGitHubIssues", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	result, err := a.appFor(r).SyncGitHubIssues(boardID, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("SyncGitHubIssues",
+		mlog.String("boardID", boardID),
+		mlog.Int("created", result.Created),
+		mlog.Int("updated", result.Updated),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("created", result.Created)
+	auditRec.AddMeta("updated", result.Updated)
+	auditRec.Success()
+}
+
+func (a *API) handleGitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /hooks/github/{boardID} gitHubWebhook
+	//
+	// Receives the webhook events of the GitHub repository of a board. The
+	// "issues" events update the cards of their issues; the other events
+	// are ignored. The request must be signed with the webhook secret of
+	// the integration
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: X-GitHub-Event
+	//   in: header
+	//   description: the name of the event
+	//   required: true
+	//   type: string
+	// - name: X-Hub-Signature-256
+	//   in: header
+	//   description: HMAC-SHA256 signature of the body, in the sha256=<hex> format
+	//   required: true
+	//   type: string
+	// responses:
+	//   '200':
+	//     description: success
+	//   '400':
+	//     description: invalid event
+	//   '401':
+	//     description: invalid signature
+	//   '404':
+	//     description: the board has no GitHub integration
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+
+	integration, err := a.app.GetGitHubIntegration(boardID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	if err = github.VerifySignature(integration.WebhookSecret, r.Header.Get(github.HeaderSignature), requestBody); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", err)
+		return
+	}
+
+	event := r.Header.Get(github.HeaderEvent)
+	if event != "issues" {
+		// the ping sent when the webhook is added, and the events the
+		// webhook was wrongly subscribed to
+		jsonStringResponse(w, http.StatusOK, "{}")
+		return
+	}
+
+	var issuesEvent github.IssuesEvent
+	if err = json.Unmarshal(requestBody, &issuesEvent); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "gitHubWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("action", issuesEvent.Action)
+	auditRec.AddMeta("issue", issuesEvent.Issue.Number)
+
+	err = a.app.ApplyGitHubIssueEvent(boardID, &issuesEvent)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GitHubWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("action", issuesEvent.Action),
+		mlog.Int("issue", issuesEvent.Issue.Number),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleImport(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/import/{importer} importFromService
+	//
+	// Imports the issues of another service as cards of a new board of the
+	// team, or of an existing board. The only importer is "gitlab"
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: importer
+	//   in: path
+	//   description: the service to import from
+	//   required: true
+	//   type: string
+	//   enum: [gitlab]
+	// - name: Body
+	//   in: body
+	//   description: the project to import and the board to import it in
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/GitLabImportRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/GitLabImportResult"
+	//   '400':
+	//     description: invalid project, or the issues cannot be read from the service
+	//   '404':
+	//     description: unknown importer or board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	importer := vars["importer"]
+	userID := getUserID(r)
+
+	if importer != model.ImporterGitLab {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "unknown importer", nil)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var request *model.GitLabImportRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil || request == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	if request.BoardID == "" {
+		if !a.hasPermissionToCreateBoardsInTeam(userID, teamID) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create board"})
+			return
+		}
+	} else if !a.permissions.HasPermissionToBoard(userID, request.BoardID, model.PermissionManageBoardCards) ||
+		!a.permissions.HasPermissionToBoard(userID, request.BoardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "importFromService", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("importer", importer)
+	auditRec.AddMeta("project", request.Project)
+	auditRec.AddMeta("boardID", request.BoardID)
+
+	result, err := a.appFor(r).ImportGitLabIssues(teamID, request, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("ImportFromService",
+		mlog.String("teamID", teamID),
+		mlog.String("importer", importer),
+		mlog.String("boardID", result.BoardID),
+		mlog.Int("created", result.Created),
+		mlog.Int("updated", result.Updated),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("importedBoardID", result.BoardID)
+	auditRec.Success()
+}
@@ -0,0 +1,505 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// The integration API is made for no-code platforms like Zapier. Its
+// requests are authenticated with the API keys of the APIKeys setting,
+// sent in the X-API-Key header, and made as the user of the key. Its
+// triggers are polled with the cursor of the last item received.
+
+func (a *API) registerIntegrationRoutes(r *mux.Router) {
+	integrations := r.PathPrefix("/api/v2/integrations").Subrouter()
+	integrations.Use(a.requestIDHandler)
+	integrations.Use(a.panicHandler)
+
+	integrations.HandleFunc("/me", a.apiKeyRequired(a.handleIntegrationGetMe)).Methods("GET")
+	integrations.HandleFunc("/boards/{boardID}/cards/new", a.apiKeyRequired(a.handleIntegrationNewCards)).Methods("GET")
+	integrations.HandleFunc("/boards/{boardID}/cards/status-changes", a.apiKeyRequired(a.handleIntegrationStatusChanges)).Methods("GET")
+	integrations.HandleFunc("/boards/{boardID}/cards", a.apiKeyRequired(a.handleIntegrationCreateCard)).Methods("POST")
+	integrations.HandleFunc("/cards/{cardID}", a.apiKeyRequired(a.handleIntegrationUpdateCard)).Methods("PATCH")
+}
+
+// apiKeyRequired authenticates the request with its API key, as the user
+// of the key.
+func (a *API) apiKeyRequired(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := a.getAPIKeyUserID(r, r.Header.Get(model.HeaderAPIKey))
+		if userID == "" {
+			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "invalid API key", nil)
+			return
+		}
+
+		// the user of the key may have been deleted since the key was
+		// configured
+		if _, err := a.appFor(r).GetUser(userID); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", err)
+			return
+		}
+
+		now := utils.GetMillis()
+		session := &model.Session{
+			ID:          userID,
+			UserID:      userID,
+			AuthService: a.authService,
+			Props:       map[string]interface{}{},
+			CreateAt:    now,
+			UpdateAt:    now,
+		}
+		ctx := context.WithValue(r.Context(), sessionContextKey, session)
+		handler(w, r.WithContext(ctx))
+	}
+}
+
+func (a *API) getAPIKeyUserID(r *http.Request, key string) string {
+	if key == "" {
+		return ""
+	}
+	for _, apiKey := range a.appFor(r).GetConfig().APIKeys {
+		if apiKey.Key != "" && subtle.ConstantTimeCompare([]byte(apiKey.Key), []byte(key)) == 1 {
+			return apiKey.UserID
+		}
+	}
+	return ""
+}
+
+// integrationPageSize reads the limit of the items returned by a trigger.
+func integrationPageSize(r *http.Request) (int, error) {
+	limit := r.URL.Query().Get("limit")
+	if limit == "" {
+		return model.DefaultIntegrationPageSize, nil
+	}
+	size, err := strconv.Atoi(limit)
+	if err != nil || size <= 0 {
+		return 0, model.NewCodedError(model.ErrCodeBadRequest, "invalid limit", map[string]interface{}{"limit": limit})
+	}
+	if size > model.MaxIntegrationPageSize {
+		size = model.MaxIntegrationPageSize
+	}
+	return size, nil
+}
+
+func (a *API) handleIntegrationGetMe(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /integrations/me integrationGetMe
+	//
+	// Returns the user of the API key, to test the key
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: X-API-Key
+	//   in: header
+	//   description: API key
+	//   required: true
+	//   type: string
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IntegrationUser"
+	//   '401':
+	//     description: invalid API key
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	user, err := a.appFor(r).GetUser(getUserID(r))
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(model.IntegrationUser{UserID: user.ID, Username: user.Username})
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleIntegrationNewCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /integrations/boards/{boardID}/cards/new integrationNewCards
+	//
+	// Returns the cards of a board created after the cursor, in creation
+	// order. Without cursor, the most recent cards are returned. The next
+	// poll is made with the cursor of the last card received
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: cursor
+	//   in: query
+	//   description: cursor of the last card received
+	//   required: false
+	//   type: string
+	// - name: limit
+	//   in: query
+	//   description: maximum number of cards returned, 50 by default and at most 100
+	//   required: false
+	//   type: integer
+	// - name: X-API-Key
+	//   in: header
+	//   description: API key
+	//   required: true
+	//   type: string
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/IntegrationCard"
+	//   '400':
+	//     description: invalid cursor or limit
+	//   '401':
+	//     description: invalid API key
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	limit, err := integrationPageSize(r)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	cards, err := a.appFor(r).GetIntegrationNewCards(boardID, r.URL.Query().Get("cursor"), limit)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("IntegrationNewCards",
+		mlog.String("boardID", boardID),
+		mlog.Int("cardsCount", len(cards)),
+	)
+
+	data, err := json.Marshal(cards)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleIntegrationStatusChanges(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /integrations/boards/{boardID}/cards/status-changes integrationStatusChanges
+	//
+	// Returns the cards of a board whose status changed after the cursor,
+	// in the order of the changes, with their current status. Without
+	// cursor, the most recent changes are returned. The next poll is made
+	// with the cursor of the last change received
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: cursor
+	//   in: query
+	//   description: cursor of the last change received
+	//   required: false
+	//   type: string
+	// - name: limit
+	//   in: query
+	//   description: maximum number of changes returned, 50 by default and at most 100
+	//   required: false
+	//   type: integer
+	// - name: X-API-Key
+	//   in: header
+	//   description: API key
+	//   required: true
+	//   type: string
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/IntegrationStatusChange"
+	//   '400':
+	//     description: invalid cursor or limit, or the board has no status property
+	//   '401':
+	//     description: invalid API key
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	limit, err := integrationPageSize(r)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	changes, err := a.appFor(r).GetIntegrationStatusChanges(boardID, r.URL.Query().Get("cursor"), limit)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("IntegrationStatusChanges",
+		mlog.String("boardID", boardID),
+		mlog.Int("changesCount", len(changes)),
+	)
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleIntegrationCreateCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /integrations/boards/{boardID}/cards integrationCreateCard
+	//
+	// Creates a card on a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: X-API-Key
+	//   in: header
+	//   description: API key
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the card to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/IntegrationCardRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IntegrationCard"
+	//   '400':
+	//     description: missing title or invalid properties
+	//   '401':
+	//     description: invalid API key
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	request, err := integrationCardRequestFromBody(r)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "integrationCreateCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	addIntegrationMeta(auditRec, r)
+	auditRec.AddMeta("boardID", boardID)
+
+	card, err := a.appFor(r).CreateIntegrationCard(boardID, request, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("IntegrationCreateCard",
+		mlog.String("boardID", boardID),
+		mlog.String("cardID", card.ID),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleIntegrationUpdateCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /integrations/cards/{cardID} integrationUpdateCard
+	//
+	// Changes the title and properties of a card
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: X-API-Key
+	//   in: header
+	//   description: API key
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the title and properties to change
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/IntegrationCardRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IntegrationCard"
+	//   '400':
+	//     description: invalid properties
+	//   '401':
+	//     description: invalid API key
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	cardID := mux.Vars(r)["cardID"]
+	userID := getUserID(r)
+
+	card, err := a.appFor(r).GetBlockByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if card == nil || card.Type != model.TypeCard {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBlockNotFound(cardID))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	request, err := integrationCardRequestFromBody(r)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "integrationUpdateCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	addIntegrationMeta(auditRec, r)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	updated, err := a.appFor(r).UpdateIntegrationCard(cardID, request, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("IntegrationUpdateCard",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+	)
+
+	data, err := json.Marshal(updated)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func integrationCardRequestFromBody(r *http.Request) (*model.IntegrationCardRequest, error) {
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	var request model.IntegrationCardRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
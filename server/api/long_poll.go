@@ -0,0 +1,198 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/changestream"
+	"github.com/mattermost/focalboard/server/ws"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	defaultLongPollTimeout = 25 * time.Second
+	maxLongPollTimeout     = 60 * time.Second
+)
+
+func (a *API) handleLongPoll(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/poll longPoll
+	//
+	// Waits for the changes of the boards of a team, for the clients
+	// that can't use websockets. The response holds the websocket
+	// messages of the changes that followed the cursor, and is sent as
+	// soon as there is one, or empty after the timeout. The next request
+	// sends the cursor of the response; if the changes that followed it
+	// are no longer available, the response is a reset and the client
+	// should reload the boards it follows
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: cursor
+	//   in: query
+	//   description: Cursor of the previous response, empty for the first request
+	//   required: false
+	//   type: string
+	// - name: boardIds
+	//   in: query
+	//   description: Comma separated IDs of the boards to receive the changes of, all the boards of the team the user can see if empty
+	//   required: false
+	//   type: string
+	// - name: timeout
+	//   in: query
+	//   description: Seconds to wait for a change, 25 by default and 60 at most
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/LongPollResponse"
+	//   '400':
+	//     description: invalid timeout
+	//   '403':
+	//     description: access denied to the team
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+	query := r.URL.Query()
+	cursor := query.Get("cursor")
+
+	timeout := defaultLongPollTimeout
+	if timeoutStr := query.Get("timeout"); timeoutStr != "" {
+		seconds, err := strconv.Atoi(timeoutStr)
+		if err != nil || seconds < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid timeout", err)
+			return
+		}
+		timeout = time.Duration(seconds) * time.Second
+		if timeout > maxLongPollTimeout {
+			timeout = maxLongPollTimeout
+		}
+	}
+
+	var boardIDs map[string]bool
+	if boardIDsStr := query.Get("boardIds"); boardIDsStr != "" {
+		boardIDs = map[string]bool{}
+		for _, boardID := range strings.Split(boardIDsStr, ",") {
+			boardIDs[strings.TrimSpace(boardID)] = true
+		}
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "longPoll", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	sub, missed, resumed := a.appFor(r).SubscribeToChanges(cursor)
+	defer sub.Cancel()
+
+	response := &model.LongPollResponse{
+		Cursor:   sub.StartID,
+		Reset:    !resumed,
+		Messages: []json.RawMessage{},
+	}
+
+	if resumed {
+		filter := newChangeFilter(a.permissions, userID, teamID)
+		add := func(event *changestream.Event) bool {
+			response.Cursor = event.ID
+			if boardIDs != nil && !boardIDs[event.Change.BoardID] {
+				return false
+			}
+			if !filter.allowed(event.Change) {
+				return false
+			}
+			data, err := json.Marshal(ws.MessageForChange(event.Change))
+			if err != nil {
+				a.logger.Error("Cannot marshal long poll message", mlog.Err(err))
+				return false
+			}
+			response.Messages = append(response.Messages, data)
+			return true
+		}
+
+		for _, event := range missed {
+			add(event)
+		}
+		// the first request only returns the cursor to start from
+		if cursor != "" && len(response.Messages) == 0 {
+			waitForChanges(r, sub, timeout, add)
+		}
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("messageCount", len(response.Messages))
+	auditRec.Success()
+}
+
+// waitForChanges passes the changes of the subscription to add until it
+// keeps one, or the timeout expires. The changes already queued by then
+// are added too, so the changes made together are sent together.
+func waitForChanges(r *http.Request, sub *changestream.Subscription, timeout time.Duration, add func(*changestream.Event) bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-timer.C:
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				// the request fell behind, the next one resumes from the
+				// cursor
+				return
+			}
+			if add(event) {
+				addQueuedChanges(sub, add)
+				return
+			}
+		}
+	}
+}
+
+func addQueuedChanges(sub *changestream.Subscription, add func(*changestream.Event) bool) {
+	for {
+		select {
+		case event, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			add(event)
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleSync(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /sync sync
+	//
+	// Returns the blocks changed since the last sync of each board, and
+	// the IDs of the blocks deleted since then, so the clients that keep
+	// a copy of the boards can refresh them cheaply. The boards that
+	// were deleted, or that the user can no longer see, are marked as
+	// removed
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: the boards to sync, with the time of their last sync
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/SyncRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/SyncResponse"
+	//   '400':
+	//     description: invalid request
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var request *model.SyncRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	if request == nil || len(request.Boards) == 0 {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "at least one board is required", nil)
+		return
+	}
+
+	if len(request.Boards) > model.MaxSyncBoards {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "too many boards", nil)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "sync", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardCount", len(request.Boards))
+
+	boardIDs := make([]string, 0, len(request.Boards))
+	for boardID := range request.Boards {
+		boardIDs = append(boardIDs, boardID)
+	}
+	sort.Strings(boardIDs)
+
+	// taken before reading the changes, so the ones made meanwhile are
+	// sent again on the next sync rather than missed
+	response := &model.SyncResponse{
+		SyncAt: utils.GetMillis(),
+		Boards: make([]*model.BoardSync, 0, len(boardIDs)),
+	}
+
+	for _, boardID := range boardIDs {
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+			response.Boards = append(response.Boards, &model.BoardSync{
+				BoardID:         boardID,
+				Blocks:          []model.Block{},
+				DeletedBlockIDs: []string{},
+				Removed:         true,
+			})
+			continue
+		}
+
+		boardSync, err := a.appFor(r).GetBoardChangesSince(boardID, request.Boards[boardID])
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		response.Boards = append(response.Boards, boardSync)
+	}
+
+	a.logger.Debug("Sync",
+		mlog.String("userID", userID),
+		mlog.Int("boardCount", len(boardIDs)),
+	)
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleEditBlockText(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/blocks/{blockID}/text-edits editBlockText
+	//
+	// Applies a delta to the text of a block, such as a card description.
+	// If the text was edited since the version the delta is based on, the
+	// delta is transformed to apply on top of those edits, so concurrent
+	// edits are merged. The applied delta is relayed to the other editors
+	// through the websocket
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of the text block
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the delta and the version of the text it is based on
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/TextEditRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, with the delta as applied
+	//     schema:
+	//       "$ref": "#/definitions/TextEdit"
+	//   '400':
+	//     description: invalid delta, or the block isn't a text block
+	//   '404':
+	//     description: block not found
+	//   '409':
+	//     description: the version the delta is based on is too old, the text must be reloaded
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	blockID := vars["blockID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	if !a.checkBlockInBoard(w, r, boardID, blockID) {
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var request *model.TextEditRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil || request == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "editBlockText", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("blockID", blockID)
+
+	edit, err := a.appFor(r).EditBlockText(blockID, request, userID)
+	if ce, ok := model.AsCodedError(err); ok {
+		a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("EditBlockText",
+		mlog.String("boardID", boardID),
+		mlog.String("blockID", blockID),
+		mlog.Int64("updateAt", edit.UpdateAt),
+	)
+
+	data, err := json.Marshal(edit)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
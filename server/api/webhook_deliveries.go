@@ -0,0 +1,170 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const defaultWebhookDeliveriesPerPage = 100
+
+func (a *API) handleAdminGetDeadWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	a.writeDeadWebhookDeliveries(w, r)
+}
+
+func (a *API) handleGetDeadWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /webhooks/deliveries getDeadWebhookDeliveries
+	//
+	// Returns the outgoing webhook deliveries that failed too many times to
+	// be retried automatically, newest first. Requires the manage system
+	// permission
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: Zero-based page number
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: Number of deliveries per page, 100 if not set
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/WebhookDelivery"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to webhook deliveries"})
+		return
+	}
+
+	a.writeDeadWebhookDeliveries(w, r)
+}
+
+func (a *API) writeDeadWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page := 0
+	perPage := defaultWebhookDeliveriesPerPage
+	if pageStr := query.Get("page"); pageStr != "" {
+		var err error
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid page", err)
+			return
+		}
+	}
+	if perPageStr := query.Get("per_page"); perPageStr != "" {
+		var err error
+		perPage, err = strconv.Atoi(perPageStr)
+		if err != nil || perPage < 1 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid per_page", err)
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "getDeadWebhookDeliveries", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	deliveries, err := a.appFor(r).GetDeadWebhookDeliveries(page, perPage)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetDeadWebhookDeliveries", mlog.Int("count", len(deliveries)))
+
+	data, err := json.Marshal(deliveries)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminRetryWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	a.retryWebhookDelivery(w, r)
+}
+
+func (a *API) handleRetryWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /webhooks/deliveries/{deliveryID}/retry retryWebhookDelivery
+	//
+	// Retries a failed outgoing webhook delivery right away. The delivery
+	// is removed if it succeeds. Requires the manage system permission
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: deliveryID
+	//   in: path
+	//   description: Webhook delivery ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: delivery not found
+	//   '502':
+	//     description: the webhook failed again
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to webhook deliveries"})
+		return
+	}
+
+	a.retryWebhookDelivery(w, r)
+}
+
+func (a *API) retryWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	deliveryID := mux.Vars(r)["deliveryID"]
+
+	auditRec := a.makeAuditRecord(r, "retryWebhookDelivery", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("deliveryID", deliveryID)
+
+	err := a.appFor(r).RetryWebhookDelivery(deliveryID)
+	if ce, ok := model.AsCodedError(err); ok && ce.Code == model.ErrCodeNotFound {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadGateway, "", err)
+		return
+	}
+
+	a.logger.Debug("RetryWebhookDelivery", mlog.String("deliveryID", deliveryID))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
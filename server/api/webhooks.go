@@ -0,0 +1,195 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/config"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// The incoming webhooks are configured in the IncomingWebhooks setting.
+// They don't use sessions: each request is authenticated with the
+// HMAC-SHA256 signature of its body, made with the secret of the webhook,
+// and its timestamp must be recent to prevent replays.
+
+func (a *API) registerWebhookRoutes(r *mux.Router) {
+	hooks := r.PathPrefix("/api/v2/hooks").Subrouter()
+	hooks.Use(a.requestIDHandler)
+	hooks.Use(a.panicHandler)
+
+	hooks.HandleFunc("/github/{boardID}", a.handleGitHubWebhook).Methods("POST")
+	hooks.HandleFunc("/{hookID}", a.handleIncomingWebhook).Methods("POST")
+}
+
+func (a *API) handleIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /hooks/{hookID} incomingWebhook
+	//
+	// Creates a card on the board of an incoming webhook. The request
+	// must be signed with the secret of the webhook
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: hookID
+	//   in: path
+	//   description: Incoming webhook ID
+	//   required: true
+	//   type: string
+	// - name: X-Focalboard-Signature
+	//   in: header
+	//   description: HMAC-SHA256 signature of the timestamp and body, in the sha256=<hex> format
+	//   required: true
+	//   type: string
+	// - name: X-Focalboard-Timestamp
+	//   in: header
+	//   description: time the request was signed, in seconds since the epoch
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the card to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BotCreateCardRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid request
+	//   '401':
+	//     description: invalid signature
+	//   '404':
+	//     description: webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	hookID := mux.Vars(r)["hookID"]
+
+	hook := a.getIncomingWebhook(hookID)
+	if hook == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "webhook not found", nil)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	tolerance := time.Duration(a.app.GetConfig().WebhookTimestampTolerance) * time.Second
+	err = model.VerifyWebhookSignature(hook.Secret,
+		r.Header.Get(model.HeaderWebhookSignature),
+		r.Header.Get(model.HeaderWebhookTimestamp),
+		requestBody, tolerance, time.Now())
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", err)
+		return
+	}
+
+	var request model.BotCreateCardRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if request.Title == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "missing card title", nil)
+		return
+	}
+
+	// the user of the webhook may have lost access to the board since
+	// the webhook was configured
+	if !a.permissions.HasPermissionToBoard(hook.UserID, hook.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	board, err := a.app.GetBoard(hook.BoardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if board == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrBoardNotFound(hook.BoardID))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "incomingWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", board.ID)
+	auditRec.AddMeta("userID", hook.UserID)
+
+	if len(request.Properties) > 0 {
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		if _, err := schema.ResolvePropertyValues(request.Properties); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
+	}
+
+	card, err := a.app.CreateCard(board.ID, request.Title, request.Description, hook.UserID)
+	if model.IsErrBoardReadOnly(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", err)
+		return
+	}
+	if model.IsErrContentRejected(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	if len(request.Properties) > 0 {
+		card, err = a.app.SetCardProperties(card.ID, request.Properties, hook.UserID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+	}
+
+	a.logger.Debug("IncomingWebhook",
+		mlog.String("hookID", hookID),
+		mlog.String("boardID", board.ID),
+		mlog.String("cardID", card.ID),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.Success()
+}
+
+func (a *API) getIncomingWebhook(hookID string) *config.IncomingWebhookConfig {
+	hooks := a.app.GetConfig().IncomingWebhooks
+	for i := range hooks {
+		// a webhook without a secret would accept any request
+		if hooks[i].ID == hookID && hooks[i].Secret != "" {
+			return &hooks[i]
+		}
+	}
+	return nil
+}
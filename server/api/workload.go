@@ -0,0 +1,210 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetBoardWorkload(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/workload getBoardWorkload
+	//
+	// Returns the number of open cards of a board and the sum of their
+	// estimates by assignee, to balance the workload
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: estimate
+	//   in: query
+	//   description: Number property, by ID or name, holding the estimates of the cards, "Estimate" by default
+	//   required: false
+	//   type: string
+	// - name: from
+	//   in: query
+	//   description: Only count the cards due from this time, in miliseconds
+	//   required: false
+	//   type: integer
+	// - name: to
+	//   in: query
+	//   description: Only count the cards due until this time, in miliseconds
+	//   required: false
+	//   type: integer
+	// - name: exclude
+	//   in: query
+	//   description: Property value, as property:value, of the done cards to leave out, like Status:Done. The stale card exclusions stored in the board properties apply if not set
+	//   required: false
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Workload"
+	//   '400':
+	//     description: invalid range, estimate property or exclusion
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	query, err := workloadQueryFromValues(r.URL.Query())
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	workload, err := a.appFor(r).GetBoardWorkload(boardID, query)
+	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetBoardWorkload",
+		mlog.String("boardID", boardID),
+		mlog.Int("assigneesCount", len(workload.Assignees)),
+	)
+
+	data, err := json.Marshal(workload)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleGetTeamWorkload(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/workload getTeamWorkload
+	//
+	// Returns the number of open cards of the team boards visible to the
+	// user and the sum of their estimates by assignee. The stale card
+	// exclusions of each board leave its done cards out
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: estimate
+	//   in: query
+	//   description: Name of the number property holding the estimates of the cards on each board, "Estimate" by default
+	//   required: false
+	//   type: string
+	// - name: from
+	//   in: query
+	//   description: Only count the cards due from this time, in miliseconds
+	//   required: false
+	//   type: integer
+	// - name: to
+	//   in: query
+	//   description: Only count the cards due until this time, in miliseconds
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Workload"
+	//   '400':
+	//     description: invalid range
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	query, err := workloadQueryFromValues(r.URL.Query())
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+
+	workload, err := a.appFor(r).GetTeamWorkload(userID, teamID, query)
+	if err != nil {
+		if ce, ok := model.AsCodedError(err); ok {
+			a.errorResponse(w, r.URL.Path, model.StatusForErrorCode(ce.Code), ce.Message, err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("GetTeamWorkload",
+		mlog.String("teamID", teamID),
+		mlog.Int("assigneesCount", len(workload.Assignees)),
+	)
+
+	data, err := json.Marshal(workload)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+// workloadQueryFromValues reads the workload query from the query string,
+// the range being in miliseconds.
+func workloadQueryFromValues(values url.Values) (model.WorkloadQuery, error) {
+	query := model.WorkloadQuery{
+		EstimateProperty: values.Get("estimate"),
+		Exclude:          values["exclude"],
+	}
+	var err error
+	if from := values.Get("from"); from != "" {
+		if query.From, err = strconv.ParseInt(from, 10, 64); err != nil {
+			return query, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if to := values.Get("to"); to != "" {
+		if query.To, err = strconv.ParseInt(to, 10, 64); err != nil {
+			return query, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	return query, nil
+}
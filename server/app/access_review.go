@@ -0,0 +1,69 @@
+package app
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// WriteAccessReviewReport writes the access review report of the team as
+// CSV: a row per member of each board of the team, with their role and
+// the last time they viewed and edited the board. The rows of each board
+// are flushed to w once the board is done, so the report is streamed.
+func (a *App) WriteAccessReviewReport(w io.Writer, teamID string) error {
+	if err := a.boardMemberActivity.flush(); err != nil {
+		a.logger.Error("cannot save board member activity", mlog.Err(err))
+	}
+
+	boardIDs, err := a.store.GetBoardIDsForTeam(teamID)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err = writer.Write(model.AccessReviewHeader); err != nil {
+		return err
+	}
+
+	usernames := map[string]string{}
+	for _, boardID := range boardIDs {
+		board, err := a.GetBoard(boardID)
+		if err != nil {
+			return err
+		}
+		if board == nil {
+			// deleted after the IDs were listed
+			continue
+		}
+
+		members, err := a.GetMembersWithActivityForBoard(boardID)
+		if err != nil {
+			return err
+		}
+
+		for _, member := range members {
+			username, ok := usernames[member.UserID]
+			if !ok {
+				if user, uErr := a.store.GetUserByID(member.UserID); uErr == nil && user != nil {
+					username = user.Username
+				}
+				usernames[member.UserID] = username
+			}
+
+			if err = writer.Write(model.AccessReviewRecord(board, member, username)); err != nil {
+				return err
+			}
+		}
+
+		writer.Flush()
+		if err = writer.Error(); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
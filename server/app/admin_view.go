@@ -0,0 +1,78 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetBoardAdminView returns a read-only copy of a board for a system
+// admin, without making them a member of it. If notifyAdmins is true,
+// the admins of the board are told about the access.
+func (a *App) GetBoardAdminView(boardID, adminID string, notifyAdmins bool) (*model.BoardAdminView, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	blocks, err := a.store.GetBlocksForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := a.store.GetMembersForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.logger.Info("Board opened in admin view",
+		mlog.String("boardID", boardID),
+		mlog.String("adminID", adminID),
+		mlog.Bool("notifyAdmins", notifyAdmins),
+	)
+
+	if notifyAdmins {
+		a.notifyAdminAccess(board, members, adminID)
+	}
+
+	return &model.BoardAdminView{
+		Board:      board,
+		Blocks:     blocks,
+		Members:    members,
+		AccessedAt: utils.GetMillis(),
+	}, nil
+}
+
+// notifyAdminAccess tells the admins of a board, except the system admin
+// who opened it, that the system admin opened the board.
+func (a *App) notifyAdminAccess(board *model.Board, members []*model.BoardMember, adminID string) {
+	if a.notifications == nil {
+		return
+	}
+
+	userIDs := []string{}
+	for _, member := range members {
+		if member.SchemeAdmin && member.UserID != adminID {
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	adminUsername := adminID
+	if user, err := a.store.GetUserByID(adminID); err == nil && user != nil {
+		adminUsername = user.Username
+	}
+
+	go a.notifications.AdminAccessedBoard(notify.AdminAccessEvent{
+		Board:         board,
+		AdminUsername: adminUsername,
+		UserIDs:       userIDs,
+	})
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestGetBoardAdminView(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("private board", func(t *testing.T) {
+		board := &model.Board{ID: "board-id", TeamID: "team-id", Type: model.BoardTypePrivate}
+		blocks := []model.Block{{ID: "card-id", BoardID: "board-id", Type: model.TypeCard}}
+		members := []*model.BoardMember{{BoardID: "board-id", UserID: "user-id", SchemeAdmin: true}}
+
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetBlocksForBoard("board-id").Return(blocks, nil)
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return(members, nil)
+
+		view, err := th.App.GetBoardAdminView("board-id", "admin-id", false)
+		require.NoError(t, err)
+		require.Equal(t, board, view.Board)
+		require.Equal(t, blocks, view.Blocks)
+		require.Equal(t, members, view.Members)
+		require.NotZero(t, view.AccessedAt)
+	})
+
+	t.Run("nonexistent board", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("missing-id").Return(nil, sql.ErrNoRows)
+
+		_, err := th.App.GetBoardAdminView("missing-id", "admin-id", false)
+		_, ok := model.AsCodedError(err)
+		require.True(t, ok)
+	})
+}
@@ -1,13 +1,21 @@
 package app
 
 import (
+	"context"
 	"time"
 
 	"github.com/mattermost/focalboard/server/auth"
+	"github.com/mattermost/focalboard/server/services/changestream"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/contentfilter"
+	"github.com/mattermost/focalboard/server/services/entitlements"
+	"github.com/mattermost/focalboard/server/services/github"
+	"github.com/mattermost/focalboard/server/services/gitlab"
+	"github.com/mattermost/focalboard/server/services/mail"
 	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/mattermost/focalboard/server/services/secrets"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/services/webhook"
 	"github.com/mattermost/focalboard/server/utils"
@@ -31,22 +39,41 @@ type Services struct {
 	Webhook          *webhook.Client
 	Metrics          *metrics.Metrics
 	Notifications    *notify.Service
+	ContentFilter    *contentfilter.Service
+	GitHub           *github.Service
+	GitLab           *gitlab.Service
+	Secrets          *secrets.Service
+	Mail             mail.Sender
 	Logger           *mlog.Logger
 	Permissions      permissions.PermissionsService
+	Entitlements     entitlements.Service
 	SkipTemplateInit bool
 }
 
 type App struct {
 	config              *config.Configuration
 	store               store.Store
+	detachedStore       store.Store
 	auth                *auth.Auth
 	wsAdapter           ws.Adapter
 	filesBackend        filestore.FileBackend
 	webhook             *webhook.Client
 	metrics             *metrics.Metrics
 	notifications       *notify.Service
+	contentFilter       *contentfilter.Service
+	github              *github.Service
+	gitlab              *gitlab.Service
+	secrets             *secrets.Service
+	mail                mail.Sender
 	logger              *mlog.Logger
+	entitlements        entitlements.Service
+	permissions         permissions.PermissionsService
 	blockChangeNotifier *utils.CallbackQueue
+	boardVisits         *boardVisitRecorder
+	boardMemberActivity *boardMemberActivityRecorder
+	propertyIndexer     *propertyIndexer
+	changes             *changestream.Stream
+	textEdits           *textEditHistory
 }
 
 func (a *App) SetConfig(config *config.Configuration) {
@@ -58,18 +85,82 @@ func (a *App) GetConfig() *config.Configuration {
 }
 
 func New(config *config.Configuration, wsAdapter ws.Adapter, services Services) *App {
+	// unless a custom entitlements service is provided, features are
+	// enabled based on the license reported by the store
+	entitlementsService := services.Entitlements
+	if entitlementsService == nil {
+		entitlementsService = entitlements.NewLicenseService(services.Store.GetLicense)
+	}
+
+	// the changes broadcast through the adapter also feed the change
+	// stream API
+	changes := changestream.New(changestream.DefaultBufferSize)
+
 	app := &App{
 		config:              config,
 		store:               services.Store,
+		detachedStore:       services.Store,
 		auth:                services.Auth,
-		wsAdapter:           wsAdapter,
+		wsAdapter:           ws.WithPublisher(wsAdapter, changes),
 		filesBackend:        services.FilesBackend,
 		webhook:             services.Webhook,
 		metrics:             services.Metrics,
 		notifications:       services.Notifications,
+		contentFilter:       services.ContentFilter,
+		github:              services.GitHub,
+		gitlab:              services.GitLab,
+		secrets:             services.Secrets,
+		mail:                services.Mail,
 		logger:              services.Logger,
+		entitlements:        entitlementsService,
+		permissions:         services.Permissions,
 		blockChangeNotifier: utils.NewCallbackQueue("blockChangeNotifier", blockChangeNotifierQueueSize, blockChangeNotifierPoolSize, services.Logger),
+		boardVisits:         newBoardVisitRecorder(services.Store, services.Logger),
+		boardMemberActivity: newBoardMemberActivityRecorder(services.Store, services.Logger),
+		propertyIndexer:     newPropertyIndexer(services.Store, services.Logger),
+		changes:             changes,
+		textEdits:           newTextEditHistory(),
 	}
 	app.initialize(services.SkipTemplateInit)
 	return app
 }
+
+// IsFeatureEnabled reports whether the configured entitlements service
+// allows the given feature to be used.
+func (a *App) IsFeatureEnabled(feature entitlements.Feature) bool {
+	return a.entitlements.IsFeatureEnabled(feature)
+}
+
+// WithRequestID returns a shallow copy of the app whose logger and
+// websocket broadcasts are tagged with the given request ID, so the
+// effects of an API call can be correlated end to end.
+func (a *App) WithRequestID(requestID string) *App {
+	if requestID == "" {
+		return a
+	}
+
+	scoped := *a
+	scoped.logger = a.logger.With(mlog.String("request_id", requestID))
+	scoped.wsAdapter = ws.WithRequestID(a.wsAdapter, requestID)
+	return &scoped
+}
+
+// WithContext returns a shallow copy of the app whose store queries run
+// with ctx, so they are cancelled along with it.
+func (a *App) WithContext(ctx context.Context) *App {
+	scoped := *a
+	scoped.store = a.store.WithContext(ctx)
+	return &scoped
+}
+
+// enqueue queues the work that follows a change, such as its broadcasts
+// and notifications. The work may run once the request that made the
+// change is served, so it gets a copy of the app whose store queries
+// aren't bound to the context of the request.
+func (a *App) enqueue(f func(a *App) error) {
+	detached := *a
+	detached.store = a.detachedStore
+	a.blockChangeNotifier.Enqueue(func() error {
+		return f(&detached)
+	})
+}
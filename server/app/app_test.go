@@ -3,8 +3,12 @@ package app
 import (
 	"testing"
 
+	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/entitlements"
 	"github.com/stretchr/testify/require"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
 )
 
 func TestSetConfig(t *testing.T) {
@@ -20,3 +24,26 @@ func TestSetConfig(t *testing.T) {
 		require.True(t, th.App.config.EnablePublicSharedBoards)
 	})
 }
+
+func TestIsFeatureEnabled(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("features follow the license by default", func(t *testing.T) {
+		enabled := true
+		th.Store.EXPECT().GetLicense().Return(&mmModel.License{Features: &mmModel.Features{CustomPermissionsSchemes: &enabled}})
+		require.True(t, th.App.IsFeatureEnabled(entitlements.FeatureCustomRoles))
+
+		th.Store.EXPECT().GetLicense().Return(nil)
+		require.False(t, th.App.IsFeatureEnabled(entitlements.FeatureCustomRoles))
+	})
+
+	t.Run("custom roles need the feature", func(t *testing.T) {
+		board := &model.Board{ID: testBoardID}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+		th.Store.EXPECT().GetLicense().Return(nil)
+
+		_, err := th.App.UpdateBoardMember(&model.BoardMember{BoardID: testBoardID, UserID: "user-id", Roles: "reviewer"})
+		require.ErrorIs(t, err, ErrInsufficientLicense)
+	})
+}
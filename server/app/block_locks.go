@@ -0,0 +1,111 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/mattermost/focalboard/server/ws"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// AcquireBlockLock locks the block for the user for ttl seconds, or the
+// default time if ttl is 0. Acquiring a lock the user already holds
+// renews it. If another user holds the lock, a block locked error is
+// returned.
+func (a *App) AcquireBlockLock(blockID string, ttl int, userID string) (*model.BlockLock, error) {
+	request := &model.BlockLockRequest{TTL: ttl}
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+	if ttl == 0 {
+		ttl = model.BlockLockDefaultTTL
+	}
+
+	block, err := a.GetBlockByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, model.NewErrBlockNotFound(blockID)
+	}
+
+	now := utils.GetMillis()
+	lock, err := a.store.AcquireBlockLock(&model.BlockLock{
+		BlockID:   blockID,
+		BoardID:   block.BoardID,
+		UserID:    userID,
+		CreateAt:  now,
+		ExpiresAt: now + (time.Duration(ttl) * time.Second).Milliseconds(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lock.UserID != userID {
+		return nil, model.NewErrBlockLocked(lock)
+	}
+
+	a.broadcastBlockLockChange(block.BoardID, blockID, lock)
+	return lock, nil
+}
+
+// ReleaseBlockLock releases the lock of the block held by the user. It
+// does nothing if the user doesn't hold it.
+func (a *App) ReleaseBlockLock(blockID, userID string) error {
+	lock, err := a.store.GetBlockLock(blockID)
+	if a.store.IsErrNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if lock.UserID != userID {
+		return nil
+	}
+
+	if err := a.store.ReleaseBlockLock(blockID, userID); err != nil {
+		return err
+	}
+
+	a.broadcastBlockLockChange(lock.BoardID, blockID, nil)
+	return nil
+}
+
+// GetBlockLocks returns the locks of the blocks of the board that haven't
+// expired.
+func (a *App) GetBlockLocks(boardID string) ([]*model.BlockLock, error) {
+	return a.store.GetBlockLocksForBoard(boardID, utils.GetMillis())
+}
+
+// CheckBlockLock returns a block locked error if the block is locked by
+// another user than userID. The locks are advisory: the callers decide
+// whether to enforce them.
+func (a *App) CheckBlockLock(blockID, userID string) error {
+	lock, err := a.store.GetBlockLock(blockID)
+	if a.store.IsErrNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if lock.UserID != userID && lock.IsActive(utils.GetMillis()) {
+		return model.NewErrBlockLocked(lock)
+	}
+	return nil
+}
+
+func (a *App) broadcastBlockLockChange(boardID, blockID string, lock *model.BlockLock) {
+	broadcaster, ok := a.wsAdapter.(ws.BlockLockBroadcaster)
+	if !ok {
+		return
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		a.logger.Error("cannot broadcast the block lock change", mlog.String("blockID", blockID), mlog.Err(err))
+		return
+	}
+
+	broadcaster.BroadcastBlockLockChange(board.TeamID, boardID, blockID, lock)
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestAcquireBlockLock(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	block := &model.Block{ID: "block-id", BoardID: "board-id"}
+	board := &model.Board{ID: "board-id", TeamID: "team-id"}
+
+	t.Run("locks the block", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("block-id").Return(block, nil)
+		th.Store.EXPECT().AcquireBlockLock(gomock.Any()).DoAndReturn(func(lock *model.BlockLock) (*model.BlockLock, error) {
+			return lock, nil
+		})
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{}, nil)
+
+		lock, err := th.App.AcquireBlockLock("block-id", 0, "user-id")
+		require.NoError(t, err)
+		require.Equal(t, "board-id", lock.BoardID)
+		require.Equal(t, "user-id", lock.UserID)
+		require.Equal(t, int64(model.BlockLockDefaultTTL*1000), lock.ExpiresAt-lock.CreateAt)
+	})
+
+	t.Run("the lock of another user is reported", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("block-id").Return(block, nil)
+		th.Store.EXPECT().AcquireBlockLock(gomock.Any()).Return(&model.BlockLock{BlockID: "block-id", UserID: "other-id"}, nil)
+
+		_, err := th.App.AcquireBlockLock("block-id", 30, "user-id")
+		require.True(t, model.IsErrBlockLocked(err))
+	})
+
+	t.Run("the ttl is validated", func(t *testing.T) {
+		_, err := th.App.AcquireBlockLock("block-id", model.BlockLockMaxTTL+1, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("the block must exist", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("missing-id").Return(nil, sql.ErrNoRows)
+
+		_, err := th.App.AcquireBlockLock("missing-id", 0, "user-id")
+		require.Error(t, err)
+	})
+}
+
+func TestCheckBlockLock(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	later := utils.GetMillis() + 60000
+	th.Store.EXPECT().IsErrNotFound(sql.ErrNoRows).Return(true).AnyTimes()
+	th.Store.EXPECT().IsErrNotFound(nil).Return(false).AnyTimes()
+
+	t.Run("no lock", func(t *testing.T) {
+		th.Store.EXPECT().GetBlockLock("block-id").Return(nil, sql.ErrNoRows)
+		require.NoError(t, th.App.CheckBlockLock("block-id", "user-id"))
+	})
+
+	t.Run("locked by the user", func(t *testing.T) {
+		th.Store.EXPECT().GetBlockLock("block-id").Return(&model.BlockLock{UserID: "user-id", ExpiresAt: later}, nil)
+		require.NoError(t, th.App.CheckBlockLock("block-id", "user-id"))
+	})
+
+	t.Run("locked by another user", func(t *testing.T) {
+		th.Store.EXPECT().GetBlockLock("block-id").Return(&model.BlockLock{UserID: "other-id", ExpiresAt: later}, nil)
+		require.True(t, model.IsErrBlockLocked(th.App.CheckBlockLock("block-id", "user-id")))
+	})
+
+	t.Run("expired lock of another user", func(t *testing.T) {
+		th.Store.EXPECT().GetBlockLock("block-id").Return(&model.BlockLock{UserID: "other-id", ExpiresAt: 1}, nil)
+		require.NoError(t, th.App.CheckBlockLock("block-id", "user-id"))
+	})
+}
+
+func TestReleaseBlockLock(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().IsErrNotFound(nil).Return(false).AnyTimes()
+
+	t.Run("the holder releases the lock", func(t *testing.T) {
+		th.Store.EXPECT().GetBlockLock("block-id").Return(&model.BlockLock{BoardID: "board-id", UserID: "user-id"}, nil)
+		th.Store.EXPECT().ReleaseBlockLock("block-id", "user-id").Return(nil)
+		th.Store.EXPECT().GetBoard("board-id").Return(&model.Board{ID: "board-id", TeamID: "team-id"}, nil)
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{}, nil)
+		require.NoError(t, th.App.ReleaseBlockLock("block-id", "user-id"))
+	})
+
+	t.Run("the lock of another user is kept", func(t *testing.T) {
+		th.Store.EXPECT().GetBlockLock("block-id").Return(&model.BlockLock{BoardID: "board-id", UserID: "other-id"}, nil)
+		require.NoError(t, th.App.ReleaseBlockLock("block-id", "user-id"))
+	})
+}
@@ -13,6 +13,8 @@ import (
 )
 
 var ErrBlocksFromMultipleBoards = errors.New("the block set contain blocks from multiple boards")
+var ErrBlockNotCard = errors.New("the block is not a card")
+var ErrCardAlreadyInBoard = errors.New("the card already belongs to the board")
 
 func (a *App) GetBlocks(boardID, parentID string, blockType string) ([]model.Block, error) {
 	if boardID == "" {
@@ -38,13 +40,16 @@ func (a *App) DuplicateBlock(boardID string, blockID string, userID string, asTe
 	if board == nil {
 		return nil, fmt.Errorf("cannot fetch board %s for DuplicateBlock: %w", boardID, err)
 	}
+	if err = a.checkBoardWritable(board, userID); err != nil {
+		return nil, err
+	}
 
 	blocks, err := a.store.DuplicateBlock(boardID, blockID, userID, asTemplate)
 	if err != nil {
 		return nil, err
 	}
 
-	a.blockChangeNotifier.Enqueue(func() error {
+	a.enqueue(func(a *App) error {
 		for _, block := range blocks {
 			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
 		}
@@ -53,6 +58,351 @@ func (a *App) DuplicateBlock(boardID string, blockID string, userID string, asTe
 	return blocks, err
 }
 
+// MoveCard moves a card, with its content and comments, to another board.
+// The card property values are remapped to the destination board schema.
+func (a *App) MoveCard(cardID, toBoardID, userID string) ([]model.Block, error) {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil {
+		return nil, model.NewErrBlockNotFound(cardID)
+	}
+	if card.Type != model.TypeCard {
+		return nil, ErrBlockNotCard
+	}
+	if card.BoardID == toBoardID {
+		return nil, ErrCardAlreadyInBoard
+	}
+
+	fromBoard, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	toBoard, err := a.store.GetBoard(toBoardID)
+	if a.store.IsErrNotFound(err) {
+		return nil, model.NewErrBoardNotFound(toBoardID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkBoardWritable(fromBoard, userID); err != nil {
+		return nil, err
+	}
+	if err = a.checkBoardWritable(toBoard, userID); err != nil {
+		return nil, err
+	}
+
+	fromSchema, err := model.ParsePropertySchema(fromBoard)
+	if err != nil {
+		return nil, err
+	}
+	toSchema, err := model.ParsePropertySchema(toBoard)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := a.store.GetSubTree2(fromBoard.ID, cardID, model.QuerySubtreeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range blocks {
+		if blocks[i].ID != cardID {
+			continue
+		}
+		if props, ok := blocks[i].Fields["properties"].(map[string]interface{}); ok {
+			blocks[i].Fields["properties"] = model.RemapProperties(props, fromSchema, toSchema)
+		}
+	}
+
+	copiedFiles, err := a.copyMovedFiles(fromBoard, toBoard, blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	movedBlocks, err := a.store.MoveBlocks(blocks, toBoard.ID, userID)
+	if err != nil {
+		a.removeFiles(copiedFiles)
+		return nil, err
+	}
+	a.removeFiles(sourceFilePaths(fromBoard, blocks))
+
+	a.logger.Debug("MoveCard",
+		mlog.String("cardID", cardID),
+		mlog.String("fromBoardID", fromBoard.ID),
+		mlog.String("toBoardID", toBoard.ID),
+		mlog.Int("blocks", len(movedBlocks)),
+	)
+
+	a.enqueue(func(a *App) error {
+		for _, block := range movedBlocks {
+			a.wsAdapter.BroadcastBlockDelete(fromBoard.TeamID, block.ID, fromBoard.ID)
+			a.wsAdapter.BroadcastBlockChange(toBoard.TeamID, block)
+		}
+		return nil
+	})
+	return movedBlocks, nil
+}
+
+// CreateCard creates a card with the given title on the board. If the
+// description is set, it is added as the first text block of the card.
+func (a *App) CreateCard(boardID, title, description, userID string) (*model.Block, error) {
+	now := utils.GetMillis()
+	card := model.Block{
+		ID:         utils.NewID(utils.IDTypeCard),
+		ParentID:   boardID,
+		BoardID:    boardID,
+		Type:       model.TypeCard,
+		Title:      title,
+		CreatedBy:  userID,
+		ModifiedBy: userID,
+		CreateAt:   now,
+		UpdateAt:   now,
+		Fields: map[string]interface{}{
+			"icon":         "",
+			"properties":   map[string]interface{}{},
+			"contentOrder": []interface{}{},
+		},
+	}
+	blocks := []model.Block{card}
+
+	if description != "" {
+		text := model.Block{
+			ID:         utils.NewID(utils.IDTypeBlock),
+			ParentID:   card.ID,
+			BoardID:    boardID,
+			Type:       model.TypeText,
+			Title:      description,
+			CreatedBy:  userID,
+			ModifiedBy: userID,
+			CreateAt:   now,
+			UpdateAt:   now,
+			Fields:     map[string]interface{}{},
+		}
+		blocks[0].Fields["contentOrder"] = []interface{}{text.ID}
+		blocks = append(blocks, text)
+	}
+
+	blocks, err := a.InsertBlocks(blocks, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	return &blocks[0], nil
+}
+
+// AddComment adds a comment with the given text to a card.
+func (a *App) AddComment(cardID, text, userID string) (*model.Block, error) {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil {
+		return nil, model.NewErrBlockNotFound(cardID)
+	}
+	if card.Type != model.TypeCard {
+		return nil, ErrBlockNotCard
+	}
+
+	now := utils.GetMillis()
+	comment := model.Block{
+		ID:         utils.NewID(utils.IDTypeBlock),
+		ParentID:   card.ID,
+		BoardID:    card.BoardID,
+		Type:       model.TypeComment,
+		Title:      text,
+		CreatedBy:  userID,
+		ModifiedBy: userID,
+		CreateAt:   now,
+		UpdateAt:   now,
+		Fields:     map[string]interface{}{},
+	}
+	if err := a.InsertBlock(comment, userID); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// SetCardProperties sets the property values of a card, given by
+// property name or ID, as resolved by PropSchema.ResolvePropertyValues.
+func (a *App) SetCardProperties(cardID string, values map[string]string, userID string) (*model.Block, error) {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil {
+		return nil, model.NewErrBlockNotFound(cardID)
+	}
+	if card.Type != model.TypeCard {
+		return nil, ErrBlockNotCard
+	}
+
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := schema.ResolvePropertyValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := map[string]interface{}{}
+	if current, ok := card.Fields["properties"].(map[string]interface{}); ok {
+		for key, value := range current {
+			properties[key] = value
+		}
+	}
+	for key, value := range resolved {
+		if value == nil {
+			delete(properties, key)
+			continue
+		}
+		properties[key] = value
+	}
+
+	patch := &model.BlockPatch{
+		UpdatedFields: map[string]interface{}{"properties": properties},
+	}
+	if err := a.PatchBlock(card.ID, patch, userID); err != nil {
+		return nil, err
+	}
+	return a.store.GetBlock(card.ID)
+}
+
+// DuplicateCard copies a card and its content, with fresh IDs. The
+// options control whether comments, attachments and the checklist state
+// are copied. All the blocks are inserted in a single transaction.
+func (a *App) DuplicateCard(boardID, cardID, userID string, opts model.DuplicateCardOptions) ([]model.Block, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkBoardWritable(board, userID); err != nil {
+		return nil, err
+	}
+
+	blocks, err := a.store.GetSubTree2(boardID, cardID, model.QuerySubtreeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	blocks = opts.FilterBlocks(cardID, blocks)
+	if len(blocks) == 0 || blocks[0].ID != cardID {
+		return nil, model.NewErrBlockNotFound(cardID)
+	}
+	if blocks[0].Type != model.TypeCard {
+		return nil, ErrBlockNotCard
+	}
+
+	var copiedFiles []string
+	if opts.IncludeAttachments {
+		copiedFiles, err = a.copyAttachmentFiles(board, blocks)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	blocks = model.GenerateBlockIDs(blocks, a.logger)
+	if err := a.store.InsertBlocks(blocks, userID); err != nil {
+		a.removeFiles(copiedFiles)
+		return nil, err
+	}
+	a.metrics.IncrementBlocksInserted(len(blocks))
+
+	a.enqueue(func(a *App) error {
+		for _, block := range blocks {
+			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		}
+		return nil
+	})
+	return blocks, nil
+}
+
+// copyAttachmentFiles copies the files of the image blocks so the copies
+// don't share their files with the originals, and returns the paths of
+// the copied files. If a copy fails, the files already copied are removed.
+func (a *App) copyAttachmentFiles(board *model.Board, blocks []model.Block) ([]string, error) {
+	copiedFiles := []string{}
+	for i := range blocks {
+		if blocks[i].Type != model.TypeImage {
+			continue
+		}
+		fileID, ok := blocks[i].Fields["fileId"].(string)
+		if !ok || fileID == "" {
+			continue
+		}
+
+		destFilename := utils.NewID(utils.IDTypeNone) + filepath.Ext(fileID)
+		sourceFilePath := filepath.Join(board.TeamID, board.ID, fileID)
+		destinationFilePath := filepath.Join(board.TeamID, board.ID, destFilename)
+
+		if err := a.filesBackend.CopyFile(sourceFilePath, destinationFilePath); err != nil {
+			a.removeFiles(copiedFiles)
+			return nil, fmt.Errorf("cannot copy file %s: %w", sourceFilePath, err)
+		}
+		copiedFiles = append(copiedFiles, destinationFilePath)
+		blocks[i].Fields["fileId"] = destFilename
+	}
+	return copiedFiles, nil
+}
+
+// copyMovedFiles copies the files of the image blocks moved to another
+// board, keeping their names, and returns the paths of the copies. If a
+// copy fails, the files already copied are removed.
+func (a *App) copyMovedFiles(fromBoard, toBoard *model.Board, blocks []model.Block) ([]string, error) {
+	copiedFiles := []string{}
+	for _, fileID := range imageFileIDs(blocks) {
+		sourceFilePath := filepath.Join(fromBoard.TeamID, fromBoard.ID, fileID)
+		destinationFilePath := filepath.Join(toBoard.TeamID, toBoard.ID, fileID)
+
+		if err := a.filesBackend.CopyFile(sourceFilePath, destinationFilePath); err != nil {
+			a.removeFiles(copiedFiles)
+			return nil, fmt.Errorf("cannot copy file %s: %w", sourceFilePath, err)
+		}
+		copiedFiles = append(copiedFiles, destinationFilePath)
+	}
+	return copiedFiles, nil
+}
+
+// sourceFilePaths returns the paths of the files of the image blocks in
+// a board.
+func sourceFilePaths(board *model.Board, blocks []model.Block) []string {
+	filePaths := []string{}
+	for _, fileID := range imageFileIDs(blocks) {
+		filePaths = append(filePaths, filepath.Join(board.TeamID, board.ID, fileID))
+	}
+	return filePaths
+}
+
+func imageFileIDs(blocks []model.Block) []string {
+	fileIDs := []string{}
+	for _, block := range blocks {
+		if block.Type != model.TypeImage {
+			continue
+		}
+		if fileID, ok := block.Fields["fileId"].(string); ok && fileID != "" {
+			fileIDs = append(fileIDs, fileID)
+		}
+	}
+	return fileIDs
+}
+
+// removeFiles removes the files copied for an operation that failed, or
+// the originals of the files moved.
+func (a *App) removeFiles(filePaths []string) {
+	for _, filePath := range filePaths {
+		if err := a.filesBackend.RemoveFile(filePath); err != nil {
+			a.logger.Error("cannot remove file", mlog.String("path", filePath), mlog.Err(err))
+		}
+	}
+}
+
 func (a *App) GetBlocksWithBoardID(boardID string) ([]model.Block, error) {
 	return a.store.GetBlocksWithBoardID(boardID)
 }
@@ -63,27 +413,47 @@ func (a *App) PatchBlock(blockID string, blockPatch *model.BlockPatch, modifiedB
 		return nil
 	}
 
+	if blockPatch.UpdateAt != nil && *blockPatch.UpdateAt != oldBlock.UpdateAt {
+		return model.NewErrVersionConflict(blockID)
+	}
+
+	if err = a.filterBlockPatch(blockID, blockPatch); err != nil {
+		return err
+	}
+
 	board, err := a.store.GetBoard(oldBlock.BoardID)
 	if err != nil {
 		return err
 	}
+	if err = a.checkBoardWritable(board, modifiedByID); err != nil {
+		return err
+	}
+
+	if err = a.enforceWIPLimits([]model.Block{*oldBlock}, []model.BlockPatch{*blockPatch}, modifiedByID); err != nil {
+		return err
+	}
+
+	if err = a.checkCardPropertyPatches(map[string]*model.Board{board.ID: board}, []model.Block{*oldBlock}, []model.BlockPatch{*blockPatch}); err != nil {
+		return err
+	}
 
 	err = a.store.PatchBlock(blockID, blockPatch, modifiedByID)
 	if err != nil {
 		return err
 	}
+	a.recordBoardEdit(board.ID, modifiedByID)
 
 	a.metrics.IncrementBlocksPatched(1)
 	block, err := a.store.GetBlock(blockID)
 	if err != nil {
 		return nil
 	}
-	a.blockChangeNotifier.Enqueue(func() error {
+	a.enqueue(func(a *App) error {
 		// broadcast on websocket
 		a.wsAdapter.BroadcastBlockChange(board.TeamID, *block)
 
 		// broadcast on webhooks
-		a.webhook.NotifyUpdate(*block)
+		a.webhook.NotifyBlockChange(model.BoardWebhookEventBlockUpdated, *block)
 
 		// send notifications
 		a.notifyBlockChanged(notify.Update, block, oldBlock, modifiedByID)
@@ -102,12 +472,44 @@ func (a *App) PatchBlocks(teamID string, blockPatches *model.BlockPatchBatch, mo
 		oldBlocks = append(oldBlocks, *oldBlock)
 	}
 
-	err := a.store.PatchBlocks(blockPatches, modifiedByID)
+	for i := range blockPatches.BlockPatches {
+		if i >= len(blockPatches.BlockIDs) {
+			break
+		}
+		if updateAt := blockPatches.BlockPatches[i].UpdateAt; updateAt != nil && *updateAt != oldBlocks[i].UpdateAt {
+			return model.NewErrVersionConflict(oldBlocks[i].ID)
+		}
+		if err := a.filterBlockPatch(blockPatches.BlockIDs[i], &blockPatches.BlockPatches[i]); err != nil {
+			return err
+		}
+	}
+
+	boardIDs := make([]string, 0, len(oldBlocks))
+	for _, oldBlock := range oldBlocks {
+		boardIDs = append(boardIDs, oldBlock.BoardID)
+	}
+	boards, err := a.getWritableBoards(boardIDs, modifiedByID)
+	if err != nil {
+		return err
+	}
+
+	if err = a.enforceWIPLimits(oldBlocks, blockPatches.BlockPatches, modifiedByID); err != nil {
+		return err
+	}
+
+	if err = a.checkCardPropertyPatches(boards, oldBlocks, blockPatches.BlockPatches); err != nil {
+		return err
+	}
+
+	err = a.store.PatchBlocks(blockPatches, modifiedByID)
 	if err != nil {
 		return err
 	}
+	for _, boardID := range boardIDs {
+		a.recordBoardEdit(boardID, modifiedByID)
+	}
 
-	a.blockChangeNotifier.Enqueue(func() error {
+	a.enqueue(func(a *App) error {
 		a.metrics.IncrementBlocksPatched(len(oldBlocks))
 		for i, blockID := range blockPatches.BlockIDs {
 			newBlock, err := a.store.GetBlock(blockID)
@@ -115,7 +517,7 @@ func (a *App) PatchBlocks(teamID string, blockPatches *model.BlockPatchBatch, mo
 				return nil
 			}
 			a.wsAdapter.BroadcastBlockChange(teamID, *newBlock)
-			a.webhook.NotifyUpdate(*newBlock)
+			a.webhook.NotifyBlockChange(model.BoardWebhookEventBlockUpdated, *newBlock)
 			a.notifyBlockChanged(notify.Update, newBlock, &oldBlocks[i], modifiedByID)
 		}
 		return nil
@@ -128,13 +530,27 @@ func (a *App) InsertBlock(block model.Block, modifiedByID string) error {
 	if bErr != nil {
 		return bErr
 	}
+	if bErr = a.checkBoardWritable(board, modifiedByID); bErr != nil {
+		return bErr
+	}
+
+	title, fErr := a.filterText(block.ID, block.Title)
+	if fErr != nil {
+		return fErr
+	}
+	block.Title = title
+
+	if pErr := a.checkCardProperties(board, []model.Block{block}, nil); pErr != nil {
+		return pErr
+	}
 
 	err := a.store.InsertBlock(&block, modifiedByID)
 	if err == nil {
-		a.blockChangeNotifier.Enqueue(func() error {
+		a.recordBoardEdit(board.ID, modifiedByID)
+		a.enqueue(func(a *App) error {
 			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
 			a.metrics.IncrementBlocksInserted(1)
-			a.webhook.NotifyUpdate(block)
+			a.webhook.NotifyBlockChange(model.BoardWebhookEventBlockCreated, block)
 			a.notifyBlockChanged(notify.Add, &block, nil, modifiedByID)
 			return nil
 		})
@@ -159,6 +575,17 @@ func (a *App) InsertBlocks(blocks []model.Block, modifiedByID string, allowNotif
 	if err != nil {
 		return nil, err
 	}
+	if err = a.checkBoardWritable(board, modifiedByID); err != nil {
+		return nil, err
+	}
+
+	// filter all the blocks first, so a rejection inserts none of them
+	if err = a.filterBlocks(blocks); err != nil {
+		return nil, err
+	}
+	if err = a.checkCardProperties(board, blocks, nil); err != nil {
+		return nil, err
+	}
 
 	needsNotify := make([]model.Block, 0, len(blocks))
 	for i := range blocks {
@@ -171,11 +598,12 @@ func (a *App) InsertBlocks(blocks []model.Block, modifiedByID string, allowNotif
 		a.wsAdapter.BroadcastBlockChange(board.TeamID, blocks[i])
 		a.metrics.IncrementBlocksInserted(1)
 	}
+	a.recordBoardEdit(board.ID, modifiedByID)
 
-	a.blockChangeNotifier.Enqueue(func() error {
+	a.enqueue(func(a *App) error {
 		for _, b := range needsNotify {
 			block := b
-			a.webhook.NotifyUpdate(block)
+			a.webhook.NotifyBlockChange(model.BoardWebhookEventBlockCreated, block)
 			if allowNotifications {
 				a.notifyBlockChanged(notify.Add, &block, nil, modifiedByID)
 			}
@@ -186,6 +614,53 @@ func (a *App) InsertBlocks(blocks []model.Block, modifiedByID string, allowNotif
 	return blocks, nil
 }
 
+// FindDuplicateCards returns, for each newly inserted card of a board
+// that has opted in to duplicate detection, the existing cards with a
+// similar title. Cards without suggestions are not included.
+func (a *App) FindDuplicateCards(boardID string, newBlocks []model.Block) (map[string][]model.DuplicateCardSuggestion, error) {
+	duplicates := map[string][]model.DuplicateCardSuggestion{}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if !board.DetectsDuplicateCards() {
+		return duplicates, nil
+	}
+
+	newCardIDs := map[string]bool{}
+	for _, block := range newBlocks {
+		if block.Type == model.TypeCard {
+			newCardIDs[block.ID] = true
+		}
+	}
+	if len(newCardIDs) == 0 {
+		return duplicates, nil
+	}
+
+	cards, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	existingCards := make([]model.Block, 0, len(cards))
+	for _, card := range cards {
+		if isTemplate, _ := card.Fields["isTemplate"].(bool); !newCardIDs[card.ID] && !isTemplate {
+			existingCards = append(existingCards, card)
+		}
+	}
+
+	for _, block := range newBlocks {
+		if !newCardIDs[block.ID] {
+			continue
+		}
+		if suggestions := model.FindDuplicateCards(block.Title, existingCards); len(suggestions) > 0 {
+			duplicates[block.ID] = suggestions
+		}
+	}
+	return duplicates, nil
+}
+
 func (a *App) CopyCardFiles(sourceBoardID string, blocks []model.Block) error {
 	// Images attached in cards have a path comprising the card's board ID.
 	// When we create a template from this board, we need to copy the files
@@ -238,6 +713,15 @@ func (a *App) GetBlockByID(blockID string) (*model.Block, error) {
 	return a.store.GetBlock(blockID)
 }
 
+// GetSubTree returns the block and its descendants up to depth levels
+// below it.
+func (a *App) GetSubTree(boardID, blockID string, depth int) ([]model.Block, error) {
+	if depth > model.MaxSubtreeDepth {
+		depth = model.MaxSubtreeDepth
+	}
+	return a.store.GetSubTree2(boardID, blockID, model.QuerySubtreeOptions{Depth: depth})
+}
+
 func (a *App) DeleteBlock(blockID string, modifiedBy string) error {
 	block, err := a.store.GetBlock(blockID)
 	if err != nil {
@@ -248,6 +732,9 @@ func (a *App) DeleteBlock(blockID string, modifiedBy string) error {
 	if err != nil {
 		return err
 	}
+	if err = a.checkBoardWritable(board, modifiedBy); err != nil {
+		return err
+	}
 
 	if block == nil {
 		// deleting non-existing block not considered an error
@@ -258,6 +745,7 @@ func (a *App) DeleteBlock(blockID string, modifiedBy string) error {
 	if err != nil {
 		return err
 	}
+	a.recordBoardEdit(board.ID, modifiedBy)
 
 	if block.Type == model.TypeImage {
 		fileName, fileIDExists := block.Fields["fileId"]
@@ -273,9 +761,10 @@ func (a *App) DeleteBlock(blockID string, modifiedBy string) error {
 		}
 	}
 
-	a.blockChangeNotifier.Enqueue(func() error {
+	a.enqueue(func(a *App) error {
 		a.wsAdapter.BroadcastBlockDelete(board.TeamID, blockID, block.BoardID)
 		a.metrics.IncrementBlocksDeleted(1)
+		a.webhook.NotifyBlockChange(model.BoardWebhookEventBlockDeleted, *block)
 		a.notifyBlockChanged(notify.Delete, block, block, modifiedBy)
 		return nil
 	})
@@ -304,10 +793,19 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 		return nil, nil
 	}
 
+	board, err := a.store.GetBoard(blocks[0].BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkBoardWritable(board, modifiedBy); err != nil {
+		return nil, err
+	}
+
 	err = a.store.UndeleteBlock(blockID, modifiedBy)
 	if err != nil {
 		return nil, err
 	}
+	a.recordBoardEdit(board.ID, modifiedBy)
 
 	block, err := a.store.GetBlock(blockID)
 	if err != nil {
@@ -319,15 +817,10 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 		return nil, nil
 	}
 
-	board, err := a.store.GetBoard(block.BoardID)
-	if err != nil {
-		return nil, err
-	}
-
-	a.blockChangeNotifier.Enqueue(func() error {
+	a.enqueue(func(a *App) error {
 		a.wsAdapter.BroadcastBlockChange(board.TeamID, *block)
 		a.metrics.IncrementBlocksInserted(1)
-		a.webhook.NotifyUpdate(*block)
+		a.webhook.NotifyBlockChange(model.BoardWebhookEventBlockCreated, *block)
 		a.notifyBlockChanged(notify.Add, block, nil, modifiedBy)
 		return nil
 	})
@@ -377,6 +870,33 @@ func (a *App) notifyBlockChanged(action notify.Action, block *model.Block, oldBl
 	a.notifications.BlockChanged(evt)
 }
 
+// notifyBoardChanged informs the notification backends of a change to the
+// board itself, such as its description.
+func (a *App) notifyBoardChanged(action notify.Action, board *model.Board, oldBoard *model.Board, modifiedByID string) {
+	// don't notify if notifications service disabled, or board change is generated via system user.
+	if a.notifications == nil || modifiedByID == model.SystemUserID {
+		return
+	}
+
+	boardMember, _ := a.GetMemberForBoard(board.ID, modifiedByID)
+	if boardMember == nil {
+		// create temporary guest board member
+		boardMember = &model.BoardMember{
+			BoardID: board.ID,
+			UserID:  modifiedByID,
+		}
+	}
+
+	evt := notify.BoardChangeEvent{
+		Action:     action,
+		TeamID:     board.TeamID,
+		Board:      board,
+		BoardOld:   oldBoard,
+		ModifiedBy: boardMember,
+	}
+	a.notifications.BoardChanged(evt)
+}
+
 const (
 	maxSearchDepth = 50
 )
@@ -7,6 +7,9 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest/mock"
+	"github.com/mattermost/mattermost-server/v6/shared/filestore/mocks"
 )
 
 type blockError struct {
@@ -43,6 +46,212 @@ func TestInsertBlock(t *testing.T) {
 	})
 }
 
+func TestCreateCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: testBoardID}
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	t.Run("without description", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), "user-id-1").Return(nil)
+
+		card, err := th.App.CreateCard(testBoardID, "new card", "", "user-id-1")
+		require.NoError(t, err)
+		require.NotEmpty(t, card.ID)
+		require.Equal(t, model.BlockType(model.TypeCard), card.Type)
+		require.Equal(t, "new card", card.Title)
+		require.Equal(t, testBoardID, card.ParentID)
+		require.Equal(t, "user-id-1", card.CreatedBy)
+		require.Empty(t, card.Fields["contentOrder"])
+	})
+
+	t.Run("with description", func(t *testing.T) {
+		var text *model.Block
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), "user-id-1").Return(nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), "user-id-1").DoAndReturn(func(block *model.Block, _ string) error {
+			text = block
+			return nil
+		})
+
+		card, err := th.App.CreateCard(testBoardID, "new card", "the description", "user-id-1")
+		require.NoError(t, err)
+		require.NotNil(t, text)
+		require.Equal(t, model.BlockType(model.TypeText), text.Type)
+		require.Equal(t, "the description", text.Title)
+		require.Equal(t, card.ID, text.ParentID)
+		require.Equal(t, []interface{}{text.ID}, card.Fields["contentOrder"])
+	})
+}
+
+func TestAddComment(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	card := &model.Block{ID: "card-id", BoardID: testBoardID, Type: model.TypeCard}
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	t.Run("success", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID}, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), "user-id-1").Return(nil)
+
+		comment, err := th.App.AddComment("card-id", "looks good", "user-id-1")
+		require.NoError(t, err)
+		require.Equal(t, model.BlockType(model.TypeComment), comment.Type)
+		require.Equal(t, "card-id", comment.ParentID)
+		require.Equal(t, testBoardID, comment.BoardID)
+		require.Equal(t, "looks good", comment.Title)
+	})
+
+	t.Run("not a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("text-id").Return(&model.Block{ID: "text-id", Type: model.TypeText}, nil)
+
+		_, err := th.App.AddComment("text-id", "looks good", "user-id-1")
+		require.ErrorIs(t, err, ErrBlockNotCard)
+	})
+}
+
+func TestSetCardProperties(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: testBoardID,
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	}
+	card := &model.Block{
+		ID:      "card-id",
+		BoardID: testBoardID,
+		Type:    model.TypeCard,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"notes": "old notes"},
+		},
+	}
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetBlock("card-id").Return(card, nil).AnyTimes()
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeView).Return([]model.Block{}, nil).AnyTimes()
+
+	t.Run("success", func(t *testing.T) {
+		var patch *model.BlockPatch
+		th.Store.EXPECT().PatchBlock("card-id", gomock.Any(), "user-id-1").DoAndReturn(func(_ string, p *model.BlockPatch, _ string) error {
+			patch = p
+			return nil
+		})
+
+		_, err := th.App.SetCardProperties("card-id", map[string]string{"Status": "done", "Notes": ""}, "user-id-1")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"status": "done"}, patch.UpdatedFields["properties"])
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		_, err := th.App.SetCardProperties("card-id", map[string]string{"Priority": "High"}, "user-id-1")
+		require.ErrorIs(t, err, model.ErrInvalidProperty)
+	})
+}
+
+func TestMoveCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	fromBoard := &model.Board{ID: "board-from", TeamID: "team-id"}
+	toBoard := &model.Board{ID: "board-to", TeamID: "team-id-2"}
+	card := &model.Block{ID: "card-id", BoardID: "board-from", ParentID: "board-from", Type: model.TypeCard}
+	blocks := []model.Block{
+		*card,
+		{ID: "image-id", BoardID: "board-from", ParentID: "card-id", Type: model.TypeImage, Fields: map[string]interface{}{"fileId": "image.png"}},
+	}
+	th.Store.EXPECT().GetBlock("card-id").Return(card, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard("board-from").Return(fromBoard, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard("board-to").Return(toBoard, nil).AnyTimes()
+	th.Store.EXPECT().IsErrNotFound(gomock.Any()).Return(false).AnyTimes()
+	th.Store.EXPECT().GetSubTree2("board-from", "card-id", gomock.Any()).Return(blocks, nil).AnyTimes()
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	t.Run("the files are moved with the card", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+
+		mockedFileBackend.On("CopyFile", "team-id/board-from/image.png", "team-id-2/board-to/image.png").Return(nil)
+		mockedFileBackend.On("RemoveFile", "team-id/board-from/image.png").Return(nil)
+		th.Store.EXPECT().MoveBlocks(gomock.Any(), "board-to", "user-id-1").Return(blocks, nil)
+
+		_, err := th.App.MoveCard("card-id", "board-to", "user-id-1")
+		require.NoError(t, err)
+		mockedFileBackend.AssertExpectations(t)
+	})
+
+	t.Run("the copied files are removed if the move fails", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+
+		mockedFileBackend.On("CopyFile", "team-id/board-from/image.png", "team-id-2/board-to/image.png").Return(nil)
+		mockedFileBackend.On("RemoveFile", "team-id-2/board-to/image.png").Return(nil)
+		th.Store.EXPECT().MoveBlocks(gomock.Any(), "board-to", "user-id-1").Return(nil, blockError{"error"})
+
+		_, err := th.App.MoveCard("card-id", "board-to", "user-id-1")
+		require.Error(t, err)
+		mockedFileBackend.AssertExpectations(t)
+		mockedFileBackend.AssertNotCalled(t, "RemoveFile", "team-id/board-from/image.png")
+	})
+}
+
+func TestDuplicateCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: testBoardID, TeamID: "team-id"}
+	blocks := []model.Block{
+		{ID: "card-id", BoardID: testBoardID, Type: model.TypeCard},
+		{ID: "image-id", BoardID: testBoardID, ParentID: "card-id", Type: model.TypeImage, Fields: map[string]interface{}{"fileId": "image.png"}},
+	}
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetSubTree2(testBoardID, "card-id", gomock.Any()).Return(blocks, nil).AnyTimes()
+
+	t.Run("copied files are removed if the insert fails", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+
+		var copiedPath string
+		mockedFileBackend.On("CopyFile", "team-id/test-board-id/image.png", mock.Anything).Run(func(args mock.Arguments) {
+			copiedPath = args.String(1)
+		}).Return(nil)
+		mockedFileBackend.On("RemoveFile", mock.Anything).Return(nil)
+		th.Store.EXPECT().InsertBlocks(gomock.Any(), "user-id-1").Return(blockError{"error"})
+
+		_, err := th.App.DuplicateCard(testBoardID, "card-id", "user-id-1", model.DuplicateCardOptions{IncludeAttachments: true})
+		require.Error(t, err)
+		mockedFileBackend.AssertCalled(t, "RemoveFile", copiedPath)
+	})
+}
+
+func TestPatchBlock(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	block := &model.Block{ID: "block-id", BoardID: testBoardID, UpdateAt: 100}
+	th.Store.EXPECT().GetBlock("block-id").Return(block, nil).AnyTimes()
+
+	t.Run("stale version", func(t *testing.T) {
+		updateAt := int64(50)
+		err := th.App.PatchBlock("block-id", &model.BlockPatch{UpdateAt: &updateAt}, "user-id-1")
+		require.True(t, model.IsErrVersionConflict(err))
+	})
+}
+
 func TestPatchBlocks(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
@@ -121,8 +330,10 @@ func TestUndeleteBlock(t *testing.T) {
 
 	t.Run("error scenerio", func(t *testing.T) {
 		block := model.Block{
-			ID: "block-id",
+			ID:      "block-id",
+			BoardID: testBoardID,
 		}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID}, nil)
 		th.Store.EXPECT().GetBlockHistory(
 			gomock.Eq("block-id"),
 			gomock.Eq(model.QueryBlockHistoryOptions{Limit: 1, Descending: true}),
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// RequestBoardAccess creates a pending request of the user for access to
+// a private board, and informs the admins of the board. Requesting again
+// replaces the message of the pending request.
+func (a *App) RequestBoardAccess(boardID, userID, message string) (*model.BoardAccessRequest, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+	if board.Type != model.BoardTypePrivate {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "only the access to private boards can be requested", map[string]interface{}{"boardId": boardID})
+	}
+
+	member, err := a.store.GetMemberForBoard(boardID, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if member != nil {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "the user is already a member of the board", map[string]interface{}{"boardId": boardID})
+	}
+
+	request, err := a.store.CreateBoardAccessRequest(&model.BoardAccessRequest{
+		BoardID: boardID,
+		UserID:  userID,
+		Message: message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.notifyBoardAccessRequested(board, request)
+	return request, nil
+}
+
+// GetAccessRequestsForBoard returns the pending access requests of the
+// board, the oldest first.
+func (a *App) GetAccessRequestsForBoard(boardID string) ([]*model.BoardAccessRequest, error) {
+	return a.store.GetAccessRequestsForBoard(boardID)
+}
+
+// ApproveBoardAccessRequest turns the pending access request of the user
+// into a membership of the board, with the role of the approval or the
+// default role of the board members.
+func (a *App) ApproveBoardAccessRequest(boardID, userID string, approval *model.BoardAccessApproval) (*model.BoardMember, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	member, err := a.store.ApproveBoardAccessRequest(approval.Member(board, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, newErrBoardAccessRequestNotFound(boardID, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.applyCategoryRules(userID, board.TeamID, []*model.Board{board})
+
+	go func() {
+		a.wsAdapter.BroadcastMemberChange(board.TeamID, boardID, member)
+		a.notifyMemberAdded(board, member)
+	}()
+
+	return member, nil
+}
+
+// DenyBoardAccessRequest removes the pending access request of the user
+// without creating a membership.
+func (a *App) DenyBoardAccessRequest(boardID, userID string) error {
+	err := a.store.DeleteBoardAccessRequest(boardID, userID, model.BoardMemberActionDenied)
+	if errors.Is(err, sql.ErrNoRows) {
+		return newErrBoardAccessRequestNotFound(boardID, userID)
+	}
+	return err
+}
+
+func newErrBoardAccessRequestNotFound(boardID, userID string) *model.CodedError {
+	return model.NewCodedError(model.ErrCodeNotFound, "board access request not found", map[string]interface{}{"boardId": boardID, "userId": userID})
+}
+
+// notifyBoardAccessRequested informs the active admins of the board of
+// the access request.
+func (a *App) notifyBoardAccessRequested(board *model.Board, request *model.BoardAccessRequest) {
+	if a.notifications == nil {
+		return
+	}
+
+	members, err := a.store.GetMembersForBoard(board.ID)
+	if err != nil {
+		a.logger.Error("cannot get the admins to notify of the access request", mlog.String("boardID", board.ID), mlog.Err(err))
+		return
+	}
+
+	adminIDs := []string{}
+	for _, member := range members {
+		if member.SchemeAdmin && !member.Inactive {
+			adminIDs = append(adminIDs, member.UserID)
+		}
+	}
+	if len(adminIDs) == 0 {
+		return
+	}
+
+	requester, err := a.store.GetUserByID(request.UserID)
+	if err != nil {
+		a.logger.Error("cannot get the user that requested the access", mlog.String("userID", request.UserID), mlog.Err(err))
+		return
+	}
+
+	go a.notifications.BoardAccessRequested(notify.BoardAccessRequestEvent{
+		Board:             board,
+		RequesterUsername: requester.Username,
+		Message:           request.Message,
+		UserIDs:           adminIDs,
+	})
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// GetBoardContent returns the blocks of the content of the board, in
+// order, and its description. The description of a board without content
+// is its legacy plain text one.
+func (a *App) GetBoardContent(boardID string) (*model.BoardContent, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	blocks, err := a.getBoardContentBlocks(board)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.BoardContent{
+		BoardID:     boardID,
+		Blocks:      blocks,
+		Description: board.Description,
+	}, nil
+}
+
+// SetBoardContent replaces the content of the board with the blocks, in
+// order, and renders the description of the board from them. The blocks
+// that are not part of the content yet get new IDs, and the blocks of the
+// content missing from the new one are deleted.
+func (a *App) SetBoardContent(boardID string, blocks []model.Block, userID string) (*model.BoardContent, error) {
+	if len(blocks) > model.MaxBoardContentBlocks {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest,
+			fmt.Sprintf("the content of a board can't have more than %d blocks", model.MaxBoardContentBlocks), nil)
+	}
+	for i := range blocks {
+		if err := model.IsValidBoardContentBlock(&blocks[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+	if err = a.checkBoardWritable(board, userID); err != nil {
+		return nil, err
+	}
+
+	existing, err := a.getBoardContentBlocks(board)
+	if err != nil {
+		return nil, err
+	}
+	existingByID := make(map[string]model.Block, len(existing))
+	for _, block := range existing {
+		existingByID[block.ID] = block
+	}
+
+	now := utils.GetMillis()
+	kept := map[string]bool{}
+	order := make([]interface{}, 0, len(blocks))
+	for i := range blocks {
+		block := &blocks[i]
+		// the IDs of other blocks are not reused, so the content can't
+		// take over the blocks of cards or of other boards
+		if old, ok := existingByID[block.ID]; ok && !kept[block.ID] {
+			block.CreatedBy = old.CreatedBy
+			block.CreateAt = old.CreateAt
+		} else {
+			block.ID = utils.NewID(utils.IDTypeBlock)
+			block.CreatedBy = userID
+			block.CreateAt = now
+		}
+		kept[block.ID] = true
+		order = append(order, block.ID)
+
+		block.BoardID = boardID
+		block.ParentID = boardID
+		block.Schema = 1
+		block.DeleteAt = 0
+		if block.Fields == nil {
+			block.Fields = map[string]interface{}{}
+		}
+	}
+
+	if err = a.filterBlocks(blocks); err != nil {
+		return nil, err
+	}
+
+	removed := []string{}
+	for _, block := range existing {
+		if !kept[block.ID] {
+			removed = append(removed, block.ID)
+		}
+	}
+
+	description := model.RenderBoardContent(blocks)
+	var updatedBoard *model.Board
+	err = a.store.RunInTransaction(func(tx store.Store) error {
+		for i := range blocks {
+			if txErr := tx.InsertBlock(&blocks[i], userID); txErr != nil {
+				return txErr
+			}
+		}
+		for _, blockID := range removed {
+			if txErr := tx.DeleteBlock(blockID, userID); txErr != nil {
+				return txErr
+			}
+		}
+
+		patch := &model.BoardPatch{
+			Description:       &description,
+			UpdatedProperties: map[string]interface{}{model.BoardPropertyContentOrder: order},
+		}
+		var txErr error
+		updatedBoard, txErr = tx.PatchBoard(boardID, patch, userID)
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for _, block := range blocks {
+			a.wsAdapter.BroadcastBlockChange(updatedBoard.TeamID, block)
+		}
+		for _, blockID := range removed {
+			a.wsAdapter.BroadcastBlockDelete(updatedBoard.TeamID, blockID, boardID)
+		}
+		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
+	}()
+
+	a.enqueue(func(a *App) error {
+		a.notifyBoardChanged(notify.Update, updatedBoard, board, userID)
+		return nil
+	})
+
+	return &model.BoardContent{
+		BoardID:     boardID,
+		Blocks:      blocks,
+		Description: description,
+	}, nil
+}
+
+// GetBoardContentHistory returns the past descriptions of the board, the
+// latest first, at most limit of them if limit is not zero.
+func (a *App) GetBoardContentHistory(boardID string, limit int) ([]*model.BoardContentRevision, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	boards, err := a.store.GetBoardHistory(boardID, model.QueryBoardHistoryOptions{Descending: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// the history has an entry for every change of the board, only the
+	// ones that changed the description are revisions of the content
+	revisions := []*model.BoardContentRevision{}
+	for i, entry := range boards {
+		if i+1 < len(boards) && boards[i+1].Description == entry.Description {
+			continue
+		}
+		revisions = append(revisions, &model.BoardContentRevision{
+			Description: entry.Description,
+			ModifiedBy:  entry.ModifiedBy,
+			UpdateAt:    entry.UpdateAt,
+		})
+		if limit > 0 && len(revisions) == limit {
+			break
+		}
+	}
+	return revisions, nil
+}
+
+// getBoardContentBlocks returns the content blocks of the board, in the
+// order of the board. The blocks missing from the order follow, the
+// oldest first.
+func (a *App) getBoardContentBlocks(board *model.Board) ([]model.Block, error) {
+	children, err := a.store.GetBlocksWithParent(board.ID, board.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	position := map[string]int{}
+	for i, id := range board.ContentOrder() {
+		position[id] = i
+	}
+
+	blocks := make([]model.Block, 0, len(children))
+	for _, block := range children {
+		if model.IsBoardContentType(block.Type) {
+			blocks = append(blocks, block)
+		}
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		pi, iOrdered := position[blocks[i].ID]
+		pj, jOrdered := position[blocks[j].ID]
+		switch {
+		case iOrdered && jOrdered:
+			return pi < pj
+		case iOrdered != jOrdered:
+			return iOrdered
+		default:
+			return blocks[i].CreateAt < blocks[j].CreateAt
+		}
+	})
+	return blocks, nil
+}
+
+// checkBoardDescriptionPatch returns an error if the patch changes the
+// description of a board that renders it from its content.
+func checkBoardDescriptionPatch(board *model.Board, patch *model.BoardPatch) error {
+	if patch.Description == nil || !board.HasBoardContent() {
+		return nil
+	}
+	for _, deleted := range patch.DeletedProperties {
+		if deleted == model.BoardPropertyContentOrder {
+			return nil
+		}
+	}
+	return model.NewCodedError(model.ErrCodeBadRequest,
+		"the description of the board is rendered from its content", map[string]interface{}{"boardId": board.ID})
+}
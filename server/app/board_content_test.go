@@ -0,0 +1,132 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestGetBoardContent(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:          "board-id",
+		Description: "## Goals",
+		Properties:  map[string]interface{}{model.BoardPropertyContentOrder: []interface{}{"heading-id", "text-id"}},
+	}
+	th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+	th.Store.EXPECT().GetBlocksWithParent("board-id", "board-id").Return([]model.Block{
+		{ID: "card-id", BoardID: "board-id", ParentID: "board-id", Type: model.TypeCard},
+		{ID: "unordered-id", BoardID: "board-id", ParentID: "board-id", Type: model.TypeText, CreateAt: 1},
+		{ID: "text-id", BoardID: "board-id", ParentID: "board-id", Type: model.TypeText},
+		{ID: "heading-id", BoardID: "board-id", ParentID: "board-id", Type: model.TypeHeading},
+	}, nil)
+
+	content, err := th.App.GetBoardContent("board-id")
+	require.NoError(t, err)
+	require.Equal(t, "## Goals", content.Description)
+	require.Len(t, content.Blocks, 3)
+	require.Equal(t, "heading-id", content.Blocks[0].ID)
+	require.Equal(t, "text-id", content.Blocks[1].ID)
+	require.Equal(t, "unordered-id", content.Blocks[2].ID)
+}
+
+func TestSetBoardContent(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:         "board-id",
+		TeamID:     "team-id",
+		Properties: map[string]interface{}{model.BoardPropertyContentOrder: []interface{}{"text-id", "old-id"}},
+	}
+	th.Store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	t.Run("replaces the content and renders the description", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithParent("board-id", "board-id").Return([]model.Block{
+			{ID: "text-id", BoardID: "board-id", ParentID: "board-id", Type: model.TypeText, CreatedBy: "author-id", CreateAt: 10},
+			{ID: "old-id", BoardID: "board-id", ParentID: "board-id", Type: model.TypeText},
+		}, nil)
+		th.expectRunInTransaction()
+		th.Store.EXPECT().InsertBlock(gomock.Any(), "user-id").Return(nil).Times(2)
+		th.Store.EXPECT().DeleteBlock("old-id", "user-id").Return(nil)
+
+		var patch *model.BoardPatch
+		th.Store.EXPECT().PatchBoard("board-id", gomock.Any(), "user-id").DoAndReturn(
+			func(_ string, p *model.BoardPatch, _ string) (*model.Board, error) {
+				patch = p
+				return board, nil
+			})
+
+		content, err := th.App.SetBoardContent("board-id", []model.Block{
+			{ID: "card-id", Type: model.TypeHeading, Title: "Goals", Fields: map[string]interface{}{"level": float64(2)}},
+			{ID: "text-id", Type: model.TypeText, Title: "Ship it"},
+		}, "user-id")
+		require.NoError(t, err)
+		require.Len(t, content.Blocks, 2)
+
+		// the ID of a block that is not part of the content is replaced
+		heading := content.Blocks[0]
+		require.NotEqual(t, "card-id", heading.ID)
+		require.Equal(t, "board-id", heading.ParentID)
+		require.Equal(t, "user-id", heading.CreatedBy)
+
+		text := content.Blocks[1]
+		require.Equal(t, "text-id", text.ID)
+		require.Equal(t, "author-id", text.CreatedBy)
+		require.Equal(t, int64(10), text.CreateAt)
+
+		require.Equal(t, "## Goals\n\nShip it", content.Description)
+		require.Equal(t, content.Description, *patch.Description)
+		require.Equal(t, []interface{}{heading.ID, "text-id"}, patch.UpdatedProperties[model.BoardPropertyContentOrder])
+	})
+
+	t.Run("invalid blocks are rejected", func(t *testing.T) {
+		_, err := th.App.SetBoardContent("board-id", []model.Block{{Type: model.TypeComment}}, "user-id")
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+	})
+}
+
+func TestGetBoardContentHistory(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().GetBoard("board-id").Return(&model.Board{ID: "board-id"}, nil)
+	th.Store.EXPECT().GetBoardHistory("board-id", model.QueryBoardHistoryOptions{Descending: true}).Return([]*model.Board{
+		{ID: "board-id", Description: "v2", ModifiedBy: "user-2", UpdateAt: 4},
+		{ID: "board-id", Description: "v2", ModifiedBy: "user-1", UpdateAt: 3},
+		{ID: "board-id", Description: "v1", ModifiedBy: "user-1", UpdateAt: 2},
+		{ID: "board-id", Description: "", ModifiedBy: "user-1", UpdateAt: 1},
+	}, nil)
+
+	revisions, err := th.App.GetBoardContentHistory("board-id", 2)
+	require.NoError(t, err)
+	require.Equal(t, []*model.BoardContentRevision{
+		{Description: "v2", ModifiedBy: "user-1", UpdateAt: 3},
+		{Description: "v1", ModifiedBy: "user-1", UpdateAt: 2},
+	}, revisions)
+}
+
+func TestPatchBoardRenderedDescription(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:         "board-id",
+		Properties: map[string]interface{}{model.BoardPropertyContentOrder: []interface{}{}},
+	}
+	th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+
+	description := "plain text"
+	_, err := th.App.PatchBoard(&model.BoardPatch{Description: &description}, "board-id", "user-id")
+	ce, ok := model.AsCodedError(err)
+	require.True(t, ok)
+	require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+}
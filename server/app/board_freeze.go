@@ -0,0 +1,153 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetBoardFreezeSchedule returns the scheduled freeze and unfreeze of the
+// board. A board without a schedule has an empty one.
+func (a *App) GetBoardFreezeSchedule(boardID string) (*model.BoardFreezeSchedule, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	schedule, err := a.store.GetBoardFreezeSchedule(boardID)
+	if a.store.IsErrNotFound(err) {
+		return &model.BoardFreezeSchedule{BoardID: boardID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// SetBoardFreezeSchedule replaces the scheduled freeze and unfreeze of
+// the board. An empty schedule cancels them.
+func (a *App) SetBoardFreezeSchedule(boardID string, schedule *model.BoardFreezeSchedule, userID string) (*model.BoardFreezeSchedule, error) {
+	now := utils.GetMillis()
+	if err := schedule.IsValid(now); err != nil {
+		return nil, err
+	}
+
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	schedule.BoardID = boardID
+	schedule.ModifiedBy = userID
+	schedule.UpdateAt = now
+
+	if schedule.IsEmpty() {
+		if err := a.store.DeleteBoardFreezeSchedule(boardID); err != nil {
+			return nil, err
+		}
+		return schedule, nil
+	}
+
+	if err := a.store.SaveBoardFreezeSchedule(schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// ApplyBoardFreezeSchedules freezes and unfreezes the boards whose
+// schedule is due, and informs their members. It is meant to run
+// periodically.
+func (a *App) ApplyBoardFreezeSchedules() {
+	now := utils.GetMillis()
+
+	schedules, err := a.store.GetDueBoardFreezeSchedules(now)
+	if err != nil {
+		a.logger.Error("cannot get the due board freeze schedules", mlog.Err(err))
+		return
+	}
+
+	for _, schedule := range schedules {
+		if err := a.applyBoardFreezeSchedule(schedule, now); err != nil {
+			a.logger.Error("cannot apply the freeze schedule of a board", mlog.String("boardID", schedule.BoardID), mlog.Err(err))
+		}
+	}
+}
+
+// applyBoardFreezeSchedule makes the board read-only or not, as the due
+// times of its schedule require, and keeps the times not due yet. The
+// schedules of deleted boards are dropped.
+func (a *App) applyBoardFreezeSchedule(schedule *model.BoardFreezeSchedule, now int64) error {
+	readOnly, ok := schedule.Apply(now)
+	if !ok {
+		return nil
+	}
+
+	board, err := a.GetBoard(schedule.BoardID)
+	if err != nil {
+		return err
+	}
+	if board == nil {
+		return a.store.DeleteBoardFreezeSchedule(schedule.BoardID)
+	}
+
+	// the board is changed before the schedule, so that a failure retries
+	// the whole schedule on the next run
+	if board.IsReadOnly() != readOnly {
+		patch := &model.BoardPatch{
+			UpdatedProperties: map[string]interface{}{model.BoardPropertyReadOnly: readOnly},
+		}
+		updatedBoard, err := a.PatchBoard(patch, board.ID, model.SystemUserID)
+		if err != nil {
+			return err
+		}
+
+		a.logger.Debug("applied the freeze schedule of a board",
+			mlog.String("boardID", board.ID),
+			mlog.Bool("readOnly", readOnly),
+		)
+		a.notifyBoardFreezeChanged(updatedBoard, readOnly)
+	}
+
+	if schedule.IsEmpty() {
+		return a.store.DeleteBoardFreezeSchedule(schedule.BoardID)
+	}
+	return a.store.SaveBoardFreezeSchedule(schedule)
+}
+
+// notifyBoardFreezeChanged informs the active members of the board that
+// it was frozen or unfrozen.
+func (a *App) notifyBoardFreezeChanged(board *model.Board, frozen bool) {
+	if a.notifications == nil {
+		return
+	}
+
+	members, err := a.store.GetMembersForBoard(board.ID)
+	if err != nil {
+		a.logger.Error("cannot get the members to notify of the board freeze", mlog.String("boardID", board.ID), mlog.Err(err))
+		return
+	}
+
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		if !member.Inactive {
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	go a.notifications.BoardFreezeChanged(notify.BoardFreezeEvent{
+		Board:   board,
+		Frozen:  frozen,
+		UserIDs: userIDs,
+	})
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestSetBoardFreezeSchedule(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board-id", TeamID: "team-id"}
+	later := utils.GetMillis() + time.Hour.Milliseconds()
+
+	t.Run("saves the schedule", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+
+		var saved *model.BoardFreezeSchedule
+		th.Store.EXPECT().SaveBoardFreezeSchedule(gomock.Any()).DoAndReturn(func(schedule *model.BoardFreezeSchedule) error {
+			saved = schedule
+			return nil
+		})
+
+		schedule, err := th.App.SetBoardFreezeSchedule("board-id", &model.BoardFreezeSchedule{FreezeAt: later}, "user-id")
+		require.NoError(t, err)
+		require.Equal(t, schedule, saved)
+		require.Equal(t, "board-id", saved.BoardID)
+		require.Equal(t, "user-id", saved.ModifiedBy)
+		require.Equal(t, later, saved.FreezeAt)
+		require.NotZero(t, saved.UpdateAt)
+	})
+
+	t.Run("an empty schedule is deleted", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().DeleteBoardFreezeSchedule("board-id").Return(nil)
+
+		schedule, err := th.App.SetBoardFreezeSchedule("board-id", &model.BoardFreezeSchedule{}, "user-id")
+		require.NoError(t, err)
+		require.True(t, schedule.IsEmpty())
+	})
+
+	t.Run("a past time is rejected", func(t *testing.T) {
+		_, err := th.App.SetBoardFreezeSchedule("board-id", &model.BoardFreezeSchedule{FreezeAt: 1}, "user-id")
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+	})
+
+	t.Run("the board must exist", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("missing-id").Return(nil, sql.ErrNoRows)
+
+		_, err := th.App.SetBoardFreezeSchedule("missing-id", &model.BoardFreezeSchedule{FreezeAt: later}, "user-id")
+		require.True(t, model.IsErrBoardNotFound(err))
+	})
+}
+
+func TestApplyBoardFreezeSchedules(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	later := utils.GetMillis() + time.Hour.Milliseconds()
+	th.Store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	t.Run("a due freeze makes the board read-only and keeps the unfreeze", func(t *testing.T) {
+		board := &model.Board{ID: "board-id", TeamID: "team-id"}
+		frozen := &model.Board{ID: "board-id", TeamID: "team-id", Properties: map[string]interface{}{model.BoardPropertyReadOnly: true}}
+
+		th.Store.EXPECT().GetDueBoardFreezeSchedules(gomock.Any()).Return([]*model.BoardFreezeSchedule{
+			{BoardID: "board-id", FreezeAt: 1, UnfreezeAt: later},
+		}, nil)
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil).Times(2)
+		th.Store.EXPECT().PatchBoard("board-id", &model.BoardPatch{
+			UpdatedProperties: map[string]interface{}{model.BoardPropertyReadOnly: true},
+		}, model.SystemUserID).Return(frozen, nil)
+		th.Store.EXPECT().SaveBoardFreezeSchedule(&model.BoardFreezeSchedule{BoardID: "board-id", UnfreezeAt: later}).Return(nil)
+
+		th.App.ApplyBoardFreezeSchedules()
+	})
+
+	t.Run("a due unfreeze of an unfrozen board only deletes the schedule", func(t *testing.T) {
+		board := &model.Board{ID: "board-id", TeamID: "team-id"}
+
+		th.Store.EXPECT().GetDueBoardFreezeSchedules(gomock.Any()).Return([]*model.BoardFreezeSchedule{
+			{BoardID: "board-id", UnfreezeAt: 1},
+		}, nil)
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().DeleteBoardFreezeSchedule("board-id").Return(nil)
+
+		th.App.ApplyBoardFreezeSchedules()
+	})
+
+	t.Run("the schedule of a deleted board is deleted", func(t *testing.T) {
+		th.Store.EXPECT().GetDueBoardFreezeSchedules(gomock.Any()).Return([]*model.BoardFreezeSchedule{
+			{BoardID: "deleted-id", FreezeAt: 1},
+		}, nil)
+		th.Store.EXPECT().GetBoard("deleted-id").Return(nil, sql.ErrNoRows)
+		th.Store.EXPECT().DeleteBoardFreezeSchedule("deleted-id").Return(nil)
+
+		th.App.ApplyBoardFreezeSchedules()
+	})
+}
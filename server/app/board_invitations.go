@@ -0,0 +1,139 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var (
+	ErrInvitedUserNotFound      = errors.New("invited user not found")
+	ErrInvitedUserAlreadyMember = errors.New("invited user is already a member of the board")
+	ErrBoardInvitationNotFound  = errors.New("board invitation not found")
+)
+
+// InviteToBoard creates a pending invitation for the user identified by
+// the username or email of the request. The user only becomes a member
+// of the board once the invitation is accepted.
+func (a *App) InviteToBoard(boardID, invitedBy string, request *model.BoardInvitationRequest) (*model.BoardInvitation, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewErrBoardNotFound(boardID)
+		}
+		return nil, err
+	}
+
+	invitee, err := a.getInvitee(request)
+	if err != nil {
+		return nil, err
+	}
+
+	existingMembership, err := a.store.GetMemberForBoard(boardID, invitee.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if existingMembership != nil {
+		return nil, ErrInvitedUserAlreadyMember
+	}
+
+	invitation := &model.BoardInvitation{
+		BoardID:         boardID,
+		UserID:          invitee.ID,
+		InvitedBy:       invitedBy,
+		SchemeAdmin:     request.SchemeAdmin,
+		SchemeEditor:    request.SchemeEditor,
+		SchemeCommenter: request.SchemeCommenter,
+		SchemeViewer:    request.SchemeViewer,
+	}
+
+	// invitations grant the same role as the added members by default.
+	if !invitation.SchemeAdmin && !invitation.SchemeEditor && !invitation.SchemeCommenter && !invitation.SchemeViewer {
+		defaultMember := board.NewDefaultMember(invitee.ID)
+		invitation.SchemeEditor = defaultMember.SchemeEditor
+		invitation.SchemeCommenter = defaultMember.SchemeCommenter
+		invitation.SchemeViewer = defaultMember.SchemeViewer
+	}
+
+	return a.store.CreateBoardInvitation(invitation)
+}
+
+func (a *App) getInvitee(request *model.BoardInvitationRequest) (*model.User, error) {
+	var user *model.User
+	var err error
+	if request.Username != "" {
+		user, err = a.store.GetUserByUsername(request.Username)
+	} else {
+		user, err = a.store.GetUserByEmail(request.Email)
+	}
+
+	if err != nil {
+		a.logger.Debug("Cannot find the invited user", mlog.Err(err))
+		return nil, ErrInvitedUserNotFound
+	}
+	if user == nil {
+		return nil, ErrInvitedUserNotFound
+	}
+
+	return user, nil
+}
+
+func (a *App) GetInvitationsForBoard(boardID string) ([]*model.BoardInvitation, error) {
+	return a.store.GetInvitationsForBoard(boardID)
+}
+
+func (a *App) GetInvitationsForUser(userID string) ([]*model.BoardInvitation, error) {
+	return a.store.GetInvitationsForUser(userID)
+}
+
+// AcceptBoardInvitation turns the pending invitation of the user into a
+// membership of the board.
+func (a *App) AcceptBoardInvitation(boardID, userID string) (*model.BoardMember, error) {
+	board, err := a.store.GetBoard(boardID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrBoardInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := a.store.AcceptBoardInvitation(boardID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrBoardInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.applyCategoryRules(userID, board.TeamID, []*model.Board{board})
+
+	go func() {
+		a.wsAdapter.BroadcastMemberChange(board.TeamID, boardID, member)
+		a.notifyMemberAdded(board, member)
+	}()
+
+	return member, nil
+}
+
+// DeclineBoardInvitation removes the pending invitation of the user
+// without creating a membership.
+func (a *App) DeclineBoardInvitation(boardID, userID string) error {
+	return a.deleteBoardInvitation(boardID, userID, model.BoardMemberActionDeclined)
+}
+
+// CancelBoardInvitation removes the pending invitation of a user on
+// behalf of the board admins.
+func (a *App) CancelBoardInvitation(boardID, userID string) error {
+	return a.deleteBoardInvitation(boardID, userID, model.BoardMemberActionCanceled)
+}
+
+func (a *App) deleteBoardInvitation(boardID, userID, action string) error {
+	err := a.store.DeleteBoardInvitation(boardID, userID, action)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrBoardInvitationNotFound
+	}
+	return err
+}
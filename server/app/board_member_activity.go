@@ -0,0 +1,171 @@
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const boardMemberActivityFlushInterval = time.Second * 30
+
+type boardMemberActivityKey struct {
+	boardID string
+	userID  string
+}
+
+type boardMemberActivityTimes struct {
+	viewAt int64
+	editAt int64
+}
+
+// boardMemberActivityRecorder buffers the views and edits of the boards in
+// memory and writes them to the store in batches, so they don't cost a
+// write each.
+type boardMemberActivityRecorder struct {
+	store  store.Store
+	logger *mlog.Logger
+
+	mu      sync.Mutex
+	pending map[boardMemberActivityKey]boardMemberActivityTimes
+
+	done     chan struct{}
+	finished chan struct{}
+	stopOnce sync.Once
+}
+
+func newBoardMemberActivityRecorder(store store.Store, logger *mlog.Logger) *boardMemberActivityRecorder {
+	r := &boardMemberActivityRecorder{
+		store:    store,
+		logger:   logger,
+		pending:  map[boardMemberActivityKey]boardMemberActivityTimes{},
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	go r.loop()
+	return r
+}
+
+func (r *boardMemberActivityRecorder) recordView(boardID, userID string, viewAt int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := boardMemberActivityKey{boardID: boardID, userID: userID}
+	times := r.pending[key]
+	if viewAt > times.viewAt {
+		times.viewAt = viewAt
+	}
+	r.pending[key] = times
+}
+
+func (r *boardMemberActivityRecorder) recordEdit(boardID, userID string, editAt int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := boardMemberActivityKey{boardID: boardID, userID: userID}
+	times := r.pending[key]
+	if editAt > times.editAt {
+		times.editAt = editAt
+	}
+	r.pending[key] = times
+}
+
+// flush writes the pending activity to the store. The activity that can't
+// be saved is kept, merged with the activity recorded meanwhile.
+func (r *boardMemberActivityRecorder) flush() error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = map[boardMemberActivityKey]boardMemberActivityTimes{}
+	r.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	activities := make([]*model.BoardMemberActivity, 0, len(pending))
+	for key, times := range pending {
+		activities = append(activities, &model.BoardMemberActivity{
+			BoardID:    key.boardID,
+			UserID:     key.userID,
+			LastViewAt: times.viewAt,
+			LastEditAt: times.editAt,
+		})
+	}
+
+	if err := r.store.SaveBoardMemberActivity(activities); err != nil {
+		for key, times := range pending {
+			r.recordView(key.boardID, key.userID, times.viewAt)
+			r.recordEdit(key.boardID, key.userID, times.editAt)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *boardMemberActivityRecorder) loop() {
+	defer close(r.finished)
+
+	ticker := time.NewTicker(boardMemberActivityFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.flush(); err != nil {
+				r.logger.Error("cannot save board member activity", mlog.Err(err))
+			}
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// shutdown stops the periodic flush and saves the pending activity.
+func (r *boardMemberActivityRecorder) shutdown() {
+	r.stopOnce.Do(func() { close(r.done) })
+	<-r.finished
+
+	if err := r.flush(); err != nil {
+		r.logger.Error("cannot save board member activity on shutdown", mlog.Err(err))
+	}
+}
+
+// recordBoardEdit registers that the user changed the board. Changes made
+// by the system are not the activity of any member.
+func (a *App) recordBoardEdit(boardID, userID string) {
+	if userID == "" || userID == model.SystemUserID {
+		return
+	}
+	a.boardMemberActivity.recordEdit(boardID, userID, model.GetMillis())
+}
+
+// GetMembersWithActivityForBoard returns the members of the board with the
+// last time each one viewed and edited it.
+func (a *App) GetMembersWithActivityForBoard(boardID string) ([]*model.BoardMember, error) {
+	if err := a.boardMemberActivity.flush(); err != nil {
+		a.logger.Error("cannot save board member activity", mlog.Err(err))
+	}
+
+	members, err := a.store.GetMembersForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	activities, err := a.store.GetBoardMemberActivity(boardID)
+	if err != nil {
+		return nil, err
+	}
+	activityByUser := make(map[string]*model.BoardMemberActivity, len(activities))
+	for _, activity := range activities {
+		activityByUser[activity.UserID] = activity
+	}
+
+	for _, member := range members {
+		if activity, ok := activityByUser[member.UserID]; ok {
+			member.LastViewAt = activity.LastViewAt
+			member.LastEditAt = activity.LastEditAt
+		}
+	}
+	return members, nil
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardMemberActivity(t *testing.T) {
+	t.Run("views and edits are saved in a single batch, keeping the latest ones", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.App.boardMemberActivity.recordView("board-id", "user-id", 100)
+		th.App.boardMemberActivity.recordEdit("board-id", "user-id", 150)
+		th.App.boardMemberActivity.recordView("board-id", "user-id", 200)
+		th.App.boardMemberActivity.recordEdit("board-id", "user-id", 120)
+		th.App.boardMemberActivity.recordView("board-id", "other-user-id", 50)
+
+		th.Store.EXPECT().SaveBoardMemberActivity(gomock.Any()).DoAndReturn(func(activities []*model.BoardMemberActivity) error {
+			require.Len(t, activities, 2)
+			for _, activity := range activities {
+				if activity.UserID == "user-id" {
+					require.Equal(t, int64(200), activity.LastViewAt)
+					require.Equal(t, int64(150), activity.LastEditAt)
+				}
+			}
+			return nil
+		})
+		require.NoError(t, th.App.boardMemberActivity.flush())
+	})
+
+	t.Run("activity is kept if it can't be saved", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.App.boardMemberActivity.recordEdit("board-id", "user-id", 100)
+
+		th.Store.EXPECT().SaveBoardMemberActivity(gomock.Any()).Return(errors.New("db error"))
+		require.Error(t, th.App.boardMemberActivity.flush())
+
+		th.App.boardMemberActivity.recordView("board-id", "user-id", 200)
+
+		th.Store.EXPECT().SaveBoardMemberActivity([]*model.BoardMemberActivity{
+			{BoardID: "board-id", UserID: "user-id", LastViewAt: 200, LastEditAt: 100},
+		}).Return(nil)
+		require.NoError(t, th.App.boardMemberActivity.flush())
+	})
+
+	t.Run("changes made by the system are not recorded", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.App.recordBoardEdit("board-id", model.SystemUserID)
+
+		// nothing is pending, so the store isn't called
+		require.NoError(t, th.App.boardMemberActivity.flush())
+	})
+
+	t.Run("members include their activity", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.App.RecordBoardVisit("user-id", "board-id")
+
+		members := []*model.BoardMember{
+			{BoardID: "board-id", UserID: "user-id"},
+			{BoardID: "board-id", UserID: "idle-user-id"},
+		}
+		gomock.InOrder(
+			th.Store.EXPECT().SaveBoardMemberActivity(gomock.Any()).Return(nil),
+			th.Store.EXPECT().GetMembersForBoard("board-id").Return(members, nil),
+			th.Store.EXPECT().GetBoardMemberActivity("board-id").Return([]*model.BoardMemberActivity{
+				{BoardID: "board-id", UserID: "user-id", LastViewAt: 100, LastEditAt: 50},
+			}, nil),
+		)
+		// the visit itself is saved on shutdown
+		th.Store.EXPECT().SaveBoardVisits(gomock.Any()).Return(nil)
+
+		result, err := th.App.GetMembersWithActivityForBoard("board-id")
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Equal(t, int64(100), result[0].LastViewAt)
+		require.Equal(t, int64(50), result[0].LastEditAt)
+		require.Zero(t, result[1].LastViewAt)
+	})
+}
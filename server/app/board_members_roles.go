@@ -0,0 +1,80 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// UpdateBoardMembersRoles changes the roles of many members of the board
+// in a single transaction, recording the reason of the change in the
+// board members history. All the users of the update must be members of
+// the board already, and the board must keep at least one admin.
+func (a *App) UpdateBoardMembersRoles(boardID string, update *model.BoardMembersRolesUpdate) ([]*model.BoardMember, error) {
+	if err := update.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	members := make([]*model.BoardMember, 0, len(update.Members))
+	for _, roles := range update.Members {
+		member := roles.BoardMember(boardID)
+		if err = a.checkCustomRoles(member); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	saved := make([]*model.BoardMember, 0, len(members))
+	err = a.store.RunInTransaction(func(tx store.Store) error {
+		existing, txErr := tx.GetMembersForBoard(boardID)
+		if txErr != nil {
+			return txErr
+		}
+		isAdmin := make(map[string]bool, len(existing))
+		for _, member := range existing {
+			isAdmin[member.UserID] = member.SchemeAdmin
+		}
+
+		for _, member := range members {
+			if _, ok := isAdmin[member.UserID]; !ok {
+				return model.NewCodedError(model.ErrCodeBadRequest, "the user is not a member of the board", map[string]interface{}{"userId": member.UserID})
+			}
+			isAdmin[member.UserID] = member.SchemeAdmin
+		}
+
+		hasAdmin := false
+		for _, admin := range isAdmin {
+			hasAdmin = hasAdmin || admin
+		}
+		if !hasAdmin {
+			return ErrBoardMemberIsLastAdmin
+		}
+
+		for _, member := range members {
+			newMember, txErr := tx.SaveMemberRoles(member, update.Reason)
+			if txErr != nil {
+				return txErr
+			}
+			saved = append(saved, newMember)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for _, member := range saved {
+			a.wsAdapter.BroadcastMemberChange(board.TeamID, boardID, member)
+		}
+	}()
+
+	return saved, nil
+}
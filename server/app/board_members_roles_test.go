@@ -0,0 +1,87 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestUpdateBoardMembersRoles(t *testing.T) {
+	board := &model.Board{ID: "board-id", TeamID: "team-id", Type: model.BoardTypeOpen}
+	existing := []*model.BoardMember{
+		{BoardID: "board-id", UserID: "user-1", SchemeAdmin: true},
+		{BoardID: "board-id", UserID: "user-2", SchemeEditor: true},
+		{BoardID: "board-id", UserID: "user-3", SchemeEditor: true},
+	}
+
+	t.Run("changes the roles with the reason", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		update := &model.BoardMembersRolesUpdate{
+			Members: []*model.BoardMemberRoles{
+				{UserID: "user-2", SchemeViewer: true},
+				{UserID: "user-3", SchemeAdmin: true},
+			},
+			Reason: "quarterly access review",
+		}
+
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return(existing, nil)
+		viewer := &model.BoardMember{BoardID: "board-id", UserID: "user-2", SchemeViewer: true}
+		admin := &model.BoardMember{BoardID: "board-id", UserID: "user-3", SchemeAdmin: true}
+		th.Store.EXPECT().SaveMemberRoles(viewer, "quarterly access review").Return(viewer, nil)
+		th.Store.EXPECT().SaveMemberRoles(admin, "quarterly access review").Return(admin, nil)
+		// the changes are broadcast in the background
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return(existing, nil).AnyTimes()
+
+		members, err := th.App.UpdateBoardMembersRoles("board-id", update)
+		require.NoError(t, err)
+		require.Equal(t, []*model.BoardMember{viewer, admin}, members)
+	})
+
+	t.Run("rejects users that are not members", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		update := &model.BoardMembersRolesUpdate{
+			Members: []*model.BoardMemberRoles{{UserID: "user-4", SchemeViewer: true}},
+		}
+
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return(existing, nil)
+
+		_, err := th.App.UpdateBoardMembersRoles("board-id", update)
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+	})
+
+	t.Run("keeps an admin on the board", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		update := &model.BoardMembersRolesUpdate{
+			Members: []*model.BoardMemberRoles{{UserID: "user-1", SchemeEditor: true}},
+		}
+
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return(existing, nil)
+
+		_, err := th.App.UpdateBoardMembersRoles("board-id", update)
+		require.ErrorIs(t, err, ErrBoardMemberIsLastAdmin)
+	})
+
+	t.Run("rejects an invalid update", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		_, err := th.App.UpdateBoardMembersRoles("board-id", &model.BoardMembersRolesUpdate{})
+		require.Error(t, err)
+	})
+}
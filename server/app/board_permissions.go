@@ -0,0 +1,140 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetBoardPermissions returns the access configuration of the board, to
+// apply it to other boards.
+func (a *App) GetBoardPermissions(boardID string) (*model.BoardPermissions, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	members, err := a.store.GetMembersForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewBoardPermissions(board, members), nil
+}
+
+// ApplyBoardPermissions sets the type, the default member role and the
+// members of the board to the ones of the permissions, in a single
+// transaction. The members of the permissions whose user doesn't exist
+// are skipped. If replace is true, the members of the board missing from
+// the permissions are removed, otherwise they are kept. The members of
+// the permissions are returned, with their membership on the board.
+func (a *App) ApplyBoardPermissions(boardID string, permissions *model.BoardPermissions, replace bool, userID string) ([]*model.BoardMember, error) {
+	if err := permissions.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	members := make([]*model.BoardMember, 0, len(permissions.Members))
+	hasAdmin := false
+	for _, roles := range permissions.Members {
+		if user, err := a.store.GetUserByID(roles.UserID); err != nil || user == nil {
+			a.logger.Debug("skipping the permissions of an unknown user",
+				mlog.String("boardID", boardID),
+				mlog.String("userID", roles.UserID),
+			)
+			continue
+		}
+		member := roles.BoardMember(boardID)
+		if err := a.checkCustomRoles(member); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+		hasAdmin = hasAdmin || member.SchemeAdmin
+	}
+	if !hasAdmin {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "none of the admins of the permissions is a user of the server", nil)
+	}
+
+	var updatedBoard *model.Board
+	saved := make([]*model.BoardMember, 0, len(members))
+	added := []*model.BoardMember{}
+	removed := []string{}
+	err = a.store.RunInTransaction(func(tx store.Store) error {
+		patch := &model.BoardPatch{
+			Type:              &permissions.Type,
+			UpdatedProperties: map[string]interface{}{model.BoardPropertyDefaultMemberRole: permissions.DefaultMemberRole},
+		}
+		var txErr error
+		if updatedBoard, txErr = tx.PatchBoard(boardID, patch, userID); txErr != nil {
+			return txErr
+		}
+
+		existing, txErr := tx.GetMembersForBoard(boardID)
+		if txErr != nil {
+			return txErr
+		}
+		isMember := map[string]bool{}
+		for _, member := range existing {
+			isMember[member.UserID] = true
+		}
+
+		inPermissions := map[string]bool{}
+		for _, member := range members {
+			newMember, txErr := tx.SaveMember(member)
+			if txErr != nil {
+				return txErr
+			}
+			saved = append(saved, newMember)
+			inPermissions[member.UserID] = true
+			if !isMember[member.UserID] {
+				added = append(added, newMember)
+			}
+		}
+
+		if !replace {
+			return nil
+		}
+		for _, member := range existing {
+			if inPermissions[member.UserID] {
+				continue
+			}
+			if txErr := tx.DeleteMember(boardID, member.UserID); txErr != nil {
+				return txErr
+			}
+			removed = append(removed, member.UserID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range added {
+		a.applyCategoryRules(member.UserID, updatedBoard.TeamID, []*model.Board{updatedBoard})
+	}
+
+	go func() {
+		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
+		for _, member := range saved {
+			a.wsAdapter.BroadcastMemberChange(updatedBoard.TeamID, boardID, member)
+		}
+		for _, removedID := range removed {
+			a.wsAdapter.BroadcastMemberDelete(updatedBoard.TeamID, boardID, removedID)
+		}
+		for _, member := range added {
+			a.notifyMemberAdded(updatedBoard, member)
+		}
+	}()
+
+	return saved, nil
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestGetBoardPermissions(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board-id", TeamID: "team-id", Type: model.BoardTypeOpen}
+	th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+	th.Store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{
+		{BoardID: "board-id", UserID: "user-2", SchemeEditor: true},
+		{BoardID: "board-id", UserID: "user-1", SchemeAdmin: true},
+	}, nil)
+
+	permissions, err := th.App.GetBoardPermissions("board-id")
+	require.NoError(t, err)
+	require.Equal(t, &model.BoardPermissions{
+		Type:              model.BoardTypeOpen,
+		DefaultMemberRole: model.BoardRoleEditor,
+		Members: []*model.BoardMemberRoles{
+			{UserID: "user-1", SchemeAdmin: true},
+			{UserID: "user-2", SchemeEditor: true},
+		},
+	}, permissions)
+}
+
+func TestApplyBoardPermissions(t *testing.T) {
+	board := &model.Board{ID: "board-id", TeamID: "team-id", Type: model.BoardTypeOpen}
+	permissions := &model.BoardPermissions{
+		Type:              model.BoardTypePrivate,
+		DefaultMemberRole: model.BoardRoleViewer,
+		Members: []*model.BoardMemberRoles{
+			{UserID: "user-1", SchemeAdmin: true},
+			{UserID: "user-2", SchemeViewer: true},
+			{UserID: "unknown-user", SchemeEditor: true},
+		},
+	}
+	admin := &model.BoardMember{BoardID: "board-id", UserID: "user-1", SchemeAdmin: true}
+	viewer := &model.BoardMember{BoardID: "board-id", UserID: "user-2", SchemeViewer: true}
+
+	expectUsers := func(th *TestHelper) {
+		th.Store.EXPECT().GetUserByID("user-1").Return(&model.User{ID: "user-1"}, nil)
+		th.Store.EXPECT().GetUserByID("user-2").Return(&model.User{ID: "user-2"}, nil)
+		th.Store.EXPECT().GetUserByID("unknown-user").Return(nil, sql.ErrNoRows)
+	}
+
+	t.Run("keeps the other members", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		expectUsers(th)
+		th.expectRunInTransaction()
+		th.Store.EXPECT().PatchBoard("board-id", gomock.Any(), "user-1").Return(board, nil)
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{
+			{BoardID: "board-id", UserID: "user-1", SchemeEditor: true},
+			{BoardID: "board-id", UserID: "user-3", SchemeEditor: true},
+		}, nil)
+		th.Store.EXPECT().SaveMember(admin).Return(admin, nil)
+		th.Store.EXPECT().SaveMember(viewer).Return(viewer, nil)
+		th.Store.EXPECT().GetCategoryRules("user-2", "team-id").Return(nil, nil)
+		// the changes are broadcast in the background
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return(nil, nil).AnyTimes()
+
+		members, err := th.App.ApplyBoardPermissions("board-id", permissions, false, "user-1")
+		require.NoError(t, err)
+		require.Equal(t, []*model.BoardMember{admin, viewer}, members)
+	})
+
+	t.Run("replaces the other members", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		expectUsers(th)
+		th.expectRunInTransaction()
+		th.Store.EXPECT().PatchBoard("board-id", gomock.Any(), "user-1").Return(board, nil)
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{
+			{BoardID: "board-id", UserID: "user-1", SchemeAdmin: true},
+			{BoardID: "board-id", UserID: "user-2", SchemeViewer: true},
+			{BoardID: "board-id", UserID: "user-3", SchemeEditor: true},
+		}, nil)
+		th.Store.EXPECT().SaveMember(admin).Return(admin, nil)
+		th.Store.EXPECT().SaveMember(viewer).Return(viewer, nil)
+		th.Store.EXPECT().DeleteMember("board-id", "user-3").Return(nil)
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return(nil, nil).AnyTimes()
+
+		members, err := th.App.ApplyBoardPermissions("board-id", permissions, true, "user-1")
+		require.NoError(t, err)
+		require.Equal(t, []*model.BoardMember{admin, viewer}, members)
+	})
+
+	t.Run("fails without a known admin", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetUserByID("unknown-admin").Return(nil, sql.ErrNoRows)
+		th.Store.EXPECT().GetUserByID("user-2").Return(&model.User{ID: "user-2"}, nil)
+
+		_, err := th.App.ApplyBoardPermissions("board-id", &model.BoardPermissions{
+			Type:              model.BoardTypeOpen,
+			DefaultMemberRole: model.BoardRoleEditor,
+			Members: []*model.BoardMemberRoles{
+				{UserID: "unknown-admin", SchemeAdmin: true},
+				{UserID: "user-2", SchemeViewer: true},
+			},
+		}, false, "user-1")
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+	})
+
+	t.Run("invalid permissions", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		_, err := th.App.ApplyBoardPermissions("board-id", &model.BoardPermissions{Type: model.BoardTypeOpen}, false, "user-1")
+		require.Error(t, err)
+	})
+}
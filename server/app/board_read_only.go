@@ -0,0 +1,54 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// checkBoardWritable returns an error if the board is read-only and the
+// user is not one of its admins. The changes of the system user, like the
+// reassignment of the cards of deactivated users, are allowed.
+func (a *App) checkBoardWritable(board *model.Board, userID string) error {
+	if board == nil || !board.IsReadOnly() || userID == model.SystemUserID {
+		return nil
+	}
+
+	member, err := a.store.GetMemberForBoard(board.ID, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if member != nil && member.SchemeAdmin {
+		return nil
+	}
+	return model.NewErrBoardReadOnly(board.ID)
+}
+
+// checkBoardsWritable returns an error if any of the boards is read-only
+// and the user is not one of its admins.
+func (a *App) checkBoardsWritable(boardIDs []string, userID string) error {
+	_, err := a.getWritableBoards(boardIDs, userID)
+	return err
+}
+
+// getWritableBoards returns the boards by ID, or an error if one of them
+// is read-only for the user.
+func (a *App) getWritableBoards(boardIDs []string, userID string) (map[string]*model.Board, error) {
+	boards := map[string]*model.Board{}
+	for _, boardID := range boardIDs {
+		if _, ok := boards[boardID]; ok {
+			continue
+		}
+
+		board, err := a.store.GetBoard(boardID)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.checkBoardWritable(board, userID); err != nil {
+			return nil, err
+		}
+		boards[boardID] = board
+	}
+	return boards, nil
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestReadOnlyBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:         testBoardID,
+		TeamID:     "team-id",
+		Properties: map[string]interface{}{model.BoardPropertyReadOnly: true},
+	}
+	block := &model.Block{ID: "block-id", BoardID: testBoardID, Type: model.TypeCard}
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetBlock("block-id").Return(block, nil).AnyTimes()
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().GetMemberForBoard(testBoardID, "editor-id").Return(&model.BoardMember{BoardID: testBoardID, UserID: "editor-id", SchemeEditor: true}, nil).AnyTimes()
+	th.Store.EXPECT().GetMemberForBoard(testBoardID, "admin-id").Return(&model.BoardMember{BoardID: testBoardID, UserID: "admin-id", SchemeAdmin: true}, nil).AnyTimes()
+	th.Store.EXPECT().GetMemberForBoard(testBoardID, "other-id").Return(nil, sql.ErrNoRows).AnyTimes()
+
+	t.Run("the editors can't change the blocks", func(t *testing.T) {
+		err := th.App.InsertBlock(model.Block{ID: "new-id", BoardID: testBoardID}, "editor-id")
+		require.True(t, model.IsErrBoardReadOnly(err))
+
+		err = th.App.PatchBlock("block-id", &model.BlockPatch{}, "editor-id")
+		require.True(t, model.IsErrBoardReadOnly(err))
+
+		err = th.App.DeleteBlock("block-id", "editor-id")
+		require.True(t, model.IsErrBoardReadOnly(err))
+
+		_, err = th.App.InsertBlocks([]model.Block{{ID: "new-id", BoardID: testBoardID}}, "other-id", false)
+		require.True(t, model.IsErrBoardReadOnly(err))
+	})
+
+	t.Run("the editors can't change the board", func(t *testing.T) {
+		title := "new title"
+		_, err := th.App.PatchBoard(&model.BoardPatch{Title: &title}, testBoardID, "editor-id")
+		require.True(t, model.IsErrBoardReadOnly(err))
+
+		err = th.App.DeleteBoard(testBoardID, "editor-id")
+		require.True(t, model.IsErrBoardReadOnly(err))
+	})
+
+	t.Run("the admins can change the blocks and the board", func(t *testing.T) {
+		newBlock := model.Block{ID: "new-id", BoardID: testBoardID}
+		th.Store.EXPECT().InsertBlock(&newBlock, "admin-id").Return(nil)
+		require.NoError(t, th.App.InsertBlock(newBlock, "admin-id"))
+
+		patch := &model.BoardPatch{UpdatedProperties: map[string]interface{}{model.BoardPropertyReadOnly: false}}
+		th.Store.EXPECT().PatchBoard(testBoardID, patch, "admin-id").Return(&model.Board{ID: testBoardID, TeamID: "team-id"}, nil)
+		_, err := th.App.PatchBoard(patch, testBoardID, "admin-id")
+		require.NoError(t, err)
+	})
+
+	t.Run("the system user can change the blocks", func(t *testing.T) {
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeView).Return([]model.Block{}, nil).AnyTimes()
+		th.Store.EXPECT().PatchBlock("block-id", gomock.Any(), model.SystemUserID).Return(nil)
+		require.NoError(t, th.App.PatchBlock("block-id", &model.BlockPatch{}, model.SystemUserID))
+	})
+}
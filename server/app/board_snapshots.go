@@ -0,0 +1,118 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// boardSnapshotsDaySetting is the system setting that holds the last day
+// the boards were snapshotted.
+const boardSnapshotsDaySetting = "BoardSnapshotsDay"
+
+// SnapshotBoards stores the number of cards of each board by status, once
+// a day. It is meant to run periodically.
+func (a *App) SnapshotBoards() {
+	now := time.Now()
+	day := model.BoardSnapshotDay(now)
+
+	lastDay, err := a.store.GetSystemSetting(boardSnapshotsDaySetting)
+	if err != nil {
+		a.logger.Error("cannot get the day of the last board snapshots", mlog.Err(err))
+		return
+	}
+	if lastDay == day {
+		return
+	}
+
+	boardIDs, err := a.store.GetNonTemplateBoardIDs()
+	if err != nil {
+		a.logger.Error("cannot get the boards to snapshot", mlog.Err(err))
+		return
+	}
+
+	for _, boardID := range boardIDs {
+		snapshot, err := a.takeBoardSnapshot(boardID, now)
+		if err != nil {
+			a.logger.Warn("cannot take the snapshot of a board", mlog.String("boardID", boardID), mlog.Err(err))
+			continue
+		}
+		if snapshot == nil {
+			continue
+		}
+		if err := a.store.SaveBoardSnapshot(snapshot); err != nil {
+			a.logger.Error("cannot save the snapshot of a board", mlog.String("boardID", boardID), mlog.Err(err))
+		}
+	}
+
+	if err := a.store.SetSystemSetting(boardSnapshotsDaySetting, day); err != nil {
+		a.logger.Error("cannot save the day of the board snapshots", mlog.Err(err))
+	}
+}
+
+// takeBoardSnapshot counts the cards of the board by the value of its
+// status property. Boards without a status property have no snapshot.
+func (a *App) takeBoardSnapshot(boardID string, now time.Time) (*model.BoardSnapshot, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	status, ok := schema.StatusProperty(board)
+	if !ok {
+		return nil, nil
+	}
+
+	groups, err := a.store.GetCardGroups(boardID, status.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	return model.NewBoardSnapshot(boardID, status.ID, groups, now), nil
+}
+
+// GetBoardSnapshots returns the daily snapshots of the board for the days
+// of the query. If the query includes today and the board wasn't
+// snapshotted yet today, the current counts are returned as the snapshot
+// of today.
+func (a *App) GetBoardSnapshots(boardID string, query model.BoardSnapshotsQuery) ([]*model.BoardSnapshot, error) {
+	if err := query.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	snapshots, err := a.store.GetBoardSnapshots(boardID, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	today := model.BoardSnapshotDay(now)
+	if query.From > today || query.To < today {
+		return snapshots, nil
+	}
+	if len(snapshots) > 0 && snapshots[len(snapshots)-1].Day == today {
+		return snapshots, nil
+	}
+
+	current, err := a.takeBoardSnapshot(boardID, now)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		snapshots = append(snapshots, current)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestSnapshotBoards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	today := model.BoardSnapshotDay(time.Now())
+	withStatus := &model.Board{
+		ID: "board-id-1",
+		CardProperties: []map[string]interface{}{
+			{"id": "status-id", "name": "Status", "type": "select", "options": []interface{}{}},
+		},
+	}
+	withoutStatus := &model.Board{ID: "board-id-2"}
+
+	t.Run("the boards with a status property are snapshotted", func(t *testing.T) {
+		th.Store.EXPECT().GetSystemSetting(boardSnapshotsDaySetting).Return("2022-03-14", nil)
+		th.Store.EXPECT().GetNonTemplateBoardIDs().Return([]string{withStatus.ID, withoutStatus.ID}, nil)
+		th.Store.EXPECT().GetBoard(withStatus.ID).Return(withStatus, nil)
+		th.Store.EXPECT().GetBoard(withoutStatus.ID).Return(withoutStatus, nil)
+		th.Store.EXPECT().GetCardGroups(withStatus.ID, "status-id", "").Return([]*model.CardGroup{
+			{Value: "", Count: 1},
+			{Value: "done-id", Count: 3},
+		}, nil)
+
+		var saved *model.BoardSnapshot
+		th.Store.EXPECT().SaveBoardSnapshot(gomock.Any()).DoAndReturn(func(snapshot *model.BoardSnapshot) error {
+			saved = snapshot
+			return nil
+		})
+		th.Store.EXPECT().SetSystemSetting(boardSnapshotsDaySetting, today).Return(nil)
+
+		th.App.SnapshotBoards()
+		require.NotNil(t, saved)
+		require.Equal(t, withStatus.ID, saved.BoardID)
+		require.Equal(t, today, saved.Day)
+		require.Equal(t, map[string]int64{"": 1, "done-id": 3}, saved.Counts)
+	})
+
+	t.Run("the boards are snapshotted once a day", func(t *testing.T) {
+		th.Store.EXPECT().GetSystemSetting(boardSnapshotsDaySetting).Return(today, nil)
+
+		th.App.SnapshotBoards()
+	})
+}
+
+func TestGetBoardSnapshots(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: testBoardID,
+		CardProperties: []map[string]interface{}{
+			{"id": "status-id", "name": "Status", "type": "select", "options": []interface{}{}},
+		},
+	}
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+
+	now := time.Now()
+	yesterday := model.BoardSnapshotDay(now.AddDate(0, 0, -1))
+	stored := []*model.BoardSnapshot{
+		{BoardID: testBoardID, Day: yesterday, PropertyID: "status-id", Counts: map[string]int64{"done-id": 2}},
+	}
+
+	t.Run("past days", func(t *testing.T) {
+		query := model.BoardSnapshotsQuery{From: model.BoardSnapshotDay(now.AddDate(0, 0, -7)), To: yesterday}
+		th.Store.EXPECT().GetBoardSnapshots(testBoardID, query.From, query.To).Return(stored, nil)
+
+		snapshots, err := th.App.GetBoardSnapshots(testBoardID, query)
+		require.NoError(t, err)
+		require.Equal(t, stored, snapshots)
+	})
+
+	t.Run("today is counted if not snapshotted yet", func(t *testing.T) {
+		query := model.NewBoardSnapshotsQuery("", "", now)
+		th.Store.EXPECT().GetBoardSnapshots(testBoardID, query.From, query.To).Return(stored, nil)
+		th.Store.EXPECT().GetCardGroups(testBoardID, "status-id", "").Return([]*model.CardGroup{{Value: "done-id", Count: 3}}, nil)
+
+		snapshots, err := th.App.GetBoardSnapshots(testBoardID, query)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		require.Equal(t, model.BoardSnapshotDay(now), snapshots[1].Day)
+		require.Equal(t, map[string]int64{"done-id": 3}, snapshots[1].Counts)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := th.App.GetBoardSnapshots(testBoardID, model.BoardSnapshotsQuery{From: "2022-03-14", To: "2022-03-01"})
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+	})
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+var (
+	ErrBoardAlreadyInTeam = errors.New("the board already belongs to the team")
+	ErrBoardTeamNotFound  = errors.New("the board is not shared with the team")
+)
+
+// GetBoardTeamIDs returns the ID of the team of a board, followed by the
+// IDs of the teams it is shared with.
+func (a *App) GetBoardTeamIDs(boardID string) ([]string, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	sharedTeamIDs, err := a.store.GetSharedTeamIDsForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{board.TeamID}, sharedTeamIDs...), nil
+}
+
+// ShareBoardWithTeam makes a board visible in another team, so its
+// members can find and join it without it being duplicated.
+func (a *App) ShareBoardWithTeam(boardID, teamID, userID string) error {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+	if board == nil {
+		return model.NewErrBoardNotFound(boardID)
+	}
+	if board.TeamID == teamID {
+		return ErrBoardAlreadyInTeam
+	}
+
+	if err := a.store.AddBoardToTeam(boardID, teamID, userID); err != nil {
+		return err
+	}
+
+	go func() {
+		a.wsAdapter.BroadcastBoardChange(teamID, board)
+	}()
+
+	return nil
+}
+
+// UnshareBoardFromTeam stops sharing a board with a team. The board
+// stays in its own team.
+func (a *App) UnshareBoardFromTeam(boardID, teamID string) error {
+	err := a.store.RemoveBoardFromTeam(boardID, teamID)
+	if a.store.IsErrNotFound(err) {
+		return ErrBoardTeamNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		// for the clients of the team, the board is gone
+		a.wsAdapter.BroadcastBoardDelete(teamID, boardID)
+	}()
+
+	return nil
+}
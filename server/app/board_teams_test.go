@@ -0,0 +1,49 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareBoardWithTeam(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board-id", TeamID: "team-id"}
+	th.Store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	t.Run("share with another team", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().AddBoardToTeam("board-id", "other-team-id", "user-id").Return(nil)
+
+		require.NoError(t, th.App.ShareBoardWithTeam("board-id", "other-team-id", "user-id"))
+	})
+
+	t.Run("the team of the board", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+
+		err := th.App.ShareBoardWithTeam("board-id", "team-id", "user-id")
+		require.ErrorIs(t, err, ErrBoardAlreadyInTeam)
+	})
+
+	t.Run("unshare from a team the board isn't shared with", func(t *testing.T) {
+		th.Store.EXPECT().RemoveBoardFromTeam("board-id", "other-team-id").Return(store.NewErrNotFound("board team"))
+		th.Store.EXPECT().IsErrNotFound(gomock.Any()).Return(true)
+
+		err := th.App.UnshareBoardFromTeam("board-id", "other-team-id")
+		require.ErrorIs(t, err, ErrBoardTeamNotFound)
+	})
+
+	t.Run("the teams of the board", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetSharedTeamIDsForBoard("board-id").Return([]string{"other-team-id"}, nil)
+
+		teamIDs, err := th.App.GetBoardTeamIDs("board-id")
+		require.NoError(t, err)
+		require.Equal(t, []string{"team-id", "other-team-id"}, teamIDs)
+	})
+}
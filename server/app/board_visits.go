@@ -0,0 +1,130 @@
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const boardVisitsFlushInterval = time.Second * 30
+
+type boardVisitKey struct {
+	userID  string
+	boardID string
+}
+
+// boardVisitRecorder buffers the board visits in memory and writes them
+// to the store in batches, so opening a board doesn't cost a write.
+type boardVisitRecorder struct {
+	store  store.Store
+	logger *mlog.Logger
+
+	mu      sync.Mutex
+	pending map[boardVisitKey]int64
+
+	done     chan struct{}
+	finished chan struct{}
+	stopOnce sync.Once
+}
+
+func newBoardVisitRecorder(store store.Store, logger *mlog.Logger) *boardVisitRecorder {
+	r := &boardVisitRecorder{
+		store:    store,
+		logger:   logger,
+		pending:  map[boardVisitKey]int64{},
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	go r.loop()
+	return r
+}
+
+func (r *boardVisitRecorder) record(userID, boardID string, visitedAt int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending[boardVisitKey{userID: userID, boardID: boardID}] = visitedAt
+}
+
+// flush writes the pending visits to the store. Visits that can't be
+// saved are kept, unless a more recent visit was recorded meanwhile.
+func (r *boardVisitRecorder) flush() error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = map[boardVisitKey]int64{}
+	r.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	visits := make([]*model.BoardVisit, 0, len(pending))
+	for key, visitedAt := range pending {
+		visits = append(visits, &model.BoardVisit{
+			UserID:    key.userID,
+			BoardID:   key.boardID,
+			VisitedAt: visitedAt,
+		})
+	}
+
+	if err := r.store.SaveBoardVisits(visits); err != nil {
+		r.mu.Lock()
+		for key, visitedAt := range pending {
+			if _, ok := r.pending[key]; !ok {
+				r.pending[key] = visitedAt
+			}
+		}
+		r.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (r *boardVisitRecorder) loop() {
+	defer close(r.finished)
+
+	ticker := time.NewTicker(boardVisitsFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.flush(); err != nil {
+				r.logger.Error("cannot save board visits", mlog.Err(err))
+			}
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// shutdown stops the periodic flush and saves the pending visits.
+func (r *boardVisitRecorder) shutdown() {
+	r.stopOnce.Do(func() { close(r.done) })
+	<-r.finished
+
+	if err := r.flush(); err != nil {
+		r.logger.Error("cannot save board visits on shutdown", mlog.Err(err))
+	}
+}
+
+// RecordBoardVisit registers that the user opened the board. Visits are
+// saved in batches, so they may take a few seconds to be persisted.
+func (a *App) RecordBoardVisit(userID, boardID string) {
+	now := model.GetMillis()
+	a.boardVisits.record(userID, boardID, now)
+	a.boardMemberActivity.recordView(boardID, userID, now)
+}
+
+// GetRecentBoards returns the boards the user visited most recently,
+// optionally restricted to a team.
+func (a *App) GetRecentBoards(userID, teamID string, limit int) ([]*model.Board, error) {
+	if err := a.boardVisits.flush(); err != nil {
+		a.logger.Error("cannot save board visits", mlog.Err(err))
+	}
+
+	return a.store.GetRecentBoardsForUser(userID, teamID, uint64(limit))
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardVisits(t *testing.T) {
+	t.Run("visits are saved in a single batch, keeping the latest one", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.App.boardVisits.record("user-id", "board-id-1", 100)
+		th.App.boardVisits.record("user-id", "board-id-1", 200)
+		th.App.boardVisits.record("user-id", "board-id-2", 150)
+
+		th.Store.EXPECT().SaveBoardVisits(gomock.Any()).DoAndReturn(func(visits []*model.BoardVisit) error {
+			require.Len(t, visits, 2)
+			for _, visit := range visits {
+				if visit.BoardID == "board-id-1" {
+					require.Equal(t, int64(200), visit.VisitedAt)
+				}
+			}
+			return nil
+		})
+		require.NoError(t, th.App.boardVisits.flush())
+
+		// nothing is pending anymore, so the store isn't called again
+		require.NoError(t, th.App.boardVisits.flush())
+	})
+
+	t.Run("visits are kept if they can't be saved", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.App.boardVisits.record("user-id", "board-id", 100)
+
+		th.Store.EXPECT().SaveBoardVisits(gomock.Any()).Return(errors.New("db error"))
+		require.Error(t, th.App.boardVisits.flush())
+
+		th.Store.EXPECT().SaveBoardVisits([]*model.BoardVisit{
+			{UserID: "user-id", BoardID: "board-id", VisitedAt: 100},
+		}).Return(nil)
+		require.NoError(t, th.App.boardVisits.flush())
+	})
+
+	t.Run("recent boards include the pending visits", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.App.RecordBoardVisit("user-id", "board-id")
+
+		boards := []*model.Board{{ID: "board-id"}}
+		gomock.InOrder(
+			th.Store.EXPECT().SaveBoardVisits(gomock.Any()).Return(nil),
+			th.Store.EXPECT().GetRecentBoardsForUser("user-id", "team-id", uint64(5)).Return(boards, nil),
+		)
+
+		result, err := th.App.GetRecentBoards("user-id", "team-id", 5)
+		require.NoError(t, err)
+		require.Equal(t, boards, result)
+	})
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// GetBoardWebhooks returns the outgoing webhooks of a board, without
+// their secrets.
+func (a *App) GetBoardWebhooks(boardID string) ([]*model.BoardWebhook, error) {
+	webhooks, err := a.store.GetBoardWebhooksForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	sanitized := make([]*model.BoardWebhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		sanitized = append(sanitized, webhook.Sanitized())
+	}
+	return sanitized, nil
+}
+
+// CreateBoardWebhook adds an outgoing webhook to a board. The changes of
+// the blocks of the board are posted to it from then on.
+func (a *App) CreateBoardWebhook(webhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+	webhook.Hydrate()
+	if err := webhook.IsValid(); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := a.store.GetBoardWebhooksForBoard(webhook.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) >= model.MaxBoardWebhooks {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "too many webhooks on the board", map[string]interface{}{"max": model.MaxBoardWebhooks})
+	}
+
+	if err := a.store.InsertBoardWebhook(webhook); err != nil {
+		return nil, err
+	}
+	return webhook.Sanitized(), nil
+}
+
+// PatchBoardWebhook modifies an outgoing webhook of a board.
+func (a *App) PatchBoardWebhook(boardID, webhookID string, patch *model.BoardWebhookPatch) (*model.BoardWebhook, error) {
+	webhook, err := a.getBoardWebhook(boardID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook = patch.Patch(webhook)
+	if err := webhook.IsValid(); err != nil {
+		return nil, err
+	}
+	webhook.UpdateAt = utils.GetMillis()
+
+	if err := a.store.UpdateBoardWebhook(webhook); err != nil {
+		return nil, err
+	}
+	return webhook.Sanitized(), nil
+}
+
+// DeleteBoardWebhook removes an outgoing webhook of a board.
+func (a *App) DeleteBoardWebhook(boardID, webhookID string) error {
+	if _, err := a.getBoardWebhook(boardID, webhookID); err != nil {
+		return err
+	}
+	return a.store.DeleteBoardWebhook(webhookID)
+}
+
+// getBoardWebhook returns the webhook, or a not found error if it doesn't
+// exist or belongs to another board.
+func (a *App) getBoardWebhook(boardID, webhookID string) (*model.BoardWebhook, error) {
+	webhook, err := a.store.GetBoardWebhook(webhookID)
+	if err != nil {
+		if a.store.IsErrNotFound(err) {
+			return nil, model.NewCodedError(model.ErrCodeNotFound, "webhook not found", map[string]interface{}{"id": webhookID})
+		}
+		return nil, err
+	}
+	if webhook.BoardID != boardID {
+		return nil, model.NewCodedError(model.ErrCodeNotFound, "webhook not found", map[string]interface{}{"id": webhookID})
+	}
+	return webhook, nil
+}
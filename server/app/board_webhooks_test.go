@@ -0,0 +1,104 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestCreateBoardWebhook(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	newWebhook := func() *model.BoardWebhook {
+		return &model.BoardWebhook{
+			BoardID:   "board-id",
+			URL:       "https://example.com/hook",
+			Events:    []string{model.BoardWebhookEventBlockCreated},
+			Secret:    "secret",
+			Enabled:   true,
+			CreatedBy: "user-id",
+		}
+	}
+
+	t.Run("creates the webhook, hiding its secret", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardWebhooksForBoard("board-id").Return(nil, nil)
+		th.Store.EXPECT().InsertBoardWebhook(gomock.Any()).DoAndReturn(func(webhook *model.BoardWebhook) error {
+			require.Equal(t, "secret", webhook.Secret)
+			return nil
+		})
+
+		created, err := th.App.CreateBoardWebhook(newWebhook())
+		require.NoError(t, err)
+		require.NotEmpty(t, created.ID)
+		require.Empty(t, created.Secret)
+		require.True(t, created.HasSecret)
+	})
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		webhook := newWebhook()
+		webhook.Events = []string{"unknown"}
+
+		_, err := th.App.CreateBoardWebhook(webhook)
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+	})
+
+	t.Run("too many webhooks", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardWebhooksForBoard("board-id").Return(make([]*model.BoardWebhook, model.MaxBoardWebhooks), nil)
+
+		_, err := th.App.CreateBoardWebhook(newWebhook())
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+	})
+}
+
+func TestPatchBoardWebhook(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	webhook := &model.BoardWebhook{
+		ID:      "webhook-id",
+		BoardID: "board-id",
+		URL:     "https://example.com/hook",
+		Events:  []string{model.BoardWebhookEventBlockCreated},
+		Enabled: true,
+	}
+
+	t.Run("patches the webhook", func(t *testing.T) {
+		disabled := false
+		th.Store.EXPECT().GetBoardWebhook("webhook-id").Return(webhook, nil)
+		th.Store.EXPECT().UpdateBoardWebhook(gomock.Any()).Return(nil)
+
+		patched, err := th.App.PatchBoardWebhook("board-id", "webhook-id", &model.BoardWebhookPatch{Enabled: &disabled})
+		require.NoError(t, err)
+		require.False(t, patched.Enabled)
+		require.NotZero(t, patched.UpdateAt)
+	})
+
+	t.Run("webhook of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardWebhook("webhook-id").Return(webhook, nil)
+
+		_, err := th.App.PatchBoardWebhook("other-board-id", "webhook-id", &model.BoardWebhookPatch{})
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeNotFound, ce.Code)
+	})
+
+	t.Run("unknown webhook", func(t *testing.T) {
+		notFound := errors.New("not found")
+		th.Store.EXPECT().GetBoardWebhook("bogus").Return(nil, notFound)
+		th.Store.EXPECT().IsErrNotFound(notFound).Return(true)
+
+		err := th.App.DeleteBoardWebhook("board-id", "bogus")
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeNotFound, ce.Code)
+	})
+}
@@ -6,7 +6,12 @@ import (
 	"fmt"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/entitlements"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 var (
@@ -27,8 +32,7 @@ func (a *App) GetBoard(boardID string) (*model.Board, error) {
 }
 
 func (a *App) GetBoardMetadata(boardID string) (*model.Board, *model.BoardMetadata, error) {
-	license := a.store.GetLicense()
-	if license == nil || !(*license.Features.Compliance) {
+	if !a.IsFeatureEnabled(entitlements.FeatureComplianceExport) {
 		return nil, nil, ErrInsufficientLicense
 	}
 
@@ -140,11 +144,52 @@ func (a *App) getBoardDescendantModifiedInfo(boardID string, latest bool) (int64
 	return timestamp, modifiedBy, nil
 }
 
-func (a *App) DuplicateBoard(boardID, userID, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
-	bab, members, err := a.store.DuplicateBoard(boardID, userID, toTeam, asTemplate)
+// DuplicateBoard copies a board and its blocks. When creating a board from
+// a template, the variables fill in the placeholders of the copy. The user
+// mapping replaces the users of the board in the copy, e.g. when it is
+// duplicated into another team.
+func (a *App) DuplicateBoard(boardID, userID, toTeam string, asTemplate bool, variables model.TemplateVariables, userMapping model.UserMapping) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	if err := userMapping.IsValid(); err != nil {
+		return nil, nil, err
+	}
+
+	var bab *model.BoardsAndBlocks
+	var members []*model.BoardMember
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		var err error
+		bab, members, err = duplicateBoard(tx, boardID, userID, toTeam, asTemplate, variables, userMapping)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a.broadcastDuplicatedBoard(bab, members)
+	if len(bab.Boards) > 0 {
+		a.applyCategoryRules(userID, bab.Boards[0].TeamID, bab.Boards)
+	}
+	return bab, members, nil
+}
+
+// duplicateBoard copies a board and fills in its template variables
+// using the given store.
+func duplicateBoard(st store.Store, boardID, userID, toTeam string, asTemplate bool, variables model.TemplateVariables, userMapping model.UserMapping) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	bab, members, err := st.DuplicateBoard(boardID, userID, toTeam, asTemplate, userMapping)
 	if err != nil {
 		return nil, nil, err
 	}
+
+	if pbab := variables.PatchBoardsAndBlocks(bab); pbab != nil {
+		patched, err := st.PatchBoardsAndBlocks(pbab, userID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot replace template variables: %w", err)
+		}
+		mergePatchedBoardsAndBlocks(bab, patched)
+	}
+	return bab, members, nil
+}
+
+func (a *App) broadcastDuplicatedBoard(bab *model.BoardsAndBlocks, members []*model.BoardMember) {
 	go func() {
 		teamID := ""
 		for _, board := range bab.Boards {
@@ -158,7 +203,25 @@ func (a *App) DuplicateBoard(boardID, userID, toTeam string, asTemplate bool) (*
 			a.wsAdapter.BroadcastMemberChange(teamID, member.BoardID, member)
 		}
 	}()
-	return bab, members, err
+}
+
+// mergePatchedBoardsAndBlocks replaces the boards and blocks of bab with
+// their patched versions.
+func mergePatchedBoardsAndBlocks(bab *model.BoardsAndBlocks, patched *model.BoardsAndBlocks) {
+	for _, board := range patched.Boards {
+		for i := range bab.Boards {
+			if bab.Boards[i].ID == board.ID {
+				bab.Boards[i] = board
+			}
+		}
+	}
+	for _, block := range patched.Blocks {
+		for i := range bab.Blocks {
+			if bab.Blocks[i].ID == block.ID {
+				bab.Blocks[i] = block
+			}
+		}
+	}
 }
 
 func (a *App) GetBoardsForUserAndTeam(userID, teamID string) ([]*model.Board, error) {
@@ -196,19 +259,63 @@ func (a *App) CreateBoard(board *model.Board, userID string, addMember bool) (*m
 		}
 	}()
 
+	if addMember {
+		a.applyCategoryRules(userID, newBoard.TeamID, []*model.Board{newBoard})
+	}
+
+	if err := a.SyncBoardWithChannel(newBoard); err != nil {
+		a.logger.Error("cannot sync the members of the new board with its channel", mlog.String("boardID", newBoard.ID), mlog.Err(err))
+	}
+
 	return newBoard, nil
 }
 
 func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*model.Board, error) {
+	// the old board is needed to check the version, whether the board is
+	// read-only, and to notify the users newly mentioned in the
+	// description
+	oldBoard, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if patch.UpdateAt != nil && *patch.UpdateAt != oldBoard.UpdateAt {
+		return nil, model.NewErrVersionConflict(boardID)
+	}
+	if err = a.checkBoardWritable(oldBoard, userID); err != nil {
+		return nil, err
+	}
+	if err = checkBoardDescriptionPatch(oldBoard, patch); err != nil {
+		return nil, err
+	}
+
 	updatedBoard, err := a.store.PatchBoard(boardID, patch, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(patch.DeletedCardProperties) != 0 {
+		a.deletePropertyIndexes(boardID, patch.DeletedCardProperties)
+	}
+
+	// the clients learn that the board was frozen or unfrozen from the
+	// properties of the broadcast board
 	go func() {
 		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
 	}()
 
+	if patch.Description != nil {
+		a.enqueue(func(a *App) error {
+			a.notifyBoardChanged(notify.Update, updatedBoard, oldBoard, userID)
+			return nil
+		})
+	}
+
+	if patch.EnablesChannelMemberSync() {
+		if err := a.SyncBoardWithChannel(updatedBoard); err != nil {
+			a.logger.Error("cannot sync the members of the board with its channel", mlog.String("boardID", boardID), mlog.Err(err))
+		}
+	}
+
 	return updatedBoard, nil
 }
 
@@ -220,6 +327,9 @@ func (a *App) DeleteBoard(boardID, userID string) error {
 	if err != nil {
 		return err
 	}
+	if err := a.checkBoardWritable(board, userID); err != nil {
+		return err
+	}
 
 	if err := a.store.DeleteBoard(boardID, userID); err != nil {
 		return err
@@ -253,27 +363,92 @@ func (a *App) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, e
 		return nil, err
 	}
 
-	existingMembership, err := a.store.GetMemberForBoard(member.BoardID, member.UserID)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+	if err = a.checkCustomRoles(member); err != nil {
+		return nil, err
+	}
+
+	var newMember *model.BoardMember
+	added := false
+	err = a.store.RunInTransaction(func(tx store.Store) error {
+		existingMembership, mErr := tx.GetMemberForBoard(member.BoardID, member.UserID)
+		if mErr != nil && !errors.Is(mErr, sql.ErrNoRows) {
+			return mErr
+		}
+
+		if existingMembership != nil {
+			newMember = existingMembership
+			return nil
+		}
+
+		newMember, mErr = tx.SaveMember(member)
+		added = mErr == nil
+		return mErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !added {
+		return newMember, nil
+	}
+
+	a.applyCategoryRules(newMember.UserID, board.TeamID, []*model.Board{board})
+
+	go func() {
+		a.wsAdapter.BroadcastMemberChange(board.TeamID, member.BoardID, member)
+		a.notifyMemberAdded(board, newMember)
+	}()
+
+	return newMember, nil
+}
+
+// JoinBoard makes the user a member of an open board, with the default
+// role of its members. Joining a board the user is a member of already
+// returns the existing membership.
+func (a *App) JoinBoard(boardID, userID string) (*model.BoardMember, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
 		return nil, err
 	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+	if board.Type != model.BoardTypeOpen {
+		return nil, model.NewCodedError(model.ErrCodeInsufficientPermissions, "only open boards can be joined", map[string]interface{}{"boardId": boardID})
+	}
 
+	existingMembership, err := a.store.GetMemberForBoard(boardID, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
 	if existingMembership != nil {
 		return existingMembership, nil
 	}
 
-	newMember, err := a.store.SaveMember(member)
+	newMember, err := a.store.JoinBoard(board.NewDefaultMember(userID))
 	if err != nil {
 		return nil, err
 	}
 
+	a.applyCategoryRules(userID, board.TeamID, []*model.Board{board})
+
 	go func() {
-		a.wsAdapter.BroadcastMemberChange(board.TeamID, member.BoardID, member)
+		a.wsAdapter.BroadcastMemberChange(board.TeamID, boardID, newMember)
+		a.notifyMemberAdded(board, newMember)
 	}()
 
 	return newMember, nil
 }
 
+// checkCustomRoles returns ErrInsufficientLicense if the member has
+// custom roles and the custom roles feature is not enabled.
+func (a *App) checkCustomRoles(member *model.BoardMember) error {
+	if member.Roles != "" && !a.IsFeatureEnabled(entitlements.FeatureCustomRoles) {
+		return ErrInsufficientLicense
+	}
+	return nil
+}
+
 func (a *App) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember, error) {
 	board, bErr := a.store.GetBoard(member.BoardID)
 	if errors.Is(bErr, sql.ErrNoRows) {
@@ -283,30 +458,41 @@ func (a *App) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember,
 		return nil, bErr
 	}
 
-	oldMember, err := a.store.GetMemberForBoard(member.BoardID, member.UserID)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, nil
-	}
-	if err != nil {
+	if err := a.checkCustomRoles(member); err != nil {
 		return nil, err
 	}
 
-	// if we're updating an admin, we need to check that there is at
-	// least still another admin on the board
-	if oldMember.SchemeAdmin && !member.SchemeAdmin {
-		isLastAdmin, err2 := a.isLastAdmin(member.UserID, member.BoardID)
-		if err2 != nil {
-			return nil, err2
+	var newMember *model.BoardMember
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		oldMember, err := tx.GetMemberForBoard(member.BoardID, member.UserID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
 		}
-		if isLastAdmin {
-			return nil, ErrBoardMemberIsLastAdmin
+
+		// if we're updating an admin, we need to check that there is at
+		// least still another admin on the board
+		if oldMember.SchemeAdmin && !member.SchemeAdmin {
+			lastAdmin, err2 := isLastAdmin(tx, member.UserID, member.BoardID)
+			if err2 != nil {
+				return err2
+			}
+			if lastAdmin {
+				return ErrBoardMemberIsLastAdmin
+			}
 		}
-	}
 
-	newMember, err := a.store.SaveMember(member)
+		newMember, err = tx.SaveMember(member)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	if newMember == nil {
+		return nil, nil
+	}
 
 	go func() {
 		a.wsAdapter.BroadcastMemberChange(board.TeamID, member.BoardID, member)
@@ -315,8 +501,8 @@ func (a *App) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember,
 	return newMember, nil
 }
 
-func (a *App) isLastAdmin(userID, boardID string) (bool, error) {
-	members, err := a.store.GetMembersForBoard(boardID)
+func isLastAdmin(st store.Store, userID, boardID string) (bool, error) {
+	members, err := st.GetMembersForBoard(boardID)
 	if err != nil {
 		return false, err
 	}
@@ -338,29 +524,40 @@ func (a *App) DeleteBoardMember(boardID, userID string) error {
 		return bErr
 	}
 
-	oldMember, err := a.store.GetMemberForBoard(boardID, userID)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil
-	}
-	if err != nil {
-		return err
-	}
-
-	// if we're removing an admin, we need to check that there is at
-	// least still another admin on the board
-	if oldMember.SchemeAdmin {
-		isLastAdmin, err := a.isLastAdmin(userID, boardID)
+	deleted := false
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		oldMember, err := tx.GetMemberForBoard(boardID, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
-		if isLastAdmin {
-			return ErrBoardMemberIsLastAdmin
+
+		// if we're removing an admin, we need to check that there is at
+		// least still another admin on the board
+		if oldMember.SchemeAdmin {
+			lastAdmin, err2 := isLastAdmin(tx, userID, boardID)
+			if err2 != nil {
+				return err2
+			}
+			if lastAdmin {
+				return ErrBoardMemberIsLastAdmin
+			}
 		}
-	}
 
-	if err := a.store.DeleteMember(boardID, userID); err != nil {
+		if err = tx.DeleteMember(boardID, userID); err != nil {
+			return err
+		}
+		deleted = true
+		return nil
+	})
+	if err != nil {
 		return err
 	}
+	if !deleted {
+		return nil
+	}
 
 	go func() {
 		a.wsAdapter.BroadcastMemberDelete(board.TeamID, boardID, userID)
@@ -369,6 +566,75 @@ func (a *App) DeleteBoardMember(boardID, userID string) error {
 	return nil
 }
 
+// RestoreBoardMember restores the deleted membership of the user on the
+// board, with the roles it had when it was deleted.
+func (a *App) RestoreBoardMember(boardID, userID string) (*model.BoardMember, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	member, err := a.store.RestoreMember(boardID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.NewCodedError(model.ErrCodeNotFound, "deleted board member not found", map[string]interface{}{"boardId": boardID, "userId": userID})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.applyCategoryRules(userID, board.TeamID, []*model.Board{board})
+
+	go func() {
+		a.wsAdapter.BroadcastMemberChange(board.TeamID, boardID, member)
+	}()
+
+	return member, nil
+}
+
+// PatchBoardMemberCustomFields updates the custom fields of a member of
+// the board.
+func (a *App) PatchBoardMemberCustomFields(boardID, userID string, patch *model.MemberCustomFieldsPatch) (*model.BoardMember, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	member, err := a.store.GetMemberForBoard(boardID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.NewCodedError(model.ErrCodeNotFound, "board member not found", map[string]interface{}{"boardId": boardID, "userId": userID})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	customFields, err := patch.Patch(member.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.store.UpdateMemberCustomFields(boardID, userID, customFields); err != nil {
+		return nil, err
+	}
+	member.CustomFields = customFields
+
+	go func() {
+		a.wsAdapter.BroadcastMemberChange(board.TeamID, boardID, member)
+	}()
+
+	return member, nil
+}
+
+// GetDeletedMembersForBoard returns the deleted memberships of the board
+// that can be restored, the most recently deleted first.
+func (a *App) GetDeletedMembersForBoard(boardID string) ([]*model.BoardMember, error) {
+	return a.store.GetDeletedMembersForBoard(boardID)
+}
+
 func (a *App) SearchBoardsForUserAndTeam(term, userID, teamID string) ([]*model.Board, error) {
 	return a.store.SearchBoardsForUserAndTeam(term, userID, teamID)
 }
@@ -399,7 +665,7 @@ func (a *App) UndeleteBoard(boardID string, modifiedBy string) error {
 		return nil
 	}
 
-	a.blockChangeNotifier.Enqueue(func() error {
+	a.enqueue(func(a *App) error {
 		a.wsAdapter.BroadcastBoardChange(board.TeamID, board)
 		return nil
 	})
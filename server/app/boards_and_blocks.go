@@ -3,6 +3,7 @@ package app
 import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/store"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -10,14 +11,20 @@ import (
 func (a *App) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string, addMember bool) (*model.BoardsAndBlocks, error) {
 	var newBab *model.BoardsAndBlocks
 	var members []*model.BoardMember
-	var err error
 
-	if addMember {
-		newBab, members, err = a.store.CreateBoardsAndBlocksWithAdmin(bab, userID)
-	} else {
-		newBab, err = a.store.CreateBoardsAndBlocks(bab, userID)
+	if err := a.filterBlocks(bab.Blocks); err != nil {
+		return nil, err
 	}
 
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		var err error
+		if addMember {
+			newBab, members, err = tx.CreateBoardsAndBlocksWithAdmin(bab, userID)
+		} else {
+			newBab, err = tx.CreateBoardsAndBlocks(bab, userID)
+		}
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +41,7 @@ func (a *App) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string, a
 		b := block
 		a.wsAdapter.BroadcastBlockChange(teamID, b)
 		a.metrics.IncrementBlocksInserted(1)
-		a.webhook.NotifyUpdate(b)
+		a.webhook.NotifyBlockChange(model.BoardWebhookEventBlockCreated, b)
 		a.notifyBlockChanged(notify.Add, &b, nil, userID)
 	}
 
@@ -42,27 +49,67 @@ func (a *App) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string, a
 		for _, member := range members {
 			a.wsAdapter.BroadcastMemberChange(teamID, member.BoardID, member)
 		}
+		a.applyCategoryRules(userID, teamID, newBab.Boards)
 	}
 
 	return newBab, nil
 }
 
 func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
-	oldBlocksMap := map[string]*model.Block{}
-	for _, blockID := range pbab.BlockIDs {
-		block, err := a.store.GetBlock(blockID)
-		if err != nil {
+	// the patched blocks belong to the patched boards
+	if err := a.checkBoardsWritable(pbab.BoardIDs, userID); err != nil {
+		return nil, err
+	}
+
+	for i, blockPatch := range pbab.BlockPatches {
+		if i >= len(pbab.BlockIDs) {
+			break
+		}
+		if err := a.filterBlockPatch(pbab.BlockIDs[i], blockPatch); err != nil {
 			return nil, err
 		}
-		oldBlocksMap[blockID] = block
 	}
 
-	bab, err := a.store.PatchBoardsAndBlocks(pbab, userID)
+	// the old blocks are read in the same transaction as the patch so
+	// that the notifications compare against the patched versions
+	var oldBlocksMap map[string]*model.Block
+	var oldBoardsMap map[string]*model.Board
+	var bab *model.BoardsAndBlocks
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		oldBlocksMap = map[string]*model.Block{}
+		for _, blockID := range pbab.BlockIDs {
+			block, err := tx.GetBlock(blockID)
+			if err != nil {
+				return err
+			}
+			oldBlocksMap[blockID] = block
+		}
+
+		// only the boards whose description changes are notified
+		oldBoardsMap = map[string]*model.Board{}
+		for i, boardID := range pbab.BoardIDs {
+			if i >= len(pbab.BoardPatches) || pbab.BoardPatches[i] == nil || pbab.BoardPatches[i].Description == nil {
+				continue
+			}
+			board, err := tx.GetBoard(boardID)
+			if err != nil {
+				return err
+			}
+			if err = checkBoardDescriptionPatch(board, pbab.BoardPatches[i]); err != nil {
+				return err
+			}
+			oldBoardsMap[boardID] = board
+		}
+
+		var err error
+		bab, err = tx.PatchBoardsAndBlocks(pbab, userID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	a.blockChangeNotifier.Enqueue(func() error {
+	a.enqueue(func(a *App) error {
 		teamID := bab.Boards[0].TeamID
 
 		for _, block := range bab.Blocks {
@@ -75,12 +122,15 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 			b := block
 			a.metrics.IncrementBlocksPatched(1)
 			a.wsAdapter.BroadcastBlockChange(teamID, b)
-			a.webhook.NotifyUpdate(b)
+			a.webhook.NotifyBlockChange(model.BoardWebhookEventBlockUpdated, b)
 			a.notifyBlockChanged(notify.Update, &b, oldBlock, userID)
 		}
 
 		for _, board := range bab.Boards {
 			a.wsAdapter.BroadcastBoardChange(board.TeamID, board)
+			if oldBoard, ok := oldBoardsMap[board.ID]; ok {
+				a.notifyBoardChanged(notify.Update, board, oldBoard, userID)
+			}
 		}
 		return nil
 	})
@@ -89,30 +139,41 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 }
 
 func (a *App) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error {
-	firstBoard, err := a.store.GetBoard(dbab.Boards[0])
-	if err != nil {
+	if err := a.checkBoardsWritable(dbab.Boards, userID); err != nil {
 		return err
 	}
 
-	// we need the block entity to notify of the block changes, so we
-	// fetch and store the blocks first
-	blocks := []*model.Block{}
-	for _, blockID := range dbab.Blocks {
-		block, err := a.store.GetBlock(blockID)
+	var firstBoard *model.Board
+	var blocks []*model.Block
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		var err error
+		firstBoard, err = tx.GetBoard(dbab.Boards[0])
 		if err != nil {
 			return err
 		}
-		blocks = append(blocks, block)
-	}
 
-	if err := a.store.DeleteBoardsAndBlocks(dbab, userID); err != nil {
+		// we need the block entity to notify of the block changes, so we
+		// fetch and store the blocks first
+		blocks = []*model.Block{}
+		for _, blockID := range dbab.Blocks {
+			block, err := tx.GetBlock(blockID)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, block)
+		}
+
+		return tx.DeleteBoardsAndBlocks(dbab, userID)
+	})
+	if err != nil {
 		return err
 	}
 
-	a.blockChangeNotifier.Enqueue(func() error {
+	a.enqueue(func(a *App) error {
 		for _, block := range blocks {
 			a.wsAdapter.BroadcastBlockDelete(firstBoard.TeamID, block.ID, block.BoardID)
 			a.metrics.IncrementBlocksDeleted(1)
+			a.webhook.NotifyBlockChange(model.BoardWebhookEventBlockDeleted, *block)
 			a.notifyBlockChanged(notify.Update, block, block, userID)
 		}
 
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// CreateCardsInBulk creates the cards of the request, with their
+// properties and content blocks, on a board. All the blocks are
+// inserted in one transaction and broadcast in a single message. It
+// returns the created blocks, each card followed by its content.
+func (a *App) CreateCardsInBulk(boardID string, request *model.BulkCardsRequest, userID string) ([]model.Block, error) {
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkBoardWritable(board, userID); err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	blocks := []model.Block{}
+	for i, bulkCard := range request.Cards {
+		properties, err := schema.ResolvePropertyValues(bulkCard.Properties)
+		if errors.Is(err, model.ErrInvalidProperty) || errors.Is(err, model.ErrInvalidPropertyValue) {
+			return nil, model.NewCodedError(model.ErrCodeBadRequest, err.Error(), map[string]interface{}{"card": i})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cardProperties := map[string]interface{}{}
+		for key, value := range properties {
+			if value != nil {
+				cardProperties[key] = value
+			}
+		}
+
+		card := model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			ParentID: boardID,
+			BoardID:  boardID,
+			Type:     model.TypeCard,
+			Title:    bulkCard.Title,
+			CreateAt: now,
+			UpdateAt: now,
+			Fields: map[string]interface{}{
+				"icon":       bulkCard.Icon,
+				"properties": cardProperties,
+			},
+		}
+
+		content := make([]model.Block, 0, len(bulkCard.Content))
+		contentOrder := make([]interface{}, 0, len(bulkCard.Content))
+		for _, bulkContent := range bulkCard.Content {
+			fields := bulkContent.Fields
+			if fields == nil {
+				fields = map[string]interface{}{}
+			}
+			block := model.Block{
+				ID:       utils.NewID(utils.IDTypeBlock),
+				ParentID: card.ID,
+				BoardID:  boardID,
+				Type:     bulkContent.Type,
+				Title:    bulkContent.Title,
+				CreateAt: now,
+				UpdateAt: now,
+				Fields:   fields,
+			}
+			content = append(content, block)
+			contentOrder = append(contentOrder, block.ID)
+		}
+		card.Fields["contentOrder"] = contentOrder
+
+		blocks = append(blocks, card)
+		blocks = append(blocks, content...)
+	}
+
+	// filter all the blocks first, so a rejection inserts none of them
+	if err = a.filterBlocks(blocks); err != nil {
+		return nil, err
+	}
+
+	if err = a.store.InsertNewBlocks(blocks, userID); err != nil {
+		return nil, err
+	}
+
+	a.wsAdapter.BroadcastBlocksChange(board.TeamID, boardID, blocks)
+	a.metrics.IncrementBlocksInserted(len(blocks))
+
+	a.enqueue(func(a *App) error {
+		for _, b := range blocks {
+			block := b
+			a.webhook.NotifyBlockChange(model.BoardWebhookEventBlockCreated, block)
+			a.notifyBlockChanged(notify.Add, &block, nil, userID)
+		}
+		return nil
+	})
+
+	return blocks, nil
+}
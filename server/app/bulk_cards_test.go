@@ -0,0 +1,103 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestCreateCardsInBulk(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	// the changes are broadcast in the background
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	board := &model.Board{
+		ID:     testBoardID,
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status-id",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "done-id", "value": "Done"},
+				},
+			},
+		},
+	}
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+
+	t.Run("cards and content are inserted together", func(t *testing.T) {
+		request := &model.BulkCardsRequest{
+			Cards: []model.BulkCard{
+				{
+					Title:      "first",
+					Properties: map[string]string{"Status": "Done"},
+					Content: []model.BulkCardContent{
+						{Type: model.TypeText, Title: "description"},
+						{Type: model.TypeCheckbox, Title: "task"},
+					},
+				},
+				{Title: "second"},
+			},
+		}
+
+		var inserted []model.Block
+		th.Store.EXPECT().InsertNewBlocks(gomock.Any(), "user-id-1").DoAndReturn(func(blocks []model.Block, userID string) error {
+			inserted = blocks
+			return nil
+		})
+
+		blocks, err := th.App.CreateCardsInBulk(testBoardID, request, "user-id-1")
+		require.NoError(t, err)
+		require.Len(t, blocks, 4)
+		require.Equal(t, blocks, inserted)
+
+		first := blocks[0]
+		require.Equal(t, model.BlockType(model.TypeCard), first.Type)
+		require.Equal(t, "first", first.Title)
+		require.Equal(t, testBoardID, first.ParentID)
+		require.Equal(t, map[string]interface{}{"status-id": "done-id"}, first.Fields["properties"])
+		require.Equal(t, []interface{}{blocks[1].ID, blocks[2].ID}, first.Fields["contentOrder"])
+
+		require.Equal(t, first.ID, blocks[1].ParentID)
+		require.Equal(t, model.BlockType(model.TypeText), blocks[1].Type)
+		require.Equal(t, first.ID, blocks[2].ParentID)
+
+		require.Equal(t, model.BlockType(model.TypeCard), blocks[3].Type)
+		require.Equal(t, "second", blocks[3].Title)
+		require.Empty(t, blocks[3].Fields["contentOrder"])
+	})
+
+	t.Run("invalid property value", func(t *testing.T) {
+		request := &model.BulkCardsRequest{
+			Cards: []model.BulkCard{
+				{Title: "first"},
+				{Title: "second", Properties: map[string]string{"Status": "Unknown"}},
+			},
+		}
+
+		_, err := th.App.CreateCardsInBulk(testBoardID, request, "user-id-1")
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, err := th.App.CreateCardsInBulk(testBoardID, &model.BulkCardsRequest{}, "user-id-1")
+		require.Error(t, err)
+	})
+
+	t.Run("insert error", func(t *testing.T) {
+		request := &model.BulkCardsRequest{Cards: []model.BulkCard{{Title: "first"}}}
+		th.Store.EXPECT().InsertNewBlocks(gomock.Any(), "user-id-1").Return(blockError{"error"})
+
+		_, err := th.App.CreateCardsInBulk(testBoardID, request, "user-id-1")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,262 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const calendarFetchTimeout = 30 * time.Second
+
+var ErrCalendarFetchFailed = errors.New("cannot fetch the calendar")
+
+// ImportCalendar creates a card on the board for each new event of the
+// iCalendar file, with the event dates in a date property, and updates
+// the cards of the events imported before. The date property is taken by
+// ID or name, the first date property of the board being used if empty.
+func (a *App) ImportCalendar(boardID string, r io.Reader, dateProperty, userID string) (*model.CalendarImportResult, error) {
+	events, err := model.ParseCalendar(r)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	return a.importCalendarEvents(board, events, dateProperty, userID)
+}
+
+// importCalendarEvents creates or updates the cards of the events,
+// matching them by the UID of their event. The cancelled and unchanged
+// events are skipped; the cards of events removed from the calendar are
+// left as they are.
+func (a *App) importCalendarEvents(board *model.Board, events []model.CalendarEvent, dateProperty, userID string) (*model.CalendarImportResult, error) {
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	def, ok := schema.DateProperty(dateProperty)
+	if !ok {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "the events must be imported in a date property of the board",
+			map[string]interface{}{"dateProperty": dateProperty})
+	}
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+	cardsByUID := map[string]*model.Block{}
+	for i := range cards {
+		if uid, _ := cards[i].Fields[model.CardFieldCalendarEventUID].(string); uid != "" {
+			cardsByUID[uid] = &cards[i]
+		}
+	}
+
+	result := &model.CalendarImportResult{}
+	now := utils.GetMillis()
+	newCards := []model.Block{}
+	patches := &model.BlockPatchBatch{}
+	imported := map[string]bool{}
+	for _, event := range events {
+		if event.Cancelled || imported[event.UID] {
+			result.Skipped++
+			continue
+		}
+		imported[event.UID] = true
+		dateValue := event.DateValue()
+
+		card, ok := cardsByUID[event.UID]
+		if !ok {
+			newCards = append(newCards, model.Block{
+				ID:       utils.NewID(utils.IDTypeCard),
+				ParentID: board.ID,
+				BoardID:  board.ID,
+				Type:     model.TypeCard,
+				Title:    event.Summary,
+				CreateAt: now,
+				UpdateAt: now,
+				Fields: map[string]interface{}{
+					"icon":                          "",
+					"properties":                    map[string]interface{}{def.ID: dateValue},
+					"contentOrder":                  []interface{}{},
+					model.CardFieldCalendarEventUID: event.UID,
+				},
+			})
+			continue
+		}
+
+		properties := map[string]interface{}{}
+		if cardProperties, ok := card.Fields["properties"].(map[string]interface{}); ok {
+			for key, value := range cardProperties {
+				properties[key] = value
+			}
+		}
+		if card.Title == event.Summary && properties[def.ID] == dateValue {
+			result.Skipped++
+			continue
+		}
+		properties[def.ID] = dateValue
+		title := event.Summary
+		patches.BlockIDs = append(patches.BlockIDs, card.ID)
+		patches.BlockPatches = append(patches.BlockPatches, model.BlockPatch{
+			Title:         &title,
+			UpdatedFields: map[string]interface{}{"properties": properties},
+		})
+	}
+
+	if len(newCards) > 0 {
+		if _, err := a.InsertBlocks(newCards, userID, false); err != nil {
+			return nil, err
+		}
+	}
+	if len(patches.BlockIDs) > 0 {
+		if err := a.PatchBlocks(board.TeamID, patches, userID); err != nil {
+			return nil, err
+		}
+	}
+	result.Created = len(newCards)
+	result.Updated = len(patches.BlockIDs)
+
+	a.logger.Debug("imported the events of a calendar",
+		mlog.String("boardID", board.ID),
+		mlog.Int("created", result.Created),
+		mlog.Int("updated", result.Updated),
+	)
+	return result, nil
+}
+
+// GetCalendarSubscription returns the calendar the board is subscribed
+// to.
+func (a *App) GetCalendarSubscription(boardID string) (*model.CalendarSubscription, error) {
+	subscription, err := a.store.GetCalendarSubscription(boardID)
+	if a.store.IsErrNotFound(err) {
+		return nil, model.NewCodedError(model.ErrCodeNotFound, "calendar subscription not found", map[string]interface{}{"boardId": boardID})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// SetCalendarSubscription subscribes the board to a calendar, replacing
+// its previous subscription. The calendar is imported on the next refresh
+// of the subscriptions, and then periodically.
+func (a *App) SetCalendarSubscription(boardID string, subscription *model.CalendarSubscription, userID string) (*model.CalendarSubscription, error) {
+	if err := subscription.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := schema.DateProperty(subscription.DateProperty); !ok {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "the events must be imported in a date property of the board",
+			map[string]interface{}{"dateProperty": subscription.DateProperty})
+	}
+
+	subscription.BoardID = boardID
+	subscription.ModifiedBy = userID
+	subscription.UpdateAt = utils.GetMillis()
+	subscription.LastSyncAt = 0
+	subscription.LastError = ""
+
+	if err := a.store.SaveCalendarSubscription(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// DeleteCalendarSubscription unsubscribes the board from its calendar.
+// The cards imported from it are kept.
+func (a *App) DeleteCalendarSubscription(boardID string) error {
+	return a.store.DeleteCalendarSubscription(boardID)
+}
+
+// RefreshCalendarSubscriptions imports the subscribed calendars that
+// were not imported for a refresh interval. It is meant to run
+// periodically.
+func (a *App) RefreshCalendarSubscriptions() {
+	now := utils.GetMillis()
+
+	subscriptions, err := a.store.GetDueCalendarSubscriptions(now - model.CalendarSubscriptionRefreshInterval)
+	if err != nil {
+		a.logger.Error("cannot get the due calendar subscriptions", mlog.Err(err))
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if err := a.refreshCalendarSubscription(subscription, now); err != nil {
+			a.logger.Error("cannot refresh the calendar subscription of a board", mlog.String("boardID", subscription.BoardID), mlog.Err(err))
+		}
+	}
+}
+
+// refreshCalendarSubscription imports the calendar of the subscription
+// and records the result of the import. The subscriptions of deleted
+// boards are dropped.
+func (a *App) refreshCalendarSubscription(subscription *model.CalendarSubscription, now int64) error {
+	board, err := a.GetBoard(subscription.BoardID)
+	if err != nil {
+		return err
+	}
+	if board == nil {
+		return a.store.DeleteCalendarSubscription(subscription.BoardID)
+	}
+
+	subscription.LastSyncAt = now
+	subscription.LastError = ""
+	if err := a.importSubscribedCalendar(board, subscription); err != nil {
+		a.logger.Warn("cannot import the subscribed calendar of a board",
+			mlog.String("boardID", board.ID),
+			mlog.Err(err),
+		)
+		subscription.LastError = err.Error()
+	}
+	return a.store.SaveCalendarSubscription(subscription)
+}
+
+func (a *App) importSubscribedCalendar(board *model.Board, subscription *model.CalendarSubscription) error {
+	req, err := http.NewRequest(http.MethodGet, subscription.FetchURL(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	client := &http.Client{Timeout: calendarFetchTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrCalendarFetchFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: the server returned %d", ErrCalendarFetchFailed, resp.StatusCode)
+	}
+
+	events, err := model.ParseCalendar(resp.Body)
+	if err != nil {
+		return err
+	}
+	_, err = a.importCalendarEvents(board, events, subscription.DateProperty, model.SystemUserID)
+	return err
+}
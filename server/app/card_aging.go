@@ -0,0 +1,40 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// getCardsAging returns how long the cards of the board have been in
+// their current status, by card ID, read from the history of the cards.
+// Boards without a status property have no aging.
+func (a *App) getCardsAging(board *model.Board, cards []model.Block) (map[string]*model.CardAging, error) {
+	if len(cards) == 0 {
+		return nil, nil
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	status, ok := schema.StatusProperty(board)
+	if !ok {
+		return nil, nil
+	}
+
+	cardIDs := make([]string, 0, len(cards))
+	for _, card := range cards {
+		cardIDs = append(cardIDs, card.ID)
+	}
+	since, err := a.store.GetCardsStatusSince(board.ID, status.ID, cardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	aging := make(map[string]*model.CardAging, len(cards))
+	for i := range cards {
+		aging[cards[i].ID] = model.NewCardAging(&cards[i], status.ID, since[cards[i].ID], now)
+	}
+	return aging, nil
+}
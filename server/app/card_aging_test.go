@@ -0,0 +1,46 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCardsAging(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: testBoardID,
+		CardProperties: []map[string]interface{}{
+			{"id": "status-id", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "doing", "value": "Doing"},
+			}},
+		},
+	}
+	cards := []model.Block{
+		{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard, CreateAt: 1000, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status-id": "doing"},
+		}},
+		{ID: "card-2", BoardID: testBoardID, Type: model.TypeCard, CreateAt: 2000},
+	}
+
+	t.Run("from the history of the cards", func(t *testing.T) {
+		th.Store.EXPECT().GetCardsStatusSince(testBoardID, "status-id", []string{"card-1", "card-2"}).Return(map[string]int64{"card-1": 5000}, nil)
+
+		aging, err := th.App.getCardsAging(board, cards)
+		require.NoError(t, err)
+		require.Len(t, aging, 2)
+		require.Equal(t, "doing", aging["card-1"].Status)
+		require.Equal(t, int64(5000), aging["card-1"].StatusSince)
+		require.Equal(t, int64(2000), aging["card-2"].StatusSince)
+		require.Positive(t, aging["card-2"].TimeInStatus)
+	})
+
+	t.Run("board without status property", func(t *testing.T) {
+		aging, err := th.App.getCardsAging(&model.Board{ID: testBoardID}, cards)
+		require.NoError(t, err)
+		require.Nil(t, aging)
+	})
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetCardGroups counts the cards of the board by the value of a select or
+// person property, as the lanes of a kanban view group them, and sums the
+// number property sumPropertyID over each group if it's not empty. The
+// groups of a select property follow the order of its options, and include
+// the options no card takes, after the group of the cards without a value.
+// If viewID is not empty, the cards are grouped by the property of the
+// view when groupByPropertyID is empty, and the groups include the status
+// of the WIP limits of the lanes of the view.
+func (a *App) GetCardGroups(boardID, groupByPropertyID, sumPropertyID, viewID string) ([]*model.CardGroup, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	var limits *model.WIPLimits
+	if viewID != "" {
+		view, err := a.store.GetBlock(viewID)
+		if err != nil {
+			return nil, err
+		}
+		if view == nil || view.BoardID != boardID || view.Type != model.TypeView {
+			return nil, model.NewErrBlockNotFound(viewID)
+		}
+		if groupByPropertyID == "" {
+			groupByPropertyID, _ = view.Fields["groupById"].(string)
+		}
+		if limits = model.WIPLimitsFromView(view); limits != nil && limits.PropertyID != groupByPropertyID {
+			limits = nil
+		}
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	groupBy, err := groupingProperty(schema, groupByPropertyID)
+	if err != nil {
+		return nil, err
+	}
+	if sumPropertyID != "" {
+		if sum, ok := schema[sumPropertyID]; !ok || sum.Type != "number" {
+			return nil, model.NewCodedError(model.ErrCodeBadRequest, "only a number property can be summed", map[string]interface{}{"propertyId": sumPropertyID})
+		}
+	}
+
+	groups, err := a.store.GetCardGroups(boardID, groupByPropertyID, sumPropertyID)
+	if err != nil {
+		return nil, err
+	}
+	if groupBy.Type == "select" {
+		groups = orderCardGroups(groupBy, groups, sumPropertyID != "")
+	}
+
+	for _, group := range groups {
+		group.Limit, group.LimitStatus = limits.Status(group.Value, group.Count)
+	}
+	return groups, nil
+}
+
+// groupingProperty returns the property of the schema the cards are
+// grouped by, which must be a select or person property.
+func groupingProperty(schema model.PropSchema, propertyID string) (model.PropDef, error) {
+	property, ok := schema[propertyID]
+	if !ok || (property.Type != "select" && property.Type != "person") {
+		return model.PropDef{}, model.NewCodedError(model.ErrCodeBadRequest, "the cards can only be grouped by a select or person property", map[string]interface{}{"propertyId": propertyID})
+	}
+	return property, nil
+}
+
+// orderCardGroups sorts the groups of a select property in the order of
+// its options, adding the empty groups. The values that are not options
+// anymore come last.
+func orderCardGroups(property model.PropDef, groups []*model.CardGroup, withSum bool) []*model.CardGroup {
+	byValue := map[string]*model.CardGroup{}
+	for _, group := range groups {
+		byValue[group.Value] = group
+	}
+
+	options := make([]model.PropDefOption, 0, len(property.Options))
+	for _, option := range property.Options {
+		options = append(options, option)
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i].Index < options[j].Index })
+
+	values := []string{""}
+	for _, option := range options {
+		values = append(values, option.ID)
+	}
+
+	ordered := make([]*model.CardGroup, 0, len(values))
+	for _, value := range values {
+		group, ok := byValue[value]
+		if !ok {
+			group = &model.CardGroup{Value: value}
+			if withSum {
+				group.Sum = new(float64)
+			}
+		}
+		delete(byValue, value)
+		ordered = append(ordered, group)
+	}
+
+	stale := make([]*model.CardGroup, 0, len(byValue))
+	for _, group := range byValue {
+		stale = append(stale, group)
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].Value < stale[j].Value })
+	return append(ordered, stale...)
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCardGroups(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: "board-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "status-id", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To do"},
+				map[string]interface{}{"id": "doing", "value": "Doing"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+			{"id": "points-id", "name": "Points", "type": "number"},
+			{"id": "title-id", "name": "Notes", "type": "text"},
+		},
+	}
+
+	t.Run("groups follow the order of the options", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		sum := 3.0
+		th.Store.EXPECT().GetCardGroups("board-id", "status-id", "points-id").Return([]*model.CardGroup{
+			{Value: "removed-option", Count: 1, Sum: &sum},
+			{Value: "done", Count: 2, Sum: &sum},
+			{Value: "todo", Count: 1, Sum: &sum},
+		}, nil)
+
+		groups, err := th.App.GetCardGroups("board-id", "status-id", "points-id", "")
+		require.NoError(t, err)
+		values := []string{}
+		for _, group := range groups {
+			values = append(values, group.Value)
+			require.NotNil(t, group.Sum)
+		}
+		require.Equal(t, []string{"", "todo", "doing", "done", "removed-option"}, values)
+		require.Equal(t, int64(0), groups[2].Count)
+		require.Equal(t, int64(2), groups[3].Count)
+	})
+
+	t.Run("invalid properties", func(t *testing.T) {
+		for _, ids := range [][2]string{{"title-id", ""}, {"missing-id", ""}, {"status-id", "title-id"}} {
+			th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+
+			_, err := th.App.GetCardGroups("board-id", ids[0], ids[1], "")
+			ce, ok := model.AsCodedError(err)
+			require.True(t, ok)
+			require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+		}
+	})
+
+	t.Run("WIP limits of the view", func(t *testing.T) {
+		view := &model.Block{
+			ID:      "view-id",
+			BoardID: "board-id",
+			Type:    model.TypeView,
+			Fields: map[string]interface{}{
+				"groupById": "status-id",
+				"wipLimits": map[string]interface{}{"doing": float64(2), "done": float64(1)},
+			},
+		}
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetBlock("view-id").Return(view, nil)
+		th.Store.EXPECT().GetCardGroups("board-id", "status-id", "").Return([]*model.CardGroup{
+			{Value: "doing", Count: 2},
+			{Value: "done", Count: 3},
+		}, nil)
+
+		groups, err := th.App.GetCardGroups("board-id", "", "", "view-id")
+		require.NoError(t, err)
+		require.Len(t, groups, 4)
+		require.Empty(t, groups[1].LimitStatus)
+		require.Equal(t, int64(2), groups[2].Limit)
+		require.Equal(t, model.WIPLimitStatusAt, groups[2].LimitStatus)
+		require.Equal(t, int64(1), groups[3].Limit)
+		require.Equal(t, model.WIPLimitStatusOver, groups[3].LimitStatus)
+	})
+
+	t.Run("view of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetBlock("view-id").Return(&model.Block{ID: "view-id", BoardID: "board-id-2", Type: model.TypeView}, nil)
+
+		_, err := th.App.GetCardGroups("board-id", "status-id", "", "view-id")
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBlockNotFound, ce.Code)
+	})
+}
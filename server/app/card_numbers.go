@@ -0,0 +1,65 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// SetBoardKey sets the key the cards of the board are referenced with.
+// The cards of the board are numbered once it has a key.
+func (a *App) SetBoardKey(boardID, key string) error {
+	key = strings.ToUpper(key)
+	if !model.IsValidBoardKey(key) {
+		return model.NewCodedError(model.ErrCodeBadRequest, "the key must have 2 to 10 letters and digits, starting with a letter", map[string]interface{}{"key": key})
+	}
+	return a.store.SetBoardKey(boardID, key)
+}
+
+// GetBoardKey returns the key of the board, or an empty string if the
+// board has no key.
+func (a *App) GetBoardKey(boardID string) (string, error) {
+	key, err := a.store.GetBoardKey(boardID)
+	if store.IsErrNotFound(err) {
+		return "", nil
+	}
+	return key, err
+}
+
+// GetCardPermalink resolves the number of a card on the board with the
+// key to the card and its canonical URL.
+func (a *App) GetCardPermalink(boardKey string, number int64) (*model.CardPermalink, error) {
+	boardKey = strings.ToUpper(boardKey)
+	boardID, err := a.store.GetBoardIDForKey(boardKey)
+	if err != nil {
+		return nil, err
+	}
+
+	cardID, err := a.store.GetCardIDForNumber(boardID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	// the card may have been deleted or moved to another board
+	if card == nil || card.BoardID != boardID || card.DeleteAt != 0 {
+		return nil, store.NewErrNotFound(model.CardReference(boardKey, number))
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CardPermalink{
+		Reference: model.CardReference(boardKey, number),
+		Number:    number,
+		URL:       utils.MakeCardLink(a.config.ServerRoot, board.TeamID, board.ID, card.ID),
+		Card:      card,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetCardPreview returns the summary of the card shown when a link to
+// the card is posted in a channel, with the time the card took its
+// current status.
+func (a *App) GetCardPreview(card *model.Block) (*model.CardPreview, error) {
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := model.NewCardPreview(card, board)
+	if err != nil {
+		return nil, err
+	}
+	if preview.Status == "" {
+		return preview, nil
+	}
+
+	aging, err := a.getCardsAging(board, []model.Block{*card})
+	if err != nil {
+		return nil, err
+	}
+	if cardAging, ok := aging[card.ID]; ok {
+		preview.StatusSince = cardAging.StatusSince
+	}
+	return preview, nil
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// checkCardProperties checks the values of the properties of the cards of
+// the board that are read-only or reference users or other cards, then
+// has the changes validated by the validation webhooks of the board. The
+// old cards are matched by index, and are nil for new cards.
+func (a *App) checkCardProperties(board *model.Board, cards []model.Block, oldCards []*model.Block) error {
+	if board == nil {
+		return nil
+	}
+	if err := checkReadOnlyProperties(board, cards, oldCards); err != nil {
+		return err
+	}
+	if err := a.checkMultiPersonProperties(board, cards, oldCards); err != nil {
+		return err
+	}
+	if err := a.checkRelationProperties(board, cards, oldCards); err != nil {
+		return err
+	}
+	return a.webhook.ValidatePropertyChanges(board.ID, cards, oldCards)
+}
+
+// checkReadOnlyProperties checks that the cards don't set values on the
+// read-only properties of the board, which are taken from the metadata of
+// the cards.
+func checkReadOnlyProperties(board *model.Board, cards []model.Block, oldCards []*model.Block) error {
+	if board == nil {
+		return nil
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil
+	}
+
+	for i := range cards {
+		if cards[i].Type != model.TypeCard {
+			continue
+		}
+		var oldCard *model.Block
+		if i < len(oldCards) {
+			oldCard = oldCards[i]
+		}
+		if err := model.CheckReadOnlyProperties(&cards[i], oldCard, schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCardPropertyPatches checks the properties of the blocks once
+// patched, see checkCardProperties. The blocks and patches are matched by
+// index, and the boards of the blocks are given by ID.
+func (a *App) checkCardPropertyPatches(boards map[string]*model.Board, blocks []model.Block, patches []model.BlockPatch) error {
+	patched := map[string][]model.Block{}
+	oldBlocks := map[string][]*model.Block{}
+	for i := range blocks {
+		if i >= len(patches) {
+			break
+		}
+		if _, ok := patches[i].UpdatedFields["properties"]; !ok {
+			continue
+		}
+
+		block := blocks[i]
+		block.Fields = make(map[string]interface{}, len(blocks[i].Fields))
+		for key, value := range blocks[i].Fields {
+			block.Fields[key] = value
+		}
+		boardID := blocks[i].BoardID
+		patched[boardID] = append(patched[boardID], *patches[i].Patch(&block))
+		oldBlocks[boardID] = append(oldBlocks[boardID], &blocks[i])
+	}
+
+	for boardID, cards := range patched {
+		board, ok := boards[boardID]
+		if !ok || board == nil {
+			continue
+		}
+		if err := a.checkCardProperties(board, cards, oldBlocks[boardID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
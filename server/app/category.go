@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/utils"
 )
 
@@ -19,91 +20,123 @@ func (a *App) CreateCategory(category *model.Category) (*model.Category, error)
 		return nil, err
 	}
 
-	if err := a.store.CreateCategory(*category); err != nil {
-		return nil, err
-	}
-
-	createdCategory, err := a.store.GetCategory(category.ID)
+	var createdCategory *model.Category
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		// the new categories come after the existing ones
+		categories, err := tx.GetUserCategories(category.UserID, category.TeamID)
+		if err != nil {
+			return err
+		}
+		category.SortOrder = 0
+		if len(categories) > 0 {
+			category.SortOrder = categories[len(categories)-1].SortOrder + 1
+		}
+
+		if err = tx.CreateCategory(*category); err != nil {
+			return err
+		}
+
+		createdCategory, err = tx.GetCategory(category.ID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	go func() {
-		a.wsAdapter.BroadcastCategoryChange(*createdCategory)
-	}()
+	a.broadcastCategoryChange(*createdCategory)
 
 	return createdCategory, nil
 }
 
 func (a *App) UpdateCategory(category *model.Category) (*model.Category, error) {
-	// verify if category belongs to the user
-	existingCategory, err := a.store.GetCategory(category.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	if existingCategory.DeleteAt != 0 {
-		return nil, ErrorCategoryDeleted
-	}
-
-	if existingCategory.UserID != category.UserID {
-		return nil, ErrorCategoryPermissionDenied
-	}
-
-	category.UpdateAt = utils.GetMillis()
-	if err = category.IsValid(); err != nil {
-		return nil, err
-	}
-	if err = a.store.UpdateCategory(*category); err != nil {
-		return nil, err
-	}
-
-	updatedCategory, err := a.store.GetCategory(category.ID)
+	var updatedCategory *model.Category
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		// verify if category belongs to the user
+		existingCategory, err := tx.GetCategory(category.ID)
+		if err != nil {
+			return err
+		}
+
+		if existingCategory.DeleteAt != 0 {
+			return ErrorCategoryDeleted
+		}
+
+		if existingCategory.UserID != category.UserID {
+			return ErrorCategoryPermissionDenied
+		}
+
+		category.UpdateAt = utils.GetMillis()
+		if err = category.IsValid(); err != nil {
+			return err
+		}
+		if err = tx.UpdateCategory(*category); err != nil {
+			return err
+		}
+
+		updatedCategory, err = tx.GetCategory(category.ID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	go func() {
-		a.wsAdapter.BroadcastCategoryChange(*updatedCategory)
-	}()
+	a.broadcastCategoryChange(*updatedCategory)
 
 	return updatedCategory, nil
 }
 
 func (a *App) DeleteCategory(categoryID, userID, teamID string) (*model.Category, error) {
-	existingCategory, err := a.store.GetCategory(categoryID)
+	var deletedCategory *model.Category
+	alreadyDeleted := false
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		existingCategory, err := tx.GetCategory(categoryID)
+		if err != nil {
+			return err
+		}
+
+		// category is already deleted. This avoids
+		// overriding the original deleted at timestamp
+		if existingCategory.DeleteAt != 0 {
+			deletedCategory = existingCategory
+			alreadyDeleted = true
+			return nil
+		}
+
+		// verify if category belongs to the user
+		if existingCategory.UserID != userID {
+			return ErrorCategoryPermissionDenied
+		}
+
+		// verify if category belongs to the team
+		if existingCategory.TeamID != teamID {
+			return ErrorInvalidCategory
+		}
+
+		if err = tx.DeleteCategory(categoryID, userID, teamID); err != nil {
+			return err
+		}
+
+		deletedCategory, err = tx.GetCategory(categoryID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	// category is already deleted. This avoids
-	// overriding the original deleted at timestamp
-	if existingCategory.DeleteAt != 0 {
-		return existingCategory, nil
-	}
-
-	// verify if category belongs to the user
-	if existingCategory.UserID != userID {
-		return nil, ErrorCategoryPermissionDenied
-	}
-
-	// verify if category belongs to the team
-	if existingCategory.TeamID != teamID {
-		return nil, ErrorInvalidCategory
+	if alreadyDeleted {
+		return deletedCategory, nil
 	}
 
-	if err = a.store.DeleteCategory(categoryID, userID, teamID); err != nil {
-		return nil, err
-	}
-
-	deletedCategory, err := a.store.GetCategory(categoryID)
-	if err != nil {
-		return nil, err
-	}
-
-	go func() {
-		a.wsAdapter.BroadcastCategoryChange(*deletedCategory)
-	}()
+	a.broadcastCategoryChange(*deletedCategory)
 
 	return deletedCategory, nil
 }
+
+// broadcastCategoryChange queues the category change with the board
+// category changes, so that the clients learn about a new category before
+// the boards moved into it.
+func (a *App) broadcastCategoryChange(category model.Category) {
+	a.enqueue(func(a *App) error {
+		a.wsAdapter.BroadcastCategoryChange(category)
+		return nil
+	})
+}
@@ -1,27 +1,118 @@
 package app
 
-import "github.com/mattermost/focalboard/server/model"
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// NoCategoryID is the category ID that moves the boards out of the
+// categories of the user.
+const NoCategoryID = "0"
 
 func (a *App) GetUserCategoryBoards(userID, teamID string) ([]model.CategoryBoards, error) {
 	return a.store.GetUserCategoryBoards(userID, teamID)
 }
 
 func (a *App) AddUpdateUserCategoryBoard(teamID, userID, categoryID, boardID string) error {
-	err := a.store.AddUpdateCategoryBoard(userID, categoryID, boardID)
+	return a.AddUpdateUserCategoryBoards(teamID, userID, categoryID, []string{boardID})
+}
+
+// AddUpdateUserCategoryBoards moves the boards into the category of the
+// user, or out of their categories if the category ID is NoCategoryID.
+// The boards are moved in a single transaction, and the sessions of the
+// user are notified with a single message.
+func (a *App) AddUpdateUserCategoryBoards(teamID, userID, categoryID string, boardIDs []string) error {
+	if len(boardIDs) == 0 {
+		return nil
+	}
+
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		if categoryID != NoCategoryID {
+			category, err := tx.GetCategory(categoryID)
+			if err != nil {
+				return err
+			}
+			if category.DeleteAt != 0 {
+				return ErrorCategoryDeleted
+			}
+			if category.UserID != userID {
+				return ErrorCategoryPermissionDenied
+			}
+			if category.TeamID != teamID {
+				return ErrorInvalidCategory
+			}
+		}
+
+		for _, boardID := range boardIDs {
+			if err := tx.AddUpdateCategoryBoard(userID, categoryID, boardID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	a.blockChangeNotifier.Enqueue(func() error {
-		a.wsAdapter.BroadcastCategoryBoardChange(
-			teamID,
-			userID,
-			model.BoardCategoryWebsocketData{
-				BoardID:    boardID,
-				CategoryID: categoryID,
-			})
+	boardCategories := make([]*model.BoardCategoryWebsocketData, 0, len(boardIDs))
+	for _, boardID := range boardIDs {
+		boardCategories = append(boardCategories, &model.BoardCategoryWebsocketData{
+			BoardID:    boardID,
+			CategoryID: categoryID,
+		})
+	}
+
+	a.enqueue(func(a *App) error {
+		a.wsAdapter.BroadcastCategoryBoardChange(teamID, userID, boardCategories)
+		return nil
+	})
+
+	return nil
+}
+
+// ReorderCategories sets the order the categories of the user are shown
+// in on the team. The new order must list each of them once.
+func (a *App) ReorderCategories(userID, teamID string, newCategoryOrder []string) ([]string, error) {
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		categories, err := tx.GetUserCategories(userID, teamID)
+		if err != nil {
+			return err
+		}
+
+		if err := validateCategoryOrder(categories, newCategoryOrder); err != nil {
+			return err
+		}
+
+		return tx.ReorderCategories(userID, teamID, newCategoryOrder)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.enqueue(func(a *App) error {
+		a.wsAdapter.BroadcastCategoryReorder(teamID, userID, newCategoryOrder)
 		return nil
 	})
 
+	return newCategoryOrder, nil
+}
+
+func validateCategoryOrder(categories []model.Category, newCategoryOrder []string) error {
+	if len(newCategoryOrder) != len(categories) {
+		return model.NewCodedError(model.ErrCodeBadRequest, "the new order must list all the categories", nil)
+	}
+
+	owned := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		owned[category.ID] = true
+	}
+
+	seen := make(map[string]bool, len(newCategoryOrder))
+	for _, categoryID := range newCategoryOrder {
+		if !owned[categoryID] || seen[categoryID] {
+			return model.NewCodedError(model.ErrCodeBadRequest, "invalid category in the new order", map[string]interface{}{"categoryId": categoryID})
+		}
+		seen[categoryID] = true
+	}
 	return nil
 }
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestAddUpdateUserCategoryBoards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	category := &model.Category{ID: "category-id", UserID: "user-id", TeamID: "team-id"}
+
+	t.Run("moves all the boards in a transaction", func(t *testing.T) {
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetCategory("category-id").Return(category, nil)
+		th.Store.EXPECT().AddUpdateCategoryBoard("user-id", "category-id", "board-id-1").Return(nil)
+		th.Store.EXPECT().AddUpdateCategoryBoard("user-id", "category-id", "board-id-2").Return(nil)
+
+		err := th.App.AddUpdateUserCategoryBoards("team-id", "user-id", "category-id", []string{"board-id-1", "board-id-2"})
+		require.NoError(t, err)
+	})
+
+	t.Run("moves the boards out of the categories", func(t *testing.T) {
+		th.expectRunInTransaction()
+		th.Store.EXPECT().AddUpdateCategoryBoard("user-id", NoCategoryID, "board-id-1").Return(nil)
+
+		err := th.App.AddUpdateUserCategoryBoards("team-id", "user-id", NoCategoryID, []string{"board-id-1"})
+		require.NoError(t, err)
+	})
+
+	t.Run("category of another user", func(t *testing.T) {
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetCategory("category-id").Return(category, nil)
+
+		err := th.App.AddUpdateUserCategoryBoards("team-id", "other-user-id", "category-id", []string{"board-id-1"})
+		require.ErrorIs(t, err, ErrorCategoryPermissionDenied)
+	})
+
+	t.Run("category of another team", func(t *testing.T) {
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetCategory("category-id").Return(category, nil)
+
+		err := th.App.AddUpdateUserCategoryBoards("other-team-id", "user-id", "category-id", []string{"board-id-1"})
+		require.ErrorIs(t, err, ErrorInvalidCategory)
+	})
+}
+
+func TestReorderCategories(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	categories := []model.Category{
+		{ID: "category-id-1", UserID: "user-id", TeamID: "team-id"},
+		{ID: "category-id-2", UserID: "user-id", TeamID: "team-id"},
+	}
+
+	t.Run("reorders the categories", func(t *testing.T) {
+		newOrder := []string{"category-id-2", "category-id-1"}
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetUserCategories("user-id", "team-id").Return(categories, nil)
+		th.Store.EXPECT().ReorderCategories("user-id", "team-id", newOrder).Return(nil)
+
+		order, err := th.App.ReorderCategories("user-id", "team-id", newOrder)
+		require.NoError(t, err)
+		require.Equal(t, newOrder, order)
+	})
+
+	t.Run("invalid orders", func(t *testing.T) {
+		for _, newOrder := range [][]string{
+			{"category-id-1"},
+			{"category-id-1", "category-id-1"},
+			{"category-id-1", "other-category-id"},
+		} {
+			th.expectRunInTransaction()
+			th.Store.EXPECT().GetUserCategories("user-id", "team-id").Return(categories, nil)
+
+			_, err := th.App.ReorderCategories("user-id", "team-id", newOrder)
+			ce, ok := model.AsCodedError(err)
+			require.True(t, ok)
+			require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+		}
+	})
+}
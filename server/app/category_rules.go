@@ -0,0 +1,113 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *App) GetCategoryRules(userID, teamID string) ([]*model.CategoryRule, error) {
+	return a.store.GetCategoryRules(userID, teamID)
+}
+
+// CreateCategoryRule adds a rule to the rules of the user. The rule
+// applies to the boards created or shared with the user from then on.
+func (a *App) CreateCategoryRule(rule *model.CategoryRule) (*model.CategoryRule, error) {
+	rule.Hydrate()
+	if err := rule.IsValid(); err != nil {
+		return nil, err
+	}
+
+	err := a.store.RunInTransaction(func(tx store.Store) error {
+		category, err := tx.GetCategory(rule.CategoryID)
+		if err != nil {
+			return err
+		}
+		if category.DeleteAt != 0 {
+			return ErrorCategoryDeleted
+		}
+		if category.UserID != rule.UserID {
+			return ErrorCategoryPermissionDenied
+		}
+		if category.TeamID != rule.TeamID {
+			return ErrorInvalidCategory
+		}
+
+		rules, err := tx.GetCategoryRules(rule.UserID, rule.TeamID)
+		if err != nil {
+			return err
+		}
+		if len(rules) >= model.MaxCategoryRules {
+			return model.NewCodedError(model.ErrCodeBadRequest, "too many category rules", map[string]interface{}{"max": model.MaxCategoryRules})
+		}
+
+		return tx.CreateCategoryRule(rule)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (a *App) DeleteCategoryRule(ruleID, userID string) error {
+	return a.store.DeleteCategoryRule(ruleID, userID)
+}
+
+// applyCategoryRules moves the boards the user just got into the
+// category of the first of their rules that matches the title of each
+// board. The boards the user has already put in a category stay there.
+// Failures are only logged, as the boards remain usable uncategorized.
+func (a *App) applyCategoryRules(userID, teamID string, boards []*model.Board) {
+	if userID == "" || userID == model.SystemUserID || len(boards) == 0 {
+		return
+	}
+
+	rules, err := a.store.GetCategoryRules(userID, teamID)
+	if err != nil {
+		a.logger.Error("cannot get the category rules of the user", mlog.String("userID", userID), mlog.Err(err))
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	categoryBoards, err := a.store.GetUserCategoryBoards(userID, teamID)
+	if err != nil {
+		a.logger.Error("cannot get the categories of the user", mlog.String("userID", userID), mlog.Err(err))
+		return
+	}
+	categorized := map[string]bool{}
+	for _, category := range categoryBoards {
+		for _, boardID := range category.BoardIDs {
+			categorized[boardID] = true
+		}
+	}
+
+	var categoryIDs []string
+	boardIDsByCategory := map[string][]string{}
+	for _, board := range boards {
+		if board.IsTemplate || board.TeamID != teamID || categorized[board.ID] {
+			continue
+		}
+		rule := model.MatchCategoryRule(rules, board.Title)
+		if rule == nil {
+			continue
+		}
+		if _, ok := boardIDsByCategory[rule.CategoryID]; !ok {
+			categoryIDs = append(categoryIDs, rule.CategoryID)
+		}
+		boardIDsByCategory[rule.CategoryID] = append(boardIDsByCategory[rule.CategoryID], board.ID)
+	}
+
+	for _, categoryID := range categoryIDs {
+		if err := a.AddUpdateUserCategoryBoards(teamID, userID, categoryID, boardIDsByCategory[categoryID]); err != nil {
+			a.logger.Error("cannot apply the category rules of the user",
+				mlog.String("userID", userID),
+				mlog.String("categoryID", categoryID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
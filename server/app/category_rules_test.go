@@ -0,0 +1,92 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestCreateCategoryRule(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	category := &model.Category{ID: "category-id", UserID: "user-id", TeamID: "team-id"}
+
+	t.Run("creates the rule", func(t *testing.T) {
+		rule := &model.CategoryRule{UserID: "user-id", TeamID: "team-id", CategoryID: "category-id", TitlePattern: "Sprint*"}
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetCategory("category-id").Return(category, nil)
+		th.Store.EXPECT().GetCategoryRules("user-id", "team-id").Return(nil, nil)
+		th.Store.EXPECT().CreateCategoryRule(rule).Return(nil)
+
+		created, err := th.App.CreateCategoryRule(rule)
+		require.NoError(t, err)
+		require.NotEmpty(t, created.ID)
+		require.NotZero(t, created.CreateAt)
+	})
+
+	t.Run("category of another user", func(t *testing.T) {
+		rule := &model.CategoryRule{UserID: "other-user-id", TeamID: "team-id", CategoryID: "category-id", TitlePattern: "Sprint*"}
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetCategory("category-id").Return(category, nil)
+
+		_, err := th.App.CreateCategoryRule(rule)
+		require.ErrorIs(t, err, ErrorCategoryPermissionDenied)
+	})
+
+	t.Run("too many rules", func(t *testing.T) {
+		rule := &model.CategoryRule{UserID: "user-id", TeamID: "team-id", CategoryID: "category-id", TitlePattern: "Sprint*"}
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetCategory("category-id").Return(category, nil)
+		th.Store.EXPECT().GetCategoryRules("user-id", "team-id").Return(make([]*model.CategoryRule, model.MaxCategoryRules), nil)
+
+		_, err := th.App.CreateCategoryRule(rule)
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+	})
+}
+
+func TestApplyCategoryRules(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	rules := []*model.CategoryRule{
+		{ID: "rule-id-1", UserID: "user-id", TeamID: "team-id", CategoryID: "sprints", TitlePattern: "Sprint*"},
+		{ID: "rule-id-2", UserID: "user-id", TeamID: "team-id", CategoryID: "retros", TitlePattern: "*retro*"},
+	}
+	sprints := &model.Category{ID: "sprints", UserID: "user-id", TeamID: "team-id"}
+
+	boards := []*model.Board{
+		{ID: "board-id-1", TeamID: "team-id", Title: "Sprint 12"},
+		{ID: "board-id-2", TeamID: "team-id", Title: "Sprint 13"},
+		{ID: "board-id-3", TeamID: "team-id", Title: "Roadmap"},
+		{ID: "board-id-4", TeamID: "team-id", Title: "Sprint template", IsTemplate: true},
+		{ID: "board-id-5", TeamID: "team-id", Title: "Sprint 11"},
+	}
+
+	t.Run("moves the matching boards", func(t *testing.T) {
+		th.Store.EXPECT().GetCategoryRules("user-id", "team-id").Return(rules, nil)
+		th.Store.EXPECT().GetUserCategoryBoards("user-id", "team-id").Return([]model.CategoryBoards{
+			{Category: model.Category{ID: "other"}, BoardIDs: []string{"board-id-5"}},
+		}, nil)
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetCategory("sprints").Return(sprints, nil)
+		th.Store.EXPECT().AddUpdateCategoryBoard("user-id", "sprints", "board-id-1").Return(nil)
+		th.Store.EXPECT().AddUpdateCategoryBoard("user-id", "sprints", "board-id-2").Return(nil)
+
+		th.App.applyCategoryRules("user-id", "team-id", boards)
+	})
+
+	t.Run("users without rules", func(t *testing.T) {
+		th.Store.EXPECT().GetCategoryRules("user-id", "team-id").Return([]*model.CategoryRule{}, nil)
+
+		th.App.applyCategoryRules("user-id", "team-id", boards)
+	})
+
+	t.Run("the system user has no rules", func(t *testing.T) {
+		th.App.applyCategoryRules(model.SystemUserID, "team-id", boards)
+	})
+}
@@ -0,0 +1,11 @@
+package app
+
+import "github.com/mattermost/focalboard/server/services/changestream"
+
+// SubscribeToChanges subscribes to the board, block and member changes
+// made on this server, resuming after lastEventID if it is set. resumed
+// is false if the changes that followed lastEventID are no longer
+// available.
+func (a *App) SubscribeToChanges(lastEventID string) (sub *changestream.Subscription, missed []*changestream.Event, resumed bool) {
+	return a.changes.Subscribe(lastEventID)
+}
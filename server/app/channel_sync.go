@@ -0,0 +1,87 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// SyncBoardWithChannel adds the members of the channel linked to the
+// board that aren't members of the board yet, with the default member
+// role of the board.
+func (a *App) SyncBoardWithChannel(board *model.Board) error {
+	if !board.SyncsChannelMembers() {
+		return nil
+	}
+
+	userIDs, err := a.store.GetChannelMemberIDs(board.ChannelID)
+	if err != nil {
+		return fmt.Errorf("cannot get the members of channel %s: %w", board.ChannelID, err)
+	}
+
+	for _, userID := range userIDs {
+		if _, err := a.AddMemberToBoard(board.NewDefaultMember(userID)); err != nil {
+			return fmt.Errorf("cannot add channel member %s to board %s: %w", userID, board.ID, err)
+		}
+	}
+	return nil
+}
+
+// OnChannelMemberJoined adds the user to the boards that are kept in
+// sync with the members of the channel.
+func (a *App) OnChannelMemberJoined(channelID, userID string) error {
+	boards, err := a.store.GetBoardsForChannel(channelID)
+	if err != nil {
+		return err
+	}
+
+	for _, board := range boards {
+		if !board.SyncsChannelMembers() {
+			continue
+		}
+		if _, err := a.AddMemberToBoard(board.NewDefaultMember(userID)); err != nil {
+			return fmt.Errorf("cannot add channel member %s to board %s: %w", userID, board.ID, err)
+		}
+	}
+	return nil
+}
+
+// OnChannelMemberLeft removes the user from the boards that are kept in
+// sync with the members of the channel. Board admins keep their
+// membership so the board isn't left without admins.
+func (a *App) OnChannelMemberLeft(channelID, userID string) error {
+	boards, err := a.store.GetBoardsForChannel(channelID)
+	if err != nil {
+		return err
+	}
+
+	for _, board := range boards {
+		if !board.SyncsChannelMembers() {
+			continue
+		}
+
+		member, err := a.store.GetMemberForBoard(board.ID, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if member.SchemeAdmin {
+			a.logger.Debug("keeping board admin that left the linked channel",
+				mlog.String("boardID", board.ID),
+				mlog.String("userID", userID),
+			)
+			continue
+		}
+
+		if err := a.DeleteBoardMember(board.ID, userID); err != nil {
+			return fmt.Errorf("cannot remove channel member %s from board %s: %w", userID, board.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestChannelMemberSync(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	// the changes are broadcast in the background
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	syncedBoard := &model.Board{
+		ID:        "board-id-1",
+		TeamID:    "team-id",
+		ChannelID: "channel-id",
+		Properties: map[string]interface{}{
+			model.BoardPropertySyncChannelMembers: true,
+			model.BoardPropertyDefaultMemberRole:  model.BoardRoleCommenter,
+		},
+	}
+	unsyncedBoard := &model.Board{
+		ID:        "board-id-2",
+		TeamID:    "team-id",
+		ChannelID: "channel-id",
+	}
+
+	t.Run("initial sync adds the channel members", func(t *testing.T) {
+		th.Store.EXPECT().GetChannelMemberIDs("channel-id").Return([]string{"user-id-1", "user-id-2"}, nil)
+		th.Store.EXPECT().GetBoard(syncedBoard.ID).Return(syncedBoard, nil).Times(2)
+		th.expectRunInTransaction().Times(2)
+		th.Store.EXPECT().GetMemberForBoard(syncedBoard.ID, "user-id-1").Return(&model.BoardMember{BoardID: syncedBoard.ID, UserID: "user-id-1", SchemeAdmin: true}, nil)
+		th.Store.EXPECT().GetMemberForBoard(syncedBoard.ID, "user-id-2").Return(nil, sql.ErrNoRows)
+		th.Store.EXPECT().SaveMember(syncedBoard.NewDefaultMember("user-id-2")).Return(syncedBoard.NewDefaultMember("user-id-2"), nil)
+		th.Store.EXPECT().GetCategoryRules("user-id-2", "team-id").Return(nil, nil)
+
+		require.NoError(t, th.App.SyncBoardWithChannel(syncedBoard))
+	})
+
+	t.Run("boards without sync are ignored", func(t *testing.T) {
+		require.NoError(t, th.App.SyncBoardWithChannel(unsyncedBoard))
+	})
+
+	t.Run("joining the channel adds the user to the synced boards", func(t *testing.T) {
+		member := &model.BoardMember{BoardID: syncedBoard.ID, UserID: "user-id", SchemeCommenter: true}
+
+		th.Store.EXPECT().GetBoardsForChannel("channel-id").Return([]*model.Board{syncedBoard, unsyncedBoard}, nil)
+		th.Store.EXPECT().GetBoard(syncedBoard.ID).Return(syncedBoard, nil)
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetMemberForBoard(syncedBoard.ID, "user-id").Return(nil, sql.ErrNoRows)
+		th.Store.EXPECT().SaveMember(member).Return(member, nil)
+		th.Store.EXPECT().GetCategoryRules("user-id", "team-id").Return(nil, nil)
+
+		require.NoError(t, th.App.OnChannelMemberJoined("channel-id", "user-id"))
+	})
+
+	t.Run("leaving the channel removes the user from the synced boards", func(t *testing.T) {
+		member := &model.BoardMember{BoardID: syncedBoard.ID, UserID: "user-id", SchemeCommenter: true}
+
+		th.Store.EXPECT().GetBoardsForChannel("channel-id").Return([]*model.Board{syncedBoard, unsyncedBoard}, nil)
+		th.Store.EXPECT().GetBoard(syncedBoard.ID).Return(syncedBoard, nil)
+		th.expectRunInTransaction()
+		th.Store.EXPECT().GetMemberForBoard(syncedBoard.ID, "user-id").Return(member, nil).Times(2)
+		th.Store.EXPECT().DeleteMember(syncedBoard.ID, "user-id").Return(nil)
+
+		require.NoError(t, th.App.OnChannelMemberLeft("channel-id", "user-id"))
+	})
+
+	t.Run("board admins are kept when leaving the channel", func(t *testing.T) {
+		admin := &model.BoardMember{BoardID: syncedBoard.ID, UserID: "admin-id", SchemeAdmin: true}
+
+		th.Store.EXPECT().GetBoardsForChannel("channel-id").Return([]*model.Board{syncedBoard}, nil)
+		th.Store.EXPECT().GetMemberForBoard(syncedBoard.ID, "admin-id").Return(admin, nil)
+
+		require.NoError(t, th.App.OnChannelMemberLeft("channel-id", "admin-id"))
+	})
+}
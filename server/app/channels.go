@@ -0,0 +1,59 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var (
+	ErrChannelNotFound      = errors.New("channel not found")
+	ErrChannelOnAnotherTeam = errors.New("the channel belongs to another team")
+)
+
+// LinkBoardToChannel links the board to the channel. The channel must
+// belong to the team of the board. If the board keeps its members in
+// sync with the channel, the channel members are added to the board.
+func (a *App) LinkBoardToChannel(boardID, channelID, userID string) (*model.Board, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := a.store.GetChannel(channelID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrChannelNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if channel.TeamId != board.TeamID {
+		return nil, ErrChannelOnAnotherTeam
+	}
+
+	updatedBoard, err := a.PatchBoard(&model.BoardPatch{ChannelID: &channelID}, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.SyncBoardWithChannel(updatedBoard); err != nil {
+		a.logger.Error("cannot sync the members of the board with its channel", mlog.String("boardID", boardID), mlog.Err(err))
+	}
+
+	return updatedBoard, nil
+}
+
+// UnlinkBoardFromChannel removes the link between the board and its
+// channel. The board members are kept.
+func (a *App) UnlinkBoardFromChannel(boardID, userID string) (*model.Board, error) {
+	channelID := ""
+	return a.PatchBoard(&model.BoardPatch{ChannelID: &channelID}, boardID, userID)
+}
+
+// GetBoardsForChannel returns the boards linked to the channel.
+func (a *App) GetBoardsForChannel(channelID string) ([]*model.Board, error) {
+	return a.store.GetBoardsForChannel(channelID)
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestLinkBoardToChannel(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	// the changes are broadcast in the background
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	board := &model.Board{ID: "board-id", TeamID: "team-id"}
+
+	t.Run("channel not found", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetChannel("channel-id").Return(nil, sql.ErrNoRows)
+
+		_, err := th.App.LinkBoardToChannel(board.ID, "channel-id", "user-id")
+		require.ErrorIs(t, err, ErrChannelNotFound)
+	})
+
+	t.Run("channel on another team", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetChannel("channel-id").Return(&mmModel.Channel{Id: "channel-id", TeamId: "other-team-id"}, nil)
+
+		_, err := th.App.LinkBoardToChannel(board.ID, "channel-id", "user-id")
+		require.ErrorIs(t, err, ErrChannelOnAnotherTeam)
+	})
+
+	t.Run("links the board", func(t *testing.T) {
+		channelID := "channel-id"
+		linkedBoard := &model.Board{ID: board.ID, TeamID: board.TeamID, ChannelID: channelID}
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).Times(2)
+		th.Store.EXPECT().GetChannel(channelID).Return(&mmModel.Channel{Id: channelID, TeamId: board.TeamID}, nil)
+		th.Store.EXPECT().PatchBoard(board.ID, &model.BoardPatch{ChannelID: &channelID}, "user-id").Return(linkedBoard, nil)
+
+		updatedBoard, err := th.App.LinkBoardToChannel(board.ID, channelID, "user-id")
+		require.NoError(t, err)
+		require.Equal(t, channelID, updatedBoard.ChannelID)
+	})
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// filterBlocks runs the text of the blocks through the content filters,
+// replacing it with the redacted text. If a filter rejects the text of a
+// block, the error carries the ID of the block.
+func (a *App) filterBlocks(blocks []model.Block) error {
+	for i := range blocks {
+		title, err := a.filterText(blocks[i].ID, blocks[i].Title)
+		if err != nil {
+			return err
+		}
+		blocks[i].Title = title
+	}
+	return nil
+}
+
+// filterBlockPatch runs the text of a block patch through the content
+// filters.
+func (a *App) filterBlockPatch(blockID string, patch *model.BlockPatch) error {
+	if patch == nil || patch.Title == nil {
+		return nil
+	}
+
+	title, err := a.filterText(blockID, *patch.Title)
+	if err != nil {
+		return err
+	}
+	patch.Title = &title
+	return nil
+}
+
+func (a *App) filterText(blockID, text string) (string, error) {
+	if a.contentFilter == nil || text == "" {
+		return text, nil
+	}
+
+	filtered, err := a.contentFilter.FilterText(text)
+	if ce, ok := model.AsCodedError(err); ok {
+		ce.Params["blockId"] = blockID
+	}
+	return filtered, err
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetDueDigestSettings returns the due digest settings of the user, or
+// the default ones, disabled, if the user never changed them.
+func (a *App) GetDueDigestSettings(userID string) (*model.DueDigestSettings, error) {
+	settings, err := a.store.GetDueDigestSettings(userID)
+	if err != nil {
+		if a.store.IsErrNotFound(err) {
+			return model.NewDueDigestSettings(userID), nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateDueDigestSettings enables or disables the due digest of the user
+// and sets the hour it is sent at.
+func (a *App) UpdateDueDigestSettings(settings *model.DueDigestSettings) (*model.DueDigestSettings, error) {
+	if err := settings.IsValid(); err != nil {
+		return nil, err
+	}
+
+	current, err := a.GetDueDigestSettings(settings.UserID)
+	if err != nil {
+		return nil, err
+	}
+	current.Enabled = settings.Enabled
+	current.Hour = settings.Hour
+
+	if err := a.store.SaveDueDigestSettings(current); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// SendDueDigests sends their due digest to the users whose hour has come
+// and that didn't get it yet today. It is meant to run periodically.
+func (a *App) SendDueDigests() {
+	if a.notifications == nil {
+		return
+	}
+
+	allSettings, err := a.store.GetEnabledDueDigestSettings()
+	if err != nil {
+		a.logger.Error("cannot get the due digest settings", mlog.Err(err))
+		return
+	}
+
+	now := time.Now()
+	for _, settings := range allSettings {
+		user, err := a.store.GetUserByID(settings.UserID)
+		if err != nil {
+			a.logger.Warn("cannot get the user of a due digest", mlog.String("userID", settings.UserID), mlog.Err(err))
+			continue
+		}
+		if user.DeleteAt != 0 {
+			continue
+		}
+
+		loc := user.Location()
+		if !settings.IsDue(now, loc) {
+			continue
+		}
+
+		cards, err := a.getDueDigestCards(settings.UserID, now, loc)
+		if err != nil {
+			a.logger.Error("cannot get the cards of a due digest", mlog.String("userID", settings.UserID), mlog.Err(err))
+			continue
+		}
+
+		a.notifications.SendDueDigest(notify.DueDigestEvent{
+			UserID: settings.UserID,
+			Cards:  cards,
+		})
+
+		// the digest is marked as sent even when empty, so the cards
+		// aren't looked up again until tomorrow
+		settings.LastSentAt = utils.GetMillisForTime(now)
+		if err := a.store.SaveDueDigestSettings(settings); err != nil {
+			a.logger.Error("cannot save the due digest settings", mlog.String("userID", settings.UserID), mlog.Err(err))
+		}
+	}
+}
+
+// getDueDigestCards returns the cards of all the boards of the user that
+// are assigned to them and are due today or overdue in their location,
+// sorted by due date.
+func (a *App) getDueDigestCards(userID string, now time.Time, loc *time.Location) ([]*model.DueDigestCard, error) {
+	members, err := a.store.GetMembersForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	boardsByID := map[string]*model.Board{}
+	schemas := map[string]model.PropSchema{}
+	boardIDs := []string{}
+	for _, member := range members {
+		if _, ok := boardsByID[member.BoardID]; ok {
+			continue
+		}
+		board, err := a.store.GetBoard(member.BoardID)
+		if err != nil {
+			a.logger.Warn("getDueDigestCards cannot get board", mlog.String("boardID", member.BoardID), mlog.Err(err))
+			continue
+		}
+		if board.IsTemplate {
+			continue
+		}
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			a.logger.Warn("getDueDigestCards cannot parse board properties", mlog.String("boardID", board.ID), mlog.Err(err))
+			continue
+		}
+		if !schema.HasPersonProperty() {
+			continue
+		}
+		boardsByID[board.ID] = board
+		schemas[board.ID] = schema
+		boardIDs = append(boardIDs, board.ID)
+	}
+	if len(boardIDs) == 0 {
+		return []*model.DueDigestCard{}, nil
+	}
+
+	cards, err := a.store.GetCardsWithFieldValue(boardIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cards due before the end of today are either due today or overdue
+	endOfToday := utils.StartOfDay(now, loc).AddDate(0, 0, 1)
+	query := model.MyWorkQuery{DueBefore: utils.GetMillisForTime(endOfToday) - 1}
+
+	matches := []*model.MyWorkCard{}
+	for _, card := range cards {
+		if isTemplate, _ := card.Fields["isTemplate"].(bool); isTemplate {
+			continue
+		}
+		if match, ok := model.MatchMyWork(card, boardsByID[card.BoardID], schemas[card.BoardID], userID, query); ok {
+			matches = append(matches, match)
+		}
+	}
+	model.SortMyWork(matches)
+
+	result := make([]*model.DueDigestCard, 0, len(matches))
+	for _, match := range matches {
+		board := boardsByID[match.Card.BoardID]
+		result = append(result, &model.DueDigestCard{
+			CardID:     match.Card.ID,
+			Title:      match.Card.Title,
+			BoardID:    board.ID,
+			BoardTitle: board.Title,
+			TeamID:     board.TeamID,
+			DueDate:    match.DueDate,
+			DueDay:     utils.GetTimeForMillis(match.DueDate).In(loc).Format("2006-01-02"),
+			Overdue:    !utils.IsSameDay(match.DueDate, now, loc),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDueDigestCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	now := time.Now().In(tokyo)
+	today := utils.StartOfDay(now, tokyo)
+
+	board := &model.Board{
+		ID:     "board-id-1",
+		TeamID: "team-id",
+		Title:  "Roadmap",
+		CardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": "person"},
+			{"id": "due", "name": "Due", "type": "date"},
+		},
+	}
+	template := &model.Board{ID: "board-id-2", IsTemplate: true}
+	cardDueAt := func(id string, due time.Time) model.Block {
+		return model.Block{
+			ID:      id,
+			BoardID: board.ID,
+			Title:   id,
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{"properties": map[string]interface{}{
+				"assignee": "user-id",
+				"due":      fmt.Sprintf(`{"from":%d}`, utils.GetMillisForTime(due)),
+			}},
+		}
+	}
+
+	th.Store.EXPECT().GetMembersForUser("user-id").Return([]*model.BoardMember{
+		{BoardID: board.ID, UserID: "user-id"},
+		{BoardID: template.ID, UserID: "user-id"},
+	}, nil)
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+	th.Store.EXPECT().GetBoard(template.ID).Return(template, nil)
+	th.Store.EXPECT().GetCardsWithFieldValue([]string{board.ID}, "user-id").Return([]model.Block{
+		cardDueAt("card-today", today.Add(time.Hour)),
+		cardDueAt("card-overdue", today.AddDate(0, 0, -3)),
+		cardDueAt("card-tomorrow", today.AddDate(0, 0, 1)),
+	}, nil)
+
+	cards, err := th.App.getDueDigestCards("user-id", now, tokyo)
+	require.NoError(t, err)
+	require.Len(t, cards, 2)
+
+	require.Equal(t, "card-overdue", cards[0].CardID)
+	require.True(t, cards[0].Overdue)
+	require.Equal(t, today.AddDate(0, 0, -3).Format("2006-01-02"), cards[0].DueDay)
+
+	require.Equal(t, "card-today", cards[1].CardID)
+	require.False(t, cards[1].Overdue)
+	require.Equal(t, "Roadmap", cards[1].BoardTitle)
+	require.Equal(t, "team-id", cards[1].TeamID)
+}
+
+func TestUpdateDueDigestSettings(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("keeps the time the digest was last sent", func(t *testing.T) {
+		th.Store.EXPECT().GetDueDigestSettings("user-id").Return(&model.DueDigestSettings{UserID: "user-id", Hour: 9, LastSentAt: 1234}, nil)
+		th.Store.EXPECT().SaveDueDigestSettings(&model.DueDigestSettings{UserID: "user-id", Enabled: true, Hour: 7, LastSentAt: 1234}).Return(nil)
+
+		settings, err := th.App.UpdateDueDigestSettings(&model.DueDigestSettings{UserID: "user-id", Enabled: true, Hour: 7})
+		require.NoError(t, err)
+		require.True(t, settings.Enabled)
+		require.Equal(t, int64(1234), settings.LastSentAt)
+	})
+
+	t.Run("users without settings", func(t *testing.T) {
+		th.Store.EXPECT().GetDueDigestSettings("user-id-2").Return(nil, sql.ErrNoRows)
+		th.Store.EXPECT().IsErrNotFound(sql.ErrNoRows).Return(true)
+		th.Store.EXPECT().SaveDueDigestSettings(&model.DueDigestSettings{UserID: "user-id-2", Enabled: true, Hour: 9}).Return(nil)
+
+		_, err := th.App.UpdateDueDigestSettings(&model.DueDigestSettings{UserID: "user-id-2", Enabled: true, Hour: 9})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid hour", func(t *testing.T) {
+		_, err := th.App.UpdateDueDigestSettings(&model.DueDigestSettings{UserID: "user-id", Hour: 24})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,252 @@
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// emailInvitationSigningKeySetting is the system setting that holds the
+// key the email invitation tokens are signed with.
+const emailInvitationSigningKeySetting = "EmailInvitationSigningKey"
+
+var (
+	ErrEmailNotConfigured     = errors.New("email invitations require email sending to be configured")
+	ErrInvalidEmailInvitation = errors.New("invalid or expired email invitation")
+)
+
+// emailInvitationClaims are the contents of an email invitation token.
+type emailInvitationClaims struct {
+	Email           string `json:"email"`
+	InvitedBy       string `json:"invitedBy"`
+	BoardID         string `json:"boardId,omitempty"`
+	SchemeAdmin     bool   `json:"schemeAdmin,omitempty"`
+	SchemeEditor    bool   `json:"schemeEditor,omitempty"`
+	SchemeCommenter bool   `json:"schemeCommenter,omitempty"`
+	SchemeViewer    bool   `json:"schemeViewer,omitempty"`
+	ExpiresAt       int64  `json:"expiresAt"`
+}
+
+// SendEmailInvitation emails a signed signup link to the address of the
+// request. If the request has a board, the account created with the link
+// becomes a member of the board.
+func (a *App) SendEmailInvitation(invitedBy string, request *model.EmailInvitationRequest) error {
+	if a.mail == nil || !a.mail.IsConfigured() {
+		return ErrEmailNotConfigured
+	}
+
+	inviter, err := a.store.GetUserByID(invitedBy)
+	if err != nil {
+		return err
+	}
+
+	claims := &emailInvitationClaims{
+		Email:     request.Email,
+		InvitedBy: invitedBy,
+		ExpiresAt: model.GetMillisForTime(time.Now().Add(time.Duration(a.config.EmailInvitationExpireTime) * time.Second)),
+	}
+
+	subject := fmt.Sprintf("%s invited you to Focalboard", inviter.Username)
+	target := "Focalboard"
+	if request.BoardID != "" {
+		board, bErr := a.store.GetBoard(request.BoardID)
+		if errors.Is(bErr, sql.ErrNoRows) {
+			return model.NewErrBoardNotFound(request.BoardID)
+		}
+		if bErr != nil {
+			return bErr
+		}
+
+		claims.BoardID = board.ID
+		claims.SchemeAdmin = request.SchemeAdmin
+		claims.SchemeEditor = request.SchemeEditor
+		claims.SchemeCommenter = request.SchemeCommenter
+		claims.SchemeViewer = request.SchemeViewer
+
+		// invitations grant the same role as the added members by default.
+		if !claims.SchemeAdmin && !claims.SchemeEditor && !claims.SchemeCommenter && !claims.SchemeViewer {
+			defaultMember := board.NewDefaultMember("")
+			claims.SchemeEditor = defaultMember.SchemeEditor
+			claims.SchemeCommenter = defaultMember.SchemeCommenter
+			claims.SchemeViewer = defaultMember.SchemeViewer
+		}
+
+		subject = fmt.Sprintf("%s invited you to the board %s", inviter.Username, board.Title)
+		target = fmt.Sprintf("the board \"%s\" on Focalboard", board.Title)
+	}
+
+	token, err := a.createEmailInvitationToken(claims)
+	if err != nil {
+		return err
+	}
+
+	link := html.EscapeString(fmt.Sprintf("%s/register?t=%s", strings.TrimSuffix(a.config.ServerRoot, "/"), url.QueryEscape(token)))
+	body := fmt.Sprintf(
+		"%s invited you to join %s.<br><br>Create your account with this email address to get started:<br><a href=\"%s\">%s</a><br><br>This invitation expires on %s.",
+		html.EscapeString(inviter.Username), html.EscapeString(target), link, link,
+		model.GetTimeForMillis(claims.ExpiresAt).UTC().Format("January 2, 2006"),
+	)
+
+	if err := a.mail.SendMail(request.Email, subject, body); err != nil {
+		return fmt.Errorf("cannot send email invitation: %w", err)
+	}
+
+	a.logger.Debug("Email invitation sent",
+		mlog.String("invited_by", invitedBy),
+		mlog.String("board_id", request.BoardID),
+	)
+	return nil
+}
+
+// ValidateEmailInvitation checks that the token is a valid email
+// invitation for the email address.
+func (a *App) ValidateEmailInvitation(token, email string) error {
+	_, err := a.getEmailInvitation(token, email)
+	return err
+}
+
+func (a *App) getEmailInvitation(token, email string) (*emailInvitationClaims, error) {
+	claims, err := a.parseEmailInvitationToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(claims.Email, email) {
+		return nil, ErrInvalidEmailInvitation
+	}
+	return claims, nil
+}
+
+// RegisterUserWithEmailInvitation creates the account of an invited
+// user and the board membership the invitation grants.
+func (a *App) RegisterUserWithEmailInvitation(username, email, password, token string) error {
+	claims, err := a.getEmailInvitation(token, email)
+	if err != nil {
+		return err
+	}
+
+	if err = a.RegisterUser(username, email, password); err != nil {
+		return err
+	}
+
+	if claims.BoardID == "" {
+		return nil
+	}
+
+	user, err := a.store.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	member := &model.BoardMember{
+		BoardID:         claims.BoardID,
+		UserID:          user.ID,
+		SchemeAdmin:     claims.SchemeAdmin,
+		SchemeEditor:    claims.SchemeEditor,
+		SchemeCommenter: claims.SchemeCommenter,
+		SchemeViewer:    claims.SchemeViewer,
+	}
+	if _, err := a.AddMemberToBoard(member); err != nil {
+		// the account exists already, so the registration succeeds
+		// and the user can still be added to the board manually.
+		a.logger.Error("Cannot add invited user to board",
+			mlog.String("board_id", claims.BoardID),
+			mlog.String("user_id", user.ID),
+			mlog.Err(err),
+		)
+	}
+
+	return nil
+}
+
+func (a *App) createEmailInvitationToken(claims *emailInvitationClaims) (string, error) {
+	key, err := a.getEmailInvitationSigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(signEmailInvitation(key, encodedPayload)), nil
+}
+
+func (a *App) parseEmailInvitationToken(token string) (*emailInvitationClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, ErrInvalidEmailInvitation
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidEmailInvitation
+	}
+
+	key, err := a.getEmailInvitationSigningKey()
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(signature, signEmailInvitation(key, parts[0])) {
+		return nil, ErrInvalidEmailInvitation
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidEmailInvitation
+	}
+
+	var claims emailInvitationClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidEmailInvitation
+	}
+	if claims.ExpiresAt < model.GetMillis() {
+		return nil, ErrInvalidEmailInvitation
+	}
+
+	return &claims, nil
+}
+
+func signEmailInvitation(key []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// getEmailInvitationSigningKey returns the key the invitation tokens are
+// signed with, generating it the first time it's needed.
+func (a *App) getEmailInvitationSigningKey() ([]byte, error) {
+	value, err := a.store.GetSystemSetting(emailInvitationSigningKeySetting)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == "" {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		value = hex.EncodeToString(key)
+
+		if err := a.store.SetSystemSetting(emailInvitationSigningKeySetting, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return hex.DecodeString(value)
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// expectSigningKey makes the mock store keep the email invitation
+// signing key in memory.
+func (th *TestHelper) expectSigningKey() {
+	var key string
+	th.Store.EXPECT().GetSystemSetting(emailInvitationSigningKeySetting).DoAndReturn(func(string) (string, error) {
+		return key, nil
+	}).AnyTimes()
+	th.Store.EXPECT().SetSystemSetting(emailInvitationSigningKeySetting, gomock.Any()).DoAndReturn(func(_, value string) error {
+		key = value
+		return nil
+	}).AnyTimes()
+}
+
+func TestEmailInvitationTokens(t *testing.T) {
+	newClaims := func() *emailInvitationClaims {
+		return &emailInvitationClaims{
+			Email:        "invited@example.com",
+			InvitedBy:    "user-id",
+			BoardID:      "board-id",
+			SchemeEditor: true,
+			ExpiresAt:    model.GetMillis() + 60*1000,
+		}
+	}
+
+	t.Run("a valid token is accepted for its email", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		th.expectSigningKey()
+
+		token, err := th.App.createEmailInvitationToken(newClaims())
+		require.NoError(t, err)
+
+		require.NoError(t, th.App.ValidateEmailInvitation(token, "Invited@Example.com"))
+		require.ErrorIs(t, th.App.ValidateEmailInvitation(token, "other@example.com"), ErrInvalidEmailInvitation)
+
+		claims, err := th.App.parseEmailInvitationToken(token)
+		require.NoError(t, err)
+		require.Equal(t, "board-id", claims.BoardID)
+		require.True(t, claims.SchemeEditor)
+	})
+
+	t.Run("tampered tokens are rejected", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		th.expectSigningKey()
+
+		token, err := th.App.createEmailInvitationToken(newClaims())
+		require.NoError(t, err)
+
+		other := newClaims()
+		other.SchemeAdmin = true
+		otherToken, err := th.App.createEmailInvitationToken(other)
+		require.NoError(t, err)
+
+		// the payload of a token with the signature of another one
+		tampered := strings.Split(otherToken, ".")[0] + "." + strings.Split(token, ".")[1]
+		require.ErrorIs(t, th.App.ValidateEmailInvitation(tampered, "invited@example.com"), ErrInvalidEmailInvitation)
+
+		for _, invalid := range []string{"", "token", "a.b.c", token + "x"} {
+			require.ErrorIs(t, th.App.ValidateEmailInvitation(invalid, "invited@example.com"), ErrInvalidEmailInvitation)
+		}
+	})
+
+	t.Run("expired tokens are rejected", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		th.expectSigningKey()
+
+		claims := newClaims()
+		claims.ExpiresAt = model.GetMillis() - 1
+		token, err := th.App.createEmailInvitationToken(claims)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, th.App.ValidateEmailInvitation(token, "invited@example.com"), ErrInvalidEmailInvitation)
+	})
+}
+
+func TestSendEmailInvitationNotConfigured(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	err := th.App.SendEmailInvitation("user-id", &model.EmailInvitationRequest{Email: "invited@example.com"})
+	require.ErrorIs(t, err, ErrEmailNotConfigured)
+}
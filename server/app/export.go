@@ -50,8 +50,9 @@ func (a *App) ExportArchive(w io.Writer, opt model.ExportArchiveOptions) (errs e
 // writeArchiveVersion writes a version file to the zip.
 func (a *App) writeArchiveVersion(zw *zip.Writer) error {
 	archiveHeader := model.ArchiveHeader{
-		Version: archiveVersion,
-		Date:    model.GetMillis(),
+		Version:       archiveVersion,
+		Date:          model.GetMillis(),
+		ServerVersion: model.CurrentVersion,
 	}
 	b, _ := json.Marshal(&archiveHeader)
 
@@ -100,6 +101,14 @@ func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.Exp
 		}
 	}
 
+	// the permissions must follow the board.jsonl, which the importers
+	// need first to create the board they apply to
+	if opt.IncludePermissions {
+		if err = a.writeArchivePermissions(zw, board); err != nil {
+			return err
+		}
+	}
+
 	// write the files
 	for _, filename := range files {
 		if err := a.writeArchiveFile(zw, filename, board.ID, opt); err != nil {
@@ -161,6 +170,26 @@ func (a *App) writeArchiveBoardLine(w io.Writer, board model.Board) error {
 	return err
 }
 
+// writeArchivePermissions writes the permissions of the board to the
+// archive, in the directory of the board.
+func (a *App) writeArchivePermissions(zw *zip.Writer, board model.Board) error {
+	permissions, err := a.GetBoardPermissions(board.ID)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(permissions)
+	if err != nil {
+		return err
+	}
+
+	w, err := zw.Create(board.ID + "/" + model.BoardPermissionsArchiveFile)
+	if err != nil {
+		return fmt.Errorf("cannot write board permissions: %w", err)
+	}
+	_, err = w.Write(b)
+	return err
+}
+
 // writeArchiveFile writes a single file to the archive.
 func (a *App) writeArchiveFile(zw *zip.Writer, filename string, boardID string, opt model.ExportArchiveOptions) error {
 	dest, err := zw.Create(boardID + "/" + filename)
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"io"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// ExportCards writes the cards of a board as a CSV or markdown table. If
+// the options have a view, only the cards that meet its filter are
+// exported, in its order and with its visible properties. The filter of
+// the options further selects the cards.
+func (a *App) ExportCards(w io.Writer, opts model.ExportCardsOptions) error {
+	if !model.IsTableExportFormat(opts.Format) {
+		return model.NewCodedError(model.ErrCodeBadRequest, "invalid export format", map[string]interface{}{"format": opts.Format})
+	}
+
+	cards, properties, usernames, err := a.getCardsForExport(opts)
+	if err != nil {
+		return err
+	}
+
+	table := model.NewCardTable(cards, properties, usernames)
+	if opts.Format == model.ExportFormatMarkdown {
+		return table.WriteMarkdown(w)
+	}
+	return table.WriteCSV(w)
+}
+
+// getCardsForExport returns the cards selected by the options, in the
+// order they are exported, with the exported properties and the
+// usernames of the users shown in them.
+func (a *App) getCardsForExport(opts model.ExportCardsOptions) ([]model.Block, []model.PropDef, map[string]string, error) {
+	board, err := a.GetBoard(opts.BoardID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if board == nil {
+		return nil, nil, nil, model.NewErrBoardNotFound(opts.BoardID)
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var view *model.Block
+	var viewFilter *model.CardFilter
+	if opts.ViewID != "" {
+		view, err = a.store.GetBlock(opts.ViewID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if view == nil || view.BoardID != board.ID || view.Type != model.TypeView {
+			return nil, nil, nil, model.NewErrBlockNotFound(opts.ViewID)
+		}
+		if viewFilter, err = model.CardFilterFromView(view); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	blocks, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cards := make([]model.Block, 0, len(blocks))
+	for i := range blocks {
+		if isTemplate, _ := blocks[i].Fields["isTemplate"].(bool); isTemplate {
+			continue
+		}
+		if viewFilter.Matches(&blocks[i], schema) && opts.Filter.Matches(&blocks[i], schema) {
+			cards = append(cards, blocks[i])
+		}
+	}
+
+	properties := model.ExportProperties(schema, view)
+
+	usernames := map[string]string{}
+	for _, userID := range model.ExportUserIDs(cards, properties) {
+		if user, err := a.store.GetUserByID(userID); err == nil && user != nil {
+			usernames[userID] = user.Username
+		}
+	}
+
+	if view != nil {
+		sortUsernames := usernames
+		if model.ViewSortsByUser(view, schema) {
+			sortUsernames = a.getCardUsernames(cards)
+		}
+		model.SortCardsForView(cards, schema, view, sortUsernames)
+	}
+
+	return cards, properties, usernames, nil
+}
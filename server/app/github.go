@@ -0,0 +1,320 @@
+package app
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/github"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// gitHubSyncedActions are the actions of the "issues" webhook events that
+// change what is synced to the cards.
+var gitHubSyncedActions = map[string]bool{
+	"opened":     true,
+	"edited":     true,
+	"closed":     true,
+	"reopened":   true,
+	"assigned":   true,
+	"unassigned": true,
+}
+
+// GetGitHubIntegration returns the GitHub integration of the board,
+// without its token.
+func (a *App) GetGitHubIntegration(boardID string) (*model.GitHubIntegration, error) {
+	integration, err := a.getGitHubIntegration(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return integration.Sanitized(), nil
+}
+
+func (a *App) getGitHubIntegration(boardID string) (*model.GitHubIntegration, error) {
+	integration, err := a.store.GetGitHubIntegration(boardID)
+	if a.store.IsErrNotFound(err) {
+		return nil, model.NewCodedError(model.ErrCodeNotFound, "github integration not found", map[string]interface{}{"boardId": boardID})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return integration, nil
+}
+
+// SetGitHubIntegration connects the board to a GitHub repository,
+// replacing its previous integration. The token is encrypted with the
+// secrets key before it is stored; an empty token keeps the stored one. The webhook secret is
+// generated the first time the board is connected.
+func (a *App) SetGitHubIntegration(boardID string, integration *model.GitHubIntegration, userID string) (*model.GitHubIntegration, error) {
+	if a.github == nil || !a.secrets.IsEnabled() {
+		return nil, model.NewCodedError(model.ErrCodeNotImplemented, "the GitHub integration is not configured on this server", nil)
+	}
+	if err := integration.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = integration.PropertyMapping(schema); err != nil {
+		return nil, err
+	}
+
+	existing, err := a.store.GetGitHubIntegration(boardID)
+	if err != nil && !a.store.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	integration.EncryptedToken = ""
+	integration.WebhookSecret = utils.NewID(utils.IDTypeToken)
+	integration.LastSyncAt = 0
+	if existing != nil {
+		integration.EncryptedToken = existing.EncryptedToken
+		integration.WebhookSecret = existing.WebhookSecret
+		if strings.EqualFold(existing.Repository, integration.Repository) {
+			integration.LastSyncAt = existing.LastSyncAt
+		}
+	}
+	if integration.Token != "" {
+		if integration.EncryptedToken, err = a.secrets.Encrypt(integration.Token); err != nil {
+			return nil, err
+		}
+	}
+
+	integration.BoardID = boardID
+	integration.ModifiedBy = userID
+	integration.UpdateAt = utils.GetMillis()
+
+	if err := a.store.SaveGitHubIntegration(integration); err != nil {
+		return nil, err
+	}
+	return integration.Sanitized(), nil
+}
+
+// DeleteGitHubIntegration disconnects the board from its repository. The
+// cards imported from it are kept.
+func (a *App) DeleteGitHubIntegration(boardID string) error {
+	return a.store.DeleteGitHubIntegration(boardID)
+}
+
+// SyncGitHubIssues imports the issues of the repository of the board,
+// creating a card for each new issue and updating the cards of the issues
+// imported before.
+func (a *App) SyncGitHubIssues(boardID, userID string) (*model.GitHubSyncResult, error) {
+	if a.github == nil || !a.secrets.IsEnabled() {
+		return nil, model.NewCodedError(model.ErrCodeNotImplemented, "the GitHub integration is not configured on this server", nil)
+	}
+
+	integration, err := a.getGitHubIntegration(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	token := ""
+	if integration.EncryptedToken != "" {
+		if token, err = a.secrets.Decrypt(integration.EncryptedToken); err != nil {
+			return nil, err
+		}
+	}
+
+	issues, err := a.github.ListIssues(integration.Repository, token)
+	if errors.Is(err, github.ErrRequestFailed) || errors.Is(err, github.ErrTooManyIssues) {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, err.Error(), map[string]interface{}{"repository": integration.Repository})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := a.applyGitHubIssues(integration, issues, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	integration.LastSyncAt = utils.GetMillis()
+	if err := a.store.SaveGitHubIntegration(integration); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ApplyGitHubIssueEvent updates the card of the issue of an "issues"
+// webhook event, creating it for new issues. The events of other
+// repositories and the actions that don't change the cards are ignored.
+func (a *App) ApplyGitHubIssueEvent(boardID string, event *github.IssuesEvent) error {
+	integration, err := a.getGitHubIntegration(boardID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(event.Repository.FullName, integration.Repository) ||
+		!gitHubSyncedActions[event.Action] || event.Issue.IsPullRequest() {
+		return nil
+	}
+
+	_, err = a.applyGitHubIssues(integration, []github.Issue{event.Issue}, model.SystemUserID)
+	return err
+}
+
+// applyGitHubIssues creates or updates the cards of the issues, matching
+// them by their CardFieldGitHubIssue field. The unchanged cards are left
+// as they are.
+func (a *App) applyGitHubIssues(integration *model.GitHubIntegration, issues []github.Issue, userID string) (*model.GitHubSyncResult, error) {
+	board, err := a.GetBoard(integration.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(integration.BoardID)
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := integration.PropertyMapping(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+	cardsByIssue := map[string]*model.Block{}
+	for i := range cards {
+		if key, _ := cards[i].Fields[model.CardFieldGitHubIssue].(string); key != "" {
+			cardsByIssue[key] = &cards[i]
+		}
+	}
+
+	result := &model.GitHubSyncResult{}
+	now := utils.GetMillis()
+	newCards := []model.Block{}
+	patches := &model.BlockPatchBatch{}
+	userIDs := map[string]string{}
+	imported := map[string]bool{}
+	for _, issue := range issues {
+		key := model.GitHubIssueKey(integration.Repository, issue.Number)
+		if imported[key] {
+			continue
+		}
+		imported[key] = true
+		card, ok := cardsByIssue[key]
+
+		properties := map[string]interface{}{}
+		if ok {
+			if cardProperties, ok := card.Fields["properties"].(map[string]interface{}); ok {
+				for id, value := range cardProperties {
+					properties[id] = value
+				}
+			}
+		}
+		changed := a.setGitHubIssueProperties(properties, issue, mapping, userIDs)
+
+		if !ok {
+			newCards = append(newCards, model.Block{
+				ID:       utils.NewID(utils.IDTypeCard),
+				ParentID: board.ID,
+				BoardID:  board.ID,
+				Type:     model.TypeCard,
+				Title:    issue.Title,
+				CreateAt: now,
+				UpdateAt: now,
+				Fields: map[string]interface{}{
+					"icon":                     "",
+					"properties":               properties,
+					"contentOrder":             []interface{}{},
+					model.CardFieldGitHubIssue: key,
+				},
+			})
+			continue
+		}
+
+		if card.Title == issue.Title && !changed {
+			continue
+		}
+		title := issue.Title
+		patches.BlockIDs = append(patches.BlockIDs, card.ID)
+		patches.BlockPatches = append(patches.BlockPatches, model.BlockPatch{
+			Title:         &title,
+			UpdatedFields: map[string]interface{}{"properties": properties},
+		})
+	}
+
+	if len(newCards) > 0 {
+		if _, err := a.InsertBlocks(newCards, userID, false); err != nil {
+			return nil, err
+		}
+	}
+	if len(patches.BlockIDs) > 0 {
+		if err := a.PatchBlocks(board.TeamID, patches, userID); err != nil {
+			return nil, err
+		}
+	}
+	result.Created = len(newCards)
+	result.Updated = len(patches.BlockIDs)
+
+	a.logger.Debug("synced the issues of a GitHub repository",
+		mlog.String("boardID", board.ID),
+		mlog.String("repository", integration.Repository),
+		mlog.Int("created", result.Created),
+		mlog.Int("updated", result.Updated),
+	)
+	return result, nil
+}
+
+// setGitHubIssueProperties sets the status and assignee of the issue to
+// the properties of its card, returning whether they changed. The
+// assignee is the first assignee of the issue whose login is the username
+// of a user; userIDs caches the users looked up by login.
+func (a *App) setGitHubIssueProperties(properties map[string]interface{}, issue github.Issue, mapping model.GitHubPropertyMapping, userIDs map[string]string) bool {
+	changed := false
+	setProperty := func(id, value string) {
+		if value == "" {
+			if _, ok := properties[id]; ok {
+				delete(properties, id)
+				changed = true
+			}
+			return
+		}
+		if properties[id] != value {
+			properties[id] = value
+			changed = true
+		}
+	}
+
+	if mapping.StatusPropertyID != "" {
+		status := mapping.OpenOptionID
+		if issue.State == model.GitHubIssueStateClosed {
+			status = mapping.ClosedOptionID
+		}
+		setProperty(mapping.StatusPropertyID, status)
+	}
+
+	if mapping.AssigneePropertyID != "" {
+		assigneeID := ""
+		for _, assignee := range issue.Assignees {
+			userID, ok := userIDs[assignee.Login]
+			if !ok {
+				if user, err := a.store.GetUserByUsername(assignee.Login); err == nil && user != nil {
+					userID = user.ID
+				}
+				userIDs[assignee.Login] = userID
+			}
+			if userID != "" {
+				assigneeID = userID
+				break
+			}
+		}
+		setProperty(mapping.AssigneePropertyID, assigneeID)
+	}
+	return changed
+}
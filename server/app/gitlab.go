@@ -0,0 +1,329 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/gitlab"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// ImportGitLabIssues imports the issues of a GitLab project as cards of
+// the board of the request, or of a new private board of the team. The
+// status, labels, milestone and assignee of the issues are set to the
+// properties of the same names, that are added to the board with their
+// options if missing. The cards of the issues imported before are
+// updated.
+func (a *App) ImportGitLabIssues(teamID string, request *model.GitLabImportRequest, userID string) (*model.GitLabImportResult, error) {
+	if a.gitlab == nil {
+		return nil, model.NewCodedError(model.ErrCodeNotImplemented, "the GitLab import is not available on this server", nil)
+	}
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	var board *model.Board
+	if request.BoardID != "" {
+		var err error
+		if board, err = a.GetBoard(request.BoardID); err != nil {
+			return nil, err
+		}
+		if board == nil || board.TeamID != teamID {
+			return nil, model.NewErrBoardNotFound(request.BoardID)
+		}
+	}
+
+	issues, err := a.gitlab.ListIssues(request.Project, request.Token)
+	if errors.Is(err, gitlab.ErrRequestFailed) || errors.Is(err, gitlab.ErrTooManyIssues) {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, err.Error(), map[string]interface{}{"project": request.Project})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	newBoard := board == nil
+	if newBoard {
+		title := request.Title
+		if title == "" {
+			title = request.Project
+		}
+		board = &model.Board{
+			TeamID:         teamID,
+			Type:           model.BoardTypePrivate,
+			Title:          title,
+			CreatedBy:      userID,
+			CreateAt:       utils.GetMillis(),
+			CardProperties: []map[string]interface{}{},
+		}
+	}
+
+	properties, err := gitLabCardProperties(board, issues)
+	if err != nil {
+		return nil, err
+	}
+
+	if newBoard {
+		board.CardProperties = properties
+		if board, err = a.CreateBoard(board, userID, true); err != nil {
+			return nil, err
+		}
+	} else if len(properties) > 0 {
+		patch := &model.BoardPatch{UpdatedCardProperties: properties}
+		if board, err = a.PatchBoard(patch, board.ID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := a.importGitLabIssues(board, request.Project, issues, newBoard, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.logger.Debug("imported the issues of a GitLab project",
+		mlog.String("boardID", board.ID),
+		mlog.String("project", request.Project),
+		mlog.Int("created", result.Created),
+		mlog.Int("updated", result.Updated),
+	)
+	return result, nil
+}
+
+// gitLabCardProperties returns the card properties of the board the
+// issues are imported in that must be added or updated with the options
+// of the issues.
+func gitLabCardProperties(board *model.Board, issues []gitlab.Issue) ([]map[string]interface{}, error) {
+	labels := []string{}
+	milestones := []string{}
+	for _, issue := range issues {
+		labels = append(labels, issue.Labels...)
+		if issue.Milestone != nil && issue.Milestone.Title != "" {
+			milestones = append(milestones, issue.Milestone.Title)
+		}
+	}
+
+	wanted := []struct {
+		name     string
+		propType string
+		values   []string
+	}{
+		{model.GitLabPropertyStatus, "select", []string{model.GitLabStatusOpen, model.GitLabStatusClosed}},
+		{model.GitLabPropertyLabels, "multiSelect", labels},
+		{model.GitLabPropertyMilestone, "select", milestones},
+		{model.GitLabPropertyAssignee, "person", nil},
+	}
+
+	properties := []map[string]interface{}{}
+	for _, w := range wanted {
+		property, changed, err := model.EnsureCardProperty(board, w.name, w.propType, w.values)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			properties = append(properties, property)
+		}
+	}
+	return properties, nil
+}
+
+// importGitLabIssues creates or updates the cards of the issues, matching
+// them by their CardFieldGitLabIssue field, and adds a board view grouped
+// by status to new boards.
+func (a *App) importGitLabIssues(board *model.Board, project string, issues []gitlab.Issue, newBoard bool, userID string) (*model.GitLabImportResult, error) {
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	statusProp := gitLabProperty(schema, model.GitLabPropertyStatus)
+	labelsProp := gitLabProperty(schema, model.GitLabPropertyLabels)
+	milestoneProp := gitLabProperty(schema, model.GitLabPropertyMilestone)
+	assigneeProp := gitLabProperty(schema, model.GitLabPropertyAssignee)
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+	cardsByIssue := map[string]*model.Block{}
+	for i := range cards {
+		if key, _ := cards[i].Fields[model.CardFieldGitLabIssue].(string); key != "" {
+			cardsByIssue[key] = &cards[i]
+		}
+	}
+
+	now := utils.GetMillis()
+	newBlocks := []model.Block{}
+	patches := &model.BlockPatchBatch{}
+	userIDs := map[string]string{}
+	imported := map[string]bool{}
+	for _, issue := range issues {
+		key := model.GitLabIssueKey(project, issue.IID)
+		if imported[key] {
+			continue
+		}
+		imported[key] = true
+
+		values := map[string]interface{}{}
+		status := model.GitLabStatusOpen
+		if issue.State == gitlab.IssueStateClosed {
+			status = model.GitLabStatusClosed
+		}
+		values[statusProp.ID] = gitLabOptionID(statusProp, status)
+		labelIDs := []interface{}{}
+		for _, label := range issue.Labels {
+			labelIDs = append(labelIDs, gitLabOptionID(labelsProp, label))
+		}
+		values[labelsProp.ID] = labelIDs
+		values[milestoneProp.ID] = ""
+		if issue.Milestone != nil {
+			values[milestoneProp.ID] = gitLabOptionID(milestoneProp, issue.Milestone.Title)
+		}
+		values[assigneeProp.ID] = a.gitLabAssigneeID(issue, userIDs)
+
+		card, ok := cardsByIssue[key]
+		properties := map[string]interface{}{}
+		if ok {
+			if cardProperties, ok := card.Fields["properties"].(map[string]interface{}); ok {
+				for id, value := range cardProperties {
+					properties[id] = value
+				}
+			}
+		}
+		changed := false
+		for id, value := range values {
+			if value == "" || reflect.DeepEqual(value, []interface{}{}) {
+				if _, ok := properties[id]; ok {
+					delete(properties, id)
+					changed = true
+				}
+				continue
+			}
+			if !reflect.DeepEqual(properties[id], value) {
+				properties[id] = value
+				changed = true
+			}
+		}
+
+		if !ok {
+			newBlocks = append(newBlocks, model.Block{
+				ID:       utils.NewID(utils.IDTypeCard),
+				ParentID: board.ID,
+				BoardID:  board.ID,
+				Type:     model.TypeCard,
+				Title:    issue.Title,
+				CreateAt: now,
+				UpdateAt: now,
+				Fields: map[string]interface{}{
+					"icon":                     "",
+					"properties":               properties,
+					"contentOrder":             []interface{}{},
+					model.CardFieldGitLabIssue: key,
+				},
+			})
+			continue
+		}
+
+		if card.Title == issue.Title && !changed {
+			continue
+		}
+		title := issue.Title
+		patches.BlockIDs = append(patches.BlockIDs, card.ID)
+		patches.BlockPatches = append(patches.BlockPatches, model.BlockPatch{
+			Title:         &title,
+			UpdatedFields: map[string]interface{}{"properties": properties},
+		})
+	}
+	result := &model.GitLabImportResult{
+		BoardID: board.ID,
+		Created: len(newBlocks),
+		Updated: len(patches.BlockIDs),
+	}
+
+	if newBoard {
+		newBlocks = append(newBlocks, gitLabBoardView(board.ID, statusProp.ID, labelsProp.ID, milestoneProp.ID, assigneeProp.ID, now))
+	}
+	if len(newBlocks) > 0 {
+		if _, err := a.InsertBlocks(newBlocks, userID, false); err != nil {
+			return nil, err
+		}
+	}
+	if len(patches.BlockIDs) > 0 {
+		if err := a.PatchBlocks(board.TeamID, patches, userID); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// gitLabProperty returns the first property of the schema with the name,
+// as model.EnsureCardProperty finds it.
+func gitLabProperty(schema model.PropSchema, name string) model.PropDef {
+	var found model.PropDef
+	for _, def := range schema {
+		if strings.EqualFold(def.Name, name) && (found.ID == "" || def.Index < found.Index) {
+			found = def
+		}
+	}
+	return found
+}
+
+// gitLabOptionID returns the ID of the option of the value, that
+// gitLabCardProperties added to the property.
+func gitLabOptionID(def model.PropDef, value string) string {
+	for _, option := range def.Options {
+		if strings.EqualFold(option.Value, value) {
+			return option.ID
+		}
+	}
+	return ""
+}
+
+// gitLabAssigneeID returns the first assignee of the issue whose GitLab
+// username is the username of a user; userIDs caches the users looked up
+// by username.
+func (a *App) gitLabAssigneeID(issue gitlab.Issue, userIDs map[string]string) string {
+	for _, assignee := range issue.Assignees {
+		userID, ok := userIDs[assignee.Username]
+		if !ok {
+			if user, err := a.store.GetUserByUsername(assignee.Username); err == nil && user != nil {
+				userID = user.ID
+			}
+			userIDs[assignee.Username] = userID
+		}
+		if userID != "" {
+			return userID
+		}
+	}
+	return ""
+}
+
+// gitLabBoardView returns the view of the boards created for the import,
+// grouping the issues by status.
+func gitLabBoardView(boardID, statusID, labelsID, milestoneID, assigneeID string, now int64) model.Block {
+	return model.Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		ParentID: boardID,
+		BoardID:  boardID,
+		Type:     model.TypeView,
+		Title:    "Issues",
+		CreateAt: now,
+		UpdateAt: now,
+		Fields: map[string]interface{}{
+			"viewType":           "board",
+			"groupById":          statusID,
+			"sortOptions":        []interface{}{},
+			"visiblePropertyIds": []interface{}{labelsID, milestoneID, assigneeID},
+			"visibleOptionIds":   []interface{}{},
+			"hiddenOptionIds":    []interface{}{},
+			"collapsedOptionIds": []interface{}{},
+			"filter":             map[string]interface{}{"operation": "and", "filters": []interface{}{}},
+			"cardOrder":          []interface{}{},
+			"columnWidths":       map[string]interface{}{},
+			"columnCalculations": map[string]interface{}{},
+			"kanbanCalculations": map[string]interface{}{},
+			"defaultTemplateId":  "",
+		},
+	}
+}
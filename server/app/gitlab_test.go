@@ -0,0 +1,67 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/gitlab"
+)
+
+func TestGitLabCardProperties(t *testing.T) {
+	issues := []gitlab.Issue{
+		{IID: 1, Title: "Crash", State: gitlab.IssueStateOpened, Labels: []string{"bug", "p1"}, Milestone: &gitlab.Milestone{Title: "v1.0"}},
+		{IID: 2, Title: "Docs", State: gitlab.IssueStateClosed, Labels: []string{"docs"}},
+	}
+
+	t.Run("the properties are added to a new board", func(t *testing.T) {
+		board := &model.Board{CardProperties: []map[string]interface{}{}}
+		properties, err := gitLabCardProperties(board, issues)
+		require.NoError(t, err)
+		require.Len(t, properties, 4)
+
+		board.CardProperties = properties
+		schema, err := model.ParsePropertySchema(board)
+		require.NoError(t, err)
+
+		labels := gitLabProperty(schema, model.GitLabPropertyLabels)
+		require.Equal(t, "multiSelect", labels.Type)
+		require.Len(t, labels.Options, 3)
+		require.NotEmpty(t, gitLabOptionID(labels, "P1"))
+
+		status := gitLabProperty(schema, model.GitLabPropertyStatus)
+		require.Equal(t, "select", status.Type)
+		require.NotEmpty(t, gitLabOptionID(status, model.GitLabStatusClosed))
+		require.Equal(t, "person", gitLabProperty(schema, model.GitLabPropertyAssignee).Type)
+	})
+
+	t.Run("only the missing options are added to an existing board", func(t *testing.T) {
+		board := &model.Board{CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "open", "value": "Open"},
+				map[string]interface{}{"id": "closed", "value": "Closed"},
+			}},
+			{"id": "labels", "name": "Labels", "type": "multiSelect", "options": []interface{}{
+				map[string]interface{}{"id": "bug", "value": "Bug"},
+			}},
+			{"id": "milestone", "name": "Milestone", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "v1", "value": "v1.0"},
+			}},
+			{"id": "assignee", "name": "Assignee", "type": "person"},
+		}}
+		properties, err := gitLabCardProperties(board, issues)
+		require.NoError(t, err)
+		require.Len(t, properties, 1)
+		require.Equal(t, "labels", properties[0]["id"])
+		require.Len(t, properties[0]["options"], 3)
+	})
+
+	t.Run("a property of another type", func(t *testing.T) {
+		board := &model.Board{CardProperties: []map[string]interface{}{
+			{"id": "milestone", "name": "Milestone", "type": "date"},
+		}}
+		_, err := gitLabCardProperties(board, issues)
+		require.Error(t, err)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/metrics"
+	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/services/store/mockstore"
 	"github.com/mattermost/focalboard/server/services/webhook"
 	"github.com/mattermost/focalboard/server/ws"
@@ -35,7 +36,7 @@ func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
 	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
 	sessionToken := "TESTTOKEN"
 	wsserver := ws.NewServer(auth, sessionToken, false, logger, store)
-	webhook := webhook.NewClient(&cfg, logger)
+	webhook := webhook.NewClient(&cfg, nil, logger)
 	metricsService := metrics.NewMetrics(metrics.InstanceInfo{})
 
 	appServices := Services{
@@ -50,6 +51,8 @@ func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
 	app2 := New(&cfg, wsserver, appServices)
 
 	tearDown := func() {
+		// the member activity recorded by the test is saved on shutdown
+		store.EXPECT().SaveBoardMemberActivity(gomock.Any()).AnyTimes()
 		app2.Shutdown()
 		if logger != nil {
 			_ = logger.Shutdown()
@@ -63,3 +66,11 @@ func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
 		logger:       logger,
 	}, tearDown
 }
+
+// expectRunInTransaction makes the mock store run a transaction with
+// itself as the transaction store.
+func (th *TestHelper) expectRunInTransaction() *gomock.Call {
+	return th.Store.EXPECT().RunInTransaction(gomock.Any()).DoAndReturn(func(fn func(store.Store) error) error {
+		return fn(th.Store)
+	})
+}
@@ -9,7 +9,6 @@ import (
 	"io"
 	"path"
 	"path/filepath"
-	"strings"
 
 	"github.com/krolaw/zipstream"
 
@@ -20,8 +19,20 @@ import (
 )
 
 const (
-	archiveVersion  = 2
-	legacyFileBegin = "{\"version\":1"
+	// archiveVersion is the version of the archives exported by the
+	// server. Changes to the archive format must bump it and add the
+	// migration from the previous version to archiveMigrations.
+	archiveVersion = 2
+
+	// legacyArchiveVersion is the version of the archives that are a
+	// single JSONL file, starting with a header line.
+	legacyArchiveVersion = 1
+
+	// firstZipArchiveVersion is the version of the zip archives that
+	// don't have a version file.
+	firstZipArchiveVersion = 2
+
+	archiveHeaderBegin = "{\"version\":"
 )
 
 var (
@@ -34,28 +45,39 @@ var (
 // Archives are ZIP files containing a `version.json` file and zero or more
 // directories, each containing a `board.jsonl` and zero or more image files.
 func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
+	_, err := a.ImportArchiveBoards(r, opt)
+	return err
+}
+
+// ImportArchiveBoards imports an archive like ImportArchive, and returns
+// the IDs of the imported boards indexed by their ID in the archive, as
+// the boards get new IDs when imported. Legacy archives return no IDs.
+func (a *App) ImportArchiveBoards(r io.Reader, opt model.ImportArchiveOptions) (map[string]string, error) {
 	// peek at the first bytes to see if this is a legacy archive format
 	br := bufio.NewReader(r)
-	peek, err := br.Peek(len(legacyFileBegin))
-	if err == nil && string(peek) == legacyFileBegin {
+	peek, err := br.Peek(len(archiveHeaderBegin))
+	if err == nil && string(peek) == archiveHeaderBegin {
 		a.logger.Debug("importing legacy archive")
-		_, errImport := a.ImportBoardJSONL(br, opt)
-		return errImport
+		if _, errImport := a.importBoardJSONL(br, legacyArchiveVersion, opt); errImport != nil {
+			return nil, errImport
+		}
+		return map[string]string{}, nil
 	}
 
 	a.logger.Debug("importing archive")
 	zr := zipstream.NewReader(br)
 
 	boardMap := make(map[string]string) // maps old board ids to new
+	version := firstZipArchiveVersion
 
 	for {
 		hdr, err := zr.Next()
 		if err != nil {
 			if errors.Is(err, io.EOF) {
 				a.logger.Debug("import archive - done", mlog.Int("boards_imported", len(boardMap)))
-				return nil
+				return boardMap, nil
 			}
-			return err
+			return nil, err
 		}
 
 		dir, filename := filepath.Split(hdr.Name)
@@ -65,17 +87,26 @@ func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
 		case "version.json":
 			ver, errVer := parseVersionFile(zr)
 			if errVer != nil {
-				return errVer
+				return nil, errVer
 			}
-			if ver != archiveVersion {
-				return model.NewErrUnsupportedArchiveVersion(ver, archiveVersion)
+			// reject the versions that can't be imported before
+			// importing any board
+			if errVer = checkArchiveVersion(ver); errVer != nil {
+				return nil, errVer
 			}
+			version = ver
 		case "board.jsonl":
-			boardID, err := a.ImportBoardJSONL(zr, opt)
+			boardID, err := a.importBoardJSONL(zr, version, opt)
 			if err != nil {
-				return fmt.Errorf("cannot import board %s: %w", dir, err)
+				return nil, fmt.Errorf("cannot import board %s: %w", dir, err)
 			}
 			boardMap[dir] = boardID
+		case model.BoardPermissionsArchiveFile:
+			boardID, ok := boardMap[dir]
+			if !ok || !opt.ApplyPermissions {
+				continue
+			}
+			a.importBoardPermissions(zr, boardID, opt)
 		default:
 			// import file/image;  dir is the old board id
 			boardID, ok := boardMap[dir]
@@ -90,7 +121,7 @@ func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
 			filePath := filepath.Join(opt.TeamID, boardID, filename)
 			_, err := a.filesBackend.WriteFile(zr, filePath)
 			if err != nil {
-				return fmt.Errorf("cannot import file %s for board %s: %w", filename, dir, err)
+				return nil, fmt.Errorf("cannot import file %s for board %s: %w", filename, dir, err)
 			}
 		}
 
@@ -101,16 +132,50 @@ func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
 	}
 }
 
+// importBoardPermissions applies the permissions of an archive to the
+// imported board. The permissions are only logged if they can't be
+// applied, as the board was imported already. The user importing the
+// board stays a member of it.
+func (a *App) importBoardPermissions(r io.Reader, boardID string, opt model.ImportArchiveOptions) {
+	var permissions *model.BoardPermissions
+	if err := json.NewDecoder(r).Decode(&permissions); err != nil || permissions == nil {
+		a.logger.Warn("invalid board permissions in archive", mlog.String("boardID", boardID), mlog.Err(err))
+		return
+	}
+
+	if _, err := a.ApplyBoardPermissions(boardID, permissions, false, opt.ModifiedBy); err != nil {
+		a.logger.Warn("cannot apply the board permissions of the archive", mlog.String("boardID", boardID), mlog.Err(err))
+	}
+}
+
 // ImportBoardJSONL imports a JSONL file containing blocks for one board. The resulting
 // board id is returned.
 func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (string, error) {
+	return a.importBoardJSONL(r, archiveVersion, opt)
+}
+
+// importBoardJSONL imports the JSONL file of a board of an archive of the
+// given version. Files that start with a header line, as legacy archives
+// do, are of the version of the header instead. The lines are upgraded to
+// the current archive version before the board is imported.
+func (a *App) importBoardJSONL(r io.Reader, version int, opt model.ImportArchiveOptions) (string, error) {
 	// TODO: Stream this once `model.GenerateBlockIDs` can take a stream of blocks.
 	//       We don't want to load the whole file in memory, even though it's a single board.
 	boardsAndBlocks := &model.BoardsAndBlocks{
 		Blocks: make([]model.Block, 0, 10),
 		Boards: make([]*model.Board, 0, 10),
 	}
-	lineReader := bufio.NewReader(r)
+
+	version, lines, err := readArchiveLines(r, version)
+	if err != nil {
+		return "", err
+	}
+	if lines, err = migrateArchiveLines(version, lines); err != nil {
+		return "", err
+	}
+	if lines, err = convertBoardBlockLine(lines); err != nil {
+		return "", err
+	}
 
 	userID := opt.ModifiedBy
 	if userID == model.SingleUser {
@@ -119,83 +184,34 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 	now := utils.GetMillis()
 	var boardID string
 
-	lineNum := 1
-	firstLine := true
-	for {
-		line, errRead := readLine(lineReader)
-		if len(line) != 0 {
-			var skip bool
-			if firstLine {
-				// first line might be a header tag (old archive format)
-				if strings.HasPrefix(string(line), legacyFileBegin) {
-					skip = true
-				}
+	for _, archiveLine := range lines {
+		switch archiveLine.Type {
+		case "board":
+			var board model.Board
+			if err2 := json.Unmarshal(archiveLine.Data, &board); err2 != nil {
+				return "", fmt.Errorf("invalid board in archive line %d: %w", archiveLine.num, err2)
 			}
-
-			if !skip {
-				var archiveLine model.ArchiveLine
-				if err := json.Unmarshal(line, &archiveLine); err != nil {
-					return "", fmt.Errorf("error parsing archive line %d: %w", lineNum, err)
-				}
-
-				// first line must be a board
-				if firstLine && archiveLine.Type == "block" {
-					archiveLine.Type = "board_block"
-				}
-
-				switch archiveLine.Type {
-				case "board":
-					var board model.Board
-					if err2 := json.Unmarshal(archiveLine.Data, &board); err2 != nil {
-						return "", fmt.Errorf("invalid board in archive line %d: %w", lineNum, err2)
-					}
-					board.ModifiedBy = userID
-					board.UpdateAt = now
-					board.TeamID = opt.TeamID
-					boardsAndBlocks.Boards = append(boardsAndBlocks.Boards, &board)
-					boardID = board.ID
-				case "board_block":
-					// legacy archives encoded boards as blocks; we need to convert them to real boards.
-					var block model.Block
-					if err2 := json.Unmarshal(archiveLine.Data, &block); err2 != nil {
-						return "", fmt.Errorf("invalid board block in archive line %d: %w", lineNum, err2)
-					}
-					block.ModifiedBy = userID
-					block.UpdateAt = now
-					board, err := a.blockToBoard(&block, opt)
-					if err != nil {
-						return "", fmt.Errorf("cannot convert archive line %d to block: %w", lineNum, err)
-					}
-					boardsAndBlocks.Boards = append(boardsAndBlocks.Boards, board)
-					boardID = board.ID
-				case "block":
-					var block model.Block
-					if err2 := json.Unmarshal(archiveLine.Data, &block); err2 != nil {
-						return "", fmt.Errorf("invalid block in archive line %d: %w", lineNum, err2)
-					}
-					block.ModifiedBy = userID
-					block.UpdateAt = now
-					block.BoardID = boardID
-					boardsAndBlocks.Blocks = append(boardsAndBlocks.Blocks, block)
-				default:
-					return "", model.NewErrUnsupportedArchiveLineType(lineNum, archiveLine.Type)
-				}
-				firstLine = false
+			board.ModifiedBy = userID
+			board.UpdateAt = now
+			board.TeamID = opt.TeamID
+			boardsAndBlocks.Boards = append(boardsAndBlocks.Boards, &board)
+			boardID = board.ID
+		case "block":
+			var block model.Block
+			if err2 := json.Unmarshal(archiveLine.Data, &block); err2 != nil {
+				return "", fmt.Errorf("invalid block in archive line %d: %w", archiveLine.num, err2)
 			}
+			block.ModifiedBy = userID
+			block.UpdateAt = now
+			block.BoardID = boardID
+			boardsAndBlocks.Blocks = append(boardsAndBlocks.Blocks, block)
+		default:
+			return "", model.NewErrUnsupportedArchiveLineType(archiveLine.num, archiveLine.Type)
 		}
-
-		if errRead != nil {
-			if errors.Is(errRead, io.EOF) {
-				break
-			}
-			return "", fmt.Errorf("error reading archive line %d: %w", lineNum, errRead)
-		}
-		lineNum++
 	}
 
 	a.fixBoardsandBlocks(boardsAndBlocks, opt)
 
-	var err error
 	boardsAndBlocks, err = model.GenerateBoardsAndBlocksIDs(boardsAndBlocks, a.logger)
 	if err != nil {
 		return "", fmt.Errorf("error generating archive block IDs: %w", err)
@@ -264,14 +280,13 @@ func (a *App) fixBoardsandBlocks(boardsAndBlocks *model.BoardsAndBlocks, opt mod
 
 // blockToBoard converts a `model.Block` to `model.Board`. Legacy archive formats encode boards as blocks
 // and need conversion during import.
-func (a *App) blockToBoard(block *model.Block, opt model.ImportArchiveOptions) (*model.Board, error) {
+func blockToBoard(block *model.Block) (*model.Board, error) {
 	if block.Type != model.TypeBoard {
 		return nil, errBlockIsNotABoard
 	}
 
 	board := &model.Board{
 		ID:             block.ID,
-		TeamID:         opt.TeamID,
 		CreatedBy:      block.CreatedBy,
 		ModifiedBy:     block.ModifiedBy,
 		Type:           model.BoardTypePrivate,
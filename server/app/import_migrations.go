@@ -0,0 +1,128 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// archiveLine is a line of the JSONL file of a board, with its line
+// number for the import errors.
+type archiveLine struct {
+	model.ArchiveLine
+	num int
+}
+
+// archiveMigration upgrades the lines of the JSONL file of a board from
+// the archive version it is registered for to the next one.
+type archiveMigration func(lines []archiveLine) ([]archiveLine, error)
+
+// archiveMigrations are indexed by the archive version they upgrade from.
+var archiveMigrations = map[int]archiveMigration{
+	legacyArchiveVersion: migrateArchiveV1ToV2,
+}
+
+// checkArchiveVersion returns an error if the archives of the version
+// can't be imported: the versions newer than the server's, and the older
+// versions without migrations to it.
+func checkArchiveVersion(version int) error {
+	if version > archiveVersion {
+		return model.NewErrUnsupportedArchiveVersion(version, archiveVersion)
+	}
+	for v := version; v < archiveVersion; v++ {
+		if _, ok := archiveMigrations[v]; !ok {
+			return model.NewErrUnsupportedArchiveVersion(version, archiveVersion)
+		}
+	}
+	return nil
+}
+
+// migrateArchiveLines upgrades the lines of a board of an archive of the
+// given version to the current archive version.
+func migrateArchiveLines(version int, lines []archiveLine) ([]archiveLine, error) {
+	if err := checkArchiveVersion(version); err != nil {
+		return nil, err
+	}
+
+	var err error
+	for v := version; v < archiveVersion; v++ {
+		if lines, err = archiveMigrations[v](lines); err != nil {
+			return nil, fmt.Errorf("cannot upgrade archive from version %d: %w", v, err)
+		}
+	}
+	return lines, nil
+}
+
+// readArchiveLines reads the lines of the JSONL file of a board. If the
+// file starts with a header line, the version of the header is returned
+// instead of the given one.
+func readArchiveLines(r io.Reader, version int) (int, []archiveLine, error) {
+	lineReader := bufio.NewReader(r)
+	lines := []archiveLine{}
+
+	lineNum := 1
+	for {
+		line, errRead := readLine(lineReader)
+		if len(line) != 0 {
+			if lineNum == 1 && strings.HasPrefix(string(line), archiveHeaderBegin) {
+				// legacy archives start with a header line
+				var header model.ArchiveHeader
+				if err := json.Unmarshal(line, &header); err != nil {
+					return 0, nil, fmt.Errorf("error parsing archive header: %w", err)
+				}
+				version = header.Version
+			} else {
+				var parsed model.ArchiveLine
+				if err := json.Unmarshal(line, &parsed); err != nil {
+					return 0, nil, fmt.Errorf("error parsing archive line %d: %w", lineNum, err)
+				}
+				lines = append(lines, archiveLine{ArchiveLine: parsed, num: lineNum})
+			}
+		}
+
+		if errRead != nil {
+			if errors.Is(errRead, io.EOF) {
+				return version, lines, nil
+			}
+			return 0, nil, fmt.Errorf("error reading archive line %d: %w", lineNum, errRead)
+		}
+		lineNum++
+	}
+}
+
+// migrateArchiveV1ToV2 converts the board of the legacy archives, stored
+// as the first block of the file, to a board line.
+func migrateArchiveV1ToV2(lines []archiveLine) ([]archiveLine, error) {
+	return convertBoardBlockLine(lines)
+}
+
+// convertBoardBlockLine converts the board stored as the first block of
+// the file to a board line. The version 2 archives exported by older
+// servers, such as the built-in templates, store their board that way too.
+func convertBoardBlockLine(lines []archiveLine) ([]archiveLine, error) {
+	if len(lines) == 0 || lines[0].Type != "block" {
+		return lines, nil
+	}
+
+	var block model.Block
+	if err := json.Unmarshal(lines[0].Data, &block); err != nil {
+		return nil, fmt.Errorf("invalid board block in archive line %d: %w", lines[0].num, err)
+	}
+	board, err := blockToBoard(&block)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert archive line %d to board: %w", lines[0].num, err)
+	}
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		return nil, err
+	}
+	lines[0].Type = "board"
+	lines[0].Data = data
+	return lines, nil
+}
@@ -1,7 +1,11 @@
 package app
 
 import (
+	"archive/zip"
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -43,6 +47,7 @@ func TestApp_ImportArchive(t *testing.T) {
 			ModifiedBy: "user",
 		}
 
+		th.expectRunInTransaction().Times(2)
 		th.Store.EXPECT().CreateBoardsAndBlocks(gomock.AssignableToTypeOf(&model.BoardsAndBlocks{}), "user").Return(babs, nil)
 		th.Store.EXPECT().GetMembersForBoard(board.ID).AnyTimes().Return([]*model.BoardMember{boardMember}, nil)
 		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
@@ -53,6 +58,74 @@ func TestApp_ImportArchive(t *testing.T) {
 	})
 }
 
+func TestMigrateArchiveLines(t *testing.T) {
+	boardBlock := `{"id":"board-id","type":"board","title":"Legacy board","fields":{"icon":"i","cardProperties":[{"id":"prop","name":"Status","type":"select"}]}}`
+	cardBlock := `{"id":"card-id","type":"card","parentId":"board-id","title":"Card"}`
+	lines := []archiveLine{
+		{ArchiveLine: model.ArchiveLine{Type: "block", Data: []byte(boardBlock)}, num: 2},
+		{ArchiveLine: model.ArchiveLine{Type: "block", Data: []byte(cardBlock)}, num: 3},
+	}
+
+	t.Run("legacy archives are upgraded", func(t *testing.T) {
+		migrated, err := migrateArchiveLines(legacyArchiveVersion, lines)
+		require.NoError(t, err)
+		require.Len(t, migrated, 2)
+		require.Equal(t, "board", migrated[0].Type)
+		require.Equal(t, "block", migrated[1].Type)
+
+		var board model.Board
+		require.NoError(t, json.Unmarshal(migrated[0].Data, &board))
+		require.Equal(t, "board-id", board.ID)
+		require.Equal(t, "Legacy board", board.Title)
+		require.Equal(t, "i", board.Icon)
+		require.Len(t, board.CardProperties, 1)
+	})
+
+	t.Run("current archives are unchanged", func(t *testing.T) {
+		current := []archiveLine{{ArchiveLine: model.ArchiveLine{Type: "board", Data: []byte(`{"id":"board-id"}`)}, num: 1}}
+		migrated, err := migrateArchiveLines(archiveVersion, current)
+		require.NoError(t, err)
+		require.Equal(t, current, migrated)
+	})
+
+	t.Run("newer archives are rejected", func(t *testing.T) {
+		_, err := migrateArchiveLines(archiveVersion+1, lines)
+		var errVersion model.ErrUnsupportedArchiveVersion
+		require.True(t, errors.As(err, &errVersion))
+		require.True(t, errVersion.IsNewer())
+	})
+
+	t.Run("versions without migrations are rejected", func(t *testing.T) {
+		_, err := migrateArchiveLines(0, lines)
+		var errVersion model.ErrUnsupportedArchiveVersion
+		require.True(t, errors.As(err, &errVersion))
+		require.False(t, errVersion.IsNewer())
+	})
+}
+
+func TestImportArchiveNewerVersion(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("version.json")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(fmt.Sprintf(`{"version":%d,"date":1,"serverVersion":"99.0.0"}`, archiveVersion+1)))
+	require.NoError(t, err)
+	w, err = zw.Create("board-id/board.jsonl")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(`{"type":"board","data":{"id":"board-id"}}` + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	// no board is created
+	_, err = th.App.ImportArchiveBoards(&buf, model.ImportArchiveOptions{TeamID: "test-team", ModifiedBy: "user"})
+	var errVersion model.ErrUnsupportedArchiveVersion
+	require.True(t, errors.As(err, &errVersion))
+	require.True(t, errVersion.IsNewer())
+}
+
 //nolint:lll
 const asana = `{"version":1,"date":1614714686842}
 {"type":"block","data":{"id":"d14b9df9-1f31-4732-8a64-92bc7162cd28","fields":{"icon":"","description":"","cardProperties":[{"id":"3bdcbaeb-bc78-4884-8531-a0323b74676a","name":"Section","type":"select","options":[{"id":"d8d94ef1-5e74-40bb-8be5-fc0eb3f47732","value":"Planning","color":"propColorGray"},{"id":"454559bb-b788-4ff6-873e-04def8491d2c","value":"Milestones","color":"propColorBrown"},{"id":"deaab476-c690-48df-828f-725b064dc476","value":"Next steps","color":"propColorOrange"},{"id":"2138305a-3157-461c-8bbe-f19ebb55846d","value":"Comms Plan","color":"propColorYellow"}]}]},"createAt":1614714686836,"updateAt":1614714686836,"deleteAt":0,"schema":1,"parentId":"","rootId":"d14b9df9-1f31-4732-8a64-92bc7162cd28","modifiedBy":"","type":"board","title":"Cross-Functional Project Plan"}}
@@ -23,4 +23,16 @@ func (a *App) Shutdown() {
 			a.logger.Warn("blockChangeNotifier shutdown timed out")
 		}
 	}
+
+	if a.boardVisits != nil {
+		a.boardVisits.shutdown()
+	}
+
+	if a.boardMemberActivity != nil {
+		a.boardMemberActivity.shutdown()
+	}
+
+	if a.propertyIndexer != nil {
+		a.propertyIndexer.shutdown()
+	}
 }
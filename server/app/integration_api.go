@@ -0,0 +1,123 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetIntegrationNewCards returns the cards of the board created after the
+// cursor, for the new card trigger of the integration API.
+func (a *App) GetIntegrationNewCards(boardID, cursor string, limit int) ([]model.IntegrationCard, error) {
+	board, schema, err := a.getIntegrationBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewIntegrationCards(cards, cursor, limit, schema, a.store)
+}
+
+// GetIntegrationStatusChanges returns the cards of the board whose status
+// changed after the cursor, for the status change trigger of the
+// integration API.
+func (a *App) GetIntegrationStatusChanges(boardID, cursor string, limit int) ([]model.IntegrationStatusChange, error) {
+	board, schema, err := a.getIntegrationBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	status, ok := schema.StatusProperty(board)
+	if !ok {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "the board has no status property", map[string]interface{}{"boardId": boardID})
+	}
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+	statusSince, err := a.store.GetCardsStatusSince(board.ID, status.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewIntegrationStatusChanges(cards, statusSince, status, cursor, limit, schema, a.store)
+}
+
+// CreateIntegrationCard creates a card on the board for the create card
+// action of the integration API. The card is created with its properties,
+// so its initial status isn't reported as a status change.
+func (a *App) CreateIntegrationCard(boardID string, request *model.IntegrationCardRequest, userID string) (*model.IntegrationCard, error) {
+	_, schema, err := a.getIntegrationBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	bulkCard := model.BulkCard{Title: request.Title, Properties: request.Properties}
+	if request.Description != "" {
+		bulkCard.Content = []model.BulkCardContent{{Type: model.TypeText, Title: request.Description}}
+	}
+	blocks, err := a.CreateCardsInBulk(boardID, &model.BulkCardsRequest{Cards: []model.BulkCard{bulkCard}}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// read back, for the fields set by the store
+	card, err := a.store.GetBlock(blocks[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	integrationCard := model.NewIntegrationCard(card, schema, a.store)
+	return &integrationCard, nil
+}
+
+// UpdateIntegrationCard changes the title and properties of a card for the
+// update card action of the integration API.
+func (a *App) UpdateIntegrationCard(cardID string, request *model.IntegrationCardRequest, userID string) (*model.IntegrationCard, error) {
+	card, err := a.store.GetBlock(cardID)
+	if a.store.IsErrNotFound(err) || (err == nil && (card == nil || card.Type != model.TypeCard)) {
+		return nil, model.NewErrBlockNotFound(cardID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, schema, err := a.getIntegrationBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = schema.ResolvePropertyValues(request.Properties); err != nil {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, err.Error(), nil)
+	}
+
+	if request.Title != "" && request.Title != card.Title {
+		title := request.Title
+		if err = a.PatchBlock(card.ID, &model.BlockPatch{Title: &title}, userID); err != nil {
+			return nil, err
+		}
+	}
+	if len(request.Properties) > 0 {
+		if _, err = a.SetCardProperties(card.ID, request.Properties, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if card, err = a.store.GetBlock(card.ID); err != nil {
+		return nil, err
+	}
+	integrationCard := model.NewIntegrationCard(card, schema, a.store)
+	return &integrationCard, nil
+}
+
+func (a *App) getIntegrationBoard(boardID string) (*model.Board, model.PropSchema, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if board == nil {
+		return nil, nil, model.NewErrBoardNotFound(boardID)
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, nil, err
+	}
+	return board, schema, nil
+}
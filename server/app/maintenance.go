@@ -0,0 +1,22 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// RunDatabaseMaintenance deletes the orphan rows of the database and
+// optimizes its tables, reporting what was done.
+func (a *App) RunDatabaseMaintenance() (*model.MaintenanceReport, error) {
+	report, err := a.store.RunMaintenance()
+	if err != nil {
+		return nil, err
+	}
+
+	a.logger.Info("Database maintenance done",
+		mlog.Int("statement_count", len(report.Statements)),
+		mlog.Int64("duration_ms", report.EndAt-report.StartAt),
+	)
+	return report, nil
+}
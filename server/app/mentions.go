@@ -0,0 +1,16 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetMentionsForUser returns the mentions of the user, newest first.
+func (a *App) GetMentionsForUser(userID string, opts model.QueryMentionsOptions) ([]*model.Mention, error) {
+	return a.store.GetMentionsForUser(userID, opts)
+}
+
+// MarkMentionsRead marks the given mentions of the user as read, or all
+// of them if no mention IDs are given.
+func (a *App) MarkMentionsRead(userID string, mentionIDs []string) error {
+	return a.store.MarkMentionsRead(userID, mentionIDs, model.GetMillis())
+}
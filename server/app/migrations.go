@@ -0,0 +1,10 @@
+package app
+
+import "github.com/mattermost/focalboard/server/model"
+
+// GetMigrationStatus returns the schema version of the database and its
+// pending migrations. On dry runs, the SQL each pending migration would
+// execute is included.
+func (a *App) GetMigrationStatus(dryRun bool) (*model.MigrationStatus, error) {
+	return a.store.GetMigrationStatus(dryRun)
+}
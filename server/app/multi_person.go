@@ -0,0 +1,44 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// checkMultiPersonProperties checks the multi-person properties of the
+// cards of the board: their values must be lists of users, and the users
+// added to them must be members of the team of the board. The old cards
+// are matched by index, and are nil for new cards.
+func (a *App) checkMultiPersonProperties(board *model.Board, cards []model.Block, oldCards []*model.Block) error {
+	if board == nil {
+		return nil
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil || !schema.HasMultiPersonProperty() {
+		return nil
+	}
+
+	for i := range cards {
+		if cards[i].Type != model.TypeCard {
+			continue
+		}
+		var oldCard *model.Block
+		if i < len(oldCards) {
+			oldCard = oldCards[i]
+		}
+
+		added, err := model.AddedMultiPersonUsers(&cards[i], oldCard, schema)
+		if err != nil {
+			return err
+		}
+		if a.permissions == nil {
+			continue
+		}
+		for _, userID := range added {
+			if !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
+				return model.NewCodedError(model.ErrCodeBadRequest, "the users of a multi-person property must be members of the team of the board",
+					map[string]interface{}{"blockId": cards[i].ID, "userId": userID})
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/stretchr/testify/require"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// teamMembersPermissions only grants the team permissions to the members
+// of the team.
+type teamMembersPermissions struct {
+	permissions.PermissionsService
+	members map[string]bool
+}
+
+func (p *teamMembersPermissions) HasPermissionToTeam(userID, teamID string, permission *mmModel.Permission) bool {
+	return p.members[userID]
+}
+
+func TestCheckMultiPersonProperties(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.permissions = &teamMembersPermissions{members: map[string]bool{"user-1": true, "user-2": true}}
+
+	board := &model.Board{
+		ID:     testBoardID,
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "owners", "name": "Owners", "type": "multiPerson"},
+		},
+	}
+	card := func(owners ...interface{}) model.Block {
+		return model.Block{
+			ID:      "card-id",
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{"owners": owners}},
+		}
+	}
+
+	t.Run("team members can be added", func(t *testing.T) {
+		require.NoError(t, th.App.checkMultiPersonProperties(board, []model.Block{card("user-1", "user-2")}, nil))
+	})
+
+	t.Run("users outside of the team are rejected", func(t *testing.T) {
+		err := th.App.checkMultiPersonProperties(board, []model.Block{card("user-1", "user-3")}, nil)
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+	})
+
+	t.Run("users already on the card are kept", func(t *testing.T) {
+		oldCard := card("user-3")
+		patch := model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"properties": map[string]interface{}{"owners": []interface{}{"user-3", "user-1"}}},
+		}
+		boards := map[string]*model.Board{board.ID: board}
+		require.NoError(t, th.App.checkCardPropertyPatches(boards, []model.Block{oldCard}, []model.BlockPatch{patch}))
+
+		patch.UpdatedFields["properties"] = map[string]interface{}{"owners": []interface{}{"user-4"}}
+		require.Error(t, th.App.checkCardPropertyPatches(boards, []model.Block{oldCard}, []model.BlockPatch{patch}))
+
+		// the old card is not changed by the check
+		require.Equal(t, []interface{}{"user-3"}, oldCard.Fields["properties"].(map[string]interface{})["owners"])
+	})
+}
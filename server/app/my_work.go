@@ -0,0 +1,67 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetMyWork returns the cards of the team boards visible to the user
+// that are assigned to them through a person property, sorted by due
+// date. The cards due today are evaluated in the timezone of the user.
+func (a *App) GetMyWork(userID, teamID string, query model.MyWorkQuery) ([]*model.MyWorkCard, error) {
+	loc, err := a.GetUserLocation(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	boards, err := a.store.GetBoardsForUserAndTeam(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	boardsByID := map[string]*model.Board{}
+	schemas := map[string]model.PropSchema{}
+	boardIDs := []string{}
+	for _, board := range boards {
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			a.logger.Warn("GetMyWork cannot parse board properties", mlog.String("boardID", board.ID), mlog.Err(err))
+			continue
+		}
+		if !schema.HasPersonProperty() {
+			continue
+		}
+		boardsByID[board.ID] = board
+		schemas[board.ID] = schema
+		boardIDs = append(boardIDs, board.ID)
+	}
+
+	cards, err := a.store.GetCardsWithFieldValue(boardIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := []*model.MyWorkCard{}
+	for _, card := range cards {
+		if isTemplate, _ := card.Fields["isTemplate"].(bool); isTemplate {
+			continue
+		}
+		match, ok := model.MatchMyWork(card, boardsByID[card.BoardID], schemas[card.BoardID], userID, query)
+		if !ok {
+			continue
+		}
+		match.DueToday = match.DueDate != 0 && utils.IsSameDay(match.DueDate, now, loc)
+		if query.DueToday && !match.DueToday {
+			continue
+		}
+		result = append(result, match)
+	}
+
+	model.SortMyWork(result)
+	return result, nil
+}
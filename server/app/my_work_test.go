@@ -0,0 +1,106 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMyWork(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	personBoard := &model.Board{
+		ID:    "board-id-1",
+		Title: "Board with assignees",
+		CardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": "person"},
+		},
+	}
+	textBoard := &model.Board{
+		ID: "board-id-2",
+		CardProperties: []map[string]interface{}{
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	}
+
+	th.Store.EXPECT().GetUserByID("user-id").Return(&model.User{ID: "user-id"}, nil)
+	th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id").Return([]*model.Board{personBoard, textBoard}, nil)
+	th.Store.EXPECT().GetCardsWithFieldValue([]string{"board-id-1"}, "user-id").Return([]model.Block{
+		{
+			ID:      "card-1",
+			BoardID: "board-id-1",
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{"assignee": "user-id"}},
+		},
+		{
+			ID:      "card-template",
+			BoardID: "board-id-1",
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{
+				"isTemplate": true,
+				"properties": map[string]interface{}{"assignee": "user-id"},
+			},
+		},
+		{
+			// the user ID is found in the fields, but not in a person property
+			ID:      "card-2",
+			BoardID: "board-id-1",
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"contentOrder": []interface{}{"user-id"}},
+		},
+	}, nil)
+
+	cards, err := th.App.GetMyWork("user-id", "team-id", model.MyWorkQuery{})
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	require.Equal(t, "card-1", cards[0].Card.ID)
+	require.Equal(t, "Board with assignees", cards[0].BoardTitle)
+}
+
+func TestGetMyWorkDueToday(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	now := time.Now().In(tokyo)
+
+	board := &model.Board{
+		ID: "board-id-1",
+		CardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": "person"},
+			{"id": "due", "name": "Due", "type": "date"},
+		},
+	}
+	cardDueAt := func(id string, due time.Time) model.Block {
+		return model.Block{
+			ID:      id,
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{"properties": map[string]interface{}{
+				"assignee": "user-id",
+				"due":      fmt.Sprintf(`{"from":%d}`, utils.GetMillisForTime(due)),
+			}},
+		}
+	}
+
+	// the start of the day in Tokyo can be the previous day in the server
+	// timezone, so the dates are only correct in the timezone of the user
+	th.Store.EXPECT().GetUserByID("user-id").Return(&model.User{ID: "user-id", Timezone: "Asia/Tokyo"}, nil)
+	th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id").Return([]*model.Board{board}, nil)
+	th.Store.EXPECT().GetCardsWithFieldValue([]string{"board-id-1"}, "user-id").Return([]model.Block{
+		cardDueAt("card-today", utils.StartOfDay(now, tokyo)),
+		cardDueAt("card-tomorrow", utils.StartOfDay(now, tokyo).AddDate(0, 0, 1)),
+	}, nil)
+
+	cards, err := th.App.GetMyWork("user-id", "team-id", model.MyWorkQuery{DueToday: true})
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	require.Equal(t, "card-today", cards[0].Card.ID)
+	require.True(t, cards[0].DueToday)
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+)
+
+// SendTestNotification sends a synthetic notification to the user through
+// each notification backend, and reports the outcome per backend.
+func (a *App) SendTestNotification(userID string) []*model.TestNotificationResult {
+	if a.notifications == nil {
+		return []*model.TestNotificationResult{}
+	}
+	return a.notifications.SendTestNotification(userID)
+}
+
+// notifyMemberAdded informs the notification backends of a user added
+// to a board.
+func (a *App) notifyMemberAdded(board *model.Board, member *model.BoardMember) {
+	if a.notifications == nil || board == nil || member == nil {
+		return
+	}
+
+	a.notifications.MemberAdded(notify.MemberChangeEvent{
+		TeamID: board.TeamID,
+		Board:  board,
+		Member: member,
+	})
+}
+
+// ValidateMentions returns the outcome the mentions of the text would
+// have if the user saved it on the board. Nothing is changed.
+func (a *App) ValidateMentions(boardID, userID, text string) ([]*model.MentionValidation, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	if a.notifications == nil {
+		return []*model.MentionValidation{}, nil
+	}
+
+	author, _ := a.GetMemberForBoard(boardID, userID)
+	if author == nil {
+		// the same temporary guest member as the notifications
+		author = &model.BoardMember{
+			BoardID: boardID,
+			UserID:  userID,
+		}
+	}
+	return a.notifications.ValidateMentions(text, board, author)
+}
@@ -4,16 +4,17 @@ import (
 	"errors"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
 )
 
 const (
 	KeyPrefix                 = "focalboard_" // use key prefix to namespace focalboard props
-	KeyOnboardingTourStarted  = KeyPrefix + "onboardingTourStarted"
-	KeyOnboardingTourCategory = KeyPrefix + "tourCategory"
-	KeyOnboardingTourStep     = KeyPrefix + "onboardingTourStep"
+	KeyOnboardingTourStarted  = model.UserPropOnboardingTourStarted
+	KeyOnboardingTourCategory = model.UserPropOnboardingTourCategory
+	KeyOnboardingTourStep     = model.UserPropOnboardingTourStep
 
 	ValueOnboardingFirstStep    = "0"
-	ValueTourCategoryOnboarding = "onboarding"
+	ValueTourCategoryOnboarding = model.TourCategoryOnboarding
 
 	WelcomeBoardTitle = "Welcome to Boards!"
 )
@@ -24,18 +25,29 @@ var (
 )
 
 func (a *App) PrepareOnboardingTour(userID string, teamID string) (string, string, error) {
-	// copy the welcome board into this workspace
-	boardID, err := a.createWelcomeBoard(userID, teamID)
+	user, err := a.store.GetUserByID(userID)
 	if err != nil {
 		return "", "", err
 	}
 
+	// the tour restarts on the welcome board created the previous time,
+	// so users don't get a new copy on every device
+	boardID := a.getReusableWelcomeBoardID(userID, teamID, user.OnboardingState().WelcomeBoardID)
+	if boardID == "" {
+		// copy the welcome board into this workspace
+		boardID, err = a.createWelcomeBoard(userID, teamID)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
 	// set user's tour state to initial state
 	userPropPatch := model.UserPropPatch{
 		UpdatedFields: map[string]string{
-			KeyOnboardingTourStarted:  "1",
-			KeyOnboardingTourStep:     ValueOnboardingFirstStep,
-			KeyOnboardingTourCategory: ValueTourCategoryOnboarding,
+			KeyOnboardingTourStarted:     "1",
+			KeyOnboardingTourStep:        ValueOnboardingFirstStep,
+			KeyOnboardingTourCategory:    ValueTourCategoryOnboarding,
+			model.UserPropWelcomeBoardID: boardID,
 		},
 	}
 	if err := a.store.PatchUserProps(userID, userPropPatch); err != nil {
@@ -45,6 +57,46 @@ func (a *App) PrepareOnboardingTour(userID string, teamID string) (string, strin
 	return teamID, boardID, nil
 }
 
+// getReusableWelcomeBoardID returns the ID of the welcome board if the
+// user is still a member of it on the team, or an empty string.
+func (a *App) getReusableWelcomeBoardID(userID, teamID, welcomeBoardID string) string {
+	if welcomeBoardID == "" {
+		return ""
+	}
+
+	board, err := a.store.GetBoard(welcomeBoardID)
+	if err != nil || board.TeamID != teamID {
+		return ""
+	}
+	if _, err := a.store.GetMemberForBoard(welcomeBoardID, userID); err != nil {
+		return ""
+	}
+	return welcomeBoardID
+}
+
+// GetOnboardingState returns the progress of the user through the
+// onboarding tour.
+func (a *App) GetOnboardingState(userID string) (*model.OnboardingState, error) {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return user.OnboardingState(), nil
+}
+
+// PatchOnboardingState updates the progress of the user through the
+// onboarding tour.
+func (a *App) PatchOnboardingState(userID string, patch *model.OnboardingStatePatch) (*model.OnboardingState, error) {
+	if err := patch.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := a.store.PatchUserProps(userID, patch.UserPropPatch()); err != nil {
+		return nil, err
+	}
+	return a.GetOnboardingState(userID)
+}
+
 func (a *App) getOnboardingBoardID() (string, error) {
 	boards, err := a.store.GetTemplateBoards(model.GlobalTeamID, "")
 	if err != nil {
@@ -72,26 +124,40 @@ func (a *App) createWelcomeBoard(userID, teamID string) (string, error) {
 		return "", err
 	}
 
-	bab, _, err := a.DuplicateBoard(onboardingBoardID, userID, teamID, false)
-	if err != nil {
-		return "", err
-	}
+	// the welcome board is copied and made private at once, so users
+	// never get a public copy if making it private fails.
+	var bab *model.BoardsAndBlocks
+	var members []*model.BoardMember
+	err = a.store.RunInTransaction(func(tx store.Store) error {
+		var err error
+		bab, members, err = duplicateBoard(tx, onboardingBoardID, userID, teamID, false, nil, nil)
+		if err != nil {
+			return err
+		}
 
-	if len(bab.Boards) != 1 {
-		return "", errCannotCreateBoard
-	}
+		if len(bab.Boards) != 1 {
+			return errCannotCreateBoard
+		}
 
-	// need variable for this to
-	// get reference for board patch
-	newType := model.BoardTypePrivate
+		// need variable for this to
+		// get reference for board patch
+		newType := model.BoardTypePrivate
 
-	patch := &model.BoardPatch{
-		Type: &newType,
-	}
+		patch := &model.BoardPatch{
+			Type: &newType,
+		}
 
-	if _, err := a.PatchBoard(patch, bab.Boards[0].ID, userID); err != nil {
+		board, err := tx.PatchBoard(bab.Boards[0].ID, patch, userID)
+		if err != nil {
+			return err
+		}
+		bab.Boards[0] = board
+		return nil
+	})
+	if err != nil {
 		return "", err
 	}
 
+	a.broadcastDuplicatedBoard(bab, members)
 	return bab.Boards[0].ID, nil
 }
@@ -25,10 +25,12 @@ func TestPrepareOnboardingTour(t *testing.T) {
 			IsTemplate: true,
 		}
 
+		th.Store.EXPECT().GetUserByID(userID).Return(&model.User{ID: userID, Props: map[string]interface{}{}}, nil)
 		th.Store.EXPECT().GetTemplateBoards("0", "").Return([]*model.Board{&welcomeBoard}, nil)
-		th.Store.EXPECT().DuplicateBoard(welcomeBoard.ID, userID, teamID, false).Return(&model.BoardsAndBlocks{Boards: []*model.Board{&welcomeBoard}},
+		th.expectRunInTransaction()
+		th.Store.EXPECT().DuplicateBoard(welcomeBoard.ID, userID, teamID, false, nil).Return(&model.BoardsAndBlocks{Boards: []*model.Board{&welcomeBoard}},
 			nil, nil)
-		th.Store.EXPECT().GetMembersForBoard(welcomeBoard.ID).Return([]*model.BoardMember{}, nil).Times(2)
+		th.Store.EXPECT().GetMembersForBoard(welcomeBoard.ID).Return([]*model.BoardMember{}, nil)
 
 		privateWelcomeBoard := model.Board{
 			ID:         "board_id_1",
@@ -42,9 +44,10 @@ func TestPrepareOnboardingTour(t *testing.T) {
 
 		userPropPatch := model.UserPropPatch{
 			UpdatedFields: map[string]string{
-				KeyOnboardingTourStarted:  "1",
-				KeyOnboardingTourStep:     ValueOnboardingFirstStep,
-				KeyOnboardingTourCategory: ValueTourCategoryOnboarding,
+				KeyOnboardingTourStarted:     "1",
+				KeyOnboardingTourStep:        ValueOnboardingFirstStep,
+				KeyOnboardingTourCategory:    ValueTourCategoryOnboarding,
+				model.UserPropWelcomeBoardID: "board_id_1",
 			},
 		}
 
@@ -55,6 +58,74 @@ func TestPrepareOnboardingTour(t *testing.T) {
 		assert.Equal(t, testTeamID, teamID)
 		assert.NotEmpty(t, boardID)
 	})
+
+	t.Run("reuses the welcome board of the user", func(t *testing.T) {
+		userID := "user_id_1"
+		user := &model.User{
+			ID:    userID,
+			Props: map[string]interface{}{model.UserPropWelcomeBoardID: "board_id_2"},
+		}
+		th.Store.EXPECT().GetUserByID(userID).Return(user, nil)
+		th.Store.EXPECT().GetBoard("board_id_2").Return(&model.Board{ID: "board_id_2", TeamID: testTeamID}, nil)
+		th.Store.EXPECT().GetMemberForBoard("board_id_2", userID).Return(&model.BoardMember{BoardID: "board_id_2", UserID: userID}, nil)
+
+		userPropPatch := model.UserPropPatch{
+			UpdatedFields: map[string]string{
+				KeyOnboardingTourStarted:     "1",
+				KeyOnboardingTourStep:        ValueOnboardingFirstStep,
+				KeyOnboardingTourCategory:    ValueTourCategoryOnboarding,
+				model.UserPropWelcomeBoardID: "board_id_2",
+			},
+		}
+		th.Store.EXPECT().PatchUserProps(userID, userPropPatch).Return(nil)
+
+		teamID, boardID, err := th.App.PrepareOnboardingTour(userID, testTeamID)
+		assert.NoError(t, err)
+		assert.Equal(t, testTeamID, teamID)
+		assert.Equal(t, "board_id_2", boardID)
+	})
+}
+
+func TestPatchOnboardingState(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("saves the tour progress", func(t *testing.T) {
+		userID := "user_id_1"
+		category := model.TourCategoryCard
+		step := "2"
+		patch := &model.OnboardingStatePatch{TourCategory: &category, TourStep: &step}
+
+		th.Store.EXPECT().PatchUserProps(userID, model.UserPropPatch{
+			UpdatedFields: map[string]string{
+				model.UserPropOnboardingTourCategory: category,
+				model.UserPropOnboardingTourStep:     step,
+			},
+		}).Return(nil)
+		th.Store.EXPECT().GetUserByID(userID).Return(&model.User{
+			ID: userID,
+			Props: map[string]interface{}{
+				model.UserPropOnboardingTourStarted:  "1",
+				model.UserPropOnboardingTourCategory: category,
+				model.UserPropOnboardingTourStep:     step,
+			},
+		}, nil)
+
+		state, err := th.App.PatchOnboardingState(userID, patch)
+		assert.NoError(t, err)
+		assert.True(t, state.TourStarted)
+		assert.Equal(t, category, state.TourCategory)
+		assert.Equal(t, step, state.TourStep)
+	})
+
+	t.Run("invalid step", func(t *testing.T) {
+		step := "-1"
+		state, err := th.App.PatchOnboardingState("user_id_1", &model.OnboardingStatePatch{TourStep: &step})
+		codedErr, ok := model.AsCodedError(err)
+		assert.True(t, ok)
+		assert.Equal(t, model.ErrCodeBadRequest, codedErr.Code)
+		assert.Nil(t, state)
+	})
 }
 
 func TestCreateWelcomeBoard(t *testing.T) {
@@ -71,9 +142,10 @@ func TestCreateWelcomeBoard(t *testing.T) {
 			IsTemplate: true,
 		}
 		th.Store.EXPECT().GetTemplateBoards("0", "").Return([]*model.Board{&welcomeBoard}, nil)
-		th.Store.EXPECT().DuplicateBoard(welcomeBoard.ID, userID, teamID, false).
+		th.expectRunInTransaction()
+		th.Store.EXPECT().DuplicateBoard(welcomeBoard.ID, userID, teamID, false, nil).
 			Return(&model.BoardsAndBlocks{Boards: []*model.Board{&welcomeBoard}}, nil, nil)
-		th.Store.EXPECT().GetMembersForBoard(welcomeBoard.ID).Return([]*model.BoardMember{}, nil).Times(2)
+		th.Store.EXPECT().GetMembersForBoard(welcomeBoard.ID).Return([]*model.BoardMember{}, nil)
 		privateWelcomeBoard := model.Board{
 			ID:         "board_id_1",
 			Title:      "Welcome to Boards!",
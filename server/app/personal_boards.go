@@ -0,0 +1,158 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// MigratePersonalBoards moves boards from the personal team into a team.
+// Each board is moved in its own transaction, so a failure leaves the
+// other boards moved, and is recorded in the report.
+func (a *App) MigratePersonalBoards(req *model.PersonalBoardsMigrationRequest) (*model.PersonalBoardsMigrationReport, error) {
+	if err := req.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if req.TeamID != model.OrganizationTeamID {
+		team, err := a.GetTeam(req.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			return nil, model.NewErrTeamNotFound(req.TeamID)
+		}
+	}
+
+	boardIDs := req.BoardIDs
+	if len(boardIDs) == 0 {
+		var err error
+		if boardIDs, err = a.store.GetBoardIDsForTeam(model.GlobalTeamID); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &model.PersonalBoardsMigrationReport{
+		TeamID:  req.TeamID,
+		Boards:  []*model.PersonalBoardMigration{},
+		StartAt: utils.GetMillis(),
+	}
+
+	for i, boardID := range boardIDs {
+		result := a.migratePersonalBoard(boardID, req.TeamID)
+		report.Boards = append(report.Boards, result)
+
+		switch result.Status {
+		case model.PersonalBoardMigrated:
+			report.Migrated++
+		case model.PersonalBoardFailed:
+			report.Failed++
+		}
+
+		a.logger.Info("Personal board migration progress",
+			mlog.String("boardID", boardID),
+			mlog.String("status", result.Status),
+			mlog.Int("processed", i+1),
+			mlog.Int("total", len(boardIDs)),
+		)
+	}
+
+	report.EndAt = utils.GetMillis()
+	return report, nil
+}
+
+func (a *App) migratePersonalBoard(boardID, teamID string) *model.PersonalBoardMigration {
+	result := &model.PersonalBoardMigration{BoardID: boardID}
+
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		result.Status = model.PersonalBoardFailed
+		result.Error = err.Error()
+		return result
+	}
+	if board == nil {
+		result.Status = model.PersonalBoardSkipped
+		result.Error = "board not found"
+		return result
+	}
+	result.Title = board.Title
+
+	if board.TeamID != model.GlobalTeamID {
+		result.Status = model.PersonalBoardSkipped
+		result.Error = "the board is not in the personal team"
+		return result
+	}
+	if board.IsTemplate {
+		result.Status = model.PersonalBoardSkipped
+		result.Error = "the global templates stay in the personal team"
+		return result
+	}
+
+	if err := a.store.MoveBoardToTeam(boardID, teamID); err != nil {
+		result.Status = model.PersonalBoardFailed
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = model.PersonalBoardMigrated
+
+	// the board is moved even if some of its files are not, as a missing
+	// file only breaks an image, that can be uploaded again
+	movedFiles, err := a.movePersonalBoardFiles(boardID, teamID)
+	if err != nil {
+		a.logger.Error("Cannot move the files of a personal board",
+			mlog.String("boardID", boardID),
+			mlog.String("teamID", teamID),
+			mlog.Err(err),
+		)
+		result.Error = err.Error()
+	}
+	result.MovedFiles = movedFiles
+
+	board.TeamID = teamID
+	go func() {
+		a.wsAdapter.BroadcastBoardDelete(model.GlobalTeamID, boardID)
+		a.wsAdapter.BroadcastBoardChange(teamID, board)
+	}()
+
+	return result
+}
+
+// movePersonalBoardFiles moves the files of the image blocks of a board
+// from the folder of the personal team, or from the root folder where
+// the older versions stored them, to the folder of the new team.
+func (a *App) movePersonalBoardFiles(boardID, teamID string) (int, error) {
+	blocks, err := a.store.GetBlocksWithType(boardID, model.TypeImage)
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, block := range blocks {
+		fileID, ok := block.Fields["fileId"].(string)
+		if !ok || fileID == "" {
+			continue
+		}
+
+		destPath := filepath.Join(teamID, boardID, fileID)
+		for _, sourcePath := range []string{filepath.Join(model.GlobalTeamID, boardID, fileID), fileID} {
+			exists, err := a.filesBackend.FileExists(sourcePath)
+			if err != nil {
+				return moved, err
+			}
+			if !exists {
+				continue
+			}
+
+			if err := a.filesBackend.MoveFile(sourcePath, destPath); err != nil {
+				return moved, fmt.Errorf("cannot move file %s: %w", sourcePath, err)
+			}
+			moved++
+			break
+		}
+	}
+	return moved, nil
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/filestore/mocks"
+)
+
+func TestMigratePersonalBoards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	t.Run("invalid destination team", func(t *testing.T) {
+		_, err := th.App.MigratePersonalBoards(&model.PersonalBoardsMigrationRequest{TeamID: model.GlobalTeamID})
+		require.ErrorIs(t, err, model.ErrPersonalBoardsInvalidTeamID)
+	})
+
+	t.Run("nonexistent destination team", func(t *testing.T) {
+		th.Store.EXPECT().GetTeam("team-id").Return(nil, nil)
+
+		_, err := th.App.MigratePersonalBoards(&model.PersonalBoardsMigrationRequest{TeamID: "team-id"})
+		_, ok := model.AsCodedError(err)
+		require.True(t, ok)
+	})
+
+	t.Run("moves the personal boards and their files", func(t *testing.T) {
+		fileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = fileBackend
+
+		personal := &model.Board{ID: "personal-id", TeamID: model.GlobalTeamID, Title: "Personal"}
+		teamBoard := &model.Board{ID: "team-board-id", TeamID: "other-team-id"}
+		broken := &model.Board{ID: "broken-id", TeamID: model.GlobalTeamID}
+
+		th.Store.EXPECT().GetTeam("team-id").Return(&model.Team{ID: "team-id"}, nil)
+		th.Store.EXPECT().GetBoardIDsForTeam(model.GlobalTeamID).Return([]string{"personal-id", "team-board-id", "broken-id"}, nil)
+		th.Store.EXPECT().GetBoard("personal-id").Return(personal, nil)
+		th.Store.EXPECT().GetBoard("team-board-id").Return(teamBoard, nil)
+		th.Store.EXPECT().GetBoard("broken-id").Return(broken, nil)
+		th.Store.EXPECT().MoveBoardToTeam("personal-id", "team-id").Return(nil)
+		th.Store.EXPECT().MoveBoardToTeam("broken-id", "team-id").Return(errors.New("database error"))
+		th.Store.EXPECT().GetBlocksWithType("personal-id", model.TypeImage).Return([]model.Block{
+			{ID: "image-1", Fields: map[string]interface{}{"fileId": "file-1.png"}},
+			{ID: "image-2", Fields: map[string]interface{}{"fileId": "file-2.png"}},
+		}, nil)
+
+		destPath := func(fileID string) string { return filepath.Join("team-id", "personal-id", fileID) }
+		fileBackend.On("FileExists", filepath.Join(model.GlobalTeamID, "personal-id", "file-1.png")).Return(true, nil)
+		fileBackend.On("MoveFile", filepath.Join(model.GlobalTeamID, "personal-id", "file-1.png"), destPath("file-1.png")).Return(nil)
+		// the second file is still in the folder of the older versions
+		fileBackend.On("FileExists", filepath.Join(model.GlobalTeamID, "personal-id", "file-2.png")).Return(false, nil)
+		fileBackend.On("FileExists", "file-2.png").Return(true, nil)
+		fileBackend.On("MoveFile", "file-2.png", destPath("file-2.png")).Return(nil)
+
+		report, err := th.App.MigratePersonalBoards(&model.PersonalBoardsMigrationRequest{TeamID: "team-id"})
+		require.NoError(t, err)
+		require.Equal(t, 1, report.Migrated)
+		require.Equal(t, 1, report.Failed)
+		require.Len(t, report.Boards, 3)
+
+		require.Equal(t, model.PersonalBoardMigrated, report.Boards[0].Status)
+		require.Equal(t, "Personal", report.Boards[0].Title)
+		require.Equal(t, 2, report.Boards[0].MovedFiles)
+		require.Equal(t, model.PersonalBoardSkipped, report.Boards[1].Status)
+		require.Equal(t, model.PersonalBoardFailed, report.Boards[2].Status)
+		require.Equal(t, "database error", report.Boards[2].Error)
+	})
+}
@@ -0,0 +1,201 @@
+package app
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	propertyIndexQueueSize = 100
+
+	// maxIndexedProperties caps the number of card properties with an
+	// index, as each index slows down the writes of the blocks. The boards
+	// created from the same template share the indexes of their properties.
+	maxIndexedProperties = 50
+)
+
+var errTooManyPropertyIndexes = errors.New("too many indexed card properties")
+
+// GetCardsWithPropertyValues returns the cards of the board whose property
+// takes one of the values. The first time the cards of a board are
+// filtered by a property, the property gets indexed in the background, so
+// that the next filters don't scan the cards of the board.
+func (a *App) GetCardsWithPropertyValues(boardID, propertyID string, values []string) ([]model.Block, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	property, ok := schema[propertyID]
+	if !ok {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "the board has no such card property", map[string]interface{}{"propertyId": propertyID})
+	}
+	if !model.IsIndexablePropertyType(property.Type) {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "the cards can't be filtered by properties of this type", map[string]interface{}{"type": property.Type})
+	}
+
+	indexed, err := isPropertyIndexed(a.store, boardID, propertyID)
+	switch {
+	case err != nil:
+		// the cards can still be filtered without the index
+		a.logger.Warn("cannot check the index of the card property",
+			mlog.String("boardID", boardID),
+			mlog.String("propertyID", propertyID),
+			mlog.Err(err),
+		)
+	case !indexed:
+		a.propertyIndexer.request(boardID, propertyID)
+	}
+
+	return a.store.GetCardsWithPropertyValues(boardID, propertyID, values)
+}
+
+func isPropertyIndexed(store store.Store, boardID, propertyID string) (bool, error) {
+	indexes, err := store.GetPropertyIndexes(boardID)
+	if err != nil {
+		return false, err
+	}
+	for _, index := range indexes {
+		if index.PropertyID == propertyID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type propertyIndexKey struct {
+	boardID    string
+	propertyID string
+}
+
+// propertyIndexer creates the indexes of the card properties in the
+// background, one at a time, so filtering the cards never waits for an
+// index to be built.
+type propertyIndexer struct {
+	store  store.Store
+	logger *mlog.Logger
+
+	queue   chan propertyIndexKey
+	mu      sync.Mutex
+	pending map[propertyIndexKey]bool
+
+	done     chan struct{}
+	finished chan struct{}
+	stopOnce sync.Once
+}
+
+func newPropertyIndexer(store store.Store, logger *mlog.Logger) *propertyIndexer {
+	x := &propertyIndexer{
+		store:    store,
+		logger:   logger,
+		queue:    make(chan propertyIndexKey, propertyIndexQueueSize),
+		pending:  map[propertyIndexKey]bool{},
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	go x.loop()
+	return x
+}
+
+// request queues the index of the card property of the board. The request
+// is dropped if the index is already queued or the queue is full; the
+// property is requested again the next time the cards are filtered by it.
+func (x *propertyIndexer) request(boardID, propertyID string) {
+	key := propertyIndexKey{boardID: boardID, propertyID: propertyID}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.pending[key] {
+		return
+	}
+	select {
+	case x.queue <- key:
+		x.pending[key] = true
+	default:
+		x.logger.Debug("property index queue full, request dropped",
+			mlog.String("boardID", boardID),
+			mlog.String("propertyID", propertyID),
+		)
+	}
+}
+
+// index creates the index of the card property of the board, unless it
+// exists or too many properties are indexed already.
+func (x *propertyIndexer) index(key propertyIndexKey) error {
+	indexed, err := isPropertyIndexed(x.store, key.boardID, key.propertyID)
+	if err != nil || indexed {
+		return err
+	}
+
+	propertyIDs, err := x.store.GetIndexedPropertyIDs()
+	if err != nil {
+		return err
+	}
+	shared := false
+	for _, propertyID := range propertyIDs {
+		if propertyID == key.propertyID {
+			shared = true
+			break
+		}
+	}
+	if !shared && len(propertyIDs) >= maxIndexedProperties {
+		return errTooManyPropertyIndexes
+	}
+
+	return x.store.CreatePropertyIndex(key.boardID, key.propertyID)
+}
+
+func (x *propertyIndexer) loop() {
+	defer close(x.finished)
+
+	for {
+		select {
+		case key := <-x.queue:
+			if err := x.index(key); err != nil {
+				x.logger.Warn("cannot index card property",
+					mlog.String("boardID", key.boardID),
+					mlog.String("propertyID", key.propertyID),
+					mlog.Err(err),
+				)
+			}
+			x.mu.Lock()
+			delete(x.pending, key)
+			x.mu.Unlock()
+		case <-x.done:
+			return
+		}
+	}
+}
+
+// shutdown stops the indexer once the index being created, if any, is
+// done. The queued requests are dropped.
+func (x *propertyIndexer) shutdown() {
+	x.stopOnce.Do(func() { close(x.done) })
+	<-x.finished
+}
+
+// deletePropertyIndexes removes the indexes of the deleted card
+// properties of the board.
+func (a *App) deletePropertyIndexes(boardID string, propertyIDs []string) {
+	for _, propertyID := range propertyIDs {
+		if err := a.store.DeletePropertyIndex(boardID, propertyID); err != nil {
+			a.logger.Error("cannot delete the index of a card property",
+				mlog.String("boardID", boardID),
+				mlog.String("propertyID", propertyID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
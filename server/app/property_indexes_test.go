@@ -0,0 +1,107 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCardsWithPropertyValues(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: "board-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "status-id", "name": "Status", "type": "select"},
+			{"id": "tags-id", "name": "Tags", "type": "multiSelect"},
+		},
+	}
+	cards := []model.Block{{ID: "card-id", BoardID: "board-id", Type: model.TypeCard}}
+
+	t.Run("the first filter indexes the property in the background", func(t *testing.T) {
+		created := make(chan struct{})
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetPropertyIndexes("board-id").Return([]*model.PropertyIndex{}, nil).Times(2)
+		th.Store.EXPECT().GetIndexedPropertyIDs().Return([]string{}, nil)
+		th.Store.EXPECT().CreatePropertyIndex("board-id", "status-id").Do(func(string, string) { close(created) }).Return(nil)
+		th.Store.EXPECT().GetCardsWithPropertyValues("board-id", "status-id", []string{"todo"}).Return(cards, nil)
+
+		result, err := th.App.GetCardsWithPropertyValues("board-id", "status-id", []string{"todo"})
+		require.NoError(t, err)
+		require.Equal(t, cards, result)
+
+		select {
+		case <-created:
+		case <-time.After(time.Second):
+			require.Fail(t, "the property wasn't indexed")
+		}
+	})
+
+	t.Run("the cards are filtered if the indexes can't be checked", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetPropertyIndexes("board-id").Return(nil, errors.New("cannot get indexes"))
+		th.Store.EXPECT().GetCardsWithPropertyValues("board-id", "status-id", []string{"todo"}).Return(cards, nil)
+
+		result, err := th.App.GetCardsWithPropertyValues("board-id", "status-id", []string{"todo"})
+		require.NoError(t, err)
+		require.Equal(t, cards, result)
+	})
+
+	t.Run("an indexed property", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetPropertyIndexes("board-id").Return([]*model.PropertyIndex{{BoardID: "board-id", PropertyID: "status-id"}}, nil)
+		th.Store.EXPECT().GetCardsWithPropertyValues("board-id", "status-id", []string{"todo"}).Return(cards, nil)
+
+		_, err := th.App.GetCardsWithPropertyValues("board-id", "status-id", []string{"todo"})
+		require.NoError(t, err)
+	})
+
+	t.Run("properties that can't be filtered", func(t *testing.T) {
+		for _, propertyID := range []string{"missing-id", "tags-id"} {
+			th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+
+			_, err := th.App.GetCardsWithPropertyValues("board-id", propertyID, []string{"todo"})
+			ce, ok := model.AsCodedError(err)
+			require.True(t, ok)
+			require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+		}
+	})
+}
+
+func TestPropertyIndexer(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	key := propertyIndexKey{boardID: "board-id", propertyID: "status-id"}
+	tooMany := make([]string, maxIndexedProperties)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("property-%d", i)
+	}
+
+	t.Run("the number of indexed properties is capped", func(t *testing.T) {
+		th.Store.EXPECT().GetPropertyIndexes("board-id").Return([]*model.PropertyIndex{}, nil)
+		th.Store.EXPECT().GetIndexedPropertyIDs().Return(tooMany, nil)
+
+		err := th.App.propertyIndexer.index(key)
+		require.ErrorIs(t, err, errTooManyPropertyIndexes)
+	})
+
+	t.Run("the indexes of other boards are shared past the cap", func(t *testing.T) {
+		th.Store.EXPECT().GetPropertyIndexes("board-id").Return([]*model.PropertyIndex{}, nil)
+		th.Store.EXPECT().GetIndexedPropertyIDs().Return(append(tooMany[1:], "status-id"), nil)
+		th.Store.EXPECT().CreatePropertyIndex("board-id", "status-id").Return(nil)
+
+		require.NoError(t, th.App.propertyIndexer.index(key))
+	})
+
+	t.Run("indexed properties", func(t *testing.T) {
+		th.Store.EXPECT().GetPropertyIndexes("board-id").Return([]*model.PropertyIndex{{BoardID: "board-id", PropertyID: "status-id"}}, nil)
+
+		require.NoError(t, th.App.propertyIndexer.index(key))
+	})
+}
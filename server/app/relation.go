@@ -0,0 +1,83 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// checkRelationProperties checks the relation properties of the cards of
+// the board: their values must be lists of cards, and the cards added to
+// them must be cards of the target boards of the properties. The old cards
+// are matched by index, and are nil for new cards.
+func (a *App) checkRelationProperties(board *model.Board, cards []model.Block, oldCards []*model.Block) error {
+	if board == nil {
+		return nil
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil || !schema.HasRelationProperty() {
+		return nil
+	}
+
+	for i := range cards {
+		if cards[i].Type != model.TypeCard {
+			continue
+		}
+		var oldCard *model.Block
+		if i < len(oldCards) {
+			oldCard = oldCards[i]
+		}
+
+		added, err := model.AddedRelationCards(&cards[i], oldCard, schema)
+		if err != nil {
+			return err
+		}
+		for targetBoardID, cardIDs := range added {
+			related, err := a.store.GetBlocksByIDs(cardIDs)
+			if err != nil {
+				return err
+			}
+			found := map[string]bool{}
+			for _, card := range related {
+				if card.Type == model.TypeCard && card.BoardID == targetBoardID {
+					found[card.ID] = true
+				}
+			}
+			for _, cardID := range cardIDs {
+				if !found[cardID] {
+					return model.NewCodedError(model.ErrCodeBadRequest, "the cards of a relation property must be cards of its target board",
+						map[string]interface{}{"blockId": cards[i].ID, "cardId": cardID, "targetBoardId": targetBoardID})
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveCardReferences returns the titles of the cards of the board, in
+// the order of the IDs. The IDs that aren't cards of the board are
+// skipped.
+func (a *App) ResolveCardReferences(boardID string, cardIDs []string) ([]model.CardLink, error) {
+	if len(cardIDs) > model.MaxCardReferences {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "too many cards to resolve",
+			map[string]interface{}{"max": model.MaxCardReferences})
+	}
+
+	blocks, err := a.store.GetBlocksByIDs(cardIDs)
+	if err != nil {
+		return nil, err
+	}
+	cards := make(map[string]*model.Block, len(blocks))
+	for i := range blocks {
+		if blocks[i].Type == model.TypeCard && blocks[i].BoardID == boardID {
+			cards[blocks[i].ID] = &blocks[i]
+		}
+	}
+
+	references := []model.CardLink{}
+	for _, cardID := range cardIDs {
+		if card, ok := cards[cardID]; ok {
+			references = append(references, model.NewCardLink(card))
+			delete(cards, cardID)
+		}
+	}
+	return references, nil
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRelationProperties(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     testBoardID,
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "tasks", "name": "Tasks", "type": "relation", "targetBoardId": "tasks-board"},
+		},
+	}
+	card := model.Block{
+		ID:      "card-id",
+		BoardID: board.ID,
+		Type:    model.TypeCard,
+		Fields:  map[string]interface{}{"properties": map[string]interface{}{"tasks": []interface{}{"task-1", "task-2"}}},
+	}
+
+	t.Run("cards of the target board can be linked", func(t *testing.T) {
+		th.Store.EXPECT().GetBlocksByIDs([]string{"task-1", "task-2"}).Return([]model.Block{
+			{ID: "task-1", BoardID: "tasks-board", Type: model.TypeCard},
+			{ID: "task-2", BoardID: "tasks-board", Type: model.TypeCard},
+		}, nil)
+		require.NoError(t, th.App.checkRelationProperties(board, []model.Block{card}, nil))
+	})
+
+	t.Run("blocks that aren't cards of the target board are rejected", func(t *testing.T) {
+		th.Store.EXPECT().GetBlocksByIDs([]string{"task-1", "task-2"}).Return([]model.Block{
+			{ID: "task-1", BoardID: "tasks-board", Type: model.TypeCard},
+			{ID: "task-2", BoardID: "other-board", Type: model.TypeCard},
+		}, nil)
+		err := th.App.checkRelationProperties(board, []model.Block{card}, nil)
+		require.True(t, model.IsErrBadRequest(err))
+
+		th.Store.EXPECT().GetBlocksByIDs([]string{"task-1", "task-2"}).Return([]model.Block{
+			{ID: "task-1", BoardID: "tasks-board", Type: model.TypeCard},
+			{ID: "task-2", BoardID: "tasks-board", Type: model.TypeText},
+		}, nil)
+		err = th.App.checkRelationProperties(board, []model.Block{card}, nil)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("cards already linked are not checked again", func(t *testing.T) {
+		oldCard := card
+		require.NoError(t, th.App.checkRelationProperties(board, []model.Block{card}, []*model.Block{&oldCard}))
+	})
+}
+
+func TestResolveCardReferences(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("the cards of the board are resolved in order", func(t *testing.T) {
+		th.Store.EXPECT().GetBlocksByIDs([]string{"card-2", "card-1", "other-card", "text"}).Return([]model.Block{
+			{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard, Title: "Card 1", Fields: map[string]interface{}{"icon": "🚀"}},
+			{ID: "card-2", BoardID: testBoardID, Type: model.TypeCard, Title: "Card 2"},
+			{ID: "other-card", BoardID: "other-board", Type: model.TypeCard, Title: "Other card"},
+			{ID: "text", BoardID: testBoardID, Type: model.TypeText, Title: "Text"},
+		}, nil)
+
+		references, err := th.App.ResolveCardReferences(testBoardID, []string{"card-2", "card-1", "other-card", "text"})
+		require.NoError(t, err)
+		require.Equal(t, []model.CardLink{
+			{ID: "card-2", BoardID: testBoardID, Title: "Card 2"},
+			{ID: "card-1", BoardID: testBoardID, Title: "Card 1", Icon: "🚀"},
+		}, references)
+	})
+
+	t.Run("too many cards are rejected", func(t *testing.T) {
+		cardIDs := make([]string, model.MaxCardReferences+1)
+		_, err := th.App.ResolveCardReferences(testBoardID, cardIDs)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	remoteCopyTimeout = 5 * time.Minute
+
+	// remoteCopyMaxErrorSize is the size of the body of an error
+	// response of the other server included in the error.
+	remoteCopyMaxErrorSize = 1024
+)
+
+// ErrRemoteCopyFailed is returned when the other server doesn't accept
+// the copy of a board.
+var ErrRemoteCopyFailed = errors.New("remote copy failed")
+
+// CopyBoardToRemote exports a board with its files and imports it into a
+// team of another server, through its archive import API. The board gets
+// new IDs on the other server; the ID of the copy is returned.
+func (a *App) CopyBoardToRemote(boardID string, request *model.RemoteBoardCopyRequest) (*model.RemoteBoardCopyResult, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", board.ID+".boardarchive")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := model.ExportArchiveOptions{
+		TeamID:   board.TeamID,
+		BoardIDs: []string{board.ID},
+	}
+	if err = a.ExportArchive(part, opts); err != nil {
+		return nil, fmt.Errorf("cannot export board %s: %w", board.ID, err)
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	serverURL := strings.TrimRight(request.ServerURL, "/")
+	importURL := fmt.Sprintf("%s/api/v2/teams/%s/archive/import", serverURL, request.TeamID)
+	req, err := http.NewRequest(http.MethodPost, importURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+request.AccessToken)
+	req.Header.Set("X-Requested-With", "XMLHttpRequest")
+
+	client := &http.Client{Timeout: remoteCopyTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteCopyFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, remoteCopyMaxErrorSize))
+		return nil, fmt.Errorf("%w: %s returned %d: %s", ErrRemoteCopyFailed, serverURL, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var result model.ImportArchiveResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: invalid response: %s", ErrRemoteCopyFailed, err)
+	}
+
+	remoteBoardID, ok := result.BoardIDs[board.ID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s didn't return the ID of the copy", ErrRemoteCopyFailed, serverURL)
+	}
+
+	a.logger.Info("Board copied to a remote server",
+		mlog.String("board_id", board.ID),
+		mlog.String("server_url", serverURL),
+		mlog.String("remote_team_id", request.TeamID),
+		mlog.String("remote_board_id", remoteBoardID),
+	)
+
+	return &model.RemoteBoardCopyResult{
+		ServerURL: serverURL,
+		TeamID:    request.TeamID,
+		BoardID:   remoteBoardID,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyBoardToRemote(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board-id", TeamID: "team-id", Title: "board"}
+	request := &model.RemoteBoardCopyRequest{AccessToken: "token", TeamID: "remote-team-id"}
+
+	t.Run("the board is imported on the other server", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/v2/teams/remote-team-id/archive/import", r.URL.Path)
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+			file, _, err := r.FormFile("file")
+			require.NoError(t, err)
+			file.Close()
+
+			_ = json.NewEncoder(w).Encode(model.ImportArchiveResult{BoardIDs: map[string]string{"board-id": "remote-board-id"}})
+		}))
+		defer server.Close()
+
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil).Times(2)
+		th.Store.EXPECT().GetBlocksWithBoardID("board-id").Return([]model.Block{}, nil)
+
+		request.ServerURL = server.URL + "/"
+		result, err := th.App.CopyBoardToRemote("board-id", request)
+		require.NoError(t, err)
+		require.Equal(t, "remote-board-id", result.BoardID)
+		require.Equal(t, server.URL, result.ServerURL)
+		require.Equal(t, "remote-team-id", result.TeamID)
+	})
+
+	t.Run("the errors of the other server are returned", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"access denied"}`))
+		}))
+		defer server.Close()
+
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil).Times(2)
+		th.Store.EXPECT().GetBlocksWithBoardID("board-id").Return([]model.Block{}, nil)
+
+		request.ServerURL = server.URL
+		_, err := th.App.CopyBoardToRemote("board-id", request)
+		require.ErrorIs(t, err, ErrRemoteCopyFailed)
+		require.Contains(t, err.Error(), "access denied")
+	})
+}
@@ -0,0 +1,197 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// maxStaleDigestCardsPerBoard is the number of cards of each board listed
+// in the stale cards digest, the least recently updated first.
+const maxStaleDigestCardsPerBoard = 10
+
+// GetStaleCards returns the cards of the board that weren't updated for
+// the days of the query, the least recently updated first. The cards with
+// one of the excluded property values are left out; the exclusions of the
+// board apply if the query has none.
+func (a *App) GetStaleCards(boardID string, query model.StaleCardsQuery) ([]*model.StaleCard, error) {
+	if err := query.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	exclusions := model.StaleCardExclusionsFromBoard(board)
+	if len(query.Exclude) > 0 {
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			return nil, err
+		}
+		if exclusions, err = model.ParseStaleCardExclusions(schema, query.Exclude); err != nil {
+			return nil, err
+		}
+	}
+
+	return a.getStaleCards(board, query.Days, exclusions, time.Now())
+}
+
+func (a *App) getStaleCards(board *model.Board, days int, exclusions model.StaleCardExclusions, now time.Time) ([]*model.StaleCard, error) {
+	before := utils.GetMillisForTime(now.AddDate(0, 0, -days))
+	cards, err := a.store.GetCardsUpdatedBefore(board.ID, before)
+	if err != nil {
+		return nil, err
+	}
+
+	stale := make([]*model.StaleCard, 0, len(cards))
+	for i := range cards {
+		if isTemplate, _ := cards[i].Fields["isTemplate"].(bool); isTemplate {
+			continue
+		}
+		if exclusions.Excludes(&cards[i]) {
+			continue
+		}
+		stale = append(stale, model.NewStaleCard(&cards[i], now))
+	}
+	return stale, nil
+}
+
+// GetStaleDigestSettings returns the stale cards digest settings of the
+// user, or the default ones, disabled, if the user never changed them.
+func (a *App) GetStaleDigestSettings(userID string) (*model.StaleDigestSettings, error) {
+	settings, err := a.store.GetStaleDigestSettings(userID)
+	if err != nil {
+		if a.store.IsErrNotFound(err) {
+			return model.NewStaleDigestSettings(userID), nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateStaleDigestSettings enables or disables the stale cards digest of
+// the user and sets when it is sent and after how many days a card is
+// stale.
+func (a *App) UpdateStaleDigestSettings(settings *model.StaleDigestSettings) (*model.StaleDigestSettings, error) {
+	if err := settings.IsValid(); err != nil {
+		return nil, err
+	}
+
+	current, err := a.GetStaleDigestSettings(settings.UserID)
+	if err != nil {
+		return nil, err
+	}
+	current.Enabled = settings.Enabled
+	current.Days = settings.Days
+	current.Weekday = settings.Weekday
+	current.Hour = settings.Hour
+
+	if err := a.store.SaveStaleDigestSettings(current); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// SendStaleDigests sends their stale cards digest to the users whose day
+// and hour have come and that didn't get it yet this week. It is meant to
+// run periodically.
+func (a *App) SendStaleDigests() {
+	if a.notifications == nil {
+		return
+	}
+
+	allSettings, err := a.store.GetEnabledStaleDigestSettings()
+	if err != nil {
+		a.logger.Error("cannot get the stale digest settings", mlog.Err(err))
+		return
+	}
+
+	now := time.Now()
+	for _, settings := range allSettings {
+		user, err := a.store.GetUserByID(settings.UserID)
+		if err != nil {
+			a.logger.Warn("cannot get the user of a stale digest", mlog.String("userID", settings.UserID), mlog.Err(err))
+			continue
+		}
+		if user.DeleteAt != 0 {
+			continue
+		}
+
+		if !settings.IsDue(now, user.Location()) {
+			continue
+		}
+
+		boards, err := a.getStaleDigestBoards(settings.UserID, settings.Days, now)
+		if err != nil {
+			a.logger.Error("cannot get the boards of a stale digest", mlog.String("userID", settings.UserID), mlog.Err(err))
+			continue
+		}
+
+		a.notifications.SendStaleDigest(notify.StaleDigestEvent{
+			UserID: settings.UserID,
+			Days:   settings.Days,
+			Boards: boards,
+		})
+
+		// the digest is marked as sent even when empty, so the cards
+		// aren't looked up again until next week
+		settings.LastSentAt = utils.GetMillisForTime(now)
+		if err := a.store.SaveStaleDigestSettings(settings); err != nil {
+			a.logger.Error("cannot save the stale digest settings", mlog.String("userID", settings.UserID), mlog.Err(err))
+		}
+	}
+}
+
+// getStaleDigestBoards returns the boards the user administers that have
+// stale cards, with the exclusions of each board applied.
+func (a *App) getStaleDigestBoards(userID string, days int, now time.Time) ([]*model.StaleDigestBoard, error) {
+	members, err := a.store.GetMembersForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*model.StaleDigestBoard{}
+	for _, member := range members {
+		if !member.SchemeAdmin {
+			continue
+		}
+		board, err := a.store.GetBoard(member.BoardID)
+		if err != nil {
+			a.logger.Warn("getStaleDigestBoards cannot get board", mlog.String("boardID", member.BoardID), mlog.Err(err))
+			continue
+		}
+		if board.IsTemplate || board.DeleteAt != 0 {
+			continue
+		}
+
+		cards, err := a.getStaleCards(board, days, model.StaleCardExclusionsFromBoard(board), now)
+		if err != nil {
+			return nil, err
+		}
+		if len(cards) == 0 {
+			continue
+		}
+
+		digestBoard := &model.StaleDigestBoard{
+			BoardID:    board.ID,
+			BoardTitle: board.Title,
+			TeamID:     board.TeamID,
+			Cards:      cards,
+			Total:      len(cards),
+		}
+		if len(cards) > maxStaleDigestCardsPerBoard {
+			digestBoard.Cards = cards[:maxStaleDigestCardsPerBoard]
+		}
+		result = append(result, digestBoard)
+	}
+	return result, nil
+}
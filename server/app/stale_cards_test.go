@@ -0,0 +1,119 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestGetStaleCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     testBoardID,
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status-id",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "done-id", "value": "Done"},
+					map[string]interface{}{"id": "doing-id", "value": "Doing"},
+				},
+			},
+		},
+		Properties: map[string]interface{}{
+			model.BoardPropertyStaleCardExclusions: map[string]interface{}{"status-id": []interface{}{"done-id"}},
+		},
+	}
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+
+	lastMonth := utils.GetMillisForTime(time.Now().AddDate(0, -1, 0))
+	cardWithStatus := func(id, status string) model.Block {
+		return model.Block{
+			ID:       id,
+			BoardID:  testBoardID,
+			Type:     model.TypeCard,
+			UpdateAt: lastMonth,
+			Fields:   map[string]interface{}{"properties": map[string]interface{}{"status-id": status}},
+		}
+	}
+	template := cardWithStatus("template", "")
+	template.Fields["isTemplate"] = true
+	cards := []model.Block{
+		cardWithStatus("card-done", "done-id"),
+		cardWithStatus("card-doing", "doing-id"),
+		template,
+	}
+
+	t.Run("the exclusions of the board apply by default", func(t *testing.T) {
+		th.Store.EXPECT().GetCardsUpdatedBefore(testBoardID, gomock.Any()).Return(cards, nil)
+
+		stale, err := th.App.GetStaleCards(testBoardID, model.StaleCardsQuery{Days: 14})
+		require.NoError(t, err)
+		require.Len(t, stale, 1)
+		require.Equal(t, "card-doing", stale[0].CardID)
+		require.GreaterOrEqual(t, stale[0].DaysStale, 28)
+	})
+
+	t.Run("the exclusions of the query replace the ones of the board", func(t *testing.T) {
+		th.Store.EXPECT().GetCardsUpdatedBefore(testBoardID, gomock.Any()).Return(cards, nil)
+
+		stale, err := th.App.GetStaleCards(testBoardID, model.StaleCardsQuery{Days: 14, Exclude: []string{"Status:Doing"}})
+		require.NoError(t, err)
+		require.Len(t, stale, 1)
+		require.Equal(t, "card-done", stale[0].CardID)
+	})
+
+	t.Run("invalid exclusion", func(t *testing.T) {
+		_, err := th.App.GetStaleCards(testBoardID, model.StaleCardsQuery{Days: 14, Exclude: []string{"Priority:High"}})
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+	})
+
+	t.Run("invalid days", func(t *testing.T) {
+		_, err := th.App.GetStaleCards(testBoardID, model.StaleCardsQuery{Days: 0})
+		require.Error(t, err)
+	})
+}
+
+func TestGetStaleDigestBoards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Now()
+	staleCards := make([]model.Block, maxStaleDigestCardsPerBoard+2)
+	for i := range staleCards {
+		staleCards[i] = model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  "board-id-1",
+			Type:     model.TypeCard,
+			UpdateAt: utils.GetMillisForTime(now.AddDate(0, 0, -30)),
+		}
+	}
+
+	th.Store.EXPECT().GetMembersForUser("user-id").Return([]*model.BoardMember{
+		{BoardID: "board-id-1", UserID: "user-id", SchemeAdmin: true},
+		{BoardID: "board-id-2", UserID: "user-id", SchemeAdmin: true},
+		{BoardID: "board-id-3", UserID: "user-id", SchemeEditor: true},
+	}, nil)
+	th.Store.EXPECT().GetBoard("board-id-1").Return(&model.Board{ID: "board-id-1", TeamID: "team-id", Title: "Roadmap"}, nil)
+	th.Store.EXPECT().GetBoard("board-id-2").Return(&model.Board{ID: "board-id-2", TeamID: "team-id"}, nil)
+	th.Store.EXPECT().GetCardsUpdatedBefore("board-id-1", utils.GetMillisForTime(now.AddDate(0, 0, -14))).Return(staleCards, nil)
+	th.Store.EXPECT().GetCardsUpdatedBefore("board-id-2", gomock.Any()).Return([]model.Block{}, nil)
+
+	boards, err := th.App.getStaleDigestBoards("user-id", 14, now)
+	require.NoError(t, err)
+	require.Len(t, boards, 1)
+	require.Equal(t, "Roadmap", boards[0].BoardTitle)
+	require.Len(t, boards[0].Cards, maxStaleDigestCardsPerBoard)
+	require.Equal(t, maxStaleDigestCardsPerBoard+2, boards[0].Total)
+}
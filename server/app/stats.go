@@ -0,0 +1,66 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/ws"
+)
+
+// GetServerStats returns the server-wide counts used by administrators
+// to monitor the health of the server.
+func (a *App) GetServerStats() (*model.ServerStats, error) {
+	teamCount, err := a.store.GetTeamCount()
+	if err != nil {
+		return nil, err
+	}
+
+	boardCount, err := a.store.GetBoardCount()
+	if err != nil {
+		return nil, err
+	}
+
+	blockCounts, err := a.store.GetBlockCountsByType()
+	if err != nil {
+		return nil, err
+	}
+
+	userCount, err := a.store.GetRegisteredUserCount()
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := a.store.GetTableStats()
+	if err != nil {
+		return nil, err
+	}
+
+	historyByTeam, err := a.store.GetHistoryStatsByTeam()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &model.ServerStats{
+		TeamCount:              teamCount,
+		BoardCount:             boardCount,
+		BlockCountsByType:      blockCounts,
+		UserCount:              userCount,
+		NotificationQueueDepth: a.blockChangeNotifier.Len(),
+		Tables:                 tables,
+		HistoryByTeam:          historyByTeam,
+	}
+
+	for _, count := range blockCounts {
+		stats.BlockCount += count
+	}
+
+	for _, table := range tables {
+		if table.Name == "notification_hints" {
+			stats.PendingNotificationHints = table.Rows
+		}
+	}
+
+	if counter, ok := a.wsAdapter.(ws.ConnectionCounter); ok {
+		stats.WebSocketConnections = counter.ConnectionCount()
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetServerStats(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().GetTeamCount().Return(int64(2), nil)
+	th.Store.EXPECT().GetBoardCount().Return(int64(3), nil)
+	th.Store.EXPECT().GetBlockCountsByType().Return(map[string]int64{
+		model.TypeCard: 10,
+		model.TypeText: 5,
+	}, nil)
+	th.Store.EXPECT().GetRegisteredUserCount().Return(4, nil)
+	th.Store.EXPECT().GetTableStats().Return([]*model.TableStats{
+		{Name: "blocks", Rows: 15, SizeBytes: 8192},
+		{Name: "notification_hints", Rows: 7},
+	}, nil)
+	th.Store.EXPECT().GetHistoryStatsByTeam().Return([]*model.TeamHistoryStats{
+		{TeamID: "team-id", BoardsHistoryRows: 3, BlocksHistoryRows: 30},
+	}, nil)
+
+	stats, err := th.App.GetServerStats()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats.TeamCount)
+	require.Equal(t, int64(3), stats.BoardCount)
+	require.Equal(t, int64(15), stats.BlockCount)
+	require.Equal(t, 4, stats.UserCount)
+	require.Equal(t, int64(7), stats.PendingNotificationHints)
+	require.Equal(t, 0, stats.WebSocketConnections)
+	require.Len(t, stats.Tables, 2)
+	require.Len(t, stats.HistoryByTeam, 1)
+	require.Equal(t, int64(30), stats.HistoryByTeam[0].BlocksHistoryRows)
+}
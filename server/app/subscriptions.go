@@ -31,8 +31,8 @@ func (a *App) DeleteSubscription(blockID string, subscriberID string) (*model.Su
 	return sub, nil
 }
 
-func (a *App) GetSubscriptions(subscriberID string) ([]*model.Subscription, error) {
-	return a.store.GetSubscriptions(subscriberID)
+func (a *App) GetSubscriptions(subscriberID string, opts model.QuerySubscriptionsOptions) ([]*model.Subscription, error) {
+	return a.store.GetSubscriptions(subscriberID, opts)
 }
 
 func (a *App) notifySubscriptionChanged(subscription *model.Subscription) {
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetSwimlanes groups the cards of the board by two select or person
+// properties at once, the rows and the columns of a swimlane board view.
+// The rows and columns of a select property follow the order of its
+// options, like the groups of GetCardGroups.
+func (a *App) GetSwimlanes(boardID, rowPropertyID, columnPropertyID string) (*model.SwimlaneMatrix, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	if rowPropertyID == columnPropertyID {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "the rows and columns must be grouped by different properties", map[string]interface{}{"propertyId": rowPropertyID})
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	rowProperty, err := groupingProperty(schema, rowPropertyID)
+	if err != nil {
+		return nil, err
+	}
+	columnProperty, err := groupingProperty(schema, columnPropertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	cells, err := a.store.GetSwimlaneCells(boardID, rowPropertyID, columnPropertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	rowValues := map[string]bool{}
+	columnValues := map[string]bool{}
+	for _, cell := range cells {
+		rowValues[cell.Row] = true
+		columnValues[cell.Column] = true
+	}
+
+	return model.NewSwimlaneMatrix(
+		rowPropertyID,
+		columnPropertyID,
+		orderGroupValues(rowProperty, rowValues),
+		orderGroupValues(columnProperty, columnValues),
+		cells,
+	), nil
+}
+
+// orderGroupValues returns the values of the property the cards take in
+// the order of the groups of GetCardGroups. The values of person
+// properties are sorted, after the empty value.
+func orderGroupValues(property model.PropDef, values map[string]bool) []string {
+	groups := make([]*model.CardGroup, 0, len(values))
+	for value := range values {
+		groups = append(groups, &model.CardGroup{Value: value})
+	}
+
+	if property.Type == "select" {
+		groups = orderCardGroups(property, groups, false)
+	} else {
+		sort.Slice(groups, func(i, j int) bool { return groups[i].Value < groups[j].Value })
+	}
+
+	ordered := make([]string, 0, len(groups))
+	for _, group := range groups {
+		ordered = append(ordered, group.Value)
+	}
+	return ordered
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSwimlanes(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: "board-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "status-id", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To do"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+			{"id": "assignee-id", "name": "Assignee", "type": "person"},
+			{"id": "notes-id", "name": "Notes", "type": "text"},
+		},
+	}
+	th.Store.EXPECT().GetBoard("board-id").Return(board, nil).AnyTimes()
+
+	t.Run("rows and columns follow the order of the groups", func(t *testing.T) {
+		th.Store.EXPECT().GetSwimlaneCells("board-id", "assignee-id", "status-id").Return([]*model.SwimlaneCell{
+			{Row: "user-2", Column: "done", Count: 1, CardIDs: []string{"card-1"}},
+			{Row: "user-1", Column: "todo", Count: 2, CardIDs: []string{"card-2", "card-3"}},
+			{Row: "", Column: "removed", Count: 1, CardIDs: []string{"card-4"}},
+		}, nil)
+
+		matrix, err := th.App.GetSwimlanes("board-id", "assignee-id", "status-id")
+		require.NoError(t, err)
+
+		rows := []string{}
+		for _, row := range matrix.Rows {
+			rows = append(rows, row.Value)
+		}
+		require.Equal(t, []string{"", "user-1", "user-2"}, rows)
+
+		columns := []string{}
+		for _, column := range matrix.Columns {
+			columns = append(columns, column.Value)
+		}
+		require.Equal(t, []string{"", "todo", "done", "removed"}, columns, "empty options are included, values that are not options come last")
+
+		require.Equal(t, []string{"card-2", "card-3"}, matrix.Cells[1][1].CardIDs)
+		require.Equal(t, []string{"card-4"}, matrix.Cells[0][3].CardIDs)
+		require.Equal(t, int64(2), matrix.Columns[1].Count)
+	})
+
+	t.Run("invalid properties", func(t *testing.T) {
+		testCases := [][2]string{
+			{"status-id", "status-id"},
+			{"notes-id", "status-id"},
+			{"assignee-id", "missing-id"},
+		}
+		for _, tc := range testCases {
+			_, err := th.App.GetSwimlanes("board-id", tc[0], tc[1])
+			ce, ok := model.AsCodedError(err)
+			require.True(t, ok, tc)
+			require.Equal(t, model.ErrCodeBadRequest, ce.Code, tc)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package app
+
+import "github.com/mattermost/focalboard/server/model"
+
+// GetBoardChangesSince returns the changes of a board since a time, with
+// the IDs of the deleted blocks, for the clients that keep a copy of
+// the board.
+func (a *App) GetBoardChangesSince(boardID string, since int64) (*model.BoardSync, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil || board.DeleteAt != 0 {
+		return &model.BoardSync{BoardID: boardID, Blocks: []model.Block{}, DeletedBlockIDs: []string{}, Removed: true}, nil
+	}
+
+	blocks, err := a.store.GetBlocksChangedSince(boardID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedBlockIDs := []string{}
+	if since != 0 {
+		deletedBlockIDs, err = a.store.GetDeletedBlockIDsSince(boardID, since)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	boardSync := &model.BoardSync{
+		BoardID:         boardID,
+		Blocks:          blocks,
+		DeletedBlockIDs: deletedBlockIDs,
+	}
+	if board.UpdateAt > since {
+		boardSync.Board = board
+	}
+	return boardSync, nil
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBoardChangesSince(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("changed blocks and deletions", func(t *testing.T) {
+		board := &model.Board{ID: "board-id", UpdateAt: 50}
+		blocks := []model.Block{{ID: "block-id", BoardID: "board-id", UpdateAt: 150}}
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetBlocksChangedSince("board-id", int64(100)).Return(blocks, nil)
+		th.Store.EXPECT().GetDeletedBlockIDsSince("board-id", int64(100)).Return([]string{"deleted-id"}, nil)
+
+		boardSync, err := th.App.GetBoardChangesSince("board-id", 100)
+		require.NoError(t, err)
+		require.False(t, boardSync.Removed)
+		require.Nil(t, boardSync.Board, "the board didn't change")
+		require.Equal(t, blocks, boardSync.Blocks)
+		require.Equal(t, []string{"deleted-id"}, boardSync.DeletedBlockIDs)
+	})
+
+	t.Run("the first sync returns the board", func(t *testing.T) {
+		board := &model.Board{ID: "board-id", UpdateAt: 50}
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetBlocksChangedSince("board-id", int64(0)).Return([]model.Block{}, nil)
+
+		boardSync, err := th.App.GetBoardChangesSince("board-id", 0)
+		require.NoError(t, err)
+		require.Equal(t, board, boardSync.Board)
+		require.Empty(t, boardSync.DeletedBlockIDs)
+	})
+
+	t.Run("deleted boards are removed", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(nil, sql.ErrNoRows)
+
+		boardSync, err := th.App.GetBoardChangesSince("board-id", 100)
+		require.NoError(t, err)
+		require.True(t, boardSync.Removed)
+	})
+}
@@ -2,7 +2,10 @@ package app
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/mattermost/focalboard/server/assets"
@@ -13,13 +16,31 @@ import (
 
 const (
 	defaultTemplateVersion = 2
+
+	// BuiltinTemplatesSource is the template source for the templates
+	// embedded in the server.
+	BuiltinTemplatesSource = "builtin"
+
+	archiveExtension = ".boardarchive"
 )
 
+var ErrNotATemplate = errors.New("board is not a template")
+
 func (a *App) InitTemplates() error {
 	_, err := a.initializeTemplates()
 	return err
 }
 
+// ReseedTemplates removes the default templates and imports them again from
+// the sources in the configuration, regardless of their version.
+func (a *App) ReseedTemplates() error {
+	boards, err := a.store.GetTemplateBoards(model.GlobalTeamID, "")
+	if err != nil {
+		return fmt.Errorf("cannot reseed templates: %w", err)
+	}
+	return a.importDefaultTemplates(boards)
+}
+
 // initializeTemplates imports default templates if the boards table is empty.
 func (a *App) initializeTemplates() (bool, error) {
 	boards, err := a.store.GetTemplateBoards(model.GlobalTeamID, "")
@@ -35,28 +56,124 @@ func (a *App) initializeTemplates() (bool, error) {
 		return false, nil
 	}
 
-	a.logger.Debug("Importing new default templates",
-		mlog.String("reason", reason),
-		mlog.Int("size", len(assets.DefaultTemplatesArchive)),
-	)
+	a.logger.Debug("Importing new default templates", mlog.String("reason", reason))
+
+	if err := a.importDefaultTemplates(boards); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// importDefaultTemplates replaces the existing default template boards with
+// the ones from the template sources.
+func (a *App) importDefaultTemplates(boards []*model.Board) error {
+	sources := a.templateSources()
+
+	// the template boards used as sources are read before the old
+	// templates are removed, as they can be among them
+	boardCopies := map[string]*model.BoardsAndBlocks{}
+	for _, source := range sources {
+		if !isBoardTemplateSource(source) {
+			continue
+		}
+		bab, err := a.copyTemplateBoard(source)
+		if err != nil {
+			return fmt.Errorf("cannot initialize global templates for team %s from %s: %w", model.GlobalTeamID, source, err)
+		}
+		boardCopies[source] = bab
+	}
 
 	// Remove in case of newer Templates
-	if err = a.store.RemoveDefaultTemplates(boards); err != nil {
-		return false, fmt.Errorf("cannot remove old template boards: %w", err)
+	if err := a.store.RemoveDefaultTemplates(boards); err != nil {
+		return fmt.Errorf("cannot remove old template boards: %w", err)
+	}
+
+	for _, source := range sources {
+		var err error
+		if bab, ok := boardCopies[source]; ok {
+			_, err = a.store.CreateBoardsAndBlocks(bab, model.SystemUserID)
+		} else {
+			err = a.importTemplateSource(source)
+		}
+		if err != nil {
+			return fmt.Errorf("cannot initialize global templates for team %s from %s: %w", model.GlobalTeamID, source, err)
+		}
+	}
+	return nil
+}
+
+// templateSources returns the configured default template sources, or the
+// built-in templates if none are configured.
+func (a *App) templateSources() []string {
+	if a.config == nil || len(a.config.DefaultTemplates) == 0 {
+		return []string{BuiltinTemplatesSource}
+	}
+	return a.config.DefaultTemplates
+}
+
+// isBoardTemplateSource returns true if a default template source is the
+// ID of a template board to copy, rather than the built-in templates or
+// the path of a board archive.
+func isBoardTemplateSource(source string) bool {
+	return source != BuiltinTemplatesSource &&
+		!strings.HasSuffix(source, archiveExtension) &&
+		!strings.ContainsAny(source, `/\`)
+}
+
+// importTemplateSource imports a default template source that is either
+// the built-in templates or the path of a board archive.
+func (a *App) importTemplateSource(source string) error {
+	if source == BuiltinTemplatesSource {
+		a.logger.Debug("Importing built-in templates", mlog.Int("size", len(assets.DefaultTemplatesArchive)))
+		return a.importTemplateArchive(bytes.NewReader(assets.DefaultTemplatesArchive))
 	}
 
-	r := bytes.NewReader(assets.DefaultTemplatesArchive)
+	f, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return a.importTemplateArchive(f)
+}
 
+// copyTemplateBoard reads a template board and its blocks, and returns
+// them with new IDs as a default template of the current version.
+func (a *App) copyTemplateBoard(boardID string) (*model.BoardsAndBlocks, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if !board.IsTemplate {
+		return nil, fmt.Errorf("board %s: %w", boardID, ErrNotATemplate)
+	}
+
+	blocks, err := a.store.GetBlocksForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	boardCopy := *board
+	boardCopy.TeamID = model.GlobalTeamID
+	boardCopy.ChannelID = ""
+	boardCopy.CreatedBy = model.SystemUserID
+	boardCopy.Type = model.BoardTypeOpen
+	boardCopy.TemplateVersion = defaultTemplateVersion
+
+	bab := &model.BoardsAndBlocks{
+		Boards: []*model.Board{&boardCopy},
+		Blocks: blocks,
+	}
+	return model.GenerateBoardsAndBlocksIDs(bab, a.logger)
+}
+
+func (a *App) importTemplateArchive(r io.Reader) error {
 	opt := model.ImportArchiveOptions{
 		TeamID:        model.GlobalTeamID,
 		ModifiedBy:    model.SystemUserID,
 		BlockModifier: fixTemplateBlock,
 		BoardModifier: fixTemplateBoard,
 	}
-	if err = a.ImportArchive(r, opt); err != nil {
-		return false, fmt.Errorf("cannot initialize global templates for team %s: %w", model.GlobalTeamID, err)
-	}
-	return true, nil
+	return a.ImportArchive(r, opt)
 }
 
 // isInitializationNeeded returns true if the blocks table contains no default templates,
@@ -45,6 +45,7 @@ func TestApp_initializeTemplates(t *testing.T) {
 
 		th.Store.EXPECT().GetTemplateBoards(model.GlobalTeamID, "").Return([]*model.Board{}, nil)
 		th.Store.EXPECT().RemoveDefaultTemplates([]*model.Board{}).Return(nil)
+		th.expectRunInTransaction().AnyTimes()
 		th.Store.EXPECT().CreateBoardsAndBlocks(gomock.Any(), gomock.Any()).AnyTimes().Return(boardsAndBlocks, nil)
 		th.Store.EXPECT().GetMembersForBoard(board.ID).AnyTimes().Return([]*model.BoardMember{}, nil)
 		th.Store.EXPECT().GetBoard(board.ID).AnyTimes().Return(board, nil)
@@ -67,4 +68,85 @@ func TestApp_initializeTemplates(t *testing.T) {
 		require.NoError(t, err, "initializeTemplates should not error")
 		require.False(t, done, "initialization was not needed")
 	})
+	t.Run("Template from board ID", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		// the source is a user template, with no template version
+		source := &model.Board{
+			ID:         utils.NewID(utils.IDTypeBoard),
+			TeamID:     "team-id",
+			Type:       model.BoardTypePrivate,
+			IsTemplate: true,
+			CreatedBy:  "user-id",
+		}
+		th.App.config.DefaultTemplates = []string{source.ID}
+		sourceBlock := block
+		sourceBlock.ParentID = source.ID
+		sourceBlock.BoardID = source.ID
+
+		var created []*model.Board
+		gomock.InOrder(
+			th.Store.EXPECT().GetTemplateBoards(model.GlobalTeamID, "").Return([]*model.Board{}, nil),
+			th.Store.EXPECT().GetBoard(source.ID).Return(source, nil),
+			th.Store.EXPECT().GetBlocksForBoard(source.ID).Return([]model.Block{sourceBlock}, nil),
+			th.Store.EXPECT().RemoveDefaultTemplates([]*model.Board{}).Return(nil),
+			th.Store.EXPECT().CreateBoardsAndBlocks(gomock.Any(), model.SystemUserID).DoAndReturn(func(bab *model.BoardsAndBlocks, _ string) (*model.BoardsAndBlocks, error) {
+				created = bab.Boards
+				return bab, nil
+			}),
+		)
+
+		done, err := th.App.initializeTemplates()
+		require.NoError(t, err, "initializeTemplates should not error")
+		require.True(t, done, "initialization was needed")
+		require.Len(t, created, 1)
+		require.NotEqual(t, source.ID, created[0].ID)
+		require.Equal(t, model.GlobalTeamID, created[0].TeamID)
+		require.Equal(t, model.SystemUserID, created[0].CreatedBy)
+		require.Equal(t, defaultTemplateVersion, created[0].TemplateVersion)
+
+		// after a restart, the copied template is current and is not
+		// imported again
+		th.Store.EXPECT().GetTemplateBoards(model.GlobalTeamID, "").Return(created, nil)
+		done, err = th.App.initializeTemplates()
+		require.NoError(t, err)
+		require.False(t, done, "initialization was not needed after a restart")
+	})
+
+	t.Run("Template from a default template board ID", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		// the source board is read before the old default templates,
+		// that include it, are removed
+		th.App.config.DefaultTemplates = []string{board.ID}
+
+		gomock.InOrder(
+			th.Store.EXPECT().GetBoard(board.ID).Return(board, nil),
+			th.Store.EXPECT().GetBlocksForBoard(board.ID).Return([]model.Block{block}, nil),
+			th.Store.EXPECT().RemoveDefaultTemplates([]*model.Board{board}).Return(nil),
+			th.Store.EXPECT().CreateBoardsAndBlocks(gomock.Any(), model.SystemUserID).Return(boardsAndBlocks, nil),
+		)
+		th.Store.EXPECT().GetTemplateBoards(model.GlobalTeamID, "").Return([]*model.Board{board}, nil)
+
+		err := th.App.ReseedTemplates()
+		require.NoError(t, err)
+	})
+
+	t.Run("Reseed templates", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		nonTemplate := &model.Board{ID: utils.NewID(utils.IDTypeBoard)}
+		th.App.config.DefaultTemplates = []string{nonTemplate.ID}
+
+		// the old templates are kept if a source is not valid
+		th.Store.EXPECT().GetTemplateBoards(model.GlobalTeamID, "").Return([]*model.Board{board}, nil)
+		th.Store.EXPECT().GetBoard(nonTemplate.ID).Return(nonTemplate, nil)
+		th.Store.EXPECT().RemoveDefaultTemplates(gomock.Any()).Times(0)
+
+		err := th.App.ReseedTemplates()
+		require.ErrorIs(t, err, ErrNotATemplate)
+	})
 }
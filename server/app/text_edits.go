@@ -0,0 +1,205 @@
+package app
+
+import (
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/ws"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	// textEditHistorySize is the number of edits of a block kept to
+	// transform the concurrent edits. An edit based on an older version
+	// of the text is refused with a version conflict.
+	textEditHistorySize = 100
+
+	// textEditHistoryTTL is how long the edits of a block that is no
+	// longer edited are kept.
+	textEditHistoryTTL = time.Hour
+)
+
+// textEdit is an edit kept in the history of a block, as it was applied.
+type textEdit struct {
+	baseUpdateAt int64
+	updateAt     int64
+	baseLength   int
+	delta        model.TextDelta
+}
+
+// blockTextEdits is the history of the recent edits of a block. Its lock
+// serializes the edits of the block.
+type blockTextEdits struct {
+	mu       sync.Mutex
+	edits    []textEdit
+	lastUsed time.Time
+}
+
+// since returns the edits applied after the version baseUpdateAt of the
+// text, up to its current version updateAt. It returns false if some of
+// them are missing, e.g. after a regular patch of the block.
+func (h *blockTextEdits) since(baseUpdateAt, updateAt int64) ([]textEdit, bool) {
+	for i, edit := range h.edits {
+		if edit.baseUpdateAt != baseUpdateAt {
+			continue
+		}
+		missed := h.edits[i:]
+		if missed[len(missed)-1].updateAt != updateAt {
+			return nil, false
+		}
+		return missed, true
+	}
+	return nil, false
+}
+
+// record adds an edit to the history. An edit that doesn't follow the
+// last one restarts the history, as the changes in between are unknown.
+func (h *blockTextEdits) record(edit textEdit) {
+	if len(h.edits) > 0 && h.edits[len(h.edits)-1].updateAt != edit.baseUpdateAt {
+		h.edits = nil
+	}
+	h.edits = append(h.edits, edit)
+	if len(h.edits) > textEditHistorySize {
+		h.edits = h.edits[len(h.edits)-textEditHistorySize:]
+	}
+}
+
+// textEditHistory keeps the recent edits of the text blocks in memory, so
+// the edits made concurrently on the same text are merged instead of
+// overwriting each other.
+type textEditHistory struct {
+	mu     sync.Mutex
+	blocks map[string]*blockTextEdits
+}
+
+func newTextEditHistory() *textEditHistory {
+	return &textEditHistory{
+		blocks: map[string]*blockTextEdits{},
+	}
+}
+
+// forBlock returns the history of the block, and forgets the blocks that
+// haven't been edited for a while.
+func (h *textEditHistory) forBlock(blockID string) *blockTextEdits {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	for id, edits := range h.blocks {
+		if id != blockID && now.Sub(edits.lastUsed) > textEditHistoryTTL {
+			delete(h.blocks, id)
+		}
+	}
+
+	edits, ok := h.blocks[blockID]
+	if !ok {
+		edits = &blockTextEdits{}
+		h.blocks[blockID] = edits
+	}
+	edits.lastUsed = now
+	return edits
+}
+
+// EditBlockText applies a delta to the text of a block. If the text was
+// edited since the version the delta is based on, the delta is first
+// transformed against those edits, so concurrent edits are merged. The
+// applied edit is relayed to the other editors of the block.
+func (a *App) EditBlockText(blockID string, request *model.TextEditRequest, userID string) (*model.TextEdit, error) {
+	if err := request.Delta.IsValid(); err != nil {
+		return nil, err
+	}
+
+	history := a.textEdits.forBlock(blockID)
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	block, err := a.store.GetBlock(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if !model.IsTextEditableBlockType(block.Type) {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "the text of the block can't be edited with deltas", map[string]interface{}{"type": block.Type})
+	}
+
+	delta := request.Delta
+	if request.BaseUpdateAt != block.UpdateAt {
+		missed, ok := history.since(request.BaseUpdateAt, block.UpdateAt)
+		if !ok {
+			return nil, model.NewErrVersionConflict(blockID)
+		}
+		for _, edit := range missed {
+			if delta, err = model.TransformTextDelta(delta, edit.delta, edit.baseLength); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	title, err := delta.Apply(block.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := &model.BlockPatch{Title: &title, UpdateAt: &block.UpdateAt}
+	if err = a.PatchBlock(blockID, patch, userID); err != nil {
+		return nil, err
+	}
+
+	updated, err := a.store.GetBlock(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseLength := utf8.RuneCountInString(block.Title)
+	if updated.Title != title {
+		// the content filter changed the text, so the delta is replaced
+		// by the change that was actually made
+		delta = model.TextDelta{{Delete: baseLength}, {Insert: updated.Title}}
+	}
+	delta = delta.Normalize(baseLength)
+
+	edit := &model.TextEdit{
+		BlockID:      blockID,
+		BoardID:      block.BoardID,
+		UserID:       userID,
+		BaseUpdateAt: block.UpdateAt,
+		UpdateAt:     updated.UpdateAt,
+		Delta:        delta,
+	}
+
+	if updated.UpdateAt == block.UpdateAt {
+		// both versions can't be told apart, so the edits based on
+		// the previous one conflict
+		history.edits = nil
+	} else {
+		history.record(textEdit{
+			baseUpdateAt: block.UpdateAt,
+			updateAt:     updated.UpdateAt,
+			baseLength:   baseLength,
+			delta:        delta,
+		})
+	}
+
+	a.broadcastTextEdit(edit)
+	return edit, nil
+}
+
+func (a *App) broadcastTextEdit(edit *model.TextEdit) {
+	broadcaster, ok := a.wsAdapter.(ws.TextEditBroadcaster)
+	if !ok {
+		return
+	}
+
+	board, err := a.store.GetBoard(edit.BoardID)
+	if err != nil {
+		a.logger.Error("cannot broadcast the text edit", mlog.String("blockID", edit.BlockID), mlog.Err(err))
+		return
+	}
+
+	a.enqueue(func(a *App) error {
+		broadcaster.BroadcastTextEdit(board.TeamID, edit)
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestBlockTextEdits(t *testing.T) {
+	history := &blockTextEdits{}
+	history.record(textEdit{baseUpdateAt: 1, updateAt: 2})
+	history.record(textEdit{baseUpdateAt: 2, updateAt: 3})
+
+	missed, ok := history.since(1, 3)
+	require.True(t, ok)
+	require.Len(t, missed, 2)
+
+	missed, ok = history.since(2, 3)
+	require.True(t, ok)
+	require.Len(t, missed, 1)
+
+	t.Run("edits missing from the history", func(t *testing.T) {
+		_, ok := history.since(0, 3)
+		require.False(t, ok)
+
+		// the block was changed by another patch after the last edit
+		_, ok = history.since(1, 4)
+		require.False(t, ok)
+	})
+
+	t.Run("an edit that doesn't follow the last one restarts the history", func(t *testing.T) {
+		history.record(textEdit{baseUpdateAt: 5, updateAt: 6})
+		require.Len(t, history.edits, 1)
+
+		_, ok := history.since(1, 6)
+		require.False(t, ok)
+	})
+
+	t.Run("the history is bounded", func(t *testing.T) {
+		history := &blockTextEdits{}
+		for i := int64(0); i < textEditHistorySize+10; i++ {
+			history.record(textEdit{baseUpdateAt: i, updateAt: i + 1})
+		}
+		require.Len(t, history.edits, textEditHistorySize)
+		require.Equal(t, int64(10), history.edits[0].baseUpdateAt)
+	})
+}
+
+func TestEditBlockTextValidation(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("invalid delta", func(t *testing.T) {
+		_, err := th.App.EditBlockText("block-id", &model.TextEditRequest{}, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("only text blocks are edited with deltas", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(&model.Block{ID: "card-id", Type: model.TypeCard, UpdateAt: 1}, nil)
+
+		_, err := th.App.EditBlockText("card-id", &model.TextEditRequest{
+			BaseUpdateAt: 1,
+			Delta:        model.TextDelta{{Insert: "text"}},
+		}, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("unknown base version", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("text-id").Return(&model.Block{ID: "text-id", Type: model.TypeText, UpdateAt: 5}, nil)
+
+		_, err := th.App.EditBlockText("text-id", &model.TextEditRequest{
+			BaseUpdateAt: 1,
+			Delta:        model.TextDelta{{Insert: "text"}},
+		}, "user-id")
+		require.True(t, model.IsErrVersionConflict(err))
+	})
+}
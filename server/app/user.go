@@ -1,6 +1,11 @@
 package app
 
-import "github.com/mattermost/focalboard/server/model"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+)
 
 func (a *App) GetTeamUsers(teamID string) ([]*model.User, error) {
 	return a.store.GetUsersByTeam(teamID)
@@ -22,3 +27,82 @@ func (a *App) UpdateUserConfig(userID string, patch model.UserPropPatch) (map[st
 
 	return user.Props, nil
 }
+
+// GetUserLocation returns the time location used to evaluate dates and
+// schedule deliveries for the user.
+func (a *App) GetUserLocation(userID string) (*time.Location, error) {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return user.Location(), nil
+}
+
+// GetNotificationPreferences returns the channels the user wants to be
+// notified through, by event type.
+func (a *App) GetNotificationPreferences(userID string) (model.NotificationPreferences, error) {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return user.NotificationPreferences(), nil
+}
+
+// UpdateNotificationPreferences replaces the notification preferences of
+// the user.
+func (a *App) UpdateNotificationPreferences(userID string, prefs model.NotificationPreferences) (model.NotificationPreferences, error) {
+	if err := prefs.IsValid(); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(prefs)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := model.UserPropPatch{
+		UpdatedFields: map[string]string{
+			model.UserPropNotificationPreferences: string(data),
+		},
+	}
+	if err := a.store.PatchUserProps(userID, patch); err != nil {
+		return nil, err
+	}
+	return a.GetNotificationPreferences(userID)
+}
+
+// GetQuietHours returns the quiet hours of the user, or nil if the user
+// has none.
+func (a *App) GetQuietHours(userID string) (*model.QuietHours, error) {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return user.QuietHours(), nil
+}
+
+// UpdateQuietHours replaces the quiet hours of the user, during which the
+// notifications are held back. Nil quiet hours clear them.
+func (a *App) UpdateQuietHours(userID string, quietHours *model.QuietHours) (*model.QuietHours, error) {
+	patch := model.UserPropPatch{}
+	if quietHours == nil {
+		patch.DeletedFields = []string{model.UserPropQuietHours}
+	} else {
+		if err := quietHours.IsValid(); err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(quietHours)
+		if err != nil {
+			return nil, err
+		}
+		patch.UpdatedFields = map[string]string{
+			model.UserPropQuietHours: string(data),
+		}
+	}
+
+	if err := a.store.PatchUserProps(userID, patch); err != nil {
+		return nil, err
+	}
+	return a.GetQuietHours(userID)
+}
@@ -0,0 +1,181 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var (
+	ErrUserNotFound           = errors.New("user not found")
+	ErrReassignToSameUser     = errors.New("cannot reassign the cards to the deactivated user")
+	ErrReassignToUserNotFound = errors.New("user to reassign the cards to not found")
+)
+
+// DeactivateUser deactivates a user of the local user store, revokes
+// their sessions and updates their boards. If reassignTo is set, the
+// cards assigned to the user are reassigned to that user.
+func (a *App) DeactivateUser(username, reassignTo string) error {
+	user, err := a.getActiveUserByUsername(username, ErrUserNotFound)
+	if err != nil {
+		return err
+	}
+
+	reassignToID := ""
+	if reassignTo != "" {
+		reassignToUser, err := a.getActiveUserByUsername(reassignTo, ErrReassignToUserNotFound)
+		if err != nil {
+			return err
+		}
+		if reassignToUser.ID == user.ID {
+			return ErrReassignToSameUser
+		}
+		reassignToID = reassignToUser.ID
+	}
+
+	if err := a.store.UpdateUserDeleteAt(username, model.GetMillis()); err != nil {
+		return err
+	}
+
+	if err := a.store.DeleteSessionsForUser(user.ID); err != nil {
+		return fmt.Errorf("cannot revoke the sessions of user %s: %w", user.ID, err)
+	}
+
+	return a.OnUserDeactivated(user.ID, reassignToID)
+}
+
+// ActivateUser reactivates a user of the local user store and restores
+// their board memberships.
+func (a *App) ActivateUser(username string) error {
+	if err := a.store.UpdateUserDeleteAt(username, 0); err != nil {
+		if a.store.IsErrNotFound(err) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	user, err := a.getActiveUserByUsername(username, ErrUserNotFound)
+	if err != nil {
+		return err
+	}
+
+	return a.OnUserActivated(user.ID)
+}
+
+// OnUserDeactivated marks the board memberships of a deactivated user
+// as inactive and, if reassignToID is set, reassigns the cards assigned
+// to them to that user.
+func (a *App) OnUserDeactivated(userID, reassignToID string) error {
+	members, err := a.store.SetMembershipsInactive(userID, true)
+	if err != nil {
+		return fmt.Errorf("cannot deactivate the memberships of user %s: %w", userID, err)
+	}
+	a.broadcastMembershipsChange(members)
+
+	if reassignToID == "" {
+		return nil
+	}
+
+	return a.reassignCards(userID, reassignToID)
+}
+
+// OnUserActivated marks the inactive board memberships of the user as
+// active again.
+func (a *App) OnUserActivated(userID string) error {
+	members, err := a.store.SetMembershipsInactive(userID, false)
+	if err != nil {
+		return fmt.Errorf("cannot activate the memberships of user %s: %w", userID, err)
+	}
+	a.broadcastMembershipsChange(members)
+	return nil
+}
+
+func (a *App) getActiveUserByUsername(username string, notFoundErr error) (*model.User, error) {
+	user, err := a.store.GetUserByUsername(username)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && (user == nil || user.DeleteAt != 0)) {
+		return nil, notFoundErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (a *App) broadcastMembershipsChange(members []*model.BoardMember) {
+	for _, member := range members {
+		board, err := a.store.GetBoard(member.BoardID)
+		if err != nil {
+			a.logger.Warn("cannot get board to broadcast membership change",
+				mlog.String("boardID", member.BoardID),
+				mlog.Err(err),
+			)
+			continue
+		}
+		a.wsAdapter.BroadcastMemberChange(board.TeamID, board.ID, member)
+	}
+}
+
+// reassignCards replaces the user with the new one in the person
+// properties of the cards of the boards the user is a member of.
+func (a *App) reassignCards(userID, reassignToID string) error {
+	members, err := a.store.GetMembersForUser(userID)
+	if err != nil {
+		return err
+	}
+
+	schemas := map[string]model.PropSchema{}
+	boardIDs := []string{}
+	for _, member := range members {
+		board, err := a.store.GetBoard(member.BoardID)
+		if err != nil {
+			a.logger.Warn("cannot get board to reassign cards", mlog.String("boardID", member.BoardID), mlog.Err(err))
+			continue
+		}
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			a.logger.Warn("cannot parse board properties to reassign cards", mlog.String("boardID", board.ID), mlog.Err(err))
+			continue
+		}
+		if !schema.HasPersonProperty() {
+			continue
+		}
+		schemas[board.ID] = schema
+		boardIDs = append(boardIDs, board.ID)
+	}
+
+	if len(boardIDs) == 0 {
+		return nil
+	}
+
+	cards, err := a.store.GetCardsWithFieldValue(boardIDs, userID)
+	if err != nil {
+		return err
+	}
+
+	reassigned := 0
+	for i := range cards {
+		props, changed := model.ReassignCard(&cards[i], schemas[cards[i].BoardID], userID, reassignToID)
+		if !changed {
+			continue
+		}
+
+		patch := &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"properties": props},
+		}
+		if err := a.PatchBlock(cards[i].ID, patch, model.SystemUserID); err != nil {
+			return fmt.Errorf("cannot reassign card %s: %w", cards[i].ID, err)
+		}
+		reassigned++
+	}
+
+	a.logger.Debug("reassigned cards of deactivated user",
+		mlog.String("userID", userID),
+		mlog.String("reassignToID", reassignToID),
+		mlog.Int("count", reassigned),
+	)
+	return nil
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestOnUserDeactivated(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	// the changes are broadcast in the background
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	board := &model.Board{
+		ID:     "board-id-1",
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": "person"},
+		},
+	}
+	member := &model.BoardMember{BoardID: board.ID, UserID: "user-id", SchemeEditor: true, Inactive: true}
+
+	t.Run("marks the memberships inactive", func(t *testing.T) {
+		th.Store.EXPECT().SetMembershipsInactive("user-id", true).Return([]*model.BoardMember{member}, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		require.NoError(t, th.App.OnUserDeactivated("user-id", ""))
+	})
+
+	t.Run("reassigns the cards of the user", func(t *testing.T) {
+		card := model.Block{
+			ID:      "card-1",
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{"assignee": "user-id"}},
+		}
+
+		th.Store.EXPECT().SetMembershipsInactive("user-id", true).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetMembersForUser("user-id").Return([]*model.BoardMember{member}, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetCardsWithFieldValue([]string{board.ID}, "user-id").Return([]model.Block{card}, nil)
+		th.Store.EXPECT().GetBlock("card-1").Return(&card, nil).Times(2)
+		th.Store.EXPECT().PatchBlock("card-1", gomock.Any(), model.SystemUserID).DoAndReturn(
+			func(_ string, patch *model.BlockPatch, _ string) error {
+				require.Equal(t, map[string]interface{}{"assignee": "new-user-id"}, patch.UpdatedFields["properties"])
+				return nil
+			})
+
+		require.NoError(t, th.App.OnUserDeactivated("user-id", "new-user-id"))
+	})
+}
+
+func TestDeactivateUser(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	user := &model.User{ID: "user-id", Username: "user"}
+
+	t.Run("user not found", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("nonexistent").Return(nil, sql.ErrNoRows)
+
+		err := th.App.DeactivateUser("nonexistent", "")
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("cannot reassign to the same user", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("user").Return(user, nil).Times(2)
+
+		err := th.App.DeactivateUser("user", "user")
+		require.ErrorIs(t, err, ErrReassignToSameUser)
+	})
+
+	t.Run("deactivates the user and revokes their sessions", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("user").Return(user, nil)
+		th.Store.EXPECT().UpdateUserDeleteAt("user", gomock.Any()).Return(nil)
+		th.Store.EXPECT().DeleteSessionsForUser("user-id").Return(nil)
+		th.Store.EXPECT().SetMembershipsInactive("user-id", true).Return([]*model.BoardMember{}, nil)
+
+		require.NoError(t, th.App.DeactivateUser("user", ""))
+	})
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetViewCards returns a window of the cards of the board in the order of
+// the view, so that the clients can load the cards of large boards as
+// they are scrolled into view. The card templates are left out, and the
+// view filters are not applied. The cards come with how long they have
+// been in their current status.
+func (a *App) GetViewCards(boardID, viewID string, offset, limit int) (*model.ViewCards, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	view, err := a.store.GetBlock(viewID)
+	if err != nil {
+		return nil, err
+	}
+	if view == nil || view.BoardID != boardID || view.Type != model.TypeView {
+		return nil, model.NewErrBlockNotFound(viewID)
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	var usernames map[string]string
+	if model.ViewSortsByUser(view, schema) {
+		userIDs, err := a.store.GetCardUserIDs(boardID)
+		if err != nil {
+			return nil, err
+		}
+		usernames = a.getUsernames(userIDs)
+	}
+
+	if limit <= 0 {
+		limit = model.DefaultViewCardsLimit
+	}
+	if limit > model.MaxViewCardsLimit {
+		limit = model.MaxViewCardsLimit
+	}
+
+	opts := model.NewQueryViewCardsOptions(view, schema, usernames, offset, limit)
+	cards, total, err := a.store.GetViewCards(boardID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ViewCards{
+		Cards:   cards,
+		Offset:  offset,
+		Total:   total,
+		HasNext: offset+len(cards) < total,
+	}
+
+	result.Aging, err = a.getCardsAging(board, result.Cards)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// getCardUsernames maps the IDs of the users who created or last modified
+// the cards to their usernames.
+func (a *App) getCardUsernames(cards []model.Block) map[string]string {
+	userIDs := []string{}
+	seen := map[string]bool{}
+	for _, card := range cards {
+		for _, userID := range []string{card.CreatedBy, card.ModifiedBy} {
+			if userID != "" && !seen[userID] {
+				seen[userID] = true
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+	return a.getUsernames(userIDs)
+}
+
+// getUsernames maps the IDs of the users to their usernames, looked up
+// at once. The unknown users map to an empty username, and sort as if the
+// cards had no user.
+func (a *App) getUsernames(userIDs []string) map[string]string {
+	usernames := make(map[string]string, len(userIDs))
+	for _, userID := range userIDs {
+		usernames[userID] = ""
+	}
+
+	users, err := a.store.GetUsersByIDs(userIDs)
+	if err != nil {
+		a.logger.Warn("Cannot get the usernames of the cards", mlog.Err(err))
+		return usernames
+	}
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+	return usernames
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetViewCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board-id"}
+	view := &model.Block{
+		ID:      "view-id",
+		BoardID: "board-id",
+		Type:    model.TypeView,
+		Fields: map[string]interface{}{"sortOptions": []interface{}{
+			map[string]interface{}{"propertyId": model.TitleColumnID, "reversed": false},
+		}},
+	}
+	cards := []model.Block{
+		{ID: "c1", Title: "a", Type: model.TypeCard},
+		{ID: "c2", Title: "b", Type: model.TypeCard},
+		{ID: "c3", Title: "c", Type: model.TypeCard},
+	}
+
+	t.Run("windows in the order of the view", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil).Times(2)
+		th.Store.EXPECT().GetBlock("view-id").Return(view, nil).Times(2)
+
+		opts := model.QueryViewCardsOptions{
+			Sorts:  []model.ViewCardsSort{{Kind: model.ViewCardsSortByTitle, PropertyID: model.TitleColumnID}},
+			Offset: 0,
+			Limit:  2,
+		}
+		th.Store.EXPECT().GetViewCards("board-id", opts).Return(cards[:2], 3, nil)
+		result, err := th.App.GetViewCards("board-id", "view-id", 0, 2)
+		require.NoError(t, err)
+		require.Equal(t, 3, result.Total)
+		require.True(t, result.HasNext)
+		require.Equal(t, cards[:2], result.Cards)
+
+		opts.Offset = 2
+		th.Store.EXPECT().GetViewCards("board-id", opts).Return(cards[2:], 3, nil)
+		result, err = th.App.GetViewCards("board-id", "view-id", 2, 2)
+		require.NoError(t, err)
+		require.False(t, result.HasNext)
+		require.Equal(t, cards[2:], result.Cards)
+	})
+
+	t.Run("users are looked up at once", func(t *testing.T) {
+		board := &model.Board{ID: "board-id", CardProperties: []map[string]interface{}{
+			{"id": "created", "name": "Created by", "type": "createdBy"},
+		}}
+		view := &model.Block{
+			ID:      "view-id",
+			BoardID: "board-id",
+			Type:    model.TypeView,
+			Fields: map[string]interface{}{"sortOptions": []interface{}{
+				map[string]interface{}{"propertyId": "created", "reversed": false},
+			}},
+		}
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetBlock("view-id").Return(view, nil)
+		th.Store.EXPECT().GetCardUserIDs("board-id").Return([]string{"user-b", "user-a", "unknown-user"}, nil)
+		th.Store.EXPECT().GetUsersByIDs(gomock.InAnyOrder([]string{"user-b", "user-a", "unknown-user"})).Return([]*model.User{
+			{ID: "user-a", Username: "alice"},
+			{ID: "user-b", Username: "bob"},
+		}, nil)
+
+		opts := model.QueryViewCardsOptions{
+			Sorts: []model.ViewCardsSort{{
+				Kind:       model.ViewCardsSortByCreatedBy,
+				PropertyID: "created",
+				Ranks:      map[string]int{"user-a": 0, "user-b": 1},
+			}},
+			Limit: model.DefaultViewCardsLimit,
+		}
+		th.Store.EXPECT().GetViewCards("board-id", opts).Return(cards, 3, nil)
+		result, err := th.App.GetViewCards("board-id", "view-id", 0, 0)
+		require.NoError(t, err)
+		require.Len(t, result.Cards, 3)
+	})
+
+	t.Run("view of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetBlock("other-view-id").Return(&model.Block{ID: "other-view-id", BoardID: "other-board-id", Type: model.TypeView}, nil)
+
+		_, err := th.App.GetViewCards("board-id", "other-view-id", 0, 10)
+		ce, ok := model.AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, model.ErrCodeBlockNotFound, ce.Code)
+	})
+}
@@ -0,0 +1,25 @@
+package app
+
+import "github.com/mattermost/focalboard/server/model"
+
+// GetDeadWebhookDeliveries returns a page of the outgoing webhook
+// deliveries that failed too many times to be retried automatically.
+func (a *App) GetDeadWebhookDeliveries(page, perPage int) ([]*model.WebhookDelivery, error) {
+	return a.store.GetDeadWebhookDeliveries(page, perPage)
+}
+
+// RetryWebhookDelivery retries a failed outgoing webhook delivery right
+// away.
+func (a *App) RetryWebhookDelivery(id string) error {
+	err := a.webhook.RetryDelivery(id)
+	if a.store.IsErrNotFound(err) {
+		return model.NewCodedError(model.ErrCodeNotFound, "webhook delivery not found", map[string]interface{}{"id": id})
+	}
+	return err
+}
+
+// RetryWebhookDeliveries retries the failed outgoing webhook deliveries
+// that are due.
+func (a *App) RetryWebhookDeliveries() {
+	a.webhook.RetryDeliveries()
+}
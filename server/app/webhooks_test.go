@@ -0,0 +1,20 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDeadWebhookDeliveries(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	deliveries := []*model.WebhookDelivery{{ID: "delivery-id", Status: model.WebhookDeliveryDead}}
+	th.Store.EXPECT().GetDeadWebhookDeliveries(1, 10).Return(deliveries, nil)
+
+	result, err := th.App.GetDeadWebhookDeliveries(1, 10)
+	require.NoError(t, err)
+	require.Equal(t, deliveries, result)
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// CheckWIPLimits returns the lanes of the views of the boards of the
+// blocks whose WIP limit the patches would exceed, by moving cards to
+// them. The blocks and patches are matched by index.
+func (a *App) CheckWIPLimits(blocks []model.Block, patches []model.BlockPatch) ([]*model.WIPLimitExceeded, error) {
+	// the cards moved to each value of each property, by board
+	moves := map[string]map[string]map[string]int64{}
+	for i := range blocks {
+		if i >= len(patches) || blocks[i].Type != model.TypeCard {
+			continue
+		}
+		for propertyID, value := range movedCardValues(&blocks[i], &patches[i]) {
+			if moves[blocks[i].BoardID] == nil {
+				moves[blocks[i].BoardID] = map[string]map[string]int64{}
+			}
+			if moves[blocks[i].BoardID][propertyID] == nil {
+				moves[blocks[i].BoardID][propertyID] = map[string]int64{}
+			}
+			moves[blocks[i].BoardID][propertyID][value]++
+		}
+	}
+
+	boardIDs := make([]string, 0, len(moves))
+	for boardID := range moves {
+		boardIDs = append(boardIDs, boardID)
+	}
+	sort.Strings(boardIDs)
+
+	exceeded := []*model.WIPLimitExceeded{}
+	for _, boardID := range boardIDs {
+		boardExceeded, err := a.checkBoardWIPLimits(boardID, moves[boardID])
+		if err != nil {
+			return nil, err
+		}
+		exceeded = append(exceeded, boardExceeded...)
+	}
+	return exceeded, nil
+}
+
+// checkBoardWIPLimits returns the lanes of the views of the board whose
+// limit is exceeded by the number of cards moved to each value of each
+// property.
+func (a *App) checkBoardWIPLimits(boardID string, moves map[string]map[string]int64) ([]*model.WIPLimitExceeded, error) {
+	views, err := a.store.GetBlocksWithType(boardID, model.TypeView)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].ID < views[j].ID })
+
+	counts := map[string]map[string]int64{}
+	exceeded := []*model.WIPLimitExceeded{}
+	for i := range views {
+		limits := model.WIPLimitsFromView(&views[i])
+		if limits == nil || len(moves[limits.PropertyID]) == 0 {
+			continue
+		}
+
+		if _, ok := counts[limits.PropertyID]; !ok {
+			groups, err := a.store.GetCardGroups(boardID, limits.PropertyID, "")
+			if err != nil {
+				return nil, err
+			}
+			counts[limits.PropertyID] = map[string]int64{}
+			for _, group := range groups {
+				counts[limits.PropertyID][group.Value] = group.Count
+			}
+		}
+
+		values := make([]string, 0, len(moves[limits.PropertyID]))
+		for value := range moves[limits.PropertyID] {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+
+		for _, value := range values {
+			count := counts[limits.PropertyID][value] + moves[limits.PropertyID][value]
+			if limit, status := limits.Status(value, count); status == model.WIPLimitStatusOver {
+				exceeded = append(exceeded, &model.WIPLimitExceeded{
+					ViewID:     limits.ViewID,
+					PropertyID: limits.PropertyID,
+					Value:      value,
+					Limit:      limit,
+					Count:      count,
+					Mode:       limits.Mode,
+				})
+			}
+		}
+	}
+	return exceeded, nil
+}
+
+// enforceWIPLimits returns an error if the patches would exceed a hard
+// WIP limit. The patches of the system user, like the reassignment of the
+// cards of deactivated users, are not checked.
+func (a *App) enforceWIPLimits(blocks []model.Block, patches []model.BlockPatch, modifiedByID string) error {
+	if modifiedByID == model.SystemUserID {
+		return nil
+	}
+
+	exceeded, err := a.CheckWIPLimits(blocks, patches)
+	if err != nil {
+		return err
+	}
+	for _, e := range exceeded {
+		if e.Mode == model.WIPLimitModeHard {
+			return model.NewErrWIPLimitExceeded(e)
+		}
+	}
+	return nil
+}
+
+// movedCardValues returns the values of the properties of the card that
+// the patch changes, by property ID. Only the single values, of the
+// select and person properties, move a card to a lane.
+func movedCardValues(card *model.Block, patch *model.BlockPatch) map[string]string {
+	newProps, ok := patch.UpdatedFields["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	oldProps, _ := card.Fields["properties"].(map[string]interface{})
+
+	moved := map[string]string{}
+	for propertyID, value := range newProps {
+		newValue, ok := value.(string)
+		if !ok || newValue == "" {
+			continue
+		}
+		if oldValue, _ := oldProps[propertyID].(string); oldValue != newValue {
+			moved[propertyID] = newValue
+		}
+	}
+	return moved
+}
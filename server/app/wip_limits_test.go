@@ -0,0 +1,92 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWIPLimits(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	views := []model.Block{
+		{
+			ID:      "view-soft",
+			BoardID: testBoardID,
+			Type:    model.TypeView,
+			Fields: map[string]interface{}{
+				"groupById": "status-id",
+				"wipLimits": map[string]interface{}{"doing": float64(2)},
+			},
+		},
+		{
+			ID:      "view-hard",
+			BoardID: testBoardID,
+			Type:    model.TypeView,
+			Fields: map[string]interface{}{
+				"groupById":    "status-id",
+				"wipLimits":    map[string]interface{}{"doing": float64(3)},
+				"wipLimitMode": model.WIPLimitModeHard,
+			},
+		},
+	}
+	card := func(id, status string) model.Block {
+		return model.Block{
+			ID:      id,
+			BoardID: testBoardID,
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{"status-id": status}},
+		}
+	}
+	moveTo := func(status string) model.BlockPatch {
+		return model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"properties": map[string]interface{}{"status-id": status}},
+		}
+	}
+	th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeView).Return(views, nil).AnyTimes()
+	th.Store.EXPECT().GetCardGroups(testBoardID, "status-id", "").Return([]*model.CardGroup{
+		{Value: "doing", Count: 2},
+	}, nil).AnyTimes()
+
+	t.Run("patches that don't move cards", func(t *testing.T) {
+		title := "new title"
+		exceeded, err := th.App.CheckWIPLimits(
+			[]model.Block{card("card-1", "todo"), card("card-2", "doing")},
+			[]model.BlockPatch{{Title: &title}, moveTo("doing")},
+		)
+		require.NoError(t, err)
+		require.Empty(t, exceeded)
+	})
+
+	t.Run("a move over the soft limit", func(t *testing.T) {
+		exceeded, err := th.App.CheckWIPLimits([]model.Block{card("card-1", "todo")}, []model.BlockPatch{moveTo("doing")})
+		require.NoError(t, err)
+		require.Equal(t, []*model.WIPLimitExceeded{{
+			ViewID:     "view-soft",
+			PropertyID: "status-id",
+			Value:      "doing",
+			Limit:      2,
+			Count:      3,
+			Mode:       model.WIPLimitModeSoft,
+		}}, exceeded)
+	})
+
+	t.Run("moves over the hard limit are rejected", func(t *testing.T) {
+		blocks := []model.Block{card("card-1", "todo"), card("card-2", "")}
+		patches := []model.BlockPatch{moveTo("doing"), moveTo("doing")}
+
+		exceeded, err := th.App.CheckWIPLimits(blocks, patches)
+		require.NoError(t, err)
+		require.Len(t, exceeded, 2)
+		require.Equal(t, "view-hard", exceeded[0].ViewID)
+		require.Equal(t, model.WIPLimitModeHard, exceeded[0].Mode)
+		require.Equal(t, int64(4), exceeded[0].Count)
+
+		err = th.App.enforceWIPLimits(blocks, patches, "user-id")
+		require.True(t, model.IsErrWIPLimitExceeded(err))
+
+		require.NoError(t, th.App.enforceWIPLimits(blocks, patches, model.SystemUserID))
+	})
+}
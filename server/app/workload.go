@@ -0,0 +1,104 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetBoardWorkload returns the open cards of the board and the sum of
+// their estimates by assignee. The cards with one of the excluded property
+// values are done; the stale card exclusions of the board apply if the
+// query has none.
+func (a *App) GetBoardWorkload(boardID string, query model.WorkloadQuery) (*model.Workload, error) {
+	if err := query.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, model.NewErrBoardNotFound(boardID)
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := schema.EstimateProperty(query.EstimateProperty); !ok && query.EstimateProperty != "" {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "the estimate property must be a number property of the board",
+			map[string]interface{}{"estimate": query.EstimateProperty})
+	}
+	exclusions := model.StaleCardExclusionsFromBoard(board)
+	if len(query.Exclude) > 0 {
+		if exclusions, err = model.ParseStaleCardExclusions(schema, query.Exclude); err != nil {
+			return nil, err
+		}
+	}
+
+	workload := model.NewWorkload()
+	if err := a.addBoardWorkload(workload, board, schema, exclusions, query); err != nil {
+		return nil, err
+	}
+	workload.Sort()
+	return workload, nil
+}
+
+// GetTeamWorkload returns the workload of the team boards visible to the
+// user, see GetBoardWorkload. The stale card exclusions of each board
+// apply, and the estimates are taken from the property with the same name
+// on each board.
+func (a *App) GetTeamWorkload(userID, teamID string, query model.WorkloadQuery) (*model.Workload, error) {
+	if err := query.IsValid(); err != nil {
+		return nil, err
+	}
+
+	boards, err := a.store.GetBoardsForUserAndTeam(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	workload := model.NewWorkload()
+	for _, board := range boards {
+		if board.IsTemplate {
+			continue
+		}
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			a.logger.Warn("GetTeamWorkload cannot parse board properties", mlog.String("boardID", board.ID), mlog.Err(err))
+			continue
+		}
+		if !schema.HasPersonProperty() {
+			continue
+		}
+		if err := a.addBoardWorkload(workload, board, schema, model.StaleCardExclusionsFromBoard(board), query); err != nil {
+			return nil, err
+		}
+	}
+	workload.Sort()
+	return workload, nil
+}
+
+func (a *App) addBoardWorkload(workload *model.Workload, board *model.Board, schema model.PropSchema, exclusions model.StaleCardExclusions, query model.WorkloadQuery) error {
+	var estimate *model.PropDef
+	if def, ok := schema.EstimateProperty(query.EstimateProperty); ok {
+		estimate = &def
+	}
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return err
+	}
+	for i := range cards {
+		if isTemplate, _ := cards[i].Fields["isTemplate"].(bool); isTemplate {
+			continue
+		}
+		if exclusions.Excludes(&cards[i]) || !query.InRange(&cards[i], schema) {
+			continue
+		}
+		workload.AddCard(&cards[i], schema, estimate)
+	}
+	return nil
+}
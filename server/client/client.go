@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
@@ -8,10 +9,14 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mattermost/focalboard/server/api"
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/github"
 )
 
 const (
@@ -192,6 +197,10 @@ func (c *Client) GetAllBlocksRoute(boardID string) string {
 	return fmt.Sprintf("%s/blocks?all=true", c.GetBoardRoute(boardID))
 }
 
+func (c *Client) GetCardRoute(cardID string) string {
+	return fmt.Sprintf("/cards/%s", cardID)
+}
+
 func (c *Client) GetBoardsAndBlocksRoute() string {
 	return "/boards-and-blocks"
 }
@@ -253,6 +262,36 @@ func (c *Client) DuplicateBoard(boardID string, asTemplate bool, teamID string)
 	return model.BoardsAndBlocksFromJSON(r.Body), BuildResponse(r)
 }
 
+// DuplicateBoardWithUserMapping duplicates a board into the team,
+// replacing the users of the board in the copy as the mapping says.
+func (c *Client) DuplicateBoardWithUserMapping(boardID, teamID string, userMapping model.UserMapping) (*model.BoardsAndBlocks, *Response) {
+	body := toJSON(map[string]interface{}{"userMapping": userMapping})
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/duplicate?asTemplate=false&toTeam="+teamID, body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsAndBlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+// CreateBoardFromTemplate creates a board from a template, filling in the
+// template placeholders with variables.
+func (c *Client) CreateBoardFromTemplate(templateID string, teamID string, variables model.TemplateVariables) (*model.BoardsAndBlocks, *Response) {
+	queryParams := "?asTemplate=false"
+	if len(teamID) > 0 {
+		queryParams = queryParams + "&toTeam=" + teamID
+	}
+	body := toJSON(map[string]model.TemplateVariables{"variables": variables})
+	r, err := c.DoAPIPost(c.GetBoardRoute(templateID)+"/duplicate"+queryParams, body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsAndBlocksFromJSON(r.Body), BuildResponse(r)
+}
+
 func (c *Client) DuplicateBlock(boardID, blockID string, asTemplate bool) (bool, *Response) {
 	queryParams := "?asTemplate=false"
 	if asTemplate {
@@ -267,6 +306,44 @@ func (c *Client) DuplicateBlock(boardID, blockID string, asTemplate bool) (bool,
 	return true, BuildResponse(r)
 }
 
+func (c *Client) MoveCard(cardID, toBoardID string) ([]model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/move", toJSON(model.MoveCardRequest{BoardID: toBoardID}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DuplicateCard(cardID string, opts model.DuplicateCardOptions) ([]model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/duplicate", toJSON(opts))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetCardPreview(cardID, readToken string) (*model.CardPreview, *Response) {
+	route := c.GetCardRoute(cardID) + "/preview"
+	if readToken != "" {
+		route += "?read_token=" + readToken
+	}
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var preview *model.CardPreview
+	if err := json.NewDecoder(r.Body).Decode(&preview); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return preview, BuildResponse(r)
+}
+
 func (c *Client) UndeleteBlock(boardID, blockID string) (bool, *Response) {
 	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/undelete", "")
 	if err != nil {
@@ -287,6 +364,21 @@ func (c *Client) InsertBlocks(boardID string, blocks []model.Block) ([]model.Blo
 	return model.BlocksFromJSON(r.Body), BuildResponse(r)
 }
 
+func (c *Client) InsertBlocksDetectingDuplicates(boardID string, blocks []model.Block) (*model.BlocksWithDuplicates, *Response) {
+	r, err := c.DoAPIPost(c.GetBlocksRoute(boardID)+"?detectDuplicates=true", toJSON(blocks))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.BlocksWithDuplicates
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
 func (c *Client) DeleteBlock(boardID, blockID string) (bool, *Response) {
 	r, err := c.DoAPIDelete(c.GetBlockRoute(boardID, blockID), "")
 	if err != nil {
@@ -410,303 +502,578 @@ func (c *Client) GetMe() (*model.User, *Response) {
 	return me, BuildResponse(r)
 }
 
-func (c *Client) GetUserRoute(id string) string {
-	return fmt.Sprintf("/users/%s", id)
-}
-
-func (c *Client) GetUser(id string) (*model.User, *Response) {
-	r, err := c.DoAPIGet(c.GetUserRoute(id), "")
+func (c *Client) GetStatistics() (*model.ServerStats, *Response) {
+	r, err := c.DoAPIGet("/statistics", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	user, err := model.UserFromJSON(r.Body)
-	if err != nil {
+	var stats *model.ServerStats
+	if err := json.NewDecoder(r.Body).Decode(&stats); err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-	return user, BuildResponse(r)
-}
-
-func (c *Client) GetUserChangePasswordRoute(id string) string {
-	return fmt.Sprintf("/users/%s/changepassword", id)
+	return stats, BuildResponse(r)
 }
 
-func (c *Client) UserChangePassword(id string, data *api.ChangePasswordRequest) (bool, *Response) {
-	r, err := c.DoAPIPost(c.GetUserChangePasswordRoute(id), toJSON(&data))
+func (c *Client) GetMigrationStatus(dryRun bool) (*model.MigrationStatus, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("/migrations/status?dryRun=%t", dryRun), "")
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	var status *model.MigrationStatus
+	if err := json.NewDecoder(r.Body).Decode(&status); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return status, BuildResponse(r)
 }
 
-func (c *Client) CreateBoard(board *model.Board) (*model.Board, *Response) {
-	r, err := c.DoAPIPost(c.GetBoardsRoute(), toJSON(board))
+func (c *Client) RunMaintenance() (*model.MaintenanceReport, *Response) {
+	r, err := c.DoAPIPost("/maintenance", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardFromJSON(r.Body), BuildResponse(r)
+	var report *model.MaintenanceReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return report, BuildResponse(r)
 }
 
-func (c *Client) PatchBoard(boardID string, patch *model.BoardPatch) (*model.Board, *Response) {
-	r, err := c.DoAPIPatch(c.GetBoardRoute(boardID), toJSON(patch))
+func (c *Client) MigratePersonalBoards(request *model.PersonalBoardsMigrationRequest) (*model.PersonalBoardsMigrationReport, *Response) {
+	r, err := c.DoAPIPost("/personal-boards/migrate", toJSON(request))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardFromJSON(r.Body), BuildResponse(r)
+	var report *model.PersonalBoardsMigrationReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return report, BuildResponse(r)
 }
 
-func (c *Client) DeleteBoard(boardID string) (bool, *Response) {
-	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID), "")
+func (c *Client) GetRecentBoards(teamID string) ([]*model.Board, *Response) {
+	url := c.GetMeRoute() + "/boards/recent"
+	if teamID != "" {
+		url += fmt.Sprintf("?teamID=%s", teamID)
+	}
+
+	r, err := c.DoAPIGet(url, "")
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	return model.BoardsFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) UndeleteBoard(boardID string) (bool, *Response) {
-	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/undelete", "")
+func (c *Client) GetMyWork(teamID string) ([]*model.MyWorkCard, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/cards?teamID=%s", c.GetMeRoute(), teamID), "")
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
-}
-
-func (c *Client) GetBoard(boardID, readToken string) (*model.Board, *Response) {
-	url := c.GetBoardRoute(boardID)
-	if readToken != "" {
-		url += fmt.Sprintf("?read_token=%s", readToken)
+	var cards []*model.MyWorkCard
+	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+		return nil, BuildErrorResponse(r, err)
 	}
+	return cards, BuildResponse(r)
+}
 
-	r, err := c.DoAPIGet(url, "")
+func (c *Client) GetMyMentions(unreadOnly bool) ([]*model.Mention, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/mentions?unread=%t", c.GetMeRoute(), unreadOnly), "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardFromJSON(r.Body), BuildResponse(r)
+	var mentions []*model.Mention
+	if err := json.NewDecoder(r.Body).Decode(&mentions); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return mentions, BuildResponse(r)
 }
 
-func (c *Client) GetBoardMetadata(boardID, readToken string) (*model.BoardMetadata, *Response) {
-	url := c.GetBoardMetadataRoute(boardID)
-	if readToken != "" {
-		url += fmt.Sprintf("?read_token=%s", readToken)
+func (c *Client) MarkMentionsRead(mentionIDs []string) *Response {
+	request := &model.MarkMentionsReadRequest{MentionIDs: mentionIDs}
+	r, err := c.DoAPIPost(c.GetMeRoute()+"/mentions/read", toJSON(request))
+	if err != nil {
+		return BuildErrorResponse(r, err)
 	}
+	defer closeBody(r)
 
-	r, err := c.DoAPIGet(url, "")
+	return BuildResponse(r)
+}
+
+func (c *Client) ValidateMentions(boardID, text string) ([]*model.MentionValidation, *Response) {
+	request := &model.MentionValidationRequest{Text: text}
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/mentions/validate", toJSON(request))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardMetadataFromJSON(r.Body), BuildResponse(r)
+	var results []*model.MentionValidation
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return results, BuildResponse(r)
 }
 
-func (c *Client) GetBoardsForTeam(teamID string) ([]*model.Board, *Response) {
-	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards", "")
+func (c *Client) SendTestNotification() ([]*model.TestNotificationResult, *Response) {
+	r, err := c.DoAPIPost("/notifications/test", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+	var results []*model.TestNotificationResult
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return results, BuildResponse(r)
 }
 
-func (c *Client) SearchBoardsForTeam(teamID, term string) ([]*model.Board, *Response) {
-	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/search?q="+term, "")
+func (c *Client) GetNotificationPreferences() (model.NotificationPreferences, *Response) {
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/notifications/preferences", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+	prefs, err := model.NotificationPreferencesFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return prefs, BuildResponse(r)
 }
 
-func (c *Client) GetMembersForBoard(boardID string) ([]*model.BoardMember, *Response) {
-	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/members", "")
+func (c *Client) UpdateNotificationPreferences(prefs model.NotificationPreferences) (model.NotificationPreferences, *Response) {
+	r, err := c.DoAPIPut(c.GetMeRoute()+"/notifications/preferences", toJSON(prefs))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardMembersFromJSON(r.Body), BuildResponse(r)
+	updated, err := model.NotificationPreferencesFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updated, BuildResponse(r)
 }
 
-func (c *Client) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, *Response) {
-	r, err := c.DoAPIPost(c.GetBoardRoute(member.BoardID)+"/members", toJSON(member))
+func (c *Client) GetQuietHours() (*model.QuietHours, *Response) {
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/quiet-hours", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+	quietHours, err := model.QuietHoursFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return quietHours, BuildResponse(r)
 }
 
-func (c *Client) JoinBoard(boardID string) (*model.BoardMember, *Response) {
-	r, err := c.DoAPIPost(c.GetJoinBoardRoute(boardID), "")
+func (c *Client) UpdateQuietHours(quietHours *model.QuietHours) (*model.QuietHours, *Response) {
+	r, err := c.DoAPIPut(c.GetMeRoute()+"/quiet-hours", toJSON(quietHours))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+	updated, err := model.QuietHoursFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updated, BuildResponse(r)
 }
 
-func (c *Client) LeaveBoard(boardID string) (*model.BoardMember, *Response) {
-	r, err := c.DoAPIPost(c.GetLeaveBoardRoute(boardID), "")
+func (c *Client) GetOnboardingState() (*model.OnboardingState, *Response) {
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/onboarding", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+	var state *model.OnboardingState
+	if err := json.NewDecoder(r.Body).Decode(&state); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return state, BuildResponse(r)
 }
 
-func (c *Client) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember, *Response) {
-	r, err := c.DoAPIPut(c.GetBoardRoute(member.BoardID)+"/members/"+member.UserID, toJSON(member))
+func (c *Client) PatchOnboardingState(patch *model.OnboardingStatePatch) (*model.OnboardingState, *Response) {
+	r, err := c.DoAPIPatch(c.GetMeRoute()+"/onboarding", toJSON(patch))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+	var state *model.OnboardingState
+	if err := json.NewDecoder(r.Body).Decode(&state); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return state, BuildResponse(r)
 }
 
-func (c *Client) DeleteBoardMember(member *model.BoardMember) (bool, *Response) {
-	r, err := c.DoAPIDelete(c.GetBoardRoute(member.BoardID)+"/members/"+member.UserID, "")
+func (c *Client) GetDueDigestSettings() (*model.DueDigestSettings, *Response) {
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/due-digest", "")
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
-}
-
-func (c *Client) GetTeamUploadFileRoute(teamID, boardID string) string {
-	return fmt.Sprintf("%s/%s/files", c.GetTeamRoute(teamID), boardID)
+	var settings *model.DueDigestSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return settings, BuildResponse(r)
 }
 
-func (c *Client) TeamUploadFile(teamID, boardID string, data io.Reader) (*api.FileUploadResponse, *Response) {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
+func (c *Client) UpdateDueDigestSettings(settings *model.DueDigestSettings) (*model.DueDigestSettings, *Response) {
+	r, err := c.DoAPIPut(c.GetMeRoute()+"/due-digest", toJSON(settings))
 	if err != nil {
-		return nil, &Response{Error: err}
-	}
-	if _, err = io.Copy(part, data); err != nil {
-		return nil, &Response{Error: err}
+		return nil, BuildErrorResponse(r, err)
 	}
-	writer.Close()
+	defer closeBody(r)
 
-	opt := func(r *http.Request) {
-		r.Header.Add("Content-Type", writer.FormDataContentType())
+	var updated *model.DueDigestSettings
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		return nil, BuildErrorResponse(r, err)
 	}
+	return updated, BuildResponse(r)
+}
 
-	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamUploadFileRoute(teamID, boardID), body, "", opt)
+func (c *Client) GetStaleDigestSettings() (*model.StaleDigestSettings, *Response) {
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/stale-digest", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	fileUploadResponse, err := api.FileUploadResponseFromJSON(r.Body)
+	var settings *model.StaleDigestSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return settings, BuildResponse(r)
+}
+
+func (c *Client) UpdateStaleDigestSettings(settings *model.StaleDigestSettings) (*model.StaleDigestSettings, *Response) {
+	r, err := c.DoAPIPut(c.GetMeRoute()+"/stale-digest", toJSON(settings))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
+	defer closeBody(r)
 
-	return fileUploadResponse, BuildResponse(r)
+	var updated *model.StaleDigestSettings
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updated, BuildResponse(r)
 }
 
-func (c *Client) GetSubscriptionsRoute() string {
-	return "/subscriptions"
+func (c *Client) GetUserRoute(id string) string {
+	return fmt.Sprintf("/users/%s", id)
 }
 
-func (c *Client) CreateSubscription(sub *model.Subscription) (*model.Subscription, *Response) {
-	r, err := c.DoAPIPost(c.GetSubscriptionsRoute(), toJSON(&sub))
+func (c *Client) GetUser(id string) (*model.User, *Response) {
+	r, err := c.DoAPIGet(c.GetUserRoute(id), "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	subNew, err := model.SubscriptionFromJSON(r.Body)
+	user, err := model.UserFromJSON(r.Body)
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-	return subNew, BuildResponse(r)
+	return user, BuildResponse(r)
 }
 
-func (c *Client) DeleteSubscription(blockID string, subscriberID string) *Response {
-	url := fmt.Sprintf("%s/%s/%s", c.GetSubscriptionsRoute(), blockID, subscriberID)
+func (c *Client) GetUserChangePasswordRoute(id string) string {
+	return fmt.Sprintf("/users/%s/changepassword", id)
+}
 
-	r, err := c.DoAPIDelete(url, "")
+func (c *Client) UserChangePassword(id string, data *api.ChangePasswordRequest) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetUserChangePasswordRoute(id), toJSON(&data))
 	if err != nil {
-		return BuildErrorResponse(r, err)
+		return false, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return BuildResponse(r)
+	return true, BuildResponse(r)
 }
 
-func (c *Client) GetSubscriptions(subscriberID string) ([]*model.Subscription, *Response) {
-	url := fmt.Sprintf("%s/%s", c.GetSubscriptionsRoute(), subscriberID)
-
-	r, err := c.DoAPIGet(url, "")
+func (c *Client) CreateBoard(board *model.Board) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardsRoute(), toJSON(board))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	var subs []*model.Subscription
-	err = json.NewDecoder(r.Body).Decode(&subs)
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) PatchBoard(boardID string, patch *model.BoardPatch) (*model.Board, *Response) {
+	r, err := c.DoAPIPatch(c.GetBoardRoute(boardID), toJSON(patch))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
+	defer closeBody(r)
 
-	return subs, BuildResponse(r)
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) GetTemplatesForTeam(teamID string) ([]*model.Board, *Response) {
-	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/templates", "")
+func (c *Client) DeleteBoard(boardID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID), "")
 	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+		return false, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+	return true, BuildResponse(r)
 }
 
-func (c *Client) ExportBoardArchive(boardID string) ([]byte, *Response) {
-	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/archive/export", "")
+func (c *Client) UndeleteBoard(boardID string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/undelete", "")
 	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+		return false, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	buf, err := ioutil.ReadAll(r.Body)
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetBoard(boardID, readToken string) (*model.Board, *Response) {
+	url := c.GetBoardRoute(boardID)
+	if readToken != "" {
+		url += fmt.Sprintf("?read_token=%s", readToken)
+	}
+
+	r, err := c.DoAPIGet(url, "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-	return buf, BuildResponse(r)
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) ImportArchive(teamID string, data io.Reader) *Response {
+func (c *Client) GetBoardMetadata(boardID, readToken string) (*model.BoardMetadata, *Response) {
+	url := c.GetBoardMetadataRoute(boardID)
+	if readToken != "" {
+		url += fmt.Sprintf("?read_token=%s", readToken)
+	}
+
+	r, err := c.DoAPIGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMetadataFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetBoardsForTeam(teamID string) ([]*model.Board, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) SearchBoardsForTeam(teamID, term string) ([]*model.Board, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/search?q="+url.QueryEscape(term), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetMembersForBoard(boardID string) ([]*model.BoardMember, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/members", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMembersFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(member.BoardID)+"/members", toJSON(member))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+// UpdateMembersRoles changes the roles of many members of the board at
+// once, recording the reason in the board members history.
+func (c *Client) UpdateMembersRoles(boardID string, update *model.BoardMembersRolesUpdate) ([]*model.BoardMember, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/members/roles", toJSON(update))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMembersFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetBoardPermissions(boardID string) (*model.BoardPermissions, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/permissions", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var permissions *model.BoardPermissions
+	if resp := decodeJSON(r, &permissions); resp.Error != nil {
+		return nil, resp
+	}
+	return permissions, BuildResponse(r)
+}
+
+// ApplyBoardPermissions applies the permissions exported from a board to
+// another one. If replace is true, the members of the board missing from
+// the permissions are removed.
+func (c *Client) ApplyBoardPermissions(boardID string, permissions *model.BoardPermissions, replace bool) ([]*model.BoardMember, *Response) {
+	r, err := c.DoAPIPut(fmt.Sprintf("%s/permissions?replace=%t", c.GetBoardRoute(boardID), replace), toJSON(permissions))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMembersFromJSON(r.Body), BuildResponse(r)
+}
+
+// GetBoardFreezeSchedule returns the scheduled freeze and unfreeze of a
+// board.
+func (c *Client) GetBoardFreezeSchedule(boardID string) (*model.BoardFreezeSchedule, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/freeze-schedule", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var schedule *model.BoardFreezeSchedule
+	if resp := decodeJSON(r, &schedule); resp.Error != nil {
+		return nil, resp
+	}
+	return schedule, BuildResponse(r)
+}
+
+// SetBoardFreezeSchedule replaces the scheduled freeze and unfreeze of a
+// board.
+func (c *Client) SetBoardFreezeSchedule(boardID string, schedule *model.BoardFreezeSchedule) (*model.BoardFreezeSchedule, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/freeze-schedule", toJSON(schedule))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updated *model.BoardFreezeSchedule
+	if resp := decodeJSON(r, &updated); resp.Error != nil {
+		return nil, resp
+	}
+	return updated, BuildResponse(r)
+}
+
+// AcquireBlockLock locks a block for the user for ttl seconds, or the
+// server default if ttl is 0.
+func (c *Client) AcquireBlockLock(boardID, blockID string, ttl int) (*model.BlockLock, *Response) {
+	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/lock", toJSON(&model.BlockLockRequest{TTL: ttl}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var lock *model.BlockLock
+	if resp := decodeJSON(r, &lock); resp.Error != nil {
+		return nil, resp
+	}
+	return lock, BuildResponse(r)
+}
+
+// ReleaseBlockLock releases the lock the user holds on a block.
+func (c *Client) ReleaseBlockLock(boardID, blockID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBlockRoute(boardID, blockID)+"/lock", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+// GetBlockLocks returns the active locks of the blocks of a board.
+func (c *Client) GetBlockLocks(boardID string) ([]*model.BlockLock, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/locks", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var locks []*model.BlockLock
+	if resp := decodeJSON(r, &locks); resp.Error != nil {
+		return nil, resp
+	}
+	return locks, BuildResponse(r)
+}
+
+// ForcePatchBlock patches a block even if it is locked by another user.
+func (c *Client) ForcePatchBlock(boardID, blockID string, blockPatch *model.BlockPatch) (bool, *Response) {
+	r, err := c.DoAPIPatch(c.GetBlockRoute(boardID, blockID)+"?force=true", toJSON(blockPatch))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+// EditBlockText applies a delta to the text of a block, and returns it as
+// applied after being merged with the concurrent edits.
+func (c *Client) EditBlockText(boardID, blockID string, request *model.TextEditRequest) (*model.TextEdit, *Response) {
+	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/text-edits", toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var edit *model.TextEdit
+	if resp := decodeJSON(r, &edit); resp.Error != nil {
+		return nil, resp
+	}
+	return edit, BuildResponse(r)
+}
+
+// ImportCalendar imports the events of an iCalendar file as cards of a
+// board, in the given date property or the first one if empty.
+func (c *Client) ImportCalendar(boardID string, data io.Reader, dateProperty string) (*model.CalendarImportResult, *Response) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, "calendar.ics")
 	if err != nil {
-		return &Response{Error: err}
+		return nil, &Response{Error: err}
 	}
 	if _, err = io.Copy(part, data); err != nil {
-		return &Response{Error: err}
+		return nil, &Response{Error: err}
 	}
 	writer.Close()
 
@@ -714,11 +1081,1697 @@ func (c *Client) ImportArchive(teamID string, data io.Reader) *Response {
 		r.Header.Add("Content-Type", writer.FormDataContentType())
 	}
 
-	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamRoute(teamID)+"/archive/import", body, "", opt)
+	query := url.Values{}
+	if dateProperty != "" {
+		query.Set("dateProperty", dateProperty)
+	}
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetBoardRoute(boardID)+"/calendar/import?"+query.Encode(), body, "", opt)
 	if err != nil {
-		return BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return BuildResponse(r)
+	var result *model.CalendarImportResult
+	if resp := decodeJSON(r, &result); resp.Error != nil {
+		return nil, resp
+	}
+	return result, BuildResponse(r)
+}
+
+func (c *Client) GetCalendarSubscription(boardID string) (*model.CalendarSubscription, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/calendar/subscription", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var subscription *model.CalendarSubscription
+	if resp := decodeJSON(r, &subscription); resp.Error != nil {
+		return nil, resp
+	}
+	return subscription, BuildResponse(r)
+}
+
+// SetCalendarSubscription subscribes a board to a calendar, replacing its
+// previous subscription.
+func (c *Client) SetCalendarSubscription(boardID string, subscription *model.CalendarSubscription) (*model.CalendarSubscription, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/calendar/subscription", toJSON(subscription))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updated *model.CalendarSubscription
+	if resp := decodeJSON(r, &updated); resp.Error != nil {
+		return nil, resp
+	}
+	return updated, BuildResponse(r)
+}
+
+func (c *Client) DeleteCalendarSubscription(boardID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/calendar/subscription", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetGitHubIntegration(boardID string) (*model.GitHubIntegration, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/github", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var integration *model.GitHubIntegration
+	if resp := decodeJSON(r, &integration); resp.Error != nil {
+		return nil, resp
+	}
+	return integration, BuildResponse(r)
+}
+
+// SetGitHubIntegration connects a board to a GitHub repository, replacing
+// its previous integration.
+func (c *Client) SetGitHubIntegration(boardID string, integration *model.GitHubIntegration) (*model.GitHubIntegration, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/github", toJSON(integration))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updated *model.GitHubIntegration
+	if resp := decodeJSON(r, &updated); resp.Error != nil {
+		return nil, resp
+	}
+	return updated, BuildResponse(r)
+}
+
+func (c *Client) DeleteGitHubIntegration(boardID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/github", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+// SyncGitHubIssues imports the issues of the GitHub repository of a board
+// as cards.
+func (c *Client) SyncGitHubIssues(boardID string) (*model.GitHubSyncResult, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/github/sync", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.GitHubSyncResult
+	if resp := decodeJSON(r, &result); resp.Error != nil {
+		return nil, resp
+	}
+	return result, BuildResponse(r)
+}
+
+// ImportGitLabIssues imports the issues of a GitLab project as cards of
+// a new board of the team or of the board of the request.
+func (c *Client) ImportGitLabIssues(teamID string, request *model.GitLabImportRequest) (*model.GitLabImportResult, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamRoute(teamID)+"/import/"+model.ImporterGitLab, toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.GitLabImportResult
+	if resp := decodeJSON(r, &result); resp.Error != nil {
+		return nil, resp
+	}
+	return result, BuildResponse(r)
+}
+
+// GetBoardContent returns the content blocks of a board and the
+// description rendered from them.
+func (c *Client) GetBoardContent(boardID string) (*model.BoardContent, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/content", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var content *model.BoardContent
+	if resp := decodeJSON(r, &content); resp.Error != nil {
+		return nil, resp
+	}
+	return content, BuildResponse(r)
+}
+
+// SetBoardContent replaces the content blocks of a board.
+func (c *Client) SetBoardContent(boardID string, blocks []model.Block) (*model.BoardContent, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/content", toJSON(blocks))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var content *model.BoardContent
+	if resp := decodeJSON(r, &content); resp.Error != nil {
+		return nil, resp
+	}
+	return content, BuildResponse(r)
+}
+
+// GetBoardContentHistory returns the past descriptions of a board, the
+// latest first, all of them if limit is zero.
+func (c *Client) GetBoardContentHistory(boardID string, limit int) ([]*model.BoardContentRevision, *Response) {
+	route := c.GetBoardRoute(boardID) + "/content/history"
+	if limit > 0 {
+		route += fmt.Sprintf("?limit=%d", limit)
+	}
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var revisions []*model.BoardContentRevision
+	if resp := decodeJSON(r, &revisions); resp.Error != nil {
+		return nil, resp
+	}
+	return revisions, BuildResponse(r)
+}
+
+func (c *Client) JoinBoard(boardID string) (*model.BoardMember, *Response) {
+	r, err := c.DoAPIPost(c.GetJoinBoardRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) LeaveBoard(boardID string) (*model.BoardMember, *Response) {
+	r, err := c.DoAPIPost(c.GetLeaveBoardRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(member.BoardID)+"/members/"+member.UserID, toJSON(member))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardMember(member *model.BoardMember) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(member.BoardID)+"/members/"+member.UserID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+// GetDeletedMembersForBoard returns the deleted members of the board that
+// can be restored.
+func (c *Client) GetDeletedMembersForBoard(boardID string) ([]*model.BoardMember, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/members/deleted", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMembersFromJSON(r.Body), BuildResponse(r)
+}
+
+// RestoreBoardMember restores the deleted membership of the user on the
+// board, with its roles.
+func (c *Client) RestoreBoardMember(boardID, userID string) (*model.BoardMember, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/members/"+userID+"/restore", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) PatchBoardMemberCustomFields(boardID, userID string, customFields map[string]interface{}) (*model.BoardMember, *Response) {
+	r, err := c.DoAPIPatch(c.GetBoardRoute(boardID)+"/members/"+userID+"/custom-fields", toJSON(model.MemberCustomFieldsPatch{CustomFields: customFields}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetBoardAdminView(boardID string, notifyAdmins bool) (*model.BoardAdminView, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+fmt.Sprintf("/admin-view?notify=%t", notifyAdmins), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var view *model.BoardAdminView
+	if resp := decodeJSON(r, &view); resp.Error != nil {
+		return nil, resp
+	}
+	return view, BuildResponse(r)
+}
+
+func (c *Client) GetBoardTeams(boardID string) ([]string, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/teams", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var teamIDs []string
+	if resp := decodeJSON(r, &teamIDs); resp.Error != nil {
+		return nil, resp
+	}
+	return teamIDs, BuildResponse(r)
+}
+
+func (c *Client) ShareBoardWithTeam(boardID, teamID string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/teams/"+teamID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) UnshareBoardFromTeam(boardID, teamID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/teams/"+teamID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetBoardWebhooks(boardID string) ([]*model.BoardWebhook, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/webhooks", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhooks []*model.BoardWebhook
+	if resp := decodeJSON(r, &webhooks); resp.Error != nil {
+		return nil, resp
+	}
+	return webhooks, BuildResponse(r)
+}
+
+func (c *Client) CreateBoardWebhook(boardID string, webhook *model.BoardWebhook) (*model.BoardWebhook, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/webhooks", toJSON(webhook))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.BoardWebhook
+	if resp := decodeJSON(r, &created); resp.Error != nil {
+		return nil, resp
+	}
+	return created, BuildResponse(r)
+}
+
+func (c *Client) PatchBoardWebhook(boardID, webhookID string, patch *model.BoardWebhookPatch) (*model.BoardWebhook, *Response) {
+	r, err := c.DoAPIPatch(c.GetBoardRoute(boardID)+"/webhooks/"+webhookID, toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var patched *model.BoardWebhook
+	if resp := decodeJSON(r, &patched); resp.Error != nil {
+		return nil, resp
+	}
+	return patched, BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardWebhook(boardID, webhookID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/webhooks/"+webhookID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetBoardKey(boardID string) (string, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/key", "")
+	if err != nil {
+		return "", BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var request model.BoardKeyRequest
+	if resp := decodeJSON(r, &request); resp.Error != nil {
+		return "", resp
+	}
+	return request.Key, BuildResponse(r)
+}
+
+func (c *Client) SetBoardKey(boardID, key string) (bool, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/key", toJSON(&model.BoardKeyRequest{Key: key}))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetCardPermalink(boardKey string, number int64) (*model.CardPermalink, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/cards/%d", c.GetBoardRoute(boardKey), number), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var permalink *model.CardPermalink
+	if resp := decodeJSON(r, &permalink); resp.Error != nil {
+		return nil, resp
+	}
+	return permalink, BuildResponse(r)
+}
+
+func (c *Client) GetCardsWithPropertyValues(boardID, propertyID string, values []string) ([]model.Block, *Response) {
+	query := url.Values{"property_id": {propertyID}, "value": values}
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/cards?%s", c.GetBoardRoute(boardID), query.Encode()), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) CreateCardsInBulk(boardID string, request *model.BulkCardsRequest) ([]model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/bulk", toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) ResolveCards(boardID string, cardIDs []string) ([]model.CardLink, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/resolve", toJSON(model.ResolveCardsRequest{CardIDs: cardIDs}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var references []model.CardLink
+	if resp := decodeJSON(r, &references); resp.Error != nil {
+		return nil, resp
+	}
+	return references, BuildResponse(r)
+}
+
+func (c *Client) GetCardGroups(boardID, groupByPropertyID, sumPropertyID, viewID string) ([]*model.CardGroup, *Response) {
+	query := url.Values{}
+	if groupByPropertyID != "" {
+		query.Set("group_by", groupByPropertyID)
+	}
+	if sumPropertyID != "" {
+		query.Set("sum", sumPropertyID)
+	}
+	if viewID != "" {
+		query.Set("view_id", viewID)
+	}
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/card-groups?%s", c.GetBoardRoute(boardID), query.Encode()), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var groups []*model.CardGroup
+	if resp := decodeJSON(r, &groups); resp.Error != nil {
+		return nil, resp
+	}
+	return groups, BuildResponse(r)
+}
+
+func (c *Client) GetSwimlanes(boardID, rowPropertyID, columnPropertyID string) (*model.SwimlaneMatrix, *Response) {
+	query := url.Values{"row_by": {rowPropertyID}, "column_by": {columnPropertyID}}
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/swimlanes?%s", c.GetBoardRoute(boardID), query.Encode()), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var matrix *model.SwimlaneMatrix
+	if resp := decodeJSON(r, &matrix); resp.Error != nil {
+		return nil, resp
+	}
+	return matrix, BuildResponse(r)
+}
+
+func (c *Client) GetBoardSnapshots(boardID, from, to string) ([]*model.BoardSnapshot, *Response) {
+	query := url.Values{}
+	if from != "" {
+		query.Set("from", from)
+	}
+	if to != "" {
+		query.Set("to", to)
+	}
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/snapshots?%s", c.GetBoardRoute(boardID), query.Encode()), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var snapshots []*model.BoardSnapshot
+	if resp := decodeJSON(r, &snapshots); resp.Error != nil {
+		return nil, resp
+	}
+	return snapshots, BuildResponse(r)
+}
+
+func (c *Client) GetStaleCards(boardID string, days int, exclude []string) ([]*model.StaleCard, *Response) {
+	query := url.Values{"exclude": exclude}
+	if days != 0 {
+		query.Set("days", strconv.Itoa(days))
+	}
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/stale-cards?%s", c.GetBoardRoute(boardID), query.Encode()), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var cards []*model.StaleCard
+	if resp := decodeJSON(r, &cards); resp.Error != nil {
+		return nil, resp
+	}
+	return cards, BuildResponse(r)
+}
+
+func (c *Client) GetBoardWorkload(boardID string, query model.WorkloadQuery) (*model.Workload, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/workload?%s", c.GetBoardRoute(boardID), workloadValues(query).Encode()), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var workload *model.Workload
+	if resp := decodeJSON(r, &workload); resp.Error != nil {
+		return nil, resp
+	}
+	return workload, BuildResponse(r)
+}
+
+func (c *Client) GetTeamWorkload(teamID string, query model.WorkloadQuery) (*model.Workload, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/workload?%s", c.GetTeamRoute(teamID), workloadValues(query).Encode()), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var workload *model.Workload
+	if resp := decodeJSON(r, &workload); resp.Error != nil {
+		return nil, resp
+	}
+	return workload, BuildResponse(r)
+}
+
+func workloadValues(query model.WorkloadQuery) url.Values {
+	values := url.Values{"exclude": query.Exclude}
+	if query.EstimateProperty != "" {
+		values.Set("estimate", query.EstimateProperty)
+	}
+	if query.From != 0 {
+		values.Set("from", strconv.FormatInt(query.From, 10))
+	}
+	if query.To != 0 {
+		values.Set("to", strconv.FormatInt(query.To, 10))
+	}
+	return values
+}
+
+func (c *Client) GetViewCards(boardID, viewID string, offset, limit int) (*model.ViewCards, *Response) {
+	query := url.Values{"offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/views/%s/cards?%s", c.GetBoardRoute(boardID), viewID, query.Encode()), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var viewCards *model.ViewCards
+	if resp := decodeJSON(r, &viewCards); resp.Error != nil {
+		return nil, resp
+	}
+	return viewCards, BuildResponse(r)
+}
+
+func (c *Client) InviteToBoard(boardID string, request *model.BoardInvitationRequest) (*model.BoardInvitation, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/invitations", toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var invitation *model.BoardInvitation
+	if err := json.NewDecoder(r.Body).Decode(&invitation); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return invitation, BuildResponse(r)
+}
+
+func (c *Client) GetBoardInvitations(boardID string) ([]*model.BoardInvitation, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/invitations", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var invitations []*model.BoardInvitation
+	if err := json.NewDecoder(r.Body).Decode(&invitations); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return invitations, BuildResponse(r)
+}
+
+func (c *Client) GetMyInvitations() ([]*model.BoardInvitation, *Response) {
+	r, err := c.DoAPIGet("/users/me/invitations", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var invitations []*model.BoardInvitation
+	if err := json.NewDecoder(r.Body).Decode(&invitations); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return invitations, BuildResponse(r)
+}
+
+func (c *Client) AcceptBoardInvitation(boardID string) (*model.BoardMember, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/invitations/accept", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DeclineBoardInvitation(boardID string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/invitations/decline", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) CancelBoardInvitation(boardID, userID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/invitations/"+userID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) RequestBoardAccess(boardID, message string) (*model.BoardAccessRequest, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/access-requests", toJSON(&model.BoardAccessRequestBody{Message: message}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var request *model.BoardAccessRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return request, BuildResponse(r)
+}
+
+func (c *Client) GetBoardAccessRequests(boardID string) ([]*model.BoardAccessRequest, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/access-requests", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var requests []*model.BoardAccessRequest
+	if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return requests, BuildResponse(r)
+}
+
+func (c *Client) ApproveBoardAccessRequest(boardID, userID string, approval *model.BoardAccessApproval) (*model.BoardMember, *Response) {
+	body := ""
+	if approval != nil {
+		body = toJSON(approval)
+	}
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/access-requests/"+userID+"/approve", body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DenyBoardAccessRequest(boardID, userID string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/access-requests/"+userID+"/deny", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) SendEmailInvitation(request *model.EmailInvitationRequest) (bool, *Response) {
+	r, err := c.DoAPIPost("/invitations/email", toJSON(request))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) LinkBoardToChannel(boardID, channelID string) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/channel", toJSON(&model.ChannelLinkRequest{ChannelID: channelID}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) UnlinkBoardFromChannel(boardID string) (*model.Board, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/channel", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetBoardsForChannel(channelID string) ([]*model.Board, *Response) {
+	r, err := c.DoAPIGet("/channels/"+channelID+"/boards", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+}
+
+// The bot action API identifies the acting integration through the
+// model.HeaderIntegrationName header, which can be set in HTTPHeader.
+
+func (c *Client) BotCreateCard(boardID string, request *model.BotCreateCardRequest) (*model.Block, *Response) {
+	r, err := c.DoAPIPost("/bot/boards/"+boardID+"/cards", toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return decodeBlock(r)
+}
+
+func (c *Client) BotAddComment(cardID, text string) (*model.Block, *Response) {
+	r, err := c.DoAPIPost("/bot/cards/"+cardID+"/comments", toJSON(&model.BotCommentRequest{Text: text}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return decodeBlock(r)
+}
+
+func (c *Client) BotSetCardProperties(cardID string, properties map[string]string) (*model.Block, *Response) {
+	r, err := c.DoAPIPatch("/bot/cards/"+cardID+"/properties", toJSON(&model.BotSetPropertiesRequest{Properties: properties}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return decodeBlock(r)
+}
+
+func decodeBlock(r *http.Response) (*model.Block, *Response) {
+	var block *model.Block
+	if resp := decodeJSON(r, &block); resp.Error != nil {
+		return nil, resp
+	}
+	return block, BuildResponse(r)
+}
+
+// decodeJSON decodes the body of the response into v.
+func decodeJSON(r *http.Response, v interface{}) *Response {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	return BuildResponse(r)
+}
+
+func (c *Client) GetTeamUploadFileRoute(teamID, boardID string) string {
+	return fmt.Sprintf("%s/%s/files", c.GetTeamRoute(teamID), boardID)
+}
+
+func (c *Client) TeamUploadFile(teamID, boardID string, data io.Reader) (*api.FileUploadResponse, *Response) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
+	if err != nil {
+		return nil, &Response{Error: err}
+	}
+	if _, err = io.Copy(part, data); err != nil {
+		return nil, &Response{Error: err}
+	}
+	writer.Close()
+
+	opt := func(r *http.Request) {
+		r.Header.Add("Content-Type", writer.FormDataContentType())
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamUploadFileRoute(teamID, boardID), body, "", opt)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	fileUploadResponse, err := api.FileUploadResponseFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return fileUploadResponse, BuildResponse(r)
+}
+
+func (c *Client) GetSubscriptionsRoute() string {
+	return "/subscriptions"
+}
+
+func (c *Client) CreateSubscription(sub *model.Subscription) (*model.Subscription, *Response) {
+	r, err := c.DoAPIPost(c.GetSubscriptionsRoute(), toJSON(&sub))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	subNew, err := model.SubscriptionFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return subNew, BuildResponse(r)
+}
+
+func (c *Client) DeleteSubscription(blockID string, subscriberID string) *Response {
+	url := fmt.Sprintf("%s/%s/%s", c.GetSubscriptionsRoute(), blockID, subscriberID)
+
+	r, err := c.DoAPIDelete(url, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetSubscriptions(subscriberID string) ([]*model.Subscription, *Response) {
+	url := fmt.Sprintf("%s/%s", c.GetSubscriptionsRoute(), subscriberID)
+
+	r, err := c.DoAPIGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var subs []*model.Subscription
+	err = json.NewDecoder(r.Body).Decode(&subs)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return subs, BuildResponse(r)
+}
+
+func (c *Client) GetSubscriptionsPage(subscriberID string, page, perPage int) ([]*model.Subscription, *Response) {
+	url := fmt.Sprintf("%s/%s?page=%d&per_page=%d", c.GetSubscriptionsRoute(), subscriberID, page, perPage)
+
+	r, err := c.DoAPIGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var subs []*model.Subscription
+	err = json.NewDecoder(r.Body).Decode(&subs)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return subs, BuildResponse(r)
+}
+
+func (c *Client) GetTemplatesForTeam(teamID string) ([]*model.Board, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/templates", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) ExportBoardArchive(boardID string) ([]byte, *Response) {
+	return c.exportBoardArchive(boardID, "")
+}
+
+// ExportBoardArchiveWithPermissions exports an archive of a board that
+// includes its permissions.
+func (c *Client) ExportBoardArchiveWithPermissions(boardID string) ([]byte, *Response) {
+	return c.exportBoardArchive(boardID, "?permissions=true")
+}
+
+func (c *Client) exportBoardArchive(boardID, query string) ([]byte, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/archive/export"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return buf, BuildResponse(r)
+}
+
+// ExportBoardCards exports the cards of a board as a CSV or markdown
+// table. The view and the filter, if set, select the exported cards.
+func (c *Client) ExportBoardCards(boardID, format, viewID string, filter *model.CardFilter) ([]byte, *Response) {
+	query := url.Values{"format": {format}}
+	if viewID != "" {
+		query.Set("view_id", viewID)
+	}
+	if filter != nil {
+		query.Set("filter", toJSON(filter))
+	}
+
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/archive/export?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return buf, BuildResponse(r)
+}
+
+// GetAccessReviewReport returns the access review report of the team as
+// CSV.
+func (c *Client) GetAccessReviewReport(teamID string) ([]byte, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/access-review", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return buf, BuildResponse(r)
+}
+
+func (c *Client) ImportArchive(teamID string, data io.Reader) *Response {
+	return c.importArchive(teamID, data, "")
+}
+
+// ImportArchiveWithPermissions imports an archive and applies the
+// permissions of its boards to the imported boards.
+func (c *Client) ImportArchiveWithPermissions(teamID string, data io.Reader) *Response {
+	return c.importArchive(teamID, data, "?permissions=true")
+}
+
+func (c *Client) importArchive(teamID string, data io.Reader, query string) *Response {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
+	if err != nil {
+		return &Response{Error: err}
+	}
+	if _, err = io.Copy(part, data); err != nil {
+		return &Response{Error: err}
+	}
+	writer.Close()
+
+	opt := func(r *http.Request) {
+		r.Header.Add("Content-Type", writer.FormDataContentType())
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamRoute(teamID)+"/archive/import"+query, body, "", opt)
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+// CopyBoardToRemote copies a board to a team of another server.
+func (c *Client) CopyBoardToRemote(boardID string, request *model.RemoteBoardCopyRequest) (*model.RemoteBoardCopyResult, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/archive/copy", toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.RemoteBoardCopyResult
+	if resp := decodeJSON(r, &result); resp.Error != nil {
+		return nil, resp
+	}
+	return result, BuildResponse(r)
+}
+
+// GetBlocksOptions filters the blocks returned by GetBlocksForBoardWithOptions.
+type GetBlocksOptions struct {
+	// Only return the children of this block
+	ParentID string
+
+	// Only return blocks of this type
+	Type model.BlockType
+
+	// Only return this block
+	BlockID string
+
+	// Also return the descendants of BlockID up to this many levels
+	// below it
+	Depth int
+
+	// Return all the blocks of the board
+	All bool
+}
+
+func (o GetBlocksOptions) query() string {
+	values := url.Values{}
+	if o.ParentID != "" {
+		values.Set("parent_id", o.ParentID)
+	}
+	if o.Type != "" {
+		values.Set("type", string(o.Type))
+	}
+	if o.BlockID != "" {
+		values.Set("block_id", o.BlockID)
+	}
+	if o.Depth > 0 {
+		values.Set("depth", strconv.Itoa(o.Depth))
+	}
+	if o.All {
+		values.Set("all", "true")
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	return "?" + values.Encode()
+}
+
+func (c *Client) GetBlocksForBoardWithOptions(boardID string, opts GetBlocksOptions) ([]model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetBlocksRoute(boardID)+opts.query(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) PatchBlocks(boardID string, patches *model.BlockPatchBatch) (bool, *Response) {
+	r, err := c.DoAPIPatch(c.GetBlocksRoute(boardID), toJSON(patches))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetMyWorkWithQuery(teamID string, query model.MyWorkQuery) ([]*model.MyWorkCard, *Response) {
+	values := url.Values{}
+	values.Set("teamID", teamID)
+	if query.DueBefore != 0 {
+		values.Set("dueBefore", strconv.FormatInt(query.DueBefore, 10))
+	}
+	if query.DueAfter != 0 {
+		values.Set("dueAfter", strconv.FormatInt(query.DueAfter, 10))
+	}
+	for _, status := range query.Statuses {
+		values.Add("status", status)
+	}
+	if query.DueToday {
+		values.Set("dueToday", "true")
+	}
+
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/cards?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var cards []*model.MyWorkCard
+	if resp := decodeJSON(r, &cards); resp.Error != nil {
+		return nil, resp
+	}
+	return cards, BuildResponse(r)
+}
+
+// GetMentionsOptions filters and pages the mentions returned by
+// GetMyMentionsWithOptions.
+type GetMentionsOptions struct {
+	// Only return the mentions that haven't been read
+	UnreadOnly bool
+
+	// Only return the mentions created before this time, in miliseconds
+	Before int64
+
+	// Maximum number of mentions to return, the server default if zero
+	Limit int
+}
+
+func (c *Client) GetMyMentionsWithOptions(opts GetMentionsOptions) ([]*model.Mention, *Response) {
+	values := url.Values{}
+	values.Set("unread", strconv.FormatBool(opts.UnreadOnly))
+	if opts.Before != 0 {
+		values.Set("before", strconv.FormatInt(opts.Before, 10))
+	}
+	if opts.Limit != 0 {
+		values.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/mentions?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var mentions []*model.Mention
+	if resp := decodeJSON(r, &mentions); resp.Error != nil {
+		return nil, resp
+	}
+	return mentions, BuildResponse(r)
+}
+
+// GetAllMyMentions pages through the mentions of the user, newest first,
+// requesting up to perPage mentions each time.
+func (c *Client) GetAllMyMentions(unreadOnly bool, perPage int) ([]*model.Mention, *Response) {
+	all := []*model.Mention{}
+	opts := GetMentionsOptions{UnreadOnly: unreadOnly, Limit: perPage}
+	for {
+		mentions, resp := c.GetMyMentionsWithOptions(opts)
+		if resp.Error != nil {
+			return nil, resp
+		}
+		all = append(all, mentions...)
+		if len(mentions) == 0 || len(mentions) < perPage {
+			return all, resp
+		}
+		opts.Before = mentions[len(mentions)-1].CreateAt
+	}
+}
+
+// GetAllSubscriptions pages through the subscriptions of the subscriber,
+// requesting up to perPage subscriptions each time.
+func (c *Client) GetAllSubscriptions(subscriberID string, perPage int) ([]*model.Subscription, *Response) {
+	all := []*model.Subscription{}
+	for page := 0; ; page++ {
+		subs, resp := c.GetSubscriptionsPage(subscriberID, page, perPage)
+		if resp.Error != nil {
+			return nil, resp
+		}
+		all = append(all, subs...)
+		if len(subs) < perPage {
+			return all, resp
+		}
+	}
+}
+
+func (c *Client) GetMyMemberships() ([]*model.BoardMember, *Response) {
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/memberships", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMembersFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) UpdateUserConfig(userID string, patch *model.UserPropPatch) (map[string]interface{}, *Response) {
+	r, err := c.DoAPIPut(c.GetUserRoute(userID)+"/config", toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var config map[string]interface{}
+	if resp := decodeJSON(r, &config); resp.Error != nil {
+		return nil, resp
+	}
+	return config, BuildResponse(r)
+}
+
+func (c *Client) Logout() (bool, *Response) {
+	r, err := c.DoAPIPost("/logout", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	c.Token = ""
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetClientConfig() (*model.ClientConfig, *Response) {
+	r, err := c.DoAPIGet("/clientConfig", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var config *model.ClientConfig
+	if resp := decodeJSON(r, &config); resp.Error != nil {
+		return nil, resp
+	}
+	return config, BuildResponse(r)
+}
+
+func (c *Client) GetTeams() ([]*model.Team, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamsRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var teams []*model.Team
+	if resp := decodeJSON(r, &teams); resp.Error != nil {
+		return nil, resp
+	}
+	return teams, BuildResponse(r)
+}
+
+func (c *Client) GetTeamUsers(teamID, search string) ([]*model.User, *Response) {
+	route := c.GetTeamRoute(teamID) + "/users"
+	if search != "" {
+		route += "?search=" + url.QueryEscape(search)
+	}
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var users []*model.User
+	if resp := decodeJSON(r, &users); resp.Error != nil {
+		return nil, resp
+	}
+	return users, BuildResponse(r)
+}
+
+func (c *Client) RegenerateTeamSignupToken(teamID string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamRoute(teamID)+"/regenerate_signup_token", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) ExportTeamArchive(teamID string) ([]byte, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/archive/export", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return buf, BuildResponse(r)
+}
+
+// Onboard prepares the onboarding tour of the user on the team, and
+// returns the team and board of the tour.
+func (c *Client) Onboard(teamID string) (string, string, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamRoute(teamID)+"/onboard", "")
+	if err != nil {
+		return "", "", BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var response map[string]string
+	if resp := decodeJSON(r, &response); resp.Error != nil {
+		return "", "", resp
+	}
+	return response["teamID"], response["boardID"], BuildResponse(r)
+}
+
+func (c *Client) GetCategoriesRoute(teamID string) string {
+	return c.GetTeamRoute(teamID) + "/categories"
+}
+
+func (c *Client) GetUserCategoryBoards(teamID string) ([]model.CategoryBoards, *Response) {
+	r, err := c.DoAPIGet(c.GetCategoriesRoute(teamID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var categoryBoards []model.CategoryBoards
+	if resp := decodeJSON(r, &categoryBoards); resp.Error != nil {
+		return nil, resp
+	}
+	return categoryBoards, BuildResponse(r)
+}
+
+func (c *Client) CreateCategory(category model.Category) (*model.Category, *Response) {
+	r, err := c.DoAPIPost(c.GetCategoriesRoute(category.TeamID), toJSON(category))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.Category
+	if resp := decodeJSON(r, &created); resp.Error != nil {
+		return nil, resp
+	}
+	return created, BuildResponse(r)
+}
+
+func (c *Client) UpdateCategory(category model.Category) (*model.Category, *Response) {
+	r, err := c.DoAPIPut(c.GetCategoriesRoute(category.TeamID)+"/"+category.ID, toJSON(category))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updated *model.Category
+	if resp := decodeJSON(r, &updated); resp.Error != nil {
+		return nil, resp
+	}
+	return updated, BuildResponse(r)
+}
+
+func (c *Client) DeleteCategory(teamID, categoryID string) (*model.Category, *Response) {
+	r, err := c.DoAPIDelete(c.GetCategoriesRoute(teamID)+"/"+categoryID, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var deleted *model.Category
+	if resp := decodeJSON(r, &deleted); resp.Error != nil {
+		return nil, resp
+	}
+	return deleted, BuildResponse(r)
+}
+
+func (c *Client) UpdateCategoryBoard(teamID, categoryID, boardID string) (bool, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/%s/boards/%s", c.GetCategoriesRoute(teamID), categoryID, boardID), "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) UpdateCategoryBoards(teamID, categoryID string, boardIDs []string) (bool, *Response) {
+	update := model.CategoryBoardsUpdate{BoardIDs: boardIDs}
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/%s/boards", c.GetCategoriesRoute(teamID), categoryID), toJSON(update))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) ReorderCategories(teamID string, newCategoryOrder []string) ([]string, *Response) {
+	r, err := c.DoAPIPut(c.GetCategoriesRoute(teamID)+"/reorder", toJSON(newCategoryOrder))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var categoryOrder []string
+	if resp := decodeJSON(r, &categoryOrder); resp.Error != nil {
+		return nil, resp
+	}
+	return categoryOrder, BuildResponse(r)
+}
+
+func (c *Client) GetCategoryRulesRoute(teamID string) string {
+	return c.GetTeamRoute(teamID) + "/category-rules"
+}
+
+func (c *Client) GetCategoryRules(teamID string) ([]*model.CategoryRule, *Response) {
+	r, err := c.DoAPIGet(c.GetCategoryRulesRoute(teamID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var rules []*model.CategoryRule
+	if resp := decodeJSON(r, &rules); resp.Error != nil {
+		return nil, resp
+	}
+	return rules, BuildResponse(r)
+}
+
+func (c *Client) CreateCategoryRule(rule *model.CategoryRule) (*model.CategoryRule, *Response) {
+	r, err := c.DoAPIPost(c.GetCategoryRulesRoute(rule.TeamID), toJSON(rule))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.CategoryRule
+	if resp := decodeJSON(r, &created); resp.Error != nil {
+		return nil, resp
+	}
+	return created, BuildResponse(r)
+}
+
+func (c *Client) DeleteCategoryRule(teamID, ruleID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetCategoryRulesRoute(teamID)+"/"+ruleID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+// SendIncomingWebhook creates a card through an incoming webhook, signing
+// the request with the secret of the webhook.
+func (c *Client) SendIncomingWebhook(hookID, secret string, request *model.BotCreateCardRequest) (*model.Block, *Response) {
+	body := []byte(toJSON(request))
+	timestamp := time.Now().Unix()
+	sign := func(rq *http.Request) {
+		rq.Header.Set(model.HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+		rq.Header.Set(model.HeaderWebhookSignature, model.SignWebhookPayload(secret, timestamp, body))
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+"/hooks/"+hookID, bytes.NewReader(body), "", sign)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return decodeBlock(r)
+}
+
+// SendGitHubWebhook sends a GitHub webhook event to the GitHub
+// integration of a board, signed as GitHub signs it.
+func (c *Client) SendGitHubWebhook(boardID, secret, event string, payload interface{}) (bool, *Response) {
+	body := []byte(toJSON(payload))
+	sign := func(rq *http.Request) {
+		rq.Header.Set(github.HeaderEvent, event)
+		rq.Header.Set(github.HeaderSignature, github.Sign(secret, body))
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+"/hooks/github/"+boardID, bytes.NewReader(body), "", sign)
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+// VerifyWebhookRequest checks the signature of a request received from a
+// Focalboard outgoing webhook, and returns its body. Requests signed more
+// than tolerance ago are rejected; a zero tolerance uses
+// model.DefaultWebhookTimestampTolerance.
+func VerifyWebhookRequest(r *http.Request, secret string, tolerance time.Duration) ([]byte, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	err = model.VerifyWebhookSignature(secret,
+		r.Header.Get(model.HeaderWebhookSignature),
+		r.Header.Get(model.HeaderWebhookTimestamp),
+		body, tolerance, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// ChangeStream reads the events of the change stream of a team.
+type ChangeStream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+}
+
+// ChangeStreamEvent is an event read from a change stream. Reset is set
+// when the changes missed since the last event are no longer available,
+// and the state of the boards must be reloaded.
+type ChangeStreamEvent struct {
+	ID     string
+	Reset  bool
+	Change *model.ChangeEvent
+}
+
+// OpenChangeStream opens the change stream of the team, resuming after
+// lastEventID if it is set.
+func (c *Client) OpenChangeStream(teamID, lastEventID string) (*ChangeStream, *Response) {
+	setLastEventID := func(rq *http.Request) {
+		if lastEventID != "" {
+			rq.Header.Set(api.HeaderLastEventID, lastEventID)
+		}
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodGet, c.APIURL+c.GetTeamRoute(teamID)+"/changes", nil, "", setLastEventID)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return &ChangeStream{body: r.Body, reader: bufio.NewReader(r.Body)}, BuildResponse(r)
+}
+
+// Next blocks until the next event of the stream.
+func (cs *ChangeStream) Next() (*ChangeStreamEvent, error) {
+	event := &ChangeStreamEvent{}
+	var name, data string
+
+	for {
+		line, err := cs.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "":
+			if name == "" && data == "" {
+				// end of a keep alive comment
+				continue
+			}
+			if name == api.ChangesEventReset {
+				event.Reset = true
+				return event, nil
+			}
+			var change model.ChangeEvent
+			if err := json.Unmarshal([]byte(data), &change); err != nil {
+				return nil, err
+			}
+			event.Change = &change
+			return event, nil
+		case strings.HasPrefix(line, "id: "):
+			event.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data += strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+// Close closes the stream.
+func (cs *ChangeStream) Close() error {
+	return cs.body.Close()
+}
+
+// LongPoll waits for the changes of the boards of a team that followed
+// the cursor, for at most timeout. An empty cursor returns the cursor to
+// start from; an empty list of boards receives the changes of all the
+// boards of the team the user can see.
+func (c *Client) LongPoll(teamID, cursor string, boardIDs []string, timeout time.Duration) (*model.LongPollResponse, *Response) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if len(boardIDs) > 0 {
+		query.Set("boardIds", strings.Join(boardIDs, ","))
+	}
+	query.Set("timeout", strconv.Itoa(int(timeout/time.Second)))
+
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/poll?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var response *model.LongPollResponse
+	if resp := decodeJSON(r, &response); resp.Error != nil {
+		return nil, resp
+	}
+	return response, BuildResponse(r)
+}
+
+// Sync returns the changes of the boards since their last sync.
+func (c *Client) Sync(request *model.SyncRequest) (*model.SyncResponse, *Response) {
+	r, err := c.DoAPIPost("/sync", toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var response *model.SyncResponse
+	if resp := decodeJSON(r, &response); resp.Error != nil {
+		return nil, resp
+	}
+	return response, BuildResponse(r)
+}
+
+func (c *Client) integrationRequest(method, route, apiKey, data string) (*http.Response, error) {
+	setAPIKey := func(rq *http.Request) {
+		if apiKey != "" {
+			rq.Header.Set(model.HeaderAPIKey, apiKey)
+		}
+	}
+	return c.doAPIRequestReader(method, c.APIURL+"/integrations"+route, strings.NewReader(data), "", setAPIKey)
+}
+
+// GetIntegrationMe returns the user of an API key of the integration API.
+func (c *Client) GetIntegrationMe(apiKey string) (*model.IntegrationUser, *Response) {
+	r, err := c.integrationRequest(http.MethodGet, "/me", apiKey, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var user *model.IntegrationUser
+	if resp := decodeJSON(r, &user); resp.Error != nil {
+		return nil, resp
+	}
+	return user, BuildResponse(r)
+}
+
+// GetIntegrationNewCards polls the cards of a board created after the
+// cursor through the integration API.
+func (c *Client) GetIntegrationNewCards(apiKey, boardID, cursor string, limit int) ([]model.IntegrationCard, *Response) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	r, err := c.integrationRequest(http.MethodGet, "/boards/"+boardID+"/cards/new?"+query.Encode(), apiKey, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var cards []model.IntegrationCard
+	if resp := decodeJSON(r, &cards); resp.Error != nil {
+		return nil, resp
+	}
+	return cards, BuildResponse(r)
+}
+
+// GetIntegrationStatusChanges polls the status changes of the cards of a
+// board made after the cursor through the integration API.
+func (c *Client) GetIntegrationStatusChanges(apiKey, boardID, cursor string, limit int) ([]model.IntegrationStatusChange, *Response) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	r, err := c.integrationRequest(http.MethodGet, "/boards/"+boardID+"/cards/status-changes?"+query.Encode(), apiKey, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var changes []model.IntegrationStatusChange
+	if resp := decodeJSON(r, &changes); resp.Error != nil {
+		return nil, resp
+	}
+	return changes, BuildResponse(r)
+}
+
+// CreateIntegrationCard creates a card on a board through the integration
+// API.
+func (c *Client) CreateIntegrationCard(apiKey, boardID string, request *model.IntegrationCardRequest) (*model.IntegrationCard, *Response) {
+	r, err := c.integrationRequest(http.MethodPost, "/boards/"+boardID+"/cards", apiKey, toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.IntegrationCard
+	if resp := decodeJSON(r, &card); resp.Error != nil {
+		return nil, resp
+	}
+	return card, BuildResponse(r)
+}
+
+// UpdateIntegrationCard changes the title and properties of a card through
+// the integration API.
+func (c *Client) UpdateIntegrationCard(apiKey, cardID string, request *model.IntegrationCardRequest) (*model.IntegrationCard, *Response) {
+	r, err := c.integrationRequest(http.MethodPatch, "/cards/"+cardID, apiKey, toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.IntegrationCard
+	if resp := decodeJSON(r, &card); resp.Error != nil {
+		return nil, resp
+	}
+	return card, BuildResponse(r)
 }
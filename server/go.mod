@@ -19,6 +19,7 @@ require (
 	github.com/mattermost/morph v0.0.0-20220324143723-e4896385ec60
 	github.com/mattn/go-sqlite3 v2.0.3+incompatible
 	github.com/minio/minio-go/v7 v7.0.23 // indirect
+	github.com/nats-io/nats.go v1.16.0
 	github.com/oklog/run v1.1.0
 	github.com/pkg/errors v0.9.1
 	github.com/prometheus/client_golang v1.12.1
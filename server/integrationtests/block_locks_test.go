@@ -0,0 +1,96 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockLocks(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+		UserID:       th.GetUser2().ID,
+		BoardID:      board.ID,
+		SchemeEditor: true,
+	})
+	th.CheckOK(resp)
+
+	blocks, resp := th.Client.InsertBlocks(board.ID, []model.Block{{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+		Title:    "Card",
+	}})
+	th.CheckOK(resp)
+	blockID := blocks[0].ID
+
+	title := "Edited"
+	patch := &model.BlockPatch{Title: &title}
+
+	t.Run("the lock holder edits the block", func(t *testing.T) {
+		lock, resp := th.Client.AcquireBlockLock(board.ID, blockID, 0)
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser1().ID, lock.UserID)
+		require.Equal(t, board.ID, lock.BoardID)
+
+		locks, resp := th.Client2.GetBlockLocks(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, locks, 1)
+		require.Equal(t, blockID, locks[0].BlockID)
+
+		_, resp = th.Client.PatchBlock(board.ID, blockID, patch)
+		th.CheckOK(resp)
+	})
+
+	t.Run("another user is warned about the lock", func(t *testing.T) {
+		_, resp := th.Client2.AcquireBlockLock(board.ID, blockID, 0)
+		th.CheckLocked(resp)
+
+		_, resp = th.Client2.PatchBlock(board.ID, blockID, patch)
+		th.CheckLocked(resp)
+
+		_, resp = th.Client2.PatchBlocks(board.ID, &model.BlockPatchBatch{
+			BlockIDs:     []string{blockID},
+			BlockPatches: []model.BlockPatch{*patch},
+		})
+		th.CheckLocked(resp)
+	})
+
+	t.Run("the lock can be overridden", func(t *testing.T) {
+		_, resp := th.Client2.ForcePatchBlock(board.ID, blockID, patch)
+		th.CheckOK(resp)
+	})
+
+	t.Run("only the holder releases the lock", func(t *testing.T) {
+		_, resp := th.Client2.ReleaseBlockLock(board.ID, blockID)
+		th.CheckOK(resp)
+		locks, resp := th.Client.GetBlockLocks(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, locks, 1)
+
+		_, resp = th.Client.ReleaseBlockLock(board.ID, blockID)
+		th.CheckOK(resp)
+		locks, resp = th.Client.GetBlockLocks(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, locks)
+
+		_, resp = th.Client2.PatchBlock(board.ID, blockID, patch)
+		th.CheckOK(resp)
+	})
+
+	t.Run("invalid ttl or block", func(t *testing.T) {
+		_, resp := th.Client.AcquireBlockLock(board.ID, blockID, model.BlockLockMaxTTL+1)
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.AcquireBlockLock(board.ID, "missing-id", 0)
+		th.CheckNotFound(resp)
+	})
+}
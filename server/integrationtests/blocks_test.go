@@ -392,3 +392,899 @@ func TestUndeleteBlock(t *testing.T) {
 		require.Len(t, blocks, initialCount)
 	})
 }
+
+func TestMoveCard(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	fromBoard := th.CreateBoard("team-id", model.BoardTypeOpen)
+	toBoard := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  fromBoard.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+		Title:    "card to move",
+	}
+	newBlocks, resp := th.Client.InsertBlocks(fromBoard.ID, []model.Block{card})
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 1)
+	cardID := newBlocks[0].ID
+
+	comment := model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  fromBoard.ID,
+		ParentID: cardID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeComment,
+		Title:    "a comment",
+	}
+	_, resp = th.Client.InsertBlocks(fromBoard.ID, []model.Block{comment})
+	require.NoError(t, resp.Error)
+
+	t.Run("missing destination board", func(t *testing.T) {
+		_, resp := th.Client.MoveCard(cardID, "")
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("move the card and its comments", func(t *testing.T) {
+		moved, resp := th.Client.MoveCard(cardID, toBoard.ID)
+		th.CheckOK(resp)
+		require.Len(t, moved, 2)
+
+		fromBlocks, resp := th.Client.GetBlocksForBoard(fromBoard.ID)
+		require.NoError(t, resp.Error)
+		for _, block := range fromBlocks {
+			require.NotEqual(t, cardID, block.ID)
+			require.NotEqual(t, cardID, block.ParentID)
+		}
+
+		toBlocks, resp := th.Client.GetBlocksForBoard(toBoard.ID)
+		require.NoError(t, resp.Error)
+		movedIDs := map[string]bool{}
+		for _, block := range toBlocks {
+			movedIDs[block.ID] = true
+		}
+		require.True(t, movedIDs[cardID])
+	})
+
+	t.Run("move to the same board", func(t *testing.T) {
+		_, resp := th.Client.MoveCard(cardID, toBoard.ID)
+		th.CheckBadRequest(resp)
+	})
+}
+
+func TestGetCardPreview(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypePrivate)
+
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  board.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+		Title:    "card to preview",
+	}
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, []model.Block{card})
+	require.NoError(t, resp.Error)
+	cardID := newBlocks[0].ID
+
+	t.Run("board members can preview the card", func(t *testing.T) {
+		preview, resp := th.Client.GetCardPreview(cardID, "")
+		th.CheckOK(resp)
+		require.Equal(t, cardID, preview.CardID)
+		require.Equal(t, "card to preview", preview.Title)
+		require.Equal(t, board.Title, preview.BoardTitle)
+		require.Empty(t, preview.Assignees)
+	})
+
+	t.Run("users without access to the board can't preview the card", func(t *testing.T) {
+		_, resp := th.Client2.GetCardPreview(cardID, "")
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("unknown card", func(t *testing.T) {
+		_, resp := th.Client.GetCardPreview(utils.NewID(utils.IDTypeCard), "")
+		th.CheckNotFound(resp)
+	})
+}
+
+func TestDuplicateCard(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  board.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+		Title:    "card to duplicate",
+	}
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, []model.Block{card})
+	require.NoError(t, resp.Error)
+	cardID := newBlocks[0].ID
+
+	children := []model.Block{
+		{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			ParentID: cardID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeComment,
+			Title:    "a comment",
+		},
+		{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			ParentID: cardID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCheckbox,
+			Title:    "a checkbox",
+			Fields:   map[string]interface{}{"value": true},
+		},
+	}
+	_, resp = th.Client.InsertBlocks(board.ID, children)
+	require.NoError(t, resp.Error)
+
+	t.Run("without comments", func(t *testing.T) {
+		blocks, resp := th.Client.DuplicateCard(cardID, model.DuplicateCardOptions{})
+		th.CheckOK(resp)
+		require.Len(t, blocks, 2)
+		require.NotEqual(t, cardID, blocks[0].ID)
+		require.Equal(t, model.BlockType(model.TypeCard), blocks[0].Type)
+		require.Equal(t, model.BlockType(model.TypeCheckbox), blocks[1].Type)
+		require.Equal(t, blocks[0].ID, blocks[1].ParentID)
+		require.Equal(t, false, blocks[1].Fields["value"])
+	})
+
+	t.Run("with comments and checklist state", func(t *testing.T) {
+		blocks, resp := th.Client.DuplicateCard(cardID, model.DuplicateCardOptions{
+			IncludeComments:    true,
+			KeepChecklistState: true,
+		})
+		th.CheckOK(resp)
+		require.Len(t, blocks, 3)
+		for _, block := range blocks[1:] {
+			require.Equal(t, blocks[0].ID, block.ParentID)
+			if block.Type == model.TypeCheckbox {
+				require.Equal(t, true, block.Fields["value"])
+			}
+		}
+	})
+
+	t.Run("not a card", func(t *testing.T) {
+		_, resp := th.Client.DuplicateCard(utils.NewID(utils.IDTypeCard), model.DuplicateCardOptions{})
+		th.CheckNotFound(resp)
+	})
+}
+
+func TestCreateCardsInBulk(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	t.Run("cards are created with their content", func(t *testing.T) {
+		request := &model.BulkCardsRequest{
+			Cards: []model.BulkCard{
+				{
+					Title: "first card",
+					Icon:  "🚀",
+					Content: []model.BulkCardContent{
+						{Type: model.TypeText, Title: "the description"},
+						{Type: model.TypeCheckbox, Title: "a task", Fields: map[string]interface{}{"value": true}},
+					},
+				},
+				{Title: "second card"},
+			},
+		}
+
+		blocks, resp := th.Client.CreateCardsInBulk(board.ID, request)
+		th.CheckOK(resp)
+		require.Len(t, blocks, 4)
+		require.Equal(t, "first card", blocks[0].Title)
+		require.Equal(t, blocks[0].ID, blocks[1].ParentID)
+		require.Equal(t, blocks[0].ID, blocks[2].ParentID)
+		require.Equal(t, "second card", blocks[3].Title)
+
+		cards, resp := th.Client.GetAllBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		titles := []string{}
+		for _, card := range cards {
+			titles = append(titles, card.Title)
+		}
+		require.Subset(t, titles, []string{"first card", "the description", "a task", "second card"})
+	})
+
+	t.Run("an invalid card creates none of them", func(t *testing.T) {
+		request := &model.BulkCardsRequest{
+			Cards: []model.BulkCard{
+				{Title: "valid card"},
+				{Title: "invalid card", Properties: map[string]string{"missing property": "value"}},
+			},
+		}
+
+		_, resp := th.Client.CreateCardsInBulk(board.ID, request)
+		th.CheckBadRequest(resp)
+
+		blocks, resp := th.Client.GetBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		for _, block := range blocks {
+			require.NotEqual(t, "valid card", block.Title)
+		}
+	})
+
+	t.Run("too many cards", func(t *testing.T) {
+		request := &model.BulkCardsRequest{Cards: make([]model.BulkCard, model.MaxBulkCards+1)}
+		for i := range request.Cards {
+			request.Cards[i].Title = "card"
+		}
+
+		_, resp := th.Client.CreateCardsInBulk(board.ID, request)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a user without access can't create cards", func(t *testing.T) {
+		request := &model.BulkCardsRequest{Cards: []model.BulkCard{{Title: "card"}}}
+
+		_, resp := th.Client2.CreateCardsInBulk(board.ID, request)
+		th.CheckForbidden(resp)
+	})
+}
+
+func TestGetStaleCards(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	_, resp := th.Client.CreateCardsInBulk(board.ID, &model.BulkCardsRequest{Cards: []model.BulkCard{{Title: "fresh card"}}})
+	th.CheckOK(resp)
+
+	t.Run("recently updated cards are not stale", func(t *testing.T) {
+		cards, resp := th.Client.GetStaleCards(board.ID, 1, nil)
+		th.CheckOK(resp)
+		require.Empty(t, cards)
+	})
+
+	t.Run("invalid days", func(t *testing.T) {
+		_, resp := th.Client.GetStaleCards(board.ID, model.MaxStaleCardDays+1, nil)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("invalid exclusion", func(t *testing.T) {
+		_, resp := th.Client.GetStaleCards(board.ID, 0, []string{"missing property:Done"})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a user without access can't get the cards", func(t *testing.T) {
+		_, resp := th.Client2.GetStaleCards(board.ID, 0, nil)
+		th.CheckForbidden(resp)
+	})
+}
+
+func TestGetBoardSnapshots(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board, resp := th.Client.CreateBoard(&model.Board{
+		TeamID: "team-id",
+		Type:   model.BoardTypeOpen,
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status-id",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo-id", "value": "To do"},
+					map[string]interface{}{"id": "done-id", "value": "Done"},
+				},
+			},
+		},
+	})
+	th.CheckOK(resp)
+
+	_, resp = th.Client.CreateCardsInBulk(board.ID, &model.BulkCardsRequest{Cards: []model.BulkCard{
+		{Title: "first", Properties: map[string]string{"Status": "To do"}},
+		{Title: "second", Properties: map[string]string{"Status": "Done"}},
+		{Title: "third", Properties: map[string]string{"Status": "Done"}},
+	}})
+	th.CheckOK(resp)
+
+	t.Run("the cards of today are counted", func(t *testing.T) {
+		snapshots, resp := th.Client.GetBoardSnapshots(board.ID, "", "")
+		th.CheckOK(resp)
+		require.Len(t, snapshots, 1)
+		require.Equal(t, model.BoardSnapshotDay(time.Now()), snapshots[0].Day)
+		require.Equal(t, "status-id", snapshots[0].PropertyID)
+		require.Equal(t, map[string]int64{"todo-id": 1, "done-id": 2}, snapshots[0].Counts)
+	})
+
+	t.Run("past days without snapshots", func(t *testing.T) {
+		snapshots, resp := th.Client.GetBoardSnapshots(board.ID, "2022-03-01", "2022-03-31")
+		th.CheckOK(resp)
+		require.Empty(t, snapshots)
+	})
+
+	t.Run("invalid days", func(t *testing.T) {
+		_, resp := th.Client.GetBoardSnapshots(board.ID, "March 1st", "")
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a user without access can't get the snapshots", func(t *testing.T) {
+		_, resp := th.Client2.GetBoardSnapshots(board.ID, "", "")
+		th.CheckForbidden(resp)
+	})
+}
+
+func TestWIPLimits(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board, resp := th.Client.CreateBoard(&model.Board{
+		TeamID: "team-id",
+		Type:   model.BoardTypeOpen,
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status-id",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo-id", "value": "To do"},
+					map[string]interface{}{"id": "doing-id", "value": "Doing"},
+				},
+			},
+		},
+	})
+	th.CheckOK(resp)
+
+	views, resp := th.Client.InsertBlocks(board.ID, []model.Block{{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeView,
+		Title:    "Kanban",
+		CreateAt: 1,
+		UpdateAt: 1,
+		Fields: map[string]interface{}{
+			"groupById":    "status-id",
+			"wipLimits":    map[string]interface{}{"doing-id": 1},
+			"wipLimitMode": model.WIPLimitModeHard,
+		},
+	}})
+	th.CheckOK(resp)
+	require.Len(t, views, 1)
+	viewID := views[0].ID
+
+	cards, resp := th.Client.CreateCardsInBulk(board.ID, &model.BulkCardsRequest{Cards: []model.BulkCard{
+		{Title: "doing", Properties: map[string]string{"Status": "Doing"}},
+		{Title: "todo", Properties: map[string]string{"Status": "To do"}},
+	}})
+	th.CheckOK(resp)
+	require.Len(t, cards, 2)
+	moveToDoing := &model.BlockPatch{
+		UpdatedFields: map[string]interface{}{"properties": map[string]interface{}{"status-id": "doing-id"}},
+	}
+
+	t.Run("the groups have the limit status of the view", func(t *testing.T) {
+		groups, resp := th.Client.GetCardGroups(board.ID, "", "", viewID)
+		th.CheckOK(resp)
+		for _, group := range groups {
+			if group.Value == "doing-id" {
+				require.Equal(t, int64(1), group.Limit)
+				require.Equal(t, model.WIPLimitStatusAt, group.LimitStatus)
+			} else {
+				require.Empty(t, group.LimitStatus)
+			}
+		}
+	})
+
+	t.Run("a hard limit rejects the move", func(t *testing.T) {
+		_, resp := th.Client.PatchBlock(board.ID, cards[1].ID, moveToDoing)
+		th.CheckConflict(resp)
+	})
+
+	t.Run("a soft limit accepts the move", func(t *testing.T) {
+		_, resp := th.Client.PatchBlock(board.ID, viewID, &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"wipLimitMode": model.WIPLimitModeSoft},
+		})
+		th.CheckOK(resp)
+
+		_, resp = th.Client.PatchBlock(board.ID, cards[1].ID, moveToDoing)
+		th.CheckOK(resp)
+
+		groups, resp := th.Client.GetCardGroups(board.ID, "", "", viewID)
+		th.CheckOK(resp)
+		for _, group := range groups {
+			if group.Value == "doing-id" {
+				require.Equal(t, int64(2), group.Count)
+				require.Equal(t, model.WIPLimitStatusOver, group.LimitStatus)
+			}
+		}
+	})
+}
+
+func TestGetSwimlanes(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board, resp := th.Client.CreateBoard(&model.Board{
+		TeamID: "team-id",
+		Type:   model.BoardTypeOpen,
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status-id",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo-id", "value": "To do"},
+					map[string]interface{}{"id": "done-id", "value": "Done"},
+				},
+			},
+			{
+				"id":   "priority-id",
+				"name": "Priority",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "high-id", "value": "High"},
+					map[string]interface{}{"id": "low-id", "value": "Low"},
+				},
+			},
+		},
+	})
+	th.CheckOK(resp)
+
+	cards, resp := th.Client.CreateCardsInBulk(board.ID, &model.BulkCardsRequest{Cards: []model.BulkCard{
+		{Title: "first", Properties: map[string]string{"Status": "To do", "Priority": "High"}},
+		{Title: "second", Properties: map[string]string{"Status": "Done", "Priority": "High"}},
+		{Title: "third", Properties: map[string]string{"Status": "Done", "Priority": "Low"}},
+	}})
+	th.CheckOK(resp)
+	require.Len(t, cards, 3)
+
+	t.Run("the cards are grouped by both properties", func(t *testing.T) {
+		matrix, resp := th.Client.GetSwimlanes(board.ID, "priority-id", "status-id")
+		th.CheckOK(resp)
+		require.Len(t, matrix.Rows, 3)
+		require.Len(t, matrix.Columns, 3)
+		require.Equal(t, "high-id", matrix.Rows[1].Value)
+		require.Equal(t, int64(2), matrix.Rows[1].Count)
+		require.Equal(t, "done-id", matrix.Columns[2].Value)
+		require.Equal(t, int64(2), matrix.Columns[2].Count)
+		require.Equal(t, []string{cards[0].ID}, matrix.Cells[1][1].CardIDs)
+		require.Equal(t, []string{cards[1].ID}, matrix.Cells[1][2].CardIDs)
+		require.Equal(t, []string{cards[2].ID}, matrix.Cells[2][2].CardIDs)
+		require.Empty(t, matrix.Cells[2][1].CardIDs)
+	})
+
+	t.Run("invalid property", func(t *testing.T) {
+		_, resp := th.Client.GetSwimlanes(board.ID, "priority-id", "missing-id")
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a user without access can't get the swimlanes", func(t *testing.T) {
+		_, resp := th.Client2.GetSwimlanes(board.ID, "priority-id", "status-id")
+		th.CheckForbidden(resp)
+	})
+}
+
+func TestCardAging(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board, resp := th.Client.CreateBoard(&model.Board{
+		TeamID: "team-id",
+		Type:   model.BoardTypeOpen,
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status-id",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo-id", "value": "To do"},
+					map[string]interface{}{"id": "doing-id", "value": "Doing"},
+				},
+			},
+		},
+	})
+	th.CheckOK(resp)
+
+	views, resp := th.Client.InsertBlocks(board.ID, []model.Block{{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeView,
+		Title:    "Kanban",
+		CreateAt: 1,
+		UpdateAt: 1,
+	}})
+	th.CheckOK(resp)
+	require.Len(t, views, 1)
+
+	cards, resp := th.Client.CreateCardsInBulk(board.ID, &model.BulkCardsRequest{Cards: []model.BulkCard{
+		{Title: "card", Properties: map[string]string{"Status": "To do"}},
+	}})
+	th.CheckOK(resp)
+	require.Len(t, cards, 1)
+	card := cards[0]
+
+	time.Sleep(10 * time.Millisecond)
+	_, resp = th.Client.PatchBlock(board.ID, card.ID, &model.BlockPatch{
+		UpdatedFields: map[string]interface{}{"properties": map[string]interface{}{"status-id": "doing-id"}},
+	})
+	th.CheckOK(resp)
+
+	blocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+	th.CheckOK(resp)
+	var movedAt int64
+	for _, block := range blocks {
+		if block.ID == card.ID {
+			movedAt = block.UpdateAt
+		}
+	}
+	require.Greater(t, movedAt, card.UpdateAt)
+
+	t.Run("the view cards have their time in status", func(t *testing.T) {
+		viewCards, resp := th.Client.GetViewCards(board.ID, views[0].ID, 0, model.DefaultViewCardsLimit)
+		th.CheckOK(resp)
+		require.Contains(t, viewCards.Aging, card.ID)
+		require.Equal(t, "doing-id", viewCards.Aging[card.ID].Status)
+		require.Equal(t, movedAt, viewCards.Aging[card.ID].StatusSince)
+	})
+
+	t.Run("the card preview has the time of its status", func(t *testing.T) {
+		preview, resp := th.Client.GetCardPreview(card.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, movedAt, preview.StatusSince)
+	})
+}
+
+func TestPostBlocksDetectingDuplicates(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	existing := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  board.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+		Title:    "Login button is broken",
+	}
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, []model.Block{existing})
+	require.NoError(t, resp.Error)
+	existingID := newBlocks[0].ID
+
+	newCard := func() model.Block {
+		return model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  board.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+			Title:    "Login button broken",
+		}
+	}
+
+	t.Run("board without duplicate detection", func(t *testing.T) {
+		result, resp := th.Client.InsertBlocksDetectingDuplicates(board.ID, []model.Block{newCard()})
+		require.NoError(t, resp.Error)
+		require.Len(t, result.Blocks, 1)
+		require.Empty(t, result.Duplicates)
+	})
+
+	t.Run("board with duplicate detection", func(t *testing.T) {
+		patch := &model.BoardPatch{
+			UpdatedProperties: map[string]interface{}{model.BoardPropertyDetectDuplicateCards: true},
+		}
+		_, resp := th.Client.PatchBoard(board.ID, patch)
+		require.NoError(t, resp.Error)
+
+		result, resp := th.Client.InsertBlocksDetectingDuplicates(board.ID, []model.Block{newCard()})
+		require.NoError(t, resp.Error)
+		require.Len(t, result.Blocks, 1)
+
+		suggestedIDs := []string{}
+		for _, suggestion := range result.Duplicates[result.Blocks[0].ID] {
+			suggestedIDs = append(suggestedIDs, suggestion.CardID)
+		}
+		require.Contains(t, suggestedIDs, existingID)
+		require.NotContains(t, suggestedIDs, result.Blocks[0].ID)
+	})
+}
+
+func TestGetMyWork(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	patch := &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": "person"},
+		},
+	}
+	_, resp := th.Client.PatchBoard(board.ID, patch)
+	require.NoError(t, resp.Error)
+
+	userID := th.GetUser1().ID
+	cards := []model.Block{
+		{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  board.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+			Title:    "assigned card",
+			Fields:   map[string]interface{}{"properties": map[string]interface{}{"assignee": userID}},
+		},
+		{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  board.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+			Title:    "unassigned card",
+		},
+	}
+	_, resp = th.Client.InsertBlocks(board.ID, cards)
+	require.NoError(t, resp.Error)
+
+	t.Run("the cards assigned to the user are returned", func(t *testing.T) {
+		myWork, resp := th.Client.GetMyWork("team-id")
+		th.CheckOK(resp)
+		require.Len(t, myWork, 1)
+		require.Equal(t, "assigned card", myWork[0].Card.Title)
+	})
+
+	t.Run("other users have no cards assigned", func(t *testing.T) {
+		myWork, resp := th.Client2.GetMyWork("team-id")
+		th.CheckOK(resp)
+		require.Empty(t, myWork)
+	})
+
+	t.Run("a team is required", func(t *testing.T) {
+		_, resp := th.Client.GetMyWork("")
+		th.CheckBadRequest(resp)
+	})
+}
+
+func TestMultiPersonProperty(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	patch := &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{
+			{"id": "owners", "name": "Owners", "type": "multiPerson"},
+		},
+	}
+	_, resp := th.Client.PatchBoard(board.ID, patch)
+	require.NoError(t, resp.Error)
+
+	newCard := func(title string, owners interface{}) model.Block {
+		return model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  board.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+			Title:    title,
+			Fields:   map[string]interface{}{"properties": map[string]interface{}{"owners": owners}},
+		}
+	}
+
+	t.Run("a value that is not a list of users is rejected", func(t *testing.T) {
+		_, resp := th.Client.InsertBlocks(board.ID, []model.Block{newCard("invalid card", th.GetUser1().ID)})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("the card is in the work of all its owners", func(t *testing.T) {
+		card := newCard("shared card", []interface{}{th.GetUser1().ID, th.GetUser2().ID})
+		_, resp := th.Client.InsertBlocks(board.ID, []model.Block{card})
+		th.CheckOK(resp)
+
+		myWork, resp := th.Client.GetMyWork("team-id")
+		th.CheckOK(resp)
+		require.Len(t, myWork, 1)
+		require.Equal(t, "shared card", myWork[0].Card.Title)
+
+		myWork, resp = th.Client2.GetMyWork("team-id")
+		th.CheckOK(resp)
+		require.Len(t, myWork, 1)
+	})
+}
+
+func TestRelationProperty(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	tasksBoard := th.CreateBoard("team-id", model.BoardTypePrivate)
+	otherBoard := th.CreateBoard("team-id", model.BoardTypeOpen)
+	epicsBoard := th.CreateBoard("team-id", model.BoardTypeOpen)
+	patch := &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{
+			{"id": "tasks", "name": "Tasks", "type": "relation", "targetBoardId": tasksBoard.ID},
+		},
+	}
+	_, resp := th.Client.PatchBoard(epicsBoard.ID, patch)
+	require.NoError(t, resp.Error)
+
+	newCard := func(boardID, title string, tasks interface{}) model.Block {
+		return model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  boardID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+			Title:    title,
+			Fields:   map[string]interface{}{"properties": map[string]interface{}{"tasks": tasks}},
+		}
+	}
+
+	// the inserted cards get new IDs
+	inserted, resp := th.Client.InsertBlocks(tasksBoard.ID, []model.Block{newCard(tasksBoard.ID, "task", nil)})
+	th.CheckOK(resp)
+	task := inserted[0]
+	inserted, resp = th.Client.InsertBlocks(otherBoard.ID, []model.Block{newCard(otherBoard.ID, "other card", nil)})
+	th.CheckOK(resp)
+	otherCard := inserted[0]
+
+	t.Run("cards of the target board can be linked", func(t *testing.T) {
+		_, resp := th.Client.InsertBlocks(epicsBoard.ID, []model.Block{newCard(epicsBoard.ID, "epic", []interface{}{task.ID})})
+		th.CheckOK(resp)
+	})
+
+	t.Run("cards of other boards are rejected", func(t *testing.T) {
+		_, resp := th.Client.InsertBlocks(epicsBoard.ID, []model.Block{newCard(epicsBoard.ID, "epic", []interface{}{otherCard.ID})})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.InsertBlocks(epicsBoard.ID, []model.Block{newCard(epicsBoard.ID, "epic", []interface{}{"unknown-card"})})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a value that is not a list of cards is rejected", func(t *testing.T) {
+		_, resp := th.Client.InsertBlocks(epicsBoard.ID, []model.Block{newCard(epicsBoard.ID, "epic", task.ID)})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("the linked cards are resolved with their titles", func(t *testing.T) {
+		references, resp := th.Client.ResolveCards(tasksBoard.ID, []string{task.ID, otherCard.ID, "unknown-card"})
+		th.CheckOK(resp)
+		require.Equal(t, []model.CardLink{{ID: task.ID, BoardID: tasksBoard.ID, Title: "task"}}, references)
+	})
+
+	t.Run("the cards of a board the user can't view are not resolved", func(t *testing.T) {
+		_, resp := th.Client2.ResolveCards(tasksBoard.ID, []string{task.ID})
+		th.CheckForbidden(resp)
+	})
+}
+
+func TestReadOnlyProperties(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	patch := &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{
+			{"id": "creator", "name": "Created by", "type": "createdBy"},
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	}
+	_, resp := th.Client.PatchBoard(board.ID, patch)
+	require.NoError(t, resp.Error)
+
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  board.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+		Title:    "card",
+		Fields:   map[string]interface{}{"properties": map[string]interface{}{"creator": th.GetUser2().ID}},
+	}
+	_, resp = th.Client.InsertBlocks(board.ID, []model.Block{card})
+	th.CheckBadRequest(resp)
+
+	card.Fields["properties"] = map[string]interface{}{"notes": "some notes"}
+	inserted, resp := th.Client.InsertBlocks(board.ID, []model.Block{card})
+	th.CheckOK(resp)
+	card = inserted[0]
+
+	blockPatch := &model.BlockPatch{
+		UpdatedFields: map[string]interface{}{"properties": map[string]interface{}{"creator": th.GetUser2().ID}},
+	}
+	_, resp = th.Client.PatchBlock(board.ID, card.ID, blockPatch)
+	th.CheckBadRequest(resp)
+}
+
+func TestGetWorkload(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	patch := &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": "person"},
+			{"id": "points", "name": "Estimate", "type": "number"},
+			{"id": "due", "name": "Due", "type": "date"},
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To do"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+		},
+	}
+	_, resp := th.Client.PatchBoard(board.ID, patch)
+	require.NoError(t, resp.Error)
+
+	userID := th.GetUser1().ID
+	newCard := func(props map[string]interface{}) model.Block {
+		return model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  board.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+			Fields:   map[string]interface{}{"properties": props},
+		}
+	}
+	_, resp = th.Client.InsertBlocks(board.ID, []model.Block{
+		newCard(map[string]interface{}{"assignee": userID, "points": "3", "due": `{"from":1000}`, "status": "todo"}),
+		newCard(map[string]interface{}{"assignee": userID, "points": "2", "due": `{"from":5000}`}),
+		newCard(map[string]interface{}{"assignee": userID, "points": "8", "status": "done"}),
+		newCard(map[string]interface{}{"points": "1"}),
+	})
+	require.NoError(t, resp.Error)
+
+	t.Run("the open cards are summed by assignee", func(t *testing.T) {
+		workload, resp := th.Client.GetBoardWorkload(board.ID, model.WorkloadQuery{Exclude: []string{"Status:Done"}})
+		th.CheckOK(resp)
+		require.Equal(t, []*model.WorkloadEntry{{UserID: userID, OpenCards: 2, Estimate: 5}}, workload.Assignees)
+		require.Equal(t, &model.WorkloadEntry{OpenCards: 1, Estimate: 1}, workload.Unassigned)
+	})
+
+	t.Run("only the cards due in the range are counted", func(t *testing.T) {
+		workload, resp := th.Client.GetBoardWorkload(board.ID, model.WorkloadQuery{From: 500, To: 2000})
+		th.CheckOK(resp)
+		require.Equal(t, []*model.WorkloadEntry{{UserID: userID, OpenCards: 1, Estimate: 3}}, workload.Assignees)
+		require.Equal(t, &model.WorkloadEntry{}, workload.Unassigned)
+	})
+
+	t.Run("the team workload includes the boards of the user", func(t *testing.T) {
+		workload, resp := th.Client.GetTeamWorkload("team-id", model.WorkloadQuery{})
+		th.CheckOK(resp)
+		require.Equal(t, []*model.WorkloadEntry{{UserID: userID, OpenCards: 3, Estimate: 13}}, workload.Assignees)
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		_, resp := th.Client.GetBoardWorkload(board.ID, model.WorkloadQuery{From: 2000, To: 1000})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.GetBoardWorkload(board.ID, model.WorkloadQuery{EstimateProperty: "Status"})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.GetBoardWorkload(board.ID, model.WorkloadQuery{Exclude: []string{"missing property:Done"}})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a user without access can't get the workload", func(t *testing.T) {
+		_, resp := th.Client2.GetBoardWorkload(board.ID, model.WorkloadQuery{})
+		th.CheckForbidden(resp)
+	})
+}
@@ -0,0 +1,117 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardAccessRequests(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+		th.Logout(th.Client2)
+
+		request, resp := th.Client2.RequestBoardAccess(board.ID, "")
+		th.CheckUnauthorized(resp)
+		require.Nil(t, request)
+	})
+
+	t.Run("a user without permissions should not manage the requests", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+
+		_, resp := th.Client2.RequestBoardAccess(board.ID, "")
+		th.CheckOK(resp)
+
+		requests, resp := th.Client2.GetBoardAccessRequests(board.ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, requests)
+
+		member, resp := th.Client2.ApproveBoardAccessRequest(board.ID, th.GetUser2().ID, nil)
+		th.CheckForbidden(resp)
+		require.Nil(t, member)
+
+		_, resp = th.Client2.DenyBoardAccessRequest(board.ID, th.GetUser2().ID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		openBoard := createTestBoard(t, th, model.BoardTypeOpen)
+		privateBoard := createTestBoard(t, th, model.BoardTypePrivate)
+
+		_, resp := th.Client2.RequestBoardAccess(openBoard.ID, "")
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.RequestBoardAccess(privateBoard.ID, "")
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.ApproveBoardAccessRequest(privateBoard.ID, th.GetUser2().ID, nil)
+		th.CheckNotFound(resp)
+
+		_, resp = th.Client.DenyBoardAccessRequest(privateBoard.ID, th.GetUser2().ID)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("request and approve", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+
+		_, resp := th.Client2.GetBoard(board.ID, "")
+		th.CheckForbidden(resp)
+
+		request, resp := th.Client2.RequestBoardAccess(board.ID, "first")
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser2().ID, request.UserID)
+
+		// requesting again replaces the message
+		_, resp = th.Client2.RequestBoardAccess(board.ID, "please let me in")
+		th.CheckOK(resp)
+
+		requests, resp := th.Client.GetBoardAccessRequests(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, requests, 1)
+		require.Equal(t, "please let me in", requests[0].Message)
+
+		member, resp := th.Client.ApproveBoardAccessRequest(board.ID, th.GetUser2().ID, &model.BoardAccessApproval{SchemeCommenter: true})
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser2().ID, member.UserID)
+		require.True(t, member.SchemeCommenter)
+		require.False(t, member.SchemeEditor)
+
+		rBoard, resp := th.Client2.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, rBoard.ID)
+
+		requests, resp = th.Client.GetBoardAccessRequests(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, requests)
+	})
+
+	t.Run("request and deny", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+
+		_, resp := th.Client2.RequestBoardAccess(board.ID, "")
+		th.CheckOK(resp)
+
+		success, resp := th.Client.DenyBoardAccessRequest(board.ID, th.GetUser2().ID)
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		requests, resp := th.Client.GetBoardAccessRequests(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, requests)
+
+		_, resp = th.Client2.GetBoard(board.ID, "")
+		th.CheckForbidden(resp)
+	})
+}
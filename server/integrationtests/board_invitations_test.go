@@ -0,0 +1,183 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestBoard(t *testing.T, th *TestHelper, boardType model.BoardType) *model.Board {
+	newBoard := &model.Board{
+		Title:  "title",
+		Type:   boardType,
+		TeamID: testTeamID,
+	}
+	board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+	require.NoError(t, err)
+	return board
+}
+
+func TestBoardInvitations(t *testing.T) {
+
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+		th.Logout(th.Client)
+
+		invitation, resp := th.Client.InviteToBoard(board.ID, &model.BoardInvitationRequest{Username: user2Username})
+		th.CheckUnauthorized(resp)
+		require.Nil(t, invitation)
+	})
+
+	t.Run("a user without permissions should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+
+		invitation, resp := th.Client2.InviteToBoard(board.ID, &model.BoardInvitationRequest{Username: user2Username})
+		th.CheckForbidden(resp)
+		require.Nil(t, invitation)
+
+		invitations, resp := th.Client2.GetBoardInvitations(board.ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, invitations)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+
+		_, resp := th.Client.InviteToBoard(board.ID, &model.BoardInvitationRequest{})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.InviteToBoard(board.ID, &model.BoardInvitationRequest{Username: "nonexistent"})
+		th.CheckNotFound(resp)
+
+		_, resp = th.Client.InviteToBoard(board.ID, &model.BoardInvitationRequest{Username: user1Username})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("invite and accept", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+
+		invitation, resp := th.Client.InviteToBoard(board.ID, &model.BoardInvitationRequest{
+			Email:           "user2@sample.com",
+			SchemeCommenter: true,
+		})
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser2().ID, invitation.UserID)
+		require.Equal(t, th.GetUser1().ID, invitation.InvitedBy)
+		require.True(t, invitation.SchemeCommenter)
+
+		invitations, resp := th.Client.GetBoardInvitations(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, invitations, 1)
+
+		// the invited user is not a member until the invitation is accepted.
+		_, resp = th.Client2.GetBoard(board.ID, "")
+		th.CheckForbidden(resp)
+
+		invitations, resp = th.Client2.GetMyInvitations()
+		th.CheckOK(resp)
+		require.Len(t, invitations, 1)
+		require.Equal(t, board.ID, invitations[0].BoardID)
+
+		member, resp := th.Client2.AcceptBoardInvitation(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser2().ID, member.UserID)
+		require.True(t, member.SchemeCommenter)
+		require.False(t, member.SchemeEditor)
+
+		_, resp = th.Client2.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+
+		invitations, resp = th.Client2.GetMyInvitations()
+		th.CheckOK(resp)
+		require.Empty(t, invitations)
+
+		_, resp = th.Client2.AcceptBoardInvitation(board.ID)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("invite and decline", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+
+		invitation, resp := th.Client.InviteToBoard(board.ID, &model.BoardInvitationRequest{Username: user2Username})
+		th.CheckOK(resp)
+		require.True(t, invitation.SchemeEditor)
+
+		success, resp := th.Client2.DeclineBoardInvitation(board.ID)
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		invitations, resp := th.Client.GetBoardInvitations(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, invitations)
+
+		_, resp = th.Client2.GetBoard(board.ID, "")
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("invite and cancel", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+
+		_, resp := th.Client.InviteToBoard(board.ID, &model.BoardInvitationRequest{Username: user2Username})
+		th.CheckOK(resp)
+
+		success, resp := th.Client.CancelBoardInvitation(board.ID, th.GetUser2().ID)
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		_, resp = th.Client2.AcceptBoardInvitation(board.ID)
+		th.CheckNotFound(resp)
+
+		_, resp = th.Client.CancelBoardInvitation(board.ID, th.GetUser2().ID)
+		th.CheckNotFound(resp)
+	})
+}
+
+func TestSendEmailInvitation(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Logout(th.Client)
+
+		_, resp := th.Client.SendEmailInvitation(&model.EmailInvitationRequest{Email: "invited@example.com"})
+		th.CheckUnauthorized(resp)
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, resp := th.Client.SendEmailInvitation(&model.EmailInvitationRequest{Email: "invalid"})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a user without permissions on the board should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+
+		_, resp := th.Client2.SendEmailInvitation(&model.EmailInvitationRequest{Email: "invited@example.com", BoardID: board.ID})
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("email invitations need an SMTP server", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, resp := th.Client.SendEmailInvitation(&model.EmailInvitationRequest{Email: "invited@example.com"})
+		th.CheckNotImplemented(resp)
+	})
+}
@@ -316,6 +316,27 @@ func TestGetAllBlocksForBoard(t *testing.T) {
 
 		require.Equal(t, insertedBlockIDs, fetchedblockIDs)
 	})
+
+	t.Run("Get the children of a block", func(t *testing.T) {
+		// the inserted blocks get new IDs, so the parent is found by its
+		// children
+		blocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+		require.NoError(t, resp.Error)
+		var insertedParentID string
+		for _, b := range blocks {
+			if b.ParentID != "" {
+				insertedParentID = b.ParentID
+			}
+		}
+		require.NotEmpty(t, insertedParentID)
+
+		fetchedBlocks, resp := th.Client.GetBlocksForBoardWithOptions(board.ID, client.GetBlocksOptions{ParentID: insertedParentID})
+		require.NoError(t, resp.Error)
+		require.Len(t, fetchedBlocks, 2)
+		for _, b := range fetchedBlocks {
+			require.Equal(t, insertedParentID, b.ParentID)
+		}
+	})
 }
 
 func TestSearchBoards(t *testing.T) {
@@ -787,6 +808,56 @@ func TestPatchBoard(t *testing.T) {
 		require.Nil(t, rBoard)
 	})
 
+	t.Run("invalid default member role", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		newBoard := &model.Board{
+			Title:  "title",
+			Type:   model.BoardTypeOpen,
+			TeamID: teamID,
+		}
+		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		require.NoError(t, err)
+
+		patch := &model.BoardPatch{
+			UpdatedProperties: map[string]interface{}{model.BoardPropertyDefaultMemberRole: "admin"},
+		}
+
+		rBoard, resp := th.Client.PatchBoard(board.ID, patch)
+		th.CheckBadRequest(resp)
+		require.Nil(t, rBoard)
+	})
+
+	t.Run("only board admins can change the default member role", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		newBoard := &model.Board{
+			Title:  "title",
+			Type:   model.BoardTypeOpen,
+			TeamID: teamID,
+		}
+		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		require.NoError(t, err)
+
+		// user2 joins as an editor
+		_, resp := th.Client2.JoinBoard(board.ID)
+		th.CheckOK(resp)
+
+		patch := &model.BoardPatch{
+			UpdatedProperties: map[string]interface{}{model.BoardPropertyDefaultMemberRole: model.BoardRoleCommenter},
+		}
+
+		rBoard, resp := th.Client2.PatchBoard(board.ID, patch)
+		th.CheckForbidden(resp)
+		require.Nil(t, rBoard)
+
+		rBoard, resp = th.Client.PatchBoard(board.ID, patch)
+		th.CheckOK(resp)
+		require.Equal(t, model.BoardRoleCommenter, rBoard.DefaultMemberRole())
+	})
+
 	t.Run("valid patch on a board with permissions", func(t *testing.T) {
 		th := SetupTestHelper(t).InitBasic()
 		defer th.TearDown()
@@ -1105,6 +1176,38 @@ func TestGetMembersForBoard(t *testing.T) {
 		th.CheckOK(resp)
 		require.Len(t, members, 2)
 	})
+
+	t.Run("should return the last activity of the members", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createBoardWithUsers(th)
+
+		_, resp := th.Client2.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+
+		block := model.Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			Type:     model.TypeCard,
+			Title:    "card",
+			CreateAt: 1,
+			UpdateAt: 1,
+		}
+		_, resp = th.Client2.InsertBlocks(board.ID, []model.Block{block})
+		th.CheckOK(resp)
+
+		members, resp := th.Client.GetMembersForBoard(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, members, 2)
+		for _, member := range members {
+			if member.UserID == th.GetUser2().ID {
+				require.NotZero(t, member.LastViewAt)
+				require.NotZero(t, member.LastEditAt)
+			} else {
+				require.Zero(t, member.LastEditAt)
+			}
+		}
+	})
 }
 
 func TestAddMember(t *testing.T) {
@@ -1440,6 +1543,94 @@ func TestUpdateMember(t *testing.T) {
 	})
 }
 
+func TestUpdateMembersRoles(t *testing.T) {
+	createBoardWithEditor := func(th *TestHelper) *model.Board {
+		board := createTestBoard(t, th, model.BoardTypeOpen)
+		_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{
+			UserID:       th.GetUser2().ID,
+			BoardID:      board.ID,
+			SchemeEditor: true,
+		})
+		require.NoError(t, err)
+
+		// boards are duplicated with their blocks, so they need at least one
+		_, err = th.Server.App().InsertBlocks([]model.Block{{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  board.ID,
+			ParentID: board.ID,
+			Type:     model.TypeCard,
+			Title:    "card",
+			CreateAt: 1,
+			UpdateAt: 1,
+		}}, th.GetUser1().ID, false)
+		require.NoError(t, err)
+		return board
+	}
+
+	t.Run("a user without permissions should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createBoardWithEditor(th)
+
+		members, resp := th.Client2.UpdateMembersRoles(board.ID, &model.BoardMembersRolesUpdate{
+			Members: []*model.BoardMemberRoles{{UserID: th.GetUser2().ID, SchemeAdmin: true}},
+		})
+		th.CheckForbidden(resp)
+		require.Nil(t, members)
+	})
+
+	t.Run("invalid updates", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createBoardWithEditor(th)
+
+		_, resp := th.Client.UpdateMembersRoles(board.ID, &model.BoardMembersRolesUpdate{})
+		th.CheckBadRequest(resp)
+
+		// the board can't be left without admins
+		_, resp = th.Client.UpdateMembersRoles(board.ID, &model.BoardMembersRolesUpdate{
+			Members: []*model.BoardMemberRoles{{UserID: th.GetUser1().ID, SchemeEditor: true}},
+		})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.UpdateMembersRoles(board.ID, &model.BoardMembersRolesUpdate{
+			Members: []*model.BoardMemberRoles{{UserID: "not-a-member", SchemeViewer: true}},
+		})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("changes the roles and records the reason", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createBoardWithEditor(th)
+
+		members, resp := th.Client.UpdateMembersRoles(board.ID, &model.BoardMembersRolesUpdate{
+			Members: []*model.BoardMemberRoles{
+				{UserID: th.GetUser1().ID, SchemeEditor: true},
+				{UserID: th.GetUser2().ID, SchemeAdmin: true},
+			},
+			Reason: "handover",
+		})
+		th.CheckOK(resp)
+		require.Len(t, members, 2)
+
+		member, err := th.Server.App().GetMemberForBoard(board.ID, th.GetUser1().ID)
+		require.NoError(t, err)
+		require.False(t, member.SchemeAdmin)
+		require.True(t, member.SchemeEditor)
+
+		history, err := th.Server.Store().GetBoardMemberHistory(board.ID, th.GetUser2().ID, 0)
+		require.NoError(t, err)
+		reasons := []string{}
+		for _, entry := range history {
+			if entry.Action == model.BoardMemberActionUpdated {
+				reasons = append(reasons, entry.Reason)
+			}
+		}
+		require.Equal(t, []string{"handover"}, reasons)
+	})
+}
+
 func TestDeleteMember(t *testing.T) {
 	teamID := testTeamID
 
@@ -1659,6 +1850,77 @@ func TestDeleteMember(t *testing.T) {
 	})
 }
 
+func TestRestoreMember(t *testing.T) {
+	createBoardWithDeletedEditor := func(th *TestHelper) *model.Board {
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+		_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{
+			UserID:       th.GetUser2().ID,
+			BoardID:      board.ID,
+			SchemeEditor: true,
+		})
+		require.NoError(t, err)
+
+		success, resp := th.Client.DeleteBoardMember(&model.BoardMember{UserID: th.GetUser2().ID, BoardID: board.ID})
+		th.CheckOK(resp)
+		require.True(t, success)
+		return board
+	}
+
+	t.Run("a user without permissions should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createBoardWithDeletedEditor(th)
+
+		members, resp := th.Client2.GetDeletedMembersForBoard(board.ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, members)
+
+		member, resp := th.Client2.RestoreBoardMember(board.ID, th.GetUser2().ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, member)
+	})
+
+	t.Run("a member that is not deleted can't be restored", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+
+		member, resp := th.Client.RestoreBoardMember(board.ID, th.GetUser1().ID)
+		th.CheckNotFound(resp)
+		require.Nil(t, member)
+	})
+
+	t.Run("restores the member with its roles", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createBoardWithDeletedEditor(th)
+
+		deleted, resp := th.Client.GetDeletedMembersForBoard(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, deleted, 1)
+		require.Equal(t, th.GetUser2().ID, deleted[0].UserID)
+
+		member, resp := th.Client.RestoreBoardMember(board.ID, th.GetUser2().ID)
+		th.CheckOK(resp)
+		require.NotNil(t, member)
+		require.True(t, member.SchemeEditor)
+		require.False(t, member.SchemeAdmin)
+
+		members, err := th.Server.App().GetMembersForBoard(board.ID)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+
+		deleted, resp = th.Client.GetDeletedMembersForBoard(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, deleted)
+
+		// the restored member can open the board again
+		rBoard, resp := th.Client2.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, rBoard.ID)
+	})
+}
+
 func TestGetTemplates(t *testing.T) {
 	t.Run("should be able to retrieve built-in templates", func(t *testing.T) {
 		th := SetupTestHelper(t).InitBasic()
@@ -1712,6 +1974,76 @@ func TestGetTemplates(t *testing.T) {
 	})
 }
 
+func TestDuplicateBoardWithUserMapping(t *testing.T) {
+	createBoardWithEditor := func(th *TestHelper) *model.Board {
+		board := createTestBoard(t, th, model.BoardTypeOpen)
+		_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{
+			UserID:       th.GetUser2().ID,
+			BoardID:      board.ID,
+			SchemeEditor: true,
+		})
+		require.NoError(t, err)
+
+		// boards are duplicated with their blocks, so they need at least one
+		_, err = th.Server.App().InsertBlocks([]model.Block{{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  board.ID,
+			ParentID: board.ID,
+			Type:     model.TypeCard,
+			Title:    "card",
+			CreateAt: 1,
+			UpdateAt: 1,
+		}}, th.GetUser1().ID, false)
+		require.NoError(t, err)
+		return board
+	}
+
+	t.Run("an invalid mapping should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createBoardWithEditor(th)
+
+		bab, resp := th.Client.DuplicateBoardWithUserMapping(board.ID, "other-team-id", model.UserMapping{"": th.GetUser2().ID})
+		th.CheckBadRequest(resp)
+		require.Nil(t, bab)
+	})
+
+	t.Run("the mapped members keep their roles on the copy", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createBoardWithEditor(th)
+
+		bab, resp := th.Client.DuplicateBoardWithUserMapping(board.ID, "other-team-id", model.UserMapping{th.GetUser2().ID: th.GetUser2().ID})
+		th.CheckOK(resp)
+		require.Len(t, bab.Boards, 1)
+		require.Equal(t, "other-team-id", bab.Boards[0].TeamID)
+
+		member, err := th.Server.App().GetMemberForBoard(bab.Boards[0].ID, th.GetUser2().ID)
+		require.NoError(t, err)
+		require.True(t, member.SchemeEditor)
+		require.False(t, member.SchemeAdmin)
+
+		member, err = th.Server.App().GetMemberForBoard(bab.Boards[0].ID, th.GetUser1().ID)
+		require.NoError(t, err)
+		require.True(t, member.SchemeAdmin)
+	})
+
+	t.Run("the dropped members are not copied", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createBoardWithEditor(th)
+
+		bab, resp := th.Client.DuplicateBoardWithUserMapping(board.ID, "other-team-id", model.UserMapping{th.GetUser2().ID: ""})
+		th.CheckOK(resp)
+		require.Len(t, bab.Boards, 1)
+
+		members, err := th.Server.App().GetMembersForBoard(bab.Boards[0].ID)
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		require.Equal(t, th.GetUser1().ID, members[0].UserID)
+	})
+}
+
 func TestJoinBoard(t *testing.T) {
 	t.Run("create and join public board", func(t *testing.T) {
 		th := SetupTestHelper(t).InitBasic()
@@ -1777,6 +2109,52 @@ func TestJoinBoard(t *testing.T) {
 		require.Nil(t, member)
 	})
 
+	t.Run("join public board with a default member role", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		newBoard := &model.Board{
+			Title:      "Public board",
+			Type:       model.BoardTypeOpen,
+			TeamID:     testTeamID,
+			Properties: map[string]interface{}{model.BoardPropertyDefaultMemberRole: model.BoardRoleViewer},
+		}
+		board, resp := th.Client.CreateBoard(newBoard)
+		th.CheckOK(resp)
+		require.NotNil(t, board)
+
+		member, resp := th.Client2.JoinBoard(board.ID)
+		th.CheckOK(resp)
+		require.NotNil(t, member)
+		require.True(t, member.SchemeViewer)
+		require.False(t, member.SchemeEditor)
+		require.False(t, member.SchemeAdmin)
+	})
+
+	t.Run("join is recorded in the members history and can be repeated", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createTestBoard(t, th, model.BoardTypeOpen)
+
+		member, resp := th.Client2.JoinBoard(board.ID)
+		th.CheckOK(resp)
+		require.NotNil(t, member)
+
+		member, resp = th.Client2.JoinBoard(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser2().ID, member.UserID)
+
+		history, err := th.Server.Store().GetBoardMemberHistory(board.ID, th.GetUser2().ID, 0)
+		require.NoError(t, err)
+		joins := 0
+		for _, entry := range history {
+			if entry.Action == model.BoardMemberActionJoined {
+				joins++
+			}
+		}
+		require.Equal(t, 1, joins)
+	})
+
 	t.Run("join invalid board", func(t *testing.T) {
 		th := SetupTestHelper(t).InitBasic()
 		defer th.TearDown()
@@ -1786,3 +2164,409 @@ func TestJoinBoard(t *testing.T) {
 		require.Nil(t, member)
 	})
 }
+
+func TestGetRecentBoards(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Logout(th.Client)
+
+		boards, resp := th.Client.GetRecentBoards(testTeamID)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, boards)
+	})
+
+	t.Run("visited boards are returned, most recent first", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board1 := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		board2 := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		boards, resp := th.Client.GetRecentBoards(testTeamID)
+		th.CheckOK(resp)
+		require.Empty(t, boards)
+
+		for _, boardID := range []string{board1.ID, board2.ID, board1.ID} {
+			_, resp = th.Client.GetBoard(boardID, "")
+			th.CheckOK(resp)
+			time.Sleep(2 * time.Millisecond)
+		}
+
+		boards, resp = th.Client.GetRecentBoards(testTeamID)
+		th.CheckOK(resp)
+		require.Len(t, boards, 2)
+		require.Equal(t, board1.ID, boards[0].ID)
+		require.Equal(t, board2.ID, boards[1].ID)
+
+		// visits are per user
+		boards, resp = th.Client2.GetRecentBoards(testTeamID)
+		th.CheckOK(resp)
+		require.Empty(t, boards)
+
+		boards, resp = th.Client.GetRecentBoards("other-team-id")
+		th.CheckOK(resp)
+		require.Empty(t, boards)
+	})
+}
+
+func TestChannelLinks(t *testing.T) {
+	t.Run("channel links are not available in standalone mode", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		_, resp := th.Client.LinkBoardToChannel(board.ID, "channel-id")
+		th.CheckNotImplemented(resp)
+
+		_, resp = th.Client.UnlinkBoardFromChannel(board.ID)
+		th.CheckNotImplemented(resp)
+
+		_, resp = th.Client.GetBoardsForChannel("channel-id")
+		th.CheckNotImplemented(resp)
+	})
+}
+
+func TestBotActions(t *testing.T) {
+	t.Run("bot actions are not available in standalone mode", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		_, resp := th.Client.BotCreateCard(board.ID, &model.BotCreateCardRequest{Title: "card"})
+		th.CheckNotImplemented(resp)
+
+		_, resp = th.Client.BotAddComment("card-id", "comment")
+		th.CheckNotImplemented(resp)
+
+		_, resp = th.Client.BotSetCardProperties("card-id", map[string]string{"Status": "Done"})
+		th.CheckNotImplemented(resp)
+	})
+}
+
+func TestBoardPermissions(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	me := th.GetUser1()
+	user2 := th.GetUser2()
+
+	source := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	// the members added through the API get the default role of the board
+	_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: source.ID, UserID: user2.ID, SchemeViewer: true})
+	require.NoError(t, err)
+
+	t.Run("the permissions of a board can be exported", func(t *testing.T) {
+		permissions, resp := th.Client.GetBoardPermissions(source.ID)
+		th.CheckOK(resp)
+		require.Equal(t, model.BoardTypePrivate, permissions.Type)
+		require.Len(t, permissions.Members, 2)
+
+		roles := map[string]*model.BoardMemberRoles{}
+		for _, member := range permissions.Members {
+			roles[member.UserID] = member
+		}
+		require.True(t, roles[me.ID].SchemeAdmin)
+		require.True(t, roles[user2.ID].SchemeViewer)
+		require.False(t, roles[user2.ID].SchemeAdmin)
+	})
+
+	t.Run("the permissions can be applied to another board", func(t *testing.T) {
+		permissions, resp := th.Client.GetBoardPermissions(source.ID)
+		th.CheckOK(resp)
+
+		target := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		members, resp := th.Client.ApplyBoardPermissions(target.ID, permissions, true)
+		th.CheckOK(resp)
+		require.Len(t, members, 2)
+
+		board, resp := th.Client.GetBoard(target.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, model.BoardTypePrivate, board.Type)
+
+		targetPermissions, resp := th.Client.GetBoardPermissions(target.ID)
+		th.CheckOK(resp)
+		require.Equal(t, permissions.Members, targetPermissions.Members)
+	})
+
+	t.Run("permissions without an admin are rejected", func(t *testing.T) {
+		target := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		_, resp := th.Client.ApplyBoardPermissions(target.ID, &model.BoardPermissions{
+			Type:              model.BoardTypeOpen,
+			DefaultMemberRole: model.BoardRoleEditor,
+			Members:           []*model.BoardMemberRoles{{UserID: user2.ID, SchemeEditor: true}},
+		}, false)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("only the board admins can export and apply the permissions", func(t *testing.T) {
+		_, resp := th.Client2.GetBoardPermissions(source.ID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.ApplyBoardPermissions(source.ID, &model.BoardPermissions{
+			Type:              model.BoardTypeOpen,
+			DefaultMemberRole: model.BoardRoleEditor,
+			Members:           []*model.BoardMemberRoles{{UserID: user2.ID, SchemeAdmin: true}},
+		}, true)
+		th.CheckForbidden(resp)
+	})
+}
+
+func TestReadOnlyBoard(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	_, resp := th.Client.AddMemberToBoard(&model.BoardMember{BoardID: board.ID, UserID: th.GetUser2().ID, SchemeEditor: true})
+	th.CheckOK(resp)
+
+	newBlock := func() []model.Block {
+		return []model.Block{{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			ParentID: board.ID,
+			Type:     model.TypeCard,
+			Title:    "card",
+			CreateAt: 1,
+			UpdateAt: 1,
+		}}
+	}
+	readOnly := func(value bool) *model.BoardPatch {
+		return &model.BoardPatch{UpdatedProperties: map[string]interface{}{model.BoardPropertyReadOnly: value}}
+	}
+
+	blocks, resp := th.Client2.InsertBlocks(board.ID, newBlock())
+	th.CheckOK(resp)
+	card := blocks[0]
+
+	t.Run("only the admins can freeze the board", func(t *testing.T) {
+		_, resp := th.Client2.PatchBoard(board.ID, readOnly(true))
+		th.CheckForbidden(resp)
+
+		updated, resp := th.Client.PatchBoard(board.ID, readOnly(true))
+		th.CheckOK(resp)
+		require.True(t, updated.IsReadOnly())
+	})
+
+	t.Run("the editors can't change a read-only board", func(t *testing.T) {
+		_, resp := th.Client2.InsertBlocks(board.ID, newBlock())
+		th.CheckForbidden(resp)
+
+		title := "new title"
+		_, resp = th.Client2.PatchBlock(board.ID, card.ID, &model.BlockPatch{Title: &title})
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.DeleteBlock(board.ID, card.ID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.PatchBoard(board.ID, &model.BoardPatch{Title: &title})
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("the admins can change a read-only board", func(t *testing.T) {
+		_, resp := th.Client.InsertBlocks(board.ID, newBlock())
+		th.CheckOK(resp)
+	})
+
+	t.Run("the editors can change the board once unfrozen", func(t *testing.T) {
+		updated, resp := th.Client.PatchBoard(board.ID, readOnly(false))
+		th.CheckOK(resp)
+		require.False(t, updated.IsReadOnly())
+
+		_, resp = th.Client2.InsertBlocks(board.ID, newBlock())
+		th.CheckOK(resp)
+	})
+}
+
+func TestBoardFreezeSchedule(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	_, resp := th.Client.AddMemberToBoard(&model.BoardMember{BoardID: board.ID, UserID: th.GetUser2().ID, SchemeEditor: true})
+	th.CheckOK(resp)
+
+	later := utils.GetMillis() + time.Hour.Milliseconds()
+
+	t.Run("only the admins can schedule the freeze", func(t *testing.T) {
+		_, resp := th.Client2.SetBoardFreezeSchedule(board.ID, &model.BoardFreezeSchedule{FreezeAt: later})
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.GetBoardFreezeSchedule(board.ID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("the freeze must be scheduled in the future", func(t *testing.T) {
+		_, resp := th.Client.SetBoardFreezeSchedule(board.ID, &model.BoardFreezeSchedule{FreezeAt: 1})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("schedule and cancel the freeze", func(t *testing.T) {
+		schedule, resp := th.Client.SetBoardFreezeSchedule(board.ID, &model.BoardFreezeSchedule{FreezeAt: later})
+		th.CheckOK(resp)
+		require.Equal(t, later, schedule.FreezeAt)
+
+		schedule, resp = th.Client.GetBoardFreezeSchedule(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, later, schedule.FreezeAt)
+		require.Equal(t, th.GetUser1().ID, schedule.ModifiedBy)
+
+		_, resp = th.Client.SetBoardFreezeSchedule(board.ID, &model.BoardFreezeSchedule{})
+		th.CheckOK(resp)
+
+		schedule, resp = th.Client.GetBoardFreezeSchedule(board.ID)
+		th.CheckOK(resp)
+		require.True(t, schedule.IsEmpty())
+	})
+
+	t.Run("a due freeze makes the board read-only", func(t *testing.T) {
+		err := th.Server.Store().SaveBoardFreezeSchedule(&model.BoardFreezeSchedule{
+			BoardID:    board.ID,
+			FreezeAt:   1,
+			UnfreezeAt: later,
+			ModifiedBy: th.GetUser1().ID,
+			UpdateAt:   1,
+		})
+		require.NoError(t, err)
+
+		th.Server.App().ApplyBoardFreezeSchedules()
+
+		frozen, resp := th.Client.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.True(t, frozen.IsReadOnly())
+
+		schedule, resp := th.Client.GetBoardFreezeSchedule(board.ID)
+		th.CheckOK(resp)
+		require.Zero(t, schedule.FreezeAt)
+		require.Equal(t, later, schedule.UnfreezeAt)
+	})
+}
+
+func TestBoardContent(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	// the members added through the API get the default role of the board
+	_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: board.ID, UserID: th.GetUser2().ID, SchemeViewer: true})
+	require.NoError(t, err)
+
+	blocks := []model.Block{
+		{Type: model.TypeHeading, Title: "Goals", Fields: map[string]interface{}{"level": 1}},
+		{Type: model.TypeCheckbox, Title: "write the docs", Fields: map[string]interface{}{"value": true}},
+		{Type: model.TypeLink, Title: "Handbook", Fields: map[string]interface{}{"url": "https://example.com/handbook"}},
+	}
+
+	t.Run("the viewers can't change the content", func(t *testing.T) {
+		_, resp := th.Client2.SetBoardContent(board.ID, blocks)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("invalid content is rejected", func(t *testing.T) {
+		_, resp := th.Client.SetBoardContent(board.ID, []model.Block{
+			{Type: model.TypeLink, Fields: map[string]interface{}{"url": "ftp://example.com"}},
+		})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("the description is rendered from the content", func(t *testing.T) {
+		content, resp := th.Client.SetBoardContent(board.ID, blocks)
+		th.CheckOK(resp)
+		require.Len(t, content.Blocks, 3)
+		require.Equal(t, "# Goals\n\n- [x] write the docs\n\n[Handbook](https://example.com/handbook)", content.Description)
+
+		content, resp = th.Client2.GetBoardContent(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, content.Blocks, 3)
+		require.Equal(t, model.BlockType(model.TypeHeading), content.Blocks[0].Type)
+
+		updated, resp := th.Client.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, content.Description, updated.Description)
+
+		description := "plain text"
+		_, resp = th.Client.PatchBoard(board.ID, &model.BoardPatch{Description: &description})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("the removed blocks are deleted and the history keeps the revisions", func(t *testing.T) {
+		content, resp := th.Client.GetBoardContent(board.ID)
+		th.CheckOK(resp)
+
+		content, resp = th.Client.SetBoardContent(board.ID, content.Blocks[:1])
+		th.CheckOK(resp)
+		require.Equal(t, "# Goals", content.Description)
+
+		content, resp = th.Client.GetBoardContent(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, content.Blocks, 1)
+
+		revisions, resp := th.Client2.GetBoardContentHistory(board.ID, 2)
+		th.CheckOK(resp)
+		require.Len(t, revisions, 2)
+		require.Equal(t, "# Goals", revisions[0].Description)
+		require.Contains(t, revisions[1].Description, "[Handbook]")
+	})
+
+	t.Run("the content follows the duplicated board", func(t *testing.T) {
+		bab, resp := th.Client.DuplicateBoard(board.ID, false, testTeamID)
+		th.CheckOK(resp)
+		require.Len(t, bab.Boards, 1)
+
+		content, resp := th.Client.GetBoardContent(bab.Boards[0].ID)
+		th.CheckOK(resp)
+		require.Len(t, content.Blocks, 1)
+		require.Equal(t, "Goals", content.Blocks[0].Title)
+		require.Equal(t, "# Goals", content.Description)
+	})
+}
+
+func TestPatchMemberCustomFields(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := createTestBoard(t, th, model.BoardTypePrivate)
+	_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{
+		UserID:       th.GetUser2().ID,
+		BoardID:      board.ID,
+		SchemeEditor: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("only board admins can set the custom fields", func(t *testing.T) {
+		member, resp := th.Client2.PatchBoardMemberCustomFields(board.ID, th.GetUser2().ID, map[string]interface{}{"capacity": 5})
+		th.CheckForbidden(resp)
+		require.Nil(t, member)
+	})
+
+	t.Run("the custom fields are set and removed", func(t *testing.T) {
+		member, resp := th.Client.PatchBoardMemberCustomFields(board.ID, th.GetUser2().ID, map[string]interface{}{"capacity": 5, "roleTitle": "Designer"})
+		th.CheckOK(resp)
+		require.Equal(t, map[string]interface{}{"capacity": float64(5), "roleTitle": "Designer"}, member.CustomFields)
+
+		member, resp = th.Client.PatchBoardMemberCustomFields(board.ID, th.GetUser2().ID, map[string]interface{}{"roleTitle": nil})
+		th.CheckOK(resp)
+		require.Equal(t, map[string]interface{}{"capacity": float64(5)}, member.CustomFields)
+
+		members, resp := th.Client.GetMembersForBoard(board.ID)
+		th.CheckOK(resp)
+		for _, m := range members {
+			if m.UserID == th.GetUser2().ID {
+				require.Equal(t, map[string]interface{}{"capacity": float64(5)}, m.CustomFields)
+			}
+		}
+	})
+
+	t.Run("invalid custom fields", func(t *testing.T) {
+		_, resp := th.Client.PatchBoardMemberCustomFields(board.ID, th.GetUser2().ID, map[string]interface{}{"": 5})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("the custom fields of a user that is not a member", func(t *testing.T) {
+		_, resp := th.Client.PatchBoardMemberCustomFields(board.ID, "unknown-user", map[string]interface{}{"capacity": 5})
+		th.CheckNotFound(resp)
+	})
+}
@@ -0,0 +1,115 @@
+package integrationtests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarImport(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	patch := &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{
+			{"id": "due", "name": "Due", "type": "date"},
+		},
+	}
+	_, resp := th.Client.PatchBoard(board.ID, patch)
+	require.NoError(t, resp.Error)
+
+	calendar := func(summary string) string {
+		return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" +
+			"BEGIN:VEVENT\r\nUID:launch@example.com\r\nSUMMARY:" + summary + "\r\nDTSTART;VALUE=DATE:20240301\r\nEND:VEVENT\r\n" +
+			"END:VCALENDAR\r\n"
+	}
+	importedCards := func() []model.Block {
+		blocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		cards := []model.Block{}
+		for _, block := range blocks {
+			if block.Type == model.TypeCard {
+				cards = append(cards, block)
+			}
+		}
+		return cards
+	}
+
+	t.Run("the events are imported as cards", func(t *testing.T) {
+		result, resp := th.Client.ImportCalendar(board.ID, strings.NewReader(calendar("Launch")), "")
+		th.CheckOK(resp)
+		require.Equal(t, &model.CalendarImportResult{Created: 1}, result)
+
+		cards := importedCards()
+		require.Len(t, cards, 1)
+		require.Equal(t, "Launch", cards[0].Title)
+		require.Equal(t, map[string]interface{}{"due": `{"from":1709251200000}`}, cards[0].Fields["properties"])
+	})
+
+	t.Run("the imported cards are updated", func(t *testing.T) {
+		result, resp := th.Client.ImportCalendar(board.ID, strings.NewReader(calendar("Launch")), "Due")
+		th.CheckOK(resp)
+		require.Equal(t, &model.CalendarImportResult{Skipped: 1}, result)
+
+		result, resp = th.Client.ImportCalendar(board.ID, strings.NewReader(calendar("Public launch")), "")
+		th.CheckOK(resp)
+		require.Equal(t, &model.CalendarImportResult{Updated: 1}, result)
+
+		cards := importedCards()
+		require.Len(t, cards, 1)
+		require.Equal(t, "Public launch", cards[0].Title)
+	})
+
+	t.Run("invalid calendar or date property", func(t *testing.T) {
+		_, resp := th.Client.ImportCalendar(board.ID, strings.NewReader("not a calendar"), "")
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.ImportCalendar(board.ID, strings.NewReader(calendar("Launch")), "Title")
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a user without access can't import", func(t *testing.T) {
+		_, resp := th.Client2.ImportCalendar(board.ID, strings.NewReader(calendar("Launch")), "")
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("subscribed calendars are refreshed", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/calendar")
+			_, _ = w.Write([]byte(calendar("Launch day")))
+		}))
+		defer ts.Close()
+
+		_, resp := th.Client.GetCalendarSubscription(board.ID)
+		th.CheckNotFound(resp)
+
+		_, resp = th.Client.SetCalendarSubscription(board.ID, &model.CalendarSubscription{URL: "file:///etc/passwd"})
+		th.CheckBadRequest(resp)
+
+		subscription, resp := th.Client.SetCalendarSubscription(board.ID, &model.CalendarSubscription{URL: ts.URL})
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser1().ID, subscription.ModifiedBy)
+
+		th.Server.App().RefreshCalendarSubscriptions()
+
+		subscription, resp = th.Client.GetCalendarSubscription(board.ID)
+		th.CheckOK(resp)
+		require.NotZero(t, subscription.LastSyncAt)
+		require.Empty(t, subscription.LastError)
+
+		cards := importedCards()
+		require.Len(t, cards, 1)
+		require.Equal(t, "Launch day", cards[0].Title)
+
+		_, resp = th.Client.DeleteCalendarSubscription(board.ID)
+		th.CheckOK(resp)
+		_, resp = th.Client.GetCalendarSubscription(board.ID)
+		th.CheckNotFound(resp)
+	})
+}
@@ -0,0 +1,104 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func nextChange(t *testing.T, stream *client.ChangeStream) *client.ChangeStreamEvent {
+	events := make(chan *client.ChangeStreamEvent, 1)
+	go func() {
+		event, err := stream.Next()
+		require.NoError(t, err)
+		events <- event
+	}()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for a change")
+		return nil
+	}
+}
+
+func TestChangeStream(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	// the inserted blocks get new IDs
+	insertBlock := func(boardID string) model.Block {
+		blocks, resp := th.Client.InsertBlocks(boardID, []model.Block{{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  boardID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+		}})
+		th.CheckOK(resp)
+		require.Len(t, blocks, 1)
+		return blocks[0]
+	}
+
+	stream, resp := th.Client.OpenChangeStream(testTeamID, "")
+	th.CheckOK(resp)
+
+	block := insertBlock(board.ID)
+
+	var first *client.ChangeStreamEvent
+	t.Run("changes are streamed", func(t *testing.T) {
+		first = nextChange(t, stream)
+		require.NotEmpty(t, first.ID)
+		require.Equal(t, model.ChangeEventBlockChanged, first.Change.Type)
+		require.Equal(t, board.ID, first.Change.BoardID)
+		require.Equal(t, block.ID, first.Change.BlockID)
+		require.NoError(t, stream.Close())
+	})
+
+	t.Run("the stream resumes after the last event", func(t *testing.T) {
+		missedBlock := insertBlock(board.ID)
+
+		resumed, resp := th.Client.OpenChangeStream(testTeamID, first.ID)
+		th.CheckOK(resp)
+		defer resumed.Close()
+
+		event := nextChange(t, resumed)
+		require.False(t, event.Reset)
+		require.Equal(t, missedBlock.ID, event.Change.BlockID)
+	})
+
+	t.Run("unknown last event", func(t *testing.T) {
+		resumed, resp := th.Client.OpenChangeStream(testTeamID, "1-1")
+		th.CheckOK(resp)
+		defer resumed.Close()
+
+		event := nextChange(t, resumed)
+		require.True(t, event.Reset)
+	})
+
+	t.Run("other users only see the boards they can access", func(t *testing.T) {
+		private := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+		_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+			BoardID:      board.ID,
+			UserID:       th.GetUser2().ID,
+			SchemeEditor: true,
+		})
+		th.CheckOK(resp)
+
+		stream2, resp := th.Client2.OpenChangeStream(testTeamID, "")
+		th.CheckOK(resp)
+		defer stream2.Close()
+
+		insertBlock(private.ID)
+		visible := insertBlock(board.ID)
+
+		event := nextChange(t, stream2)
+		require.Equal(t, visible.ID, event.Change.BlockID)
+	})
+}
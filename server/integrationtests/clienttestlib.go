@@ -58,6 +58,9 @@ type TestHelper struct {
 type FakePermissionPluginAPI struct{}
 
 func (*FakePermissionPluginAPI) LogError(str string, params ...interface{}) {}
+func (*FakePermissionPluginAPI) HasPermissionTo(userID string, permission *mmModel.Permission) bool {
+	return userID == userAdmin
+}
 func (*FakePermissionPluginAPI) HasPermissionToTeam(userID string, teamID string, permission *mmModel.Permission) bool {
 	if userID == userNoTeamMember {
 		return false
@@ -67,6 +70,9 @@ func (*FakePermissionPluginAPI) HasPermissionToTeam(userID string, teamID string
 	}
 	return true
 }
+func (*FakePermissionPluginAPI) HasPermissionToChannel(userID string, channelID string, permission *mmModel.Permission) bool {
+	return userID != userNoTeamMember && channelID != "private-channel"
+}
 
 func getTestConfig() (*config.Configuration, error) {
 	dbType, connectionString, err := sqlstore.PrepareNewTestDatabase()
@@ -108,6 +114,8 @@ func getTestConfig() (*config.Configuration, error) {
 		LoggingCfgJSON:    logging,
 		SessionExpireTime: int64(30 * time.Second),
 		AuthMode:          "native",
+		// the webhooks of the boards call the local test servers
+		BoardWebhookAllowedNetworks: []string{"127.0.0.0/8", "::1"},
 	}, nil
 }
 
@@ -125,7 +133,7 @@ func newTestServerWithLicense(singleUserToken string, licenseType LicenseType) *
 	if err = logger.Configure("", cfg.LoggingCfgJSON, nil); err != nil {
 		panic(err)
 	}
-	innerStore, err := server.NewStore(cfg, logger)
+	innerStore, err := server.NewStore(cfg, nil, logger)
 	if err != nil {
 		panic(err)
 	}
@@ -173,7 +181,7 @@ func newTestServerPluginMode() *server.Server {
 	if err = logger.Configure("", cfg.LoggingCfgJSON, nil); err != nil {
 		panic(err)
 	}
-	innerStore, err := server.NewStore(cfg, logger)
+	innerStore, err := server.NewStore(cfg, nil, logger)
 	if err != nil {
 		panic(err)
 	}
@@ -388,6 +396,16 @@ func (th *TestHelper) CheckForbidden(r *client.Response) {
 	require.Error(th.T, r.Error)
 }
 
+func (th *TestHelper) CheckConflict(r *client.Response) {
+	require.Equal(th.T, http.StatusConflict, r.StatusCode)
+	require.Error(th.T, r.Error)
+}
+
+func (th *TestHelper) CheckLocked(r *client.Response) {
+	require.Equal(th.T, http.StatusLocked, r.StatusCode)
+	require.Error(th.T, r.Error)
+}
+
 func (th *TestHelper) CheckRequestEntityTooLarge(r *client.Response) {
 	require.Equal(th.T, http.StatusRequestEntityTooLarge, r.StatusCode)
 	require.Error(th.T, r.Error)
@@ -2,6 +2,7 @@ package integrationtests
 
 import (
 	"bytes"
+	"encoding/csv"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -64,3 +65,165 @@ func TestExportBoard(t *testing.T) {
 		require.Equal(t, block.Title, blocksImported[0].Title)
 	})
 }
+
+func TestExportBoardCards(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	now := utils.GetMillis()
+	board := &model.Board{
+		ID:        utils.NewID(utils.IDTypeBoard),
+		TeamID:    "test-team",
+		Title:     "Export Cards Board",
+		CreatedBy: th.GetUser1().ID,
+		Type:      model.BoardTypeOpen,
+		CreateAt:  now,
+		UpdateAt:  now,
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "done", "value": "Done"},
+					map[string]interface{}{"id": "todo", "value": "To Do"},
+				},
+			},
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	}
+
+	card := func(title, status string) model.Block {
+		return model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			ParentID: board.ID,
+			BoardID:  board.ID,
+			Type:     model.TypeCard,
+			Title:    title,
+			CreateAt: now,
+			UpdateAt: now,
+			Fields: map[string]interface{}{
+				"properties": map[string]interface{}{"status": status, "notes": "a | b"},
+			},
+		}
+	}
+	view := model.Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		ParentID: board.ID,
+		BoardID:  board.ID,
+		Type:     model.TypeView,
+		Title:    "Done cards",
+		CreateAt: now,
+		UpdateAt: now,
+		Fields: map[string]interface{}{
+			"visiblePropertyIds": []interface{}{"status"},
+			"filter": map[string]interface{}{
+				"operation": "and",
+				"filters": []interface{}{
+					map[string]interface{}{"propertyId": "status", "condition": "includes", "values": []interface{}{"done"}},
+				},
+			},
+		},
+	}
+
+	babs, resp := th.Client.CreateBoardsAndBlocks(&model.BoardsAndBlocks{
+		Boards: []*model.Board{board},
+		Blocks: []model.Block{card("finished card", "done"), card("pending card", "todo"), view},
+	})
+	th.CheckOK(resp)
+	boardID := babs.Boards[0].ID
+	var viewID string
+	for _, block := range babs.Blocks {
+		if block.Type == model.TypeView {
+			viewID = block.ID
+		}
+	}
+
+	t.Run("csv of all the cards", func(t *testing.T) {
+		data, resp := th.Client.ExportBoardCards(boardID, model.ExportFormatCSV, "", nil)
+		th.CheckOK(resp)
+		csv := string(data)
+		require.Contains(t, csv, "Name,Status,Notes\n")
+		require.Contains(t, csv, "finished card,Done,a | b\n")
+		require.Contains(t, csv, "pending card,To Do,a | b\n")
+	})
+
+	t.Run("csv of the cards of a view", func(t *testing.T) {
+		data, resp := th.Client.ExportBoardCards(boardID, model.ExportFormatCSV, viewID, nil)
+		th.CheckOK(resp)
+		require.Equal(t, "Name,Status\nfinished card,Done\n", string(data))
+	})
+
+	t.Run("markdown of the cards that meet a filter", func(t *testing.T) {
+		filter := &model.CardFilter{
+			Operation: model.FilterOperationAnd,
+			Filters: []*model.CardFilter{
+				{PropertyID: "status", Condition: model.FilterConditionNotIncludes, Values: []string{"done"}},
+			},
+		}
+		data, resp := th.Client.ExportBoardCards(boardID, model.ExportFormatMarkdown, "", filter)
+		th.CheckOK(resp)
+		require.Equal(t, "| Name | Status | Notes |\n| --- | --- | --- |\n| pending card | To Do | a \\| b |\n", string(data))
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		filter := &model.CardFilter{PropertyID: "status", Condition: "startsWith"}
+		_, resp := th.Client.ExportBoardCards(boardID, model.ExportFormatCSV, "", filter)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("unknown view", func(t *testing.T) {
+		_, resp := th.Client.ExportBoardCards(boardID, model.ExportFormatCSV, "unknown-view", nil)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, resp := th.Client.ExportBoardCards(boardID, "pdf", "", nil)
+		th.CheckBadRequest(resp)
+	})
+}
+
+func TestAccessReviewReport(t *testing.T) {
+	t.Run("a user without the manage system permission should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		data, resp := th.Client.GetAccessReviewReport(testTeamID)
+		th.CheckForbidden(resp)
+		require.Empty(t, data)
+	})
+
+	t.Run("the report has a row per member of each board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := createTestBoard(t, th, model.BoardTypePrivate)
+		_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{
+			BoardID:      board.ID,
+			UserID:       th.GetUser2().ID,
+			SchemeViewer: true,
+		})
+		require.NoError(t, err)
+		th.Server.App().RecordBoardVisit(th.GetUser2().ID, board.ID)
+
+		var buf bytes.Buffer
+		require.NoError(t, th.Server.App().WriteAccessReviewReport(&buf, testTeamID))
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, model.AccessReviewHeader, records[0])
+
+		rows := map[string][]string{}
+		for _, record := range records[1:] {
+			if record[0] == board.ID {
+				rows[record[3]] = record
+			}
+		}
+		require.Len(t, rows, 2)
+		require.Equal(t, "admin", rows[th.GetUser1().ID][5])
+		require.Equal(t, user2Username, rows[th.GetUser2().ID][4])
+		require.Equal(t, "viewer", rows[th.GetUser2().ID][5])
+		require.NotEmpty(t, rows[th.GetUser2().ID][8])
+		require.Empty(t, rows[th.GetUser2().ID][9])
+	})
+}
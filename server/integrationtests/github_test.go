@@ -0,0 +1,134 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/github"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubIntegration(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	patch := &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To do"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+		},
+	}
+	_, resp := th.Client.PatchBoard(board.ID, patch)
+	require.NoError(t, resp.Error)
+
+	t.Run("the integration can't be set without an encryption key", func(t *testing.T) {
+		_, resp := th.Client.SetGitHubIntegration(board.ID, &model.GitHubIntegration{Repository: "owner/repo", Token: "token"})
+		th.CheckNotImplemented(resp)
+	})
+
+	t.Run("a user without access can't see the integration", func(t *testing.T) {
+		_, resp := th.Client2.GetGitHubIntegration(board.ID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("webhook of a board without integration", func(t *testing.T) {
+		_, resp := th.Client.SendGitHubWebhook(board.ID, "secret", "ping", map[string]interface{}{})
+		th.CheckNotFound(resp)
+	})
+
+	// the integration is stored directly, the test server having no
+	// encryption key
+	require.NoError(t, th.Server.Store().SaveGitHubIntegration(&model.GitHubIntegration{
+		BoardID:        board.ID,
+		Repository:     "owner/repo",
+		StatusProperty: "Status",
+		OpenStatus:     "To do",
+		ClosedStatus:   "done",
+		WebhookSecret:  "secret",
+		ModifiedBy:     th.GetUser1().ID,
+		UpdateAt:       utils.GetMillis(),
+	}))
+
+	issueCards := func() []model.Block {
+		blocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		cards := []model.Block{}
+		for _, block := range blocks {
+			if block.Type == model.TypeCard && block.Fields[model.CardFieldGitHubIssue] != nil {
+				cards = append(cards, block)
+			}
+		}
+		return cards
+	}
+	event := func(action, state string) *github.IssuesEvent {
+		return &github.IssuesEvent{
+			Action:     action,
+			Issue:      github.Issue{Number: 7, Title: "Crash on start", State: state},
+			Repository: github.Repository{FullName: "owner/repo"},
+		}
+	}
+
+	t.Run("the integration is returned without its secrets", func(t *testing.T) {
+		integration, resp := th.Client.GetGitHubIntegration(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, "owner/repo", integration.Repository)
+		require.Equal(t, "secret", integration.WebhookSecret)
+		require.False(t, integration.HasToken)
+	})
+
+	t.Run("webhook with an invalid signature", func(t *testing.T) {
+		_, resp := th.Client.SendGitHubWebhook(board.ID, "wrong", "issues", event("opened", model.GitHubIssueStateOpen))
+		th.CheckUnauthorized(resp)
+		require.Empty(t, issueCards())
+	})
+
+	t.Run("ping", func(t *testing.T) {
+		_, resp := th.Client.SendGitHubWebhook(board.ID, "secret", "ping", map[string]interface{}{"zen": "Keep it simple."})
+		th.CheckOK(resp)
+	})
+
+	t.Run("opened issues are imported", func(t *testing.T) {
+		_, resp := th.Client.SendGitHubWebhook(board.ID, "secret", "issues", event("opened", model.GitHubIssueStateOpen))
+		th.CheckOK(resp)
+
+		cards := issueCards()
+		require.Len(t, cards, 1)
+		require.Equal(t, "Crash on start", cards[0].Title)
+		require.Equal(t, "owner/repo#7", cards[0].Fields[model.CardFieldGitHubIssue])
+		require.Equal(t, map[string]interface{}{"status": "todo"}, cards[0].Fields["properties"])
+	})
+
+	t.Run("closed issues update their card", func(t *testing.T) {
+		_, resp := th.Client.SendGitHubWebhook(board.ID, "secret", "issues", event("closed", model.GitHubIssueStateClosed))
+		th.CheckOK(resp)
+
+		cards := issueCards()
+		require.Len(t, cards, 1)
+		require.Equal(t, map[string]interface{}{"status": "done"}, cards[0].Fields["properties"])
+	})
+
+	t.Run("events of other repositories are ignored", func(t *testing.T) {
+		other := event("reopened", model.GitHubIssueStateOpen)
+		other.Repository.FullName = "owner/other"
+		_, resp := th.Client.SendGitHubWebhook(board.ID, "secret", "issues", other)
+		th.CheckOK(resp)
+
+		cards := issueCards()
+		require.Len(t, cards, 1)
+		require.Equal(t, map[string]interface{}{"status": "done"}, cards[0].Fields["properties"])
+	})
+
+	t.Run("the integration can be deleted", func(t *testing.T) {
+		_, resp := th.Client.DeleteGitHubIntegration(board.ID)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.GetGitHubIntegration(board.ID)
+		th.CheckNotFound(resp)
+		require.Len(t, issueCards(), 1)
+	})
+}
@@ -0,0 +1,35 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestGitLabImport(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	t.Run("unknown importer", func(t *testing.T) {
+		r, err := th.Client.DoAPIPost(th.Client.GetTeamRoute("team-id")+"/import/bitbucket", "{}")
+		th.CheckNotFound(client.BuildErrorResponse(r, err))
+	})
+
+	t.Run("invalid project", func(t *testing.T) {
+		_, resp := th.Client.ImportGitLabIssues("team-id", &model.GitLabImportRequest{Project: "https://gitlab.com/group/project"})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a user without access can't import in a board", func(t *testing.T) {
+		_, resp := th.Client2.ImportGitLabIssues("team-id", &model.GitLabImportRequest{Project: "group/project", BoardID: board.ID})
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("board of another team", func(t *testing.T) {
+		_, resp := th.Client.ImportGitLabIssues("other-team-id", &model.GitLabImportRequest{Project: "group/project", BoardID: board.ID})
+		th.CheckNotFound(resp)
+	})
+}
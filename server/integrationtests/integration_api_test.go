@@ -0,0 +1,124 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationAPI(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	th.Server.Config().APIKeys = []config.APIKeyConfig{
+		{Key: "user1-key", UserID: th.GetUser1().ID},
+		{Key: "user2-key", UserID: th.GetUser2().ID},
+	}
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	patch := &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To do"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+		},
+	}
+	_, resp := th.Client.PatchBoard(board.ID, patch)
+	require.NoError(t, resp.Error)
+
+	t.Run("missing or invalid API key", func(t *testing.T) {
+		_, resp := th.Client.GetIntegrationMe("")
+		th.CheckUnauthorized(resp)
+
+		_, resp = th.Client.GetIntegrationMe("invalid-key")
+		th.CheckUnauthorized(resp)
+	})
+
+	t.Run("user of the API key", func(t *testing.T) {
+		user, resp := th.Client.GetIntegrationMe("user1-key")
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser1().ID, user.UserID)
+	})
+
+	t.Run("the user of the key needs access to the board", func(t *testing.T) {
+		_, resp := th.Client.GetIntegrationNewCards("user2-key", board.ID, "", 0)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client.CreateIntegrationCard("user2-key", board.ID, &model.IntegrationCardRequest{Title: "Card"})
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, resp := th.Client.CreateIntegrationCard("user1-key", board.ID, &model.IntegrationCardRequest{})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.CreateIntegrationCard("user1-key", board.ID, &model.IntegrationCardRequest{
+			Title:      "Card",
+			Properties: map[string]string{"Status": "Unknown"},
+		})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.GetIntegrationNewCards("user1-key", board.ID, "invalid", 0)
+		th.CheckBadRequest(resp)
+	})
+
+	var first *model.IntegrationCard
+	t.Run("create and poll cards", func(t *testing.T) {
+		var resp *client.Response
+		first, resp = th.Client.CreateIntegrationCard("user1-key", board.ID, &model.IntegrationCardRequest{
+			Title:      "First",
+			Properties: map[string]string{"Status": "To do"},
+		})
+		th.CheckOK(resp)
+		require.Equal(t, "First", first.Title)
+		require.Equal(t, th.GetUser1().ID, first.CreatedBy)
+		require.Equal(t, map[string]string{"Status": "To do"}, first.Properties)
+
+		cards, resp := th.Client.GetIntegrationNewCards("user1-key", board.ID, "", 0)
+		th.CheckOK(resp)
+		require.Len(t, cards, 1)
+		require.Equal(t, first.ID, cards[0].ID)
+
+		second, resp := th.Client.CreateIntegrationCard("user1-key", board.ID, &model.IntegrationCardRequest{Title: "Second"})
+		th.CheckOK(resp)
+
+		cards, resp = th.Client.GetIntegrationNewCards("user1-key", board.ID, cards[0].Cursor, 0)
+		th.CheckOK(resp)
+		require.Len(t, cards, 1)
+		require.Equal(t, second.ID, cards[0].ID)
+
+		cards, resp = th.Client.GetIntegrationNewCards("user1-key", board.ID, cards[0].Cursor, 0)
+		th.CheckOK(resp)
+		require.Empty(t, cards)
+	})
+
+	t.Run("update a card and poll its status change", func(t *testing.T) {
+		card, resp := th.Client.UpdateIntegrationCard("user1-key", first.ID, &model.IntegrationCardRequest{
+			Title:      "First renamed",
+			Properties: map[string]string{"Status": "Done"},
+		})
+		th.CheckOK(resp)
+		require.Equal(t, "First renamed", card.Title)
+		require.Equal(t, map[string]string{"Status": "Done"}, card.Properties)
+
+		changes, resp := th.Client.GetIntegrationStatusChanges("user1-key", board.ID, "", 0)
+		th.CheckOK(resp)
+		require.Len(t, changes, 1)
+		require.Equal(t, first.ID, changes[0].Card.ID)
+		require.Equal(t, "Done", changes[0].Status)
+
+		changes, resp = th.Client.GetIntegrationStatusChanges("user1-key", board.ID, changes[0].Cursor, 0)
+		th.CheckOK(resp)
+		require.Empty(t, changes)
+	})
+
+	t.Run("update an unknown card", func(t *testing.T) {
+		_, resp := th.Client.UpdateIntegrationCard("user1-key", "unknown-card", &model.IntegrationCardRequest{Title: "Card"})
+		th.CheckNotFound(resp)
+	})
+}
@@ -0,0 +1,93 @@
+package integrationtests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/mattermost/focalboard/server/ws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLongPoll(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	otherBoard := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	// the inserted blocks get new IDs, so they are matched by their title
+	newBlock := func(boardID string) model.Block {
+		return model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  boardID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+			Title:    utils.NewID(utils.IDTypeNone),
+		}
+	}
+
+	start, resp := th.Client.LongPoll(testTeamID, "", nil, time.Second)
+	th.CheckOK(resp)
+	require.NotEmpty(t, start.Cursor)
+	require.False(t, start.Reset)
+	require.Empty(t, start.Messages)
+
+	t.Run("polls return the changes after the cursor", func(t *testing.T) {
+		block := newBlock(board.ID)
+		_, resp := th.Client.InsertBlocks(board.ID, []model.Block{block})
+		th.CheckOK(resp)
+
+		result, resp := th.Client.LongPoll(testTeamID, start.Cursor, nil, 5*time.Second)
+		th.CheckOK(resp)
+		require.False(t, result.Reset)
+		require.Len(t, result.Messages, 1)
+		require.NotEqual(t, start.Cursor, result.Cursor)
+
+		var msg ws.UpdateBlockMsg
+		require.NoError(t, json.Unmarshal(result.Messages[0], &msg))
+		require.Equal(t, "UPDATE_BLOCK", msg.Action)
+		require.Equal(t, block.Title, msg.Block.Title)
+	})
+
+	t.Run("polls wait for the subscribed boards", func(t *testing.T) {
+		cursor, resp := th.Client.LongPoll(testTeamID, "", nil, 0)
+		th.CheckOK(resp)
+
+		block := newBlock(board.ID)
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			_, resp := th.Client.InsertBlocks(otherBoard.ID, []model.Block{newBlock(otherBoard.ID)})
+			th.CheckOK(resp)
+			_, resp = th.Client.InsertBlocks(board.ID, []model.Block{block})
+			th.CheckOK(resp)
+		}()
+
+		result, resp := th.Client.LongPoll(testTeamID, cursor.Cursor, []string{board.ID}, 5*time.Second)
+		th.CheckOK(resp)
+		require.Len(t, result.Messages, 1)
+
+		var msg ws.UpdateBlockMsg
+		require.NoError(t, json.Unmarshal(result.Messages[0], &msg))
+		require.Equal(t, block.Title, msg.Block.Title)
+	})
+
+	t.Run("polls without changes time out", func(t *testing.T) {
+		cursor, resp := th.Client.LongPoll(testTeamID, "", nil, 0)
+		th.CheckOK(resp)
+
+		result, resp := th.Client.LongPoll(testTeamID, cursor.Cursor, nil, time.Second)
+		th.CheckOK(resp)
+		require.Empty(t, result.Messages)
+		require.Equal(t, cursor.Cursor, result.Cursor)
+	})
+
+	t.Run("unknown cursors reset", func(t *testing.T) {
+		result, resp := th.Client.LongPoll(testTeamID, "1-1", nil, time.Second)
+		th.CheckOK(resp)
+		require.True(t, result.Reset)
+		require.NotEmpty(t, result.Cursor)
+	})
+}
@@ -1043,6 +1043,38 @@ func TestPermissionsCreateBoardMembers(t *testing.T) {
 	runTestCases(t, ttCases, testData, clients)
 }
 
+func TestPermissionsBoardInvitations(t *testing.T) {
+	th := SetupTestHelperPluginMode(t)
+	defer th.TearDown()
+	testData := setupData(t, th)
+	clients := setupClients(th)
+
+	ttCases := []TestCase{
+		{"/boards/{PRIVATE_BOARD_ID}/invitations", methodGet, "", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/invitations", methodGet, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/invitations", methodGet, "", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/invitations", methodGet, "", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/invitations", methodGet, "", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/invitations", methodGet, "", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/invitations", methodGet, "", userAdmin, http.StatusOK, 0},
+
+		{"/boards/{PUBLIC_BOARD_ID}/invitations", methodGet, "", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/invitations", methodGet, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/invitations", methodGet, "", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/invitations", methodGet, "", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/invitations", methodGet, "", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/invitations", methodGet, "", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/invitations", methodGet, "", userAdmin, http.StatusOK, 0},
+
+		// invitations can only be accepted by the invited users
+		{"/boards/{PRIVATE_BOARD_ID}/invitations/accept", methodPost, "", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/invitations/accept", methodPost, "", userNoTeamMember, http.StatusNotFound, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/invitations/accept", methodPost, "", userTeamMember, http.StatusNotFound, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/invitations/accept", methodPost, "", userAdmin, http.StatusNotFound, 0},
+	}
+	runTestCases(t, ttCases, testData, clients)
+}
+
 func TestPermissionsUpdateBoardMember(t *testing.T) {
 	th := SetupTestHelperPluginMode(t)
 	defer th.TearDown()
@@ -1991,6 +2023,33 @@ func TestPermissionsUpdateCategoryBoard(t *testing.T) {
 	runTestCases(t, ttCases, testData, clients)
 }
 
+func TestPermissionsUpdateCategoryBoards(t *testing.T) {
+	th := SetupTestHelperPluginMode(t)
+	defer th.TearDown()
+	testData := setupData(t, th)
+	clients := setupClients(th)
+
+	categoryTeamMember, err := th.Server.App().CreateCategory(
+		&model.Category{Name: "Test category", TeamID: "test-team", UserID: userTeamMember, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
+	)
+	require.NoError(t, err)
+	categoryEditor, err := th.Server.App().CreateCategory(
+		&model.Category{Name: "Test category", TeamID: "test-team", UserID: userEditor, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
+	)
+	require.NoError(t, err)
+
+	boards := toJSON(t, model.CategoryBoardsUpdate{BoardIDs: []string{testData.publicBoard.ID, testData.privateBoard.ID}})
+
+	ttCases := []TestCase{
+		{"/teams/test-team/categories/any/boards", methodPost, boards, userAnon, http.StatusUnauthorized, 0},
+		{"/teams/test-team/categories/" + categoryTeamMember.ID + "/boards", methodPost, boards, userTeamMember, http.StatusOK, 0},
+		{"/teams/test-team/categories/" + categoryEditor.ID + "/boards", methodPost, boards, userEditor, http.StatusOK, 0},
+		{"/teams/test-team/categories/" + categoryEditor.ID + "/boards", methodPost, boards, userTeamMember, http.StatusForbidden, 0},
+		{"/teams/other-team/categories/" + categoryEditor.ID + "/boards", methodPost, boards, userEditor, http.StatusBadRequest, 0},
+	}
+	runTestCases(t, ttCases, testData, clients)
+}
+
 func TestPermissionsGetFile(t *testing.T) {
 	th := SetupTestHelperPluginMode(t)
 	defer th.TearDown()
@@ -2144,6 +2203,28 @@ func TestPermissionsOnboard(t *testing.T) {
 	runTestCases(t, ttCases, testData, clients)
 }
 
+func TestPermissionsBoardArchiveCopy(t *testing.T) {
+	th := SetupTestHelperPluginMode(t)
+	defer th.TearDown()
+	testData := setupData(t, th)
+	clients := setupClients(th)
+
+	// the request is incomplete, so the copy is refused once the
+	// permissions are checked
+	body := toJSON(t, model.RemoteBoardCopyRequest{ServerURL: "http://localhost"})
+
+	ttCases := []TestCase{
+		{"/boards/{PUBLIC_BOARD_ID}/archive/copy", methodPost, body, userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/archive/copy", methodPost, body, userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/archive/copy", methodPost, body, userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/archive/copy", methodPost, body, userViewer, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/archive/copy", methodPost, body, userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/archive/copy", methodPost, body, userEditor, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/archive/copy", methodPost, body, userAdmin, http.StatusBadRequest, 0},
+	}
+	runTestCases(t, ttCases, testData, clients)
+}
+
 func TestPermissionsBoardArchiveExport(t *testing.T) {
 	th := SetupTestHelperPluginMode(t)
 	defer th.TearDown()
@@ -2203,3 +2284,185 @@ func TestPermissionsBoardArchiveImport(t *testing.T) {
 	}
 	runTestCases(t, ttCases, testData, clients)
 }
+
+func TestPermissionsStatistics(t *testing.T) {
+	th := SetupTestHelperPluginMode(t)
+	defer th.TearDown()
+	testData := setupData(t, th)
+	clients := setupClients(th)
+
+	ttCases := []TestCase{
+		{"/statistics", methodGet, "", userAnon, http.StatusUnauthorized, 0},
+		{"/statistics", methodGet, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/statistics", methodGet, "", userTeamMember, http.StatusForbidden, 0},
+		{"/statistics", methodGet, "", userViewer, http.StatusForbidden, 0},
+		{"/statistics", methodGet, "", userCommenter, http.StatusForbidden, 0},
+		{"/statistics", methodGet, "", userEditor, http.StatusForbidden, 0},
+		{"/statistics", methodGet, "", userAdmin, http.StatusOK, 1},
+	}
+	runTestCases(t, ttCases, testData, clients)
+}
+
+func TestPermissionsMigrationStatus(t *testing.T) {
+	th := SetupTestHelperPluginMode(t)
+	defer th.TearDown()
+	testData := setupData(t, th)
+	clients := setupClients(th)
+
+	ttCases := []TestCase{
+		{"/migrations/status", methodGet, "", userAnon, http.StatusUnauthorized, 0},
+		{"/migrations/status", methodGet, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/migrations/status", methodGet, "", userTeamMember, http.StatusForbidden, 0},
+		{"/migrations/status", methodGet, "", userViewer, http.StatusForbidden, 0},
+		{"/migrations/status", methodGet, "", userCommenter, http.StatusForbidden, 0},
+		{"/migrations/status", methodGet, "", userEditor, http.StatusForbidden, 0},
+		{"/migrations/status", methodGet, "", userAdmin, http.StatusOK, 1},
+		{"/migrations/status?dryRun=true", methodGet, "", userAdmin, http.StatusOK, 1},
+	}
+	runTestCases(t, ttCases, testData, clients)
+}
+
+func TestPermissionsRunMaintenance(t *testing.T) {
+	th := SetupTestHelperPluginMode(t)
+	defer th.TearDown()
+	testData := setupData(t, th)
+	clients := setupClients(th)
+
+	ttCases := []TestCase{
+		{"/maintenance", methodPost, "", userAnon, http.StatusUnauthorized, 0},
+		{"/maintenance", methodPost, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/maintenance", methodPost, "", userTeamMember, http.StatusForbidden, 0},
+		{"/maintenance", methodPost, "", userViewer, http.StatusForbidden, 0},
+		{"/maintenance", methodPost, "", userCommenter, http.StatusForbidden, 0},
+		{"/maintenance", methodPost, "", userEditor, http.StatusForbidden, 0},
+		{"/maintenance", methodPost, "", userAdmin, http.StatusOK, 1},
+	}
+	runTestCases(t, ttCases, testData, clients)
+}
+
+func TestPermissionsMigratePersonalBoards(t *testing.T) {
+	th := SetupTestHelperPluginMode(t)
+	defer th.TearDown()
+	testData := setupData(t, th)
+	clients := setupClients(th)
+
+	// the personal team is not a valid destination, so the users allowed
+	// to migrate get a bad request instead of a forbidden
+	body := toJSON(t, model.PersonalBoardsMigrationRequest{TeamID: model.GlobalTeamID})
+
+	ttCases := []TestCase{
+		{"/personal-boards/migrate", methodPost, body, userAnon, http.StatusUnauthorized, 0},
+		{"/personal-boards/migrate", methodPost, body, userNoTeamMember, http.StatusForbidden, 0},
+		{"/personal-boards/migrate", methodPost, body, userTeamMember, http.StatusForbidden, 0},
+		{"/personal-boards/migrate", methodPost, body, userViewer, http.StatusForbidden, 0},
+		{"/personal-boards/migrate", methodPost, body, userCommenter, http.StatusForbidden, 0},
+		{"/personal-boards/migrate", methodPost, body, userEditor, http.StatusForbidden, 0},
+		{"/personal-boards/migrate", methodPost, body, userAdmin, http.StatusBadRequest, 0},
+	}
+	runTestCases(t, ttCases, testData, clients)
+}
+
+func TestPermissionsGetBoardAdminView(t *testing.T) {
+	th := SetupTestHelperPluginMode(t)
+	defer th.TearDown()
+	testData := setupData(t, th)
+	clients := setupClients(th)
+
+	ttCases := []TestCase{
+		{"/boards/{PRIVATE_BOARD_ID}/admin-view", methodGet, "", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/admin-view", methodGet, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/admin-view", methodGet, "", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/admin-view", methodGet, "", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/admin-view", methodGet, "", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/admin-view", methodGet, "", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/admin-view", methodGet, "", userAdmin, http.StatusOK, 1},
+	}
+	runTestCases(t, ttCases, testData, clients)
+}
+
+func TestPermissionsSendTestNotification(t *testing.T) {
+	th := SetupTestHelperPluginMode(t)
+	defer th.TearDown()
+	testData := setupData(t, th)
+	clients := setupClients(th)
+
+	ttCases := []TestCase{
+		{"/notifications/test", methodPost, "", userAnon, http.StatusUnauthorized, 0},
+		{"/notifications/test", methodPost, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/notifications/test", methodPost, "", userTeamMember, http.StatusForbidden, 0},
+		{"/notifications/test", methodPost, "", userViewer, http.StatusForbidden, 0},
+		{"/notifications/test", methodPost, "", userCommenter, http.StatusForbidden, 0},
+		{"/notifications/test", methodPost, "", userEditor, http.StatusForbidden, 0},
+		{"/notifications/test", methodPost, "", userAdmin, http.StatusOK, 1},
+	}
+	runTestCases(t, ttCases, testData, clients)
+}
+
+func TestPermissionsChannelLink(t *testing.T) {
+	th := SetupTestHelperPluginMode(t)
+	defer th.TearDown()
+	testData := setupData(t, th)
+	clients := setupClients(th)
+
+	linkBody := toJSON(t, model.ChannelLinkRequest{ChannelID: "test-channel"})
+
+	ttCases := []TestCase{
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodPost, linkBody, userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodPost, linkBody, userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodPost, linkBody, userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodPost, linkBody, userViewer, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodPost, linkBody, userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodPost, linkBody, userEditor, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodPost, toJSON(t, model.ChannelLinkRequest{ChannelID: "private-channel"}), userAdmin, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodPost, toJSON(t, model.ChannelLinkRequest{ChannelID: "other-team-channel"}), userAdmin, http.StatusBadRequest, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodPost, toJSON(t, model.ChannelLinkRequest{ChannelID: "missing-channel"}), userAdmin, http.StatusNotFound, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodPost, linkBody, userAdmin, http.StatusOK, 1},
+
+		{"/channels/test-channel/boards", methodGet, "", userAnon, http.StatusUnauthorized, 0},
+		{"/channels/test-channel/boards", methodGet, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/channels/test-channel/boards", methodGet, "", userTeamMember, http.StatusOK, 0},
+		{"/channels/test-channel/boards", methodGet, "", userViewer, http.StatusOK, 1},
+		{"/channels/test-channel/boards", methodGet, "", userAdmin, http.StatusOK, 1},
+
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodDelete, "", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodDelete, "", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodDelete, "", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/channel", methodDelete, "", userAdmin, http.StatusOK, 1},
+
+		{"/channels/test-channel/boards", methodGet, "", userAdmin, http.StatusOK, 0},
+	}
+	runTestCases(t, ttCases, testData, clients)
+}
+
+func TestPermissionsBotActions(t *testing.T) {
+	th := SetupTestHelperPluginMode(t)
+	defer th.TearDown()
+	testData := setupData(t, th)
+	clients := setupClients(th)
+
+	cardBody := toJSON(t, model.BotCreateCardRequest{Title: "From a script"})
+	commentBody := toJSON(t, model.BotCommentRequest{Text: "deployed"})
+	propertiesBody := toJSON(t, model.BotSetPropertiesRequest{Properties: map[string]string{"Status": "Done"}})
+
+	ttCases := []TestCase{
+		{"/bot/boards/{PRIVATE_BOARD_ID}/cards", methodPost, cardBody, userAnon, http.StatusUnauthorized, 0},
+		{"/bot/boards/{PRIVATE_BOARD_ID}/cards", methodPost, cardBody, userNoTeamMember, http.StatusForbidden, 0},
+		{"/bot/boards/{PRIVATE_BOARD_ID}/cards", methodPost, cardBody, userTeamMember, http.StatusForbidden, 0},
+		{"/bot/boards/{PRIVATE_BOARD_ID}/cards", methodPost, cardBody, userViewer, http.StatusForbidden, 0},
+		{"/bot/boards/{PRIVATE_BOARD_ID}/cards", methodPost, cardBody, userCommenter, http.StatusForbidden, 0},
+		{"/bot/boards/{PRIVATE_BOARD_ID}/cards", methodPost, toJSON(t, model.BotCreateCardRequest{}), userEditor, http.StatusBadRequest, 0},
+		{"/bot/boards/{PRIVATE_BOARD_ID}/cards", methodPost, cardBody, userEditor, http.StatusOK, 1},
+		{"/bot/boards/{PRIVATE_BOARD_ID}/cards", methodPost, cardBody, userAdmin, http.StatusOK, 1},
+
+		{"/bot/cards/block-4/comments", methodPost, commentBody, userAnon, http.StatusUnauthorized, 0},
+		{"/bot/cards/block-4/comments", methodPost, commentBody, userNoTeamMember, http.StatusForbidden, 0},
+		{"/bot/cards/block-4/comments", methodPost, commentBody, userViewer, http.StatusForbidden, 0},
+		{"/bot/cards/missing-card/comments", methodPost, commentBody, userEditor, http.StatusNotFound, 0},
+		{"/bot/cards/block-4/comments", methodPost, commentBody, userEditor, http.StatusOK, 1},
+
+		{"/bot/cards/block-4/properties", methodPatch, propertiesBody, userAnon, http.StatusUnauthorized, 0},
+		{"/bot/cards/block-4/properties", methodPatch, propertiesBody, userCommenter, http.StatusForbidden, 0},
+		{"/bot/cards/block-4/properties", methodPatch, propertiesBody, userEditor, http.StatusBadRequest, 0},
+	}
+	runTestCases(t, ttCases, testData, clients)
+}
@@ -1,11 +1,15 @@
 package integrationtests
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"strings"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/store"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
 )
 
 var errTestStore = errors.New("plugin test store error")
@@ -79,6 +83,12 @@ func NewPluginTestStore(innerStore store.Store) *PluginTestStore {
 	}
 }
 
+func (s *PluginTestStore) WithContext(ctx context.Context) store.Store {
+	scoped := *s
+	scoped.Store = s.Store.WithContext(ctx)
+	return &scoped
+}
+
 func (s *PluginTestStore) GetTeam(id string) (*model.Team, error) {
 	switch id {
 	case "0":
@@ -93,6 +103,16 @@ func (s *PluginTestStore) GetTeam(id string) (*model.Team, error) {
 	return nil, errTestStore
 }
 
+func (s *PluginTestStore) GetChannel(channelID string) (*mmModel.Channel, error) {
+	switch channelID {
+	case "test-channel", "private-channel":
+		return &mmModel.Channel{Id: channelID, TeamId: s.testTeam.ID, Type: mmModel.ChannelTypeOpen}, nil
+	case "other-team-channel":
+		return &mmModel.Channel{Id: channelID, TeamId: s.otherTeam.ID, Type: mmModel.ChannelTypeOpen}, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
 func (s *PluginTestStore) GetTeamsForUser(userID string) ([]*model.Team, error) {
 	switch userID {
 	case "no-team-member":
@@ -118,6 +118,32 @@ func TestGetSubscriptions(t *testing.T) {
 		require.Len(t, subsFound, 5)
 		assert.ElementsMatch(t, mySubs, subsFound)
 	})
+
+	t.Run("Get subscriptions by page", func(t *testing.T) {
+		user, resp := th.Client.GetMe()
+		require.NoError(t, resp.Error)
+
+		allSubs, resp := th.Client.GetSubscriptions(user.ID)
+		require.NoError(t, resp.Error)
+
+		firstPage, resp := th.Client.GetSubscriptionsPage(user.ID, 0, 3)
+		require.NoError(t, resp.Error)
+		require.Len(t, firstPage, 3)
+
+		secondPage, resp := th.Client.GetSubscriptionsPage(user.ID, 1, 3)
+		require.NoError(t, resp.Error)
+		require.Len(t, secondPage, len(allSubs)-3)
+
+		assert.ElementsMatch(t, allSubs, append(firstPage, secondPage...))
+	})
+
+	t.Run("Invalid page size", func(t *testing.T) {
+		user, resp := th.Client.GetMe()
+		require.NoError(t, resp.Error)
+
+		_, resp = th.Client.GetSubscriptionsPage(user.ID, 0, 0)
+		th.CheckBadRequest(resp)
+	})
 }
 
 func TestDeleteSubscription(t *testing.T) {
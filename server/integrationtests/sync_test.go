@@ -0,0 +1,76 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	// the inserted blocks get new IDs
+	insertBlock := func(boardID string) model.Block {
+		blocks, resp := th.Client.InsertBlocks(boardID, []model.Block{{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  boardID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+		}})
+		th.CheckOK(resp)
+		require.Len(t, blocks, 1)
+		return blocks[0]
+	}
+
+	insertBlock(board.ID)
+	deleted := insertBlock(board.ID)
+
+	t.Run("the first sync returns the whole board", func(t *testing.T) {
+		result, resp := th.Client.Sync(&model.SyncRequest{Boards: map[string]int64{board.ID: 0}})
+		th.CheckOK(resp)
+		require.NotZero(t, result.SyncAt)
+		require.Len(t, result.Boards, 1)
+		require.NotNil(t, result.Boards[0].Board)
+		require.Len(t, result.Boards[0].Blocks, 2)
+		require.Empty(t, result.Boards[0].DeletedBlockIDs)
+	})
+
+	t.Run("later syncs only return the changes", func(t *testing.T) {
+		first, resp := th.Client.Sync(&model.SyncRequest{Boards: map[string]int64{board.ID: 0}})
+		th.CheckOK(resp)
+		time.Sleep(10 * time.Millisecond)
+
+		added := insertBlock(board.ID)
+		_, resp = th.Client.DeleteBlock(board.ID, deleted.ID)
+		th.CheckOK(resp)
+
+		result, resp := th.Client.Sync(&model.SyncRequest{Boards: map[string]int64{board.ID: first.SyncAt}})
+		th.CheckOK(resp)
+		require.Len(t, result.Boards, 1)
+		require.Nil(t, result.Boards[0].Board)
+		require.Len(t, result.Boards[0].Blocks, 1)
+		require.Equal(t, added.ID, result.Boards[0].Blocks[0].ID)
+		require.Equal(t, []string{deleted.ID}, result.Boards[0].DeletedBlockIDs)
+	})
+
+	t.Run("boards the user can't see are removed", func(t *testing.T) {
+		private := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+		result, resp := th.Client2.Sync(&model.SyncRequest{Boards: map[string]int64{private.ID: 0}})
+		th.CheckOK(resp)
+		require.Len(t, result.Boards, 1)
+		require.True(t, result.Boards[0].Removed)
+		require.Empty(t, result.Boards[0].Blocks)
+	})
+
+	t.Run("at least one board is required", func(t *testing.T) {
+		_, resp := th.Client.Sync(&model.SyncRequest{})
+		th.CheckBadRequest(resp)
+	})
+}
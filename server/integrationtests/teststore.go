@@ -1,6 +1,8 @@
 package integrationtests
 
 import (
+	"context"
+
 	"github.com/mattermost/focalboard/server/services/store"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
@@ -108,3 +110,9 @@ func NewTestProfessionalStore(store store.Store) *TestStore {
 func (s *TestStore) GetLicense() *mmModel.License {
 	return s.license
 }
+
+func (s *TestStore) WithContext(ctx context.Context) store.Store {
+	scoped := *s
+	scoped.Store = s.Store.WithContext(ctx)
+	return &scoped
+}
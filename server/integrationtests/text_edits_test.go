@@ -0,0 +1,118 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditBlockText(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+		UserID:       th.GetUser2().ID,
+		BoardID:      board.ID,
+		SchemeEditor: true,
+	})
+	th.CheckOK(resp)
+
+	getBlock := func(blockID string) model.Block {
+		blocks, resp := th.Client.GetBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		for _, block := range blocks {
+			if block.ID == blockID {
+				return block
+			}
+		}
+		require.FailNow(t, "block not found", blockID)
+		return model.Block{}
+	}
+
+	insertBlock := func(blockType model.BlockType, title string) model.Block {
+		blocks, resp := th.Client.InsertBlocks(board.ID, []model.Block{{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     blockType,
+			Title:    title,
+		}})
+		th.CheckOK(resp)
+		block := getBlock(blocks[0].ID)
+		// the edits are versioned by their update time
+		time.Sleep(10 * time.Millisecond)
+		return block
+	}
+
+	t.Run("concurrent edits are merged", func(t *testing.T) {
+		block := insertBlock(model.TypeText, "Hello world")
+
+		edit, resp := th.Client.EditBlockText(board.ID, block.ID, &model.TextEditRequest{
+			BaseUpdateAt: block.UpdateAt,
+			Delta:        model.TextDelta{{Retain: 6}, {Insert: "big "}},
+		})
+		th.CheckOK(resp)
+		require.Equal(t, block.UpdateAt, edit.BaseUpdateAt)
+		require.Greater(t, edit.UpdateAt, block.UpdateAt)
+		time.Sleep(10 * time.Millisecond)
+
+		// the second user edits the text they loaded before the first edit
+		edit, resp = th.Client2.EditBlockText(board.ID, block.ID, &model.TextEditRequest{
+			BaseUpdateAt: block.UpdateAt,
+			Delta:        model.TextDelta{{Retain: 11}, {Insert: "!"}},
+		})
+		th.CheckOK(resp)
+		require.Equal(t, model.TextDelta{{Retain: 15}, {Insert: "!"}}, edit.Delta)
+
+		updated := getBlock(block.ID)
+		require.Equal(t, "Hello big world!", updated.Title)
+		require.Equal(t, edit.UpdateAt, updated.UpdateAt)
+	})
+
+	t.Run("a delta based on an unknown version conflicts", func(t *testing.T) {
+		block := insertBlock(model.TypeText, "Text")
+
+		_, resp := th.Client.EditBlockText(board.ID, block.ID, &model.TextEditRequest{
+			BaseUpdateAt: block.UpdateAt - 1000,
+			Delta:        model.TextDelta{{Insert: "More "}},
+		})
+		th.CheckConflict(resp)
+	})
+
+	t.Run("invalid delta or block", func(t *testing.T) {
+		block := insertBlock(model.TypeText, "Text")
+		_, resp := th.Client.EditBlockText(board.ID, block.ID, &model.TextEditRequest{
+			BaseUpdateAt: block.UpdateAt,
+			Delta:        model.TextDelta{{Retain: 10}},
+		})
+		th.CheckBadRequest(resp)
+
+		card := insertBlock(model.TypeCard, "Card")
+		_, resp = th.Client.EditBlockText(board.ID, card.ID, &model.TextEditRequest{
+			BaseUpdateAt: card.UpdateAt,
+			Delta:        model.TextDelta{{Insert: "New "}},
+		})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.EditBlockText(board.ID, "missing-id", &model.TextEditRequest{
+			BaseUpdateAt: 1,
+			Delta:        model.TextDelta{{Insert: "New "}},
+		})
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("a user without access can't edit", func(t *testing.T) {
+		other := th.CreateBoard("team-id", model.BoardTypePrivate)
+		_, resp := th.Client2.EditBlockText(other.ID, "block-id", &model.TextEditRequest{
+			BaseUpdateAt: 1,
+			Delta:        model.TextDelta{{Insert: "New "}},
+		})
+		th.CheckForbidden(resp)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/mattermost/focalboard/server/api"
+	"github.com/mattermost/focalboard/server/client"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 	"github.com/stretchr/testify/require"
@@ -266,3 +267,205 @@ func TestTeamUploadFile(t *testing.T) {
 		require.Nil(t, result)
 	})
 }
+
+func TestGetMyMentions(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	userID := th.GetUser1().ID
+
+	for i := 0; i < 2; i++ {
+		mention := &model.Mention{
+			UserID:      userID,
+			TeamID:      "team-id",
+			BoardID:     board.ID,
+			CardID:      utils.NewID(utils.IDTypeCard),
+			BlockID:     utils.NewID(utils.IDTypeBlock),
+			MentionedBy: userID,
+			Extract:     "hello @user",
+			CreateAt:    int64(1000 + i),
+		}
+		require.NoError(t, th.Server.Store().InsertMention(mention))
+	}
+
+	t.Run("the mentions of the user are returned", func(t *testing.T) {
+		mentions, resp := th.Client.GetMyMentions(false)
+		th.CheckOK(resp)
+		require.Len(t, mentions, 2)
+	})
+
+	t.Run("mark a mention as read", func(t *testing.T) {
+		mentions, resp := th.Client.GetMyMentions(true)
+		th.CheckOK(resp)
+		require.Len(t, mentions, 2)
+
+		resp = th.Client.MarkMentionsRead([]string{mentions[0].ID})
+		th.CheckOK(resp)
+
+		unread, resp := th.Client.GetMyMentions(true)
+		th.CheckOK(resp)
+		require.Len(t, unread, 1)
+		require.Equal(t, mentions[1].ID, unread[0].ID)
+	})
+
+	t.Run("mark all mentions as read", func(t *testing.T) {
+		resp := th.Client.MarkMentionsRead(nil)
+		th.CheckOK(resp)
+
+		unread, resp := th.Client.GetMyMentions(true)
+		th.CheckOK(resp)
+		require.Empty(t, unread)
+
+		mentions, resp := th.Client.GetMyMentions(false)
+		th.CheckOK(resp)
+		require.Len(t, mentions, 2)
+	})
+
+	t.Run("the mentions can be paged", func(t *testing.T) {
+		mentions, resp := th.Client.GetMyMentionsWithOptions(client.GetMentionsOptions{Limit: 1})
+		th.CheckOK(resp)
+		require.Len(t, mentions, 1)
+
+		all, resp := th.Client.GetAllMyMentions(false, 1)
+		th.CheckOK(resp)
+		require.Len(t, all, 2)
+		require.Equal(t, mentions[0].ID, all[0].ID)
+	})
+
+	t.Run("other users don't see the mentions", func(t *testing.T) {
+		mentions, resp := th.Client2.GetMyMentions(false)
+		th.CheckOK(resp)
+		require.Empty(t, mentions)
+	})
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("no preferences by default", func(t *testing.T) {
+		prefs, resp := th.Client.GetNotificationPreferences()
+		th.CheckOK(resp)
+		require.Empty(t, prefs)
+		require.Equal(t, model.DefaultNotificationChannels, prefs.ChannelsFor(model.NotifyEventMention))
+	})
+
+	t.Run("update the preferences", func(t *testing.T) {
+		prefs := model.NotificationPreferences{
+			model.NotifyEventMention:    {model.NotifyChannelDM, model.NotifyChannelEmail},
+			model.NotifyEventAssignment: {},
+		}
+
+		updated, resp := th.Client.UpdateNotificationPreferences(prefs)
+		th.CheckOK(resp)
+		require.Equal(t, prefs, updated)
+
+		fetched, resp := th.Client.GetNotificationPreferences()
+		th.CheckOK(resp)
+		require.Equal(t, prefs, fetched)
+		require.Empty(t, fetched.ChannelsFor(model.NotifyEventAssignment))
+	})
+
+	t.Run("unknown channels are rejected", func(t *testing.T) {
+		prefs := model.NotificationPreferences{
+			model.NotifyEventMention: {"carrier-pigeon"},
+		}
+
+		_, resp := th.Client.UpdateNotificationPreferences(prefs)
+		th.CheckBadRequest(resp)
+	})
+}
+
+func TestDueDigestSettings(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		settings, resp := th.Client.GetDueDigestSettings()
+		th.CheckOK(resp)
+		require.False(t, settings.Enabled)
+		require.Equal(t, model.DefaultDueDigestHour, settings.Hour)
+	})
+
+	t.Run("enable the digest", func(t *testing.T) {
+		updated, resp := th.Client.UpdateDueDigestSettings(&model.DueDigestSettings{Enabled: true, Hour: 7})
+		th.CheckOK(resp)
+		require.True(t, updated.Enabled)
+		require.Equal(t, 7, updated.Hour)
+
+		fetched, resp := th.Client.GetDueDigestSettings()
+		th.CheckOK(resp)
+		require.Equal(t, updated, fetched)
+	})
+
+	t.Run("invalid hours are rejected", func(t *testing.T) {
+		_, resp := th.Client.UpdateDueDigestSettings(&model.DueDigestSettings{Enabled: true, Hour: 25})
+		th.CheckBadRequest(resp)
+	})
+}
+
+func TestQuietHours(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("none by default", func(t *testing.T) {
+		quietHours, resp := th.Client.GetQuietHours()
+		th.CheckOK(resp)
+		require.Nil(t, quietHours)
+	})
+
+	t.Run("set the quiet hours", func(t *testing.T) {
+		updated, resp := th.Client.UpdateQuietHours(&model.QuietHours{Start: "22:00", End: "07:30"})
+		th.CheckOK(resp)
+		require.Equal(t, &model.QuietHours{Start: "22:00", End: "07:30"}, updated)
+
+		fetched, resp := th.Client.GetQuietHours()
+		th.CheckOK(resp)
+		require.Equal(t, updated, fetched)
+	})
+
+	t.Run("invalid quiet hours are rejected", func(t *testing.T) {
+		_, resp := th.Client.UpdateQuietHours(&model.QuietHours{Start: "25:00", End: "07:00"})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("clear the quiet hours", func(t *testing.T) {
+		updated, resp := th.Client.UpdateQuietHours(nil)
+		th.CheckOK(resp)
+		require.Nil(t, updated)
+
+		fetched, resp := th.Client.GetQuietHours()
+		th.CheckOK(resp)
+		require.Nil(t, fetched)
+	})
+}
+
+func TestStaleDigestSettings(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		settings, resp := th.Client.GetStaleDigestSettings()
+		th.CheckOK(resp)
+		require.False(t, settings.Enabled)
+		require.Equal(t, model.DefaultStaleCardDays, settings.Days)
+		require.Equal(t, model.DefaultStaleDigestWeekday, settings.Weekday)
+	})
+
+	t.Run("enable the digest", func(t *testing.T) {
+		updated, resp := th.Client.UpdateStaleDigestSettings(&model.StaleDigestSettings{Enabled: true, Days: 30, Weekday: 5, Hour: 16})
+		th.CheckOK(resp)
+		require.True(t, updated.Enabled)
+		require.Equal(t, 30, updated.Days)
+
+		fetched, resp := th.Client.GetStaleDigestSettings()
+		th.CheckOK(resp)
+		require.Equal(t, updated, fetched)
+	})
+
+	t.Run("invalid settings are rejected", func(t *testing.T) {
+		_, resp := th.Client.UpdateStaleDigestSettings(&model.StaleDigestSettings{Enabled: true, Days: 14, Weekday: 7, Hour: 9})
+		th.CheckBadRequest(resp)
+	})
+}
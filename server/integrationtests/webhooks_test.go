@@ -0,0 +1,176 @@
+package integrationtests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncomingWebhook(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	th.Server.Config().IncomingWebhooks = []config.IncomingWebhookConfig{
+		{ID: "hook-id", Secret: "secret", BoardID: board.ID, UserID: th.GetUser1().ID},
+		{ID: "no-secret", BoardID: board.ID, UserID: th.GetUser1().ID},
+	}
+
+	t.Run("signed request creates a card", func(t *testing.T) {
+		card, resp := th.Client.SendIncomingWebhook("hook-id", "secret", &model.BotCreateCardRequest{Title: "from webhook"})
+		th.CheckOK(resp)
+		require.NotNil(t, card)
+		require.Equal(t, board.ID, card.BoardID)
+		require.Equal(t, "from webhook", card.Title)
+		require.Equal(t, th.GetUser1().ID, card.CreatedBy)
+	})
+
+	t.Run("request signed with another secret", func(t *testing.T) {
+		_, resp := th.Client.SendIncomingWebhook("hook-id", "wrong", &model.BotCreateCardRequest{Title: "from webhook"})
+		th.CheckUnauthorized(resp)
+	})
+
+	t.Run("unknown webhook", func(t *testing.T) {
+		_, resp := th.Client.SendIncomingWebhook("other-id", "secret", &model.BotCreateCardRequest{Title: "from webhook"})
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("webhook without a secret", func(t *testing.T) {
+		_, resp := th.Client.SendIncomingWebhook("no-secret", "", &model.BotCreateCardRequest{Title: "from webhook"})
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("missing title", func(t *testing.T) {
+		_, resp := th.Client.SendIncomingWebhook("hook-id", "secret", &model.BotCreateCardRequest{})
+		th.CheckBadRequest(resp)
+	})
+}
+
+func TestBoardWebhooks(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+	var webhookID string
+
+	t.Run("create a webhook", func(t *testing.T) {
+		created, resp := th.Client.CreateBoardWebhook(board.ID, &model.BoardWebhook{
+			URL:     "https://example.com/hook",
+			Events:  []string{model.BoardWebhookEventBlockCreated},
+			Secret:  "secret",
+			Enabled: true,
+		})
+		th.CheckOK(resp)
+		require.NotEmpty(t, created.ID)
+		require.Equal(t, board.ID, created.BoardID)
+		require.Equal(t, th.GetUser1().ID, created.CreatedBy)
+		require.Empty(t, created.Secret)
+		require.True(t, created.HasSecret)
+		webhookID = created.ID
+	})
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		_, resp := th.Client.CreateBoardWebhook(board.ID, &model.BoardWebhook{URL: "not a url", Events: []string{model.BoardWebhookEventBlockCreated}})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("patch and list the webhooks", func(t *testing.T) {
+		disabled := false
+		patched, resp := th.Client.PatchBoardWebhook(board.ID, webhookID, &model.BoardWebhookPatch{Enabled: &disabled})
+		th.CheckOK(resp)
+		require.False(t, patched.Enabled)
+
+		webhooks, resp := th.Client.GetBoardWebhooks(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, webhooks, 1)
+		require.Equal(t, webhookID, webhooks[0].ID)
+		require.False(t, webhooks[0].Enabled)
+		require.Empty(t, webhooks[0].Secret)
+	})
+
+	t.Run("only board admins manage the webhooks", func(t *testing.T) {
+		_, resp := th.Client2.GetBoardWebhooks(board.ID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.DeleteBoardWebhook(board.ID, webhookID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("delete the webhook", func(t *testing.T) {
+		_, resp := th.Client.DeleteBoardWebhook(board.ID, webhookID)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.DeleteBoardWebhook(board.ID, webhookID)
+		th.CheckNotFound(resp)
+
+		webhooks, resp := th.Client.GetBoardWebhooks(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, webhooks)
+	})
+}
+
+func TestValidationWebhooks(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	// the releases are only accepted for the cards with an estimate
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload model.PropertyValidationPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		props, _ := payload.Card.Fields["properties"].(map[string]interface{})
+		if payload.Changes["status"].New == "released" && props["estimate"] == nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"the card has no estimate"}`))
+		}
+	}))
+	defer ts.Close()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	_, resp := th.Client.CreateBoardWebhook(board.ID, &model.BoardWebhook{
+		URL:         ts.URL,
+		Events:      []string{model.BoardWebhookEventPropertyValidation},
+		PropertyIDs: []string{"status"},
+		TimeoutMs:   2000,
+		Enabled:     true,
+	})
+	th.CheckOK(resp)
+
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  board.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+		Title:    "card",
+		Fields:   map[string]interface{}{"properties": map[string]interface{}{"status": "todo"}},
+	}
+	inserted, resp := th.Client.InsertBlocks(board.ID, []model.Block{card})
+	th.CheckOK(resp)
+	card = inserted[0]
+
+	patchStatus := func(properties map[string]interface{}) *client.Response {
+		_, resp := th.Client.PatchBlock(board.ID, card.ID, &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"properties": properties},
+		})
+		return resp
+	}
+
+	t.Run("rejected change", func(t *testing.T) {
+		th.CheckBadRequest(patchStatus(map[string]interface{}{"status": "released"}))
+	})
+
+	t.Run("accepted change", func(t *testing.T) {
+		th.CheckOK(patchStatus(map[string]interface{}{"status": "released", "estimate": "3"}))
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/mattermost/focalboard/server/server"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/permissions/localpermissions"
+	"github.com/mattermost/focalboard/server/services/secrets"
 )
 import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -70,6 +71,9 @@ func main() {
 	pSingleUser := flag.Bool("single-user", false, "single user mode")
 	pDBType := flag.String("dbtype", "", "Database type")
 	pDBConfig := flag.String("dbconfig", "", "Database config")
+	pMigrationStatus := flag.Bool("migration-status", false, "print the database schema version and the pending migrations, then exit")
+	pMigrationDryRun := flag.Bool("migration-dry-run", false, "print the SQL of the pending migrations, then exit")
+	pEncryptSecret := flag.Bool("encrypt-secret", false, "print the secret read from the standard input encrypted with the secrets key, then exit")
 	pConfigFilePath := flag.String(
 		"config",
 		"",
@@ -141,7 +145,26 @@ func main() {
 		config.Port = *pPort
 	}
 
-	db, err := server.NewStore(config, logger)
+	if *pMigrationStatus || *pMigrationDryRun {
+		if err := printMigrationStatus(config, logger, *pMigrationDryRun); err != nil {
+			logger.Fatal("Unable to get the migration status", mlog.Err(err))
+		}
+		return
+	}
+
+	if *pEncryptSecret {
+		if err := printEncryptedSecret(config, os.Stdin); err != nil {
+			logger.Fatal("Unable to encrypt the secret", mlog.Err(err))
+		}
+		return
+	}
+
+	secretsService, err := secrets.New(config.Secrets, config.GitHub.EncryptionKey)
+	if err != nil {
+		logger.Fatal("Invalid secrets configuration", mlog.Err(err))
+	}
+
+	db, err := server.NewStore(config, secretsService, logger)
 	if err != nil {
 		logger.Fatal("server.NewStore ERROR", mlog.Err(err))
 	}
@@ -154,6 +177,7 @@ func main() {
 		DBStore:            db,
 		Logger:             logger,
 		PermissionsService: permissionsService,
+		Secrets:            secretsService,
 	}
 
 	server, err := server.New(params)
@@ -232,7 +256,12 @@ func startServer(webPath string, filesPath string, port int, singleUserToken, db
 		config.DBConfigString = dbConfigString
 	}
 
-	db, err := server.NewStore(config, logger)
+	secretsService, err := secrets.New(config.Secrets, config.GitHub.EncryptionKey)
+	if err != nil {
+		logger.Fatal("Invalid secrets configuration", mlog.Err(err))
+	}
+
+	db, err := server.NewStore(config, secretsService, logger)
 	if err != nil {
 		logger.Fatal("server.NewStore ERROR", mlog.Err(err))
 	}
@@ -245,6 +274,7 @@ func startServer(webPath string, filesPath string, port int, singleUserToken, db
 		DBStore:            db,
 		Logger:             logger,
 		PermissionsService: permissionsService,
+		Secrets:            secretsService,
 	}
 
 	pServer, err = server.New(params)
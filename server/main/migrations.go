@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/server"
+	"github.com/mattermost/focalboard/server/services/config"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// printMigrationStatus prints the schema version of the database and
+// its pending migrations without applying them. On dry runs, the SQL
+// each pending migration would execute is printed too.
+func printMigrationStatus(config *config.Configuration, logger *mlog.Logger, dryRun bool) error {
+	db, err := server.NewStoreWithoutMigrations(config, logger)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Shutdown() }()
+
+	status, err := db.GetMigrationStatus(dryRun)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Current schema version: %d\n", status.CurrentVersion)
+	fmt.Printf("Latest schema version: %d\n", status.LatestVersion)
+
+	if len(status.Pending) == 0 {
+		fmt.Println("No pending migrations")
+		return nil
+	}
+
+	fmt.Printf("Pending migrations: %d\n", len(status.Pending))
+	for _, migration := range status.Pending {
+		fmt.Printf("%06d %s\n", migration.Version, migration.Name)
+		if dryRun {
+			fmt.Println(migration.SQL)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/secrets"
+)
+
+// printEncryptedSecret prints the secret of the first line of the input
+// encrypted with the secrets key, to be used in the configuration, like
+// the SMTP password.
+func printEncryptedSecret(config *config.Configuration, input io.Reader) error {
+	service, err := secrets.New(config.Secrets, config.GitHub.EncryptionKey)
+	if err != nil {
+		return err
+	}
+	if !service.IsEnabled() {
+		return secrets.ErrNotConfigured
+	}
+
+	line, err := bufio.NewReader(input).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	secret := strings.TrimRight(line, "\r\n")
+	if secret == "" {
+		return errors.New("no secret to encrypt")
+	}
+
+	encrypted, err := service.Encrypt(secret)
+	if err != nil {
+		return err
+	}
+	fmt.Println(encrypted)
+	return nil
+}
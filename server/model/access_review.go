@@ -0,0 +1,46 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// AccessReviewHeader is the header of the access review report of a team,
+// one row per member of each board.
+var AccessReviewHeader = []string{
+	"board_id",
+	"board_title",
+	"board_type",
+	"user_id",
+	"username",
+	"role",
+	"custom_roles",
+	"inactive",
+	"last_view_at",
+	"last_edit_at",
+}
+
+// AccessReviewRecord returns the row of the access review report for the
+// member of the board. The times are in RFC 3339 format, in UTC, and
+// empty if the member never viewed or edited the board.
+func AccessReviewRecord(board *Board, member *BoardMember, username string) []string {
+	return []string{
+		board.ID,
+		board.Title,
+		string(board.Type),
+		member.UserID,
+		username,
+		member.SchemeRole(),
+		member.Roles,
+		strconv.FormatBool(member.Inactive),
+		accessReviewTime(member.LastViewAt),
+		accessReviewTime(member.LastEditAt),
+	}
+}
+
+func accessReviewTime(millis int64) string {
+	if millis == 0 {
+		return ""
+	}
+	return GetTimeForMillis(millis).UTC().Format(time.RFC3339)
+}
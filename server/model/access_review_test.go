@@ -0,0 +1,34 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessReviewRecord(t *testing.T) {
+	board := &Board{ID: "board-id", Title: "Roadmap", Type: BoardTypePrivate}
+	viewAt := time.Date(2022, 3, 4, 10, 30, 0, 0, time.UTC)
+	member := &BoardMember{
+		BoardID:      "board-id",
+		UserID:       "user-id",
+		SchemeEditor: true,
+		SchemeViewer: true,
+		LastViewAt:   GetMillisForTime(viewAt),
+	}
+
+	record := AccessReviewRecord(board, member, "alice")
+	require.Len(t, record, len(AccessReviewHeader))
+	require.Equal(t, []string{
+		"board-id", "Roadmap", "P", "user-id", "alice", "editor", "", "false", "2022-03-04T10:30:00Z", "",
+	}, record)
+}
+
+func TestBoardMemberSchemeRole(t *testing.T) {
+	require.Equal(t, "admin", (&BoardMember{SchemeAdmin: true, SchemeEditor: true}).SchemeRole())
+	require.Equal(t, "editor", (&BoardMember{SchemeEditor: true}).SchemeRole())
+	require.Equal(t, "commenter", (&BoardMember{SchemeCommenter: true, SchemeViewer: true}).SchemeRole())
+	require.Equal(t, "viewer", (&BoardMember{SchemeViewer: true}).SchemeRole())
+	require.Equal(t, "", (&BoardMember{}).SchemeRole())
+}
@@ -0,0 +1,22 @@
+package model
+
+// BoardAdminView is a read-only copy of a board opened by a system admin
+// that is not necessarily a member of it
+// swagger:model
+type BoardAdminView struct {
+	// The board
+	// required: true
+	Board *Board `json:"board"`
+
+	// The blocks of the board
+	// required: true
+	Blocks []Block `json:"blocks"`
+
+	// The members of the board
+	// required: true
+	Members []*BoardMember `json:"members"`
+
+	// The time of the access, in miliseconds
+	// required: true
+	AccessedAt int64 `json:"accessedAt"`
+}
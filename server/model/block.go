@@ -94,6 +94,11 @@ type BlockPatch struct {
 	// The board id that the block belongs to
 	// required: false
 	BoardID *string `json:"boardId"`
+
+	// The update time of the block the patch is based on. If set and the
+	// block has changed since, the patch is refused with a version conflict
+	// required: false
+	UpdateAt *int64 `json:"updateAt,omitempty"`
 }
 
 // BlockPatchBatch is a batch of IDs and patches for modify blocks
@@ -106,6 +111,14 @@ type BlockPatchBatch struct {
 	BlockPatches []BlockPatch `json:"block_patches"`
 }
 
+// MoveCardRequest is the destination of a card move
+// swagger:model
+type MoveCardRequest struct {
+	// The ID of the board the card is moved to
+	// required: true
+	BoardID string `json:"boardId"`
+}
+
 // BoardModifier is a callback that can modify each board during an import.
 // A cache of arbitrary data will be passed for each call and any changes
 // to the cache will be preserved for the next call.
@@ -177,8 +190,13 @@ type QuerySubtreeOptions struct {
 	BeforeUpdateAt int64  // if non-zero then filter for records with update_at less than BeforeUpdateAt
 	AfterUpdateAt  int64  // if non-zero then filter for records with update_at greater than AfterUpdateAt
 	Limit          uint64 // if non-zero then limit the number of returned records
+	Depth          int    // if greater than one then return descendants up to Depth levels below the block, else only its children
 }
 
+// MaxSubtreeDepth is the deepest level of descendants that can be fetched
+// with a block.
+const MaxSubtreeDepth = 5
+
 // QueryBlockHistoryOptions are query options that can be passed to GetBlockHistory.
 type QueryBlockHistoryOptions struct {
 	BeforeUpdateAt int64  // if non-zero then filter for records with update_at less than BeforeUpdateAt
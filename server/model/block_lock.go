@@ -0,0 +1,75 @@
+package model
+
+const (
+	// BlockLockDefaultTTL is the time, in seconds, a lock is held for when
+	// the request doesn't give one.
+	BlockLockDefaultTTL = 60
+
+	// BlockLockMaxTTL is the longest time, in seconds, a lock can be held
+	// for without being renewed.
+	BlockLockMaxTTL = 600
+)
+
+// BlockLock is an advisory lock on a block, taken by a user while they
+// edit it so that the other users don't overwrite their changes. The
+// lock expires unless it is renewed by acquiring it again.
+// swagger:model
+type BlockLock struct {
+	// The id of the locked block
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The id of the board of the block
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the user holding the lock
+	// required: true
+	UserID string `json:"userId"`
+
+	// The time the lock was acquired in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The time the lock expires in miliseconds since the current epoch
+	// required: true
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+// BlockLockRequest is the request to acquire or renew a lock on a block.
+// swagger:model
+type BlockLockRequest struct {
+	// The time to hold the lock for in seconds, 60 by default and at most 600
+	// required: false
+	TTL int `json:"ttl"`
+}
+
+// IsValid checks the time to hold the lock for.
+func (r *BlockLockRequest) IsValid() error {
+	if r.TTL < 0 || r.TTL > BlockLockMaxTTL {
+		return NewCodedError(ErrCodeBadRequest, "the lock ttl must be between 0 and 600 seconds", map[string]interface{}{"ttl": r.TTL})
+	}
+	return nil
+}
+
+// IsActive returns true if the lock hasn't expired at now.
+func (l *BlockLock) IsActive(now int64) bool {
+	return l.ExpiresAt > now
+}
+
+// NewErrBlockLocked returns the error for a change of a block locked by
+// another user.
+func NewErrBlockLocked(lock *BlockLock) *CodedError {
+	return NewCodedError(ErrCodeBlockLocked, "the block is being edited by another user", map[string]interface{}{
+		"blockId":   lock.BlockID,
+		"userId":    lock.UserID,
+		"expiresAt": lock.ExpiresAt,
+	})
+}
+
+// IsErrBlockLocked returns true if the error is a change of a block
+// locked by another user.
+func IsErrBlockLocked(err error) bool {
+	ce, ok := AsCodedError(err)
+	return ok && ce.Code == ErrCodeBlockLocked
+}
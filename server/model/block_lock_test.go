@@ -0,0 +1,33 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockLockRequestIsValid(t *testing.T) {
+	require.NoError(t, (&BlockLockRequest{}).IsValid())
+	require.NoError(t, (&BlockLockRequest{TTL: 30}).IsValid())
+	require.NoError(t, (&BlockLockRequest{TTL: BlockLockMaxTTL}).IsValid())
+
+	for _, ttl := range []int{-1, BlockLockMaxTTL + 1} {
+		ce, ok := AsCodedError((&BlockLockRequest{TTL: ttl}).IsValid())
+		require.True(t, ok, ttl)
+		require.Equal(t, ErrCodeBadRequest, ce.Code)
+	}
+}
+
+func TestBlockLockIsActive(t *testing.T) {
+	lock := &BlockLock{ExpiresAt: 2000}
+	require.True(t, lock.IsActive(1000))
+	require.False(t, lock.IsActive(2000))
+	require.False(t, lock.IsActive(3000))
+}
+
+func TestErrBlockLocked(t *testing.T) {
+	err := NewErrBlockLocked(&BlockLock{BlockID: "block-id", UserID: "user-id", ExpiresAt: 2000})
+	require.True(t, IsErrBlockLocked(err))
+	require.Equal(t, "user-id", err.Params["userId"])
+	require.False(t, IsErrBlockLocked(NewErrBoardReadOnly("board-id")))
+}
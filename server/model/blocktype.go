@@ -14,13 +14,17 @@ import (
 type BlockType string
 
 const (
-	TypeUnknown = "unknown"
-	TypeBoard   = "board"
-	TypeCard    = "card"
-	TypeView    = "view"
-	TypeText    = "text"
-	TypeComment = "comment"
-	TypeImage   = "image"
+	TypeUnknown  = "unknown"
+	TypeBoard    = "board"
+	TypeCard     = "card"
+	TypeView     = "view"
+	TypeText     = "text"
+	TypeComment  = "comment"
+	TypeImage    = "image"
+	TypeCheckbox = "checkbox"
+	TypeHeading  = "heading"
+	TypeLink     = "link"
+	TypeDivider  = "divider"
 )
 
 func (bt BlockType) String() string {
@@ -42,6 +46,14 @@ func BlockTypeFromString(s string) (BlockType, error) {
 		return TypeComment, nil
 	case "image":
 		return TypeImage, nil
+	case "checkbox":
+		return TypeCheckbox, nil
+	case "heading":
+		return TypeHeading, nil
+	case "link":
+		return TypeLink, nil
+	case "divider":
+		return TypeDivider, nil
 	}
 	return TypeUnknown, ErrInvalidBlockType{s}
 }
@@ -55,7 +67,7 @@ func BlockType2IDType(blockType BlockType) utils.IDType {
 		return utils.IDTypeCard
 	case TypeView:
 		return utils.IDTypeView
-	case TypeText, TypeComment:
+	case TypeText, TypeComment, TypeHeading, TypeLink, TypeDivider:
 		return utils.IDTypeBlock
 	}
 	return utils.IDTypeNone
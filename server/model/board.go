@@ -13,6 +13,36 @@ const (
 	BoardTypePrivate BoardType = "P"
 )
 
+// OrganizationTeamID is the team ID of the boards that belong to the
+// whole installation instead of to a team. These boards are listed in
+// every team, for the users allowed to see the organization scope.
+const OrganizationTeamID = "organization"
+
+const (
+	// BoardPropertyDefaultMemberRole is the board property holding the
+	// role given to the members added without an explicit role.
+	BoardPropertyDefaultMemberRole = "defaultMemberRole"
+
+	// BoardPropertySyncChannelMembers is the board property that, when
+	// true, keeps the members of the board in sync with the members of
+	// its linked channel.
+	BoardPropertySyncChannelMembers = "syncChannelMembers"
+
+	// BoardPropertySyncChannelComments is the board property that, when
+	// true, mirrors the card comments into threads of the linked channel,
+	// and the replies on those threads back into card comments.
+	BoardPropertySyncChannelComments = "syncChannelComments"
+
+	// BoardPropertyReadOnly is the board property that, when true, freezes
+	// the board: only its admins can change its blocks and settings.
+	BoardPropertyReadOnly = "readOnly"
+
+	BoardRoleViewer    = "viewer"
+	BoardRoleCommenter = "commenter"
+	BoardRoleEditor    = "editor"
+	BoardRoleAdmin     = "admin"
+)
+
 // Board groups a set of blocks and its layout
 // swagger:model
 type Board struct {
@@ -123,6 +153,24 @@ type BoardPatch struct {
 	// The board removed card properties
 	// required: false
 	DeletedCardProperties []string `json:"deletedCardProperties"`
+
+	// The ID of the channel linked to the board, only set through the
+	// channel link endpoints
+	ChannelID *string `json:"-"`
+
+	// The update time of the board the patch is based on. If set and the
+	// board has changed since, the patch is refused with a version conflict
+	// required: false
+	UpdateAt *int64 `json:"updateAt,omitempty"`
+}
+
+// ChannelLinkRequest is the body of a request to link a board to a
+// channel
+// swagger:model
+type ChannelLinkRequest struct {
+	// The ID of the channel to link the board to
+	// required: true
+	ChannelID string `json:"channelId"`
 }
 
 // BoardMember stores the information of the membership of a user on a board
@@ -155,6 +203,26 @@ type BoardMember struct {
 	// Marks the user as an viewer of the board
 	// required: true
 	SchemeViewer bool `json:"schemeViewer"`
+
+	// Marks the membership as inactive because the user is deactivated
+	// required: false
+	Inactive bool `json:"inactive,omitempty"`
+
+	// The time the membership was deleted in miliseconds since the current epoch, 0 if it wasn't
+	// required: false
+	DeleteAt int64 `json:"deleteAt,omitempty"`
+
+	// The last time the user opened the board in miliseconds since the current epoch, only set by the members API
+	// required: false
+	LastViewAt int64 `json:"lastViewAt,omitempty"`
+
+	// The last time the user changed the blocks of the board in miliseconds since the current epoch, only set by the members API
+	// required: false
+	LastEditAt int64 `json:"lastEditAt,omitempty"`
+
+	// The custom fields of the member on the board, e.g. a capacity or a role title
+	// required: false
+	CustomFields map[string]interface{} `json:"customFields,omitempty"`
 }
 
 // BoardMetadata contains metadata for a Board
@@ -193,6 +261,14 @@ func BoardsFromJSON(data io.Reader) []*Board {
 	return boards
 }
 
+func ChannelLinkRequestFromJSON(data io.Reader) (*ChannelLinkRequest, error) {
+	var request ChannelLinkRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
 func BoardMemberFromJSON(data io.Reader) *BoardMember {
 	var boardMember *BoardMember
 	_ = json.NewDecoder(data).Decode(&boardMember)
@@ -233,6 +309,13 @@ func (p *BoardPatch) Patch(board *Board) *Board {
 		board.ShowDescription = *p.ShowDescription
 	}
 
+	if p.ChannelID != nil {
+		board.ChannelID = *p.ChannelID
+	}
+
+	if len(p.UpdatedProperties) != 0 && board.Properties == nil {
+		board.Properties = map[string]interface{}{}
+	}
 	for key, property := range p.UpdatedProperties {
 		board.Properties[key] = property
 	}
@@ -296,14 +379,152 @@ func IsBoardTypeValid(t BoardType) bool {
 	return t == BoardTypeOpen || t == BoardTypePrivate
 }
 
+// IsDefaultMemberRoleValid returns true if the role can be used as the
+// default member role of a board.
+func IsDefaultMemberRoleValid(role string) bool {
+	return role == BoardRoleViewer || role == BoardRoleCommenter || role == BoardRoleEditor
+}
+
+// DefaultMemberRole returns the role given to the members added to the
+// board without an explicit role, which is editor unless the board
+// sets a different one.
+func (b *Board) DefaultMemberRole() string {
+	if role, ok := b.Properties[BoardPropertyDefaultMemberRole].(string); ok && IsDefaultMemberRoleValid(role) {
+		return role
+	}
+	return BoardRoleEditor
+}
+
+// SyncsChannelMembers returns true if the board is linked to a channel
+// and its members are kept in sync with the channel members.
+func (b *Board) SyncsChannelMembers() bool {
+	sync, _ := b.Properties[BoardPropertySyncChannelMembers].(bool)
+	return sync && b.ChannelID != ""
+}
+
+// SyncsChannelComments returns true if the board is linked to a channel
+// and its card comments are kept in sync with threads of the channel.
+func (b *Board) SyncsChannelComments() bool {
+	sync, _ := b.Properties[BoardPropertySyncChannelComments].(bool)
+	return sync && b.ChannelID != ""
+}
+
+// IsReadOnly returns true if the board is frozen and only its admins
+// can change it.
+func (b *Board) IsReadOnly() bool {
+	readOnly, _ := b.Properties[BoardPropertyReadOnly].(bool)
+	return readOnly
+}
+
+// NewDefaultMember returns a membership of the user on the board with
+// the default member role of the board.
+func (b *Board) NewDefaultMember(userID string) *BoardMember {
+	member := &BoardMember{
+		BoardID: b.ID,
+		UserID:  userID,
+	}
+
+	switch b.DefaultMemberRole() {
+	case BoardRoleViewer:
+		member.SchemeViewer = true
+	case BoardRoleCommenter:
+		member.SchemeCommenter = true
+	default:
+		member.SchemeEditor = true
+	}
+	return member
+}
+
+// SchemeRole returns the highest scheme role of the member: admin,
+// editor, commenter or viewer, or an empty string if it has none.
+func (m *BoardMember) SchemeRole() string {
+	switch {
+	case m.SchemeAdmin:
+		return BoardRoleAdmin
+	case m.SchemeEditor:
+		return BoardRoleEditor
+	case m.SchemeCommenter:
+		return BoardRoleCommenter
+	case m.SchemeViewer:
+		return BoardRoleViewer
+	}
+	return ""
+}
+
 func (p *BoardPatch) IsValid() error {
 	if p.Type != nil && !IsBoardTypeValid(*p.Type) {
 		return InvalidBoardErr{"invalid-board-type"}
 	}
 
+	if value, ok := p.UpdatedProperties[BoardPropertyDefaultMemberRole]; ok {
+		if role, _ := value.(string); !IsDefaultMemberRoleValid(role) {
+			return InvalidBoardErr{"invalid-default-member-role"}
+		}
+	}
+
+	if value, ok := p.UpdatedProperties[BoardPropertySyncChannelMembers]; ok {
+		if _, isBool := value.(bool); !isBool {
+			return InvalidBoardErr{"invalid-sync-channel-members"}
+		}
+	}
+
+	if value, ok := p.UpdatedProperties[BoardPropertySyncChannelComments]; ok {
+		if _, isBool := value.(bool); !isBool {
+			return InvalidBoardErr{"invalid-sync-channel-comments"}
+		}
+	}
+
+	if value, ok := p.UpdatedProperties[BoardPropertyReadOnly]; ok {
+		if _, isBool := value.(bool); !isBool {
+			return InvalidBoardErr{"invalid-read-only"}
+		}
+	}
+
+	// the content order is changed with the content of the board
+	if _, ok := p.UpdatedProperties[BoardPropertyContentOrder]; ok {
+		return InvalidBoardErr{"content-order-not-patchable"}
+	}
+
 	return nil
 }
 
+// ChangesMembershipProperties returns true if the patch updates or
+// removes the properties that control how members join the board.
+func (p *BoardPatch) ChangesMembershipProperties() bool {
+	for _, key := range []string{BoardPropertyDefaultMemberRole, BoardPropertySyncChannelMembers} {
+		if _, ok := p.UpdatedProperties[key]; ok {
+			return true
+		}
+		for _, deleted := range p.DeletedProperties {
+			if deleted == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// EnablesChannelMemberSync returns true if the patch turns on the sync
+// of the board members with the linked channel members.
+func (p *BoardPatch) EnablesChannelMemberSync() bool {
+	sync, _ := p.UpdatedProperties[BoardPropertySyncChannelMembers].(bool)
+	return sync
+}
+
+// ChangesReadOnly returns true if the patch updates or removes the
+// property that freezes the board.
+func (p *BoardPatch) ChangesReadOnly() bool {
+	if _, ok := p.UpdatedProperties[BoardPropertyReadOnly]; ok {
+		return true
+	}
+	for _, deleted := range p.DeletedProperties {
+		if deleted == BoardPropertyReadOnly {
+			return true
+		}
+	}
+	return false
+}
+
 type InvalidBoardErr struct {
 	msg string
 }
@@ -312,6 +533,12 @@ func (ibe InvalidBoardErr) Error() string {
 	return ibe.msg
 }
 
+// IsOrganizationBoard returns true if the board belongs to the whole
+// installation instead of to a team.
+func (b *Board) IsOrganizationBoard() bool {
+	return b.TeamID == OrganizationTeamID
+}
+
 func (b *Board) IsValid() error {
 	if b.TeamID == "" {
 		return InvalidBoardErr{"empty-team-id"}
@@ -320,9 +547,41 @@ func (b *Board) IsValid() error {
 	if !IsBoardTypeValid(b.Type) {
 		return InvalidBoardErr{"invalid-board-type"}
 	}
+
+	if value, ok := b.Properties[BoardPropertyDefaultMemberRole]; ok {
+		if role, _ := value.(string); !IsDefaultMemberRoleValid(role) {
+			return InvalidBoardErr{"invalid-default-member-role"}
+		}
+	}
+
+	if value, ok := b.Properties[BoardPropertySyncChannelMembers]; ok {
+		if _, isBool := value.(bool); !isBool {
+			return InvalidBoardErr{"invalid-sync-channel-members"}
+		}
+	}
+
+	if value, ok := b.Properties[BoardPropertySyncChannelComments]; ok {
+		if _, isBool := value.(bool); !isBool {
+			return InvalidBoardErr{"invalid-sync-channel-comments"}
+		}
+	}
 	return nil
 }
 
+const (
+	// BoardMemberActionJoined is the board members history action of a
+	// user that joined an open board by themselves.
+	BoardMemberActionJoined = "joined"
+
+	// BoardMemberActionUpdated is the board members history action of a
+	// change of the roles of a member.
+	BoardMemberActionUpdated = "updated"
+
+	// BoardMemberActionRestored is the board members history action of a
+	// deleted membership that was restored.
+	BoardMemberActionRestored = "restored"
+)
+
 // BoardMemberHistoryEntry stores the information of the membership of a user on a board
 // swagger:model
 type BoardMemberHistoryEntry struct {
@@ -334,10 +593,14 @@ type BoardMemberHistoryEntry struct {
 	// required: true
 	UserID string `json:"userId"`
 
-	// The action that added this history entry (created or deleted)
+	// The action that added this history entry (created, deleted, restored, joined, updated, invited, accepted, declined, canceled, requested, approved or denied)
 	// required: false
 	Action string `json:"action"`
 
+	// The reason given for the action, if any
+	// required: false
+	Reason string `json:"reason,omitempty"`
+
 	// The insertion time
 	// required: true
 	InsertAt time.Time `json:"insertAt"`
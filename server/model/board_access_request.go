@@ -0,0 +1,99 @@
+package model
+
+import (
+	"unicode/utf8"
+)
+
+// Actions recorded in the board members history along the lifecycle of
+// an access request.
+const (
+	BoardMemberActionRequested = "requested"
+	BoardMemberActionApproved  = "approved"
+	BoardMemberActionDenied    = "denied"
+)
+
+// MaxBoardAccessRequestMessageLength is the maximum number of characters
+// of the message of an access request.
+const MaxBoardAccessRequestMessageLength = 500
+
+// BoardAccessRequest is a pending request of a user to become a member of
+// a board they can't access. The membership is only created once an admin
+// of the board approves it
+// swagger:model
+type BoardAccessRequest struct {
+	// The ID of the request
+	// required: true
+	ID string `json:"id"`
+
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the user that requested the access
+	// required: true
+	UserID string `json:"userId"`
+
+	// The message of the user to the admins of the board
+	// required: false
+	Message string `json:"message"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// IsValid checks that the request has a board and a user, and that its
+// message is not too long.
+func (r *BoardAccessRequest) IsValid() error {
+	if r.BoardID == "" {
+		return NewCodedError(ErrCodeBadRequest, "missing board id", nil)
+	}
+	if r.UserID == "" {
+		return NewCodedError(ErrCodeBadRequest, "missing user id", nil)
+	}
+	if utf8.RuneCountInString(r.Message) > MaxBoardAccessRequestMessageLength {
+		return NewCodedError(ErrCodeBadRequest, "the message of the request is too long",
+			map[string]interface{}{"maxLength": MaxBoardAccessRequestMessageLength})
+	}
+	return nil
+}
+
+// BoardAccessRequestBody is the body of a request for access to a board
+// swagger:model
+type BoardAccessRequestBody struct {
+	// The message to the admins of the board
+	// required: false
+	Message string `json:"message"`
+}
+
+// BoardAccessApproval is the body of the approval of an access request.
+// Without a role, the user gets the default role of the board members
+// swagger:model
+type BoardAccessApproval struct {
+	// The user will be an editor of the board
+	// required: false
+	SchemeEditor bool `json:"schemeEditor"`
+
+	// The user will be a commenter of the board
+	// required: false
+	SchemeCommenter bool `json:"schemeCommenter"`
+
+	// The user will be a viewer of the board
+	// required: false
+	SchemeViewer bool `json:"schemeViewer"`
+}
+
+// Member returns the membership of the user that the approval grants on
+// the board.
+func (a *BoardAccessApproval) Member(board *Board, userID string) *BoardMember {
+	if a == nil || (!a.SchemeEditor && !a.SchemeCommenter && !a.SchemeViewer) {
+		return board.NewDefaultMember(userID)
+	}
+	return &BoardMember{
+		BoardID:         board.ID,
+		UserID:          userID,
+		SchemeEditor:    a.SchemeEditor,
+		SchemeCommenter: a.SchemeCommenter,
+		SchemeViewer:    a.SchemeViewer,
+	}
+}
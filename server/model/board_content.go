@@ -0,0 +1,179 @@
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// BoardPropertyContentOrder is the board property that holds the
+	// IDs of the blocks of the content of the board, in order.
+	BoardPropertyContentOrder = "contentOrder"
+
+	// MaxBoardContentBlocks is the maximum number of blocks of the content
+	// of a board.
+	MaxBoardContentBlocks = 500
+
+	maxHeadingLevel = 3
+)
+
+// BoardContent is the rich description of a board, the blocks of its
+// home page, like headings, links and checklists
+// swagger:model
+type BoardContent struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The blocks of the content, in order
+	// required: true
+	Blocks []Block `json:"blocks"`
+
+	// The description of the board, rendered from the blocks as markdown
+	// required: true
+	Description string `json:"description"`
+}
+
+// BoardContentRevision is a past version of the content of a board
+// swagger:model
+type BoardContentRevision struct {
+	// The description of the board, rendered from the blocks of the revision
+	// required: true
+	Description string `json:"description"`
+
+	// The id of the user that made the revision
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The time of the revision in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// IsBoardContentType returns true if blocks of the type can be part of the
+// content of a board.
+func IsBoardContentType(blockType BlockType) bool {
+	switch blockType {
+	case TypeText, TypeHeading, TypeLink, TypeCheckbox, TypeImage, TypeDivider:
+		return true
+	}
+	return false
+}
+
+// IsValidBoardContentBlock checks that the block can be part of the
+// content of a board: headings have a level between 1 and 3, and links
+// an http or https URL.
+func IsValidBoardContentBlock(block *Block) error {
+	if !IsBoardContentType(block.Type) {
+		return NewCodedError(ErrCodeBadRequest, fmt.Sprintf("blocks of type %s can't be part of the content of a board", block.Type), map[string]interface{}{"blockId": block.ID})
+	}
+
+	switch block.Type {
+	case TypeHeading:
+		if level := headingLevel(block); level < 1 || level > maxHeadingLevel {
+			return NewCodedError(ErrCodeBadRequest, "the level of a heading must be between 1 and 3", map[string]interface{}{"blockId": block.ID})
+		}
+	case TypeLink:
+		rawURL, _ := block.Fields["url"].(string)
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return NewCodedError(ErrCodeBadRequest, "a link must have an http or https URL", map[string]interface{}{"blockId": block.ID})
+		}
+	}
+	return nil
+}
+
+// ContentOrder returns the IDs of the blocks of the content of the
+// board, in order.
+func (b *Board) ContentOrder() []string {
+	stored, _ := b.Properties[BoardPropertyContentOrder].([]interface{})
+	order := make([]string, 0, len(stored))
+	for _, id := range stored {
+		if s, ok := id.(string); ok && s != "" {
+			order = append(order, s)
+		}
+	}
+	return order
+}
+
+// HasBoardContent returns true if the description of the board is rendered
+// from content blocks.
+func (b *Board) HasBoardContent() bool {
+	_, ok := b.Properties[BoardPropertyContentOrder]
+	return ok
+}
+
+// remapContentOrder replaces the IDs of the content order of the board
+// with the new IDs of its blocks.
+func (b *Board) remapContentOrder(newIDs map[string]string) {
+	if !b.HasBoardContent() {
+		return
+	}
+	order := b.ContentOrder()
+	remapped := make([]interface{}, 0, len(order))
+	for _, id := range order {
+		if newID, ok := newIDs[id]; ok {
+			id = newID
+		}
+		remapped = append(remapped, id)
+	}
+	b.Properties[BoardPropertyContentOrder] = remapped
+}
+
+// RenderBoardContent renders the blocks of the content of a board as
+// markdown, the description of the board. Images are left out, as the
+// description can't show them.
+func RenderBoardContent(blocks []Block) string {
+	var sb strings.Builder
+	var previous BlockType
+	for i := range blocks {
+		line := renderBoardContentBlock(&blocks[i])
+		if line == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			// the items of a checklist are kept together
+			if previous == TypeCheckbox && blocks[i].Type == TypeCheckbox {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(line)
+		previous = blocks[i].Type
+	}
+	return sb.String()
+}
+
+func renderBoardContentBlock(block *Block) string {
+	switch block.Type {
+	case TypeText:
+		return block.Title
+	case TypeHeading:
+		return strings.Repeat("#", headingLevel(block)) + " " + block.Title
+	case TypeLink:
+		rawURL, _ := block.Fields["url"].(string)
+		if block.Title == "" {
+			return "<" + rawURL + ">"
+		}
+		return "[" + block.Title + "](" + rawURL + ")"
+	case TypeCheckbox:
+		if checked, _ := block.Fields["value"].(bool); checked {
+			return "- [x] " + block.Title
+		}
+		return "- [ ] " + block.Title
+	case TypeDivider:
+		return "---"
+	}
+	return ""
+}
+
+// headingLevel returns the level of a heading block, 1 if it has none.
+func headingLevel(block *Block) int {
+	level, ok := block.Fields["level"].(float64)
+	if !ok {
+		return 1
+	}
+	return int(level)
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidBoardContentBlock(t *testing.T) {
+	require.NoError(t, IsValidBoardContentBlock(&Block{Type: TypeText, Title: "text"}))
+	require.NoError(t, IsValidBoardContentBlock(&Block{Type: TypeHeading, Fields: map[string]interface{}{"level": float64(2)}}))
+	require.NoError(t, IsValidBoardContentBlock(&Block{Type: TypeLink, Fields: map[string]interface{}{"url": "https://example.com/docs"}}))
+
+	require.Error(t, IsValidBoardContentBlock(&Block{Type: TypeCard}))
+	require.Error(t, IsValidBoardContentBlock(&Block{Type: TypeComment}))
+	require.Error(t, IsValidBoardContentBlock(&Block{Type: TypeHeading, Fields: map[string]interface{}{"level": float64(4)}}))
+	require.Error(t, IsValidBoardContentBlock(&Block{Type: TypeLink, Fields: map[string]interface{}{"url": "javascript:alert(1)"}}))
+	require.Error(t, IsValidBoardContentBlock(&Block{Type: TypeLink}))
+}
+
+func TestRenderBoardContent(t *testing.T) {
+	blocks := []Block{
+		{Type: TypeHeading, Title: "Sprint goals", Fields: map[string]interface{}{"level": float64(2)}},
+		{Type: TypeText, Title: "What we ship this sprint."},
+		{Type: TypeCheckbox, Title: "release notes", Fields: map[string]interface{}{"value": true}},
+		{Type: TypeCheckbox, Title: "demo"},
+		{Type: TypeImage, Fields: map[string]interface{}{"fileId": "file-id"}},
+		{Type: TypeDivider},
+		{Type: TypeLink, Title: "Docs", Fields: map[string]interface{}{"url": "https://example.com/docs"}},
+		{Type: TypeLink, Fields: map[string]interface{}{"url": "https://example.com"}},
+	}
+
+	expected := "## Sprint goals\n\n" +
+		"What we ship this sprint.\n\n" +
+		"- [x] release notes\n" +
+		"- [ ] demo\n\n" +
+		"---\n\n" +
+		"[Docs](https://example.com/docs)\n\n" +
+		"<https://example.com>"
+	require.Equal(t, expected, RenderBoardContent(blocks))
+	require.Empty(t, RenderBoardContent(nil))
+}
+
+func TestBoardContentOrder(t *testing.T) {
+	board := &Board{ID: "board-id"}
+	require.False(t, board.HasBoardContent())
+	require.Empty(t, board.ContentOrder())
+
+	board.Properties = map[string]interface{}{BoardPropertyContentOrder: []interface{}{"block-1", 2, "block-2"}}
+	require.True(t, board.HasBoardContent())
+	require.Equal(t, []string{"block-1", "block-2"}, board.ContentOrder())
+
+	patch := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertyContentOrder: []interface{}{"block-1"}}}
+	require.Error(t, patch.IsValid())
+}
+
+func TestGenerateBoardsAndBlocksIDsContentOrder(t *testing.T) {
+	bab := &BoardsAndBlocks{
+		Boards: []*Board{{
+			ID:         "board-id",
+			Type:       BoardTypeOpen,
+			Properties: map[string]interface{}{BoardPropertyContentOrder: []interface{}{"heading-id", "text-id"}},
+		}},
+		Blocks: []Block{
+			{ID: "text-id", BoardID: "board-id", ParentID: "board-id", Type: TypeText},
+			{ID: "heading-id", BoardID: "board-id", ParentID: "board-id", Type: TypeHeading},
+		},
+	}
+
+	newBab, err := GenerateBoardsAndBlocksIDs(bab, nil)
+	require.NoError(t, err)
+	require.Len(t, newBab.Blocks, 2)
+	require.Equal(t, []string{newBab.Blocks[1].ID, newBab.Blocks[0].ID}, newBab.Boards[0].ContentOrder())
+	require.NotEqual(t, "heading-id", newBab.Blocks[1].ID)
+	require.Equal(t, newBab.Boards[0].ID, newBab.Blocks[0].ParentID)
+}
@@ -0,0 +1,76 @@
+package model
+
+// BoardFreezeSchedule is the times at which a board is frozen, made
+// read-only, and unfrozen, e.g. at the end of a sprint
+// swagger:model
+type BoardFreezeSchedule struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The time the board is frozen in miliseconds since the current epoch, 0 if no freeze is scheduled
+	// required: false
+	FreezeAt int64 `json:"freezeAt"`
+
+	// The time the board is unfrozen in miliseconds since the current epoch, 0 if no unfreeze is scheduled
+	// required: false
+	UnfreezeAt int64 `json:"unfreezeAt"`
+
+	// The id of the user that last changed the schedule
+	// required: false
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The last time the schedule was changed in miliseconds since the current epoch
+	// required: false
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// IsEmpty returns true if neither a freeze nor an unfreeze is scheduled.
+func (s *BoardFreezeSchedule) IsEmpty() bool {
+	return s.FreezeAt == 0 && s.UnfreezeAt == 0
+}
+
+// IsValid checks that the scheduled times are in the future and that a
+// board frozen by the schedule is unfrozen after it is frozen.
+func (s *BoardFreezeSchedule) IsValid(now int64) error {
+	if s.FreezeAt < 0 || s.UnfreezeAt < 0 {
+		return NewCodedError(ErrCodeBadRequest, "the scheduled times must not be negative", nil)
+	}
+	if s.FreezeAt != 0 && s.FreezeAt <= now {
+		return NewCodedError(ErrCodeBadRequest, "the freeze must be scheduled in the future", map[string]interface{}{"freezeAt": s.FreezeAt})
+	}
+	if s.UnfreezeAt != 0 && s.UnfreezeAt <= now {
+		return NewCodedError(ErrCodeBadRequest, "the unfreeze must be scheduled in the future", map[string]interface{}{"unfreezeAt": s.UnfreezeAt})
+	}
+	if s.FreezeAt != 0 && s.UnfreezeAt != 0 && s.UnfreezeAt <= s.FreezeAt {
+		return NewCodedError(ErrCodeBadRequest, "the unfreeze must be scheduled after the freeze", map[string]interface{}{"freezeAt": s.FreezeAt, "unfreezeAt": s.UnfreezeAt})
+	}
+	return nil
+}
+
+// Apply clears the times of the schedule that are due at now, and
+// returns whether the board must be read-only once they are applied.
+// The last of the due times wins. If none is due, ok is false.
+func (s *BoardFreezeSchedule) Apply(now int64) (readOnly bool, ok bool) {
+	freezeDue := s.FreezeAt != 0 && s.FreezeAt <= now
+	unfreezeDue := s.UnfreezeAt != 0 && s.UnfreezeAt <= now
+
+	switch {
+	case freezeDue && unfreezeDue:
+		readOnly = s.FreezeAt > s.UnfreezeAt
+	case freezeDue:
+		readOnly = true
+	case unfreezeDue:
+		readOnly = false
+	default:
+		return false, false
+	}
+
+	if freezeDue {
+		s.FreezeAt = 0
+	}
+	if unfreezeDue {
+		s.UnfreezeAt = 0
+	}
+	return readOnly, true
+}
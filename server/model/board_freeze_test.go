@@ -0,0 +1,66 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardFreezeScheduleIsValid(t *testing.T) {
+	now := int64(1000)
+
+	require.NoError(t, (&BoardFreezeSchedule{FreezeAt: 2000}).IsValid(now))
+	require.NoError(t, (&BoardFreezeSchedule{UnfreezeAt: 2000}).IsValid(now))
+	require.NoError(t, (&BoardFreezeSchedule{FreezeAt: 2000, UnfreezeAt: 3000}).IsValid(now))
+	require.NoError(t, (&BoardFreezeSchedule{}).IsValid(now))
+
+	testCases := []*BoardFreezeSchedule{
+		{FreezeAt: -1},
+		{FreezeAt: 500},
+		{UnfreezeAt: 1000},
+		{FreezeAt: 3000, UnfreezeAt: 2000},
+	}
+	for _, schedule := range testCases {
+		ce, ok := AsCodedError(schedule.IsValid(now))
+		require.True(t, ok, schedule)
+		require.Equal(t, ErrCodeBadRequest, ce.Code)
+	}
+}
+
+func TestBoardFreezeScheduleApply(t *testing.T) {
+	t.Run("nothing due", func(t *testing.T) {
+		schedule := &BoardFreezeSchedule{FreezeAt: 2000, UnfreezeAt: 3000}
+		_, ok := schedule.Apply(1000)
+		require.False(t, ok)
+		require.Equal(t, int64(2000), schedule.FreezeAt)
+	})
+
+	t.Run("freeze due", func(t *testing.T) {
+		schedule := &BoardFreezeSchedule{FreezeAt: 2000, UnfreezeAt: 3000}
+		readOnly, ok := schedule.Apply(2500)
+		require.True(t, ok)
+		require.True(t, readOnly)
+		require.Equal(t, &BoardFreezeSchedule{UnfreezeAt: 3000}, schedule)
+		require.False(t, schedule.IsEmpty())
+	})
+
+	t.Run("unfreeze due", func(t *testing.T) {
+		schedule := &BoardFreezeSchedule{UnfreezeAt: 3000}
+		readOnly, ok := schedule.Apply(3000)
+		require.True(t, ok)
+		require.False(t, readOnly)
+		require.True(t, schedule.IsEmpty())
+	})
+
+	t.Run("the last due time wins", func(t *testing.T) {
+		schedule := &BoardFreezeSchedule{FreezeAt: 2000, UnfreezeAt: 3000}
+		readOnly, ok := schedule.Apply(4000)
+		require.True(t, ok)
+		require.False(t, readOnly)
+		require.True(t, schedule.IsEmpty())
+
+		schedule = &BoardFreezeSchedule{FreezeAt: 3000, UnfreezeAt: 2000}
+		readOnly, _ = schedule.Apply(4000)
+		require.True(t, readOnly)
+	})
+}
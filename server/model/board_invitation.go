@@ -0,0 +1,185 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/mail"
+)
+
+// Actions recorded in the board members history along the lifecycle of
+// an invitation.
+const (
+	BoardMemberActionInvited  = "invited"
+	BoardMemberActionAccepted = "accepted"
+	BoardMemberActionDeclined = "declined"
+	BoardMemberActionCanceled = "canceled"
+)
+
+// BoardInvitation is a pending invitation for a user to become a member
+// of a board. The membership is only created once the user accepts it
+// swagger:model
+type BoardInvitation struct {
+	// The ID of the invitation
+	// required: true
+	ID string `json:"id"`
+
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the invited user
+	// required: true
+	UserID string `json:"userId"`
+
+	// The ID of the user that sent the invitation
+	// required: true
+	InvitedBy string `json:"invitedBy"`
+
+	// The invited user will be an admin of the board
+	// required: true
+	SchemeAdmin bool `json:"schemeAdmin"`
+
+	// The invited user will be an editor of the board
+	// required: true
+	SchemeEditor bool `json:"schemeEditor"`
+
+	// The invited user will be a commenter of the board
+	// required: true
+	SchemeCommenter bool `json:"schemeCommenter"`
+
+	// The invited user will be a viewer of the board
+	// required: true
+	SchemeViewer bool `json:"schemeViewer"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+func (bi *BoardInvitation) IsValid() error {
+	if bi == nil {
+		return ErrInvalidBoardInvitation{"cannot be nil"}
+	}
+	if bi.BoardID == "" {
+		return ErrInvalidBoardInvitation{"missing board id"}
+	}
+	if bi.UserID == "" {
+		return ErrInvalidBoardInvitation{"missing user id"}
+	}
+	if bi.InvitedBy == "" {
+		return ErrInvalidBoardInvitation{"missing inviter id"}
+	}
+	return nil
+}
+
+// Member returns the membership the invitation grants once accepted.
+func (bi *BoardInvitation) Member() *BoardMember {
+	return &BoardMember{
+		BoardID:         bi.BoardID,
+		UserID:          bi.UserID,
+		SchemeAdmin:     bi.SchemeAdmin,
+		SchemeEditor:    bi.SchemeEditor,
+		SchemeCommenter: bi.SchemeCommenter,
+		SchemeViewer:    bi.SchemeViewer,
+	}
+}
+
+type ErrInvalidBoardInvitation struct {
+	msg string
+}
+
+func (e ErrInvalidBoardInvitation) Error() string {
+	return e.msg
+}
+
+// BoardInvitationRequest is the body of a request to invite a user to a
+// board, identified either by username or by email
+// swagger:model
+type BoardInvitationRequest struct {
+	// The username of the user to invite
+	// required: false
+	Username string `json:"username"`
+
+	// The email of the user to invite, used if no username is set
+	// required: false
+	Email string `json:"email"`
+
+	// The invited user will be an admin of the board
+	// required: false
+	SchemeAdmin bool `json:"schemeAdmin"`
+
+	// The invited user will be an editor of the board
+	// required: false
+	SchemeEditor bool `json:"schemeEditor"`
+
+	// The invited user will be a commenter of the board
+	// required: false
+	SchemeCommenter bool `json:"schemeCommenter"`
+
+	// The invited user will be a viewer of the board
+	// required: false
+	SchemeViewer bool `json:"schemeViewer"`
+}
+
+func BoardInvitationRequestFromJSON(data io.Reader) (*BoardInvitationRequest, error) {
+	var request BoardInvitationRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *BoardInvitationRequest) IsValid() error {
+	if r.Username == "" && r.Email == "" {
+		return ErrInvalidBoardInvitation{"missing username or email"}
+	}
+	return nil
+}
+
+// EmailInvitationRequest is the body of a request to invite someone to
+// the server by email, optionally adding them to a board once they
+// create their account
+// swagger:model
+type EmailInvitationRequest struct {
+	// The email address to send the invitation to
+	// required: true
+	Email string `json:"email"`
+
+	// The ID of the board the invited user will be a member of
+	// required: false
+	BoardID string `json:"boardId"`
+
+	// The invited user will be an admin of the board
+	// required: false
+	SchemeAdmin bool `json:"schemeAdmin"`
+
+	// The invited user will be an editor of the board
+	// required: false
+	SchemeEditor bool `json:"schemeEditor"`
+
+	// The invited user will be a commenter of the board
+	// required: false
+	SchemeCommenter bool `json:"schemeCommenter"`
+
+	// The invited user will be a viewer of the board
+	// required: false
+	SchemeViewer bool `json:"schemeViewer"`
+}
+
+func EmailInvitationRequestFromJSON(data io.Reader) (*EmailInvitationRequest, error) {
+	var request EmailInvitationRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *EmailInvitationRequest) IsValid() error {
+	if r.Email == "" {
+		return ErrInvalidBoardInvitation{"missing email"}
+	}
+	if _, err := mail.ParseAddress(r.Email); err != nil {
+		return ErrInvalidBoardInvitation{"invalid email"}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package model
+
+// BoardMemberActivity records the last time a user viewed and edited a
+// board
+// swagger:model
+type BoardMemberActivity struct {
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the user
+	// required: true
+	UserID string `json:"userId"`
+
+	// The last time the user opened the board in miliseconds since the current epoch, 0 if never
+	// required: true
+	LastViewAt int64 `json:"lastViewAt"`
+
+	// The last time the user changed the blocks of the board in miliseconds since the current epoch, 0 if never
+	// required: true
+	LastEditAt int64 `json:"lastEditAt"`
+}
+
+// LastActivityAt returns the last time the user viewed or edited the board.
+func (a *BoardMemberActivity) LastActivityAt() int64 {
+	if a.LastEditAt > a.LastViewAt {
+		return a.LastEditAt
+	}
+	return a.LastViewAt
+}
@@ -0,0 +1,54 @@
+package model
+
+import "fmt"
+
+const (
+	// MaxBoardMembersRolesUpdate is the maximum number of members whose
+	// roles can be changed at once.
+	MaxBoardMembersRolesUpdate = 200
+
+	// MaxBoardMemberHistoryReasonLength is the maximum length of the
+	// reason recorded in the board members history.
+	MaxBoardMemberHistoryReasonLength = 255
+)
+
+// BoardMembersRolesUpdate is a change of the roles of many members of a
+// board at once, e.g. after an access review
+// swagger:model
+type BoardMembersRolesUpdate struct {
+	// The new roles of the members
+	// required: true
+	Members []*BoardMemberRoles `json:"members"`
+
+	// The reason of the change, recorded in the board members history
+	// required: false
+	Reason string `json:"reason"`
+}
+
+// IsValid checks that the update changes the roles of at least one
+// member, of each member once, and that the reason is not too long.
+func (u *BoardMembersRolesUpdate) IsValid() error {
+	if len(u.Members) == 0 {
+		return NewCodedError(ErrCodeBadRequest, "the update must change the roles of at least one member", nil)
+	}
+	if len(u.Members) > MaxBoardMembersRolesUpdate {
+		return NewCodedError(ErrCodeBadRequest,
+			fmt.Sprintf("the roles of at most %d members can be changed at once", MaxBoardMembersRolesUpdate), nil)
+	}
+	if len(u.Reason) > MaxBoardMemberHistoryReasonLength {
+		return NewCodedError(ErrCodeBadRequest,
+			fmt.Sprintf("the reason can't be longer than %d characters", MaxBoardMemberHistoryReasonLength), nil)
+	}
+
+	userIDs := map[string]bool{}
+	for _, member := range u.Members {
+		if member == nil || member.UserID == "" {
+			return NewCodedError(ErrCodeBadRequest, "the members must have a user ID", nil)
+		}
+		if userIDs[member.UserID] {
+			return NewCodedError(ErrCodeBadRequest, "duplicate member", map[string]interface{}{"userId": member.UserID})
+		}
+		userIDs[member.UserID] = true
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardMembersRolesUpdateIsValid(t *testing.T) {
+	valid := func() *BoardMembersRolesUpdate {
+		return &BoardMembersRolesUpdate{
+			Members: []*BoardMemberRoles{
+				{UserID: "user-1", SchemeAdmin: true},
+				{UserID: "user-2", SchemeViewer: true},
+			},
+			Reason: "access review",
+		}
+	}
+	require.NoError(t, valid().IsValid())
+
+	update := valid()
+	update.Members = nil
+	require.Error(t, update.IsValid())
+
+	update = valid()
+	update.Members = append(update.Members, &BoardMemberRoles{UserID: "user-1"})
+	require.Error(t, update.IsValid())
+
+	update = valid()
+	update.Members = append(update.Members, &BoardMemberRoles{})
+	require.Error(t, update.IsValid())
+
+	update = valid()
+	update.Reason = strings.Repeat("a", MaxBoardMemberHistoryReasonLength+1)
+	require.Error(t, update.IsValid())
+
+	update = valid()
+	for i := len(update.Members); i <= MaxBoardMembersRolesUpdate; i++ {
+		update.Members = append(update.Members, &BoardMemberRoles{UserID: fmt.Sprintf("other-user-%d", i)})
+	}
+	require.Error(t, update.IsValid())
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"sort"
+)
+
+// BoardPermissionsArchiveFile is the name of the file of the archives
+// holding the permissions of a board, next to its board.jsonl. Older
+// servers import it as an orphan file of the board.
+const BoardPermissionsArchiveFile = "permissions.json"
+
+// BoardPermissions is the access configuration of a board: its type, the
+// role of the members added without one, and the roles of its members,
+// exported to replicate the access setup on other boards
+// swagger:model
+type BoardPermissions struct {
+	// The type of the board, open or private
+	// required: true
+	Type BoardType `json:"type"`
+
+	// The role given to the members added without an explicit role
+	// required: true
+	DefaultMemberRole string `json:"defaultMemberRole"`
+
+	// The roles of the members of the board
+	// required: true
+	Members []*BoardMemberRoles `json:"members"`
+}
+
+// BoardMemberRoles is the roles of a member of a board, regardless of the
+// board
+// swagger:model
+type BoardMemberRoles struct {
+	// The ID of the user
+	// required: true
+	UserID string `json:"userId"`
+
+	// The independent roles of the user on the board
+	// required: false
+	Roles string `json:"roles,omitempty"`
+
+	// Marks the user as an admin of the board
+	// required: true
+	SchemeAdmin bool `json:"schemeAdmin"`
+
+	// Marks the user as an editor of the board
+	// required: true
+	SchemeEditor bool `json:"schemeEditor"`
+
+	// Marks the user as an commenter of the board
+	// required: true
+	SchemeCommenter bool `json:"schemeCommenter"`
+
+	// Marks the user as an viewer of the board
+	// required: true
+	SchemeViewer bool `json:"schemeViewer"`
+}
+
+// NewBoardPermissions returns the permissions of the board with its
+// members, sorted by user ID. The inactive memberships of deactivated
+// users are left out.
+func NewBoardPermissions(board *Board, members []*BoardMember) *BoardPermissions {
+	permissions := &BoardPermissions{
+		Type:              board.Type,
+		DefaultMemberRole: board.DefaultMemberRole(),
+		Members:           make([]*BoardMemberRoles, 0, len(members)),
+	}
+	for _, member := range members {
+		if member.Inactive {
+			continue
+		}
+		permissions.Members = append(permissions.Members, &BoardMemberRoles{
+			UserID:          member.UserID,
+			Roles:           member.Roles,
+			SchemeAdmin:     member.SchemeAdmin,
+			SchemeEditor:    member.SchemeEditor,
+			SchemeCommenter: member.SchemeCommenter,
+			SchemeViewer:    member.SchemeViewer,
+		})
+	}
+	sort.Slice(permissions.Members, func(i, j int) bool {
+		return permissions.Members[i].UserID < permissions.Members[j].UserID
+	})
+	return permissions
+}
+
+// IsValid checks that the permissions have a valid type and default role,
+// and at least one admin, so that a board they are applied to keeps one.
+func (p *BoardPermissions) IsValid() error {
+	if !IsBoardTypeValid(p.Type) {
+		return NewCodedError(ErrCodeBadRequest, "invalid board type", map[string]interface{}{"type": p.Type})
+	}
+	if !IsDefaultMemberRoleValid(p.DefaultMemberRole) {
+		return NewCodedError(ErrCodeBadRequest, "invalid default member role", map[string]interface{}{"defaultMemberRole": p.DefaultMemberRole})
+	}
+
+	hasAdmin := false
+	userIDs := map[string]bool{}
+	for _, member := range p.Members {
+		if member == nil || member.UserID == "" {
+			return NewCodedError(ErrCodeBadRequest, "the members must have a user ID", nil)
+		}
+		if userIDs[member.UserID] {
+			return NewCodedError(ErrCodeBadRequest, "duplicate member", map[string]interface{}{"userId": member.UserID})
+		}
+		userIDs[member.UserID] = true
+		hasAdmin = hasAdmin || member.SchemeAdmin
+	}
+	if !hasAdmin {
+		return NewCodedError(ErrCodeBadRequest, "the permissions must have at least one admin", nil)
+	}
+	return nil
+}
+
+// BoardMember returns the membership of the user with the roles on the
+// board.
+func (m *BoardMemberRoles) BoardMember(boardID string) *BoardMember {
+	return &BoardMember{
+		BoardID:         boardID,
+		UserID:          m.UserID,
+		Roles:           m.Roles,
+		SchemeAdmin:     m.SchemeAdmin,
+		SchemeEditor:    m.SchemeEditor,
+		SchemeCommenter: m.SchemeCommenter,
+		SchemeViewer:    m.SchemeViewer,
+	}
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBoardPermissions(t *testing.T) {
+	board := &Board{
+		ID:         "board-id",
+		Type:       BoardTypePrivate,
+		Properties: map[string]interface{}{BoardPropertyDefaultMemberRole: BoardRoleViewer},
+	}
+	members := []*BoardMember{
+		{BoardID: "board-id", UserID: "user-3", SchemeViewer: true},
+		{BoardID: "board-id", UserID: "user-1", SchemeAdmin: true},
+		{BoardID: "board-id", UserID: "user-2", SchemeEditor: true, Inactive: true},
+	}
+
+	permissions := NewBoardPermissions(board, members)
+	require.Equal(t, BoardTypePrivate, permissions.Type)
+	require.Equal(t, BoardRoleViewer, permissions.DefaultMemberRole)
+	require.Equal(t, []*BoardMemberRoles{
+		{UserID: "user-1", SchemeAdmin: true},
+		{UserID: "user-3", SchemeViewer: true},
+	}, permissions.Members)
+	require.NoError(t, permissions.IsValid())
+
+	member := permissions.Members[0].BoardMember("other-board-id")
+	require.Equal(t, &BoardMember{BoardID: "other-board-id", UserID: "user-1", SchemeAdmin: true}, member)
+}
+
+func TestBoardPermissionsIsValid(t *testing.T) {
+	admin := &BoardMemberRoles{UserID: "user-1", SchemeAdmin: true}
+	editor := &BoardMemberRoles{UserID: "user-2", SchemeEditor: true}
+
+	testCases := []struct {
+		name        string
+		permissions *BoardPermissions
+	}{
+		{"invalid type", &BoardPermissions{Type: "X", DefaultMemberRole: BoardRoleEditor, Members: []*BoardMemberRoles{admin}}},
+		{"invalid default role", &BoardPermissions{Type: BoardTypeOpen, DefaultMemberRole: "admin", Members: []*BoardMemberRoles{admin}}},
+		{"no admin", &BoardPermissions{Type: BoardTypeOpen, DefaultMemberRole: BoardRoleEditor, Members: []*BoardMemberRoles{editor}}},
+		{"no user ID", &BoardPermissions{Type: BoardTypeOpen, DefaultMemberRole: BoardRoleEditor, Members: []*BoardMemberRoles{admin, {SchemeViewer: true}}}},
+		{"duplicate member", &BoardPermissions{Type: BoardTypeOpen, DefaultMemberRole: BoardRoleEditor, Members: []*BoardMemberRoles{admin, admin}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ce, ok := AsCodedError(tc.permissions.IsValid())
+			require.True(t, ok)
+			require.Equal(t, ErrCodeBadRequest, ce.Code)
+		})
+	}
+
+	require.NoError(t, (&BoardPermissions{
+		Type:              BoardTypeOpen,
+		DefaultMemberRole: BoardRoleCommenter,
+		Members:           []*BoardMemberRoles{admin, editor},
+	}).IsValid())
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	// BoardSnapshotDayLayout is the layout of the days of the board
+	// snapshots, taken in UTC.
+	BoardSnapshotDayLayout = "2006-01-02"
+
+	// DefaultBoardSnapshotDays is the number of days of snapshots returned
+	// when no range is given.
+	DefaultBoardSnapshotDays = 30
+
+	// MaxBoardSnapshotDays is the largest number of days of snapshots that
+	// can be queried at once.
+	MaxBoardSnapshotDays = 366
+)
+
+// BoardSnapshot is the number of cards of a board for each value of its
+// status property at the end of a day, the data points of a cumulative
+// flow diagram
+// swagger:model
+type BoardSnapshot struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The day of the snapshot, as YYYY-MM-DD in UTC
+	// required: true
+	Day string `json:"day"`
+
+	// The id of the status property the cards are counted by
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The number of cards by option ID of the status property, with the
+	// cards without a status under the empty key
+	// required: true
+	Counts map[string]int64 `json:"counts"`
+
+	// The time the snapshot was taken in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// BoardSnapshotDay returns the day of the snapshots taken at the time.
+func BoardSnapshotDay(t time.Time) string {
+	return t.UTC().Format(BoardSnapshotDayLayout)
+}
+
+// BoardSnapshotsQuery selects the snapshots of a board between two days,
+// both included
+type BoardSnapshotsQuery struct {
+	From string
+	To   string
+}
+
+// NewBoardSnapshotsQuery returns the query of the snapshots of the last
+// DefaultBoardSnapshotDays days up to the day of now, for the days not
+// given.
+func NewBoardSnapshotsQuery(from, to string, now time.Time) BoardSnapshotsQuery {
+	if to == "" {
+		to = BoardSnapshotDay(now)
+	}
+	if from == "" {
+		if toDay, err := time.Parse(BoardSnapshotDayLayout, to); err == nil {
+			from = toDay.AddDate(0, 0, -DefaultBoardSnapshotDays+1).Format(BoardSnapshotDayLayout)
+		}
+	}
+	return BoardSnapshotsQuery{From: from, To: to}
+}
+
+func (q BoardSnapshotsQuery) IsValid() error {
+	from, err := time.Parse(BoardSnapshotDayLayout, q.From)
+	if err != nil {
+		return NewCodedError(ErrCodeBadRequest, "from must be a day as YYYY-MM-DD", map[string]interface{}{"from": q.From})
+	}
+	to, err := time.Parse(BoardSnapshotDayLayout, q.To)
+	if err != nil {
+		return NewCodedError(ErrCodeBadRequest, "to must be a day as YYYY-MM-DD", map[string]interface{}{"to": q.To})
+	}
+	if to.Before(from) {
+		return NewCodedError(ErrCodeBadRequest, "from must not be after to", map[string]interface{}{"from": q.From, "to": q.To})
+	}
+	if to.Sub(from) >= MaxBoardSnapshotDays*24*time.Hour {
+		return NewCodedError(ErrCodeBadRequest, "too many days of snapshots", map[string]interface{}{"maxDays": MaxBoardSnapshotDays})
+	}
+	return nil
+}
+
+// NewBoardSnapshot returns the snapshot of the card groups of the status
+// property of a board.
+func NewBoardSnapshot(boardID, propertyID string, groups []*CardGroup, now time.Time) *BoardSnapshot {
+	counts := make(map[string]int64, len(groups))
+	for _, group := range groups {
+		counts[group.Value] += group.Count
+	}
+	return &BoardSnapshot{
+		BoardID:    boardID,
+		Day:        BoardSnapshotDay(now),
+		PropertyID: propertyID,
+		Counts:     counts,
+		CreateAt:   utils.GetMillisForTime(now),
+	}
+}
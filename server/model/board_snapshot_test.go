@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardSnapshotsQuery(t *testing.T) {
+	now := time.Date(2022, 3, 14, 23, 30, 0, 0, time.UTC)
+
+	t.Run("defaults to the last days", func(t *testing.T) {
+		query := NewBoardSnapshotsQuery("", "", now)
+		require.Equal(t, BoardSnapshotsQuery{From: "2022-02-13", To: "2022-03-14"}, query)
+		require.NoError(t, query.IsValid())
+
+		query = NewBoardSnapshotsQuery("", "2022-01-31", now)
+		require.Equal(t, BoardSnapshotsQuery{From: "2022-01-02", To: "2022-01-31"}, query)
+	})
+
+	t.Run("invalid ranges", func(t *testing.T) {
+		testCases := []BoardSnapshotsQuery{
+			{From: "14/03/2022", To: "2022-03-14"},
+			{From: "2022-03-14", To: ""},
+			{From: "2022-03-15", To: "2022-03-14"},
+			{From: "2021-01-01", To: "2022-03-14"},
+		}
+		for _, query := range testCases {
+			ce, ok := AsCodedError(query.IsValid())
+			require.True(t, ok, query)
+			require.Equal(t, ErrCodeBadRequest, ce.Code)
+		}
+	})
+
+	t.Run("a single day", func(t *testing.T) {
+		require.NoError(t, BoardSnapshotsQuery{From: "2022-03-14", To: "2022-03-14"}.IsValid())
+	})
+}
+
+func TestNewBoardSnapshot(t *testing.T) {
+	// the day is taken in UTC
+	now := time.Date(2022, 3, 15, 0, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	snapshot := NewBoardSnapshot("board-id", "status-id", []*CardGroup{
+		{Value: "", Count: 2},
+		{Value: "done-id", Count: 5},
+	}, now)
+	require.Equal(t, "2022-03-14", snapshot.Day)
+	require.Equal(t, map[string]int64{"": 2, "done-id": 5}, snapshot.Counts)
+	require.Equal(t, "status-id", snapshot.PropertyID)
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDefaultMember(t *testing.T) {
+	t.Run("editor by default", func(t *testing.T) {
+		board := &Board{ID: "board-id"}
+		member := board.NewDefaultMember("user-id")
+		require.Equal(t, &BoardMember{BoardID: "board-id", UserID: "user-id", SchemeEditor: true}, member)
+	})
+
+	t.Run("role set on the board", func(t *testing.T) {
+		board := &Board{
+			ID:         "board-id",
+			Properties: map[string]interface{}{BoardPropertyDefaultMemberRole: BoardRoleCommenter},
+		}
+		member := board.NewDefaultMember("user-id")
+		require.Equal(t, &BoardMember{BoardID: "board-id", UserID: "user-id", SchemeCommenter: true}, member)
+	})
+
+	t.Run("invalid role set on the board", func(t *testing.T) {
+		board := &Board{
+			ID:         "board-id",
+			Properties: map[string]interface{}{BoardPropertyDefaultMemberRole: "admin"},
+		}
+		require.Equal(t, BoardRoleEditor, board.DefaultMemberRole())
+	})
+}
+
+func TestBoardPatchDefaultMemberRole(t *testing.T) {
+	valid := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertyDefaultMemberRole: BoardRoleViewer}}
+	require.NoError(t, valid.IsValid())
+	require.True(t, valid.ChangesMembershipProperties())
+
+	invalid := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertyDefaultMemberRole: "admin"}}
+	require.Error(t, invalid.IsValid())
+
+	deleted := &BoardPatch{DeletedProperties: []string{BoardPropertyDefaultMemberRole}}
+	require.NoError(t, deleted.IsValid())
+	require.True(t, deleted.ChangesMembershipProperties())
+
+	other := &BoardPatch{UpdatedProperties: map[string]interface{}{"other": "value"}}
+	require.False(t, other.ChangesMembershipProperties())
+}
+
+func TestBoardChannelMemberSync(t *testing.T) {
+	board := &Board{
+		ID:         "board-id",
+		Properties: map[string]interface{}{BoardPropertySyncChannelMembers: true},
+	}
+	require.False(t, board.SyncsChannelMembers())
+
+	board.ChannelID = "channel-id"
+	require.True(t, board.SyncsChannelMembers())
+
+	patch := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertySyncChannelMembers: true}}
+	require.NoError(t, patch.IsValid())
+	require.True(t, patch.ChangesMembershipProperties())
+	require.True(t, patch.EnablesChannelMemberSync())
+
+	invalid := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertySyncChannelMembers: "yes"}}
+	require.Error(t, invalid.IsValid())
+}
+
+func TestBoardChannelCommentSync(t *testing.T) {
+	board := &Board{
+		ID:         "board-id",
+		Properties: map[string]interface{}{BoardPropertySyncChannelComments: true},
+	}
+	require.False(t, board.SyncsChannelComments())
+
+	board.ChannelID = "channel-id"
+	require.True(t, board.SyncsChannelComments())
+
+	patch := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertySyncChannelComments: false}}
+	require.NoError(t, patch.IsValid())
+	require.False(t, patch.ChangesMembershipProperties())
+
+	invalid := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertySyncChannelComments: "yes"}}
+	require.Error(t, invalid.IsValid())
+}
+
+func TestBoardReadOnly(t *testing.T) {
+	board := &Board{ID: "board-id"}
+	require.False(t, board.IsReadOnly())
+
+	board.Properties = map[string]interface{}{BoardPropertyReadOnly: true}
+	require.True(t, board.IsReadOnly())
+
+	patch := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertyReadOnly: true}}
+	require.NoError(t, patch.IsValid())
+	require.True(t, patch.ChangesReadOnly())
+	require.False(t, patch.ChangesMembershipProperties())
+
+	deleted := &BoardPatch{DeletedProperties: []string{BoardPropertyReadOnly}}
+	require.True(t, deleted.ChangesReadOnly())
+
+	other := &BoardPatch{UpdatedProperties: map[string]interface{}{"other": "value"}}
+	require.False(t, other.ChangesReadOnly())
+
+	invalid := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertyReadOnly: "yes"}}
+	require.Error(t, invalid.IsValid())
+}
@@ -0,0 +1,27 @@
+package model
+
+const (
+	// DefaultRecentBoardsLimit is the number of recently visited boards
+	// returned when the client doesn't ask for a specific amount.
+	DefaultRecentBoardsLimit = 10
+
+	// MaxRecentBoardsLimit is the maximum number of recently visited
+	// boards that can be requested at once.
+	MaxRecentBoardsLimit = 50
+)
+
+// BoardVisit records the last time a user opened a board
+// swagger:model
+type BoardVisit struct {
+	// The ID of the user
+	// required: true
+	UserID string `json:"userId"`
+
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The time of the last visit in miliseconds since the current epoch
+	// required: true
+	VisitedAt int64 `json:"visitedAt"`
+}
@@ -0,0 +1,332 @@
+package model
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	// BoardWebhookEventBlockCreated is sent when a block is added to the board.
+	BoardWebhookEventBlockCreated = "block_created"
+
+	// BoardWebhookEventBlockUpdated is sent when a block of the board changes.
+	BoardWebhookEventBlockUpdated = "block_updated"
+
+	// BoardWebhookEventBlockDeleted is sent when a block of the board is deleted.
+	BoardWebhookEventBlockDeleted = "block_deleted"
+
+	// BoardWebhookEventPropertyValidation is sent before a change of the
+	// properties of a card of the board is saved, and the webhook can
+	// reject it.
+	BoardWebhookEventPropertyValidation = "property_validation"
+
+	// MaxBoardWebhooks is the number of webhooks a board can have.
+	MaxBoardWebhooks = 20
+
+	// DefaultValidationWebhookTimeoutMs is the time a validation webhook
+	// has to answer when it has no timeout.
+	DefaultValidationWebhookTimeoutMs = 3000
+
+	// MaxValidationWebhookTimeoutMs is the longest timeout of a validation
+	// webhook, as the changes wait for it.
+	MaxValidationWebhookTimeoutMs = 10000
+)
+
+var boardWebhookEvents = map[string]bool{
+	BoardWebhookEventBlockCreated:       true,
+	BoardWebhookEventBlockUpdated:       true,
+	BoardWebhookEventBlockDeleted:       true,
+	BoardWebhookEventPropertyValidation: true,
+}
+
+// BoardWebhook is an outgoing webhook of a board, called when the blocks
+// of the board change
+// swagger:model
+type BoardWebhook struct {
+	// The id of the webhook
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board of the webhook
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The URL the events are posted to
+	// required: true
+	URL string `json:"url"`
+
+	// The events sent to the webhook: block_created, block_updated,
+	// block_deleted or property_validation
+	// required: true
+	Events []string `json:"events"`
+
+	// The properties whose changes are validated by the webhook, all of
+	// them if empty
+	// required: false
+	PropertyIDs []string `json:"propertyIds,omitempty"`
+
+	// The time in milliseconds the webhook has to validate a change
+	// required: false
+	TimeoutMs int64 `json:"timeoutMs,omitempty"`
+
+	// True if the changes are saved when the webhook can't validate them
+	// in time, false if they are rejected
+	// required: false
+	FailOpen bool `json:"failOpen"`
+
+	// The secret the requests are signed with. It is never returned
+	// required: false
+	Secret string `json:"secret,omitempty"`
+
+	// True if the webhook has a secret
+	// required: true
+	HasSecret bool `json:"hasSecret"`
+
+	// False if the events aren't sent to the webhook
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The id of the user that created the webhook
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modification time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// BoardWebhookPatch is a patch to modify a board webhook
+// swagger:model
+type BoardWebhookPatch struct {
+	// The URL the events are posted to
+	// required: false
+	URL *string `json:"url"`
+
+	// The events sent to the webhook
+	// required: false
+	Events []string `json:"events"`
+
+	// The properties whose changes are validated by the webhook
+	// required: false
+	PropertyIDs []string `json:"propertyIds"`
+
+	// The time in milliseconds the webhook has to validate a change
+	// required: false
+	TimeoutMs *int64 `json:"timeoutMs"`
+
+	// True if the changes are saved when the webhook can't validate them
+	// required: false
+	FailOpen *bool `json:"failOpen"`
+
+	// The secret the requests are signed with. An empty secret removes it
+	// required: false
+	Secret *string `json:"secret"`
+
+	// False if the events aren't sent to the webhook
+	// required: false
+	Enabled *bool `json:"enabled"`
+}
+
+// BoardWebhookPayload is the body posted to the board webhooks
+// swagger:model
+type BoardWebhookPayload struct {
+	// The event: block_created, block_updated or block_deleted
+	// required: true
+	Event string `json:"event"`
+
+	// The id of the board of the block
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The block the event is about
+	// required: true
+	Block Block `json:"block"`
+
+	// The time of the event in miliseconds since the current epoch
+	// required: true
+	Timestamp int64 `json:"timestamp"`
+}
+
+// PropertyValidationPayload is the body posted to the validation webhooks
+// before a change of the properties of a card is saved
+// swagger:model
+type PropertyValidationPayload struct {
+	// The event: property_validation
+	// required: true
+	Event string `json:"event"`
+
+	// The id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The card, with the change
+	// required: true
+	Card Block `json:"card"`
+
+	// The changed properties by ID, with their old and new values
+	// required: true
+	Changes map[string]PropertyChange `json:"changes"`
+
+	// The time of the event in miliseconds since the current epoch
+	// required: true
+	Timestamp int64 `json:"timestamp"`
+}
+
+// PropertyChange is the change of the value of a property of a card
+// swagger:model
+type PropertyChange struct {
+	// The value before the change, null if the property had none
+	// required: true
+	Old interface{} `json:"old"`
+
+	// The value after the change, null if the property was cleared
+	// required: true
+	New interface{} `json:"new"`
+}
+
+// ChangedProperties returns the properties of the card whose values
+// differ from the old card, which is nil for a new card, by property ID.
+func ChangedProperties(card, oldCard *Block) map[string]PropertyChange {
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	var oldProps map[string]interface{}
+	if oldCard != nil {
+		oldProps, _ = oldCard.Fields["properties"].(map[string]interface{})
+	}
+
+	changes := map[string]PropertyChange{}
+	for id, value := range props {
+		if !reflect.DeepEqual(value, oldProps[id]) {
+			changes[id] = PropertyChange{Old: oldProps[id], New: value}
+		}
+	}
+	for id, oldValue := range oldProps {
+		if _, ok := props[id]; !ok && oldValue != nil {
+			changes[id] = PropertyChange{Old: oldValue, New: nil}
+		}
+	}
+	return changes
+}
+
+// PropertyValidationResponse is the body a validation webhook may answer
+// with when it rejects a change
+// swagger:model
+type PropertyValidationResponse struct {
+	// Why the change is rejected
+	// required: false
+	Message string `json:"message"`
+}
+
+func (h *BoardWebhook) Hydrate() {
+	h.ID = utils.NewID(utils.IDTypeNone)
+	h.CreateAt = utils.GetMillis()
+	h.UpdateAt = h.CreateAt
+}
+
+func (h *BoardWebhook) IsValid() error {
+	if strings.TrimSpace(h.BoardID) == "" {
+		return NewCodedError(ErrCodeBadRequest, "webhook board ID cannot be empty", nil)
+	}
+
+	u, err := url.Parse(h.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return NewCodedError(ErrCodeBadRequest, "webhook URL must be an absolute http or https URL", map[string]interface{}{"url": h.URL})
+	}
+
+	if len(h.Events) == 0 {
+		return NewCodedError(ErrCodeBadRequest, "webhook must have at least one event", nil)
+	}
+	for _, event := range h.Events {
+		if !boardWebhookEvents[event] {
+			return NewCodedError(ErrCodeBadRequest, "invalid webhook event", map[string]interface{}{"event": event})
+		}
+	}
+
+	if h.TimeoutMs < 0 || h.TimeoutMs > MaxValidationWebhookTimeoutMs {
+		return NewCodedError(ErrCodeBadRequest, "invalid webhook timeout", map[string]interface{}{"timeoutMs": h.TimeoutMs, "max": MaxValidationWebhookTimeoutMs})
+	}
+	for _, propertyID := range h.PropertyIDs {
+		if propertyID == "" {
+			return NewCodedError(ErrCodeBadRequest, "webhook property IDs cannot be empty", nil)
+		}
+	}
+	return nil
+}
+
+// HasEvent returns true if the event is sent to the webhook.
+func (h *BoardWebhook) HasEvent(event string) bool {
+	for _, e := range h.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatesChange returns true if the webhook validates the changes of
+// one of the properties.
+func (h *BoardWebhook) ValidatesChange(changes map[string]PropertyChange) bool {
+	if !h.Enabled || !h.HasEvent(BoardWebhookEventPropertyValidation) || len(changes) == 0 {
+		return false
+	}
+	if len(h.PropertyIDs) == 0 {
+		return true
+	}
+	for _, propertyID := range h.PropertyIDs {
+		if _, ok := changes[propertyID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidationTimeout returns the time the webhook has to validate a change.
+func (h *BoardWebhook) ValidationTimeout() time.Duration {
+	timeoutMs := h.TimeoutMs
+	if timeoutMs <= 0 {
+		timeoutMs = DefaultValidationWebhookTimeoutMs
+	}
+	return time.Duration(timeoutMs) * time.Millisecond
+}
+
+// Sanitized returns a copy of the webhook without its secret, to be
+// returned by the API.
+func (h *BoardWebhook) Sanitized() *BoardWebhook {
+	sanitized := *h
+	sanitized.HasSecret = h.Secret != ""
+	sanitized.Secret = ""
+	return &sanitized
+}
+
+// Patch returns an updated version of the webhook.
+func (p *BoardWebhookPatch) Patch(webhook *BoardWebhook) *BoardWebhook {
+	if p.URL != nil {
+		webhook.URL = *p.URL
+	}
+	if p.Events != nil {
+		webhook.Events = p.Events
+	}
+	if p.Secret != nil {
+		webhook.Secret = *p.Secret
+	}
+	if p.Enabled != nil {
+		webhook.Enabled = *p.Enabled
+	}
+	if p.PropertyIDs != nil {
+		webhook.PropertyIDs = p.PropertyIDs
+	}
+	if p.TimeoutMs != nil {
+		webhook.TimeoutMs = *p.TimeoutMs
+	}
+	if p.FailOpen != nil {
+		webhook.FailOpen = *p.FailOpen
+	}
+	return webhook
+}
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardWebhookIsValid(t *testing.T) {
+	valid := func() *BoardWebhook {
+		return &BoardWebhook{
+			BoardID: "board-id",
+			URL:     "https://example.com/hook",
+			Events:  []string{BoardWebhookEventBlockCreated, BoardWebhookEventBlockDeleted},
+		}
+	}
+
+	require.NoError(t, valid().IsValid())
+
+	testCases := []struct {
+		name   string
+		modify func(h *BoardWebhook)
+	}{
+		{"no board", func(h *BoardWebhook) { h.BoardID = "" }},
+		{"no URL", func(h *BoardWebhook) { h.URL = "" }},
+		{"relative URL", func(h *BoardWebhook) { h.URL = "/hook" }},
+		{"other scheme", func(h *BoardWebhook) { h.URL = "ftp://example.com/hook" }},
+		{"no events", func(h *BoardWebhook) { h.Events = nil }},
+		{"unknown event", func(h *BoardWebhook) { h.Events = []string{"board_deleted"} }},
+		{"negative timeout", func(h *BoardWebhook) { h.TimeoutMs = -1 }},
+		{"long timeout", func(h *BoardWebhook) { h.TimeoutMs = MaxValidationWebhookTimeoutMs + 1 }},
+		{"empty property", func(h *BoardWebhook) { h.PropertyIDs = []string{""} }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			webhook := valid()
+			tc.modify(webhook)
+			err := webhook.IsValid()
+			require.Error(t, err)
+			ce, ok := AsCodedError(err)
+			require.True(t, ok)
+			require.Equal(t, ErrCodeBadRequest, ce.Code)
+		})
+	}
+}
+
+func TestBoardWebhookPatch(t *testing.T) {
+	webhook := &BoardWebhook{
+		URL:     "https://example.com/hook",
+		Events:  []string{BoardWebhookEventBlockCreated},
+		Secret:  "secret",
+		Enabled: true,
+	}
+
+	url := "https://example.com/other"
+	disabled := false
+	empty := ""
+	patch := &BoardWebhookPatch{URL: &url, Enabled: &disabled, Secret: &empty}
+	patched := patch.Patch(webhook)
+
+	require.Equal(t, "https://example.com/other", patched.URL)
+	require.Equal(t, []string{BoardWebhookEventBlockCreated}, patched.Events)
+	require.Empty(t, patched.Secret)
+	require.False(t, patched.Enabled)
+}
+
+func TestBoardWebhookSanitized(t *testing.T) {
+	webhook := &BoardWebhook{ID: "webhook-id", Secret: "secret"}
+	sanitized := webhook.Sanitized()
+
+	require.Empty(t, sanitized.Secret)
+	require.True(t, sanitized.HasSecret)
+	require.Equal(t, "secret", webhook.Secret)
+}
+
+func TestBoardWebhookValidatesChange(t *testing.T) {
+	webhook := &BoardWebhook{
+		Events:      []string{BoardWebhookEventPropertyValidation},
+		PropertyIDs: []string{"status"},
+		Enabled:     true,
+	}
+
+	require.True(t, webhook.ValidatesChange(map[string]PropertyChange{"status": {New: "done"}}))
+	require.False(t, webhook.ValidatesChange(map[string]PropertyChange{"estimate": {New: "3"}}))
+	require.False(t, webhook.ValidatesChange(map[string]PropertyChange{}))
+
+	webhook.PropertyIDs = nil
+	require.True(t, webhook.ValidatesChange(map[string]PropertyChange{"estimate": {New: "3"}}))
+
+	webhook.Enabled = false
+	require.False(t, webhook.ValidatesChange(map[string]PropertyChange{"status": {New: "done"}}))
+
+	webhook.Enabled = true
+	webhook.Events = []string{BoardWebhookEventBlockUpdated}
+	require.False(t, webhook.ValidatesChange(map[string]PropertyChange{"status": {New: "done"}}))
+}
+
+func TestBoardWebhookValidationTimeout(t *testing.T) {
+	require.Equal(t, DefaultValidationWebhookTimeoutMs*time.Millisecond, (&BoardWebhook{}).ValidationTimeout())
+	require.Equal(t, 500*time.Millisecond, (&BoardWebhook{TimeoutMs: 500}).ValidationTimeout())
+}
+
+func TestChangedProperties(t *testing.T) {
+	card := func(props map[string]interface{}) *Block {
+		return &Block{Fields: map[string]interface{}{"properties": props}}
+	}
+
+	oldCard := card(map[string]interface{}{
+		"status": "todo",
+		"tags":   []interface{}{"a"},
+		"notes":  "old notes",
+		"owner":  "user-1",
+	})
+	newCard := card(map[string]interface{}{
+		"status":   "done",
+		"tags":     []interface{}{"a"},
+		"owner":    "user-1",
+		"estimate": "3",
+		"cleared":  nil,
+	})
+
+	require.Equal(t, map[string]PropertyChange{
+		"status":   {Old: "todo", New: "done"},
+		"notes":    {Old: "old notes", New: nil},
+		"estimate": {Old: nil, New: "3"},
+	}, ChangedProperties(newCard, oldCard))
+
+	require.Equal(t, map[string]PropertyChange{
+		"status": {Old: nil, New: "todo"},
+		"tags":   {Old: nil, New: []interface{}{"a"}},
+		"notes":  {Old: nil, New: "old notes"},
+		"owner":  {Old: nil, New: "user-1"},
+	}, ChangedProperties(oldCard, nil))
+}
@@ -141,6 +141,11 @@ func GenerateBoardsAndBlocksIDs(bab *BoardsAndBlocks, logger *mlog.Logger) (*Boa
 	for _, board := range bab.Boards {
 		newID := utils.NewID(utils.IDTypeBoard)
 		for _, block := range blocksByBoard[board.ID] {
+			// the blocks at the root of the board, like its content,
+			// follow it
+			if block.ParentID == board.ID {
+				block.ParentID = newID
+			}
 			block.BoardID = newID
 			blocks = append(blocks, block)
 		}
@@ -149,9 +154,20 @@ func GenerateBoardsAndBlocksIDs(bab *BoardsAndBlocks, logger *mlog.Logger) (*Boa
 		boards = append(boards, board)
 	}
 
+	newBlocks := GenerateBlockIDs(blocks, logger)
+
+	// the content order of the boards refers to the blocks by ID
+	newIDs := make(map[string]string, len(blocks))
+	for i := range blocks {
+		newIDs[blocks[i].ID] = newBlocks[i].ID
+	}
+	for _, board := range boards {
+		board.remapContentOrder(newIDs)
+	}
+
 	newBab := &BoardsAndBlocks{
 		Boards: boards,
-		Blocks: GenerateBlockIDs(blocks, logger),
+		Blocks: newBlocks,
 	}
 
 	return newBab, nil
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// HeaderIntegrationName is the header the integrations use to identify
+// themselves on the bot action API, recorded in the audit log.
+const HeaderIntegrationName = "X-Integration-Name"
+
+// BotCreateCardRequest is the request to create a card through the bot action API
+// swagger:model
+type BotCreateCardRequest struct {
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The description of the card, in markdown
+	// required: false
+	Description string `json:"description"`
+
+	// The property values of the card, keyed by property name or ID.
+	// Select options can be given by value
+	// required: false
+	Properties map[string]string `json:"properties"`
+}
+
+// BotCommentRequest is the request to comment on a card through the bot action API
+// swagger:model
+type BotCommentRequest struct {
+	// The text of the comment
+	// required: true
+	Text string `json:"text"`
+}
+
+// BotSetPropertiesRequest is the request to set property values of a card
+// through the bot action API
+// swagger:model
+type BotSetPropertiesRequest struct {
+	// The property values to set, keyed by property name or ID. Select
+	// options can be given by value, and empty values clear the property
+	// required: true
+	Properties map[string]string `json:"properties"`
+}
+
+func BotCreateCardRequestFromJSON(data io.Reader) (*BotCreateCardRequest, error) {
+	var request BotCreateCardRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func BotCommentRequestFromJSON(data io.Reader) (*BotCommentRequest, error) {
+	var request BotCommentRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func BotSetPropertiesRequestFromJSON(data io.Reader) (*BotSetPropertiesRequest, error) {
+	var request BotSetPropertiesRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
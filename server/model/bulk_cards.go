@@ -0,0 +1,101 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	// MaxBulkCards is the number of cards that can be created in a
+	// single bulk request.
+	MaxBulkCards = 100
+
+	// MaxBulkCardContentBlocks is the number of content blocks each card
+	// of a bulk request can have.
+	MaxBulkCardContentBlocks = 50
+)
+
+var bulkCardContentTypes = map[BlockType]bool{
+	TypeText:     true,
+	TypeCheckbox: true,
+	TypeImage:    true,
+}
+
+// BulkCardsRequest is the request to create several cards of a board at once
+// swagger:model
+type BulkCardsRequest struct {
+	// The cards to create
+	// required: true
+	Cards []BulkCard `json:"cards"`
+}
+
+// BulkCard is a card to create through the bulk card API
+// swagger:model
+type BulkCard struct {
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The icon of the card
+	// required: false
+	Icon string `json:"icon"`
+
+	// The property values of the card, keyed by property name or ID.
+	// Select options can be given by value
+	// required: false
+	Properties map[string]string `json:"properties"`
+
+	// The content blocks of the card, in order
+	// required: false
+	Content []BulkCardContent `json:"content"`
+}
+
+// BulkCardContent is a content block of a card created in bulk
+// swagger:model
+type BulkCardContent struct {
+	// The type of the block: text, checkbox or image
+	// required: true
+	Type BlockType `json:"type"`
+
+	// The title of the block
+	// required: false
+	Title string `json:"title"`
+
+	// The block fields
+	// required: false
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// IsValid checks the number of cards and their content, but not their
+// property values, which depend on the board.
+func (r *BulkCardsRequest) IsValid() error {
+	if len(r.Cards) == 0 {
+		return NewCodedError(ErrCodeBadRequest, "no cards to create", nil)
+	}
+	if len(r.Cards) > MaxBulkCards {
+		return NewCodedError(ErrCodeBadRequest, "too many cards", map[string]interface{}{"maxCards": MaxBulkCards})
+	}
+
+	for i, card := range r.Cards {
+		if card.Title == "" {
+			return NewCodedError(ErrCodeBadRequest, "missing card title", map[string]interface{}{"card": i})
+		}
+		if len(card.Content) > MaxBulkCardContentBlocks {
+			return NewCodedError(ErrCodeBadRequest, "too many content blocks", map[string]interface{}{"card": i, "maxContentBlocks": MaxBulkCardContentBlocks})
+		}
+		for _, content := range card.Content {
+			if !bulkCardContentTypes[content.Type] {
+				return NewCodedError(ErrCodeBadRequest, "invalid content block type", map[string]interface{}{"card": i, "type": content.Type})
+			}
+		}
+	}
+	return nil
+}
+
+func BulkCardsRequestFromJSON(data io.Reader) (*BulkCardsRequest, error) {
+	var request BulkCardsRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkCardsRequestIsValid(t *testing.T) {
+	valid := func() *BulkCardsRequest {
+		return &BulkCardsRequest{
+			Cards: []BulkCard{
+				{Title: "first", Content: []BulkCardContent{{Type: TypeText, Title: "description"}}},
+				{Title: "second", Properties: map[string]string{"Status": "Done"}},
+			},
+		}
+	}
+
+	require.NoError(t, valid().IsValid())
+
+	testCases := []struct {
+		name   string
+		modify func(r *BulkCardsRequest)
+	}{
+		{"no cards", func(r *BulkCardsRequest) { r.Cards = nil }},
+		{"too many cards", func(r *BulkCardsRequest) { r.Cards = make([]BulkCard, MaxBulkCards+1) }},
+		{"no title", func(r *BulkCardsRequest) { r.Cards[1].Title = "" }},
+		{"too many content blocks", func(r *BulkCardsRequest) {
+			r.Cards[0].Content = make([]BulkCardContent, MaxBulkCardContentBlocks+1)
+		}},
+		{"card content", func(r *BulkCardsRequest) { r.Cards[0].Content[0].Type = TypeCard }},
+		{"comment content", func(r *BulkCardsRequest) { r.Cards[0].Content[0].Type = TypeComment }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := valid()
+			tc.modify(request)
+			err := request.IsValid()
+			require.Error(t, err)
+			ce, ok := AsCodedError(err)
+			require.True(t, ok)
+			require.Equal(t, ErrCodeBadRequest, ce.Code)
+		})
+	}
+}
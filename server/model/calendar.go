@@ -0,0 +1,299 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxCalendarFileSize is the maximum size of an imported calendar.
+	MaxCalendarFileSize = 5 * 1024 * 1024
+
+	// MaxCalendarEvents is the maximum number of events imported from a
+	// calendar.
+	MaxCalendarEvents = 1000
+
+	// CalendarSubscriptionRefreshInterval is the time between two imports
+	// of a subscribed calendar, in miliseconds.
+	CalendarSubscriptionRefreshInterval = int64(60 * 60 * 1000)
+
+	// CardFieldCalendarEventUID is the field of the cards imported from a
+	// calendar holding the UID of their event, to update them on the next
+	// import.
+	CardFieldCalendarEventUID = "calendarEventUid"
+)
+
+// CalendarEvent is an event of an iCalendar (.ics) file.
+type CalendarEvent struct {
+	UID     string
+	Summary string
+
+	// The start and end of the event in miliseconds, End being 0 if the
+	// event has no end or ends the day it starts for all-day events
+	Start int64
+	End   int64
+
+	AllDay    bool
+	Cancelled bool
+}
+
+// DateValue returns the value of a date property set to the event.
+func (e CalendarEvent) DateValue() string {
+	value := struct {
+		From        int64 `json:"from"`
+		To          int64 `json:"to,omitempty"`
+		IncludeTime bool  `json:"includeTime,omitempty"`
+	}{From: e.Start, To: e.End, IncludeTime: !e.AllDay}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// ParseCalendar reads the events of an iCalendar file. The events
+// without UID or start are skipped, as are the changed occurrences of
+// recurring events, that are only imported at their first occurrence.
+func ParseCalendar(r io.Reader) ([]CalendarEvent, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, MaxCalendarFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxCalendarFileSize {
+		return nil, NewCodedError(ErrCodeBadRequest, "the calendar is too large", map[string]interface{}{"maxSize": MaxCalendarFileSize})
+	}
+
+	lines := unfoldCalendarLines(data)
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, NewCodedError(ErrCodeBadRequest, "the file is not an iCalendar file", nil)
+	}
+
+	events := []CalendarEvent{}
+	var event *CalendarEvent
+	var skip bool
+	depth := 0
+	for _, line := range lines {
+		name, params, value := parseCalendarLine(line)
+		switch name {
+		case "BEGIN":
+			if event != nil {
+				depth++
+			} else if strings.EqualFold(value, "VEVENT") {
+				event = &CalendarEvent{}
+				skip = false
+			}
+			continue
+		case "END":
+			if event == nil {
+				continue
+			}
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if !skip && event.UID != "" && event.Start != 0 {
+				if len(events) == MaxCalendarEvents {
+					return nil, NewCodedError(ErrCodeBadRequest, "the calendar has too many events", map[string]interface{}{"maxEvents": MaxCalendarEvents})
+				}
+				events = append(events, *event)
+			}
+			event = nil
+			continue
+		}
+
+		// only the properties of the events themselves are read, not
+		// those of their alarms
+		if event == nil || depth != 0 {
+			continue
+		}
+
+		switch name {
+		case "UID":
+			event.UID = value
+		case "SUMMARY":
+			event.Summary = unescapeCalendarText(value)
+		case "STATUS":
+			event.Cancelled = strings.EqualFold(value, "CANCELLED")
+		case "RECURRENCE-ID":
+			skip = true
+		case "DTSTART":
+			event.Start, event.AllDay = parseCalendarTime(value, params)
+		case "DTEND":
+			event.End, _ = parseCalendarTime(value, params)
+		}
+	}
+
+	for i := range events {
+		// the end of all-day events is the day after their last day
+		if events[i].AllDay && events[i].End != 0 {
+			events[i].End -= int64(24 * time.Hour / time.Millisecond)
+		}
+		if events[i].End <= events[i].Start {
+			events[i].End = 0
+		}
+	}
+	return events, nil
+}
+
+// unfoldCalendarLines returns the content lines of the calendar, joining
+// the lines folded on several ones.
+func unfoldCalendarLines(data []byte) []string {
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxCalendarFileSize)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseCalendarLine splits a content line as NAME;PARAM=VALUE:value.
+func parseCalendarLine(line string) (name string, params map[string]string, value string) {
+	quoted := false
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon == -1 {
+		return strings.ToUpper(line), nil, ""
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params = map[string]string{}
+	for _, param := range parts[1:] {
+		if kv := strings.SplitN(param, "=", 2); len(kv) == 2 {
+			params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:]
+}
+
+// parseCalendarTime returns the time of a DTSTART or DTEND value in
+// miliseconds, and whether it is a date without time. The times without
+// time zone are read in UTC.
+func parseCalendarTime(value string, params map[string]string) (int64, bool) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, time.UTC)
+		if err != nil {
+			return 0, false
+		}
+		return t.UnixNano() / int64(time.Millisecond), true
+	}
+
+	loc := time.UTC
+	if tzid := params["TZID"]; tzid != "" && !strings.HasSuffix(value, "Z") {
+		if tzLoc, err := time.LoadLocation(tzid); err == nil {
+			loc = tzLoc
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", strings.TrimSuffix(value, "Z"), loc)
+	if err != nil {
+		return 0, false
+	}
+	return t.UnixNano() / int64(time.Millisecond), false
+}
+
+var calendarTextReplacer = strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";")
+
+func unescapeCalendarText(value string) string {
+	return calendarTextReplacer.Replace(value)
+}
+
+// DateProperty returns the date property the events of a calendar are
+// imported in, by ID or name, or the first date property if name is
+// empty.
+func (s PropSchema) DateProperty(name string) (PropDef, bool) {
+	if name == "" {
+		return s.firstOfType(propTypeDate, "")
+	}
+	def, ok := s.findProperty(name)
+	if !ok || def.Type != propTypeDate {
+		return PropDef{}, false
+	}
+	return def, true
+}
+
+// CalendarImportResult is the number of cards created and updated by the
+// import of a calendar
+// swagger:model
+type CalendarImportResult struct {
+	// The number of cards created for new events
+	// required: true
+	Created int `json:"created"`
+
+	// The number of cards updated for changed events
+	// required: true
+	Updated int `json:"updated"`
+
+	// The number of events left out because they are cancelled or
+	// unchanged
+	// required: true
+	Skipped int `json:"skipped"`
+}
+
+// CalendarSubscription is a calendar whose events are imported
+// periodically as cards of a board
+// swagger:model
+type CalendarSubscription struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The http, https or webcal URL of the calendar
+	// required: true
+	URL string `json:"url"`
+
+	// The date property, by ID or name, the events are imported in; the
+	// first date property of the board if empty
+	// required: false
+	DateProperty string `json:"dateProperty"`
+
+	// The id of the user that last changed the subscription
+	// required: false
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The last time the subscription was changed in miliseconds since the current epoch
+	// required: false
+	UpdateAt int64 `json:"updateAt"`
+
+	// The last time the calendar was imported in miliseconds since the current epoch
+	// required: false
+	LastSyncAt int64 `json:"lastSyncAt"`
+
+	// The error of the last import, empty if it succeeded
+	// required: false
+	LastError string `json:"lastError"`
+}
+
+// IsValid checks that the subscription has an absolute calendar URL.
+func (s *CalendarSubscription) IsValid() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "webcal") || u.Host == "" {
+		return NewCodedError(ErrCodeBadRequest, "the calendar URL must be an absolute http, https or webcal URL", map[string]interface{}{"url": s.URL})
+	}
+	return nil
+}
+
+// FetchURL returns the URL the calendar is downloaded from, webcal URLs
+// being fetched with https.
+func (s *CalendarSubscription) FetchURL() string {
+	if strings.HasPrefix(s.URL, "webcal://") {
+		return "https://" + strings.TrimPrefix(s.URL, "webcal://")
+	}
+	return s.URL
+}
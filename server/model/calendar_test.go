@@ -0,0 +1,96 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Test//EN\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:launch@example.com\r\n" +
+	"SUMMARY:Product launch\\, phase 1\r\n" +
+	"DTSTART;VALUE=DATE:20240301\r\n" +
+	"DTEND;VALUE=DATE:20240303\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"SUMMARY:Reminder\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:review@example.com\r\n" +
+	"SUMMARY:Quarterly review with a long title that is\r\n" +
+	"  folded\r\n" +
+	"DTSTART:20240315T140000Z\r\n" +
+	"DTEND:20240315T150000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup@example.com\r\n" +
+	"SUMMARY:Standup\r\n" +
+	"DTSTART;TZID=Europe/Paris:20240320T090000\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:review@example.com\r\n" +
+	"RECURRENCE-ID:20240415T140000Z\r\n" +
+	"DTSTART:20240416T140000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:No UID\r\n" +
+	"DTSTART:20240315T140000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseCalendar(t *testing.T) {
+	t.Run("events", func(t *testing.T) {
+		events, err := ParseCalendar(strings.NewReader(testCalendar))
+		require.NoError(t, err)
+		require.Equal(t, []CalendarEvent{
+			{
+				UID:     "launch@example.com",
+				Summary: "Product launch, phase 1",
+				Start:   1709251200000,
+				End:     1709337600000,
+				AllDay:  true,
+			},
+			{
+				UID:     "review@example.com",
+				Summary: "Quarterly review with a long title that is folded",
+				Start:   1710511200000,
+				End:     1710514800000,
+			},
+			{
+				UID:       "standup@example.com",
+				Summary:   "Standup",
+				Start:     1710921600000,
+				Cancelled: true,
+			},
+		}, events)
+	})
+
+	t.Run("not a calendar", func(t *testing.T) {
+		_, err := ParseCalendar(strings.NewReader("title,date\nlaunch,2024-03-01\n"))
+		require.True(t, IsErrBadRequest(err))
+	})
+
+	t.Run("date values", func(t *testing.T) {
+		require.Equal(t, `{"from":1709251200000,"to":1709337600000}`,
+			CalendarEvent{Start: 1709251200000, End: 1709337600000, AllDay: true}.DateValue())
+		require.Equal(t, `{"from":1710511200000,"includeTime":true}`,
+			CalendarEvent{Start: 1710511200000}.DateValue())
+	})
+}
+
+func TestCalendarSubscription(t *testing.T) {
+	for _, u := range []string{"https://example.com/team.ics", "webcal://example.com/team.ics"} {
+		require.NoError(t, (&CalendarSubscription{URL: u}).IsValid(), u)
+	}
+	for _, u := range []string{"", "example.com/team.ics", "ftp://example.com/team.ics"} {
+		require.Error(t, (&CalendarSubscription{URL: u}).IsValid(), u)
+	}
+
+	require.Equal(t, "https://example.com/team.ics", (&CalendarSubscription{URL: "webcal://example.com/team.ics"}).FetchURL())
+	require.Equal(t, "http://example.com/team.ics", (&CalendarSubscription{URL: "http://example.com/team.ics"}).FetchURL())
+}
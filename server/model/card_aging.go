@@ -0,0 +1,51 @@
+package model
+
+// CardAging is how long a card has been in its current status, shown as
+// an aging badge on the card
+// swagger:model
+type CardAging struct {
+	// The ID of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The ID of the status property of the board
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The current status of the card, the option ID, or empty if the card
+	// has no status
+	// required: true
+	Status string `json:"status"`
+
+	// The time the card took its current status in miliseconds since the
+	// current epoch
+	// required: true
+	StatusSince int64 `json:"statusSince"`
+
+	// The time the card has been in its current status in miliseconds
+	// required: true
+	TimeInStatus int64 `json:"timeInStatus"`
+}
+
+// NewCardAging returns the aging of the card in the status property at
+// now. Without a history of the status, the card is in its status since
+// it was created.
+func NewCardAging(card *Block, propertyID string, since, now int64) *CardAging {
+	if since == 0 {
+		since = card.CreateAt
+	}
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	status, _ := props[propertyID].(string)
+
+	timeInStatus := now - since
+	if timeInStatus < 0 {
+		timeInStatus = 0
+	}
+	return &CardAging{
+		CardID:       card.ID,
+		PropertyID:   propertyID,
+		Status:       status,
+		StatusSince:  since,
+		TimeInStatus: timeInStatus,
+	}
+}
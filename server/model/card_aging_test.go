@@ -0,0 +1,37 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCardAging(t *testing.T) {
+	card := &Block{
+		ID:       "card-id",
+		CreateAt: 1000,
+		Fields:   map[string]interface{}{"properties": map[string]interface{}{"status-id": "doing"}},
+	}
+
+	t.Run("since the change of status", func(t *testing.T) {
+		require.Equal(t, &CardAging{
+			CardID:       "card-id",
+			PropertyID:   "status-id",
+			Status:       "doing",
+			StatusSince:  4000,
+			TimeInStatus: 6000,
+		}, NewCardAging(card, "status-id", 4000, 10000))
+	})
+
+	t.Run("without history, since the creation", func(t *testing.T) {
+		aging := NewCardAging(card, "status-id", 0, 10000)
+		require.Equal(t, int64(1000), aging.StatusSince)
+		require.Equal(t, int64(9000), aging.TimeInStatus)
+	})
+
+	t.Run("card without status", func(t *testing.T) {
+		aging := NewCardAging(&Block{ID: "card-id"}, "status-id", 4000, 3000)
+		require.Empty(t, aging.Status)
+		require.Zero(t, aging.TimeInStatus, "clock skew doesn't make the time negative")
+	})
+}
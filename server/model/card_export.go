@@ -0,0 +1,235 @@
+package model
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	// ExportFormatArchive exports the boards to a .boardarchive file.
+	ExportFormatArchive = "archive"
+
+	// ExportFormatCSV exports the cards of a board as a CSV table.
+	ExportFormatCSV = "csv"
+
+	// ExportFormatMarkdown exports the cards of a board as a markdown table.
+	ExportFormatMarkdown = "markdown"
+
+	exportTimeLayout = "January 02, 2006 15:04"
+)
+
+// ExportCardsOptions provides options when exporting the cards of a board
+// as a table.
+type ExportCardsOptions struct {
+	BoardID string
+
+	// Format is ExportFormatCSV or ExportFormatMarkdown.
+	Format string
+
+	// ViewID is the view whose filter, sort order and visible properties
+	// are applied, if set.
+	ViewID string
+
+	// Filter selects the cards to export, on top of the filter of the view.
+	Filter *CardFilter
+}
+
+// IsTableExportFormat returns true for the formats that export the cards
+// of a board as a table.
+func IsTableExportFormat(format string) bool {
+	return format == ExportFormatCSV || format == ExportFormatMarkdown
+}
+
+// CardTable is the cards of a board as a table, with the title of the
+// cards and the display value of their properties.
+type CardTable struct {
+	Header []string
+	Rows   [][]string
+}
+
+// ExportProperties returns the properties exported as columns, in the
+// order of the board: the visible properties of the view, or all of them
+// if there is no view.
+func ExportProperties(schema PropSchema, view *Block) []PropDef {
+	var visible map[string]bool
+	if view != nil {
+		visible = map[string]bool{}
+		ids, _ := view.Fields["visiblePropertyIds"].([]interface{})
+		for _, id := range ids {
+			if s, ok := id.(string); ok {
+				visible[s] = true
+			}
+		}
+	}
+
+	properties := make([]PropDef, 0, len(schema))
+	for _, def := range schema {
+		if visible == nil || visible[def.ID] {
+			properties = append(properties, def)
+		}
+	}
+	sort.Slice(properties, func(i, j int) bool { return properties[i].Index < properties[j].Index })
+	return properties
+}
+
+// NewCardTable builds the table of the cards. Usernames maps the IDs of
+// the users of the person, createdBy and updatedBy properties to their
+// usernames; unknown users are shown by ID.
+func NewCardTable(cards []Block, properties []PropDef, usernames map[string]string) *CardTable {
+	table := &CardTable{
+		Header: make([]string, 0, len(properties)+1),
+		Rows:   make([][]string, 0, len(cards)),
+	}
+
+	table.Header = append(table.Header, "Name")
+	for _, def := range properties {
+		table.Header = append(table.Header, def.Name)
+	}
+
+	for i := range cards {
+		row := make([]string, 0, len(properties)+1)
+		row = append(row, cards[i].Title)
+		for _, def := range properties {
+			row = append(row, exportDisplayValue(&cards[i], def, usernames))
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// WriteCSV writes the table as CSV, with a header row.
+func (t *CardTable) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// WriteMarkdown writes the table as a markdown table.
+func (t *CardTable) WriteMarkdown(w io.Writer) error {
+	separator := make([]string, len(t.Header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+
+	lines := append([][]string{t.Header, separator}, t.Rows...)
+	for _, line := range lines {
+		cells := make([]string, len(line))
+		for i, cell := range line {
+			cells[i] = escapeMarkdownCell(cell)
+		}
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeMarkdownCell keeps the text of a cell on a single line and
+// escapes the characters that would end it.
+func escapeMarkdownCell(text string) string {
+	text = strings.ReplaceAll(text, "\\", "\\\\")
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.ReplaceAll(text, "\r\n", "<br>")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+// exportDisplayValue returns the value of a property of the card as the
+// web app displays it, with multiple values separated by `|`.
+func exportDisplayValue(card *Block, def PropDef, usernames map[string]string) string {
+	username := func(userID string) string {
+		if name := usernames[userID]; name != "" {
+			return name
+		}
+		return userID
+	}
+
+	switch def.Type {
+	case propTypeCreatedTime:
+		return utils.GetTimeForMillis(card.CreateAt).Format(exportTimeLayout)
+	case propTypeUpdatedTime:
+		return utils.GetTimeForMillis(card.UpdateAt).Format(exportTimeLayout)
+	case propTypeCreatedBy:
+		return username(card.CreatedBy)
+	case propTypeUpdatedBy:
+		return username(card.ModifiedBy)
+	}
+
+	switch value := cardPropertyValue(card, def.ID).(type) {
+	case nil:
+		return ""
+	case string:
+		switch def.Type {
+		case propTypeSelect:
+			if option, ok := def.Options[value]; ok {
+				return option.Value
+			}
+		case propTypePerson:
+			return username(value)
+		case propTypeDate:
+			if date, err := def.ParseDate(value); err == nil {
+				return date
+			}
+		}
+		return value
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			s := fmt.Sprintf("%v", item)
+			if option, ok := def.Options[s]; ok {
+				s = option.Value
+			} else if def.Type == propTypeMultiPerson {
+				s = username(s)
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, "|")
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// ExportUserIDs returns the IDs of the users shown in the exported
+// properties of the cards.
+func ExportUserIDs(cards []Block, properties []PropDef) []string {
+	seen := map[string]bool{}
+	ids := []string{}
+	add := func(userID string) {
+		if userID != "" && !seen[userID] {
+			seen[userID] = true
+			ids = append(ids, userID)
+		}
+	}
+
+	for i := range cards {
+		for _, def := range properties {
+			switch def.Type {
+			case propTypeCreatedBy:
+				add(cards[i].CreatedBy)
+			case propTypeUpdatedBy:
+				add(cards[i].ModifiedBy)
+			case propTypePerson, propTypeMultiPerson:
+				switch value := cardPropertyValue(&cards[i], def.ID).(type) {
+				case string:
+					add(value)
+				case []interface{}:
+					for _, item := range value {
+						if s, ok := item.(string); ok {
+							add(s)
+						}
+					}
+				}
+			}
+		}
+	}
+	return ids
+}
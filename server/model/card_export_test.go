@@ -0,0 +1,63 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardTable(t *testing.T) {
+	schema := PropSchema{
+		"status": {ID: "status", Index: 0, Name: "Status", Type: "select", Options: map[string]PropDefOption{"done": {ID: "done", Value: "Done"}}},
+		"tags":   {ID: "tags", Index: 1, Name: "Tags", Type: "multiSelect", Options: map[string]PropDefOption{"red": {ID: "red", Value: "Red"}, "blue": {ID: "blue", Value: "Blue"}}},
+		"owner":  {ID: "owner", Index: 2, Name: "Owner", Type: "person"},
+		"notes":  {ID: "notes", Index: 3, Name: "Notes", Type: "text"},
+	}
+	cards := []Block{
+		{
+			Title: "first",
+			Fields: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"status": "done",
+					"tags":   []interface{}{"red", "blue"},
+					"owner":  "user-1",
+					"notes":  "line 1\nline | 2",
+				},
+			},
+		},
+		{Title: "second, with a comma"},
+	}
+	usernames := map[string]string{"user-1": "alice"}
+
+	t.Run("properties of a view", func(t *testing.T) {
+		view := &Block{Fields: map[string]interface{}{"visiblePropertyIds": []interface{}{"notes", "status"}}}
+		properties := ExportProperties(schema, view)
+		require.Len(t, properties, 2)
+		require.Equal(t, "status", properties[0].ID)
+		require.Equal(t, "notes", properties[1].ID)
+	})
+
+	properties := ExportProperties(schema, nil)
+	require.Len(t, properties, 4)
+	require.Equal(t, []string{"user-1"}, ExportUserIDs(cards, properties))
+
+	table := NewCardTable(cards, properties, usernames)
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, table.WriteCSV(&buf))
+		require.Equal(t, "Name,Status,Tags,Owner,Notes\n"+
+			"first,Done,Red|Blue,alice,\"line 1\nline | 2\"\n"+
+			"\"second, with a comma\",,,,\n", buf.String())
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, table.WriteMarkdown(&buf))
+		require.Equal(t, "| Name | Status | Tags | Owner | Notes |\n"+
+			"| --- | --- | --- | --- | --- |\n"+
+			"| first | Done | Red\\|Blue | alice | line 1<br>line \\| 2 |\n"+
+			"| second, with a comma |  |  |  |  |\n", buf.String())
+	})
+}
@@ -0,0 +1,235 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const (
+	FilterOperationAnd = "and"
+	FilterOperationOr  = "or"
+
+	FilterConditionIncludes    = "includes"
+	FilterConditionNotIncludes = "notIncludes"
+	FilterConditionIsEmpty     = "isEmpty"
+	FilterConditionIsNotEmpty  = "isNotEmpty"
+	FilterConditionIsBefore    = "isBefore"
+	FilterConditionIsAfter     = "isAfter"
+)
+
+// CardFilter is a filter of the cards of a board, in the format the views
+// store in their `filter` field. It is either a group, with an operation
+// and a list of filters, or a clause on the value of a property
+// swagger:model
+type CardFilter struct {
+	// The operation of a group: and or or
+	// required: false
+	Operation string `json:"operation,omitempty"`
+
+	// The filters of a group
+	// required: false
+	Filters []*CardFilter `json:"filters,omitempty"`
+
+	// The property of a clause
+	// required: false
+	PropertyID string `json:"propertyId,omitempty"`
+
+	// The condition of a clause: includes, notIncludes, isEmpty,
+	// isNotEmpty, isBefore or isAfter
+	// required: false
+	Condition string `json:"condition,omitempty"`
+
+	// The values of a clause, option IDs for the select properties, and a
+	// single time in milliseconds for isBefore and isAfter
+	// required: false
+	Values []string `json:"values,omitempty"`
+}
+
+// ParseCardFilter parses a filter from its JSON representation.
+func ParseCardFilter(data string) (*CardFilter, error) {
+	var filter CardFilter
+	if err := json.Unmarshal([]byte(data), &filter); err != nil {
+		return nil, NewCodedError(ErrCodeBadRequest, "invalid filter", map[string]interface{}{"error": err.Error()})
+	}
+	if err := filter.IsValid(); err != nil {
+		return nil, err
+	}
+	return &filter, nil
+}
+
+// CardFilterFromView returns the filter of a view, or nil if the view
+// doesn't filter its cards.
+func CardFilterFromView(view *Block) (*CardFilter, error) {
+	value, ok := view.Fields["filter"]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter CardFilter
+	if err := json.Unmarshal(data, &filter); err != nil {
+		return nil, fmt.Errorf("invalid filter on view %s: %w", view.ID, err)
+	}
+	return &filter, nil
+}
+
+// IsGroup returns true if the filter is a group of filters.
+func (f *CardFilter) IsGroup() bool {
+	return f.Operation != ""
+}
+
+// IsValid checks the operations and conditions of the filter and of the
+// filters it groups.
+func (f *CardFilter) IsValid() error {
+	if f.IsGroup() {
+		if f.Operation != FilterOperationAnd && f.Operation != FilterOperationOr {
+			return NewCodedError(ErrCodeBadRequest, "invalid filter operation", map[string]interface{}{"operation": f.Operation})
+		}
+		for _, filter := range f.Filters {
+			if filter == nil {
+				return NewCodedError(ErrCodeBadRequest, "empty filter", nil)
+			}
+			if err := filter.IsValid(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if f.PropertyID == "" {
+		return NewCodedError(ErrCodeBadRequest, "filter property ID cannot be empty", nil)
+	}
+	switch f.Condition {
+	case FilterConditionIncludes, FilterConditionNotIncludes, FilterConditionIsEmpty, FilterConditionIsNotEmpty:
+		return nil
+	case FilterConditionIsBefore, FilterConditionIsAfter:
+		if len(f.Values) != 1 {
+			return NewCodedError(ErrCodeBadRequest, "a time filter must have a single value", map[string]interface{}{"condition": f.Condition})
+		}
+		if _, err := strconv.ParseInt(f.Values[0], 10, 64); err != nil {
+			return NewCodedError(ErrCodeBadRequest, "invalid filter time", map[string]interface{}{"value": f.Values[0]})
+		}
+		return nil
+	}
+	return NewCodedError(ErrCodeBadRequest, "invalid filter condition", map[string]interface{}{"condition": f.Condition})
+}
+
+// Matches returns true if the card meets the filter, evaluated as the
+// web app does: groups without filters and clauses without values are
+// always met. The schema gives the read-only properties, whose values are
+// taken from the metadata of the card, and may be nil.
+func (f *CardFilter) Matches(card *Block, schema PropSchema) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.IsGroup() {
+		if len(f.Filters) == 0 {
+			return true
+		}
+		if f.Operation == FilterOperationOr {
+			for _, filter := range f.Filters {
+				if filter.Matches(card, schema) {
+					return true
+				}
+			}
+			return false
+		}
+		for _, filter := range f.Filters {
+			if !filter.Matches(card, schema) {
+				return false
+			}
+		}
+		return true
+	}
+
+	value := cardPropertyValue(card, f.PropertyID)
+	if def, ok := schema[f.PropertyID]; ok {
+		if readOnlyValue, ok := ReadOnlyPropertyValue(card, def); ok {
+			value = readOnlyValue
+		}
+	}
+	switch f.Condition {
+	case FilterConditionIncludes:
+		if len(f.Values) == 0 {
+			return true
+		}
+		return filterValueIncludes(value, f.Values)
+	case FilterConditionNotIncludes:
+		if len(f.Values) == 0 {
+			return true
+		}
+		return !filterValueIncludes(value, f.Values)
+	case FilterConditionIsEmpty:
+		return filterValueIsEmpty(value)
+	case FilterConditionIsNotEmpty:
+		return !filterValueIsEmpty(value)
+	case FilterConditionIsBefore, FilterConditionIsAfter:
+		if len(f.Values) == 0 {
+			return true
+		}
+		limit, _ := strconv.ParseInt(f.Values[0], 10, 64)
+		at, ok := filterValueTime(value)
+		if !ok {
+			return false
+		}
+		if f.Condition == FilterConditionIsBefore {
+			return at < limit
+		}
+		return at > limit
+	}
+	return true
+}
+
+// filterValueTime returns the time of a property value in milliseconds,
+// either a time or the start of a date property value.
+func filterValueTime(value interface{}) (int64, bool) {
+	s, _ := value.(string)
+	if s == "" {
+		return 0, false
+	}
+	if at, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return at, true
+	}
+	if from := parseDateFrom(s); from != 0 {
+		return from, true
+	}
+	return 0, false
+}
+
+// filterValueIncludes returns true if the property value, or one of its
+// values for the multiSelect properties, is one of the filter values.
+func filterValueIncludes(value interface{}, values []string) bool {
+	for _, v := range values {
+		switch typed := value.(type) {
+		case []interface{}:
+			for _, item := range typed {
+				if item == v {
+					return true
+				}
+			}
+		case string:
+			if typed == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func filterValueIsEmpty(value interface{}) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case string:
+		return typed == ""
+	case []interface{}:
+		return len(typed) == 0
+	}
+	return false
+}
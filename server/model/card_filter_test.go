@@ -0,0 +1,130 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardFilterMatches(t *testing.T) {
+	card := &Block{
+		CreatedBy:  "user-1",
+		ModifiedBy: "user-2",
+		CreateAt:   1000,
+		UpdateAt:   2000,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"status":  "done",
+				"tags":    []interface{}{"red", "blue"},
+				"empty":   "",
+				"due":     `{"from":1500}`,
+				"creator": "ignored",
+			},
+		},
+	}
+	schema := PropSchema{
+		"creator":  {ID: "creator", Type: "createdBy"},
+		"modifier": {ID: "modifier", Type: "updatedBy"},
+		"created":  {ID: "created", Type: "createdTime"},
+		"updated":  {ID: "updated", Type: "updatedTime"},
+		"due":      {ID: "due", Type: "date"},
+	}
+
+	clause := func(propertyID, condition string, values ...string) *CardFilter {
+		return &CardFilter{PropertyID: propertyID, Condition: condition, Values: values}
+	}
+
+	testCases := []struct {
+		name    string
+		filter  *CardFilter
+		matches bool
+	}{
+		{"no filter", nil, true},
+		{"empty group", &CardFilter{Operation: FilterOperationAnd}, true},
+		{"includes", clause("status", FilterConditionIncludes, "todo", "done"), true},
+		{"does not include", clause("status", FilterConditionIncludes, "todo"), false},
+		{"includes without values", clause("status", FilterConditionIncludes), true},
+		{"includes in a list", clause("tags", FilterConditionIncludes, "blue"), true},
+		{"not includes", clause("tags", FilterConditionNotIncludes, "red"), false},
+		{"not includes a missing value", clause("missing", FilterConditionNotIncludes, "red"), true},
+		{"is empty", clause("empty", FilterConditionIsEmpty), true},
+		{"missing is empty", clause("missing", FilterConditionIsEmpty), true},
+		{"is not empty", clause("tags", FilterConditionIsNotEmpty), true},
+		{"created by", clause("creator", FilterConditionIncludes, "user-1"), true},
+		{"not created by", clause("creator", FilterConditionNotIncludes, "user-1"), false},
+		{"modified by", clause("modifier", FilterConditionIncludes, "user-1"), false},
+		{"created before", clause("created", FilterConditionIsBefore, "1500"), true},
+		{"updated before", clause("updated", FilterConditionIsBefore, "1500"), false},
+		{"updated after", clause("updated", FilterConditionIsAfter, "1500"), true},
+		{"date after", clause("due", FilterConditionIsAfter, "1000"), true},
+		{"date before", clause("due", FilterConditionIsBefore, "1000"), false},
+		{"missing date before", clause("missing", FilterConditionIsBefore, "1000"), false},
+		{"and", &CardFilter{Operation: FilterOperationAnd, Filters: []*CardFilter{
+			clause("status", FilterConditionIncludes, "done"),
+			clause("tags", FilterConditionIncludes, "green"),
+		}}, false},
+		{"or", &CardFilter{Operation: FilterOperationOr, Filters: []*CardFilter{
+			clause("status", FilterConditionIncludes, "todo"),
+			clause("tags", FilterConditionIncludes, "red"),
+		}}, true},
+		{"nested groups", &CardFilter{Operation: FilterOperationAnd, Filters: []*CardFilter{
+			clause("status", FilterConditionIsNotEmpty),
+			{Operation: FilterOperationOr, Filters: []*CardFilter{
+				clause("tags", FilterConditionIncludes, "green"),
+				clause("empty", FilterConditionIsEmpty),
+			}},
+		}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.matches, tc.filter.Matches(card, schema))
+		})
+	}
+}
+
+func TestParseCardFilter(t *testing.T) {
+	t.Run("view filter", func(t *testing.T) {
+		filter, err := ParseCardFilter(`{"operation":"or","filters":[{"propertyId":"status","condition":"includes","values":["done"]},{"operation":"and","filters":[]}]}`)
+		require.NoError(t, err)
+		require.True(t, filter.IsGroup())
+		require.Len(t, filter.Filters, 2)
+		require.False(t, filter.Filters[0].IsGroup())
+		require.True(t, filter.Filters[1].IsGroup())
+	})
+
+	testCases := map[string]string{
+		"invalid JSON":      `{"operation":`,
+		"invalid operation": `{"operation":"xor","filters":[]}`,
+		"invalid condition": `{"propertyId":"status","condition":"startsWith"}`,
+		"missing property":  `{"operation":"and","filters":[{"condition":"isEmpty"}]}`,
+		"missing time":      `{"propertyId":"created","condition":"isBefore"}`,
+		"invalid time":      `{"propertyId":"created","condition":"isAfter","values":["yesterday"]}`,
+	}
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseCardFilter(data)
+			ce, ok := AsCodedError(err)
+			require.True(t, ok)
+			require.Equal(t, ErrCodeBadRequest, ce.Code)
+		})
+	}
+}
+
+func TestCardFilterFromView(t *testing.T) {
+	view := &Block{Fields: map[string]interface{}{}}
+	filter, err := CardFilterFromView(view)
+	require.NoError(t, err)
+	require.Nil(t, filter)
+
+	view.Fields["filter"] = map[string]interface{}{
+		"operation": "and",
+		"filters": []interface{}{
+			map[string]interface{}{"propertyId": "status", "condition": "isEmpty", "values": []interface{}{}},
+		},
+	}
+	filter, err = CardFilterFromView(view)
+	require.NoError(t, err)
+	require.Equal(t, FilterOperationAnd, filter.Operation)
+	require.Equal(t, "status", filter.Filters[0].PropertyID)
+}
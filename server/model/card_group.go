@@ -0,0 +1,27 @@
+package model
+
+// CardGroup is the number of cards of a board whose grouping property
+// takes a value, as shown in the header of a kanban lane
+// swagger:model
+type CardGroup struct {
+	// The value of the grouping property, the option ID for select
+	// properties, or empty for the cards without a value
+	// required: true
+	Value string `json:"value"`
+
+	// The number of cards
+	// required: true
+	Count int64 `json:"count"`
+
+	// The sum of the numeric property over the cards, if requested
+	// required: false
+	Sum *float64 `json:"sum,omitempty"`
+
+	// The maximum number of cards of the lane in the requested view, if it has one
+	// required: false
+	Limit int64 `json:"limit,omitempty"`
+
+	// The number of cards relative to the limit of the lane: under, at or over
+	// required: false
+	LimitStatus string `json:"limitStatus,omitempty"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var boardKeyRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
+
+// IsValidBoardKey returns true if the key can reference the cards of a
+// board, as in PROJ-123: 2 to 10 uppercase letters and digits, starting
+// with a letter.
+func IsValidBoardKey(key string) bool {
+	return boardKeyRegexp.MatchString(key)
+}
+
+// BoardKeyRequest sets the key of a board
+// swagger:model
+type BoardKeyRequest struct {
+	// The key the cards of the board are referenced with
+	// required: true
+	Key string `json:"key"`
+}
+
+// CardPermalink is a card resolved from its human readable reference
+// swagger:model
+type CardPermalink struct {
+	// The reference of the card, e.g. PROJ-123
+	// required: true
+	Reference string `json:"reference"`
+
+	// The number of the card on its board
+	// required: true
+	Number int64 `json:"number"`
+
+	// The canonical URL of the card
+	// required: true
+	URL string `json:"url"`
+
+	// The card
+	// required: true
+	Card *Block `json:"card"`
+}
+
+// CardReference returns the human readable reference of a card.
+func CardReference(boardKey string, number int64) string {
+	return fmt.Sprintf("%s-%d", boardKey, number)
+}
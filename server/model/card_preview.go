@@ -0,0 +1,103 @@
+package model
+
+import (
+	"sort"
+)
+
+// CardPreview is the summary of a card shown when a link to the card is
+// posted in a channel
+// swagger:model
+type CardPreview struct {
+	// The ID of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The ID of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the team of the board
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The icon of the card
+	// required: false
+	Icon string `json:"icon,omitempty"`
+
+	// The title of the board of the card
+	// required: true
+	BoardTitle string `json:"boardTitle"`
+
+	// The name of the status property of the card
+	// required: false
+	StatusName string `json:"statusName,omitempty"`
+
+	// The status of the card
+	// required: false
+	Status string `json:"status,omitempty"`
+
+	// The color of the status option of the card
+	// required: false
+	StatusColor string `json:"statusColor,omitempty"`
+
+	// The time the card took its current status in miliseconds since the
+	// current epoch
+	// required: false
+	StatusSince int64 `json:"statusSince,omitempty"`
+
+	// The IDs of the users the card is assigned to
+	// required: true
+	Assignees []string `json:"assignees"`
+
+	// The ID of the user that created the card
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The last modified time of the card in miliseconds
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// NewCardPreview returns the preview of the card, with the status and
+// the assignees resolved from the card properties of the board.
+func NewCardPreview(card *Block, board *Board) (*CardPreview, error) {
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &CardPreview{
+		CardID:     card.ID,
+		BoardID:    board.ID,
+		TeamID:     board.TeamID,
+		Title:      card.Title,
+		BoardTitle: board.Title,
+		CreatedBy:  card.CreatedBy,
+		UpdateAt:   card.UpdateAt,
+		Assignees:  []string{},
+	}
+	preview.Icon, _ = card.Fields["icon"].(string)
+
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	if def, ok := schema.StatusProperty(board); ok {
+		if value, ok := props[def.ID].(string); ok && value != "" {
+			preview.StatusName = def.Name
+			preview.Status = value
+			if option, ok := def.Options[value]; ok {
+				preview.Status = option.Value
+				preview.StatusColor = option.Color
+			}
+		}
+	}
+
+	for userID := range CardAssignees(card, schema) {
+		preview.Assignees = append(preview.Assignees, userID)
+	}
+	sort.Strings(preview.Assignees)
+
+	return preview, nil
+}
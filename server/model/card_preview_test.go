@@ -0,0 +1,58 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCardPreview(t *testing.T) {
+	board := &Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		Title:  "Sprint board",
+		CardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": "person"},
+			{"id": "reviewer", "name": "Reviewer", "type": "person"},
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "opt-todo", "value": "To Do", "color": "propColorRed"},
+			}},
+		},
+	}
+
+	t.Run("resolves the status and the assignees", func(t *testing.T) {
+		card := &Block{
+			ID:        "card-id",
+			BoardID:   board.ID,
+			Title:     "Fix the login",
+			CreatedBy: "creator-id",
+			UpdateAt:  1000,
+			Fields: map[string]interface{}{
+				"icon": "🐛",
+				"properties": map[string]interface{}{
+					"assignee": "user-2",
+					"reviewer": []interface{}{"user-1", "user-2"},
+					"status":   "opt-todo",
+				},
+			},
+		}
+
+		preview, err := NewCardPreview(card, board)
+		require.NoError(t, err)
+		require.Equal(t, "Fix the login", preview.Title)
+		require.Equal(t, "🐛", preview.Icon)
+		require.Equal(t, "Sprint board", preview.BoardTitle)
+		require.Equal(t, "team-id", preview.TeamID)
+		require.Equal(t, "Status", preview.StatusName)
+		require.Equal(t, "To Do", preview.Status)
+		require.Equal(t, "propColorRed", preview.StatusColor)
+		require.Equal(t, []string{"user-1", "user-2"}, preview.Assignees)
+	})
+
+	t.Run("cards without properties", func(t *testing.T) {
+		preview, err := NewCardPreview(&Block{ID: "card-id", BoardID: board.ID, Title: "Empty"}, board)
+		require.NoError(t, err)
+		require.Empty(t, preview.Status)
+		require.Empty(t, preview.Assignees)
+	})
+}
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// BoardPropertyDetectDuplicateCards is the board property that enables
+	// the detection of duplicate cards on creation.
+	BoardPropertyDetectDuplicateCards = "detectDuplicateCards"
+
+	// DuplicateCardThreshold is the minimum title similarity for an
+	// existing card to be suggested as a duplicate.
+	DuplicateCardThreshold = 0.6
+
+	// MaxDuplicateCardSuggestions is the maximum number of suggestions
+	// returned for each created card.
+	MaxDuplicateCardSuggestions = 5
+)
+
+// DuplicateCardSuggestion is an existing card whose title closely
+// matches the title of a newly created card
+// swagger:model
+type DuplicateCardSuggestion struct {
+	// The ID of the existing card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The title of the existing card
+	// required: true
+	Title string `json:"title"`
+
+	// The similarity of the titles, between 0 and 1
+	// required: true
+	Similarity float64 `json:"similarity"`
+}
+
+// BlocksWithDuplicates is the response of a block creation when
+// duplicate detection is requested
+// swagger:model
+type BlocksWithDuplicates struct {
+	// The inserted blocks
+	// required: true
+	Blocks []Block `json:"blocks"`
+
+	// The duplicate suggestions, indexed by the ID of the created card
+	// required: true
+	Duplicates map[string][]DuplicateCardSuggestion `json:"duplicates"`
+}
+
+// DetectsDuplicateCards returns true if the board has opted in to the
+// duplicate card detection.
+func (b *Board) DetectsDuplicateCards() bool {
+	enabled, _ := b.Properties[BoardPropertyDetectDuplicateCards].(bool)
+	return enabled
+}
+
+// TitleSimilarity returns the trigram similarity of two titles, from 0
+// for unrelated titles to 1 for titles that are equal once case,
+// punctuation and spacing are ignored.
+func TitleSimilarity(a, b string) float64 {
+	trigramsA := trigrams(a)
+	trigramsB := trigrams(b)
+	if len(trigramsA) == 0 || len(trigramsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for trigram := range trigramsA {
+		if trigramsB[trigram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(trigramsA)+len(trigramsB)-shared)
+}
+
+// FindDuplicateCards returns the cards whose title is similar to the
+// given one, sorted by decreasing similarity.
+func FindDuplicateCards(title string, cards []Block) []DuplicateCardSuggestion {
+	suggestions := []DuplicateCardSuggestion{}
+	for _, card := range cards {
+		similarity := TitleSimilarity(title, card.Title)
+		if similarity < DuplicateCardThreshold {
+			continue
+		}
+		suggestions = append(suggestions, DuplicateCardSuggestion{
+			CardID:     card.ID,
+			Title:      card.Title,
+			Similarity: similarity,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Similarity > suggestions[j].Similarity
+	})
+	if len(suggestions) > MaxDuplicateCardSuggestions {
+		suggestions = suggestions[:MaxDuplicateCardSuggestions]
+	}
+	return suggestions
+}
+
+// trigrams returns the set of trigrams of the normalized words of a
+// text, padding each word the same way pg_trgm does.
+func trigrams(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	result := map[string]bool{}
+	for _, word := range words {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			result[string(runes[i:i+3])] = true
+		}
+	}
+	return result
+}
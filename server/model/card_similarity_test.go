@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTitleSimilarity(t *testing.T) {
+	t.Run("case, punctuation and spacing are ignored", func(t *testing.T) {
+		require.Equal(t, 1.0, TitleSimilarity("Login button broken", "login-button  BROKEN!"))
+	})
+
+	t.Run("close titles are similar", func(t *testing.T) {
+		require.GreaterOrEqual(t, TitleSimilarity("Login button broken", "Login button is broken"), DuplicateCardThreshold)
+	})
+
+	t.Run("unrelated titles are not similar", func(t *testing.T) {
+		require.Less(t, TitleSimilarity("Login button broken", "Export fails on large boards"), DuplicateCardThreshold)
+	})
+
+	t.Run("empty titles are never similar", func(t *testing.T) {
+		require.Equal(t, 0.0, TitleSimilarity("", ""))
+		require.Equal(t, 0.0, TitleSimilarity("title", "!!"))
+	})
+}
+
+func TestFindDuplicateCards(t *testing.T) {
+	cards := []Block{
+		{ID: "card-1", Type: TypeCard, Title: "Export fails on large boards"},
+		{ID: "card-2", Type: TypeCard, Title: "Login button is broken"},
+		{ID: "card-3", Type: TypeCard, Title: "login button broken"},
+	}
+
+	suggestions := FindDuplicateCards("Login button broken", cards)
+	require.Len(t, suggestions, 2)
+	require.Equal(t, "card-3", suggestions[0].CardID)
+	require.Equal(t, "card-2", suggestions[1].CardID)
+	require.Equal(t, "Login button is broken", suggestions[1].Title)
+
+	require.Empty(t, FindDuplicateCards("Something else entirely", cards))
+}
+
+func TestBoardDetectsDuplicateCards(t *testing.T) {
+	require.False(t, (&Board{}).DetectsDuplicateCards())
+	require.False(t, (&Board{Properties: map[string]interface{}{BoardPropertyDetectDuplicateCards: "true"}}).DetectsDuplicateCards())
+	require.True(t, (&Board{Properties: map[string]interface{}{BoardPropertyDetectDuplicateCards: true}}).DetectsDuplicateCards())
+}
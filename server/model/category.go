@@ -36,6 +36,10 @@ type Category struct {
 	// The deleted time in miliseconds since the current epoch. Set to indicate this category is deleted
 	// required: false
 	DeleteAt int64 `json:"deleteAt"`
+
+	// The position of this category in the sidebar, starting at 0
+	// required: false
+	SortOrder int `json:"sortOrder"`
 }
 
 func (c *Category) Hydrate() {
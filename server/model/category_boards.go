@@ -14,3 +14,11 @@ type BoardCategoryWebsocketData struct {
 	BoardID    string `json:"boardID"`
 	CategoryID string `json:"categoryID"`
 }
+
+// CategoryBoardsUpdate is the list of the boards moved into a category
+// swagger:model
+type CategoryBoardsUpdate struct {
+	// The IDs of the boards to move
+	// required: true
+	BoardIDs []string `json:"boardIDs"`
+}
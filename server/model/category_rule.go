@@ -0,0 +1,111 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	// MaxCategoryRules is the number of rules a user can have on a team.
+	MaxCategoryRules = 50
+
+	// MaxCategoryRulePatternLength is the length of the longest title
+	// pattern of a rule.
+	MaxCategoryRulePatternLength = 255
+)
+
+// CategoryRule moves the boards whose title matches a pattern into a
+// category of the user, when they are created or become visible to them
+// swagger:model
+type CategoryRule struct {
+	// The id for this rule
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the user this rule belongs to
+	// required: true
+	UserID string `json:"userID"`
+
+	// The id of the team this rule applies on
+	// required: true
+	TeamID string `json:"teamID"`
+
+	// The id of the category the boards are moved into
+	// required: true
+	CategoryID string `json:"categoryID"`
+
+	// The pattern the board titles are matched against, regardless of
+	// case. A * matches any text, as in Sprint*
+	// required: true
+	TitlePattern string `json:"titlePattern"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+func (r *CategoryRule) Hydrate() {
+	r.ID = utils.NewID(utils.IDTypeNone)
+	r.CreateAt = utils.GetMillis()
+}
+
+func (r *CategoryRule) IsValid() error {
+	if strings.TrimSpace(r.CategoryID) == "" {
+		return NewCodedError(ErrCodeBadRequest, "category rule category ID cannot be empty", nil)
+	}
+
+	if strings.Trim(r.TitlePattern, " *") == "" {
+		return NewCodedError(ErrCodeBadRequest, "category rule title pattern cannot be empty", nil)
+	}
+
+	if utf8.RuneCountInString(r.TitlePattern) > MaxCategoryRulePatternLength {
+		return NewCodedError(ErrCodeBadRequest, "category rule title pattern is too long", map[string]interface{}{"maxLength": MaxCategoryRulePatternLength})
+	}
+
+	return nil
+}
+
+// Matches returns true if the title matches the pattern of the rule,
+// regardless of case.
+func (r *CategoryRule) Matches(title string) bool {
+	return matchTitlePattern(strings.ToLower(strings.TrimSpace(r.TitlePattern)), strings.ToLower(strings.TrimSpace(title)))
+}
+
+// matchTitlePattern matches the text against the pattern, where each *
+// stands for any text.
+func matchTitlePattern(pattern, text string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == text
+	}
+
+	// the text must start with the first part, end with the last one,
+	// and contain the ones in between in order
+	if !strings.HasPrefix(text, parts[0]) {
+		return false
+	}
+	text = text[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(text, part)
+		if i < 0 {
+			return false
+		}
+		text = text[i+len(part):]
+	}
+	return len(text) >= len(last) && strings.HasSuffix(text, last)
+}
+
+// MatchCategoryRule returns the first of the rules that matches the
+// title, or nil if none does.
+func MatchCategoryRule(rules []*CategoryRule, title string) *CategoryRule {
+	for _, rule := range rules {
+		if rule.Matches(title) {
+			return rule
+		}
+	}
+	return nil
+}
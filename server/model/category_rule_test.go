@@ -0,0 +1,49 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryRuleMatches(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		title   string
+		matches bool
+	}{
+		{"Sprint*", "Sprint 12", true},
+		{"Sprint*", "sprint", true},
+		{"Sprint*", "Next sprint", false},
+		{"*Retro*", "Q3 retro notes", true},
+		{"*Retro", "Retro notes", false},
+		{"Roadmap", " roadmap ", true},
+		{"Roadmap", "Roadmap 2023", false},
+		{"Q*-*plan", "Q3 - launch plan", true},
+		{"Q*-*plan", "Q3 launch plan", false},
+		{"a*a", "a", false},
+	}
+
+	for _, tc := range testCases {
+		rule := &CategoryRule{TitlePattern: tc.pattern}
+		require.Equal(t, tc.matches, rule.Matches(tc.title), "pattern %q, title %q", tc.pattern, tc.title)
+	}
+}
+
+func TestMatchCategoryRule(t *testing.T) {
+	sprints := &CategoryRule{CategoryID: "sprints", TitlePattern: "Sprint*"}
+	all := &CategoryRule{CategoryID: "all", TitlePattern: "*Sprint*"}
+	rules := []*CategoryRule{sprints, all}
+
+	require.Equal(t, sprints, MatchCategoryRule(rules, "Sprint 12"), "the first matching rule wins")
+	require.Equal(t, all, MatchCategoryRule(rules, "Next sprint"))
+	require.Nil(t, MatchCategoryRule(rules, "Roadmap"))
+}
+
+func TestCategoryRuleIsValid(t *testing.T) {
+	require.NoError(t, (&CategoryRule{CategoryID: "category-id", TitlePattern: "Sprint*"}).IsValid())
+	require.Error(t, (&CategoryRule{TitlePattern: "Sprint*"}).IsValid())
+	require.Error(t, (&CategoryRule{CategoryID: "category-id", TitlePattern: " * "}).IsValid())
+	require.Error(t, (&CategoryRule{CategoryID: "category-id", TitlePattern: strings.Repeat("a", MaxCategoryRulePatternLength+1)}).IsValid())
+}
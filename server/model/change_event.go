@@ -0,0 +1,54 @@
+package model
+
+// ChangeEventType is the type of the changes published to the event bus.
+type ChangeEventType string
+
+const (
+	ChangeEventBlockChanged  ChangeEventType = "block_changed"
+	ChangeEventBlockDeleted  ChangeEventType = "block_deleted"
+	ChangeEventBoardChanged  ChangeEventType = "board_changed"
+	ChangeEventBoardDeleted  ChangeEventType = "board_deleted"
+	ChangeEventMemberChanged ChangeEventType = "member_changed"
+	ChangeEventMemberDeleted ChangeEventType = "member_deleted"
+)
+
+// ChangeEvent is a board, block or member change published to the event
+// bus. Only the fields relevant to the type of the event are set
+// swagger:model
+type ChangeEvent struct {
+	// The type of the change
+	// required: true
+	Type ChangeEventType `json:"type"`
+
+	// The ID of the team of the board
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the changed or deleted block
+	// required: false
+	BlockID string `json:"blockId,omitempty"`
+
+	// The ID of the changed or removed member
+	// required: false
+	UserID string `json:"userId,omitempty"`
+
+	// The changed block
+	// required: false
+	Block *Block `json:"block,omitempty"`
+
+	// The changed board
+	// required: false
+	Board *Board `json:"board,omitempty"`
+
+	// The changed member
+	// required: false
+	Member *BoardMember `json:"member,omitempty"`
+
+	// The time of the change, in miliseconds
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
@@ -1,7 +1,8 @@
 package model
 
 const (
-	SqliteDBType   = "sqlite3"
-	PostgresDBType = "postgres"
-	MysqlDBType    = "mysql"
+	SqliteDBType    = "sqlite3"
+	PostgresDBType  = "postgres"
+	MysqlDBType     = "mysql"
+	CockroachDBType = "cockroach"
 )
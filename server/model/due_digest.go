@@ -0,0 +1,83 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// DefaultDueDigestHour is the hour of the day the due digest is sent at
+// when the user hasn't chosen one.
+const DefaultDueDigestHour = 9
+
+// DueDigestSettings are the settings of the daily message listing the
+// cards assigned to a user that are due today or overdue
+// swagger:model
+type DueDigestSettings struct {
+	// The id of the user receiving the digest
+	// required: true
+	UserID string `json:"userId"`
+
+	// Indicates if the digest is sent to the user
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The hour of the day, from 0 to 23 in the timezone of the user, the digest is sent at
+	// required: true
+	Hour int `json:"hour"`
+
+	// The last time the digest was sent in miliseconds since the current epoch
+	// required: false
+	LastSentAt int64 `json:"lastSentAt"`
+}
+
+// NewDueDigestSettings returns the settings of a user that hasn't
+// enabled the digest.
+func NewDueDigestSettings(userID string) *DueDigestSettings {
+	return &DueDigestSettings{
+		UserID: userID,
+		Hour:   DefaultDueDigestHour,
+	}
+}
+
+func (s *DueDigestSettings) IsValid() error {
+	if s.Hour < 0 || s.Hour > 23 {
+		return NewCodedError(ErrCodeBadRequest, "due digest hour must be between 0 and 23", map[string]interface{}{"hour": s.Hour})
+	}
+	return nil
+}
+
+// IsDue returns true if the digest is enabled and wasn't sent yet since
+// its hour of today in the location of the user.
+func (s *DueDigestSettings) IsDue(now time.Time, loc *time.Location) bool {
+	if !s.Enabled {
+		return false
+	}
+
+	sendAt := utils.StartOfDay(now, loc).Add(time.Duration(s.Hour) * time.Hour)
+	if now.Before(sendAt) {
+		return false
+	}
+	return s.LastSentAt < utils.GetMillisForTime(sendAt)
+}
+
+// DueDigestCard is a card of the due digest, with the details needed to
+// link to it from the message
+type DueDigestCard struct {
+	CardID     string
+	Title      string
+	BoardID    string
+	BoardTitle string
+	TeamID     string
+
+	// DueDate is the start of the first date property of the card, in
+	// miliseconds
+	DueDate int64
+
+	// DueDay is the day of the due date in the location of the user, as
+	// YYYY-MM-DD
+	DueDay string
+
+	// Overdue is true if the card was due before today
+	Overdue bool
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestDueDigestSettingsIsDue(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	sendAt := time.Date(2022, 3, 14, 9, 0, 0, 0, loc)
+
+	testCases := []struct {
+		name     string
+		settings DueDigestSettings
+		now      time.Time
+		due      bool
+	}{
+		{"disabled", DueDigestSettings{Hour: 9}, sendAt.Add(time.Hour), false},
+		{"before the hour", DueDigestSettings{Enabled: true, Hour: 9}, sendAt.Add(-time.Minute), false},
+		{"never sent", DueDigestSettings{Enabled: true, Hour: 9}, sendAt, true},
+		{"sent yesterday", DueDigestSettings{Enabled: true, Hour: 9, LastSentAt: utils.GetMillisForTime(sendAt.AddDate(0, 0, -1))}, sendAt.Add(time.Hour), true},
+		{"already sent today", DueDigestSettings{Enabled: true, Hour: 9, LastSentAt: utils.GetMillisForTime(sendAt.Add(time.Minute))}, sendAt.Add(time.Hour), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.due, tc.settings.IsDue(tc.now, loc))
+		})
+	}
+}
+
+func TestDueDigestSettingsIsValid(t *testing.T) {
+	require.NoError(t, (&DueDigestSettings{Hour: 0}).IsValid())
+	require.NoError(t, (&DueDigestSettings{Hour: 23}).IsValid())
+	require.Error(t, (&DueDigestSettings{Hour: -1}).IsValid())
+	require.Error(t, (&DueDigestSettings{Hour: 24}).IsValid())
+}
@@ -0,0 +1,96 @@
+package model
+
+// DuplicateCardOptions are the options of a card duplication
+// swagger:model
+type DuplicateCardOptions struct {
+	// Copy the comments of the card
+	// required: false
+	IncludeComments bool `json:"includeComments"`
+
+	// Copy the images attached to the card
+	// required: false
+	IncludeAttachments bool `json:"includeAttachments"`
+
+	// Keep the checked state of the card checkboxes; when false,
+	// every checkbox of the copy is unchecked
+	// required: false
+	KeepChecklistState bool `json:"keepChecklistState"`
+}
+
+// FilterBlocks returns the blocks of a card subtree that are copied
+// according to the options, with the card as the first block. The
+// returned blocks don't share their fields with the original blocks.
+func (o DuplicateCardOptions) FilterBlocks(cardID string, blocks []Block) []Block {
+	var card *Block
+	skipped := map[string]bool{}
+	filtered := make([]Block, 0, len(blocks))
+
+	for _, block := range blocks {
+		block.Fields = copyFields(block.Fields)
+
+		switch {
+		case block.ID == cardID:
+			cardCopy := block
+			card = &cardCopy
+			continue
+		case block.Type == TypeComment && !o.IncludeComments,
+			block.Type == TypeImage && !o.IncludeAttachments:
+			skipped[block.ID] = true
+			continue
+		case block.Type == TypeCheckbox && !o.KeepChecklistState:
+			block.Fields["value"] = false
+		}
+		filtered = append(filtered, block)
+	}
+
+	if card == nil {
+		return filtered
+	}
+
+	if contentOrder, ok := card.Fields["contentOrder"].([]interface{}); ok {
+		card.Fields["contentOrder"] = removeFromContentOrder(contentOrder, skipped)
+	}
+	return append([]Block{*card}, filtered...)
+}
+
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	newFields := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		if order, ok := v.([]interface{}); ok {
+			v = copyContentOrder(order)
+		}
+		newFields[k] = v
+	}
+	return newFields
+}
+
+func copyContentOrder(order []interface{}) []interface{} {
+	newOrder := make([]interface{}, len(order))
+	for i, v := range order {
+		if column, ok := v.([]interface{}); ok {
+			v = copyContentOrder(column)
+		}
+		newOrder[i] = v
+	}
+	return newOrder
+}
+
+func removeFromContentOrder(order []interface{}, removed map[string]bool) []interface{} {
+	newOrder := make([]interface{}, 0, len(order))
+	for _, v := range order {
+		switch id := v.(type) {
+		case string:
+			if removed[id] {
+				continue
+			}
+		case []interface{}:
+			column := removeFromContentOrder(id, removed)
+			if len(column) == 0 {
+				continue
+			}
+			v = column
+		}
+		newOrder = append(newOrder, v)
+	}
+	return newOrder
+}
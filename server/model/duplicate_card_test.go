@@ -0,0 +1,46 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateCardOptionsFilterBlocks(t *testing.T) {
+	blocks := []Block{
+		{ID: "comment", ParentID: "card", Type: TypeComment},
+		{ID: "card", Type: TypeCard, Fields: map[string]interface{}{
+			"contentOrder": []interface{}{"text", []interface{}{"image", "checkbox"}},
+		}},
+		{ID: "text", ParentID: "card", Type: TypeText},
+		{ID: "image", ParentID: "card", Type: TypeImage},
+		{ID: "checkbox", ParentID: "card", Type: TypeCheckbox, Fields: map[string]interface{}{"value": true}},
+	}
+
+	blockIDs := func(blocks []Block) []string {
+		ids := make([]string, len(blocks))
+		for i, b := range blocks {
+			ids[i] = b.ID
+		}
+		return ids
+	}
+
+	t.Run("default options", func(t *testing.T) {
+		filtered := DuplicateCardOptions{}.FilterBlocks("card", blocks)
+		require.Equal(t, []string{"card", "text", "checkbox"}, blockIDs(filtered))
+		require.Equal(t, []interface{}{"text", []interface{}{"checkbox"}}, filtered[0].Fields["contentOrder"])
+		require.Equal(t, false, filtered[2].Fields["value"])
+
+		// the original blocks are left untouched
+		require.Equal(t, true, blocks[4].Fields["value"])
+		require.Len(t, blocks[1].Fields["contentOrder"], 2)
+	})
+
+	t.Run("all options", func(t *testing.T) {
+		opts := DuplicateCardOptions{IncludeComments: true, IncludeAttachments: true, KeepChecklistState: true}
+		filtered := opts.FilterBlocks("card", blocks)
+		require.Equal(t, []string{"card", "comment", "text", "image", "checkbox"}, blockIDs(filtered))
+		require.Equal(t, blocks[1].Fields["contentOrder"], filtered[0].Fields["contentOrder"])
+		require.Equal(t, true, filtered[4].Fields["value"])
+	})
+}
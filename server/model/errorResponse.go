@@ -1,5 +1,32 @@
 package model
 
+import (
+	"errors"
+	"net/http"
+)
+
+// Machine readable error codes returned by the API.
+const (
+	ErrCodeBadRequest              = "bad_request"
+	ErrCodeUnauthorized            = "unauthorized"
+	ErrCodeInsufficientPermissions = "insufficient_permissions"
+	ErrCodeInsufficientLicense     = "insufficient_license"
+	ErrCodeNotFound                = "not_found"
+	ErrCodeBoardNotFound           = "board_not_found"
+	ErrCodeBlockNotFound           = "block_not_found"
+	ErrCodeCategoryNotFound        = "category_not_found"
+	ErrCodeTeamNotFound            = "team_not_found"
+	ErrCodeBoardKeyTaken           = "board_key_taken"
+	ErrCodeContentRejected         = "content_rejected"
+	ErrCodeVersionConflict         = "version_conflict"
+	ErrCodeWIPLimitExceeded        = "wip_limit_exceeded"
+	ErrCodeBoardReadOnly           = "board_read_only"
+	ErrCodeBlockLocked             = "block_locked"
+	ErrCodeRequestTooLarge         = "request_too_large"
+	ErrCodeNotImplemented          = "not_implemented"
+	ErrCodeInternal                = "internal_error"
+)
+
 // ErrorResponse is an error response
 // swagger:model
 type ErrorResponse struct {
@@ -10,4 +37,170 @@ type ErrorResponse struct {
 	// The error code
 	// required: false
 	ErrorCode int `json:"errorCode"`
+
+	// The machine readable error code, e.g. board_not_found
+	// required: false
+	Code string `json:"code,omitempty"`
+
+	// Additional parameters describing the error
+	// required: false
+	Params map[string]interface{} `json:"params,omitempty"`
+
+	// The ID of the request that caused the error
+	// required: false
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// CodedError is an error that carries a machine readable code and
+// optional parameters to be returned to the API clients.
+type CodedError struct {
+	Code    string
+	Message string
+	Params  map[string]interface{}
+}
+
+// NewCodedError creates a new CodedError.
+func NewCodedError(code, message string, params map[string]interface{}) *CodedError {
+	return &CodedError{
+		Code:    code,
+		Message: message,
+		Params:  params,
+	}
+}
+
+// NewErrBoardNotFound creates an error for a board that doesn't exist or
+// that is not accessible from the requested path.
+func NewErrBoardNotFound(boardID string) *CodedError {
+	return NewCodedError(ErrCodeBoardNotFound, "board not found", map[string]interface{}{"boardId": boardID})
+}
+
+// IsErrBoardNotFound returns true if the error is a board that doesn't
+// exist or that is not accessible.
+func IsErrBoardNotFound(err error) bool {
+	ce, ok := AsCodedError(err)
+	return ok && ce.Code == ErrCodeBoardNotFound
+}
+
+// NewErrBlockNotFound creates an error for a block that doesn't exist or
+// that is not accessible from the requested path.
+func NewErrBlockNotFound(blockID string) *CodedError {
+	return NewCodedError(ErrCodeBlockNotFound, "block not found", map[string]interface{}{"blockId": blockID})
+}
+
+// NewErrTeamNotFound creates an error for a team that doesn't exist.
+func NewErrTeamNotFound(teamID string) *CodedError {
+	return NewCodedError(ErrCodeTeamNotFound, "team not found", map[string]interface{}{"teamId": teamID})
+}
+
+// NewErrBoardKeyTaken creates an error for a board key already used by
+// another board.
+func NewErrBoardKeyTaken(key string) *CodedError {
+	return NewCodedError(ErrCodeBoardKeyTaken, "board key already in use", map[string]interface{}{"key": key})
+}
+
+// NewErrContentRejected creates an error for a text refused by a content
+// filter.
+func NewErrContentRejected(filter, reason string) *CodedError {
+	return NewCodedError(ErrCodeContentRejected, reason, map[string]interface{}{"filter": filter})
+}
+
+// IsErrContentRejected returns true if the error is a text refused by a
+// content filter.
+func IsErrContentRejected(err error) bool {
+	ce, ok := AsCodedError(err)
+	return ok && ce.Code == ErrCodeContentRejected
+}
+
+// IsErrBadRequest returns true if the error is a request with invalid
+// values.
+func IsErrBadRequest(err error) bool {
+	ce, ok := AsCodedError(err)
+	return ok && ce.Code == ErrCodeBadRequest
+}
+
+// NewErrVersionConflict creates an error for an update based on a stale
+// version of an entity.
+func NewErrVersionConflict(entityID string) *CodedError {
+	return NewCodedError(ErrCodeVersionConflict, "version conflict", map[string]interface{}{"id": entityID})
+}
+
+// IsErrVersionConflict returns true if the error is an update based on a
+// stale version of an entity.
+func IsErrVersionConflict(err error) bool {
+	ce, ok := AsCodedError(err)
+	return ok && ce.Code == ErrCodeVersionConflict
+}
+
+// NewErrBoardReadOnly creates an error for a change of a board frozen by
+// its admins.
+func NewErrBoardReadOnly(boardID string) *CodedError {
+	return NewCodedError(ErrCodeBoardReadOnly, "the board is read-only", map[string]interface{}{"boardId": boardID})
+}
+
+// IsErrBoardReadOnly returns true if the error is a change of a board
+// frozen by its admins.
+func IsErrBoardReadOnly(err error) bool {
+	ce, ok := AsCodedError(err)
+	return ok && ce.Code == ErrCodeBoardReadOnly
+}
+
+func (ce *CodedError) Error() string {
+	return ce.Message
+}
+
+// ErrorCodeForStatus returns the generic error code for an HTTP status.
+func ErrorCodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrCodeInsufficientPermissions
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusConflict:
+		return ErrCodeVersionConflict
+	case http.StatusRequestEntityTooLarge:
+		return ErrCodeRequestTooLarge
+	case http.StatusNotImplemented:
+		return ErrCodeNotImplemented
+	}
+	if status >= http.StatusInternalServerError {
+		return ErrCodeInternal
+	}
+	return ErrCodeBadRequest
+}
+
+// StatusForErrorCode returns the HTTP status for an error code, the
+// reverse of ErrorCodeForStatus.
+func StatusForErrorCode(code string) int {
+	switch code {
+	case ErrCodeBadRequest, ErrCodeContentRejected:
+		return http.StatusBadRequest
+	case ErrCodeUnauthorized:
+		return http.StatusUnauthorized
+	case ErrCodeInsufficientPermissions, ErrCodeInsufficientLicense, ErrCodeBoardReadOnly:
+		return http.StatusForbidden
+	case ErrCodeNotFound, ErrCodeBoardNotFound, ErrCodeBlockNotFound, ErrCodeCategoryNotFound, ErrCodeTeamNotFound:
+		return http.StatusNotFound
+	case ErrCodeVersionConflict, ErrCodeBoardKeyTaken, ErrCodeWIPLimitExceeded:
+		return http.StatusConflict
+	case ErrCodeBlockLocked:
+		return http.StatusLocked
+	case ErrCodeRequestTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case ErrCodeNotImplemented:
+		return http.StatusNotImplemented
+	}
+	return http.StatusInternalServerError
+}
+
+// AsCodedError returns the CodedError that err is or wraps, if any.
+func AsCodedError(err error) (*CodedError, bool) {
+	var ce *CodedError
+	if errors.As(err, &ce) {
+		return ce, true
+	}
+	return nil, false
 }
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodeForStatus(t *testing.T) {
+	require.Equal(t, ErrCodeBadRequest, ErrorCodeForStatus(http.StatusBadRequest))
+	require.Equal(t, ErrCodeUnauthorized, ErrorCodeForStatus(http.StatusUnauthorized))
+	require.Equal(t, ErrCodeInsufficientPermissions, ErrorCodeForStatus(http.StatusForbidden))
+	require.Equal(t, ErrCodeNotFound, ErrorCodeForStatus(http.StatusNotFound))
+	require.Equal(t, ErrCodeVersionConflict, ErrorCodeForStatus(http.StatusConflict))
+	require.Equal(t, ErrCodeInternal, ErrorCodeForStatus(http.StatusInternalServerError))
+	require.Equal(t, ErrCodeInternal, ErrorCodeForStatus(http.StatusServiceUnavailable))
+}
+
+func TestStatusForErrorCode(t *testing.T) {
+	require.Equal(t, http.StatusNotFound, StatusForErrorCode(ErrCodeBoardNotFound))
+	require.Equal(t, http.StatusForbidden, StatusForErrorCode(ErrCodeInsufficientLicense))
+	require.Equal(t, http.StatusForbidden, StatusForErrorCode(ErrCodeBoardReadOnly))
+	require.Equal(t, http.StatusConflict, StatusForErrorCode(ErrCodeVersionConflict))
+	require.Equal(t, http.StatusLocked, StatusForErrorCode(ErrCodeBlockLocked))
+	require.Equal(t, http.StatusInternalServerError, StatusForErrorCode("unknown_code"))
+
+	for _, status := range []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict} {
+		require.Equal(t, status, StatusForErrorCode(ErrorCodeForStatus(status)))
+	}
+}
+
+func TestAsCodedError(t *testing.T) {
+	t.Run("plain error", func(t *testing.T) {
+		_, ok := AsCodedError(fmt.Errorf("some error"))
+		require.False(t, ok)
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		_, ok := AsCodedError(nil)
+		require.False(t, ok)
+	})
+
+	t.Run("wrapped coded error", func(t *testing.T) {
+		err := fmt.Errorf("cannot get board: %w", NewErrBoardNotFound("board-id"))
+		ce, ok := AsCodedError(err)
+		require.True(t, ok)
+		require.Equal(t, ErrCodeBoardNotFound, ce.Code)
+		require.Equal(t, "board-id", ce.Params["boardId"])
+	})
+}
@@ -0,0 +1,165 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+)
+
+const (
+	// CardFieldGitHubIssue is the field of the cards imported from GitHub
+	// holding their issue, as owner/name#number, to update them on the
+	// next sync and from the webhook events.
+	CardFieldGitHubIssue = "githubIssue"
+
+	// GitHubIssueStateOpen and GitHubIssueStateClosed are the states of
+	// the GitHub issues.
+	GitHubIssueStateOpen   = "open"
+	GitHubIssueStateClosed = "closed"
+)
+
+var gitHubRepositoryRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
+// GitHubIntegration syncs the issues of a GitHub repository to the cards
+// of a board. The status and assignee of the issues are kept in sync by
+// the webhook of the repository
+// swagger:model
+type GitHubIntegration struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The repository, as owner/name
+	// required: true
+	Repository string `json:"repository"`
+
+	// The access token used to read the issues. It is stored encrypted
+	// and never returned; an empty token keeps the stored one
+	// required: false
+	Token string `json:"token,omitempty"`
+
+	// Whether the integration has an access token
+	// required: false
+	HasToken bool `json:"hasToken"`
+
+	// The select property, by ID or name, set to the status of the issues
+	// required: false
+	StatusProperty string `json:"statusProperty"`
+
+	// The option, by ID or value, of the status of the open issues
+	// required: false
+	OpenStatus string `json:"openStatus"`
+
+	// The option, by ID or value, of the status of the closed issues
+	// required: false
+	ClosedStatus string `json:"closedStatus"`
+
+	// The person property, by ID or name, set to the first assignee of the
+	// issues whose GitHub login is the username of a user
+	// required: false
+	AssigneeProperty string `json:"assigneeProperty"`
+
+	// The secret of the webhook to add to the repository, for the "issues"
+	// events, generated when the integration is created
+	// required: false
+	WebhookSecret string `json:"webhookSecret"`
+
+	// The id of the user that last changed the integration
+	// required: false
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The last time the integration was changed in miliseconds since the current epoch
+	// required: false
+	UpdateAt int64 `json:"updateAt"`
+
+	// The last time the issues were imported in miliseconds since the current epoch
+	// required: false
+	LastSyncAt int64 `json:"lastSyncAt"`
+
+	// The encrypted access token, as stored
+	EncryptedToken string `json:"-"`
+}
+
+// IsValid checks the repository of the integration and that a status
+// property comes with both statuses.
+func (i *GitHubIntegration) IsValid() error {
+	if !gitHubRepositoryRegexp.MatchString(i.Repository) {
+		return NewCodedError(ErrCodeBadRequest, "the repository must be owner/name", map[string]interface{}{"repository": i.Repository})
+	}
+	if i.StatusProperty != "" && (i.OpenStatus == "" || i.ClosedStatus == "") {
+		return NewCodedError(ErrCodeBadRequest, "the status property requires the open and closed statuses", nil)
+	}
+	return nil
+}
+
+// Sanitized returns a copy of the integration without its token, to be
+// returned to the clients.
+func (i *GitHubIntegration) Sanitized() *GitHubIntegration {
+	sanitized := *i
+	sanitized.HasToken = i.EncryptedToken != ""
+	sanitized.Token = ""
+	sanitized.EncryptedToken = ""
+	return &sanitized
+}
+
+// GitHubPropertyMapping is the card properties and options an
+// integration sets, resolved on the schema of its board. The empty IDs
+// are not set.
+type GitHubPropertyMapping struct {
+	StatusPropertyID   string
+	OpenOptionID       string
+	ClosedOptionID     string
+	AssigneePropertyID string
+}
+
+// PropertyMapping resolves the properties of the integration on the
+// schema of its board. The status property must be a select property
+// with both statuses, and the assignee property a person property.
+func (i *GitHubIntegration) PropertyMapping(schema PropSchema) (GitHubPropertyMapping, error) {
+	mapping := GitHubPropertyMapping{}
+
+	if i.StatusProperty != "" {
+		def, ok := schema.findProperty(i.StatusProperty)
+		if !ok || def.Type != propTypeSelect {
+			return mapping, NewCodedError(ErrCodeBadRequest, "the status property must be a select property of the board",
+				map[string]interface{}{"statusProperty": i.StatusProperty})
+		}
+		mapping.StatusPropertyID = def.ID
+		if mapping.OpenOptionID, ok = def.findOption(i.OpenStatus); !ok {
+			return mapping, NewCodedError(ErrCodeBadRequest, "the open status must be an option of the status property",
+				map[string]interface{}{"openStatus": i.OpenStatus})
+		}
+		if mapping.ClosedOptionID, ok = def.findOption(i.ClosedStatus); !ok {
+			return mapping, NewCodedError(ErrCodeBadRequest, "the closed status must be an option of the status property",
+				map[string]interface{}{"closedStatus": i.ClosedStatus})
+		}
+	}
+
+	if i.AssigneeProperty != "" {
+		def, ok := schema.findProperty(i.AssigneeProperty)
+		if !ok || def.Type != propTypePerson {
+			return mapping, NewCodedError(ErrCodeBadRequest, "the assignee property must be a person property of the board",
+				map[string]interface{}{"assigneeProperty": i.AssigneeProperty})
+		}
+		mapping.AssigneePropertyID = def.ID
+	}
+	return mapping, nil
+}
+
+// GitHubIssueKey returns the key of an issue of a repository, as stored
+// in the CardFieldGitHubIssue field of its card.
+func GitHubIssueKey(repository string, number int) string {
+	return repository + "#" + strconv.Itoa(number)
+}
+
+// GitHubSyncResult is the number of cards created and updated by a sync
+// of the issues of a repository
+// swagger:model
+type GitHubSyncResult struct {
+	// The number of cards created for new issues
+	// required: true
+	Created int `json:"created"`
+
+	// The number of cards updated for changed issues
+	// required: true
+	Updated int `json:"updated"`
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubIntegrationIsValid(t *testing.T) {
+	require.NoError(t, (&GitHubIntegration{Repository: "mattermost/focalboard"}).IsValid())
+	require.NoError(t, (&GitHubIntegration{Repository: "owner/repo.js", StatusProperty: "Status", OpenStatus: "To do", ClosedStatus: "Done"}).IsValid())
+
+	for _, repository := range []string{"", "owner", "owner/", "owner/repo/issues", "https://github.com/owner/repo"} {
+		require.Error(t, (&GitHubIntegration{Repository: repository}).IsValid(), repository)
+	}
+	require.Error(t, (&GitHubIntegration{Repository: "owner/repo", StatusProperty: "Status", OpenStatus: "To do"}).IsValid())
+}
+
+func TestGitHubIntegrationSanitized(t *testing.T) {
+	integration := &GitHubIntegration{Repository: "owner/repo", Token: "token", EncryptedToken: "encrypted"}
+	sanitized := integration.Sanitized()
+	require.True(t, sanitized.HasToken)
+	require.Empty(t, sanitized.Token)
+	require.Empty(t, sanitized.EncryptedToken)
+	require.Equal(t, "encrypted", integration.EncryptedToken)
+
+	require.False(t, (&GitHubIntegration{Repository: "owner/repo"}).Sanitized().HasToken)
+}
+
+func TestGitHubIntegrationPropertyMapping(t *testing.T) {
+	board := &Board{
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To do"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+			{"id": "owner", "name": "Owner", "type": "person"},
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	}
+	schema, err := ParsePropertySchema(board)
+	require.NoError(t, err)
+
+	t.Run("properties and options by ID or name", func(t *testing.T) {
+		integration := &GitHubIntegration{StatusProperty: "Status", OpenStatus: "todo", ClosedStatus: "Done", AssigneeProperty: "owner"}
+		mapping, err := integration.PropertyMapping(schema)
+		require.NoError(t, err)
+		require.Equal(t, GitHubPropertyMapping{
+			StatusPropertyID:   "status",
+			OpenOptionID:       "todo",
+			ClosedOptionID:     "done",
+			AssigneePropertyID: "owner",
+		}, mapping)
+	})
+
+	t.Run("no properties", func(t *testing.T) {
+		mapping, err := (&GitHubIntegration{}).PropertyMapping(schema)
+		require.NoError(t, err)
+		require.Equal(t, GitHubPropertyMapping{}, mapping)
+	})
+
+	t.Run("invalid properties", func(t *testing.T) {
+		for _, integration := range []*GitHubIntegration{
+			{StatusProperty: "Notes", OpenStatus: "todo", ClosedStatus: "done"},
+			{StatusProperty: "Status", OpenStatus: "todo", ClosedStatus: "Closed"},
+			{StatusProperty: "Missing", OpenStatus: "todo", ClosedStatus: "done"},
+			{AssigneeProperty: "Status"},
+		} {
+			_, err := integration.PropertyMapping(schema)
+			require.Error(t, err)
+			ce, ok := AsCodedError(err)
+			require.True(t, ok)
+			require.Equal(t, ErrCodeBadRequest, ce.Code)
+		}
+	})
+}
+
+func TestGitHubIssueKey(t *testing.T) {
+	require.Equal(t, "owner/repo#42", GitHubIssueKey("owner/repo", 42))
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+)
+
+const (
+	// ImporterGitLab is the importer of the issues of a GitLab project.
+	ImporterGitLab = "gitlab"
+
+	// CardFieldGitLabIssue is the field of the cards imported from GitLab
+	// holding their issue, as project#iid, to update them on the next
+	// import.
+	CardFieldGitLabIssue = "gitlabIssue"
+
+	// The names of the card properties the issues are imported in. They
+	// are added to the board if missing.
+	GitLabPropertyStatus    = "Status"
+	GitLabPropertyLabels    = "Labels"
+	GitLabPropertyMilestone = "Milestone"
+	GitLabPropertyAssignee  = "Assignee"
+
+	// GitLabStatusOpen and GitLabStatusClosed are the options of the status
+	// property for the open and closed issues.
+	GitLabStatusOpen   = "Open"
+	GitLabStatusClosed = "Closed"
+)
+
+var gitLabProjectRegexp = regexp.MustCompile(`^([0-9]+|[A-Za-z0-9_][A-Za-z0-9_.-]*(/[A-Za-z0-9_][A-Za-z0-9_.-]*)+)$`)
+
+// GitLabImportRequest imports the issues of a GitLab project as cards of
+// a new or existing board
+// swagger:model
+type GitLabImportRequest struct {
+	// The project, by path as group/name or by ID
+	// required: true
+	Project string `json:"project"`
+
+	// The access token used to read the issues; it is not stored. Can be
+	// empty for public projects
+	// required: false
+	Token string `json:"token"`
+
+	// The board the issues are imported in, a new board of the team being
+	// created if empty
+	// required: false
+	BoardID string `json:"boardId"`
+
+	// The title of the new board, the project if empty
+	// required: false
+	Title string `json:"title"`
+}
+
+// IsValid checks the project of the request.
+func (r *GitLabImportRequest) IsValid() error {
+	if !gitLabProjectRegexp.MatchString(r.Project) {
+		return NewCodedError(ErrCodeBadRequest, "the project must be a path as group/name or an ID", map[string]interface{}{"project": r.Project})
+	}
+	return nil
+}
+
+// GitLabIssueKey returns the key of an issue of a project, as stored in
+// the CardFieldGitLabIssue field of its card.
+func GitLabIssueKey(project string, iid int) string {
+	return project + "#" + strconv.Itoa(iid)
+}
+
+// GitLabImportResult is the board the issues of a GitLab project were
+// imported in, and the number of cards created and updated
+// swagger:model
+type GitLabImportResult struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The number of cards created for new issues
+	// required: true
+	Created int `json:"created"`
+
+	// The number of cards updated for changed issues
+	// required: true
+	Updated int `json:"updated"`
+}
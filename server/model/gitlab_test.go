@@ -0,0 +1,20 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLabImportRequestIsValid(t *testing.T) {
+	for _, project := range []string{"group/project", "group/subgroup/my.project", "42"} {
+		require.NoError(t, (&GitLabImportRequest{Project: project}).IsValid(), project)
+	}
+	for _, project := range []string{"", "project", "group/", "../projects", "https://gitlab.com/group/project"} {
+		require.Error(t, (&GitLabImportRequest{Project: project}).IsValid(), project)
+	}
+}
+
+func TestGitLabIssueKey(t *testing.T) {
+	require.Equal(t, "group/project#3", GitLabIssueKey("group/project", 3))
+}
@@ -8,6 +8,10 @@ import (
 
 var (
 	ErrInvalidImageBlock = errors.New("invalid image block")
+
+	ErrRemoteCopyServerURLRequired   = errors.New("server URL is required")
+	ErrRemoteCopyAccessTokenRequired = errors.New("access token is required")
+	ErrRemoteCopyTeamIDRequired      = errors.New("team ID is required")
 )
 
 // Archive is an import / export archive.
@@ -22,6 +26,11 @@ type Archive struct {
 type ArchiveHeader struct {
 	Version int   `json:"version"`
 	Date    int64 `json:"date"`
+
+	// ServerVersion is the version of the server that exported the
+	// archive, to tell which release is needed to import the newer
+	// archive versions.
+	ServerVersion string `json:"serverVersion,omitempty"`
 }
 
 // ArchiveLine is any line in an archive.
@@ -38,6 +47,10 @@ type ExportArchiveOptions struct {
 	// BoardIDs is the list of boards to include in the archive.
 	// Empty slice means export all boards from workspace/team.
 	BoardIDs []string
+
+	// IncludePermissions adds the permissions of each board to the
+	// archive.
+	IncludePermissions bool
 }
 
 // ImportArchiveOptions provides options when importing an archive.
@@ -46,6 +59,67 @@ type ImportArchiveOptions struct {
 	ModifiedBy    string
 	BoardModifier BoardModifier
 	BlockModifier BlockModifier
+
+	// ApplyPermissions applies the permissions of the boards of the
+	// archive, if it has them, to the imported boards, keeping the user
+	// importing them as a member.
+	ApplyPermissions bool
+}
+
+// ImportArchiveResult is the result of an archive import
+// swagger:model
+type ImportArchiveResult struct {
+	// The IDs of the imported boards, indexed by their ID in the archive
+	// required: true
+	BoardIDs map[string]string `json:"boardIds"`
+}
+
+// RemoteBoardCopyRequest is a request to copy a board to another server
+// swagger:model
+type RemoteBoardCopyRequest struct {
+	// The URL of the server to copy the board to
+	// required: true
+	ServerURL string `json:"serverUrl"`
+
+	// The access token of the user the board is imported as on the
+	// other server
+	// required: true
+	AccessToken string `json:"accessToken"`
+
+	// The ID of the team to import the board into on the other server
+	// required: true
+	TeamID string `json:"teamId"`
+}
+
+// IsValid checks that all the fields of a remote copy request are set.
+func (r *RemoteBoardCopyRequest) IsValid() error {
+	if r.ServerURL == "" {
+		return ErrRemoteCopyServerURLRequired
+	}
+	if r.AccessToken == "" {
+		return ErrRemoteCopyAccessTokenRequired
+	}
+	if r.TeamID == "" {
+		return ErrRemoteCopyTeamIDRequired
+	}
+	return nil
+}
+
+// RemoteBoardCopyResult is the result of the copy of a board to another
+// server
+// swagger:model
+type RemoteBoardCopyResult struct {
+	// The URL of the server the board was copied to
+	// required: true
+	ServerURL string `json:"serverUrl"`
+
+	// The ID of the team the board was copied to
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The ID of the copy of the board on the other server
+	// required: true
+	BoardID string `json:"boardId"`
 }
 
 // ErrUnsupportedArchiveVersion is an error returned when trying to import an
@@ -64,9 +138,17 @@ func NewErrUnsupportedArchiveVersion(got int, want int) ErrUnsupportedArchiveVer
 }
 
 func (e ErrUnsupportedArchiveVersion) Error() string {
+	if e.IsNewer() {
+		return fmt.Sprintf("archive version %d is newer than the supported version %d; the server must be upgraded to import it", e.got, e.want)
+	}
 	return fmt.Sprintf("unsupported archive version; got %d, want %d", e.got, e.want)
 }
 
+// IsNewer returns true if the archive was exported by a newer server.
+func (e ErrUnsupportedArchiveVersion) IsNewer() bool {
+	return e.got > e.want
+}
+
 // ErrUnsupportedArchiveLineType is an error returned when trying to import an
 // archive containing an unsupported line type.
 type ErrUnsupportedArchiveLineType struct {
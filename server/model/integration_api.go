@@ -0,0 +1,279 @@
+package model
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// HeaderAPIKey holds the API key of the requests of the integration
+	// API.
+	HeaderAPIKey = "X-API-Key"
+
+	// DefaultIntegrationPageSize and MaxIntegrationPageSize are the
+	// default and maximum number of items returned by the triggers of the
+	// integration API.
+	DefaultIntegrationPageSize = 50
+	MaxIntegrationPageSize     = 100
+)
+
+// The integration API is made for no-code platforms like Zapier: its
+// triggers are polled with the cursor of the last item received, and its
+// items have a flat format whose property values are strings keyed by
+// property name.
+
+// IntegrationUser is the user of an API key
+// swagger:model
+type IntegrationUser struct {
+	// The id of the user
+	// required: true
+	UserID string `json:"userId"`
+
+	// The username of the user
+	// required: true
+	Username string `json:"username"`
+}
+
+// IntegrationCard is a card in the format of the integration API
+// swagger:model
+type IntegrationCard struct {
+	// The id of the card
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The property values of the card, keyed by property name. Options
+	// are given by value and users by username; multiple values are
+	// separated by commas
+	// required: true
+	Properties map[string]string `json:"properties"`
+
+	// The id of the user that created the card
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time of the card in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last time the card was changed in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The cursor to poll the new cards created after this one
+	// required: true
+	Cursor string `json:"cursor"`
+}
+
+// IntegrationStatusChange is a change of the status of a card
+// swagger:model
+type IntegrationStatusChange struct {
+	// The id of the change, unique for each change of each card
+	// required: true
+	ID string `json:"id"`
+
+	// The status of the card after the change, the value of its option
+	// required: true
+	Status string `json:"status"`
+
+	// The time of the change in miliseconds since the current epoch
+	// required: true
+	ChangedAt int64 `json:"changedAt"`
+
+	// The card, as it is now
+	// required: true
+	Card IntegrationCard `json:"card"`
+
+	// The cursor to poll the changes made after this one
+	// required: true
+	Cursor string `json:"cursor"`
+}
+
+// IntegrationCardRequest creates or updates a card through the
+// integration API
+// swagger:model
+type IntegrationCardRequest struct {
+	// The title of the card, required to create a card and left unchanged
+	// if empty on updates
+	// required: false
+	Title string `json:"title"`
+
+	// The description of the new card, in markdown. Ignored on updates
+	// required: false
+	Description string `json:"description"`
+
+	// The property values to set, keyed by property name or ID. Options
+	// can be given by value, users are given by ID; multiple values are
+	// separated by commas and an empty value clears the property
+	// required: false
+	Properties map[string]string `json:"properties"`
+}
+
+// IntegrationCursor returns the cursor of an item of the integration
+// API, made of its time and ID.
+func IntegrationCursor(at int64, id string) string {
+	return strconv.FormatInt(at, 10) + "_" + id
+}
+
+// ParseIntegrationCursor returns the time and ID of a cursor, zero for
+// an empty cursor.
+func ParseIntegrationCursor(cursor string) (int64, string, error) {
+	if cursor == "" {
+		return 0, "", nil
+	}
+	parts := strings.SplitN(cursor, "_", 2)
+	at, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || len(parts) != 2 || at < 0 {
+		return 0, "", NewCodedError(ErrCodeBadRequest, "invalid cursor", map[string]interface{}{"cursor": cursor})
+	}
+	return at, parts[1], nil
+}
+
+// isAfterIntegrationCursor returns true if the item of the time and ID is
+// after the cursor.
+func isAfterIntegrationCursor(at int64, id string, cursorAt int64, cursorID string) bool {
+	return at > cursorAt || (at == cursorAt && id > cursorID)
+}
+
+// NewIntegrationCard returns the card in the format of the integration
+// API. The values that can't be read with the schema are left out.
+func NewIntegrationCard(card *Block, schema PropSchema, resolver PropValueResolver) IntegrationCard {
+	properties := map[string]string{}
+	values, _ := card.Fields["properties"].(map[string]interface{})
+	for id, value := range values {
+		def, ok := schema[id]
+		if !ok {
+			continue
+		}
+		if formatted, ok := integrationPropertyValue(def, value, resolver); ok && formatted != "" {
+			properties[def.Name] = formatted
+		}
+	}
+
+	return IntegrationCard{
+		ID:         card.ID,
+		BoardID:    card.BoardID,
+		Title:      card.Title,
+		Properties: properties,
+		CreatedBy:  card.CreatedBy,
+		CreateAt:   card.CreateAt,
+		UpdateAt:   card.UpdateAt,
+		Cursor:     IntegrationCursor(card.CreateAt, card.ID),
+	}
+}
+
+// integrationPropertyValue formats a property value like
+// PropDef.GetValue, but with the option values as they are.
+func integrationPropertyValue(def PropDef, value interface{}, resolver PropValueResolver) (string, bool) {
+	switch def.Type {
+	case propTypeSelect:
+		id, _ := value.(string)
+		opt, ok := def.Options[id]
+		return opt.Value, ok
+	case "multiSelect":
+		ids, _ := value.([]interface{})
+		names := make([]string, 0, len(ids))
+		for _, item := range ids {
+			id, _ := item.(string)
+			if opt, ok := def.Options[id]; ok {
+				names = append(names, opt.Value)
+			}
+		}
+		return strings.Join(names, ", "), true
+	}
+
+	formatted, err := def.GetValue(value, resolver)
+	return formatted, err == nil
+}
+
+// NewIntegrationCards returns the cards created after the cursor, in the
+// format of the integration API and in creation order. Without cursor,
+// the most recent cards are returned.
+func NewIntegrationCards(cards []Block, cursor string, limit int, schema PropSchema, resolver PropValueResolver) ([]IntegrationCard, error) {
+	cursorAt, cursorID, err := ParseIntegrationCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := []*Block{}
+	for i := range cards {
+		if cursor == "" || isAfterIntegrationCursor(cards[i].CreateAt, cards[i].ID, cursorAt, cursorID) {
+			selected = append(selected, &cards[i])
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return isAfterIntegrationCursor(selected[j].CreateAt, selected[j].ID, selected[i].CreateAt, selected[i].ID)
+	})
+	if len(selected) > limit {
+		if cursor == "" {
+			selected = selected[len(selected)-limit:]
+		} else {
+			selected = selected[:limit]
+		}
+	}
+
+	result := make([]IntegrationCard, 0, len(selected))
+	for _, card := range selected {
+		result = append(result, NewIntegrationCard(card, schema, resolver))
+	}
+	return result, nil
+}
+
+// NewIntegrationStatusChanges returns the last status change of the
+// cards made after the cursor, in the format of the integration API and
+// in the order they were made. statusSince is the time each card got its
+// current status; the status set when a card is created is not a change.
+// Without cursor, the most recent changes are returned.
+func NewIntegrationStatusChanges(cards []Block, statusSince map[string]int64, status PropDef, cursor string, limit int,
+	schema PropSchema, resolver PropValueResolver) ([]IntegrationStatusChange, error) {
+	cursorAt, cursorID, err := ParseIntegrationCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	type change struct {
+		card *Block
+		at   int64
+	}
+	changes := []change{}
+	for i := range cards {
+		at, ok := statusSince[cards[i].ID]
+		if !ok || at <= cards[i].CreateAt {
+			continue
+		}
+		if cursor == "" || isAfterIntegrationCursor(at, cards[i].ID, cursorAt, cursorID) {
+			changes = append(changes, change{card: &cards[i], at: at})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return isAfterIntegrationCursor(changes[j].at, changes[j].card.ID, changes[i].at, changes[i].card.ID)
+	})
+	if len(changes) > limit {
+		if cursor == "" {
+			changes = changes[len(changes)-limit:]
+		} else {
+			changes = changes[:limit]
+		}
+	}
+
+	result := make([]IntegrationStatusChange, 0, len(changes))
+	for _, c := range changes {
+		value, _ := integrationPropertyValue(status, cardPropertyValue(c.card, status.ID), resolver)
+		result = append(result, IntegrationStatusChange{
+			ID:        c.card.ID + ":" + strconv.FormatInt(c.at, 10),
+			Status:    value,
+			ChangedAt: c.at,
+			Card:      NewIntegrationCard(c.card, schema, resolver),
+			Cursor:    IntegrationCursor(c.at, c.card.ID),
+		})
+	}
+	return result, nil
+}
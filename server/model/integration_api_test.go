@@ -0,0 +1,105 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationCursor(t *testing.T) {
+	at, id, err := ParseIntegrationCursor(IntegrationCursor(1000, "card_1"))
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), at)
+	require.Equal(t, "card_1", id)
+
+	at, id, err = ParseIntegrationCursor("")
+	require.NoError(t, err)
+	require.Zero(t, at)
+	require.Empty(t, id)
+
+	for _, cursor := range []string{"1000", "abc_card", "-1_card"} {
+		_, _, err = ParseIntegrationCursor(cursor)
+		require.Error(t, err, cursor)
+	}
+}
+
+func TestNewIntegrationCards(t *testing.T) {
+	schema := PropSchema{
+		"status": {ID: "status", Name: "Status", Type: "select", Options: map[string]PropDefOption{
+			"todo": {ID: "todo", Value: "To do"},
+		}},
+	}
+	cards := []Block{
+		{ID: "c3", Title: "Third", CreateAt: 300},
+		{ID: "c1", Title: "First", CreateAt: 100, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "deleted": "value"},
+		}},
+		{ID: "c2", Title: "Second", CreateAt: 200},
+	}
+
+	t.Run("without cursor the most recent cards are returned", func(t *testing.T) {
+		result, err := NewIntegrationCards(cards, "", 2, schema, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Equal(t, "c2", result[0].ID)
+		require.Equal(t, "c3", result[1].ID)
+	})
+
+	t.Run("cards after the cursor in creation order", func(t *testing.T) {
+		result, err := NewIntegrationCards(cards, IntegrationCursor(100, "c1"), 1, schema, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "c2", result[0].ID)
+
+		result, err = NewIntegrationCards(cards, result[0].Cursor, 10, schema, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "c3", result[0].ID)
+	})
+
+	t.Run("properties by name", func(t *testing.T) {
+		result, err := NewIntegrationCards(cards, "", 1, schema, nil)
+		require.NoError(t, err)
+		require.Empty(t, result[0].Properties)
+
+		result, err = NewIntegrationCards(cards, "", 3, schema, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"Status": "To do"}, result[0].Properties)
+	})
+}
+
+func TestNewIntegrationStatusChanges(t *testing.T) {
+	status := PropDef{ID: "status", Name: "Status", Type: "select", Options: map[string]PropDefOption{
+		"todo": {ID: "todo", Value: "To do"},
+		"done": {ID: "done", Value: "Done"},
+	}}
+	schema := PropSchema{"status": status}
+	card := func(id, statusID string) Block {
+		return Block{ID: id, CreateAt: 100, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": statusID},
+		}}
+	}
+	cards := []Block{card("c1", "done"), card("c2", "todo"), card("c3", "done")}
+	statusSince := map[string]int64{
+		"c1": 400,
+		"c2": 100, // set when the card was created
+		"c3": 300,
+	}
+
+	changes, err := NewIntegrationStatusChanges(cards, statusSince, status, "", 10, schema, nil)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, "c3", changes[0].Card.ID)
+	require.Equal(t, "Done", changes[0].Status)
+	require.Equal(t, int64(300), changes[0].ChangedAt)
+	require.Equal(t, "c1", changes[1].Card.ID)
+	require.NotEqual(t, changes[0].ID, changes[1].ID)
+
+	changes, err = NewIntegrationStatusChanges(cards, statusSince, status, changes[0].Cursor, 10, schema, nil)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, "c1", changes[0].Card.ID)
+
+	_, err = NewIntegrationStatusChanges(cards, statusSince, status, "invalid", 10, schema, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,23 @@
+package model
+
+import "encoding/json"
+
+// LongPollResponse is the result of a long-polling request for the
+// changes of a team
+// swagger:model
+type LongPollResponse struct {
+	// The cursor to send with the next request, to receive the changes
+	// that follow these ones
+	// required: true
+	Cursor string `json:"cursor"`
+
+	// True if the changes that followed the cursor of the request are no
+	// longer available; the client should reload the boards it follows
+	// required: true
+	Reset bool `json:"reset"`
+
+	// The changes, as the websocket messages the clients subscribed to
+	// the boards receive
+	// required: true
+	Messages []json.RawMessage `json:"messages"`
+}
@@ -0,0 +1,38 @@
+package model
+
+// MaintenanceReport describes what a database maintenance run did
+// swagger:model
+type MaintenanceReport struct {
+	// The type of the database
+	// required: true
+	DBType string `json:"dbType"`
+
+	// The maintenance statements that were executed, in order
+	// required: true
+	Statements []*MaintenanceStatement `json:"statements"`
+
+	// The number of orphan rows deleted from each table
+	// required: true
+	DeletedOrphans map[string]int64 `json:"deletedOrphans"`
+
+	// The time the maintenance started, in miliseconds
+	// required: true
+	StartAt int64 `json:"startAt"`
+
+	// The time the maintenance ended, in miliseconds
+	// required: true
+	EndAt int64 `json:"endAt"`
+}
+
+// MaintenanceStatement is a statement executed by a database
+// maintenance run
+// swagger:model
+type MaintenanceStatement struct {
+	// The SQL statement
+	// required: true
+	SQL string `json:"sql"`
+
+	// How long the statement took, in miliseconds
+	// required: true
+	DurationMs int64 `json:"durationMs"`
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+const (
+	// MaxMemberCustomFields is the number of custom fields a board member
+	// can have.
+	MaxMemberCustomFields = 50
+
+	// MaxMemberCustomFieldsSize is the size in bytes of the JSON encoding
+	// of the custom fields of a board member.
+	MaxMemberCustomFieldsSize = 8 * 1024
+)
+
+// MemberCustomFieldsPatch is a patch of the custom fields of a board member
+// swagger:model
+type MemberCustomFieldsPatch struct {
+	// The custom fields to set by name. A null value removes the field
+	// required: true
+	CustomFields map[string]interface{} `json:"customFields"`
+}
+
+func MemberCustomFieldsPatchFromJSON(data io.Reader) (*MemberCustomFieldsPatch, error) {
+	var patch MemberCustomFieldsPatch
+	if err := json.NewDecoder(data).Decode(&patch); err != nil {
+		return nil, err
+	}
+	return &patch, nil
+}
+
+// Patch returns the custom fields updated by the patch, and checks their
+// names, number and size.
+func (p *MemberCustomFieldsPatch) Patch(customFields map[string]interface{}) (map[string]interface{}, error) {
+	patched := make(map[string]interface{}, len(customFields)+len(p.CustomFields))
+	for name, value := range customFields {
+		patched[name] = value
+	}
+	for name, value := range p.CustomFields {
+		if strings.TrimSpace(name) == "" {
+			return nil, NewCodedError(ErrCodeBadRequest, "custom field names cannot be empty", nil)
+		}
+		if value == nil {
+			delete(patched, name)
+		} else {
+			patched[name] = value
+		}
+	}
+
+	if len(patched) > MaxMemberCustomFields {
+		return nil, NewCodedError(ErrCodeBadRequest, "too many custom fields", map[string]interface{}{"max": MaxMemberCustomFields})
+	}
+	data, err := json.Marshal(patched)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxMemberCustomFieldsSize {
+		return nil, NewCodedError(ErrCodeBadRequest, "custom fields too large", map[string]interface{}{"maxSize": MaxMemberCustomFieldsSize})
+	}
+	return patched, nil
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemberCustomFieldsPatch(t *testing.T) {
+	t.Run("fields are set and removed", func(t *testing.T) {
+		patch, err := MemberCustomFieldsPatchFromJSON(strings.NewReader(`{"customFields":{"capacity":5,"roleTitle":null}}`))
+		require.NoError(t, err)
+
+		patched, err := patch.Patch(map[string]interface{}{"roleTitle": "Designer", "team": "Web"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"capacity": float64(5), "team": "Web"}, patched)
+	})
+
+	t.Run("the current fields are not modified", func(t *testing.T) {
+		current := map[string]interface{}{"capacity": 3}
+		patch := &MemberCustomFieldsPatch{CustomFields: map[string]interface{}{"capacity": nil}}
+		patched, err := patch.Patch(current)
+		require.NoError(t, err)
+		require.Empty(t, patched)
+		require.Equal(t, map[string]interface{}{"capacity": 3}, current)
+	})
+
+	t.Run("invalid patches", func(t *testing.T) {
+		tooMany := map[string]interface{}{}
+		for i := 0; i <= MaxMemberCustomFields; i++ {
+			tooMany[strings.Repeat("f", i+1)] = true
+		}
+
+		testCases := map[string]map[string]interface{}{
+			"empty name": {" ": "value"},
+			"too many":   tooMany,
+			"too large":  {"notes": strings.Repeat("a", MaxMemberCustomFieldsSize)},
+		}
+		for name, customFields := range testCases {
+			t.Run(name, func(t *testing.T) {
+				_, err := (&MemberCustomFieldsPatch{CustomFields: customFields}).Patch(nil)
+				require.True(t, IsErrBadRequest(err))
+			})
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	// DefaultMentionsLimit is the number of mentions returned when the
+	// client doesn't ask for a specific amount.
+	DefaultMentionsLimit = 50
+
+	// MaxMentionsLimit is the maximum number of mentions that can be
+	// requested at once.
+	MaxMentionsLimit = 200
+)
+
+// Mention records that a user was mentioned in a card
+// swagger:model
+type Mention struct {
+	// The ID of the mention
+	// required: true
+	ID string `json:"id"`
+
+	// The ID of the mentioned user
+	// required: true
+	UserID string `json:"userId"`
+
+	// The ID of the team of the board
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the card, empty for the mentions on the board itself
+	// required: false
+	CardID string `json:"cardId"`
+
+	// The ID of the block that contains the mention
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The ID of the user that wrote the mention
+	// required: true
+	MentionedBy string `json:"mentionedBy"`
+
+	// The text around the mention
+	// required: true
+	Extract string `json:"extract"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The time the mention was read in miliseconds since the current epoch, zero if unread
+	// required: true
+	ReadAt int64 `json:"readAt"`
+}
+
+func (m *Mention) IsValid() error {
+	if m == nil {
+		return ErrInvalidMention{"cannot be nil"}
+	}
+	if m.UserID == "" {
+		return ErrInvalidMention{"missing user id"}
+	}
+	if m.BoardID == "" {
+		return ErrInvalidMention{"missing board id"}
+	}
+	if m.BlockID == "" {
+		return ErrInvalidMention{"missing block id"}
+	}
+	return nil
+}
+
+type ErrInvalidMention struct {
+	msg string
+}
+
+func (e ErrInvalidMention) Error() string {
+	return e.msg
+}
+
+// QueryMentionsOptions are query options that can be passed to GetMentionsForUser.
+type QueryMentionsOptions struct {
+	UnreadOnly     bool   // if true, only mentions that haven't been read are returned
+	BeforeCreateAt int64  // if non-zero then filter for mentions created before this time
+	Limit          uint64 // if non-zero then limit the number of returned records
+}
+
+// MarkMentionsReadRequest is the body of a request to mark mentions as read
+// swagger:model
+type MarkMentionsReadRequest struct {
+	// The IDs of the mentions to mark as read; all the mentions of the user if empty
+	// required: false
+	MentionIDs []string `json:"mentionIds"`
+}
+
+func MarkMentionsReadRequestFromJSON(data io.Reader) (*MarkMentionsReadRequest, error) {
+	var request MarkMentionsReadRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
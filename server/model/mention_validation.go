@@ -0,0 +1,43 @@
+package model
+
+const (
+	// MentionStatusResolved is a mention of a user that is notified.
+	MentionStatusResolved = "resolved"
+	// MentionStatusAdded is a mention of a user that is added to the
+	// board, then notified.
+	MentionStatusAdded = "added"
+	// MentionStatusRejected is a mention of a user that the author is not
+	// allowed to mention.
+	MentionStatusRejected = "rejected"
+	// MentionStatusUnknown is a mention that doesn't match any active user.
+	MentionStatusUnknown = "unknown"
+)
+
+// MentionValidationRequest is the draft text to validate the mentions of
+// swagger:model
+type MentionValidationRequest struct {
+	// The text of the block being edited
+	// required: true
+	Text string `json:"text"`
+}
+
+// MentionValidation is the outcome a mention of a draft text will have
+// when the text is saved
+// swagger:model
+type MentionValidation struct {
+	// The mentioned username, without the @
+	// required: true
+	Username string `json:"username"`
+
+	// The ID of the mentioned user, if the username matches a user
+	// required: false
+	UserID string `json:"userId,omitempty"`
+
+	// The outcome of the mention: resolved, added, rejected or unknown
+	// required: true
+	Status string `json:"status"`
+
+	// The reason the mention is rejected
+	// required: false
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,35 @@
+package model
+
+// MigrationStatus reports the schema version of the database and the
+// migrations that haven't been applied to it yet
+// swagger:model
+type MigrationStatus struct {
+	// The highest migration version applied to the database
+	// required: true
+	CurrentVersion uint32 `json:"currentVersion"`
+
+	// The highest migration version known to the server
+	// required: true
+	LatestVersion uint32 `json:"latestVersion"`
+
+	// The migrations that are pending, in the order they would run
+	// required: true
+	Pending []*PendingMigration `json:"pending"`
+}
+
+// PendingMigration is a migration that hasn't been applied to the
+// database yet
+// swagger:model
+type PendingMigration struct {
+	// The version of the migration
+	// required: true
+	Version uint32 `json:"version"`
+
+	// The name of the migration
+	// required: true
+	Name string `json:"name"`
+
+	// The SQL the migration would execute, only set on dry runs
+	// required: false
+	SQL string `json:"sql,omitempty"`
+}
@@ -0,0 +1,60 @@
+package model
+
+// IsPersonPropertyType returns true for the types of the properties whose
+// values are users: person properties hold one user and multi-person
+// properties a list of them.
+func IsPersonPropertyType(propType string) bool {
+	return propType == propTypePerson || propType == propTypeMultiPerson
+}
+
+// HasMultiPersonProperty returns true if the schema has a multi-person
+// property.
+func (s PropSchema) HasMultiPersonProperty() bool {
+	for _, def := range s {
+		if def.Type == propTypeMultiPerson {
+			return true
+		}
+	}
+	return false
+}
+
+// AddedMultiPersonUsers returns the users added to the multi-person
+// properties of the card, compared to the old card, which is nil for a
+// new card. The values of the properties must be lists of user IDs.
+func AddedMultiPersonUsers(card, oldCard *Block, schema PropSchema) ([]string, error) {
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	var oldProps map[string]interface{}
+	if oldCard != nil {
+		oldProps, _ = oldCard.Fields["properties"].(map[string]interface{})
+	}
+
+	added := []string{}
+	for id, value := range props {
+		if def, ok := schema[id]; !ok || def.Type != propTypeMultiPerson || value == nil {
+			continue
+		}
+		userIDs, ok := value.([]interface{})
+		if !ok {
+			return nil, NewCodedError(ErrCodeBadRequest, "the value of a multi-person property must be a list of users", map[string]interface{}{"blockId": card.ID, "propertyId": id})
+		}
+		for _, item := range userIDs {
+			userID, ok := item.(string)
+			if !ok || userID == "" {
+				return nil, NewCodedError(ErrCodeBadRequest, "the value of a multi-person property must be a list of users", map[string]interface{}{"blockId": card.ID, "propertyId": id})
+			}
+			if !isAssignedTo(oldProps[id], userID) && !containsString(added, userID) {
+				added = append(added, userID)
+			}
+		}
+	}
+	return added, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddedMultiPersonUsers(t *testing.T) {
+	schema := PropSchema{
+		"assignee": {ID: "assignee", Name: "Assignee", Type: "person"},
+		"owners":   {ID: "owners", Name: "Owners", Type: "multiPerson"},
+	}
+	card := func(props map[string]interface{}) *Block {
+		return &Block{ID: "card-id", Type: TypeCard, Fields: map[string]interface{}{"properties": props}}
+	}
+
+	t.Run("all the users of a new card are added", func(t *testing.T) {
+		added, err := AddedMultiPersonUsers(card(map[string]interface{}{
+			"assignee": "user-1",
+			"owners":   []interface{}{"user-2", "user-3", "user-2"},
+		}), nil, schema)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"user-2", "user-3"}, added)
+	})
+
+	t.Run("only the new users of a changed card are added", func(t *testing.T) {
+		oldCard := card(map[string]interface{}{"owners": []interface{}{"user-2"}})
+		added, err := AddedMultiPersonUsers(card(map[string]interface{}{"owners": []interface{}{"user-2", "user-4"}}), oldCard, schema)
+		require.NoError(t, err)
+		require.Equal(t, []string{"user-4"}, added)
+
+		added, err = AddedMultiPersonUsers(card(map[string]interface{}{"owners": nil}), oldCard, schema)
+		require.NoError(t, err)
+		require.Empty(t, added)
+	})
+
+	t.Run("values that are not lists of users are rejected", func(t *testing.T) {
+		_, err := AddedMultiPersonUsers(card(map[string]interface{}{"owners": "user-2"}), nil, schema)
+		require.Error(t, err)
+
+		_, err = AddedMultiPersonUsers(card(map[string]interface{}{"owners": []interface{}{"user-2", 3}}), nil, schema)
+		require.Error(t, err)
+
+		_, err = AddedMultiPersonUsers(card(map[string]interface{}{"owners": []interface{}{""}}), nil, schema)
+		require.Error(t, err)
+	})
+}
+
+func TestResolveMultiPersonValues(t *testing.T) {
+	schema := PropSchema{"owners": {ID: "owners", Name: "Owners", Type: "multiPerson"}}
+
+	resolved, err := schema.ResolvePropertyValues(map[string]string{"Owners": "user-1, user-2,user-1"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"owners": []interface{}{"user-1", "user-2"}}, resolved)
+}
+
+func TestMultiPersonGetValue(t *testing.T) {
+	def := PropDef{ID: "owners", Name: "Owners", Type: "multiPerson"}
+
+	value, err := def.GetValue([]interface{}{"user-1", "user-2"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "user-1, user-2", value)
+
+	_, err = def.GetValue("user-1", nil)
+	require.ErrorIs(t, err, ErrInvalidPropertyValueType)
+}
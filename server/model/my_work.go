@@ -0,0 +1,263 @@
+package model
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+const (
+	propTypePerson      = "person"
+	propTypeMultiPerson = "multiPerson"
+	propTypeRelation    = "relation"
+	propTypeSelect      = "select"
+	propTypeCreatedBy   = "createdBy"
+	propTypeCreatedTime = "createdTime"
+	propTypeUpdatedBy   = "updatedBy"
+	propTypeUpdatedTime = "updatedTime"
+
+	statusPropertyName = "status"
+
+	// BoardPropertyStatusProperty is the board property holding the ID of
+	// the card property used as the status of the cards.
+	BoardPropertyStatusProperty = "statusPropertyId"
+)
+
+// MyWorkQuery filters the cards assigned to a user
+type MyWorkQuery struct {
+	// Only return cards due before this time, in miliseconds
+	DueBefore int64
+
+	// Only return cards due after this time, in miliseconds
+	DueAfter int64
+
+	// Only return cards with one of these status values
+	Statuses []string
+
+	// Only return cards due today in the timezone of the user
+	DueToday bool
+}
+
+// MyWorkCard is a card assigned to a user, with the details needed to
+// show it outside of its board
+// swagger:model
+type MyWorkCard struct {
+	// The card
+	// required: true
+	Card Block `json:"card"`
+
+	// The title of the board of the card
+	// required: true
+	BoardTitle string `json:"boardTitle"`
+
+	// The due date of the card in miliseconds, taken from its first date property
+	// required: false
+	DueDate int64 `json:"dueDate,omitempty"`
+
+	// The status of the card, taken from its "Status" select property
+	// required: false
+	Status string `json:"status,omitempty"`
+
+	// Indicates if the card is due today in the timezone of the user
+	// required: false
+	DueToday bool `json:"dueToday,omitempty"`
+}
+
+// HasPersonProperty returns true if the schema has a property that
+// cards can be assigned with.
+func (s PropSchema) HasPersonProperty() bool {
+	for _, def := range s {
+		if IsPersonPropertyType(def.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstOfType returns the first property of the schema, by index, with
+// the given type and optionally name.
+func (s PropSchema) firstOfType(propType, name string) (PropDef, bool) {
+	var found PropDef
+	ok := false
+	for _, def := range s {
+		if def.Type != propType || (name != "" && !strings.EqualFold(def.Name, name)) {
+			continue
+		}
+		if !ok || def.Index < found.Index {
+			found = def
+			ok = true
+		}
+	}
+	return found, ok
+}
+
+// StatusProperty returns the card property used as the status of the
+// cards of the board: the one designated in the board properties, or
+// else the first select property named "Status".
+func (s PropSchema) StatusProperty(board *Board) (PropDef, bool) {
+	if board != nil {
+		if id, ok := board.Properties[BoardPropertyStatusProperty].(string); ok {
+			if def, ok := s[id]; ok {
+				return def, true
+			}
+		}
+	}
+	return s.firstOfType(propTypeSelect, statusPropertyName)
+}
+
+// MatchMyWork returns the card as a MyWorkCard if one of its person
+// properties is the user and it passes the query filters.
+func MatchMyWork(card Block, board *Board, schema PropSchema, userID string, query MyWorkQuery) (*MyWorkCard, bool) {
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	if len(props) == 0 {
+		return nil, false
+	}
+
+	assigned := false
+	for id, value := range props {
+		if def, ok := schema[id]; ok && IsPersonPropertyType(def.Type) && isAssignedTo(value, userID) {
+			assigned = true
+			break
+		}
+	}
+	if !assigned {
+		return nil, false
+	}
+
+	result := &MyWorkCard{Card: card, BoardTitle: board.Title}
+
+	if def, ok := schema.firstOfType(propTypeDate, ""); ok {
+		if value, ok := props[def.ID].(string); ok {
+			result.DueDate = parseDateFrom(value)
+		}
+	}
+	if def, ok := schema.StatusProperty(board); ok {
+		if value, ok := props[def.ID].(string); ok {
+			result.Status = value
+			if def.Type == propTypeSelect {
+				result.Status = def.Options[value].Value
+			}
+		}
+	}
+
+	if query.DueBefore != 0 && (result.DueDate == 0 || result.DueDate > query.DueBefore) {
+		return nil, false
+	}
+	if query.DueAfter != 0 && (result.DueDate == 0 || result.DueDate < query.DueAfter) {
+		return nil, false
+	}
+	if len(query.Statuses) > 0 && !containsFold(query.Statuses, result.Status) {
+		return nil, false
+	}
+	return result, true
+}
+
+// SortMyWork sorts the cards by due date, cards without due date last,
+// and then by most recently updated.
+func SortMyWork(cards []*MyWorkCard) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, b := cards[i], cards[j]
+		if a.DueDate != b.DueDate {
+			if a.DueDate == 0 || b.DueDate == 0 {
+				return b.DueDate == 0
+			}
+			return a.DueDate < b.DueDate
+		}
+		return a.Card.UpdateAt > b.Card.UpdateAt
+	})
+}
+
+// CardAssignees returns the IDs of the users referenced by the person and
+// multi-person properties of the card.
+func CardAssignees(card *Block, schema PropSchema) map[string]struct{} {
+	assignees := map[string]struct{}{}
+	if card == nil {
+		return assignees
+	}
+
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	for id, value := range props {
+		if def, ok := schema[id]; !ok || !IsPersonPropertyType(def.Type) {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			if v != "" {
+				assignees[v] = struct{}{}
+			}
+		case []interface{}:
+			for _, item := range v {
+				if userID, ok := item.(string); ok && userID != "" {
+					assignees[userID] = struct{}{}
+				}
+			}
+		}
+	}
+	return assignees
+}
+
+// ReassignCard returns the properties of the card with the person
+// properties that reference fromUserID set to toUserID, and true if any
+// of them changed.
+func ReassignCard(card *Block, schema PropSchema, fromUserID, toUserID string) (map[string]interface{}, bool) {
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	newProps := make(map[string]interface{}, len(props))
+	changed := false
+	for id, value := range props {
+		newProps[id] = value
+		if def, ok := schema[id]; !ok || !IsPersonPropertyType(def.Type) || !isAssignedTo(value, fromUserID) {
+			continue
+		}
+		changed = true
+		switch v := value.(type) {
+		case string:
+			newProps[id] = toUserID
+		case []interface{}:
+			userIDs := []interface{}{}
+			for _, item := range v {
+				if item == fromUserID {
+					item = toUserID
+				}
+				if userID, ok := item.(string); ok && isAssignedTo(userIDs, userID) {
+					continue
+				}
+				userIDs = append(userIDs, item)
+			}
+			newProps[id] = userIDs
+		}
+	}
+	return newProps, changed
+}
+
+func isAssignedTo(value interface{}, userID string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == userID
+	case []interface{}:
+		for _, item := range v {
+			if item == userID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseDateFrom returns the start of a date property value, or zero if
+// the value isn't a valid date.
+func parseDateFrom(value string) int64 {
+	var date map[string]int64
+	if err := json.Unmarshal([]byte(value), &date); err != nil {
+		return 0
+	}
+	return date["from"]
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchMyWork(t *testing.T) {
+	board := &Board{Title: "Sprint board"}
+	schema := PropSchema{
+		"assignee": {ID: "assignee", Index: 0, Name: "Assignee", Type: "person"},
+		"reviewer": {ID: "reviewer", Index: 1, Name: "Reviewer", Type: "person"},
+		"status": {ID: "status", Index: 2, Name: "Status", Type: "select", Options: map[string]PropDefOption{
+			"opt-todo": {ID: "opt-todo", Value: "To Do"},
+			"opt-done": {ID: "opt-done", Value: "Done"},
+		}},
+		"due":   {ID: "due", Index: 3, Name: "Due", Type: "date"},
+		"start": {ID: "start", Index: 4, Name: "Start", Type: "date"},
+	}
+
+	card := func(props map[string]interface{}) Block {
+		return Block{ID: "card-id", Type: TypeCard, Fields: map[string]interface{}{"properties": props}}
+	}
+
+	t.Run("cards not assigned to the user don't match", func(t *testing.T) {
+		_, ok := MatchMyWork(card(map[string]interface{}{"assignee": "other-user"}), board, schema, "user-id", MyWorkQuery{})
+		require.False(t, ok)
+
+		_, ok = MatchMyWork(card(map[string]interface{}{"unknown": "user-id"}), board, schema, "user-id", MyWorkQuery{})
+		require.False(t, ok)
+
+		_, ok = MatchMyWork(Block{ID: "card-id", Type: TypeCard}, board, schema, "user-id", MyWorkQuery{})
+		require.False(t, ok)
+	})
+
+	t.Run("any person property matches", func(t *testing.T) {
+		result, ok := MatchMyWork(card(map[string]interface{}{
+			"reviewer": "user-id",
+			"status":   "opt-todo",
+			"due":      `{"from":1000}`,
+			"start":    `{"from":500}`,
+		}), board, schema, "user-id", MyWorkQuery{})
+		require.True(t, ok)
+		require.Equal(t, "Sprint board", result.BoardTitle)
+		require.Equal(t, int64(1000), result.DueDate)
+		require.Equal(t, "To Do", result.Status)
+	})
+
+	t.Run("multiple person values", func(t *testing.T) {
+		_, ok := MatchMyWork(card(map[string]interface{}{"assignee": []interface{}{"other-user", "user-id"}}), board, schema, "user-id", MyWorkQuery{})
+		require.True(t, ok)
+	})
+
+	t.Run("multi-person properties match", func(t *testing.T) {
+		multiSchema := PropSchema{"owners": {ID: "owners", Name: "Owners", Type: "multiPerson"}}
+
+		_, ok := MatchMyWork(card(map[string]interface{}{"owners": []interface{}{"other-user", "user-id"}}), board, multiSchema, "user-id", MyWorkQuery{})
+		require.True(t, ok)
+		require.True(t, multiSchema.HasPersonProperty())
+	})
+
+	t.Run("due date filters", func(t *testing.T) {
+		assigned := card(map[string]interface{}{"assignee": "user-id", "due": `{"from":1000}`})
+		unscheduled := card(map[string]interface{}{"assignee": "user-id"})
+
+		_, ok := MatchMyWork(assigned, board, schema, "user-id", MyWorkQuery{DueBefore: 2000})
+		require.True(t, ok)
+		_, ok = MatchMyWork(assigned, board, schema, "user-id", MyWorkQuery{DueBefore: 500})
+		require.False(t, ok)
+		_, ok = MatchMyWork(assigned, board, schema, "user-id", MyWorkQuery{DueAfter: 500})
+		require.True(t, ok)
+		_, ok = MatchMyWork(assigned, board, schema, "user-id", MyWorkQuery{DueAfter: 2000})
+		require.False(t, ok)
+		_, ok = MatchMyWork(unscheduled, board, schema, "user-id", MyWorkQuery{DueBefore: 2000})
+		require.False(t, ok)
+	})
+
+	t.Run("status filter is case insensitive", func(t *testing.T) {
+		assigned := card(map[string]interface{}{"assignee": "user-id", "status": "opt-done"})
+
+		_, ok := MatchMyWork(assigned, board, schema, "user-id", MyWorkQuery{Statuses: []string{"to do", "done"}})
+		require.True(t, ok)
+		_, ok = MatchMyWork(assigned, board, schema, "user-id", MyWorkQuery{Statuses: []string{"to do"}})
+		require.False(t, ok)
+	})
+}
+
+func TestSortMyWork(t *testing.T) {
+	cards := []*MyWorkCard{
+		{Card: Block{ID: "no-due-old", UpdateAt: 1}},
+		{Card: Block{ID: "due-late"}, DueDate: 2000},
+		{Card: Block{ID: "no-due-new", UpdateAt: 2}},
+		{Card: Block{ID: "due-soon"}, DueDate: 1000},
+	}
+
+	SortMyWork(cards)
+
+	ids := make([]string, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.Card.ID)
+	}
+	require.Equal(t, []string{"due-soon", "due-late", "no-due-new", "no-due-old"}, ids)
+}
+
+func TestCardAssignees(t *testing.T) {
+	schema := PropSchema{
+		"assignee":  {ID: "assignee", Name: "Assignee", Type: "person"},
+		"reviewers": {ID: "reviewers", Name: "Reviewers", Type: "person"},
+		"owner":     {ID: "owner", Name: "Owner", Type: "text"},
+		"owners":    {ID: "owners", Name: "Owners", Type: "multiPerson"},
+	}
+	card := &Block{
+		Type: TypeCard,
+		Fields: map[string]interface{}{"properties": map[string]interface{}{
+			"assignee":  "user-1",
+			"reviewers": []interface{}{"user-2", "user-1"},
+			"owner":     "user-3",
+			"unknown":   "user-4",
+			"owners":    []interface{}{"user-5"},
+		}},
+	}
+
+	require.Equal(t, map[string]struct{}{"user-1": {}, "user-2": {}, "user-5": {}}, CardAssignees(card, schema))
+	require.Empty(t, CardAssignees(nil, schema))
+}
+
+func TestReassignCard(t *testing.T) {
+	schema := PropSchema{
+		"assignee":  {ID: "assignee", Name: "Assignee", Type: "person"},
+		"reviewers": {ID: "reviewers", Name: "Reviewers", Type: "person"},
+		"owner":     {ID: "owner", Name: "Owner", Type: "text"},
+	}
+	card := &Block{
+		Type: TypeCard,
+		Fields: map[string]interface{}{"properties": map[string]interface{}{
+			"assignee":  "user-1",
+			"reviewers": []interface{}{"user-2", "user-1"},
+			"owner":     "user-1",
+		}},
+	}
+
+	t.Run("reassign to a user", func(t *testing.T) {
+		props, changed := ReassignCard(card, schema, "user-1", "user-3")
+		require.True(t, changed)
+		require.Equal(t, map[string]interface{}{
+			"assignee":  "user-3",
+			"reviewers": []interface{}{"user-2", "user-3"},
+			"owner":     "user-1",
+		}, props)
+	})
+
+	t.Run("reassign to a user already assigned", func(t *testing.T) {
+		props, changed := ReassignCard(card, schema, "user-1", "user-2")
+		require.True(t, changed)
+		require.Equal(t, []interface{}{"user-2"}, props["reviewers"])
+	})
+
+	t.Run("card not assigned to the user", func(t *testing.T) {
+		_, changed := ReassignCard(card, schema, "user-4", "user-3")
+		require.False(t, changed)
+	})
+}
+
+func TestStatusProperty(t *testing.T) {
+	schema := PropSchema{
+		"status": {ID: "status", Index: 0, Name: "Status", Type: "select"},
+		"stage":  {ID: "stage", Index: 1, Name: "Stage", Type: "select"},
+		"notes":  {ID: "notes", Index: 2, Name: "Notes", Type: "text"},
+	}
+
+	t.Run("select property named status by default", func(t *testing.T) {
+		def, ok := schema.StatusProperty(&Board{})
+		require.True(t, ok)
+		require.Equal(t, "status", def.ID)
+	})
+
+	t.Run("designated property", func(t *testing.T) {
+		board := &Board{Properties: map[string]interface{}{BoardPropertyStatusProperty: "stage"}}
+		def, ok := schema.StatusProperty(board)
+		require.True(t, ok)
+		require.Equal(t, "stage", def.ID)
+	})
+
+	t.Run("unknown designated property falls back to default", func(t *testing.T) {
+		board := &Board{Properties: map[string]interface{}{BoardPropertyStatusProperty: "deleted"}}
+		def, ok := schema.StatusProperty(board)
+		require.True(t, ok)
+		require.Equal(t, "status", def.ID)
+	})
+
+	t.Run("no status property", func(t *testing.T) {
+		_, ok := PropSchema{"notes": schema["notes"]}.StatusProperty(nil)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	// UserPropNotificationPreferences is the user prop holding the
+	// notification preferences of the user, as JSON.
+	UserPropNotificationPreferences = "focalboard_notificationPreferences"
+
+	NotifyEventMention     = "mention"
+	NotifyEventAssignment  = "assignment"
+	NotifyEventDueDigest   = "dueDigest"
+	NotifyEventStaleDigest = "staleDigest"
+
+	NotifyChannelDM      = "dm"
+	NotifyChannelEmail   = "email"
+	NotifyChannelWebhook = "webhook"
+)
+
+// DefaultNotificationChannels are the channels used for the event types
+// the user has no preference for.
+var DefaultNotificationChannels = []string{NotifyChannelDM}
+
+// NotificationPreferences are the channels a user wants to be notified
+// through, by event type
+// swagger:model
+type NotificationPreferences map[string][]string
+
+// IsValid returns an error if the preferences contain unknown event
+// types or channels.
+func (p NotificationPreferences) IsValid() error {
+	for eventType, channels := range p {
+		switch eventType {
+		case NotifyEventMention, NotifyEventAssignment, NotifyEventDueDigest, NotifyEventStaleDigest:
+		default:
+			return ErrInvalidNotificationPreferences{fmt.Sprintf("unknown event type %s", eventType)}
+		}
+		for _, channel := range channels {
+			switch channel {
+			case NotifyChannelDM, NotifyChannelEmail, NotifyChannelWebhook:
+			default:
+				return ErrInvalidNotificationPreferences{fmt.Sprintf("unknown channel %s", channel)}
+			}
+		}
+	}
+	return nil
+}
+
+// ChannelsFor returns the channels selected for the event type without
+// repetitions, or the default channels if there is no preference. An
+// empty list turns the notifications of that type off.
+func (p NotificationPreferences) ChannelsFor(eventType string) []string {
+	channels, ok := p[eventType]
+	if !ok {
+		return DefaultNotificationChannels
+	}
+
+	seen := map[string]bool{}
+	result := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		if !seen[channel] {
+			seen[channel] = true
+			result = append(result, channel)
+		}
+	}
+	return result
+}
+
+// NotificationPreferences returns the notification preferences of the
+// user. Preferences that can't be parsed are ignored.
+func (u *User) NotificationPreferences() NotificationPreferences {
+	prefs := NotificationPreferences{}
+	if value, ok := u.Props[UserPropNotificationPreferences].(string); ok && value != "" {
+		if err := json.Unmarshal([]byte(value), &prefs); err != nil {
+			return NotificationPreferences{}
+		}
+	}
+	return prefs
+}
+
+func NotificationPreferencesFromJSON(data io.Reader) (NotificationPreferences, error) {
+	var prefs NotificationPreferences
+	if err := json.NewDecoder(data).Decode(&prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+type ErrInvalidNotificationPreferences struct {
+	msg string
+}
+
+func (e ErrInvalidNotificationPreferences) Error() string {
+	return e.msg
+}
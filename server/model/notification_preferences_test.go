@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreferences(t *testing.T) {
+	t.Run("validation", func(t *testing.T) {
+		require.NoError(t, NotificationPreferences{}.IsValid())
+		require.NoError(t, NotificationPreferences{NotifyEventMention: {NotifyChannelDM, NotifyChannelEmail}}.IsValid())
+		require.Error(t, NotificationPreferences{"unknown": {NotifyChannelDM}}.IsValid())
+		require.Error(t, NotificationPreferences{NotifyEventMention: {"pigeon"}}.IsValid())
+		require.Error(t, NotificationPreferences{NotifyEventMention: {"push"}}.IsValid(), "there is no push notification channel")
+	})
+
+	t.Run("channels for event type", func(t *testing.T) {
+		prefs := NotificationPreferences{
+			NotifyEventMention:    {NotifyChannelEmail, NotifyChannelDM, NotifyChannelEmail},
+			NotifyEventAssignment: {},
+		}
+		require.Equal(t, []string{NotifyChannelEmail, NotifyChannelDM}, prefs.ChannelsFor(NotifyEventMention))
+		require.Empty(t, prefs.ChannelsFor(NotifyEventAssignment))
+		require.Equal(t, DefaultNotificationChannels, NotificationPreferences{}.ChannelsFor(NotifyEventMention))
+	})
+
+	t.Run("user preferences", func(t *testing.T) {
+		user := &User{Props: map[string]interface{}{
+			UserPropNotificationPreferences: `{"mention":["webhook"]}`,
+		}}
+		require.Equal(t, []string{NotifyChannelWebhook}, user.NotificationPreferences().ChannelsFor(NotifyEventMention))
+
+		user.Props[UserPropNotificationPreferences] = "not json"
+		require.Empty(t, user.NotificationPreferences())
+		require.Empty(t, (&User{}).NotificationPreferences())
+	})
+}
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"strconv"
+)
+
+const (
+	// UserPropWelcomePageViewed is the user prop set once the user has
+	// seen the welcome page.
+	UserPropWelcomePageViewed = "focalboard_welcomePageViewed"
+
+	// UserPropOnboardingTourStarted is the user prop set once the user
+	// has started the onboarding tour.
+	UserPropOnboardingTourStarted = "focalboard_onboardingTourStarted"
+
+	// UserPropOnboardingTourCategory is the user prop holding the part of
+	// the onboarding tour the user is in.
+	UserPropOnboardingTourCategory = "focalboard_tourCategory"
+
+	// UserPropOnboardingTourStep is the user prop holding the step of the
+	// onboarding tour the user is at.
+	UserPropOnboardingTourStep = "focalboard_onboardingTourStep"
+
+	// UserPropWelcomeBoardID is the user prop holding the ID of the
+	// welcome board created for the user's onboarding tour.
+	UserPropWelcomeBoardID = "focalboard_welcomeBoardId"
+
+	TourCategoryOnboarding = "onboarding"
+	TourCategoryCard       = "card"
+	TourCategoryBoard      = "board"
+)
+
+// OnboardingState is the progress of a user through the onboarding tour,
+// kept with the user so that it follows them across devices
+// swagger:model
+type OnboardingState struct {
+	// Indicates if the user has seen the welcome page
+	// required: true
+	WelcomePageViewed bool `json:"welcomePageViewed"`
+
+	// Indicates if the user has started the tour
+	// required: true
+	TourStarted bool `json:"tourStarted"`
+
+	// The part of the tour the user is in: onboarding, card or board
+	// required: false
+	TourCategory string `json:"tourCategory"`
+
+	// The step of the tour the user is at
+	// required: false
+	TourStep string `json:"tourStep"`
+
+	// The ID of the welcome board created for the tour, if any
+	// required: false
+	WelcomeBoardID string `json:"welcomeBoardId"`
+}
+
+// OnboardingStatePatch is a patch of the onboarding state of a user. The
+// welcome board can't be patched, as it is created by the server
+// swagger:model
+type OnboardingStatePatch struct {
+	// Indicates if the user has seen the welcome page
+	// required: false
+	WelcomePageViewed *bool `json:"welcomePageViewed"`
+
+	// Indicates if the user has started the tour
+	// required: false
+	TourStarted *bool `json:"tourStarted"`
+
+	// The part of the tour the user is in: onboarding, card or board
+	// required: false
+	TourCategory *string `json:"tourCategory"`
+
+	// The step of the tour the user is at
+	// required: false
+	TourStep *string `json:"tourStep"`
+}
+
+// OnboardingState returns the onboarding state of the user.
+func (u *User) OnboardingState() *OnboardingState {
+	return &OnboardingState{
+		WelcomePageViewed: isUserPropSet(u.Props[UserPropWelcomePageViewed]),
+		TourStarted:       isUserPropSet(u.Props[UserPropOnboardingTourStarted]),
+		TourCategory:      userPropString(u.Props[UserPropOnboardingTourCategory]),
+		TourStep:          userPropString(u.Props[UserPropOnboardingTourStep]),
+		WelcomeBoardID:    userPropString(u.Props[UserPropWelcomeBoardID]),
+	}
+}
+
+// isUserPropSet returns true for the flags the web app considers set,
+// which are any non empty value.
+func isUserPropSet(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v != ""
+	}
+	return false
+}
+
+func userPropString(value interface{}) string {
+	v, _ := value.(string)
+	return v
+}
+
+// IsValid returns an error if the patch holds an unknown tour category
+// or a step that isn't a number.
+func (p *OnboardingStatePatch) IsValid() error {
+	if p.TourCategory != nil {
+		switch *p.TourCategory {
+		case "", TourCategoryOnboarding, TourCategoryCard, TourCategoryBoard:
+		default:
+			return NewCodedError(ErrCodeBadRequest, "invalid tour category", map[string]interface{}{"tourCategory": *p.TourCategory})
+		}
+	}
+	if p.TourStep != nil && *p.TourStep != "" {
+		if step, err := strconv.Atoi(*p.TourStep); err != nil || step < 0 {
+			return NewCodedError(ErrCodeBadRequest, "invalid tour step", map[string]interface{}{"tourStep": *p.TourStep})
+		}
+	}
+	return nil
+}
+
+// UserPropPatch returns the patch of the user props applying the patch.
+// The cleared flags and values are removed from the props.
+func (p *OnboardingStatePatch) UserPropPatch() UserPropPatch {
+	patch := UserPropPatch{UpdatedFields: map[string]string{}}
+
+	setFlag := func(key string, value *bool) {
+		switch {
+		case value == nil:
+		case *value:
+			patch.UpdatedFields[key] = "1"
+		default:
+			patch.DeletedFields = append(patch.DeletedFields, key)
+		}
+	}
+	setString := func(key string, value *string) {
+		switch {
+		case value == nil:
+		case *value != "":
+			patch.UpdatedFields[key] = *value
+		default:
+			patch.DeletedFields = append(patch.DeletedFields, key)
+		}
+	}
+
+	setFlag(UserPropWelcomePageViewed, p.WelcomePageViewed)
+	setFlag(UserPropOnboardingTourStarted, p.TourStarted)
+	setString(UserPropOnboardingTourCategory, p.TourCategory)
+	setString(UserPropOnboardingTourStep, p.TourStep)
+	return patch
+}
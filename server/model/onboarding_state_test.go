@@ -0,0 +1,62 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserOnboardingState(t *testing.T) {
+	user := &User{
+		Props: map[string]interface{}{
+			UserPropWelcomePageViewed:      "1",
+			UserPropOnboardingTourStarted:  true,
+			UserPropOnboardingTourCategory: TourCategoryCard,
+			UserPropOnboardingTourStep:     "2",
+			UserPropWelcomeBoardID:         "board-id",
+		},
+	}
+
+	require.Equal(t, &OnboardingState{
+		WelcomePageViewed: true,
+		TourStarted:       true,
+		TourCategory:      TourCategoryCard,
+		TourStep:          "2",
+		WelcomeBoardID:    "board-id",
+	}, user.OnboardingState())
+
+	require.Equal(t, &OnboardingState{}, (&User{}).OnboardingState())
+}
+
+func TestOnboardingStatePatchIsValid(t *testing.T) {
+	category := "unknown"
+	require.Error(t, (&OnboardingStatePatch{TourCategory: &category}).IsValid())
+
+	step := "next"
+	require.Error(t, (&OnboardingStatePatch{TourStep: &step}).IsValid())
+
+	category = TourCategoryBoard
+	step = "3"
+	require.NoError(t, (&OnboardingStatePatch{TourCategory: &category, TourStep: &step}).IsValid())
+}
+
+func TestOnboardingStatePatchUserPropPatch(t *testing.T) {
+	viewed := true
+	started := false
+	step := "1"
+	category := ""
+	patch := &OnboardingStatePatch{
+		WelcomePageViewed: &viewed,
+		TourStarted:       &started,
+		TourCategory:      &category,
+		TourStep:          &step,
+	}
+
+	require.Equal(t, UserPropPatch{
+		UpdatedFields: map[string]string{
+			UserPropWelcomePageViewed:  "1",
+			UserPropOnboardingTourStep: "1",
+		},
+		DeletedFields: []string{UserPropOnboardingTourStarted, UserPropOnboardingTourCategory},
+	}, patch.UserPropPatch())
+}
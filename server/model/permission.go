@@ -5,10 +5,13 @@ import (
 )
 
 var (
+	PermissionManageSystem          = mmModel.PermissionManageSystem
 	PermissionViewTeam              = mmModel.PermissionViewTeam
 	PermissionViewMembers           = mmModel.PermissionViewMembers
 	PermissionCreatePublicChannel   = mmModel.PermissionCreatePublicChannel
 	PermissionCreatePrivateChannel  = mmModel.PermissionCreatePrivateChannel
+	PermissionReadChannel           = mmModel.PermissionReadChannel
+	PermissionCreatePost            = mmModel.PermissionCreatePost
 	PermissionManageBoardType       = &mmModel.Permission{Id: "manage_board_type", Name: "", Description: "", Scope: ""}
 	PermissionDeleteBoard           = &mmModel.Permission{Id: "delete_board", Name: "", Description: "", Scope: ""}
 	PermissionViewBoard             = &mmModel.Permission{Id: "view_board", Name: "", Description: "", Scope: ""}
@@ -0,0 +1,93 @@
+package model
+
+import "errors"
+
+var (
+	ErrPersonalBoardsTeamIDRequired = errors.New("the ID of the destination team is required")
+	ErrPersonalBoardsInvalidTeamID  = errors.New("the boards cannot be migrated to the personal team")
+)
+
+const (
+	PersonalBoardMigrated = "migrated"
+	PersonalBoardSkipped  = "skipped"
+	PersonalBoardFailed   = "failed"
+)
+
+// PersonalBoardsMigrationRequest is a request to move the boards of the
+// personal team into a team
+// swagger:model
+type PersonalBoardsMigrationRequest struct {
+	// The ID of the team to move the boards to
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The IDs of the boards to move. Empty moves all the boards of the
+	// personal team, except the templates
+	// required: false
+	BoardIDs []string `json:"boardIds"`
+}
+
+// IsValid checks that the request can be processed.
+func (r *PersonalBoardsMigrationRequest) IsValid() error {
+	if r.TeamID == "" {
+		return ErrPersonalBoardsTeamIDRequired
+	}
+	if r.TeamID == GlobalTeamID {
+		return ErrPersonalBoardsInvalidTeamID
+	}
+	return nil
+}
+
+// PersonalBoardsMigrationReport describes the outcome of a migration of
+// personal boards
+// swagger:model
+type PersonalBoardsMigrationReport struct {
+	// The ID of the team the boards were moved to
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The outcome for each board, in the order they were processed
+	// required: true
+	Boards []*PersonalBoardMigration `json:"boards"`
+
+	// The number of boards moved
+	// required: true
+	Migrated int `json:"migrated"`
+
+	// The number of boards that could not be moved
+	// required: true
+	Failed int `json:"failed"`
+
+	// The time the migration started, in miliseconds
+	// required: true
+	StartAt int64 `json:"startAt"`
+
+	// The time the migration ended, in miliseconds
+	// required: true
+	EndAt int64 `json:"endAt"`
+}
+
+// PersonalBoardMigration is the outcome of the migration of a personal
+// board
+// swagger:model
+type PersonalBoardMigration struct {
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the board
+	// required: false
+	Title string `json:"title"`
+
+	// The outcome of the migration: migrated, skipped or failed
+	// required: true
+	Status string `json:"status"`
+
+	// Why the board was skipped or could not be moved
+	// required: false
+	Error string `json:"error,omitempty"`
+
+	// The number of files moved to the folder of the new team
+	// required: true
+	MovedFiles int `json:"movedFiles"`
+}
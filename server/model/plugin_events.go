@@ -0,0 +1,122 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// PluginEventType is the type of the board events delivered to other
+// plugins.
+type PluginEventType string
+
+const (
+	PluginEventCardCreated         PluginEventType = "card_created"
+	PluginEventCardPropertyChanged PluginEventType = "card_property_changed"
+	PluginEventMemberAdded         PluginEventType = "member_added"
+)
+
+func IsPluginEventTypeValid(eventType PluginEventType) bool {
+	switch eventType {
+	case PluginEventCardCreated, PluginEventCardPropertyChanged, PluginEventMemberAdded:
+		return true
+	}
+	return false
+}
+
+// PluginEventSubscription is the subscription of another plugin to the
+// board events. The events are POSTed to the callback path of the
+// plugin through the inter-plugin HTTP API.
+// swagger:model
+type PluginEventSubscription struct {
+	// The ID of the subscribed plugin. Set from the inter-plugin request
+	// required: false
+	PluginID string `json:"pluginId"`
+
+	// The path, on the subscribed plugin, the events are delivered to
+	// required: true
+	CallbackPath string `json:"callbackPath"`
+
+	// The types of events to deliver. All if empty
+	// required: false
+	Events []PluginEventType `json:"events"`
+}
+
+// Wants returns true if the subscription includes the event type.
+func (s *PluginEventSubscription) Wants(eventType PluginEventType) bool {
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, e := range s.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PluginEventSubscription) IsValid() error {
+	if s.PluginID == "" {
+		return InvalidPluginEventSubscriptionErr{"missing-plugin-id"}
+	}
+	if s.CallbackPath == "" || s.CallbackPath[0] != '/' {
+		return InvalidPluginEventSubscriptionErr{"invalid-callback-path"}
+	}
+	for _, e := range s.Events {
+		if !IsPluginEventTypeValid(e) {
+			return InvalidPluginEventSubscriptionErr{"invalid-event-type"}
+		}
+	}
+	return nil
+}
+
+func PluginEventSubscriptionFromJSON(data io.Reader) (*PluginEventSubscription, error) {
+	var subscription PluginEventSubscription
+	if err := json.NewDecoder(data).Decode(&subscription); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+type InvalidPluginEventSubscriptionErr struct {
+	msg string
+}
+
+func (e InvalidPluginEventSubscriptionErr) Error() string {
+	return e.msg
+}
+
+// PluginEvent is a board event delivered to the subscribed plugins
+// swagger:model
+type PluginEvent struct {
+	// The type of the event
+	// required: true
+	Type PluginEventType `json:"type"`
+
+	// The ID of the team of the board
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the user that caused the event
+	// required: false
+	UserID string `json:"userId"`
+
+	// The card, for card events
+	// required: false
+	Card *Block `json:"card,omitempty"`
+
+	// The IDs of the changed properties, for property change events
+	// required: false
+	ChangedProperties []string `json:"changedProperties,omitempty"`
+
+	// The added member, for member events
+	// required: false
+	Member *BoardMember `json:"member,omitempty"`
+
+	// The time of the event in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
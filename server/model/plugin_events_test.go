@@ -0,0 +1,30 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginEventSubscription(t *testing.T) {
+	subscription := &PluginEventSubscription{
+		PluginID:     "playbooks",
+		CallbackPath: "/boards/events",
+		Events:       []PluginEventType{PluginEventCardCreated},
+	}
+	require.NoError(t, subscription.IsValid())
+	require.True(t, subscription.Wants(PluginEventCardCreated))
+	require.False(t, subscription.Wants(PluginEventMemberAdded))
+
+	all := &PluginEventSubscription{PluginID: "playbooks", CallbackPath: "/events"}
+	require.True(t, all.Wants(PluginEventMemberAdded))
+
+	invalid := []*PluginEventSubscription{
+		{CallbackPath: "/events"},
+		{PluginID: "playbooks", CallbackPath: "events"},
+		{PluginID: "playbooks", CallbackPath: "/events", Events: []PluginEventType{"card_deleted"}},
+	}
+	for _, s := range invalid {
+		require.Error(t, s.IsValid())
+	}
+}
@@ -54,6 +54,9 @@ type PropDef struct {
 	Name    string                   `json:"name"`
 	Type    string                   `json:"type"`
 	Options map[string]PropDefOption `json:"options"`
+
+	// TargetBoardID is the board of the cards of a relation property.
+	TargetBoardID string `json:"targetBoardId,omitempty"`
 }
 
 // GetValue resolves the value of a property if the passed value is an ID for an option,
@@ -98,6 +101,48 @@ func (pd PropDef) GetValue(v interface{}, resolver PropValueResolver) (string, e
 		}
 		return userID, nil
 
+	case propTypeMultiPerson:
+		// v is a slice of userids
+		userIDs, ok := v.([]interface{})
+		if !ok {
+			return "", ErrInvalidPropertyValueType
+		}
+		names := make([]string, 0, len(userIDs))
+		for _, item := range userIDs {
+			userID, ok := item.(string)
+			if !ok {
+				return "", ErrInvalidPropertyValueType
+			}
+			name := userID
+			if resolver != nil {
+				user, err := resolver.GetUserByID(userID)
+				if err != nil {
+					return "", err
+				}
+				if user != nil {
+					name = user.Username
+				}
+			}
+			names = append(names, name)
+		}
+		return strings.Join(names, ", "), nil
+
+	case propTypeRelation:
+		// v is a slice of card ids
+		cardIDs, ok := v.([]interface{})
+		if !ok {
+			return "", ErrInvalidPropertyValueType
+		}
+		ids := make([]string, 0, len(cardIDs))
+		for _, item := range cardIDs {
+			cardID, ok := item.(string)
+			if !ok {
+				return "", ErrInvalidPropertyValueType
+			}
+			ids = append(ids, cardID)
+		}
+		return strings.Join(ids, ", "), nil
+
 	case "multiSelect":
 		// v is a slice of strings containing option ids
 		ms, ok := v.([]interface{})
@@ -157,6 +202,8 @@ func ParsePropertySchema(board *Board) (PropSchema, error) {
 			Name:    getMapString("name", prop),
 			Type:    getMapString("type", prop),
 			Options: make(map[string]PropDefOption),
+
+			TargetBoardID: getMapString("targetBoardId", prop),
 		}
 		optsIface, ok := prop["options"]
 		if ok {
@@ -243,3 +290,205 @@ func ParseProperties(block *Block, schema PropSchema, resolver PropValueResolver
 	}
 	return props, nil
 }
+
+// ResolvePropertyValues converts property values given as text to the
+// property values of a card. Properties are matched by ID or name, and
+// select options by ID or value; multiSelect, multiPerson and relation
+// values are comma separated.
+// Empty values resolve to nil, to clear the property, and read-only
+// properties can't be set.
+func (s PropSchema) ResolvePropertyValues(values map[string]string) (map[string]interface{}, error) {
+	resolved := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		def, ok := s.findProperty(key)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidProperty, key)
+		}
+		if IsReadOnlyPropertyType(def.Type) {
+			return nil, fmt.Errorf("%w: %s is read-only", ErrInvalidProperty, def.Name)
+		}
+
+		value = strings.TrimSpace(value)
+		if value == "" {
+			resolved[def.ID] = nil
+			continue
+		}
+
+		switch def.Type {
+		case "select":
+			optID, ok := def.findOption(value)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s for %s", ErrInvalidPropertyValue, value, def.Name)
+			}
+			resolved[def.ID] = optID
+		case "multiSelect":
+			optIDs := []interface{}{}
+			for _, v := range strings.Split(value, ",") {
+				optID, ok := def.findOption(strings.TrimSpace(v))
+				if !ok {
+					return nil, fmt.Errorf("%w: %s for %s", ErrInvalidPropertyValue, v, def.Name)
+				}
+				optIDs = append(optIDs, optID)
+			}
+			resolved[def.ID] = optIDs
+		case propTypeMultiPerson, propTypeRelation:
+			ids := []interface{}{}
+			for _, v := range strings.Split(value, ",") {
+				if id := strings.TrimSpace(v); id != "" && !isAssignedTo(ids, id) {
+					ids = append(ids, id)
+				}
+			}
+			resolved[def.ID] = ids
+		default:
+			resolved[def.ID] = value
+		}
+	}
+	return resolved, nil
+}
+
+func (s PropSchema) findProperty(key string) (PropDef, bool) {
+	if def, ok := s[key]; ok {
+		return def, true
+	}
+	for _, def := range s {
+		if strings.EqualFold(def.Name, key) {
+			return def, true
+		}
+	}
+	return PropDef{}, false
+}
+
+func (pd PropDef) findOption(value string) (string, bool) {
+	if _, ok := pd.Options[value]; ok {
+		return value, true
+	}
+	for _, opt := range pd.Options {
+		if strings.EqualFold(opt.Value, value) {
+			return opt.ID, true
+		}
+	}
+	return "", false
+}
+
+// RemapProperties converts the property values of a card from the schema of
+// one board to the schema of another. Properties are matched by name and type,
+// and select options by value. Properties without a match are dropped.
+func RemapProperties(props map[string]interface{}, from PropSchema, to PropSchema) map[string]interface{} {
+	toByName := make(map[string]PropDef, len(to))
+	for _, def := range to {
+		toByName[strings.ToLower(def.Name)] = def
+	}
+
+	remapped := make(map[string]interface{}, len(props))
+	for id, v := range props {
+		fromDef, ok := from[id]
+		if !ok {
+			continue
+		}
+		toDef, ok := toByName[strings.ToLower(fromDef.Name)]
+		if !ok || toDef.Type != fromDef.Type {
+			continue
+		}
+
+		switch fromDef.Type {
+		case "select":
+			optID, ok := v.(string)
+			if !ok {
+				continue
+			}
+			if newOptID, ok := remapOption(optID, fromDef, toDef); ok {
+				remapped[toDef.ID] = newOptID
+			}
+		case "multiSelect":
+			optIDs, ok := v.([]interface{})
+			if !ok {
+				continue
+			}
+			newOptIDs := make([]interface{}, 0, len(optIDs))
+			for _, optIDIface := range optIDs {
+				optID, ok := optIDIface.(string)
+				if !ok {
+					continue
+				}
+				if newOptID, ok := remapOption(optID, fromDef, toDef); ok {
+					newOptIDs = append(newOptIDs, newOptID)
+				}
+			}
+			if len(newOptIDs) > 0 {
+				remapped[toDef.ID] = newOptIDs
+			}
+		default:
+			remapped[toDef.ID] = v
+		}
+	}
+	return remapped
+}
+
+func remapOption(optID string, from PropDef, to PropDef) (string, bool) {
+	opt, ok := from.Options[optID]
+	if !ok {
+		return "", false
+	}
+	for _, toOpt := range to.Options {
+		if strings.EqualFold(toOpt.Value, opt.Value) {
+			return toOpt.ID, true
+		}
+	}
+	return "", false
+}
+
+// EnsureCardProperty returns the card property of the board with the name
+// and type, with the options of the values added to it if missing. The
+// property is created if the board has none with the name. changed is
+// false if the board already has the property with all the options; the
+// property must otherwise be saved with a board patch.
+func EnsureCardProperty(board *Board, name, propType string, values []string) (property map[string]interface{}, changed bool, err error) {
+	var options []interface{}
+	for _, prop := range board.CardProperties {
+		if propName, _ := prop["name"].(string); !strings.EqualFold(propName, name) {
+			continue
+		}
+		if prop["type"] != propType {
+			return nil, false, NewCodedError(ErrCodeBadRequest, fmt.Sprintf("the %s property of the board must be a %s property", name, propType),
+				map[string]interface{}{"property": name})
+		}
+		property = make(map[string]interface{}, len(prop))
+		for key, value := range prop {
+			property[key] = value
+		}
+		existing, _ := prop["options"].([]interface{})
+		options = append(options, existing...)
+		break
+	}
+	if property == nil {
+		property = map[string]interface{}{
+			"id":   utils.NewID(utils.IDTypeBlock),
+			"name": name,
+			"type": propType,
+		}
+		changed = true
+	}
+
+	for _, value := range values {
+		found := false
+		for _, option := range options {
+			if optionMap, ok := option.(map[string]interface{}); ok && strings.EqualFold(getMapString("value", optionMap), value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			options = append(options, map[string]interface{}{
+				"id":    utils.NewID(utils.IDTypeBlock),
+				"value": value,
+				"color": "propColorDefault",
+			})
+			changed = true
+		}
+	}
+	if options == nil {
+		options = []interface{}{}
+	}
+	property["options"] = options
+	return property, changed, nil
+}
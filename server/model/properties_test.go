@@ -116,3 +116,98 @@ const (
 	   }
 	]`
 )
+
+func TestRemapProperties(t *testing.T) {
+	from := PropSchema{
+		"status-1": {ID: "status-1", Name: "Status", Type: "select", Options: map[string]PropDefOption{
+			"done-1": {ID: "done-1", Value: "Done"},
+			"todo-1": {ID: "todo-1", Value: "To Do"},
+		}},
+		"tags-1": {ID: "tags-1", Name: "Tags", Type: "multiSelect", Options: map[string]PropDefOption{
+			"bug-1":  {ID: "bug-1", Value: "bug"},
+			"idea-1": {ID: "idea-1", Value: "idea"},
+		}},
+		"notes-1":    {ID: "notes-1", Name: "Notes", Type: "text"},
+		"estimate-1": {ID: "estimate-1", Name: "Estimate", Type: "number"},
+		"owner-1":    {ID: "owner-1", Name: "Owner", Type: "person"},
+	}
+	to := PropSchema{
+		"status-2": {ID: "status-2", Name: "status", Type: "select", Options: map[string]PropDefOption{
+			"done-2": {ID: "done-2", Value: "DONE"},
+		}},
+		"tags-2": {ID: "tags-2", Name: "Tags", Type: "multiSelect", Options: map[string]PropDefOption{
+			"bug-2": {ID: "bug-2", Value: "bug"},
+		}},
+		"notes-2":    {ID: "notes-2", Name: "Notes", Type: "text"},
+		"estimate-2": {ID: "estimate-2", Name: "Estimate", Type: "text"},
+	}
+
+	props := map[string]interface{}{
+		"status-1":   "done-1",
+		"tags-1":     []interface{}{"bug-1", "idea-1"},
+		"notes-1":    "some notes",
+		"estimate-1": "3",
+		"owner-1":    "user-id",
+		"unknown":    "value",
+	}
+
+	remapped := RemapProperties(props, from, to)
+	assert.Equal(t, map[string]interface{}{
+		"status-2": "done-2",
+		"tags-2":   []interface{}{"bug-2"},
+		"notes-2":  "some notes",
+	}, remapped)
+
+	t.Run("unmatched option is dropped", func(t *testing.T) {
+		remapped := RemapProperties(map[string]interface{}{"status-1": "todo-1"}, from, to)
+		assert.Empty(t, remapped)
+	})
+}
+
+func TestResolvePropertyValues(t *testing.T) {
+	schema := PropSchema{
+		"status": {ID: "status", Name: "Status", Type: "select", Options: map[string]PropDefOption{
+			"done": {ID: "done", Value: "Done"},
+			"todo": {ID: "todo", Value: "To Do"},
+		}},
+		"tags": {ID: "tags", Name: "Tags", Type: "multiSelect", Options: map[string]PropDefOption{
+			"bug":  {ID: "bug", Value: "Bug"},
+			"idea": {ID: "idea", Value: "Idea"},
+		}},
+		"notes":   {ID: "notes", Name: "Notes", Type: "text"},
+		"creator": {ID: "creator", Name: "Created by", Type: "createdBy"},
+	}
+
+	resolved, err := schema.ResolvePropertyValues(map[string]string{
+		"status": "to do",
+		"Tags":   "bug, idea",
+		"notes":  "",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"status": "todo",
+		"tags":   []interface{}{"bug", "idea"},
+		"notes":  nil,
+	}, resolved)
+
+	t.Run("options by ID", func(t *testing.T) {
+		resolved, err := schema.ResolvePropertyValues(map[string]string{"Status": "done"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"status": "done"}, resolved)
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		_, err := schema.ResolvePropertyValues(map[string]string{"Priority": "High"})
+		require.ErrorIs(t, err, ErrInvalidProperty)
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		_, err := schema.ResolvePropertyValues(map[string]string{"Status": "Blocked"})
+		require.ErrorIs(t, err, ErrInvalidPropertyValue)
+	})
+
+	t.Run("read-only property", func(t *testing.T) {
+		_, err := schema.ResolvePropertyValues(map[string]string{"Created by": "user-1"})
+		require.ErrorIs(t, err, ErrInvalidProperty)
+	})
+}
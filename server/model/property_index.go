@@ -0,0 +1,42 @@
+package model
+
+import (
+	"regexp"
+)
+
+var propertyIndexIDRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,36}$`)
+
+// IsValidPropertyIndexID returns true if the ID can be embedded in the
+// definition of a property index: up to 36 letters, digits, underscores
+// and hyphens, as generated for boards and card properties.
+func IsValidPropertyIndexID(id string) bool {
+	return propertyIndexIDRegexp.MatchString(id)
+}
+
+// IsIndexablePropertyType returns true if the cards store a single string
+// value for properties of the type, which a property index can match.
+// Properties holding lists, such as multiSelect, are not indexable.
+func IsIndexablePropertyType(propType string) bool {
+	switch propType {
+	case "select", "person", "text", "number", "checkbox", "email", "url", "phone":
+		return true
+	}
+	return false
+}
+
+// PropertyIndex is an index on the values a card property of a board
+// takes, created the first time the cards are filtered by the property
+// swagger:model
+type PropertyIndex struct {
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the indexed card property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
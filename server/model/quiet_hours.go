@@ -0,0 +1,114 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+const (
+	// UserPropQuietHours is the user prop holding the quiet hours of the
+	// user, as JSON.
+	UserPropQuietHours = "focalboard_quietHours"
+
+	quietHoursLayout = "15:04"
+)
+
+// QuietHours is the time range of each day, in the timezone of the user,
+// the notifications are held back and delivered once it is over
+// swagger:model
+type QuietHours struct {
+	// The start of the quiet hours, as HH:MM
+	// required: true
+	Start string `json:"start"`
+
+	// The end of the quiet hours, as HH:MM. An end before the start is on the next day
+	// required: true
+	End string `json:"end"`
+}
+
+func (q *QuietHours) IsValid() error {
+	start, err := time.Parse(quietHoursLayout, q.Start)
+	if err != nil {
+		return NewCodedError(ErrCodeBadRequest, "invalid quiet hours start", map[string]interface{}{"start": q.Start})
+	}
+	end, err := time.Parse(quietHoursLayout, q.End)
+	if err != nil {
+		return NewCodedError(ErrCodeBadRequest, "invalid quiet hours end", map[string]interface{}{"end": q.End})
+	}
+	if start.Equal(end) {
+		return NewCodedError(ErrCodeBadRequest, "quiet hours cannot start and end at the same time", nil)
+	}
+	return nil
+}
+
+// EndAfter returns the end of the quiet hours and true if the time is
+// within them in the location, or false otherwise.
+func (q *QuietHours) EndAfter(now time.Time, loc *time.Location) (time.Time, bool) {
+	start, err := time.Parse(quietHoursLayout, q.Start)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := time.Parse(quietHoursLayout, q.End)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := now.In(loc)
+	at := func(clock time.Time, days int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day()+days, clock.Hour(), clock.Minute(), 0, 0, loc)
+	}
+
+	todayStart, todayEnd := at(start, 0), at(end, 0)
+	if todayStart.Before(todayEnd) {
+		// quiet hours within the day, as 13:00 to 14:00
+		if !local.Before(todayStart) && local.Before(todayEnd) {
+			return todayEnd, true
+		}
+		return time.Time{}, false
+	}
+
+	// quiet hours over midnight, as 22:00 to 07:00
+	if local.Before(todayEnd) {
+		return todayEnd, true
+	}
+	if !local.Before(todayStart) {
+		return at(end, 1), true
+	}
+	return time.Time{}, false
+}
+
+// QuietHours returns the quiet hours of the user, or nil if the user has
+// none or they can't be parsed.
+func (u *User) QuietHours() *QuietHours {
+	value, ok := u.Props[UserPropQuietHours].(string)
+	if !ok || value == "" {
+		return nil
+	}
+
+	var quietHours QuietHours
+	if err := json.Unmarshal([]byte(value), &quietHours); err != nil || quietHours.IsValid() != nil {
+		return nil
+	}
+	return &quietHours
+}
+
+func QuietHoursFromJSON(data io.Reader) (*QuietHours, error) {
+	var quietHours *QuietHours
+	if err := json.NewDecoder(data).Decode(&quietHours); err != nil {
+		return nil, err
+	}
+	return quietHours, nil
+}
+
+// DeferredNotification is a notification held back until the user is
+// available, because of their quiet hours or Do Not Disturb status.
+type DeferredNotification struct {
+	ID        string
+	UserID    string
+	EventType string
+	Key       string
+	Message   string
+	Link      string
+	CreateAt  int64
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietHoursIsValid(t *testing.T) {
+	require.NoError(t, (&QuietHours{Start: "22:00", End: "07:00"}).IsValid())
+	require.NoError(t, (&QuietHours{Start: "13:00", End: "14:30"}).IsValid())
+	require.Error(t, (&QuietHours{Start: "", End: "07:00"}).IsValid())
+	require.Error(t, (&QuietHours{Start: "22:00", End: "7pm"}).IsValid())
+	require.Error(t, (&QuietHours{Start: "24:00", End: "07:00"}).IsValid())
+	require.Error(t, (&QuietHours{Start: "08:00", End: "08:00"}).IsValid())
+}
+
+func TestQuietHoursEndAfter(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2022, 3, day, hour, minute, 0, 0, loc)
+	}
+
+	testCases := []struct {
+		name       string
+		quietHours QuietHours
+		now        time.Time
+		quiet      bool
+		end        time.Time
+	}{
+		{"within the day, before", QuietHours{"13:00", "14:00"}, at(14, 12, 59), false, time.Time{}},
+		{"within the day, at the start", QuietHours{"13:00", "14:00"}, at(14, 13, 0), true, at(14, 14, 0)},
+		{"within the day, at the end", QuietHours{"13:00", "14:00"}, at(14, 14, 0), false, time.Time{}},
+		{"over midnight, evening", QuietHours{"22:00", "07:00"}, at(14, 23, 30), true, at(15, 7, 0)},
+		{"over midnight, morning", QuietHours{"22:00", "07:00"}, at(15, 6, 30), true, at(15, 7, 0)},
+		{"over midnight, daytime", QuietHours{"22:00", "07:00"}, at(15, 12, 0), false, time.Time{}},
+		{"other timezone", QuietHours{"22:00", "07:00"}, time.Date(2022, 3, 14, 22, 30, 0, 0, time.UTC), true, at(15, 7, 0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			end, quiet := tc.quietHours.EndAfter(tc.now, loc)
+			require.Equal(t, tc.quiet, quiet)
+			require.True(t, tc.end.Equal(end), "expected %s, got %s", tc.end, end)
+		})
+	}
+}
+
+func TestUserQuietHours(t *testing.T) {
+	user := &User{Props: map[string]interface{}{}}
+	require.Nil(t, user.QuietHours())
+
+	user.Props[UserPropQuietHours] = `{"start":"22:00","end":"07:00"}`
+	require.Equal(t, &QuietHours{Start: "22:00", End: "07:00"}, user.QuietHours())
+
+	user.Props[UserPropQuietHours] = `{"start":"22:00"}`
+	require.Nil(t, user.QuietHours())
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// IsReadOnlyPropertyType returns true for the types of the properties
+// whose values are not stored on the cards but taken from their metadata:
+// who created and last modified them, and when.
+func IsReadOnlyPropertyType(propType string) bool {
+	switch propType {
+	case propTypeCreatedBy, propTypeCreatedTime, propTypeUpdatedBy, propTypeUpdatedTime:
+		return true
+	}
+	return false
+}
+
+// ReadOnlyPropertyValue returns the value of a read-only property of the
+// card, taken from its metadata: a user ID for the users, and a time in
+// milliseconds for the times. ok is false if the property isn't read-only.
+func ReadOnlyPropertyValue(card *Block, def PropDef) (value string, ok bool) {
+	switch def.Type {
+	case propTypeCreatedBy:
+		return card.CreatedBy, true
+	case propTypeCreatedTime:
+		return strconv.FormatInt(card.CreateAt, 10), true
+	case propTypeUpdatedBy:
+		return card.ModifiedBy, true
+	case propTypeUpdatedTime:
+		return strconv.FormatInt(card.UpdateAt, 10), true
+	}
+	return "", false
+}
+
+// CheckReadOnlyProperties checks that the card doesn't set values on its
+// read-only properties, compared to the old card, which is nil for a new
+// card. Clearing them is allowed.
+func CheckReadOnlyProperties(card, oldCard *Block, schema PropSchema) error {
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	var oldProps map[string]interface{}
+	if oldCard != nil {
+		oldProps, _ = oldCard.Fields["properties"].(map[string]interface{})
+	}
+
+	for id, value := range props {
+		def, ok := schema[id]
+		if !ok || !IsReadOnlyPropertyType(def.Type) || value == nil {
+			continue
+		}
+		if !reflect.DeepEqual(value, oldProps[id]) {
+			return NewCodedError(ErrCodeBadRequest, "the value of a read-only property can't be set",
+				map[string]interface{}{"blockId": card.ID, "propertyId": id})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyPropertyValue(t *testing.T) {
+	card := &Block{CreatedBy: "user-1", ModifiedBy: "user-2", CreateAt: 1000, UpdateAt: 2000}
+
+	testCases := map[string]string{
+		"createdBy":   "user-1",
+		"updatedBy":   "user-2",
+		"createdTime": "1000",
+		"updatedTime": "2000",
+	}
+	for propType, expected := range testCases {
+		value, ok := ReadOnlyPropertyValue(card, PropDef{Type: propType})
+		require.True(t, ok, propType)
+		require.Equal(t, expected, value, propType)
+		require.True(t, IsReadOnlyPropertyType(propType))
+	}
+
+	_, ok := ReadOnlyPropertyValue(card, PropDef{Type: "text"})
+	require.False(t, ok)
+	require.False(t, IsReadOnlyPropertyType("text"))
+}
+
+func TestCheckReadOnlyProperties(t *testing.T) {
+	schema := PropSchema{
+		"creator": {ID: "creator", Type: "createdBy"},
+		"notes":   {ID: "notes", Type: "text"},
+	}
+	card := func(props map[string]interface{}) *Block {
+		return &Block{ID: "card-id", Type: TypeCard, Fields: map[string]interface{}{"properties": props}}
+	}
+
+	require.NoError(t, CheckReadOnlyProperties(card(map[string]interface{}{"notes": "text"}), nil, schema))
+	require.NoError(t, CheckReadOnlyProperties(card(map[string]interface{}{"creator": nil}), nil, schema))
+	require.True(t, IsErrBadRequest(CheckReadOnlyProperties(card(map[string]interface{}{"creator": "user-1"}), nil, schema)))
+
+	// values stored before the property was read-only are kept
+	oldCard := card(map[string]interface{}{"creator": "user-1"})
+	require.NoError(t, CheckReadOnlyProperties(card(map[string]interface{}{"creator": "user-1"}), oldCard, schema))
+	require.True(t, IsErrBadRequest(CheckReadOnlyProperties(card(map[string]interface{}{"creator": "user-2"}), oldCard, schema)))
+}
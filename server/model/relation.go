@@ -0,0 +1,105 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// CardLink is the title of a card linked by a relation property
+// swagger:model
+type CardLink struct {
+	// The ID of the card
+	// required: true
+	ID string `json:"id"`
+
+	// The ID of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The icon of the card
+	// required: false
+	Icon string `json:"icon,omitempty"`
+}
+
+// MaxCardReferences is the maximum number of cards resolved at once.
+const MaxCardReferences = 200
+
+// ResolveCardsRequest is the request to resolve the cards linked by the
+// relation properties of cards
+// swagger:model
+type ResolveCardsRequest struct {
+	// The IDs of the cards to resolve
+	// required: true
+	CardIDs []string `json:"cardIds"`
+}
+
+func ResolveCardsRequestFromJSON(data io.Reader) (*ResolveCardsRequest, error) {
+	var request ResolveCardsRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// NewCardLink returns the link to the card.
+func NewCardLink(card *Block) CardLink {
+	icon, _ := card.Fields["icon"].(string)
+	return CardLink{
+		ID:      card.ID,
+		BoardID: card.BoardID,
+		Title:   card.Title,
+		Icon:    icon,
+	}
+}
+
+// HasRelationProperty returns true if the schema has a relation property.
+func (s PropSchema) HasRelationProperty() bool {
+	for _, def := range s {
+		if def.Type == propTypeRelation {
+			return true
+		}
+	}
+	return false
+}
+
+// AddedRelationCards returns the cards added to the relation properties of
+// the card compared to the old card, which is nil for a new card, keyed by
+// the target board of the properties. The values of the properties must
+// be lists of card IDs, and the properties must have a target board.
+func AddedRelationCards(card, oldCard *Block, schema PropSchema) (map[string][]string, error) {
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	var oldProps map[string]interface{}
+	if oldCard != nil {
+		oldProps, _ = oldCard.Fields["properties"].(map[string]interface{})
+	}
+
+	added := map[string][]string{}
+	for id, value := range props {
+		def, ok := schema[id]
+		if !ok || def.Type != propTypeRelation || value == nil {
+			continue
+		}
+		params := map[string]interface{}{"blockId": card.ID, "propertyId": id}
+		if def.TargetBoardID == "" {
+			return nil, NewCodedError(ErrCodeBadRequest, "a relation property must have a target board", params)
+		}
+		cardIDs, ok := value.([]interface{})
+		if !ok {
+			return nil, NewCodedError(ErrCodeBadRequest, "the value of a relation property must be a list of cards", params)
+		}
+		for _, item := range cardIDs {
+			cardID, ok := item.(string)
+			if !ok || cardID == "" {
+				return nil, NewCodedError(ErrCodeBadRequest, "the value of a relation property must be a list of cards", params)
+			}
+			if !isAssignedTo(oldProps[id], cardID) && !containsString(added[def.TargetBoardID], cardID) {
+				added[def.TargetBoardID] = append(added[def.TargetBoardID], cardID)
+			}
+		}
+	}
+	return added, nil
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddedRelationCards(t *testing.T) {
+	schema, err := ParsePropertySchema(&Board{
+		CardProperties: []map[string]interface{}{
+			{"id": "tasks", "name": "Tasks", "type": "relation", "targetBoardId": "tasks-board"},
+			{"id": "bugs", "name": "Bugs", "type": "relation", "targetBoardId": "bugs-board"},
+			{"id": "broken", "name": "Broken", "type": "relation"},
+		},
+	})
+	require.NoError(t, err)
+
+	card := func(props map[string]interface{}) *Block {
+		return &Block{ID: "card-id", Type: TypeCard, Fields: map[string]interface{}{"properties": props}}
+	}
+
+	t.Run("the added cards are keyed by target board", func(t *testing.T) {
+		oldCard := card(map[string]interface{}{"tasks": []interface{}{"task-1"}})
+		added, err := AddedRelationCards(card(map[string]interface{}{
+			"tasks": []interface{}{"task-1", "task-2", "task-2"},
+			"bugs":  []interface{}{"bug-1"},
+		}), oldCard, schema)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{"tasks-board": {"task-2"}, "bugs-board": {"bug-1"}}, added)
+	})
+
+	t.Run("cleared properties are skipped", func(t *testing.T) {
+		added, err := AddedRelationCards(card(map[string]interface{}{"tasks": nil, "broken": nil}), nil, schema)
+		require.NoError(t, err)
+		require.Empty(t, added)
+	})
+
+	t.Run("invalid values are rejected", func(t *testing.T) {
+		for _, props := range []map[string]interface{}{
+			{"tasks": "task-1"},
+			{"tasks": []interface{}{""}},
+			{"tasks": []interface{}{1}},
+			{"broken": []interface{}{"task-1"}},
+		} {
+			_, err := AddedRelationCards(card(props), nil, schema)
+			require.True(t, IsErrBadRequest(err), props)
+		}
+	})
+}
@@ -0,0 +1,239 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	// BoardPropertyStaleCardExclusions is the board property holding the
+	// property values of the cards that are never stale, like the "Done"
+	// option of the status property, as StaleCardExclusions.
+	BoardPropertyStaleCardExclusions = "staleCardExclusions"
+
+	// DefaultStaleCardDays is the number of days without updates after
+	// which a card is stale, when not given.
+	DefaultStaleCardDays = 14
+
+	// MaxStaleCardDays is the largest number of days that can be given.
+	MaxStaleCardDays = 365
+
+	// DefaultStaleDigestWeekday is the day of the week the stale cards
+	// digest is sent on when the user hasn't chosen one.
+	DefaultStaleDigestWeekday = int(time.Monday)
+
+	// DefaultStaleDigestHour is the hour of the day the stale cards digest
+	// is sent at when the user hasn't chosen one.
+	DefaultStaleDigestHour = 9
+)
+
+// StaleCardExclusions maps the IDs of card properties to the values,
+// option IDs for the select properties, that keep a card out of the stale
+// cards.
+type StaleCardExclusions map[string][]string
+
+// StaleCardExclusionsFromBoard returns the exclusions stored in the
+// properties of the board, or nil if the board has none.
+func StaleCardExclusionsFromBoard(board *Board) StaleCardExclusions {
+	stored, ok := board.Properties[BoardPropertyStaleCardExclusions].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	exclusions := StaleCardExclusions{}
+	for propertyID, value := range stored {
+		values, _ := value.([]interface{})
+		for _, v := range values {
+			if s, ok := v.(string); ok && s != "" {
+				exclusions[propertyID] = append(exclusions[propertyID], s)
+			}
+		}
+	}
+	return exclusions
+}
+
+// ParseStaleCardExclusions parses exclusions given as property:value,
+// with properties matched by ID or name and select options by ID or
+// value.
+func ParseStaleCardExclusions(schema PropSchema, exclusions []string) (StaleCardExclusions, error) {
+	parsed := StaleCardExclusions{}
+	for _, exclusion := range exclusions {
+		parts := strings.SplitN(exclusion, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, NewCodedError(ErrCodeBadRequest, "exclusions must be given as property:value", map[string]interface{}{"exclude": exclusion})
+		}
+
+		resolved, err := schema.ResolvePropertyValues(map[string]string{parts[0]: parts[1]})
+		if err != nil {
+			if errors.Is(err, ErrInvalidProperty) || errors.Is(err, ErrInvalidPropertyValue) {
+				return nil, NewCodedError(ErrCodeBadRequest, err.Error(), map[string]interface{}{"exclude": exclusion})
+			}
+			return nil, err
+		}
+		for propertyID, v := range resolved {
+			switch typed := v.(type) {
+			case string:
+				parsed[propertyID] = append(parsed[propertyID], typed)
+			case []interface{}:
+				for _, item := range typed {
+					parsed[propertyID] = append(parsed[propertyID], fmt.Sprintf("%v", item))
+				}
+			}
+		}
+	}
+	return parsed, nil
+}
+
+// Excludes returns true if the card has one of the excluded values.
+func (e StaleCardExclusions) Excludes(card *Block) bool {
+	for propertyID, values := range e {
+		if filterValueIncludes(cardPropertyValue(card, propertyID), values) {
+			return true
+		}
+	}
+	return false
+}
+
+// StaleCardsQuery selects the stale cards of a board
+type StaleCardsQuery struct {
+	// The number of days without updates after which a card is stale
+	Days int
+
+	// The exclusions as property:value; the exclusions of the board apply
+	// if empty
+	Exclude []string
+}
+
+func (q StaleCardsQuery) IsValid() error {
+	if q.Days < 1 || q.Days > MaxStaleCardDays {
+		return NewCodedError(ErrCodeBadRequest, fmt.Sprintf("days must be between 1 and %d", MaxStaleCardDays), map[string]interface{}{"days": q.Days})
+	}
+	return nil
+}
+
+// StaleCard is a card that wasn't updated for a number of days
+// swagger:model
+type StaleCard struct {
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The last time the card was updated in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The id of the user that last updated the card
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The number of whole days since the card was updated
+	// required: true
+	DaysStale int `json:"daysStale"`
+}
+
+// NewStaleCard returns the stale card of the block, with the days since
+// it was last updated.
+func NewStaleCard(card *Block, now time.Time) *StaleCard {
+	return &StaleCard{
+		CardID:     card.ID,
+		Title:      card.Title,
+		BoardID:    card.BoardID,
+		UpdateAt:   card.UpdateAt,
+		ModifiedBy: card.ModifiedBy,
+		DaysStale:  int(now.Sub(utils.GetTimeForMillis(card.UpdateAt)) / (24 * time.Hour)),
+	}
+}
+
+// StaleDigestSettings are the settings of the weekly message listing the
+// stale cards of the boards a user administers
+// swagger:model
+type StaleDigestSettings struct {
+	// The id of the user receiving the digest
+	// required: true
+	UserID string `json:"userId"`
+
+	// Indicates if the digest is sent to the user
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The number of days without updates after which a card is stale
+	// required: true
+	Days int `json:"days"`
+
+	// The day of the week, from 0 for Sunday to 6, the digest is sent on
+	// required: true
+	Weekday int `json:"weekday"`
+
+	// The hour of the day, from 0 to 23 in the timezone of the user, the digest is sent at
+	// required: true
+	Hour int `json:"hour"`
+
+	// The last time the digest was sent in miliseconds since the current epoch
+	// required: false
+	LastSentAt int64 `json:"lastSentAt"`
+}
+
+// NewStaleDigestSettings returns the settings of a user that hasn't
+// enabled the digest.
+func NewStaleDigestSettings(userID string) *StaleDigestSettings {
+	return &StaleDigestSettings{
+		UserID:  userID,
+		Days:    DefaultStaleCardDays,
+		Weekday: DefaultStaleDigestWeekday,
+		Hour:    DefaultStaleDigestHour,
+	}
+}
+
+func (s *StaleDigestSettings) IsValid() error {
+	if err := (StaleCardsQuery{Days: s.Days}).IsValid(); err != nil {
+		return err
+	}
+	if s.Weekday < 0 || s.Weekday > 6 {
+		return NewCodedError(ErrCodeBadRequest, "stale digest weekday must be between 0 and 6", map[string]interface{}{"weekday": s.Weekday})
+	}
+	if s.Hour < 0 || s.Hour > 23 {
+		return NewCodedError(ErrCodeBadRequest, "stale digest hour must be between 0 and 23", map[string]interface{}{"hour": s.Hour})
+	}
+	return nil
+}
+
+// IsDue returns true if the digest is enabled and wasn't sent yet since
+// its last weekday and hour in the location of the user.
+func (s *StaleDigestSettings) IsDue(now time.Time, loc *time.Location) bool {
+	if !s.Enabled {
+		return false
+	}
+
+	today := utils.StartOfDay(now, loc)
+	daysSince := (int(today.Weekday()) - s.Weekday + 7) % 7
+	sendAt := today.AddDate(0, 0, -daysSince).Add(time.Duration(s.Hour) * time.Hour)
+	if now.Before(sendAt) {
+		sendAt = sendAt.AddDate(0, 0, -7)
+	}
+	return s.LastSentAt < utils.GetMillisForTime(sendAt)
+}
+
+// StaleDigestBoard is a board of the stale cards digest, with its least
+// recently updated stale cards
+type StaleDigestBoard struct {
+	BoardID    string
+	BoardTitle string
+	TeamID     string
+	Cards      []*StaleCard
+
+	// Total is the number of stale cards of the board, that can be more
+	// than the cards listed
+	Total int
+}
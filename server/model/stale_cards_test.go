@@ -0,0 +1,108 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestStaleCardExclusions(t *testing.T) {
+	board := &Board{
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "done", "value": "Done"},
+					map[string]interface{}{"id": "archived", "value": "Archived"},
+					map[string]interface{}{"id": "doing", "value": "Doing"},
+				},
+			},
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	}
+	schema, err := ParsePropertySchema(board)
+	require.NoError(t, err)
+
+	cardWith := func(props map[string]interface{}) *Block {
+		return &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": props}}
+	}
+
+	t.Run("parse by name and value", func(t *testing.T) {
+		exclusions, err := ParseStaleCardExclusions(schema, []string{"Status:Done", "status:archived", "Notes:later"})
+		require.NoError(t, err)
+		require.Equal(t, StaleCardExclusions{"status": {"done", "archived"}, "notes": {"later"}}, exclusions)
+
+		require.True(t, exclusions.Excludes(cardWith(map[string]interface{}{"status": "done"})))
+		require.True(t, exclusions.Excludes(cardWith(map[string]interface{}{"notes": "later"})))
+		require.False(t, exclusions.Excludes(cardWith(map[string]interface{}{"status": "doing"})))
+		require.False(t, exclusions.Excludes(cardWith(map[string]interface{}{})))
+	})
+
+	t.Run("invalid exclusions", func(t *testing.T) {
+		for _, exclusion := range []string{"Status", "Status:", "Priority:High", "Status:Unknown"} {
+			_, err := ParseStaleCardExclusions(schema, []string{exclusion})
+			ce, ok := AsCodedError(err)
+			require.True(t, ok, exclusion)
+			require.Equal(t, ErrCodeBadRequest, ce.Code)
+		}
+	})
+
+	t.Run("from the board properties", func(t *testing.T) {
+		require.Nil(t, StaleCardExclusionsFromBoard(board))
+
+		board.Properties = map[string]interface{}{
+			BoardPropertyStaleCardExclusions: map[string]interface{}{"status": []interface{}{"done"}},
+		}
+		require.Equal(t, StaleCardExclusions{"status": {"done"}}, StaleCardExclusionsFromBoard(board))
+	})
+}
+
+func TestNewStaleCard(t *testing.T) {
+	now := time.Now()
+	card := &Block{ID: "card-id", BoardID: "board-id", Title: "card", UpdateAt: utils.GetMillisForTime(now.Add(-50 * time.Hour))}
+
+	stale := NewStaleCard(card, now)
+	require.Equal(t, "card-id", stale.CardID)
+	require.Equal(t, 2, stale.DaysStale)
+}
+
+func TestStaleDigestSettingsIsDue(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	// Monday, March 14 2022 at 9:00
+	sendAt := time.Date(2022, 3, 14, 9, 0, 0, 0, loc)
+	monday := int(time.Monday)
+
+	testCases := []struct {
+		name     string
+		settings StaleDigestSettings
+		now      time.Time
+		due      bool
+	}{
+		{"disabled", StaleDigestSettings{Weekday: monday, Hour: 9}, sendAt.Add(time.Hour), false},
+		{"never sent", StaleDigestSettings{Enabled: true, Weekday: monday, Hour: 9}, sendAt, true},
+		{"sent last week", StaleDigestSettings{Enabled: true, Weekday: monday, Hour: 9, LastSentAt: utils.GetMillisForTime(sendAt.AddDate(0, 0, -7))}, sendAt.Add(time.Hour), true},
+		{"before the hour", StaleDigestSettings{Enabled: true, Weekday: monday, Hour: 9, LastSentAt: utils.GetMillisForTime(sendAt.AddDate(0, 0, -7))}, sendAt.Add(-time.Minute), false},
+		{"already sent this week", StaleDigestSettings{Enabled: true, Weekday: monday, Hour: 9, LastSentAt: utils.GetMillisForTime(sendAt.Add(time.Minute))}, sendAt.AddDate(0, 0, 3), false},
+		{"missed weekday", StaleDigestSettings{Enabled: true, Weekday: monday, Hour: 9, LastSentAt: utils.GetMillisForTime(sendAt.AddDate(0, 0, -7))}, sendAt.AddDate(0, 0, 2), true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.due, tc.settings.IsDue(tc.now, loc))
+		})
+	}
+}
+
+func TestStaleDigestSettingsIsValid(t *testing.T) {
+	require.NoError(t, NewStaleDigestSettings("user-id").IsValid())
+	require.Error(t, (&StaleDigestSettings{Days: 0, Weekday: 1, Hour: 9}).IsValid())
+	require.Error(t, (&StaleDigestSettings{Days: MaxStaleCardDays + 1, Weekday: 1, Hour: 9}).IsValid())
+	require.Error(t, (&StaleDigestSettings{Days: 14, Weekday: 7, Hour: 9}).IsValid())
+	require.Error(t, (&StaleDigestSettings{Days: 14, Weekday: 1, Hour: 24}).IsValid())
+}
@@ -0,0 +1,90 @@
+package model
+
+// TableStats holds the size of a database table
+// swagger:model
+type TableStats struct {
+	// The name of the table, without the table prefix
+	// required: true
+	Name string `json:"name"`
+
+	// The number of rows of the table
+	// required: true
+	Rows int64 `json:"rows"`
+
+	// The size of the table and its indexes in bytes, zero if the
+	// database doesn't report it
+	// required: true
+	SizeBytes int64 `json:"sizeBytes"`
+}
+
+// TeamHistoryStats holds the size of the history of the boards of a
+// team, to help deciding when to enable the retention pruning
+// swagger:model
+type TeamHistoryStats struct {
+	// The ID of the team
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The number of rows of the boards history of the team
+	// required: true
+	BoardsHistoryRows int64 `json:"boardsHistoryRows"`
+
+	// The approximate size of the boards history of the team in bytes,
+	// zero if the database doesn't report it
+	// required: true
+	BoardsHistorySizeBytes int64 `json:"boardsHistorySizeBytes"`
+
+	// The number of rows of the blocks history of the team
+	// required: true
+	BlocksHistoryRows int64 `json:"blocksHistoryRows"`
+
+	// The approximate size of the blocks history of the team in bytes,
+	// zero if the database doesn't report it
+	// required: true
+	BlocksHistorySizeBytes int64 `json:"blocksHistorySizeBytes"`
+}
+
+// ServerStats summarizes the server-wide counts used to monitor the
+// health of a server
+// swagger:model
+type ServerStats struct {
+	// The number of teams
+	// required: true
+	TeamCount int64 `json:"teamCount"`
+
+	// The number of boards, including templates
+	// required: true
+	BoardCount int64 `json:"boardCount"`
+
+	// The number of blocks
+	// required: true
+	BlockCount int64 `json:"blockCount"`
+
+	// The number of blocks of each type
+	// required: true
+	BlockCountsByType map[string]int64 `json:"blockCountsByType"`
+
+	// The number of registered users
+	// required: true
+	UserCount int `json:"userCount"`
+
+	// The number of open websocket connections on this server
+	// required: true
+	WebSocketConnections int `json:"webSocketConnections"`
+
+	// The number of block changes waiting to be broadcast and notified
+	// required: true
+	NotificationQueueDepth int `json:"notificationQueueDepth"`
+
+	// The number of pending subscription notifications
+	// required: true
+	PendingNotificationHints int64 `json:"pendingNotificationHints"`
+
+	// The size of each database table
+	// required: true
+	Tables []*TableStats `json:"tables"`
+
+	// The size of the boards and blocks history of each team
+	// required: true
+	HistoryByTeam []*TeamHistoryStats `json:"historyByTeam"`
+}
@@ -101,3 +101,9 @@ type Subscriber struct {
 	// NotifiedAt is the timestamp this subscriber was last notified
 	NotifiedAt int64 `json:"notified_at"`
 }
+
+// QuerySubscriptionsOptions are query options that can be passed to GetSubscriptions.
+type QuerySubscriptionsOptions struct {
+	Page    int // zero-based page number, used when PerPage is non-zero
+	PerPage int // if non-zero then limit the number of returned records
+}
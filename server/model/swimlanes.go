@@ -0,0 +1,85 @@
+package model
+
+// SwimlaneCell is the cards of a board whose row and column properties
+// take a pair of values, as shown in a lane of a swimlane of a board view
+// swagger:model
+type SwimlaneCell struct {
+	// The value of the row property, the option ID for select properties,
+	// or empty for the cards without a value
+	// required: true
+	Row string `json:"row"`
+
+	// The value of the column property, the option ID for select
+	// properties, or empty for the cards without a value
+	// required: true
+	Column string `json:"column"`
+
+	// The number of cards
+	// required: true
+	Count int64 `json:"count"`
+
+	// The IDs of the cards, in the order they were created
+	// required: true
+	CardIDs []string `json:"cardIds"`
+}
+
+// SwimlaneMatrix is the cards of a board grouped by two properties at
+// once, the rows and columns of a swimlane board view
+// swagger:model
+type SwimlaneMatrix struct {
+	// The id of the property of the rows
+	// required: true
+	RowPropertyID string `json:"rowPropertyId"`
+
+	// The id of the property of the columns
+	// required: true
+	ColumnPropertyID string `json:"columnPropertyId"`
+
+	// The values of the rows in order, with their number of cards
+	// required: true
+	Rows []*CardGroup `json:"rows"`
+
+	// The values of the columns in order, with their number of cards
+	// required: true
+	Columns []*CardGroup `json:"columns"`
+
+	// The cells by row then column, in the order of the rows and columns
+	// required: true
+	Cells [][]*SwimlaneCell `json:"cells"`
+}
+
+// NewSwimlaneMatrix returns the matrix of the cells for the rows and
+// columns, in their order. The pairs of values no card takes have empty
+// cells, and the totals of the rows and columns are counted from the
+// cells.
+func NewSwimlaneMatrix(rowPropertyID, columnPropertyID string, rows, columns []string, cells []*SwimlaneCell) *SwimlaneMatrix {
+	byValues := make(map[[2]string]*SwimlaneCell, len(cells))
+	for _, cell := range cells {
+		byValues[[2]string{cell.Row, cell.Column}] = cell
+	}
+
+	matrix := &SwimlaneMatrix{
+		RowPropertyID:    rowPropertyID,
+		ColumnPropertyID: columnPropertyID,
+		Rows:             make([]*CardGroup, len(rows)),
+		Columns:          make([]*CardGroup, len(columns)),
+		Cells:            make([][]*SwimlaneCell, len(rows)),
+	}
+	for j, column := range columns {
+		matrix.Columns[j] = &CardGroup{Value: column}
+	}
+	for i, row := range rows {
+		matrix.Rows[i] = &CardGroup{Value: row}
+		matrix.Cells[i] = make([]*SwimlaneCell, len(columns))
+		for j, column := range columns {
+			cell, ok := byValues[[2]string{row, column}]
+			if !ok {
+				cell = &SwimlaneCell{Row: row, Column: column, CardIDs: []string{}}
+			}
+			matrix.Cells[i][j] = cell
+			matrix.Rows[i].Count += cell.Count
+			matrix.Columns[j].Count += cell.Count
+		}
+	}
+	return matrix
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSwimlaneMatrix(t *testing.T) {
+	cells := []*SwimlaneCell{
+		{Row: "high", Column: "todo", Count: 2, CardIDs: []string{"card-1", "card-2"}},
+		{Row: "", Column: "done", Count: 1, CardIDs: []string{"card-3"}},
+	}
+
+	matrix := NewSwimlaneMatrix("priority-id", "status-id", []string{"", "high"}, []string{"todo", "done"}, cells)
+	require.Equal(t, "priority-id", matrix.RowPropertyID)
+	require.Equal(t, "status-id", matrix.ColumnPropertyID)
+	require.Equal(t, []*CardGroup{{Value: "", Count: 1}, {Value: "high", Count: 2}}, matrix.Rows)
+	require.Equal(t, []*CardGroup{{Value: "todo", Count: 2}, {Value: "done", Count: 1}}, matrix.Columns)
+
+	require.Len(t, matrix.Cells, 2)
+	require.Equal(t, &SwimlaneCell{Row: "", Column: "todo", CardIDs: []string{}}, matrix.Cells[0][0])
+	require.Same(t, cells[1], matrix.Cells[0][1])
+	require.Same(t, cells[0], matrix.Cells[1][0])
+	require.Equal(t, int64(0), matrix.Cells[1][1].Count)
+}
@@ -0,0 +1,51 @@
+package model
+
+// MaxSyncBoards is the maximum number of boards of a sync request.
+const MaxSyncBoards = 100
+
+// SyncRequest lists the boards to sync, with the time of their last
+// sync
+// swagger:model
+type SyncRequest struct {
+	// The time of the last sync of each board, in miliseconds, indexed
+	// by board ID. 0 returns all the blocks of the board
+	// required: true
+	Boards map[string]int64 `json:"boards"`
+}
+
+// BoardSync holds the changes of a board since its last sync
+// swagger:model
+type BoardSync struct {
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The board, if it changed since the last sync
+	// required: false
+	Board *Board `json:"board,omitempty"`
+
+	// The blocks created or changed since the last sync
+	// required: true
+	Blocks []Block `json:"blocks"`
+
+	// The IDs of the blocks deleted since the last sync
+	// required: true
+	DeletedBlockIDs []string `json:"deletedBlockIds"`
+
+	// True if the board was deleted, or the user can no longer see it
+	// required: true
+	Removed bool `json:"removed"`
+}
+
+// SyncResponse holds the changes of the synced boards
+// swagger:model
+type SyncResponse struct {
+	// The time to send as the last sync of the boards in the next
+	// request, in miliseconds
+	// required: true
+	SyncAt int64 `json:"syncAt"`
+
+	// The changes of each board
+	// required: true
+	Boards []*BoardSync `json:"boards"`
+}
@@ -0,0 +1,163 @@
+package model
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	// TemplateDateLayout is the layout of dates passed as template variables.
+	TemplateDateLayout = "2006-01-02"
+
+	propTypeDate = "date"
+)
+
+var templateVariableRegexp = regexp.MustCompile(`{{\s*([a-zA-Z0-9_]+)\s*}}`)
+
+// TemplateVariables are the values used to fill in the placeholders, such as
+// `{{sprint_number}}`, when creating a board from a template
+// swagger:model
+type TemplateVariables map[string]string
+
+// TemplateVariablesFromJSON decodes the variables of a create-from-template request.
+func TemplateVariablesFromJSON(data []byte) (TemplateVariables, error) {
+	var request struct {
+		Variables TemplateVariables `json:"variables"`
+	}
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, err
+	}
+	return request.Variables, nil
+}
+
+// Replace returns s with the placeholders of known variables replaced by
+// their values. Unknown placeholders are left untouched.
+func (tv TemplateVariables) Replace(s string) string {
+	if len(tv) == 0 {
+		return s
+	}
+	return templateVariableRegexp.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := templateVariableRegexp.FindStringSubmatch(placeholder)[1]
+		if value, ok := tv[name]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// replaceDate returns the date property value for a value that consists
+// of a single placeholder. The variable can be a date in the
+// TemplateDateLayout format or a timestamp in milliseconds.
+func (tv TemplateVariables) replaceDate(s string) (string, bool) {
+	match := templateVariableRegexp.FindStringSubmatch(s)
+	if match == nil || match[0] != s {
+		return "", false
+	}
+
+	value, ok := tv[match[1]]
+	if !ok {
+		return "", false
+	}
+
+	millis, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		date, err := time.Parse(TemplateDateLayout, value)
+		if err != nil {
+			return "", false
+		}
+		millis = utils.GetMillisForTime(date)
+	}
+
+	data, err := json.Marshal(map[string]int64{"from": millis})
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// PatchBoardsAndBlocks returns the patches that fill in the placeholders of
+// the board titles, and of the titles and the text and date properties of
+// the blocks. Nil is returned if no placeholder was replaced.
+func (tv TemplateVariables) PatchBoardsAndBlocks(bab *BoardsAndBlocks) *PatchBoardsAndBlocks {
+	if len(tv) == 0 || bab == nil {
+		return nil
+	}
+
+	pbab := &PatchBoardsAndBlocks{}
+	schemas := map[string]PropSchema{}
+
+	for _, board := range bab.Boards {
+		schema, err := ParsePropertySchema(board)
+		if err == nil {
+			schemas[board.ID] = schema
+		}
+
+		title := tv.Replace(board.Title)
+		if title == board.Title {
+			continue
+		}
+		pbab.BoardIDs = append(pbab.BoardIDs, board.ID)
+		pbab.BoardPatches = append(pbab.BoardPatches, &BoardPatch{Title: &title})
+	}
+
+	for _, block := range bab.Blocks {
+		patch := &BlockPatch{}
+		changed := false
+
+		if title := tv.Replace(block.Title); title != block.Title {
+			patch.Title = &title
+			changed = true
+		}
+
+		if props, ok := tv.replaceProperties(block, schemas[block.BoardID]); ok {
+			patch.UpdatedFields = map[string]interface{}{"properties": props}
+			changed = true
+		}
+
+		if changed {
+			pbab.BlockIDs = append(pbab.BlockIDs, block.ID)
+			pbab.BlockPatches = append(pbab.BlockPatches, patch)
+		}
+	}
+
+	if len(pbab.BoardIDs) == 0 && len(pbab.BlockIDs) == 0 {
+		return nil
+	}
+	return pbab
+}
+
+func (tv TemplateVariables) replaceProperties(block Block, schema PropSchema) (map[string]interface{}, bool) {
+	props, ok := block.Fields["properties"].(map[string]interface{})
+	if !ok || len(props) == 0 {
+		return nil, false
+	}
+
+	newProps := make(map[string]interface{}, len(props))
+	changed := false
+	for id, v := range props {
+		newProps[id] = v
+
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+
+		if def, ok := schema[id]; ok && def.Type == propTypeDate {
+			if date, ok := tv.replaceDate(s); ok {
+				newProps[id] = date
+				changed = true
+			}
+			continue
+		}
+
+		if replaced := tv.Replace(s); replaced != s {
+			newProps[id] = replaced
+			changed = true
+		}
+	}
+	return newProps, changed
+}
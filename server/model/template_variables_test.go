@@ -0,0 +1,79 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateVariablesReplace(t *testing.T) {
+	tv := TemplateVariables{"sprint_number": "12"}
+
+	require.Equal(t, "Sprint 12", tv.Replace("Sprint {{sprint_number}}"))
+	require.Equal(t, "Sprint 12", tv.Replace("Sprint {{ sprint_number }}"))
+	require.Equal(t, "Release {{version}}", tv.Replace("Release {{version}}"))
+	require.Equal(t, "Sprint {{sprint_number}}", TemplateVariables(nil).Replace("Sprint {{sprint_number}}"))
+}
+
+func TestTemplateVariablesPatchBoardsAndBlocks(t *testing.T) {
+	board := &Board{
+		ID:    "board-id",
+		Title: "Sprint {{sprint_number}}",
+		CardProperties: []map[string]interface{}{
+			{"id": "due-prop", "name": "Due", "type": "date"},
+			{"id": "text-prop", "name": "Notes", "type": "text"},
+		},
+	}
+	card := Block{
+		ID:      "card-id",
+		BoardID: board.ID,
+		Type:    TypeCard,
+		Title:   "Plan sprint {{sprint_number}}",
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"due-prop":  "{{start_date}}",
+				"text-prop": "starts {{start_date}}",
+			},
+		},
+	}
+	untouched := Block{
+		ID:      "text-id",
+		BoardID: board.ID,
+		Type:    TypeText,
+		Title:   "no placeholders",
+	}
+	bab := &BoardsAndBlocks{Boards: []*Board{board}, Blocks: []Block{card, untouched}}
+
+	t.Run("no variables", func(t *testing.T) {
+		require.Nil(t, TemplateVariables{}.PatchBoardsAndBlocks(bab))
+	})
+
+	t.Run("no placeholders replaced", func(t *testing.T) {
+		require.Nil(t, TemplateVariables{"other": "value"}.PatchBoardsAndBlocks(bab))
+	})
+
+	t.Run("placeholders replaced", func(t *testing.T) {
+		tv := TemplateVariables{"sprint_number": "12", "start_date": "2022-05-02"}
+		pbab := tv.PatchBoardsAndBlocks(bab)
+		require.NotNil(t, pbab)
+
+		require.Equal(t, []string{board.ID}, pbab.BoardIDs)
+		require.Equal(t, "Sprint 12", *pbab.BoardPatches[0].Title)
+
+		require.Equal(t, []string{card.ID}, pbab.BlockIDs)
+		require.Equal(t, "Plan sprint 12", *pbab.BlockPatches[0].Title)
+		props := pbab.BlockPatches[0].UpdatedFields["properties"].(map[string]interface{})
+		require.Equal(t, `{"from":1651449600000}`, props["due-prop"])
+		require.Equal(t, "starts 2022-05-02", props["text-prop"])
+	})
+
+	t.Run("invalid date leaves the property untouched", func(t *testing.T) {
+		tv := TemplateVariables{"start_date": "next monday"}
+		pbab := tv.PatchBoardsAndBlocks(bab)
+		require.NotNil(t, pbab)
+		require.Empty(t, pbab.BoardIDs)
+		props := pbab.BlockPatches[0].UpdatedFields["properties"].(map[string]interface{})
+		require.Equal(t, "{{start_date}}", props["due-prop"])
+		require.Equal(t, "starts next monday", props["text-prop"])
+	})
+}
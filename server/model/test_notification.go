@@ -0,0 +1,22 @@
+package model
+
+// TestNotificationResult is the outcome of sending a test notification
+// through one notification backend
+// swagger:model
+type TestNotificationResult struct {
+	// The name of the notification backend
+	// required: true
+	Backend string `json:"backend"`
+
+	// Whether the test notification was delivered
+	// required: true
+	Success bool `json:"success"`
+
+	// Whether the backend doesn't support test notifications
+	// required: false
+	Skipped bool `json:"skipped,omitempty"`
+
+	// The reason the delivery failed
+	// required: false
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,309 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TextOp is an operation of a text delta. It either keeps, inserts or
+// deletes characters at the current position of the delta. The lengths
+// are in Unicode code points.
+// swagger:model
+type TextOp struct {
+	// The number of characters to keep
+	// required: false
+	Retain int `json:"retain,omitempty"`
+
+	// The text to insert
+	// required: false
+	Insert string `json:"insert,omitempty"`
+
+	// The number of characters to delete
+	// required: false
+	Delete int `json:"delete,omitempty"`
+}
+
+func (op TextOp) length() int {
+	switch {
+	case op.Retain > 0:
+		return op.Retain
+	case op.Delete > 0:
+		return op.Delete
+	}
+	return utf8.RuneCountInString(op.Insert)
+}
+
+// TextDelta is a change of a text, as the operations applied from its
+// start. The characters after the last operation are kept.
+type TextDelta []TextOp
+
+// IsValid checks that each operation of the delta does exactly one thing.
+func (d TextDelta) IsValid() error {
+	if len(d) == 0 {
+		return NewCodedError(ErrCodeBadRequest, "the delta is empty", nil)
+	}
+	for i, op := range d {
+		set := 0
+		if op.Retain != 0 {
+			set++
+		}
+		if op.Insert != "" {
+			set++
+		}
+		if op.Delete != 0 {
+			set++
+		}
+		if set != 1 || op.Retain < 0 || op.Delete < 0 {
+			return NewCodedError(ErrCodeBadRequest, "each delta operation must retain, insert or delete", map[string]interface{}{"index": i})
+		}
+	}
+	return nil
+}
+
+// BaseLength returns the number of characters of the text the delta
+// covers, at least.
+func (d TextDelta) BaseLength() int {
+	length := 0
+	for _, op := range d {
+		length += op.Retain + op.Delete
+	}
+	return length
+}
+
+// TargetLength returns the length of a text of baseLength characters once
+// the delta is applied.
+func (d TextDelta) TargetLength(baseLength int) int {
+	length := baseLength
+	for _, op := range d {
+		length += utf8.RuneCountInString(op.Insert) - op.Delete
+	}
+	return length
+}
+
+// Apply returns the text with the delta applied.
+func (d TextDelta) Apply(text string) (string, error) {
+	if err := d.IsValid(); err != nil {
+		return "", err
+	}
+
+	runes := []rune(text)
+	if d.BaseLength() > len(runes) {
+		return "", NewCodedError(ErrCodeBadRequest, "the delta is longer than the text", map[string]interface{}{"length": len(runes)})
+	}
+
+	var result strings.Builder
+	pos := 0
+	for _, op := range d {
+		switch {
+		case op.Retain > 0:
+			result.WriteString(string(runes[pos : pos+op.Retain]))
+			pos += op.Retain
+		case op.Delete > 0:
+			pos += op.Delete
+		default:
+			result.WriteString(op.Insert)
+		}
+	}
+	result.WriteString(string(runes[pos:]))
+	return result.String(), nil
+}
+
+// Normalize returns the delta with its adjacent operations of the same
+// kind merged, and the characters it keeps implicitly up to length
+// retained explicitly.
+func (d TextDelta) Normalize(length int) TextDelta {
+	b := &textDeltaBuilder{}
+	for _, op := range d {
+		b.add(op)
+	}
+	b.retain(length - d.BaseLength())
+	return b.ops
+}
+
+// TransformTextDelta returns the delta transformed to apply after
+// applied, when both were made concurrently on the same text of length
+// characters. When both insert at the same position, the text inserted
+// by applied comes first.
+func TransformTextDelta(delta, applied TextDelta, length int) (TextDelta, error) {
+	if err := delta.IsValid(); err != nil {
+		return nil, err
+	}
+	if delta.BaseLength() > length || applied.BaseLength() > length {
+		return nil, NewCodedError(ErrCodeBadRequest, "the delta is longer than the text", map[string]interface{}{"length": length})
+	}
+
+	ops1 := applied.Normalize(length)
+	ops2 := delta.Normalize(length)
+	result := &textDeltaBuilder{}
+
+	i1, i2 := 0, 0
+	var op1, op2 *TextOp
+	next := func(ops TextDelta, i *int) *TextOp {
+		if *i >= len(ops) {
+			return nil
+		}
+		op := ops[*i]
+		*i++
+		return &op
+	}
+	// consume removes n characters from the start of op, moving to the
+	// next operation once it is consumed
+	consume := func(op *TextOp, n int, ops TextDelta, i *int) *TextOp {
+		if op.length() == n {
+			return next(ops, i)
+		}
+		if op.Retain > 0 {
+			op.Retain -= n
+		} else {
+			op.Delete -= n
+		}
+		return op
+	}
+
+	op1 = next(ops1, &i1)
+	op2 = next(ops2, &i2)
+	for op1 != nil || op2 != nil {
+		if op1 != nil && op1.Insert != "" {
+			result.retain(op1.length())
+			op1 = next(ops1, &i1)
+			continue
+		}
+		if op2 != nil && op2.Insert != "" {
+			result.insert(op2.Insert)
+			op2 = next(ops2, &i2)
+			continue
+		}
+		if op1 == nil || op2 == nil {
+			// both deltas are normalized to the same length
+			break
+		}
+
+		n := op1.length()
+		if op2.length() < n {
+			n = op2.length()
+		}
+		switch {
+		case op1.Retain > 0 && op2.Retain > 0:
+			result.retain(n)
+		case op1.Retain > 0 && op2.Delete > 0:
+			result.delete(n)
+		}
+		// the characters deleted by applied are gone, whatever the
+		// delta does with them
+		op1 = consume(op1, n, ops1, &i1)
+		op2 = consume(op2, n, ops2, &i2)
+	}
+	return result.ops, nil
+}
+
+// textDeltaBuilder builds a delta, merging the adjacent operations of the
+// same kind.
+type textDeltaBuilder struct {
+	ops TextDelta
+}
+
+func (b *textDeltaBuilder) add(op TextOp) {
+	switch {
+	case op.Retain > 0:
+		b.retain(op.Retain)
+	case op.Delete > 0:
+		b.delete(op.Delete)
+	default:
+		b.insert(op.Insert)
+	}
+}
+
+func (b *textDeltaBuilder) last() *TextOp {
+	if len(b.ops) == 0 {
+		return nil
+	}
+	return &b.ops[len(b.ops)-1]
+}
+
+func (b *textDeltaBuilder) retain(n int) {
+	if n <= 0 {
+		return
+	}
+	if last := b.last(); last != nil && last.Retain > 0 {
+		last.Retain += n
+		return
+	}
+	b.ops = append(b.ops, TextOp{Retain: n})
+}
+
+func (b *textDeltaBuilder) insert(text string) {
+	if text == "" {
+		return
+	}
+	if last := b.last(); last != nil && last.Insert != "" {
+		last.Insert += text
+		return
+	}
+	b.ops = append(b.ops, TextOp{Insert: text})
+}
+
+func (b *textDeltaBuilder) delete(n int) {
+	if n <= 0 {
+		return
+	}
+	if last := b.last(); last != nil && last.Delete > 0 {
+		last.Delete += n
+		return
+	}
+	b.ops = append(b.ops, TextOp{Delete: n})
+}
+
+// TextEditRequest is a delta to apply to the text of a block, such as a
+// card description, by a collaborative editor.
+// swagger:model
+type TextEditRequest struct {
+	// The update time of the block the delta is based on. If the text has
+	// been edited since, the delta is transformed to apply on top of the
+	// edits instead of overwriting them
+	// required: true
+	BaseUpdateAt int64 `json:"baseUpdateAt"`
+
+	// The delta to apply
+	// required: true
+	Delta TextDelta `json:"delta"`
+}
+
+// TextEdit is a delta applied to the text of a block, as relayed to its
+// other editors.
+// swagger:model
+type TextEdit struct {
+	// The id of the edited block
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The id of the board of the block
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the user who made the edit
+	// required: true
+	UserID string `json:"userId"`
+
+	// The update time of the block the delta applies to
+	// required: true
+	BaseUpdateAt int64 `json:"baseUpdateAt"`
+
+	// The update time of the block once the delta is applied
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The delta, transformed against the concurrent edits
+	// required: true
+	Delta TextDelta `json:"delta"`
+}
+
+// IsTextEditableBlockType returns true for the blocks whose title is a
+// text that can be edited collaboratively with deltas: the text blocks,
+// such as card descriptions, headings and checkboxes.
+func IsTextEditableBlockType(blockType BlockType) bool {
+	switch blockType {
+	case TypeText, TypeHeading, TypeCheckbox:
+		return true
+	}
+	return false
+}
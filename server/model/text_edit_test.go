@@ -0,0 +1,90 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextDeltaApply(t *testing.T) {
+	text, err := TextDelta{{Retain: 6}, {Insert: "big "}}.Apply("Hello world")
+	require.NoError(t, err)
+	require.Equal(t, "Hello big world", text)
+
+	text, err = TextDelta{{Retain: 1}, {Delete: 1}, {Insert: "e"}}.Apply("héllo")
+	require.NoError(t, err)
+	require.Equal(t, "hello", text)
+
+	t.Run("invalid deltas", func(t *testing.T) {
+		for _, delta := range []TextDelta{
+			{},
+			{{Retain: 1, Insert: "a"}},
+			{{Retain: -1}},
+			{{}},
+		} {
+			_, err := delta.Apply("text")
+			require.True(t, IsErrBadRequest(err), delta)
+		}
+
+		_, err := TextDelta{{Retain: 5}}.Apply("text")
+		require.True(t, IsErrBadRequest(err))
+	})
+}
+
+func TestTextDeltaNormalize(t *testing.T) {
+	delta := TextDelta{{Retain: 1}, {Retain: 2}, {Insert: "a"}, {Insert: "b"}, {Delete: 1}}
+	require.Equal(t, TextDelta{{Retain: 3}, {Insert: "ab"}, {Delete: 1}, {Retain: 2}}, delta.Normalize(6))
+	require.Equal(t, 7, delta.TargetLength(6))
+}
+
+func TestTransformTextDelta(t *testing.T) {
+	transform := func(base string, applied, delta TextDelta) string {
+		text, err := applied.Apply(base)
+		require.NoError(t, err)
+		transformed, err := TransformTextDelta(delta, applied, len([]rune(base)))
+		require.NoError(t, err)
+		text, err = transformed.Apply(text)
+		require.NoError(t, err)
+		return text
+	}
+
+	t.Run("inserts at different positions", func(t *testing.T) {
+		require.Equal(t, "Hello big world!", transform("Hello world",
+			TextDelta{{Retain: 6}, {Insert: "big "}},
+			TextDelta{{Retain: 11}, {Insert: "!"}},
+		))
+	})
+
+	t.Run("inserts at the same position", func(t *testing.T) {
+		require.Equal(t, "aXYb", transform("ab",
+			TextDelta{{Retain: 1}, {Insert: "X"}},
+			TextDelta{{Retain: 1}, {Insert: "Y"}},
+		))
+	})
+
+	t.Run("overlapping deletes", func(t *testing.T) {
+		require.Equal(t, "Hellothere", transform("Hello world",
+			TextDelta{{Retain: 5}, {Delete: 6}},
+			TextDelta{{Retain: 6}, {Delete: 5}, {Insert: "there"}},
+		))
+	})
+
+	t.Run("insert in deleted text", func(t *testing.T) {
+		require.Equal(t, "Hello!", transform("Hello world",
+			TextDelta{{Retain: 5}, {Delete: 6}},
+			TextDelta{{Retain: 8}, {Insert: "!"}},
+		))
+	})
+
+	t.Run("delta longer than the text", func(t *testing.T) {
+		_, err := TransformTextDelta(TextDelta{{Retain: 10}}, TextDelta{{Insert: "a"}}, 5)
+		require.True(t, IsErrBadRequest(err))
+	})
+}
+
+func TestIsTextEditableBlockType(t *testing.T) {
+	require.True(t, IsTextEditableBlockType(TypeText))
+	require.True(t, IsTextEditableBlockType(TypeCheckbox))
+	require.False(t, IsTextEditableBlockType(TypeCard))
+	require.False(t, IsTextEditableBlockType(TypeImage))
+}
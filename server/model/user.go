@@ -3,12 +3,21 @@ package model
 import (
 	"encoding/json"
 	"io"
+	"time"
 )
 
 const (
 	SingleUser   = "single-user"
 	GlobalTeamID = "0"
 	SystemUserID = "system"
+
+	// UserPropTimezone is the user prop holding the user's timezone preference
+	// when it isn't provided by Mattermost.
+	UserPropTimezone = "focalboard_timezone"
+
+	// UserPropAssignmentNotifications is the user prop that, when set to
+	// "false", stops the notifications about card assignments.
+	UserPropAssignmentNotifications = "focalboard_assignmentNotifications"
 )
 
 // User is a user
@@ -61,6 +70,37 @@ type User struct {
 	// If the user is a guest or not
 	// required: true
 	IsGuest bool `json:"is_guest"`
+
+	// The user's timezone from the Mattermost profile, as an IANA name
+	// required: false
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// Location returns the time location of the user, preferring the timezone
+// preference set in Focalboard, then the one from the Mattermost profile.
+// The server's local time is used if the user has no valid timezone.
+func (u *User) Location() *time.Location {
+	candidates := []string{u.Timezone}
+	if tz, ok := u.Props[UserPropTimezone].(string); ok {
+		candidates = append([]string{tz}, candidates...)
+	}
+
+	for _, tz := range candidates {
+		if tz == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(tz); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// WantsAssignmentNotifications returns false if the user opted out of
+// the notifications about being assigned to cards.
+func (u *User) WantsAssignmentNotifications() bool {
+	value, _ := u.Props[UserPropAssignmentNotifications].(string)
+	return value != "false"
 }
 
 // UserPropPatch is a user property patch
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"encoding/json"
+)
+
+// UserMapping maps the IDs of the users of a board to the IDs of the
+// users that replace them when the board is duplicated, e.g. into another
+// team. A user mapped to an empty ID is dropped
+// swagger:model
+type UserMapping map[string]string
+
+// UserMappingFromJSON decodes the user mapping of a duplicate request.
+func UserMappingFromJSON(data []byte) (UserMapping, error) {
+	var request struct {
+		UserMapping UserMapping `json:"userMapping"`
+	}
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, err
+	}
+	return request.UserMapping, nil
+}
+
+// IsValid checks that the mapping has no empty user IDs to map.
+func (m UserMapping) IsValid() error {
+	for userID := range m {
+		if userID == "" {
+			return NewCodedError(ErrCodeBadRequest, "the users of a user mapping must not be empty", nil)
+		}
+	}
+	return nil
+}
+
+// MapUser returns the ID of the user that replaces the user. If the user
+// is not part of the mapping, ok is false. A dropped user has an empty ID.
+func (m UserMapping) MapUser(userID string) (newUserID string, ok bool) {
+	newUserID, ok = m[userID]
+	return newUserID, ok
+}
+
+// TargetUserIDs returns the IDs of the users that replace the users of
+// the mapping.
+func (m UserMapping) TargetUserIDs() []string {
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, newUserID := range m {
+		if newUserID != "" && !seen[newUserID] {
+			seen[newUserID] = true
+			ids = append(ids, newUserID)
+		}
+	}
+	return ids
+}
+
+// MapPersonProperties replaces the users of the person properties of the
+// blocks of the board. Dropped users are removed from the properties, and
+// the users missing from the mapping are kept.
+func (m UserMapping) MapPersonProperties(board *Board, blocks []Block) {
+	if len(m) == 0 {
+		return
+	}
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return
+	}
+
+	for i := range blocks {
+		props, ok := blocks[i].Fields["properties"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for id, value := range props {
+			def, ok := schema[id]
+			if !ok || !IsPersonPropertyType(def.Type) {
+				continue
+			}
+			switch value := value.(type) {
+			case string:
+				if newUserID, ok := m.MapUser(value); ok {
+					if newUserID == "" {
+						delete(props, id)
+					} else {
+						props[id] = newUserID
+					}
+				}
+			case []interface{}:
+				mapped := make([]interface{}, 0, len(value))
+				for _, item := range value {
+					if s, isString := item.(string); isString {
+						if newUserID, ok := m.MapUser(s); ok {
+							if newUserID == "" {
+								continue
+							}
+							item = newUserID
+						}
+					}
+					mapped = append(mapped, item)
+				}
+				props[id] = mapped
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserMappingFromJSON(t *testing.T) {
+	mapping, err := UserMappingFromJSON([]byte(`{"variables":{"a":"b"},"userMapping":{"user-1":"user-2","user-3":""}}`))
+	require.NoError(t, err)
+	require.Equal(t, UserMapping{"user-1": "user-2", "user-3": ""}, mapping)
+
+	mapping, err = UserMappingFromJSON([]byte(`{"variables":{"a":"b"}}`))
+	require.NoError(t, err)
+	require.Empty(t, mapping)
+
+	_, err = UserMappingFromJSON([]byte(`{"userMapping":["user-1"]}`))
+	require.Error(t, err)
+}
+
+func TestUserMappingIsValid(t *testing.T) {
+	require.NoError(t, UserMapping(nil).IsValid())
+	require.NoError(t, UserMapping{"user-1": "user-2", "user-3": ""}.IsValid())
+	require.Error(t, UserMapping{"": "user-2"}.IsValid())
+}
+
+func TestUserMappingTargetUserIDs(t *testing.T) {
+	mapping := UserMapping{"user-1": "user-2", "user-3": "user-2", "user-4": ""}
+	require.Equal(t, []string{"user-2"}, mapping.TargetUserIDs())
+}
+
+func TestUserMappingMapPersonProperties(t *testing.T) {
+	board := &Board{
+		ID: "board-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "owner-prop", "name": "Owner", "type": "person"},
+			{"id": "reviewer-prop", "name": "Reviewer", "type": "person"},
+			{"id": "team-prop", "name": "Team", "type": "multiPerson"},
+			{"id": "text-prop", "name": "Notes", "type": "text"},
+		},
+	}
+	blocks := []Block{
+		{
+			ID:      "card-id",
+			BoardID: board.ID,
+			Type:    TypeCard,
+			Fields: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"owner-prop":    "user-1",
+					"reviewer-prop": "user-3",
+					"team-prop":     []interface{}{"user-1", "user-3", "user-5"},
+					"text-prop":     "user-1",
+				},
+			},
+		},
+		{ID: "text-id", BoardID: board.ID, Type: TypeText},
+	}
+
+	mapping := UserMapping{"user-1": "user-2", "user-3": ""}
+	mapping.MapPersonProperties(board, blocks)
+
+	require.Equal(t, map[string]interface{}{
+		"owner-prop": "user-2",
+		"team-prop":  []interface{}{"user-2", "user-5"},
+		"text-prop":  "user-1",
+	}, blocks[0].Fields["properties"])
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserLocation(t *testing.T) {
+	t.Run("no timezone", func(t *testing.T) {
+		user := &User{}
+		require.Equal(t, time.Local, user.Location())
+	})
+
+	t.Run("mattermost timezone", func(t *testing.T) {
+		user := &User{Timezone: "Europe/Berlin"}
+		require.Equal(t, "Europe/Berlin", user.Location().String())
+	})
+
+	t.Run("preference overrides mattermost timezone", func(t *testing.T) {
+		user := &User{
+			Timezone: "Europe/Berlin",
+			Props:    map[string]interface{}{UserPropTimezone: "Asia/Tokyo"},
+		}
+		require.Equal(t, "Asia/Tokyo", user.Location().String())
+	})
+
+	t.Run("invalid preference is ignored", func(t *testing.T) {
+		user := &User{
+			Timezone: "Europe/Berlin",
+			Props:    map[string]interface{}{UserPropTimezone: "Mars/Olympus_Mons"},
+		}
+		require.Equal(t, "Europe/Berlin", user.Location().String())
+	})
+}
+
+func TestUserWantsAssignmentNotifications(t *testing.T) {
+	require.True(t, (&User{}).WantsAssignmentNotifications())
+	require.True(t, (&User{Props: map[string]interface{}{UserPropAssignmentNotifications: "true"}}).WantsAssignmentNotifications())
+	require.False(t, (&User{Props: map[string]interface{}{UserPropAssignmentNotifications: "false"}}).WantsAssignmentNotifications())
+}
@@ -0,0 +1,397 @@
+package model
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// TitleColumnID is the property ID the views sort the cards by title
+	// with.
+	TitleColumnID = "__title"
+
+	// DefaultViewCardsLimit is the number of cards of a view returned
+	// when no limit is requested.
+	DefaultViewCardsLimit = 100
+
+	// MaxViewCardsLimit is the largest number of cards of a view returned
+	// at once.
+	MaxViewCardsLimit = 1000
+)
+
+// ViewCards is a window of the cards of a board in the order of a view
+// swagger:model
+type ViewCards struct {
+	// The cards of the window
+	// required: true
+	Cards []Block `json:"cards"`
+
+	// The position of the first card of the window in the view
+	// required: true
+	Offset int `json:"offset"`
+
+	// The number of cards of the board
+	// required: true
+	Total int `json:"total"`
+
+	// Indicates if there are cards after the window
+	// required: true
+	HasNext bool `json:"hasNext"`
+
+	// How long the cards of the window have been in their current status,
+	// by card ID, if the board has a status property
+	// required: false
+	Aging map[string]*CardAging `json:"aging,omitempty"`
+}
+
+// ViewSortOption is a property a view sorts the cards by.
+type ViewSortOption struct {
+	PropertyID string
+	Reversed   bool
+}
+
+// ViewSortOptions returns the sort options of the view.
+func ViewSortOptions(view *Block) []ViewSortOption {
+	items, _ := view.Fields["sortOptions"].([]interface{})
+	options := make([]ViewSortOption, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		propertyID, _ := m["propertyId"].(string)
+		reversed, _ := m["reversed"].(bool)
+		if propertyID != "" {
+			options = append(options, ViewSortOption{PropertyID: propertyID, Reversed: reversed})
+		}
+	}
+	return options
+}
+
+// ViewSortsByUser returns true if the view sorts the cards by the user
+// who created or last modified them, which needs their usernames.
+func ViewSortsByUser(view *Block, schema PropSchema) bool {
+	for _, option := range ViewSortOptions(view) {
+		if def, ok := schema[option.PropertyID]; ok && (def.Type == propTypeCreatedBy || def.Type == propTypeUpdatedBy) {
+			return true
+		}
+	}
+	return false
+}
+
+// The kinds of ViewCardsSort, how the store sorts the cards by a sort
+// option of a view.
+const (
+	ViewCardsSortByTitle     = "title"
+	ViewCardsSortByCreateAt  = "createAt"
+	ViewCardsSortByUpdateAt  = "updateAt"
+	ViewCardsSortByNumber    = "number"
+	ViewCardsSortByDate      = "date"
+	ViewCardsSortByText      = "text"
+	ViewCardsSortByOption    = "option"
+	ViewCardsSortByCreatedBy = "createdBy"
+	ViewCardsSortByUpdatedBy = "updatedBy"
+)
+
+// ViewCardsSort is a sort option of a view, as the store applies it.
+type ViewCardsSort struct {
+	Kind       string // one of the ViewCardsSortBy* kinds
+	PropertyID string // the property of the number, date, text and option kinds
+	List       bool   // the property holds a list, which sorts by its first item
+	Reversed   bool
+
+	// Ranks orders the options of the option kind, and the users of the
+	// user kinds, by their text. The options without a rank sort as an
+	// empty text, the users without one as if the cards had no user.
+	Ranks map[string]int
+}
+
+// QueryViewCardsOptions are the options of GetViewCards: the order of
+// the view and the window of cards.
+type QueryViewCardsOptions struct {
+	Sorts     []ViewCardsSort // the sort options of the view
+	CardOrder []string        // the manual order of the view, used without sort options
+	Offset    int
+	Limit     int
+}
+
+// NewQueryViewCardsOptions returns the options that get the window of
+// cards in the order SortCardsForView sorts them.
+func NewQueryViewCardsOptions(view *Block, schema PropSchema, usernames map[string]string, offset, limit int) QueryViewCardsOptions {
+	opts := QueryViewCardsOptions{Offset: offset, Limit: limit}
+
+	options := ViewSortOptions(view)
+	if len(options) == 0 {
+		seen := map[string]bool{}
+		items, _ := view.Fields["cardOrder"].([]interface{})
+		for _, item := range items {
+			if id, ok := item.(string); ok && !seen[id] {
+				seen[id] = true
+				opts.CardOrder = append(opts.CardOrder, id)
+			}
+		}
+		return opts
+	}
+
+	for _, option := range options {
+		sort := ViewCardsSort{PropertyID: option.PropertyID, Reversed: option.Reversed}
+		if option.PropertyID == TitleColumnID {
+			sort.Kind = ViewCardsSortByTitle
+			opts.Sorts = append(opts.Sorts, sort)
+			continue
+		}
+
+		def, ok := schema[option.PropertyID]
+		if !ok {
+			continue
+		}
+		switch def.Type {
+		case propTypeCreatedTime:
+			sort.Kind = ViewCardsSortByCreateAt
+		case propTypeUpdatedTime:
+			sort.Kind = ViewCardsSortByUpdateAt
+		case "number":
+			sort.Kind = ViewCardsSortByNumber
+		case propTypeDate:
+			sort.Kind = ViewCardsSortByDate
+		case propTypeCreatedBy, propTypeUpdatedBy:
+			sort.Kind = ViewCardsSortByCreatedBy
+			if def.Type == propTypeUpdatedBy {
+				sort.Kind = ViewCardsSortByUpdatedBy
+			}
+			sort.Ranks = textRanks(usernames)
+		case propTypeSelect, "multiSelect":
+			sort.Kind = ViewCardsSortByOption
+			sort.List = def.Type == "multiSelect"
+			values := make(map[string]string, len(def.Options))
+			for id, option := range def.Options {
+				values[id] = option.Value
+			}
+			sort.Ranks = textRanks(values)
+		default:
+			sort.Kind = ViewCardsSortByText
+			sort.List = def.Type == propTypeMultiPerson
+		}
+		opts.Sorts = append(opts.Sorts, sort)
+	}
+	return opts
+}
+
+// textRanks ranks the keys by their text as compareText orders them, the
+// keys with the same text sharing a rank. The keys with an empty text
+// are left out.
+func textRanks(texts map[string]string) map[string]int {
+	distinct := []string{}
+	seen := map[string]bool{}
+	for _, text := range texts {
+		if text != "" && !seen[text] {
+			seen[text] = true
+			distinct = append(distinct, text)
+		}
+	}
+	sort.Slice(distinct, func(i, j int) bool {
+		return compareText(distinct[i], distinct[j]) < 0
+	})
+
+	rankOf := make(map[string]int, len(distinct))
+	for i, text := range distinct {
+		rankOf[text] = i
+	}
+	ranks := make(map[string]int, len(texts))
+	for key, text := range texts {
+		if text != "" {
+			ranks[key] = rankOf[text]
+		}
+	}
+	return ranks
+}
+
+// SortCardsForView sorts the cards in the order the view shows them, as
+// the web app does: by the sort options of the view, or by its manual
+// card order if it has none. Usernames maps the IDs of the users who
+// created or modified the cards to their usernames, and is only needed if
+// ViewSortsByUser.
+func SortCardsForView(cards []Block, schema PropSchema, view *Block, usernames map[string]string) {
+	options := ViewSortOptions(view)
+	if len(options) == 0 {
+		sortCardsManually(cards, view)
+		return
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		for _, option := range options {
+			if result := compareCardsByOption(&cards[i], &cards[j], option, schema, usernames); result != 0 {
+				return result < 0
+			}
+		}
+		return false
+	})
+}
+
+func sortCardsManually(cards []Block, view *Block) {
+	order := map[string]int{}
+	items, _ := view.Fields["cardOrder"].([]interface{})
+	for i, item := range items {
+		if id, ok := item.(string); ok {
+			if _, ok := order[id]; !ok {
+				order[id] = i
+			}
+		}
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, aOK := order[cards[i].ID]
+		b, bOK := order[cards[j].ID]
+		switch {
+		case aOK && bOK:
+			return a < b
+		case aOK != bOK:
+			// the cards missing from the order come last
+			return aOK
+		}
+		return compareTitleOrCreated(&cards[i], &cards[j]) < 0
+	})
+}
+
+// compareTitleOrCreated orders the cards by title, the untitled ones last
+// by creation time.
+func compareTitleOrCreated(a, b *Block) int {
+	switch {
+	case a.Title != "" && b.Title != "":
+		return compareText(a.Title, b.Title)
+	case a.Title != "":
+		return -1
+	case b.Title != "":
+		return 1
+	}
+	return compareInt64(a.CreateAt, b.CreateAt)
+}
+
+func compareCardsByOption(a, b *Block, option ViewSortOption, schema PropSchema, usernames map[string]string) int {
+	reverse := func(result int) int {
+		if option.Reversed {
+			return -result
+		}
+		return result
+	}
+
+	if option.PropertyID == TitleColumnID {
+		return reverse(compareTitleOrCreated(a, b))
+	}
+
+	def, ok := schema[option.PropertyID]
+	if !ok {
+		return 0
+	}
+
+	var result int
+	switch def.Type {
+	case propTypeCreatedTime:
+		result = compareInt64(a.CreateAt, b.CreateAt)
+	case propTypeUpdatedTime:
+		result = compareInt64(a.UpdateAt, b.UpdateAt)
+	case "number", propTypeDate:
+		aValue, aOK := numericSortValue(a, def)
+		bValue, bOK := numericSortValue(b, def)
+		// the cards without a value come last, whatever the direction
+		switch {
+		case aOK != bOK:
+			if aOK {
+				return -1
+			}
+			return 1
+		case !aOK:
+			return compareTitleOrCreated(a, b)
+		}
+		switch {
+		case aValue < bValue:
+			result = -1
+		case aValue > bValue:
+			result = 1
+		}
+	default:
+		aValue, aOK := textSortValue(a, def, usernames)
+		bValue, bOK := textSortValue(b, def, usernames)
+		switch {
+		case aOK != bOK:
+			if aOK {
+				return -1
+			}
+			return 1
+		case !aOK:
+			return compareTitleOrCreated(a, b)
+		}
+		result = compareText(aValue, bValue)
+	}
+
+	if result == 0 {
+		result = compareTitleOrCreated(a, b)
+	}
+	return reverse(result)
+}
+
+func cardPropertyValue(card *Block, propertyID string) interface{} {
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	return props[propertyID]
+}
+
+func numericSortValue(card *Block, def PropDef) (float64, bool) {
+	value, _ := cardPropertyValue(card, def.ID).(string)
+	if value == "" {
+		return 0, false
+	}
+	if def.Type == propTypeDate {
+		return float64(parseDateFrom(value)), true
+	}
+	number, _ := strconv.ParseFloat(value, 64)
+	return number, true
+}
+
+// textSortValue returns the text the card sorts by, the option value for
+// select properties, and false if the card has no value.
+func textSortValue(card *Block, def PropDef, usernames map[string]string) (string, bool) {
+	switch def.Type {
+	case propTypeCreatedBy:
+		return usernames[card.CreatedBy], usernames[card.CreatedBy] != ""
+	case propTypeUpdatedBy:
+		return usernames[card.ModifiedBy], usernames[card.ModifiedBy] != ""
+	}
+
+	var value string
+	switch v := cardPropertyValue(card, def.ID).(type) {
+	case string:
+		value = v
+	case []interface{}:
+		// lists sort by their first item
+		if len(v) > 0 {
+			value, _ = v[0].(string)
+		}
+	}
+	if value == "" {
+		return "", false
+	}
+
+	if def.Type == propTypeSelect || def.Type == "multiSelect" {
+		return def.Options[value].Value, true
+	}
+	return value, true
+}
+
+// compareText orders the texts alphabetically regardless of case.
+func compareText(a, b string) int {
+	if result := strings.Compare(strings.ToLower(a), strings.ToLower(b)); result != 0 {
+		return result
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortCardsForView(t *testing.T) {
+	schema := PropSchema{
+		"status": {ID: "status", Name: "Status", Type: "select", Options: map[string]PropDefOption{
+			"opt-a": {ID: "opt-a", Value: "Backlog"},
+			"opt-b": {ID: "opt-b", Value: "active"},
+		}},
+		"points":  {ID: "points", Name: "Points", Type: "number"},
+		"created": {ID: "created", Name: "Created by", Type: "createdBy"},
+	}
+
+	card := func(id, title string, createAt int64, props map[string]interface{}) Block {
+		return Block{ID: id, Title: title, CreateAt: createAt, CreatedBy: id + "-user", Type: TypeCard, Fields: map[string]interface{}{"properties": props}}
+	}
+	view := func(fields map[string]interface{}) *Block {
+		return &Block{ID: "view-id", Type: TypeView, Fields: fields}
+	}
+	sortOptions := func(propertyID string, reversed bool) map[string]interface{} {
+		return map[string]interface{}{"sortOptions": []interface{}{
+			map[string]interface{}{"propertyId": propertyID, "reversed": reversed},
+		}}
+	}
+	ids := func(cards []Block) []string {
+		result := []string{}
+		for _, c := range cards {
+			result = append(result, c.ID)
+		}
+		return result
+	}
+	cards := func() []Block {
+		return []Block{
+			card("c1", "banana", 1, map[string]interface{}{"status": "opt-b", "points": "10"}),
+			card("c2", "", 2, map[string]interface{}{"status": "opt-a"}),
+			card("c3", "Apple", 3, map[string]interface{}{"points": "2"}),
+			card("c4", "", 4, map[string]interface{}{"points": "2"}),
+		}
+	}
+
+	t.Run("manual order", func(t *testing.T) {
+		c := cards()
+		SortCardsForView(c, schema, view(map[string]interface{}{"cardOrder": []interface{}{"c4", "c2"}}), nil)
+		require.Equal(t, []string{"c4", "c2", "c3", "c1"}, ids(c), "the cards missing from the order come last by title")
+	})
+
+	t.Run("title", func(t *testing.T) {
+		c := cards()
+		SortCardsForView(c, schema, view(sortOptions(TitleColumnID, false)), nil)
+		require.Equal(t, []string{"c3", "c1", "c2", "c4"}, ids(c))
+
+		SortCardsForView(c, schema, view(sortOptions(TitleColumnID, true)), nil)
+		require.Equal(t, []string{"c4", "c2", "c1", "c3"}, ids(c))
+	})
+
+	t.Run("select by option value", func(t *testing.T) {
+		c := cards()
+		SortCardsForView(c, schema, view(sortOptions("status", false)), nil)
+		require.Equal(t, []string{"c1", "c2", "c3", "c4"}, ids(c))
+	})
+
+	t.Run("numbers with the empty values last", func(t *testing.T) {
+		c := cards()
+		SortCardsForView(c, schema, view(sortOptions("points", true)), nil)
+		require.Equal(t, []string{"c1", "c4", "c3", "c2"}, ids(c), "the ties are broken by title, in the same direction")
+	})
+
+	t.Run("created by", func(t *testing.T) {
+		c := cards()
+		usernames := map[string]string{"c1-user": "zoe", "c2-user": "adam", "c3-user": "bob"}
+		require.True(t, ViewSortsByUser(view(sortOptions("created", false)), schema))
+		SortCardsForView(c, schema, view(sortOptions("created", false)), usernames)
+		require.Equal(t, []string{"c2", "c3", "c1", "c4"}, ids(c))
+	})
+}
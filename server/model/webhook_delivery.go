@@ -0,0 +1,103 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+const (
+	// WebhookDeliveryPending deliveries are retried by the server.
+	WebhookDeliveryPending = "pending"
+
+	// WebhookDeliveryDead deliveries failed too many times, and are only
+	// retried manually through the admin API.
+	WebhookDeliveryDead = "dead"
+
+	// MaxWebhookDeliveryAttempts is the number of attempts after which a
+	// delivery is moved to the dead-letter queue.
+	MaxWebhookDeliveryAttempts = 8
+
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = 4 * time.Hour
+)
+
+// WebhookDelivery is an outgoing webhook request that failed, kept to be
+// retried
+// swagger:model
+type WebhookDelivery struct {
+	// The ID of the delivery
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board webhook, empty for the webhooks of the configuration
+	// required: false
+	WebhookID string `json:"webhookId,omitempty"`
+
+	// The URL of the webhook
+	// required: true
+	URL string `json:"url"`
+
+	// The JSON body of the request
+	// required: true
+	Payload string `json:"payload"`
+
+	// The number of failed attempts
+	// required: true
+	Attempts int `json:"attempts"`
+
+	// The error of the last attempt
+	// required: false
+	LastError string `json:"lastError"`
+
+	// The status of the delivery, pending or dead
+	// required: true
+	Status string `json:"status"`
+
+	// The time of the next automatic attempt, in miliseconds
+	// required: true
+	NextAttemptAt int64 `json:"nextAttemptAt"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last update time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// WebhookRetryDelay returns how long to wait before the next attempt of a
+// delivery that failed the given number of times. The delay doubles with
+// each attempt, up to a maximum.
+func WebhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= webhookRetryMaxDelay {
+			return webhookRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// RecordFailure updates the delivery after a failed attempt, scheduling
+// the next one or moving it to the dead-letter queue.
+func (d *WebhookDelivery) RecordFailure(err error, now int64) {
+	d.Attempts++
+	d.LastError = err.Error()
+	d.UpdateAt = now
+	if d.Attempts >= MaxWebhookDeliveryAttempts {
+		d.Status = WebhookDeliveryDead
+		d.NextAttemptAt = 0
+		return
+	}
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = now + WebhookRetryDelay(d.Attempts).Milliseconds()
+}
+
+func WebhookDeliveriesFromJSON(data io.Reader) []*WebhookDelivery {
+	var deliveries []*WebhookDelivery
+	_ = json.NewDecoder(data).Decode(&deliveries)
+	return deliveries
+}
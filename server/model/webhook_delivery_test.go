@@ -0,0 +1,32 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookRetryDelay(t *testing.T) {
+	require.Equal(t, 30*time.Second, WebhookRetryDelay(1))
+	require.Equal(t, time.Minute, WebhookRetryDelay(2))
+	require.Equal(t, 2*time.Minute, WebhookRetryDelay(3))
+	require.Equal(t, 4*time.Hour, WebhookRetryDelay(20))
+}
+
+func TestWebhookDeliveryRecordFailure(t *testing.T) {
+	delivery := &WebhookDelivery{URL: "http://example.com", Status: WebhookDeliveryPending}
+
+	delivery.RecordFailure(errors.New("connection refused"), 1000)
+	require.Equal(t, 1, delivery.Attempts)
+	require.Equal(t, "connection refused", delivery.LastError)
+	require.Equal(t, WebhookDeliveryPending, delivery.Status)
+	require.Equal(t, int64(1000+30000), delivery.NextAttemptAt)
+
+	for delivery.Attempts < MaxWebhookDeliveryAttempts {
+		delivery.RecordFailure(errors.New("connection refused"), 2000)
+	}
+	require.Equal(t, WebhookDeliveryDead, delivery.Status)
+	require.Zero(t, delivery.NextAttemptAt)
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"title":"hello"}`)
+	now := time.Unix(1650000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := SignWebhookPayload("secret", now.Unix(), body)
+
+	t.Run("valid signature", func(t *testing.T) {
+		require.NoError(t, VerifyWebhookSignature("secret", signature, timestamp, body, 0, now))
+		require.NoError(t, VerifyWebhookSignature("secret", signature, timestamp, body, 0, now.Add(4*time.Minute)))
+	})
+
+	t.Run("missing headers", func(t *testing.T) {
+		require.ErrorIs(t, VerifyWebhookSignature("secret", "", timestamp, body, 0, now), ErrWebhookSignatureMissing)
+		require.ErrorIs(t, VerifyWebhookSignature("secret", signature, "", body, 0, now), ErrWebhookSignatureMissing)
+	})
+
+	t.Run("wrong secret or body", func(t *testing.T) {
+		require.ErrorIs(t, VerifyWebhookSignature("other", signature, timestamp, body, 0, now), ErrWebhookSignatureInvalid)
+		require.ErrorIs(t, VerifyWebhookSignature("secret", signature, timestamp, []byte("{}"), 0, now), ErrWebhookSignatureInvalid)
+		require.ErrorIs(t, VerifyWebhookSignature("secret", "md5=abc", timestamp, body, 0, now), ErrWebhookSignatureInvalid)
+	})
+
+	t.Run("timestamp is signed", func(t *testing.T) {
+		later := strconv.FormatInt(now.Unix()+60, 10)
+		require.ErrorIs(t, VerifyWebhookSignature("secret", signature, later, body, 0, now), ErrWebhookSignatureInvalid)
+	})
+
+	t.Run("replayed request", func(t *testing.T) {
+		require.ErrorIs(t, VerifyWebhookSignature("secret", signature, timestamp, body, 0, now.Add(10*time.Minute)), ErrWebhookTimestampExpired)
+		require.ErrorIs(t, VerifyWebhookSignature("secret", signature, timestamp, body, 0, now.Add(-10*time.Minute)), ErrWebhookTimestampExpired)
+		require.NoError(t, VerifyWebhookSignature("secret", signature, timestamp, body, time.Hour, now.Add(10*time.Minute)))
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		require.ErrorIs(t, VerifyWebhookSignature("secret", signature, "yesterday", body, 0, now), ErrWebhookTimestampInvalid)
+	})
+}
@@ -0,0 +1,171 @@
+package model
+
+import (
+	"fmt"
+)
+
+const (
+	// WIPLimitModeSoft accepts the moves that exceed the limit of a lane,
+	// and flags them.
+	WIPLimitModeSoft = "soft"
+
+	// WIPLimitModeHard rejects the moves that exceed the limit of a lane.
+	WIPLimitModeHard = "hard"
+
+	// WIPLimitStatusUnder is the status of a lane with less cards than its
+	// limit.
+	WIPLimitStatusUnder = "under"
+
+	// WIPLimitStatusAt is the status of a lane with as many cards as its
+	// limit.
+	WIPLimitStatusAt = "at"
+
+	// WIPLimitStatusOver is the status of a lane with more cards than its
+	// limit.
+	WIPLimitStatusOver = "over"
+
+	viewFieldGroupByID    = "groupById"
+	viewFieldWIPLimits    = "wipLimits"
+	viewFieldWIPLimitMode = "wipLimitMode"
+)
+
+// WIPLimits are the maximum numbers of cards of the lanes of a kanban
+// view, stored in the view fields as wipLimits, by option ID, and
+// wipLimitMode.
+type WIPLimits struct {
+	ViewID string
+
+	// PropertyID is the property the view groups the cards by.
+	PropertyID string
+
+	// Limits are the maximum numbers of cards by value of the property.
+	Limits map[string]int64
+
+	// Mode is WIPLimitModeSoft or WIPLimitModeHard.
+	Mode string
+}
+
+// WIPLimitsFromView returns the limits of the lanes of a view, or nil if
+// the view has none. Limits that are not positive numbers are ignored.
+func WIPLimitsFromView(view *Block) *WIPLimits {
+	propertyID, _ := view.Fields[viewFieldGroupByID].(string)
+	stored, _ := view.Fields[viewFieldWIPLimits].(map[string]interface{})
+	if propertyID == "" || len(stored) == 0 {
+		return nil
+	}
+
+	limits := map[string]int64{}
+	for value, limit := range stored {
+		if n, ok := limit.(float64); ok && n >= 1 {
+			limits[value] = int64(n)
+		}
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+
+	mode, _ := view.Fields[viewFieldWIPLimitMode].(string)
+	if mode != WIPLimitModeHard {
+		mode = WIPLimitModeSoft
+	}
+
+	return &WIPLimits{
+		ViewID:     view.ID,
+		PropertyID: propertyID,
+		Limits:     limits,
+		Mode:       mode,
+	}
+}
+
+// Status returns the limit of the lane of the value and whether the
+// number of cards is under, at or over it. The status is empty if the
+// lane has no limit.
+func (l *WIPLimits) Status(value string, count int64) (int64, string) {
+	if l == nil {
+		return 0, ""
+	}
+	limit, ok := l.Limits[value]
+	if !ok {
+		return 0, ""
+	}
+	switch {
+	case count < limit:
+		return limit, WIPLimitStatusUnder
+	case count == limit:
+		return limit, WIPLimitStatusAt
+	default:
+		return limit, WIPLimitStatusOver
+	}
+}
+
+// WIPLimitExceeded is a lane of a view whose limit is exceeded by moving
+// cards to it
+// swagger:model
+type WIPLimitExceeded struct {
+	// The id of the view
+	// required: true
+	ViewID string `json:"viewId"`
+
+	// The id of the property the view groups the cards by
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The value of the property of the lane, the option ID for select properties
+	// required: true
+	Value string `json:"value"`
+
+	// The maximum number of cards of the lane
+	// required: true
+	Limit int64 `json:"limit"`
+
+	// The number of cards of the lane after the move
+	// required: true
+	Count int64 `json:"count"`
+
+	// The mode of the limit, soft or hard
+	// required: true
+	Mode string `json:"mode"`
+}
+
+// BlockPatchResult is the result of patching blocks, with the soft WIP
+// limits the patches exceeded
+// swagger:model
+type BlockPatchResult struct {
+	// The lanes whose soft limit was exceeded by the patches
+	// required: false
+	WIPLimitsExceeded []*WIPLimitExceeded `json:"wipLimitsExceeded,omitempty"`
+}
+
+// NewBlockPatchResult returns the result of patches that exceeded the
+// limits of the lanes, keeping the soft ones, as the hard ones reject the
+// patches.
+func NewBlockPatchResult(exceeded []*WIPLimitExceeded) *BlockPatchResult {
+	result := &BlockPatchResult{}
+	for _, e := range exceeded {
+		if e.Mode == WIPLimitModeSoft {
+			result.WIPLimitsExceeded = append(result.WIPLimitsExceeded, e)
+		}
+	}
+	return result
+}
+
+// NewErrWIPLimitExceeded creates an error for a move rejected by the hard
+// limit of a lane.
+func NewErrWIPLimitExceeded(exceeded *WIPLimitExceeded) *CodedError {
+	return NewCodedError(ErrCodeWIPLimitExceeded,
+		fmt.Sprintf("the lane can't have more than %d cards", exceeded.Limit),
+		map[string]interface{}{
+			"viewId":     exceeded.ViewID,
+			"propertyId": exceeded.PropertyID,
+			"value":      exceeded.Value,
+			"limit":      exceeded.Limit,
+		},
+	)
+}
+
+// IsErrWIPLimitExceeded returns true if the error is a move rejected by
+// the hard limit of a lane.
+func IsErrWIPLimitExceeded(err error) bool {
+	ce, ok := AsCodedError(err)
+	return ok && ce.Code == ErrCodeWIPLimitExceeded
+}
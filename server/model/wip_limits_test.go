@@ -0,0 +1,76 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWIPLimitsFromView(t *testing.T) {
+	t.Run("view without limits", func(t *testing.T) {
+		require.Nil(t, WIPLimitsFromView(&Block{ID: "view-id", Fields: map[string]interface{}{"groupById": "status-id"}}))
+		require.Nil(t, WIPLimitsFromView(&Block{ID: "view-id", Fields: map[string]interface{}{
+			"wipLimits": map[string]interface{}{"doing": float64(2)},
+		}}))
+		require.Nil(t, WIPLimitsFromView(&Block{ID: "view-id", Fields: map[string]interface{}{
+			"groupById": "status-id",
+			"wipLimits": map[string]interface{}{"doing": float64(0), "done": "3"},
+		}}))
+	})
+
+	t.Run("limits default to soft", func(t *testing.T) {
+		limits := WIPLimitsFromView(&Block{ID: "view-id", Fields: map[string]interface{}{
+			"groupById":    "status-id",
+			"wipLimits":    map[string]interface{}{"doing": float64(2), "done": float64(-1)},
+			"wipLimitMode": "strict",
+		}})
+		require.Equal(t, &WIPLimits{
+			ViewID:     "view-id",
+			PropertyID: "status-id",
+			Limits:     map[string]int64{"doing": 2},
+			Mode:       WIPLimitModeSoft,
+		}, limits)
+	})
+
+	t.Run("hard limits", func(t *testing.T) {
+		limits := WIPLimitsFromView(&Block{ID: "view-id", Fields: map[string]interface{}{
+			"groupById":    "status-id",
+			"wipLimits":    map[string]interface{}{"doing": float64(2)},
+			"wipLimitMode": WIPLimitModeHard,
+		}})
+		require.Equal(t, WIPLimitModeHard, limits.Mode)
+	})
+}
+
+func TestWIPLimitsStatus(t *testing.T) {
+	limits := &WIPLimits{Limits: map[string]int64{"doing": 2}}
+
+	testCases := []struct {
+		value  string
+		count  int64
+		limit  int64
+		status string
+	}{
+		{"doing", 1, 2, WIPLimitStatusUnder},
+		{"doing", 2, 2, WIPLimitStatusAt},
+		{"doing", 3, 2, WIPLimitStatusOver},
+		{"done", 10, 0, ""},
+	}
+	for _, tc := range testCases {
+		limit, status := limits.Status(tc.value, tc.count)
+		require.Equal(t, tc.limit, limit, tc)
+		require.Equal(t, tc.status, status, tc)
+	}
+
+	limit, status := (*WIPLimits)(nil).Status("doing", 3)
+	require.Zero(t, limit)
+	require.Empty(t, status)
+}
+
+func TestNewBlockPatchResult(t *testing.T) {
+	soft := &WIPLimitExceeded{ViewID: "view-1", Value: "doing", Mode: WIPLimitModeSoft}
+	hard := &WIPLimitExceeded{ViewID: "view-2", Value: "doing", Mode: WIPLimitModeHard}
+
+	require.Equal(t, []*WIPLimitExceeded{soft}, NewBlockPatchResult([]*WIPLimitExceeded{soft, hard}).WIPLimitsExceeded)
+	require.Empty(t, NewBlockPatchResult(nil).WIPLimitsExceeded)
+}
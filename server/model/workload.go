@@ -0,0 +1,169 @@
+package model
+
+import (
+	"sort"
+	"strconv"
+)
+
+// defaultEstimatePropertyName is the name of the number property the
+// estimates of the cards are taken from when none is given.
+const defaultEstimatePropertyName = "estimate"
+
+// WorkloadQuery selects the cards counted in a workload
+type WorkloadQuery struct {
+	// The number property, by ID or name, holding the estimates of the
+	// cards; the one named "Estimate" if empty
+	EstimateProperty string
+
+	// Only count the cards due from this time, in miliseconds
+	From int64
+
+	// Only count the cards due until this time, in miliseconds
+	To int64
+
+	// The cards left out, as property:value, because they are done; the
+	// stale card exclusions of the boards apply if empty
+	Exclude []string
+}
+
+func (q WorkloadQuery) IsValid() error {
+	if q.From < 0 || q.To < 0 {
+		return NewCodedError(ErrCodeBadRequest, "the workload range cannot be negative", map[string]interface{}{"from": q.From, "to": q.To})
+	}
+	if q.From != 0 && q.To != 0 && q.From > q.To {
+		return NewCodedError(ErrCodeBadRequest, "the workload range must end after it starts", map[string]interface{}{"from": q.From, "to": q.To})
+	}
+	return nil
+}
+
+// HasRange returns true if the query only counts the cards due in a range.
+func (q WorkloadQuery) HasRange() bool {
+	return q.From != 0 || q.To != 0
+}
+
+// InRange returns true if the card is due in the range of the query,
+// taking its due date from its first date property. Without range, all
+// the cards are in it; with one, the cards without due date aren't.
+func (q WorkloadQuery) InRange(card *Block, schema PropSchema) bool {
+	if !q.HasRange() {
+		return true
+	}
+	def, ok := schema.firstOfType(propTypeDate, "")
+	if !ok {
+		return false
+	}
+	value, _ := cardPropertyValue(card, def.ID).(string)
+	due := parseDateFrom(value)
+	if due == 0 {
+		return false
+	}
+	return (q.From == 0 || due >= q.From) && (q.To == 0 || due <= q.To)
+}
+
+// EstimateProperty returns the number property holding the estimates of
+// the cards, by ID or name, or the one named "Estimate" if name is empty.
+func (s PropSchema) EstimateProperty(name string) (PropDef, bool) {
+	if name == "" {
+		name = defaultEstimatePropertyName
+	}
+	def, ok := s.findProperty(name)
+	if !ok || def.Type != "number" {
+		return PropDef{}, false
+	}
+	return def, true
+}
+
+// WorkloadEntry is the workload of an assignee
+// swagger:model
+type WorkloadEntry struct {
+	// The id of the assignee, empty for the unassigned cards
+	// required: true
+	UserID string `json:"userId"`
+
+	// The number of open cards assigned
+	// required: true
+	OpenCards int `json:"openCards"`
+
+	// The sum of the estimates of the open cards
+	// required: true
+	Estimate float64 `json:"estimate"`
+
+	// The number of open cards without estimate
+	// required: true
+	UnestimatedCards int `json:"unestimatedCards"`
+}
+
+// Workload is the open cards and their estimates by assignee
+// swagger:model
+type Workload struct {
+	// The workload of the assignees, the most loaded first
+	// required: true
+	Assignees []*WorkloadEntry `json:"assignees"`
+
+	// The workload of the cards without assignee
+	// required: true
+	Unassigned *WorkloadEntry `json:"unassigned"`
+
+	byUser map[string]*WorkloadEntry
+}
+
+// NewWorkload returns an empty workload.
+func NewWorkload() *Workload {
+	return &Workload{
+		Assignees:  []*WorkloadEntry{},
+		Unassigned: &WorkloadEntry{},
+		byUser:     map[string]*WorkloadEntry{},
+	}
+}
+
+// AddCard counts the card in the workload of each of its assignees, or in
+// the unassigned one, with its estimate if estimate is not nil.
+func (w *Workload) AddCard(card *Block, schema PropSchema, estimate *PropDef) {
+	value, estimated := 0.0, false
+	if estimate != nil {
+		if s, ok := cardPropertyValue(card, estimate.ID).(string); ok && s != "" {
+			if number, err := strconv.ParseFloat(s, 64); err == nil {
+				value, estimated = number, true
+			}
+		}
+	}
+
+	assignees := CardAssignees(card, schema)
+	if len(assignees) == 0 {
+		w.Unassigned.add(value, estimated)
+		return
+	}
+	for userID := range assignees {
+		entry, ok := w.byUser[userID]
+		if !ok {
+			entry = &WorkloadEntry{UserID: userID}
+			w.byUser[userID] = entry
+			w.Assignees = append(w.Assignees, entry)
+		}
+		entry.add(value, estimated)
+	}
+}
+
+// Sort orders the assignees by estimate, then by number of cards, the
+// most loaded first.
+func (w *Workload) Sort() {
+	sort.SliceStable(w.Assignees, func(i, j int) bool {
+		a, b := w.Assignees[i], w.Assignees[j]
+		if a.Estimate != b.Estimate {
+			return a.Estimate > b.Estimate
+		}
+		if a.OpenCards != b.OpenCards {
+			return a.OpenCards > b.OpenCards
+		}
+		return a.UserID < b.UserID
+	})
+}
+
+func (e *WorkloadEntry) add(estimate float64, estimated bool) {
+	e.OpenCards++
+	if estimated {
+		e.Estimate += estimate
+	} else {
+		e.UnestimatedCards++
+	}
+}
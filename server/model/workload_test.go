@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkload(t *testing.T) {
+	board := &Board{
+		CardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": "person"},
+			{"id": "points", "name": "Estimate", "type": "number"},
+			{"id": "due", "name": "Due", "type": "date"},
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	}
+	schema, err := ParsePropertySchema(board)
+	require.NoError(t, err)
+
+	card := func(props map[string]interface{}) *Block {
+		return &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": props}}
+	}
+
+	t.Run("estimate property", func(t *testing.T) {
+		def, ok := schema.EstimateProperty("")
+		require.True(t, ok)
+		require.Equal(t, "points", def.ID)
+
+		def, ok = schema.EstimateProperty("points")
+		require.True(t, ok)
+		require.Equal(t, "points", def.ID)
+
+		_, ok = schema.EstimateProperty("Notes")
+		require.False(t, ok)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		require.NoError(t, WorkloadQuery{}.IsValid())
+		require.NoError(t, WorkloadQuery{From: 100, To: 200}.IsValid())
+		require.Error(t, WorkloadQuery{From: 200, To: 100}.IsValid())
+		require.Error(t, WorkloadQuery{From: -1}.IsValid())
+	})
+
+	t.Run("cards in range", func(t *testing.T) {
+		due := card(map[string]interface{}{"due": `{"from":150}`})
+		noDue := card(map[string]interface{}{})
+
+		require.True(t, WorkloadQuery{}.InRange(noDue, schema))
+		require.True(t, WorkloadQuery{From: 100, To: 200}.InRange(due, schema))
+		require.True(t, WorkloadQuery{From: 100}.InRange(due, schema))
+		require.False(t, WorkloadQuery{To: 100}.InRange(due, schema))
+		require.False(t, WorkloadQuery{From: 100, To: 200}.InRange(noDue, schema))
+	})
+
+	t.Run("sums by assignee", func(t *testing.T) {
+		estimate, _ := schema.EstimateProperty("")
+		workload := NewWorkload()
+		workload.AddCard(card(map[string]interface{}{"assignee": "user-1", "points": "3"}), schema, &estimate)
+		workload.AddCard(card(map[string]interface{}{"assignee": "user-2", "points": "5"}), schema, &estimate)
+		workload.AddCard(card(map[string]interface{}{"assignee": "user-1", "points": "2.5"}), schema, &estimate)
+		workload.AddCard(card(map[string]interface{}{"assignee": "user-1"}), schema, &estimate)
+		workload.AddCard(card(map[string]interface{}{"points": "1"}), schema, &estimate)
+		workload.Sort()
+
+		require.Equal(t, []*WorkloadEntry{
+			{UserID: "user-1", OpenCards: 3, Estimate: 5.5, UnestimatedCards: 1},
+			{UserID: "user-2", OpenCards: 1, Estimate: 5},
+		}, workload.Assignees)
+		require.Equal(t, &WorkloadEntry{OpenCards: 1, Estimate: 1}, workload.Unassigned)
+	})
+}
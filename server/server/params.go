@@ -4,8 +4,12 @@ import (
 	"fmt"
 
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/contentfilter"
+	"github.com/mattermost/focalboard/server/services/entitlements"
+	"github.com/mattermost/focalboard/server/services/mail"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/mattermost/focalboard/server/services/secrets"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/ws"
 
@@ -21,6 +25,10 @@ type Params struct {
 	WSAdapter          ws.Adapter
 	NotifyBackends     []notify.Backend
 	PermissionsService permissions.PermissionsService
+	Entitlements       entitlements.Service
+	ContentFilters     []contentfilter.Filter
+	MailSender         mail.Sender
+	Secrets            *secrets.Service
 	SkipTemplateInit   bool
 }
 
@@ -20,10 +20,16 @@ import (
 	appModel "github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/contentfilter"
+	"github.com/mattermost/focalboard/server/services/eventbus"
+	"github.com/mattermost/focalboard/server/services/github"
+	"github.com/mattermost/focalboard/server/services/gitlab"
+	"github.com/mattermost/focalboard/server/services/mail"
 	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/notify/notifylogger"
 	"github.com/mattermost/focalboard/server/services/scheduler"
+	"github.com/mattermost/focalboard/server/services/secrets"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/services/store/sqlstore"
 	"github.com/mattermost/focalboard/server/services/telemetry"
@@ -39,8 +45,15 @@ import (
 )
 
 const (
-	cleanupSessionTaskFrequency = 10 * time.Minute
-	updateMetricsTaskFrequency  = 15 * time.Minute
+	cleanupSessionTaskFrequency       = 10 * time.Minute
+	cleanupSubscriptionsTaskFrequency = 24 * time.Hour
+	updateMetricsTaskFrequency        = 15 * time.Minute
+	retryWebhooksTaskFrequency        = 30 * time.Second
+	sendDueDigestsTaskFrequency       = 5 * time.Minute
+	sendStaleDigestsTaskFrequency     = 15 * time.Minute
+	snapshotBoardsTaskFrequency       = 1 * time.Hour
+	applyBoardFreezeTaskFrequency     = 1 * time.Minute
+	refreshCalendarsTaskFrequency     = 5 * time.Minute
 
 	minSessionExpiryTime = int64(60 * 60 * 24 * 31) // 31 days
 
@@ -56,11 +69,19 @@ type Server struct {
 	telemetry              *telemetry.Service
 	logger                 *mlog.Logger
 	cleanUpSessionsTask    *scheduler.ScheduledTask
+	cleanUpSubsTask        *scheduler.ScheduledTask
 	metricsServer          *metrics.Service
 	metricsService         *metrics.Metrics
 	metricsUpdaterTask     *scheduler.ScheduledTask
+	retryWebhooksTask      *scheduler.ScheduledTask
+	sendDueDigestsTask     *scheduler.ScheduledTask
+	sendStaleDigestsTask   *scheduler.ScheduledTask
+	snapshotBoardsTask     *scheduler.ScheduledTask
+	applyBoardFreezeTask   *scheduler.ScheduledTask
+	refreshCalendarsTask   *scheduler.ScheduledTask
 	auditService           *audit.Audit
 	notificationService    *notify.Service
+	eventBus               *eventbus.Service
 	servicesStartStopMutex sync.Mutex
 
 	localRouter     *mux.Router
@@ -103,7 +124,13 @@ func New(params Params) (*Server, error) {
 		return nil, errors.New("unable to initialize the files storage")
 	}
 
-	webhookClient := webhook.NewClient(params.Cfg, params.Logger)
+	webhookClient := webhook.NewClient(params.Cfg, params.DBStore, params.Logger)
+	// the emails are sent through the SMTP server of the configuration,
+	// unless the server is given a sender of its own
+	mailSender := params.MailSender
+	if mailSender == nil {
+		mailSender = mail.New(params.Cfg, params.Secrets, params.Logger)
+	}
 
 	// Init metrics
 	instanceInfo := metrics.InstanceInfo{
@@ -119,7 +146,19 @@ func New(params Params) (*Server, error) {
 	if errAudit != nil {
 		return nil, fmt.Errorf("unable to create the audit service: %w", errAudit)
 	}
-	if err := auditService.Configure(params.Cfg.AuditCfgFile, params.Cfg.AuditCfgJSON); err != nil {
+	auditCfgJSON, errAudit := audit.AppendSIEMTarget(params.Cfg.AuditCfgJSON, audit.SIEMTarget{
+		Type:     params.Cfg.AuditSIEM.Type,
+		Filename: params.Cfg.AuditSIEM.Filename,
+		IP:       params.Cfg.AuditSIEM.IP,
+		Port:     params.Cfg.AuditSIEM.Port,
+		TLS:      params.Cfg.AuditSIEM.TLS,
+		Insecure: params.Cfg.AuditSIEM.Insecure,
+		Tag:      params.Cfg.AuditSIEM.Tag,
+	})
+	if errAudit != nil {
+		return nil, fmt.Errorf("invalid audit SIEM configuration: %w", errAudit)
+	}
+	if err := auditService.Configure(params.Cfg.AuditCfgFile, auditCfgJSON); err != nil {
 		return nil, fmt.Errorf("unable to initialize the audit service: %w", err)
 	}
 
@@ -129,18 +168,46 @@ func New(params Params) (*Server, error) {
 		return nil, fmt.Errorf("cannot initialize notification service(s): %w", errNotify)
 	}
 
+	// Init content filters, the built-in ones first
+	contentFilterService, errFilter := contentfilter.New(params.Cfg.ContentFilter, params.Logger)
+	if errFilter != nil {
+		return nil, fmt.Errorf("invalid content filter configuration: %w", errFilter)
+	}
+	for _, filter := range params.ContentFilters {
+		contentFilterService.AddFilter(filter)
+	}
+
 	appServices := app.Services{
 		Auth:             authenticator,
 		Store:            params.DBStore,
 		FilesBackend:     filesBackend,
 		Webhook:          webhookClient,
+		Mail:             mailSender,
 		Metrics:          metricsService,
 		Notifications:    notificationService,
+		ContentFilter:    contentFilterService,
+		GitHub:           github.New(params.Cfg.GitHub),
+		GitLab:           gitlab.New(params.Cfg.GitLab),
+		Secrets:          params.Secrets,
 		Logger:           params.Logger,
 		Permissions:      params.PermissionsService,
+		Entitlements:     params.Entitlements,
 		SkipTemplateInit: utils.IsRunningUnitTests(),
 	}
-	app := app.New(params.Cfg, wsAdapter, appServices)
+	// the app broadcasts through the event bus publisher when one is
+	// configured; the web server still needs the original adapter
+	appWSAdapter := wsAdapter
+	var eventBus *eventbus.Service
+	if params.Cfg.EventBus.Type != "" {
+		var errBus error
+		eventBus, errBus = eventbus.New(params.Cfg.EventBus, params.Logger)
+		if errBus != nil {
+			return nil, fmt.Errorf("unable to initialize the event bus: %w", errBus)
+		}
+		appWSAdapter = ws.WithPublisher(wsAdapter, eventBus)
+	}
+
+	app := app.New(params.Cfg, appWSAdapter, appServices)
 
 	focalboardAPI := api.NewAPI(app, params.SingleUserToken, params.Cfg.AuthMode, params.PermissionsService, params.Logger, auditService)
 
@@ -196,6 +263,7 @@ func New(params Params) (*Server, error) {
 		metricsService:      metricsService,
 		auditService:        auditService,
 		notificationService: notificationService,
+		eventBus:            eventBus,
 		logger:              params.Logger,
 		localRouter:         localRouter,
 		api:                 focalboardAPI,
@@ -207,8 +275,28 @@ func New(params Params) (*Server, error) {
 	return &server, nil
 }
 
-func NewStore(config *config.Configuration, logger *mlog.Logger) (store.Store, error) {
-	sqlDB, err := sql.Open(config.DBType, config.DBConfigString)
+// NewStore opens the store, encrypting the stored secrets with the
+// secrets service the server is given too.
+func NewStore(config *config.Configuration, secretsService *secrets.Service, logger *mlog.Logger) (store.Store, error) {
+	return newStore(config, secretsService, logger, false)
+}
+
+// NewStoreWithoutMigrations opens the store without migrating the
+// database, so the pending migrations can be inspected. The stored
+// secrets aren't encrypted either.
+func NewStoreWithoutMigrations(config *config.Configuration, logger *mlog.Logger) (store.Store, error) {
+	return newStore(config, nil, logger, true)
+}
+
+func newStore(config *config.Configuration, secretsService *secrets.Service, logger *mlog.Logger, skipMigrations bool) (store.Store, error) {
+	connectionString := config.DBConfigString
+	if config.DBType == appModel.SqliteDBType {
+		// WAL mode lets readers run alongside the writer, and the busy
+		// timeout makes writers wait for each other instead of failing.
+		connectionString = sqlstore.SQLiteConnectionString(connectionString, config.SQLiteWALMode, config.SQLiteBusyTimeout)
+	}
+
+	sqlDB, err := sql.Open(sqlstore.DriverName(config.DBType), connectionString)
 	if err != nil {
 		logger.Error("connectDatabase failed", mlog.Err(err))
 		return nil, err
@@ -222,11 +310,13 @@ func NewStore(config *config.Configuration, logger *mlog.Logger) (store.Store, e
 
 	storeParams := sqlstore.Params{
 		DBType:           config.DBType,
-		ConnectionString: config.DBConfigString,
+		ConnectionString: connectionString,
 		TablePrefix:      config.DBTablePrefix,
 		Logger:           logger,
 		DB:               sqlDB,
 		IsPlugin:         false,
+		SkipMigrations:   skipMigrations,
+		Secrets:          secretsService,
 	}
 
 	var db store.Store
@@ -264,6 +354,15 @@ func (s *Server) Start() error {
 		}, cleanupSessionTaskFrequency)
 	}
 
+	s.cleanUpSubsTask = scheduler.CreateRecurringTask("cleanUpSubscriptions", func() {
+		count, err := s.store.CleanUpStaleSubscriptions()
+		if err != nil {
+			s.logger.Error("Unable to clean up the subscriptions", mlog.Err(err))
+			return
+		}
+		s.logger.Debug("Stale subscriptions cleaned up", mlog.Int64("count", count))
+	}, cleanupSubscriptionsTaskFrequency)
+
 	metricsUpdater := func() {
 		blockCounts, err := s.store.GetBlockCountsByType()
 		if err != nil {
@@ -285,6 +384,18 @@ func (s *Server) Start() error {
 	// metricsUpdater()   Calling this immediately causes integration unit tests to fail.
 	s.metricsUpdaterTask = scheduler.CreateRecurringTask("updateMetrics", metricsUpdater, updateMetricsTaskFrequency)
 
+	s.retryWebhooksTask = scheduler.CreateRecurringTask("retryWebhooks", s.app.RetryWebhookDeliveries, retryWebhooksTaskFrequency)
+
+	s.sendDueDigestsTask = scheduler.CreateRecurringTask("sendDueDigests", s.app.SendDueDigests, sendDueDigestsTaskFrequency)
+
+	s.sendStaleDigestsTask = scheduler.CreateRecurringTask("sendStaleDigests", s.app.SendStaleDigests, sendStaleDigestsTaskFrequency)
+
+	s.snapshotBoardsTask = scheduler.CreateRecurringTask("snapshotBoards", s.app.SnapshotBoards, snapshotBoardsTaskFrequency)
+
+	s.applyBoardFreezeTask = scheduler.CreateRecurringTask("applyBoardFreezeSchedules", s.app.ApplyBoardFreezeSchedules, applyBoardFreezeTaskFrequency)
+
+	s.refreshCalendarsTask = scheduler.CreateRecurringTask("refreshCalendarSubscriptions", s.app.RefreshCalendarSubscriptions, refreshCalendarsTaskFrequency)
+
 	if s.config.Telemetry {
 		firstRun := utils.GetMillis()
 		s.telemetry.RunTelemetryJob(firstRun)
@@ -322,10 +433,38 @@ func (s *Server) Shutdown() error {
 		s.cleanUpSessionsTask.Cancel()
 	}
 
+	if s.cleanUpSubsTask != nil {
+		s.cleanUpSubsTask.Cancel()
+	}
+
 	if s.metricsUpdaterTask != nil {
 		s.metricsUpdaterTask.Cancel()
 	}
 
+	if s.retryWebhooksTask != nil {
+		s.retryWebhooksTask.Cancel()
+	}
+
+	if s.sendDueDigestsTask != nil {
+		s.sendDueDigestsTask.Cancel()
+	}
+
+	if s.sendStaleDigestsTask != nil {
+		s.sendStaleDigestsTask.Cancel()
+	}
+
+	if s.snapshotBoardsTask != nil {
+		s.snapshotBoardsTask.Cancel()
+	}
+
+	if s.applyBoardFreezeTask != nil {
+		s.applyBoardFreezeTask.Cancel()
+	}
+
+	if s.refreshCalendarsTask != nil {
+		s.refreshCalendarsTask.Cancel()
+	}
+
 	if err := s.telemetry.Shutdown(); err != nil {
 		s.logger.Warn("Error occurred when shutting down telemetry", mlog.Err(err))
 	}
@@ -340,6 +479,12 @@ func (s *Server) Shutdown() error {
 
 	s.app.Shutdown()
 
+	if s.eventBus != nil {
+		if err := s.eventBus.Shutdown(); err != nil {
+			s.logger.Warn("Error occurred when shutting down the event bus", mlog.Err(err))
+		}
+	}
+
 	defer s.logger.Info("Server.Shutdown")
 
 	return s.store.Shutdown()
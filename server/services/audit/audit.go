@@ -16,6 +16,14 @@ const (
 	KeyIPAddress = "ip_address"
 	KeyClusterID = "cluster_id"
 	KeyTeamID    = "team_id"
+	KeyRequestID = "request_id"
+
+	// guaranteed fields, present in every record regardless of the
+	// event, so SIEM pipelines can index them without per-event parsing.
+	KeyActor  = "actor"
+	KeyAction = "action"
+	KeyObject = "object"
+	KeyResult = "result"
 
 	Success = "success"
 	Attempt = "attempt"
@@ -64,7 +72,12 @@ func (a *Audit) Shutdown() error {
 
 // LogRecord emits an audit record with complete info.
 func (a *Audit) LogRecord(level mlog.Level, rec *Record) {
-	fields := make([]mlog.Field, 0, 7+len(rec.Meta))
+	fields := make([]mlog.Field, 0, 11+len(rec.Meta))
+
+	fields = append(fields, mlog.String(KeyActor, rec.UserID))
+	fields = append(fields, mlog.String(KeyAction, rec.Event))
+	fields = append(fields, mlog.String(KeyObject, rec.Object))
+	fields = append(fields, mlog.String(KeyResult, rec.Status))
 
 	fields = append(fields, mlog.String(KeyAPIPath, rec.APIPath))
 	fields = append(fields, mlog.String(KeyEvent, rec.Event))
@@ -24,6 +24,7 @@ type Record struct {
 	SessionID string
 	Client    string
 	IPAddress string
+	Object    string
 	Meta      []Meta
 	metaConv  []FuncMetaTypeConv
 }
@@ -38,6 +39,12 @@ func (rec *Record) Fail() {
 	rec.Status = Fail
 }
 
+// SetObject sets the object the audited action was performed on, in
+// the form `type:id`.
+func (rec *Record) SetObject(objectType string, id string) {
+	rec.Object = objectType + ":" + id
+}
+
 // AddMeta adds a single name/value pair to this audit record's metadata.
 func (rec *Record) AddMeta(name string, val interface{}) {
 	if rec.Meta == nil {
@@ -0,0 +1,135 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	SIEMTargetName = "siem"
+
+	SIEMTypeFile   = "file"
+	SIEMTypeSyslog = "syslog"
+	SIEMTypeTCP    = "tcp"
+
+	defaultSIEMFilename = "focalboard_audit.log"
+)
+
+var ErrInvalidSIEMType = errors.New("invalid SIEM target type")
+
+// SIEMTarget describes a log target that receives every audit record
+// as a JSON document, independently of the debug log configuration.
+type SIEMTarget struct {
+	Type     string
+	Filename string
+	IP       string
+	Port     int
+	TLS      bool
+	Insecure bool
+	Tag      string
+}
+
+type siemTargetCfg struct {
+	Type          string          `json:"type"`
+	Format        string          `json:"format"`
+	FormatOptions json.RawMessage `json:"format_options,omitempty"`
+	Options       interface{}     `json:"options"`
+	Levels        []siemLevel     `json:"levels"`
+	MaxQueueSize  int             `json:"maxqueuesize"`
+}
+
+type siemLevel struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type siemFileOptions struct {
+	Filename   string `json:"filename"`
+	MaxSizeMB  int    `json:"max_size"`
+	MaxAgeDays int    `json:"max_age"`
+	MaxBackups int    `json:"max_backups"`
+	Compress   bool   `json:"compress"`
+}
+
+type siemNetOptions struct {
+	IP       string `json:"ip"`
+	Port     int    `json:"port"`
+	TLS      bool   `json:"tls"`
+	Insecure bool   `json:"insecure"`
+	Tag      string `json:"tag,omitempty"`
+}
+
+func (t SIEMTarget) targetCfg() (*siemTargetCfg, error) {
+	cfg := &siemTargetCfg{
+		Type:          t.Type,
+		Format:        "json",
+		FormatOptions: json.RawMessage(`{"enable_caller": false}`),
+		Levels: []siemLevel{
+			{ID: int(LevelAuth.ID), Name: LevelAuth.Name},
+			{ID: int(LevelModify.ID), Name: LevelModify.Name},
+			{ID: int(LevelRead.ID), Name: LevelRead.Name},
+		},
+		MaxQueueSize: DefMaxQueueSize,
+	}
+
+	switch t.Type {
+	case SIEMTypeFile:
+		filename := t.Filename
+		if filename == "" {
+			filename = defaultSIEMFilename
+		}
+		cfg.Options = siemFileOptions{
+			Filename:   filename,
+			MaxSizeMB:  100,
+			MaxBackups: 10,
+			Compress:   true,
+		}
+	case SIEMTypeSyslog, SIEMTypeTCP:
+		if t.IP == "" || t.Port == 0 {
+			return nil, fmt.Errorf("%s SIEM target requires an IP and a port: %w", t.Type, ErrInvalidSIEMType)
+		}
+		cfg.Options = siemNetOptions{
+			IP:       t.IP,
+			Port:     t.Port,
+			TLS:      t.TLS,
+			Insecure: t.Insecure,
+			Tag:      t.Tag,
+		}
+	default:
+		return nil, fmt.Errorf("%s: %w", t.Type, ErrInvalidSIEMType)
+	}
+
+	return cfg, nil
+}
+
+// AppendSIEMTarget adds the SIEM target to an escaped JSON logging
+// configuration, returning the resulting configuration. If the target
+// has no type, the configuration is returned unchanged.
+func AppendSIEMTarget(cfgEscaped string, target SIEMTarget) (string, error) {
+	if target.Type == "" {
+		return cfgEscaped, nil
+	}
+
+	targetCfg, err := target.targetCfg()
+	if err != nil {
+		return "", err
+	}
+
+	targets := map[string]interface{}{}
+	if cfgEscaped != "" {
+		if err := json.Unmarshal([]byte(cfgEscaped), &targets); err != nil {
+			return "", fmt.Errorf("cannot parse audit logging configuration: %w", err)
+		}
+	}
+	targets[SIEMTargetName] = targetCfg
+
+	data, err := json.Marshal(targets)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
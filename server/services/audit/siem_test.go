@@ -0,0 +1,58 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendSIEMTarget(t *testing.T) {
+	t.Run("no target type leaves config unchanged", func(t *testing.T) {
+		cfg, err := AppendSIEMTarget(`{"other": {}}`, SIEMTarget{})
+		require.NoError(t, err)
+		require.Equal(t, `{"other": {}}`, cfg)
+	})
+
+	t.Run("invalid target type", func(t *testing.T) {
+		_, err := AppendSIEMTarget("", SIEMTarget{Type: "carrier-pigeon"})
+		require.ErrorIs(t, err, ErrInvalidSIEMType)
+	})
+
+	t.Run("network target requires an address", func(t *testing.T) {
+		_, err := AppendSIEMTarget("", SIEMTarget{Type: SIEMTypeSyslog})
+		require.ErrorIs(t, err, ErrInvalidSIEMType)
+	})
+
+	t.Run("target is merged with existing config", func(t *testing.T) {
+		cfg, err := AppendSIEMTarget(`{"other": {"type": "console"}}`, SIEMTarget{
+			Type: SIEMTypeTCP,
+			IP:   "localhost",
+			Port: 5514,
+		})
+		require.NoError(t, err)
+
+		var targets map[string]map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(cfg), &targets))
+		require.Contains(t, targets, "other")
+		require.Contains(t, targets, SIEMTargetName)
+		require.Equal(t, "tcp", targets[SIEMTargetName]["type"])
+		require.Equal(t, "json", targets[SIEMTargetName]["format"])
+		require.Len(t, targets[SIEMTargetName]["levels"], 3)
+	})
+
+	t.Run("file target gets a default filename", func(t *testing.T) {
+		cfg, err := AppendSIEMTarget("", SIEMTarget{Type: SIEMTypeFile})
+		require.NoError(t, err)
+		require.Contains(t, cfg, defaultSIEMFilename)
+	})
+}
+
+func TestRecord_SetObject(t *testing.T) {
+	rec := &Record{}
+	rec.SetObject("board", "board-id")
+	require.Equal(t, "board:board-id", rec.Object)
+}
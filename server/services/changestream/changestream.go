@@ -0,0 +1,172 @@
+// Package changestream keeps the recent board, block and member changes
+// in memory and fans them out to the subscribers of the change stream
+// API, so they can resume after a disconnection.
+package changestream
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+const (
+	// DefaultBufferSize is the number of recent changes kept to resume
+	// the streams.
+	DefaultBufferSize = 1000
+
+	// subscriberQueueSize is the number of changes waiting to be sent to
+	// a subscriber after which it is disconnected, so a slow client
+	// doesn't hold up the server; the client can then resume from its
+	// last event.
+	subscriberQueueSize = 256
+)
+
+var ErrInvalidEventID = errors.New("invalid event ID")
+
+// Event is a change with its ID in the stream.
+type Event struct {
+	ID     string
+	Change *model.ChangeEvent
+}
+
+// Subscription receives the changes published after it was created. C
+// is closed when the subscription is cancelled or falls behind.
+type Subscription struct {
+	C <-chan *Event
+
+	// StartID is the ID of the last change published before the
+	// subscription was created. It can be used to resume after it,
+	// even if no change was published yet.
+	StartID string
+
+	c      chan *Event
+	stream *Stream
+}
+
+// Cancel stops the subscription.
+func (sub *Subscription) Cancel() {
+	sub.stream.unsubscribe(sub)
+}
+
+// Stream numbers the changes and sends them to the subscribers. The
+// event IDs start with the time the stream was created, so the IDs
+// handed out before a server restart are recognized as unknown.
+//
+// The stream only sees the changes made on this server.
+type Stream struct {
+	epoch      int64
+	bufferSize int
+
+	mux         sync.Mutex
+	seq         uint64
+	buffer      []*Event
+	subscribers map[*Subscription]struct{}
+}
+
+// New creates a stream that keeps the last bufferSize changes.
+func New(bufferSize int) *Stream {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Stream{
+		epoch:       model.GetMillis(),
+		bufferSize:  bufferSize,
+		buffer:      make([]*Event, 0, bufferSize),
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Publish adds a change to the stream.
+func (s *Stream) Publish(change *model.ChangeEvent) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.seq++
+	event := &Event{ID: s.eventID(s.seq), Change: change}
+
+	if len(s.buffer) == s.bufferSize {
+		copy(s.buffer, s.buffer[1:])
+		s.buffer = s.buffer[:len(s.buffer)-1]
+	}
+	s.buffer = append(s.buffer, event)
+
+	for sub := range s.subscribers {
+		select {
+		case sub.c <- event:
+		default:
+			s.removeLocked(sub)
+		}
+	}
+}
+
+// Subscribe creates a subscription to the new changes. If lastEventID is
+// set, the changes that followed it are returned too; resumed is false
+// when they are no longer available, and the subscriber must reload its
+// state instead.
+func (s *Stream) Subscribe(lastEventID string) (sub *Subscription, missed []*Event, resumed bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	c := make(chan *Event, subscriberQueueSize)
+	sub = &Subscription{C: c, c: c, stream: s, StartID: s.eventID(s.seq)}
+	s.subscribers[sub] = struct{}{}
+
+	if lastEventID == "" {
+		return sub, nil, true
+	}
+
+	seq, err := s.parseEventID(lastEventID)
+	if err != nil || seq > s.seq {
+		return sub, nil, false
+	}
+	if seq == s.seq {
+		return sub, nil, true
+	}
+	// the event following the last one seen must still be in the buffer
+	if len(s.buffer) == 0 || s.bufferSeq(0) > seq+1 {
+		return sub, nil, false
+	}
+
+	start := int(seq + 1 - s.bufferSeq(0))
+	missed = make([]*Event, len(s.buffer)-start)
+	copy(missed, s.buffer[start:])
+	return sub, missed, true
+}
+
+func (s *Stream) unsubscribe(sub *Subscription) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.removeLocked(sub)
+}
+
+func (s *Stream) removeLocked(sub *Subscription) {
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.c)
+	}
+}
+
+// bufferSeq returns the sequence number of the i-th buffered event.
+func (s *Stream) bufferSeq(i int) uint64 {
+	return s.seq - uint64(len(s.buffer)-1-i)
+}
+
+func (s *Stream) eventID(seq uint64) string {
+	return fmt.Sprintf("%d-%d", s.epoch, seq)
+}
+
+func (s *Stream) parseEventID(id string) (uint64, error) {
+	parts := strings.Split(id, "-")
+	if len(parts) != 2 || parts[0] != strconv.FormatInt(s.epoch, 10) {
+		return 0, ErrInvalidEventID
+	}
+	seq, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidEventID
+	}
+	return seq, nil
+}
@@ -0,0 +1,110 @@
+package changestream
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func publish(s *Stream, count int) {
+	for i := 0; i < count; i++ {
+		s.Publish(&model.ChangeEvent{Type: model.ChangeEventBlockChanged, TeamID: "team-id"})
+	}
+}
+
+func TestStream(t *testing.T) {
+	t.Run("subscribers receive the new changes", func(t *testing.T) {
+		s := New(10)
+		sub, missed, resumed := s.Subscribe("")
+		defer sub.Cancel()
+		require.True(t, resumed)
+		require.Empty(t, missed)
+
+		publish(s, 2)
+		first := <-sub.C
+		second := <-sub.C
+		require.NotEqual(t, first.ID, second.ID)
+		require.Equal(t, model.ChangeEventBlockChanged, first.Change.Type)
+	})
+
+	t.Run("resume from the last event", func(t *testing.T) {
+		s := New(10)
+		sub, _, _ := s.Subscribe("")
+		publish(s, 3)
+		first := <-sub.C
+		sub.Cancel()
+
+		// the changes buffered before the cancellation are still delivered
+		for range sub.C {
+		}
+
+		resumedSub, missed, resumed := s.Subscribe(first.ID)
+		defer resumedSub.Cancel()
+		require.True(t, resumed)
+		require.Len(t, missed, 2)
+
+		latest, missed, resumed := s.Subscribe(missed[1].ID)
+		defer latest.Cancel()
+		require.True(t, resumed)
+		require.Empty(t, missed)
+	})
+
+	t.Run("changes no longer buffered can't be resumed", func(t *testing.T) {
+		s := New(2)
+		sub, _, _ := s.Subscribe("")
+		publish(s, 1)
+		first := <-sub.C
+		sub.Cancel()
+
+		publish(s, 2)
+		resumedSub, _, resumed := s.Subscribe(first.ID)
+		defer resumedSub.Cancel()
+		require.True(t, resumed, "the change following the first one is still buffered")
+
+		publish(s, 1)
+		resumedSub2, _, resumed := s.Subscribe(first.ID)
+		defer resumedSub2.Cancel()
+		require.False(t, resumed)
+	})
+
+	t.Run("subscriptions start after the last change", func(t *testing.T) {
+		s := New(10)
+		empty, _, _ := s.Subscribe("")
+		defer empty.Cancel()
+
+		resumedEmpty, _, resumed := s.Subscribe(empty.StartID)
+		defer resumedEmpty.Cancel()
+		require.True(t, resumed, "streams without changes can be resumed")
+
+		publish(s, 2)
+		<-empty.C
+		last := <-empty.C
+
+		sub, _, _ := s.Subscribe("")
+		defer sub.Cancel()
+		require.Equal(t, last.ID, sub.StartID)
+	})
+
+	t.Run("unknown event IDs", func(t *testing.T) {
+		s := New(2)
+		for _, id := range []string{"garbage", "1-1", s.eventID(5)} {
+			sub, _, resumed := s.Subscribe(id)
+			require.False(t, resumed, id)
+			sub.Cancel()
+		}
+	})
+
+	t.Run("slow subscribers are disconnected", func(t *testing.T) {
+		s := New(10)
+		sub, _, _ := s.Subscribe("")
+		publish(s, subscriberQueueSize+1)
+
+		count := 0
+		for range sub.C {
+			count++
+		}
+		require.Equal(t, subscriberQueueSize, count)
+		sub.Cancel()
+	})
+}
@@ -24,6 +24,116 @@ type AmazonS3Config struct {
 	Trace           bool
 }
 
+// AuditSIEMConfig configures a log target that receives audit records
+// as JSON, for ingestion by a SIEM. Type can be "file", "syslog" or
+// "tcp"; when empty no SIEM target is created.
+type AuditSIEMConfig struct {
+	Type     string `json:"type" mapstructure:"type"`
+	Filename string `json:"filename" mapstructure:"filename"`
+	IP       string `json:"ip" mapstructure:"ip"`
+	Port     int    `json:"port" mapstructure:"port"`
+	TLS      bool   `json:"tls" mapstructure:"tls"`
+	Insecure bool   `json:"insecure" mapstructure:"insecure"`
+	Tag      string `json:"tag" mapstructure:"tag"`
+}
+
+// SMTPConfig configures the mail server used to send emails, such as
+// the email invitations. Emails are disabled unless Server and
+// FromAddress are set. ConnectionSecurity can be empty, "TLS" or
+// "STARTTLS".
+type SMTPConfig struct {
+	Server                            string `json:"server" mapstructure:"server"`
+	Port                              int    `json:"port" mapstructure:"port"`
+	Username                          string `json:"username" mapstructure:"username"`
+	Password                          string `json:"password" mapstructure:"password"`
+	ConnectionSecurity                string `json:"connection_security" mapstructure:"connection_security"`
+	SkipServerCertificateVerification bool   `json:"skip_server_certificate_verification" mapstructure:"skip_server_certificate_verification"`
+	FromAddress                       string `json:"from_address" mapstructure:"from_address"`
+}
+
+// EventBusConfig configures the publishing of the board, block and member
+// changes to a message broker. Type is "nats" or "kafka", and empty turns
+// the publishing off. For NATS, URL is the address of the server
+// (nats://host:4222, or tls://host:4222 to require TLS); for Kafka, it is
+// the URL of a Kafka REST proxy. The changes of each team go to the
+// TopicPrefix.<teamID> topic.
+//
+// The NATS server is authenticated with CredentialsFile, a NATS
+// credentials file, Token, or Username and Password, in that order. The
+// TLS files are the CA certificate to verify the server with and the
+// certificate and key of the client.
+type EventBusConfig struct {
+	Type            string `json:"type" mapstructure:"type"`
+	URL             string `json:"url" mapstructure:"url"`
+	TopicPrefix     string `json:"topic_prefix" mapstructure:"topic_prefix"`
+	Username        string `json:"username" mapstructure:"username"`
+	Password        string `json:"password" mapstructure:"password"`
+	Token           string `json:"token" mapstructure:"token"`
+	CredentialsFile string `json:"credentials_file" mapstructure:"credentials_file"`
+	TLSCAFile       string `json:"tls_ca_file" mapstructure:"tls_ca_file"`
+	TLSCertFile     string `json:"tls_cert_file" mapstructure:"tls_cert_file"`
+	TLSKeyFile      string `json:"tls_key_file" mapstructure:"tls_key_file"`
+}
+
+// ContentFilterConfig configures the filters the text of the blocks goes
+// through when they are inserted or patched. The actions are "redact",
+// to replace the matches, or "reject", to refuse the change; an empty
+// action turns the filter off. SecretPatterns are regular expressions
+// matched besides the built-in ones, that detect the common API keys and
+// the private keys.
+type ContentFilterConfig struct {
+	BlockedWords       []string `json:"blocked_words" mapstructure:"blocked_words"`
+	BlockedWordsAction string   `json:"blocked_words_action" mapstructure:"blocked_words_action"`
+	SecretsAction      string   `json:"secrets_action" mapstructure:"secrets_action"`
+	SecretPatterns     []string `json:"secret_patterns" mapstructure:"secret_patterns"`
+}
+
+// GitHubConfig configures the integration syncing the issues of GitHub
+// repositories to boards. The access tokens of the boards are encrypted
+// in the database with the secrets key; without one the integration is
+// disabled. EncryptionKey is the secrets key when Secrets has none, kept
+// for the servers that configured it before. APIURL can point to a GitHub
+// Enterprise server.
+type GitHubConfig struct {
+	APIURL        string `json:"api_url" mapstructure:"api_url"`
+	EncryptionKey string `json:"encryption_key" mapstructure:"encryption_key"`
+}
+
+// GitLabConfig configures the import of the issues of GitLab projects.
+// APIURL can point to a self-managed GitLab server.
+type GitLabConfig struct {
+	APIURL string `json:"api_url" mapstructure:"api_url"`
+}
+
+// SecretsConfig configures the encryption of the secrets stored in the
+// database, like the secrets of the board webhooks and the tokens of the
+// integrations. The key is Key, or the output of KeyCommand, that can
+// fetch it from a KMS. PreviousKeys still decrypt the secrets encrypted
+// before the key was changed. Without key, the secrets are stored in
+// plaintext. The SMTP password can be given encrypted with the key.
+type SecretsConfig struct {
+	Key          string   `json:"key" mapstructure:"key"`
+	KeyCommand   string   `json:"key_command" mapstructure:"key_command"`
+	PreviousKeys []string `json:"previous_keys" mapstructure:"previous_keys"`
+}
+
+// IncomingWebhookConfig is a webhook that creates cards on a board. The
+// requests must be signed with the secret of the webhook.
+type IncomingWebhookConfig struct {
+	ID      string `json:"id" mapstructure:"id"`
+	Secret  string `json:"secret" mapstructure:"secret"`
+	BoardID string `json:"board_id" mapstructure:"board_id"`
+	UserID  string `json:"user_id" mapstructure:"user_id"`
+}
+
+// APIKeyConfig is a key of the integration API for no-code platforms,
+// sent in the X-API-Key header. The requests are made as the user of the
+// key.
+type APIKeyConfig struct {
+	Key    string `json:"key" mapstructure:"key"`
+	UserID string `json:"user_id" mapstructure:"user_id"`
+}
+
 // Configuration is the app configuration stored in a json file.
 type Configuration struct {
 	ServerRoot               string            `json:"serverRoot" mapstructure:"serverRoot"`
@@ -31,6 +141,8 @@ type Configuration struct {
 	DBType                   string            `json:"dbtype" mapstructure:"dbtype"`
 	DBConfigString           string            `json:"dbconfig" mapstructure:"dbconfig"`
 	DBTablePrefix            string            `json:"dbtableprefix" mapstructure:"dbtableprefix"`
+	SQLiteWALMode            bool              `json:"sqlite_wal_mode" mapstructure:"sqlite_wal_mode"`
+	SQLiteBusyTimeout        int               `json:"sqlite_busy_timeout" mapstructure:"sqlite_busy_timeout"`
 	UseSSL                   bool              `json:"useSSL" mapstructure:"useSSL"`
 	SecureCookie             bool              `json:"secureCookie" mapstructure:"secureCookie"`
 	WebPath                  string            `json:"webpath" mapstructure:"webpath"`
@@ -42,6 +154,7 @@ type Configuration struct {
 	TelemetryID              string            `json:"telemetryid" mapstructure:"telemetryid"`
 	PrometheusAddress        string            `json:"prometheusaddress" mapstructure:"prometheusaddress"`
 	WebhookUpdate            []string          `json:"webhook_update" mapstructure:"webhook_update"`
+	NotifyWebhooks           []string          `json:"notify_webhooks" mapstructure:"notify_webhooks"`
 	Secret                   string            `json:"secret" mapstructure:"secret"`
 	SessionExpireTime        int64             `json:"session_expire_time" mapstructure:"session_expire_time"`
 	SessionRefreshTime       int64             `json:"session_refresh_time" mapstructure:"session_refresh_time"`
@@ -52,17 +165,43 @@ type Configuration struct {
 	FeatureFlags             map[string]string `json:"featureFlags" mapstructure:"featureFlags"`
 	EnableDataRetention      bool              `json:"enable_data_retention" mapstructure:"enable_data_retention"`
 	DataRetentionDays        int               `json:"data_retention_days" mapstructure:"data_retention_days"`
+	DefaultTemplates         []string          `json:"default_templates" mapstructure:"default_templates"`
 
 	AuthMode string `json:"authMode" mapstructure:"authMode"`
 
+	// WebhookSecrets are the signing secrets of the outgoing webhooks, by URL
+	WebhookSecrets            map[string]string       `json:"webhook_secrets" mapstructure:"webhook_secrets"`
+	IncomingWebhooks          []IncomingWebhookConfig `json:"incoming_webhooks" mapstructure:"incoming_webhooks"`
+	WebhookTimestampTolerance int                     `json:"webhook_timestamp_tolerance" mapstructure:"webhook_timestamp_tolerance"`
+
+	// BoardWebhookAllowedNetworks are the loopback, private and link-local
+	// networks, in CIDR notation or as single addresses, the webhooks of
+	// the boards may call anyway
+	BoardWebhookAllowedNetworks []string `json:"board_webhook_allowed_networks" mapstructure:"board_webhook_allowed_networks"`
+
+	APIKeys []APIKeyConfig `json:"api_keys" mapstructure:"api_keys"`
+
+	EventBus EventBusConfig `json:"event_bus" mapstructure:"event_bus"`
+
+	ContentFilter ContentFilterConfig `json:"content_filter" mapstructure:"content_filter"`
+
+	GitHub GitHubConfig `json:"github" mapstructure:"github"`
+	GitLab GitLabConfig `json:"gitlab" mapstructure:"gitlab"`
+
+	Secrets SecretsConfig `json:"secrets" mapstructure:"secrets"`
+
 	LoggingCfgFile string `json:"logging_cfg_file" mapstructure:"logging_cfg_file"`
 	LoggingCfgJSON string `json:"logging_cfg_json" mapstructure:"logging_cfg_json"`
 
-	AuditCfgFile string `json:"audit_cfg_file" mapstructure:"audit_cfg_file"`
-	AuditCfgJSON string `json:"audit_cfg_json" mapstructure:"audit_cfg_json"`
+	AuditCfgFile string          `json:"audit_cfg_file" mapstructure:"audit_cfg_file"`
+	AuditCfgJSON string          `json:"audit_cfg_json" mapstructure:"audit_cfg_json"`
+	AuditSIEM    AuditSIEMConfig `json:"audit_siem" mapstructure:"audit_siem"`
 
 	NotifyFreqCardSeconds  int `json:"notify_freq_card_seconds" mapstructure:"notify_freq_card_seconds"`
 	NotifyFreqBoardSeconds int `json:"notify_freq_board_seconds" mapstructure:"notify_freq_board_seconds"`
+
+	SMTP                      SMTPConfig `json:"smtp" mapstructure:"smtp"`
+	EmailInvitationExpireTime int64      `json:"email_invitation_expire_time" mapstructure:"email_invitation_expire_time"`
 }
 
 // ReadConfigFile read the configuration from the filesystem.
@@ -80,6 +219,8 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("DBType", "sqlite3")
 	viper.SetDefault("DBConfigString", "./focalboard.db")
 	viper.SetDefault("DBTablePrefix", "")
+	viper.SetDefault("SQLiteWALMode", true)
+	viper.SetDefault("SQLiteBusyTimeout", 5000) // milliseconds
 	viper.SetDefault("SecureCookie", false)
 	viper.SetDefault("WebPath", "./pack")
 	viper.SetDefault("FilesPath", "./files")
@@ -87,6 +228,15 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("Telemetry", true)
 	viper.SetDefault("TelemetryID", "")
 	viper.SetDefault("WebhookUpdate", nil)
+	viper.SetDefault("NotifyWebhooks", nil)
+	viper.SetDefault("WebhookSecrets", map[string]string{})
+	viper.SetDefault("IncomingWebhooks", nil)
+	viper.SetDefault("APIKeys", nil)
+	viper.SetDefault("WebhookTimestampTolerance", 300) // 5 minutes
+	viper.SetDefault("BoardWebhookAllowedNetworks", nil)
+	viper.SetDefault("EventBus.TopicPrefix", "focalboard")
+	viper.SetDefault("github.api_url", "https://api.github.com")
+	viper.SetDefault("gitlab.api_url", "https://gitlab.com/api/v4")
 	viper.SetDefault("SessionExpireTime", 60*60*24*30) // 30 days session lifetime
 	viper.SetDefault("SessionRefreshTime", 60*60*5)    // 5 minutes session refresh
 	viper.SetDefault("LocalOnly", false)
@@ -94,12 +244,15 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("LocalModeSocketLocation", "/var/tmp/focalboard_local.socket")
 	viper.SetDefault("EnablePublicSharedBoards", false)
 	viper.SetDefault("FeatureFlags", map[string]string{})
+	viper.SetDefault("DefaultTemplates", []string{})
 	viper.SetDefault("AuthMode", "native")
 	viper.SetDefault("NotifyFreqCardSeconds", 120)    // 2 minutes after last card edit
 	viper.SetDefault("NotifyFreqBoardSeconds", 86400) // 1 day after last card edit
 	viper.SetDefault("EnableDataRetention", false)
 	viper.SetDefault("DataRetentionDays", 365) // 1 year is default
 	viper.SetDefault("PrometheusAddress", "")
+	viper.SetDefault("SMTP.Port", 25)
+	viper.SetDefault("EmailInvitationExpireTime", 60*60*24*7) // 7 days
 
 	err := viper.ReadInConfig() // Find and read the config file
 	if err != nil {             // Handle errors reading the config file
@@ -121,5 +274,14 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 
 func removeSecurityData(config Configuration) Configuration {
 	clean := config
+	clean.SMTP.Password = ""
+	clean.WebhookSecrets = nil
+	clean.IncomingWebhooks = nil
+	clean.APIKeys = nil
+	clean.EventBus.Password = ""
+	clean.EventBus.Token = ""
+	clean.GitHub.EncryptionKey = ""
+	clean.Secrets.Key = ""
+	clean.Secrets.PreviousKeys = nil
 	return clean
 }
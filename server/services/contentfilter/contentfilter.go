@@ -0,0 +1,107 @@
+// Package contentfilter checks the text of the blocks before it is
+// saved, to redact or reject unwanted content such as profanity or
+// leaked secrets.
+package contentfilter
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	// ActionRedact replaces the matches of a filter in the text.
+	ActionRedact = "redact"
+	// ActionReject refuses the text with an error.
+	ActionReject = "reject"
+)
+
+var ErrInvalidAction = errors.New("invalid content filter action")
+
+// Filter checks a text before it is saved. It returns the text to save,
+// which can be redacted, or an error created by Reject to refuse it.
+type Filter interface {
+	Name() string
+	FilterText(text string) (string, error)
+}
+
+// Reject returns the error a filter uses to refuse a text. The reason is
+// returned to the API clients, so it must not quote the content.
+func Reject(filter, reason string) error {
+	return model.NewErrContentRejected(filter, reason)
+}
+
+// Service runs the text through all of its filters, in order.
+type Service struct {
+	mux     sync.RWMutex
+	filters []Filter
+	logger  *mlog.Logger
+}
+
+// New creates a service with the built-in filters enabled by the
+// configuration.
+func New(cfg config.ContentFilterConfig, logger *mlog.Logger) (*Service, error) {
+	s := &Service{logger: logger}
+
+	if cfg.BlockedWordsAction != "" && len(cfg.BlockedWords) > 0 {
+		filter, err := NewWordFilter(cfg.BlockedWords, cfg.BlockedWordsAction)
+		if err != nil {
+			return nil, err
+		}
+		s.AddFilter(filter)
+	}
+
+	if cfg.SecretsAction != "" {
+		filter, err := NewSecretsFilter(cfg.SecretPatterns, cfg.SecretsAction)
+		if err != nil {
+			return nil, err
+		}
+		s.AddFilter(filter)
+	}
+
+	return s, nil
+}
+
+// AddFilter adds a filter, run after the ones already added.
+func (s *Service) AddFilter(filter Filter) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.filters = append(s.filters, filter)
+	s.logger.Info("Added content filter", mlog.String("name", filter.Name()))
+}
+
+// HasFilters returns true if the service has at least one filter.
+func (s *Service) HasFilters() bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return len(s.filters) > 0
+}
+
+// FilterText runs the text through the filters, each one receiving the
+// text returned by the previous one. The first rejection stops it.
+func (s *Service) FilterText(text string) (string, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, filter := range s.filters {
+		filtered, err := filter.FilterText(text)
+		if err != nil {
+			s.logger.Debug("Content rejected", mlog.String("filter", filter.Name()), mlog.Err(err))
+			return "", err
+		}
+		text = filtered
+	}
+	return text, nil
+}
+
+func checkAction(action string) error {
+	if action != ActionRedact && action != ActionReject {
+		return fmt.Errorf("%w: %s", ErrInvalidAction, action)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package contentfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const redactedSecret = "[REDACTED]"
+
+// defaultSecretPatterns detect the most common API keys and tokens, and
+// the private keys in PEM format.
+var defaultSecretPatterns = []string{
+	`\bAKIA[0-9A-Z]{16}\b`,             // AWS access key ID
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,   // GitHub token
+	`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`, // Slack token
+	`\b[rs]k_live_[A-Za-z0-9]{20,}\b`,  // Stripe secret key
+	`\bAIza[0-9A-Za-z_-]{35}\b`,        // Google API key
+	`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?(-----END [A-Z ]*PRIVATE KEY-----|$)`, // private key
+	`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]+`,                // JSON web token
+}
+
+// WordFilter matches a list of words, regardless of their case.
+type WordFilter struct {
+	re     *regexp.Regexp
+	action string
+}
+
+// NewWordFilter creates a filter for a list of words. The redacted words
+// are replaced with asterisks.
+func NewWordFilter(words []string, action string) (*WordFilter, error) {
+	if err := checkAction(action); err != nil {
+		return nil, err
+	}
+
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word != "" {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+
+	re, err := regexp.Compile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)
+	if err != nil {
+		return nil, err
+	}
+	return &WordFilter{re: re, action: action}, nil
+}
+
+func (f *WordFilter) Name() string {
+	return "blockedWords"
+}
+
+func (f *WordFilter) FilterText(text string) (string, error) {
+	if !f.re.MatchString(text) {
+		return text, nil
+	}
+	if f.action == ActionReject {
+		return "", Reject(f.Name(), "the text contains blocked words")
+	}
+	return f.re.ReplaceAllStringFunc(text, func(word string) string {
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	}), nil
+}
+
+// SecretsFilter matches API keys, tokens and private keys.
+type SecretsFilter struct {
+	patterns []*regexp.Regexp
+	action   string
+}
+
+// NewSecretsFilter creates a filter for the built-in secret patterns and
+// the extra ones given. The redacted secrets are replaced with
+// [REDACTED].
+func NewSecretsFilter(extraPatterns []string, action string) (*SecretsFilter, error) {
+	if err := checkAction(action); err != nil {
+		return nil, err
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(defaultSecretPatterns)+len(extraPatterns))
+	for _, pattern := range append(append([]string{}, defaultSecretPatterns...), extraPatterns...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret pattern %q: %w", pattern, err)
+		}
+		patterns = append(patterns, re)
+	}
+	return &SecretsFilter{patterns: patterns, action: action}, nil
+}
+
+func (f *SecretsFilter) Name() string {
+	return "secrets"
+}
+
+func (f *SecretsFilter) FilterText(text string) (string, error) {
+	for _, re := range f.patterns {
+		if !re.MatchString(text) {
+			continue
+		}
+		if f.action == ActionReject {
+			return "", Reject(f.Name(), "the text contains a secret, such as an API key")
+		}
+		text = re.ReplaceAllString(text, redactedSecret)
+	}
+	return text, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package entitlements
+
+import (
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// Feature identifies a capability that may be restricted depending on
+// the edition or license the server is running with.
+type Feature string
+
+const (
+	FeatureGuestAccounts    Feature = "guest_accounts"
+	FeatureComplianceExport Feature = "compliance_export"
+	FeatureCustomRoles      Feature = "custom_roles"
+	FeatureDataRetention    Feature = "data_retention"
+)
+
+// Service is consulted by the app layer before enabling a restricted
+// feature. Downstream distributions can provide their own
+// implementation to plug in a different entitlement system.
+type Service interface {
+	IsFeatureEnabled(feature Feature) bool
+}
+
+// AllowAll enables every feature, for the distributions that have no
+// license to check.
+type AllowAll struct{}
+
+func (AllowAll) IsFeatureEnabled(feature Feature) bool {
+	return true
+}
+
+// LicenseFunc returns the license currently in effect, or nil if
+// there is none.
+type LicenseFunc func() *mmModel.License
+
+// LicenseService enables features based on the flags of a Mattermost
+// license.
+type LicenseService struct {
+	getLicense LicenseFunc
+}
+
+// NewLicenseService creates a Service that checks the license
+// returned by getLicense each time a feature is queried, so license
+// changes are picked up without a restart.
+func NewLicenseService(getLicense LicenseFunc) *LicenseService {
+	return &LicenseService{
+		getLicense: getLicense,
+	}
+}
+
+func (s *LicenseService) IsFeatureEnabled(feature Feature) bool {
+	license := s.getLicense()
+	if license == nil || license.Features == nil {
+		return false
+	}
+
+	var enabled *bool
+	switch feature {
+	case FeatureGuestAccounts:
+		enabled = license.Features.GuestAccounts
+	case FeatureComplianceExport:
+		enabled = license.Features.Compliance
+	case FeatureCustomRoles:
+		enabled = license.Features.CustomPermissionsSchemes
+	case FeatureDataRetention:
+		enabled = license.Features.DataRetention
+	}
+
+	return enabled != nil && *enabled
+}
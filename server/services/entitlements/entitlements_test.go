@@ -0,0 +1,49 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package entitlements
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestAllowAll(t *testing.T) {
+	s := AllowAll{}
+	assert.True(t, s.IsFeatureEnabled(FeatureGuestAccounts))
+	assert.True(t, s.IsFeatureEnabled(FeatureComplianceExport))
+	assert.True(t, s.IsFeatureEnabled(FeatureCustomRoles))
+	assert.True(t, s.IsFeatureEnabled(FeatureDataRetention))
+}
+
+func TestLicenseService(t *testing.T) {
+	trueValue := true
+	falseValue := false
+
+	t.Run("no license", func(t *testing.T) {
+		s := NewLicenseService(func() *mmModel.License { return nil })
+		assert.False(t, s.IsFeatureEnabled(FeatureComplianceExport))
+	})
+
+	t.Run("license without features", func(t *testing.T) {
+		s := NewLicenseService(func() *mmModel.License { return &mmModel.License{} })
+		assert.False(t, s.IsFeatureEnabled(FeatureComplianceExport))
+	})
+
+	t.Run("license with features", func(t *testing.T) {
+		license := &mmModel.License{
+			Features: &mmModel.Features{
+				Compliance:    &trueValue,
+				DataRetention: &falseValue,
+			},
+		}
+		s := NewLicenseService(func() *mmModel.License { return license })
+		assert.True(t, s.IsFeatureEnabled(FeatureComplianceExport))
+		assert.False(t, s.IsFeatureEnabled(FeatureDataRetention))
+		assert.False(t, s.IsFeatureEnabled(FeatureGuestAccounts))
+		assert.False(t, s.IsFeatureEnabled(Feature("unknown")))
+	})
+}
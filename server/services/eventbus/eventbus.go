@@ -0,0 +1,143 @@
+// Package eventbus publishes the board, block and member changes to a
+// message broker, for data pipelines and external automation.
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	TypeNATS  = "nats"
+	TypeKafka = "kafka"
+
+	defaultTopicPrefix = "focalboard"
+
+	// queueSize is the number of events waiting to be published after
+	// which new events are dropped, so a slow broker doesn't hold up the
+	// API requests.
+	queueSize = 1000
+)
+
+var ErrUnknownEventBusType = errors.New("unknown event bus type")
+
+// transport sends the messages to a broker. The key is used by the
+// brokers that partition the topics, to keep the changes of a board in
+// order.
+type transport interface {
+	publish(topic, key string, payload []byte) error
+	close() error
+}
+
+// Service publishes the changes asynchronously, in the order they happen.
+type Service struct {
+	transport   transport
+	topicPrefix string
+	logger      *mlog.Logger
+
+	mux    sync.RWMutex
+	queue  chan *model.ChangeEvent
+	closed bool
+	done   chan struct{}
+}
+
+// New creates the publisher for the configured broker.
+func New(cfg config.EventBusConfig, logger *mlog.Logger) (*Service, error) {
+	var t transport
+	var err error
+	switch cfg.Type {
+	case TypeNATS:
+		t, err = newNATSTransport(cfg)
+	case TypeKafka:
+		t, err = newKafkaTransport(cfg)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEventBusType, cfg.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newService(t, cfg.TopicPrefix, logger), nil
+}
+
+func newService(t transport, topicPrefix string, logger *mlog.Logger) *Service {
+	if topicPrefix == "" {
+		topicPrefix = defaultTopicPrefix
+	}
+
+	s := &Service{
+		transport:   t,
+		topicPrefix: topicPrefix,
+		logger:      logger,
+		queue:       make(chan *model.ChangeEvent, queueSize),
+		done:        make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Publish queues the event to be published on the topic of its team.
+func (s *Service) Publish(event *model.ChangeEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.queue <- event:
+	default:
+		s.logger.Warn("Event bus queue full, dropping event",
+			mlog.String("type", string(event.Type)),
+			mlog.String("board_id", event.BoardID),
+		)
+	}
+}
+
+// Topic returns the topic the changes of the team are published on.
+func (s *Service) Topic(teamID string) string {
+	return s.topicPrefix + "." + teamID
+}
+
+func (s *Service) run() {
+	defer close(s.done)
+
+	for event := range s.queue {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			s.logger.Error("Cannot marshal event bus event", mlog.Err(err))
+			continue
+		}
+
+		if err := s.transport.publish(s.Topic(event.TeamID), event.BoardID, payload); err != nil {
+			s.logger.Error("Cannot publish event bus event",
+				mlog.String("type", string(event.Type)),
+				mlog.String("board_id", event.BoardID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// Shutdown publishes the queued events and disconnects from the broker.
+func (s *Service) Shutdown() error {
+	s.mux.Lock()
+	if s.closed {
+		s.mux.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.queue)
+	s.mux.Unlock()
+
+	<-s.done
+	return s.transport.close()
+}
@@ -0,0 +1,82 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type publishedMessage struct {
+	topic   string
+	key     string
+	payload []byte
+}
+
+type fakeTransport struct {
+	mux      sync.Mutex
+	messages []publishedMessage
+	closed   bool
+}
+
+func (ft *fakeTransport) publish(topic, key string, payload []byte) error {
+	ft.mux.Lock()
+	defer ft.mux.Unlock()
+	ft.messages = append(ft.messages, publishedMessage{topic: topic, key: key, payload: payload})
+	return nil
+}
+
+func (ft *fakeTransport) close() error {
+	ft.closed = true
+	return nil
+}
+
+func TestService(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := New(config.EventBusConfig{Type: "carrier-pigeon"}, logger)
+		require.ErrorIs(t, err, ErrUnknownEventBusType)
+	})
+
+	t.Run("events are published in order on the team topic", func(t *testing.T) {
+		transport := &fakeTransport{}
+		service := newService(transport, "", logger)
+
+		service.Publish(&model.ChangeEvent{Type: model.ChangeEventBoardChanged, TeamID: "team-1", BoardID: "board-1"})
+		service.Publish(&model.ChangeEvent{Type: model.ChangeEventBlockDeleted, TeamID: "team-2", BoardID: "board-2", BlockID: "block-id"})
+		require.NoError(t, service.Shutdown())
+		require.True(t, transport.closed)
+
+		require.Len(t, transport.messages, 2)
+		require.Equal(t, "focalboard.team-1", transport.messages[0].topic)
+		require.Equal(t, "board-1", transport.messages[0].key)
+		require.Equal(t, "focalboard.team-2", transport.messages[1].topic)
+
+		var event model.ChangeEvent
+		require.NoError(t, json.Unmarshal(transport.messages[1].payload, &event))
+		require.Equal(t, model.ChangeEventBlockDeleted, event.Type)
+		require.Equal(t, "block-id", event.BlockID)
+	})
+
+	t.Run("events published after shutdown are ignored", func(t *testing.T) {
+		transport := &fakeTransport{}
+		service := newService(transport, "boards", logger)
+		require.NoError(t, service.Shutdown())
+		require.NoError(t, service.Shutdown())
+
+		service.Publish(&model.ChangeEvent{Type: model.ChangeEventBoardChanged, TeamID: "team-1"})
+		require.Empty(t, transport.messages)
+		require.Equal(t, "boards.team-1", service.Topic("team-1"))
+	})
+}
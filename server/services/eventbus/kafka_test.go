@@ -0,0 +1,52 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaTransport(t *testing.T) {
+	t.Run("invalid URL", func(t *testing.T) {
+		_, err := newKafkaTransport(config.EventBusConfig{URL: "nats://localhost"})
+		require.Error(t, err)
+	})
+
+	t.Run("message is produced", func(t *testing.T) {
+		var path, contentType, username string
+		var request kafkaProduceRequest
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			contentType = r.Header.Get("Content-Type")
+			username, _, _ = r.BasicAuth()
+			_ = json.NewDecoder(r.Body).Decode(&request)
+		}))
+		defer ts.Close()
+
+		transport, err := newKafkaTransport(config.EventBusConfig{URL: ts.URL + "/", Username: "user", Password: "pass"})
+		require.NoError(t, err)
+
+		require.NoError(t, transport.publish("focalboard.team-id", "board-id", []byte(`{"type":"board_changed"}`)))
+		require.Equal(t, "/topics/focalboard.team-id", path)
+		require.Equal(t, kafkaContentType, contentType)
+		require.Equal(t, "user", username)
+		require.Len(t, request.Records, 1)
+		require.Equal(t, "board-id", request.Records[0].Key)
+		require.JSONEq(t, `{"type":"board_changed"}`, string(request.Records[0].Value))
+	})
+
+	t.Run("proxy error", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer ts.Close()
+
+		transport, err := newKafkaTransport(config.EventBusConfig{URL: ts.URL})
+		require.NoError(t, err)
+		require.Error(t, transport.publish("focalboard.team-id", "board-id", []byte("{}")))
+	})
+}
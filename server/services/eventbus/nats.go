@@ -0,0 +1,82 @@
+package eventbus
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/mattermost/focalboard/server/services/config"
+)
+
+const (
+	natsDialTimeout  = 5 * time.Second
+	natsFlushTimeout = 5 * time.Second
+)
+
+// natsTransport publishes with the NATS client. The client reconnects
+// when the connection is lost, buffering the messages published in the
+// meantime, and connects over TLS when the URL or the server asks for it.
+type natsTransport struct {
+	conn *nats.Conn
+}
+
+// natsOptions returns the options of the connection: the credentials,
+// from the configuration or else the URL, and the TLS files.
+func natsOptions(cfg config.EventBusConfig) []nats.Option {
+	options := []nats.Option{
+		nats.Name("focalboard"),
+		nats.Timeout(natsDialTimeout),
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+	}
+
+	switch {
+	case cfg.CredentialsFile != "":
+		options = append(options, nats.UserCredentials(cfg.CredentialsFile))
+	case cfg.Token != "":
+		options = append(options, nats.Token(cfg.Token))
+	case cfg.Username != "":
+		options = append(options, nats.UserInfo(cfg.Username, cfg.Password))
+	}
+
+	if cfg.TLSCAFile != "" {
+		options = append(options, nats.RootCAs(cfg.TLSCAFile))
+	}
+	if cfg.TLSCertFile != "" || cfg.TLSKeyFile != "" {
+		options = append(options, nats.ClientCert(cfg.TLSCertFile, cfg.TLSKeyFile))
+	}
+	return options
+}
+
+func newNATSTransport(cfg config.EventBusConfig) (*natsTransport, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("invalid NATS URL %q", cfg.URL)
+	}
+
+	// the server not being reachable yet isn't an error, the client keeps
+	// trying to connect in the background.
+	conn, err := nats.Connect(cfg.URL, natsOptions(cfg)...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to NATS server: %w", err)
+	}
+	return &natsTransport{conn: conn}, nil
+}
+
+func (t *natsTransport) publish(topic, _ string, payload []byte) error {
+	return t.conn.Publish(topic, payload)
+}
+
+func (t *natsTransport) close() error {
+	defer t.conn.Close()
+
+	if !t.conn.IsConnected() {
+		return nil
+	}
+	return t.conn.FlushTimeout(natsFlushTimeout)
+}
@@ -0,0 +1,102 @@
+package eventbus
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeNATSInfo = `{"server_id":"test","max_payload":1048576}`
+
+// fakeNATSServer accepts one connection, completes the handshake and
+// sends the connect options and the published messages to the returned
+// channel.
+func fakeNATSServer(t *testing.T) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	received := make(chan string, 10)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+
+		_, _ = conn.Write([]byte("INFO " + fakeNATSInfo + "\r\n"))
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				close(received)
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				received <- strings.TrimSpace(line)
+			case strings.TrimSpace(line) == "PING":
+				_, _ = conn.Write([]byte("PONG\r\n"))
+			case strings.HasPrefix(line, "PUB "):
+				payload, _ := reader.ReadString('\n')
+				received <- strings.TrimSpace(line) + " " + strings.TrimSpace(payload)
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestNATSTransport(t *testing.T) {
+	t.Run("invalid URL", func(t *testing.T) {
+		_, err := newNATSTransport(config.EventBusConfig{URL: "http://localhost"})
+		require.Error(t, err)
+	})
+
+	t.Run("message is published with the credentials of the URL", func(t *testing.T) {
+		address, received := fakeNATSServer(t)
+		transport, err := newNATSTransport(config.EventBusConfig{URL: "nats://user:pass@" + address})
+		require.NoError(t, err)
+
+		connect := <-received
+		require.Contains(t, connect, `"user":"user"`)
+		require.Contains(t, connect, `"pass":"pass"`)
+
+		require.NoError(t, transport.publish("focalboard.team-id", "board-id", []byte(`{"type":"board_changed"}`)))
+		require.NoError(t, transport.close())
+		require.Equal(t, `PUB focalboard.team-id 24 {"type":"board_changed"}`, <-received)
+	})
+
+	t.Run("token of the configuration", func(t *testing.T) {
+		address, received := fakeNATSServer(t)
+		transport, err := newNATSTransport(config.EventBusConfig{URL: "nats://" + address, Token: "secret-token"})
+		require.NoError(t, err)
+		defer func() { _ = transport.close() }()
+
+		require.Contains(t, <-received, `"auth_token":"secret-token"`)
+	})
+
+	t.Run("invalid TLS files", func(t *testing.T) {
+		_, err := newNATSTransport(config.EventBusConfig{URL: "tls://localhost", TLSCAFile: "/nonexistent/ca.pem"})
+		require.Error(t, err)
+
+		_, err = newNATSTransport(config.EventBusConfig{URL: "tls://localhost", TLSCertFile: "/nonexistent/cert.pem", TLSKeyFile: "/nonexistent/key.pem"})
+		require.Error(t, err)
+	})
+
+	t.Run("unreachable server", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		listener.Close()
+
+		transport, err := newNATSTransport(config.EventBusConfig{URL: "nats://" + address})
+		require.NoError(t, err, "the client keeps trying to connect")
+		require.NoError(t, transport.publish("focalboard.team-id", "", []byte("{}")))
+		require.NoError(t, transport.close())
+	})
+}
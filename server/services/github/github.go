@@ -0,0 +1,169 @@
+// Package github talks to the GitHub REST API for the integration
+// syncing the issues of repositories to boards.
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+)
+
+const (
+	// DefaultAPIURL is the URL of the API of github.com.
+	DefaultAPIURL = "https://api.github.com"
+
+	// HeaderEvent holds the name of the event of a GitHub webhook request.
+	HeaderEvent = "X-GitHub-Event"
+
+	// HeaderSignature holds the HMAC-SHA256 signature of the body of a
+	// GitHub webhook request, in the "sha256=<hex>" format.
+	HeaderSignature = "X-Hub-Signature-256"
+
+	requestTimeout = 30 * time.Second
+	issuesPerPage  = 100
+
+	// maxIssuePages limits the issues imported from a repository to
+	// maxIssuePages * issuesPerPage.
+	maxIssuePages = 50
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	ErrRequestFailed   = errors.New("GitHub request failed")
+	ErrTooManyIssues   = errors.New("the repository has too many issues to import")
+	errInvalidResponse = errors.New("invalid GitHub response")
+)
+
+// User is a GitHub user.
+type User struct {
+	Login string `json:"login"`
+}
+
+// Issue is a GitHub issue. Pull requests are listed as issues by the API,
+// with a PullRequest.
+type Issue struct {
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	State       string          `json:"state"`
+	HTMLURL     string          `json:"html_url"`
+	Assignees   []User          `json:"assignees"`
+	PullRequest json.RawMessage `json:"pull_request,omitempty"`
+}
+
+// IsPullRequest returns true if the issue is a pull request.
+func (i *Issue) IsPullRequest() bool {
+	return len(i.PullRequest) > 0
+}
+
+// Repository is a GitHub repository.
+type Repository struct {
+	FullName string `json:"full_name"`
+}
+
+// IssuesEvent is the body of an "issues" webhook event.
+type IssuesEvent struct {
+	Action     string     `json:"action"`
+	Issue      Issue      `json:"issue"`
+	Repository Repository `json:"repository"`
+}
+
+// Service calls the GitHub API.
+type Service struct {
+	apiURL     string
+	httpClient *http.Client
+}
+
+// New creates the service for the configuration.
+func New(cfg config.GitHubConfig) *Service {
+	apiURL := strings.TrimRight(cfg.APIURL, "/")
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	return &Service{
+		apiURL:     apiURL,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// ListIssues returns the open and closed issues of the repository, as
+// owner/name, without the pull requests.
+func (s *Service) ListIssues(repository, token string) ([]Issue, error) {
+	issues := []Issue{}
+	for page := 1; ; page++ {
+		if page > maxIssuePages {
+			return nil, ErrTooManyIssues
+		}
+
+		url := fmt.Sprintf("%s/repos/%s/issues?state=all&per_page=%d&page=%d", s.apiURL, repository, issuesPerPage, page)
+		var pageIssues []Issue
+		if err := s.get(url, token, &pageIssues); err != nil {
+			return nil, err
+		}
+		for _, issue := range pageIssues {
+			if !issue.IsPullRequest() {
+				issues = append(issues, issue)
+			}
+		}
+		if len(pageIssues) < issuesPerPage {
+			return issues, nil
+		}
+	}
+}
+
+func (s *Service) get(url, token string, result interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: GitHub returned %d", ErrRequestFailed, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("%w: %s", errInvalidResponse, err)
+	}
+	return nil
+}
+
+// VerifySignature checks the signature header of a GitHub webhook
+// request against its body, signed with the secret of the webhook.
+func VerifySignature(secret, signature string, body []byte) error {
+	if signature == "" {
+		return model.ErrWebhookSignatureMissing
+	}
+	if secret == "" || !strings.HasPrefix(signature, signaturePrefix) {
+		return model.ErrWebhookSignatureInvalid
+	}
+	if !hmac.Equal([]byte(Sign(secret, body)), []byte(signature)) {
+		return model.ErrWebhookSignatureInvalid
+	}
+	return nil
+}
+
+// Sign returns the signature of a webhook body, as GitHub sends it.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
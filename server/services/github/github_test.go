@@ -0,0 +1,64 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+)
+
+func TestListIssues(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/repos/owner/repo/issues", r.URL.Path)
+		require.Equal(t, "Bearer ghp_token", r.Header.Get("Authorization"))
+
+		issues := []map[string]interface{}{}
+		if r.URL.Query().Get("page") == "1" {
+			for i := 1; i <= issuesPerPage; i++ {
+				issue := map[string]interface{}{"number": i, "title": fmt.Sprintf("issue %d", i), "state": "open"}
+				if i == 1 {
+					issue["pull_request"] = map[string]interface{}{"url": "https://example.com/pull/1"}
+				}
+				issues = append(issues, issue)
+			}
+		} else {
+			issues = append(issues, map[string]interface{}{"number": 101, "title": "last", "state": "closed"})
+		}
+		_ = json.NewEncoder(w).Encode(issues)
+	}))
+	defer ts.Close()
+
+	s := New(config.GitHubConfig{APIURL: ts.URL + "/"})
+	issues, err := s.ListIssues("owner/repo", "ghp_token")
+	require.NoError(t, err)
+	require.Len(t, issues, issuesPerPage)
+	require.Equal(t, 2, issues[0].Number)
+	require.Equal(t, "closed", issues[len(issues)-1].State)
+
+	t.Run("errors of the API", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer failing.Close()
+
+		_, err := New(config.GitHubConfig{APIURL: failing.URL}).ListIssues("owner/repo", "bad-token")
+		require.ErrorIs(t, err, ErrRequestFailed)
+	})
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"action":"closed"}`)
+	signature := Sign("secret", body)
+
+	require.NoError(t, VerifySignature("secret", signature, body))
+	require.ErrorIs(t, VerifySignature("secret", "", body), model.ErrWebhookSignatureMissing)
+	require.ErrorIs(t, VerifySignature("other-secret", signature, body), model.ErrWebhookSignatureInvalid)
+	require.ErrorIs(t, VerifySignature("secret", signature, []byte(`{"action":"opened"}`)), model.ErrWebhookSignatureInvalid)
+	require.ErrorIs(t, VerifySignature("", signature, body), model.ErrWebhookSignatureInvalid)
+}
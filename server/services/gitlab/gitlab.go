@@ -0,0 +1,125 @@
+// Package gitlab talks to the GitLab REST API for the import of the
+// issues of GitLab projects as cards.
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/config"
+)
+
+const (
+	// DefaultAPIURL is the URL of the API of gitlab.com.
+	DefaultAPIURL = "https://gitlab.com/api/v4"
+
+	// IssueStateOpened and IssueStateClosed are the states of the GitLab
+	// issues.
+	IssueStateOpened = "opened"
+	IssueStateClosed = "closed"
+
+	requestTimeout = 30 * time.Second
+	issuesPerPage  = 100
+
+	// maxIssuePages limits the issues imported from a project to
+	// maxIssuePages * issuesPerPage.
+	maxIssuePages = 50
+)
+
+var (
+	ErrRequestFailed   = errors.New("GitLab request failed")
+	ErrTooManyIssues   = errors.New("the project has too many issues to import")
+	errInvalidResponse = errors.New("invalid GitLab response")
+)
+
+// User is a GitLab user.
+type User struct {
+	Username string `json:"username"`
+}
+
+// Milestone is a milestone of a GitLab project.
+type Milestone struct {
+	Title string `json:"title"`
+}
+
+// Issue is a GitLab issue. IID is the number of the issue in its project.
+type Issue struct {
+	IID       int        `json:"iid"`
+	Title     string     `json:"title"`
+	State     string     `json:"state"`
+	WebURL    string     `json:"web_url"`
+	Labels    []string   `json:"labels"`
+	Milestone *Milestone `json:"milestone"`
+	Assignees []User     `json:"assignees"`
+}
+
+// Service calls the GitLab API.
+type Service struct {
+	apiURL     string
+	httpClient *http.Client
+}
+
+// New creates the service for the configuration.
+func New(cfg config.GitLabConfig) *Service {
+	apiURL := strings.TrimRight(cfg.APIURL, "/")
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	return &Service{
+		apiURL:     apiURL,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// ListIssues returns the open and closed issues of the project, given by
+// its path or ID. The token can be empty for public projects.
+func (s *Service) ListIssues(project, token string) ([]Issue, error) {
+	issues := []Issue{}
+	for page := 1; ; page++ {
+		if page > maxIssuePages {
+			return nil, ErrTooManyIssues
+		}
+
+		requestURL := fmt.Sprintf("%s/projects/%s/issues?scope=all&state=all&per_page=%d&page=%d",
+			s.apiURL, url.PathEscape(project), issuesPerPage, page)
+		var pageIssues []Issue
+		if err := s.get(requestURL, token, &pageIssues); err != nil {
+			return nil, err
+		}
+		issues = append(issues, pageIssues...)
+		if len(pageIssues) < issuesPerPage {
+			return issues, nil
+		}
+	}
+}
+
+func (s *Service) get(requestURL, token string, result interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("PRIVATE-TOKEN", token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: GitLab returned %d", ErrRequestFailed, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("%w: %s", errInvalidResponse, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/services/config"
+)
+
+func TestListIssues(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/projects/group%2Fproject/issues", r.URL.EscapedPath())
+		require.Equal(t, "glpat-token", r.Header.Get("PRIVATE-TOKEN"))
+		require.Equal(t, "all", r.URL.Query().Get("state"))
+
+		issues := []map[string]interface{}{}
+		if r.URL.Query().Get("page") == "1" {
+			for i := 1; i <= issuesPerPage; i++ {
+				issues = append(issues, map[string]interface{}{"iid": i, "title": fmt.Sprintf("issue %d", i), "state": "opened"})
+			}
+		} else {
+			issues = append(issues, map[string]interface{}{
+				"iid":       101,
+				"title":     "last",
+				"state":     "closed",
+				"labels":    []string{"bug"},
+				"milestone": map[string]interface{}{"title": "v1.0"},
+				"assignees": []map[string]interface{}{{"username": "alice"}},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(issues)
+	}))
+	defer ts.Close()
+
+	s := New(config.GitLabConfig{APIURL: ts.URL + "/"})
+	issues, err := s.ListIssues("group/project", "glpat-token")
+	require.NoError(t, err)
+	require.Len(t, issues, issuesPerPage+1)
+	require.Equal(t, 1, issues[0].IID)
+	require.Nil(t, issues[0].Milestone)
+
+	last := issues[len(issues)-1]
+	require.Equal(t, IssueStateClosed, last.State)
+	require.Equal(t, []string{"bug"}, last.Labels)
+	require.Equal(t, "v1.0", last.Milestone.Title)
+	require.Equal(t, []User{{Username: "alice"}}, last.Assignees)
+
+	t.Run("errors of the API", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer failing.Close()
+
+		_, err := New(config.GitLabConfig{APIURL: failing.URL}).ListIssues("group/project", "")
+		require.ErrorIs(t, err, ErrRequestFailed)
+	})
+}
@@ -0,0 +1,175 @@
+// Package mail delivers emails through the SMTP server of the
+// configuration. The plugin sends its emails through the Mattermost
+// server instead, with a Sender of its own.
+package mail
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/secrets"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	ConnectionSecurityNone     = ""
+	ConnectionSecurityTLS      = "TLS"
+	ConnectionSecurityStartTLS = "STARTTLS"
+
+	dialTimeout = 10 * time.Second
+)
+
+var ErrNotConfigured = errors.New("the SMTP server is not configured")
+
+// Sender sends HTML emails. All the emails of the server, such as the
+// notifications and the invitations, are sent through the same Sender.
+type Sender interface {
+	// IsConfigured reports whether emails can be sent.
+	IsConfigured() bool
+	// SendMail sends an email to a specific address.
+	SendMail(to, subject, htmlBody string) error
+}
+
+// Service sends emails through the configured SMTP server.
+type Service struct {
+	config  *config.Configuration
+	secrets *secrets.Service
+	logger  *mlog.Logger
+}
+
+// New creates a new mail Service. The SMTP password of the configuration
+// can be encrypted with the secrets key.
+func New(config *config.Configuration, secrets *secrets.Service, logger *mlog.Logger) *Service {
+	return &Service{
+		config:  config,
+		secrets: secrets,
+		logger:  logger,
+	}
+}
+
+// IsConfigured reports whether the SMTP server and the sender address
+// are set, so emails can be sent.
+func (s *Service) IsConfigured() bool {
+	return s.config.SMTP.Server != "" && s.config.SMTP.FromAddress != ""
+}
+
+// SendMail delivers an HTML email to its recipient.
+func (s *Service) SendMail(to, subject, htmlBody string) error {
+	if !s.IsConfigured() {
+		return ErrNotConfigured
+	}
+
+	cfg := s.config.SMTP
+	from, err := mail.ParseAddress(cfg.FromAddress)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP from address: %w", err)
+	}
+	toAddress, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	client, err := s.dial()
+	if err != nil {
+		return fmt.Errorf("cannot connect to the SMTP server: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if cfg.Username != "" {
+		password, err := s.secrets.Decrypt(cfg.Password)
+		if err != nil {
+			return fmt.Errorf("cannot decrypt the SMTP password: %w", err)
+		}
+		auth := smtp.PlainAuth("", cfg.Username, password, cfg.Server)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("cannot authenticate with the SMTP server: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(toAddress.Address); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(buildMessage(from, toAddress, subject, htmlBody, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	s.logger.Debug("Email sent", mlog.String("subject", subject))
+	return client.Quit()
+}
+
+func (s *Service) dial() (*smtp.Client, error) {
+	cfg := s.config.SMTP
+	addr := net.JoinHostPort(cfg.Server, strconv.Itoa(cfg.Port))
+	tlsConfig := &tls.Config{
+		ServerName:         cfg.Server,
+		InsecureSkipVerify: cfg.SkipServerCertificateVerification, //nolint:gosec
+	}
+
+	if cfg.ConnectionSecurity == ConnectionSecurityTLS {
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, "tcp", addr, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		return smtp.NewClient(conn, cfg.Server)
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	client, err := smtp.NewClient(conn, cfg.Server)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	if cfg.ConnectionSecurity == ConnectionSecurityStartTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// buildMessage returns the message with its headers, ready to be sent
+// as the DATA of the SMTP transaction.
+func buildMessage(from, to *mail.Address, subject, htmlBody string, date time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+
+	// SMTP requires CRLF line endings.
+	body := strings.ReplaceAll(htmlBody, "\r\n", "\n")
+	buf.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return buf.Bytes()
+}
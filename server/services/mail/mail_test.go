@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func TestIsConfigured(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+
+	cfg := &config.Configuration{}
+	s := New(cfg, nil, logger)
+	require.False(t, s.IsConfigured())
+	require.ErrorIs(t, s.SendMail("user@example.com", "subject", "body"), ErrNotConfigured)
+
+	cfg.SMTP.Server = "localhost"
+	require.False(t, s.IsConfigured())
+
+	cfg.SMTP.FromAddress = "boards@example.com"
+	require.True(t, s.IsConfigured())
+}
+
+func TestBuildMessage(t *testing.T) {
+	from := &mail.Address{Name: "Boards", Address: "boards@example.com"}
+	to := &mail.Address{Address: "user@example.com"}
+	date := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	data := string(buildMessage(from, to, "Invitation to Ünïcode board", "line 1\nline 2", date))
+
+	parts := strings.SplitN(data, "\r\n\r\n", 2)
+	require.Len(t, parts, 2)
+	headers, body := parts[0], parts[1]
+	require.Contains(t, headers, "From: \"Boards\" <boards@example.com>\r\n")
+	require.Contains(t, headers, "To: <user@example.com>\r\n")
+	require.Contains(t, headers, "Subject: =?utf-8?q?")
+	require.Contains(t, headers, "Date: Sun, 01 May 2022 10:00:00 +0000\r\n")
+	require.Contains(t, headers, "Content-Type: text/html; charset=\"utf-8\"\r\n")
+	require.Equal(t, "line 1\r\nline 2", body)
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notify
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+const (
+	DefaultLocale = "en"
+
+	defTestCard    = "Test notification"
+	defTestMessage = "This is a test notification from Boards."
+
+	defAdminAccessTitle   = "Private board accessed by a system admin"
+	defAdminAccessMessage = "@{{.Admin}} opened your private board **{{.Board}}** in admin view. The access was recorded in the audit log."
+
+	defBoardFrozenTitle     = "Board frozen"
+	defBoardFrozenMessage   = "The board **{{.Board}}** is now read-only, as scheduled. Only its admins can change it."
+	defBoardUnfrozenTitle   = "Board unfrozen"
+	defBoardUnfrozenMessage = "The board **{{.Board}}** can be changed again, as scheduled."
+
+	defBoardAccessRequestTitle   = "Access requested to a board"
+	defBoardAccessRequestMessage = "@{{.User}} requested access to the board **{{.Board}}**. Approve or deny the request from the members of the board."
+)
+
+//go:embed i18n/*.json
+var translationFiles embed.FS
+
+var (
+	defaultBundle     *Bundle
+	defaultBundleErr  error
+	defaultBundleOnce sync.Once
+)
+
+// Bundle holds the translations of the notification messages, keyed by
+// locale and message ID. Messages are text/template strings. A nil
+// bundle returns the default messages.
+type Bundle struct {
+	translations map[string]map[string]string
+}
+
+// NewBundle creates a bundle with the translations embedded in the server.
+func NewBundle() (*Bundle, error) {
+	entries, err := translationFiles.ReadDir("i18n")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read notification translations: %w", err)
+	}
+
+	bundle := &Bundle{
+		translations: make(map[string]map[string]string),
+	}
+
+	for _, entry := range entries {
+		data, err := translationFiles.ReadFile(path.Join("i18n", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read notification translations %s: %w", entry.Name(), err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("cannot parse notification translations %s: %w", entry.Name(), err)
+		}
+
+		locale := normalizeLocale(strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+		bundle.translations[locale] = messages
+	}
+	return bundle, nil
+}
+
+// GetBundle returns the bundle shared by the notification backends. On
+// error, the nil bundle returned still gives the default messages, so the
+// backends can ignore the error.
+func GetBundle() (*Bundle, error) {
+	defaultBundleOnce.Do(func() {
+		defaultBundle, defaultBundleErr = NewBundle()
+	})
+	return defaultBundle, defaultBundleErr
+}
+
+// Locales returns the locales available in the bundle.
+func (b *Bundle) Locales() []string {
+	locales := make([]string, 0, len(b.translations))
+	for locale := range b.translations {
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+// Lookup returns the message template for the ID in the locale specified.
+// Regional locales fall back to their base language and then to the
+// default locale; def is returned when no translation exists.
+func (b *Bundle) Lookup(locale string, id string, def string) string {
+	if b == nil {
+		return def
+	}
+
+	locale = normalizeLocale(locale)
+	candidates := []string{locale}
+	if i := strings.Index(locale, "_"); i > 0 {
+		candidates = append(candidates, locale[:i])
+	}
+	candidates = append(candidates, DefaultLocale)
+
+	for _, candidate := range candidates {
+		if s, ok := b.translations[candidate][id]; ok && s != "" {
+			return s
+		}
+	}
+	return def
+}
+
+// T returns the translated message for the ID in the locale specified,
+// executing the message template with data. The default message is used
+// if the translation cannot be executed.
+func (b *Bundle) T(locale string, id string, def string, data interface{}) string {
+	if s, err := execMessage(id, b.Lookup(locale, id, def), data); err == nil {
+		return s
+	}
+	if s, err := execMessage(id, def, data); err == nil {
+		return s
+	}
+	return def
+}
+
+// TestNotificationText returns the title of the synthetic card test
+// notifications are about, and their message, in the locale specified.
+func (b *Bundle) TestNotificationText(locale string) (string, string) {
+	return b.T(locale, "notify.test.card", defTestCard, nil),
+		b.T(locale, "notify.test.message", defTestMessage, nil)
+}
+
+// AdminAccessText returns the title and the message of the notification
+// sent to the admins of a board opened by a system admin in admin view,
+// in the locale specified.
+func (b *Bundle) AdminAccessText(locale, admin, board string) (string, string) {
+	data := map[string]string{"Admin": admin, "Board": board}
+	return b.T(locale, "notify.admin_access.title", defAdminAccessTitle, nil),
+		b.T(locale, "notify.admin_access.message", defAdminAccessMessage, data)
+}
+
+// BoardFreezeText returns the title and the message of the notification
+// sent to the members of a board when a scheduled freeze or unfreeze of
+// the board takes effect, in the locale specified.
+func (b *Bundle) BoardFreezeText(locale, board string, frozen bool) (string, string) {
+	data := map[string]string{"Board": board}
+	if frozen {
+		return b.T(locale, "notify.board_freeze.frozen_title", defBoardFrozenTitle, nil),
+			b.T(locale, "notify.board_freeze.frozen_message", defBoardFrozenMessage, data)
+	}
+	return b.T(locale, "notify.board_freeze.unfrozen_title", defBoardUnfrozenTitle, nil),
+		b.T(locale, "notify.board_freeze.unfrozen_message", defBoardUnfrozenMessage, data)
+}
+
+// BoardAccessRequestText returns the title and the message of the
+// notification sent to the admins of a board when a user requests access
+// to it, in the locale specified.
+func (b *Bundle) BoardAccessRequestText(locale, user, board string) (string, string) {
+	data := map[string]string{"User": user, "Board": board}
+	return b.T(locale, "notify.board_access_request.title", defBoardAccessRequestTitle, nil),
+		b.T(locale, "notify.board_access_request.message", defBoardAccessRequestMessage, data)
+}
+
+func execMessage(id string, msg string, data interface{}) (string, error) {
+	t, err := template.New(id).Parse(msg)
+	if err != nil {
+		return "", err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// normalizeLocale converts locales such as `pt-BR` to the `pt_br` form
+// used as bundle keys.
+func normalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if locale == "" {
+		return DefaultLocale
+	}
+	return strings.ReplaceAll(locale, "-", "_")
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle(t *testing.T) {
+	bundle, err := NewBundle()
+	require.NoError(t, err)
+
+	t.Run("all locales translate the default messages", func(t *testing.T) {
+		for _, locale := range bundle.Locales() {
+			for id := range bundle.translations[DefaultLocale] {
+				assert.Contains(t, bundle.translations[locale], id, "locale %s is missing %s", locale, id)
+			}
+		}
+	})
+
+	t.Run("lookup falls back to base language and default locale", func(t *testing.T) {
+		assert.Equal(t, "Titel", bundle.Lookup("de", "notify.subscription.field_title", "def"))
+		assert.Equal(t, "Titel", bundle.Lookup("de-AT", "notify.subscription.field_title", "def"))
+		assert.Equal(t, "Title", bundle.Lookup("xx", "notify.subscription.field_title", "def"))
+		assert.Equal(t, "Title", bundle.Lookup("", "notify.subscription.field_title", "def"))
+		assert.Equal(t, "def", bundle.Lookup("de", "notify.missing", "def"))
+	})
+
+	t.Run("nil bundle returns the default message", func(t *testing.T) {
+		var nilBundle *Bundle
+		assert.Equal(t, "hello world", nilBundle.T("de", "notify.missing", "hello {{.Name}}", map[string]string{"Name": "world"}))
+	})
+
+	t.Run("translate with data", func(t *testing.T) {
+		msg := bundle.T("fr", "notify.subscription.field_comment_by", "Comment by {{.Authors}}", map[string]string{"Authors": "@bob"})
+		assert.Equal(t, "Commentaire de @bob", msg)
+	})
+
+	t.Run("admin access text", func(t *testing.T) {
+		title, msg := bundle.AdminAccessText("en", "sysadmin", "Roadmap")
+		assert.Equal(t, "Private board accessed by a system admin", title)
+		assert.Equal(t, "@sysadmin opened your private board **Roadmap** in admin view. The access was recorded in the audit log.", msg)
+	})
+
+	t.Run("board freeze text", func(t *testing.T) {
+		title, msg := bundle.BoardFreezeText("en", "Sprint 12", true)
+		assert.Equal(t, "Board frozen", title)
+		assert.Equal(t, "The board **Sprint 12** is now read-only, as scheduled. Only its admins can change it.", msg)
+
+		title, _ = bundle.BoardFreezeText("de", "Sprint 12", false)
+		assert.Equal(t, "Board freigegeben", title)
+	})
+
+	t.Run("board access request text", func(t *testing.T) {
+		title, msg := bundle.BoardAccessRequestText("en", "jdoe", "Roadmap")
+		assert.Equal(t, "Access requested to a board", title)
+		assert.Equal(t, "@jdoe requested access to the board **Roadmap**. Approve or deny the request from the members of the board.", msg)
+
+		title, _ = bundle.BoardAccessRequestText("es", "jdoe", "Roadmap")
+		assert.Equal(t, "Acceso solicitado a un tablero", title)
+	})
+}
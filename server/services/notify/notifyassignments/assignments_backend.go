@@ -0,0 +1,184 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyassignments
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	backendName = "notifyAssignments"
+)
+
+type BackendParams struct {
+	Store       Store
+	Permissions permissions.PermissionsService
+	Delivery    AssignmentDelivery
+	Logger      *mlog.Logger
+}
+
+// Backend provides the notification backend for users being assigned to,
+// or removed from, cards through person properties.
+type Backend struct {
+	store       Store
+	permissions permissions.PermissionsService
+	delivery    AssignmentDelivery
+	logger      *mlog.Logger
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		store:       params.Store,
+		permissions: params.Permissions,
+		delivery:    params.Delivery,
+		logger:      params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	if evt.Board == nil || evt.Card == nil || evt.ModifiedBy == nil {
+		return nil
+	}
+
+	if evt.Action == notify.Delete || evt.BlockChanged.Type != model.TypeCard {
+		return nil
+	}
+
+	schema, err := model.ParsePropertySchema(evt.Board)
+	if err != nil {
+		return fmt.Errorf("cannot parse property schema for board %s: %w", evt.Board.ID, err)
+	}
+
+	added, removed := diffAssignees(schema, evt.BlockOld, evt.BlockChanged)
+	merr := merror.New()
+
+	for _, userID := range added {
+		if err := b.notify(userID, true, evt); err != nil {
+			merr.Append(fmt.Errorf("cannot deliver assignment notification for %s: %w", userID, err))
+		}
+	}
+	for _, userID := range removed {
+		if err := b.notify(userID, false, evt); err != nil {
+			merr.Append(fmt.Errorf("cannot deliver unassignment notification for %s: %w", userID, err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+func (b *Backend) notify(userID string, assigned bool, evt notify.BlockChangeEvent) error {
+	if userID == evt.ModifiedBy.UserID {
+		// no need to tell users about their own changes
+		return nil
+	}
+
+	if !b.permissions.HasPermissionToBoard(userID, evt.Board.ID, model.PermissionViewBoard) {
+		b.logger.Debug("skipping assignment notification; user cannot view board",
+			mlog.String("user_id", userID),
+			mlog.String("board_id", evt.Board.ID),
+		)
+		return nil
+	}
+
+	user, err := b.store.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("cannot lookup assigned user: %w", err)
+	}
+
+	if !user.WantsAssignmentNotifications() {
+		return nil
+	}
+
+	if err := b.delivery.AssignmentDeliver(userID, assigned, evt); err != nil {
+		return err
+	}
+
+	b.logger.Debug("Assignment notification delivered",
+		mlog.String("user_id", userID),
+		mlog.String("card_id", evt.Card.ID),
+		mlog.Bool("assigned", assigned),
+	)
+	return nil
+}
+
+// SendDueDigest delivers the digest of the cards assigned to the user
+// that are due today or overdue. Empty digests aren't sent.
+func (b *Backend) SendDueDigest(evt notify.DueDigestEvent) error {
+	if len(evt.Cards) == 0 {
+		return nil
+	}
+
+	if err := b.delivery.DueDigestDeliver(evt.UserID, evt.Cards); err != nil {
+		return err
+	}
+
+	b.logger.Debug("Due digest delivered",
+		mlog.String("user_id", evt.UserID),
+		mlog.Int("card_count", len(evt.Cards)),
+	)
+	return nil
+}
+
+// SendStaleDigest delivers the digest of the stale cards of the boards
+// the user administers. Empty digests aren't sent.
+func (b *Backend) SendStaleDigest(evt notify.StaleDigestEvent) error {
+	if len(evt.Boards) == 0 {
+		return nil
+	}
+
+	if err := b.delivery.StaleDigestDeliver(evt.UserID, evt.Days, evt.Boards); err != nil {
+		return err
+	}
+
+	b.logger.Debug("Stale digest delivered",
+		mlog.String("user_id", evt.UserID),
+		mlog.Int("board_count", len(evt.Boards)),
+	)
+	return nil
+}
+
+// diffAssignees returns the users added to and removed from the person
+// properties of a card, sorted so notifications are sent in a stable order.
+func diffAssignees(schema model.PropSchema, oldCard *model.Block, newCard *model.Block) ([]string, []string) {
+	oldAssignees := model.CardAssignees(oldCard, schema)
+	newAssignees := model.CardAssignees(newCard, schema)
+
+	added := []string{}
+	for userID := range newAssignees {
+		if _, ok := oldAssignees[userID]; !ok {
+			added = append(added, userID)
+		}
+	}
+
+	removed := []string{}
+	for userID := range oldAssignees {
+		if _, ok := newAssignees[userID]; !ok {
+			removed = append(removed, userID)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyassignments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func Test_diffAssignees(t *testing.T) {
+	schema := model.PropSchema{
+		"assignee":  {ID: "assignee", Name: "Assignee", Type: "person"},
+		"reviewers": {ID: "reviewers", Name: "Reviewers", Type: "person"},
+		"notes":     {ID: "notes", Name: "Notes", Type: "text"},
+	}
+
+	tests := []struct {
+		name        string
+		oldProps    map[string]interface{}
+		newProps    map[string]interface{}
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{
+			name:        "new card",
+			newProps:    map[string]interface{}{"assignee": "user-1"},
+			wantAdded:   []string{"user-1"},
+			wantRemoved: []string{},
+		},
+		{
+			name:        "reassigned",
+			oldProps:    map[string]interface{}{"assignee": "user-1"},
+			newProps:    map[string]interface{}{"assignee": "user-2"},
+			wantAdded:   []string{"user-2"},
+			wantRemoved: []string{"user-1"},
+		},
+		{
+			name:        "moved between person properties",
+			oldProps:    map[string]interface{}{"assignee": "user-1"},
+			newProps:    map[string]interface{}{"reviewers": []interface{}{"user-1", "user-2"}},
+			wantAdded:   []string{"user-2"},
+			wantRemoved: []string{},
+		},
+		{
+			name:        "other properties are ignored",
+			oldProps:    map[string]interface{}{"notes": "user-1"},
+			newProps:    map[string]interface{}{"notes": "user-2"},
+			wantAdded:   []string{},
+			wantRemoved: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var oldCard *model.Block
+			if tt.oldProps != nil {
+				oldCard = makeCard(tt.oldProps)
+			}
+			added, removed := diffAssignees(schema, oldCard, makeCard(tt.newProps))
+			require.Equal(t, tt.wantAdded, added)
+			require.Equal(t, tt.wantRemoved, removed)
+		})
+	}
+}
+
+func makeCard(props map[string]interface{}) *model.Block {
+	return &model.Block{
+		ID:     "card-id",
+		Type:   model.TypeCard,
+		Fields: map[string]interface{}{"properties": props},
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyassignments
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+)
+
+// AssignmentDelivery provides an interface for delivering card assignment notifications to other systems, such as
+// channels server via plugin API.
+type AssignmentDelivery interface {
+	AssignmentDeliver(userID string, assigned bool, evt notify.BlockChangeEvent) error
+	DueDigestDeliver(userID string, cards []*model.DueDigestCard) error
+	StaleDigestDeliver(userID string, days int, boards []*model.StaleDigestBoard) error
+}
@@ -0,0 +1,9 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+package notifyassignments
+
+import "github.com/mattermost/focalboard/server/model"
+
+type Store interface {
+	GetUserByID(userID string) (*model.User, error)
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifychannelsync
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	backendName = "notifyChannelSync"
+
+	// CardFieldChannelThreadID is the card field holding the ID of the
+	// root post of the channel thread the card comments are synced with.
+	CardFieldChannelThreadID = "channelThreadId"
+
+	// CommentFieldChannelPostID is the comment field holding the ID of the
+	// channel post the comment was created from.
+	CommentFieldChannelPostID = "channelPostId"
+
+	// PostPropFromBoards marks the posts created by the sync, so they are
+	// not synced back into the card.
+	PostPropFromBoards = "from_boards"
+
+	// PostPropBoardID and PostPropCardID are set on the root post of the
+	// synced threads to find the card the replies belong to.
+	PostPropBoardID = "boards_board_id"
+	PostPropCardID  = "boards_card_id"
+)
+
+type BackendParams struct {
+	ServerRoot string
+	BotID      string
+	Store      Store
+	Posts      PostCreator
+	Logger     *mlog.Logger
+}
+
+// Backend mirrors the card comments of the boards linked to a channel
+// into a thread of the channel, one thread per card, posted by the bot.
+type Backend struct {
+	serverRoot string
+	botID      string
+	store      Store
+	posts      PostCreator
+	logger     *mlog.Logger
+
+	// mux serializes the creation of the threads, so concurrent comments
+	// on a card don't create more than one.
+	mux sync.Mutex
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		serverRoot: params.ServerRoot,
+		botID:      params.BotID,
+		store:      params.Store,
+		posts:      params.Posts,
+		logger:     params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+// BotID returns the ID of the bot that posts the synced comments.
+func (b *Backend) BotID() string {
+	return b.botID
+}
+
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	if !shouldSyncComment(evt) {
+		return nil
+	}
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	card, err := b.store.GetBlock(evt.Card.ID)
+	if err != nil {
+		return fmt.Errorf("cannot get card %s: %w", evt.Card.ID, err)
+	}
+
+	rootID, _ := card.Fields[CardFieldChannelThreadID].(string)
+	if rootID == "" {
+		if rootID, err = b.createThread(evt.Board, card, evt.BlockChanged.ModifiedBy); err != nil {
+			return err
+		}
+	}
+
+	message, err := b.commentMessage(evt.BlockChanged)
+	if err != nil {
+		return err
+	}
+
+	post := &mm_model.Post{
+		UserId:    b.botID,
+		ChannelId: evt.Board.ChannelID,
+		RootId:    rootID,
+		Message:   message,
+	}
+	post.AddProp(PostPropFromBoards, true)
+	if err := b.posts.CreatePost(post); err != nil {
+		return fmt.Errorf("cannot post comment %s to channel: %w", evt.BlockChanged.ID, err)
+	}
+
+	b.logger.Debug("Comment synced to channel thread",
+		mlog.String("comment_id", evt.BlockChanged.ID),
+		mlog.String("channel_id", evt.Board.ChannelID),
+		mlog.String("root_id", rootID),
+	)
+	return nil
+}
+
+// shouldSyncComment returns true for the new comments of the boards
+// that sync their comments, unless the comment was itself created from
+// a channel post, which would loop it back into the channel.
+func shouldSyncComment(evt notify.BlockChangeEvent) bool {
+	if evt.Board == nil || evt.Card == nil || evt.BlockChanged == nil {
+		return false
+	}
+	if evt.Action != notify.Add || evt.BlockChanged.Type != model.TypeComment {
+		return false
+	}
+	if !evt.Board.SyncsChannelComments() {
+		return false
+	}
+	postID, _ := evt.BlockChanged.Fields[CommentFieldChannelPostID].(string)
+	return postID == ""
+}
+
+// createThread posts the root of the thread of the card and stores its
+// ID on the card.
+func (b *Backend) createThread(board *model.Board, card *model.Block, userID string) (string, error) {
+	link := utils.MakeCardLink(b.serverRoot, board.TeamID, board.ID, card.ID)
+	root := &mm_model.Post{
+		UserId:    b.botID,
+		ChannelId: board.ChannelID,
+		Message:   fmt.Sprintf("Comments on card [%s](%s) of board **%s**", cardTitle(card), link, board.Title),
+	}
+	root.AddProp(PostPropFromBoards, true)
+	root.AddProp(PostPropBoardID, board.ID)
+	root.AddProp(PostPropCardID, card.ID)
+	if err := b.posts.CreatePost(root); err != nil {
+		return "", fmt.Errorf("cannot create thread for card %s: %w", card.ID, err)
+	}
+
+	patch := &model.BlockPatch{
+		UpdatedFields: map[string]interface{}{CardFieldChannelThreadID: root.Id},
+	}
+	if err := b.store.PatchBlock(card.ID, patch, userID); err != nil {
+		return "", fmt.Errorf("cannot store thread of card %s: %w", card.ID, err)
+	}
+	return root.Id, nil
+}
+
+// commentMessage returns the text of the comment attributed to its author.
+func (b *Backend) commentMessage(comment *model.Block) (string, error) {
+	user, err := b.store.GetUserByID(comment.ModifiedBy)
+	if err != nil {
+		return "", fmt.Errorf("cannot lookup comment author: %w", err)
+	}
+	return fmt.Sprintf("**@%s** commented:\n%s", user.Username, comment.Title), nil
+}
+
+func cardTitle(card *model.Block) string {
+	if card.Title == "" {
+		return "Untitled"
+	}
+	return card.Title
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifychannelsync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type fakeStore struct {
+	card    *model.Block
+	patches []*model.BlockPatch
+}
+
+func (s *fakeStore) GetBlock(blockID string) (*model.Block, error) {
+	return s.card, nil
+}
+
+func (s *fakeStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error {
+	s.patches = append(s.patches, blockPatch)
+	for key, value := range blockPatch.UpdatedFields {
+		s.card.Fields[key] = value
+	}
+	return nil
+}
+
+func (s *fakeStore) GetUserByID(userID string) (*model.User, error) {
+	return &model.User{ID: userID, Username: "jane"}, nil
+}
+
+type fakePosts struct {
+	posts []*mm_model.Post
+}
+
+func (p *fakePosts) CreatePost(post *mm_model.Post) error {
+	post.Id = mm_model.NewId()
+	p.posts = append(p.posts, post)
+	return nil
+}
+
+func makeEvent(comment *model.Block) notify.BlockChangeEvent {
+	return notify.BlockChangeEvent{
+		Action: notify.Add,
+		Board: &model.Board{
+			ID:         "board-id",
+			TeamID:     "team-id",
+			ChannelID:  "channel-id",
+			Title:      "Board",
+			Properties: map[string]interface{}{model.BoardPropertySyncChannelComments: true},
+		},
+		Card:         &model.Block{ID: "card-id", Type: model.TypeCard},
+		BlockChanged: comment,
+	}
+}
+
+func makeComment(fields map[string]interface{}) *model.Block {
+	return &model.Block{
+		ID:         "comment-id",
+		ParentID:   "card-id",
+		Type:       model.TypeComment,
+		Title:      "looks good",
+		ModifiedBy: "user-id",
+		Fields:     fields,
+	}
+}
+
+func Test_shouldSyncComment(t *testing.T) {
+	require.True(t, shouldSyncComment(makeEvent(makeComment(nil))))
+
+	t.Run("comments created from posts are not synced back", func(t *testing.T) {
+		evt := makeEvent(makeComment(map[string]interface{}{CommentFieldChannelPostID: "post-id"}))
+		require.False(t, shouldSyncComment(evt))
+	})
+
+	t.Run("boards without comment sync", func(t *testing.T) {
+		evt := makeEvent(makeComment(nil))
+		evt.Board.Properties = map[string]interface{}{}
+		require.False(t, shouldSyncComment(evt))
+	})
+
+	t.Run("boards without channel", func(t *testing.T) {
+		evt := makeEvent(makeComment(nil))
+		evt.Board.ChannelID = ""
+		require.False(t, shouldSyncComment(evt))
+	})
+
+	t.Run("updated comments", func(t *testing.T) {
+		evt := makeEvent(makeComment(nil))
+		evt.Action = notify.Update
+		require.False(t, shouldSyncComment(evt))
+	})
+
+	t.Run("other blocks", func(t *testing.T) {
+		block := makeComment(nil)
+		block.Type = model.TypeText
+		require.False(t, shouldSyncComment(makeEvent(block)))
+	})
+}
+
+func TestBlockChanged(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(true, mlog.LvlError)
+	store := &fakeStore{card: &model.Block{ID: "card-id", Title: "Card", Fields: map[string]interface{}{}}}
+	posts := &fakePosts{}
+	backend := New(BackendParams{
+		ServerRoot: "http://localhost/boards",
+		BotID:      "bot-id",
+		Store:      store,
+		Posts:      posts,
+		Logger:     logger,
+	})
+
+	require.NoError(t, backend.BlockChanged(makeEvent(makeComment(nil))))
+	require.Len(t, posts.posts, 2)
+
+	root, reply := posts.posts[0], posts.posts[1]
+	require.Equal(t, "card-id", root.GetProp(PostPropCardID))
+	require.Equal(t, root.Id, reply.RootId)
+	require.Equal(t, "bot-id", reply.UserId)
+	require.Equal(t, true, reply.GetProp(PostPropFromBoards))
+	require.Equal(t, "**@jane** commented:\nlooks good", reply.Message)
+	require.Equal(t, root.Id, store.card.Fields[CardFieldChannelThreadID])
+
+	// the following comments reuse the thread
+	require.NoError(t, backend.BlockChanged(makeEvent(makeComment(nil))))
+	require.Len(t, posts.posts, 3)
+	require.Equal(t, root.Id, posts.posts[2].RootId)
+	require.Len(t, store.patches, 1)
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifychannelsync
+
+import (
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// PostCreator creates the posts of the synced threads.
+type PostCreator interface {
+	// CreatePost creates a post, setting its ID.
+	CreatePost(post *mm_model.Post) error
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifychannelsync
+
+import "github.com/mattermost/focalboard/server/model"
+
+type Store interface {
+	GetBlock(blockID string) (*model.Block, error)
+	PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error
+	GetUserByID(userID string) (*model.User, error)
+}
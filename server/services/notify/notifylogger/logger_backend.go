@@ -54,6 +54,13 @@ func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
 	return nil
 }
 
+func (b *Backend) SendTestNotification(userID string) error {
+	b.logger.Log(b.level, "Test notification",
+		mlog.String("user_id", userID),
+	)
+	return nil
+}
+
 func (b *Backend) Name() string {
 	return backendName
 }
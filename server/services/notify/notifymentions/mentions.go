@@ -17,13 +17,20 @@ var atMentionRegexp = regexp.MustCompile(`\B@[[:alnum:]][[:alnum:]\.\-_:]*`)
 // extractMentions extracts any mentions in the specified block and returns
 // a slice of usernames.
 func extractMentions(block *model.Block) map[string]struct{} {
+	if block == nil {
+		return make(map[string]struct{})
+	}
+	return extractMentionsFromText(block.Title)
+}
+
+// extractMentionsFromText extracts any mentions in the text and returns
+// the set of usernames.
+func extractMentionsFromText(str string) map[string]struct{} {
 	mentions := make(map[string]struct{})
-	if block == nil || !strings.Contains(block.Title, "@") {
+	if !strings.Contains(str, "@") {
 		return mentions
 	}
 
-	str := block.Title
-
 	for _, match := range atMentionRegexp.FindAllString(str, -1) {
 		name := mm_model.NormalizeUsername(match[1:])
 		if mm_model.IsValidUsernameAllowRemote(name) {
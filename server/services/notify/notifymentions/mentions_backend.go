@@ -6,14 +6,17 @@ package notifymentions
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/mattermost/focalboard/server/utils"
 	"github.com/mattermost/focalboard/server/ws"
 	"github.com/wiggin77/merror"
 
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
@@ -93,7 +96,13 @@ func (b *Backend) RemoveListener(l MentionListener) {
 }
 
 func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
-	if evt.Board == nil || evt.Card == nil {
+	if evt.Board == nil {
+		return nil
+	}
+
+	// the text blocks of the board itself, outside of any card, are
+	// mentionable the same way the text of the cards is
+	if evt.Card == nil && evt.BlockChanged.ParentID != evt.Board.ID {
 		return nil
 	}
 
@@ -107,6 +116,50 @@ func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
 		return nil
 	}
 
+	return b.notifyMentions(evt)
+}
+
+// BoardChanged notifies the users mentioned in the description of the
+// board, with the same permission rules as the mentions in the cards.
+func (b *Backend) BoardChanged(evt notify.BoardChangeEvent) error {
+	if evt.Board == nil || evt.Action == notify.Delete {
+		return nil
+	}
+
+	oldDescription := ""
+	if evt.BoardOld != nil {
+		oldDescription = evt.BoardOld.Description
+	}
+	if evt.Board.Description == oldDescription {
+		return nil
+	}
+
+	// the description is handled as a text block of the board, so that
+	// it is delivered and recorded as any other board level mention
+	blockEvt := notify.BlockChangeEvent{
+		Action:       evt.Action,
+		TeamID:       evt.TeamID,
+		Board:        evt.Board,
+		BlockChanged: descriptionBlock(evt.Board.ID, evt.Board.Description),
+		BlockOld:     descriptionBlock(evt.Board.ID, oldDescription),
+		ModifiedBy:   evt.ModifiedBy,
+	}
+	return b.notifyMentions(blockEvt)
+}
+
+func descriptionBlock(boardID, description string) *model.Block {
+	return &model.Block{
+		ID:       boardID,
+		ParentID: boardID,
+		BoardID:  boardID,
+		Type:     model.TypeText,
+		Title:    description,
+	}
+}
+
+// notifyMentions delivers a notification to each user mentioned in the
+// changed block that wasn't mentioned in its previous version.
+func (b *Backend) notifyMentions(evt notify.BlockChangeEvent) error {
 	mentions := extractMentions(evt.BlockChanged)
 	if len(mentions) == 0 {
 		return nil
@@ -143,6 +196,10 @@ func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
 			mlog.Int("listener_count", len(listeners)),
 		)
 
+		if err := b.saveMention(userID, extract, evt); err != nil {
+			merr.Append(fmt.Errorf("cannot save mention for @%s: %w", username, err))
+		}
+
 		for _, listener := range listeners {
 			safeCallListener(listener, userID, evt, b.logger)
 		}
@@ -150,6 +207,66 @@ func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
 	return merr.ErrorOrNil()
 }
 
+// SendTestNotification delivers a synthetic mention of the user, about a
+// card that doesn't exist. The mention isn't recorded in the user's inbox
+// and listeners aren't informed.
+func (b *Backend) SendTestNotification(userID string) error {
+	user, err := b.store.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("cannot lookup user %s: %w", userID, err)
+	}
+
+	mentionedUser, err := b.delivery.UserByUsername(user.Username)
+	if err != nil {
+		return fmt.Errorf("cannot lookup mentioned user: %w", err)
+	}
+
+	bundle, _ := notify.GetBundle()
+	cardTitle, message := bundle.TestNotificationText(mentionedUser.Locale)
+
+	evt := notify.BlockChangeEvent{
+		Action: notify.Add,
+		Board:  &model.Board{ID: utils.NewID(utils.IDTypeBoard)},
+		Card: &model.Block{
+			ID:    utils.NewID(utils.IDTypeCard),
+			Type:  model.TypeCard,
+			Title: cardTitle,
+		},
+		BlockChanged: &model.Block{
+			ID:    utils.NewID(utils.IDTypeBlock),
+			Type:  model.TypeComment,
+			Title: "@" + user.Username + " " + message,
+		},
+		ModifiedBy: &model.BoardMember{UserID: userID},
+	}
+
+	extract := extractText(evt.BlockChanged.Title, user.Username, newLimits())
+	_, err = b.delivery.MentionDeliver(mentionedUser, extract, evt)
+	return err
+}
+
+// saveMention records the mention so it shows in the mentioned user's inbox.
+func (b *Backend) saveMention(userID string, extract string, evt notify.BlockChangeEvent) error {
+	// the mentions on the board itself aren't on any card
+	cardID := ""
+	if evt.Card != nil {
+		cardID = evt.Card.ID
+	}
+
+	mention := &model.Mention{
+		ID:          utils.NewID(utils.IDTypeNone),
+		UserID:      userID,
+		TeamID:      evt.TeamID,
+		BoardID:     evt.Board.ID,
+		CardID:      cardID,
+		BlockID:     evt.BlockChanged.ID,
+		MentionedBy: evt.ModifiedBy.UserID,
+		Extract:     extract,
+		CreateAt:    model.GetMillis(),
+	}
+	return b.store.InsertMention(mention)
+}
+
 func safeCallListener(listener MentionListener, userID string, evt notify.BlockChangeEvent, logger *mlog.Logger) {
 	// don't let panicky listeners stop notifications
 	defer func() {
@@ -171,67 +288,121 @@ func (b *Backend) deliverMentionNotification(username string, extract string, ev
 		}
 	}
 
-	if evt.ModifiedBy == nil {
-		return "", fmt.Errorf("invalid user cannot mention: %w", ErrMentionPermission)
+	if mentionedUser.DeleteAt != 0 {
+		// deactivated users aren't mentionable.
+		return "", nil
+	}
+
+	autoAdd, err := b.checkMention(mentionedUser, evt.Board, evt.TeamID, evt.ModifiedBy)
+	if err != nil {
+		return "", err
+	}
+
+	if autoAdd {
+		newBoardMember := evt.Board.NewDefaultMember(mentionedUser.Id)
+		member, err := b.store.SaveMember(newBoardMember)
+		if err != nil {
+			return "", fmt.Errorf("cannot add mentioned user %s to board %s: %w", mentionedUser.Id, evt.Board.ID, err)
+		}
+		b.logger.Debug("auto-added mentioned user to board",
+			mlog.String("user_id", mentionedUser.Id),
+			mlog.String("board_id", evt.Board.ID),
+			mlog.String("board_type", string(evt.Board.Type)),
+		)
+		b.wsAdapter.BroadcastMemberChange(evt.TeamID, evt.Board.ID, member)
+	}
+
+	return b.delivery.MentionDeliver(mentionedUser, extract, evt)
+}
+
+// checkMention applies the mention permission rules to a mention of the
+// user by the author, without changing anything. It returns true if the
+// mentioned user has to be added to the board.
+func (b *Backend) checkMention(mentionedUser *mm_model.User, board *model.Board, teamID string, author *model.BoardMember) (bool, error) {
+	if author == nil {
+		return false, fmt.Errorf("invalid user cannot mention: %w", ErrMentionPermission)
 	}
 
-	if evt.Board.Type == model.BoardTypeOpen {
+	if board.Type == model.BoardTypeOpen {
 		// public board rules:
 		//    - admin, editor, commenter: can mention anyone on team (mentioned users are automatically added to board)
 		//    - guest: can mention board members
 		switch {
-		case evt.ModifiedBy.SchemeAdmin, evt.ModifiedBy.SchemeEditor, evt.ModifiedBy.SchemeCommenter:
-			if !b.permissions.HasPermissionToTeam(mentionedUser.Id, evt.TeamID, model.PermissionViewTeam) {
-				return "", fmt.Errorf("%s cannot mention non-team member %s : %w", evt.ModifiedBy.UserID, mentionedUser.Id, ErrMentionPermission)
-			}
-			// add mentioned user to board (if not already a member)
-			member, err := b.store.GetMemberForBoard(evt.Board.ID, mentionedUser.Id)
-			if member == nil || b.store.IsErrNotFound(err) {
-				// currently all memberships are created as editors by default
-				newBoardMember := &model.BoardMember{
-					UserID:       mentionedUser.Id,
-					BoardID:      evt.Board.ID,
-					SchemeEditor: true,
-				}
-				if member, err = b.store.SaveMember(newBoardMember); err != nil {
-					return "", fmt.Errorf("cannot add mentioned user %s to board %s: %w", mentionedUser.Id, evt.Board.ID, err)
-				}
-				b.logger.Debug("auto-added mentioned user to board",
-					mlog.String("user_id", mentionedUser.Id),
-					mlog.String("board_id", evt.Board.ID),
-					mlog.String("board_type", string(evt.Board.Type)),
-				)
-				b.wsAdapter.BroadcastMemberChange(evt.TeamID, evt.Board.ID, member)
-			} else {
-				b.logger.Debug("skipping auto-add mentioned user to board; already a member",
-					mlog.String("user_id", mentionedUser.Id),
-					mlog.String("board_id", evt.Board.ID),
-					mlog.String("board_type", string(evt.Board.Type)),
-				)
+		case author.SchemeAdmin, author.SchemeEditor, author.SchemeCommenter:
+			if !b.permissions.HasPermissionToTeam(mentionedUser.Id, teamID, model.PermissionViewTeam) {
+				return false, fmt.Errorf("%s cannot mention non-team member %s : %w", author.UserID, mentionedUser.Id, ErrMentionPermission)
 			}
-		case evt.ModifiedBy.SchemeViewer:
+			// the mentioned user is added to board if not already a member
+			member, err := b.store.GetMemberForBoard(board.ID, mentionedUser.Id)
+			return member == nil || b.store.IsErrNotFound(err), nil
+		case author.SchemeViewer:
 			// viewer should not have gotten this far since they cannot add text to a card
-			return "", fmt.Errorf("%s (viewer) cannot mention user %s: %w", evt.ModifiedBy.UserID, mentionedUser.Id, ErrMentionPermission)
+			return false, fmt.Errorf("%s (viewer) cannot mention user %s: %w", author.UserID, mentionedUser.Id, ErrMentionPermission)
 		default:
 			// this is a guest
-			if !b.permissions.HasPermissionToBoard(mentionedUser.Id, evt.Board.ID, model.PermissionViewBoard) {
-				return "", fmt.Errorf("%s cannot mention non-board member %s : %w", evt.ModifiedBy.UserID, mentionedUser.Id, ErrMentionPermission)
+			if !b.permissions.HasPermissionToBoard(mentionedUser.Id, board.ID, model.PermissionViewBoard) {
+				return false, fmt.Errorf("%s cannot mention non-board member %s : %w", author.UserID, mentionedUser.Id, ErrMentionPermission)
 			}
 		}
 	} else {
 		// private board rules:
 		//    - admin, editor, commenter, guest: can mention board members
 		switch {
-		case evt.ModifiedBy.SchemeViewer:
+		case author.SchemeViewer:
 			// viewer should not have gotten this far since they cannot add text to a card
-			return "", fmt.Errorf("%s (viewer) cannot mention user %s: %w", evt.ModifiedBy.UserID, mentionedUser.Id, ErrMentionPermission)
+			return false, fmt.Errorf("%s (viewer) cannot mention user %s: %w", author.UserID, mentionedUser.Id, ErrMentionPermission)
 		default:
 			// everyone else can mention board members
-			if !b.permissions.HasPermissionToBoard(mentionedUser.Id, evt.Board.ID, model.PermissionViewBoard) {
-				return "", fmt.Errorf("%s cannot mention non-board member %s : %w", evt.ModifiedBy.UserID, mentionedUser.Id, ErrMentionPermission)
+			if !b.permissions.HasPermissionToBoard(mentionedUser.Id, board.ID, model.PermissionViewBoard) {
+				return false, fmt.Errorf("%s cannot mention non-board member %s : %w", author.UserID, mentionedUser.Id, ErrMentionPermission)
 			}
 		}
 	}
+	return false, nil
+}
 
-	return b.delivery.MentionDeliver(mentionedUser, extract, evt)
+// ValidateMentions reports the outcome each mention of the text would
+// have if the author saved it on the board, without adding or notifying
+// anyone.
+func (b *Backend) ValidateMentions(text string, board *model.Board, author *model.BoardMember) ([]*model.MentionValidation, error) {
+	mentions := extractMentionsFromText(text)
+	usernames := make([]string, 0, len(mentions))
+	for username := range mentions {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	results := make([]*model.MentionValidation, 0, len(usernames))
+	for _, username := range usernames {
+		result := &model.MentionValidation{Username: username}
+		results = append(results, result)
+
+		mentionedUser, err := b.delivery.UserByUsername(username)
+		if err != nil {
+			if b.delivery.IsErrNotFound(err) {
+				result.Status = model.MentionStatusUnknown
+				continue
+			}
+			return nil, fmt.Errorf("cannot lookup mentioned user: %w", err)
+		}
+		if mentionedUser.DeleteAt != 0 {
+			result.Status = model.MentionStatusUnknown
+			continue
+		}
+		result.UserID = mentionedUser.Id
+
+		autoAdd, err := b.checkMention(mentionedUser, board, board.TeamID, author)
+		switch {
+		case errors.Is(err, ErrMentionPermission):
+			result.Status = model.MentionStatusRejected
+			result.Reason = err.Error()
+		case err != nil:
+			return nil, err
+		case autoAdd:
+			result.Status = model.MentionStatusAdded
+		default:
+			result.Status = model.MentionStatusResolved
+		}
+	}
+	return results, nil
 }
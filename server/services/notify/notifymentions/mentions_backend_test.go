@@ -0,0 +1,232 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifymentions
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type testStore struct {
+	Store
+	members  map[string]*model.BoardMember
+	saved    []*model.BoardMember
+	mentions []*model.Mention
+}
+
+func (s *testStore) InsertMention(mention *model.Mention) error {
+	s.mentions = append(s.mentions, mention)
+	return nil
+}
+
+func (s *testStore) GetMemberForBoard(boardID, userID string) (*model.BoardMember, error) {
+	if member, ok := s.members[userID]; ok {
+		return member, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s *testStore) SaveMember(bm *model.BoardMember) (*model.BoardMember, error) {
+	s.saved = append(s.saved, bm)
+	return bm, nil
+}
+
+func (s *testStore) IsErrNotFound(err error) bool {
+	return errors.Is(err, sql.ErrNoRows)
+}
+
+type testPermissions struct {
+	teamMembers  map[string]bool
+	boardMembers map[string]bool
+}
+
+func (p *testPermissions) HasPermissionTo(string, *mm_model.Permission) bool {
+	return false
+}
+
+func (p *testPermissions) HasPermissionToTeam(userID, _ string, _ *mm_model.Permission) bool {
+	return p.teamMembers[userID]
+}
+
+func (p *testPermissions) HasPermissionToBoard(userID, _ string, _ *mm_model.Permission) bool {
+	return p.boardMembers[userID]
+}
+
+func (p *testPermissions) HasPermissionToChannel(string, string, *mm_model.Permission) bool {
+	return false
+}
+
+type testDelivery struct {
+	users     map[string]*mm_model.User
+	delivered []string
+}
+
+func (d *testDelivery) MentionDeliver(mentionedUser *mm_model.User, _ string, _ notify.BlockChangeEvent) (string, error) {
+	d.delivered = append(d.delivered, mentionedUser.Id)
+	return mentionedUser.Id, nil
+}
+
+func (d *testDelivery) UserByUsername(username string) (*mm_model.User, error) {
+	if user, ok := d.users[username]; ok {
+		return user, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (d *testDelivery) IsErrNotFound(err error) bool {
+	return errors.Is(err, sql.ErrNoRows)
+}
+
+func TestValidateMentions(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() { _ = logger.Shutdown() }()
+
+	st := &testStore{members: map[string]*model.BoardMember{"member-id": {UserID: "member-id"}}}
+	delivery := &testDelivery{users: map[string]*mm_model.User{
+		"member":      {Id: "member-id", Username: "member"},
+		"teammate":    {Id: "teammate-id", Username: "teammate"},
+		"stranger":    {Id: "stranger-id", Username: "stranger"},
+		"deactivated": {Id: "deactivated-id", Username: "deactivated", DeleteAt: 1},
+	}}
+	perms := &testPermissions{
+		teamMembers:  map[string]bool{"member-id": true, "teammate-id": true},
+		boardMembers: map[string]bool{"member-id": true},
+	}
+	b := New(BackendParams{Store: st, Permissions: perms, Delivery: delivery, Logger: logger})
+
+	text := "@member @teammate @stranger @deactivated @nobody"
+	statuses := func(results []*model.MentionValidation) map[string]string {
+		m := map[string]string{}
+		for _, result := range results {
+			m[result.Username] = result.Status
+		}
+		return m
+	}
+
+	t.Run("editor on an open board", func(t *testing.T) {
+		board := &model.Board{ID: "board-id", TeamID: "team-id", Type: model.BoardTypeOpen}
+		results, err := b.ValidateMentions(text, board, &model.BoardMember{UserID: "author-id", SchemeEditor: true})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"member":      model.MentionStatusResolved,
+			"teammate":    model.MentionStatusAdded,
+			"stranger":    model.MentionStatusRejected,
+			"deactivated": model.MentionStatusUnknown,
+			"nobody":      model.MentionStatusUnknown,
+		}, statuses(results))
+	})
+
+	t.Run("editor on a private board", func(t *testing.T) {
+		board := &model.Board{ID: "board-id", TeamID: "team-id", Type: model.BoardTypePrivate}
+		results, err := b.ValidateMentions(text, board, &model.BoardMember{UserID: "author-id", SchemeEditor: true})
+		require.NoError(t, err)
+		require.Equal(t, model.MentionStatusResolved, statuses(results)["member"])
+		require.Equal(t, model.MentionStatusRejected, statuses(results)["teammate"])
+	})
+
+	t.Run("nothing is added or delivered", func(t *testing.T) {
+		require.Empty(t, st.saved)
+		require.Empty(t, delivery.delivered)
+	})
+}
+
+func TestBoardLevelMentions(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() { _ = logger.Shutdown() }()
+
+	newBackend := func() (*Backend, *testStore, *testDelivery) {
+		st := &testStore{members: map[string]*model.BoardMember{"member-id": {UserID: "member-id"}}}
+		delivery := &testDelivery{users: map[string]*mm_model.User{
+			"member":   {Id: "member-id", Username: "member"},
+			"teammate": {Id: "teammate-id", Username: "teammate"},
+		}}
+		perms := &testPermissions{
+			teamMembers:  map[string]bool{"member-id": true, "teammate-id": true},
+			boardMembers: map[string]bool{"member-id": true},
+		}
+		return New(BackendParams{Store: st, Permissions: perms, Delivery: delivery, Logger: logger}), st, delivery
+	}
+
+	board := &model.Board{ID: "board-id", TeamID: "team-id", Type: model.BoardTypePrivate, Description: "cc @member @teammate"}
+	author := &model.BoardMember{UserID: "author-id", SchemeEditor: true}
+
+	t.Run("description mentions the new users with the board rules", func(t *testing.T) {
+		b, st, delivery := newBackend()
+		err := b.BoardChanged(notify.BoardChangeEvent{
+			Action:     notify.Update,
+			TeamID:     "team-id",
+			Board:      board,
+			BoardOld:   &model.Board{ID: "board-id", Description: "old text"},
+			ModifiedBy: author,
+		})
+
+		// the teammate isn't a member of the private board
+		requireMentionPermissionError(t, err)
+		require.Equal(t, []string{"member-id"}, delivery.delivered)
+		require.Len(t, st.mentions, 1)
+		require.Equal(t, "board-id", st.mentions[0].BoardID)
+		require.Empty(t, st.mentions[0].CardID)
+	})
+
+	t.Run("unchanged mentions in the description aren't notified again", func(t *testing.T) {
+		b, st, delivery := newBackend()
+		err := b.BoardChanged(notify.BoardChangeEvent{
+			Action:     notify.Update,
+			TeamID:     "team-id",
+			Board:      board,
+			BoardOld:   &model.Board{ID: "board-id", Description: "@member"},
+			ModifiedBy: author,
+		})
+
+		// only the teammate is new, and can't be mentioned
+		requireMentionPermissionError(t, err)
+		require.Empty(t, delivery.delivered)
+		require.Empty(t, st.mentions)
+	})
+
+	t.Run("text block of the board", func(t *testing.T) {
+		b, _, delivery := newBackend()
+		err := b.BlockChanged(notify.BlockChangeEvent{
+			Action:       notify.Add,
+			TeamID:       "team-id",
+			Board:        board,
+			BlockChanged: &model.Block{ID: "block-id", ParentID: "board-id", BoardID: "board-id", Type: model.TypeText, Title: "welcome @member"},
+			ModifiedBy:   author,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"member-id"}, delivery.delivered)
+	})
+
+	t.Run("text block outside of cards and the board", func(t *testing.T) {
+		b, _, delivery := newBackend()
+		err := b.BlockChanged(notify.BlockChangeEvent{
+			Action:       notify.Add,
+			TeamID:       "team-id",
+			Board:        board,
+			BlockChanged: &model.Block{ID: "block-id", ParentID: "view-id", BoardID: "board-id", Type: model.TypeText, Title: "@member"},
+			ModifiedBy:   author,
+		})
+		require.NoError(t, err)
+		require.Empty(t, delivery.delivered)
+	})
+}
+
+// requireMentionPermissionError checks that the only error collected by
+// the backend is a mention permission error.
+func requireMentionPermissionError(t *testing.T, err error) {
+	var merr *merror.MError
+	require.ErrorAs(t, err, &merr)
+	require.Len(t, merr.Errors(), 1)
+	require.ErrorIs(t, merr.Errors()[0], ErrMentionPermission)
+}
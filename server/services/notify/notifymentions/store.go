@@ -12,5 +12,7 @@ type Store interface {
 
 	CreateSubscription(sub *model.Subscription) (*model.Subscription, error)
 
+	InsertMention(mention *model.Mention) error
+
 	IsErrNotFound(err error) bool
 }
@@ -0,0 +1,15 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyplugins
+
+import (
+	"net/http"
+)
+
+// PluginAPI sends requests to other plugins.
+type PluginAPI interface {
+	// PluginHTTP sends an inter-plugin request. The path of the request
+	// starts with the ID of the destination plugin.
+	PluginHTTP(request *http.Request) *http.Response
+}
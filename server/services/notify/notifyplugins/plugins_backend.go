@@ -0,0 +1,187 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyplugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	backendName = "notifyPlugins"
+)
+
+type BackendParams struct {
+	Store  SubscriptionStore
+	API    PluginAPI
+	Logger *mlog.Logger
+}
+
+// Backend delivers the board events to the plugins subscribed to them,
+// so they can react to board changes without polling the REST API.
+type Backend struct {
+	store  SubscriptionStore
+	api    PluginAPI
+	logger *mlog.Logger
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		store:  params.Store,
+		api:    params.API,
+		logger: params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	if evt.Board == nil || evt.Board.IsTemplate || evt.BlockChanged == nil || evt.BlockChanged.Type != model.TypeCard {
+		return nil
+	}
+
+	event := &model.PluginEvent{
+		TeamID:   evt.TeamID,
+		BoardID:  evt.Board.ID,
+		UserID:   evt.BlockChanged.ModifiedBy,
+		Card:     evt.BlockChanged,
+		CreateAt: utils.GetMillis(),
+	}
+
+	switch evt.Action {
+	case notify.Add:
+		event.Type = model.PluginEventCardCreated
+	case notify.Update:
+		changed := changedProperties(evt.BlockOld, evt.BlockChanged)
+		if len(changed) == 0 {
+			return nil
+		}
+		event.Type = model.PluginEventCardPropertyChanged
+		event.ChangedProperties = changed
+	default:
+		return nil
+	}
+
+	return b.deliver(event)
+}
+
+func (b *Backend) MemberAdded(evt notify.MemberChangeEvent) error {
+	if evt.Board.IsTemplate {
+		return nil
+	}
+
+	return b.deliver(&model.PluginEvent{
+		Type:     model.PluginEventMemberAdded,
+		TeamID:   evt.TeamID,
+		BoardID:  evt.Board.ID,
+		UserID:   evt.Member.UserID,
+		Member:   evt.Member,
+		CreateAt: utils.GetMillis(),
+	})
+}
+
+// deliver sends the event to every plugin subscribed to its type.
+func (b *Backend) deliver(event *model.PluginEvent) error {
+	subscriptions, err := b.store.GetPluginEventSubscriptions()
+	if err != nil {
+		return fmt.Errorf("cannot get plugin event subscriptions: %w", err)
+	}
+
+	var body []byte
+	merr := merror.New()
+	for _, subscription := range subscriptions {
+		if !subscription.Wants(event.Type) {
+			continue
+		}
+
+		if body == nil {
+			if body, err = json.Marshal(event); err != nil {
+				return fmt.Errorf("cannot marshal plugin event: %w", err)
+			}
+		}
+
+		if err := b.post(subscription, body); err != nil {
+			merr.Append(fmt.Errorf("cannot deliver %s event to plugin %s: %w", event.Type, subscription.PluginID, err))
+			continue
+		}
+
+		b.logger.Debug("Plugin event delivered",
+			mlog.String("plugin_id", subscription.PluginID),
+			mlog.String("event", string(event.Type)),
+			mlog.String("board_id", event.BoardID),
+		)
+	}
+	return merr.ErrorOrNil()
+}
+
+func (b *Backend) post(subscription *model.PluginEventSubscription, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, "/"+subscription.PluginID+subscription.CallbackPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := b.api.PluginHTTP(req)
+	if resp == nil {
+		return fmt.Errorf("no response from plugin")
+	}
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("plugin responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// changedProperties returns the IDs of the card properties with a
+// different value on each version of the card, sorted.
+func changedProperties(oldCard, newCard *model.Block) []string {
+	oldProps := cardProperties(oldCard)
+	newProps := cardProperties(newCard)
+
+	changed := []string{}
+	for id, value := range newProps {
+		if oldValue, ok := oldProps[id]; !ok || !reflect.DeepEqual(oldValue, value) {
+			changed = append(changed, id)
+		}
+	}
+	for id := range oldProps {
+		if _, ok := newProps[id]; !ok {
+			changed = append(changed, id)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func cardProperties(card *model.Block) map[string]interface{} {
+	if card == nil {
+		return map[string]interface{}{}
+	}
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	return props
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyplugins
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type fakeStore struct {
+	subscriptions []*model.PluginEventSubscription
+}
+
+func (s *fakeStore) GetPluginEventSubscriptions() ([]*model.PluginEventSubscription, error) {
+	return s.subscriptions, nil
+}
+
+type fakeAPI struct {
+	paths  []string
+	events []*model.PluginEvent
+}
+
+func (a *fakeAPI) PluginHTTP(request *http.Request) *http.Response {
+	a.paths = append(a.paths, request.URL.Path)
+	body, _ := ioutil.ReadAll(request.Body)
+	var event *model.PluginEvent
+	_ = json.Unmarshal(body, &event)
+	a.events = append(a.events, event)
+	return &http.Response{StatusCode: http.StatusOK}
+}
+
+func makeCard(props map[string]interface{}) *model.Block {
+	return &model.Block{
+		ID:     "card-id",
+		Type:   model.TypeCard,
+		Fields: map[string]interface{}{"properties": props},
+	}
+}
+
+func Test_changedProperties(t *testing.T) {
+	oldCard := makeCard(map[string]interface{}{"status": "todo", "tags": []interface{}{"a"}, "notes": "x"})
+	newCard := makeCard(map[string]interface{}{"status": "done", "tags": []interface{}{"a"}, "owner": "user-1"})
+
+	require.Equal(t, []string{"notes", "owner", "status"}, changedProperties(oldCard, newCard))
+	require.Empty(t, changedProperties(oldCard, oldCard))
+	require.Equal(t, []string{"status"}, changedProperties(nil, makeCard(map[string]interface{}{"status": "todo"})))
+}
+
+func TestBackend(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(true, mlog.LvlError)
+	store := &fakeStore{subscriptions: []*model.PluginEventSubscription{
+		{PluginID: "playbooks", CallbackPath: "/boards/events"},
+		{PluginID: "other", CallbackPath: "/events", Events: []model.PluginEventType{model.PluginEventMemberAdded}},
+	}}
+	api := &fakeAPI{}
+	backend := New(BackendParams{Store: store, API: api, Logger: logger})
+	board := &model.Board{ID: "board-id", TeamID: "team-id"}
+
+	t.Run("card created", func(t *testing.T) {
+		api.paths, api.events = nil, nil
+		err := backend.BlockChanged(notify.BlockChangeEvent{
+			Action:       notify.Add,
+			TeamID:       "team-id",
+			Board:        board,
+			BlockChanged: makeCard(nil),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"/playbooks/boards/events"}, api.paths)
+		require.Equal(t, model.PluginEventCardCreated, api.events[0].Type)
+		require.Equal(t, "card-id", api.events[0].Card.ID)
+	})
+
+	t.Run("updates without property changes are skipped", func(t *testing.T) {
+		api.paths, api.events = nil, nil
+		card := makeCard(map[string]interface{}{"status": "todo"})
+		err := backend.BlockChanged(notify.BlockChangeEvent{
+			Action:       notify.Update,
+			Board:        board,
+			BlockChanged: card,
+			BlockOld:     card,
+		})
+		require.NoError(t, err)
+		require.Empty(t, api.paths)
+	})
+
+	t.Run("member added", func(t *testing.T) {
+		api.paths, api.events = nil, nil
+		err := backend.MemberAdded(notify.MemberChangeEvent{
+			TeamID: "team-id",
+			Board:  board,
+			Member: &model.BoardMember{BoardID: "board-id", UserID: "user-id"},
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"/playbooks/boards/events", "/other/events"}, api.paths)
+		require.Equal(t, "user-id", api.events[0].UserID)
+	})
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyplugins
+
+import "github.com/mattermost/focalboard/server/model"
+
+// SubscriptionStore provides the subscriptions of the plugins to the
+// board events.
+type SubscriptionStore interface {
+	GetPluginEventSubscriptions() ([]*model.PluginEventSubscription, error)
+}
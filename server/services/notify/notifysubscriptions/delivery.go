@@ -14,4 +14,7 @@ import (
 type SubscriptionDelivery interface {
 	SubscriptionDeliverSlackAttachments(subscriberID string, subscriberType model.SubscriberType,
 		attachments []*mm_model.SlackAttachment) error
+
+	// SubscriberLocale returns the locale the subscriber's notifications should be written in.
+	SubscriberLocale(subscriberID string, subscriberType model.SubscriberType) string
 }
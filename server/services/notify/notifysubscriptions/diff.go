@@ -26,6 +26,7 @@ type Diff struct {
 
 	schemaDiffs []SchemaDiff
 	PropDiffs   []PropDiff
+	StatusDiff  *PropDiff // change of the status property of a card, if any
 
 	Diffs []*Diff // Diffs for child blocks
 }
@@ -287,6 +288,9 @@ func (dg *diffGenerator) generateDiffForBlock(newBlock *model.Block, schema mode
 		PropDiffs:   propDiffs,
 		schemaDiffs: nil,
 	}
+	if newBlock.Type == model.TypeCard {
+		diff.StatusDiff = findStatusDiff(propDiffs, dg.board, schema)
+	}
 	return diff, nil
 }
 
@@ -362,3 +366,18 @@ func sortPropDiffs(propDiffs []PropDiff) []PropDiff {
 	})
 	return propDiffs
 }
+
+// findStatusDiff returns the change of the board's status property among
+// the property diffs, or nil if the status didn't change.
+func findStatusDiff(propDiffs []PropDiff, board *model.Board, schema model.PropSchema) *PropDiff {
+	def, ok := schema.StatusProperty(board)
+	if !ok {
+		return nil
+	}
+	for i := range propDiffs {
+		if propDiffs[i].ID == def.ID && propDiffs[i].OldValue != propDiffs[i].NewValue {
+			return &propDiffs[i]
+		}
+	}
+	return nil
+}
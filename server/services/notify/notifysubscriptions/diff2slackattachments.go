@@ -12,6 +12,7 @@ import (
 	"text/template"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/wiggin77/merror"
 
 	mm_model "github.com/mattermost/mattermost-server/v6/model"
@@ -20,9 +21,18 @@ import (
 
 const (
 	// card change notifications.
-	defAddCardNotify    = "{{.Authors | printAuthors \"unknown_user\" }} has added the card {{. | makeLink}}\n"
-	defModifyCardNotify = "###### {{.Authors | printAuthors \"unknown_user\" }} has modified the card {{. | makeLink}}\n"
-	defDeleteCardNotify = "{{.Authors | printAuthors \"unknown_user\" }} has deleted the card {{. | makeLink}}\n"
+	defAddCardNotify    = "{{.Authors | printAuthors unknownUser }} has added the card {{. | makeLink}}\n"
+	defModifyCardNotify = "###### {{.Authors | printAuthors unknownUser }} has modified the card {{. | makeLink}}\n"
+	defDeleteCardNotify = "{{.Authors | printAuthors unknownUser }} has deleted the card {{. | makeLink}}\n"
+
+	// card field titles.
+	defTitleField       = "Title"
+	defDescriptionField = "Description"
+	defCommentByField   = "Comment by {{.Authors}}"
+	defStatusField      = "{{.Name}} changed"
+	defStatusChange     = "{{if .OldValue}}`{{.OldValue}}`{{else}}_(empty)_{{end}} → {{if .NewValue}}`{{.NewValue}}`{{else}}_(empty)_{{end}}"
+
+	defUnknownUser = "unknown_user"
 )
 
 var (
@@ -34,6 +44,7 @@ var (
 // DiffConvOpts provides options when converting diffs to slack attachments.
 type DiffConvOpts struct {
 	Language     string
+	Bundle       *notify.Bundle
 	MakeCardLink func(block *model.Block, board *model.Board, card *model.Block) string
 	Logger       *mlog.Logger
 }
@@ -64,10 +75,13 @@ func getTemplate(name string, opts DiffConvOpts, def string) (*template.Template
 			"printAuthors": func(empty string, authors StringMap) string {
 				return makeAuthorsList(authors, empty)
 			},
+			"unknownUser": func() string {
+				return unknownUser(opts)
+			},
 		}
 		t.Funcs(myFuncs)
 
-		s := def // TODO: lookup i18n string when supported on server
+		s := opts.Bundle.Lookup(opts.Language, "notify.subscription."+name, def)
 		t2, err := t.Parse(s)
 		if err != nil {
 			return nil, fmt.Errorf("cannot parse markdown template '%s' for notifications: %w", key, err)
@@ -77,6 +91,15 @@ func getTemplate(name string, opts DiffConvOpts, def string) (*template.Template
 	return t, nil
 }
 
+// translate returns the message for the ID in the language specified by the options.
+func translate(opts DiffConvOpts, id string, def string, data interface{}) string {
+	return opts.Bundle.T(opts.Language, id, def, data)
+}
+
+func unknownUser(opts DiffConvOpts) string {
+	return translate(opts, "notify.unknown_user", defUnknownUser, nil)
+}
+
 func makeAuthorsList(authors StringMap, empty string) string {
 	if len(authors) == 0 {
 		return empty
@@ -134,7 +157,7 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 
 	// card added
 	if cardDiff.NewBlock != nil && cardDiff.OldBlock == nil {
-		if err := execTemplate(buf, "AddCardNotify", opts, defAddCardNotify, cardDiff); err != nil {
+		if err := execTemplate(buf, "card_added", opts, defAddCardNotify, cardDiff); err != nil {
 			return nil, err
 		}
 		attachment.Pretext = buf.String()
@@ -145,7 +168,7 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 	// card deleted
 	if (cardDiff.NewBlock == nil || cardDiff.NewBlock.DeleteAt != 0) && cardDiff.OldBlock != nil {
 		buf.Reset()
-		if err := execTemplate(buf, "DeleteCardNotify", opts, defDeleteCardNotify, cardDiff); err != nil {
+		if err := execTemplate(buf, "card_deleted", opts, defDeleteCardNotify, cardDiff); err != nil {
 			return nil, err
 		}
 		attachment.Pretext = buf.String()
@@ -164,17 +187,29 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 	)
 
 	buf.Reset()
-	if err := execTemplate(buf, "ModifyCardNotify", opts, defModifyCardNotify, cardDiff); err != nil {
+	if err := execTemplate(buf, "card_modified", opts, defModifyCardNotify, cardDiff); err != nil {
 		return nil, fmt.Errorf("cannot write notification for card %s: %w", cardDiff.NewBlock.ID, err)
 	}
 	attachment.Pretext = buf.String()
 	attachment.Fallback = attachment.Pretext
 
+	// status changes go first so they stand out
+	if cardDiff.StatusDiff != nil {
+		statusDiff := *cardDiff.StatusDiff
+		statusDiff.OldValue = stripNewlines(statusDiff.OldValue)
+		statusDiff.NewValue = stripNewlines(statusDiff.NewValue)
+		attachment.Fields = append(attachment.Fields, &mm_model.SlackAttachmentField{
+			Short: false,
+			Title: translate(opts, "notify.subscription.field_status", defStatusField, statusDiff),
+			Value: translate(opts, "notify.subscription.status_change", defStatusChange, statusDiff),
+		})
+	}
+
 	// title changes
 	if cardDiff.NewBlock.Title != cardDiff.OldBlock.Title {
 		attachment.Fields = append(attachment.Fields, &mm_model.SlackAttachmentField{
 			Short: false,
-			Title: "Title",
+			Title: translate(opts, "notify.subscription.field_title", defTitleField, nil),
 			Value: fmt.Sprintf("%s  ~~`%s`~~", stripNewlines(cardDiff.NewBlock.Title), stripNewlines(cardDiff.OldBlock.Title)),
 		})
 	}
@@ -185,6 +220,9 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 			if propDiff.NewValue == propDiff.OldValue {
 				continue
 			}
+			if cardDiff.StatusDiff != nil && propDiff.ID == cardDiff.StatusDiff.ID {
+				continue
+			}
 
 			var val string
 			if propDiff.OldValue != "" {
@@ -221,7 +259,9 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 			if format != "" {
 				attachment.Fields = append(attachment.Fields, &mm_model.SlackAttachmentField{
 					Short: false,
-					Title: "Comment by " + makeAuthorsList(child.Authors, "unknown_user"), // todo:  localize this when server has i18n
+					Title: translate(opts, "notify.subscription.field_comment_by", defCommentByField, map[string]string{
+						"Authors": makeAuthorsList(child.Authors, unknownUser(opts)),
+					}),
 					Value: fmt.Sprintf(format, stripNewlines(block.Title)),
 				})
 			}
@@ -250,7 +290,7 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 
 			attachment.Fields = append(attachment.Fields, &mm_model.SlackAttachmentField{
 				Short: false,
-				Title: "Description",
+				Title: translate(opts, "notify.subscription.field_description", defDescriptionField, nil),
 				Value: markdown,
 			})
 		}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifysubscriptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func Test_findStatusDiff(t *testing.T) {
+	schema := model.PropSchema{
+		"status":   {ID: "status", Index: 0, Name: "Status", Type: "select"},
+		"priority": {ID: "priority", Index: 1, Name: "Priority", Type: "select"},
+	}
+	propDiffs := []PropDiff{
+		{ID: "status", Name: "Status", OldValue: "To Do", NewValue: "Done"},
+		{ID: "priority", Name: "Priority", OldValue: "Low", NewValue: "High"},
+	}
+
+	t.Run("default status property", func(t *testing.T) {
+		statusDiff := findStatusDiff(propDiffs, &model.Board{}, schema)
+		require.NotNil(t, statusDiff)
+		assert.Equal(t, "status", statusDiff.ID)
+	})
+
+	t.Run("designated status property", func(t *testing.T) {
+		board := &model.Board{Properties: map[string]interface{}{model.BoardPropertyStatusProperty: "priority"}}
+		statusDiff := findStatusDiff(propDiffs, board, schema)
+		require.NotNil(t, statusDiff)
+		assert.Equal(t, "priority", statusDiff.ID)
+	})
+
+	t.Run("status unchanged", func(t *testing.T) {
+		assert.Nil(t, findStatusDiff(propDiffs[1:], &model.Board{}, schema))
+	})
+}
+
+func Test_cardDiff2SlackAttachmentStatus(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	opts := DiffConvOpts{Language: "en", Logger: logger}
+
+	card := &model.Block{ID: "card-id", Type: model.TypeCard, Title: "card"}
+	statusDiff := PropDiff{ID: "status", Index: 0, Name: "Status", OldValue: "To Do", NewValue: "Done"}
+	cardDiff := &Diff{
+		Board:     &model.Board{ID: "board-id"},
+		Card:      card,
+		Authors:   StringMap{},
+		BlockType: model.TypeCard,
+		OldBlock:  card,
+		NewBlock:  card,
+		PropDiffs: []PropDiff{
+			{ID: "priority", Index: 1, Name: "Priority", OldValue: "Low", NewValue: "High"},
+			statusDiff,
+		},
+		StatusDiff: &statusDiff,
+	}
+
+	attachment, err := cardDiff2SlackAttachment(cardDiff, opts)
+	require.NoError(t, err)
+	require.NotNil(t, attachment)
+	require.Len(t, attachment.Fields, 2)
+
+	assert.Equal(t, "Status changed", attachment.Fields[0].Title)
+	assert.Equal(t, "`To Do` → `Done`", attachment.Fields[0].Value)
+	assert.Equal(t, "Priority", attachment.Fields[1].Title)
+
+	t.Run("empty values", func(t *testing.T) {
+		cleared := PropDiff{ID: "status", Name: "Status", OldValue: "Done"}
+		cardDiff.PropDiffs = []PropDiff{cleared}
+		cardDiff.StatusDiff = &cleared
+
+		attachment, err := cardDiff2SlackAttachment(cardDiff, opts)
+		require.NoError(t, err)
+		require.Len(t, attachment.Fields, 1)
+		assert.Equal(t, "`Done` → _(empty)_", attachment.Fields[0].Value)
+	})
+}
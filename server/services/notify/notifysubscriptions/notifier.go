@@ -10,11 +10,13 @@ import (
 	"time"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/permissions"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/utils"
 	"github.com/wiggin77/merror"
 
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
@@ -36,6 +38,7 @@ type notifier struct {
 	store       Store
 	permissions permissions.PermissionsService
 	delivery    SubscriptionDelivery
+	router      *notify.Router
 	logger      *mlog.Logger
 
 	hints chan *model.NotificationHint
@@ -50,6 +53,7 @@ func newNotifier(params BackendParams) *notifier {
 		store:       params.Store,
 		permissions: params.Permissions,
 		delivery:    params.Delivery,
+		router:      params.Router,
 		logger:      params.Logger,
 		done:        nil,
 		hints:       make(chan *model.NotificationHint, hintQueueSize),
@@ -146,6 +150,26 @@ func (n *notifier) notify() {
 	}
 }
 
+// claimChanges claims the subscription notification of the user for the
+// changes of the card by each author, returning false if more specific
+// notifications were sent for all of them. Without a router, the
+// notifications are always sent.
+func (n *notifier) claimChanges(userID string, cardID string, authors StringMap) bool {
+	if n.router == nil {
+		return true
+	}
+	claimed := false
+	for authorID := range authors {
+		if authorID == userID {
+			continue
+		}
+		if n.router.ClaimChange(userID, cardID, authorID, notify.EventSubscription) {
+			claimed = true
+		}
+	}
+	return claimed
+}
+
 func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 	// 	get the subscriber list
 	subs, err := n.store.GetSubscribersForBlock(hint.BlockID)
@@ -200,15 +224,35 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 		diffAuthors.Append(d.Authors)
 	}
 
-	opts := DiffConvOpts{
-		Language: "en", // TODO: use correct language with i18n available on server.
-		MakeCardLink: func(block *model.Block, board *model.Board, card *model.Block) string {
-			return fmt.Sprintf("[%s](%s)", block.Title, utils.MakeCardLink(n.serverRoot, board.TeamID, board.ID, card.ID))
-		},
-		Logger: n.logger,
+	bundle, err := notify.GetBundle()
+	if err != nil {
+		n.logger.Warn("notifySubscribers - cannot load translations", mlog.Err(err))
 	}
 
-	attachments, err := Diffs2SlackAttachments(diffs, opts)
+	// attachments are generated once per locale.
+	attachmentsByLocale := make(map[string][]*mm_model.SlackAttachment)
+	getAttachments := func(locale string) ([]*mm_model.SlackAttachment, error) {
+		if attachments, ok := attachmentsByLocale[locale]; ok {
+			return attachments, nil
+		}
+		opts := DiffConvOpts{
+			Language: locale,
+			Bundle:   bundle,
+			MakeCardLink: func(block *model.Block, board *model.Board, card *model.Block) string {
+				return fmt.Sprintf("[%s](%s)", block.Title, utils.MakeCardLink(n.serverRoot, board.TeamID, board.ID, card.ID))
+			},
+			Logger: n.logger,
+		}
+		attachments, err := Diffs2SlackAttachments(diffs, opts)
+		if err != nil {
+			return nil, err
+		}
+		attachmentsByLocale[locale] = attachments
+		return attachments, nil
+	}
+
+	// the default locale is used to detect if there is anything to deliver.
+	attachments, err := getAttachments(notify.DefaultLocale)
 	if err != nil {
 		return err
 	}
@@ -237,14 +281,33 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 				continue
 			}
 
+			// users already sent a more specific notification for all the changes,
+			// such as a mention, don't get the subscription notification too.
+			if sub.SubscriberType == model.SubTypeUser && !n.claimChanges(sub.SubscriberID, card.ID, diffAuthors) {
+				n.logger.Debug("notifySubscribers - skipping notified subscriber",
+					mlog.Any("hint", hint),
+					mlog.String("subscriber_id", sub.SubscriberID),
+				)
+				continue
+			}
+
+			locale := n.delivery.SubscriberLocale(sub.SubscriberID, sub.SubscriberType)
+			subAttachments, err := getAttachments(locale)
+			if err != nil {
+				merr.Append(fmt.Errorf("cannot generate notification for subscriber %s [%s]: %w",
+					sub.SubscriberID, sub.SubscriberType, err))
+				continue
+			}
+
 			n.logger.Debug("notifySubscribers - deliver",
 				mlog.Any("hint", hint),
 				mlog.String("modified_by_id", hint.ModifiedByID),
 				mlog.String("subscriber_id", sub.SubscriberID),
 				mlog.String("subscriber_type", string(sub.SubscriberType)),
+				mlog.String("locale", locale),
 			)
 
-			if err = n.delivery.SubscriptionDeliverSlackAttachments(sub.SubscriberID, sub.SubscriberType, attachments); err != nil {
+			if err = n.delivery.SubscriptionDeliverSlackAttachments(sub.SubscriberID, sub.SubscriberType, subAttachments); err != nil {
 				merr.Append(fmt.Errorf("cannot deliver notification to subscriber %s [%s]: %w",
 					sub.SubscriberID, sub.SubscriberType, err))
 			}
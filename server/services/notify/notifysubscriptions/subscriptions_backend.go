@@ -5,6 +5,7 @@ package notifysubscriptions
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -13,6 +14,7 @@ import (
 	"github.com/mattermost/focalboard/server/ws"
 	"github.com/wiggin77/merror"
 
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
@@ -29,6 +31,7 @@ type BackendParams struct {
 	Logger                 *mlog.Logger
 	NotifyFreqCardSeconds  int
 	NotifyFreqBoardSeconds int
+	Router                 *notify.Router
 }
 
 // Backend provides the notification backend for subscriptions.
@@ -216,6 +219,100 @@ func (b *Backend) OnMention(userID string, evt notify.BlockChangeEvent) {
 	)
 }
 
+// SendTestNotification delivers a synthetic subscription notification to
+// the user.
+func (b *Backend) SendTestNotification(userID string) error {
+	locale := b.delivery.SubscriberLocale(userID, model.SubTypeUser)
+
+	bundle, _ := notify.GetBundle()
+	cardTitle, message := bundle.TestNotificationText(locale)
+
+	attachments := []*mm_model.SlackAttachment{
+		{
+			Pretext:  "###### " + cardTitle,
+			Text:     message,
+			Fallback: message,
+		},
+	}
+	return b.delivery.SubscriptionDeliverSlackAttachments(userID, model.SubTypeUser, attachments)
+}
+
+// AdminAccessedBoard sends a direct message to each of the users of the
+// event, telling them that a system admin opened the board.
+func (b *Backend) AdminAccessedBoard(evt notify.AdminAccessEvent) error {
+	bundle, _ := notify.GetBundle()
+
+	merr := merror.New()
+	for _, userID := range evt.UserIDs {
+		locale := b.delivery.SubscriberLocale(userID, model.SubTypeUser)
+		title, message := bundle.AdminAccessText(locale, evt.AdminUsername, evt.Board.Title)
+
+		attachments := []*mm_model.SlackAttachment{
+			{
+				Pretext:  "###### " + title,
+				Text:     message,
+				Fallback: message,
+			},
+		}
+		if err := b.delivery.SubscriptionDeliverSlackAttachments(userID, model.SubTypeUser, attachments); err != nil {
+			merr.Append(err)
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// BoardFreezeChanged sends a direct message to each of the users of the
+// event, telling them that the board was frozen or unfrozen as scheduled.
+func (b *Backend) BoardFreezeChanged(evt notify.BoardFreezeEvent) error {
+	bundle, _ := notify.GetBundle()
+
+	merr := merror.New()
+	for _, userID := range evt.UserIDs {
+		locale := b.delivery.SubscriberLocale(userID, model.SubTypeUser)
+		title, message := bundle.BoardFreezeText(locale, evt.Board.Title, evt.Frozen)
+
+		attachments := []*mm_model.SlackAttachment{
+			{
+				Pretext:  "###### " + title,
+				Text:     message,
+				Fallback: message,
+			},
+		}
+		if err := b.delivery.SubscriptionDeliverSlackAttachments(userID, model.SubTypeUser, attachments); err != nil {
+			merr.Append(err)
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// BoardAccessRequested sends a direct message to each of the users of the
+// event, telling them that a user requested access to the board, with the
+// message of the request quoted.
+func (b *Backend) BoardAccessRequested(evt notify.BoardAccessRequestEvent) error {
+	bundle, _ := notify.GetBundle()
+
+	merr := merror.New()
+	for _, userID := range evt.UserIDs {
+		locale := b.delivery.SubscriberLocale(userID, model.SubTypeUser)
+		title, message := bundle.BoardAccessRequestText(locale, evt.RequesterUsername, evt.Board.Title)
+		if evt.Message != "" {
+			message += "\n> " + strings.ReplaceAll(evt.Message, "\n", "\n> ")
+		}
+
+		attachments := []*mm_model.SlackAttachment{
+			{
+				Pretext:  "###### " + title,
+				Text:     message,
+				Fallback: message,
+			},
+		}
+		if err := b.delivery.SubscriptionDeliverSlackAttachments(userID, model.SubTypeUser, attachments); err != nil {
+			merr.Append(err)
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
 // BroadcastSubscriptionChange sends a websocket message with details of the changed subscription to all
 // connected users in the team.
 func (b *Backend) BroadcastSubscriptionChange(teamID string, subscription *model.Subscription) {
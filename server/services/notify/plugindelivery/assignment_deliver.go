@@ -0,0 +1,61 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	defAssignedTemplate   = "@{{.Author}} assigned you to the card [{{.Card}}]({{.Link}})"
+	defUnassignedTemplate = "@{{.Author}} removed you from the card [{{.Card}}]({{.Link}})"
+)
+
+type assignmentMessage struct {
+	Author string
+	Card   string
+	Link   string
+}
+
+// AssignmentDeliver notifies a user they have been assigned to, or removed from, a card via the plugin API.
+func (pd *PluginDelivery) AssignmentDeliver(userID string, assigned bool, evt notify.BlockChangeEvent) error {
+	user, err := pd.api.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("cannot find assigned user: %w", err)
+	}
+
+	author, err := pd.api.GetUserByID(evt.ModifiedBy.UserID)
+	if err != nil {
+		return fmt.Errorf("cannot find user: %w", err)
+	}
+
+	link := utils.MakeCardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID, evt.Card.ID)
+
+	id, template := "notify.assignment.assigned", defAssignedTemplate
+	if !assigned {
+		id, template = "notify.assignment.unassigned", defUnassignedTemplate
+	}
+
+	bundle, _ := notify.GetBundle()
+	message := bundle.T(user.Locale, id, template, assignmentMessage{
+		Author: author.Username,
+		Card:   evt.Card.Title,
+		Link:   link,
+	})
+
+	n := &notify.Notification{
+		EventType: model.NotifyEventAssignment,
+		UserID:    user.Id,
+		Key:       fmt.Sprintf("%s|%t", evt.Card.ID, assigned),
+		Message:   message,
+		Link:      link,
+		ChangeID:  evt.Card.ID,
+		AuthorID:  evt.ModifiedBy.UserID,
+	}
+	return pd.deliver(n)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	defDueDigestTitleTemplate   = "You have {{.Count}} cards due today or overdue"
+	defDueDigestTodayTemplate   = "Due today"
+	defDueDigestOverdueTemplate = "Overdue"
+	defDueDigestCardTemplate    = "[{{.Card}}]({{.Link}}) in {{.Board}}"
+	defDueDigestLateTemplate    = "[{{.Card}}]({{.Link}}) in {{.Board}}, due {{.Date}}"
+)
+
+type dueDigestMessage struct {
+	Count int
+	Card  string
+	Board string
+	Link  string
+	Date  string
+}
+
+// DueDigestDeliver sends the user the list of the cards assigned to them
+// that are due today or overdue, with links to each card.
+func (pd *PluginDelivery) DueDigestDeliver(userID string, cards []*model.DueDigestCard) error {
+	user, err := pd.api.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("cannot find user: %w", err)
+	}
+
+	n := &notify.Notification{
+		EventType: model.NotifyEventDueDigest,
+		UserID:    user.Id,
+		Key:       "dueDigest",
+		Message:   pd.formatDueDigest(user.Locale, cards),
+	}
+	return pd.deliver(n)
+}
+
+func (pd *PluginDelivery) formatDueDigest(locale string, cards []*model.DueDigestCard) string {
+	bundle, _ := notify.GetBundle()
+
+	var today, overdue []string
+	for _, card := range cards {
+		data := dueDigestMessage{
+			Card:  card.Title,
+			Board: card.BoardTitle,
+			Link:  utils.MakeCardLink(pd.serverRoot, card.TeamID, card.BoardID, card.CardID),
+			Date:  card.DueDay,
+		}
+		if card.Overdue {
+			overdue = append(overdue, "- "+bundle.T(locale, "notify.due_digest.card_overdue", defDueDigestLateTemplate, data))
+		} else {
+			today = append(today, "- "+bundle.T(locale, "notify.due_digest.card", defDueDigestCardTemplate, data))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("###### ")
+	sb.WriteString(bundle.T(locale, "notify.due_digest.title", defDueDigestTitleTemplate, dueDigestMessage{Count: len(cards)}))
+	if len(today) > 0 {
+		sb.WriteString("\n**" + bundle.T(locale, "notify.due_digest.today", defDueDigestTodayTemplate, nil) + "**\n")
+		sb.WriteString(strings.Join(today, "\n"))
+	}
+	if len(overdue) > 0 {
+		sb.WriteString("\n**" + bundle.T(locale, "notify.due_digest.overdue", defDueDigestOverdueTemplate, nil) + "**\n")
+		sb.WriteString(strings.Join(overdue, "\n"))
+	}
+	return sb.String()
+}
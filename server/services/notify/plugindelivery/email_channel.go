@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+)
+
+const emailSubject = "Boards notification"
+
+// EmailChannel delivers notifications by email via the plugin API.
+type EmailChannel struct {
+	api PluginAPI
+}
+
+func NewEmailChannel(api PluginAPI) *EmailChannel {
+	return &EmailChannel{
+		api: api,
+	}
+}
+
+func (ec *EmailChannel) Name() string {
+	return model.NotifyChannelEmail
+}
+
+func (ec *EmailChannel) Deliver(n *notify.Notification) error {
+	user, err := ec.api.GetUserByID(n.UserID)
+	if err != nil {
+		return fmt.Errorf("cannot find user: %w", err)
+	}
+	if user.Email == "" {
+		return fmt.Errorf("user %s has no email address", n.UserID)
+	}
+
+	body := strings.ReplaceAll(html.EscapeString(n.Message), "\n", "<br>")
+	if n.Link != "" {
+		link := html.EscapeString(n.Link)
+		body += fmt.Sprintf(`<br><br><a href="%s">%s</a>`, link, link)
+	}
+	return ec.api.SendMail(user.Email, emailSubject, body)
+}
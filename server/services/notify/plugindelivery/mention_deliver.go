@@ -6,6 +6,7 @@ package plugindelivery
 import (
 	"fmt"
 
+	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/utils"
 
@@ -19,16 +20,26 @@ func (pd *PluginDelivery) MentionDeliver(mentionedUser *mm_model.User, extract s
 		return "", fmt.Errorf("cannot find user: %w", err)
 	}
 
-	channel, err := pd.api.GetDirectChannel(mentionedUser.Id, pd.botID)
-	if err != nil {
-		return "", fmt.Errorf("cannot get direct channel: %w", err)
+	var link, message string
+	changeID := evt.Board.ID
+	if evt.Card == nil {
+		// the mention is in the description or a text of the board itself
+		link = utils.MakeBoardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID)
+		message = formatBoardMessage(mentionedUser.Locale, author.Username, extract, evt.Board.Title, link)
+	} else {
+		changeID = evt.Card.ID
+		link = utils.MakeCardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID, evt.Card.ID)
+		message = formatMessage(mentionedUser.Locale, author.Username, extract, evt.Card.Title, link, evt.BlockChanged)
 	}
-	link := utils.MakeCardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID, evt.Card.ID)
 
-	post := &mm_model.Post{
-		UserId:    pd.botID,
-		ChannelId: channel.Id,
-		Message:   formatMessage(author.Username, extract, evt.Card.Title, link, evt.BlockChanged),
+	n := &notify.Notification{
+		EventType: model.NotifyEventMention,
+		UserID:    mentionedUser.Id,
+		Key:       evt.BlockChanged.ID,
+		Message:   message,
+		Link:      link,
+		ChangeID:  changeID,
+		AuthorID:  evt.ModifiedBy.UserID,
 	}
-	return mentionedUser.Id, pd.api.CreatePost(post)
+	return mentionedUser.Id, pd.deliver(n)
 }
@@ -4,21 +4,45 @@
 package plugindelivery
 
 import (
-	"fmt"
-
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
 )
 
 const (
-	// TODO: localize these when i18n is available.
-	defCommentTemplate     = "@%s mentioned you in a comment on the card [%s](%s)\n> %s"
-	defDescriptionTemplate = "@%s mentioned you in the card [%s](%s)\n> %s"
+	defCommentTemplate     = "@{{.Author}} mentioned you in a comment on the card [{{.Card}}]({{.Link}})\n> {{.Extract}}"
+	defDescriptionTemplate = "@{{.Author}} mentioned you in the card [{{.Card}}]({{.Link}})\n> {{.Extract}}"
+	defBoardTemplate       = "@{{.Author}} mentioned you in the board [{{.Board}}]({{.Link}})\n> {{.Extract}}"
 )
 
-func formatMessage(author string, extract string, card string, link string, block *model.Block) string {
-	template := defDescriptionTemplate
+type mentionMessage struct {
+	Author  string
+	Extract string
+	Card    string
+	Board   string
+	Link    string
+}
+
+func formatMessage(locale string, author string, extract string, card string, link string, block *model.Block) string {
+	id, template := "notify.mention.card", defDescriptionTemplate
 	if block.Type == model.TypeComment {
-		template = defCommentTemplate
+		id, template = "notify.mention.comment", defCommentTemplate
 	}
-	return fmt.Sprintf(template, author, card, link, extract)
+
+	bundle, _ := notify.GetBundle()
+	return bundle.T(locale, id, template, mentionMessage{
+		Author:  author,
+		Extract: extract,
+		Card:    card,
+		Link:    link,
+	})
+}
+
+func formatBoardMessage(locale string, author string, extract string, board string, link string) string {
+	bundle, _ := notify.GetBundle()
+	return bundle.T(locale, "notify.mention.board", defBoardTemplate, mentionMessage{
+		Author:  author,
+		Extract: extract,
+		Board:   board,
+		Link:    link,
+	})
 }
@@ -4,6 +4,11 @@
 package plugindelivery
 
 import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+
 	mm_model "github.com/mattermost/mattermost-server/v6/model"
 )
 
@@ -30,6 +35,9 @@ type PluginAPI interface {
 	// GetChannelMember gets a channel member by userID.
 	GetChannelMember(channelID string, userID string) (*mm_model.ChannelMember, error)
 
+	// SendMail sends an email to a specific address.
+	SendMail(to, subject, htmlBody string) error
+
 	// IsErrNotFound returns true if `err` or one of its wrapped children are the `ErrNotFound`
 	// as defined in the plugin API.
 	IsErrNotFound(err error) bool
@@ -40,6 +48,7 @@ type PluginDelivery struct {
 	botID      string
 	serverRoot string
 	api        PluginAPI
+	router     *notify.Router
 }
 
 func New(botID string, serverRoot string, api PluginAPI) *PluginDelivery {
@@ -55,3 +64,37 @@ func New(botID string, serverRoot string, api PluginAPI) *PluginDelivery {
 func (pd *PluginDelivery) IsErrNotFound(err error) bool {
 	return pd.api.IsErrNotFound(err)
 }
+
+// SetRouter makes the notifications go through the router, so they are
+// delivered over the channels selected by each user. Without a router
+// notifications are delivered as direct messages.
+func (pd *PluginDelivery) SetRouter(router *notify.Router) {
+	pd.router = router
+}
+
+// Name returns the name of the direct message notification channel.
+func (pd *PluginDelivery) Name() string {
+	return model.NotifyChannelDM
+}
+
+// Deliver sends the notification as a direct message from the bot.
+func (pd *PluginDelivery) Deliver(n *notify.Notification) error {
+	channel, err := pd.api.GetDirectChannel(n.UserID, pd.botID)
+	if err != nil {
+		return fmt.Errorf("cannot get direct channel: %w", err)
+	}
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channel.Id,
+		Message:   n.Message,
+	}
+	return pd.api.CreatePost(post)
+}
+
+func (pd *PluginDelivery) deliver(n *notify.Notification) error {
+	if pd.router == nil {
+		return pd.Deliver(n)
+	}
+	return pd.router.Route(n)
+}
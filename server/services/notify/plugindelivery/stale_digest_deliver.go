@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	defStaleDigestTitleTemplate = "{{.Count}} cards of your boards weren't updated for {{.Days}} days or more"
+	defStaleDigestBoardTemplate = "**[{{.Board}}]({{.Link}})**"
+	defStaleDigestCardTemplate  = "[{{.Card}}]({{.Link}}), last updated {{.Days}} days ago"
+	defStaleDigestMoreTemplate  = "and {{.Count}} more"
+)
+
+type staleDigestMessage struct {
+	Count int
+	Days  int
+	Card  string
+	Board string
+	Link  string
+}
+
+// StaleDigestDeliver sends the user the list of the stale cards of the
+// boards they administer, grouped by board, with links to each card.
+func (pd *PluginDelivery) StaleDigestDeliver(userID string, days int, boards []*model.StaleDigestBoard) error {
+	user, err := pd.api.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("cannot find user: %w", err)
+	}
+
+	n := &notify.Notification{
+		EventType: model.NotifyEventStaleDigest,
+		UserID:    user.Id,
+		Key:       "staleDigest",
+		Message:   pd.formatStaleDigest(user.Locale, days, boards),
+	}
+	return pd.deliver(n)
+}
+
+func (pd *PluginDelivery) formatStaleDigest(locale string, days int, boards []*model.StaleDigestBoard) string {
+	bundle, _ := notify.GetBundle()
+
+	total := 0
+	for _, board := range boards {
+		total += board.Total
+	}
+
+	var sb strings.Builder
+	sb.WriteString("###### ")
+	sb.WriteString(bundle.T(locale, "notify.stale_digest.title", defStaleDigestTitleTemplate, staleDigestMessage{Count: total, Days: days}))
+	for _, board := range boards {
+		sb.WriteString("\n")
+		sb.WriteString(bundle.T(locale, "notify.stale_digest.board", defStaleDigestBoardTemplate, staleDigestMessage{
+			Board: board.BoardTitle,
+			Link:  utils.MakeBoardLink(pd.serverRoot, board.TeamID, board.BoardID),
+		}))
+		for _, card := range board.Cards {
+			sb.WriteString("\n- ")
+			sb.WriteString(bundle.T(locale, "notify.stale_digest.card", defStaleDigestCardTemplate, staleDigestMessage{
+				Card: card.Title,
+				Link: utils.MakeCardLink(pd.serverRoot, board.TeamID, board.BoardID, card.CardID),
+				Days: card.DaysStale,
+			}))
+		}
+		if more := board.Total - len(board.Cards); more > 0 {
+			sb.WriteString("\n- ")
+			sb.WriteString(bundle.T(locale, "notify.stale_digest.more", defStaleDigestMoreTemplate, staleDigestMessage{Count: more}))
+		}
+	}
+	return sb.String()
+}
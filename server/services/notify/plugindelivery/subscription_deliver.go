@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
 
 	mm_model "github.com/mattermost/mattermost-server/v6/model"
 )
@@ -62,3 +63,17 @@ func (pd *PluginDelivery) getDirectChannelID(subscriberID string, subscriberType
 		return "", ErrUnsupportedSubscriberType
 	}
 }
+
+// SubscriberLocale returns the locale of a user subscriber. Channel subscribers, and users
+// without a locale, get the default locale.
+func (pd *PluginDelivery) SubscriberLocale(subscriberID string, subscriberType model.SubscriberType) string {
+	if subscriberType != model.SubTypeUser {
+		return notify.DefaultLocale
+	}
+
+	user, err := pd.api.GetUserByID(subscriberID)
+	if err != nil || user.Locale == "" {
+		return notify.DefaultLocale
+	}
+	return user.Locale
+}
@@ -137,6 +137,10 @@ func (m pluginAPIMock) GetChannelMember(channelID string, userID string) (*mm_mo
 	return nil, ErrNotFound{}
 }
 
+func (m pluginAPIMock) SendMail(to, subject, htmlBody string) error {
+	return nil
+}
+
 func (m pluginAPIMock) IsErrNotFound(err error) bool {
 	return false
 }
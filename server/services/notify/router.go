@@ -0,0 +1,416 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notify
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/scheduler"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// dedupWindow is how long a delivered notification is remembered, so the
+// same notification isn't delivered twice over a channel.
+const dedupWindow = time.Minute
+
+// DefaultChangeWindow is how long the notifications of a change are
+// remembered by default, so less specific notifications of the same change
+// are dropped.
+const DefaultChangeWindow = 5 * time.Minute
+
+// EventSubscription is the event type of the notifications of the block
+// subscriptions. They aren't routed, but are dropped like the routed
+// notifications when a more specific notification of the change was sent.
+const EventSubscription = "subscription"
+
+// eventSpecificity ranks the notifications a single change can cause, from
+// the least to the most specific. The user only gets the most specific.
+var eventSpecificity = map[string]int{
+	EventSubscription:           1,
+	model.NotifyEventAssignment: 2,
+	model.NotifyEventMention:    3,
+}
+
+// deferredDeliveryFrequency is how often the deferred notifications of the
+// users that became available are delivered.
+const deferredDeliveryFrequency = time.Minute
+
+// deferredClaimTimeout is how long a deferred notification claimed by a
+// server for delivery is left to it. Past that, the server is assumed to
+// have stopped, and another server can deliver the notification.
+const deferredClaimTimeout = 5 * time.Minute
+
+// Notification is a single logical notification for a user, that can be
+// delivered over several channels.
+type Notification struct {
+	EventType string // one of the model.NotifyEvent* types
+	UserID    string // the user to notify
+	Key       string // identifies the notification, for de-duplication
+	Message   string // markdown message
+	Link      string // link to the card or board the notification is about
+	ChangeID  string // the card or board changed, for de-duplication across event types
+	AuthorID  string // the author of the change
+}
+
+// Channel delivers notifications to users over one medium, such as direct
+// messages or email.
+type Channel interface {
+	Name() string
+	Deliver(n *Notification) error
+}
+
+// PreferencesStore provides the users, and so their notification preferences.
+type PreferencesStore interface {
+	GetUserByID(userID string) (*model.User, error)
+}
+
+// DeferredStore keeps the notifications held back until the users are
+// available. A notification is claimed by the server delivering it, so
+// the servers of a cluster don't deliver it more than once.
+type DeferredStore interface {
+	InsertDeferredNotification(notification *model.DeferredNotification) error
+	GetUsersWithDeferredNotifications() ([]string, error)
+	GetDeferredNotificationsForUser(userID string) ([]*model.DeferredNotification, error)
+	ClaimDeferredNotification(id, claimedBy string, staleBefore int64) (bool, error)
+	ReleaseDeferredNotification(id, claimedBy string) error
+	DeleteDeferredNotification(id string) error
+}
+
+// AvailabilityChecker tells if a user is available to be notified, as
+// when the user has no Do Not Disturb status.
+type AvailabilityChecker interface {
+	IsUserAvailable(userID string) (bool, error)
+}
+
+// Router delivers notifications over the channels each user selected for
+// the event type in their notification preferences.
+type Router struct {
+	id     string // claims the deferred notifications delivered by this server
+	store  PreferencesStore
+	logger *mlog.Logger
+
+	mux          sync.Mutex
+	channels     map[string]Channel
+	delivered    map[string]time.Time
+	changes      map[string]changeDelivery
+	changeWindow time.Duration
+
+	deferred     DeferredStore
+	availability AvailabilityChecker
+	deliveryTask *scheduler.ScheduledTask
+}
+
+// NewRouter creates a notification router without channels.
+func NewRouter(store PreferencesStore, logger *mlog.Logger) *Router {
+	return &Router{
+		id:           utils.NewID(utils.IDTypeNone),
+		store:        store,
+		logger:       logger,
+		channels:     make(map[string]Channel),
+		delivered:    make(map[string]time.Time),
+		changes:      make(map[string]changeDelivery),
+		changeWindow: DefaultChangeWindow,
+	}
+}
+
+type changeDelivery struct {
+	specificity int
+	at          time.Time
+}
+
+// AddChannel makes a channel available to the users' preferences,
+// replacing any channel with the same name.
+func (r *Router) AddChannel(channel Channel) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.channels[channel.Name()] = channel
+}
+
+// SetChangeWindow sets how long the notifications of a change are
+// remembered. It must cover the batching of the subscription notifications,
+// which are sent last.
+func (r *Router) SetChangeWindow(window time.Duration) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.changeWindow = window
+}
+
+// SetDeferral holds back the notifications of the users within their
+// quiet hours, or not available according to the checker, until they are
+// available again. The checker is optional.
+func (r *Router) SetDeferral(store DeferredStore, checker AvailabilityChecker) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.deferred = store
+	r.availability = checker
+}
+
+// Route delivers the notification over the channels selected by the user.
+// Channels that aren't available are skipped, and notifications already
+// delivered over a channel recently are not delivered again, nor are the
+// notifications of a change the user got a more specific notification of.
+// When deferral is set and the user isn't available, the notification is
+// stored to be delivered later instead.
+func (r *Router) Route(n *Notification) error {
+	if n.ChangeID != "" && !r.ClaimChange(n.UserID, n.ChangeID, n.AuthorID, n.EventType) {
+		r.logger.Debug("Skipping notification of a change already notified",
+			mlog.String("user_id", n.UserID),
+			mlog.String("event_type", n.EventType),
+			mlog.String("change_id", n.ChangeID),
+		)
+		return nil
+	}
+
+	user, err := r.store.GetUserByID(n.UserID)
+	if err != nil {
+		return fmt.Errorf("cannot lookup user to notify: %w", err)
+	}
+
+	r.mux.Lock()
+	deferred := r.deferred
+	r.mux.Unlock()
+
+	if deferred != nil && !r.isAvailable(user) {
+		notification := &model.DeferredNotification{
+			ID:        utils.NewID(utils.IDTypeNone),
+			UserID:    n.UserID,
+			EventType: n.EventType,
+			Key:       n.Key,
+			Message:   n.Message,
+			Link:      n.Link,
+			CreateAt:  utils.GetMillis(),
+		}
+		if err := deferred.InsertDeferredNotification(notification); err != nil {
+			return fmt.Errorf("cannot defer notification: %w", err)
+		}
+
+		r.logger.Debug("Notification deferred",
+			mlog.String("user_id", n.UserID),
+			mlog.String("event_type", n.EventType),
+		)
+		return nil
+	}
+
+	return r.deliver(user, n)
+}
+
+// DeliverDeferred delivers the deferred notifications of the users that
+// are available again.
+func (r *Router) DeliverDeferred() {
+	r.mux.Lock()
+	deferred := r.deferred
+	r.mux.Unlock()
+
+	if deferred == nil {
+		return
+	}
+
+	userIDs, err := deferred.GetUsersWithDeferredNotifications()
+	if err != nil {
+		r.logger.Error("Cannot get users with deferred notifications", mlog.Err(err))
+		return
+	}
+
+	for _, userID := range userIDs {
+		user, err := r.store.GetUserByID(userID)
+		if err != nil {
+			r.logger.Error("Cannot lookup user with deferred notifications", mlog.String("user_id", userID), mlog.Err(err))
+			continue
+		}
+		if !r.isAvailable(user) {
+			continue
+		}
+
+		notifications, err := deferred.GetDeferredNotificationsForUser(userID)
+		if err != nil {
+			r.logger.Error("Cannot get deferred notifications", mlog.String("user_id", userID), mlog.Err(err))
+			continue
+		}
+
+		for _, notification := range notifications {
+			r.deliverDeferred(deferred, user, notification)
+		}
+	}
+}
+
+// deliverDeferred delivers the deferred notification unless another server
+// claimed it. It is removed once delivered, and left to be delivered again
+// otherwise.
+func (r *Router) deliverDeferred(deferred DeferredStore, user *model.User, notification *model.DeferredNotification) {
+	staleBefore := utils.GetMillis() - deferredClaimTimeout.Milliseconds()
+	claimed, err := deferred.ClaimDeferredNotification(notification.ID, r.id, staleBefore)
+	if err != nil {
+		r.logger.Error("Cannot claim deferred notification", mlog.String("id", notification.ID), mlog.Err(err))
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	n := &Notification{
+		EventType: notification.EventType,
+		UserID:    notification.UserID,
+		Key:       notification.Key,
+		Message:   notification.Message,
+		Link:      notification.Link,
+	}
+	if err := r.deliver(user, n); err != nil {
+		r.logger.Error("Cannot deliver deferred notification", mlog.String("user_id", user.ID), mlog.Err(err))
+		if err := deferred.ReleaseDeferredNotification(notification.ID, r.id); err != nil {
+			r.logger.Error("Cannot release deferred notification", mlog.String("id", notification.ID), mlog.Err(err))
+		}
+		return
+	}
+
+	if err := deferred.DeleteDeferredNotification(notification.ID); err != nil {
+		r.logger.Error("Cannot delete deferred notification", mlog.String("id", notification.ID), mlog.Err(err))
+	}
+}
+
+// StartDeferredDelivery periodically delivers the deferred notifications
+// until Shutdown is called.
+func (r *Router) StartDeferredDelivery() {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.deliveryTask != nil {
+		return
+	}
+	r.deliveryTask = scheduler.CreateRecurringTask("deliverDeferredNotifications", r.DeliverDeferred, deferredDeliveryFrequency)
+}
+
+// Shutdown stops the delivery of the deferred notifications.
+func (r *Router) Shutdown() {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.deliveryTask != nil {
+		r.deliveryTask.Cancel()
+		r.deliveryTask = nil
+	}
+}
+
+// isAvailable returns false if the user is within their quiet hours or
+// the availability checker says so. Errors of the checker are logged and
+// the user considered available, so notifications are not lost.
+func (r *Router) isAvailable(user *model.User) bool {
+	if quietHours := user.QuietHours(); quietHours != nil {
+		if _, quiet := quietHours.EndAfter(time.Now(), user.Location()); quiet {
+			return false
+		}
+	}
+
+	r.mux.Lock()
+	checker := r.availability
+	r.mux.Unlock()
+
+	if checker == nil {
+		return true
+	}
+	available, err := checker.IsUserAvailable(user.ID)
+	if err != nil {
+		r.logger.Warn("Cannot check user availability", mlog.String("user_id", user.ID), mlog.Err(err))
+		return true
+	}
+	return available
+}
+
+// deliver delivers the notification over the channels selected by the user.
+func (r *Router) deliver(user *model.User, n *Notification) error {
+	merr := merror.New()
+	for _, name := range user.NotificationPreferences().ChannelsFor(n.EventType) {
+		channel, ok := r.claim(name, n)
+		if !ok {
+			continue
+		}
+
+		if err := channel.Deliver(n); err != nil {
+			r.release(name, n)
+			merr.Append(fmt.Errorf("cannot deliver notification over %s: %w", name, err))
+			continue
+		}
+
+		r.logger.Debug("Notification delivered",
+			mlog.String("user_id", n.UserID),
+			mlog.String("event_type", n.EventType),
+			mlog.String("channel", name),
+		)
+	}
+	return merr.ErrorOrNil()
+}
+
+// claim returns the channel if it is available and the notification
+// wasn't delivered over it recently, marking it as delivered.
+func (r *Router) claim(name string, n *Notification) (Channel, bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	channel, ok := r.channels[name]
+	if !ok {
+		r.logger.Debug("Skipping unavailable notification channel",
+			mlog.String("user_id", n.UserID),
+			mlog.String("channel", name),
+		)
+		return nil, false
+	}
+
+	now := time.Now()
+	for key, at := range r.delivered {
+		if now.Sub(at) > dedupWindow {
+			delete(r.delivered, key)
+		}
+	}
+
+	key := dedupKey(name, n)
+	if _, ok := r.delivered[key]; ok {
+		r.logger.Debug("Skipping duplicate notification",
+			mlog.String("user_id", n.UserID),
+			mlog.String("channel", name),
+			mlog.String("key", n.Key),
+		)
+		return nil, false
+	}
+	r.delivered[key] = now
+	return channel, true
+}
+
+// ClaimChange records that a notification of the event type is about to be
+// sent to the user for the change of the card or board by the author. It
+// returns false if a more specific notification of the change was sent, in
+// which case the notification should be dropped. The notifications of the
+// same event type are left to the de-duplication over each channel.
+func (r *Router) ClaimChange(userID, changeID, authorID, eventType string) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	now := time.Now()
+	for key, change := range r.changes {
+		if now.Sub(change.at) > r.changeWindow {
+			delete(r.changes, key)
+		}
+	}
+
+	specificity := eventSpecificity[eventType]
+	key := userID + "|" + changeID + "|" + authorID
+	if change, ok := r.changes[key]; ok && change.specificity >= specificity {
+		return change.specificity == specificity
+	}
+	r.changes[key] = changeDelivery{specificity: specificity, at: now}
+	return true
+}
+
+// release forgets a delivery that failed, so it can be retried.
+func (r *Router) release(name string, n *Notification) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	delete(r.delivered, dedupKey(name, n))
+}
+
+func dedupKey(channel string, n *Notification) string {
+	return channel + "|" + n.UserID + "|" + n.EventType + "|" + n.Key
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type testPreferencesStore map[string]*model.User
+
+func (s testPreferencesStore) GetUserByID(userID string) (*model.User, error) {
+	user, ok := s[userID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return user, nil
+}
+
+type testChannel struct {
+	name      string
+	err       error
+	delivered []*Notification
+}
+
+func (c *testChannel) Name() string {
+	return c.name
+}
+
+func (c *testChannel) Deliver(n *Notification) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.delivered = append(c.delivered, n)
+	return nil
+}
+
+func TestRouter(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	store := testPreferencesStore{
+		"default-user": {ID: "default-user"},
+		"email-user": {ID: "email-user", Props: map[string]interface{}{
+			model.UserPropNotificationPreferences: `{"mention":["email","webhook","dm","email"],"assignment":[]}`,
+		}},
+	}
+
+	setup := func() (*Router, *testChannel, *testChannel) {
+		router := NewRouter(store, logger)
+		dm := &testChannel{name: model.NotifyChannelDM}
+		email := &testChannel{name: model.NotifyChannelEmail}
+		router.AddChannel(dm)
+		router.AddChannel(email)
+		return router, dm, email
+	}
+
+	t.Run("default channels", func(t *testing.T) {
+		router, dm, email := setup()
+		require.NoError(t, router.Route(&Notification{EventType: model.NotifyEventMention, UserID: "default-user", Key: "block-1"}))
+		assert.Len(t, dm.delivered, 1)
+		assert.Empty(t, email.delivered)
+	})
+
+	t.Run("selected channels, skipping unavailable ones", func(t *testing.T) {
+		router, dm, email := setup()
+		require.NoError(t, router.Route(&Notification{EventType: model.NotifyEventMention, UserID: "email-user", Key: "block-1"}))
+		assert.Len(t, dm.delivered, 1)
+		assert.Len(t, email.delivered, 1)
+	})
+
+	t.Run("disabled event type", func(t *testing.T) {
+		router, dm, email := setup()
+		require.NoError(t, router.Route(&Notification{EventType: model.NotifyEventAssignment, UserID: "email-user", Key: "card-1"}))
+		assert.Empty(t, dm.delivered)
+		assert.Empty(t, email.delivered)
+	})
+
+	t.Run("duplicates are delivered once", func(t *testing.T) {
+		router, dm, _ := setup()
+		n := &Notification{EventType: model.NotifyEventMention, UserID: "default-user", Key: "block-1"}
+		require.NoError(t, router.Route(n))
+		require.NoError(t, router.Route(n))
+		assert.Len(t, dm.delivered, 1)
+
+		require.NoError(t, router.Route(&Notification{EventType: model.NotifyEventMention, UserID: "default-user", Key: "block-2"}))
+		assert.Len(t, dm.delivered, 2)
+	})
+
+	t.Run("failed deliveries can be retried", func(t *testing.T) {
+		router, dm, _ := setup()
+		dm.err = errors.New("unavailable")
+		n := &Notification{EventType: model.NotifyEventMention, UserID: "default-user", Key: "block-1"}
+		require.Error(t, router.Route(n))
+
+		dm.err = nil
+		require.NoError(t, router.Route(n))
+		assert.Len(t, dm.delivered, 1)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		router, _, _ := setup()
+		require.Error(t, router.Route(&Notification{EventType: model.NotifyEventMention, UserID: "bogus"}))
+	})
+}
+
+type testDeferredStore struct {
+	notifications []*model.DeferredNotification
+	claims        map[string]string
+}
+
+func (s *testDeferredStore) InsertDeferredNotification(notification *model.DeferredNotification) error {
+	s.notifications = append(s.notifications, notification)
+	return nil
+}
+
+func (s *testDeferredStore) GetUsersWithDeferredNotifications() ([]string, error) {
+	userIDs := []string{}
+	seen := map[string]bool{}
+	for _, notification := range s.notifications {
+		if !seen[notification.UserID] {
+			seen[notification.UserID] = true
+			userIDs = append(userIDs, notification.UserID)
+		}
+	}
+	return userIDs, nil
+}
+
+func (s *testDeferredStore) GetDeferredNotificationsForUser(userID string) ([]*model.DeferredNotification, error) {
+	notifications := []*model.DeferredNotification{}
+	for _, notification := range s.notifications {
+		if notification.UserID == userID {
+			notifications = append(notifications, notification)
+		}
+	}
+	return notifications, nil
+}
+
+func (s *testDeferredStore) ClaimDeferredNotification(id, claimedBy string, staleBefore int64) (bool, error) {
+	if s.claims == nil {
+		s.claims = map[string]string{}
+	}
+	if _, ok := s.claims[id]; ok {
+		return false, nil
+	}
+	s.claims[id] = claimedBy
+	return true, nil
+}
+
+func (s *testDeferredStore) ReleaseDeferredNotification(id, claimedBy string) error {
+	if s.claims[id] == claimedBy {
+		delete(s.claims, id)
+	}
+	return nil
+}
+
+func (s *testDeferredStore) DeleteDeferredNotification(id string) error {
+	for i, notification := range s.notifications {
+		if notification.ID == id {
+			s.notifications = append(s.notifications[:i], s.notifications[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+type testAvailability map[string]bool
+
+func (a testAvailability) IsUserAvailable(userID string) (bool, error) {
+	if available, ok := a[userID]; ok {
+		return available, nil
+	}
+	return false, errors.New("unknown status")
+}
+
+func TestRouterDeferral(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+
+	// quiet hours around the current time, in UTC
+	now := time.Now().UTC()
+	clock := func(d time.Duration) string {
+		return now.Add(d).Format("15:04")
+	}
+	quiet := fmt.Sprintf(`{"start":%q,"end":%q}`, clock(-time.Hour), clock(time.Hour))
+
+	store := testPreferencesStore{
+		"quiet-user":     {ID: "quiet-user", Props: map[string]interface{}{model.UserPropQuietHours: quiet, model.UserPropTimezone: "UTC"}},
+		"dnd-user":       {ID: "dnd-user"},
+		"available-user": {ID: "available-user"},
+		"unknown-user":   {ID: "unknown-user"},
+	}
+	availability := testAvailability{"quiet-user": true, "dnd-user": false, "available-user": true}
+
+	setup := func() (*Router, *testChannel, *testDeferredStore) {
+		router := NewRouter(store, logger)
+		dm := &testChannel{name: model.NotifyChannelDM}
+		router.AddChannel(dm)
+		deferred := &testDeferredStore{}
+		router.SetDeferral(deferred, availability)
+		return router, dm, deferred
+	}
+
+	t.Run("available users are notified right away", func(t *testing.T) {
+		router, dm, deferred := setup()
+		require.NoError(t, router.Route(&Notification{EventType: model.NotifyEventMention, UserID: "available-user", Key: "block-1"}))
+		assert.Len(t, dm.delivered, 1)
+		assert.Empty(t, deferred.notifications)
+	})
+
+	t.Run("availability errors don't hold notifications back", func(t *testing.T) {
+		router, dm, deferred := setup()
+		require.NoError(t, router.Route(&Notification{EventType: model.NotifyEventMention, UserID: "unknown-user", Key: "block-1"}))
+		assert.Len(t, dm.delivered, 1)
+		assert.Empty(t, deferred.notifications)
+	})
+
+	t.Run("notifications are deferred during quiet hours and do not disturb", func(t *testing.T) {
+		router, dm, deferred := setup()
+		require.NoError(t, router.Route(&Notification{EventType: model.NotifyEventMention, UserID: "quiet-user", Key: "block-1", Message: "hello"}))
+		require.NoError(t, router.Route(&Notification{EventType: model.NotifyEventMention, UserID: "dnd-user", Key: "block-1"}))
+		assert.Empty(t, dm.delivered)
+		require.Len(t, deferred.notifications, 2)
+		assert.Equal(t, "hello", deferred.notifications[0].Message)
+
+		router.DeliverDeferred()
+		assert.Empty(t, dm.delivered)
+		assert.Len(t, deferred.notifications, 2)
+	})
+
+	t.Run("deferred notifications are delivered once the user is available", func(t *testing.T) {
+		router, dm, deferred := setup()
+		require.NoError(t, router.Route(&Notification{EventType: model.NotifyEventMention, UserID: "dnd-user", Key: "block-1", Message: "hello"}))
+		require.Empty(t, dm.delivered)
+
+		router.SetDeferral(deferred, testAvailability{"dnd-user": true})
+		router.DeliverDeferred()
+		require.Len(t, dm.delivered, 1)
+		assert.Equal(t, "hello", dm.delivered[0].Message)
+		assert.Empty(t, deferred.notifications)
+	})
+
+	t.Run("deferred notifications are kept when the delivery fails", func(t *testing.T) {
+		router, dm, deferred := setup()
+		require.NoError(t, router.Route(&Notification{EventType: model.NotifyEventMention, UserID: "dnd-user", Key: "block-1", Message: "hello"}))
+
+		router.SetDeferral(deferred, testAvailability{"dnd-user": true})
+		dm.err = errors.New("delivery failed")
+		router.DeliverDeferred()
+		require.Len(t, deferred.notifications, 1)
+
+		dm.err = nil
+		router.DeliverDeferred()
+		require.Len(t, dm.delivered, 1)
+		assert.Empty(t, deferred.notifications)
+	})
+
+	t.Run("deferred notifications claimed by another server are left to it", func(t *testing.T) {
+		router, dm, deferred := setup()
+		require.NoError(t, router.Route(&Notification{EventType: model.NotifyEventMention, UserID: "dnd-user", Key: "block-1"}))
+		require.Len(t, deferred.notifications, 1)
+
+		deferred.claims = map[string]string{deferred.notifications[0].ID: "other-server"}
+		router.SetDeferral(deferred, testAvailability{"dnd-user": true})
+		router.DeliverDeferred()
+		assert.Empty(t, dm.delivered)
+		assert.Len(t, deferred.notifications, 1)
+	})
+}
+
+func TestRouterChanges(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	store := testPreferencesStore{
+		"user-1": {ID: "user-1"},
+		"user-2": {ID: "user-2"},
+	}
+
+	setup := func() (*Router, *testChannel) {
+		router := NewRouter(store, logger)
+		dm := &testChannel{name: model.NotifyChannelDM}
+		router.AddChannel(dm)
+		return router, dm
+	}
+
+	mention := func(userID, changeID string) *Notification {
+		return &Notification{EventType: model.NotifyEventMention, UserID: userID, Key: "block-1", ChangeID: changeID, AuthorID: "author-1"}
+	}
+	assignment := func(userID, changeID string) *Notification {
+		return &Notification{EventType: model.NotifyEventAssignment, UserID: userID, Key: changeID + "|true", ChangeID: changeID, AuthorID: "author-1"}
+	}
+
+	t.Run("a mention and an assignment of a change send a single message", func(t *testing.T) {
+		router, dm := setup()
+		require.NoError(t, router.Route(mention("user-1", "card-1")))
+		require.NoError(t, router.Route(assignment("user-1", "card-1")))
+		require.Len(t, dm.delivered, 1)
+		assert.Equal(t, model.NotifyEventMention, dm.delivered[0].EventType)
+		assert.False(t, router.ClaimChange("user-1", "card-1", "author-1", EventSubscription))
+	})
+
+	t.Run("more specific notifications are still sent", func(t *testing.T) {
+		router, dm := setup()
+		assert.True(t, router.ClaimChange("user-1", "card-1", "author-1", EventSubscription))
+		require.NoError(t, router.Route(assignment("user-1", "card-1")))
+		require.NoError(t, router.Route(mention("user-1", "card-1")))
+		assert.Len(t, dm.delivered, 2)
+	})
+
+	t.Run("other users, cards and authors are separate changes", func(t *testing.T) {
+		router, dm := setup()
+		require.NoError(t, router.Route(mention("user-1", "card-1")))
+		require.NoError(t, router.Route(assignment("user-2", "card-1")))
+		require.NoError(t, router.Route(assignment("user-1", "card-2")))
+		assert.Len(t, dm.delivered, 3)
+		assert.True(t, router.ClaimChange("user-1", "card-1", "author-2", EventSubscription))
+	})
+
+	t.Run("changes are forgotten after the window", func(t *testing.T) {
+		router, dm := setup()
+		router.SetChangeWindow(time.Millisecond)
+		require.NoError(t, router.Route(mention("user-1", "card-1")))
+		time.Sleep(5 * time.Millisecond)
+		require.NoError(t, router.Route(assignment("user-1", "card-1")))
+		assert.Len(t, dm.delivered, 2)
+	})
+}
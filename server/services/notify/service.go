@@ -30,6 +30,106 @@ type BlockChangeEvent struct {
 	ModifiedBy   *model.BoardMember
 }
 
+// BoardChangeEvent is sent when the properties of a board, such as its
+// description, change.
+type BoardChangeEvent struct {
+	Action     Action
+	TeamID     string
+	Board      *model.Board
+	BoardOld   *model.Board
+	ModifiedBy *model.BoardMember
+}
+
+// BoardChangeNotifier is implemented by backends that are informed of the
+// changes to the boards themselves.
+type BoardChangeNotifier interface {
+	BoardChanged(evt BoardChangeEvent) error
+}
+
+// MemberChangeEvent is sent when a user is added to a board.
+type MemberChangeEvent struct {
+	TeamID string
+	Board  *model.Board
+	Member *model.BoardMember
+}
+
+// MemberChangeNotifier is implemented by backends that are informed of
+// the users added to boards.
+type MemberChangeNotifier interface {
+	MemberAdded(evt MemberChangeEvent) error
+}
+
+// AdminAccessEvent is sent when a system admin opens a board in admin
+// view, to inform the admins of the board.
+type AdminAccessEvent struct {
+	Board         *model.Board
+	AdminUsername string
+	UserIDs       []string
+}
+
+// AdminAccessNotifier is implemented by backends that can inform users
+// of the accesses of system admins to their boards.
+type AdminAccessNotifier interface {
+	AdminAccessedBoard(evt AdminAccessEvent) error
+}
+
+// BoardFreezeEvent is sent when a scheduled freeze or unfreeze of a board
+// takes effect, to inform its members.
+type BoardFreezeEvent struct {
+	Board   *model.Board
+	Frozen  bool
+	UserIDs []string
+}
+
+// BoardFreezeNotifier is implemented by backends that can inform users of
+// the scheduled freezes and unfreezes of their boards.
+type BoardFreezeNotifier interface {
+	BoardFreezeChanged(evt BoardFreezeEvent) error
+}
+
+// BoardAccessRequestEvent is sent when a user requests access to a board,
+// to inform the admins of the board.
+type BoardAccessRequestEvent struct {
+	Board             *model.Board
+	RequesterUsername string
+	Message           string
+	UserIDs           []string
+}
+
+// BoardAccessRequestNotifier is implemented by backends that can inform
+// users of the requests for access to their boards.
+type BoardAccessRequestNotifier interface {
+	BoardAccessRequested(evt BoardAccessRequestEvent) error
+}
+
+// DueDigestEvent is sent once a day to each user that enabled the due
+// digest, with the cards assigned to them that are due today or overdue.
+type DueDigestEvent struct {
+	UserID string
+	Cards  []*model.DueDigestCard
+}
+
+// DueDigestNotifier is implemented by backends that can deliver the due
+// digests to the users.
+type DueDigestNotifier interface {
+	SendDueDigest(evt DueDigestEvent) error
+}
+
+// StaleDigestEvent is sent once a week to each user that enabled the
+// stale cards digest, with the boards they administer that have cards not
+// updated for Days days.
+type StaleDigestEvent struct {
+	UserID string
+	Days   int
+	Boards []*model.StaleDigestBoard
+}
+
+// StaleDigestNotifier is implemented by backends that can deliver the
+// stale cards digests to the users.
+type StaleDigestNotifier interface {
+	SendStaleDigest(evt StaleDigestEvent) error
+}
+
 type SubscriptionChangeNotifier interface {
 	BroadcastSubscriptionChange(teamID string, subscription *model.Subscription)
 }
@@ -42,6 +142,18 @@ type Backend interface {
 	Name() string
 }
 
+// TestNotifier is implemented by backends that can send a synthetic
+// notification, to check their delivery setup.
+type TestNotifier interface {
+	SendTestNotification(userID string) error
+}
+
+// MentionValidator is implemented by backends that can tell, before a text
+// is saved, the outcome its mentions will have.
+type MentionValidator interface {
+	ValidateMentions(text string, board *model.Board, author *model.BoardMember) ([]*model.MentionValidation, error)
+}
+
 // Service is a service that sends notifications based on block activity using one or more backends.
 type Service struct {
 	mux      sync.RWMutex
@@ -111,6 +223,207 @@ func (s *Service) BlockChanged(evt BlockChangeEvent) {
 	}
 }
 
+// BoardChanged should be called whenever the description of a board
+// changes. The backends that implement BoardChangeNotifier are informed
+// of it.
+func (s *Service) BoardChanged(evt BoardChangeEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		bcn, ok := backend.(BoardChangeNotifier)
+		if !ok {
+			continue
+		}
+		if err := bcn.BoardChanged(evt); err != nil {
+			s.logger.Error("Error delivering board notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("action", string(evt.Action)),
+				mlog.String("board_id", evt.Board.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// MemberAdded should be called whenever a user is added to a board.
+// The backends that implement MemberChangeNotifier are informed of it.
+func (s *Service) MemberAdded(evt MemberChangeEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		mcn, ok := backend.(MemberChangeNotifier)
+		if !ok {
+			continue
+		}
+		if err := mcn.MemberAdded(evt); err != nil {
+			s.logger.Error("Error delivering member notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("board_id", evt.Board.ID),
+				mlog.String("user_id", evt.Member.UserID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// AdminAccessedBoard should be called whenever a system admin opens a
+// board in admin view and its admins asked to be informed. The backends
+// that implement AdminAccessNotifier are informed of it.
+func (s *Service) AdminAccessedBoard(evt AdminAccessEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		aan, ok := backend.(AdminAccessNotifier)
+		if !ok {
+			continue
+		}
+		if err := aan.AdminAccessedBoard(evt); err != nil {
+			s.logger.Error("Error delivering admin access notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("board_id", evt.Board.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// BoardFreezeChanged should be called whenever a scheduled freeze or
+// unfreeze of a board takes effect. The backends that implement
+// BoardFreezeNotifier are informed of it.
+func (s *Service) BoardFreezeChanged(evt BoardFreezeEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		bfn, ok := backend.(BoardFreezeNotifier)
+		if !ok {
+			continue
+		}
+		if err := bfn.BoardFreezeChanged(evt); err != nil {
+			s.logger.Error("Error delivering board freeze notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("board_id", evt.Board.ID),
+				mlog.Bool("frozen", evt.Frozen),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// BoardAccessRequested should be called whenever a user requests access
+// to a board. The backends that implement BoardAccessRequestNotifier are
+// informed of it.
+func (s *Service) BoardAccessRequested(evt BoardAccessRequestEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		barn, ok := backend.(BoardAccessRequestNotifier)
+		if !ok {
+			continue
+		}
+		if err := barn.BoardAccessRequested(evt); err != nil {
+			s.logger.Error("Error delivering board access request notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("board_id", evt.Board.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// SendDueDigest delivers the due digest of a user through the backends
+// that implement DueDigestNotifier.
+func (s *Service) SendDueDigest(evt DueDigestEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		ddn, ok := backend.(DueDigestNotifier)
+		if !ok {
+			continue
+		}
+		if err := ddn.SendDueDigest(evt); err != nil {
+			s.logger.Error("Error delivering due digest",
+				mlog.String("backend", backend.Name()),
+				mlog.String("user_id", evt.UserID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// SendStaleDigest delivers the stale cards digest of a user through the
+// backends that implement StaleDigestNotifier.
+func (s *Service) SendStaleDigest(evt StaleDigestEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		sdn, ok := backend.(StaleDigestNotifier)
+		if !ok {
+			continue
+		}
+		if err := sdn.SendStaleDigest(evt); err != nil {
+			s.logger.Error("Error delivering stale digest",
+				mlog.String("backend", backend.Name()),
+				mlog.String("user_id", evt.UserID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// SendTestNotification sends a synthetic notification to the user through
+// every backend that supports it, and reports the outcome per backend.
+func (s *Service) SendTestNotification(userID string) []*model.TestNotificationResult {
+	s.mux.RLock()
+	backends := make([]Backend, len(s.backends))
+	copy(backends, s.backends)
+	s.mux.RUnlock()
+
+	results := make([]*model.TestNotificationResult, 0, len(backends))
+	for _, backend := range backends {
+		result := &model.TestNotificationResult{Backend: backend.Name()}
+		results = append(results, result)
+
+		tester, ok := backend.(TestNotifier)
+		if !ok {
+			result.Skipped = true
+			continue
+		}
+
+		if err := tester.SendTestNotification(userID); err != nil {
+			s.logger.Error("Error delivering test notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("user_id", userID),
+				mlog.Err(err),
+			)
+			result.Error = err.Error()
+			continue
+		}
+		result.Success = true
+	}
+	return results
+}
+
+// ValidateMentions returns the outcome of the mentions of the text with
+// the first backend that supports it, or no outcome if none does.
+func (s *Service) ValidateMentions(text string, board *model.Board, author *model.BoardMember) ([]*model.MentionValidation, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		if validator, ok := backend.(MentionValidator); ok {
+			return validator.ValidateMentions(text, board, author)
+		}
+	}
+	return []*model.MentionValidation{}, nil
+}
+
 // BroadcastSubscriptionChange sends a websocket message with details of the changed subscription to all
 // connected users in the workspace.
 func (s *Service) BroadcastSubscriptionChange(teamID string, subscription *model.Subscription) {
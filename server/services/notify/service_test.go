@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type testBackend struct {
+	name string
+}
+
+func (b *testBackend) Start() error                            { return nil }
+func (b *testBackend) ShutDown() error                         { return nil }
+func (b *testBackend) BlockChanged(evt BlockChangeEvent) error { return nil }
+func (b *testBackend) Name() string                            { return b.name }
+
+type testNotifierBackend struct {
+	testBackend
+	err   error
+	users []string
+}
+
+func (b *testNotifierBackend) SendTestNotification(userID string) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.users = append(b.users, userID)
+	return nil
+}
+
+type testAdminAccessBackend struct {
+	testBackend
+	events []AdminAccessEvent
+}
+
+func (b *testAdminAccessBackend) AdminAccessedBoard(evt AdminAccessEvent) error {
+	b.events = append(b.events, evt)
+	return nil
+}
+
+func TestAdminAccessedBoard(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+
+	supported := &testAdminAccessBackend{testBackend: testBackend{name: "supported"}}
+	unsupported := &testBackend{name: "unsupported"}
+
+	service, err := New(logger, supported, unsupported)
+	require.NoError(t, err)
+
+	evt := AdminAccessEvent{
+		Board:         &model.Board{ID: "board-id", Title: "Roadmap"},
+		AdminUsername: "sysadmin",
+		UserIDs:       []string{"user-id"},
+	}
+	service.AdminAccessedBoard(evt)
+
+	require.Len(t, supported.events, 1)
+	assert.Equal(t, evt, supported.events[0])
+}
+
+type testBoardFreezeBackend struct {
+	testBackend
+	events []BoardFreezeEvent
+}
+
+func (b *testBoardFreezeBackend) BoardFreezeChanged(evt BoardFreezeEvent) error {
+	b.events = append(b.events, evt)
+	return nil
+}
+
+func TestBoardFreezeChanged(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+
+	supported := &testBoardFreezeBackend{testBackend: testBackend{name: "supported"}}
+	unsupported := &testBackend{name: "unsupported"}
+
+	service, err := New(logger, supported, unsupported)
+	require.NoError(t, err)
+
+	evt := BoardFreezeEvent{
+		Board:   &model.Board{ID: "board-id", Title: "Sprint 12"},
+		Frozen:  true,
+		UserIDs: []string{"user-id"},
+	}
+	service.BoardFreezeChanged(evt)
+
+	require.Len(t, supported.events, 1)
+	assert.Equal(t, evt, supported.events[0])
+}
+
+type testBoardAccessRequestBackend struct {
+	testBackend
+	events []BoardAccessRequestEvent
+}
+
+func (b *testBoardAccessRequestBackend) BoardAccessRequested(evt BoardAccessRequestEvent) error {
+	b.events = append(b.events, evt)
+	return nil
+}
+
+func TestBoardAccessRequested(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+
+	supported := &testBoardAccessRequestBackend{testBackend: testBackend{name: "supported"}}
+	unsupported := &testBackend{name: "unsupported"}
+
+	service, err := New(logger, supported, unsupported)
+	require.NoError(t, err)
+
+	evt := BoardAccessRequestEvent{
+		Board:             &model.Board{ID: "board-id", Title: "Roadmap"},
+		RequesterUsername: "jdoe",
+		Message:           "I joined the team",
+		UserIDs:           []string{"admin-id"},
+	}
+	service.BoardAccessRequested(evt)
+
+	require.Len(t, supported.events, 1)
+	assert.Equal(t, evt, supported.events[0])
+}
+
+func TestSendTestNotification(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+
+	working := &testNotifierBackend{testBackend: testBackend{name: "working"}}
+	failing := &testNotifierBackend{testBackend: testBackend{name: "failing"}, err: errors.New("smtp unreachable")}
+	unsupported := &testBackend{name: "unsupported"}
+
+	service, err := New(logger, working, failing, unsupported)
+	require.NoError(t, err)
+
+	results := service.SendTestNotification("user-id")
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "working", results[0].Backend)
+	assert.True(t, results[0].Success)
+	assert.Empty(t, results[0].Error)
+	assert.Equal(t, []string{"user-id"}, working.users)
+
+	assert.Equal(t, "failing", results[1].Backend)
+	assert.False(t, results[1].Success)
+	assert.Equal(t, "smtp unreachable", results[1].Error)
+
+	assert.Equal(t, "unsupported", results[2].Backend)
+	assert.False(t, results[2].Success)
+	assert.True(t, results[2].Skipped)
+}
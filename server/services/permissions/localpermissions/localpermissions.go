@@ -26,6 +26,13 @@ func New(store permissions.Store, logger *mlog.Logger) *Service {
 	}
 }
 
+// HasPermissionTo always returns false, as there are no system-wide
+// roles in standalone mode. Server administration goes through the
+// admin routes, only available on the local unix socket.
+func (s *Service) HasPermissionTo(userID string, permission *mmModel.Permission) bool {
+	return false
+}
+
 func (s *Service) HasPermissionToTeam(userID, teamID string, permission *mmModel.Permission) bool {
 	if userID == "" || teamID == "" || permission == nil {
 		return false
@@ -33,6 +40,12 @@ func (s *Service) HasPermissionToTeam(userID, teamID string, permission *mmModel
 	return true
 }
 
+// HasPermissionToChannel always returns false, as there are no
+// channels in standalone mode.
+func (s *Service) HasPermissionToChannel(userID, channelID string, permission *mmModel.Permission) bool {
+	return false
+}
+
 func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmModel.Permission) bool {
 	if userID == "" || boardID == "" || permission == nil {
 		return false
@@ -14,6 +14,14 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestHasPermissionTo(t *testing.T) {
+	th := SetupTestHelper(t)
+
+	t.Run("no user has system permissions", func(t *testing.T) {
+		assert.False(t, th.permissions.HasPermissionTo("user-id", model.PermissionManageSystem))
+	})
+}
+
 func TestHasPermissionToTeam(t *testing.T) {
 	th := SetupTestHelper(t)
 
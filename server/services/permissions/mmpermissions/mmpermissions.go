@@ -14,7 +14,9 @@ import (
 )
 
 type APIInterface interface {
+	HasPermissionTo(userID string, permission *mmModel.Permission) bool
 	HasPermissionToTeam(userID string, teamID string, permission *mmModel.Permission) bool
+	HasPermissionToChannel(userID string, channelID string, permission *mmModel.Permission) bool
 	LogError(string, ...interface{})
 }
 
@@ -30,13 +32,41 @@ func New(store permissions.Store, api APIInterface) *Service {
 	}
 }
 
+func (s *Service) HasPermissionTo(userID string, permission *mmModel.Permission) bool {
+	if userID == "" || permission == nil {
+		return false
+	}
+	return s.api.HasPermissionTo(userID, permission)
+}
+
 func (s *Service) HasPermissionToTeam(userID, teamID string, permission *mmModel.Permission) bool {
 	if userID == "" || teamID == "" || permission == nil {
 		return false
 	}
+	if teamID == model.OrganizationTeamID {
+		return s.hasPermissionToOrganization(userID, permission)
+	}
 	return s.api.HasPermissionToTeam(userID, teamID, permission)
 }
 
+// hasPermissionToOrganization checks the permissions on the
+// organization scope, that has no team in the server. Every user but
+// the guests can see it, and only the system admins can add boards to
+// it.
+func (s *Service) hasPermissionToOrganization(userID string, permission *mmModel.Permission) bool {
+	if permission == model.PermissionViewTeam {
+		return s.api.HasPermissionTo(userID, model.PermissionViewMembers)
+	}
+	return s.api.HasPermissionTo(userID, model.PermissionManageSystem)
+}
+
+func (s *Service) HasPermissionToChannel(userID, channelID string, permission *mmModel.Permission) bool {
+	if userID == "" || channelID == "" || permission == nil {
+		return false
+	}
+	return s.api.HasPermissionToChannel(userID, channelID, permission)
+}
+
 func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmModel.Permission) bool {
 	if userID == "" || boardID == "" || permission == nil {
 		return false
@@ -64,7 +94,7 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 
 	// we need to check that the user has permission to see the team
 	// regardless of its local permissions to the board
-	if !s.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
+	if !s.hasPermissionToBoardTeam(userID, board) {
 		return false
 	}
 
@@ -92,3 +122,28 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 		return false
 	}
 }
+
+// hasPermissionToBoardTeam checks that the user can see the team of the
+// board, or one of the teams the board is shared with.
+func (s *Service) hasPermissionToBoardTeam(userID string, board *model.Board) bool {
+	if s.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
+		return true
+	}
+
+	teamIDs, err := s.store.GetSharedTeamIDsForBoard(board.ID)
+	if err != nil {
+		s.api.LogError("error getting shared teams for board",
+			"boardID", board.ID,
+			"userID", userID,
+			"error", err,
+		)
+		return false
+	}
+
+	for _, teamID := range teamIDs {
+		if s.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+			return true
+		}
+	}
+	return false
+}
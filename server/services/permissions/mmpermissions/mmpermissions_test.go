@@ -13,11 +13,67 @@ import (
 )
 
 const (
-	testTeamID  = "team-id"
-	testBoardID = "board-id"
-	testUserID  = "user-id"
+	testTeamID    = "team-id"
+	testBoardID   = "board-id"
+	testChannelID = "channel-id"
+	testUserID    = "user-id"
 )
 
+func TestHasPermissionTo(t *testing.T) {
+	th := SetupTestHelper(t)
+
+	t.Run("empty input should always unauthorize", func(t *testing.T) {
+		assert.False(t, th.permissions.HasPermissionTo("", model.PermissionManageSystem))
+		assert.False(t, th.permissions.HasPermissionTo(testUserID, nil))
+	})
+
+	t.Run("should authorize if the plugin API does", func(t *testing.T) {
+		th.api.EXPECT().
+			HasPermissionTo(testUserID, model.PermissionManageSystem).
+			Return(true).
+			Times(1)
+
+		assert.True(t, th.permissions.HasPermissionTo(testUserID, model.PermissionManageSystem))
+	})
+
+	t.Run("should not authorize if the plugin API doesn't", func(t *testing.T) {
+		th.api.EXPECT().
+			HasPermissionTo(testUserID, model.PermissionManageSystem).
+			Return(false).
+			Times(1)
+
+		assert.False(t, th.permissions.HasPermissionTo(testUserID, model.PermissionManageSystem))
+	})
+}
+
+func TestHasPermissionToChannel(t *testing.T) {
+	th := SetupTestHelper(t)
+
+	t.Run("empty input should always unauthorize", func(t *testing.T) {
+		assert.False(t, th.permissions.HasPermissionToChannel("", testChannelID, model.PermissionReadChannel))
+		assert.False(t, th.permissions.HasPermissionToChannel(testUserID, "", model.PermissionReadChannel))
+		assert.False(t, th.permissions.HasPermissionToChannel(testUserID, testChannelID, nil))
+	})
+
+	t.Run("should authorize if the plugin API does", func(t *testing.T) {
+		th.api.EXPECT().
+			HasPermissionToChannel(testUserID, testChannelID, model.PermissionCreatePost).
+			Return(true).
+			Times(1)
+
+		assert.True(t, th.permissions.HasPermissionToChannel(testUserID, testChannelID, model.PermissionCreatePost))
+	})
+
+	t.Run("should not authorize if the plugin API doesn't", func(t *testing.T) {
+		th.api.EXPECT().
+			HasPermissionToChannel(testUserID, testChannelID, model.PermissionCreatePost).
+			Return(false).
+			Times(1)
+
+		assert.False(t, th.permissions.HasPermissionToChannel(testUserID, testChannelID, model.PermissionCreatePost))
+	})
+}
+
 func TestHasPermissionsToTeam(t *testing.T) {
 	th := SetupTestHelper(t)
 
@@ -52,6 +108,40 @@ func TestHasPermissionsToTeam(t *testing.T) {
 		hasPermission := th.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam)
 		assert.False(t, hasPermission)
 	})
+
+	t.Run("organization is visible to the users that can see other users", func(t *testing.T) {
+		th.api.EXPECT().
+			HasPermissionTo(testUserID, model.PermissionViewMembers).
+			Return(true).
+			Times(1)
+
+		assert.True(t, th.permissions.HasPermissionToTeam(testUserID, model.OrganizationTeamID, model.PermissionViewTeam))
+	})
+
+	t.Run("organization is not visible to guests", func(t *testing.T) {
+		th.api.EXPECT().
+			HasPermissionTo(testUserID, model.PermissionViewMembers).
+			Return(false).
+			Times(1)
+
+		assert.False(t, th.permissions.HasPermissionToTeam(testUserID, model.OrganizationTeamID, model.PermissionViewTeam))
+	})
+
+	t.Run("only system admins can create boards in the organization", func(t *testing.T) {
+		th.api.EXPECT().
+			HasPermissionTo(testUserID, model.PermissionManageSystem).
+			Return(false).
+			Times(1)
+
+		assert.False(t, th.permissions.HasPermissionToTeam(testUserID, model.OrganizationTeamID, model.PermissionCreatePublicChannel))
+
+		th.api.EXPECT().
+			HasPermissionTo(testUserID, model.PermissionManageSystem).
+			Return(true).
+			Times(1)
+
+		assert.True(t, th.permissions.HasPermissionToTeam(testUserID, model.OrganizationTeamID, model.PermissionCreatePublicChannel))
+	})
 }
 
 // test case for user removed.
@@ -103,6 +193,65 @@ func TestHasPermissionToBoard(t *testing.T) {
 		assert.False(t, hasPermission)
 	})
 
+	t.Run("member of a team the board is shared with", func(t *testing.T) {
+		member := &model.BoardMember{
+			UserID:       userID,
+			BoardID:      boardID,
+			SchemeViewer: true,
+		}
+
+		th.store.EXPECT().
+			GetBoard(boardID).
+			Return(&model.Board{ID: boardID, TeamID: teamID}, nil).
+			Times(1)
+
+		th.api.EXPECT().
+			HasPermissionToTeam(userID, teamID, model.PermissionViewTeam).
+			Return(false).
+			Times(1)
+
+		th.store.EXPECT().
+			GetSharedTeamIDsForBoard(boardID).
+			Return([]string{"other-team-id"}, nil).
+			Times(1)
+
+		th.api.EXPECT().
+			HasPermissionToTeam(userID, "other-team-id", model.PermissionViewTeam).
+			Return(true).
+			Times(1)
+
+		th.store.EXPECT().
+			GetMemberForBoard(boardID, userID).
+			Return(member, nil).
+			Times(1)
+
+		assert.True(t, th.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard))
+	})
+
+	t.Run("member of none of the teams of the board", func(t *testing.T) {
+		th.store.EXPECT().
+			GetBoard(boardID).
+			Return(&model.Board{ID: boardID, TeamID: teamID}, nil).
+			Times(1)
+
+		th.api.EXPECT().
+			HasPermissionToTeam(userID, teamID, model.PermissionViewTeam).
+			Return(false).
+			Times(1)
+
+		th.store.EXPECT().
+			GetSharedTeamIDsForBoard(boardID).
+			Return([]string{"other-team-id"}, nil).
+			Times(1)
+
+		th.api.EXPECT().
+			HasPermissionToTeam(userID, "other-team-id", model.PermissionViewTeam).
+			Return(false).
+			Times(1)
+
+		assert.False(t, th.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard))
+	})
+
 	t.Run("user that has been removed from the team", func(t *testing.T) {
 		member := &model.BoardMember{
 			UserID:      userID,
@@ -78,3 +78,18 @@ func (mr *MockStoreMockRecorder) GetMemberForBoard(arg0, arg1 interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberForBoard", reflect.TypeOf((*MockStore)(nil).GetMemberForBoard), arg0, arg1)
 }
+
+// GetSharedTeamIDsForBoard mocks base method.
+func (m *MockStore) GetSharedTeamIDsForBoard(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedTeamIDsForBoard", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedTeamIDsForBoard indicates an expected call of GetSharedTeamIDsForBoard.
+func (mr *MockStoreMockRecorder) GetSharedTeamIDsForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedTeamIDsForBoard", reflect.TypeOf((*MockStore)(nil).GetSharedTeamIDsForBoard), arg0)
+}
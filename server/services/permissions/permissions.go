@@ -11,12 +11,15 @@ import (
 )
 
 type PermissionsService interface {
+	HasPermissionTo(userID string, permission *mmModel.Permission) bool
 	HasPermissionToTeam(userID, teamID string, permission *mmModel.Permission) bool
 	HasPermissionToBoard(userID, boardID string, permission *mmModel.Permission) bool
+	HasPermissionToChannel(userID, channelID string, permission *mmModel.Permission) bool
 }
 
 type Store interface {
 	GetBoard(boardID string) (*model.Board, error)
 	GetMemberForBoard(boardID, userID string) (*model.BoardMember, error)
+	GetSharedTeamIDsForBoard(boardID string) ([]string, error)
 	GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error)
 }
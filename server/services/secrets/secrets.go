@@ -0,0 +1,179 @@
+// Package secrets encrypts the secrets the server stores, like the
+// secrets of the board webhooks and the access tokens of the
+// integrations, so they are never kept in plaintext.
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/services/config"
+)
+
+// Prefix starts the encrypted values, telling them apart from the
+// plaintext values stored before the encryption was configured.
+const Prefix = "enc:v1:"
+
+var (
+	ErrNotConfigured = errors.New("no secrets encryption key is configured")
+	ErrInvalidSecret = errors.New("the encrypted secret is invalid")
+)
+
+// Service encrypts and decrypts the secrets with AES-256-GCM. The
+// encrypted values are the nonce and the sealed secret, encoded in base64
+// after the Prefix.
+type Service struct {
+	key []byte
+
+	// previousKeys still decrypt the secrets encrypted before the key
+	// was changed.
+	previousKeys [][]byte
+}
+
+// New creates the service for the configuration. The key is the Key of
+// the configuration, or the output of its KeyCommand; without either,
+// the encryption key of the GitHub integration is used, so the tokens it
+// encrypted stay readable. A nil service is returned when no key is
+// configured.
+func New(cfg config.SecretsConfig, gitHubKey string) (*Service, error) {
+	key := cfg.Key
+	if key == "" && cfg.KeyCommand != "" {
+		var err error
+		if key, err = runKeyCommand(cfg.KeyCommand); err != nil {
+			return nil, err
+		}
+	}
+
+	previous := cfg.PreviousKeys
+	if key == "" {
+		key = gitHubKey
+	} else if gitHubKey != "" && gitHubKey != key {
+		previous = append(previous, gitHubKey)
+	}
+	if key == "" {
+		return nil, nil
+	}
+
+	s := &Service{key: deriveKey(key)}
+	for _, k := range previous {
+		if k != "" {
+			s.previousKeys = append(s.previousKeys, deriveKey(k))
+		}
+	}
+	return s, nil
+}
+
+// runKeyCommand returns the output of the command, without the trailing
+// new lines, e.g. to decrypt the key with a KMS. The command is split on
+// spaces and run without shell.
+func runKeyCommand(command string) (string, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return "", errors.New("the secrets key command is empty")
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command(args[0], args[1:]...) //nolint:gosec
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("cannot get the secrets key from its command: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	key := strings.TrimRight(string(out), "\r\n")
+	if key == "" {
+		return "", errors.New("the secrets key command printed no key")
+	}
+	return key, nil
+}
+
+// deriveKey stretches a configured key to an AES-256 key.
+func deriveKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// IsEnabled returns true if the secrets can be encrypted.
+func (s *Service) IsEnabled() bool {
+	return s != nil && len(s.key) > 0
+}
+
+// IsEncrypted returns true if the value was encrypted by a Service.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Encrypt encrypts a secret. Empty secrets stay empty.
+func (s *Service) Encrypt(secret string) (string, error) {
+	if !s.IsEnabled() {
+		return "", ErrNotConfigured
+	}
+	if secret == "" {
+		return "", nil
+	}
+	gcm, err := newGCM(s.key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return Prefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a secret encrypted by Encrypt, with the key or one of
+// the previous keys. Values that aren't encrypted are returned as they
+// are, being stored before the encryption was configured.
+func (s *Service) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if !s.IsEnabled() {
+		return "", ErrNotConfigured
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, Prefix))
+	if err != nil {
+		return "", ErrInvalidSecret
+	}
+	for _, key := range append([][]byte{s.key}, s.previousKeys...) {
+		gcm, err := newGCM(key)
+		if err != nil {
+			return "", err
+		}
+		if len(sealed) < gcm.NonceSize() {
+			return "", ErrInvalidSecret
+		}
+		if secret, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil); err == nil {
+			return string(secret), nil
+		}
+	}
+	return "", ErrInvalidSecret
+}
+
+// EncryptIfEnabled encrypts the secret if a key is configured, and
+// returns it as it is otherwise.
+func (s *Service) EncryptIfEnabled(secret string) (string, error) {
+	if !s.IsEnabled() {
+		return secret, nil
+	}
+	return s.Encrypt(secret)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,105 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/services/config"
+)
+
+func TestEncryption(t *testing.T) {
+	s, err := New(config.SecretsConfig{Key: "encryption-key"}, "")
+	require.NoError(t, err)
+	require.True(t, s.IsEnabled())
+
+	encrypted, err := s.Encrypt("ghp_token")
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(encrypted))
+	require.NotContains(t, encrypted, "ghp_token")
+
+	secret, err := s.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "ghp_token", secret)
+
+	t.Run("plaintext values are returned as they are", func(t *testing.T) {
+		secret, err := s.Decrypt("plaintext")
+		require.NoError(t, err)
+		require.Equal(t, "plaintext", secret)
+
+		empty, err := s.Encrypt("")
+		require.NoError(t, err)
+		require.Empty(t, empty)
+	})
+
+	t.Run("another key can't decrypt the secret", func(t *testing.T) {
+		other, err := New(config.SecretsConfig{Key: "other-key"}, "")
+		require.NoError(t, err)
+		_, err = other.Decrypt(encrypted)
+		require.ErrorIs(t, err, ErrInvalidSecret)
+	})
+
+	t.Run("the previous keys decrypt the secret", func(t *testing.T) {
+		rotated, err := New(config.SecretsConfig{Key: "new-key", PreviousKeys: []string{"encryption-key"}}, "")
+		require.NoError(t, err)
+		secret, err := rotated.Decrypt(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, "ghp_token", secret)
+	})
+
+	t.Run("the GitHub key is used without secrets key", func(t *testing.T) {
+		github, err := New(config.SecretsConfig{}, "encryption-key")
+		require.NoError(t, err)
+		secret, err := github.Decrypt(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, "ghp_token", secret)
+
+		withKey, err := New(config.SecretsConfig{Key: "new-key"}, "encryption-key")
+		require.NoError(t, err)
+		secret, err = withKey.Decrypt(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, "ghp_token", secret)
+	})
+
+	t.Run("tampered secret", func(t *testing.T) {
+		_, err := s.Decrypt(Prefix + "not base64!")
+		require.ErrorIs(t, err, ErrInvalidSecret)
+		_, err = s.Decrypt(strings.TrimSuffix(encrypted, encrypted[len(encrypted)-4:]) + "AAAA")
+		require.ErrorIs(t, err, ErrInvalidSecret)
+	})
+
+	t.Run("without key the encryption is disabled", func(t *testing.T) {
+		disabled, err := New(config.SecretsConfig{}, "")
+		require.NoError(t, err)
+		require.False(t, disabled.IsEnabled())
+
+		_, err = disabled.Encrypt("ghp_token")
+		require.ErrorIs(t, err, ErrNotConfigured)
+		_, err = disabled.Decrypt(encrypted)
+		require.ErrorIs(t, err, ErrNotConfigured)
+
+		secret, err := disabled.EncryptIfEnabled("ghp_token")
+		require.NoError(t, err)
+		require.Equal(t, "ghp_token", secret)
+	})
+}
+
+func TestKeyCommand(t *testing.T) {
+	s, err := New(config.SecretsConfig{KeyCommand: "echo encryption-key"}, "")
+	require.NoError(t, err)
+	direct, err := New(config.SecretsConfig{Key: "encryption-key"}, "")
+	require.NoError(t, err)
+
+	encrypted, err := direct.Encrypt("secret")
+	require.NoError(t, err)
+	secret, err := s.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "secret", secret)
+
+	_, err = New(config.SecretsConfig{KeyCommand: "false"}, "")
+	require.Error(t, err)
+
+	_, err = New(config.SecretsConfig{KeyCommand: "  "}, "")
+	require.Error(t, err)
+}
@@ -83,6 +83,11 @@ var blacklistedStoreMethodNames = map[string]bool{
 	"Shutdown":      true,
 	"IsErrNotFound": true,
 	"DBType":        true,
+	// RunInTransaction binds a copy of the store to a transaction, so it
+	// is written by hand.
+	"RunInTransaction": true,
+	// WithContext binds a copy of the store to a context.
+	"WithContext": true,
 }
 
 func extractMethodMetadata(method *ast.Field, src []byte) methodData {
@@ -1,6 +1,7 @@
 package mattermostauthlayer
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 
@@ -31,6 +32,10 @@ type MattermostAuthLayer struct {
 	mmDB      *sql.DB
 	logger    *mlog.Logger
 	pluginAPI plugin.API
+
+	// ctx is the context the queries of the layer run with, set by
+	// WithContext.
+	ctx context.Context
 }
 
 // New creates a new SQL implementation of the store.
@@ -51,13 +56,39 @@ func (s *MattermostAuthLayer) Shutdown() error {
 	return s.Store.Shutdown()
 }
 
+// RunInTransaction runs fn in a transaction of the underlying store,
+// keeping the users from the Mattermost database.
+func (s *MattermostAuthLayer) RunInTransaction(fn func(tx store.Store) error) error {
+	return s.Store.RunInTransaction(func(tx store.Store) error {
+		layer := *s
+		layer.Store = tx
+		return fn(&layer)
+	})
+}
+
+// WithContext returns a copy of the layer whose queries, and those of the
+// underlying store, run with ctx.
+func (s *MattermostAuthLayer) WithContext(ctx context.Context) store.Store {
+	layer := *s
+	layer.Store = s.Store.WithContext(ctx)
+	layer.ctx = ctx
+	return &layer
+}
+
+func (s *MattermostAuthLayer) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
 func (s *MattermostAuthLayer) GetRegisteredUserCount() (int, error) {
 	query := s.getQueryBuilder().
 		Select("count(*)").
 		From("Users").
 		Where(sq.Eq{"deleteAt": 0}).
 		Where(sq.NotEq{"roles": "system_guest"})
-	row := query.QueryRow()
+	row := query.QueryRowContext(s.context())
 
 	var count int
 	err := row.Scan(&count)
@@ -95,6 +126,29 @@ func (s *MattermostAuthLayer) GetUserByUsername(username string) (*model.User, e
 	return &user, nil
 }
 
+// GetUsersByIDs returns the users with the IDs, in any order. The users
+// not found are left out.
+func (s *MattermostAuthLayer) GetUsersByIDs(userIDs []string) ([]*model.User, error) {
+	if len(userIDs) == 0 {
+		return []*model.User{}, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select("u.id", "u.username", "u.props", "u.CreateAt as create_at", "u.UpdateAt as update_at",
+			"u.DeleteAt as delete_at", "b.UserId IS NOT NULL AS is_bot").
+		From("Users as u").
+		LeftJoin("Bots b ON ( b.UserId = u.id )").
+		Where(sq.Eq{"u.id": userIDs})
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.usersFromRows(rows)
+}
+
 func (s *MattermostAuthLayer) CreateUser(user *model.User) error {
 	return NotSupportedError{"no user creation allowed from focalboard, create it using mattermost"}
 }
@@ -111,6 +165,10 @@ func (s *MattermostAuthLayer) UpdateUserPasswordByID(userID, password string) er
 	return NotSupportedError{"no update allowed from focalboard, update it using mattermost"}
 }
 
+func (s *MattermostAuthLayer) UpdateUserDeleteAt(username string, deleteAt int64) error {
+	return NotSupportedError{"no update allowed from focalboard, update it using mattermost"}
+}
+
 func (s *MattermostAuthLayer) PatchUserProps(userID string, patch model.UserPropPatch) error {
 	user, err := s.pluginAPI.GetUser(userID)
 	if err != nil {
@@ -145,7 +203,7 @@ func (s *MattermostAuthLayer) GetActiveUserCount(updatedSecondsAgo int64) (int,
 		From("Sessions").
 		Where(sq.Gt{"LastActivityAt": utils.GetMillis() - utils.SecondsToMillis(updatedSecondsAgo)})
 
-	row := query.QueryRow()
+	row := query.QueryRowContext(s.context())
 
 	var count int
 	err := row.Scan(&count)
@@ -176,6 +234,10 @@ func (s *MattermostAuthLayer) DeleteSession(sessionID string) error {
 	return NotSupportedError{"no update allowed from focalboard, update it using mattermost"}
 }
 
+func (s *MattermostAuthLayer) DeleteSessionsForUser(userID string) error {
+	return NotSupportedError{"no update allowed from focalboard, update it using mattermost"}
+}
+
 func (s *MattermostAuthLayer) CleanUpSessions(expireTime int64) error {
 	return NotSupportedError{"no update allowed from focalboard, update it using mattermost"}
 }
@@ -195,7 +257,7 @@ func (s *MattermostAuthLayer) GetTeam(id string) (*model.Team, error) {
 		From("Teams").
 		Where(sq.Eq{"ID": id})
 
-	row := query.QueryRow()
+	row := query.QueryRowContext(s.context())
 	var displayName string
 	err := row.Scan(&displayName)
 	if err != nil && !s.IsErrNotFound(err) {
@@ -217,7 +279,7 @@ func (s *MattermostAuthLayer) GetTeamsForUser(userID string) ([]*model.Team, err
 		Join("TeamMembers as tm on t.Id=tm.TeamId").
 		Where(sq.Eq{"tm.UserId": userID})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		return nil, err
 	}
@@ -261,7 +323,7 @@ func (s *MattermostAuthLayer) GetUsersByTeam(teamID string) ([]*model.User, erro
 		Where(sq.NotEq{"u.roles": "system_guest"}).
 		Where(sq.Eq{"tm.TeamId": teamID})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		return nil, err
 	}
@@ -294,7 +356,7 @@ func (s *MattermostAuthLayer) SearchUsersByTeam(teamID string, searchQuery strin
 		OrderBy("u.username").
 		Limit(10)
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		return nil, err
 	}
@@ -339,6 +401,51 @@ func (s *MattermostAuthLayer) usersFromRows(rows *sql.Rows) ([]*model.User, erro
 	return users, nil
 }
 
+// GetChannelMemberIDs returns the IDs of the active users that are
+// members of the channel.
+func (s *MattermostAuthLayer) GetChannelMemberIDs(channelID string) ([]string, error) {
+	query := s.getQueryBuilder().
+		Select("cm.UserId").
+		From("ChannelMembers as cm").
+		Join("Users as u ON u.Id = cm.UserId").
+		Where(sq.Eq{"cm.ChannelId": channelID}).
+		Where(sq.Eq{"u.DeleteAt": 0})
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	userIDs := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+// GetChannel returns the channel if it exists and hasn't been deleted.
+func (s *MattermostAuthLayer) GetChannel(channelID string) (*mmModel.Channel, error) {
+	query := s.getQueryBuilder().
+		Select("Id", "TeamId", "Type", "DisplayName", "Name").
+		From("Channels").
+		Where(sq.Eq{"Id": channelID}).
+		Where(sq.Eq{"DeleteAt": 0})
+
+	var channel mmModel.Channel
+	err := query.QueryRowContext(s.context()).Scan(&channel.Id, &channel.TeamId, &channel.Type, &channel.DisplayName, &channel.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &channel, nil
+}
+
 func (s *MattermostAuthLayer) CloseRows(rows *sql.Rows) {
 	if err := rows.Close(); err != nil {
 		s.logger.Error("error closing MattermostAuthLayer row set", mlog.Err(err))
@@ -380,6 +487,7 @@ func mmUserToFbUser(mmUser *mmModel.User) model.User {
 		DeleteAt:    mmUser.DeleteAt,
 		IsBot:       mmUser.IsBot,
 		IsGuest:     mmUser.IsGuest(),
+		Timezone:    mmUser.GetPreferredTimezone(),
 	}
 }
 
@@ -5,11 +5,13 @@
 package mockstore
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/mattermost/focalboard/server/model"
+	store "github.com/mattermost/focalboard/server/services/store"
 	model0 "github.com/mattermost/mattermost-server/v6/model"
 )
 
@@ -31,11 +33,240 @@ func NewMockStore(ctrl *gomock.Controller) *MockStore {
 	return mock
 }
 
+// AcquireBlockLock mocks base method.
+func (m *MockStore) AcquireBlockLock(arg0 *model.BlockLock) (*model.BlockLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireBlockLock", arg0)
+	ret0, _ := ret[0].(*model.BlockLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireBlockLock indicates an expected call of AcquireBlockLock.
+func (mr *MockStoreMockRecorder) AcquireBlockLock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireBlockLock", reflect.TypeOf((*MockStore)(nil).AcquireBlockLock), arg0)
+}
+
+// ApproveBoardAccessRequest mocks base method.
+func (m *MockStore) ApproveBoardAccessRequest(arg0 *model.BoardMember) (*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveBoardAccessRequest", arg0)
+	ret0, _ := ret[0].(*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveBoardAccessRequest indicates an expected call of ApproveBoardAccessRequest.
+func (mr *MockStoreMockRecorder) ApproveBoardAccessRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveBoardAccessRequest", reflect.TypeOf((*MockStore)(nil).ApproveBoardAccessRequest), arg0)
+}
+
+// ClaimDeferredNotification mocks base method.
+func (m *MockStore) ClaimDeferredNotification(arg0, arg1 string, arg2 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeferredNotification", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeferredNotification indicates an expected call of ClaimDeferredNotification.
+func (mr *MockStoreMockRecorder) ClaimDeferredNotification(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeferredNotification", reflect.TypeOf((*MockStore)(nil).ClaimDeferredNotification), arg0, arg1, arg2)
+}
+
+// CreateBoardAccessRequest mocks base method.
+func (m *MockStore) CreateBoardAccessRequest(arg0 *model.BoardAccessRequest) (*model.BoardAccessRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardAccessRequest", arg0)
+	ret0, _ := ret[0].(*model.BoardAccessRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardAccessRequest indicates an expected call of CreateBoardAccessRequest.
+func (mr *MockStoreMockRecorder) CreateBoardAccessRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardAccessRequest", reflect.TypeOf((*MockStore)(nil).CreateBoardAccessRequest), arg0)
+}
+
+// CreateCategoryRule mocks base method.
+func (m *MockStore) CreateCategoryRule(arg0 *model.CategoryRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategoryRule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCategoryRule indicates an expected call of CreateCategoryRule.
+func (mr *MockStoreMockRecorder) CreateCategoryRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryRule", reflect.TypeOf((*MockStore)(nil).CreateCategoryRule), arg0)
+}
+
+// CreatePropertyIndex mocks base method.
+func (m *MockStore) CreatePropertyIndex(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePropertyIndex", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePropertyIndex indicates an expected call of CreatePropertyIndex.
+func (mr *MockStoreMockRecorder) CreatePropertyIndex(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePropertyIndex", reflect.TypeOf((*MockStore)(nil).CreatePropertyIndex), arg0, arg1)
+}
+
+// DeleteBoardAccessRequest mocks base method.
+func (m *MockStore) DeleteBoardAccessRequest(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardAccessRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardAccessRequest indicates an expected call of DeleteBoardAccessRequest.
+func (mr *MockStoreMockRecorder) DeleteBoardAccessRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardAccessRequest", reflect.TypeOf((*MockStore)(nil).DeleteBoardAccessRequest), arg0, arg1, arg2)
+}
+
+// DeleteBoardFreezeSchedule mocks base method.
+func (m *MockStore) DeleteBoardFreezeSchedule(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardFreezeSchedule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardFreezeSchedule indicates an expected call of DeleteBoardFreezeSchedule.
+func (mr *MockStoreMockRecorder) DeleteBoardFreezeSchedule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardFreezeSchedule", reflect.TypeOf((*MockStore)(nil).DeleteBoardFreezeSchedule), arg0)
+}
+
+// DeleteBoardWebhook mocks base method.
+func (m *MockStore) DeleteBoardWebhook(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardWebhook indicates an expected call of DeleteBoardWebhook.
+func (mr *MockStoreMockRecorder) DeleteBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardWebhook", reflect.TypeOf((*MockStore)(nil).DeleteBoardWebhook), arg0)
+}
+
+// DeleteCalendarSubscription mocks base method.
+func (m *MockStore) DeleteCalendarSubscription(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCalendarSubscription", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCalendarSubscription indicates an expected call of DeleteCalendarSubscription.
+func (mr *MockStoreMockRecorder) DeleteCalendarSubscription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendarSubscription", reflect.TypeOf((*MockStore)(nil).DeleteCalendarSubscription), arg0)
+}
+
+// DeleteCategoryRule mocks base method.
+func (m *MockStore) DeleteCategoryRule(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategoryRule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategoryRule indicates an expected call of DeleteCategoryRule.
+func (mr *MockStoreMockRecorder) DeleteCategoryRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategoryRule", reflect.TypeOf((*MockStore)(nil).DeleteCategoryRule), arg0, arg1)
+}
+
+// DeleteDeferredNotification mocks base method.
+func (m *MockStore) DeleteDeferredNotification(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeferredNotification", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeferredNotification indicates an expected call of DeleteDeferredNotification.
+func (mr *MockStoreMockRecorder) DeleteDeferredNotification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeferredNotification", reflect.TypeOf((*MockStore)(nil).DeleteDeferredNotification), arg0)
+}
+
+// DeleteGitHubIntegration mocks base method.
+func (m *MockStore) DeleteGitHubIntegration(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGitHubIntegration", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGitHubIntegration indicates an expected call of DeleteGitHubIntegration.
+func (mr *MockStoreMockRecorder) DeleteGitHubIntegration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGitHubIntegration", reflect.TypeOf((*MockStore)(nil).DeleteGitHubIntegration), arg0)
+}
+
+// DeletePropertyIndex mocks base method.
+func (m *MockStore) DeletePropertyIndex(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePropertyIndex", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePropertyIndex indicates an expected call of DeletePropertyIndex.
+func (mr *MockStoreMockRecorder) DeletePropertyIndex(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePropertyIndex", reflect.TypeOf((*MockStore)(nil).DeletePropertyIndex), arg0, arg1)
+}
+
 // EXPECT returns an object that allows the caller to indicate expected use.
 func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcceptBoardInvitation mocks base method.
+func (m *MockStore) AcceptBoardInvitation(arg0, arg1 string) (*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptBoardInvitation", arg0, arg1)
+	ret0, _ := ret[0].(*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptBoardInvitation indicates an expected call of AcceptBoardInvitation.
+func (mr *MockStoreMockRecorder) AcceptBoardInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptBoardInvitation", reflect.TypeOf((*MockStore)(nil).AcceptBoardInvitation), arg0, arg1)
+}
+
+// AddBoardToTeam mocks base method.
+func (m *MockStore) AddBoardToTeam(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBoardToTeam", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBoardToTeam indicates an expected call of AddBoardToTeam.
+func (mr *MockStoreMockRecorder) AddBoardToTeam(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBoardToTeam", reflect.TypeOf((*MockStore)(nil).AddBoardToTeam), arg0, arg1, arg2)
+}
+
 // AddUpdateCategoryBoard mocks base method.
 func (m *MockStore) AddUpdateCategoryBoard(arg0, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -64,6 +295,36 @@ func (mr *MockStoreMockRecorder) CleanUpSessions(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanUpSessions", reflect.TypeOf((*MockStore)(nil).CleanUpSessions), arg0)
 }
 
+// CleanUpStaleSubscriptions mocks base method.
+func (m *MockStore) CleanUpStaleSubscriptions() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanUpStaleSubscriptions")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanUpStaleSubscriptions indicates an expected call of CleanUpStaleSubscriptions.
+func (mr *MockStoreMockRecorder) CleanUpStaleSubscriptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanUpStaleSubscriptions", reflect.TypeOf((*MockStore)(nil).CleanUpStaleSubscriptions))
+}
+
+// CreateBoardInvitation mocks base method.
+func (m *MockStore) CreateBoardInvitation(arg0 *model.BoardInvitation) (*model.BoardInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardInvitation", arg0)
+	ret0, _ := ret[0].(*model.BoardInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardInvitation indicates an expected call of CreateBoardInvitation.
+func (mr *MockStoreMockRecorder) CreateBoardInvitation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardInvitation", reflect.TypeOf((*MockStore)(nil).CreateBoardInvitation), arg0)
+}
+
 // CreateBoardsAndBlocks mocks base method.
 func (m *MockStore) CreateBoardsAndBlocks(arg0 *model.BoardsAndBlocks, arg1 string) (*model.BoardsAndBlocks, error) {
 	m.ctrl.T.Helper()
@@ -194,6 +455,20 @@ func (mr *MockStoreMockRecorder) DeleteBoard(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoard", reflect.TypeOf((*MockStore)(nil).DeleteBoard), arg0, arg1)
 }
 
+// DeleteBoardInvitation mocks base method.
+func (m *MockStore) DeleteBoardInvitation(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardInvitation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardInvitation indicates an expected call of DeleteBoardInvitation.
+func (mr *MockStoreMockRecorder) DeleteBoardInvitation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardInvitation", reflect.TypeOf((*MockStore)(nil).DeleteBoardInvitation), arg0, arg1, arg2)
+}
+
 // DeleteBoardsAndBlocks mocks base method.
 func (m *MockStore) DeleteBoardsAndBlocks(arg0 *model.DeleteBoardsAndBlocks, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -264,6 +539,20 @@ func (mr *MockStoreMockRecorder) DeleteSession(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), arg0)
 }
 
+// DeleteSessionsForUser mocks base method.
+func (m *MockStore) DeleteSessionsForUser(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionsForUser", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionsForUser indicates an expected call of DeleteSessionsForUser.
+func (mr *MockStoreMockRecorder) DeleteSessionsForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionsForUser", reflect.TypeOf((*MockStore)(nil).DeleteSessionsForUser), arg0)
+}
+
 // DeleteSubscription mocks base method.
 func (m *MockStore) DeleteSubscription(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -278,6 +567,20 @@ func (mr *MockStoreMockRecorder) DeleteSubscription(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockStore)(nil).DeleteSubscription), arg0, arg1)
 }
 
+// DeleteWebhookDelivery mocks base method.
+func (m *MockStore) DeleteWebhookDelivery(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookDelivery", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhookDelivery indicates an expected call of DeleteWebhookDelivery.
+func (mr *MockStoreMockRecorder) DeleteWebhookDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookDelivery", reflect.TypeOf((*MockStore)(nil).DeleteWebhookDelivery), arg0)
+}
+
 // DuplicateBlock mocks base method.
 func (m *MockStore) DuplicateBlock(arg0, arg1, arg2 string, arg3 bool) ([]model.Block, error) {
 	m.ctrl.T.Helper()
@@ -294,9 +597,9 @@ func (mr *MockStoreMockRecorder) DuplicateBlock(arg0, arg1, arg2, arg3 interface
 }
 
 // DuplicateBoard mocks base method.
-func (m *MockStore) DuplicateBoard(arg0, arg1, arg2 string, arg3 bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+func (m *MockStore) DuplicateBoard(arg0, arg1, arg2 string, arg3 bool, arg4 model.UserMapping) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DuplicateBoard", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "DuplicateBoard", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(*model.BoardsAndBlocks)
 	ret1, _ := ret[1].([]*model.BoardMember)
 	ret2, _ := ret[2].(error)
@@ -304,9 +607,24 @@ func (m *MockStore) DuplicateBoard(arg0, arg1, arg2 string, arg3 bool) (*model.B
 }
 
 // DuplicateBoard indicates an expected call of DuplicateBoard.
-func (mr *MockStoreMockRecorder) DuplicateBoard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) DuplicateBoard(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateBoard", reflect.TypeOf((*MockStore)(nil).DuplicateBoard), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetAccessRequestsForBoard mocks base method.
+func (m *MockStore) GetAccessRequestsForBoard(arg0 string) ([]*model.BoardAccessRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessRequestsForBoard", arg0)
+	ret0, _ := ret[0].([]*model.BoardAccessRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccessRequestsForBoard indicates an expected call of GetAccessRequestsForBoard.
+func (mr *MockStoreMockRecorder) GetAccessRequestsForBoard(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateBoard", reflect.TypeOf((*MockStore)(nil).DuplicateBoard), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessRequestsForBoard", reflect.TypeOf((*MockStore)(nil).GetAccessRequestsForBoard), arg0)
 }
 
 // GetActiveUserCount mocks base method.
@@ -399,6 +717,66 @@ func (mr *MockStoreMockRecorder) GetBlockHistoryDescendants(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHistoryDescendants", reflect.TypeOf((*MockStore)(nil).GetBlockHistoryDescendants), arg0, arg1)
 }
 
+// GetBlockLock mocks base method.
+func (m *MockStore) GetBlockLock(arg0 string) (*model.BlockLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockLock", arg0)
+	ret0, _ := ret[0].(*model.BlockLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockLock indicates an expected call of GetBlockLock.
+func (mr *MockStoreMockRecorder) GetBlockLock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockLock", reflect.TypeOf((*MockStore)(nil).GetBlockLock), arg0)
+}
+
+// GetBlockLocksForBoard mocks base method.
+func (m *MockStore) GetBlockLocksForBoard(arg0 string, arg1 int64) ([]*model.BlockLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockLocksForBoard", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BlockLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockLocksForBoard indicates an expected call of GetBlockLocksForBoard.
+func (mr *MockStoreMockRecorder) GetBlockLocksForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockLocksForBoard", reflect.TypeOf((*MockStore)(nil).GetBlockLocksForBoard), arg0, arg1)
+}
+
+// GetBlocksByIDs mocks base method.
+func (m *MockStore) GetBlocksByIDs(arg0 []string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksByIDs", arg0)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksByIDs indicates an expected call of GetBlocksByIDs.
+func (mr *MockStoreMockRecorder) GetBlocksByIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksByIDs", reflect.TypeOf((*MockStore)(nil).GetBlocksByIDs), arg0)
+}
+
+// GetBlocksChangedSince mocks base method.
+func (m *MockStore) GetBlocksChangedSince(arg0 string, arg1 int64) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksChangedSince", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksChangedSince indicates an expected call of GetBlocksChangedSince.
+func (mr *MockStoreMockRecorder) GetBlocksChangedSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksChangedSince", reflect.TypeOf((*MockStore)(nil).GetBlocksChangedSince), arg0, arg1)
+}
+
 // GetBlocksForBoard mocks base method.
 func (m *MockStore) GetBlocksForBoard(arg0 string) ([]model.Block, error) {
 	m.ctrl.T.Helper()
@@ -489,6 +867,21 @@ func (mr *MockStoreMockRecorder) GetBoard(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockStore)(nil).GetBoard), arg0)
 }
 
+// GetBoardAccessRequest mocks base method.
+func (m *MockStore) GetBoardAccessRequest(arg0, arg1 string) (*model.BoardAccessRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardAccessRequest", arg0, arg1)
+	ret0, _ := ret[0].(*model.BoardAccessRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardAccessRequest indicates an expected call of GetBoardAccessRequest.
+func (mr *MockStoreMockRecorder) GetBoardAccessRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAccessRequest", reflect.TypeOf((*MockStore)(nil).GetBoardAccessRequest), arg0, arg1)
+}
+
 // GetBoardAndCard mocks base method.
 func (m *MockStore) GetBoardAndCard(arg0 *model.Block) (*model.Board, *model.Block, error) {
 	m.ctrl.T.Helper()
@@ -521,6 +914,36 @@ func (mr *MockStoreMockRecorder) GetBoardAndCardByID(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAndCardByID", reflect.TypeOf((*MockStore)(nil).GetBoardAndCardByID), arg0)
 }
 
+// GetBoardCount mocks base method.
+func (m *MockStore) GetBoardCount() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCount")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCount indicates an expected call of GetBoardCount.
+func (mr *MockStoreMockRecorder) GetBoardCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCount", reflect.TypeOf((*MockStore)(nil).GetBoardCount))
+}
+
+// GetBoardFreezeSchedule mocks base method.
+func (m *MockStore) GetBoardFreezeSchedule(arg0 string) (*model.BoardFreezeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardFreezeSchedule", arg0)
+	ret0, _ := ret[0].(*model.BoardFreezeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardFreezeSchedule indicates an expected call of GetBoardFreezeSchedule.
+func (mr *MockStoreMockRecorder) GetBoardFreezeSchedule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardFreezeSchedule", reflect.TypeOf((*MockStore)(nil).GetBoardFreezeSchedule), arg0)
+}
+
 // GetBoardHistory mocks base method.
 func (m *MockStore) GetBoardHistory(arg0 string, arg1 model.QueryBoardHistoryOptions) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -536,38 +959,293 @@ func (mr *MockStoreMockRecorder) GetBoardHistory(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardHistory", reflect.TypeOf((*MockStore)(nil).GetBoardHistory), arg0, arg1)
 }
 
-// GetBoardMemberHistory mocks base method.
-func (m *MockStore) GetBoardMemberHistory(arg0, arg1 string, arg2 uint64) ([]*model.BoardMemberHistoryEntry, error) {
+// GetBoardIDForKey mocks base method.
+func (m *MockStore) GetBoardIDForKey(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardMemberHistory", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]*model.BoardMemberHistoryEntry)
+	ret := m.ctrl.Call(m, "GetBoardIDForKey", arg0)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoardMemberHistory indicates an expected call of GetBoardMemberHistory.
-func (mr *MockStoreMockRecorder) GetBoardMemberHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetBoardIDForKey indicates an expected call of GetBoardIDForKey.
+func (mr *MockStoreMockRecorder) GetBoardIDForKey(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberHistory", reflect.TypeOf((*MockStore)(nil).GetBoardMemberHistory), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardIDForKey", reflect.TypeOf((*MockStore)(nil).GetBoardIDForKey), arg0)
 }
 
-// GetBoardsForUserAndTeam mocks base method.
-func (m *MockStore) GetBoardsForUserAndTeam(arg0, arg1 string) ([]*model.Board, error) {
+// GetBoardIDsForTeam mocks base method.
+func (m *MockStore) GetBoardIDsForTeam(arg0 string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardsForUserAndTeam", arg0, arg1)
-	ret0, _ := ret[0].([]*model.Board)
+	ret := m.ctrl.Call(m, "GetBoardIDsForTeam", arg0)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoardsForUserAndTeam indicates an expected call of GetBoardsForUserAndTeam.
-func (mr *MockStoreMockRecorder) GetBoardsForUserAndTeam(arg0, arg1 interface{}) *gomock.Call {
+// GetBoardIDsForTeam indicates an expected call of GetBoardIDsForTeam.
+func (mr *MockStoreMockRecorder) GetBoardIDsForTeam(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsForUserAndTeam", reflect.TypeOf((*MockStore)(nil).GetBoardsForUserAndTeam), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardIDsForTeam", reflect.TypeOf((*MockStore)(nil).GetBoardIDsForTeam), arg0)
 }
 
-// GetCategory mocks base method.
-func (m *MockStore) GetCategory(arg0 string) (*model.Category, error) {
+// GetBoardInvitation mocks base method.
+func (m *MockStore) GetBoardInvitation(arg0, arg1 string) (*model.BoardInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardInvitation", arg0, arg1)
+	ret0, _ := ret[0].(*model.BoardInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardInvitation indicates an expected call of GetBoardInvitation.
+func (mr *MockStoreMockRecorder) GetBoardInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardInvitation", reflect.TypeOf((*MockStore)(nil).GetBoardInvitation), arg0, arg1)
+}
+
+// GetBoardKey mocks base method.
+func (m *MockStore) GetBoardKey(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardKey", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardKey indicates an expected call of GetBoardKey.
+func (mr *MockStoreMockRecorder) GetBoardKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardKey", reflect.TypeOf((*MockStore)(nil).GetBoardKey), arg0)
+}
+
+// GetBoardMemberActivity mocks base method.
+func (m *MockStore) GetBoardMemberActivity(arg0 string) ([]*model.BoardMemberActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardMemberActivity", arg0)
+	ret0, _ := ret[0].([]*model.BoardMemberActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardMemberActivity indicates an expected call of GetBoardMemberActivity.
+func (mr *MockStoreMockRecorder) GetBoardMemberActivity(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberActivity", reflect.TypeOf((*MockStore)(nil).GetBoardMemberActivity), arg0)
+}
+
+// GetBoardMemberHistory mocks base method.
+func (m *MockStore) GetBoardMemberHistory(arg0, arg1 string, arg2 uint64) ([]*model.BoardMemberHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardMemberHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.BoardMemberHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardMemberHistory indicates an expected call of GetBoardMemberHistory.
+func (mr *MockStoreMockRecorder) GetBoardMemberHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberHistory", reflect.TypeOf((*MockStore)(nil).GetBoardMemberHistory), arg0, arg1, arg2)
+}
+
+// GetBoardSnapshots mocks base method.
+func (m *MockStore) GetBoardSnapshots(arg0, arg1, arg2 string) ([]*model.BoardSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardSnapshots", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.BoardSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardSnapshots indicates an expected call of GetBoardSnapshots.
+func (mr *MockStoreMockRecorder) GetBoardSnapshots(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardSnapshots", reflect.TypeOf((*MockStore)(nil).GetBoardSnapshots), arg0, arg1, arg2)
+}
+
+// GetBoardWebhook mocks base method.
+func (m *MockStore) GetBoardWebhook(arg0 string) (*model.BoardWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardWebhook", arg0)
+	ret0, _ := ret[0].(*model.BoardWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardWebhook indicates an expected call of GetBoardWebhook.
+func (mr *MockStoreMockRecorder) GetBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardWebhook", reflect.TypeOf((*MockStore)(nil).GetBoardWebhook), arg0)
+}
+
+// GetBoardWebhooksForBoard mocks base method.
+func (m *MockStore) GetBoardWebhooksForBoard(arg0 string) ([]*model.BoardWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardWebhooksForBoard", arg0)
+	ret0, _ := ret[0].([]*model.BoardWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardWebhooksForBoard indicates an expected call of GetBoardWebhooksForBoard.
+func (mr *MockStoreMockRecorder) GetBoardWebhooksForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardWebhooksForBoard", reflect.TypeOf((*MockStore)(nil).GetBoardWebhooksForBoard), arg0)
+}
+
+// GetBoardsForChannel mocks base method.
+func (m *MockStore) GetBoardsForChannel(arg0 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsForChannel", arg0)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsForChannel indicates an expected call of GetBoardsForChannel.
+func (mr *MockStoreMockRecorder) GetBoardsForChannel(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsForChannel", reflect.TypeOf((*MockStore)(nil).GetBoardsForChannel), arg0)
+}
+
+// GetBoardsForUserAndTeam mocks base method.
+func (m *MockStore) GetBoardsForUserAndTeam(arg0, arg1 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsForUserAndTeam", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsForUserAndTeam indicates an expected call of GetBoardsForUserAndTeam.
+func (mr *MockStoreMockRecorder) GetBoardsForUserAndTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsForUserAndTeam", reflect.TypeOf((*MockStore)(nil).GetBoardsForUserAndTeam), arg0, arg1)
+}
+
+// GetCalendarSubscription mocks base method.
+func (m *MockStore) GetCalendarSubscription(arg0 string) (*model.CalendarSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarSubscription", arg0)
+	ret0, _ := ret[0].(*model.CalendarSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarSubscription indicates an expected call of GetCalendarSubscription.
+func (mr *MockStoreMockRecorder) GetCalendarSubscription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarSubscription", reflect.TypeOf((*MockStore)(nil).GetCalendarSubscription), arg0)
+}
+
+// GetCardGroups mocks base method.
+func (m *MockStore) GetCardGroups(arg0, arg1, arg2 string) ([]*model.CardGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardGroups", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.CardGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardGroups indicates an expected call of GetCardGroups.
+func (mr *MockStoreMockRecorder) GetCardGroups(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardGroups", reflect.TypeOf((*MockStore)(nil).GetCardGroups), arg0, arg1, arg2)
+}
+
+// GetCardIDForNumber mocks base method.
+func (m *MockStore) GetCardIDForNumber(arg0 string, arg1 int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardIDForNumber", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardIDForNumber indicates an expected call of GetCardIDForNumber.
+func (mr *MockStoreMockRecorder) GetCardIDForNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardIDForNumber", reflect.TypeOf((*MockStore)(nil).GetCardIDForNumber), arg0, arg1)
+}
+
+// GetCardUserIDs mocks base method.
+func (m *MockStore) GetCardUserIDs(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardUserIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardUserIDs indicates an expected call of GetCardUserIDs.
+func (mr *MockStoreMockRecorder) GetCardUserIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardUserIDs", reflect.TypeOf((*MockStore)(nil).GetCardUserIDs), arg0)
+}
+
+// GetCardsStatusSince mocks base method.
+func (m *MockStore) GetCardsStatusSince(arg0, arg1 string, arg2 []string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardsStatusSince", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardsStatusSince indicates an expected call of GetCardsStatusSince.
+func (mr *MockStoreMockRecorder) GetCardsStatusSince(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsStatusSince", reflect.TypeOf((*MockStore)(nil).GetCardsStatusSince), arg0, arg1, arg2)
+}
+
+// GetCardsUpdatedBefore mocks base method.
+func (m *MockStore) GetCardsUpdatedBefore(arg0 string, arg1 int64) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardsUpdatedBefore", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardsUpdatedBefore indicates an expected call of GetCardsUpdatedBefore.
+func (mr *MockStoreMockRecorder) GetCardsUpdatedBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsUpdatedBefore", reflect.TypeOf((*MockStore)(nil).GetCardsUpdatedBefore), arg0, arg1)
+}
+
+// GetCardsWithFieldValue mocks base method.
+func (m *MockStore) GetCardsWithFieldValue(arg0 []string, arg1 string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardsWithFieldValue", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardsWithFieldValue indicates an expected call of GetCardsWithFieldValue.
+func (mr *MockStoreMockRecorder) GetCardsWithFieldValue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsWithFieldValue", reflect.TypeOf((*MockStore)(nil).GetCardsWithFieldValue), arg0, arg1)
+}
+
+// GetCardsWithPropertyValues mocks base method.
+func (m *MockStore) GetCardsWithPropertyValues(arg0, arg1 string, arg2 []string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardsWithPropertyValues", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardsWithPropertyValues indicates an expected call of GetCardsWithPropertyValues.
+func (mr *MockStoreMockRecorder) GetCardsWithPropertyValues(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsWithPropertyValues", reflect.TypeOf((*MockStore)(nil).GetCardsWithPropertyValues), arg0, arg1, arg2)
+}
+
+// GetCategory mocks base method.
+func (m *MockStore) GetCategory(arg0 string) (*model.Category, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetCategory", arg0)
 	ret0, _ := ret[0].(*model.Category)
@@ -575,10 +1253,280 @@ func (m *MockStore) GetCategory(arg0 string) (*model.Category, error) {
 	return ret0, ret1
 }
 
-// GetCategory indicates an expected call of GetCategory.
-func (mr *MockStoreMockRecorder) GetCategory(arg0 interface{}) *gomock.Call {
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockStoreMockRecorder) GetCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0)
+}
+
+// GetCategoryRules mocks base method.
+func (m *MockStore) GetCategoryRules(arg0, arg1 string) ([]*model.CategoryRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryRules", arg0, arg1)
+	ret0, _ := ret[0].([]*model.CategoryRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryRules indicates an expected call of GetCategoryRules.
+func (mr *MockStoreMockRecorder) GetCategoryRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryRules", reflect.TypeOf((*MockStore)(nil).GetCategoryRules), arg0, arg1)
+}
+
+// GetChannel mocks base method.
+func (m *MockStore) GetChannel(arg0 string) (*model0.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannel", arg0)
+	ret0, _ := ret[0].(*model0.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannel indicates an expected call of GetChannel.
+func (mr *MockStoreMockRecorder) GetChannel(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockStore)(nil).GetChannel), arg0)
+}
+
+// GetChannelMemberIDs mocks base method.
+func (m *MockStore) GetChannelMemberIDs(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMemberIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelMemberIDs indicates an expected call of GetChannelMemberIDs.
+func (mr *MockStoreMockRecorder) GetChannelMemberIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMemberIDs", reflect.TypeOf((*MockStore)(nil).GetChannelMemberIDs), arg0)
+}
+
+// GetDeadWebhookDeliveries mocks base method.
+func (m *MockStore) GetDeadWebhookDeliveries(arg0, arg1 int) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadWebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadWebhookDeliveries indicates an expected call of GetDeadWebhookDeliveries.
+func (mr *MockStoreMockRecorder) GetDeadWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).GetDeadWebhookDeliveries), arg0, arg1)
+}
+
+// GetDeferredNotificationsForUser mocks base method.
+func (m *MockStore) GetDeferredNotificationsForUser(arg0 string) ([]*model.DeferredNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeferredNotificationsForUser", arg0)
+	ret0, _ := ret[0].([]*model.DeferredNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeferredNotificationsForUser indicates an expected call of GetDeferredNotificationsForUser.
+func (mr *MockStoreMockRecorder) GetDeferredNotificationsForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeferredNotificationsForUser", reflect.TypeOf((*MockStore)(nil).GetDeferredNotificationsForUser), arg0)
+}
+
+// GetDeletedBlockIDsSince mocks base method.
+func (m *MockStore) GetDeletedBlockIDsSince(arg0 string, arg1 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedBlockIDsSince", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedBlockIDsSince indicates an expected call of GetDeletedBlockIDsSince.
+func (mr *MockStoreMockRecorder) GetDeletedBlockIDsSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedBlockIDsSince", reflect.TypeOf((*MockStore)(nil).GetDeletedBlockIDsSince), arg0, arg1)
+}
+
+// GetDeletedMembersForBoard mocks base method.
+func (m *MockStore) GetDeletedMembersForBoard(arg0 string) ([]*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedMembersForBoard", arg0)
+	ret0, _ := ret[0].([]*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedMembersForBoard indicates an expected call of GetDeletedMembersForBoard.
+func (mr *MockStoreMockRecorder) GetDeletedMembersForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedMembersForBoard", reflect.TypeOf((*MockStore)(nil).GetDeletedMembersForBoard), arg0)
+}
+
+// GetDueBoardFreezeSchedules mocks base method.
+func (m *MockStore) GetDueBoardFreezeSchedules(arg0 int64) ([]*model.BoardFreezeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueBoardFreezeSchedules", arg0)
+	ret0, _ := ret[0].([]*model.BoardFreezeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueBoardFreezeSchedules indicates an expected call of GetDueBoardFreezeSchedules.
+func (mr *MockStoreMockRecorder) GetDueBoardFreezeSchedules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueBoardFreezeSchedules", reflect.TypeOf((*MockStore)(nil).GetDueBoardFreezeSchedules), arg0)
+}
+
+// GetDueCalendarSubscriptions mocks base method.
+func (m *MockStore) GetDueCalendarSubscriptions(arg0 int64) ([]*model.CalendarSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueCalendarSubscriptions", arg0)
+	ret0, _ := ret[0].([]*model.CalendarSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueCalendarSubscriptions indicates an expected call of GetDueCalendarSubscriptions.
+func (mr *MockStoreMockRecorder) GetDueCalendarSubscriptions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueCalendarSubscriptions", reflect.TypeOf((*MockStore)(nil).GetDueCalendarSubscriptions), arg0)
+}
+
+// GetDueDigestSettings mocks base method.
+func (m *MockStore) GetDueDigestSettings(arg0 string) (*model.DueDigestSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueDigestSettings", arg0)
+	ret0, _ := ret[0].(*model.DueDigestSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueDigestSettings indicates an expected call of GetDueDigestSettings.
+func (mr *MockStoreMockRecorder) GetDueDigestSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueDigestSettings", reflect.TypeOf((*MockStore)(nil).GetDueDigestSettings), arg0)
+}
+
+// GetDueWebhookDeliveries mocks base method.
+func (m *MockStore) GetDueWebhookDeliveries(arg0 int64, arg1 uint64) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueWebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueWebhookDeliveries indicates an expected call of GetDueWebhookDeliveries.
+func (mr *MockStoreMockRecorder) GetDueWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).GetDueWebhookDeliveries), arg0, arg1)
+}
+
+// GetEnabledDueDigestSettings mocks base method.
+func (m *MockStore) GetEnabledDueDigestSettings() ([]*model.DueDigestSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledDueDigestSettings")
+	ret0, _ := ret[0].([]*model.DueDigestSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledDueDigestSettings indicates an expected call of GetEnabledDueDigestSettings.
+func (mr *MockStoreMockRecorder) GetEnabledDueDigestSettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledDueDigestSettings", reflect.TypeOf((*MockStore)(nil).GetEnabledDueDigestSettings))
+}
+
+// GetEnabledStaleDigestSettings mocks base method.
+func (m *MockStore) GetEnabledStaleDigestSettings() ([]*model.StaleDigestSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledStaleDigestSettings")
+	ret0, _ := ret[0].([]*model.StaleDigestSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledStaleDigestSettings indicates an expected call of GetEnabledStaleDigestSettings.
+func (mr *MockStoreMockRecorder) GetEnabledStaleDigestSettings() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledStaleDigestSettings", reflect.TypeOf((*MockStore)(nil).GetEnabledStaleDigestSettings))
+}
+
+// GetGitHubIntegration mocks base method.
+func (m *MockStore) GetGitHubIntegration(arg0 string) (*model.GitHubIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGitHubIntegration", arg0)
+	ret0, _ := ret[0].(*model.GitHubIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGitHubIntegration indicates an expected call of GetGitHubIntegration.
+func (mr *MockStoreMockRecorder) GetGitHubIntegration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubIntegration", reflect.TypeOf((*MockStore)(nil).GetGitHubIntegration), arg0)
+}
+
+// GetHistoryStatsByTeam mocks base method.
+func (m *MockStore) GetHistoryStatsByTeam() ([]*model.TeamHistoryStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoryStatsByTeam")
+	ret0, _ := ret[0].([]*model.TeamHistoryStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoryStatsByTeam indicates an expected call of GetHistoryStatsByTeam.
+func (mr *MockStoreMockRecorder) GetHistoryStatsByTeam() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryStatsByTeam", reflect.TypeOf((*MockStore)(nil).GetHistoryStatsByTeam))
+}
+
+// GetIndexedPropertyIDs mocks base method.
+func (m *MockStore) GetIndexedPropertyIDs() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndexedPropertyIDs")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIndexedPropertyIDs indicates an expected call of GetIndexedPropertyIDs.
+func (mr *MockStoreMockRecorder) GetIndexedPropertyIDs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndexedPropertyIDs", reflect.TypeOf((*MockStore)(nil).GetIndexedPropertyIDs))
+}
+
+// GetInvitationsForBoard mocks base method.
+func (m *MockStore) GetInvitationsForBoard(arg0 string) ([]*model.BoardInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationsForBoard", arg0)
+	ret0, _ := ret[0].([]*model.BoardInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationsForBoard indicates an expected call of GetInvitationsForBoard.
+func (mr *MockStoreMockRecorder) GetInvitationsForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationsForBoard", reflect.TypeOf((*MockStore)(nil).GetInvitationsForBoard), arg0)
+}
+
+// GetInvitationsForUser mocks base method.
+func (m *MockStore) GetInvitationsForUser(arg0 string) ([]*model.BoardInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationsForUser", arg0)
+	ret0, _ := ret[0].([]*model.BoardInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationsForUser indicates an expected call of GetInvitationsForUser.
+func (mr *MockStoreMockRecorder) GetInvitationsForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationsForUser", reflect.TypeOf((*MockStore)(nil).GetInvitationsForUser), arg0)
 }
 
 // GetLicense mocks base method.
@@ -640,6 +1588,36 @@ func (mr *MockStoreMockRecorder) GetMembersForUser(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForUser", reflect.TypeOf((*MockStore)(nil).GetMembersForUser), arg0)
 }
 
+// GetMentionsForUser mocks base method.
+func (m *MockStore) GetMentionsForUser(arg0 string, arg1 model.QueryMentionsOptions) ([]*model.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentionsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMentionsForUser indicates an expected call of GetMentionsForUser.
+func (mr *MockStoreMockRecorder) GetMentionsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentionsForUser", reflect.TypeOf((*MockStore)(nil).GetMentionsForUser), arg0, arg1)
+}
+
+// GetMigrationStatus mocks base method.
+func (m *MockStore) GetMigrationStatus(arg0 bool) (*model.MigrationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMigrationStatus", arg0)
+	ret0, _ := ret[0].(*model.MigrationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMigrationStatus indicates an expected call of GetMigrationStatus.
+func (mr *MockStoreMockRecorder) GetMigrationStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrationStatus", reflect.TypeOf((*MockStore)(nil).GetMigrationStatus), arg0)
+}
+
 // GetNextNotificationHint mocks base method.
 func (m *MockStore) GetNextNotificationHint(arg0 bool) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
@@ -655,6 +1633,21 @@ func (mr *MockStoreMockRecorder) GetNextNotificationHint(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNextNotificationHint), arg0)
 }
 
+// GetNonTemplateBoardIDs mocks base method.
+func (m *MockStore) GetNonTemplateBoardIDs() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNonTemplateBoardIDs")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNonTemplateBoardIDs indicates an expected call of GetNonTemplateBoardIDs.
+func (mr *MockStoreMockRecorder) GetNonTemplateBoardIDs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNonTemplateBoardIDs", reflect.TypeOf((*MockStore)(nil).GetNonTemplateBoardIDs))
+}
+
 // GetNotificationHint mocks base method.
 func (m *MockStore) GetNotificationHint(arg0 string) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
@@ -670,6 +1663,36 @@ func (mr *MockStoreMockRecorder) GetNotificationHint(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNotificationHint), arg0)
 }
 
+// GetPropertyIndexes mocks base method.
+func (m *MockStore) GetPropertyIndexes(arg0 string) ([]*model.PropertyIndex, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPropertyIndexes", arg0)
+	ret0, _ := ret[0].([]*model.PropertyIndex)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPropertyIndexes indicates an expected call of GetPropertyIndexes.
+func (mr *MockStoreMockRecorder) GetPropertyIndexes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPropertyIndexes", reflect.TypeOf((*MockStore)(nil).GetPropertyIndexes), arg0)
+}
+
+// GetRecentBoardsForUser mocks base method.
+func (m *MockStore) GetRecentBoardsForUser(arg0, arg1 string, arg2 uint64) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentBoardsForUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentBoardsForUser indicates an expected call of GetRecentBoardsForUser.
+func (mr *MockStoreMockRecorder) GetRecentBoardsForUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentBoardsForUser", reflect.TypeOf((*MockStore)(nil).GetRecentBoardsForUser), arg0, arg1, arg2)
+}
+
 // GetRegisteredUserCount mocks base method.
 func (m *MockStore) GetRegisteredUserCount() (int, error) {
 	m.ctrl.T.Helper()
@@ -700,6 +1723,21 @@ func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
 }
 
+// GetSharedTeamIDsForBoard mocks base method.
+func (m *MockStore) GetSharedTeamIDsForBoard(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedTeamIDsForBoard", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedTeamIDsForBoard indicates an expected call of GetSharedTeamIDsForBoard.
+func (mr *MockStoreMockRecorder) GetSharedTeamIDsForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedTeamIDsForBoard", reflect.TypeOf((*MockStore)(nil).GetSharedTeamIDsForBoard), arg0)
+}
+
 // GetSharing mocks base method.
 func (m *MockStore) GetSharing(arg0 string) (*model.Sharing, error) {
 	m.ctrl.T.Helper()
@@ -715,6 +1753,21 @@ func (mr *MockStoreMockRecorder) GetSharing(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharing", reflect.TypeOf((*MockStore)(nil).GetSharing), arg0)
 }
 
+// GetStaleDigestSettings mocks base method.
+func (m *MockStore) GetStaleDigestSettings(arg0 string) (*model.StaleDigestSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStaleDigestSettings", arg0)
+	ret0, _ := ret[0].(*model.StaleDigestSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStaleDigestSettings indicates an expected call of GetStaleDigestSettings.
+func (mr *MockStoreMockRecorder) GetStaleDigestSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStaleDigestSettings", reflect.TypeOf((*MockStore)(nil).GetStaleDigestSettings), arg0)
+}
+
 // GetSubTree2 mocks base method.
 func (m *MockStore) GetSubTree2(arg0, arg1 string, arg2 model.QuerySubtreeOptions) ([]model.Block, error) {
 	m.ctrl.T.Helper()
@@ -776,18 +1829,33 @@ func (mr *MockStoreMockRecorder) GetSubscription(arg0, arg1 interface{}) *gomock
 }
 
 // GetSubscriptions mocks base method.
-func (m *MockStore) GetSubscriptions(arg0 string) ([]*model.Subscription, error) {
+func (m *MockStore) GetSubscriptions(arg0 string, arg1 model.QuerySubscriptionsOptions) ([]*model.Subscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubscriptions", arg0)
+	ret := m.ctrl.Call(m, "GetSubscriptions", arg0, arg1)
 	ret0, _ := ret[0].([]*model.Subscription)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSubscriptions indicates an expected call of GetSubscriptions.
-func (mr *MockStoreMockRecorder) GetSubscriptions(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) GetSubscriptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockStore)(nil).GetSubscriptions), arg0, arg1)
+}
+
+// GetSwimlaneCells mocks base method.
+func (m *MockStore) GetSwimlaneCells(arg0, arg1, arg2 string) ([]*model.SwimlaneCell, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwimlaneCells", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.SwimlaneCell)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwimlaneCells indicates an expected call of GetSwimlaneCells.
+func (mr *MockStoreMockRecorder) GetSwimlaneCells(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockStore)(nil).GetSubscriptions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwimlaneCells", reflect.TypeOf((*MockStore)(nil).GetSwimlaneCells), arg0, arg1, arg2)
 }
 
 // GetSystemSetting mocks base method.
@@ -820,6 +1888,21 @@ func (mr *MockStoreMockRecorder) GetSystemSettings() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemSettings", reflect.TypeOf((*MockStore)(nil).GetSystemSettings))
 }
 
+// GetTableStats mocks base method.
+func (m *MockStore) GetTableStats() ([]*model.TableStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableStats")
+	ret0, _ := ret[0].([]*model.TableStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTableStats indicates an expected call of GetTableStats.
+func (mr *MockStoreMockRecorder) GetTableStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableStats", reflect.TypeOf((*MockStore)(nil).GetTableStats))
+}
+
 // GetTeam mocks base method.
 func (m *MockStore) GetTeam(arg0 string) (*model.Team, error) {
 	m.ctrl.T.Helper()
@@ -925,6 +2008,21 @@ func (mr *MockStoreMockRecorder) GetUserByUsername(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), arg0)
 }
 
+// GetUserCategories mocks base method.
+func (m *MockStore) GetUserCategories(arg0, arg1 string) ([]model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCategories", arg0, arg1)
+	ret0, _ := ret[0].([]model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCategories indicates an expected call of GetUserCategories.
+func (mr *MockStoreMockRecorder) GetUserCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCategories", reflect.TypeOf((*MockStore)(nil).GetUserCategories), arg0, arg1)
+}
+
 // GetUserCategoryBoards mocks base method.
 func (m *MockStore) GetUserCategoryBoards(arg0, arg1 string) ([]model.CategoryBoards, error) {
 	m.ctrl.T.Helper()
@@ -937,95 +2035,284 @@ func (m *MockStore) GetUserCategoryBoards(arg0, arg1 string) ([]model.CategoryBo
 // GetUserCategoryBoards indicates an expected call of GetUserCategoryBoards.
 func (mr *MockStoreMockRecorder) GetUserCategoryBoards(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCategoryBoards", reflect.TypeOf((*MockStore)(nil).GetUserCategoryBoards), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCategoryBoards", reflect.TypeOf((*MockStore)(nil).GetUserCategoryBoards), arg0, arg1)
+}
+
+// GetUsersByIDs mocks base method.
+func (m *MockStore) GetUsersByIDs(arg0 []string) ([]*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIDs", arg0)
+	ret0, _ := ret[0].([]*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIDs indicates an expected call of GetUsersByIDs.
+func (mr *MockStoreMockRecorder) GetUsersByIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*MockStore)(nil).GetUsersByIDs), arg0)
+}
+
+// GetUsersByTeam mocks base method.
+func (m *MockStore) GetUsersByTeam(arg0 string) ([]*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByTeam", arg0)
+	ret0, _ := ret[0].([]*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByTeam indicates an expected call of GetUsersByTeam.
+func (mr *MockStoreMockRecorder) GetUsersByTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByTeam", reflect.TypeOf((*MockStore)(nil).GetUsersByTeam), arg0)
+}
+
+// GetUsersWithDeferredNotifications mocks base method.
+func (m *MockStore) GetUsersWithDeferredNotifications() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersWithDeferredNotifications")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersWithDeferredNotifications indicates an expected call of GetUsersWithDeferredNotifications.
+func (mr *MockStoreMockRecorder) GetUsersWithDeferredNotifications() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithDeferredNotifications", reflect.TypeOf((*MockStore)(nil).GetUsersWithDeferredNotifications))
+}
+
+// GetViewCards mocks base method.
+func (m *MockStore) GetViewCards(arg0 string, arg1 model.QueryViewCardsOptions) ([]model.Block, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViewCards", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetViewCards indicates an expected call of GetViewCards.
+func (mr *MockStoreMockRecorder) GetViewCards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViewCards", reflect.TypeOf((*MockStore)(nil).GetViewCards), arg0, arg1)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockStore) GetWebhookDelivery(arg0 string) (*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", arg0)
+	ret0, _ := ret[0].(*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockStoreMockRecorder) GetWebhookDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockStore)(nil).GetWebhookDelivery), arg0)
+}
+
+// InsertBlock mocks base method.
+func (m *MockStore) InsertBlock(arg0 *model.Block, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBlock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertBlock indicates an expected call of InsertBlock.
+func (mr *MockStoreMockRecorder) InsertBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBlock", reflect.TypeOf((*MockStore)(nil).InsertBlock), arg0, arg1)
+}
+
+// InsertBlocks mocks base method.
+func (m *MockStore) InsertBlocks(arg0 []model.Block, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBlocks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertBlocks indicates an expected call of InsertBlocks.
+func (mr *MockStoreMockRecorder) InsertBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBlocks", reflect.TypeOf((*MockStore)(nil).InsertBlocks), arg0, arg1)
+}
+
+// InsertBoard mocks base method.
+func (m *MockStore) InsertBoard(arg0 *model.Board, arg1 string) (*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBoard", arg0, arg1)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertBoard indicates an expected call of InsertBoard.
+func (mr *MockStoreMockRecorder) InsertBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoard", reflect.TypeOf((*MockStore)(nil).InsertBoard), arg0, arg1)
+}
+
+// InsertBoardWebhook mocks base method.
+func (m *MockStore) InsertBoardWebhook(arg0 *model.BoardWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBoardWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertBoardWebhook indicates an expected call of InsertBoardWebhook.
+func (mr *MockStoreMockRecorder) InsertBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoardWebhook", reflect.TypeOf((*MockStore)(nil).InsertBoardWebhook), arg0)
+}
+
+// InsertBoardWithAdmin mocks base method.
+func (m *MockStore) InsertBoardWithAdmin(arg0 *model.Board, arg1 string) (*model.Board, *model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBoardWithAdmin", arg0, arg1)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].(*model.BoardMember)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// InsertBoardWithAdmin indicates an expected call of InsertBoardWithAdmin.
+func (mr *MockStoreMockRecorder) InsertBoardWithAdmin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoardWithAdmin", reflect.TypeOf((*MockStore)(nil).InsertBoardWithAdmin), arg0, arg1)
+}
+
+// InsertDeferredNotification mocks base method.
+func (m *MockStore) InsertDeferredNotification(arg0 *model.DeferredNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertDeferredNotification", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertDeferredNotification indicates an expected call of InsertDeferredNotification.
+func (mr *MockStoreMockRecorder) InsertDeferredNotification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDeferredNotification", reflect.TypeOf((*MockStore)(nil).InsertDeferredNotification), arg0)
+}
+
+// InsertMention mocks base method.
+func (m *MockStore) InsertMention(arg0 *model.Mention) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertMention", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertMention indicates an expected call of InsertMention.
+func (mr *MockStoreMockRecorder) InsertMention(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMention", reflect.TypeOf((*MockStore)(nil).InsertMention), arg0)
 }
 
-// GetUsersByTeam mocks base method.
-func (m *MockStore) GetUsersByTeam(arg0 string) ([]*model.User, error) {
+// InsertNewBlocks mocks base method.
+func (m *MockStore) InsertNewBlocks(arg0 []model.Block, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersByTeam", arg0)
-	ret0, _ := ret[0].([]*model.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "InsertNewBlocks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUsersByTeam indicates an expected call of GetUsersByTeam.
-func (mr *MockStoreMockRecorder) GetUsersByTeam(arg0 interface{}) *gomock.Call {
+// InsertNewBlocks indicates an expected call of InsertNewBlocks.
+func (mr *MockStoreMockRecorder) InsertNewBlocks(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByTeam", reflect.TypeOf((*MockStore)(nil).GetUsersByTeam), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewBlocks", reflect.TypeOf((*MockStore)(nil).InsertNewBlocks), arg0, arg1)
 }
 
-// InsertBlock mocks base method.
-func (m *MockStore) InsertBlock(arg0 *model.Block, arg1 string) error {
+// InsertWebhookDelivery mocks base method.
+func (m *MockStore) InsertWebhookDelivery(arg0 *model.WebhookDelivery) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertBlock", arg0, arg1)
+	ret := m.ctrl.Call(m, "InsertWebhookDelivery", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertBlock indicates an expected call of InsertBlock.
-func (mr *MockStoreMockRecorder) InsertBlock(arg0, arg1 interface{}) *gomock.Call {
+// InsertWebhookDelivery indicates an expected call of InsertWebhookDelivery.
+func (mr *MockStoreMockRecorder) InsertWebhookDelivery(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBlock", reflect.TypeOf((*MockStore)(nil).InsertBlock), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWebhookDelivery", reflect.TypeOf((*MockStore)(nil).InsertWebhookDelivery), arg0)
 }
 
-// InsertBlocks mocks base method.
-func (m *MockStore) InsertBlocks(arg0 []model.Block, arg1 string) error {
+// IsErrNotFound mocks base method.
+func (m *MockStore) IsErrNotFound(arg0 error) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertBlocks", arg0, arg1)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "IsErrNotFound", arg0)
+	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
-// InsertBlocks indicates an expected call of InsertBlocks.
-func (mr *MockStoreMockRecorder) InsertBlocks(arg0, arg1 interface{}) *gomock.Call {
+// IsErrNotFound indicates an expected call of IsErrNotFound.
+func (mr *MockStoreMockRecorder) IsErrNotFound(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBlocks", reflect.TypeOf((*MockStore)(nil).InsertBlocks), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotFound", reflect.TypeOf((*MockStore)(nil).IsErrNotFound), arg0)
 }
 
-// InsertBoard mocks base method.
-func (m *MockStore) InsertBoard(arg0 *model.Board, arg1 string) (*model.Board, error) {
+// JoinBoard mocks base method.
+func (m *MockStore) JoinBoard(arg0 *model.BoardMember) (*model.BoardMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertBoard", arg0, arg1)
-	ret0, _ := ret[0].(*model.Board)
+	ret := m.ctrl.Call(m, "JoinBoard", arg0)
+	ret0, _ := ret[0].(*model.BoardMember)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InsertBoard indicates an expected call of InsertBoard.
-func (mr *MockStoreMockRecorder) InsertBoard(arg0, arg1 interface{}) *gomock.Call {
+// JoinBoard indicates an expected call of JoinBoard.
+func (mr *MockStoreMockRecorder) JoinBoard(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoard", reflect.TypeOf((*MockStore)(nil).InsertBoard), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinBoard", reflect.TypeOf((*MockStore)(nil).JoinBoard), arg0)
 }
 
-// InsertBoardWithAdmin mocks base method.
-func (m *MockStore) InsertBoardWithAdmin(arg0 *model.Board, arg1 string) (*model.Board, *model.BoardMember, error) {
+// MarkMentionsRead mocks base method.
+func (m *MockStore) MarkMentionsRead(arg0 string, arg1 []string, arg2 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertBoardWithAdmin", arg0, arg1)
-	ret0, _ := ret[0].(*model.Board)
-	ret1, _ := ret[1].(*model.BoardMember)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "MarkMentionsRead", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// InsertBoardWithAdmin indicates an expected call of InsertBoardWithAdmin.
-func (mr *MockStoreMockRecorder) InsertBoardWithAdmin(arg0, arg1 interface{}) *gomock.Call {
+// MarkMentionsRead indicates an expected call of MarkMentionsRead.
+func (mr *MockStoreMockRecorder) MarkMentionsRead(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoardWithAdmin", reflect.TypeOf((*MockStore)(nil).InsertBoardWithAdmin), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMentionsRead", reflect.TypeOf((*MockStore)(nil).MarkMentionsRead), arg0, arg1, arg2)
 }
 
-// IsErrNotFound mocks base method.
-func (m *MockStore) IsErrNotFound(arg0 error) bool {
+// MoveBlocks mocks base method.
+func (m *MockStore) MoveBlocks(arg0 []model.Block, arg1, arg2 string) ([]model.Block, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsErrNotFound", arg0)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "MoveBlocks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveBlocks indicates an expected call of MoveBlocks.
+func (mr *MockStoreMockRecorder) MoveBlocks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveBlocks", reflect.TypeOf((*MockStore)(nil).MoveBlocks), arg0, arg1, arg2)
+}
+
+// MoveBoardToTeam mocks base method.
+func (m *MockStore) MoveBoardToTeam(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveBoardToTeam", arg0, arg1)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IsErrNotFound indicates an expected call of IsErrNotFound.
-func (mr *MockStoreMockRecorder) IsErrNotFound(arg0 interface{}) *gomock.Call {
+// MoveBoardToTeam indicates an expected call of MoveBoardToTeam.
+func (mr *MockStoreMockRecorder) MoveBoardToTeam(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotFound", reflect.TypeOf((*MockStore)(nil).IsErrNotFound), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveBoardToTeam", reflect.TypeOf((*MockStore)(nil).MoveBoardToTeam), arg0, arg1)
 }
 
 // PatchBlock mocks base method.
@@ -1114,6 +2401,48 @@ func (mr *MockStoreMockRecorder) RefreshSession(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockStore)(nil).RefreshSession), arg0)
 }
 
+// ReleaseBlockLock mocks base method.
+func (m *MockStore) ReleaseBlockLock(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseBlockLock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseBlockLock indicates an expected call of ReleaseBlockLock.
+func (mr *MockStoreMockRecorder) ReleaseBlockLock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseBlockLock", reflect.TypeOf((*MockStore)(nil).ReleaseBlockLock), arg0, arg1)
+}
+
+// ReleaseDeferredNotification mocks base method.
+func (m *MockStore) ReleaseDeferredNotification(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDeferredNotification", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDeferredNotification indicates an expected call of ReleaseDeferredNotification.
+func (mr *MockStoreMockRecorder) ReleaseDeferredNotification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDeferredNotification", reflect.TypeOf((*MockStore)(nil).ReleaseDeferredNotification), arg0, arg1)
+}
+
+// RemoveBoardFromTeam mocks base method.
+func (m *MockStore) RemoveBoardFromTeam(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBoardFromTeam", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBoardFromTeam indicates an expected call of RemoveBoardFromTeam.
+func (mr *MockStoreMockRecorder) RemoveBoardFromTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBoardFromTeam", reflect.TypeOf((*MockStore)(nil).RemoveBoardFromTeam), arg0, arg1)
+}
+
 // RemoveDefaultTemplates mocks base method.
 func (m *MockStore) RemoveDefaultTemplates(arg0 []*model.Board) error {
 	m.ctrl.T.Helper()
@@ -1128,6 +2457,35 @@ func (mr *MockStoreMockRecorder) RemoveDefaultTemplates(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDefaultTemplates", reflect.TypeOf((*MockStore)(nil).RemoveDefaultTemplates), arg0)
 }
 
+// ReorderCategories mocks base method.
+func (m *MockStore) ReorderCategories(arg0, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderCategories", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderCategories indicates an expected call of ReorderCategories.
+func (mr *MockStoreMockRecorder) ReorderCategories(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderCategories", reflect.TypeOf((*MockStore)(nil).ReorderCategories), arg0, arg1, arg2)
+}
+
+// RestoreMember mocks base method.
+func (m *MockStore) RestoreMember(arg0, arg1 string) (*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMember", arg0, arg1)
+	ret0, _ := ret[0].(*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreMember indicates an expected call of RestoreMember.
+func (mr *MockStoreMockRecorder) RestoreMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMember", reflect.TypeOf((*MockStore)(nil).RestoreMember), arg0, arg1)
+}
+
 // RunDataRetention mocks base method.
 func (m *MockStore) RunDataRetention(arg0, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1143,6 +2501,133 @@ func (mr *MockStoreMockRecorder) RunDataRetention(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDataRetention", reflect.TypeOf((*MockStore)(nil).RunDataRetention), arg0, arg1)
 }
 
+// RunInTransaction mocks base method.
+func (m *MockStore) RunInTransaction(arg0 func(store.Store) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunInTransaction", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunInTransaction indicates an expected call of RunInTransaction.
+func (mr *MockStoreMockRecorder) RunInTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTransaction", reflect.TypeOf((*MockStore)(nil).RunInTransaction), arg0)
+}
+
+// RunMaintenance mocks base method.
+func (m *MockStore) RunMaintenance() (*model.MaintenanceReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunMaintenance")
+	ret0, _ := ret[0].(*model.MaintenanceReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunMaintenance indicates an expected call of RunMaintenance.
+func (mr *MockStoreMockRecorder) RunMaintenance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunMaintenance", reflect.TypeOf((*MockStore)(nil).RunMaintenance))
+}
+
+// SaveBoardFreezeSchedule mocks base method.
+func (m *MockStore) SaveBoardFreezeSchedule(arg0 *model.BoardFreezeSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBoardFreezeSchedule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBoardFreezeSchedule indicates an expected call of SaveBoardFreezeSchedule.
+func (mr *MockStoreMockRecorder) SaveBoardFreezeSchedule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBoardFreezeSchedule", reflect.TypeOf((*MockStore)(nil).SaveBoardFreezeSchedule), arg0)
+}
+
+// SaveBoardMemberActivity mocks base method.
+func (m *MockStore) SaveBoardMemberActivity(arg0 []*model.BoardMemberActivity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBoardMemberActivity", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBoardMemberActivity indicates an expected call of SaveBoardMemberActivity.
+func (mr *MockStoreMockRecorder) SaveBoardMemberActivity(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBoardMemberActivity", reflect.TypeOf((*MockStore)(nil).SaveBoardMemberActivity), arg0)
+}
+
+// SaveBoardSnapshot mocks base method.
+func (m *MockStore) SaveBoardSnapshot(arg0 *model.BoardSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBoardSnapshot", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBoardSnapshot indicates an expected call of SaveBoardSnapshot.
+func (mr *MockStoreMockRecorder) SaveBoardSnapshot(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBoardSnapshot", reflect.TypeOf((*MockStore)(nil).SaveBoardSnapshot), arg0)
+}
+
+// SaveBoardVisits mocks base method.
+func (m *MockStore) SaveBoardVisits(arg0 []*model.BoardVisit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBoardVisits", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBoardVisits indicates an expected call of SaveBoardVisits.
+func (mr *MockStoreMockRecorder) SaveBoardVisits(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBoardVisits", reflect.TypeOf((*MockStore)(nil).SaveBoardVisits), arg0)
+}
+
+// SaveCalendarSubscription mocks base method.
+func (m *MockStore) SaveCalendarSubscription(arg0 *model.CalendarSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCalendarSubscription", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCalendarSubscription indicates an expected call of SaveCalendarSubscription.
+func (mr *MockStoreMockRecorder) SaveCalendarSubscription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCalendarSubscription", reflect.TypeOf((*MockStore)(nil).SaveCalendarSubscription), arg0)
+}
+
+// SaveDueDigestSettings mocks base method.
+func (m *MockStore) SaveDueDigestSettings(arg0 *model.DueDigestSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDueDigestSettings", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDueDigestSettings indicates an expected call of SaveDueDigestSettings.
+func (mr *MockStoreMockRecorder) SaveDueDigestSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDueDigestSettings", reflect.TypeOf((*MockStore)(nil).SaveDueDigestSettings), arg0)
+}
+
+// SaveGitHubIntegration mocks base method.
+func (m *MockStore) SaveGitHubIntegration(arg0 *model.GitHubIntegration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGitHubIntegration", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveGitHubIntegration indicates an expected call of SaveGitHubIntegration.
+func (mr *MockStoreMockRecorder) SaveGitHubIntegration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGitHubIntegration", reflect.TypeOf((*MockStore)(nil).SaveGitHubIntegration), arg0)
+}
+
 // SaveMember mocks base method.
 func (m *MockStore) SaveMember(arg0 *model.BoardMember) (*model.BoardMember, error) {
 	m.ctrl.T.Helper()
@@ -1158,6 +2643,35 @@ func (mr *MockStoreMockRecorder) SaveMember(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMember", reflect.TypeOf((*MockStore)(nil).SaveMember), arg0)
 }
 
+// SaveMemberRoles mocks base method.
+func (m *MockStore) SaveMemberRoles(arg0 *model.BoardMember, arg1 string) (*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMemberRoles", arg0, arg1)
+	ret0, _ := ret[0].(*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveMemberRoles indicates an expected call of SaveMemberRoles.
+func (mr *MockStoreMockRecorder) SaveMemberRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMemberRoles", reflect.TypeOf((*MockStore)(nil).SaveMemberRoles), arg0, arg1)
+}
+
+// SaveStaleDigestSettings mocks base method.
+func (m *MockStore) SaveStaleDigestSettings(arg0 *model.StaleDigestSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveStaleDigestSettings", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveStaleDigestSettings indicates an expected call of SaveStaleDigestSettings.
+func (mr *MockStoreMockRecorder) SaveStaleDigestSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveStaleDigestSettings", reflect.TypeOf((*MockStore)(nil).SaveStaleDigestSettings), arg0)
+}
+
 // SearchBoardsForUserAndTeam mocks base method.
 func (m *MockStore) SearchBoardsForUserAndTeam(arg0, arg1, arg2 string) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -1188,6 +2702,35 @@ func (mr *MockStoreMockRecorder) SearchUsersByTeam(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsersByTeam", reflect.TypeOf((*MockStore)(nil).SearchUsersByTeam), arg0, arg1)
 }
 
+// SetBoardKey mocks base method.
+func (m *MockStore) SetBoardKey(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBoardKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBoardKey indicates an expected call of SetBoardKey.
+func (mr *MockStoreMockRecorder) SetBoardKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBoardKey", reflect.TypeOf((*MockStore)(nil).SetBoardKey), arg0, arg1)
+}
+
+// SetMembershipsInactive mocks base method.
+func (m *MockStore) SetMembershipsInactive(arg0 string, arg1 bool) ([]*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMembershipsInactive", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMembershipsInactive indicates an expected call of SetMembershipsInactive.
+func (mr *MockStoreMockRecorder) SetMembershipsInactive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMembershipsInactive", reflect.TypeOf((*MockStore)(nil).SetMembershipsInactive), arg0, arg1)
+}
+
 // SetSystemSetting mocks base method.
 func (m *MockStore) SetSystemSetting(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1244,6 +2787,20 @@ func (mr *MockStoreMockRecorder) UndeleteBoard(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBoard", reflect.TypeOf((*MockStore)(nil).UndeleteBoard), arg0, arg1)
 }
 
+// UpdateBoardWebhook mocks base method.
+func (m *MockStore) UpdateBoardWebhook(arg0 *model.BoardWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBoardWebhook indicates an expected call of UpdateBoardWebhook.
+func (mr *MockStoreMockRecorder) UpdateBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardWebhook", reflect.TypeOf((*MockStore)(nil).UpdateBoardWebhook), arg0)
+}
+
 // UpdateCategory mocks base method.
 func (m *MockStore) UpdateCategory(arg0 model.Category) error {
 	m.ctrl.T.Helper()
@@ -1258,6 +2815,20 @@ func (mr *MockStoreMockRecorder) UpdateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0)
 }
 
+// UpdateMemberCustomFields mocks base method.
+func (m *MockStore) UpdateMemberCustomFields(arg0, arg1 string, arg2 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberCustomFields", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberCustomFields indicates an expected call of UpdateMemberCustomFields.
+func (mr *MockStoreMockRecorder) UpdateMemberCustomFields(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberCustomFields", reflect.TypeOf((*MockStore)(nil).UpdateMemberCustomFields), arg0, arg1, arg2)
+}
+
 // UpdateSession mocks base method.
 func (m *MockStore) UpdateSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -1300,6 +2871,20 @@ func (mr *MockStoreMockRecorder) UpdateUser(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0)
 }
 
+// UpdateUserDeleteAt mocks base method.
+func (m *MockStore) UpdateUserDeleteAt(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserDeleteAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserDeleteAt indicates an expected call of UpdateUserDeleteAt.
+func (mr *MockStoreMockRecorder) UpdateUserDeleteAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserDeleteAt", reflect.TypeOf((*MockStore)(nil).UpdateUserDeleteAt), arg0, arg1)
+}
+
 // UpdateUserPassword mocks base method.
 func (m *MockStore) UpdateUserPassword(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1328,6 +2913,20 @@ func (mr *MockStoreMockRecorder) UpdateUserPasswordByID(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPasswordByID", reflect.TypeOf((*MockStore)(nil).UpdateUserPasswordByID), arg0, arg1)
 }
 
+// UpdateWebhookDelivery mocks base method.
+func (m *MockStore) UpdateWebhookDelivery(arg0 *model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDelivery", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookDelivery indicates an expected call of UpdateWebhookDelivery.
+func (mr *MockStoreMockRecorder) UpdateWebhookDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDelivery", reflect.TypeOf((*MockStore)(nil).UpdateWebhookDelivery), arg0)
+}
+
 // UpsertNotificationHint mocks base method.
 func (m *MockStore) UpsertNotificationHint(arg0 *model.NotificationHint, arg1 time.Duration) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
@@ -1384,3 +2983,17 @@ func (mr *MockStoreMockRecorder) UpsertTeamSignupToken(arg0 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTeamSignupToken", reflect.TypeOf((*MockStore)(nil).UpsertTeamSignupToken), arg0)
 }
+
+// WithContext mocks base method.
+func (m *MockStore) WithContext(arg0 context.Context) store.Store {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", arg0)
+	ret0, _ := ret[0].(store.Store)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockStoreMockRecorder) WithContext(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockStore)(nil).WithContext), arg0)
+}
@@ -0,0 +1,219 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	batchedMigrationDefaultBatchSize = 1000
+	batchedMigrationPause            = 100 * time.Millisecond
+	batchedMigrationCheckpointSuffix = "Checkpoint"
+)
+
+// batchedMigration is a data backfill that runs in small batches, each
+// one in its own transaction, so huge tables like blocks_history aren't
+// locked for the whole duration of the migration. The progress is
+// checkpointed in the system settings after each batch, so a migration
+// interrupted by a restart resumes from where it stopped.
+type batchedMigration struct {
+	// key identifies the migration, and is set to true in the system
+	// settings once the migration completes.
+	key string
+
+	// batchSize is the maximum number of rows each batch processes.
+	batchSize int
+
+	// runBatch processes the rows that come after the checkpoint, up
+	// to batchSize, and returns the checkpoint of the last processed
+	// row. An empty checkpoint means there were no rows left.
+	runBatch func(s *SQLStore, db sq.BaseRunner, checkpoint string, batchSize int) (string, error)
+}
+
+// batchedMigrations run in order, in the background, once the schema
+// migrations have been applied. Schema changes that need to backfill
+// huge tables add their backfill here instead of running it in the
+// migration itself.
+var batchedMigrations = []batchedMigration{
+	{key: "BlocksHistoryBoardIDBackfillComplete", runBatch: backfillBlocksHistoryBoardID},
+}
+
+// startBatchedMigrations runs the pending batched migrations in the
+// background. They are stopped when the store shuts down.
+func (s *SQLStore) startBatchedMigrations(migrations []batchedMigration) {
+	if len(migrations) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopBatchedMigrations = cancel
+
+	s.batchedMigrationsWG.Add(1)
+	go func() {
+		defer s.batchedMigrationsWG.Done()
+
+		if err := s.runBatchedMigrations(ctx, migrations); err != nil && ctx.Err() == nil {
+			s.logger.Error("Batched migrations failed, they will be resumed on the next start", mlog.Err(err))
+		}
+	}()
+}
+
+func (s *SQLStore) runBatchedMigrations(ctx context.Context, migrations []batchedMigration) error {
+	if s.isPlugin {
+		mutex, err := s.NewMutexFn("Boards_batchedMigrationsMutex")
+		if err != nil {
+			return fmt.Errorf("error creating batched migrations mutex: %w", err)
+		}
+
+		// only one node of the cluster runs the batched migrations.
+		if err := mutex.LockWithContext(ctx); err != nil {
+			return err
+		}
+		defer mutex.Unlock()
+	}
+
+	for _, migration := range migrations {
+		if err := s.runBatchedMigration(ctx, migration); err != nil {
+			return fmt.Errorf("error running batched migration %s: %w", migration.key, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SQLStore) runBatchedMigration(ctx context.Context, migration batchedMigration) error {
+	setting, err := s.getSystemSetting(s.db, migration.key)
+	if err != nil {
+		return fmt.Errorf("cannot get migration state: %w", err)
+	}
+
+	// If the migration is already completed, do not run it again.
+	if hasAlreadyRun, _ := strconv.ParseBool(setting); hasAlreadyRun {
+		return nil
+	}
+
+	checkpointKey := migration.key + batchedMigrationCheckpointSuffix
+	checkpoint, err := s.getSystemSetting(s.db, checkpointKey)
+	if err != nil {
+		return fmt.Errorf("cannot get migration checkpoint: %w", err)
+	}
+
+	batchSize := migration.batchSize
+	if batchSize <= 0 {
+		batchSize = batchedMigrationDefaultBatchSize
+	}
+
+	s.logger.Info("Running batched migration",
+		mlog.String("key", migration.key),
+		mlog.String("checkpoint", checkpoint),
+	)
+
+	for batch := 1; ; batch++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var next string
+		err := s.WithContext(ctx).RunInTransaction(func(tx store.Store) error {
+			txStore := tx.(*SQLStore)
+
+			var bErr error
+			next, bErr = migration.runBatch(txStore, txStore.txRunner, checkpoint, batchSize)
+			if bErr != nil {
+				return bErr
+			}
+
+			// the checkpoint is saved along with the batch, so the
+			// batch is never applied twice.
+			if next == "" {
+				return txStore.setSystemSetting(txStore.txRunner, migration.key, strconv.FormatBool(true))
+			}
+			return txStore.setSystemSetting(txStore.txRunner, checkpointKey, next)
+		})
+		if err != nil {
+			return err
+		}
+
+		if next == "" {
+			s.logger.Info("Batched migration finished successfully",
+				mlog.String("key", migration.key),
+				mlog.Int("batches", batch),
+			)
+			return nil
+		}
+
+		s.logger.Debug("Batched migration progress",
+			mlog.String("key", migration.key),
+			mlog.Int("batch", batch),
+			mlog.String("checkpoint", next),
+		)
+		checkpoint = next
+
+		// give way to the regular traffic between batches.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(batchedMigrationPause):
+		}
+	}
+}
+
+// backfillBlocksHistoryBoardID sets the board of the history rows that
+// the teams and boards migration left without one, because their block
+// was nested too deep to find its board, taking it from the current
+// version of the block. The rows of the blocks that don't exist anymore
+// are left as they are.
+func backfillBlocksHistoryBoardID(s *SQLStore, db sq.BaseRunner, checkpoint string, batchSize int) (string, error) {
+	noBoardID := sq.Or{sq.Eq{"board_id": nil}, sq.Eq{"board_id": ""}}
+
+	rows, err := s.getQueryBuilder(db).
+		Select("id").
+		Distinct().
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Gt{"id": checkpoint}).
+		Where(noBoardID).
+		OrderBy("id").
+		Limit(uint64(batchSize)).
+		QueryContext(s.context())
+	if err != nil {
+		return "", err
+	}
+	defer s.CloseRows(rows)
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return "", err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	if len(ids) == 0 {
+		return "", nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"blocks_history").
+		Set("board_id", sq.Expr(fmt.Sprintf("(SELECT MAX(b.board_id) FROM %[1]sblocks AS b WHERE b.id = %[1]sblocks_history.id)", s.tablePrefix))).
+		Where(sq.Eq{"id": ids}).
+		Where(noBoardID).
+		Where(fmt.Sprintf("EXISTS (SELECT 1 FROM %[1]sblocks AS b WHERE b.id = %[1]sblocks_history.id)", s.tablePrefix))
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		return "", err
+	}
+
+	return ids[len(ids)-1], nil
+}
@@ -0,0 +1,188 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+var errBatchFailed = errors.New("batch failed")
+
+// blockIDsMigration walks the blocks by ID, recording the IDs of each
+// batch, and fails on the batch number failOnBatch if set.
+type blockIDsMigration struct {
+	batches     [][]string
+	failOnBatch int
+}
+
+func (m *blockIDsMigration) runBatch(s *SQLStore, db sq.BaseRunner, checkpoint string, batchSize int) (string, error) {
+	if m.failOnBatch != 0 && len(m.batches)+1 == m.failOnBatch {
+		m.failOnBatch = 0
+		return "", errBatchFailed
+	}
+
+	rows, err := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Gt{"id": checkpoint}).
+		OrderBy("id").
+		Limit(uint64(batchSize)).
+		Query()
+	if err != nil {
+		return "", err
+	}
+	defer s.CloseRows(rows)
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return "", err
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return "", nil
+	}
+
+	m.batches = append(m.batches, ids)
+	return ids[len(ids)-1], nil
+}
+
+func TestRunBatchedMigration(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	expectedIDs := []string{}
+	for i := 0; i < 5; i++ {
+		block := &model.Block{
+			ID:      fmt.Sprintf("block-id-%d", i),
+			BoardID: "board-id",
+			Type:    model.TypeCard,
+		}
+		require.NoError(t, sqlStore.InsertBlock(block, "user-id"))
+		expectedIDs = append(expectedIDs, block.ID)
+	}
+	sort.Strings(expectedIDs)
+
+	t.Run("processes all the rows in batches and marks the migration as done", func(t *testing.T) {
+		m := &blockIDsMigration{}
+		migration := batchedMigration{key: "TestBatchedMigrationComplete", batchSize: 2, runBatch: m.runBatch}
+
+		require.NoError(t, sqlStore.runBatchedMigration(context.Background(), migration))
+		require.Len(t, m.batches, 3)
+
+		processedIDs := []string{}
+		for _, batch := range m.batches {
+			require.LessOrEqual(t, len(batch), 2)
+			processedIDs = append(processedIDs, batch...)
+		}
+		require.Equal(t, expectedIDs, processedIDs)
+
+		setting, err := sqlStore.GetSystemSetting(migration.key)
+		require.NoError(t, err)
+		require.Equal(t, "true", setting)
+
+		// a completed migration doesn't run again.
+		m.batches = nil
+		require.NoError(t, sqlStore.runBatchedMigration(context.Background(), migration))
+		require.Empty(t, m.batches)
+	})
+
+	t.Run("resumes from the last checkpoint after a failure", func(t *testing.T) {
+		m := &blockIDsMigration{failOnBatch: 2}
+		migration := batchedMigration{key: "TestResumedBatchedMigrationComplete", batchSize: 2, runBatch: m.runBatch}
+
+		err := sqlStore.runBatchedMigration(context.Background(), migration)
+		require.ErrorIs(t, err, errBatchFailed)
+		require.Len(t, m.batches, 1)
+
+		checkpoint, err := sqlStore.GetSystemSetting(migration.key + batchedMigrationCheckpointSuffix)
+		require.NoError(t, err)
+		require.Equal(t, m.batches[0][len(m.batches[0])-1], checkpoint)
+
+		require.NoError(t, sqlStore.runBatchedMigration(context.Background(), migration))
+
+		processedIDs := []string{}
+		for _, batch := range m.batches {
+			processedIDs = append(processedIDs, batch...)
+		}
+		require.Equal(t, expectedIDs, processedIDs)
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		m := &blockIDsMigration{}
+		migration := batchedMigration{key: "TestCancelledBatchedMigrationComplete", batchSize: 2, runBatch: m.runBatch}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := sqlStore.runBatchedMigration(ctx, migration)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Empty(t, m.batches)
+	})
+}
+
+func TestBackfillBlocksHistoryBoardID(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	for i := 0; i < 3; i++ {
+		block := &model.Block{
+			ID:      fmt.Sprintf("block-id-%d", i),
+			BoardID: "board-id",
+			Type:    model.TypeCard,
+		}
+		require.NoError(t, sqlStore.InsertBlock(block, "user-id"))
+	}
+
+	// the history rows of two blocks and of a block that doesn't exist
+	// anymore are missing their board
+	_, err := sqlStore.getQueryBuilder(sqlStore.db).
+		Update(sqlStore.tablePrefix+"blocks_history").
+		Set("board_id", "").
+		Where(sq.Eq{"id": []string{"block-id-0", "block-id-2"}}).
+		Exec()
+	require.NoError(t, err)
+	_, err = sqlStore.getQueryBuilder(sqlStore.db).
+		Insert(sqlStore.tablePrefix+"blocks_history").
+		Columns("id", "board_id", "parent_id", "type", "title", "fields", "modified_by", "created_by", "create_at", "update_at", "delete_at").
+		Values("deleted-block-id", "", "", model.TypeCard, "", "{}", "user-id", "user-id", 1, 1, 1).
+		Exec()
+	require.NoError(t, err)
+
+	migration := batchedMigration{key: "TestBackfillBlocksHistoryBoardIDComplete", batchSize: 1, runBatch: backfillBlocksHistoryBoardID}
+	require.NoError(t, sqlStore.runBatchedMigration(context.Background(), migration))
+
+	setting, err := sqlStore.GetSystemSetting(migration.key)
+	require.NoError(t, err)
+	require.Equal(t, "true", setting)
+
+	boardIDs := map[string]string{}
+	rows, err := sqlStore.getQueryBuilder(sqlStore.db).
+		Select("id", "COALESCE(board_id, '')").
+		From(sqlStore.tablePrefix + "blocks_history").
+		Query()
+	require.NoError(t, err)
+	defer sqlStore.CloseRows(rows)
+	for rows.Next() {
+		var id, boardID string
+		require.NoError(t, rows.Scan(&id, &boardID))
+		boardIDs[id] = boardID
+	}
+
+	require.Equal(t, "board-id", boardIDs["block-id-0"])
+	require.Equal(t, "board-id", boardIDs["block-id-1"])
+	require.Equal(t, "board-id", boardIDs["block-id-2"])
+	require.Equal(t, "", boardIDs["deleted-block-id"])
+}
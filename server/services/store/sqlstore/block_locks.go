@@ -0,0 +1,134 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var blockLockFields = []string{
+	"block_id",
+	"board_id",
+	"user_id",
+	"create_at",
+	"expires_at",
+}
+
+func (s *SQLStore) getBlockLock(db sq.BaseRunner, blockID string) (*model.BlockLock, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(blockLockFields...).
+		From(s.tablePrefix + "block_locks").
+		Where(sq.Eq{"block_id": blockID}).
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getBlockLock error", mlog.String("blockID", blockID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	locks, err := s.blockLocksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(locks) == 0 {
+		return nil, store.NewErrNotFound("block lock " + blockID)
+	}
+	return locks[0], nil
+}
+
+// acquireBlockLock stores the lock, unless the block is locked by another
+// user and the lock hasn't expired at lock.CreateAt. It returns the lock
+// of the block once stored, which is the lock of the other user in that
+// case. A lock renewed by its holder keeps the time it was first
+// acquired. The expired locks of the board are removed on the way.
+func (s *SQLStore) acquireBlockLock(db sq.BaseRunner, lock *model.BlockLock) (*model.BlockLock, error) {
+	now := lock.CreateAt
+
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "block_locks").
+		Where(sq.Or{sq.Eq{"block_id": lock.BlockID}, sq.Eq{"board_id": lock.BoardID}}).
+		Where(sq.LtOrEq{"expires_at": now}).
+		ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("acquireBlockLock delete error", mlog.String("blockID", lock.BlockID), mlog.Err(err))
+		return nil, err
+	}
+
+	// the lock is stored with a single statement, so two users acquiring
+	// it at the same time can't both get it: the lock of the other user
+	// is kept as is, and the holder only renews it.
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"block_locks").
+		Columns(blockLockFields...).
+		Values(lock.BlockID, lock.BoardID, lock.UserID, lock.CreateAt, lock.ExpiresAt)
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE expires_at = IF(user_id = VALUES(user_id), VALUES(expires_at), expires_at)")
+	} else {
+		query = query.Suffix(fmt.Sprintf("ON CONFLICT (block_id) DO UPDATE SET expires_at = EXCLUDED.expires_at WHERE %sblock_locks.user_id = EXCLUDED.user_id", s.tablePrefix))
+	}
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("acquireBlockLock insert error", mlog.String("blockID", lock.BlockID), mlog.Err(err))
+		return nil, err
+	}
+	return s.getBlockLock(db, lock.BlockID)
+}
+
+// releaseBlockLock removes the lock of the block if it is held by the
+// user.
+func (s *SQLStore) releaseBlockLock(db sq.BaseRunner, blockID, userID string) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "block_locks").
+		Where(sq.Eq{"block_id": blockID}).
+		Where(sq.Eq{"user_id": userID}).
+		ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("releaseBlockLock error", mlog.String("blockID", blockID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getBlockLocksForBoard returns the locks of the board that haven't
+// expired at now.
+func (s *SQLStore) getBlockLocksForBoard(db sq.BaseRunner, boardID string, now int64) ([]*model.BlockLock, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(blockLockFields...).
+		From(s.tablePrefix+"block_locks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Gt{"expires_at": now}).
+		OrderBy("create_at", "block_id").
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getBlockLocksForBoard error", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blockLocksFromRows(rows)
+}
+
+func (s *SQLStore) blockLocksFromRows(rows *sql.Rows) ([]*model.BlockLock, error) {
+	results := []*model.BlockLock{}
+	for rows.Next() {
+		var lock model.BlockLock
+		err := rows.Scan(
+			&lock.BlockID,
+			&lock.BoardID,
+			&lock.UserID,
+			&lock.CreateAt,
+			&lock.ExpiresAt,
+		)
+		if err != nil {
+			s.logger.Error("blockLocksFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		results = append(results, &lock)
+	}
+	return results, nil
+}
@@ -43,7 +43,7 @@ func (s *SQLStore) timestampToCharField(name string, as string) string {
 	switch s.dbType {
 	case model.MysqlDBType:
 		return fmt.Sprintf("date_format(%s, '%%Y-%%m-%%d %%H:%%i:%%S') AS %s", name, as)
-	case model.PostgresDBType:
+	case model.PostgresDBType, model.CockroachDBType:
 		return fmt.Sprintf("to_char(%s, 'YYYY-MM-DD HH:MI:SS.MS') AS %s", name, as)
 	default:
 		return fmt.Sprintf("%s AS %s", name, as)
@@ -76,7 +76,7 @@ func (s *SQLStore) getBlocksWithParentAndType(db sq.BaseRunner, boardID, parentI
 		Where(sq.Eq{"parent_id": parentID}).
 		Where(sq.Eq{"type": blockType})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getBlocksWithParentAndType ERROR`, mlog.Err(err))
 
@@ -94,7 +94,7 @@ func (s *SQLStore) getBlocksWithParent(db sq.BaseRunner, boardID, parentID strin
 		Where(sq.Eq{"parent_id": parentID}).
 		Where(sq.Eq{"board_id": boardID})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getBlocksWithParent ERROR`, mlog.Err(err))
 
@@ -111,7 +111,7 @@ func (s *SQLStore) getBlocksWithBoardID(db sq.BaseRunner, boardID string) ([]mod
 		From(s.tablePrefix + "blocks").
 		Where(sq.Eq{"board_id": boardID})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`GetBlocksWithBoardID ERROR`, mlog.Err(err))
 
@@ -129,7 +129,7 @@ func (s *SQLStore) getBlocksWithType(db sq.BaseRunner, boardID, blockType string
 		Where(sq.Eq{"type": blockType}).
 		Where(sq.Eq{"board_id": boardID})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getBlocksWithParentAndType ERROR`, mlog.Err(err))
 
@@ -140,15 +140,68 @@ func (s *SQLStore) getBlocksWithType(db sq.BaseRunner, boardID, blockType string
 	return s.blocksFromRows(rows)
 }
 
-// getSubTree2 returns blocks within 2 levels of the given blockID.
+// getCardsWithFieldValue returns the cards of the given boards whose
+// fields contain the value as a JSON string. The caller is expected to
+// check in which field the value was found.
+func (s *SQLStore) getCardsWithFieldValue(db sq.BaseRunner, boardIDs []string, value string) ([]model.Block, error) {
+	if len(boardIDs) == 0 || value == "" {
+		return []model.Block{}, nil
+	}
+
+	fieldsColumn := "fields"
+	if s.isPostgresCompatible() {
+		fieldsColumn = "fields::text"
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardIDs}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Like{fieldsColumn: "%\"" + value + "\"%"})
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error(`getCardsWithFieldValue ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+// getSubTree2 returns blocks within 2 levels of the given blockID, or
+// within opts.Depth+1 levels if a greater depth is requested.
 func (s *SQLStore) getSubTree2(db sq.BaseRunner, boardID string, blockID string, opts model.QuerySubtreeOptions) ([]model.Block, error) {
 	query := s.getQueryBuilder(db).
 		Select(s.blockFields()...).
 		From(s.tablePrefix + "blocks").
-		Where(sq.Or{sq.Eq{"id": blockID}, sq.Eq{"parent_id": blockID}}).
 		Where(sq.Eq{"board_id": boardID}).
 		OrderBy("insert_at, update_at")
 
+	switch {
+	case opts.Depth <= 1:
+		query = query.Where(sq.Or{sq.Eq{"id": blockID}, sq.Eq{"parent_id": blockID}})
+	case s.dbType == model.MysqlDBType:
+		// MySQL 5.7 doesn't support recursive CTEs, so the tree is
+		// walked a level at a time
+		ids, err := s.getSubTreeIDs(db, boardID, blockID, opts.Depth)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(sq.Eq{"id": ids})
+	default:
+		query = query.
+			Prefix(`WITH RECURSIVE subtree(id, depth) AS (
+				SELECT id, 0 FROM `+s.tablePrefix+`blocks WHERE id = ? AND board_id = ?
+				UNION ALL
+				SELECT b.id, subtree.depth + 1
+				FROM `+s.tablePrefix+`blocks b JOIN subtree ON b.parent_id = subtree.id
+				WHERE b.board_id = ? AND subtree.depth < ?
+			)`, blockID, boardID, boardID, opts.Depth).
+			Where("id IN (SELECT id FROM subtree)")
+	}
+
 	if opts.BeforeUpdateAt != 0 {
 		query = query.Where(sq.LtOrEq{"update_at": opts.BeforeUpdateAt})
 	}
@@ -161,7 +214,7 @@ func (s *SQLStore) getSubTree2(db sq.BaseRunner, boardID string, blockID string,
 		query = query.Limit(opts.Limit)
 	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getSubTree ERROR`, mlog.Err(err))
 
@@ -172,13 +225,45 @@ func (s *SQLStore) getSubTree2(db sq.BaseRunner, boardID string, blockID string,
 	return s.blocksFromRows(rows)
 }
 
+// getSubTreeIDs returns the IDs of the block and of its descendants up to
+// depth levels below it.
+func (s *SQLStore) getSubTreeIDs(db sq.BaseRunner, boardID, blockID string, depth int) ([]string, error) {
+	ids := []string{blockID}
+	parentIDs := []string{blockID}
+	for level := 0; level < depth && len(parentIDs) > 0; level++ {
+		rows, err := s.getQueryBuilder(db).
+			Select("id").
+			From(s.tablePrefix + "blocks").
+			Where(sq.Eq{"board_id": boardID}).
+			Where(sq.Eq{"parent_id": parentIDs}).
+			QueryContext(s.context())
+		if err != nil {
+			s.logger.Error(`getSubTreeIDs ERROR`, mlog.Err(err))
+			return nil, err
+		}
+
+		parentIDs = []string{}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				s.CloseRows(rows)
+				return nil, err
+			}
+			parentIDs = append(parentIDs, id)
+		}
+		s.CloseRows(rows)
+		ids = append(ids, parentIDs...)
+	}
+	return ids, nil
+}
+
 func (s *SQLStore) getBlocksForBoard(db sq.BaseRunner, boardID string) ([]model.Block, error) {
 	query := s.getQueryBuilder(db).
 		Select(s.blockFields()...).
 		From(s.tablePrefix + "blocks").
 		Where(sq.Eq{"board_id": boardID})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getAllBlocksForBoard ERROR`, mlog.Err(err))
 		return nil, err
@@ -188,6 +273,75 @@ func (s *SQLStore) getBlocksForBoard(db sq.BaseRunner, boardID string) ([]model.
 	return s.blocksFromRows(rows)
 }
 
+func (s *SQLStore) getBlocksChangedSince(db sq.BaseRunner, boardID string, since int64) ([]model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Gt{"update_at": since}).
+		OrderBy("update_at")
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error(`getBlocksChangedSince ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+// getCardsUpdatedBefore returns the cards of a board that weren't
+// updated since before, the least recently updated first.
+func (s *SQLStore) getCardsUpdatedBefore(db sq.BaseRunner, boardID string, before int64) ([]model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix+"blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Eq{"delete_at": 0}).
+		Where(sq.Lt{"update_at": before}).
+		OrderBy("update_at", "id")
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error(`getCardsUpdatedBefore ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+// getDeletedBlockIDsSince returns the IDs of the blocks of a board that
+// were deleted after since, and weren't restored.
+func (s *SQLStore) getDeletedBlockIDsSince(db sq.BaseRunner, boardID string, since int64) ([]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("bh.id").
+		Distinct().
+		From(s.tablePrefix + "blocks_history as bh").
+		Where(sq.Eq{"bh.board_id": boardID}).
+		Where(sq.Gt{"bh.delete_at": since}).
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]sblocks as b WHERE b.id = bh.id)", s.tablePrefix))
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error(`getDeletedBlockIDsSince ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	blockIDs := []string{}
+	for rows.Next() {
+		var blockID string
+		if err := rows.Scan(&blockID); err != nil {
+			return nil, err
+		}
+		blockIDs = append(blockIDs, blockID)
+	}
+	return blockIDs, rows.Err()
+}
+
 func (s *SQLStore) blocksFromRows(rows *sql.Rows) ([]model.Block, error) {
 	results := []model.Block{}
 
@@ -301,7 +455,7 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 			Set("update_at", block.UpdateAt).
 			Set("delete_at", block.DeleteAt)
 
-		if _, err := query.Exec(); err != nil {
+		if _, err := query.ExecContext(s.context()); err != nil {
 			s.logger.Error(`InsertBlock error occurred while updating existing block`, mlog.String("blockID", block.ID), mlog.Err(err))
 
 			return err
@@ -309,14 +463,20 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 	} else {
 		block.CreatedBy = userID
 		query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "blocks")
-		if _, err := query.Exec(); err != nil {
+		if _, err := query.ExecContext(s.context()); err != nil {
 			return err
 		}
+
+		if block.Type == model.TypeCard {
+			if _, err := s.assignCardNumber(db, block.BoardID, block.ID); err != nil {
+				return err
+			}
+		}
 	}
 
 	// writing block history
 	query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "blocks_history")
-	if _, err := query.Exec(); err != nil {
+	if _, err := query.ExecContext(s.context()); err != nil {
 		return err
 	}
 
@@ -361,6 +521,95 @@ func (s *SQLStore) insertBlocks(db sq.BaseRunner, blocks []model.Block, userID s
 	return nil
 }
 
+// insertNewBlocksBatchSize is the number of blocks written by each of
+// the statements of insertNewBlocks.
+const insertNewBlocksBatchSize = 100
+
+// insertNewBlocks inserts blocks that don't exist yet. Unlike
+// insertBlocks, the blocks and their history are written in batches
+// instead of one statement per block, and an existing ID is an error.
+func (s *SQLStore) insertNewBlocks(db sq.BaseRunner, blocks []model.Block, userID string) error {
+	for _, block := range blocks {
+		if block.BoardID == "" {
+			return BoardIDNilError{}
+		}
+	}
+
+	columns := []string{
+		"channel_id",
+		"id",
+		"parent_id",
+		"created_by",
+		"modified_by",
+		s.escapeField("schema"),
+		"type",
+		"title",
+		"fields",
+		"create_at",
+		"update_at",
+		"delete_at",
+		"board_id",
+	}
+
+	now := utils.GetMillis()
+	for start := 0; start < len(blocks); start += insertNewBlocksBatchSize {
+		end := start + insertNewBlocksBatchSize
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+
+		blocksQuery := s.getQueryBuilder(db).Insert(s.tablePrefix + "blocks").Columns(columns...)
+		historyQuery := s.getQueryBuilder(db).Insert(s.tablePrefix + "blocks_history").Columns(columns...)
+
+		for i := start; i < end; i++ {
+			block := &blocks[i]
+			fieldsJSON, err := json.Marshal(block.Fields)
+			if err != nil {
+				return err
+			}
+
+			block.CreatedBy = userID
+			block.ModifiedBy = userID
+			block.CreateAt = now
+			block.UpdateAt = now
+
+			values := []interface{}{
+				"",
+				block.ID,
+				block.ParentID,
+				userID,
+				userID,
+				block.Schema,
+				block.Type,
+				block.Title,
+				fieldsJSON,
+				now,
+				now,
+				block.DeleteAt,
+				block.BoardID,
+			}
+			blocksQuery = blocksQuery.Values(values...)
+			historyQuery = historyQuery.Values(values...)
+		}
+
+		if _, err := blocksQuery.ExecContext(s.context()); err != nil {
+			return err
+		}
+		if _, err := historyQuery.ExecContext(s.context()); err != nil {
+			return err
+		}
+	}
+
+	for _, block := range blocks {
+		if block.Type == model.TypeCard {
+			if _, err := s.assignCardNumber(db, block.BoardID, block.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (s *SQLStore) deleteBlock(db sq.BaseRunner, blockID string, modifiedBy string) error {
 	block, err := s.getBlock(db, blockID)
 	if err != nil {
@@ -408,7 +657,7 @@ func (s *SQLStore) deleteBlock(db sq.BaseRunner, blockID string, modifiedBy stri
 			block.CreatedBy,
 		)
 
-	if _, err := insertQuery.Exec(); err != nil {
+	if _, err := insertQuery.ExecContext(s.context()); err != nil {
 		return err
 	}
 
@@ -416,7 +665,7 @@ func (s *SQLStore) deleteBlock(db sq.BaseRunner, blockID string, modifiedBy stri
 		Delete(s.tablePrefix + "blocks").
 		Where(sq.Eq{"id": blockID})
 
-	if _, err := deleteQuery.Exec(); err != nil {
+	if _, err := deleteQuery.ExecContext(s.context()); err != nil {
 		return err
 	}
 
@@ -484,11 +733,11 @@ func (s *SQLStore) undeleteBlock(db sq.BaseRunner, blockID string, modifiedBy st
 		Columns(columns...).
 		Values(values...)
 
-	if _, err := insertHistoryQuery.Exec(); err != nil {
+	if _, err := insertHistoryQuery.ExecContext(s.context()); err != nil {
 		return err
 	}
 
-	if _, err := insertQuery.Exec(); err != nil {
+	if _, err := insertQuery.ExecContext(s.context()); err != nil {
 		return err
 	}
 
@@ -504,7 +753,7 @@ func (s *SQLStore) getBlockCountsByType(db sq.BaseRunner) (map[string]int64, err
 		From(s.tablePrefix + "blocks").
 		GroupBy("type")
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`GetBlockCountsByType ERROR`, mlog.Err(err))
 
@@ -534,7 +783,7 @@ func (s *SQLStore) getBlock(db sq.BaseRunner, blockID string) (*model.Block, err
 		From(s.tablePrefix + "blocks").
 		Where(sq.Eq{"id": blockID})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`GetBlock ERROR`, mlog.Err(err))
 		return nil, err
@@ -553,6 +802,26 @@ func (s *SQLStore) getBlock(db sq.BaseRunner, blockID string) (*model.Block, err
 	return &blocks[0], nil
 }
 
+func (s *SQLStore) getBlocksByIDs(db sq.BaseRunner, ids []string) ([]model.Block, error) {
+	if len(ids) == 0 {
+		return []model.Block{}, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"id": ids})
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error(`GetBlocksByIDs ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
 func (s *SQLStore) getBlockHistory(db sq.BaseRunner, blockID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error) {
 	var order string
 	if opts.Descending {
@@ -577,7 +846,7 @@ func (s *SQLStore) getBlockHistory(db sq.BaseRunner, blockID string, opts model.
 		query = query.Limit(opts.Limit)
 	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`GetBlockHistory ERROR`, mlog.Err(err))
 		return nil, err
@@ -611,7 +880,7 @@ func (s *SQLStore) getBlockHistoryDescendants(db sq.BaseRunner, boardID string,
 		query = query.Limit(opts.Limit)
 	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`GetBlockHistory ERROR`, mlog.Err(err))
 		return nil, err
@@ -682,11 +951,11 @@ func (s *SQLStore) getBoardAndCard(db sq.BaseRunner, block *model.Block) (board
 
 func (s *SQLStore) replaceBlockID(db sq.BaseRunner, currentID, newID, workspaceID string) error {
 	runUpdateForBlocksAndHistory := func(query sq.UpdateBuilder) error {
-		if _, err := query.Table(s.tablePrefix + "blocks").Exec(); err != nil {
+		if _, err := query.Table(s.tablePrefix + "blocks").ExecContext(s.context()); err != nil {
 			return err
 		}
 
-		if _, err := query.Table(s.tablePrefix + "blocks_history").Exec(); err != nil {
+		if _, err := query.Table(s.tablePrefix + "blocks_history").ExecContext(s.context()); err != nil {
 			return err
 		}
 
@@ -728,7 +997,7 @@ func (s *SQLStore) replaceBlockID(db sq.BaseRunner, currentID, newID, workspaceI
 
 	// update parent contentOrder
 	updateContentOrder := baseQuery.Update("")
-	if s.dbType == model.PostgresDBType {
+	if s.isPostgresCompatible() {
 		updateContentOrder = updateContentOrder.
 			Set("fields", sq.Expr("REPLACE(fields::text, ?, ?)::json", currentID, newID)).
 			Where(sq.Like{"fields->>'contentOrder'": "%" + currentID + "%"}).
@@ -753,12 +1022,13 @@ func (s *SQLStore) runDataRetention(db sq.BaseRunner, globalRetentionDate int64,
 		mlog.String("Global Retention Date", time.Unix(globalRetentionDate/1000, 0).String()),
 		mlog.Int64("Raw Date", globalRetentionDate))
 	deleteTables := map[string]string{
-		"blocks":         "board_id",
-		"blocks_history": "board_id",
-		"boards":         "id",
-		"boards_history": "id",
-		"board_members":  "board_id",
-		"sharing":        "id",
+		"blocks":            "board_id",
+		"blocks_history":    "board_id",
+		"boards":            "id",
+		"boards_history":    "id",
+		"board_members":     "board_id",
+		"board_invitations": "board_id",
+		"sharing":           "id",
 	}
 
 	subBuilder := s.getQueryBuilder(db).
@@ -776,7 +1046,7 @@ func (s *SQLStore) runDataRetention(db sq.BaseRunner, globalRetentionDate int64,
 		Where(sq.NotEq{"team_id": "0"}).
 		Where(sq.Eq{"is_template": false})
 
-	rows, err := builder.Query()
+	rows, err := builder.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`dataRetention subquery ERROR`, mlog.Err(err))
 		return 0, err
@@ -836,7 +1106,7 @@ func (s *SQLStore) genericRetentionPoliciesDeletion(
 	var totalRowsAffected int64
 	var batchRowsAffected int64
 	for {
-		result, err := deleteQuery.Exec()
+		result, err := deleteQuery.ExecContext(s.context())
 		if err != nil {
 			return 0, errors.Wrap(err, "failed to delete "+table)
 		}
@@ -885,3 +1155,88 @@ func (s *SQLStore) duplicateBlock(db sq.BaseRunner, boardID string, blockID stri
 	}
 	return allBlocks, nil
 }
+
+// moveBlocks moves the blocks to another board, keeping their IDs. The
+// blocks whose parent is the source board, such as the cards, get the
+// destination board as their parent. The history records the blocks as
+// updated in the destination board: a second row recording them as
+// deleted from the source board would share the same ID and insert time,
+// which is the key of the history.
+func (s *SQLStore) moveBlocks(db sq.BaseRunner, blocks []model.Block, toBoardID string, userID string) ([]model.Block, error) {
+	now := utils.GetMillis()
+	movedBlocks := make([]model.Block, 0, len(blocks))
+
+	for _, block := range blocks {
+		fieldsJSON, err := json.Marshal(block.Fields)
+		if err != nil {
+			return nil, err
+		}
+
+		parentID := block.ParentID
+		if parentID == block.BoardID {
+			parentID = toBoardID
+		}
+
+		historyQuery := s.getQueryBuilder(db).Insert(s.tablePrefix+"blocks_history").
+			Columns(
+				"board_id",
+				"id",
+				"parent_id",
+				s.escapeField("schema"),
+				"type",
+				"title",
+				"fields",
+				"modified_by",
+				"create_at",
+				"update_at",
+				"delete_at",
+				"created_by",
+			).
+			Values(
+				toBoardID,
+				block.ID,
+				parentID,
+				block.Schema,
+				block.Type,
+				block.Title,
+				fieldsJSON,
+				userID,
+				block.CreateAt,
+				now,
+				0,
+				block.CreatedBy,
+			)
+
+		if _, err := historyQuery.ExecContext(s.context()); err != nil {
+			return nil, err
+		}
+
+		updateQuery := s.getQueryBuilder(db).Update(s.tablePrefix+"blocks").
+			Where(sq.Eq{"id": block.ID}).
+			Where(sq.Eq{"board_id": block.BoardID}).
+			Set("board_id", toBoardID).
+			Set("parent_id", parentID).
+			Set("fields", fieldsJSON).
+			Set("modified_by", userID).
+			Set("update_at", now)
+
+		result, err := updateQuery.ExecContext(s.context())
+		if err != nil {
+			return nil, err
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, BlockNotFoundErr{block.ID}
+		}
+
+		block.BoardID = toBoardID
+		block.ParentID = parentID
+		block.ModifiedBy = userID
+		block.UpdateAt = now
+		movedBlocks = append(movedBlocks, block)
+	}
+	return movedBlocks, nil
+}
@@ -92,6 +92,9 @@ var boardMemberFields = []string{
 	"scheme_editor",
 	"scheme_commenter",
 	"scheme_viewer",
+	"inactive",
+	"delete_at",
+	"COALESCE(custom_fields, '')",
 }
 
 func (s *SQLStore) boardsFromRows(rows *sql.Rows) ([]*model.Board, error) {
@@ -148,6 +151,7 @@ func (s *SQLStore) boardMembersFromRows(rows *sql.Rows) ([]*model.BoardMember, e
 
 	for rows.Next() {
 		var boardMember model.BoardMember
+		var customFieldsJSON string
 
 		err := rows.Scan(
 			&boardMember.BoardID,
@@ -157,10 +161,19 @@ func (s *SQLStore) boardMembersFromRows(rows *sql.Rows) ([]*model.BoardMember, e
 			&boardMember.SchemeEditor,
 			&boardMember.SchemeCommenter,
 			&boardMember.SchemeViewer,
+			&boardMember.Inactive,
+			&boardMember.DeleteAt,
+			&customFieldsJSON,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if customFieldsJSON != "" {
+			if err := json.Unmarshal([]byte(customFieldsJSON), &boardMember.CustomFields); err != nil {
+				s.logger.Error("board member custom fields json.Unmarshal", mlog.String("board_id", boardMember.BoardID), mlog.String("user_id", boardMember.UserID), mlog.Err(err))
+				return nil, err
+			}
+		}
 
 		boardMembers = append(boardMembers, &boardMember)
 	}
@@ -179,6 +192,7 @@ func (s *SQLStore) boardMemberHistoryEntriesFromRows(rows *sql.Rows) ([]*model.B
 			&boardMemberHistoryEntry.BoardID,
 			&boardMemberHistoryEntry.UserID,
 			&boardMemberHistoryEntry.Action,
+			&boardMemberHistoryEntry.Reason,
 			&insertAt,
 		)
 		if err != nil {
@@ -219,7 +233,7 @@ func (s *SQLStore) getBoardsByCondition(db sq.BaseRunner, conditions ...interfac
 		query = query.Where(c)
 	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getBoardsByCondition ERROR`, mlog.Err(err))
 		return nil, err
@@ -242,23 +256,41 @@ func (s *SQLStore) getBoard(db sq.BaseRunner, boardID string) (*model.Board, err
 	return s.getBoardByCondition(db, sq.Eq{"id": boardID})
 }
 
+func (s *SQLStore) getBoardsForChannel(db sq.BaseRunner, channelID string) ([]*model.Board, error) {
+	boards, err := s.getBoardsByCondition(db, sq.Eq{"channel_id": channelID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return []*model.Board{}, nil
+	}
+	return boards, err
+}
+
+// boardTeamCondition matches the boards listed in a team: its own
+// boards, the boards shared with it and the boards of the organization.
+func (s *SQLStore) boardTeamCondition(teamID string) sq.Sqlizer {
+	return sq.Or{
+		sq.Eq{"b.team_id": []string{teamID, model.OrganizationTeamID}},
+		sq.Expr(fmt.Sprintf("b.id IN (SELECT board_id FROM %sboard_teams WHERE team_id = ?)", s.tablePrefix), teamID),
+	}
+}
+
 func (s *SQLStore) getBoardsForUserAndTeam(db sq.BaseRunner, userID, teamID string) ([]*model.Board, error) {
 	query := s.getQueryBuilder(db).
 		Select(boardFields("b.")...).
 		Distinct().
 		From(s.tablePrefix + "boards as b").
 		LeftJoin(s.tablePrefix + "board_members as bm on b.id=bm.board_id").
-		Where(sq.Eq{"b.team_id": teamID}).
+		Where(s.boardTeamCondition(teamID)).
 		Where(sq.Eq{"b.is_template": false}).
 		Where(sq.Or{
 			sq.Eq{"b.type": model.BoardTypeOpen},
 			sq.And{
 				sq.Eq{"b.type": model.BoardTypePrivate},
 				sq.Eq{"bm.user_id": userID},
+				sq.Eq{"bm.delete_at": 0},
 			},
 		})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getBoardsForUserAndTeam ERROR`, mlog.Err(err))
 		return nil, err
@@ -325,6 +357,7 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 		query := s.getQueryBuilder(db).Update(s.tablePrefix+"boards").
 			Where(sq.Eq{"id": board.ID}).
 			Set("modified_by", userID).
+			Set("channel_id", board.ChannelID).
 			Set("type", board.Type).
 			Set("title", board.Title).
 			Set("description", board.Description).
@@ -337,7 +370,7 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 			Set("update_at", now).
 			Set("delete_at", board.DeleteAt)
 
-		if _, err := query.Exec(); err != nil {
+		if _, err := query.ExecContext(s.context()); err != nil {
 			s.logger.Error(`InsertBoard error occurred while updating existing board`, mlog.String("boardID", board.ID), mlog.Err(err))
 			return nil, fmt.Errorf("insertBoard error occurred while updating existing board %s: %w", board.ID, err)
 		}
@@ -347,14 +380,14 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 		insertQueryValues["update_at"] = now
 
 		query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "boards")
-		if _, err := query.Exec(); err != nil {
+		if _, err := query.ExecContext(s.context()); err != nil {
 			return nil, fmt.Errorf("insertBoard error occurred while inserting board %s: %w", board.ID, err)
 		}
 	}
 
 	// writing board history
 	query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "boards_history")
-	if _, err := query.Exec(); err != nil {
+	if _, err := query.ExecContext(s.context()); err != nil {
 		s.logger.Error("failed to insert board history", mlog.String("board_id", board.ID), mlog.Err(err))
 		return nil, fmt.Errorf("failed to insert board %s history: %w", board.ID, err)
 	}
@@ -417,7 +450,7 @@ func (s *SQLStore) deleteBoard(db sq.BaseRunner, boardID, userID string) error {
 		Columns(boardHistoryFields()...)
 
 	query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "boards_history")
-	if _, err := query.Exec(); err != nil {
+	if _, err := query.ExecContext(s.context()); err != nil {
 		return err
 	}
 
@@ -426,7 +459,7 @@ func (s *SQLStore) deleteBoard(db sq.BaseRunner, boardID, userID string) error {
 		Where(sq.Eq{"id": boardID}).
 		Where(sq.Eq{"COALESCE(team_id, '0')": board.TeamID})
 
-	if _, err := deleteQuery.Exec(); err != nil {
+	if _, err := deleteQuery.ExecContext(s.context()); err != nil {
 		return err
 	}
 
@@ -455,6 +488,15 @@ func (s *SQLStore) insertBoardWithAdmin(db sq.BaseRunner, board *model.Board, us
 }
 
 func (s *SQLStore) saveMember(db sq.BaseRunner, bm *model.BoardMember) (*model.BoardMember, error) {
+	return s.saveMemberWithHistory(db, bm, "", "")
+}
+
+// saveMemberWithHistory saves the member, recording the action and its
+// reason in the board members history. Without an action, only the
+// creation of a new member is recorded. The history is keyed by the
+// insert time, which is the time of the transaction on Postgres, so a
+// single row is recorded for the member.
+func (s *SQLStore) saveMemberWithHistory(db sq.BaseRunner, bm *model.BoardMember, action, reason string) (*model.BoardMember, error) {
 	queryValues := map[string]interface{}{
 		"board_id":         bm.BoardID,
 		"user_id":          bm.UserID,
@@ -463,6 +505,7 @@ func (s *SQLStore) saveMember(db sq.BaseRunner, bm *model.BoardMember) (*model.B
 		"scheme_editor":    bm.SchemeEditor,
 		"scheme_commenter": bm.SchemeCommenter,
 		"scheme_viewer":    bm.SchemeViewer,
+		"delete_at":        0,
 	}
 
 	oldMember, err := s.getMemberForBoard(db, bm.BoardID, bm.UserID)
@@ -476,41 +519,63 @@ func (s *SQLStore) saveMember(db sq.BaseRunner, bm *model.BoardMember) (*model.B
 
 	if s.dbType == model.MysqlDBType {
 		query = query.Suffix(
-			"ON DUPLICATE KEY UPDATE scheme_admin = ?, scheme_editor = ?, scheme_commenter = ?, scheme_viewer = ?",
+			"ON DUPLICATE KEY UPDATE scheme_admin = ?, scheme_editor = ?, scheme_commenter = ?, scheme_viewer = ?, delete_at = 0",
 			bm.SchemeAdmin, bm.SchemeEditor, bm.SchemeCommenter, bm.SchemeViewer)
 	} else {
 		query = query.Suffix(
 			`ON CONFLICT (board_id, user_id)
              DO UPDATE SET scheme_admin = EXCLUDED.scheme_admin, scheme_editor = EXCLUDED.scheme_editor,
-			   scheme_commenter = EXCLUDED.scheme_commenter, scheme_viewer = EXCLUDED.scheme_viewer`,
+			   scheme_commenter = EXCLUDED.scheme_commenter, scheme_viewer = EXCLUDED.scheme_viewer,
+			   delete_at = 0`,
 		)
 	}
 
-	if _, err := query.Exec(); err != nil {
+	if _, err := query.ExecContext(s.context()); err != nil {
 		return nil, err
 	}
 
-	if oldMember == nil {
-		addToMembersHistory := s.getQueryBuilder(db).
-			Insert(s.tablePrefix+"board_members_history").
-			Columns("board_id", "user_id", "action").
-			Values(bm.BoardID, bm.UserID, "created")
-
-		if _, err := addToMembersHistory.Exec(); err != nil {
-			return nil, err
+	if action == "" {
+		if oldMember != nil {
+			return bm, nil
 		}
+		action = "created"
+	}
+
+	addToMembersHistory := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_members_history").
+		Columns("board_id", "user_id", "action", "reason").
+		Values(bm.BoardID, bm.UserID, action, reason)
+
+	if _, err := addToMembersHistory.ExecContext(s.context()); err != nil {
+		return nil, err
 	}
 
 	return bm, nil
 }
 
+// joinBoard creates the membership of a user that joins an open board by
+// themselves, recording the join in the board members history.
+func (s *SQLStore) joinBoard(db sq.BaseRunner, bm *model.BoardMember) (*model.BoardMember, error) {
+	return s.saveMemberWithHistory(db, bm, model.BoardMemberActionJoined, "")
+}
+
+// saveMemberRoles saves the new roles of a member, recording the change
+// and its reason in the board members history.
+func (s *SQLStore) saveMemberRoles(db sq.BaseRunner, bm *model.BoardMember, reason string) (*model.BoardMember, error) {
+	return s.saveMemberWithHistory(db, bm, model.BoardMemberActionUpdated, reason)
+}
+
+// deleteMember flags the membership as deleted. The row is kept, so the
+// membership can be restored with its roles.
 func (s *SQLStore) deleteMember(db sq.BaseRunner, boardID, userID string) error {
 	deleteQuery := s.getQueryBuilder(db).
-		Delete(s.tablePrefix + "board_members").
+		Update(s.tablePrefix+"board_members").
+		Set("delete_at", utils.GetMillis()).
 		Where(sq.Eq{"board_id": boardID}).
-		Where(sq.Eq{"user_id": userID})
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"delete_at": 0})
 
-	result, err := deleteQuery.Exec()
+	result, err := deleteQuery.ExecContext(s.context())
 	if err != nil {
 		return err
 	}
@@ -526,7 +591,7 @@ func (s *SQLStore) deleteMember(db sq.BaseRunner, boardID, userID string) error
 			Columns("board_id", "user_id", "action").
 			Values(boardID, userID, "deleted")
 
-		if _, err := addToMembersHistory.Exec(); err != nil {
+		if _, err := addToMembersHistory.ExecContext(s.context()); err != nil {
 			return err
 		}
 	}
@@ -534,14 +599,134 @@ func (s *SQLStore) deleteMember(db sq.BaseRunner, boardID, userID string) error
 	return nil
 }
 
-func (s *SQLStore) getMemberForBoard(db sq.BaseRunner, boardID, userID string) (*model.BoardMember, error) {
+// restoreMember clears the deleted flag of the membership, restoring it
+// with the roles it had when it was deleted. It returns sql.ErrNoRows if
+// there is no deleted membership of the user on the board.
+func (s *SQLStore) restoreMember(db sq.BaseRunner, boardID, userID string) (*model.BoardMember, error) {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_members").
+		Set("delete_at", 0).
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Gt{"delete_at": 0})
+
+	result, err := query.ExecContext(s.context())
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	if err := s.addToBoardMembersHistory(db, boardID, userID, model.BoardMemberActionRestored); err != nil {
+		return nil, err
+	}
+
+	return s.getMemberForBoard(db, boardID, userID)
+}
+
+// updateMemberCustomFields replaces the custom fields of the membership.
+func (s *SQLStore) updateMemberCustomFields(db sq.BaseRunner, boardID, userID string, customFields map[string]interface{}) error {
+	var customFieldsJSON interface{}
+	if len(customFields) > 0 {
+		data, err := json.Marshal(customFields)
+		if err != nil {
+			return err
+		}
+		customFieldsJSON = string(data)
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_members").
+		Set("custom_fields", customFieldsJSON).
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("updateMemberCustomFields ERROR", mlog.String("board_id", boardID), mlog.String("user_id", userID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getDeletedMembersForBoard returns the deleted memberships of the board
+// that can be restored, the most recently deleted first.
+func (s *SQLStore) getDeletedMembersForBoard(db sq.BaseRunner, boardID string) ([]*model.BoardMember, error) {
 	query := s.getQueryBuilder(db).
 		Select(boardMemberFields...).
 		From(s.tablePrefix + "board_members").
 		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Gt{"delete_at": 0}).
+		OrderBy("delete_at DESC")
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error(`getDeletedMembersForBoard ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardMembersFromRows(rows)
+}
+
+// setMembershipsInactive flags all the board memberships of the user
+// as inactive or active again, and returns the memberships that changed.
+func (s *SQLStore) setMembershipsInactive(db sq.BaseRunner, userID string, inactive bool) ([]*model.BoardMember, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardMemberFields...).
+		From(s.tablePrefix + "board_members").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"inactive": !inactive}).
+		Where(sq.Eq{"delete_at": 0})
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error(`setMembershipsInactive ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	members, err := s.boardMembersFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) == 0 {
+		return members, nil
+	}
+
+	updateQuery := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_members").
+		Set("inactive", inactive).
 		Where(sq.Eq{"user_id": userID})
 
-	rows, err := query.Query()
+	if _, err := updateQuery.ExecContext(s.context()); err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		member.Inactive = inactive
+	}
+
+	return members, nil
+}
+
+func (s *SQLStore) getMemberForBoard(db sq.BaseRunner, boardID, userID string) (*model.BoardMember, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardMemberFields...).
+		From(s.tablePrefix + "board_members").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getMemberForBoard ERROR`, mlog.Err(err))
 		return nil, err
@@ -564,9 +749,10 @@ func (s *SQLStore) getMembersForUser(db sq.BaseRunner, userID string) ([]*model.
 	query := s.getQueryBuilder(db).
 		Select(boardMemberFields...).
 		From(s.tablePrefix + "board_members").
-		Where(sq.Eq{"user_id": userID})
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"delete_at": 0})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getMembersForUser ERROR`, mlog.Err(err))
 		return nil, err
@@ -585,9 +771,10 @@ func (s *SQLStore) getMembersForBoard(db sq.BaseRunner, boardID string) ([]*mode
 	query := s.getQueryBuilder(db).
 		Select(boardMemberFields...).
 		From(s.tablePrefix + "board_members").
-		Where(sq.Eq{"board_id": boardID})
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"delete_at": 0})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getMembersForBoard ERROR`, mlog.Err(err))
 		return nil, err
@@ -607,13 +794,14 @@ func (s *SQLStore) searchBoardsForUserAndTeam(db sq.BaseRunner, term, userID, te
 		Distinct().
 		From(s.tablePrefix + "boards as b").
 		LeftJoin(s.tablePrefix + "board_members as bm on b.id=bm.board_id").
-		Where(sq.Eq{"b.team_id": teamID}).
+		Where(s.boardTeamCondition(teamID)).
 		Where(sq.Eq{"b.is_template": false}).
 		Where(sq.Or{
 			sq.Eq{"b.type": model.BoardTypeOpen},
 			sq.And{
 				sq.Eq{"b.type": model.BoardTypePrivate},
 				sq.Eq{"bm.user_id": userID},
+				sq.Eq{"bm.delete_at": 0},
 			},
 		})
 
@@ -633,7 +821,7 @@ func (s *SQLStore) searchBoardsForUserAndTeam(db sq.BaseRunner, term, userID, te
 		query = query.Where(conditions)
 	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`searchBoardsForUserAndTeam ERROR`, mlog.Err(err))
 		return nil, err
@@ -667,7 +855,7 @@ func (s *SQLStore) getBoardHistory(db sq.BaseRunner, boardID string, opts model.
 		query = query.Limit(opts.Limit)
 	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getBoardHistory ERROR`, mlog.Err(err))
 		return nil, err
@@ -751,11 +939,11 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 		Columns(columns...).
 		Values(values...)
 
-	if _, err := insertHistoryQuery.Exec(); err != nil {
+	if _, err := insertHistoryQuery.ExecContext(s.context()); err != nil {
 		return err
 	}
 
-	if _, err := insertQuery.Exec(); err != nil {
+	if _, err := insertQuery.ExecContext(s.context()); err != nil {
 		return err
 	}
 
@@ -764,7 +952,7 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 
 func (s *SQLStore) getBoardMemberHistory(db sq.BaseRunner, boardID, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
 	query := s.getQueryBuilder(db).
-		Select("board_id", "user_id", "action", "insert_at").
+		Select("board_id", "user_id", "action", "COALESCE(reason, '')", "insert_at").
 		From(s.tablePrefix + "board_members_history").
 		Where(sq.Eq{"board_id": boardID}).
 		Where(sq.Eq{"user_id": userID}).
@@ -774,7 +962,7 @@ func (s *SQLStore) getBoardMemberHistory(db sq.BaseRunner, boardID, userID strin
 		query = query.Limit(limit)
 	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getBoardMemberHistory ERROR`, mlog.Err(err))
 		return nil, err
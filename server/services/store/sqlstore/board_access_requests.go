@@ -0,0 +1,180 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var boardAccessRequestFields = []string{
+	"id",
+	"board_id",
+	"user_id",
+	"message",
+	"create_at",
+}
+
+func (s *SQLStore) boardAccessRequestsFromRows(rows *sql.Rows) ([]*model.BoardAccessRequest, error) {
+	requests := []*model.BoardAccessRequest{}
+
+	for rows.Next() {
+		var request model.BoardAccessRequest
+		var message sql.NullString
+		err := rows.Scan(
+			&request.ID,
+			&request.BoardID,
+			&request.UserID,
+			&message,
+			&request.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		request.Message = message.String
+		requests = append(requests, &request)
+	}
+	return requests, nil
+}
+
+// createBoardAccessRequest creates a pending access request, or updates
+// the message of the pending request of the user if there is one
+// already.
+func (s *SQLStore) createBoardAccessRequest(db sq.BaseRunner, request *model.BoardAccessRequest) (*model.BoardAccessRequest, error) {
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.getBoardAccessRequest(db, request.BoardID, request.UserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if existing != nil {
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"board_access_requests").
+			Set("message", request.Message).
+			Where(sq.Eq{"id": existing.ID})
+
+		if _, err := query.ExecContext(s.context()); err != nil {
+			return nil, err
+		}
+
+		request.ID = existing.ID
+		request.CreateAt = existing.CreateAt
+		return request, nil
+	}
+
+	request.ID = utils.NewID(utils.IDTypeNone)
+	request.CreateAt = model.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_access_requests").
+		Columns(boardAccessRequestFields...).
+		Values(
+			request.ID,
+			request.BoardID,
+			request.UserID,
+			request.Message,
+			request.CreateAt,
+		)
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("Cannot insert board access request",
+			mlog.String("board_id", request.BoardID),
+			mlog.String("user_id", request.UserID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	if err := s.addToBoardMembersHistory(db, request.BoardID, request.UserID, model.BoardMemberActionRequested); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func (s *SQLStore) getBoardAccessRequest(db sq.BaseRunner, boardID, userID string) (*model.BoardAccessRequest, error) {
+	requests, err := s.getBoardAccessRequestsByCondition(db, sq.Eq{"board_id": boardID, "user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(requests) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return requests[0], nil
+}
+
+func (s *SQLStore) getAccessRequestsForBoard(db sq.BaseRunner, boardID string) ([]*model.BoardAccessRequest, error) {
+	return s.getBoardAccessRequestsByCondition(db, sq.Eq{"board_id": boardID})
+}
+
+func (s *SQLStore) getBoardAccessRequestsByCondition(db sq.BaseRunner, condition sq.Eq) ([]*model.BoardAccessRequest, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardAccessRequestFields...).
+		From(s.tablePrefix + "board_access_requests").
+		Where(condition).
+		OrderBy("create_at")
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error(`getBoardAccessRequestsByCondition ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardAccessRequestsFromRows(rows)
+}
+
+// approveBoardAccessRequest removes the pending access request of the
+// user and saves the membership it is turned into, recording the
+// approval in the board members history.
+func (s *SQLStore) approveBoardAccessRequest(db sq.BaseRunner, member *model.BoardMember) (*model.BoardMember, error) {
+	if err := s.removeBoardAccessRequest(db, member.BoardID, member.UserID); err != nil {
+		return nil, err
+	}
+
+	return s.saveMemberWithHistory(db, member, model.BoardMemberActionApproved, "")
+}
+
+// deleteBoardAccessRequest removes the pending access request of the
+// user, recording the action that closed it in the board members history.
+func (s *SQLStore) deleteBoardAccessRequest(db sq.BaseRunner, boardID, userID, action string) error {
+	if err := s.removeBoardAccessRequest(db, boardID, userID); err != nil {
+		return err
+	}
+
+	return s.addToBoardMembersHistory(db, boardID, userID, action)
+}
+
+// removeBoardAccessRequest removes the pending access request of the
+// user. It returns sql.ErrNoRows if the user has no pending request.
+func (s *SQLStore) removeBoardAccessRequest(db sq.BaseRunner, boardID, userID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_access_requests").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"user_id": userID})
+
+	result, err := query.ExecContext(s.context())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var boardFreezeScheduleFields = []string{
+	"board_id",
+	"freeze_at",
+	"unfreeze_at",
+	"modified_by",
+	"update_at",
+}
+
+// saveBoardFreezeSchedule stores the freeze schedule of a board,
+// replacing its previous one.
+func (s *SQLStore) saveBoardFreezeSchedule(db sq.BaseRunner, schedule *model.BoardFreezeSchedule) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_freeze_schedules").
+		Columns(boardFreezeScheduleFields...).
+		Values(
+			schedule.BoardID,
+			schedule.FreezeAt,
+			schedule.UnfreezeAt,
+			schedule.ModifiedBy,
+			schedule.UpdateAt,
+		)
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE freeze_at = ?, unfreeze_at = ?, modified_by = ?, update_at = ?",
+			schedule.FreezeAt, schedule.UnfreezeAt, schedule.ModifiedBy, schedule.UpdateAt)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (board_id)
+			 DO UPDATE SET freeze_at = EXCLUDED.freeze_at, unfreeze_at = EXCLUDED.unfreeze_at,
+			 modified_by = EXCLUDED.modified_by, update_at = EXCLUDED.update_at`,
+		)
+	}
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("saveBoardFreezeSchedule error", mlog.String("boardID", schedule.BoardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) getBoardFreezeSchedule(db sq.BaseRunner, boardID string) (*model.BoardFreezeSchedule, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(boardFreezeScheduleFields...).
+		From(s.tablePrefix + "board_freeze_schedules").
+		Where(sq.Eq{"board_id": boardID}).
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getBoardFreezeSchedule error", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	schedules, err := s.boardFreezeSchedulesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		return nil, store.NewErrNotFound("board freeze schedule " + boardID)
+	}
+	return schedules[0], nil
+}
+
+func (s *SQLStore) deleteBoardFreezeSchedule(db sq.BaseRunner, boardID string) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_freeze_schedules").
+		Where(sq.Eq{"board_id": boardID}).
+		ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("deleteBoardFreezeSchedule error", mlog.String("boardID", boardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getDueBoardFreezeSchedules returns the schedules with a freeze or an
+// unfreeze due at now.
+func (s *SQLStore) getDueBoardFreezeSchedules(db sq.BaseRunner, now int64) ([]*model.BoardFreezeSchedule, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(boardFreezeScheduleFields...).
+		From(s.tablePrefix + "board_freeze_schedules").
+		Where(sq.Or{
+			sq.And{sq.Gt{"freeze_at": 0}, sq.LtOrEq{"freeze_at": now}},
+			sq.And{sq.Gt{"unfreeze_at": 0}, sq.LtOrEq{"unfreeze_at": now}},
+		}).
+		OrderBy("board_id").
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getDueBoardFreezeSchedules error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardFreezeSchedulesFromRows(rows)
+}
+
+func (s *SQLStore) boardFreezeSchedulesFromRows(rows *sql.Rows) ([]*model.BoardFreezeSchedule, error) {
+	results := []*model.BoardFreezeSchedule{}
+	for rows.Next() {
+		var schedule model.BoardFreezeSchedule
+		err := rows.Scan(
+			&schedule.BoardID,
+			&schedule.FreezeAt,
+			&schedule.UnfreezeAt,
+			&schedule.ModifiedBy,
+			&schedule.UpdateAt,
+		)
+		if err != nil {
+			s.logger.Error("boardFreezeSchedulesFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		results = append(results, &schedule)
+	}
+	return results, nil
+}
@@ -0,0 +1,211 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var boardInvitationFields = []string{
+	"id",
+	"board_id",
+	"user_id",
+	"invited_by",
+	"scheme_admin",
+	"scheme_editor",
+	"scheme_commenter",
+	"scheme_viewer",
+	"create_at",
+}
+
+func (s *SQLStore) boardInvitationsFromRows(rows *sql.Rows) ([]*model.BoardInvitation, error) {
+	invitations := []*model.BoardInvitation{}
+
+	for rows.Next() {
+		var invitation model.BoardInvitation
+		err := rows.Scan(
+			&invitation.ID,
+			&invitation.BoardID,
+			&invitation.UserID,
+			&invitation.InvitedBy,
+			&invitation.SchemeAdmin,
+			&invitation.SchemeEditor,
+			&invitation.SchemeCommenter,
+			&invitation.SchemeViewer,
+			&invitation.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, &invitation)
+	}
+	return invitations, nil
+}
+
+func (s *SQLStore) addToBoardMembersHistory(db sq.BaseRunner, boardID, userID, action string) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_members_history").
+		Columns("board_id", "user_id", "action").
+		Values(boardID, userID, action)
+
+	_, err := query.ExecContext(s.context())
+	return err
+}
+
+// createBoardInvitation creates a pending invitation, or updates the
+// roles of the pending invitation of the user if there is one already.
+func (s *SQLStore) createBoardInvitation(db sq.BaseRunner, invitation *model.BoardInvitation) (*model.BoardInvitation, error) {
+	if err := invitation.IsValid(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.getBoardInvitation(db, invitation.BoardID, invitation.UserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if existing != nil {
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"board_invitations").
+			Set("invited_by", invitation.InvitedBy).
+			Set("scheme_admin", invitation.SchemeAdmin).
+			Set("scheme_editor", invitation.SchemeEditor).
+			Set("scheme_commenter", invitation.SchemeCommenter).
+			Set("scheme_viewer", invitation.SchemeViewer).
+			Where(sq.Eq{"id": existing.ID})
+
+		if _, err := query.ExecContext(s.context()); err != nil {
+			return nil, err
+		}
+
+		invitation.ID = existing.ID
+		invitation.CreateAt = existing.CreateAt
+		return invitation, nil
+	}
+
+	invitation.ID = utils.NewID(utils.IDTypeNone)
+	invitation.CreateAt = model.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_invitations").
+		Columns(boardInvitationFields...).
+		Values(
+			invitation.ID,
+			invitation.BoardID,
+			invitation.UserID,
+			invitation.InvitedBy,
+			invitation.SchemeAdmin,
+			invitation.SchemeEditor,
+			invitation.SchemeCommenter,
+			invitation.SchemeViewer,
+			invitation.CreateAt,
+		)
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("Cannot insert board invitation",
+			mlog.String("board_id", invitation.BoardID),
+			mlog.String("user_id", invitation.UserID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	if err := s.addToBoardMembersHistory(db, invitation.BoardID, invitation.UserID, model.BoardMemberActionInvited); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (s *SQLStore) getBoardInvitation(db sq.BaseRunner, boardID, userID string) (*model.BoardInvitation, error) {
+	invitations, err := s.getBoardInvitationsByCondition(db, sq.Eq{"board_id": boardID, "user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invitations) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return invitations[0], nil
+}
+
+func (s *SQLStore) getInvitationsForBoard(db sq.BaseRunner, boardID string) ([]*model.BoardInvitation, error) {
+	return s.getBoardInvitationsByCondition(db, sq.Eq{"board_id": boardID})
+}
+
+func (s *SQLStore) getInvitationsForUser(db sq.BaseRunner, userID string) ([]*model.BoardInvitation, error) {
+	return s.getBoardInvitationsByCondition(db, sq.Eq{"user_id": userID})
+}
+
+func (s *SQLStore) getBoardInvitationsByCondition(db sq.BaseRunner, condition sq.Eq) ([]*model.BoardInvitation, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardInvitationFields...).
+		From(s.tablePrefix + "board_invitations").
+		Where(condition).
+		OrderBy("create_at")
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error(`getBoardInvitationsByCondition ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardInvitationsFromRows(rows)
+}
+
+// acceptBoardInvitation removes the pending invitation of the user and
+// creates the membership it grants.
+func (s *SQLStore) acceptBoardInvitation(db sq.BaseRunner, boardID, userID string) (*model.BoardMember, error) {
+	invitation, err := s.getBoardInvitation(db, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.removeBoardInvitation(db, boardID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.saveMemberWithHistory(db, invitation.Member(), model.BoardMemberActionAccepted, "")
+}
+
+// deleteBoardInvitation removes the pending invitation of the user,
+// recording the action that closed it in the board members history.
+func (s *SQLStore) deleteBoardInvitation(db sq.BaseRunner, boardID, userID, action string) error {
+	if err := s.removeBoardInvitation(db, boardID, userID); err != nil {
+		return err
+	}
+
+	return s.addToBoardMembersHistory(db, boardID, userID, action)
+}
+
+// removeBoardInvitation removes the pending invitation of the user. It
+// returns sql.ErrNoRows if the user has no pending invitation.
+func (s *SQLStore) removeBoardInvitation(db sq.BaseRunner, boardID, userID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_invitations").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"user_id": userID})
+
+	result, err := query.ExecContext(s.context())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// saveBoardMemberActivity records the activity of the members, keeping the
+// latest view and edit times of each one. A zero time doesn't replace the
+// stored one.
+func (s *SQLStore) saveBoardMemberActivity(db sq.BaseRunner, activities []*model.BoardMemberActivity) error {
+	table := s.tablePrefix + "board_member_activity"
+	for _, activity := range activities {
+		query := s.getQueryBuilder(db).
+			Insert(table).
+			Columns("board_id", "user_id", "last_view_at", "last_edit_at").
+			Values(activity.BoardID, activity.UserID, activity.LastViewAt, activity.LastEditAt)
+
+		if s.dbType == model.MysqlDBType {
+			query = query.Suffix(
+				`ON DUPLICATE KEY UPDATE last_view_at = GREATEST(last_view_at, VALUES(last_view_at)),
+				   last_edit_at = GREATEST(last_edit_at, VALUES(last_edit_at))`,
+			)
+		} else {
+			query = query.Suffix(
+				`ON CONFLICT (board_id, user_id)
+				 DO UPDATE SET
+				   last_view_at = CASE WHEN EXCLUDED.last_view_at > ` + table + `.last_view_at THEN EXCLUDED.last_view_at ELSE ` + table + `.last_view_at END,
+				   last_edit_at = CASE WHEN EXCLUDED.last_edit_at > ` + table + `.last_edit_at THEN EXCLUDED.last_edit_at ELSE ` + table + `.last_edit_at END`,
+			)
+		}
+
+		if _, err := query.ExecContext(s.context()); err != nil {
+			s.logger.Error("saveBoardMemberActivity ERROR",
+				mlog.String("boardID", activity.BoardID),
+				mlog.String("userID", activity.UserID),
+				mlog.Err(err),
+			)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) getBoardMemberActivity(db sq.BaseRunner, boardID string) ([]*model.BoardMemberActivity, error) {
+	query := s.getQueryBuilder(db).
+		Select("board_id", "user_id", "last_view_at", "last_edit_at").
+		From(s.tablePrefix + "board_member_activity").
+		Where(sq.Eq{"board_id": boardID})
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error(`getBoardMemberActivity ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	activities := []*model.BoardMemberActivity{}
+	for rows.Next() {
+		var activity model.BoardMemberActivity
+		if err := rows.Scan(&activity.BoardID, &activity.UserID, &activity.LastViewAt, &activity.LastEditAt); err != nil {
+			return nil, err
+		}
+		activities = append(activities, &activity)
+	}
+	return activities, nil
+}
@@ -0,0 +1,80 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// saveBoardSnapshot stores the snapshot of a board, replacing the one of
+// the same day if the board was already snapshotted.
+func (s *SQLStore) saveBoardSnapshot(db sq.BaseRunner, snapshot *model.BoardSnapshot) error {
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_snapshots").
+		Where(sq.Eq{"board_id": snapshot.BoardID}).
+		Where(sq.Eq{"snapshot_day": snapshot.Day})
+	if _, err := deleteQuery.ExecContext(s.context()); err != nil {
+		s.logger.Error("saveBoardSnapshot delete error", mlog.String("boardID", snapshot.BoardID), mlog.Err(err))
+		return err
+	}
+
+	if len(snapshot.Counts) == 0 {
+		return nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_snapshots").
+		Columns("board_id", "snapshot_day", "value", "property_id", "card_count", "create_at")
+	for value, count := range snapshot.Counts {
+		query = query.Values(snapshot.BoardID, snapshot.Day, value, snapshot.PropertyID, count, snapshot.CreateAt)
+	}
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("saveBoardSnapshot insert error", mlog.String("boardID", snapshot.BoardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getBoardSnapshots returns the snapshots of a board from one day to
+// another, both included, in the order of the days.
+func (s *SQLStore) getBoardSnapshots(db sq.BaseRunner, boardID, fromDay, toDay string) ([]*model.BoardSnapshot, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select("snapshot_day", "value", "property_id", "card_count", "create_at").
+		From(s.tablePrefix+"board_snapshots").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.GtOrEq{"snapshot_day": fromDay}).
+		Where(sq.LtOrEq{"snapshot_day": toDay}).
+		OrderBy("snapshot_day", "value").
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getBoardSnapshots error", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	snapshots := []*model.BoardSnapshot{}
+	var current *model.BoardSnapshot
+	for rows.Next() {
+		var day, value, propertyID string
+		var count, createAt int64
+		if err := rows.Scan(&day, &value, &propertyID, &count, &createAt); err != nil {
+			s.logger.Error("getBoardSnapshots scan error", mlog.Err(err))
+			return nil, err
+		}
+
+		if current == nil || current.Day != day {
+			current = &model.BoardSnapshot{
+				BoardID:    boardID,
+				Day:        day,
+				PropertyID: propertyID,
+				Counts:     map[string]int64{},
+				CreateAt:   createAt,
+			}
+			snapshots = append(snapshots, current)
+		}
+		current.Counts[value] = count
+	}
+	return snapshots, rows.Err()
+}
@@ -0,0 +1,189 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// addBoardToTeam shares a board with a team other than its own. Sharing
+// a board twice with the same team is not an error.
+func (s *SQLStore) addBoardToTeam(db sq.BaseRunner, boardID, teamID, userID string) error {
+	var count int
+	err := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "board_teams").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"team_id": teamID}).
+		QueryRowContext(s.context()).
+		Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_teams").
+		Columns("board_id", "team_id", "created_by", "create_at").
+		Values(boardID, teamID, userID, utils.GetMillis())
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("addBoardToTeam ERROR", mlog.String("boardID", boardID), mlog.String("teamID", teamID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) removeBoardFromTeam(db sq.BaseRunner, boardID, teamID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_teams").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"team_id": teamID})
+
+	result, err := query.ExecContext(s.context())
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return store.NewErrNotFound("board team " + boardID + "/" + teamID)
+	}
+	return nil
+}
+
+// getSharedTeamIDsForBoard returns the IDs of the teams a board is
+// shared with, besides its own team.
+func (s *SQLStore) getSharedTeamIDsForBoard(db sq.BaseRunner, boardID string) ([]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("team_id").
+		From(s.tablePrefix + "board_teams").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("team_id")
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getSharedTeamIDsForBoard ERROR", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	teamIDs := []string{}
+	for rows.Next() {
+		var teamID string
+		if err := rows.Scan(&teamID); err != nil {
+			return nil, err
+		}
+		teamIDs = append(teamIDs, teamID)
+	}
+	return teamIDs, rows.Err()
+}
+
+// getBoardIDsForTeam returns the IDs of the boards of a team, without
+// the templates.
+func (s *SQLStore) getBoardIDsForTeam(db sq.BaseRunner, teamID string) ([]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix+"boards").
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"is_template": false}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getBoardIDsForTeam ERROR", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	boardIDs := []string{}
+	for rows.Next() {
+		var boardID string
+		if err := rows.Scan(&boardID); err != nil {
+			return nil, err
+		}
+		boardIDs = append(boardIDs, boardID)
+	}
+	return boardIDs, rows.Err()
+}
+
+// getNonTemplateBoardIDs returns the IDs of the boards of all the teams
+// that are not templates.
+func (s *SQLStore) getNonTemplateBoardIDs(db sq.BaseRunner) ([]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix+"boards").
+		Where(sq.Eq{"is_template": false}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getNonTemplateBoardIDs ERROR", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	boardIDs := []string{}
+	for rows.Next() {
+		var boardID string
+		if err := rows.Scan(&boardID); err != nil {
+			return nil, err
+		}
+		boardIDs = append(boardIDs, boardID)
+	}
+	return boardIDs, rows.Err()
+}
+
+// moveBoardToTeam changes the team of a board, its history and its
+// mentions. The board is removed from the categories of its members, as
+// they belong to the old team, so it shows up as uncategorized in the
+// new one.
+func (s *SQLStore) moveBoardToTeam(db sq.BaseRunner, boardID, teamID string) error {
+	for _, table := range []string{"boards", "boards_history"} {
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+table).
+			Set("team_id", teamID).
+			Where(sq.Eq{"id": boardID})
+		if _, err := query.ExecContext(s.context()); err != nil {
+			s.logger.Error("moveBoardToTeam ERROR", mlog.String("table", table), mlog.String("boardID", boardID), mlog.Err(err))
+			return err
+		}
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"mentions").
+		Set("team_id", teamID).
+		Where(sq.Eq{"board_id": boardID})
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("moveBoardToTeam ERROR", mlog.String("table", "mentions"), mlog.String("boardID", boardID), mlog.Err(err))
+		return err
+	}
+
+	query = s.getQueryBuilder(db).
+		Update(s.tablePrefix+"category_boards").
+		Set("delete_at", utils.GetMillis()).
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"delete_at": 0})
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("moveBoardToTeam ERROR", mlog.String("table", "category_boards"), mlog.String("boardID", boardID), mlog.Err(err))
+		return err
+	}
+
+	// the board doesn't need to be shared with its own team
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_teams").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"team_id": teamID})
+	if _, err := deleteQuery.ExecContext(s.context()); err != nil {
+		s.logger.Error("moveBoardToTeam ERROR", mlog.String("table", "board_teams"), mlog.String("boardID", boardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
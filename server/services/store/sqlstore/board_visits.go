@@ -0,0 +1,57 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (s *SQLStore) saveBoardVisits(db sq.BaseRunner, visits []*model.BoardVisit) error {
+	for _, visit := range visits {
+		query := s.getQueryBuilder(db).
+			Insert(s.tablePrefix+"board_visits").
+			Columns("user_id", "board_id", "visited_at").
+			Values(visit.UserID, visit.BoardID, visit.VisitedAt)
+
+		if s.dbType == model.MysqlDBType {
+			query = query.Suffix("ON DUPLICATE KEY UPDATE visited_at = ?", visit.VisitedAt)
+		} else {
+			query = query.Suffix("ON CONFLICT (user_id, board_id) DO UPDATE SET visited_at = EXCLUDED.visited_at")
+		}
+
+		if _, err := query.ExecContext(s.context()); err != nil {
+			s.logger.Error("saveBoardVisits ERROR",
+				mlog.String("userID", visit.UserID),
+				mlog.String("boardID", visit.BoardID),
+				mlog.Err(err),
+			)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) getRecentBoardsForUser(db sq.BaseRunner, userID, teamID string, limit uint64) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("b.")...).
+		From(s.tablePrefix + "boards as b").
+		Join(s.tablePrefix + "board_visits as bv on b.id=bv.board_id").
+		Where(sq.Eq{"bv.user_id": userID}).
+		Where(sq.Eq{"b.is_template": false}).
+		OrderBy("bv.visited_at DESC").
+		Limit(limit)
+
+	if teamID != "" {
+		query = query.Where(sq.Eq{"b.team_id": teamID})
+	}
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error(`getRecentBoardsForUser ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
@@ -0,0 +1,206 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var boardWebhookFields = []string{
+	"id",
+	"board_id",
+	"url",
+	"events",
+	"COALESCE(secret, '')",
+	"enabled",
+	"created_by",
+	"create_at",
+	"update_at",
+	"COALESCE(property_ids, '')",
+	"timeout_ms",
+	"fail_open",
+}
+
+func (s *SQLStore) boardWebhooksFromRows(rows *sql.Rows) ([]*model.BoardWebhook, error) {
+	webhooks := []*model.BoardWebhook{}
+
+	for rows.Next() {
+		var webhook model.BoardWebhook
+		var eventsJSON []byte
+		var propertyIDsJSON string
+		err := rows.Scan(
+			&webhook.ID,
+			&webhook.BoardID,
+			&webhook.URL,
+			&eventsJSON,
+			&webhook.Secret,
+			&webhook.Enabled,
+			&webhook.CreatedBy,
+			&webhook.CreateAt,
+			&webhook.UpdateAt,
+			&propertyIDsJSON,
+			&webhook.TimeoutMs,
+			&webhook.FailOpen,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if webhook.Secret, err = s.decryptSecret(webhook.Secret); err != nil {
+			s.logger.Error("board webhook secret decryption", mlog.String("id", webhook.ID), mlog.Err(err))
+			return nil, err
+		}
+		if err := json.Unmarshal(eventsJSON, &webhook.Events); err != nil {
+			s.logger.Error("board webhook events json.Unmarshal", mlog.String("id", webhook.ID), mlog.Err(err))
+			return nil, err
+		}
+		if propertyIDsJSON != "" {
+			if err := json.Unmarshal([]byte(propertyIDsJSON), &webhook.PropertyIDs); err != nil {
+				s.logger.Error("board webhook property IDs json.Unmarshal", mlog.String("id", webhook.ID), mlog.Err(err))
+				return nil, err
+			}
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+	return webhooks, nil
+}
+
+func (s *SQLStore) insertBoardWebhook(db sq.BaseRunner, webhook *model.BoardWebhook) error {
+	eventsJSON, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+	propertyIDsJSON, err := json.Marshal(webhook.PropertyIDs)
+	if err != nil {
+		return err
+	}
+	secret, err := s.encryptSecret(webhook.Secret)
+	if err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"webhooks").
+		Columns("id", "board_id", "url", "events", "secret", "enabled", "created_by", "create_at", "update_at",
+			"property_ids", "timeout_ms", "fail_open").
+		Values(
+			webhook.ID,
+			webhook.BoardID,
+			webhook.URL,
+			string(eventsJSON),
+			secret,
+			webhook.Enabled,
+			webhook.CreatedBy,
+			webhook.CreateAt,
+			webhook.UpdateAt,
+			string(propertyIDsJSON),
+			webhook.TimeoutMs,
+			webhook.FailOpen,
+		)
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("Cannot insert board webhook", mlog.String("board_id", webhook.BoardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) updateBoardWebhook(db sq.BaseRunner, webhook *model.BoardWebhook) error {
+	eventsJSON, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+	propertyIDsJSON, err := json.Marshal(webhook.PropertyIDs)
+	if err != nil {
+		return err
+	}
+	secret, err := s.encryptSecret(webhook.Secret)
+	if err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"webhooks").
+		Set("url", webhook.URL).
+		Set("events", string(eventsJSON)).
+		Set("secret", secret).
+		Set("enabled", webhook.Enabled).
+		Set("property_ids", string(propertyIDsJSON)).
+		Set("timeout_ms", webhook.TimeoutMs).
+		Set("fail_open", webhook.FailOpen).
+		Set("update_at", webhook.UpdateAt).
+		Where(sq.Eq{"id": webhook.ID})
+
+	result, err := query.ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("Cannot update board webhook", mlog.String("id", webhook.ID), mlog.Err(err))
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return store.NewErrNotFound(webhook.ID)
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteBoardWebhook(db sq.BaseRunner, id string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "webhooks").
+		Where(sq.Eq{"id": id})
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("Cannot delete board webhook", mlog.String("id", id), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) getBoardWebhook(db sq.BaseRunner, id string) (*model.BoardWebhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardWebhookFields...).
+		From(s.tablePrefix + "webhooks").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("Cannot fetch board webhook", mlog.String("id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	webhooks, err := s.boardWebhooksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) == 0 {
+		return nil, store.NewErrNotFound(id)
+	}
+	return webhooks[0], nil
+}
+
+// getBoardWebhooksForBoard returns the webhooks of the board, oldest
+// first.
+func (s *SQLStore) getBoardWebhooksForBoard(db sq.BaseRunner, boardID string) ([]*model.BoardWebhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardWebhookFields...).
+		From(s.tablePrefix+"webhooks").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("Cannot fetch board webhooks", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardWebhooksFromRows(rows)
+}
@@ -131,7 +131,11 @@ func (s *SQLStore) deleteBoardsAndBlocks(db sq.BaseRunner, dbab *model.DeleteBoa
 	return nil
 }
 
-func (s *SQLStore) duplicateBoard(db sq.BaseRunner, boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+// duplicateBoard copies a board and its blocks into a new private board
+// administered by the user. The user mapping replaces the users of the
+// board: the mapped members get the same roles on the new board, and
+// the mapped creators and person properties of the blocks are replaced.
+func (s *SQLStore) duplicateBoard(db sq.BaseRunner, boardID string, userID string, toTeam string, asTemplate bool, userMapping model.UserMapping) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
 	bab := &model.BoardsAndBlocks{
 		Boards: []*model.Board{},
 		Blocks: []model.Block{},
@@ -155,12 +159,77 @@ func (s *SQLStore) duplicateBoard(db sq.BaseRunner, boardID string, userID strin
 	if err != nil {
 		return nil, nil, err
 	}
+	userMapping.MapPersonProperties(board, blocks)
 	bab.Blocks = blocks
 
+	var sourceMembers []*model.BoardMember
+	if len(userMapping) > 0 {
+		sourceMembers, err = s.getMembersForBoard(db, boardID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	bab, err = model.GenerateBoardsAndBlocksIDs(bab, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.createBoardsAndBlocksWithAdmin(db, bab, userID)
+	newBab, members, err := s.createBoardsAndBlocksWithAdmin(db, bab, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(userMapping) == 0 {
+		return newBab, members, nil
+	}
+
+	for i := range newBab.Blocks {
+		block := &newBab.Blocks[i]
+		creatorID, ok := userMapping.MapUser(block.CreatedBy)
+		if !ok || creatorID == "" {
+			creatorID = userID
+		}
+		block.CreatedBy = creatorID
+		if creatorID == userID {
+			continue
+		}
+		if err := s.setBlockCreatedBy(db, block.ID, creatorID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	newBoardID := newBab.Boards[0].ID
+	for _, sourceMember := range sourceMembers {
+		memberID, ok := userMapping.MapUser(sourceMember.UserID)
+		if !ok || memberID == "" || memberID == userID {
+			continue
+		}
+
+		member, err := s.saveMember(db, &model.BoardMember{
+			BoardID:         newBoardID,
+			UserID:          memberID,
+			SchemeAdmin:     sourceMember.SchemeAdmin,
+			SchemeEditor:    sourceMember.SchemeEditor,
+			SchemeCommenter: sourceMember.SchemeCommenter,
+			SchemeViewer:    sourceMember.SchemeViewer,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		members = append(members, member)
+	}
+
+	return newBab, members, nil
+}
+
+// setBlockCreatedBy replaces the creator of a block that was just
+// inserted.
+func (s *SQLStore) setBlockCreatedBy(db sq.BaseRunner, blockID, userID string) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"blocks").
+		Set("created_by", userID).
+		Where(sq.Eq{"id": blockID})
+
+	_, err := query.ExecContext(s.context())
+	return err
 }
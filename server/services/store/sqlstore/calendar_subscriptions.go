@@ -0,0 +1,129 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var calendarSubscriptionFields = []string{
+	"board_id",
+	"url",
+	"date_property",
+	"modified_by",
+	"update_at",
+	"last_sync_at",
+	"COALESCE(last_error, '')",
+}
+
+// saveCalendarSubscription stores the calendar subscription of a board,
+// replacing its previous one.
+func (s *SQLStore) saveCalendarSubscription(db sq.BaseRunner, subscription *model.CalendarSubscription) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"calendar_subscriptions").
+		Columns("board_id", "url", "date_property", "modified_by", "update_at", "last_sync_at", "last_error").
+		Values(
+			subscription.BoardID,
+			subscription.URL,
+			subscription.DateProperty,
+			subscription.ModifiedBy,
+			subscription.UpdateAt,
+			subscription.LastSyncAt,
+			subscription.LastError,
+		)
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE url = ?, date_property = ?, modified_by = ?, update_at = ?, last_sync_at = ?, last_error = ?",
+			subscription.URL, subscription.DateProperty, subscription.ModifiedBy, subscription.UpdateAt, subscription.LastSyncAt, subscription.LastError)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (board_id)
+			 DO UPDATE SET url = EXCLUDED.url, date_property = EXCLUDED.date_property,
+			 modified_by = EXCLUDED.modified_by, update_at = EXCLUDED.update_at,
+			 last_sync_at = EXCLUDED.last_sync_at, last_error = EXCLUDED.last_error`,
+		)
+	}
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("saveCalendarSubscription error", mlog.String("boardID", subscription.BoardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) getCalendarSubscription(db sq.BaseRunner, boardID string) (*model.CalendarSubscription, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(calendarSubscriptionFields...).
+		From(s.tablePrefix + "calendar_subscriptions").
+		Where(sq.Eq{"board_id": boardID}).
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getCalendarSubscription error", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	subscriptions, err := s.calendarSubscriptionsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(subscriptions) == 0 {
+		return nil, store.NewErrNotFound("calendar subscription " + boardID)
+	}
+	return subscriptions[0], nil
+}
+
+func (s *SQLStore) deleteCalendarSubscription(db sq.BaseRunner, boardID string) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "calendar_subscriptions").
+		Where(sq.Eq{"board_id": boardID}).
+		ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("deleteCalendarSubscription error", mlog.String("boardID", boardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getDueCalendarSubscriptions returns the subscriptions last imported at
+// or before syncedBefore, the least recently imported first.
+func (s *SQLStore) getDueCalendarSubscriptions(db sq.BaseRunner, syncedBefore int64) ([]*model.CalendarSubscription, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(calendarSubscriptionFields...).
+		From(s.tablePrefix+"calendar_subscriptions").
+		Where(sq.LtOrEq{"last_sync_at": syncedBefore}).
+		OrderBy("last_sync_at", "board_id").
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getDueCalendarSubscriptions error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.calendarSubscriptionsFromRows(rows)
+}
+
+func (s *SQLStore) calendarSubscriptionsFromRows(rows *sql.Rows) ([]*model.CalendarSubscription, error) {
+	results := []*model.CalendarSubscription{}
+	for rows.Next() {
+		var subscription model.CalendarSubscription
+		err := rows.Scan(
+			&subscription.BoardID,
+			&subscription.URL,
+			&subscription.DateProperty,
+			&subscription.ModifiedBy,
+			&subscription.UpdateAt,
+			&subscription.LastSyncAt,
+			&subscription.LastError,
+		)
+		if err != nil {
+			s.logger.Error("calendarSubscriptionsFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		results = append(results, &subscription)
+	}
+	return results, nil
+}
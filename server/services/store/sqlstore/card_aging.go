@@ -0,0 +1,51 @@
+package sqlstore
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// getCardsStatusSince returns, by card ID, the time the cards of the board
+// took their current value of the property, read from the history of the
+// cards. Only the given cards are returned if cardIDs is not empty.
+func (s *SQLStore) getCardsStatusSince(db sq.BaseRunner, boardID, propertyID string, cardIDs []string) (map[string]int64, error) {
+	if !model.IsValidPropertyIndexID(propertyID) {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "invalid property ID", map[string]interface{}{"propertyId": propertyID})
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("id", "update_at", fmt.Sprintf("COALESCE(%s, '')", s.propertyValueExpression(propertyID))).
+		From(s.tablePrefix+"blocks_history").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		OrderBy("id", "insert_at", "update_at")
+	if len(cardIDs) > 0 {
+		query = query.Where(sq.Eq{"id": cardIDs})
+	}
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getCardsStatusSince ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	since := map[string]int64{}
+	values := map[string]string{}
+	for rows.Next() {
+		var cardID, value string
+		var updateAt int64
+		if err := rows.Scan(&cardID, &updateAt, &value); err != nil {
+			return nil, err
+		}
+		if previous, ok := values[cardID]; !ok || previous != value {
+			since[cardID] = updateAt
+			values[cardID] = value
+		}
+	}
+	return since, nil
+}
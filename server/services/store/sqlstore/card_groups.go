@@ -0,0 +1,139 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// numericPropertyValueExpression returns the SQL expression that extracts
+// the value of a card property as a number, or NULL if the value is not
+// numeric. The patterns avoid ?, which the query builder would take for a
+// placeholder.
+func (s *SQLStore) numericPropertyValueExpression(propertyID string) string {
+	value := s.propertyValueExpression(propertyID)
+	switch {
+	case s.isPostgresCompatible():
+		return fmt.Sprintf("CASE WHEN %[1]s ~ '^-{0,1}[0-9]+([.][0-9]+){0,1}$' THEN CAST(%[1]s AS DOUBLE PRECISION) END", value)
+	case s.dbType == model.MysqlDBType:
+		return fmt.Sprintf("CASE WHEN %[1]s REGEXP '^-{0,1}[0-9]+([.][0-9]+){0,1}$' THEN CAST(%[1]s AS DECIMAL(65, 10)) END", value)
+	default:
+		return fmt.Sprintf("CASE WHEN %[1]s GLOB '*[0-9]*' AND %[1]s NOT GLOB '*[^0-9.-]*' THEN CAST(%[1]s AS REAL) END", value)
+	}
+}
+
+// notCardTemplateCondition returns the SQL condition that excludes the
+// card templates, which are cards flagged in their fields.
+func (s *SQLStore) notCardTemplateCondition() string {
+	switch {
+	case s.isPostgresCompatible():
+		return "COALESCE(fields->>'isTemplate', 'false') <> 'true'"
+	case s.dbType == model.MysqlDBType:
+		return "COALESCE(IF(JSON_VALID(fields), JSON_UNQUOTE(JSON_EXTRACT(fields, '$.isTemplate')), NULL), 'false') <> 'true'"
+	default:
+		return "COALESCE(json_extract(fields, '$.isTemplate'), 0) = 0"
+	}
+}
+
+// getCardGroups counts the cards of the board by the value of the
+// grouping property, and sums the numeric property over each group if
+// sumPropertyID is not empty. The card templates are not counted.
+func (s *SQLStore) getCardGroups(db sq.BaseRunner, boardID, groupByPropertyID, sumPropertyID string) ([]*model.CardGroup, error) {
+	for _, propertyID := range []string{groupByPropertyID, sumPropertyID} {
+		if propertyID != "" && !model.IsValidPropertyIndexID(propertyID) {
+			return nil, model.NewCodedError(model.ErrCodeBadRequest, "invalid property ID", map[string]interface{}{"propertyId": propertyID})
+		}
+	}
+
+	value := fmt.Sprintf("COALESCE(%s, '')", s.propertyValueExpression(groupByPropertyID))
+	columns := []string{value, "COUNT(*)"}
+	if sumPropertyID != "" {
+		columns = append(columns, fmt.Sprintf("SUM(%s)", s.numericPropertyValueExpression(sumPropertyID)))
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(columns...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Eq{"delete_at": 0}).
+		Where(s.notCardTemplateCondition()).
+		GroupBy(value)
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getCardGroups ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	groups := []*model.CardGroup{}
+	for rows.Next() {
+		var group model.CardGroup
+		if sumPropertyID == "" {
+			err = rows.Scan(&group.Value, &group.Count)
+		} else {
+			var sum sql.NullFloat64
+			err = rows.Scan(&group.Value, &group.Count, &sum)
+			group.Sum = &sum.Float64
+		}
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, &group)
+	}
+	return groups, nil
+}
+
+// getSwimlaneCells returns the cards of the board grouped by the values of
+// two properties, with the IDs of the cards of each pair of values in the
+// order they were created. The card templates are not included.
+func (s *SQLStore) getSwimlaneCells(db sq.BaseRunner, boardID, rowPropertyID, columnPropertyID string) ([]*model.SwimlaneCell, error) {
+	for _, propertyID := range []string{rowPropertyID, columnPropertyID} {
+		if !model.IsValidPropertyIndexID(propertyID) {
+			return nil, model.NewCodedError(model.ErrCodeBadRequest, "invalid property ID", map[string]interface{}{"propertyId": propertyID})
+		}
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(
+			fmt.Sprintf("COALESCE(%s, '')", s.propertyValueExpression(rowPropertyID)),
+			fmt.Sprintf("COALESCE(%s, '')", s.propertyValueExpression(columnPropertyID)),
+			"id",
+		).
+		From(s.tablePrefix+"blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Eq{"delete_at": 0}).
+		Where(s.notCardTemplateCondition()).
+		OrderBy("create_at", "id")
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getSwimlaneCells ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	cells := []*model.SwimlaneCell{}
+	byValues := map[[2]string]*model.SwimlaneCell{}
+	for rows.Next() {
+		var row, column, cardID string
+		if err := rows.Scan(&row, &column, &cardID); err != nil {
+			return nil, err
+		}
+		cell, ok := byValues[[2]string{row, column}]
+		if !ok {
+			cell = &model.SwimlaneCell{Row: row, Column: column, CardIDs: []string{}}
+			byValues[[2]string{row, column}] = cell
+			cells = append(cells, cell)
+		}
+		cell.Count++
+		cell.CardIDs = append(cell.CardIDs, cardID)
+	}
+	return cells, nil
+}
@@ -0,0 +1,204 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// setBoardKey sets the key the cards of a board are referenced with, as
+// in KEY-123, and numbers the cards of the board that don't have a number
+// yet, in the order they were created.
+func (s *SQLStore) setBoardKey(db sq.BaseRunner, boardID, key string) error {
+	var ownerID string
+	err := s.getQueryBuilder(db).
+		Select("board_id").
+		From(s.tablePrefix + "board_keys").
+		Where(sq.Eq{"board_key": key}).
+		QueryRowContext(s.context()).
+		Scan(&ownerID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if ownerID == boardID {
+		return nil
+	}
+	if ownerID != "" {
+		return model.NewErrBoardKeyTaken(key)
+	}
+
+	var nextNumber int64
+	err = s.getQueryBuilder(db).
+		Select("next_number").
+		From(s.tablePrefix + "board_keys").
+		Where(sq.Eq{"board_id": boardID}).
+		QueryRowContext(s.context()).
+		Scan(&nextNumber)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		query := s.getQueryBuilder(db).
+			Insert(s.tablePrefix+"board_keys").
+			Columns("board_id", "board_key", "next_number").
+			Values(boardID, key, 1)
+		if _, err := query.ExecContext(s.context()); err != nil {
+			s.logger.Error("setBoardKey ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		// the cards keep their numbers when the key changes
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"board_keys").
+			Set("board_key", key).
+			Where(sq.Eq{"board_id": boardID})
+		if _, err := query.ExecContext(s.context()); err != nil {
+			s.logger.Error("setBoardKey ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+			return err
+		}
+	}
+
+	rows, err := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix+"blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Expr("id NOT IN (SELECT card_id FROM "+s.tablePrefix+"card_numbers)")).
+		OrderBy("create_at", "id").
+		QueryContext(s.context())
+	if err != nil {
+		return err
+	}
+
+	cardIDs := []string{}
+	for rows.Next() {
+		var cardID string
+		if err := rows.Scan(&cardID); err != nil {
+			s.CloseRows(rows)
+			return err
+		}
+		cardIDs = append(cardIDs, cardID)
+	}
+	s.CloseRows(rows)
+
+	for _, cardID := range cardIDs {
+		if _, err := s.assignCardNumber(db, boardID, cardID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignCardNumber gives the card the next number of its board. Cards of
+// boards without a key don't get numbers, and are numbered if the board
+// gets a key later.
+func (s *SQLStore) assignCardNumber(db sq.BaseRunner, boardID, cardID string) (int64, error) {
+	number, err := s.takeNextCardNumber(db, boardID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	insert := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_numbers").
+		Columns("board_id", "card_number", "card_id").
+		Values(boardID, number, cardID)
+	if _, err := insert.ExecContext(s.context()); err != nil {
+		s.logger.Error("assignCardNumber ERROR", mlog.String("cardID", cardID), mlog.Err(err))
+		return 0, err
+	}
+	return number, nil
+}
+
+// takeNextCardNumber increments the next card number of the board and
+// returns the number it had, so concurrent inserts never get the same
+// number. It returns sql.ErrNoRows if the board has no key.
+func (s *SQLStore) takeNextCardNumber(db sq.BaseRunner, boardID string) (int64, error) {
+	increment := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_keys").
+		Set("next_number", sq.Expr("next_number + 1")).
+		Where(sq.Eq{"board_id": boardID})
+
+	var number int64
+	if s.dbType == model.PostgresDBType {
+		err := increment.
+			Suffix("RETURNING next_number - 1").
+			QueryRowContext(s.context()).
+			Scan(&number)
+		return number, err
+	}
+
+	// MySQL locks the row until the transaction ends. SQLite doesn't
+	// support row locks, but its transactions don't write concurrently.
+	query := s.getQueryBuilder(db).
+		Select("next_number").
+		From(s.tablePrefix + "board_keys").
+		Where(sq.Eq{"board_id": boardID})
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("FOR UPDATE")
+	}
+	if err := query.QueryRowContext(s.context()).Scan(&number); err != nil {
+		return 0, err
+	}
+
+	if _, err := increment.ExecContext(s.context()); err != nil {
+		return 0, err
+	}
+	return number, nil
+}
+
+// getBoardKey returns the key of the board.
+func (s *SQLStore) getBoardKey(db sq.BaseRunner, boardID string) (string, error) {
+	var key string
+	err := s.getQueryBuilder(db).
+		Select("board_key").
+		From(s.tablePrefix + "board_keys").
+		Where(sq.Eq{"board_id": boardID}).
+		QueryRowContext(s.context()).
+		Scan(&key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", store.NewErrNotFound("board key for " + boardID)
+	}
+	return key, err
+}
+
+// getBoardIDForKey returns the ID of the board with the key.
+func (s *SQLStore) getBoardIDForKey(db sq.BaseRunner, key string) (string, error) {
+	var boardID string
+	err := s.getQueryBuilder(db).
+		Select("board_id").
+		From(s.tablePrefix + "board_keys").
+		Where(sq.Eq{"board_key": key}).
+		QueryRowContext(s.context()).
+		Scan(&boardID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", store.NewErrNotFound("board key " + key)
+	}
+	return boardID, err
+}
+
+// getCardIDForNumber returns the ID of the card with the number on the
+// board.
+func (s *SQLStore) getCardIDForNumber(db sq.BaseRunner, boardID string, number int64) (string, error) {
+	var cardID string
+	err := s.getQueryBuilder(db).
+		Select("card_id").
+		From(s.tablePrefix + "card_numbers").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"card_number": number}).
+		QueryRowContext(s.context()).
+		Scan(&cardID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", store.NewErrNotFound(fmt.Sprintf("card number %d of board %s", number, boardID))
+	}
+	return cardID, err
+}
@@ -13,11 +13,11 @@ import (
 
 func (s *SQLStore) getCategory(db sq.BaseRunner, id string) (*model.Category, error) {
 	query := s.getQueryBuilder(db).
-		Select("id", "name", "user_id", "team_id", "create_at", "update_at", "delete_at").
+		Select("id", "name", "user_id", "team_id", "create_at", "update_at", "delete_at", "sort_order").
 		From(s.tablePrefix + "categories").
 		Where(sq.Eq{"id": id})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("getCategory error", mlog.Err(err))
 		return nil, err
@@ -47,6 +47,7 @@ func (s *SQLStore) createCategory(db sq.BaseRunner, category model.Category) err
 			"create_at",
 			"update_at",
 			"delete_at",
+			"sort_order",
 		).
 		Values(
 			category.ID,
@@ -56,9 +57,10 @@ func (s *SQLStore) createCategory(db sq.BaseRunner, category model.Category) err
 			category.CreateAt,
 			category.UpdateAt,
 			category.DeleteAt,
+			category.SortOrder,
 		)
 
-	_, err := query.Exec()
+	_, err := query.ExecContext(s.context())
 	if err != nil {
 		s.logger.Error("Error creating category", mlog.String("category name", category.Name), mlog.Err(err))
 		return err
@@ -73,7 +75,7 @@ func (s *SQLStore) updateCategory(db sq.BaseRunner, category model.Category) err
 		Set("update_at", category.UpdateAt).
 		Where(sq.Eq{"id": category.ID})
 
-	_, err := query.Exec()
+	_, err := query.ExecContext(s.context())
 	if err != nil {
 		s.logger.Error("Error updating category", mlog.String("category_id", category.ID), mlog.String("category_name", category.Name), mlog.Err(err))
 		return err
@@ -91,7 +93,7 @@ func (s *SQLStore) deleteCategory(db sq.BaseRunner, categoryID, userID, teamID s
 			"team_id": teamID,
 		})
 
-	_, err := query.Exec()
+	_, err := query.ExecContext(s.context())
 	if err != nil {
 		s.logger.Error(
 			"Error updating category",
@@ -107,15 +109,16 @@ func (s *SQLStore) deleteCategory(db sq.BaseRunner, categoryID, userID, teamID s
 
 func (s *SQLStore) getUserCategories(db sq.BaseRunner, userID, teamID string) ([]model.Category, error) {
 	query := s.getQueryBuilder(db).
-		Select("id", "name", "user_id", "team_id", "create_at", "update_at", "delete_at").
-		From(s.tablePrefix + "categories").
+		Select("id", "name", "user_id", "team_id", "create_at", "update_at", "delete_at", "sort_order").
+		From(s.tablePrefix+"categories").
 		Where(sq.Eq{
 			"user_id":   userID,
 			"team_id":   teamID,
 			"delete_at": 0,
-		})
+		}).
+		OrderBy("sort_order", "name", "id")
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("getUserCategories error", mlog.Err(err))
 		return nil, err
@@ -124,6 +127,33 @@ func (s *SQLStore) getUserCategories(db sq.BaseRunner, userID, teamID string) ([
 	return s.categoriesFromRows(rows)
 }
 
+// reorderCategories sets the position of the categories of the user on
+// the team to their index in the new order.
+func (s *SQLStore) reorderCategories(db sq.BaseRunner, userID, teamID string, newCategoryOrder []string) error {
+	for i, categoryID := range newCategoryOrder {
+		_, err := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"categories").
+			Set("sort_order", i).
+			Where(sq.Eq{
+				"id":      categoryID,
+				"user_id": userID,
+				"team_id": teamID,
+			}).
+			ExecContext(s.context())
+		if err != nil {
+			s.logger.Error(
+				"reorderCategories error",
+				mlog.String("category_id", categoryID),
+				mlog.String("user_id", userID),
+				mlog.String("team_id", teamID),
+				mlog.Err(err),
+			)
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *SQLStore) categoriesFromRows(rows *sql.Rows) ([]model.Category, error) {
 	var categories []model.Category
 
@@ -137,6 +167,7 @@ func (s *SQLStore) categoriesFromRows(rows *sql.Rows) ([]model.Category, error)
 			&category.CreateAt,
 			&category.UpdateAt,
 			&category.DeleteAt,
+			&category.SortOrder,
 		)
 
 		if err != nil {
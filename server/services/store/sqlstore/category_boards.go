@@ -48,7 +48,7 @@ func (s *SQLStore) getCategoryBoardAttributes(db sq.BaseRunner, categoryID strin
 			"delete_at":   0,
 		})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("getCategoryBoards error fetching categoryblocks", mlog.String("categoryID", categoryID), mlog.Err(err))
 		return nil, err
@@ -92,7 +92,7 @@ func (s *SQLStore) userCategoryBoardExists(db sq.BaseRunner, userID, teamID, cat
 			"board_id":      boardID,
 		})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("getCategoryBoard error", mlog.Err(err))
 		return false, err
@@ -111,7 +111,7 @@ func (s *SQLStore) updateUserCategoryBoard(db sq.BaseRunner, userID, boardID, ca
 			"board_id": boardID,
 			"user_id":  userID,
 		}).
-		Exec()
+		ExecContext(s.context())
 
 	if err != nil {
 		s.logger.Error("updateUserCategoryBoard error", mlog.Err(err))
@@ -147,7 +147,7 @@ func (s *SQLStore) addUserCategoryBoard(db sq.BaseRunner, userID, categoryID, bo
 			utils.GetMillis(),
 			utils.GetMillis(),
 			0,
-		).Exec()
+		).ExecContext(s.context())
 
 	if err != nil {
 		s.logger.Error("addUserCategoryBoard error", mlog.Err(err))
@@ -164,7 +164,7 @@ func (s *SQLStore) deleteUserCategoryBoard(db sq.BaseRunner, userID, boardID str
 			"user_id":   userID,
 			"board_id":  boardID,
 			"delete_at": 0,
-		}).Exec()
+		}).ExecContext(s.context())
 
 	if err != nil {
 		s.logger.Error(
@@ -0,0 +1,107 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// getCategoryRules returns the rules of the user on the team, in the
+// order they were created. The rules of deleted categories are left out.
+func (s *SQLStore) getCategoryRules(db sq.BaseRunner, userID, teamID string) ([]*model.CategoryRule, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(
+			"r.id",
+			"r.user_id",
+			"r.team_id",
+			"r.category_id",
+			"r.title_pattern",
+			"r.create_at",
+		).
+		From(s.tablePrefix+"category_rules AS r").
+		Join(s.tablePrefix+"categories AS c ON c.id = r.category_id").
+		Where(sq.Eq{
+			"r.user_id":   userID,
+			"r.team_id":   teamID,
+			"c.delete_at": 0,
+		}).
+		OrderBy("r.create_at", "r.id").
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getCategoryRules error", mlog.String("userID", userID), mlog.String("teamID", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	rules := []*model.CategoryRule{}
+	for rows.Next() {
+		var rule model.CategoryRule
+		err := rows.Scan(
+			&rule.ID,
+			&rule.UserID,
+			&rule.TeamID,
+			&rule.CategoryID,
+			&rule.TitlePattern,
+			&rule.CreateAt,
+		)
+		if err != nil {
+			s.logger.Error("getCategoryRules row scan error", mlog.Err(err))
+			return nil, err
+		}
+		rules = append(rules, &rule)
+	}
+	return rules, nil
+}
+
+func (s *SQLStore) createCategoryRule(db sq.BaseRunner, rule *model.CategoryRule) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"category_rules").
+		Columns(
+			"id",
+			"user_id",
+			"team_id",
+			"category_id",
+			"title_pattern",
+			"create_at",
+		).
+		Values(
+			rule.ID,
+			rule.UserID,
+			rule.TeamID,
+			rule.CategoryID,
+			rule.TitlePattern,
+			rule.CreateAt,
+		)
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("createCategoryRule error", mlog.String("userID", rule.UserID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// deleteCategoryRule deletes the rule of the user.
+func (s *SQLStore) deleteCategoryRule(db sq.BaseRunner, ruleID, userID string) error {
+	result, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "category_rules").
+		Where(sq.Eq{
+			"id":      ruleID,
+			"user_id": userID,
+		}).
+		ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("deleteCategoryRule error", mlog.String("ruleID", ruleID), mlog.Err(err))
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return store.NewErrNotFound("category rule " + ruleID)
+	}
+	return nil
+}
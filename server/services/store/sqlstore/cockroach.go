@@ -0,0 +1,58 @@
+package sqlstore
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	// maxTransactionAttempts is how many times a transaction that failed
+	// because of a conflict with a concurrent transaction is attempted.
+	maxTransactionAttempts = 5
+
+	// serializationFailureCode is the SQLSTATE code CockroachDB returns
+	// when a transaction has to be retried by the client.
+	serializationFailureCode = "40001"
+)
+
+// DriverName returns the database/sql driver used to connect to the
+// database type. CockroachDB speaks the PostgreSQL wire protocol, so it
+// uses the postgres driver.
+func DriverName(dbType string) string {
+	if dbType == model.CockroachDBType {
+		return model.PostgresDBType
+	}
+	return dbType
+}
+
+// isPostgresCompatible returns true if the store uses the PostgreSQL
+// SQL dialect.
+func (s *SQLStore) isPostgresCompatible() bool {
+	return s.dbType == model.PostgresDBType || s.dbType == model.CockroachDBType
+}
+
+// shouldRetryTransaction returns true if the transaction failed because of
+// a serialization conflict and can be attempted again. CockroachDB runs
+// every transaction as serializable, and expects clients to retry the
+// ones aborted by conflicts.
+func (s *SQLStore) shouldRetryTransaction(err error, attempt int, methodName string) bool {
+	if s.dbType != model.CockroachDBType || attempt >= maxTransactionAttempts {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != serializationFailureCode {
+		return false
+	}
+
+	s.logger.Debug("retrying transaction after serialization failure",
+		mlog.String("methodName", methodName),
+		mlog.Int("attempt", attempt),
+	)
+	return true
+}
@@ -0,0 +1,71 @@
+package sqlstore
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func TestDriverName(t *testing.T) {
+	assert.Equal(t, "postgres", DriverName(model.CockroachDBType))
+	assert.Equal(t, "postgres", DriverName(model.PostgresDBType))
+	assert.Equal(t, "mysql", DriverName(model.MysqlDBType))
+	assert.Equal(t, "sqlite3", DriverName(model.SqliteDBType))
+}
+
+func TestShouldRetryTransaction(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	serializationErr := fmt.Errorf("insert failed: %w", &pq.Error{Code: serializationFailureCode})
+
+	t.Run("serialization failures are retried on cockroach", func(t *testing.T) {
+		s := &SQLStore{dbType: model.CockroachDBType, logger: logger}
+		assert.True(t, s.shouldRetryTransaction(serializationErr, 1, "Test"))
+	})
+
+	t.Run("retries stop after the maximum number of attempts", func(t *testing.T) {
+		s := &SQLStore{dbType: model.CockroachDBType, logger: logger}
+		assert.False(t, s.shouldRetryTransaction(serializationErr, maxTransactionAttempts, "Test"))
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		s := &SQLStore{dbType: model.CockroachDBType, logger: logger}
+		assert.False(t, s.shouldRetryTransaction(errors.New("boom"), 1, "Test"))
+		assert.False(t, s.shouldRetryTransaction(&pq.Error{Code: "23505"}, 1, "Test"))
+	})
+
+	t.Run("other databases are not retried", func(t *testing.T) {
+		s := &SQLStore{dbType: model.PostgresDBType, logger: logger}
+		assert.False(t, s.shouldRetryTransaction(serializationErr, 1, "Test"))
+	})
+}
+
+func TestCockroachMigrations(t *testing.T) {
+	s := &SQLStore{dbType: model.CockroachDBType, tablePrefix: "focalboard_", isPlugin: true}
+
+	sql, err := s.renderMigration("000018_populate_categories.up.sql")
+	assert.NoError(t, err)
+	assert.Contains(t, string(sql), "gen_random_uuid()")
+	assert.NotContains(t, string(sql), "cstring")
+
+	s.dbType = model.PostgresDBType
+	sql, err = s.renderMigration("000018_populate_categories.up.sql")
+	assert.NoError(t, err)
+	assert.Contains(t, string(sql), "cstring")
+}
+
+func TestCockroachMaintenanceStatements(t *testing.T) {
+	s := &SQLStore{dbType: model.CockroachDBType, tablePrefix: "focalboard_"}
+
+	statements := s.maintenanceStatements()
+	assert.Len(t, statements, len(statsTables))
+	for _, statement := range statements {
+		assert.Regexp(t, "^ANALYZE focalboard_", statement)
+	}
+}
@@ -0,0 +1,44 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// WithContext returns a copy of the store whose statements run with ctx,
+// so they are cancelled when ctx is, e.g. when the client of the request
+// they serve goes away. The transactions started by the copy are bound
+// to ctx as well.
+func (s *SQLStore) WithContext(ctx context.Context) store.Store {
+	scoped := *s
+	scoped.ctx = ctx
+	return &scoped
+}
+
+// context returns the context the statements of the store run with.
+func (s *SQLStore) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// execContext runs a raw statement with the context of the store.
+func (s *SQLStore) execContext(db sq.BaseRunner, query string, args ...interface{}) (sql.Result, error) {
+	if runner, ok := db.(sq.ExecerContext); ok {
+		return runner.ExecContext(s.context(), query, args...)
+	}
+	return db.Exec(query, args...)
+}
+
+// queryContext runs a raw query with the context of the store.
+func (s *SQLStore) queryContext(db sq.BaseRunner, query string, args ...interface{}) (*sql.Rows, error) {
+	if runner, ok := db.(sq.QueryerContext); ok {
+		return runner.QueryContext(s.context(), query, args...)
+	}
+	return db.Query(query, args...)
+}
@@ -0,0 +1,159 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (s *SQLStore) insertDeferredNotification(db sq.BaseRunner, notification *model.DeferredNotification) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"deferred_notifications").
+		Columns(
+			"id",
+			"user_id",
+			"event_type",
+			"notification_key",
+			"message",
+			"link",
+			"create_at",
+		).
+		Values(
+			notification.ID,
+			notification.UserID,
+			notification.EventType,
+			notification.Key,
+			notification.Message,
+			notification.Link,
+			notification.CreateAt,
+		)
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("insertDeferredNotification error", mlog.String("userID", notification.UserID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getUsersWithDeferredNotifications returns the IDs of the users that
+// have notifications waiting to be delivered.
+func (s *SQLStore) getUsersWithDeferredNotifications(db sq.BaseRunner) ([]string, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select("DISTINCT user_id").
+		From(s.tablePrefix + "deferred_notifications").
+		OrderBy("user_id").
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getUsersWithDeferredNotifications error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return idsFromRows(rows)
+}
+
+// getDeferredNotificationsForUser returns the notifications of the user
+// waiting to be delivered, oldest first.
+func (s *SQLStore) getDeferredNotificationsForUser(db sq.BaseRunner, userID string) ([]*model.DeferredNotification, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(
+			"id",
+			"user_id",
+			"event_type",
+			"notification_key",
+			"COALESCE(message, '')",
+			"COALESCE(link, '')",
+			"create_at",
+		).
+		From(s.tablePrefix+"deferred_notifications").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("create_at", "id").
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getDeferredNotificationsForUser error", mlog.String("userID", userID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	notifications := []*model.DeferredNotification{}
+	for rows.Next() {
+		var notification model.DeferredNotification
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.EventType,
+			&notification.Key,
+			&notification.Message,
+			&notification.Link,
+			&notification.CreateAt,
+		)
+		if err != nil {
+			s.logger.Error("getDeferredNotificationsForUser row scan error", mlog.Err(err))
+			return nil, err
+		}
+		notifications = append(notifications, &notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// claimDeferredNotification marks the notification as being delivered by
+// the claimer, so the other servers of the cluster don't deliver it too.
+// The claims made before staleBefore, by servers that likely stopped
+// before delivering the notification, can be taken over. It returns false
+// if the notification is claimed by another server, or was delivered.
+func (s *SQLStore) claimDeferredNotification(db sq.BaseRunner, id, claimedBy string, staleBefore int64) (bool, error) {
+	result, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"deferred_notifications").
+		Set("claimed_by", claimedBy).
+		Set("claim_at", utils.GetMillis()).
+		Where(sq.Eq{"id": id}).
+		Where(sq.Or{
+			sq.Eq{"claimed_by": nil},
+			sq.Lt{"claim_at": staleBefore},
+		}).
+		ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("claimDeferredNotification error", mlog.String("id", id), mlog.Err(err))
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+// releaseDeferredNotification removes the claim of the claimer on the
+// notification, so it can be delivered again.
+func (s *SQLStore) releaseDeferredNotification(db sq.BaseRunner, id, claimedBy string) error {
+	_, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"deferred_notifications").
+		Set("claimed_by", nil).
+		Set("claim_at", 0).
+		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"claimed_by": claimedBy}).
+		ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("releaseDeferredNotification error", mlog.String("id", id), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteDeferredNotification(db sq.BaseRunner, id string) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "deferred_notifications").
+		Where(sq.Eq{"id": id}).
+		ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("deleteDeferredNotification error", mlog.String("id", id), mlog.Err(err))
+		return err
+	}
+	return nil
+}
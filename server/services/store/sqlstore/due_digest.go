@@ -0,0 +1,104 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var dueDigestSettingsFields = []string{
+	"user_id",
+	"enabled",
+	"send_hour",
+	"last_sent_at",
+}
+
+func (s *SQLStore) getDueDigestSettings(db sq.BaseRunner, userID string) (*model.DueDigestSettings, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(dueDigestSettingsFields...).
+		From(s.tablePrefix + "due_digest_settings").
+		Where(sq.Eq{"user_id": userID}).
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getDueDigestSettings error", mlog.String("userID", userID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	settings, err := s.dueDigestSettingsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return nil, store.NewErrNotFound("due digest settings " + userID)
+	}
+	return settings[0], nil
+}
+
+// getEnabledDueDigestSettings returns the settings of all the users that
+// enabled the due digest.
+func (s *SQLStore) getEnabledDueDigestSettings(db sq.BaseRunner) ([]*model.DueDigestSettings, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(dueDigestSettingsFields...).
+		From(s.tablePrefix + "due_digest_settings").
+		Where(sq.Eq{"enabled": true}).
+		OrderBy("user_id").
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getEnabledDueDigestSettings error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.dueDigestSettingsFromRows(rows)
+}
+
+func (s *SQLStore) saveDueDigestSettings(db sq.BaseRunner, settings *model.DueDigestSettings) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"due_digest_settings").
+		Columns(dueDigestSettingsFields...).
+		Values(
+			settings.UserID,
+			settings.Enabled,
+			settings.Hour,
+			settings.LastSentAt,
+		)
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE enabled = ?, send_hour = ?, last_sent_at = ?",
+			settings.Enabled, settings.Hour, settings.LastSentAt)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (user_id)
+			 DO UPDATE SET enabled = EXCLUDED.enabled, send_hour = EXCLUDED.send_hour, last_sent_at = EXCLUDED.last_sent_at`,
+		)
+	}
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("saveDueDigestSettings error", mlog.String("userID", settings.UserID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) dueDigestSettingsFromRows(rows *sql.Rows) ([]*model.DueDigestSettings, error) {
+	results := []*model.DueDigestSettings{}
+	for rows.Next() {
+		var settings model.DueDigestSettings
+		err := rows.Scan(
+			&settings.UserID,
+			&settings.Enabled,
+			&settings.Hour,
+			&settings.LastSentAt,
+		)
+		if err != nil {
+			s.logger.Error("dueDigestSettingsFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		results = append(results, &settings)
+	}
+	return results, nil
+}
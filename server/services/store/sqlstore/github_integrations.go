@@ -0,0 +1,151 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var gitHubIntegrationColumns = []string{
+	"board_id",
+	"repository",
+	"encrypted_token",
+	"status_property",
+	"open_status",
+	"closed_status",
+	"assignee_property",
+	"webhook_secret",
+	"modified_by",
+	"update_at",
+	"last_sync_at",
+}
+
+// saveGitHubIntegration stores the GitHub integration of a board,
+// replacing its previous one. The token is stored as given, encrypted,
+// and the webhook secret is encrypted with the secrets key.
+func (s *SQLStore) saveGitHubIntegration(db sq.BaseRunner, integration *model.GitHubIntegration) error {
+	webhookSecret, err := s.encryptSecret(integration.WebhookSecret)
+	if err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"github_integrations").
+		Columns(gitHubIntegrationColumns...).
+		Values(
+			integration.BoardID,
+			integration.Repository,
+			integration.EncryptedToken,
+			integration.StatusProperty,
+			integration.OpenStatus,
+			integration.ClosedStatus,
+			integration.AssigneeProperty,
+			webhookSecret,
+			integration.ModifiedBy,
+			integration.UpdateAt,
+			integration.LastSyncAt,
+		)
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix(
+			`ON DUPLICATE KEY UPDATE repository = ?, encrypted_token = ?, status_property = ?, open_status = ?,
+			 closed_status = ?, assignee_property = ?, webhook_secret = ?, modified_by = ?, update_at = ?, last_sync_at = ?`,
+			integration.Repository, integration.EncryptedToken, integration.StatusProperty, integration.OpenStatus,
+			integration.ClosedStatus, integration.AssigneeProperty, webhookSecret, integration.ModifiedBy,
+			integration.UpdateAt, integration.LastSyncAt)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (board_id)
+			 DO UPDATE SET repository = EXCLUDED.repository, encrypted_token = EXCLUDED.encrypted_token,
+			 status_property = EXCLUDED.status_property, open_status = EXCLUDED.open_status,
+			 closed_status = EXCLUDED.closed_status, assignee_property = EXCLUDED.assignee_property,
+			 webhook_secret = EXCLUDED.webhook_secret, modified_by = EXCLUDED.modified_by,
+			 update_at = EXCLUDED.update_at, last_sync_at = EXCLUDED.last_sync_at`,
+		)
+	}
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("saveGitHubIntegration error", mlog.String("boardID", integration.BoardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) getGitHubIntegration(db sq.BaseRunner, boardID string) (*model.GitHubIntegration, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(
+			"board_id",
+			"repository",
+			"COALESCE(encrypted_token, '')",
+			"status_property",
+			"open_status",
+			"closed_status",
+			"assignee_property",
+			"webhook_secret",
+			"modified_by",
+			"update_at",
+			"last_sync_at",
+		).
+		From(s.tablePrefix + "github_integrations").
+		Where(sq.Eq{"board_id": boardID}).
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getGitHubIntegration error", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	integrations, err := s.gitHubIntegrationsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(integrations) == 0 {
+		return nil, store.NewErrNotFound("github integration " + boardID)
+	}
+	return integrations[0], nil
+}
+
+func (s *SQLStore) deleteGitHubIntegration(db sq.BaseRunner, boardID string) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "github_integrations").
+		Where(sq.Eq{"board_id": boardID}).
+		ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("deleteGitHubIntegration error", mlog.String("boardID", boardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) gitHubIntegrationsFromRows(rows *sql.Rows) ([]*model.GitHubIntegration, error) {
+	results := []*model.GitHubIntegration{}
+	for rows.Next() {
+		var integration model.GitHubIntegration
+		err := rows.Scan(
+			&integration.BoardID,
+			&integration.Repository,
+			&integration.EncryptedToken,
+			&integration.StatusProperty,
+			&integration.OpenStatus,
+			&integration.ClosedStatus,
+			&integration.AssigneeProperty,
+			&integration.WebhookSecret,
+			&integration.ModifiedBy,
+			&integration.UpdateAt,
+			&integration.LastSyncAt,
+		)
+		if err != nil {
+			s.logger.Error("gitHubIntegrationsFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		if integration.WebhookSecret, err = s.decryptSecret(integration.WebhookSecret); err != nil {
+			s.logger.Error("gitHubIntegrationsFromRows secret decryption error", mlog.String("boardID", integration.BoardID), mlog.Err(err))
+			return nil, err
+		}
+		results = append(results, &integration)
+	}
+	return results, nil
+}
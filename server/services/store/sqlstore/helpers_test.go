@@ -17,7 +17,7 @@ func SetupTests(t *testing.T) (store.Store, func()) {
 
 	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
 
-	sqlDB, err := sql.Open(dbType, connectionString)
+	sqlDB, err := sql.Open(DriverName(dbType), connectionString)
 	require.NoError(t, err)
 	err = sqlDB.Ping()
 	require.NoError(t, err)
@@ -33,6 +33,10 @@ func SetupTests(t *testing.T) (store.Store, func()) {
 	store, err := New(storeParams)
 	require.Nil(t, err)
 
+	// the batched migrations run in the background, the tests start
+	// once they are done
+	store.batchedMigrationsWG.Wait()
+
 	tearDown := func() {
 		defer func() { _ = logger.Shutdown() }()
 		err = store.Shutdown()
@@ -91,7 +91,7 @@ func (s *SQLStore) getLegacyBlock(db sq.BaseRunner, workspaceID string, blockID
 		Where(sq.Eq{"id": blockID}).
 		Where(sq.Eq{"coalesce(workspace_id, '0')": workspaceID})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`GetBlock ERROR`, mlog.Err(err))
 		return nil, err
@@ -179,7 +179,7 @@ func (s *SQLStore) insertLegacyBlock(db sq.BaseRunner, workspaceID string, block
 			Set("update_at", block.UpdateAt).
 			Set("delete_at", block.DeleteAt)
 
-		if _, err := query.Exec(); err != nil {
+		if _, err := query.ExecContext(s.context()); err != nil {
 			s.logger.Error(`InsertBlock error occurred while updating existing block`, mlog.String("blockID", block.ID), mlog.Err(err))
 			return err
 		}
@@ -193,14 +193,14 @@ func (s *SQLStore) insertLegacyBlock(db sq.BaseRunner, workspaceID string, block
 		insertQueryValues["modified_by"] = block.ModifiedBy
 
 		query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "blocks")
-		if _, err := query.Exec(); err != nil {
+		if _, err := query.ExecContext(s.context()); err != nil {
 			return err
 		}
 	}
 
 	// writing block history
 	query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "blocks_history")
-	if _, err := query.Exec(); err != nil {
+	if _, err := query.ExecContext(s.context()); err != nil {
 		return err
 	}
 
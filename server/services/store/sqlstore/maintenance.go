@@ -0,0 +1,132 @@
+package sqlstore
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// boardChildTables are the tables whose rows belong to a board, and
+// become orphans once the board and its history are gone.
+var boardChildTables = []string{
+	"board_invitations",
+	"board_keys",
+	"board_members",
+	"board_teams",
+	"board_visits",
+	"card_numbers",
+	"category_boards",
+	"mentions",
+}
+
+// runMaintenance deletes the orphan rows and then optimizes the tables
+// and refreshes their statistics. It doesn't run in a transaction, as
+// VACUUM can't.
+func (s *SQLStore) runMaintenance(db sq.BaseRunner) (*model.MaintenanceReport, error) {
+	report := &model.MaintenanceReport{
+		DBType:         s.dbType,
+		Statements:     []*model.MaintenanceStatement{},
+		DeletedOrphans: map[string]int64{},
+		StartAt:        utils.GetMillis(),
+	}
+
+	for _, name := range boardChildTables {
+		count, err := s.deleteBoardOrphans(db, name)
+		if err != nil {
+			return nil, err
+		}
+		report.DeletedOrphans[name] = count
+	}
+
+	count, err := s.cleanUpStaleSubscriptions(db)
+	if err != nil {
+		return nil, err
+	}
+	report.DeletedOrphans["subscriptions"] = count
+
+	count, err = s.deleteOrphanPropertyIndexes(db)
+	if err != nil {
+		return nil, err
+	}
+	report.DeletedOrphans["property_indexes"] = count
+
+	for _, statement := range s.maintenanceStatements() {
+		start := utils.GetMillis()
+		if err := s.execMaintenanceStatement(db, statement); err != nil {
+			s.logger.Error("Maintenance statement failed", mlog.String("sql", statement), mlog.Err(err))
+			return nil, err
+		}
+		report.Statements = append(report.Statements, &model.MaintenanceStatement{
+			SQL:        statement,
+			DurationMs: utils.GetMillis() - start,
+		})
+	}
+
+	report.EndAt = utils.GetMillis()
+	return report, nil
+}
+
+// deleteBoardOrphans deletes the rows of a table that belong to a board
+// that doesn't exist anymore, not even in the history, so the boards
+// that can still be restored keep their rows.
+func (s *SQLStore) deleteBoardOrphans(db sq.BaseRunner, name string) (int64, error) {
+	table := s.tablePrefix + name
+	query := s.getQueryBuilder(db).
+		Delete(table).
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]sboards WHERE %[1]sboards.id = %[2]s.board_id)", s.tablePrefix, table)).
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]sboards_history WHERE %[1]sboards_history.id = %[2]s.board_id)", s.tablePrefix, table))
+
+	result, err := query.ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("Cannot delete orphan rows", mlog.String("table", table), mlog.Err(err))
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLStore) maintenanceStatements() []string {
+	switch s.dbType {
+	case model.PostgresDBType:
+		statements := make([]string, 0, len(statsTables))
+		for _, name := range statsTables {
+			statements = append(statements, "VACUUM ANALYZE "+s.tablePrefix+name)
+		}
+		return statements
+	case model.MysqlDBType:
+		statements := make([]string, 0, len(statsTables))
+		for _, name := range statsTables {
+			statements = append(statements, "OPTIMIZE TABLE "+s.tablePrefix+name)
+		}
+		return statements
+	case model.CockroachDBType:
+		// CockroachDB has no VACUUM, as it garbage collects the old row
+		// versions by itself, so only the statistics are refreshed
+		statements := make([]string, 0, len(statsTables))
+		for _, name := range statsTables {
+			statements = append(statements, "ANALYZE "+s.tablePrefix+name)
+		}
+		return statements
+	case model.SqliteDBType:
+		return []string{"VACUUM", "ANALYZE"}
+	}
+	return nil
+}
+
+func (s *SQLStore) execMaintenanceStatement(db sq.BaseRunner, statement string) error {
+	if s.dbType == model.MysqlDBType {
+		// OPTIMIZE TABLE returns a result set, which is discarded when
+		// the rows are closed
+		rows, err := s.queryContext(db, statement)
+		if err != nil {
+			return err
+		}
+		return rows.Close()
+	}
+
+	_, err := s.execContext(db, statement)
+	return err
+}
@@ -0,0 +1,135 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var mentionFields = []string{
+	"id",
+	"user_id",
+	"team_id",
+	"board_id",
+	"card_id",
+	"block_id",
+	"mentioned_by",
+	"extract_text",
+	"create_at",
+	"read_at",
+}
+
+func (s *SQLStore) mentionsFromRows(rows *sql.Rows) ([]*model.Mention, error) {
+	mentions := []*model.Mention{}
+
+	for rows.Next() {
+		var mention model.Mention
+		err := rows.Scan(
+			&mention.ID,
+			&mention.UserID,
+			&mention.TeamID,
+			&mention.BoardID,
+			&mention.CardID,
+			&mention.BlockID,
+			&mention.MentionedBy,
+			&mention.Extract,
+			&mention.CreateAt,
+			&mention.ReadAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, &mention)
+	}
+	return mentions, nil
+}
+
+func (s *SQLStore) insertMention(db sq.BaseRunner, mention *model.Mention) error {
+	if err := mention.IsValid(); err != nil {
+		return err
+	}
+
+	if mention.ID == "" {
+		mention.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if mention.CreateAt == 0 {
+		mention.CreateAt = model.GetMillis()
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"mentions").
+		Columns(mentionFields...).
+		Values(
+			mention.ID,
+			mention.UserID,
+			mention.TeamID,
+			mention.BoardID,
+			mention.CardID,
+			mention.BlockID,
+			mention.MentionedBy,
+			mention.Extract,
+			mention.CreateAt,
+			mention.ReadAt,
+		)
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("Cannot insert mention",
+			mlog.String("user_id", mention.UserID),
+			mlog.String("block_id", mention.BlockID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) getMentionsForUser(db sq.BaseRunner, userID string, opts model.QueryMentionsOptions) ([]*model.Mention, error) {
+	query := s.getQueryBuilder(db).
+		Select(mentionFields...).
+		From(s.tablePrefix + "mentions").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("create_at DESC")
+
+	if opts.UnreadOnly {
+		query = query.Where(sq.Eq{"read_at": 0})
+	}
+	if opts.BeforeCreateAt != 0 {
+		query = query.Where(sq.Lt{"create_at": opts.BeforeCreateAt})
+	}
+	if opts.Limit != 0 {
+		query = query.Limit(opts.Limit)
+	}
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("Cannot fetch mentions for user", mlog.String("user_id", userID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.mentionsFromRows(rows)
+}
+
+// markMentionsRead marks the given unread mentions of the user as read.
+// If no mention IDs are given, all the mentions of the user are marked.
+func (s *SQLStore) markMentionsRead(db sq.BaseRunner, userID string, mentionIDs []string, readAt int64) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"mentions").
+		Set("read_at", readAt).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"read_at": 0})
+
+	if len(mentionIDs) > 0 {
+		query = query.Where(sq.Eq{"id": mentionIDs})
+	}
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("Cannot mark mentions as read", mlog.String("user_id", userID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
@@ -74,7 +74,7 @@ func (s *SQLStore) getMigrationConnection() (*sql.DB, error) {
 		}
 	}
 
-	db, err := sql.Open(s.dbType, connectionString)
+	db, err := sql.Open(DriverName(s.dbType), connectionString)
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +86,40 @@ func (s *SQLStore) getMigrationConnection() (*sql.DB, error) {
 	return db, nil
 }
 
+// migrationParams returns the values the migration templates are
+// rendered with.
+func (s *SQLStore) migrationParams() map[string]interface{} {
+	return map[string]interface{}{
+		"prefix":    s.tablePrefix,
+		"postgres":  s.isPostgresCompatible(),
+		"cockroach": s.dbType == model.CockroachDBType,
+		"sqlite":    s.dbType == model.SqliteDBType,
+		"mysql":     s.dbType == model.MysqlDBType,
+		"plugin":    s.isPlugin,
+	}
+}
+
+// renderMigration returns the SQL of the named migration file for
+// the store's database type.
+func (s *SQLStore) renderMigration(name string) ([]byte, error) {
+	asset, err := assets.ReadFile("migrations/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("sql").Parse(string(asset))
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := bytes.NewBufferString("")
+	if err := tmpl.Execute(buffer, s.migrationParams()); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
 func (s *SQLStore) Migrate() error {
 	var driver drivers.Driver
 	var err error
@@ -112,7 +146,7 @@ func (s *SQLStore) Migrate() error {
 		defer db.Close()
 	}
 
-	if s.dbType == model.PostgresDBType {
+	if s.isPostgresCompatible() {
 		driver, err = postgres.WithInstance(db, &postgres.Config{Config: migrationConfig})
 		if err != nil {
 			return err
@@ -135,35 +169,9 @@ func (s *SQLStore) Migrate() error {
 		assetNamesForDriver[i] = dirEntry.Name()
 	}
 
-	params := map[string]interface{}{
-		"prefix":   s.tablePrefix,
-		"postgres": s.dbType == model.PostgresDBType,
-		"sqlite":   s.dbType == model.SqliteDBType,
-		"mysql":    s.dbType == model.MysqlDBType,
-		"plugin":   s.isPlugin,
-	}
-
 	migrationAssets := &embedded.AssetSource{
-		Names: assetNamesForDriver,
-		AssetFunc: func(name string) ([]byte, error) {
-			asset, mErr := assets.ReadFile("migrations/" + name)
-			if mErr != nil {
-				return nil, mErr
-			}
-
-			tmpl, pErr := template.New("sql").Parse(string(asset))
-			if pErr != nil {
-				return nil, pErr
-			}
-			buffer := bytes.NewBufferString("")
-
-			err = tmpl.Execute(buffer, params)
-			if err != nil {
-				return nil, err
-			}
-
-			return buffer.Bytes(), nil
-		},
+		Names:     assetNamesForDriver,
+		AssetFunc: s.renderMigration,
 	}
 
 	src, err := embedded.WithInstance(migrationAssets)
@@ -179,6 +187,10 @@ func (s *SQLStore) Migrate() error {
 		opts = opts[:0] // sqlite driver does not support locking, it doesn't need to anyway.
 	}
 
+	if s.dbType == model.CockroachDBType {
+		opts = opts[:0] // cockroach does not support the advisory locks used by the postgres driver.
+	}
+
 	engine, err := morph.New(context.Background(), driver, src, opts...)
 	if err != nil {
 		return err
@@ -311,7 +323,7 @@ func (s *SQLStore) isSchemaMigrationNeeded() (bool, error) {
 			"COLUMN_NAME": "dirty",
 		})
 
-	row := query.QueryRow()
+	row := query.QueryRowContext(s.context())
 
 	var count int
 	if err := row.Scan(&count); err != nil {
@@ -327,7 +339,7 @@ func (s *SQLStore) isSchemaMigrationNeededSQLite() (bool, error) {
 	// for SQLite. Hence, the separate function
 
 	query := fmt.Sprintf("PRAGMA table_info(\"%sschema_migrations\");", s.tablePrefix)
-	rows, err := s.db.Query(query)
+	rows, err := s.db.QueryContext(s.context(), query)
 	if err != nil {
 		s.logger.Error("SQLite - failed to check for columns in schema_migrations table", mlog.Err(err))
 		return false, err
@@ -372,7 +384,7 @@ func (s *SQLStore) getLegacySchemaVersion() (uint32, error) {
 		Select("version").
 		From(s.tablePrefix + "schema_migrations")
 
-	row := query.QueryRow()
+	row := query.QueryRowContext(s.context())
 
 	var version uint32
 	if err := row.Scan(&version); err != nil {
@@ -388,7 +400,7 @@ func (s *SQLStore) createTempSchemaTable() error {
 	// squirrel doesn't support DDL query in query builder
 	// so, we need to use a plain old string
 	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (Version bigint NOT NULL, Name varchar(64) NOT NULL, PRIMARY KEY (Version))", s.tablePrefix+tempSchemaMigrationTableName)
-	if _, err := s.db.Exec(query); err != nil {
+	if _, err := s.db.ExecContext(s.context(), query); err != nil {
 		s.logger.Error("failed to create temporary schema migration table", mlog.Err(err))
 		s.logger.Error("createTempSchemaTable error  " + err.Error())
 		return err
@@ -416,7 +428,7 @@ func (s *SQLStore) populateTempSchemaTable(migrations []*models.Migration, legac
 		query = query.Values(migration.Version, migration.Name)
 	}
 
-	if _, err := query.Exec(); err != nil {
+	if _, err := query.ExecContext(s.context()); err != nil {
 		s.logger.Error("failed to insert migration records into temporary schema table", mlog.Err(err))
 		return err
 	}
@@ -437,7 +449,7 @@ func (s *SQLStore) useNewSchemaTable() error {
 		query = fmt.Sprintf("ALTER TABLE %sschema_migrations RENAME TO %sschema_migrations_old_temp", s.tablePrefix, s.tablePrefix)
 	}
 
-	if _, err := s.db.Exec(query); err != nil {
+	if _, err := s.db.ExecContext(s.context(), query); err != nil {
 		s.logger.Error("failed to rename old schema migration table", mlog.Err(err))
 		return err
 	}
@@ -449,7 +461,7 @@ func (s *SQLStore) useNewSchemaTable() error {
 		query = fmt.Sprintf("ALTER TABLE %s%s RENAME TO %sschema_migrations", s.tablePrefix, tempSchemaMigrationTableName, s.tablePrefix)
 	}
 
-	if _, err := s.db.Exec(query); err != nil {
+	if _, err := s.db.ExecContext(s.context(), query); err != nil {
 		s.logger.Error("failed to rename temp schema table", mlog.Err(err))
 		return err
 	}
@@ -459,7 +471,7 @@ func (s *SQLStore) useNewSchemaTable() error {
 
 func (s *SQLStore) deleteOldSchemaMigrationTable() error {
 	query := "DROP TABLE IF EXISTS " + s.tablePrefix + "schema_migrations_old_temp"
-	if _, err := s.db.Exec(query); err != nil {
+	if _, err := s.db.ExecContext(s.context(), query); err != nil {
 		s.logger.Error("failed to delete old temp schema migrations table", mlog.Err(err))
 		return err
 	}
@@ -526,7 +538,7 @@ func (s *SQLStore) migrateTeamLessBoards() error {
 			Set("type", model.BoardTypePrivate).
 			Where(sq.Eq{"id": boards[i].ID})
 
-		if _, err := query.Exec(); err != nil {
+		if _, err := query.ExecContext(s.context()); err != nil {
 			s.logger.Error("failed to set team id for board", mlog.String("board_id", boards[i].ID), mlog.String("team_id", teamID), mlog.Err(err))
 			return err
 		}
@@ -634,7 +646,7 @@ func (s *SQLStore) getBoardUserTeams(tx sq.BaseRunner, board *model.Board) (map[
 		Join("teammembers ON channelmembers.userid = teammembers.userid").
 		Where(sq.Eq{"channelid": board.ChannelID})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("failed to fetch user teams for board", mlog.String("boardID", board.ID), mlog.String("channelID", board.ChannelID), mlog.Err(err))
 		return nil, err
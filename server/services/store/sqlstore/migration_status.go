@@ -0,0 +1,173 @@
+package sqlstore
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+var migrationFileRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.up\.sql$`)
+
+type migrationFile struct {
+	version  uint32
+	name     string
+	fileName string
+}
+
+// migrationFiles returns the up migrations embedded in the server,
+// sorted by version.
+func migrationFiles() ([]migrationFile, error) {
+	entries, err := assets.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	files := []migrationFile{}
+	for _, entry := range entries {
+		matches := migrationFileRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(matches[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+
+		files = append(files, migrationFile{
+			version:  uint32(version),
+			name:     matches[2],
+			fileName: entry.Name(),
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].version < files[j].version })
+	return files, nil
+}
+
+func (s *SQLStore) getMigrationStatus(db sq.BaseRunner, includeSQL bool) (*model.MigrationStatus, error) {
+	files, err := migrationFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := s.getAppliedMigrationVersions(db, files)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &model.MigrationStatus{
+		Pending: []*model.PendingMigration{},
+	}
+	for _, file := range files {
+		status.LatestVersion = file.version
+
+		if applied[file.version] {
+			status.CurrentVersion = file.version
+			continue
+		}
+
+		pending := &model.PendingMigration{
+			Version: file.version,
+			Name:    file.name,
+		}
+		if includeSQL {
+			rendered, err := s.renderMigration(file.fileName)
+			if err != nil {
+				return nil, fmt.Errorf("cannot render migration %s: %w", file.fileName, err)
+			}
+			pending.SQL = string(rendered)
+		}
+		status.Pending = append(status.Pending, pending)
+	}
+
+	return status, nil
+}
+
+// getAppliedMigrationVersions returns the set of migration versions
+// applied to the database, taking into account databases that still
+// use the legacy schema table and databases that weren't migrated yet.
+func (s *SQLStore) getAppliedMigrationVersions(db sq.BaseRunner, files []migrationFile) (map[uint32]bool, error) {
+	applied := map[uint32]bool{}
+
+	exists, err := s.schemaTableExists(db)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return applied, nil
+	}
+
+	legacy, err := s.isSchemaMigrationNeeded()
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		legacyVersion, err := s.getLegacySchemaVersion()
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if file.version <= legacyVersion {
+				applied[file.version] = true
+			}
+		}
+		return applied, nil
+	}
+
+	rows, err := s.getQueryBuilder(db).
+		Select("Version").
+		From(s.tablePrefix + "schema_migrations").
+		QueryContext(s.context())
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var version uint32
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+func (s *SQLStore) schemaTableExists(db sq.BaseRunner) (bool, error) {
+	tableName := s.tablePrefix + "schema_migrations"
+
+	var query sq.SelectBuilder
+	switch {
+	case s.dbType == model.SqliteDBType:
+		query = s.getQueryBuilder(db).
+			Select("count(*)").
+			From("sqlite_master").
+			Where(sq.Eq{"type": "table", "name": tableName})
+	case s.dbType == model.MysqlDBType:
+		query = s.getQueryBuilder(db).
+			Select("count(*)").
+			From("information_schema.TABLES").
+			Where(sq.Eq{"TABLE_NAME": tableName}).
+			Where("TABLE_SCHEMA = DATABASE()")
+	default:
+		query = s.getQueryBuilder(db).
+			Select("count(*)").
+			From("information_schema.TABLES").
+			Where(sq.Eq{"TABLE_NAME": tableName}).
+			Where("TABLE_SCHEMA = current_schema()")
+	}
+
+	var count int
+	if err := query.QueryRowContext(s.context()).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
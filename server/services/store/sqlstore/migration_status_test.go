@@ -0,0 +1,58 @@
+package sqlstore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMigrationStatus(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	defer tearDown()
+	sqlStore := store.(*SQLStore)
+
+	status, err := sqlStore.GetMigrationStatus(false)
+	require.NoError(t, err)
+	require.Empty(t, status.Pending)
+	latestVersion := status.LatestVersion
+
+	t.Run("reports migrations missing from the schema table as pending", func(t *testing.T) {
+		_, err := sqlStore.getQueryBuilder(sqlStore.db).
+			Delete(sqlStore.tablePrefix+"schema_migrations").
+			Where("Version = ?", latestVersion).
+			Exec()
+		require.NoError(t, err)
+
+		status, err := sqlStore.GetMigrationStatus(false)
+		require.NoError(t, err)
+		require.Equal(t, latestVersion, status.LatestVersion)
+		require.Less(t, status.CurrentVersion, latestVersion)
+		require.Len(t, status.Pending, 1)
+		require.Equal(t, latestVersion, status.Pending[0].Version)
+		require.Empty(t, status.Pending[0].SQL)
+	})
+
+	t.Run("includes the rendered SQL on dry runs", func(t *testing.T) {
+		status, err := sqlStore.GetMigrationStatus(true)
+		require.NoError(t, err)
+		require.Len(t, status.Pending, 1)
+		require.NotEmpty(t, status.Pending[0].SQL)
+		require.NotContains(t, status.Pending[0].SQL, "{{")
+		require.Contains(t, status.Pending[0].SQL, sqlStore.tablePrefix)
+	})
+}
+
+func TestMigrationFiles(t *testing.T) {
+	files, err := migrationFiles()
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for i, file := range files {
+		require.NotEmpty(t, file.name)
+		require.True(t, strings.HasSuffix(file.fileName, ".up.sql"))
+		if i > 0 {
+			require.Greater(t, file.version, files[i-1].version)
+		}
+	}
+}
@@ -24,7 +24,9 @@ CREATE INDEX idx_categories_user_id_team_id ON {{.prefix}}categories(user_id, te
         delete_at
     )
     SELECT
-        {{ if .postgres }}
+        {{ if .cockroach }}
+            REPLACE(gen_random_uuid()::varchar, '-', ''),
+        {{ else if .postgres }}
             REPLACE(uuid_in(md5(random()::text || clock_timestamp()::text)::cstring)::varchar, '-', ''),
         {{ end }}
         {{ if .mysql }}
@@ -14,7 +14,9 @@ CREATE INDEX idx_categoryboards_category_id ON {{.prefix}}category_boards(catego
 {{if .plugin}}
     INSERT INTO {{.prefix}}category_boards(id, user_id, category_id, board_id, create_at, update_at, delete_at)
     SELECT
-        {{ if .postgres }}
+        {{ if .cockroach }}
+            REPLACE(gen_random_uuid()::varchar, '-', ''),
+        {{ else if .postgres }}
             REPLACE(uuid_in(md5(random()::text || clock_timestamp()::text)::cstring)::varchar, '-', ''),
         {{ end }}
         {{ if .mysql }}
@@ -0,0 +1,8 @@
+CREATE TABLE {{.prefix}}board_visits (
+    user_id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    visited_at BIGINT NOT NULL,
+    PRIMARY KEY (user_id, board_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_boardvisits_user_id_visited_at ON {{.prefix}}board_visits(user_id, visited_at);
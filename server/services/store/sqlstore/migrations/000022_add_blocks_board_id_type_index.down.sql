@@ -0,0 +1,2 @@
+{{if .mysql}}DROP INDEX idx_blocks_board_id_type ON {{.prefix}}blocks;{{end}}
+{{if not .mysql}}DROP INDEX idx_blocks_board_id_type;{{end}}
@@ -0,0 +1 @@
+CREATE INDEX idx_blocks_board_id_type ON {{.prefix}}blocks(board_id, type);
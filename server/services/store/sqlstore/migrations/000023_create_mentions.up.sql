@@ -0,0 +1,15 @@
+CREATE TABLE {{.prefix}}mentions (
+    id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    team_id VARCHAR(36),
+    board_id VARCHAR(36) NOT NULL,
+    card_id VARCHAR(36),
+    block_id VARCHAR(36) NOT NULL,
+    mentioned_by VARCHAR(36),
+    extract_text TEXT,
+    create_at BIGINT NOT NULL,
+    read_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_mentions_user_id_create_at ON {{.prefix}}mentions(user_id, create_at);
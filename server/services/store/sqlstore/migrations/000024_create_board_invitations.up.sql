@@ -0,0 +1,15 @@
+CREATE TABLE {{.prefix}}board_invitations (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    invited_by VARCHAR(36) NOT NULL,
+    scheme_admin BOOLEAN NOT NULL,
+    scheme_editor BOOLEAN NOT NULL,
+    scheme_commenter BOOLEAN NOT NULL,
+    scheme_viewer BOOLEAN NOT NULL,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE UNIQUE INDEX idx_board_invitations_board_id_user_id ON {{.prefix}}board_invitations(board_id, user_id);
+CREATE INDEX idx_board_invitations_user_id ON {{.prefix}}board_invitations(user_id);
@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}board_members ADD COLUMN inactive BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,14 @@
+CREATE TABLE {{.prefix}}webhook_deliveries (
+    id VARCHAR(36) NOT NULL,
+    url TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    status VARCHAR(10) NOT NULL,
+    next_attempt_at BIGINT NOT NULL DEFAULT 0,
+    create_at BIGINT NOT NULL,
+    update_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_webhook_deliveries_status_next_attempt_at ON {{.prefix}}webhook_deliveries(status, next_attempt_at);
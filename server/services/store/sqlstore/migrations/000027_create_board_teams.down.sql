@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}board_teams;
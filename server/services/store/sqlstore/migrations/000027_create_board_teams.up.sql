@@ -0,0 +1,9 @@
+CREATE TABLE {{.prefix}}board_teams (
+    board_id VARCHAR(36) NOT NULL,
+    team_id VARCHAR(36) NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (board_id, team_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_board_teams_team_id ON {{.prefix}}board_teams(team_id);
@@ -0,0 +1,2 @@
+DROP TABLE {{.prefix}}card_numbers;
+DROP TABLE {{.prefix}}board_keys;
@@ -0,0 +1,17 @@
+CREATE TABLE {{.prefix}}board_keys (
+    board_id VARCHAR(36) NOT NULL,
+    board_key VARCHAR(16) NOT NULL,
+    next_number BIGINT NOT NULL,
+    PRIMARY KEY (board_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE UNIQUE INDEX idx_board_keys_board_key ON {{.prefix}}board_keys(board_key);
+
+CREATE TABLE {{.prefix}}card_numbers (
+    board_id VARCHAR(36) NOT NULL,
+    card_number BIGINT NOT NULL,
+    card_id VARCHAR(36) NOT NULL,
+    PRIMARY KEY (board_id, card_number)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE UNIQUE INDEX idx_card_numbers_card_id ON {{.prefix}}card_numbers(card_id);
@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}property_indexes;
@@ -0,0 +1,6 @@
+CREATE TABLE {{.prefix}}property_indexes (
+    board_id VARCHAR(36) NOT NULL,
+    property_id VARCHAR(36) NOT NULL,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (board_id, property_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}categories DROP COLUMN sort_order;
@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}categories ADD COLUMN sort_order BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+CREATE TABLE {{.prefix}}category_rules (
+    id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    team_id VARCHAR(36) NOT NULL,
+    category_id VARCHAR(36) NOT NULL,
+    title_pattern VARCHAR(255) NOT NULL,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_category_rules_user_id_team_id ON {{.prefix}}category_rules(user_id, team_id);
@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}due_digest_settings;
@@ -0,0 +1,7 @@
+CREATE TABLE {{.prefix}}due_digest_settings (
+    user_id VARCHAR(36) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    send_hour INT NOT NULL DEFAULT 9,
+    last_sent_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}deferred_notifications;
@@ -0,0 +1,14 @@
+CREATE TABLE {{.prefix}}deferred_notifications (
+    id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    event_type VARCHAR(32) NOT NULL,
+    notification_key VARCHAR(255) NOT NULL,
+    message TEXT,
+    link TEXT,
+    create_at BIGINT NOT NULL,
+    claimed_by VARCHAR(36),
+    claim_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_deferred_notifications_user_id ON {{.prefix}}deferred_notifications(user_id);
@@ -0,0 +1,14 @@
+CREATE TABLE {{.prefix}}webhooks (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    url TEXT NOT NULL,
+    events TEXT NOT NULL,
+    secret VARCHAR(255),
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT NOT NULL,
+    update_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_webhooks_board_id ON {{.prefix}}webhooks(board_id);
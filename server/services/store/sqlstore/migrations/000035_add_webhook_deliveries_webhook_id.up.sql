@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}webhook_deliveries ADD COLUMN webhook_id VARCHAR(36);
@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}stale_digest_settings;
@@ -0,0 +1,9 @@
+CREATE TABLE {{.prefix}}stale_digest_settings (
+    user_id VARCHAR(36) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    stale_days INT NOT NULL DEFAULT 14,
+    send_weekday INT NOT NULL DEFAULT 1,
+    send_hour INT NOT NULL DEFAULT 9,
+    last_sent_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
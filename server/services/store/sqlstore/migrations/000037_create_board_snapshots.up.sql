@@ -0,0 +1,9 @@
+CREATE TABLE {{.prefix}}board_snapshots (
+    board_id VARCHAR(36) NOT NULL,
+    snapshot_day VARCHAR(10) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    property_id VARCHAR(36) NOT NULL,
+    card_count BIGINT NOT NULL DEFAULT 0,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (board_id, snapshot_day, value)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
@@ -0,0 +1,8 @@
+CREATE TABLE {{.prefix}}board_freeze_schedules (
+    board_id VARCHAR(36) NOT NULL,
+    freeze_at BIGINT NOT NULL DEFAULT 0,
+    unfreeze_at BIGINT NOT NULL DEFAULT 0,
+    modified_by VARCHAR(36) NOT NULL,
+    update_at BIGINT NOT NULL,
+    PRIMARY KEY (board_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
@@ -0,0 +1,10 @@
+CREATE TABLE {{.prefix}}board_access_requests (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    message TEXT,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE UNIQUE INDEX idx_board_access_requests_board_id_user_id ON {{.prefix}}board_access_requests(board_id, user_id);
@@ -0,0 +1,7 @@
+CREATE TABLE {{.prefix}}board_member_activity (
+    board_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    last_view_at BIGINT NOT NULL DEFAULT 0,
+    last_edit_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (board_id, user_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
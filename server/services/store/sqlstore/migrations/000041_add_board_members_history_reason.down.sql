@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}board_members_history DROP COLUMN reason;
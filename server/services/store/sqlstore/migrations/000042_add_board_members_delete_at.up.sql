@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}board_members ADD COLUMN delete_at BIGINT NOT NULL DEFAULT 0;
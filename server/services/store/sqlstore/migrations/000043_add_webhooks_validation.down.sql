@@ -0,0 +1,3 @@
+ALTER TABLE {{.prefix}}webhooks DROP COLUMN property_ids;
+ALTER TABLE {{.prefix}}webhooks DROP COLUMN timeout_ms;
+ALTER TABLE {{.prefix}}webhooks DROP COLUMN fail_open;
@@ -0,0 +1,3 @@
+ALTER TABLE {{.prefix}}webhooks ADD COLUMN property_ids TEXT;
+ALTER TABLE {{.prefix}}webhooks ADD COLUMN timeout_ms BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE {{.prefix}}webhooks ADD COLUMN fail_open BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}board_members ADD COLUMN custom_fields TEXT;
@@ -0,0 +1,10 @@
+CREATE TABLE {{.prefix}}calendar_subscriptions (
+    board_id VARCHAR(36) NOT NULL,
+    url TEXT NOT NULL,
+    date_property VARCHAR(100) NOT NULL DEFAULT '',
+    modified_by VARCHAR(36) NOT NULL,
+    update_at BIGINT NOT NULL,
+    last_sync_at BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    PRIMARY KEY (board_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
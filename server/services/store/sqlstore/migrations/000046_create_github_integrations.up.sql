@@ -0,0 +1,14 @@
+CREATE TABLE {{.prefix}}github_integrations (
+    board_id VARCHAR(36) NOT NULL,
+    repository VARCHAR(200) NOT NULL,
+    encrypted_token TEXT,
+    status_property VARCHAR(100) NOT NULL DEFAULT '',
+    open_status VARCHAR(100) NOT NULL DEFAULT '',
+    closed_status VARCHAR(100) NOT NULL DEFAULT '',
+    assignee_property VARCHAR(100) NOT NULL DEFAULT '',
+    webhook_secret VARCHAR(100) NOT NULL,
+    modified_by VARCHAR(36) NOT NULL,
+    update_at BIGINT NOT NULL,
+    last_sync_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (board_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
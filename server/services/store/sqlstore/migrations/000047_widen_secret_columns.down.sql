@@ -0,0 +1,9 @@
+{{if .mysql}}
+ALTER TABLE {{.prefix}}webhooks MODIFY secret VARCHAR(255);
+ALTER TABLE {{.prefix}}github_integrations MODIFY webhook_secret VARCHAR(100) NOT NULL;
+{{end}}
+
+{{if .postgres}}
+ALTER TABLE {{.prefix}}webhooks ALTER COLUMN secret TYPE VARCHAR(255);
+ALTER TABLE {{.prefix}}github_integrations ALTER COLUMN webhook_secret TYPE VARCHAR(100);
+{{end}}
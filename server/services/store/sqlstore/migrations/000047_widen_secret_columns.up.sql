@@ -0,0 +1,9 @@
+{{if .mysql}}
+ALTER TABLE {{.prefix}}webhooks MODIFY secret TEXT;
+ALTER TABLE {{.prefix}}github_integrations MODIFY webhook_secret TEXT NOT NULL;
+{{end}}
+
+{{if .postgres}}
+ALTER TABLE {{.prefix}}webhooks ALTER COLUMN secret TYPE TEXT;
+ALTER TABLE {{.prefix}}github_integrations ALTER COLUMN webhook_secret TYPE TEXT;
+{{end}}
@@ -0,0 +1,10 @@
+CREATE TABLE {{.prefix}}block_locks (
+    block_id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    create_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (block_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_blocklocks_board_id ON {{.prefix}}block_locks(board_id);
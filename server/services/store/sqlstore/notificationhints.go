@@ -76,7 +76,7 @@ func (s *SQLStore) upsertNotificationHint(db sq.BaseRunner, hint *model.Notifica
 		query = query.Suffix("ON CONFLICT (block_id) DO UPDATE SET notify_at = ?", notifyAt)
 	}
 
-	if _, err := query.Exec(); err != nil {
+	if _, err := query.ExecContext(s.context()); err != nil {
 		s.logger.Error("Cannot upsert notification hint",
 			mlog.String("block_id", hint.BlockID),
 			mlog.Err(err),
@@ -92,7 +92,7 @@ func (s *SQLStore) deleteNotificationHint(db sq.BaseRunner, blockID string) erro
 		Delete(s.tablePrefix + "notification_hints").
 		Where(sq.Eq{"block_id": blockID})
 
-	result, err := query.Exec()
+	result, err := query.ExecContext(s.context())
 	if err != nil {
 		return err
 	}
@@ -116,7 +116,7 @@ func (s *SQLStore) getNotificationHint(db sq.BaseRunner, blockID string) (*model
 		From(s.tablePrefix + "notification_hints").
 		Where(sq.Eq{"block_id": blockID})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("Cannot fetch notification hint",
 			mlog.String("block_id", blockID),
@@ -149,7 +149,7 @@ func (s *SQLStore) getNextNotificationHint(db sq.BaseRunner, remove bool) (*mode
 		OrderBy("notify_at").
 		Limit(1)
 
-	rows, err := selectQuery.Query()
+	rows, err := selectQuery.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("Cannot fetch next notification hint",
 			mlog.Err(err),
@@ -176,7 +176,7 @@ func (s *SQLStore) getNextNotificationHint(db sq.BaseRunner, remove bool) (*mode
 			Delete(s.tablePrefix + "notification_hints").
 			Where(sq.Eq{"block_id": hint.BlockID})
 
-		result, err := deleteQuery.Exec()
+		result, err := deleteQuery.ExecContext(s.context())
 		if err != nil {
 			return nil, fmt.Errorf("cannot delete while getting next notification hint: %w", err)
 		}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/mattermost/focalboard/server/services/secrets"
+
 	"github.com/mattermost/mattermost-server/v6/plugin"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -19,6 +21,11 @@ type Params struct {
 	NewMutexFn       MutexFactory
 	PluginAPI        *plugin.API
 	SkipTemplateInit bool
+	SkipMigrations   bool
+
+	// Secrets encrypts the secrets stored in the database; they are
+	// stored in plaintext if nil.
+	Secrets *secrets.Service
 }
 
 func (p Params) CheckValid() error {
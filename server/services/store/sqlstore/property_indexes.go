@@ -0,0 +1,313 @@
+package sqlstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// propertyIndexHash derives the name of the index of a card property
+// from its ID. The boards created from the same template share property
+// IDs, and so share their indexes, which include the board_id.
+func propertyIndexHash(propertyID string) string {
+	sum := sha256.Sum256([]byte(propertyID))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (s *SQLStore) propertyIndexName(propertyID string) string {
+	return s.tablePrefix + "idx_blocks_prop_" + propertyIndexHash(propertyID)
+}
+
+// propertyGeneratedColumn is the name of the generated column the
+// property indexes use on MySQL, which can't index expressions.
+func propertyGeneratedColumn(propertyID string) string {
+	return "prop_" + propertyIndexHash(propertyID)
+}
+
+// propertyValueExpression returns the SQL expression that extracts the
+// value of a card property from the fields of the blocks. On PostgreSQL
+// and SQLite it is the expression the index is defined on, so the
+// queries using it are served by the index.
+func (s *SQLStore) propertyValueExpression(propertyID string) string {
+	switch {
+	case s.isPostgresCompatible():
+		return fmt.Sprintf("(fields->'properties'->>'%s')", propertyID)
+	case s.dbType == model.MysqlDBType:
+		return fmt.Sprintf(`IF(JSON_VALID(fields), JSON_UNQUOTE(JSON_EXTRACT(fields, '$.properties."%s"')), NULL)`, propertyID)
+	default:
+		return fmt.Sprintf(`json_extract(fields, '$.properties."%s"')`, propertyID)
+	}
+}
+
+// createPropertyIndex indexes the values a card property of the board
+// takes. The index is created concurrently on PostgreSQL, so this must
+// not run in a transaction.
+func (s *SQLStore) createPropertyIndex(db sq.BaseRunner, boardID, propertyID string) error {
+	if !model.IsValidPropertyIndexID(propertyID) {
+		return model.NewCodedError(model.ErrCodeBadRequest, "invalid property ID", map[string]interface{}{"propertyId": propertyID})
+	}
+
+	var statements []string
+	indexName := s.propertyIndexName(propertyID)
+	blocksTable := s.tablePrefix + "blocks"
+	switch {
+	case s.isPostgresCompatible():
+		statements = append(statements, fmt.Sprintf("CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s (board_id, %s)",
+			indexName, blocksTable, s.propertyValueExpression(propertyID)))
+	case s.dbType == model.MysqlDBType:
+		exists, err := s.mysqlColumnExists(db, blocksTable, propertyGeneratedColumn(propertyID))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s VARCHAR(255) AS (LEFT(%s, 255)) VIRTUAL, ADD INDEX %s (board_id, %s)",
+				blocksTable, propertyGeneratedColumn(propertyID), s.propertyValueExpression(propertyID), indexName, propertyGeneratedColumn(propertyID)))
+		}
+	default:
+		statements = append(statements, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (board_id, %s)",
+			indexName, blocksTable, s.propertyValueExpression(propertyID)))
+	}
+
+	for _, statement := range statements {
+		if _, err := s.execContext(db, statement); err != nil {
+			s.logger.Error("createPropertyIndex ERROR", mlog.String("propertyID", propertyID), mlog.String("sql", statement), mlog.Err(err))
+			return err
+		}
+	}
+
+	indexes, err := s.getPropertyIndexes(db, boardID)
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if index.PropertyID == propertyID {
+			return nil
+		}
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"property_indexes").
+		Columns("board_id", "property_id", "create_at").
+		Values(boardID, propertyID, utils.GetMillis())
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("createPropertyIndex ERROR", mlog.String("boardID", boardID), mlog.String("propertyID", propertyID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// deletePropertyIndex removes the index of a card property of the board,
+// dropping it once no board uses it anymore.
+func (s *SQLStore) deletePropertyIndex(db sq.BaseRunner, boardID, propertyID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "property_indexes").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"property_id": propertyID})
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("deletePropertyIndex ERROR", mlog.String("boardID", boardID), mlog.String("propertyID", propertyID), mlog.Err(err))
+		return err
+	}
+
+	return s.dropUnusedPropertyIndex(db, propertyID)
+}
+
+// dropUnusedPropertyIndex drops the index of the card property if no
+// board uses it.
+func (s *SQLStore) dropUnusedPropertyIndex(db sq.BaseRunner, propertyID string) error {
+	if !model.IsValidPropertyIndexID(propertyID) {
+		return nil
+	}
+
+	var count int64
+	err := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "property_indexes").
+		Where(sq.Eq{"property_id": propertyID}).
+		QueryRowContext(s.context()).
+		Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var statement string
+	blocksTable := s.tablePrefix + "blocks"
+	switch {
+	case s.isPostgresCompatible():
+		statement = "DROP INDEX CONCURRENTLY IF EXISTS " + s.propertyIndexName(propertyID)
+	case s.dbType == model.MysqlDBType:
+		exists, err := s.mysqlColumnExists(db, blocksTable, propertyGeneratedColumn(propertyID))
+		if err != nil || !exists {
+			return err
+		}
+		// dropping the column drops its index
+		statement = fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", blocksTable, propertyGeneratedColumn(propertyID))
+	default:
+		statement = "DROP INDEX IF EXISTS " + s.propertyIndexName(propertyID)
+	}
+
+	if _, err := s.execContext(db, statement); err != nil {
+		s.logger.Error("dropUnusedPropertyIndex ERROR", mlog.String("propertyID", propertyID), mlog.String("sql", statement), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getPropertyIndexes returns the indexed card properties of the board.
+func (s *SQLStore) getPropertyIndexes(db sq.BaseRunner, boardID string) ([]*model.PropertyIndex, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select("board_id", "property_id", "create_at").
+		From(s.tablePrefix+"property_indexes").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "property_id").
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getPropertyIndexes ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	indexes := []*model.PropertyIndex{}
+	for rows.Next() {
+		var index model.PropertyIndex
+		if err := rows.Scan(&index.BoardID, &index.PropertyID, &index.CreateAt); err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, &index)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return indexes, nil
+}
+
+// getIndexedPropertyIDs returns the card properties indexed for at least
+// one board. Each of them has its own index on the blocks.
+func (s *SQLStore) getIndexedPropertyIDs(db sq.BaseRunner) ([]string, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select("DISTINCT property_id").
+		From(s.tablePrefix + "property_indexes").
+		OrderBy("property_id").
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getIndexedPropertyIDs ERROR", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	propertyIDs := []string{}
+	for rows.Next() {
+		var propertyID string
+		if err := rows.Scan(&propertyID); err != nil {
+			return nil, err
+		}
+		propertyIDs = append(propertyIDs, propertyID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return propertyIDs, nil
+}
+
+// getCardsWithPropertyValues returns the cards of the board whose
+// property takes one of the values. The query is served by the index of
+// the property, if it has one.
+func (s *SQLStore) getCardsWithPropertyValues(db sq.BaseRunner, boardID, propertyID string, values []string) ([]model.Block, error) {
+	if !model.IsValidPropertyIndexID(propertyID) {
+		return nil, model.NewCodedError(model.ErrCodeBadRequest, "invalid property ID", map[string]interface{}{"propertyId": propertyID})
+	}
+	if len(values) == 0 {
+		return []model.Block{}, nil
+	}
+
+	column := s.propertyValueExpression(propertyID)
+	if s.dbType == model.MysqlDBType {
+		exists, err := s.mysqlColumnExists(db, s.tablePrefix+"blocks", propertyGeneratedColumn(propertyID))
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			column = propertyGeneratedColumn(propertyID)
+		}
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix+"blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{column: values}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Eq{"delete_at": 0}).
+		OrderBy("insert_at", "id")
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getCardsWithPropertyValues ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+// deleteOrphanPropertyIndexes removes the property indexes of the boards
+// that were permanently deleted, dropping the indexes no board uses
+// anymore, and returns the number of removed rows.
+func (s *SQLStore) deleteOrphanPropertyIndexes(db sq.BaseRunner) (int64, error) {
+	table := s.tablePrefix + "property_indexes"
+	rows, err := s.getQueryBuilder(db).
+		Select("DISTINCT property_id").
+		From(table).
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]sboards WHERE %[1]sboards.id = %[2]s.board_id)", s.tablePrefix, table)).
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]sboards_history WHERE %[1]sboards_history.id = %[2]s.board_id)", s.tablePrefix, table)).
+		QueryContext(s.context())
+	if err != nil {
+		return 0, err
+	}
+	propertyIDs := []string{}
+	for rows.Next() {
+		var propertyID string
+		if err := rows.Scan(&propertyID); err != nil {
+			s.CloseRows(rows)
+			return 0, err
+		}
+		propertyIDs = append(propertyIDs, propertyID)
+	}
+	s.CloseRows(rows)
+
+	count, err := s.deleteBoardOrphans(db, "property_indexes")
+	if err != nil {
+		return 0, err
+	}
+
+	for _, propertyID := range propertyIDs {
+		if err := s.dropUnusedPropertyIndex(db, propertyID); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+func (s *SQLStore) mysqlColumnExists(db sq.BaseRunner, table, column string) (bool, error) {
+	var count int64
+	err := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From("information_schema.COLUMNS").
+		Where("TABLE_SCHEMA = DATABASE()").
+		Where(sq.Eq{"TABLE_NAME": table}).
+		Where(sq.Eq{"COLUMN_NAME": column}).
+		QueryRowContext(s.context()).
+		Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
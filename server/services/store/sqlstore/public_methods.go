@@ -13,7 +13,6 @@
 package sqlstore
 
 import (
-	"context"
 	"time"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -22,770 +21,2076 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+func (s *SQLStore) AcceptBoardInvitation(boardID string, userID string) (*model.BoardMember, error) {
+	if s.txRunner != nil {
+		return s.acceptBoardInvitation(s.txRunner, boardID, userID)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.acceptBoardInvitation(s.db, boardID, userID)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+
+		result, err := s.acceptBoardInvitation(tx, boardID, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "AcceptBoardInvitation"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "AcceptBoardInvitation") {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "AcceptBoardInvitation") {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+}
+
+func (s *SQLStore) AcquireBlockLock(lock *model.BlockLock) (*model.BlockLock, error) {
+	if s.txRunner != nil {
+		return s.acquireBlockLock(s.txRunner, lock)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.acquireBlockLock(s.db, lock)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+
+		result, err := s.acquireBlockLock(tx, lock)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "AcquireBlockLock"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "AcquireBlockLock") {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "AcquireBlockLock") {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+}
+
+func (s *SQLStore) AddBoardToTeam(boardID string, teamID string, userID string) error {
+	return s.addBoardToTeam(s.runner(), boardID, teamID, userID)
+
+}
+
 func (s *SQLStore) AddUpdateCategoryBoard(userID string, categoryID string, blockID string) error {
-	return s.addUpdateCategoryBoard(s.db, userID, categoryID, blockID)
+	return s.addUpdateCategoryBoard(s.runner(), userID, categoryID, blockID)
+
+}
+
+func (s *SQLStore) ApproveBoardAccessRequest(member *model.BoardMember) (*model.BoardMember, error) {
+	return s.approveBoardAccessRequest(s.runner(), member)
+
+}
+
+func (s *SQLStore) ClaimDeferredNotification(id string, claimedBy string, staleBefore int64) (bool, error) {
+	return s.claimDeferredNotification(s.runner(), id, claimedBy, staleBefore)
 
 }
 
 func (s *SQLStore) CleanUpSessions(expireTime int64) error {
-	return s.cleanUpSessions(s.db, expireTime)
+	return s.cleanUpSessions(s.runner(), expireTime)
+
+}
+
+func (s *SQLStore) CleanUpStaleSubscriptions() (int64, error) {
+	return s.cleanUpStaleSubscriptions(s.runner())
+
+}
+
+func (s *SQLStore) CreateBoardAccessRequest(request *model.BoardAccessRequest) (*model.BoardAccessRequest, error) {
+	return s.createBoardAccessRequest(s.runner(), request)
+
+}
+
+func (s *SQLStore) CreateBoardInvitation(invitation *model.BoardInvitation) (*model.BoardInvitation, error) {
+	if s.txRunner != nil {
+		return s.createBoardInvitation(s.txRunner, invitation)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.createBoardInvitation(s.db, invitation)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+
+		result, err := s.createBoardInvitation(tx, invitation)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardInvitation"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "CreateBoardInvitation") {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "CreateBoardInvitation") {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
 
 }
 
 func (s *SQLStore) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	if s.txRunner != nil {
+		return s.createBoardsAndBlocks(s.txRunner, bab, userID)
+	}
 	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
 		return s.createBoardsAndBlocks(s.db, bab, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, txErr
-	}
-	result, err := s.createBoardsAndBlocks(tx, bab, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocks"))
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
 		}
-		return nil, err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
+		result, err := s.createBoardsAndBlocks(tx, bab, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocks"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "CreateBoardsAndBlocks") {
+				continue
+			}
+			return nil, err
+		}
 
-	return result, nil
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "CreateBoardsAndBlocks") {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
 
 }
 
 func (s *SQLStore) CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	if s.txRunner != nil {
+		return s.createBoardsAndBlocksWithAdmin(s.txRunner, bab, userID)
+	}
 	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
 		return s.createBoardsAndBlocksWithAdmin(s.db, bab, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, nil, txErr
-	}
-	result, resultVar1, err := s.createBoardsAndBlocksWithAdmin(tx, bab, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocksWithAdmin"))
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, nil, txErr
 		}
-		return nil, nil, err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, nil, err
-	}
+		result, resultVar1, err := s.createBoardsAndBlocksWithAdmin(tx, bab, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocksWithAdmin"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "CreateBoardsAndBlocksWithAdmin") {
+				continue
+			}
+			return nil, nil, err
+		}
 
-	return result, resultVar1, nil
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "CreateBoardsAndBlocksWithAdmin") {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		return result, resultVar1, nil
+	}
 
 }
 
 func (s *SQLStore) CreateCategory(category model.Category) error {
-	return s.createCategory(s.db, category)
+	return s.createCategory(s.runner(), category)
+
+}
+
+func (s *SQLStore) CreateCategoryRule(rule *model.CategoryRule) error {
+	return s.createCategoryRule(s.runner(), rule)
+
+}
+
+func (s *SQLStore) CreatePropertyIndex(boardID string, propertyID string) error {
+	return s.createPropertyIndex(s.runner(), boardID, propertyID)
 
 }
 
 func (s *SQLStore) CreateSession(session *model.Session) error {
-	return s.createSession(s.db, session)
+	return s.createSession(s.runner(), session)
 
 }
 
 func (s *SQLStore) CreateSubscription(sub *model.Subscription) (*model.Subscription, error) {
-	return s.createSubscription(s.db, sub)
+	return s.createSubscription(s.runner(), sub)
 
 }
 
 func (s *SQLStore) CreateUser(user *model.User) error {
-	return s.createUser(s.db, user)
+	return s.createUser(s.runner(), user)
 
 }
 
 func (s *SQLStore) DeleteBlock(blockID string, modifiedBy string) error {
+	if s.txRunner != nil {
+		return s.deleteBlock(s.txRunner, blockID, modifiedBy)
+	}
 	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
 		return s.deleteBlock(s.db, blockID, modifiedBy)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.deleteBlock(tx, blockID, modifiedBy)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBlock"))
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
 		}
-		return err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+		err := s.deleteBlock(tx, blockID, modifiedBy)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBlock"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "DeleteBlock") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "DeleteBlock") {
+				continue
+			}
+			return err
+		}
 
-	return nil
+		return nil
+	}
 
 }
 
 func (s *SQLStore) DeleteBoard(boardID string, userID string) error {
+	if s.txRunner != nil {
+		return s.deleteBoard(s.txRunner, boardID, userID)
+	}
 	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
 		return s.deleteBoard(s.db, boardID, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.deleteBoard(tx, boardID, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoard"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "DeleteBoard") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "DeleteBoard") {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+}
+
+func (s *SQLStore) DeleteBoardAccessRequest(boardID string, userID string, action string) error {
+	if s.txRunner != nil {
+		return s.deleteBoardAccessRequest(s.txRunner, boardID, userID, action)
 	}
-	err := s.deleteBoard(tx, boardID, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoard"))
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.deleteBoardAccessRequest(s.db, boardID, userID, action)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.deleteBoardAccessRequest(tx, boardID, userID, action)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardAccessRequest"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "DeleteBoardAccessRequest") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "DeleteBoardAccessRequest") {
+				continue
+			}
+			return err
 		}
-		return err
+
+		return nil
+	}
+
+}
+
+func (s *SQLStore) DeleteBoardFreezeSchedule(boardID string) error {
+	return s.deleteBoardFreezeSchedule(s.runner(), boardID)
+
+}
+
+func (s *SQLStore) DeleteBoardInvitation(boardID string, userID string, action string) error {
+	if s.txRunner != nil {
+		return s.deleteBoardInvitation(s.txRunner, boardID, userID, action)
 	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.deleteBoardInvitation(s.db, boardID, userID, action)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.deleteBoardInvitation(tx, boardID, userID, action)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardInvitation"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "DeleteBoardInvitation") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "DeleteBoardInvitation") {
+				continue
+			}
+			return err
+		}
 
-	if err := tx.Commit(); err != nil {
-		return err
+		return nil
 	}
 
-	return nil
+}
+
+func (s *SQLStore) DeleteBoardWebhook(id string) error {
+	return s.deleteBoardWebhook(s.runner(), id)
 
 }
 
 func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error {
+	if s.txRunner != nil {
+		return s.deleteBoardsAndBlocks(s.txRunner, dbab, userID)
+	}
 	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
 		return s.deleteBoardsAndBlocks(s.db, dbab, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.deleteBoardsAndBlocks(tx, dbab, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardsAndBlocks"))
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.deleteBoardsAndBlocks(tx, dbab, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardsAndBlocks"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "DeleteBoardsAndBlocks") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "DeleteBoardsAndBlocks") {
+				continue
+			}
+			return err
 		}
-		return err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
+		return nil
 	}
 
-	return nil
+}
+
+func (s *SQLStore) DeleteCalendarSubscription(boardID string) error {
+	return s.deleteCalendarSubscription(s.runner(), boardID)
 
 }
 
 func (s *SQLStore) DeleteCategory(categoryID string, userID string, teamID string) error {
-	return s.deleteCategory(s.db, categoryID, userID, teamID)
+	return s.deleteCategory(s.runner(), categoryID, userID, teamID)
+
+}
+
+func (s *SQLStore) DeleteCategoryRule(ruleID string, userID string) error {
+	return s.deleteCategoryRule(s.runner(), ruleID, userID)
+
+}
+
+func (s *SQLStore) DeleteDeferredNotification(id string) error {
+	return s.deleteDeferredNotification(s.runner(), id)
+
+}
+
+func (s *SQLStore) DeleteGitHubIntegration(boardID string) error {
+	return s.deleteGitHubIntegration(s.runner(), boardID)
 
 }
 
 func (s *SQLStore) DeleteMember(boardID string, userID string) error {
-	return s.deleteMember(s.db, boardID, userID)
+	return s.deleteMember(s.runner(), boardID, userID)
 
 }
 
 func (s *SQLStore) DeleteNotificationHint(blockID string) error {
-	return s.deleteNotificationHint(s.db, blockID)
+	return s.deleteNotificationHint(s.runner(), blockID)
+
+}
+
+func (s *SQLStore) DeletePropertyIndex(boardID string, propertyID string) error {
+	return s.deletePropertyIndex(s.runner(), boardID, propertyID)
 
 }
 
 func (s *SQLStore) DeleteSession(sessionID string) error {
-	return s.deleteSession(s.db, sessionID)
+	return s.deleteSession(s.runner(), sessionID)
+
+}
+
+func (s *SQLStore) DeleteSessionsForUser(userID string) error {
+	return s.deleteSessionsForUser(s.runner(), userID)
 
 }
 
 func (s *SQLStore) DeleteSubscription(blockID string, subscriberID string) error {
-	return s.deleteSubscription(s.db, blockID, subscriberID)
+	return s.deleteSubscription(s.runner(), blockID, subscriberID)
+
+}
+
+func (s *SQLStore) DeleteWebhookDelivery(id string) error {
+	return s.deleteWebhookDelivery(s.runner(), id)
 
 }
 
 func (s *SQLStore) DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]model.Block, error) {
+	if s.txRunner != nil {
+		return s.duplicateBlock(s.txRunner, boardID, blockID, userID, asTemplate)
+	}
 	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
 		return s.duplicateBlock(s.db, boardID, blockID, userID, asTemplate)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, txErr
-	}
-	result, err := s.duplicateBlock(tx, boardID, blockID, userID, asTemplate)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBlock"))
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
 		}
-		return nil, err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
+		result, err := s.duplicateBlock(tx, boardID, blockID, userID, asTemplate)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBlock"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "DuplicateBlock") {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "DuplicateBlock") {
+				continue
+			}
+			return nil, err
+		}
 
-	return result, nil
+		return result, nil
+	}
 
 }
 
-func (s *SQLStore) DuplicateBoard(boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
-	if s.dbType == model.SqliteDBType {
-		return s.duplicateBoard(s.db, boardID, userID, toTeam, asTemplate)
-	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, nil, txErr
+func (s *SQLStore) DuplicateBoard(boardID string, userID string, toTeam string, asTemplate bool, userMapping model.UserMapping) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	if s.txRunner != nil {
+		return s.duplicateBoard(s.txRunner, boardID, userID, toTeam, asTemplate, userMapping)
 	}
-	result, resultVar1, err := s.duplicateBoard(tx, boardID, userID, toTeam, asTemplate)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBoard"))
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.duplicateBoard(s.db, boardID, userID, toTeam, asTemplate, userMapping)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, nil, txErr
 		}
-		return nil, nil, err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, nil, err
+		result, resultVar1, err := s.duplicateBoard(tx, boardID, userID, toTeam, asTemplate, userMapping)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBoard"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "DuplicateBoard") {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "DuplicateBoard") {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		return result, resultVar1, nil
 	}
 
-	return result, resultVar1, nil
+}
+
+func (s *SQLStore) GetAccessRequestsForBoard(boardID string) ([]*model.BoardAccessRequest, error) {
+	return s.getAccessRequestsForBoard(s.runner(), boardID)
 
 }
 
 func (s *SQLStore) GetActiveUserCount(updatedSecondsAgo int64) (int, error) {
-	return s.getActiveUserCount(s.db, updatedSecondsAgo)
+	return s.getActiveUserCount(s.runner(), updatedSecondsAgo)
 
 }
 
 func (s *SQLStore) GetAllTeams() ([]*model.Team, error) {
-	return s.getAllTeams(s.db)
+	return s.getAllTeams(s.runner())
 
 }
 
 func (s *SQLStore) GetBlock(blockID string) (*model.Block, error) {
-	return s.getBlock(s.db, blockID)
+	return s.getBlock(s.runner(), blockID)
 
 }
 
 func (s *SQLStore) GetBlockCountsByType() (map[string]int64, error) {
-	return s.getBlockCountsByType(s.db)
+	return s.getBlockCountsByType(s.runner())
 
 }
 
 func (s *SQLStore) GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error) {
-	return s.getBlockHistory(s.db, blockID, opts)
+	return s.getBlockHistory(s.runner(), blockID, opts)
 
 }
 
 func (s *SQLStore) GetBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error) {
-	return s.getBlockHistoryDescendants(s.db, boardID, opts)
+	return s.getBlockHistoryDescendants(s.runner(), boardID, opts)
+
+}
+
+func (s *SQLStore) GetBlockLock(blockID string) (*model.BlockLock, error) {
+	return s.getBlockLock(s.runner(), blockID)
+
+}
+
+func (s *SQLStore) GetBlockLocksForBoard(boardID string, now int64) ([]*model.BlockLock, error) {
+	return s.getBlockLocksForBoard(s.runner(), boardID, now)
+
+}
+
+func (s *SQLStore) GetBlocksByIDs(ids []string) ([]model.Block, error) {
+	return s.getBlocksByIDs(s.runner(), ids)
+
+}
+
+func (s *SQLStore) GetBlocksChangedSince(boardID string, since int64) ([]model.Block, error) {
+	return s.getBlocksChangedSince(s.runner(), boardID, since)
 
 }
 
 func (s *SQLStore) GetBlocksForBoard(boardID string) ([]model.Block, error) {
-	return s.getBlocksForBoard(s.db, boardID)
+	return s.getBlocksForBoard(s.runner(), boardID)
 
 }
 
 func (s *SQLStore) GetBlocksWithBoardID(boardID string) ([]model.Block, error) {
-	return s.getBlocksWithBoardID(s.db, boardID)
+	return s.getBlocksWithBoardID(s.runner(), boardID)
 
 }
 
 func (s *SQLStore) GetBlocksWithParent(boardID string, parentID string) ([]model.Block, error) {
-	return s.getBlocksWithParent(s.db, boardID, parentID)
+	return s.getBlocksWithParent(s.runner(), boardID, parentID)
 
 }
 
 func (s *SQLStore) GetBlocksWithParentAndType(boardID string, parentID string, blockType string) ([]model.Block, error) {
-	return s.getBlocksWithParentAndType(s.db, boardID, parentID, blockType)
+	return s.getBlocksWithParentAndType(s.runner(), boardID, parentID, blockType)
 
 }
 
 func (s *SQLStore) GetBlocksWithType(boardID string, blockType string) ([]model.Block, error) {
-	return s.getBlocksWithType(s.db, boardID, blockType)
+	return s.getBlocksWithType(s.runner(), boardID, blockType)
 
 }
 
 func (s *SQLStore) GetBoard(id string) (*model.Board, error) {
-	return s.getBoard(s.db, id)
+	return s.getBoard(s.runner(), id)
+
+}
+
+func (s *SQLStore) GetBoardAccessRequest(boardID string, userID string) (*model.BoardAccessRequest, error) {
+	return s.getBoardAccessRequest(s.runner(), boardID, userID)
 
 }
 
 func (s *SQLStore) GetBoardAndCard(block *model.Block) (*model.Board, *model.Block, error) {
-	return s.getBoardAndCard(s.db, block)
+	return s.getBoardAndCard(s.runner(), block)
 
 }
 
 func (s *SQLStore) GetBoardAndCardByID(blockID string) (*model.Board, *model.Block, error) {
-	return s.getBoardAndCardByID(s.db, blockID)
+	return s.getBoardAndCardByID(s.runner(), blockID)
+
+}
+
+func (s *SQLStore) GetBoardCount() (int64, error) {
+	return s.getBoardCount(s.runner())
+
+}
+
+func (s *SQLStore) GetBoardFreezeSchedule(boardID string) (*model.BoardFreezeSchedule, error) {
+	return s.getBoardFreezeSchedule(s.runner(), boardID)
 
 }
 
 func (s *SQLStore) GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
-	return s.getBoardHistory(s.db, boardID, opts)
+	return s.getBoardHistory(s.runner(), boardID, opts)
 
 }
 
-func (s *SQLStore) GetBoardMemberHistory(boardID string, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
-	return s.getBoardMemberHistory(s.db, boardID, userID, limit)
+func (s *SQLStore) GetBoardIDForKey(key string) (string, error) {
+	return s.getBoardIDForKey(s.runner(), key)
 
 }
 
-func (s *SQLStore) GetBoardsForUserAndTeam(userID string, teamID string) ([]*model.Board, error) {
-	return s.getBoardsForUserAndTeam(s.db, userID, teamID)
+func (s *SQLStore) GetBoardIDsForTeam(teamID string) ([]string, error) {
+	return s.getBoardIDsForTeam(s.runner(), teamID)
 
 }
 
-func (s *SQLStore) GetCategory(id string) (*model.Category, error) {
-	return s.getCategory(s.db, id)
+func (s *SQLStore) GetBoardInvitation(boardID string, userID string) (*model.BoardInvitation, error) {
+	return s.getBoardInvitation(s.runner(), boardID, userID)
 
 }
 
-func (s *SQLStore) GetLicense() *mmModel.License {
-	return s.getLicense(s.db)
+func (s *SQLStore) GetBoardKey(boardID string) (string, error) {
+	return s.getBoardKey(s.runner(), boardID)
 
 }
 
-func (s *SQLStore) GetMemberForBoard(boardID string, userID string) (*model.BoardMember, error) {
-	return s.getMemberForBoard(s.db, boardID, userID)
+func (s *SQLStore) GetBoardMemberActivity(boardID string) ([]*model.BoardMemberActivity, error) {
+	return s.getBoardMemberActivity(s.runner(), boardID)
 
 }
 
-func (s *SQLStore) GetMembersForBoard(boardID string) ([]*model.BoardMember, error) {
-	return s.getMembersForBoard(s.db, boardID)
+func (s *SQLStore) GetBoardMemberHistory(boardID string, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
+	return s.getBoardMemberHistory(s.runner(), boardID, userID, limit)
 
 }
 
-func (s *SQLStore) GetMembersForUser(userID string) ([]*model.BoardMember, error) {
-	return s.getMembersForUser(s.db, userID)
+func (s *SQLStore) GetBoardSnapshots(boardID string, fromDay string, toDay string) ([]*model.BoardSnapshot, error) {
+	return s.getBoardSnapshots(s.runner(), boardID, fromDay, toDay)
 
 }
 
-func (s *SQLStore) GetNextNotificationHint(remove bool) (*model.NotificationHint, error) {
-	return s.getNextNotificationHint(s.db, remove)
+func (s *SQLStore) GetBoardWebhook(id string) (*model.BoardWebhook, error) {
+	return s.getBoardWebhook(s.runner(), id)
 
 }
 
-func (s *SQLStore) GetNotificationHint(blockID string) (*model.NotificationHint, error) {
-	return s.getNotificationHint(s.db, blockID)
+func (s *SQLStore) GetBoardWebhooksForBoard(boardID string) ([]*model.BoardWebhook, error) {
+	return s.getBoardWebhooksForBoard(s.runner(), boardID)
 
 }
 
-func (s *SQLStore) GetRegisteredUserCount() (int, error) {
-	return s.getRegisteredUserCount(s.db)
+func (s *SQLStore) GetBoardsForChannel(channelID string) ([]*model.Board, error) {
+	return s.getBoardsForChannel(s.runner(), channelID)
 
 }
 
-func (s *SQLStore) GetSession(token string, expireTime int64) (*model.Session, error) {
-	return s.getSession(s.db, token, expireTime)
+func (s *SQLStore) GetBoardsForUserAndTeam(userID string, teamID string) ([]*model.Board, error) {
+	return s.getBoardsForUserAndTeam(s.runner(), userID, teamID)
 
 }
 
-func (s *SQLStore) GetSharing(rootID string) (*model.Sharing, error) {
-	return s.getSharing(s.db, rootID)
+func (s *SQLStore) GetCalendarSubscription(boardID string) (*model.CalendarSubscription, error) {
+	return s.getCalendarSubscription(s.runner(), boardID)
 
 }
 
-func (s *SQLStore) GetSubTree2(boardID string, blockID string, opts model.QuerySubtreeOptions) ([]model.Block, error) {
-	return s.getSubTree2(s.db, boardID, blockID, opts)
+func (s *SQLStore) GetCardGroups(boardID string, groupByPropertyID string, sumPropertyID string) ([]*model.CardGroup, error) {
+	return s.getCardGroups(s.runner(), boardID, groupByPropertyID, sumPropertyID)
 
 }
 
-func (s *SQLStore) GetSubscribersCountForBlock(blockID string) (int, error) {
-	return s.getSubscribersCountForBlock(s.db, blockID)
+func (s *SQLStore) GetCardIDForNumber(boardID string, number int64) (string, error) {
+	return s.getCardIDForNumber(s.runner(), boardID, number)
 
 }
 
-func (s *SQLStore) GetSubscribersForBlock(blockID string) ([]*model.Subscriber, error) {
-	return s.getSubscribersForBlock(s.db, blockID)
+func (s *SQLStore) GetCardUserIDs(boardID string) ([]string, error) {
+	return s.getCardUserIDs(s.runner(), boardID)
 
 }
 
-func (s *SQLStore) GetSubscription(blockID string, subscriberID string) (*model.Subscription, error) {
-	return s.getSubscription(s.db, blockID, subscriberID)
+func (s *SQLStore) GetCardsStatusSince(boardID string, propertyID string, cardIDs []string) (map[string]int64, error) {
+	return s.getCardsStatusSince(s.runner(), boardID, propertyID, cardIDs)
 
 }
 
-func (s *SQLStore) GetSubscriptions(subscriberID string) ([]*model.Subscription, error) {
-	return s.getSubscriptions(s.db, subscriberID)
+func (s *SQLStore) GetCardsUpdatedBefore(boardID string, before int64) ([]model.Block, error) {
+	return s.getCardsUpdatedBefore(s.runner(), boardID, before)
 
 }
 
-func (s *SQLStore) GetSystemSetting(key string) (string, error) {
-	return s.getSystemSetting(s.db, key)
+func (s *SQLStore) GetCardsWithFieldValue(boardIDs []string, value string) ([]model.Block, error) {
+	return s.getCardsWithFieldValue(s.runner(), boardIDs, value)
 
 }
 
-func (s *SQLStore) GetSystemSettings() (map[string]string, error) {
-	return s.getSystemSettings(s.db)
+func (s *SQLStore) GetCardsWithPropertyValues(boardID string, propertyID string, values []string) ([]model.Block, error) {
+	return s.getCardsWithPropertyValues(s.runner(), boardID, propertyID, values)
 
 }
 
-func (s *SQLStore) GetTeam(ID string) (*model.Team, error) {
-	return s.getTeam(s.db, ID)
+func (s *SQLStore) GetCategory(id string) (*model.Category, error) {
+	return s.getCategory(s.runner(), id)
 
 }
 
-func (s *SQLStore) GetTeamCount() (int64, error) {
-	return s.getTeamCount(s.db)
+func (s *SQLStore) GetCategoryRules(userID string, teamID string) ([]*model.CategoryRule, error) {
+	return s.getCategoryRules(s.runner(), userID, teamID)
 
 }
 
-func (s *SQLStore) GetTeamsForUser(userID string) ([]*model.Team, error) {
-	return s.getTeamsForUser(s.db, userID)
+func (s *SQLStore) GetChannel(channelID string) (*mmModel.Channel, error) {
+	return s.getChannel(s.runner(), channelID)
 
 }
 
-func (s *SQLStore) GetTemplateBoards(teamID string, userID string) ([]*model.Board, error) {
-	return s.getTemplateBoards(s.db, teamID, userID)
+func (s *SQLStore) GetChannelMemberIDs(channelID string) ([]string, error) {
+	return s.getChannelMemberIDs(s.runner(), channelID)
 
 }
 
-func (s *SQLStore) GetUserByEmail(email string) (*model.User, error) {
-	return s.getUserByEmail(s.db, email)
+func (s *SQLStore) GetDeadWebhookDeliveries(page int, perPage int) ([]*model.WebhookDelivery, error) {
+	return s.getDeadWebhookDeliveries(s.runner(), page, perPage)
 
 }
 
-func (s *SQLStore) GetUserByID(userID string) (*model.User, error) {
-	return s.getUserByID(s.db, userID)
+func (s *SQLStore) GetDeferredNotificationsForUser(userID string) ([]*model.DeferredNotification, error) {
+	return s.getDeferredNotificationsForUser(s.runner(), userID)
 
 }
 
-func (s *SQLStore) GetUserByUsername(username string) (*model.User, error) {
-	return s.getUserByUsername(s.db, username)
+func (s *SQLStore) GetDeletedBlockIDsSince(boardID string, since int64) ([]string, error) {
+	return s.getDeletedBlockIDsSince(s.runner(), boardID, since)
 
 }
 
-func (s *SQLStore) GetUserCategoryBoards(userID string, teamID string) ([]model.CategoryBoards, error) {
-	return s.getUserCategoryBoards(s.db, userID, teamID)
+func (s *SQLStore) GetDeletedMembersForBoard(boardID string) ([]*model.BoardMember, error) {
+	return s.getDeletedMembersForBoard(s.runner(), boardID)
 
 }
 
-func (s *SQLStore) GetUsersByTeam(teamID string) ([]*model.User, error) {
-	return s.getUsersByTeam(s.db, teamID)
+func (s *SQLStore) GetDueBoardFreezeSchedules(now int64) ([]*model.BoardFreezeSchedule, error) {
+	return s.getDueBoardFreezeSchedules(s.runner(), now)
 
 }
 
-func (s *SQLStore) InsertBlock(block *model.Block, userID string) error {
-	if s.dbType == model.SqliteDBType {
-		return s.insertBlock(s.db, block, userID)
-	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.insertBlock(tx, block, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlock"))
-		}
-		return err
-	}
+func (s *SQLStore) GetDueCalendarSubscriptions(syncedBefore int64) ([]*model.CalendarSubscription, error) {
+	return s.getDueCalendarSubscriptions(s.runner(), syncedBefore)
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+}
 
-	return nil
+func (s *SQLStore) GetDueDigestSettings(userID string) (*model.DueDigestSettings, error) {
+	return s.getDueDigestSettings(s.runner(), userID)
 
 }
 
-func (s *SQLStore) InsertBlocks(blocks []model.Block, userID string) error {
-	if s.dbType == model.SqliteDBType {
-		return s.insertBlocks(s.db, blocks, userID)
-	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.insertBlocks(tx, blocks, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlocks"))
-		}
-		return err
-	}
+func (s *SQLStore) GetDueWebhookDeliveries(now int64, limit uint64) ([]*model.WebhookDelivery, error) {
+	return s.getDueWebhookDeliveries(s.runner(), now, limit)
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+}
 
-	return nil
+func (s *SQLStore) GetEnabledDueDigestSettings() ([]*model.DueDigestSettings, error) {
+	return s.getEnabledDueDigestSettings(s.runner())
 
 }
 
-func (s *SQLStore) InsertBoard(board *model.Board, userID string) (*model.Board, error) {
-	return s.insertBoard(s.db, board, userID)
+func (s *SQLStore) GetEnabledStaleDigestSettings() ([]*model.StaleDigestSettings, error) {
+	return s.getEnabledStaleDigestSettings(s.runner())
 
 }
 
-func (s *SQLStore) InsertBoardWithAdmin(board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
-	if s.dbType == model.SqliteDBType {
-		return s.insertBoardWithAdmin(s.db, board, userID)
-	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, nil, txErr
-	}
-	result, resultVar1, err := s.insertBoardWithAdmin(tx, board, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBoardWithAdmin"))
-		}
-		return nil, nil, err
-	}
+func (s *SQLStore) GetGitHubIntegration(boardID string) (*model.GitHubIntegration, error) {
+	return s.getGitHubIntegration(s.runner(), boardID)
 
-	if err := tx.Commit(); err != nil {
-		return nil, nil, err
-	}
+}
 
-	return result, resultVar1, nil
+func (s *SQLStore) GetHistoryStatsByTeam() ([]*model.TeamHistoryStats, error) {
+	return s.getHistoryStatsByTeam(s.runner())
 
 }
 
-func (s *SQLStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error {
-	if s.dbType == model.SqliteDBType {
-		return s.patchBlock(s.db, blockID, blockPatch, userID)
-	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.patchBlock(tx, blockID, blockPatch, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlock"))
-		}
-		return err
-	}
+func (s *SQLStore) GetIndexedPropertyIDs() ([]string, error) {
+	return s.getIndexedPropertyIDs(s.runner())
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+}
 
-	return nil
+func (s *SQLStore) GetInvitationsForBoard(boardID string) ([]*model.BoardInvitation, error) {
+	return s.getInvitationsForBoard(s.runner(), boardID)
 
 }
 
-func (s *SQLStore) PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error {
-	if s.dbType == model.SqliteDBType {
-		return s.patchBlocks(s.db, blockPatches, userID)
-	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.patchBlocks(tx, blockPatches, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlocks"))
-		}
-		return err
-	}
+func (s *SQLStore) GetInvitationsForUser(userID string) ([]*model.BoardInvitation, error) {
+	return s.getInvitationsForUser(s.runner(), userID)
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+}
 
-	return nil
+func (s *SQLStore) GetLicense() *mmModel.License {
+	return s.getLicense(s.runner())
 
 }
 
-func (s *SQLStore) PatchBoard(boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error) {
+func (s *SQLStore) GetMemberForBoard(boardID string, userID string) (*model.BoardMember, error) {
+	return s.getMemberForBoard(s.runner(), boardID, userID)
+
+}
+
+func (s *SQLStore) GetMembersForBoard(boardID string) ([]*model.BoardMember, error) {
+	return s.getMembersForBoard(s.runner(), boardID)
+
+}
+
+func (s *SQLStore) GetMembersForUser(userID string) ([]*model.BoardMember, error) {
+	return s.getMembersForUser(s.runner(), userID)
+
+}
+
+func (s *SQLStore) GetMentionsForUser(userID string, opts model.QueryMentionsOptions) ([]*model.Mention, error) {
+	return s.getMentionsForUser(s.runner(), userID, opts)
+
+}
+
+func (s *SQLStore) GetMigrationStatus(includeSQL bool) (*model.MigrationStatus, error) {
+	return s.getMigrationStatus(s.runner(), includeSQL)
+
+}
+
+func (s *SQLStore) GetNextNotificationHint(remove bool) (*model.NotificationHint, error) {
+	return s.getNextNotificationHint(s.runner(), remove)
+
+}
+
+func (s *SQLStore) GetNonTemplateBoardIDs() ([]string, error) {
+	return s.getNonTemplateBoardIDs(s.runner())
+
+}
+
+func (s *SQLStore) GetNotificationHint(blockID string) (*model.NotificationHint, error) {
+	return s.getNotificationHint(s.runner(), blockID)
+
+}
+
+func (s *SQLStore) GetPropertyIndexes(boardID string) ([]*model.PropertyIndex, error) {
+	return s.getPropertyIndexes(s.runner(), boardID)
+
+}
+
+func (s *SQLStore) GetRecentBoardsForUser(userID string, teamID string, limit uint64) ([]*model.Board, error) {
+	return s.getRecentBoardsForUser(s.runner(), userID, teamID, limit)
+
+}
+
+func (s *SQLStore) GetRegisteredUserCount() (int, error) {
+	return s.getRegisteredUserCount(s.runner())
+
+}
+
+func (s *SQLStore) GetSession(token string, expireTime int64) (*model.Session, error) {
+	return s.getSession(s.runner(), token, expireTime)
+
+}
+
+func (s *SQLStore) GetSharedTeamIDsForBoard(boardID string) ([]string, error) {
+	return s.getSharedTeamIDsForBoard(s.runner(), boardID)
+
+}
+
+func (s *SQLStore) GetSharing(rootID string) (*model.Sharing, error) {
+	return s.getSharing(s.runner(), rootID)
+
+}
+
+func (s *SQLStore) GetStaleDigestSettings(userID string) (*model.StaleDigestSettings, error) {
+	return s.getStaleDigestSettings(s.runner(), userID)
+
+}
+
+func (s *SQLStore) GetSubTree2(boardID string, blockID string, opts model.QuerySubtreeOptions) ([]model.Block, error) {
+	return s.getSubTree2(s.runner(), boardID, blockID, opts)
+
+}
+
+func (s *SQLStore) GetSubscribersCountForBlock(blockID string) (int, error) {
+	return s.getSubscribersCountForBlock(s.runner(), blockID)
+
+}
+
+func (s *SQLStore) GetSubscribersForBlock(blockID string) ([]*model.Subscriber, error) {
+	return s.getSubscribersForBlock(s.runner(), blockID)
+
+}
+
+func (s *SQLStore) GetSubscription(blockID string, subscriberID string) (*model.Subscription, error) {
+	return s.getSubscription(s.runner(), blockID, subscriberID)
+
+}
+
+func (s *SQLStore) GetSubscriptions(subscriberID string, opts model.QuerySubscriptionsOptions) ([]*model.Subscription, error) {
+	return s.getSubscriptions(s.runner(), subscriberID, opts)
+
+}
+
+func (s *SQLStore) GetSwimlaneCells(boardID string, rowPropertyID string, columnPropertyID string) ([]*model.SwimlaneCell, error) {
+	return s.getSwimlaneCells(s.runner(), boardID, rowPropertyID, columnPropertyID)
+
+}
+
+func (s *SQLStore) GetSystemSetting(key string) (string, error) {
+	return s.getSystemSetting(s.runner(), key)
+
+}
+
+func (s *SQLStore) GetSystemSettings() (map[string]string, error) {
+	return s.getSystemSettings(s.runner())
+
+}
+
+func (s *SQLStore) GetTableStats() ([]*model.TableStats, error) {
+	return s.getTableStats(s.runner())
+
+}
+
+func (s *SQLStore) GetTeam(ID string) (*model.Team, error) {
+	return s.getTeam(s.runner(), ID)
+
+}
+
+func (s *SQLStore) GetTeamCount() (int64, error) {
+	return s.getTeamCount(s.runner())
+
+}
+
+func (s *SQLStore) GetTeamsForUser(userID string) ([]*model.Team, error) {
+	return s.getTeamsForUser(s.runner(), userID)
+
+}
+
+func (s *SQLStore) GetTemplateBoards(teamID string, userID string) ([]*model.Board, error) {
+	return s.getTemplateBoards(s.runner(), teamID, userID)
+
+}
+
+func (s *SQLStore) GetUserByEmail(email string) (*model.User, error) {
+	return s.getUserByEmail(s.runner(), email)
+
+}
+
+func (s *SQLStore) GetUserByID(userID string) (*model.User, error) {
+	return s.getUserByID(s.runner(), userID)
+
+}
+
+func (s *SQLStore) GetUserByUsername(username string) (*model.User, error) {
+	return s.getUserByUsername(s.runner(), username)
+
+}
+
+func (s *SQLStore) GetUserCategories(userID string, teamID string) ([]model.Category, error) {
+	return s.getUserCategories(s.runner(), userID, teamID)
+
+}
+
+func (s *SQLStore) GetUserCategoryBoards(userID string, teamID string) ([]model.CategoryBoards, error) {
+	return s.getUserCategoryBoards(s.runner(), userID, teamID)
+
+}
+
+func (s *SQLStore) GetUsersByIDs(userIDs []string) ([]*model.User, error) {
+	return s.getUsersByIDs(s.runner(), userIDs)
+
+}
+
+func (s *SQLStore) GetUsersByTeam(teamID string) ([]*model.User, error) {
+	return s.getUsersByTeam(s.runner(), teamID)
+
+}
+
+func (s *SQLStore) GetUsersWithDeferredNotifications() ([]string, error) {
+	return s.getUsersWithDeferredNotifications(s.runner())
+
+}
+
+func (s *SQLStore) GetViewCards(boardID string, opts model.QueryViewCardsOptions) ([]model.Block, int, error) {
+	return s.getViewCards(s.runner(), boardID, opts)
+
+}
+
+func (s *SQLStore) GetWebhookDelivery(id string) (*model.WebhookDelivery, error) {
+	return s.getWebhookDelivery(s.runner(), id)
+
+}
+
+func (s *SQLStore) InsertBlock(block *model.Block, userID string) error {
+	if s.txRunner != nil {
+		return s.insertBlock(s.txRunner, block, userID)
+	}
 	if s.dbType == model.SqliteDBType {
-		return s.patchBoard(s.db, boardID, boardPatch, userID)
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.insertBlock(s.db, block, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, txErr
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.insertBlock(tx, block, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlock"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "InsertBlock") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "InsertBlock") {
+				continue
+			}
+			return err
+		}
+
+		return nil
 	}
-	result, err := s.patchBoard(tx, boardID, boardPatch, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoard"))
+
+}
+
+func (s *SQLStore) InsertBlocks(blocks []model.Block, userID string) error {
+	if s.txRunner != nil {
+		return s.insertBlocks(s.txRunner, blocks, userID)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.insertBlocks(s.db, blocks, userID)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
 		}
-		return nil, err
+
+		err := s.insertBlocks(tx, blocks, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlocks"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "InsertBlocks") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "InsertBlocks") {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+}
+
+func (s *SQLStore) InsertBoard(board *model.Board, userID string) (*model.Board, error) {
+	return s.insertBoard(s.runner(), board, userID)
+
+}
+
+func (s *SQLStore) InsertBoardWebhook(webhook *model.BoardWebhook) error {
+	return s.insertBoardWebhook(s.runner(), webhook)
+
+}
+
+func (s *SQLStore) InsertBoardWithAdmin(board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
+	if s.txRunner != nil {
+		return s.insertBoardWithAdmin(s.txRunner, board, userID)
 	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.insertBoardWithAdmin(s.db, board, userID)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, nil, txErr
+		}
 
-	if err := tx.Commit(); err != nil {
-		return nil, err
+		result, resultVar1, err := s.insertBoardWithAdmin(tx, board, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBoardWithAdmin"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "InsertBoardWithAdmin") {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "InsertBoardWithAdmin") {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		return result, resultVar1, nil
 	}
 
-	return result, nil
+}
+
+func (s *SQLStore) InsertDeferredNotification(notification *model.DeferredNotification) error {
+	return s.insertDeferredNotification(s.runner(), notification)
 
 }
 
-func (s *SQLStore) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+func (s *SQLStore) InsertMention(mention *model.Mention) error {
+	return s.insertMention(s.runner(), mention)
+
+}
+
+func (s *SQLStore) InsertNewBlocks(blocks []model.Block, userID string) error {
+	if s.txRunner != nil {
+		return s.insertNewBlocks(s.txRunner, blocks, userID)
+	}
 	if s.dbType == model.SqliteDBType {
-		return s.patchBoardsAndBlocks(s.db, pbab, userID)
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.insertNewBlocks(s.db, blocks, userID)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.insertNewBlocks(tx, blocks, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertNewBlocks"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "InsertNewBlocks") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "InsertNewBlocks") {
+				continue
+			}
+			return err
+		}
+
+		return nil
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, txErr
+
+}
+
+func (s *SQLStore) InsertWebhookDelivery(delivery *model.WebhookDelivery) error {
+	return s.insertWebhookDelivery(s.runner(), delivery)
+
+}
+
+func (s *SQLStore) JoinBoard(bm *model.BoardMember) (*model.BoardMember, error) {
+	if s.txRunner != nil {
+		return s.joinBoard(s.txRunner, bm)
 	}
-	result, err := s.patchBoardsAndBlocks(tx, pbab, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoardsAndBlocks"))
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.joinBoard(s.db, bm)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+
+		result, err := s.joinBoard(tx, bm)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "JoinBoard"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "JoinBoard") {
+				continue
+			}
+			return nil, err
 		}
-		return nil, err
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "JoinBoard") {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, err
+}
+
+func (s *SQLStore) MarkMentionsRead(userID string, mentionIDs []string, readAt int64) error {
+	return s.markMentionsRead(s.runner(), userID, mentionIDs, readAt)
+
+}
+
+func (s *SQLStore) MoveBlocks(blocks []model.Block, toBoardID string, userID string) ([]model.Block, error) {
+	if s.txRunner != nil {
+		return s.moveBlocks(s.txRunner, blocks, toBoardID, userID)
 	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.moveBlocks(s.db, blocks, toBoardID, userID)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
 
-	return result, nil
+		result, err := s.moveBlocks(tx, blocks, toBoardID, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MoveBlocks"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "MoveBlocks") {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "MoveBlocks") {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+}
+
+func (s *SQLStore) MoveBoardToTeam(boardID string, teamID string) error {
+	if s.txRunner != nil {
+		return s.moveBoardToTeam(s.txRunner, boardID, teamID)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.moveBoardToTeam(s.db, boardID, teamID)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.moveBoardToTeam(tx, boardID, teamID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MoveBoardToTeam"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "MoveBoardToTeam") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "MoveBoardToTeam") {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+}
+
+func (s *SQLStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error {
+	if s.txRunner != nil {
+		return s.patchBlock(s.txRunner, blockID, blockPatch, userID)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.patchBlock(s.db, blockID, blockPatch, userID)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.patchBlock(tx, blockID, blockPatch, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlock"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "PatchBlock") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "PatchBlock") {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+}
+
+func (s *SQLStore) PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error {
+	if s.txRunner != nil {
+		return s.patchBlocks(s.txRunner, blockPatches, userID)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.patchBlocks(s.db, blockPatches, userID)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.patchBlocks(tx, blockPatches, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlocks"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "PatchBlocks") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "PatchBlocks") {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+}
+
+func (s *SQLStore) PatchBoard(boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error) {
+	if s.txRunner != nil {
+		return s.patchBoard(s.txRunner, boardID, boardPatch, userID)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.patchBoard(s.db, boardID, boardPatch, userID)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+
+		result, err := s.patchBoard(tx, boardID, boardPatch, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoard"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "PatchBoard") {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "PatchBoard") {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+}
+
+func (s *SQLStore) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	if s.txRunner != nil {
+		return s.patchBoardsAndBlocks(s.txRunner, pbab, userID)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.patchBoardsAndBlocks(s.db, pbab, userID)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+
+		result, err := s.patchBoardsAndBlocks(tx, pbab, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoardsAndBlocks"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "PatchBoardsAndBlocks") {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "PatchBoardsAndBlocks") {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
 
 }
 
 func (s *SQLStore) PatchUserProps(userID string, patch model.UserPropPatch) error {
-	return s.patchUserProps(s.db, userID, patch)
+	return s.patchUserProps(s.runner(), userID, patch)
 
 }
 
 func (s *SQLStore) RefreshSession(session *model.Session) error {
-	return s.refreshSession(s.db, session)
+	return s.refreshSession(s.runner(), session)
+
+}
+
+func (s *SQLStore) ReleaseBlockLock(blockID string, userID string) error {
+	return s.releaseBlockLock(s.runner(), blockID, userID)
+
+}
+
+func (s *SQLStore) ReleaseDeferredNotification(id string, claimedBy string) error {
+	return s.releaseDeferredNotification(s.runner(), id, claimedBy)
+
+}
+
+func (s *SQLStore) RemoveBoardFromTeam(boardID string, teamID string) error {
+	return s.removeBoardFromTeam(s.runner(), boardID, teamID)
 
 }
 
 func (s *SQLStore) RemoveDefaultTemplates(boards []*model.Board) error {
-	return s.removeDefaultTemplates(s.db, boards)
+	return s.removeDefaultTemplates(s.runner(), boards)
+
+}
+
+func (s *SQLStore) ReorderCategories(userID string, teamID string, newCategoryOrder []string) error {
+	return s.reorderCategories(s.runner(), userID, teamID, newCategoryOrder)
+
+}
+
+func (s *SQLStore) RestoreMember(boardID string, userID string) (*model.BoardMember, error) {
+	if s.txRunner != nil {
+		return s.restoreMember(s.txRunner, boardID, userID)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.restoreMember(s.db, boardID, userID)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+
+		result, err := s.restoreMember(tx, boardID, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RestoreMember"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "RestoreMember") {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "RestoreMember") {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
 
 }
 
 func (s *SQLStore) RunDataRetention(globalRetentionDate int64, batchSize int64) (int64, error) {
+	if s.txRunner != nil {
+		return s.runDataRetention(s.txRunner, globalRetentionDate, batchSize)
+	}
 	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
 		return s.runDataRetention(s.db, globalRetentionDate, batchSize)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return 0, txErr
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return 0, txErr
+		}
+
+		result, err := s.runDataRetention(tx, globalRetentionDate, batchSize)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RunDataRetention"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "RunDataRetention") {
+				continue
+			}
+			return 0, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "RunDataRetention") {
+				continue
+			}
+			return 0, err
+		}
+
+		return result, nil
+	}
+
+}
+
+func (s *SQLStore) RunMaintenance() (*model.MaintenanceReport, error) {
+	return s.runMaintenance(s.runner())
+
+}
+
+func (s *SQLStore) SaveBoardFreezeSchedule(schedule *model.BoardFreezeSchedule) error {
+	return s.saveBoardFreezeSchedule(s.runner(), schedule)
+
+}
+
+func (s *SQLStore) SaveBoardMemberActivity(activities []*model.BoardMemberActivity) error {
+	if s.txRunner != nil {
+		return s.saveBoardMemberActivity(s.txRunner, activities)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.saveBoardMemberActivity(s.db, activities)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.saveBoardMemberActivity(tx, activities)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveBoardMemberActivity"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "SaveBoardMemberActivity") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "SaveBoardMemberActivity") {
+				continue
+			}
+			return err
+		}
+
+		return nil
 	}
-	result, err := s.runDataRetention(tx, globalRetentionDate, batchSize)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RunDataRetention"))
+
+}
+
+func (s *SQLStore) SaveBoardSnapshot(snapshot *model.BoardSnapshot) error {
+	if s.txRunner != nil {
+		return s.saveBoardSnapshot(s.txRunner, snapshot)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.saveBoardSnapshot(s.db, snapshot)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
 		}
-		return 0, err
+
+		err := s.saveBoardSnapshot(tx, snapshot)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveBoardSnapshot"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "SaveBoardSnapshot") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "SaveBoardSnapshot") {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+}
+
+func (s *SQLStore) SaveBoardVisits(visits []*model.BoardVisit) error {
+	if s.txRunner != nil {
+		return s.saveBoardVisits(s.txRunner, visits)
 	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.saveBoardVisits(s.db, visits)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.saveBoardVisits(tx, visits)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveBoardVisits"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "SaveBoardVisits") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "SaveBoardVisits") {
+				continue
+			}
+			return err
+		}
 
-	if err := tx.Commit(); err != nil {
-		return 0, err
+		return nil
 	}
 
-	return result, nil
+}
+
+func (s *SQLStore) SaveCalendarSubscription(subscription *model.CalendarSubscription) error {
+	return s.saveCalendarSubscription(s.runner(), subscription)
+
+}
+
+func (s *SQLStore) SaveDueDigestSettings(settings *model.DueDigestSettings) error {
+	return s.saveDueDigestSettings(s.runner(), settings)
+
+}
+
+func (s *SQLStore) SaveGitHubIntegration(integration *model.GitHubIntegration) error {
+	return s.saveGitHubIntegration(s.runner(), integration)
 
 }
 
 func (s *SQLStore) SaveMember(bm *model.BoardMember) (*model.BoardMember, error) {
-	return s.saveMember(s.db, bm)
+	return s.saveMember(s.runner(), bm)
+
+}
+
+func (s *SQLStore) SaveMemberRoles(bm *model.BoardMember, reason string) (*model.BoardMember, error) {
+	if s.txRunner != nil {
+		return s.saveMemberRoles(s.txRunner, bm, reason)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.saveMemberRoles(s.db, bm, reason)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+
+		result, err := s.saveMemberRoles(tx, bm, reason)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveMemberRoles"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "SaveMemberRoles") {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "SaveMemberRoles") {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+}
+
+func (s *SQLStore) SaveStaleDigestSettings(settings *model.StaleDigestSettings) error {
+	return s.saveStaleDigestSettings(s.runner(), settings)
 
 }
 
 func (s *SQLStore) SearchBoardsForUserAndTeam(term string, userID string, teamID string) ([]*model.Board, error) {
-	return s.searchBoardsForUserAndTeam(s.db, term, userID, teamID)
+	return s.searchBoardsForUserAndTeam(s.runner(), term, userID, teamID)
 
 }
 
 func (s *SQLStore) SearchUsersByTeam(teamID string, searchQuery string) ([]*model.User, error) {
-	return s.searchUsersByTeam(s.db, teamID, searchQuery)
+	return s.searchUsersByTeam(s.runner(), teamID, searchQuery)
+
+}
+
+func (s *SQLStore) SetBoardKey(boardID string, key string) error {
+	if s.txRunner != nil {
+		return s.setBoardKey(s.txRunner, boardID, key)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.setBoardKey(s.db, boardID, key)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.setBoardKey(tx, boardID, key)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SetBoardKey"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "SetBoardKey") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "SetBoardKey") {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+}
+
+func (s *SQLStore) SetMembershipsInactive(userID string, inactive bool) ([]*model.BoardMember, error) {
+	if s.txRunner != nil {
+		return s.setMembershipsInactive(s.txRunner, userID, inactive)
+	}
+	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+		return s.setMembershipsInactive(s.db, userID, inactive)
+	}
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+
+		result, err := s.setMembershipsInactive(tx, userID, inactive)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SetMembershipsInactive"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "SetMembershipsInactive") {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "SetMembershipsInactive") {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
 
 }
 
 func (s *SQLStore) SetSystemSetting(key string, value string) error {
-	return s.setSystemSetting(s.db, key, value)
+	return s.setSystemSetting(s.runner(), key, value)
 
 }
 
 func (s *SQLStore) UndeleteBlock(blockID string, modifiedBy string) error {
+	if s.txRunner != nil {
+		return s.undeleteBlock(s.txRunner, blockID, modifiedBy)
+	}
 	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
 		return s.undeleteBlock(s.db, blockID, modifiedBy)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.undeleteBlock(tx, blockID, modifiedBy)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBlock"))
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
 		}
-		return err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+		err := s.undeleteBlock(tx, blockID, modifiedBy)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBlock"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "UndeleteBlock") {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "UndeleteBlock") {
+				continue
+			}
+			return err
+		}
 
-	return nil
+		return nil
+	}
 
 }
 
 func (s *SQLStore) UndeleteBoard(boardID string, modifiedBy string) error {
+	if s.txRunner != nil {
+		return s.undeleteBoard(s.txRunner, boardID, modifiedBy)
+	}
 	if s.dbType == model.SqliteDBType {
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
 		return s.undeleteBoard(s.db, boardID, modifiedBy)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.undeleteBoard(tx, boardID, modifiedBy)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBoard"))
+	for attempt := 1; ; attempt++ {
+		tx, txErr := s.db.BeginTx(s.context(), nil)
+		if txErr != nil {
+			return txErr
+		}
+
+		err := s.undeleteBoard(tx, boardID, modifiedBy)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBoard"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "UndeleteBoard") {
+				continue
+			}
+			return err
 		}
-		return err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
+		if err := tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "UndeleteBoard") {
+				continue
+			}
+			return err
+		}
+
+		return nil
 	}
 
-	return nil
+}
+
+func (s *SQLStore) UpdateBoardWebhook(webhook *model.BoardWebhook) error {
+	return s.updateBoardWebhook(s.runner(), webhook)
 
 }
 
 func (s *SQLStore) UpdateCategory(category model.Category) error {
-	return s.updateCategory(s.db, category)
+	return s.updateCategory(s.runner(), category)
+
+}
+
+func (s *SQLStore) UpdateMemberCustomFields(boardID string, userID string, customFields map[string]interface{}) error {
+	return s.updateMemberCustomFields(s.runner(), boardID, userID, customFields)
 
 }
 
 func (s *SQLStore) UpdateSession(session *model.Session) error {
-	return s.updateSession(s.db, session)
+	return s.updateSession(s.runner(), session)
 
 }
 
 func (s *SQLStore) UpdateSubscribersNotifiedAt(blockID string, notifiedAt int64) error {
-	return s.updateSubscribersNotifiedAt(s.db, blockID, notifiedAt)
+	return s.updateSubscribersNotifiedAt(s.runner(), blockID, notifiedAt)
 
 }
 
 func (s *SQLStore) UpdateUser(user *model.User) error {
-	return s.updateUser(s.db, user)
+	return s.updateUser(s.runner(), user)
+
+}
+
+func (s *SQLStore) UpdateUserDeleteAt(username string, deleteAt int64) error {
+	return s.updateUserDeleteAt(s.runner(), username, deleteAt)
 
 }
 
 func (s *SQLStore) UpdateUserPassword(username string, password string) error {
-	return s.updateUserPassword(s.db, username, password)
+	return s.updateUserPassword(s.runner(), username, password)
 
 }
 
 func (s *SQLStore) UpdateUserPasswordByID(userID string, password string) error {
-	return s.updateUserPasswordByID(s.db, userID, password)
+	return s.updateUserPasswordByID(s.runner(), userID, password)
+
+}
+
+func (s *SQLStore) UpdateWebhookDelivery(delivery *model.WebhookDelivery) error {
+	return s.updateWebhookDelivery(s.runner(), delivery)
 
 }
 
 func (s *SQLStore) UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
-	return s.upsertNotificationHint(s.db, hint, notificationFreq)
+	return s.upsertNotificationHint(s.runner(), hint, notificationFreq)
 
 }
 
 func (s *SQLStore) UpsertSharing(sharing model.Sharing) error {
-	return s.upsertSharing(s.db, sharing)
+	return s.upsertSharing(s.runner(), sharing)
 
 }
 
 func (s *SQLStore) UpsertTeamSettings(team model.Team) error {
-	return s.upsertTeamSettings(s.db, team)
+	return s.upsertTeamSettings(s.runner(), team)
 
 }
 
 func (s *SQLStore) UpsertTeamSignupToken(team model.Team) error {
-	return s.upsertTeamSignupToken(s.db, team)
+	return s.upsertTeamSignupToken(s.runner(), team)
 
 }
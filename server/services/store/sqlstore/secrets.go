@@ -0,0 +1,113 @@
+package sqlstore
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/focalboard/server/services/secrets"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// encryptSecret encrypts a secret before it is stored, if the store has a
+// secrets key.
+func (s *SQLStore) encryptSecret(secret string) (string, error) {
+	return s.secrets.EncryptIfEnabled(secret)
+}
+
+// decryptSecret decrypts a stored secret. The secrets stored before the
+// key was configured are in plaintext, and read as they are.
+func (s *SQLStore) decryptSecret(value string) (string, error) {
+	return s.secrets.Decrypt(value)
+}
+
+// secretColumn is a column holding secrets.
+type secretColumn struct {
+	table  string
+	key    string
+	column string
+
+	// encryptedLegacy is true for the columns whose values were already
+	// encrypted by the GitHub integration, without the prefix of the
+	// secrets service.
+	encryptedLegacy bool
+}
+
+var secretColumns = []secretColumn{
+	{table: "webhooks", key: "id", column: "secret"},
+	{table: "github_integrations", key: "board_id", column: "webhook_secret"},
+	{table: "github_integrations", key: "board_id", column: "encrypted_token", encryptedLegacy: true},
+}
+
+// encryptStoredSecrets encrypts the secrets stored in plaintext, before
+// the secrets key was configured. It runs on each start, as the key can
+// be configured at any time, and does nothing without key.
+func (s *SQLStore) encryptStoredSecrets() error {
+	if !s.secrets.IsEnabled() {
+		return nil
+	}
+
+	return s.RunInTransaction(func(tx store.Store) error {
+		txStore := tx.(*SQLStore)
+		for _, column := range secretColumns {
+			count, err := txStore.encryptSecretColumn(txStore.txRunner, column)
+			if err != nil {
+				return fmt.Errorf("cannot encrypt %s.%s: %w", column.table, column.column, err)
+			}
+			if count > 0 {
+				s.logger.Info("Encrypted the stored secrets",
+					mlog.String("table", column.table),
+					mlog.String("column", column.column),
+					mlog.Int("count", count),
+				)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLStore) encryptSecretColumn(db sq.BaseRunner, column secretColumn) (int, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(column.key, column.column).
+		From(s.tablePrefix + column.table).
+		Where(sq.NotEq{column.column: nil}).
+		Where(sq.NotEq{column.column: ""}).
+		Where(sq.NotLike{column.column: secrets.Prefix + "%"}).
+		QueryContext(s.context())
+	if err != nil {
+		return 0, err
+	}
+
+	plaintext := map[string]string{}
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			s.CloseRows(rows)
+			return 0, err
+		}
+		plaintext[key] = value
+	}
+	s.CloseRows(rows)
+
+	for key, value := range plaintext {
+		encrypted := secrets.Prefix + value
+		if !column.encryptedLegacy {
+			if encrypted, err = s.secrets.Encrypt(value); err != nil {
+				return 0, err
+			}
+		}
+
+		_, err := s.getQueryBuilder(db).
+			Update(s.tablePrefix+column.table).
+			Set(column.column, encrypted).
+			Where(sq.Eq{column.key: key}).
+			Where(sq.Eq{column.column: value}).
+			ExecContext(s.context())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(plaintext), nil
+}
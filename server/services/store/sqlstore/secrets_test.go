@@ -0,0 +1,98 @@
+package sqlstore
+
+import (
+	"strings"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/secrets"
+)
+
+func TestStoredSecretsEncryption(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	rawValue := func(table, key, column, id string) string {
+		var value string
+		err := sqlStore.getQueryBuilder(sqlStore.db).
+			Select(column).
+			From(sqlStore.tablePrefix + table).
+			Where(sq.Eq{key: id}).
+			QueryRow().
+			Scan(&value)
+		require.NoError(t, err)
+		return value
+	}
+
+	// without key, the secrets are stored in plaintext
+	webhook := &model.BoardWebhook{
+		ID:      "webhook-id",
+		BoardID: "board-id",
+		URL:     "https://example.com/hook",
+		Events:  []string{model.BoardWebhookEventBlockCreated},
+		Secret:  "webhook-secret",
+	}
+	require.NoError(t, sqlStore.InsertBoardWebhook(webhook))
+	require.Equal(t, "webhook-secret", rawValue("webhooks", "id", "secret", webhook.ID))
+
+	// the tokens encrypted by the GitHub integration before the secrets
+	// service had no prefix
+	gitHubKeyService, err := secrets.New(config.SecretsConfig{Key: "github-key"}, "")
+	require.NoError(t, err)
+	token, err := gitHubKeyService.Encrypt("ghp_token")
+	require.NoError(t, err)
+	require.NoError(t, sqlStore.SaveGitHubIntegration(&model.GitHubIntegration{
+		BoardID:        "board-id",
+		Repository:     "owner/repo",
+		EncryptedToken: strings.TrimPrefix(token, secrets.Prefix),
+		WebhookSecret:  "github-secret",
+		ModifiedBy:     "user-id",
+	}))
+
+	service, err := secrets.New(config.SecretsConfig{Key: "secrets-key"}, "github-key")
+	require.NoError(t, err)
+	sqlStore.secrets = service
+
+	t.Run("the plaintext secrets are encrypted", func(t *testing.T) {
+		require.NoError(t, sqlStore.encryptStoredSecrets())
+
+		raw := rawValue("webhooks", "id", "secret", webhook.ID)
+		require.True(t, secrets.IsEncrypted(raw))
+		require.NotContains(t, raw, "webhook-secret")
+		require.True(t, secrets.IsEncrypted(rawValue("github_integrations", "board_id", "webhook_secret", "board-id")))
+
+		// running again leaves the encrypted secrets as they are
+		require.NoError(t, sqlStore.encryptStoredSecrets())
+		require.Equal(t, raw, rawValue("webhooks", "id", "secret", webhook.ID))
+	})
+
+	t.Run("the secrets are decrypted when read", func(t *testing.T) {
+		stored, err := sqlStore.GetBoardWebhook(webhook.ID)
+		require.NoError(t, err)
+		require.Equal(t, "webhook-secret", stored.Secret)
+
+		integration, err := sqlStore.GetGitHubIntegration("board-id")
+		require.NoError(t, err)
+		require.Equal(t, "github-secret", integration.WebhookSecret)
+
+		token, err := service.Decrypt(integration.EncryptedToken)
+		require.NoError(t, err)
+		require.Equal(t, "ghp_token", token)
+	})
+
+	t.Run("new secrets are stored encrypted", func(t *testing.T) {
+		webhook.Secret = "new-secret"
+		require.NoError(t, sqlStore.UpdateBoardWebhook(webhook))
+
+		raw := rawValue("webhooks", "id", "secret", webhook.ID)
+		require.True(t, secrets.IsEncrypted(raw))
+		stored, err := sqlStore.GetBoardWebhook(webhook.ID)
+		require.NoError(t, err)
+		require.Equal(t, "new-secret", stored.Secret)
+	})
+}
@@ -15,7 +15,7 @@ func (s *SQLStore) getActiveUserCount(db sq.BaseRunner, updatedSecondsAgo int64)
 		From(s.tablePrefix + "sessions").
 		Where(sq.Gt{"update_at": utils.GetMillis() - utils.SecondsToMillis(updatedSecondsAgo)})
 
-	row := query.QueryRow()
+	row := query.QueryRowContext(s.context())
 
 	var count int
 	err := row.Scan(&count)
@@ -33,7 +33,7 @@ func (s *SQLStore) getSession(db sq.BaseRunner, token string, expireTimeSeconds
 		Where(sq.Eq{"token": token}).
 		Where(sq.Gt{"update_at": utils.GetMillis() - utils.SecondsToMillis(expireTimeSeconds)})
 
-	row := query.QueryRow()
+	row := query.QueryRowContext(s.context())
 	session := model.Session{}
 
 	var propsBytes []byte
@@ -62,7 +62,7 @@ func (s *SQLStore) createSession(db sq.BaseRunner, session *model.Session) error
 		Columns("id", "token", "user_id", "auth_service", "props", "create_at", "update_at").
 		Values(session.ID, session.Token, session.UserID, session.AuthService, propsBytes, now, now)
 
-	_, err = query.Exec()
+	_, err = query.ExecContext(s.context())
 	return err
 }
 
@@ -73,7 +73,7 @@ func (s *SQLStore) refreshSession(db sq.BaseRunner, session *model.Session) erro
 		Where(sq.Eq{"token": session.Token}).
 		Set("update_at", now)
 
-	_, err := query.Exec()
+	_, err := query.ExecContext(s.context())
 	return err
 }
 
@@ -90,7 +90,7 @@ func (s *SQLStore) updateSession(db sq.BaseRunner, session *model.Session) error
 		Set("update_at", now).
 		Set("props", propsBytes)
 
-	_, err = query.Exec()
+	_, err = query.ExecContext(s.context())
 	return err
 }
 
@@ -98,7 +98,15 @@ func (s *SQLStore) deleteSession(db sq.BaseRunner, sessionID string) error {
 	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "sessions").
 		Where(sq.Eq{"id": sessionID})
 
-	_, err := query.Exec()
+	_, err := query.ExecContext(s.context())
+	return err
+}
+
+func (s *SQLStore) deleteSessionsForUser(db sq.BaseRunner, userID string) error {
+	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "sessions").
+		Where(sq.Eq{"user_id": userID})
+
+	_, err := query.ExecContext(s.context())
 	return err
 }
 
@@ -106,6 +114,6 @@ func (s *SQLStore) cleanUpSessions(db sq.BaseRunner, expireTimeSeconds int64) er
 	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "sessions").
 		Where(sq.Lt{"update_at": utils.GetMillis() - utils.SecondsToMillis(expireTimeSeconds)})
 
-	_, err := query.Exec()
+	_, err := query.ExecContext(s.context())
 	return err
 }
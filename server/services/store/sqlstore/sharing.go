@@ -36,7 +36,7 @@ func (s *SQLStore) upsertSharing(db sq.BaseRunner, sharing model.Sharing) error
 		)
 	}
 
-	_, err := query.Exec()
+	_, err := query.ExecContext(s.context())
 	return err
 }
 
@@ -51,7 +51,7 @@ func (s *SQLStore) getSharing(db sq.BaseRunner, boardID string) (*model.Sharing,
 		).
 		From(s.tablePrefix + "sharing").
 		Where(sq.Eq{"id": boardID})
-	row := query.QueryRow()
+	row := query.QueryRowContext(s.context())
 	sharing := model.Sharing{}
 
 	err := row.Scan(
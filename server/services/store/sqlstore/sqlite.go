@@ -0,0 +1,86 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// sqliteRunner runs statements on a SQLite database, holding the write
+// lock of the store while running the statements that modify it.
+// Concurrent readers are left alone, as WAL mode allows readers to run
+// alongside the writer.
+type sqliteRunner struct {
+	db       *sql.DB
+	writeMux *sync.Mutex
+}
+
+func (r *sqliteRunner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	r.writeMux.Lock()
+	defer r.writeMux.Unlock()
+	return r.db.Exec(query, args...)
+}
+
+func (r *sqliteRunner) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return r.db.Query(query, args...)
+}
+
+func (r *sqliteRunner) QueryRow(query string, args ...interface{}) *sql.Row {
+	return r.db.QueryRow(query, args...)
+}
+
+func (r *sqliteRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.writeMux.Lock()
+	defer r.writeMux.Unlock()
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *sqliteRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.db.QueryContext(ctx, query, args...)
+}
+
+func (r *sqliteRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.db.QueryRowContext(ctx, query, args...)
+}
+
+// SQLiteConnectionString adds the parameters that enable WAL journal mode
+// and set the busy timeout to a SQLite connection string. Parameters
+// already present in the connection string are kept as they are.
+func SQLiteConnectionString(connectionString string, walMode bool, busyTimeout int) string {
+	base, query := connectionString, ""
+	if i := strings.IndexRune(connectionString, '?'); i >= 0 {
+		base, query = connectionString[:i], connectionString[i+1:]
+	}
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return connectionString
+	}
+
+	changed := false
+	if walMode && !hasAnyParam(params, "_journal_mode", "_journal") {
+		params.Set("_journal_mode", "WAL")
+		changed = true
+	}
+	if busyTimeout > 0 && !hasAnyParam(params, "_busy_timeout", "_timeout") {
+		params.Set("_busy_timeout", strconv.Itoa(busyTimeout))
+		changed = true
+	}
+
+	if !changed {
+		return connectionString
+	}
+	return base + "?" + params.Encode()
+}
+
+func hasAnyParam(params url.Values, names ...string) bool {
+	for _, name := range names {
+		if _, ok := params[name]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteConnectionString(t *testing.T) {
+	testCases := []struct {
+		Scenario    string
+		DSN         string
+		WALMode     bool
+		BusyTimeout int
+		ExpectedDSN string
+	}{
+		{
+			"Should add the WAL mode and busy timeout params",
+			"./focalboard.db",
+			true,
+			5000,
+			"./focalboard.db?_busy_timeout=5000&_journal_mode=WAL",
+		},
+		{
+			"Should keep the existing params",
+			"file:focalboard.db?cache=shared",
+			true,
+			1000,
+			"file:focalboard.db?_busy_timeout=1000&_journal_mode=WAL&cache=shared",
+		},
+		{
+			"Should not override the params set in the DSN",
+			"./focalboard.db?_journal=DELETE&_timeout=200",
+			true,
+			5000,
+			"./focalboard.db?_journal=DELETE&_timeout=200",
+		},
+		{
+			"Should not change the DSN if WAL mode and the busy timeout are disabled",
+			"./focalboard.db",
+			false,
+			0,
+			"./focalboard.db",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			res := SQLiteConnectionString(tc.DSN, tc.WALMode, tc.BusyTimeout)
+			assert.Equal(t, tc.ExpectedDSN, res)
+		})
+	}
+}
@@ -1,13 +1,16 @@
 package sqlstore
 
 import (
+	"context"
 	"database/sql"
+	"sync"
 
 	"github.com/mattermost/mattermost-server/v6/plugin"
 
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/secrets"
 	"github.com/mattermost/mattermost-plugin-api/cluster"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
@@ -24,6 +27,24 @@ type SQLStore struct {
 	logger           *mlog.Logger
 	NewMutexFn       MutexFactory
 	pluginAPI        *plugin.API
+	secrets          *secrets.Service
+
+	// writeMux serializes the writes to SQLite databases, which only
+	// support one writer at a time.
+	writeMux *sync.Mutex
+
+	// txRunner is the transaction the store is bound to by
+	// RunInTransaction, nil if the store isn't bound to one.
+	txRunner sq.BaseRunner
+
+	// ctx is the context the statements run with, set by WithContext.
+	// The statements run with the background context if it is nil.
+	ctx context.Context
+
+	// stopBatchedMigrations cancels the batched migrations running in
+	// the background, and batchedMigrationsWG waits for them to stop.
+	stopBatchedMigrations context.CancelFunc
+	batchedMigrationsWG   *sync.WaitGroup
 }
 
 // MutexFactory is used by the store in plugin mode to generate
@@ -39,14 +60,21 @@ func New(params Params) (*SQLStore, error) {
 	params.Logger.Info("connectDatabase", mlog.String("dbType", params.DBType))
 	store := &SQLStore{
 		// TODO: add replica DB support too.
-		db:               params.DB,
-		dbType:           params.DBType,
-		tablePrefix:      params.TablePrefix,
-		connectionString: params.ConnectionString,
-		logger:           params.Logger,
-		isPlugin:         params.IsPlugin,
-		NewMutexFn:       params.NewMutexFn,
-		pluginAPI:        params.PluginAPI,
+		db:                  params.DB,
+		dbType:              params.DBType,
+		tablePrefix:         params.TablePrefix,
+		connectionString:    params.ConnectionString,
+		logger:              params.Logger,
+		isPlugin:            params.IsPlugin,
+		NewMutexFn:          params.NewMutexFn,
+		pluginAPI:           params.PluginAPI,
+		secrets:             params.Secrets,
+		writeMux:            &sync.Mutex{},
+		batchedMigrationsWG: &sync.WaitGroup{},
+	}
+
+	if params.SkipMigrations {
+		return store, nil
 	}
 
 	err := store.Migrate()
@@ -55,11 +83,25 @@ func New(params Params) (*SQLStore, error) {
 
 		return nil, err
 	}
+
+	if err := store.encryptStoredSecrets(); err != nil {
+		params.Logger.Error(`Encryption of the stored secrets failed`, mlog.Err(err))
+
+		return nil, err
+	}
+
+	store.startBatchedMigrations(batchedMigrations)
+
 	return store, nil
 }
 
 // Shutdown close the connection with the store.
 func (s *SQLStore) Shutdown() error {
+	if s.stopBatchedMigrations != nil {
+		s.stopBatchedMigrations()
+	}
+	s.batchedMigrationsWG.Wait()
+
 	return s.db.Close()
 }
 
@@ -75,9 +117,21 @@ func (s *SQLStore) DBType() string {
 	return s.dbType
 }
 
+// runner returns the runner for the statements that aren't part of a
+// transactional method.
+func (s *SQLStore) runner() sq.BaseRunner {
+	if s.txRunner != nil {
+		return s.txRunner
+	}
+	if s.dbType == model.SqliteDBType {
+		return &sqliteRunner{db: s.db, writeMux: s.writeMux}
+	}
+	return s.db
+}
+
 func (s *SQLStore) getQueryBuilder(db sq.BaseRunner) sq.StatementBuilderType {
 	builder := sq.StatementBuilder
-	if s.dbType == model.PostgresDBType || s.dbType == model.SqliteDBType {
+	if s.isPostgresCompatible() || s.dbType == model.SqliteDBType {
 		builder = builder.PlaceholderFormat(sq.Dollar)
 	}
 
@@ -88,7 +142,7 @@ func (s *SQLStore) escapeField(fieldName string) string { //nolint:unparam
 	if s.dbType == model.MysqlDBType {
 		return "`" + fieldName + "`"
 	}
-	if s.dbType == model.PostgresDBType || s.dbType == model.SqliteDBType {
+	if s.isPostgresCompatible() || s.dbType == model.SqliteDBType {
 		return "\"" + fieldName + "\""
 	}
 	return fieldName
@@ -97,3 +151,15 @@ func (s *SQLStore) escapeField(fieldName string) string { //nolint:unparam
 func (s *SQLStore) getLicense(db sq.BaseRunner) *mmModel.License {
 	return nil
 }
+
+// getChannelMemberIDs returns no members, as channels only exist when
+// running as a plugin.
+func (s *SQLStore) getChannelMemberIDs(db sq.BaseRunner, _ string) ([]string, error) {
+	return []string{}, nil
+}
+
+// getChannel never finds the channel, as channels only exist when
+// running as a plugin.
+func (s *SQLStore) getChannel(db sq.BaseRunner, _ string) (*mmModel.Channel, error) {
+	return nil, sql.ErrNoRows
+}
@@ -20,4 +20,29 @@ func TestSQLStore(t *testing.T) {
 	t.Run("BoardsAndBlocksStore", func(t *testing.T) { storetests.StoreTestBoardsAndBlocksStore(t, SetupTests) })
 	t.Run("SubscriptionStore", func(t *testing.T) { storetests.StoreTestSubscriptionsStore(t, SetupTests) })
 	t.Run("NotificationHintStore", func(t *testing.T) { storetests.StoreTestNotificationHintsStore(t, SetupTests) })
+	t.Run("MentionStore", func(t *testing.T) { storetests.StoreTestMentionsStore(t, SetupTests) })
+	t.Run("BoardInvitationStore", func(t *testing.T) { storetests.StoreTestBoardInvitationsStore(t, SetupTests) })
+	t.Run("WebhookDeliveryStore", func(t *testing.T) { storetests.StoreTestWebhookDeliveriesStore(t, SetupTests) })
+	t.Run("StatsStore", func(t *testing.T) { storetests.StoreTestStatsStore(t, SetupTests) })
+	t.Run("MaintenanceStore", func(t *testing.T) { storetests.StoreTestMaintenanceStore(t, SetupTests) })
+	t.Run("BoardTeamsStore", func(t *testing.T) { storetests.StoreTestBoardTeamsStore(t, SetupTests) })
+	t.Run("CardNumbersStore", func(t *testing.T) { storetests.StoreTestCardNumbersStore(t, SetupTests) })
+	t.Run("PropertyIndexesStore", func(t *testing.T) { storetests.StoreTestPropertyIndexesStore(t, SetupTests) })
+	t.Run("CardGroupsStore", func(t *testing.T) { storetests.StoreTestCardGroupsStore(t, SetupTests) })
+	t.Run("ViewCardsStore", func(t *testing.T) { storetests.StoreTestViewCardsStore(t, SetupTests) })
+	t.Run("CardAgingStore", func(t *testing.T) { storetests.StoreTestCardAgingStore(t, SetupTests) })
+	t.Run("CategoryRulesStore", func(t *testing.T) { storetests.StoreTestCategoryRulesStore(t, SetupTests) })
+	t.Run("DueDigestStore", func(t *testing.T) { storetests.StoreTestDueDigestStore(t, SetupTests) })
+	t.Run("StaleDigestStore", func(t *testing.T) { storetests.StoreTestStaleDigestStore(t, SetupTests) })
+	t.Run("BoardSnapshotsStore", func(t *testing.T) { storetests.StoreTestBoardSnapshotsStore(t, SetupTests) })
+	t.Run("BoardFreezeStore", func(t *testing.T) { storetests.StoreTestBoardFreezeStore(t, SetupTests) })
+	t.Run("CalendarSubscriptionsStore", func(t *testing.T) { storetests.StoreTestCalendarSubscriptionsStore(t, SetupTests) })
+	t.Run("GitHubIntegrationsStore", func(t *testing.T) { storetests.StoreTestGitHubIntegrationsStore(t, SetupTests) })
+	t.Run("BlockLocksStore", func(t *testing.T) { storetests.StoreTestBlockLocksStore(t, SetupTests) })
+	t.Run("BoardAccessRequestStore", func(t *testing.T) { storetests.StoreTestBoardAccessRequestStore(t, SetupTests) })
+	t.Run("BoardMemberActivityStore", func(t *testing.T) { storetests.StoreTestBoardMemberActivityStore(t, SetupTests) })
+	t.Run("DeferredNotificationsStore", func(t *testing.T) { storetests.StoreTestDeferredNotificationsStore(t, SetupTests) })
+	t.Run("BoardWebhooksStore", func(t *testing.T) { storetests.StoreTestBoardWebhooksStore(t, SetupTests) })
+	t.Run("Transactions", func(t *testing.T) { storetests.StoreTestTransactions(t, SetupTests) })
+	t.Run("ContextStore", func(t *testing.T) { storetests.StoreTestContextStore(t, SetupTests) })
 }
@@ -0,0 +1,111 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var staleDigestSettingsFields = []string{
+	"user_id",
+	"enabled",
+	"stale_days",
+	"send_weekday",
+	"send_hour",
+	"last_sent_at",
+}
+
+func (s *SQLStore) getStaleDigestSettings(db sq.BaseRunner, userID string) (*model.StaleDigestSettings, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(staleDigestSettingsFields...).
+		From(s.tablePrefix + "stale_digest_settings").
+		Where(sq.Eq{"user_id": userID}).
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getStaleDigestSettings error", mlog.String("userID", userID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	settings, err := s.staleDigestSettingsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return nil, store.NewErrNotFound("stale digest settings " + userID)
+	}
+	return settings[0], nil
+}
+
+// getEnabledStaleDigestSettings returns the settings of all the users that
+// enabled the stale cards digest.
+func (s *SQLStore) getEnabledStaleDigestSettings(db sq.BaseRunner) ([]*model.StaleDigestSettings, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(staleDigestSettingsFields...).
+		From(s.tablePrefix + "stale_digest_settings").
+		Where(sq.Eq{"enabled": true}).
+		OrderBy("user_id").
+		QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getEnabledStaleDigestSettings error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.staleDigestSettingsFromRows(rows)
+}
+
+func (s *SQLStore) saveStaleDigestSettings(db sq.BaseRunner, settings *model.StaleDigestSettings) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"stale_digest_settings").
+		Columns(staleDigestSettingsFields...).
+		Values(
+			settings.UserID,
+			settings.Enabled,
+			settings.Days,
+			settings.Weekday,
+			settings.Hour,
+			settings.LastSentAt,
+		)
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE enabled = ?, stale_days = ?, send_weekday = ?, send_hour = ?, last_sent_at = ?",
+			settings.Enabled, settings.Days, settings.Weekday, settings.Hour, settings.LastSentAt)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (user_id)
+			 DO UPDATE SET enabled = EXCLUDED.enabled, stale_days = EXCLUDED.stale_days, send_weekday = EXCLUDED.send_weekday,
+			 send_hour = EXCLUDED.send_hour, last_sent_at = EXCLUDED.last_sent_at`,
+		)
+	}
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("saveStaleDigestSettings error", mlog.String("userID", settings.UserID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) staleDigestSettingsFromRows(rows *sql.Rows) ([]*model.StaleDigestSettings, error) {
+	results := []*model.StaleDigestSettings{}
+	for rows.Next() {
+		var settings model.StaleDigestSettings
+		err := rows.Scan(
+			&settings.UserID,
+			&settings.Enabled,
+			&settings.Days,
+			&settings.Weekday,
+			&settings.Hour,
+			&settings.LastSentAt,
+		)
+		if err != nil {
+			s.logger.Error("staleDigestSettingsFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		results = append(results, &settings)
+	}
+	return results, nil
+}
@@ -0,0 +1,217 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// statsTables are the tables reported in the server statistics.
+var statsTables = []string{
+	"blocks",
+	"blocks_history",
+	"board_access_requests",
+	"board_freeze_schedules",
+	"board_invitations",
+	"board_keys",
+	"board_member_activity",
+	"board_members",
+	"board_members_history",
+	"board_snapshots",
+	"board_teams",
+	"board_visits",
+	"boards",
+	"boards_history",
+	"card_numbers",
+	"categories",
+	"category_boards",
+	"category_rules",
+	"deferred_notifications",
+	"due_digest_settings",
+	"mentions",
+	"notification_hints",
+	"property_indexes",
+	"sessions",
+	"sharing",
+	"stale_digest_settings",
+	"subscriptions",
+	"system_settings",
+	"teams",
+	"users",
+	"webhook_deliveries",
+	"webhooks",
+}
+
+func (s *SQLStore) getBoardCount(db sq.BaseRunner) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*) AS count").
+		From(s.tablePrefix + "boards")
+
+	var count int64
+	if err := query.QueryRowContext(s.context()).Scan(&count); err != nil {
+		s.logger.Error("Failed to fetch board count", mlog.Err(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *SQLStore) getTableStats(db sq.BaseRunner) ([]*model.TableStats, error) {
+	stats := make([]*model.TableStats, 0, len(statsTables))
+	for _, name := range statsTables {
+		table := s.tablePrefix + name
+
+		var rows int64
+		err := s.getQueryBuilder(db).
+			Select("COUNT(*) AS count").
+			From(table).
+			QueryRowContext(s.context()).
+			Scan(&rows)
+		if err != nil {
+			s.logger.Error("Failed to fetch table row count", mlog.String("table", table), mlog.Err(err))
+			return nil, err
+		}
+
+		size, err := s.getTableSize(db, table)
+		if err != nil {
+			s.logger.Error("Failed to fetch table size", mlog.String("table", table), mlog.Err(err))
+			return nil, err
+		}
+
+		stats = append(stats, &model.TableStats{
+			Name:      name,
+			Rows:      rows,
+			SizeBytes: size,
+		})
+	}
+	return stats, nil
+}
+
+// getHistoryStatsByTeam returns the number of rows of the boards and
+// blocks history of each team. The size of the history of a team is
+// estimated from its share of the rows of the table.
+func (s *SQLStore) getHistoryStatsByTeam(db sq.BaseRunner) ([]*model.TeamHistoryStats, error) {
+	boardsQuery := s.getQueryBuilder(db).
+		Select("team_id", "COUNT(*) AS count").
+		From(s.tablePrefix + "boards_history").
+		GroupBy("team_id")
+
+	boardRows, err := s.countRowsByTeam(boardsQuery)
+	if err != nil {
+		s.logger.Error("Failed to fetch boards history row counts", mlog.Err(err))
+		return nil, err
+	}
+
+	// the team of the blocks is taken from the boards history, so the
+	// history of the deleted boards is counted too
+	blocksQuery := s.getQueryBuilder(db).
+		Select("bt.team_id", "COUNT(*) AS count").
+		From(s.tablePrefix + "blocks_history AS bh").
+		Join(fmt.Sprintf("(SELECT id, MAX(team_id) AS team_id FROM %sboards_history GROUP BY id) AS bt ON bt.id = bh.board_id", s.tablePrefix)).
+		GroupBy("bt.team_id")
+
+	blockRows, err := s.countRowsByTeam(blocksQuery)
+	if err != nil {
+		s.logger.Error("Failed to fetch blocks history row counts", mlog.Err(err))
+		return nil, err
+	}
+
+	boardsSize, err := s.getTableSize(db, s.tablePrefix+"boards_history")
+	if err != nil {
+		return nil, err
+	}
+	blocksSize, err := s.getTableSize(db, s.tablePrefix+"blocks_history")
+	if err != nil {
+		return nil, err
+	}
+
+	byTeam := map[string]*model.TeamHistoryStats{}
+	teamStats := func(teamID string) *model.TeamHistoryStats {
+		if _, ok := byTeam[teamID]; !ok {
+			byTeam[teamID] = &model.TeamHistoryStats{TeamID: teamID}
+		}
+		return byTeam[teamID]
+	}
+
+	var boardsTotal, blocksTotal int64
+	for _, count := range boardRows {
+		boardsTotal += count
+	}
+	for _, count := range blockRows {
+		blocksTotal += count
+	}
+
+	for teamID, count := range boardRows {
+		stats := teamStats(teamID)
+		stats.BoardsHistoryRows = count
+		stats.BoardsHistorySizeBytes = boardsSize * count / boardsTotal
+	}
+	for teamID, count := range blockRows {
+		stats := teamStats(teamID)
+		stats.BlocksHistoryRows = count
+		stats.BlocksHistorySizeBytes = blocksSize * count / blocksTotal
+	}
+
+	stats := make([]*model.TeamHistoryStats, 0, len(byTeam))
+	for _, teamStats := range byTeam {
+		stats = append(stats, teamStats)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].TeamID < stats[j].TeamID
+	})
+	return stats, nil
+}
+
+func (s *SQLStore) countRowsByTeam(query sq.SelectBuilder) (map[string]int64, error) {
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	counts := map[string]int64{}
+	for rows.Next() {
+		var teamID string
+		var count int64
+		if err := rows.Scan(&teamID, &count); err != nil {
+			return nil, err
+		}
+		counts[teamID] = count
+	}
+	return counts, rows.Err()
+}
+
+// getTableSize returns the size in bytes of a table and its indexes.
+// SQLite and CockroachDB don't report per table sizes, so zero is returned.
+func (s *SQLStore) getTableSize(db sq.BaseRunner, table string) (int64, error) {
+	var query sq.SelectBuilder
+	switch s.dbType {
+	case model.PostgresDBType:
+		query = s.getQueryBuilder(db).
+			Select("pg_total_relation_size(c.oid)").
+			From("pg_class c").
+			Join("pg_namespace n ON n.oid = c.relnamespace").
+			Where(sq.Eq{"c.relname": table}).
+			Where("n.nspname = current_schema()")
+	case model.MysqlDBType:
+		query = s.getQueryBuilder(db).
+			Select("COALESCE(data_length + index_length, 0)").
+			From("information_schema.tables").
+			Where(sq.Eq{"table_name": table}).
+			Where("table_schema = DATABASE()")
+	default:
+		return 0, nil
+	}
+
+	var size int64
+	err := query.QueryRowContext(s.context()).Scan(&size)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return size, err
+}
@@ -5,6 +5,7 @@ package sqlstore
 
 import (
 	"database/sql"
+	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/focalboard/server/model"
@@ -82,7 +83,7 @@ func (s *SQLStore) createSubscription(db sq.BaseRunner, sub *model.Subscription)
 		query = query.Suffix("ON CONFLICT (block_id,subscriber_id) DO UPDATE SET delete_at = 0, notified_at = ?", now)
 	}
 
-	if _, err := query.Exec(); err != nil {
+	if _, err := query.ExecContext(s.context()); err != nil {
 		s.logger.Error("Cannot create subscription",
 			mlog.String("block_id", sub.BlockID),
 			mlog.String("subscriber_id", sub.SubscriberID),
@@ -103,7 +104,7 @@ func (s *SQLStore) deleteSubscription(db sq.BaseRunner, blockID string, subscrib
 		Where(sq.Eq{"block_id": blockID}).
 		Where(sq.Eq{"subscriber_id": subscriberID})
 
-	result, err := query.Exec()
+	result, err := query.ExecContext(s.context())
 	if err != nil {
 		return err
 	}
@@ -129,7 +130,7 @@ func (s *SQLStore) getSubscription(db sq.BaseRunner, blockID string, subscriberI
 		Where(sq.Eq{"subscriber_id": subscriberID}).
 		Where(sq.Eq{"delete_at": 0})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("Cannot fetch subscription for block & subscriber",
 			mlog.String("block_id", blockID),
@@ -155,15 +156,22 @@ func (s *SQLStore) getSubscription(db sq.BaseRunner, blockID string, subscriberI
 	return subscriptions[0], nil
 }
 
-// getSubscriptions fetches all subscriptions for a specific subscriber.
-func (s *SQLStore) getSubscriptions(db sq.BaseRunner, subscriberID string) ([]*model.Subscription, error) {
+// getSubscriptions fetches the subscriptions for a specific subscriber, oldest first.
+func (s *SQLStore) getSubscriptions(db sq.BaseRunner, subscriberID string, opts model.QuerySubscriptionsOptions) ([]*model.Subscription, error) {
 	query := s.getQueryBuilder(db).
 		Select(subscriptionFields...).
 		From(s.tablePrefix + "subscriptions").
 		Where(sq.Eq{"subscriber_id": subscriberID}).
-		Where(sq.Eq{"delete_at": 0})
+		Where(sq.Eq{"delete_at": 0}).
+		OrderBy("create_at, block_id")
+
+	if opts.PerPage > 0 {
+		query = query.
+			Limit(uint64(opts.PerPage)).
+			Offset(uint64(opts.Page * opts.PerPage))
+	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("Cannot fetch subscriptions for subscriber",
 			mlog.String("subscriber_id", subscriberID),
@@ -189,7 +197,7 @@ func (s *SQLStore) getSubscribersForBlock(db sq.BaseRunner, blockID string) ([]*
 		Where(sq.Eq{"delete_at": 0}).
 		OrderBy("notified_at")
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("Cannot fetch subscribers for block",
 			mlog.String("block_id", blockID),
@@ -224,7 +232,7 @@ func (s *SQLStore) getSubscribersCountForBlock(db sq.BaseRunner, blockID string)
 		Where(sq.Eq{"block_id": blockID}).
 		Where(sq.Eq{"delete_at": 0})
 
-	row := query.QueryRow()
+	row := query.QueryRowContext(s.context())
 
 	var count int
 	err := row.Scan(&count)
@@ -246,7 +254,7 @@ func (s *SQLStore) updateSubscribersNotifiedAt(db sq.BaseRunner, blockID string,
 		Where(sq.Eq{"block_id": blockID}).
 		Where(sq.Eq{"delete_at": 0})
 
-	if _, err := query.Exec(); err != nil {
+	if _, err := query.ExecContext(s.context()); err != nil {
 		s.logger.Error("UpdateSubscribersNotifiedAt error occurred while updating subscriber(s)",
 			mlog.String("blockID", blockID),
 			mlog.Err(err),
@@ -255,3 +263,21 @@ func (s *SQLStore) updateSubscribersNotifiedAt(db sq.BaseRunner, blockID string,
 	}
 	return nil
 }
+
+// cleanUpStaleSubscriptions deletes the subscriptions to blocks and boards
+// that were permanently deleted, returning the number of deleted rows.
+func (s *SQLStore) cleanUpStaleSubscriptions(db sq.BaseRunner) (int64, error) {
+	subscriptionsTable := s.tablePrefix + "subscriptions"
+	query := s.getQueryBuilder(db).
+		Delete(subscriptionsTable).
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]sblocks WHERE %[1]sblocks.id = %[2]s.block_id)", s.tablePrefix, subscriptionsTable)).
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]sboards WHERE %[1]sboards.id = %[2]s.block_id)", s.tablePrefix, subscriptionsTable))
+
+	result, err := query.ExecContext(s.context())
+	if err != nil {
+		s.logger.Error("Cannot clean up stale subscriptions", mlog.Err(err))
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
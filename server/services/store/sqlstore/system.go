@@ -13,7 +13,7 @@ func (s *SQLStore) getSystemSetting(db sq.BaseRunner, key string) (string, error
 		Select("value").
 		From(s.tablePrefix + "system_settings").
 		Where(sq.Eq{"id": key}).
-		QueryRow()
+		QueryRowContext(s.context())
 
 	var result string
 	err := scanner.Scan(&result)
@@ -27,7 +27,7 @@ func (s *SQLStore) getSystemSetting(db sq.BaseRunner, key string) (string, error
 func (s *SQLStore) getSystemSettings(db sq.BaseRunner) (map[string]string, error) {
 	query := s.getQueryBuilder(db).Select("*").From(s.tablePrefix + "system_settings")
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +59,7 @@ func (s *SQLStore) setSystemSetting(db sq.BaseRunner, id, value string) error {
 		query = query.Suffix("ON CONFLICT (id) DO UPDATE SET value = EXCLUDED.value")
 	}
 
-	_, err := query.Exec()
+	_, err := query.ExecContext(s.context())
 	if err != nil {
 		return err
 	}
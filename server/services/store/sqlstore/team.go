@@ -49,7 +49,7 @@ func (s *SQLStore) upsertTeamSignupToken(db sq.BaseRunner, team model.Team) erro
 		)
 	}
 
-	_, err := query.Exec()
+	_, err := query.ExecContext(s.context())
 	return err
 }
 
@@ -87,7 +87,7 @@ func (s *SQLStore) upsertTeamSettings(db sq.BaseRunner, team model.Team) error {
 		)
 	}
 
-	_, err = query.Exec()
+	_, err = query.ExecContext(s.context())
 	return err
 }
 
@@ -104,7 +104,7 @@ func (s *SQLStore) getTeam(db sq.BaseRunner, id string) (*model.Team, error) {
 		).
 		From(s.tablePrefix + "teams").
 		Where(sq.Eq{"id": id})
-	row := query.QueryRow()
+	row := query.QueryRowContext(s.context())
 	team := model.Team{}
 
 	err := row.Scan(
@@ -138,7 +138,7 @@ func (s *SQLStore) getTeamCount(db sq.BaseRunner) (int64, error) {
 		).
 		From(s.tablePrefix + "teams")
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("ERROR GetTeamCount", mlog.Err(err))
 		return 0, err
@@ -189,7 +189,7 @@ func (s *SQLStore) getAllTeams(db sq.BaseRunner) ([]*model.Team, error) {
 	query := s.getQueryBuilder(db).
 		Select(teamFields...).
 		From(s.tablePrefix + "teams")
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error("ERROR GetAllTeams", mlog.Err(err))
 		return nil, err
@@ -27,7 +27,7 @@ func (s *SQLStore) removeDefaultTemplates(db sq.BaseRunner, boards []*model.Boar
 			Where(sq.Eq{"id": board.ID}).
 			Where(sq.Eq{"is_template": true})
 
-		if _, err := deleteQuery.Exec(); err != nil {
+		if _, err := deleteQuery.ExecContext(s.context()); err != nil {
 			return fmt.Errorf("cannot delete default template %s: %w", board.ID, err)
 		}
 
@@ -39,7 +39,7 @@ func (s *SQLStore) removeDefaultTemplates(db sq.BaseRunner, boards []*model.Boar
 				sq.Eq{"board_id": board.ID},
 			})
 
-		if _, err := deleteQuery.Exec(); err != nil {
+		if _, err := deleteQuery.ExecContext(s.context()); err != nil {
 			return fmt.Errorf("cannot delete default template %s: %w", board.ID, err)
 		}
 
@@ -57,9 +57,9 @@ func (s *SQLStore) removeDefaultTemplates(db sq.BaseRunner, boards []*model.Boar
 // getTemplateBoards fetches all template boards .
 func (s *SQLStore) getTemplateBoards(db sq.BaseRunner, teamID, userID string) ([]*model.Board, error) {
 	query := s.getQueryBuilder(db).
-		Select(boardFields("")...).
+		Select(boardFields("b.")...).
 		From(s.tablePrefix+"boards as b").
-		LeftJoin(s.tablePrefix+"board_members as bm on b.id = bm.board_id and bm.user_id = ?", userID).
+		LeftJoin(s.tablePrefix+"board_members as bm on b.id = bm.board_id and bm.user_id = ? and bm.delete_at = 0", userID).
 		Where(sq.Eq{"is_template": true}).
 		Where(sq.Eq{"b.team_id": teamID}).
 		Where(sq.Or{
@@ -73,7 +73,7 @@ func (s *SQLStore) getTemplateBoards(db sq.BaseRunner, teamID, userID string) ([
 			},
 		})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getTemplateBoards ERROR`, mlog.Err(err))
 		return nil, err
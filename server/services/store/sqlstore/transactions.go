@@ -0,0 +1,52 @@
+package sqlstore
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// RunInTransaction calls fn with a copy of the store bound to a new
+// transaction. Calls made while the store is already bound to a
+// transaction join it.
+func (s *SQLStore) RunInTransaction(fn func(tx store.Store) error) error {
+	if s.txRunner != nil {
+		return fn(s)
+	}
+
+	if s.dbType == model.SqliteDBType {
+		// the transaction is the only writer, so the other writers wait
+		// for it instead of failing with "database is locked".
+		s.writeMux.Lock()
+		defer s.writeMux.Unlock()
+	}
+
+	for attempt := 1; ; attempt++ {
+		tx, err := s.db.BeginTx(s.context(), nil)
+		if err != nil {
+			return err
+		}
+
+		txStore := *s
+		txStore.txRunner = tx
+
+		if err = fn(&txStore); err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RunInTransaction"))
+			}
+			if s.shouldRetryTransaction(err, attempt, "RunInTransaction") {
+				continue
+			}
+			return err
+		}
+
+		if err = tx.Commit(); err != nil {
+			if s.shouldRetryTransaction(err, attempt, "RunInTransaction") {
+				continue
+			}
+			return err
+		}
+		return nil
+	}
+}
@@ -3,11 +3,13 @@ package sqlstore
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -26,7 +28,7 @@ func (s *SQLStore) getRegisteredUserCount(db sq.BaseRunner) (int, error) {
 		Select("count(*)").
 		From(s.tablePrefix + "users").
 		Where(sq.Eq{"delete_at": 0})
-	row := query.QueryRow()
+	row := query.QueryRowContext(s.context())
 
 	var count int
 	err := row.Scan(&count)
@@ -73,7 +75,7 @@ func (s *SQLStore) getUsersByCondition(db sq.BaseRunner, condition interface{},
 		query = query.Limit(limit)
 	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(s.context())
 	if err != nil {
 		s.logger.Error(`getUsersByCondition ERROR`, mlog.Err(err))
 		return nil, err
@@ -96,6 +98,19 @@ func (s *SQLStore) getUserByID(db sq.BaseRunner, userID string) (*model.User, er
 	return s.getUserByCondition(db, sq.Eq{"id": userID})
 }
 
+// getUsersByIDs returns the users with the IDs, in any order. The users
+// not found are left out.
+func (s *SQLStore) getUsersByIDs(db sq.BaseRunner, userIDs []string) ([]*model.User, error) {
+	if len(userIDs) == 0 {
+		return []*model.User{}, nil
+	}
+	users, err := s.getUsersByCondition(db, sq.Eq{"id": userIDs}, 0)
+	if errors.Is(err, sql.ErrNoRows) {
+		return []*model.User{}, nil
+	}
+	return users, err
+}
+
 func (s *SQLStore) getUserByEmail(db sq.BaseRunner, email string) (*model.User, error) {
 	return s.getUserByCondition(db, sq.Eq{"email": email})
 }
@@ -116,7 +131,7 @@ func (s *SQLStore) createUser(db sq.BaseRunner, user *model.User) error {
 		Columns("id", "username", "email", "password", "mfa_secret", "auth_service", "auth_data", "props", "create_at", "update_at", "delete_at").
 		Values(user.ID, user.Username, user.Email, user.Password, user.MfaSecret, user.AuthService, user.AuthData, propsBytes, now, now, 0)
 
-	_, err = query.Exec()
+	_, err = query.ExecContext(s.context())
 	return err
 }
 
@@ -135,7 +150,7 @@ func (s *SQLStore) updateUser(db sq.BaseRunner, user *model.User) error {
 		Set("update_at", now).
 		Where(sq.Eq{"id": user.ID})
 
-	result, err := query.Exec()
+	result, err := query.ExecContext(s.context())
 	if err != nil {
 		return err
 	}
@@ -160,7 +175,7 @@ func (s *SQLStore) updateUserPassword(db sq.BaseRunner, username, password strin
 		Set("update_at", now).
 		Where(sq.Eq{"username": username})
 
-	result, err := query.Exec()
+	result, err := query.ExecContext(s.context())
 	if err != nil {
 		return err
 	}
@@ -185,7 +200,7 @@ func (s *SQLStore) updateUserPasswordByID(db sq.BaseRunner, userID, password str
 		Set("update_at", now).
 		Where(sq.Eq{"id": userID})
 
-	result, err := query.Exec()
+	result, err := query.ExecContext(s.context())
 	if err != nil {
 		return err
 	}
@@ -202,6 +217,33 @@ func (s *SQLStore) updateUserPasswordByID(db sq.BaseRunner, userID, password str
 	return nil
 }
 
+// updateUserDeleteAt deactivates the user when deleteAt is set, or
+// reactivates them when it is zero.
+func (s *SQLStore) updateUserDeleteAt(db sq.BaseRunner, username string, deleteAt int64) error {
+	now := utils.GetMillis()
+
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
+		Set("delete_at", deleteAt).
+		Set("update_at", now).
+		Where(sq.Eq{"username": username})
+
+	result, err := query.ExecContext(s.context())
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount < 1 {
+		return store.NewErrNotFound(username)
+	}
+
+	return nil
+}
+
 func (s *SQLStore) getUsersByTeam(db sq.BaseRunner, _ string) ([]*model.User, error) {
 	return s.getUsersByCondition(db, nil, 0)
 }
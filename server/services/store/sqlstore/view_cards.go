@@ -0,0 +1,236 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// literalIDRegexp matches the IDs the view cards queries embed as
+// literals. The card orders and ranks of a view can hold more IDs than
+// SQLite allows placeholders, and the IDs not matching are treated as
+// unknown.
+var literalIDRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,36}$`)
+
+// caseExpression returns a CASE expression mapping the value to the
+// position or rank of each ID, and to the default otherwise.
+func caseExpression(value string, ids map[string]int, def string) string {
+	if len(ids) == 0 {
+		return def
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "CASE %s", value)
+	for id, n := range ids {
+		if literalIDRegexp.MatchString(id) {
+			fmt.Fprintf(&b, " WHEN '%s' THEN %d", id, n)
+		}
+	}
+	fmt.Fprintf(&b, " ELSE %s END", def)
+	return b.String()
+}
+
+// listItemValueExpression returns the SQL expression that extracts the
+// first item of a card property holding a list.
+func (s *SQLStore) listItemValueExpression(propertyID string) string {
+	switch {
+	case s.isPostgresCompatible():
+		return fmt.Sprintf("(fields->'properties'->'%s'->>0)", propertyID)
+	case s.dbType == model.MysqlDBType:
+		return fmt.Sprintf(`IF(JSON_VALID(fields), JSON_UNQUOTE(JSON_EXTRACT(fields, '$.properties."%s"[0]')), NULL)`, propertyID)
+	default:
+		return fmt.Sprintf(`json_extract(fields, '$.properties."%s"[0]')`, propertyID)
+	}
+}
+
+// dateFromExpression returns the SQL expression that extracts the start
+// of the date range a date property stores as JSON, or NULL.
+func (s *SQLStore) dateFromExpression(value string) string {
+	switch {
+	case s.isPostgresCompatible():
+		return fmt.Sprintf(`CAST(substring(%s from '"from":\s*(-{0,1}[0-9]+)') AS BIGINT)`, value)
+	case s.dbType == model.MysqlDBType:
+		return fmt.Sprintf("IF(JSON_VALID(%[1]s), CAST(JSON_EXTRACT(%[1]s, '$.from') AS SIGNED), NULL)", value)
+	default:
+		return fmt.Sprintf("CASE WHEN json_valid(%[1]s) THEN CAST(json_extract(%[1]s, '$.from') AS INTEGER) END", value)
+	}
+}
+
+// titleOrCreatedOrder orders the cards by title, the untitled ones last
+// by creation time. If only is set, the terms only apply to the cards
+// matching the condition.
+func titleOrCreatedOrder(direction, only string) []string {
+	terms := []string{"CASE WHEN title = '' THEN 1 ELSE 0 END", "LOWER(title)", "title", "create_at"}
+	for i, term := range terms {
+		if only != "" {
+			term = fmt.Sprintf("CASE WHEN %s THEN %s END", only, term)
+		}
+		terms[i] = term + " " + direction
+	}
+	return terms
+}
+
+// viewCardsSortOrder returns the ORDER BY terms of a sort option, as
+// model.SortCardsForView compares the cards.
+func (s *SQLStore) viewCardsSortOrder(sort model.ViewCardsSort) ([]string, error) {
+	direction := "ASC"
+	if sort.Reversed {
+		direction = "DESC"
+	}
+
+	switch sort.Kind {
+	case model.ViewCardsSortByTitle:
+		return titleOrCreatedOrder(direction, ""), nil
+	case model.ViewCardsSortByCreateAt:
+		return append([]string{"create_at " + direction}, titleOrCreatedOrder(direction, "")...), nil
+	case model.ViewCardsSortByUpdateAt:
+		return append([]string{"update_at " + direction}, titleOrCreatedOrder(direction, "")...), nil
+	}
+
+	var value string
+	if sort.Kind == model.ViewCardsSortByCreatedBy || sort.Kind == model.ViewCardsSortByUpdatedBy {
+		column := "created_by"
+		if sort.Kind == model.ViewCardsSortByUpdatedBy {
+			column = "modified_by"
+		}
+		value = caseExpression(column, sort.Ranks, "NULL")
+	} else {
+		if !model.IsValidPropertyIndexID(sort.PropertyID) {
+			return nil, model.NewCodedError(model.ErrCodeBadRequest, "invalid property ID", map[string]interface{}{"propertyId": sort.PropertyID})
+		}
+		property := s.propertyValueExpression(sort.PropertyID)
+		if sort.List {
+			property = s.listItemValueExpression(sort.PropertyID)
+		}
+		property = fmt.Sprintf("NULLIF(%s, '')", property)
+
+		switch sort.Kind {
+		case model.ViewCardsSortByNumber:
+			// the values that aren't numbers sort as 0
+			value = fmt.Sprintf("CASE WHEN %s IS NOT NULL THEN COALESCE(%s, 0) END", property, s.numericPropertyValueExpression(sort.PropertyID))
+		case model.ViewCardsSortByDate:
+			value = fmt.Sprintf("CASE WHEN %s IS NOT NULL THEN COALESCE(%s, 0) END", property, s.dateFromExpression(property))
+		case model.ViewCardsSortByOption:
+			value = fmt.Sprintf("CASE WHEN %s IS NOT NULL THEN %s END", property, caseExpression(property, sort.Ranks, "-1"))
+		case model.ViewCardsSortByText:
+			value = property
+		default:
+			return nil, model.NewCodedError(model.ErrCodeBadRequest, "invalid view sort", map[string]interface{}{"kind": sort.Kind})
+		}
+	}
+
+	// the cards without a value come last, whatever the direction, by
+	// title.
+	hasValue := value + " IS NOT NULL"
+	terms := []string{fmt.Sprintf("CASE WHEN %s THEN 0 ELSE 1 END", hasValue)}
+	if sort.Kind == model.ViewCardsSortByText {
+		terms = append(terms, fmt.Sprintf("LOWER(%s) %s", value, direction))
+	}
+	terms = append(terms, value+" "+direction)
+	terms = append(terms, titleOrCreatedOrder(direction, hasValue)...)
+	terms = append(terms, titleOrCreatedOrder("ASC", value+" IS NULL")...)
+	return terms, nil
+}
+
+// getViewCards returns the window of the cards of the board in the order
+// of the view, and the number of cards of the board. The card templates
+// are left out.
+func (s *SQLStore) getViewCards(db sq.BaseRunner, boardID string, opts model.QueryViewCardsOptions) ([]model.Block, int, error) {
+	var orderBy []string
+	if len(opts.Sorts) == 0 {
+		// the cards missing from the manual order come last, by title
+		positions := make(map[string]int, len(opts.CardOrder))
+		for i, id := range opts.CardOrder {
+			positions[id] = i
+		}
+		position := caseExpression("id", positions, "NULL")
+		orderBy = append(orderBy, fmt.Sprintf("CASE WHEN %s IS NULL THEN 1 ELSE 0 END", position), position)
+		orderBy = append(orderBy, titleOrCreatedOrder("ASC", "")...)
+	}
+	for _, sort := range opts.Sorts {
+		terms, err := s.viewCardsSortOrder(sort)
+		if err != nil {
+			return nil, 0, err
+		}
+		orderBy = append(orderBy, terms...)
+	}
+	orderBy = append(orderBy, "id")
+
+	cards := s.getQueryBuilder(db).
+		Select().
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Eq{"delete_at": 0}).
+		Where(s.notCardTemplateCondition())
+
+	var total int
+	if err := cards.Columns("COUNT(*)").QueryRowContext(s.context()).Scan(&total); err != nil {
+		s.logger.Error("getViewCards count ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, 0, err
+	}
+
+	query := cards.Columns(s.blockFields()...).OrderBy(orderBy...)
+	if opts.Limit > 0 {
+		query = query.Limit(uint64(opts.Limit)).Offset(uint64(opts.Offset))
+	}
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("getViewCards ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, 0, err
+	}
+	defer s.CloseRows(rows)
+
+	blocks, err := s.blocksFromRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return blocks, total, nil
+}
+
+// getCardUserIDs returns the IDs of the users who created or last
+// modified the cards of the board, the card templates left out.
+func (s *SQLStore) getCardUserIDs(db sq.BaseRunner, boardID string) ([]string, error) {
+	userIDs := []string{}
+	seen := map[string]bool{}
+	for _, column := range []string{"created_by", "modified_by"} {
+		query := s.getQueryBuilder(db).
+			Select(column).
+			Distinct().
+			From(s.tablePrefix + "blocks").
+			Where(sq.Eq{"board_id": boardID}).
+			Where(sq.Eq{"type": model.TypeCard}).
+			Where(sq.Eq{"delete_at": 0}).
+			Where(s.notCardTemplateCondition())
+
+		rows, err := query.QueryContext(s.context())
+		if err != nil {
+			s.logger.Error("getCardUserIDs ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+			return nil, err
+		}
+
+		for rows.Next() {
+			var userID sql.NullString
+			if err := rows.Scan(&userID); err != nil {
+				s.CloseRows(rows)
+				return nil, err
+			}
+			if userID.String != "" && !seen[userID.String] {
+				seen[userID.String] = true
+				userIDs = append(userIDs, userID.String)
+			}
+		}
+		err = rows.Err()
+		s.CloseRows(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,179 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var webhookDeliveryFields = []string{
+	"id",
+	"COALESCE(webhook_id, '')",
+	"url",
+	"payload",
+	"attempts",
+	"COALESCE(last_error, '')",
+	"status",
+	"next_attempt_at",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) webhookDeliveriesFromRows(rows *sql.Rows) ([]*model.WebhookDelivery, error) {
+	deliveries := []*model.WebhookDelivery{}
+
+	for rows.Next() {
+		var delivery model.WebhookDelivery
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.URL,
+			&delivery.Payload,
+			&delivery.Attempts,
+			&delivery.LastError,
+			&delivery.Status,
+			&delivery.NextAttemptAt,
+			&delivery.CreateAt,
+			&delivery.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+func (s *SQLStore) insertWebhookDelivery(db sq.BaseRunner, delivery *model.WebhookDelivery) error {
+	if delivery.ID == "" {
+		delivery.ID = utils.NewID(utils.IDTypeNone)
+	}
+	now := model.GetMillis()
+	if delivery.CreateAt == 0 {
+		delivery.CreateAt = now
+	}
+	delivery.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"webhook_deliveries").
+		Columns("id", "webhook_id", "url", "payload", "attempts", "last_error", "status", "next_attempt_at", "create_at", "update_at").
+		Values(
+			delivery.ID,
+			delivery.WebhookID,
+			delivery.URL,
+			delivery.Payload,
+			delivery.Attempts,
+			delivery.LastError,
+			delivery.Status,
+			delivery.NextAttemptAt,
+			delivery.CreateAt,
+			delivery.UpdateAt,
+		)
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("Cannot insert webhook delivery", mlog.String("url", delivery.URL), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) updateWebhookDelivery(db sq.BaseRunner, delivery *model.WebhookDelivery) error {
+	delivery.UpdateAt = model.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"webhook_deliveries").
+		Set("attempts", delivery.Attempts).
+		Set("last_error", delivery.LastError).
+		Set("status", delivery.Status).
+		Set("next_attempt_at", delivery.NextAttemptAt).
+		Set("update_at", delivery.UpdateAt).
+		Where(sq.Eq{"id": delivery.ID})
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("Cannot update webhook delivery", mlog.String("id", delivery.ID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteWebhookDelivery(db sq.BaseRunner, id string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "webhook_deliveries").
+		Where(sq.Eq{"id": id})
+
+	if _, err := query.ExecContext(s.context()); err != nil {
+		s.logger.Error("Cannot delete webhook delivery", mlog.String("id", id), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) getWebhookDelivery(db sq.BaseRunner, id string) (*model.WebhookDelivery, error) {
+	query := s.getQueryBuilder(db).
+		Select(webhookDeliveryFields...).
+		From(s.tablePrefix + "webhook_deliveries").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("Cannot fetch webhook delivery", mlog.String("id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	deliveries, err := s.webhookDeliveriesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, store.NewErrNotFound(id)
+	}
+	return deliveries[0], nil
+}
+
+// getDueWebhookDeliveries returns the pending deliveries whose next
+// attempt is due, oldest first.
+func (s *SQLStore) getDueWebhookDeliveries(db sq.BaseRunner, now int64, limit uint64) ([]*model.WebhookDelivery, error) {
+	query := s.getQueryBuilder(db).
+		Select(webhookDeliveryFields...).
+		From(s.tablePrefix + "webhook_deliveries").
+		Where(sq.Eq{"status": model.WebhookDeliveryPending}).
+		Where(sq.LtOrEq{"next_attempt_at": now}).
+		OrderBy("next_attempt_at").
+		Limit(limit)
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("Cannot fetch due webhook deliveries", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.webhookDeliveriesFromRows(rows)
+}
+
+// getDeadWebhookDeliveries returns a page of the dead-letter queue,
+// newest first.
+func (s *SQLStore) getDeadWebhookDeliveries(db sq.BaseRunner, page, perPage int) ([]*model.WebhookDelivery, error) {
+	query := s.getQueryBuilder(db).
+		Select(webhookDeliveryFields...).
+		From(s.tablePrefix+"webhook_deliveries").
+		Where(sq.Eq{"status": model.WebhookDeliveryDead}).
+		OrderBy("update_at DESC", "id").
+		Offset(uint64(page * perPage)).
+		Limit(uint64(perPage))
+
+	rows, err := query.QueryContext(s.context())
+	if err != nil {
+		s.logger.Error("Cannot fetch dead webhook deliveries", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.webhookDeliveriesFromRows(rows)
+}
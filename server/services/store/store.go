@@ -3,6 +3,7 @@
 package store
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -19,8 +20,12 @@ type Store interface {
 	GetBlocksWithParent(boardID, parentID string) ([]model.Block, error)
 	GetBlocksWithBoardID(boardID string) ([]model.Block, error)
 	GetBlocksWithType(boardID, blockType string) ([]model.Block, error)
+	GetCardsWithFieldValue(boardIDs []string, value string) ([]model.Block, error)
 	GetSubTree2(boardID, blockID string, opts model.QuerySubtreeOptions) ([]model.Block, error)
 	GetBlocksForBoard(boardID string) ([]model.Block, error)
+	GetBlocksChangedSince(boardID string, since int64) ([]model.Block, error)
+	GetDeletedBlockIDsSince(boardID string, since int64) ([]string, error)
+	GetCardsUpdatedBefore(boardID string, before int64) ([]model.Block, error)
 	// @withTransaction
 	InsertBlock(block *model.Block, userID string) error
 	// @withTransaction
@@ -28,11 +33,14 @@ type Store interface {
 	// @withTransaction
 	InsertBlocks(blocks []model.Block, userID string) error
 	// @withTransaction
+	InsertNewBlocks(blocks []model.Block, userID string) error
+	// @withTransaction
 	UndeleteBlock(blockID string, modifiedBy string) error
 	// @withTransaction
 	UndeleteBoard(boardID string, modifiedBy string) error
 	GetBlockCountsByType() (map[string]int64, error)
 	GetBlock(blockID string) (*model.Block, error)
+	GetBlocksByIDs(ids []string) ([]model.Block, error)
 	// @withTransaction
 	PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error
 	GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error)
@@ -41,14 +49,26 @@ type Store interface {
 	GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error)
 	GetBoardAndCard(block *model.Block) (board *model.Board, card *model.Block, err error)
 	// @withTransaction
-	DuplicateBoard(boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error)
+	DuplicateBoard(boardID string, userID string, toTeam string, asTemplate bool, userMapping model.UserMapping) (*model.BoardsAndBlocks, []*model.BoardMember, error)
 	// @withTransaction
 	DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]model.Block, error)
 	// @withTransaction
+	MoveBlocks(blocks []model.Block, toBoardID string, userID string) ([]model.Block, error)
+	// @withTransaction
 	PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error
 
 	Shutdown() error
 
+	// RunInTransaction calls fn with a store whose methods all run in the
+	// same transaction, which is committed if fn returns nil and rolled
+	// back otherwise. fn must only use the store it is given, and may be
+	// called more than once if the transaction has to be retried.
+	RunInTransaction(fn func(tx Store) error) error
+
+	// WithContext returns a copy of the store whose queries run with ctx,
+	// so they are cancelled along with it.
+	WithContext(ctx context.Context) Store
+
 	GetSystemSetting(key string) (string, error)
 	GetSystemSettings() (map[string]string, error)
 	SetSystemSetting(key, value string) error
@@ -57,10 +77,12 @@ type Store interface {
 	GetUserByID(userID string) (*model.User, error)
 	GetUserByEmail(email string) (*model.User, error)
 	GetUserByUsername(username string) (*model.User, error)
+	GetUsersByIDs(userIDs []string) ([]*model.User, error)
 	CreateUser(user *model.User) error
 	UpdateUser(user *model.User) error
 	UpdateUserPassword(username, password string) error
 	UpdateUserPasswordByID(userID, password string) error
+	UpdateUserDeleteAt(username string, deleteAt int64) error
 	GetUsersByTeam(teamID string) ([]*model.User, error)
 	SearchUsersByTeam(teamID string, searchQuery string) ([]*model.User, error)
 	PatchUserProps(userID string, patch model.UserPropPatch) error
@@ -71,6 +93,7 @@ type Store interface {
 	RefreshSession(session *model.Session) error
 	UpdateSession(session *model.Session) error
 	DeleteSession(sessionID string) error
+	DeleteSessionsForUser(userID string) error
 	CleanUpSessions(expireTime int64) error
 
 	UpsertSharing(sharing model.Sharing) error
@@ -82,6 +105,11 @@ type Store interface {
 	GetTeamsForUser(userID string) ([]*model.Team, error)
 	GetAllTeams() ([]*model.Team, error)
 	GetTeamCount() (int64, error)
+	GetBoardCount() (int64, error)
+	GetTableStats() ([]*model.TableStats, error)
+	GetHistoryStatsByTeam() ([]*model.TeamHistoryStats, error)
+	GetMigrationStatus(includeSQL bool) (*model.MigrationStatus, error)
+	RunMaintenance() (*model.MaintenanceReport, error)
 
 	InsertBoard(board *model.Board, userID string) (*model.Board, error)
 	// @withTransaction
@@ -90,17 +118,67 @@ type Store interface {
 	PatchBoard(boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error)
 	GetBoard(id string) (*model.Board, error)
 	GetBoardsForUserAndTeam(userID, teamID string) ([]*model.Board, error)
+	GetBoardsForChannel(channelID string) ([]*model.Board, error)
 	// @withTransaction
 	DeleteBoard(boardID, userID string) error
 
 	SaveMember(bm *model.BoardMember) (*model.BoardMember, error)
+	// @withTransaction
+	JoinBoard(bm *model.BoardMember) (*model.BoardMember, error)
+	// @withTransaction
+	SaveMemberRoles(bm *model.BoardMember, reason string) (*model.BoardMember, error)
 	DeleteMember(boardID, userID string) error
+	// @withTransaction
+	RestoreMember(boardID, userID string) (*model.BoardMember, error)
 	GetMemberForBoard(boardID, userID string) (*model.BoardMember, error)
+	AddBoardToTeam(boardID, teamID, userID string) error
+	RemoveBoardFromTeam(boardID, teamID string) error
+	GetSharedTeamIDsForBoard(boardID string) ([]string, error)
+	// @withTransaction
+	SetBoardKey(boardID, key string) error
+	GetBoardKey(boardID string) (string, error)
+	GetBoardIDForKey(key string) (string, error)
+	GetCardIDForNumber(boardID string, number int64) (string, error)
+	CreatePropertyIndex(boardID, propertyID string) error
+	DeletePropertyIndex(boardID, propertyID string) error
+	GetPropertyIndexes(boardID string) ([]*model.PropertyIndex, error)
+	GetIndexedPropertyIDs() ([]string, error)
+	GetCardsWithPropertyValues(boardID, propertyID string, values []string) ([]model.Block, error)
+	GetCardGroups(boardID, groupByPropertyID, sumPropertyID string) ([]*model.CardGroup, error)
+	GetSwimlaneCells(boardID, rowPropertyID, columnPropertyID string) ([]*model.SwimlaneCell, error)
+	GetViewCards(boardID string, opts model.QueryViewCardsOptions) ([]model.Block, int, error)
+	GetCardUserIDs(boardID string) ([]string, error)
+	GetCardsStatusSince(boardID, propertyID string, cardIDs []string) (map[string]int64, error)
+	GetBoardIDsForTeam(teamID string) ([]string, error)
+	GetNonTemplateBoardIDs() ([]string, error)
+	// @withTransaction
+	MoveBoardToTeam(boardID, teamID string) error
 	GetBoardMemberHistory(boardID, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error)
 	GetMembersForBoard(boardID string) ([]*model.BoardMember, error)
+	GetDeletedMembersForBoard(boardID string) ([]*model.BoardMember, error)
+	UpdateMemberCustomFields(boardID, userID string, customFields map[string]interface{}) error
 	GetMembersForUser(userID string) ([]*model.BoardMember, error)
+	// @withTransaction
+	SetMembershipsInactive(userID string, inactive bool) ([]*model.BoardMember, error)
+
+	// @withTransaction
+	CreateBoardInvitation(invitation *model.BoardInvitation) (*model.BoardInvitation, error)
+	GetBoardInvitation(boardID, userID string) (*model.BoardInvitation, error)
+	GetInvitationsForBoard(boardID string) ([]*model.BoardInvitation, error)
+	GetInvitationsForUser(userID string) ([]*model.BoardInvitation, error)
+	// @withTransaction
+	AcceptBoardInvitation(boardID, userID string) (*model.BoardMember, error)
+	// @withTransaction
+	DeleteBoardInvitation(boardID, userID, action string) error
 	SearchBoardsForUserAndTeam(term, userID, teamID string) ([]*model.Board, error)
 
+	// @withTransaction
+	SaveBoardVisits(visits []*model.BoardVisit) error
+	GetRecentBoardsForUser(userID, teamID string, limit uint64) ([]*model.Board, error)
+	// @withTransaction
+	SaveBoardMemberActivity(activities []*model.BoardMemberActivity) error
+	GetBoardMemberActivity(boardID string) ([]*model.BoardMemberActivity, error)
+
 	// @withTransaction
 	CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error)
 	// @withTransaction
@@ -114,6 +192,12 @@ type Store interface {
 	CreateCategory(category model.Category) error
 	UpdateCategory(category model.Category) error
 	DeleteCategory(categoryID, userID, teamID string) error
+	GetUserCategories(userID, teamID string) ([]model.Category, error)
+	ReorderCategories(userID, teamID string, newCategoryOrder []string) error
+
+	GetCategoryRules(userID, teamID string) ([]*model.CategoryRule, error)
+	CreateCategoryRule(rule *model.CategoryRule) error
+	DeleteCategoryRule(ruleID, userID string) error
 
 	GetUserCategoryBoards(userID, teamID string) ([]model.CategoryBoards, error)
 	AddUpdateCategoryBoard(userID, categoryID, blockID string) error
@@ -121,10 +205,75 @@ type Store interface {
 	CreateSubscription(sub *model.Subscription) (*model.Subscription, error)
 	DeleteSubscription(blockID string, subscriberID string) error
 	GetSubscription(blockID string, subscriberID string) (*model.Subscription, error)
-	GetSubscriptions(subscriberID string) ([]*model.Subscription, error)
+	GetSubscriptions(subscriberID string, opts model.QuerySubscriptionsOptions) ([]*model.Subscription, error)
 	GetSubscribersForBlock(blockID string) ([]*model.Subscriber, error)
 	GetSubscribersCountForBlock(blockID string) (int, error)
 	UpdateSubscribersNotifiedAt(blockID string, notifiedAt int64) error
+	CleanUpStaleSubscriptions() (int64, error)
+
+	InsertMention(mention *model.Mention) error
+	GetMentionsForUser(userID string, opts model.QueryMentionsOptions) ([]*model.Mention, error)
+	MarkMentionsRead(userID string, mentionIDs []string, readAt int64) error
+
+	GetDueDigestSettings(userID string) (*model.DueDigestSettings, error)
+	GetEnabledDueDigestSettings() ([]*model.DueDigestSettings, error)
+	SaveDueDigestSettings(settings *model.DueDigestSettings) error
+
+	GetStaleDigestSettings(userID string) (*model.StaleDigestSettings, error)
+	GetEnabledStaleDigestSettings() ([]*model.StaleDigestSettings, error)
+	SaveStaleDigestSettings(settings *model.StaleDigestSettings) error
+
+	// @withTransaction
+	SaveBoardSnapshot(snapshot *model.BoardSnapshot) error
+	GetBoardSnapshots(boardID, fromDay, toDay string) ([]*model.BoardSnapshot, error)
+
+	SaveBoardFreezeSchedule(schedule *model.BoardFreezeSchedule) error
+	GetBoardFreezeSchedule(boardID string) (*model.BoardFreezeSchedule, error)
+	DeleteBoardFreezeSchedule(boardID string) error
+	GetDueBoardFreezeSchedules(now int64) ([]*model.BoardFreezeSchedule, error)
+
+	SaveCalendarSubscription(subscription *model.CalendarSubscription) error
+	GetCalendarSubscription(boardID string) (*model.CalendarSubscription, error)
+	DeleteCalendarSubscription(boardID string) error
+	GetDueCalendarSubscriptions(syncedBefore int64) ([]*model.CalendarSubscription, error)
+
+	SaveGitHubIntegration(integration *model.GitHubIntegration) error
+	GetGitHubIntegration(boardID string) (*model.GitHubIntegration, error)
+	DeleteGitHubIntegration(boardID string) error
+
+	GetBlockLock(blockID string) (*model.BlockLock, error)
+	// @withTransaction
+	AcquireBlockLock(lock *model.BlockLock) (*model.BlockLock, error)
+	ReleaseBlockLock(blockID, userID string) error
+	GetBlockLocksForBoard(boardID string, now int64) ([]*model.BlockLock, error)
+
+	CreateBoardAccessRequest(request *model.BoardAccessRequest) (*model.BoardAccessRequest, error)
+	GetBoardAccessRequest(boardID, userID string) (*model.BoardAccessRequest, error)
+	GetAccessRequestsForBoard(boardID string) ([]*model.BoardAccessRequest, error)
+	// @withTransaction
+	ApproveBoardAccessRequest(member *model.BoardMember) (*model.BoardMember, error)
+	// @withTransaction
+	DeleteBoardAccessRequest(boardID, userID, action string) error
+
+	InsertDeferredNotification(notification *model.DeferredNotification) error
+	GetUsersWithDeferredNotifications() ([]string, error)
+	GetDeferredNotificationsForUser(userID string) ([]*model.DeferredNotification, error)
+	ClaimDeferredNotification(id, claimedBy string, staleBefore int64) (bool, error)
+	ReleaseDeferredNotification(id, claimedBy string) error
+	DeleteDeferredNotification(id string) error
+
+	InsertWebhookDelivery(delivery *model.WebhookDelivery) error
+	UpdateWebhookDelivery(delivery *model.WebhookDelivery) error
+	DeleteWebhookDelivery(id string) error
+	GetWebhookDelivery(id string) (*model.WebhookDelivery, error)
+	GetDueWebhookDeliveries(now int64, limit uint64) ([]*model.WebhookDelivery, error)
+	GetDeadWebhookDeliveries(page, perPage int) ([]*model.WebhookDelivery, error)
+
+	InsertBoardWebhook(webhook *model.BoardWebhook) error
+	UpdateBoardWebhook(webhook *model.BoardWebhook) error
+	DeleteBoardWebhook(id string) error
+	GetBoardWebhook(id string) (*model.BoardWebhook, error)
+	GetBoardWebhooksForBoard(boardID string) ([]*model.BoardWebhook, error)
 
 	UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error)
 	DeleteNotificationHint(blockID string) error
@@ -142,6 +291,8 @@ type Store interface {
 	IsErrNotFound(err error) bool
 
 	GetLicense() *mmModel.License
+	GetChannelMemberIDs(channelID string) ([]string, error)
+	GetChannel(channelID string) (*mmModel.Channel, error)
 }
 
 // ErrNotFound is an error type that can be returned by store APIs when a query unexpectedly fetches no records.
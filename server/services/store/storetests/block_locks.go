@@ -0,0 +1,127 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestBlockLocksStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("AcquireBlockLock", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testAcquireBlockLock(t, store)
+	})
+	t.Run("ReleaseBlockLock", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testReleaseBlockLock(t, store)
+	})
+	t.Run("GetBlockLocksForBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlockLocksForBoard(t, store)
+	})
+}
+
+func testAcquireBlockLock(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	blockID := utils.NewID(utils.IDTypeBlock)
+
+	_, err := store.GetBlockLock(blockID)
+	require.True(t, store.IsErrNotFound(err))
+
+	lock := &model.BlockLock{BlockID: blockID, BoardID: boardID, UserID: "user-1", CreateAt: 1000, ExpiresAt: 2000}
+	acquired, err := store.AcquireBlockLock(lock)
+	require.NoError(t, err)
+	require.Equal(t, "user-1", acquired.UserID)
+
+	saved, err := store.GetBlockLock(blockID)
+	require.NoError(t, err)
+	require.Equal(t, lock, saved)
+
+	t.Run("another user gets the active lock", func(t *testing.T) {
+		held, err := store.AcquireBlockLock(&model.BlockLock{BlockID: blockID, BoardID: boardID, UserID: "user-2", CreateAt: 1500, ExpiresAt: 2500})
+		require.NoError(t, err)
+		require.Equal(t, "user-1", held.UserID)
+		require.Equal(t, int64(2000), held.ExpiresAt)
+	})
+
+	t.Run("the holder renews the lock", func(t *testing.T) {
+		renewed, err := store.AcquireBlockLock(&model.BlockLock{BlockID: blockID, BoardID: boardID, UserID: "user-1", CreateAt: 1800, ExpiresAt: 2800})
+		require.NoError(t, err)
+		require.Equal(t, int64(1000), renewed.CreateAt)
+		require.Equal(t, int64(2800), renewed.ExpiresAt)
+	})
+
+	t.Run("another user takes the expired lock", func(t *testing.T) {
+		taken, err := store.AcquireBlockLock(&model.BlockLock{BlockID: blockID, BoardID: boardID, UserID: "user-2", CreateAt: 3000, ExpiresAt: 4000})
+		require.NoError(t, err)
+		require.Equal(t, "user-2", taken.UserID)
+
+		saved, err := store.GetBlockLock(blockID)
+		require.NoError(t, err)
+		require.Equal(t, "user-2", saved.UserID)
+		require.Equal(t, int64(3000), saved.CreateAt)
+	})
+}
+
+func testReleaseBlockLock(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	blockID := utils.NewID(utils.IDTypeBlock)
+
+	_, err := store.AcquireBlockLock(&model.BlockLock{BlockID: blockID, BoardID: boardID, UserID: "user-1", CreateAt: 1000, ExpiresAt: 2000})
+	require.NoError(t, err)
+
+	// only the holder releases the lock
+	require.NoError(t, store.ReleaseBlockLock(blockID, "user-2"))
+	_, err = store.GetBlockLock(blockID)
+	require.NoError(t, err)
+
+	require.NoError(t, store.ReleaseBlockLock(blockID, "user-1"))
+	_, err = store.GetBlockLock(blockID)
+	require.True(t, store.IsErrNotFound(err))
+}
+
+func testGetBlockLocksForBoard(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	block1 := utils.NewID(utils.IDTypeBlock)
+	block2 := utils.NewID(utils.IDTypeBlock)
+	block3 := utils.NewID(utils.IDTypeBlock)
+
+	locks, err := store.GetBlockLocksForBoard(boardID, 1000)
+	require.NoError(t, err)
+	require.Empty(t, locks)
+
+	_, err = store.AcquireBlockLock(&model.BlockLock{BlockID: block1, BoardID: boardID, UserID: "user-1", CreateAt: 1000, ExpiresAt: 2000})
+	require.NoError(t, err)
+	_, err = store.AcquireBlockLock(&model.BlockLock{BlockID: block2, BoardID: boardID, UserID: "user-2", CreateAt: 1100, ExpiresAt: 5000})
+	require.NoError(t, err)
+	_, err = store.AcquireBlockLock(&model.BlockLock{BlockID: block3, BoardID: "other-board", UserID: "user-1", CreateAt: 1200, ExpiresAt: 5000})
+	require.NoError(t, err)
+
+	locks, err = store.GetBlockLocksForBoard(boardID, 1500)
+	require.NoError(t, err)
+	require.Len(t, locks, 2)
+	require.Equal(t, block1, locks[0].BlockID)
+	require.Equal(t, block2, locks[1].BlockID)
+
+	// the expired locks are left out
+	locks, err = store.GetBlockLocksForBoard(boardID, 3000)
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	require.Equal(t, block2, locks[0].BlockID)
+
+	// and removed when another lock of the board is acquired
+	_, err = store.AcquireBlockLock(&model.BlockLock{BlockID: block3, BoardID: boardID, UserID: "user-1", CreateAt: 3000, ExpiresAt: 4000})
+	require.NoError(t, err)
+	_, err = store.GetBlockLock(block1)
+	require.True(t, store.IsErrNotFound(err))
+}
@@ -29,6 +29,11 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testInsertBlocks(t, store)
 	})
+	t.Run("InsertNewBlocks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testInsertNewBlocks(t, store)
+	})
 	t.Run("PatchBlock", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -54,6 +59,11 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testGetSubTree2(t, store)
 	})
+	t.Run("MoveBlocks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMoveBlocks(t, store)
+	})
 	t.Run("GetBlocks", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -64,6 +74,11 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testGetBlock(t, store)
 	})
+	t.Run("GetBlocksByIDs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlocksByIDs(t, store)
+	})
 	t.Run("DuplicateBlock", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -79,6 +94,21 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testGetBlockMetadata(t, store)
 	})
+	t.Run("GetCardsWithFieldValue", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCardsWithFieldValue(t, store)
+	})
+	t.Run("GetBlocksChangedSince", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlocksChangedSince(t, store)
+	})
+	t.Run("GetCardsUpdatedBefore", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCardsUpdatedBefore(t, store)
+	})
 }
 
 func testInsertBlock(t *testing.T, store store.Store) {
@@ -243,6 +273,63 @@ func testInsertBlocks(t *testing.T, store store.Store) {
 	})
 }
 
+func testInsertNewBlocks(t *testing.T, store store.Store) {
+	boardID := "board-id-new-blocks"
+
+	t.Run("blocks and history are inserted", func(t *testing.T) {
+		newBlocks := []model.Block{
+			{ID: "card-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "card 1"},
+			{ID: "text-1", BoardID: boardID, ParentID: "card-1", Type: model.TypeText, Title: "text 1"},
+			{ID: "card-2", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "card 2"},
+		}
+
+		err := store.InsertNewBlocks(newBlocks, testUserID)
+		require.NoError(t, err)
+		for _, block := range newBlocks {
+			require.Equal(t, testUserID, block.CreatedBy)
+			require.NotZero(t, block.CreateAt)
+		}
+
+		blocks, err := store.GetBlocksForBoard(boardID)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+
+		history, err := store.GetBlockHistory("text-1", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, "text 1", history[0].Title)
+	})
+
+	t.Run("existing block", func(t *testing.T) {
+		newBlocks := []model.Block{
+			{ID: "card-3", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "card 3"},
+			{ID: "card-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "card 1 again"},
+		}
+
+		err := store.InsertNewBlocks(newBlocks, testUserID)
+		require.Error(t, err)
+
+		// nothing is inserted
+		blocks, err := store.GetBlocksForBoard(boardID)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+	})
+
+	t.Run("invalid block", func(t *testing.T) {
+		newBlocks := []model.Block{
+			{ID: "card-4", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+			{ID: "card-5", BoardID: "", Type: model.TypeCard},
+		}
+
+		err := store.InsertNewBlocks(newBlocks, testUserID)
+		require.Error(t, err)
+
+		blocks, err := store.GetBlocksForBoard(boardID)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+	})
+}
+
 func testPatchBlock(t *testing.T, store store.Store) {
 	userID := testUserID
 	boardID := "board-id-1"
@@ -510,6 +597,24 @@ func testGetSubTree2(t *testing.T, store store.Store) {
 		require.NoError(t, err)
 		require.Len(t, blocks, 0)
 	})
+
+	t.Run("with depth", func(t *testing.T) {
+		blocks, err = store.GetSubTree2(boardID, "parent", model.QuerySubtreeOptions{Depth: 2})
+		require.NoError(t, err)
+		require.Len(t, blocks, 5)
+		require.True(t, ContainsBlockWithID(blocks, "grandchild1"))
+		require.True(t, ContainsBlockWithID(blocks, "grandchild2"))
+		require.False(t, ContainsBlockWithID(blocks, "greatgrandchild1"))
+
+		blocks, err = store.GetSubTree2(boardID, "parent", model.QuerySubtreeOptions{Depth: 3})
+		require.NoError(t, err)
+		require.Len(t, blocks, 6)
+
+		blocks, err = store.GetSubTree2(boardID, "child1", model.QuerySubtreeOptions{Depth: 5})
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+		require.False(t, ContainsBlockWithID(blocks, "parent"))
+	})
 }
 
 func testDeleteBlock(t *testing.T, store store.Store) {
@@ -771,6 +876,39 @@ func testGetBlocks(t *testing.T, store store.Store) {
 	})
 }
 
+func testMoveBlocks(t *testing.T, store store.Store) {
+	blocks := []model.Block{
+		{ID: "card-to-move", BoardID: "board-from", ParentID: "board-from", Type: model.TypeCard, ModifiedBy: testUserID},
+		{ID: "text-to-move", BoardID: "board-from", ParentID: "card-to-move", Type: model.TypeText, ModifiedBy: testUserID},
+		{ID: "image-to-move", BoardID: "board-from", ParentID: "card-to-move", Type: model.TypeImage, ModifiedBy: testUserID,
+			Fields: map[string]interface{}{"fileId": "image.png"}},
+	}
+	InsertBlocks(t, store, blocks, testUserID)
+
+	moved, err := store.MoveBlocks(blocks, "board-to", testUserID)
+	require.NoError(t, err)
+	require.Len(t, moved, 3)
+
+	card, err := store.GetBlock("card-to-move")
+	require.NoError(t, err)
+	require.Equal(t, "board-to", card.BoardID)
+	require.Equal(t, "board-to", card.ParentID)
+
+	text, err := store.GetBlock("text-to-move")
+	require.NoError(t, err)
+	require.Equal(t, "board-to", text.BoardID)
+	require.Equal(t, "card-to-move", text.ParentID)
+
+	image, err := store.GetBlock("image-to-move")
+	require.NoError(t, err)
+	require.Equal(t, "board-to", image.BoardID)
+	require.Equal(t, "image.png", image.Fields["fileId"])
+
+	fromBlocks, err := store.GetBlocksForBoard("board-from")
+	require.NoError(t, err)
+	require.Empty(t, fromBlocks)
+}
+
 func testGetBlock(t *testing.T, store store.Store) {
 	t.Run("get a block", func(t *testing.T) {
 		block := model.Block{
@@ -800,6 +938,31 @@ func testGetBlock(t *testing.T, store store.Store) {
 	})
 }
 
+func testGetBlocksByIDs(t *testing.T, store store.Store) {
+	blocks := []model.Block{
+		{ID: "block-id-1", BoardID: "board-id-1", ModifiedBy: "user-id-1"},
+		{ID: "block-id-2", BoardID: "board-id-2", ModifiedBy: "user-id-1"},
+		{ID: "block-id-3", BoardID: "board-id-1", ModifiedBy: "user-id-1"},
+	}
+	require.NoError(t, store.InsertBlocks(blocks, "user-id-1"))
+
+	t.Run("get blocks of several boards", func(t *testing.T) {
+		fetchedBlocks, err := store.GetBlocksByIDs([]string{"block-id-1", "block-id-2", "non-existing-id"})
+		require.NoError(t, err)
+		ids := make([]string, 0, len(fetchedBlocks))
+		for _, block := range fetchedBlocks {
+			ids = append(ids, block.ID)
+		}
+		require.ElementsMatch(t, []string{"block-id-1", "block-id-2"}, ids)
+	})
+
+	t.Run("get no blocks", func(t *testing.T) {
+		fetchedBlocks, err := store.GetBlocksByIDs([]string{})
+		require.NoError(t, err)
+		require.Empty(t, fetchedBlocks)
+	})
+}
+
 func testRunDataRetention(t *testing.T, store store.Store) {
 	validBoard := model.Board{
 		ID:         "board-id-test",
@@ -1072,3 +1235,132 @@ func testGetBlockMetadata(t *testing.T, store store.Store) {
 		require.Equal(t, expectedBlock.ID, block.ID)
 	})
 }
+
+func testGetCardsWithFieldValue(t *testing.T, store store.Store) {
+	blocks := []model.Block{
+		{
+			ID:      "card-1",
+			BoardID: "board-id-1",
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{"assignee": "user-id-1"}},
+		},
+		{
+			ID:      "card-2",
+			BoardID: "board-id-1",
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{"assignee": "user-id-10"}},
+		},
+		{
+			ID:      "card-3",
+			BoardID: "board-id-2",
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{"assignee": "user-id-1"}},
+		},
+		{
+			ID:       "text-1",
+			BoardID:  "board-id-1",
+			ParentID: "card-1",
+			Type:     model.TypeText,
+			Fields:   map[string]interface{}{"value": "user-id-1"},
+		},
+	}
+	require.NoError(t, store.InsertBlocks(blocks, testUserID))
+
+	t.Run("only the cards of the given boards containing the value are returned", func(t *testing.T) {
+		cards, err := store.GetCardsWithFieldValue([]string{"board-id-1"}, "user-id-1")
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		require.Equal(t, "card-1", cards[0].ID)
+	})
+
+	t.Run("cards from several boards", func(t *testing.T) {
+		cards, err := store.GetCardsWithFieldValue([]string{"board-id-1", "board-id-2"}, "user-id-1")
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+	})
+
+	t.Run("no boards", func(t *testing.T) {
+		cards, err := store.GetCardsWithFieldValue([]string{}, "user-id-1")
+		require.NoError(t, err)
+		require.Empty(t, cards)
+	})
+}
+
+func testGetBlocksChangedSince(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	blocks := []model.Block{
+		{ID: "block-1", BoardID: boardID, Type: model.TypeCard},
+		{ID: "block-2", BoardID: boardID, Type: model.TypeCard},
+		{ID: "block-3", BoardID: boardID, Type: model.TypeCard},
+	}
+	require.NoError(t, store.InsertBlocks(blocks, testUserID))
+
+	time.Sleep(10 * time.Millisecond)
+	since := utils.GetMillis()
+	time.Sleep(10 * time.Millisecond)
+
+	title := "changed"
+	require.NoError(t, store.PatchBlock("block-1", &model.BlockPatch{Title: &title}, testUserID))
+	require.NoError(t, store.DeleteBlock("block-2", testUserID))
+
+	t.Run("only the blocks changed after the timestamp are returned", func(t *testing.T) {
+		changed, err := store.GetBlocksChangedSince(boardID, since)
+		require.NoError(t, err)
+		require.Len(t, changed, 1)
+		require.Equal(t, "block-1", changed[0].ID)
+
+		all, err := store.GetBlocksChangedSince(boardID, 0)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+	})
+
+	t.Run("deleted blocks", func(t *testing.T) {
+		deleted, err := store.GetDeletedBlockIDsSince(boardID, since)
+		require.NoError(t, err)
+		require.Equal(t, []string{"block-2"}, deleted)
+
+		deleted, err = store.GetDeletedBlockIDsSince(boardID, utils.GetMillis()+1000)
+		require.NoError(t, err)
+		require.Empty(t, deleted)
+	})
+
+	t.Run("restored blocks are not deleted anymore", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.UndeleteBlock("block-2", testUserID))
+
+		deleted, err := store.GetDeletedBlockIDsSince(boardID, since)
+		require.NoError(t, err)
+		require.Empty(t, deleted)
+	})
+}
+
+func testGetCardsUpdatedBefore(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	blocks := []model.Block{
+		{ID: "card-1", BoardID: boardID, Type: model.TypeCard},
+		{ID: "card-2", BoardID: boardID, Type: model.TypeCard},
+		{ID: "text-1", BoardID: boardID, ParentID: "card-1", Type: model.TypeText},
+		{ID: "card-3", BoardID: utils.NewID(utils.IDTypeBoard), Type: model.TypeCard},
+	}
+	require.NoError(t, store.InsertBlocks(blocks, testUserID))
+
+	time.Sleep(10 * time.Millisecond)
+	before := utils.GetMillis()
+	time.Sleep(10 * time.Millisecond)
+
+	title := "changed"
+	require.NoError(t, store.PatchBlock("card-2", &model.BlockPatch{Title: &title}, testUserID))
+
+	t.Run("only the cards of the board not updated since are returned", func(t *testing.T) {
+		cards, err := store.GetCardsUpdatedBefore(boardID, before)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		require.Equal(t, "card-1", cards[0].ID)
+	})
+
+	t.Run("no cards", func(t *testing.T) {
+		cards, err := store.GetCardsUpdatedBefore(boardID, 0)
+		require.NoError(t, err)
+		require.Empty(t, cards)
+	})
+}
@@ -0,0 +1,168 @@
+package storetests
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestBoardAccessRequestStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateBoardAccessRequest", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateBoardAccessRequest(t, store)
+	})
+
+	t.Run("GetAccessRequestsForBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetAccessRequestsForBoard(t, store)
+	})
+
+	t.Run("ApproveBoardAccessRequest", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testApproveBoardAccessRequest(t, store)
+	})
+
+	t.Run("DeleteBoardAccessRequest", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardAccessRequest(t, store)
+	})
+}
+
+func testCreateBoardAccessRequest(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, err := store.CreateBoardAccessRequest(&model.BoardAccessRequest{BoardID: boardID})
+		require.Error(t, err)
+
+		_, err = store.CreateBoardAccessRequest(&model.BoardAccessRequest{
+			BoardID: boardID,
+			UserID:  "user-1",
+			Message: strings.Repeat("a", model.MaxBoardAccessRequestMessageLength+1),
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("create request", func(t *testing.T) {
+		request, err := store.CreateBoardAccessRequest(&model.BoardAccessRequest{BoardID: boardID, UserID: "user-1", Message: "please"})
+		require.NoError(t, err)
+		require.NotEmpty(t, request.ID)
+		require.NotZero(t, request.CreateAt)
+
+		rRequest, err := store.GetBoardAccessRequest(boardID, "user-1")
+		require.NoError(t, err)
+		require.Equal(t, request, rRequest)
+
+		require.Equal(t, []string{model.BoardMemberActionRequested}, boardMemberHistoryActions(t, store, boardID, "user-1"))
+	})
+
+	t.Run("request again", func(t *testing.T) {
+		first, err := store.GetBoardAccessRequest(boardID, "user-1")
+		require.NoError(t, err)
+
+		request, err := store.CreateBoardAccessRequest(&model.BoardAccessRequest{BoardID: boardID, UserID: "user-1", Message: "please, I need it"})
+		require.NoError(t, err)
+		require.Equal(t, first.ID, request.ID)
+		require.Equal(t, first.CreateAt, request.CreateAt)
+
+		rRequest, err := store.GetBoardAccessRequest(boardID, "user-1")
+		require.NoError(t, err)
+		require.Equal(t, "please, I need it", rRequest.Message)
+
+		// only the first request is recorded
+		require.Equal(t, []string{model.BoardMemberActionRequested}, boardMemberHistoryActions(t, store, boardID, "user-1"))
+	})
+}
+
+func testGetAccessRequestsForBoard(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+
+	for _, request := range []*model.BoardAccessRequest{
+		{BoardID: boardID, UserID: "user-1"},
+		{BoardID: boardID, UserID: "user-2"},
+		{BoardID: otherBoardID, UserID: "user-1"},
+	} {
+		_, err := store.CreateBoardAccessRequest(request)
+		require.NoError(t, err)
+	}
+
+	requests, err := store.GetAccessRequestsForBoard(boardID)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+
+	requests, err = store.GetAccessRequestsForBoard(utils.NewID(utils.IDTypeBoard))
+	require.NoError(t, err)
+	require.Empty(t, requests)
+
+	_, err = store.GetBoardAccessRequest(boardID, "user-3")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testApproveBoardAccessRequest(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	_, err := store.CreateBoardAccessRequest(&model.BoardAccessRequest{BoardID: boardID, UserID: "user-1"})
+	require.NoError(t, err)
+
+	t.Run("approve request", func(t *testing.T) {
+		member, err := store.ApproveBoardAccessRequest(&model.BoardMember{BoardID: boardID, UserID: "user-1", SchemeCommenter: true})
+		require.NoError(t, err)
+		require.True(t, member.SchemeCommenter)
+
+		rMember, err := store.GetMemberForBoard(boardID, "user-1")
+		require.NoError(t, err)
+		require.True(t, rMember.SchemeCommenter)
+
+		_, err = store.GetBoardAccessRequest(boardID, "user-1")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		require.ElementsMatch(t,
+			[]string{model.BoardMemberActionRequested, model.BoardMemberActionApproved},
+			boardMemberHistoryActions(t, store, boardID, "user-1"),
+		)
+	})
+
+	t.Run("approve nonexistent request", func(t *testing.T) {
+		_, err := store.ApproveBoardAccessRequest(&model.BoardMember{BoardID: boardID, UserID: "user-2", SchemeViewer: true})
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = store.GetMemberForBoard(boardID, "user-2")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func testDeleteBoardAccessRequest(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	_, err := store.CreateBoardAccessRequest(&model.BoardAccessRequest{BoardID: boardID, UserID: "user-1"})
+	require.NoError(t, err)
+
+	t.Run("deny request", func(t *testing.T) {
+		err := store.DeleteBoardAccessRequest(boardID, "user-1", model.BoardMemberActionDenied)
+		require.NoError(t, err)
+
+		_, err = store.GetBoardAccessRequest(boardID, "user-1")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		require.ElementsMatch(t,
+			[]string{model.BoardMemberActionRequested, model.BoardMemberActionDenied},
+			boardMemberHistoryActions(t, store, boardID, "user-1"),
+		)
+	})
+
+	t.Run("delete nonexistent request", func(t *testing.T) {
+		err := store.DeleteBoardAccessRequest(boardID, "user-1", model.BoardMemberActionDenied)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
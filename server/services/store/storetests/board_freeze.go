@@ -0,0 +1,70 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestBoardFreezeStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("BoardFreezeSchedule", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testBoardFreezeSchedule(t, store)
+	})
+	t.Run("GetDueBoardFreezeSchedules", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDueBoardFreezeSchedules(t, store)
+	})
+}
+
+func testBoardFreezeSchedule(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	_, err := store.GetBoardFreezeSchedule(boardID)
+	require.True(t, store.IsErrNotFound(err))
+
+	schedule := &model.BoardFreezeSchedule{BoardID: boardID, FreezeAt: 2000, ModifiedBy: "user-id", UpdateAt: 1000}
+	require.NoError(t, store.SaveBoardFreezeSchedule(schedule))
+
+	saved, err := store.GetBoardFreezeSchedule(boardID)
+	require.NoError(t, err)
+	require.Equal(t, schedule, saved)
+
+	schedule.UnfreezeAt = 3000
+	schedule.ModifiedBy = "user-id-2"
+	require.NoError(t, store.SaveBoardFreezeSchedule(schedule))
+
+	saved, err = store.GetBoardFreezeSchedule(boardID)
+	require.NoError(t, err)
+	require.Equal(t, schedule, saved)
+
+	require.NoError(t, store.DeleteBoardFreezeSchedule(boardID))
+	_, err = store.GetBoardFreezeSchedule(boardID)
+	require.True(t, store.IsErrNotFound(err))
+}
+
+func testGetDueBoardFreezeSchedules(t *testing.T, store store.Store) {
+	freezeDue := &model.BoardFreezeSchedule{BoardID: "board-1", FreezeAt: 1000, UnfreezeAt: 5000, ModifiedBy: "user-id", UpdateAt: 1}
+	unfreezeDue := &model.BoardFreezeSchedule{BoardID: "board-2", UnfreezeAt: 2000, ModifiedBy: "user-id", UpdateAt: 1}
+	notDue := &model.BoardFreezeSchedule{BoardID: "board-3", FreezeAt: 4000, ModifiedBy: "user-id", UpdateAt: 1}
+	for _, schedule := range []*model.BoardFreezeSchedule{notDue, unfreezeDue, freezeDue} {
+		require.NoError(t, store.SaveBoardFreezeSchedule(schedule))
+	}
+
+	due, err := store.GetDueBoardFreezeSchedules(3000)
+	require.NoError(t, err)
+	require.Equal(t, []*model.BoardFreezeSchedule{freezeDue, unfreezeDue}, due)
+
+	due, err = store.GetDueBoardFreezeSchedules(500)
+	require.NoError(t, err)
+	require.Empty(t, due)
+}
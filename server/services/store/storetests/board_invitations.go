@@ -0,0 +1,209 @@
+package storetests
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestBoardInvitationsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateBoardInvitation", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateBoardInvitation(t, store)
+	})
+
+	t.Run("GetInvitations", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetInvitations(t, store)
+	})
+
+	t.Run("AcceptBoardInvitation", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testAcceptBoardInvitation(t, store)
+	})
+
+	t.Run("DeleteBoardInvitation", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardInvitation(t, store)
+	})
+}
+
+func newTestBoardInvitation(boardID, userID string) *model.BoardInvitation {
+	return &model.BoardInvitation{
+		BoardID:      boardID,
+		UserID:       userID,
+		InvitedBy:    "inviter-id",
+		SchemeEditor: true,
+	}
+}
+
+func boardMemberHistoryActions(t *testing.T, store store.Store, boardID, userID string) []string {
+	entries, err := store.GetBoardMemberHistory(boardID, userID, 0)
+	require.NoError(t, err)
+
+	actions := []string{}
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+	}
+	return actions
+}
+
+func testCreateBoardInvitation(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	t.Run("invalid invitation", func(t *testing.T) {
+		_, err := store.CreateBoardInvitation(&model.BoardInvitation{BoardID: boardID})
+		require.Error(t, err)
+	})
+
+	t.Run("create invitation", func(t *testing.T) {
+		invitation, err := store.CreateBoardInvitation(newTestBoardInvitation(boardID, "user-1"))
+		require.NoError(t, err)
+		require.NotEmpty(t, invitation.ID)
+		require.NotZero(t, invitation.CreateAt)
+
+		rInvitation, err := store.GetBoardInvitation(boardID, "user-1")
+		require.NoError(t, err)
+		require.Equal(t, invitation, rInvitation)
+
+		require.Equal(t, []string{model.BoardMemberActionInvited}, boardMemberHistoryActions(t, store, boardID, "user-1"))
+
+		// no membership is created until the invitation is accepted.
+		_, err = store.GetMemberForBoard(boardID, "user-1")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("invite the same user again", func(t *testing.T) {
+		original, err := store.GetBoardInvitation(boardID, "user-1")
+		require.NoError(t, err)
+
+		invitation := newTestBoardInvitation(boardID, "user-1")
+		invitation.SchemeEditor = false
+		invitation.SchemeViewer = true
+		updated, err := store.CreateBoardInvitation(invitation)
+		require.NoError(t, err)
+		require.Equal(t, original.ID, updated.ID)
+
+		rInvitation, err := store.GetBoardInvitation(boardID, "user-1")
+		require.NoError(t, err)
+		require.False(t, rInvitation.SchemeEditor)
+		require.True(t, rInvitation.SchemeViewer)
+
+		// the pending invitation is updated instead of duplicated.
+		require.Equal(t, []string{model.BoardMemberActionInvited}, boardMemberHistoryActions(t, store, boardID, "user-1"))
+	})
+}
+
+func testGetInvitations(t *testing.T, store store.Store) {
+	board1 := utils.NewID(utils.IDTypeBoard)
+	board2 := utils.NewID(utils.IDTypeBoard)
+
+	for _, invitation := range []*model.BoardInvitation{
+		newTestBoardInvitation(board1, "user-1"),
+		newTestBoardInvitation(board1, "user-2"),
+		newTestBoardInvitation(board2, "user-1"),
+	} {
+		_, err := store.CreateBoardInvitation(invitation)
+		require.NoError(t, err)
+	}
+
+	t.Run("for board", func(t *testing.T) {
+		invitations, err := store.GetInvitationsForBoard(board1)
+		require.NoError(t, err)
+		require.Len(t, invitations, 2)
+
+		invitations, err = store.GetInvitationsForBoard("nonexistent")
+		require.NoError(t, err)
+		require.Empty(t, invitations)
+	})
+
+	t.Run("for user", func(t *testing.T) {
+		invitations, err := store.GetInvitationsForUser("user-1")
+		require.NoError(t, err)
+		require.Len(t, invitations, 2)
+
+		invitations, err = store.GetInvitationsForUser("user-2")
+		require.NoError(t, err)
+		require.Len(t, invitations, 1)
+		require.Equal(t, board1, invitations[0].BoardID)
+	})
+
+	t.Run("nonexistent invitation", func(t *testing.T) {
+		_, err := store.GetBoardInvitation(board2, "user-2")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func testAcceptBoardInvitation(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	invitation := newTestBoardInvitation(boardID, "user-1")
+	invitation.SchemeEditor = false
+	invitation.SchemeCommenter = true
+	_, err := store.CreateBoardInvitation(invitation)
+	require.NoError(t, err)
+
+	t.Run("accept invitation", func(t *testing.T) {
+		member, err := store.AcceptBoardInvitation(boardID, "user-1")
+		require.NoError(t, err)
+		require.True(t, member.SchemeCommenter)
+		require.False(t, member.SchemeEditor)
+
+		rMember, err := store.GetMemberForBoard(boardID, "user-1")
+		require.NoError(t, err)
+		require.True(t, rMember.SchemeCommenter)
+
+		_, err = store.GetBoardInvitation(boardID, "user-1")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		require.ElementsMatch(t,
+			[]string{model.BoardMemberActionInvited, model.BoardMemberActionAccepted},
+			boardMemberHistoryActions(t, store, boardID, "user-1"),
+		)
+	})
+
+	t.Run("accept nonexistent invitation", func(t *testing.T) {
+		_, err := store.AcceptBoardInvitation(boardID, "user-2")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = store.GetMemberForBoard(boardID, "user-2")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func testDeleteBoardInvitation(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	_, err := store.CreateBoardInvitation(newTestBoardInvitation(boardID, "user-1"))
+	require.NoError(t, err)
+
+	t.Run("decline invitation", func(t *testing.T) {
+		err := store.DeleteBoardInvitation(boardID, "user-1", model.BoardMemberActionDeclined)
+		require.NoError(t, err)
+
+		_, err = store.GetBoardInvitation(boardID, "user-1")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = store.GetMemberForBoard(boardID, "user-1")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		require.ElementsMatch(t,
+			[]string{model.BoardMemberActionInvited, model.BoardMemberActionDeclined},
+			boardMemberHistoryActions(t, store, boardID, "user-1"),
+		)
+	})
+
+	t.Run("delete nonexistent invitation", func(t *testing.T) {
+		err := store.DeleteBoardInvitation(boardID, "user-1", model.BoardMemberActionCanceled)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
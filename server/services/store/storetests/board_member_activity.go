@@ -0,0 +1,67 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestBoardMemberActivityStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveBoardMemberActivity", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveBoardMemberActivity(t, store)
+	})
+}
+
+func testSaveBoardMemberActivity(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+
+	activityOf := func(userID string) *model.BoardMemberActivity {
+		activities, err := store.GetBoardMemberActivity(boardID)
+		require.NoError(t, err)
+		for _, activity := range activities {
+			if activity.UserID == userID {
+				return activity
+			}
+		}
+		return nil
+	}
+
+	t.Run("should create the activity of the member", func(t *testing.T) {
+		require.NoError(t, store.SaveBoardMemberActivity([]*model.BoardMemberActivity{
+			{BoardID: boardID, UserID: "user-1", LastViewAt: 100},
+			{BoardID: boardID, UserID: "user-2", LastViewAt: 150, LastEditAt: 120},
+			{BoardID: otherBoardID, UserID: "user-1", LastEditAt: 300},
+		}))
+
+		activities, err := store.GetBoardMemberActivity(boardID)
+		require.NoError(t, err)
+		require.Len(t, activities, 2)
+
+		activity := activityOf("user-1")
+		require.NotNil(t, activity)
+		require.Equal(t, int64(100), activity.LastViewAt)
+		require.Zero(t, activity.LastEditAt)
+	})
+
+	t.Run("should keep the latest times", func(t *testing.T) {
+		require.NoError(t, store.SaveBoardMemberActivity([]*model.BoardMemberActivity{
+			{BoardID: boardID, UserID: "user-1", LastEditAt: 200},
+			{BoardID: boardID, UserID: "user-2", LastViewAt: 50},
+		}))
+
+		activity := activityOf("user-1")
+		require.Equal(t, int64(100), activity.LastViewAt)
+		require.Equal(t, int64(200), activity.LastEditAt)
+
+		activity = activityOf("user-2")
+		require.Equal(t, int64(150), activity.LastViewAt)
+		require.Equal(t, int64(120), activity.LastEditAt)
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestBoardSnapshotsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("BoardSnapshots", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testBoardSnapshots(t, store)
+	})
+	t.Run("GetNonTemplateBoardIDs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetNonTemplateBoardIDs(t, store)
+	})
+}
+
+func testBoardSnapshots(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	snapshot := func(day string, counts map[string]int64) *model.BoardSnapshot {
+		return &model.BoardSnapshot{BoardID: boardID, Day: day, PropertyID: "status-id", Counts: counts, CreateAt: 1000}
+	}
+
+	first := snapshot("2022-03-13", map[string]int64{"": 1, "todo-id": 4})
+	second := snapshot("2022-03-14", map[string]int64{"todo-id": 3, "done-id": 2})
+	require.NoError(t, store.SaveBoardSnapshot(first))
+	require.NoError(t, store.SaveBoardSnapshot(second))
+	require.NoError(t, store.SaveBoardSnapshot(&model.BoardSnapshot{
+		BoardID: utils.NewID(utils.IDTypeBoard), Day: "2022-03-14", PropertyID: "status-id", Counts: map[string]int64{"todo-id": 7},
+	}))
+
+	t.Run("the snapshots of the days are returned in order", func(t *testing.T) {
+		snapshots, err := store.GetBoardSnapshots(boardID, "2022-03-01", "2022-03-31")
+		require.NoError(t, err)
+		require.Equal(t, []*model.BoardSnapshot{first, second}, snapshots)
+
+		snapshots, err = store.GetBoardSnapshots(boardID, "2022-03-14", "2022-03-14")
+		require.NoError(t, err)
+		require.Equal(t, []*model.BoardSnapshot{second}, snapshots)
+	})
+
+	t.Run("saving the snapshot of a day again replaces it", func(t *testing.T) {
+		updated := snapshot("2022-03-14", map[string]int64{"done-id": 5})
+		require.NoError(t, store.SaveBoardSnapshot(updated))
+
+		snapshots, err := store.GetBoardSnapshots(boardID, "2022-03-14", "2022-03-14")
+		require.NoError(t, err)
+		require.Equal(t, []*model.BoardSnapshot{updated}, snapshots)
+	})
+
+	t.Run("no snapshots", func(t *testing.T) {
+		snapshots, err := store.GetBoardSnapshots(boardID, "2021-01-01", "2021-12-31")
+		require.NoError(t, err)
+		require.Empty(t, snapshots)
+	})
+}
+
+func testGetNonTemplateBoardIDs(t *testing.T, store store.Store) {
+	board := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: testTeamID, Type: model.BoardTypeOpen}
+	template := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: testTeamID, Type: model.BoardTypeOpen, IsTemplate: true}
+	_, err := store.InsertBoard(board, testUserID)
+	require.NoError(t, err)
+	_, err = store.InsertBoard(template, testUserID)
+	require.NoError(t, err)
+
+	boardIDs, err := store.GetNonTemplateBoardIDs()
+	require.NoError(t, err)
+	require.Contains(t, boardIDs, board.ID)
+	require.NotContains(t, boardIDs, template.ID)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestBoardTeamsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("ShareBoardWithTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testShareBoardWithTeam(t, store)
+	})
+	t.Run("GetBoardsForUserAndSharedTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsForUserAndSharedTeam(t, store)
+	})
+	t.Run("GetBoardsForUserAndTeamWithOrganizationBoards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsForUserAndTeamWithOrganizationBoards(t, store)
+	})
+	t.Run("MoveBoardToTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMoveBoardToTeam(t, store)
+	})
+}
+
+func testShareBoardWithTeam(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	teamIDs, err := store.GetSharedTeamIDsForBoard(boardID)
+	require.NoError(t, err)
+	require.Empty(t, teamIDs)
+
+	require.NoError(t, store.AddBoardToTeam(boardID, "team-b", testUserID))
+	require.NoError(t, store.AddBoardToTeam(boardID, "team-a", testUserID))
+	require.NoError(t, store.AddBoardToTeam(boardID, "team-a", testUserID), "sharing twice is not an error")
+
+	teamIDs, err = store.GetSharedTeamIDsForBoard(boardID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"team-a", "team-b"}, teamIDs)
+
+	require.NoError(t, store.RemoveBoardFromTeam(boardID, "team-a"))
+
+	err = store.RemoveBoardFromTeam(boardID, "team-a")
+	require.Error(t, err)
+	require.True(t, store.IsErrNotFound(err))
+
+	teamIDs, err = store.GetSharedTeamIDsForBoard(boardID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"team-b"}, teamIDs)
+}
+
+func testGetBoardsForUserAndSharedTeam(t *testing.T, store store.Store) {
+	board, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+	}, testUserID)
+	require.NoError(t, err)
+
+	_, err = store.SaveMember(&model.BoardMember{BoardID: board.ID, UserID: testUserID, SchemeEditor: true})
+	require.NoError(t, err)
+
+	boards, err := store.GetBoardsForUserAndTeam(testUserID, "other-team")
+	require.NoError(t, err)
+	require.Empty(t, boards)
+
+	require.NoError(t, store.AddBoardToTeam(board.ID, "other-team", testUserID))
+
+	boards, err = store.GetBoardsForUserAndTeam(testUserID, "other-team")
+	require.NoError(t, err)
+	require.Len(t, boards, 1)
+	require.Equal(t, board.ID, boards[0].ID)
+
+	boards, err = store.GetBoardsForUserAndTeam(testUserID, testTeamID)
+	require.NoError(t, err)
+	require.Len(t, boards, 1, "the board is still listed in its own team")
+}
+
+func testGetBoardsForUserAndTeamWithOrganizationBoards(t *testing.T, store store.Store) {
+	orgBoard, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: model.OrganizationTeamID,
+		Type:   model.BoardTypeOpen,
+	}, testUserID)
+	require.NoError(t, err)
+
+	privateOrgBoard, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: model.OrganizationTeamID,
+		Type:   model.BoardTypePrivate,
+	}, testUserID)
+	require.NoError(t, err)
+
+	for _, teamID := range []string{testTeamID, "other-team"} {
+		boards, err := store.GetBoardsForUserAndTeam(testUserID, teamID)
+		require.NoError(t, err)
+		require.Len(t, boards, 1)
+		require.Equal(t, orgBoard.ID, boards[0].ID)
+	}
+
+	_, err = store.SaveMember(&model.BoardMember{BoardID: privateOrgBoard.ID, UserID: testUserID, SchemeViewer: true})
+	require.NoError(t, err)
+
+	boards, err := store.SearchBoardsForUserAndTeam("", testUserID, "other-team")
+	require.NoError(t, err)
+	require.Len(t, boards, 2)
+}
+
+func testMoveBoardToTeam(t *testing.T, store store.Store) {
+	board, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: model.GlobalTeamID,
+		Type:   model.BoardTypeOpen,
+	}, testUserID)
+	require.NoError(t, err)
+
+	_, err = store.InsertBoard(&model.Board{
+		ID:         utils.NewID(utils.IDTypeBoard),
+		TeamID:     model.GlobalTeamID,
+		Type:       model.BoardTypeOpen,
+		IsTemplate: true,
+	}, testUserID)
+	require.NoError(t, err)
+
+	boardIDs, err := store.GetBoardIDsForTeam(model.GlobalTeamID)
+	require.NoError(t, err)
+	require.Equal(t, []string{board.ID}, boardIDs, "the templates are not listed")
+
+	require.NoError(t, store.AddBoardToTeam(board.ID, testTeamID, testUserID))
+	require.NoError(t, store.MoveBoardToTeam(board.ID, testTeamID))
+
+	movedBoard, err := store.GetBoard(board.ID)
+	require.NoError(t, err)
+	require.Equal(t, testTeamID, movedBoard.TeamID)
+
+	history, err := store.GetBoardHistory(board.ID, model.QueryBoardHistoryOptions{})
+	require.NoError(t, err)
+	for _, entry := range history {
+		require.Equal(t, testTeamID, entry.TeamID)
+	}
+
+	teamIDs, err := store.GetSharedTeamIDsForBoard(board.ID)
+	require.NoError(t, err)
+	require.Empty(t, teamIDs, "the board is no longer shared with its own team")
+
+	boardIDs, err = store.GetBoardIDsForTeam(model.GlobalTeamID)
+	require.NoError(t, err)
+	require.Empty(t, boardIDs)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+func StoreTestBoardWebhooksStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("InsertAndUpdateBoardWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testInsertAndUpdateBoardWebhook(t, store)
+	})
+
+	t.Run("GetBoardWebhooksForBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardWebhooksForBoard(t, store)
+	})
+}
+
+func newTestBoardWebhook(boardID string) *model.BoardWebhook {
+	webhook := &model.BoardWebhook{
+		BoardID:   boardID,
+		URL:       "https://example.com/hook",
+		Events:    []string{model.BoardWebhookEventBlockCreated, model.BoardWebhookEventBlockUpdated},
+		Secret:    "secret",
+		Enabled:   true,
+		CreatedBy: "user-id",
+	}
+	webhook.Hydrate()
+	return webhook
+}
+
+func testInsertAndUpdateBoardWebhook(t *testing.T, store store.Store) {
+	webhook := newTestBoardWebhook("board-id")
+	require.NoError(t, store.InsertBoardWebhook(webhook))
+
+	fetched, err := store.GetBoardWebhook(webhook.ID)
+	require.NoError(t, err)
+	require.Equal(t, webhook, fetched)
+
+	webhook.Events = []string{model.BoardWebhookEventBlockDeleted, model.BoardWebhookEventPropertyValidation}
+	webhook.Secret = ""
+	webhook.Enabled = false
+	webhook.PropertyIDs = []string{"status-prop"}
+	webhook.TimeoutMs = 1500
+	webhook.FailOpen = true
+	webhook.UpdateAt++
+	require.NoError(t, store.UpdateBoardWebhook(webhook))
+
+	fetched, err = store.GetBoardWebhook(webhook.ID)
+	require.NoError(t, err)
+	require.Equal(t, webhook, fetched)
+
+	require.NoError(t, store.DeleteBoardWebhook(webhook.ID))
+	_, err = store.GetBoardWebhook(webhook.ID)
+	require.True(t, store.IsErrNotFound(err))
+
+	err = store.UpdateBoardWebhook(webhook)
+	require.True(t, store.IsErrNotFound(err))
+}
+
+func testGetBoardWebhooksForBoard(t *testing.T, store store.Store) {
+	webhooks, err := store.GetBoardWebhooksForBoard("board-id")
+	require.NoError(t, err)
+	require.Empty(t, webhooks)
+
+	first := newTestBoardWebhook("board-id")
+	second := newTestBoardWebhook("board-id")
+	second.CreateAt = first.CreateAt + 1
+	other := newTestBoardWebhook("other-board-id")
+	require.NoError(t, store.InsertBoardWebhook(second))
+	require.NoError(t, store.InsertBoardWebhook(first))
+	require.NoError(t, store.InsertBoardWebhook(other))
+
+	webhooks, err = store.GetBoardWebhooksForBoard("board-id")
+	require.NoError(t, err)
+	require.Equal(t, []*model.BoardWebhook{first, second}, webhooks)
+}
@@ -23,6 +23,11 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testGetBoardsForUserAndTeam(t, store)
 	})
+	t.Run("GetBoardsForChannel", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsForChannel(t, store)
+	})
 	t.Run("InsertBoard", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -53,6 +58,16 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testSaveMember(t, store)
 	})
+	t.Run("JoinBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testJoinBoard(t, store)
+	})
+	t.Run("SaveMemberRoles", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveMemberRoles(t, store)
+	})
 	t.Run("GetMemberForBoard", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -68,6 +83,21 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testDeleteMember(t, store)
 	})
+	t.Run("RestoreMember", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRestoreMember(t, store)
+	})
+	t.Run("UpdateMemberCustomFields", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateMemberCustomFields(t, store)
+	})
+	t.Run("SetMembershipsInactive", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSetMembershipsInactive(t, store)
+	})
 	t.Run("SearchBoardsForUserAndTeam", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -78,6 +108,11 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testGetBoardHistory(t, store)
 	})
+	t.Run("GetRecentBoardsForUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetRecentBoardsForUser(t, store)
+	})
 }
 
 func testGetBoard(t *testing.T, store store.Store) {
@@ -187,6 +222,46 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 	})
 }
 
+func testGetBoardsForChannel(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	t.Run("should return empty if no boards are linked to the channel", func(t *testing.T) {
+		boards, err := store.GetBoardsForChannel("channel-id")
+		require.NoError(t, err)
+		require.Empty(t, boards)
+	})
+
+	t.Run("should return the boards linked to the channel", func(t *testing.T) {
+		for _, board := range []*model.Board{
+			{ID: "board-id-1", TeamID: testTeamID, ChannelID: "channel-id", Type: model.BoardTypeOpen},
+			{ID: "board-id-2", TeamID: testTeamID, ChannelID: "channel-id", Type: model.BoardTypePrivate},
+			{ID: "board-id-3", TeamID: testTeamID, ChannelID: "other-channel-id", Type: model.BoardTypeOpen},
+			{ID: "board-id-4", TeamID: testTeamID, Type: model.BoardTypeOpen},
+		} {
+			_, err := store.InsertBoard(board, userID)
+			require.NoError(t, err)
+		}
+
+		boards, err := store.GetBoardsForChannel("channel-id")
+		require.NoError(t, err)
+		require.Len(t, boards, 2)
+
+		boardIDs := []string{boards[0].ID, boards[1].ID}
+		require.ElementsMatch(t, []string{"board-id-1", "board-id-2"}, boardIDs)
+	})
+
+	t.Run("should return the boards linked by a patch", func(t *testing.T) {
+		channelID := "patched-channel-id"
+		_, err := store.PatchBoard("board-id-4", &model.BoardPatch{ChannelID: &channelID}, userID)
+		require.NoError(t, err)
+
+		boards, err := store.GetBoardsForChannel(channelID)
+		require.NoError(t, err)
+		require.Len(t, boards, 1)
+		require.Equal(t, "board-id-4", boards[0].ID)
+	})
+}
+
 func testInsertBoard(t *testing.T, store store.Store) {
 	userID := testUserID
 
@@ -563,6 +638,69 @@ func testSaveMember(t *testing.T, store store.Store) {
 	})
 }
 
+func testJoinBoard(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	t.Run("should create the member and record the join", func(t *testing.T) {
+		bm := &model.BoardMember{
+			UserID:       userID,
+			BoardID:      boardID,
+			SchemeEditor: true,
+		}
+
+		nbm, err := store.JoinBoard(bm)
+		require.NoError(t, err)
+		require.Equal(t, userID, nbm.UserID)
+		require.Equal(t, boardID, nbm.BoardID)
+		require.True(t, nbm.SchemeEditor)
+
+		member, err := store.GetMemberForBoard(boardID, userID)
+		require.NoError(t, err)
+		require.True(t, member.SchemeEditor)
+
+		memberHistory, err := store.GetBoardMemberHistory(boardID, userID, 0)
+		require.NoError(t, err)
+		actions := make([]string, len(memberHistory))
+		for i, entry := range memberHistory {
+			actions[i] = entry.Action
+		}
+		require.Equal(t, []string{model.BoardMemberActionJoined}, actions)
+	})
+}
+
+func testSaveMemberRoles(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	t.Run("should update the member and record the reason", func(t *testing.T) {
+		_, err := store.SaveMember(&model.BoardMember{UserID: userID, BoardID: boardID, SchemeEditor: true})
+		require.NoError(t, err)
+
+		nbm, err := store.SaveMemberRoles(&model.BoardMember{UserID: userID, BoardID: boardID, SchemeViewer: true}, "access review")
+		require.NoError(t, err)
+		require.True(t, nbm.SchemeViewer)
+
+		member, err := store.GetMemberForBoard(boardID, userID)
+		require.NoError(t, err)
+		require.False(t, member.SchemeEditor)
+		require.True(t, member.SchemeViewer)
+
+		memberHistory, err := store.GetBoardMemberHistory(boardID, userID, 0)
+		require.NoError(t, err)
+		var updated *model.BoardMemberHistoryEntry
+		for _, entry := range memberHistory {
+			if entry.Action == model.BoardMemberActionUpdated {
+				updated = entry
+			} else {
+				require.Empty(t, entry.Reason)
+			}
+		}
+		require.NotNil(t, updated)
+		require.Equal(t, "access review", updated.Reason)
+	})
+}
+
 func testGetMemberForBoard(t *testing.T, store store.Store) {
 	userID := testUserID
 	boardID := testBoardID
@@ -683,6 +821,172 @@ func testDeleteMember(t *testing.T, store store.Store) {
 	})
 }
 
+func testUpdateMemberCustomFields(t *testing.T, store store.Store) {
+	_, err := store.SaveMember(&model.BoardMember{UserID: testUserID, BoardID: testBoardID, SchemeEditor: true})
+	require.NoError(t, err)
+
+	t.Run("should set the custom fields", func(t *testing.T) {
+		require.NoError(t, store.UpdateMemberCustomFields(testBoardID, testUserID, map[string]interface{}{"capacity": 5, "roleTitle": "Designer"}))
+
+		bm, err := store.GetMemberForBoard(testBoardID, testUserID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"capacity": float64(5), "roleTitle": "Designer"}, bm.CustomFields)
+	})
+
+	t.Run("should keep the custom fields when the roles change", func(t *testing.T) {
+		_, err := store.SaveMember(&model.BoardMember{UserID: testUserID, BoardID: testBoardID, SchemeAdmin: true})
+		require.NoError(t, err)
+
+		bm, err := store.GetMemberForBoard(testBoardID, testUserID)
+		require.NoError(t, err)
+		require.True(t, bm.SchemeAdmin)
+		require.Equal(t, map[string]interface{}{"capacity": float64(5), "roleTitle": "Designer"}, bm.CustomFields)
+	})
+
+	t.Run("should clear the custom fields", func(t *testing.T) {
+		require.NoError(t, store.UpdateMemberCustomFields(testBoardID, testUserID, nil))
+
+		bm, err := store.GetMemberForBoard(testBoardID, testUserID)
+		require.NoError(t, err)
+		require.Nil(t, bm.CustomFields)
+	})
+}
+
+func testRestoreMember(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	t.Run("should return a no rows error if the member is not deleted", func(t *testing.T) {
+		bm, err := store.RestoreMember(boardID, userID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.Nil(t, bm)
+
+		_, err = store.SaveMember(&model.BoardMember{UserID: userID, BoardID: boardID, SchemeEditor: true})
+		require.NoError(t, err)
+
+		bm, err = store.RestoreMember(boardID, userID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.Nil(t, bm)
+	})
+
+	t.Run("should restore a deleted member with its roles", func(t *testing.T) {
+		// the history rows of the member are keyed by their insert time
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.DeleteMember(boardID, userID))
+
+		members, err := store.GetMembersForBoard(boardID)
+		require.NoError(t, err)
+		require.Empty(t, members)
+
+		deleted, err := store.GetDeletedMembersForBoard(boardID)
+		require.NoError(t, err)
+		require.Len(t, deleted, 1)
+		require.Equal(t, userID, deleted[0].UserID)
+		require.NotZero(t, deleted[0].DeleteAt)
+
+		time.Sleep(10 * time.Millisecond)
+		bm, err := store.RestoreMember(boardID, userID)
+		require.NoError(t, err)
+		require.NotNil(t, bm)
+		require.Zero(t, bm.DeleteAt)
+		require.True(t, bm.SchemeEditor)
+		require.False(t, bm.SchemeAdmin)
+
+		deleted, err = store.GetDeletedMembersForBoard(boardID)
+		require.NoError(t, err)
+		require.Empty(t, deleted)
+
+		memberHistory, err := store.GetBoardMemberHistory(boardID, userID, 0)
+		require.NoError(t, err)
+		actions := make([]string, len(memberHistory))
+		for i, entry := range memberHistory {
+			actions[i] = entry.Action
+		}
+		require.ElementsMatch(t, []string{"created", "deleted", model.BoardMemberActionRestored}, actions)
+	})
+
+	t.Run("should replace the roles of a deleted member that is added again", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.DeleteMember(boardID, userID))
+
+		time.Sleep(10 * time.Millisecond)
+		_, err := store.SaveMember(&model.BoardMember{UserID: userID, BoardID: boardID, SchemeAdmin: true})
+		require.NoError(t, err)
+
+		bm, err := store.GetMemberForBoard(boardID, userID)
+		require.NoError(t, err)
+		require.True(t, bm.SchemeAdmin)
+		require.Zero(t, bm.DeleteAt)
+
+		deleted, err := store.GetDeletedMembersForBoard(boardID)
+		require.NoError(t, err)
+		require.Empty(t, deleted)
+	})
+}
+
+func testSetMembershipsInactive(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	t.Run("should return empty if the user has no memberships", func(t *testing.T) {
+		members, err := store.SetMembershipsInactive(userID, true)
+		require.NoError(t, err)
+		require.Empty(t, members)
+	})
+
+	t.Run("should flag the memberships of the user only", func(t *testing.T) {
+		for _, boardID := range []string{"board-id-1", "board-id-2"} {
+			_, err := store.SaveMember(&model.BoardMember{BoardID: boardID, UserID: userID, SchemeEditor: true})
+			require.NoError(t, err)
+		}
+		_, err := store.SaveMember(&model.BoardMember{BoardID: "board-id-1", UserID: "other-user-id", SchemeEditor: true})
+		require.NoError(t, err)
+
+		members, err := store.SetMembershipsInactive(userID, true)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		for _, member := range members {
+			require.True(t, member.Inactive)
+		}
+
+		member, err := store.GetMemberForBoard("board-id-1", userID)
+		require.NoError(t, err)
+		require.True(t, member.Inactive)
+		require.True(t, member.SchemeEditor)
+
+		otherMember, err := store.GetMemberForBoard("board-id-1", "other-user-id")
+		require.NoError(t, err)
+		require.False(t, otherMember.Inactive)
+
+		// already inactive memberships are not returned again
+		members, err = store.SetMembershipsInactive(userID, true)
+		require.NoError(t, err)
+		require.Empty(t, members)
+	})
+
+	t.Run("should keep the flag when the member roles change", func(t *testing.T) {
+		_, err := store.SaveMember(&model.BoardMember{BoardID: "board-id-1", UserID: userID, SchemeAdmin: true})
+		require.NoError(t, err)
+
+		member, err := store.GetMemberForBoard("board-id-1", userID)
+		require.NoError(t, err)
+		require.True(t, member.Inactive)
+		require.True(t, member.SchemeAdmin)
+	})
+
+	t.Run("should restore the memberships", func(t *testing.T) {
+		members, err := store.SetMembershipsInactive(userID, false)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+
+		members, err = store.GetMembersForUser(userID)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		for _, member := range members {
+			require.False(t, member.Inactive)
+		}
+	})
+}
+
 func testSearchBoardsForUserAndTeam(t *testing.T, store store.Store) {
 	teamID1 := "team-id-1"
 	teamID2 := "team-id-2"
@@ -994,3 +1298,59 @@ func testGetBoardHistory(t *testing.T, store store.Store) {
 		require.Len(t, boards, 0)
 	})
 }
+
+func testGetRecentBoardsForUser(t *testing.T, store store.Store) {
+	userID := "user-id-1"
+	teamID := "team-id-1"
+
+	for _, board := range []*model.Board{
+		{ID: "board-id-1", TeamID: teamID, Type: model.BoardTypeOpen},
+		{ID: "board-id-2", TeamID: teamID, Type: model.BoardTypeOpen},
+		{ID: "board-id-3", TeamID: "team-id-2", Type: model.BoardTypeOpen},
+		{ID: "board-id-4", TeamID: teamID, Type: model.BoardTypeOpen, IsTemplate: true},
+	} {
+		_, err := store.InsertBoard(board, userID)
+		require.NoError(t, err)
+	}
+
+	t.Run("should return an empty list if the user has no visits", func(t *testing.T) {
+		boards, err := store.GetRecentBoardsForUser(userID, teamID, 10)
+		require.NoError(t, err)
+		require.Empty(t, boards)
+	})
+
+	visits := []*model.BoardVisit{
+		{UserID: userID, BoardID: "board-id-1", VisitedAt: 100},
+		{UserID: userID, BoardID: "board-id-2", VisitedAt: 200},
+		{UserID: userID, BoardID: "board-id-3", VisitedAt: 300},
+		{UserID: userID, BoardID: "board-id-4", VisitedAt: 400},
+		{UserID: "other-user", BoardID: "board-id-1", VisitedAt: 500},
+	}
+	require.NoError(t, store.SaveBoardVisits(visits))
+
+	t.Run("should return the visited boards of the team, most recent first", func(t *testing.T) {
+		boards, err := store.GetRecentBoardsForUser(userID, teamID, 10)
+		require.NoError(t, err)
+		require.Len(t, boards, 2)
+		require.Equal(t, "board-id-2", boards[0].ID)
+		require.Equal(t, "board-id-1", boards[1].ID)
+	})
+
+	t.Run("should return the boards of every team if no team is specified", func(t *testing.T) {
+		boards, err := store.GetRecentBoardsForUser(userID, "", 10)
+		require.NoError(t, err)
+		require.Len(t, boards, 3)
+		require.Equal(t, "board-id-3", boards[0].ID)
+	})
+
+	t.Run("should update existing visits and honor the limit", func(t *testing.T) {
+		require.NoError(t, store.SaveBoardVisits([]*model.BoardVisit{
+			{UserID: userID, BoardID: "board-id-1", VisitedAt: 1000},
+		}))
+
+		boards, err := store.GetRecentBoardsForUser(userID, teamID, 1)
+		require.NoError(t, err)
+		require.Len(t, boards, 1)
+		require.Equal(t, "board-id-1", boards[0].ID)
+	})
+}
@@ -594,7 +594,7 @@ func testDuplicateBoard(t *testing.T, store store.Store) {
 	require.Len(t, bab.Blocks, 2)
 
 	t.Run("duplicate existing board as no template", func(t *testing.T) {
-		bab, members, err := store.DuplicateBoard("board-id-1", userID, teamID, false)
+		bab, members, err := store.DuplicateBoard("board-id-1", userID, teamID, false, nil)
 		require.NoError(t, err)
 		require.Len(t, members, 1)
 		require.Len(t, bab.Boards, 1)
@@ -603,7 +603,7 @@ func testDuplicateBoard(t *testing.T, store store.Store) {
 	})
 
 	t.Run("duplicate existing board as template", func(t *testing.T) {
-		bab, members, err := store.DuplicateBoard("board-id-1", userID, teamID, true)
+		bab, members, err := store.DuplicateBoard("board-id-1", userID, teamID, true, nil)
 		require.NoError(t, err)
 		require.Len(t, members, 1)
 		require.Len(t, bab.Boards, 1)
@@ -611,8 +611,59 @@ func testDuplicateBoard(t *testing.T, store store.Store) {
 		require.Equal(t, bab.Boards[0].IsTemplate, true)
 	})
 
+	t.Run("duplicate existing board with a user mapping", func(t *testing.T) {
+		board := &model.Board{
+			ID:     "board-id-mapping",
+			TeamID: teamID,
+			Type:   model.BoardTypeOpen,
+			CardProperties: []map[string]interface{}{
+				{"id": "owner-prop", "name": "Owner", "type": "person"},
+			},
+		}
+		_, err := store.CreateBoardsAndBlocks(&model.BoardsAndBlocks{Boards: []*model.Board{board}}, userID)
+		require.NoError(t, err)
+
+		card := &model.Block{
+			ID:      "block-id-mapping",
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{
+				"properties": map[string]interface{}{"owner-prop": "old-user-id"},
+			},
+		}
+		require.NoError(t, store.InsertBlock(card, "old-user-id"))
+
+		_, err = store.SaveMember(&model.BoardMember{BoardID: board.ID, UserID: "old-user-id", SchemeEditor: true})
+		require.NoError(t, err)
+		_, err = store.SaveMember(&model.BoardMember{BoardID: board.ID, UserID: "dropped-user-id", SchemeViewer: true})
+		require.NoError(t, err)
+
+		userMapping := model.UserMapping{"old-user-id": "new-user-id", "dropped-user-id": ""}
+		bab, members, err := store.DuplicateBoard(board.ID, userID, "other-team-id", false, userMapping)
+		require.NoError(t, err)
+		require.Len(t, bab.Boards, 1)
+		require.Len(t, bab.Blocks, 1)
+		require.Equal(t, "other-team-id", bab.Boards[0].TeamID)
+
+		newBoardID := bab.Boards[0].ID
+		require.Len(t, members, 2)
+		member, err := store.GetMemberForBoard(newBoardID, "new-user-id")
+		require.NoError(t, err)
+		require.True(t, member.SchemeEditor)
+		require.False(t, member.SchemeAdmin)
+
+		_, err = store.GetMemberForBoard(newBoardID, "dropped-user-id")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		block, err := store.GetBlock(bab.Blocks[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, "new-user-id", block.CreatedBy)
+		props, _ := block.Fields["properties"].(map[string]interface{})
+		require.Equal(t, "new-user-id", props["owner-prop"])
+	})
+
 	t.Run("duplicate not existing board", func(t *testing.T) {
-		bab, members, err := store.DuplicateBoard("not-existing-id", userID, teamID, false)
+		bab, members, err := store.DuplicateBoard("not-existing-id", userID, teamID, false, nil)
 		require.Error(t, err)
 		require.Nil(t, members)
 		require.Nil(t, bab)
@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestCalendarSubscriptionsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CalendarSubscription", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCalendarSubscription(t, store)
+	})
+	t.Run("GetDueCalendarSubscriptions", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDueCalendarSubscriptions(t, store)
+	})
+}
+
+func testCalendarSubscription(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	_, err := store.GetCalendarSubscription(boardID)
+	require.True(t, store.IsErrNotFound(err))
+
+	subscription := &model.CalendarSubscription{
+		BoardID:    boardID,
+		URL:        "https://example.com/calendar.ics",
+		ModifiedBy: "user-id",
+		UpdateAt:   1000,
+	}
+	require.NoError(t, store.SaveCalendarSubscription(subscription))
+
+	saved, err := store.GetCalendarSubscription(boardID)
+	require.NoError(t, err)
+	require.Equal(t, subscription, saved)
+
+	subscription.DateProperty = "due"
+	subscription.LastSyncAt = 2000
+	subscription.LastError = "not found"
+	require.NoError(t, store.SaveCalendarSubscription(subscription))
+
+	saved, err = store.GetCalendarSubscription(boardID)
+	require.NoError(t, err)
+	require.Equal(t, subscription, saved)
+
+	require.NoError(t, store.DeleteCalendarSubscription(boardID))
+	_, err = store.GetCalendarSubscription(boardID)
+	require.True(t, store.IsErrNotFound(err))
+}
+
+func testGetDueCalendarSubscriptions(t *testing.T, store store.Store) {
+	neverSynced := &model.CalendarSubscription{BoardID: "board-1", URL: "https://example.com/1.ics", ModifiedBy: "user-id", UpdateAt: 1}
+	syncedLongAgo := &model.CalendarSubscription{BoardID: "board-2", URL: "https://example.com/2.ics", ModifiedBy: "user-id", UpdateAt: 1, LastSyncAt: 1000}
+	syncedRecently := &model.CalendarSubscription{BoardID: "board-3", URL: "https://example.com/3.ics", ModifiedBy: "user-id", UpdateAt: 1, LastSyncAt: 5000}
+	for _, subscription := range []*model.CalendarSubscription{syncedRecently, syncedLongAgo, neverSynced} {
+		require.NoError(t, store.SaveCalendarSubscription(subscription))
+	}
+
+	due, err := store.GetDueCalendarSubscriptions(2000)
+	require.NoError(t, err)
+	require.Equal(t, []*model.CalendarSubscription{neverSynced, syncedLongAgo}, due)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestCardAgingStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("GetCardsStatusSince", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCardsStatusSince(t, store)
+	})
+}
+
+func testGetCardsStatusSince(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	statusID := utils.NewID(utils.IDTypeNone)
+	newCard := func(status string) *model.Block {
+		card := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: boardID,
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{statusID: status}},
+		}
+		require.NoError(t, store.InsertBlock(card, testUserID))
+		card, err := store.GetBlock(card.ID)
+		require.NoError(t, err)
+		return card
+	}
+	patch := func(cardID string, patch *model.BlockPatch) *model.Block {
+		// the versions of the cards need distinct times
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.PatchBlock(cardID, patch, testUserID))
+		card, err := store.GetBlock(cardID)
+		require.NoError(t, err)
+		return card
+	}
+
+	unchanged := newCard("todo")
+	moved := newCard("todo")
+	moved = patch(moved.ID, &model.BlockPatch{
+		UpdatedFields: map[string]interface{}{"properties": map[string]interface{}{statusID: "doing"}},
+	})
+	title := "new title"
+	patch(moved.ID, &model.BlockPatch{Title: &title})
+	patch(unchanged.ID, &model.BlockPatch{Title: &title})
+
+	t.Run("since the last change of status", func(t *testing.T) {
+		since, err := store.GetCardsStatusSince(boardID, statusID, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{
+			unchanged.ID: unchanged.UpdateAt,
+			moved.ID:     moved.UpdateAt,
+		}, since)
+	})
+
+	t.Run("only the given cards", func(t *testing.T) {
+		since, err := store.GetCardsStatusSince(boardID, statusID, []string{moved.ID})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{moved.ID: moved.UpdateAt}, since)
+	})
+
+	t.Run("invalid property", func(t *testing.T) {
+		_, err := store.GetCardsStatusSince(boardID, "status'", nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestCardGroupsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("GetCardGroups", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCardGroups(t, store)
+	})
+	t.Run("GetSwimlaneCells", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetSwimlaneCells(t, store)
+	})
+}
+
+func testGetCardGroups(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	statusID := utils.NewID(utils.IDTypeNone)
+	pointsID := utils.NewID(utils.IDTypeNone)
+	newCard := func(fields map[string]interface{}) {
+		card := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: boardID,
+			Type:    model.TypeCard,
+			Fields:  fields,
+		}
+		require.NoError(t, store.InsertBlock(card, testUserID))
+	}
+	properties := func(status, points string) map[string]interface{} {
+		props := map[string]interface{}{}
+		if status != "" {
+			props[statusID] = status
+		}
+		if points != "" {
+			props[pointsID] = points
+		}
+		return map[string]interface{}{"properties": props}
+	}
+
+	newCard(properties("todo", "3"))
+	newCard(properties("todo", "2.5"))
+	newCard(properties("todo", "many"))
+	newCard(properties("done", "1"))
+	newCard(properties("", "8"))
+	templateFields := properties("done", "100")
+	templateFields["isTemplate"] = true
+	newCard(templateFields)
+
+	byValue := func(groups []*model.CardGroup) map[string]*model.CardGroup {
+		m := map[string]*model.CardGroup{}
+		for _, group := range groups {
+			m[group.Value] = group
+		}
+		return m
+	}
+
+	t.Run("counts", func(t *testing.T) {
+		groups, err := store.GetCardGroups(boardID, statusID, "")
+		require.NoError(t, err)
+		require.Len(t, groups, 3)
+		m := byValue(groups)
+		require.Equal(t, int64(3), m["todo"].Count)
+		require.Equal(t, int64(1), m["done"].Count, "card templates are not counted")
+		require.Equal(t, int64(1), m[""].Count)
+		require.Nil(t, m["todo"].Sum)
+	})
+
+	t.Run("sums", func(t *testing.T) {
+		groups, err := store.GetCardGroups(boardID, statusID, pointsID)
+		require.NoError(t, err)
+		m := byValue(groups)
+		require.InDelta(t, 5.5, *m["todo"].Sum, 0.001, "values that are not numbers are ignored")
+		require.InDelta(t, 1, *m["done"].Sum, 0.001)
+		require.InDelta(t, 8, *m[""].Sum, 0.001)
+	})
+
+	t.Run("empty board", func(t *testing.T) {
+		groups, err := store.GetCardGroups(utils.NewID(utils.IDTypeBoard), statusID, "")
+		require.NoError(t, err)
+		require.Empty(t, groups)
+	})
+}
+
+func testGetSwimlaneCells(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	statusID := utils.NewID(utils.IDTypeNone)
+	priorityID := utils.NewID(utils.IDTypeNone)
+	newCard := func(status, priority string, isTemplate bool) string {
+		props := map[string]interface{}{}
+		if status != "" {
+			props[statusID] = status
+		}
+		if priority != "" {
+			props[priorityID] = priority
+		}
+		fields := map[string]interface{}{"properties": props}
+		if isTemplate {
+			fields["isTemplate"] = true
+		}
+		card := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: boardID,
+			Type:    model.TypeCard,
+			Fields:  fields,
+		}
+		require.NoError(t, store.InsertBlock(card, testUserID))
+		return card.ID
+	}
+
+	first := newCard("todo", "high", false)
+	second := newCard("todo", "high", false)
+	done := newCard("done", "high", false)
+	noPriority := newCard("todo", "", false)
+	newCard("done", "high", true)
+
+	t.Run("cells", func(t *testing.T) {
+		cells, err := store.GetSwimlaneCells(boardID, priorityID, statusID)
+		require.NoError(t, err)
+		require.Len(t, cells, 3)
+
+		byValues := map[[2]string]*model.SwimlaneCell{}
+		for _, cell := range cells {
+			byValues[[2]string{cell.Row, cell.Column}] = cell
+		}
+		require.ElementsMatch(t, []string{first, second}, byValues[[2]string{"high", "todo"}].CardIDs)
+		require.Equal(t, int64(2), byValues[[2]string{"high", "todo"}].Count)
+		require.Equal(t, []string{done}, byValues[[2]string{"high", "done"}].CardIDs, "card templates are not included")
+		require.Equal(t, []string{noPriority}, byValues[[2]string{"", "todo"}].CardIDs)
+	})
+
+	t.Run("empty board", func(t *testing.T) {
+		cells, err := store.GetSwimlaneCells(utils.NewID(utils.IDTypeBoard), priorityID, statusID)
+		require.NoError(t, err)
+		require.Empty(t, cells)
+	})
+
+	t.Run("invalid property", func(t *testing.T) {
+		_, err := store.GetSwimlaneCells(boardID, "priority'", statusID)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestCardNumbersStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SetBoardKey", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSetBoardKey(t, store)
+	})
+	t.Run("CardNumbers", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCardNumbers(t, store)
+	})
+}
+
+func testSetBoardKey(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+
+	_, err := store.GetBoardKey(boardID)
+	require.True(t, store.IsErrNotFound(err))
+
+	require.NoError(t, store.SetBoardKey(boardID, "PROJ"))
+	require.NoError(t, store.SetBoardKey(boardID, "PROJ"), "setting the same key twice is not an error")
+
+	key, err := store.GetBoardKey(boardID)
+	require.NoError(t, err)
+	require.Equal(t, "PROJ", key)
+
+	id, err := store.GetBoardIDForKey("PROJ")
+	require.NoError(t, err)
+	require.Equal(t, boardID, id)
+
+	err = store.SetBoardKey(otherBoardID, "PROJ")
+	ce, ok := model.AsCodedError(err)
+	require.True(t, ok)
+	require.Equal(t, model.ErrCodeBoardKeyTaken, ce.Code)
+
+	// the old key is free once the board has a new one
+	require.NoError(t, store.SetBoardKey(boardID, "NEW"))
+	require.NoError(t, store.SetBoardKey(otherBoardID, "PROJ"))
+
+	_, err = store.GetBoardIDForKey("MISSING")
+	require.True(t, store.IsErrNotFound(err))
+}
+
+func testCardNumbers(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newCard := func() *model.Block {
+		card := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: boardID,
+			Type:    model.TypeCard,
+		}
+		require.NoError(t, store.InsertBlock(card, testUserID))
+		return card
+	}
+
+	// the cards of a board without a key have no numbers
+	card1 := newCard()
+	card2 := newCard()
+	_, err := store.GetCardIDForNumber(boardID, 1)
+	require.True(t, store.IsErrNotFound(err))
+
+	// the existing cards are numbered when the board gets a key
+	require.NoError(t, store.SetBoardKey(boardID, "PROJ"))
+	first, err := store.GetCardIDForNumber(boardID, 1)
+	require.NoError(t, err)
+	second, err := store.GetCardIDForNumber(boardID, 2)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{card1.ID, card2.ID}, []string{first, second})
+
+	// the new cards get the next number, the other blocks don't
+	card3 := newCard()
+	require.NoError(t, store.InsertBlock(&model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  boardID,
+		ParentID: card3.ID,
+		Type:     model.TypeText,
+	}, testUserID))
+
+	third, err := store.GetCardIDForNumber(boardID, 3)
+	require.NoError(t, err)
+	require.Equal(t, card3.ID, third)
+	_, err = store.GetCardIDForNumber(boardID, 4)
+	require.True(t, store.IsErrNotFound(err))
+
+	// updating a card keeps its number
+	card3.Title = "updated"
+	require.NoError(t, store.InsertBlock(card3, testUserID))
+	_, err = store.GetCardIDForNumber(boardID, 4)
+	require.True(t, store.IsErrNotFound(err))
+
+	// the cards inserted concurrently get different numbers
+	const concurrentCards = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrentCards)
+	for i := 0; i < concurrentCards; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- store.InsertBlock(&model.Block{
+				ID:      utils.NewID(utils.IDTypeCard),
+				BoardID: boardID,
+				Type:    model.TypeCard,
+			}, testUserID)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	numbered := map[string]bool{}
+	for number := int64(4); number < 4+concurrentCards; number++ {
+		cardID, err := store.GetCardIDForNumber(boardID, number)
+		require.NoError(t, err)
+		numbered[cardID] = true
+	}
+	require.Len(t, numbered, concurrentCards)
+}
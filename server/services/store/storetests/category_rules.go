@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestCategoryRulesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CategoryRules", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCategoryRules(t, store)
+	})
+}
+
+func testCategoryRules(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	teamID := "team-id"
+
+	createCategory := func() *model.Category {
+		category := &model.Category{Name: "Sprints", UserID: userID, TeamID: teamID}
+		category.Hydrate()
+		require.NoError(t, store.CreateCategory(*category))
+		return category
+	}
+	createRule := func(categoryID, pattern string, createAt int64) *model.CategoryRule {
+		rule := &model.CategoryRule{UserID: userID, TeamID: teamID, CategoryID: categoryID, TitlePattern: pattern}
+		rule.Hydrate()
+		rule.CreateAt = createAt
+		require.NoError(t, store.CreateCategoryRule(rule))
+		return rule
+	}
+
+	rules, err := store.GetCategoryRules(userID, teamID)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	category := createCategory()
+	otherCategory := createCategory()
+	second := createRule(category.ID, "Sprint*", 200)
+	first := createRule(otherCategory.ID, "*Retro*", 100)
+
+	t.Run("the rules come in the order they were created", func(t *testing.T) {
+		rules, err := store.GetCategoryRules(userID, teamID)
+		require.NoError(t, err)
+		require.Equal(t, []*model.CategoryRule{first, second}, rules)
+
+		rules, err = store.GetCategoryRules(userID, "other-team-id")
+		require.NoError(t, err)
+		require.Empty(t, rules)
+	})
+
+	t.Run("the rules of deleted categories are left out", func(t *testing.T) {
+		require.NoError(t, store.DeleteCategory(otherCategory.ID, userID, teamID))
+
+		rules, err := store.GetCategoryRules(userID, teamID)
+		require.NoError(t, err)
+		require.Equal(t, []*model.CategoryRule{second}, rules)
+	})
+
+	t.Run("delete a rule", func(t *testing.T) {
+		err := store.DeleteCategoryRule(second.ID, "other-user-id")
+		require.True(t, store.IsErrNotFound(err), "only the owner deletes their rules")
+
+		require.NoError(t, store.DeleteCategoryRule(second.ID, userID))
+
+		rules, err := store.GetCategoryRules(userID, teamID)
+		require.NoError(t, err)
+		require.Empty(t, rules)
+
+		err = store.DeleteCategoryRule(second.ID, userID)
+		require.True(t, store.IsErrNotFound(err))
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+func StoreTestContextStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("WithContext", func(t *testing.T) {
+		st, tearDown := setup(t)
+		defer tearDown()
+		testWithContext(t, st)
+	})
+	t.Run("WithCancelledContext", func(t *testing.T) {
+		st, tearDown := setup(t)
+		defer tearDown()
+		testWithCancelledContext(t, st)
+	})
+}
+
+func testWithContext(t *testing.T, st store.Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scoped := st.WithContext(ctx)
+
+	require.NoError(t, scoped.SetSystemSetting("context-key", "value"))
+
+	value, err := scoped.GetSystemSetting("context-key")
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+
+	err = scoped.RunInTransaction(func(tx store.Store) error {
+		return tx.SetSystemSetting("context-key", "updated")
+	})
+	require.NoError(t, err)
+
+	value, err = st.GetSystemSetting("context-key")
+	require.NoError(t, err)
+	require.Equal(t, "updated", value)
+}
+
+func testWithCancelledContext(t *testing.T, st store.Store) {
+	require.NoError(t, st.SetSystemSetting("context-key", "value"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scoped := st.WithContext(ctx)
+	cancel()
+
+	t.Run("queries are cancelled", func(t *testing.T) {
+		_, err := scoped.GetSystemSetting("context-key")
+		require.ErrorIs(t, err, context.Canceled)
+
+		_, err = scoped.GetSystemSettings()
+		require.ErrorIs(t, err, context.Canceled)
+
+		err = scoped.SetSystemSetting("context-key", "cancelled")
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("transactions are cancelled", func(t *testing.T) {
+		called := false
+		err := scoped.RunInTransaction(func(tx store.Store) error {
+			called = true
+			return nil
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.False(t, called)
+	})
+
+	t.Run("the store itself isn't affected", func(t *testing.T) {
+		value, err := st.GetSystemSetting("context-key")
+		require.NoError(t, err)
+		require.Equal(t, "value", value)
+	})
+}
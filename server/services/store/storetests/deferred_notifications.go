@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestDeferredNotificationsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("DeferredNotifications", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeferredNotifications(t, store)
+	})
+}
+
+func testDeferredNotifications(t *testing.T, store store.Store) {
+	userID := "user-id-1"
+	otherUserID := "user-id-2"
+
+	insert := func(userID, key string, createAt int64) *model.DeferredNotification {
+		notification := &model.DeferredNotification{
+			ID:        utils.NewID(utils.IDTypeNone),
+			UserID:    userID,
+			EventType: model.NotifyEventMention,
+			Key:       key,
+			Message:   "@someone mentioned you",
+			Link:      "http://localhost/boards/team/board",
+			CreateAt:  createAt,
+		}
+		require.NoError(t, store.InsertDeferredNotification(notification))
+		return notification
+	}
+
+	userIDs, err := store.GetUsersWithDeferredNotifications()
+	require.NoError(t, err)
+	require.Empty(t, userIDs)
+
+	second := insert(userID, "block-2", 200)
+	first := insert(userID, "block-1", 100)
+	insert(otherUserID, "block-1", 100)
+
+	t.Run("users with deferred notifications", func(t *testing.T) {
+		userIDs, err := store.GetUsersWithDeferredNotifications()
+		require.NoError(t, err)
+		require.Equal(t, []string{userID, otherUserID}, userIDs)
+	})
+
+	t.Run("the notifications of the user come oldest first", func(t *testing.T) {
+		notifications, err := store.GetDeferredNotificationsForUser(userID)
+		require.NoError(t, err)
+		require.Equal(t, []*model.DeferredNotification{first, second}, notifications)
+	})
+
+	t.Run("a notification is claimed by a single server", func(t *testing.T) {
+		claimed, err := store.ClaimDeferredNotification(second.ID, "server-1", 0)
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		claimed, err = store.ClaimDeferredNotification(second.ID, "server-2", 0)
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		// the claims of the servers that stopped are taken over
+		claimed, err = store.ClaimDeferredNotification(second.ID, "server-2", utils.GetMillis()+1)
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		// only the claimer can release the claim
+		require.NoError(t, store.ReleaseDeferredNotification(second.ID, "server-1"))
+		claimed, err = store.ClaimDeferredNotification(second.ID, "server-1", 0)
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		require.NoError(t, store.ReleaseDeferredNotification(second.ID, "server-2"))
+		claimed, err = store.ClaimDeferredNotification(second.ID, "server-1", 0)
+		require.NoError(t, err)
+		require.True(t, claimed)
+		require.NoError(t, store.ReleaseDeferredNotification(second.ID, "server-1"))
+	})
+
+	t.Run("delete a notification", func(t *testing.T) {
+		require.NoError(t, store.DeleteDeferredNotification(first.ID))
+
+		notifications, err := store.GetDeferredNotificationsForUser(userID)
+		require.NoError(t, err)
+		require.Equal(t, []*model.DeferredNotification{second}, notifications)
+	})
+}
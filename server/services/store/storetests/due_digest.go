@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestDueDigestStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("DueDigestSettings", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDueDigestSettings(t, store)
+	})
+}
+
+func testDueDigestSettings(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	otherUserID := utils.NewID(utils.IDTypeUser)
+
+	t.Run("users without settings", func(t *testing.T) {
+		settings, err := store.GetDueDigestSettings(userID)
+		require.True(t, store.IsErrNotFound(err))
+		require.Nil(t, settings)
+
+		enabled, err := store.GetEnabledDueDigestSettings()
+		require.NoError(t, err)
+		require.Empty(t, enabled)
+	})
+
+	settings := &model.DueDigestSettings{UserID: userID, Enabled: true, Hour: 8}
+	require.NoError(t, store.SaveDueDigestSettings(settings))
+	require.NoError(t, store.SaveDueDigestSettings(&model.DueDigestSettings{UserID: otherUserID, Hour: 10}))
+
+	t.Run("get the saved settings", func(t *testing.T) {
+		saved, err := store.GetDueDigestSettings(userID)
+		require.NoError(t, err)
+		require.Equal(t, settings, saved)
+	})
+
+	t.Run("only the enabled settings are listed", func(t *testing.T) {
+		enabled, err := store.GetEnabledDueDigestSettings()
+		require.NoError(t, err)
+		require.Equal(t, []*model.DueDigestSettings{settings}, enabled)
+	})
+
+	t.Run("saving again updates the settings", func(t *testing.T) {
+		settings.Hour = 18
+		settings.LastSentAt = 1234
+		require.NoError(t, store.SaveDueDigestSettings(settings))
+
+		saved, err := store.GetDueDigestSettings(userID)
+		require.NoError(t, err)
+		require.Equal(t, settings, saved)
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestGitHubIntegrationsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("GitHubIntegration", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGitHubIntegration(t, store)
+	})
+}
+
+func testGitHubIntegration(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	_, err := store.GetGitHubIntegration(boardID)
+	require.True(t, store.IsErrNotFound(err))
+
+	integration := &model.GitHubIntegration{
+		BoardID:       boardID,
+		Repository:    "owner/repo",
+		WebhookSecret: "secret",
+		ModifiedBy:    "user-id",
+		UpdateAt:      1000,
+	}
+	require.NoError(t, store.SaveGitHubIntegration(integration))
+
+	saved, err := store.GetGitHubIntegration(boardID)
+	require.NoError(t, err)
+	require.Equal(t, integration, saved)
+
+	integration.EncryptedToken = "encrypted-token"
+	integration.StatusProperty = "status"
+	integration.OpenStatus = "open"
+	integration.ClosedStatus = "done"
+	integration.AssigneeProperty = "assignee"
+	integration.LastSyncAt = 2000
+	require.NoError(t, store.SaveGitHubIntegration(integration))
+
+	saved, err = store.GetGitHubIntegration(boardID)
+	require.NoError(t, err)
+	require.Equal(t, integration, saved)
+
+	require.NoError(t, store.DeleteGitHubIntegration(boardID))
+	_, err = store.GetGitHubIntegration(boardID)
+	require.True(t, store.IsErrNotFound(err))
+}
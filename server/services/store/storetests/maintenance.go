@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestMaintenanceStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("RunMaintenance", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRunMaintenance(t, store)
+	})
+}
+
+func testRunMaintenance(t *testing.T, store store.Store) {
+	board, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+	}, testUserID)
+	require.NoError(t, err)
+
+	deletedBoard, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+	}, testUserID)
+	require.NoError(t, err)
+	// the history rows of the board are keyed by their insert time
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, store.DeleteBoard(deletedBoard.ID, testUserID))
+
+	goneBoardID := utils.NewID(utils.IDTypeBoard)
+	for _, boardID := range []string{board.ID, deletedBoard.ID, goneBoardID} {
+		_, err = store.SaveMember(&model.BoardMember{BoardID: boardID, UserID: testUserID, SchemeEditor: true})
+		require.NoError(t, err)
+	}
+
+	report, err := store.RunMaintenance()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), report.DeletedOrphans["board_members"])
+	require.NotEmpty(t, report.Statements)
+	require.GreaterOrEqual(t, report.EndAt, report.StartAt)
+
+	_, err = store.GetMemberForBoard(board.ID, testUserID)
+	require.NoError(t, err)
+
+	_, err = store.GetMemberForBoard(deletedBoard.ID, testUserID)
+	require.NoError(t, err, "the members of the boards that can be restored are kept")
+
+	_, err = store.GetMemberForBoard(goneBoardID, testUserID)
+	require.Error(t, err)
+}
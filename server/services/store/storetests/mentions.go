@@ -0,0 +1,145 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestMentionsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("InsertMention", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testInsertMention(t, store)
+	})
+
+	t.Run("GetMentionsForUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetMentionsForUser(t, store)
+	})
+
+	t.Run("MarkMentionsRead", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMarkMentionsRead(t, store)
+	})
+}
+
+func newTestMention(userID string, createAt int64) *model.Mention {
+	return &model.Mention{
+		UserID:      userID,
+		TeamID:      testTeamID,
+		BoardID:     utils.NewID(utils.IDTypeBoard),
+		CardID:      utils.NewID(utils.IDTypeCard),
+		BlockID:     utils.NewID(utils.IDTypeBlock),
+		MentionedBy: utils.NewID(utils.IDTypeUser),
+		Extract:     "hello @user",
+		CreateAt:    createAt,
+	}
+}
+
+func testInsertMention(t *testing.T, store store.Store) {
+	t.Run("insert mention", func(t *testing.T) {
+		mention := newTestMention("user-1", 0)
+
+		err := store.InsertMention(mention)
+		require.NoError(t, err)
+		assert.NotEmpty(t, mention.ID)
+		assert.NotZero(t, mention.CreateAt)
+
+		mentions, err := store.GetMentionsForUser("user-1", model.QueryMentionsOptions{})
+		require.NoError(t, err)
+		require.Len(t, mentions, 1)
+		assert.Equal(t, mention, mentions[0])
+	})
+
+	t.Run("invalid mention", func(t *testing.T) {
+		mention := newTestMention("", 0)
+
+		err := store.InsertMention(mention)
+		var errInvalid model.ErrInvalidMention
+		assert.ErrorAs(t, err, &errInvalid)
+	})
+}
+
+func testGetMentionsForUser(t *testing.T, store store.Store) {
+	for i := int64(1); i <= 5; i++ {
+		require.NoError(t, store.InsertMention(newTestMention("user-1", i*1000)))
+	}
+	require.NoError(t, store.InsertMention(newTestMention("user-2", 1000)))
+
+	t.Run("newest first", func(t *testing.T) {
+		mentions, err := store.GetMentionsForUser("user-1", model.QueryMentionsOptions{})
+		require.NoError(t, err)
+		require.Len(t, mentions, 5)
+		for i, mention := range mentions {
+			assert.Equal(t, "user-1", mention.UserID)
+			assert.Equal(t, int64(5-i)*1000, mention.CreateAt)
+		}
+	})
+
+	t.Run("before and limit", func(t *testing.T) {
+		mentions, err := store.GetMentionsForUser("user-1", model.QueryMentionsOptions{BeforeCreateAt: 4000, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, mentions, 2)
+		assert.Equal(t, int64(3000), mentions[0].CreateAt)
+		assert.Equal(t, int64(2000), mentions[1].CreateAt)
+	})
+
+	t.Run("no mentions", func(t *testing.T) {
+		mentions, err := store.GetMentionsForUser("user-3", model.QueryMentionsOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, mentions)
+	})
+}
+
+func testMarkMentionsRead(t *testing.T, store store.Store) {
+	mentions := make([]*model.Mention, 3)
+	for i := range mentions {
+		mentions[i] = newTestMention("user-1", int64(i+1)*1000)
+		require.NoError(t, store.InsertMention(mentions[i]))
+	}
+	other := newTestMention("user-2", 1000)
+	require.NoError(t, store.InsertMention(other))
+
+	t.Run("mark some mentions", func(t *testing.T) {
+		err := store.MarkMentionsRead("user-1", []string{mentions[0].ID, other.ID}, 5000)
+		require.NoError(t, err)
+
+		unread, err := store.GetMentionsForUser("user-1", model.QueryMentionsOptions{UnreadOnly: true})
+		require.NoError(t, err)
+		require.Len(t, unread, 2)
+		assert.Equal(t, mentions[2].ID, unread[0].ID)
+		assert.Equal(t, mentions[1].ID, unread[1].ID)
+
+		// mentions of other users are left alone
+		unread, err = store.GetMentionsForUser("user-2", model.QueryMentionsOptions{UnreadOnly: true})
+		require.NoError(t, err)
+		require.Len(t, unread, 1)
+	})
+
+	t.Run("mark all mentions", func(t *testing.T) {
+		err := store.MarkMentionsRead("user-1", nil, 6000)
+		require.NoError(t, err)
+
+		unread, err := store.GetMentionsForUser("user-1", model.QueryMentionsOptions{UnreadOnly: true})
+		require.NoError(t, err)
+		assert.Empty(t, unread)
+
+		all, err := store.GetMentionsForUser("user-1", model.QueryMentionsOptions{})
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		// already read mentions keep their original read time
+		assert.Equal(t, int64(5000), all[2].ReadAt)
+		assert.Equal(t, int64(6000), all[0].ReadAt)
+	})
+}
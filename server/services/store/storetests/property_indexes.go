@@ -0,0 +1,110 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestPropertyIndexesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("PropertyIndexes", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testPropertyIndexes(t, store)
+	})
+	t.Run("GetCardsWithPropertyValues", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCardsWithPropertyValues(t, store)
+	})
+}
+
+func testPropertyIndexes(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+	propertyID := utils.NewID(utils.IDTypeNone)
+
+	indexes, err := store.GetPropertyIndexes(boardID)
+	require.NoError(t, err)
+	require.Empty(t, indexes)
+
+	require.NoError(t, store.CreatePropertyIndex(boardID, propertyID))
+	require.NoError(t, store.CreatePropertyIndex(boardID, propertyID), "indexing a property twice is not an error")
+	require.NoError(t, store.CreatePropertyIndex(otherBoardID, propertyID))
+
+	indexes, err = store.GetPropertyIndexes(boardID)
+	require.NoError(t, err)
+	require.Len(t, indexes, 1)
+	require.Equal(t, propertyID, indexes[0].PropertyID)
+
+	// the boards share the index of the property
+	propertyIDs, err := store.GetIndexedPropertyIDs()
+	require.NoError(t, err)
+	require.Equal(t, []string{propertyID}, propertyIDs)
+
+	// the index is kept while another board uses it
+	require.NoError(t, store.DeletePropertyIndex(boardID, propertyID))
+	indexes, err = store.GetPropertyIndexes(boardID)
+	require.NoError(t, err)
+	require.Empty(t, indexes)
+
+	require.NoError(t, store.DeletePropertyIndex(otherBoardID, propertyID))
+	require.NoError(t, store.CreatePropertyIndex(boardID, propertyID), "a dropped index can be created again")
+
+	err = store.CreatePropertyIndex(boardID, "bad'id")
+	ce, ok := model.AsCodedError(err)
+	require.True(t, ok)
+	require.Equal(t, model.ErrCodeBadRequest, ce.Code)
+}
+
+func testGetCardsWithPropertyValues(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	propertyID := utils.NewID(utils.IDTypeNone)
+	newCard := func(value interface{}) *model.Block {
+		card := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: boardID,
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{propertyID: value}},
+		}
+		require.NoError(t, store.InsertBlock(card, testUserID))
+		return card
+	}
+
+	todo := newCard("todo")
+	done := newCard("done")
+	newCard("doing")
+	newCard([]string{"todo"})
+
+	check := func() {
+		cards, err := store.GetCardsWithPropertyValues(boardID, propertyID, []string{"todo"})
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		require.Equal(t, todo.ID, cards[0].ID)
+
+		cards, err = store.GetCardsWithPropertyValues(boardID, propertyID, []string{"todo", "done"})
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+		require.True(t, ContainsBlockWithID(cards, done.ID))
+
+		cards, err = store.GetCardsWithPropertyValues(boardID, propertyID, []string{})
+		require.NoError(t, err)
+		require.Empty(t, cards)
+	}
+
+	t.Run("without index", func(t *testing.T) {
+		check()
+	})
+
+	t.Run("with index", func(t *testing.T) {
+		require.NoError(t, store.CreatePropertyIndex(boardID, propertyID))
+		check()
+	})
+}
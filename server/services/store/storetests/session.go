@@ -52,6 +52,26 @@ func testCreateAndGetAndDeleteSession(t *testing.T, store store.Store) {
 		_, err = store.GetSession(session.Token, 60*60)
 		require.Error(t, err)
 	})
+
+	t.Run("DeleteSessionsForUser", func(t *testing.T) {
+		userSessions := []*model.Session{
+			{ID: "session-id-1", Token: "token-1", UserID: "user-id"},
+			{ID: "session-id-2", Token: "token-2", UserID: "user-id"},
+		}
+		otherSession := &model.Session{ID: "session-id-3", Token: "token-3", UserID: "other-user-id"}
+		for _, s := range append(userSessions, otherSession) {
+			require.NoError(t, store.CreateSession(s))
+		}
+
+		require.NoError(t, store.DeleteSessionsForUser("user-id"))
+
+		for _, s := range userSessions {
+			_, err := store.GetSession(s.Token, 60*60)
+			require.Error(t, err)
+		}
+		_, err := store.GetSession(otherSession.Token, 60*60)
+		require.NoError(t, err)
+	})
 }
 
 func testGetActiveUserCount(t *testing.T, store store.Store) {
@@ -0,0 +1,64 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestStaleDigestStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("StaleDigestSettings", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testStaleDigestSettings(t, store)
+	})
+}
+
+func testStaleDigestSettings(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	otherUserID := utils.NewID(utils.IDTypeUser)
+
+	t.Run("users without settings", func(t *testing.T) {
+		settings, err := store.GetStaleDigestSettings(userID)
+		require.True(t, store.IsErrNotFound(err))
+		require.Nil(t, settings)
+
+		enabled, err := store.GetEnabledStaleDigestSettings()
+		require.NoError(t, err)
+		require.Empty(t, enabled)
+	})
+
+	settings := &model.StaleDigestSettings{UserID: userID, Enabled: true, Days: 21, Weekday: 5, Hour: 16}
+	require.NoError(t, store.SaveStaleDigestSettings(settings))
+	require.NoError(t, store.SaveStaleDigestSettings(&model.StaleDigestSettings{UserID: otherUserID, Days: 14, Weekday: 1, Hour: 9}))
+
+	t.Run("get the saved settings", func(t *testing.T) {
+		saved, err := store.GetStaleDigestSettings(userID)
+		require.NoError(t, err)
+		require.Equal(t, settings, saved)
+	})
+
+	t.Run("only the enabled settings are listed", func(t *testing.T) {
+		enabled, err := store.GetEnabledStaleDigestSettings()
+		require.NoError(t, err)
+		require.Equal(t, []*model.StaleDigestSettings{settings}, enabled)
+	})
+
+	t.Run("saving again updates the settings", func(t *testing.T) {
+		settings.Days = 7
+		settings.Weekday = 0
+		settings.LastSentAt = 1234
+		require.NoError(t, store.SaveStaleDigestSettings(settings))
+
+		saved, err := store.GetStaleDigestSettings(userID)
+		require.NoError(t, err)
+		require.Equal(t, settings, saved)
+	})
+}
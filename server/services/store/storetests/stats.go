@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestStatsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("GetHistoryStatsByTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetHistoryStatsByTeam(t, store)
+	})
+}
+
+func testGetHistoryStatsByTeam(t *testing.T, store store.Store) {
+	insertBoard := func(teamID string) *model.Board {
+		board, err := store.InsertBoard(&model.Board{
+			ID:     utils.NewID(utils.IDTypeBoard),
+			TeamID: teamID,
+			Type:   model.BoardTypeOpen,
+		}, testUserID)
+		require.NoError(t, err)
+		return board
+	}
+
+	board1 := insertBoard("team-1")
+	board2 := insertBoard("team-2")
+	blocks := []model.Block{
+		{ID: "block-1", BoardID: board1.ID, Type: model.TypeCard},
+		{ID: "block-2", BoardID: board1.ID, Type: model.TypeCard},
+		{ID: "block-3", BoardID: board2.ID, Type: model.TypeCard},
+	}
+	require.NoError(t, store.InsertBlocks(blocks, testUserID))
+
+	// the history of deleted boards still counts for their team
+	require.NoError(t, store.DeleteBoard(board2.ID, testUserID))
+
+	stats, err := store.GetHistoryStatsByTeam()
+	require.NoError(t, err)
+
+	byTeam := map[string]*model.TeamHistoryStats{}
+	for _, teamStats := range stats {
+		byTeam[teamStats.TeamID] = teamStats
+	}
+
+	require.Contains(t, byTeam, "team-1")
+	require.Equal(t, int64(1), byTeam["team-1"].BoardsHistoryRows)
+	require.Equal(t, int64(2), byTeam["team-1"].BlocksHistoryRows)
+
+	require.Contains(t, byTeam, "team-2")
+	require.Equal(t, int64(2), byTeam["team-2"].BoardsHistoryRows)
+	require.Equal(t, int64(1), byTeam["team-2"].BlocksHistoryRows)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
 )
 
 func StoreTestSubscriptionsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
@@ -49,6 +50,12 @@ func StoreTestSubscriptionsStore(t *testing.T, setup func(t *testing.T) (store.S
 		defer tearDown()
 		testGetSubscribersForBlock(t, store)
 	})
+
+	t.Run("CleanUpStaleSubscriptions", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCleanUpStaleSubscriptions(t, store)
+	})
 }
 
 func testCreateSubscription(t *testing.T, store store.Store) {
@@ -75,7 +82,7 @@ func testCreateSubscription(t *testing.T, store store.Store) {
 
 		// ensure each user has the right number of subscriptions
 		for i, user := range users {
-			subs, err := store.GetSubscriptions(user.ID)
+			subs, err := store.GetSubscriptions(user.ID, model.QuerySubscriptionsOptions{})
 			require.NoError(t, err, "get subscriptions should not error")
 			assert.Len(t, subs, i)
 		}
@@ -154,7 +161,7 @@ func testDeleteSubscription(t *testing.T, s store.Store) {
 		require.NoError(t, err, "create subscription should not error")
 
 		// check the subscription exists
-		subs, err := s.GetSubscriptions(user.ID)
+		subs, err := s.GetSubscriptions(user.ID, model.QuerySubscriptionsOptions{})
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Len(t, subs, 1)
 		assert.Equal(t, subNew.BlockID, subs[0].BlockID)
@@ -164,7 +171,7 @@ func testDeleteSubscription(t *testing.T, s store.Store) {
 		require.NoError(t, err, "delete subscription should not error")
 
 		// check the subscription was deleted
-		subs, err = s.GetSubscriptions(user.ID)
+		subs, err = s.GetSubscriptions(user.ID, model.QuerySubscriptionsOptions{})
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Empty(t, subs)
 	})
@@ -193,7 +200,7 @@ func testUndeleteSubscription(t *testing.T, s store.Store) {
 		require.NoError(t, err, "create subscription should not error")
 
 		// check the subscription exists
-		subs, err := s.GetSubscriptions(user.ID)
+		subs, err := s.GetSubscriptions(user.ID, model.QuerySubscriptionsOptions{})
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Len(t, subs, 1)
 		assert.Equal(t, subNew.BlockID, subs[0].BlockID)
@@ -203,7 +210,7 @@ func testUndeleteSubscription(t *testing.T, s store.Store) {
 		require.NoError(t, err, "delete subscription should not error")
 
 		// check the subscription was deleted
-		subs, err = s.GetSubscriptions(user.ID)
+		subs, err = s.GetSubscriptions(user.ID, model.QuerySubscriptionsOptions{})
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Empty(t, subs)
 
@@ -212,7 +219,7 @@ func testUndeleteSubscription(t *testing.T, s store.Store) {
 		require.NoError(t, err, "create subscription should not error")
 
 		// check the undeleted subscription exists
-		subs, err = s.GetSubscriptions(user.ID)
+		subs, err = s.GetSubscriptions(user.ID, model.QuerySubscriptionsOptions{})
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Len(t, subs, 1)
 		assert.Equal(t, subUndeleted.BlockID, subs[0].BlockID)
@@ -268,18 +275,55 @@ func testGetSubscriptions(t *testing.T, store store.Store) {
 		}
 
 		// ensure user has the right number of subscriptions
-		subs, err := store.GetSubscriptions(user.ID)
+		subs, err := store.GetSubscriptions(user.ID, model.QuerySubscriptionsOptions{})
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Len(t, subs, len(blocks))
 
 		// ensure author has no subscriptions
-		subs, err = store.GetSubscriptions(author.ID)
+		subs, err = store.GetSubscriptions(author.ID, model.QuerySubscriptionsOptions{})
+		require.NoError(t, err, "get subscriptions should not error")
+		assert.Empty(t, subs)
+	})
+
+	t.Run("get subscriptions by page", func(t *testing.T) {
+		author := createTestUsers(t, store, 1)[0]
+		user := createTestUsers(t, store, 1)[0]
+		blocks := createTestBlocks(t, store, author.ID, 25)
+
+		for _, block := range blocks {
+			sub := &model.Subscription{
+				BlockType:      block.Type,
+				BlockID:        block.ID,
+				SubscriberType: "user",
+				SubscriberID:   user.ID,
+			}
+			_, err := store.CreateSubscription(sub)
+			require.NoError(t, err, "create subscription should not error")
+		}
+
+		seen := map[string]bool{}
+		for page := 0; page < 3; page++ {
+			subs, err := store.GetSubscriptions(user.ID, model.QuerySubscriptionsOptions{Page: page, PerPage: 10})
+			require.NoError(t, err, "get subscriptions should not error")
+			if page < 2 {
+				require.Len(t, subs, 10)
+			} else {
+				require.Len(t, subs, 5)
+			}
+			for _, sub := range subs {
+				assert.False(t, seen[sub.BlockID], "subscription returned in more than one page")
+				seen[sub.BlockID] = true
+			}
+		}
+		assert.Len(t, seen, len(blocks))
+
+		subs, err := store.GetSubscriptions(user.ID, model.QuerySubscriptionsOptions{Page: 3, PerPage: 10})
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Empty(t, subs)
 	})
 
 	t.Run("get subscriptions for invalid user", func(t *testing.T) {
-		subs, err := store.GetSubscriptions("bogus")
+		subs, err := store.GetSubscriptions("bogus", model.QuerySubscriptionsOptions{})
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Empty(t, subs)
 	})
@@ -326,3 +370,54 @@ func testGetSubscribersForBlock(t *testing.T, store store.Store) {
 		assert.Empty(t, subs)
 	})
 }
+
+func testCleanUpStaleSubscriptions(t *testing.T, store store.Store) {
+	t.Run("clean up stale subscriptions", func(t *testing.T) {
+		user := createTestUsers(t, store, 1)[0]
+		blocks := createTestBlocks(t, store, user.ID, 3)
+
+		board := &model.Board{
+			ID:     utils.NewID(utils.IDTypeBoard),
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+		}
+		_, err := store.InsertBoard(board, user.ID)
+		require.NoError(t, err)
+
+		subs := []*model.Subscription{{
+			BlockType:      model.TypeBoard,
+			BlockID:        board.ID,
+			SubscriberType: "user",
+			SubscriberID:   user.ID,
+		}}
+		for _, block := range blocks {
+			subs = append(subs, &model.Subscription{
+				BlockType:      block.Type,
+				BlockID:        block.ID,
+				SubscriberType: "user",
+				SubscriberID:   user.ID,
+			})
+		}
+		for _, sub := range subs {
+			_, err = store.CreateSubscription(sub)
+			require.NoError(t, err, "create subscription should not error")
+		}
+
+		// nothing to clean up while blocks and boards exist
+		count, err := store.CleanUpStaleSubscriptions()
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		require.NoError(t, store.DeleteBlock(blocks[0].ID, user.ID))
+		require.NoError(t, store.DeleteBoard(board.ID, user.ID))
+
+		count, err = store.CleanUpStaleSubscriptions()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		found, err := store.GetSubscriptions(user.ID, model.QuerySubscriptionsOptions{})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.ElementsMatch(t, []string{blocks[1].ID, blocks[2].ID}, []string{found[0].BlockID, found[1].BlockID})
+	})
+}
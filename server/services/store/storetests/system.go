@@ -10,8 +10,9 @@ import (
 // these system settings are created when running the data migrations,
 // so they will be present after the tests setup.
 var dataMigrationSystemSettings = map[string]string{
-	"UniqueIDsMigrationComplete":      "true",
-	"CategoryUuidIdMigrationComplete": "true",
+	"UniqueIDsMigrationComplete":           "true",
+	"CategoryUuidIdMigrationComplete":      "true",
+	"BlocksHistoryBoardIDBackfillComplete": "true",
 }
 
 func addBaseSettings(m map[string]string) map[string]string {
@@ -31,6 +32,12 @@ func StoreTestSystemStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testSetGetSystemSettings(t, store)
 	})
+
+	t.Run("GetMigrationStatus", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetMigrationStatus(t, store)
+	})
 }
 
 func testSetGetSystemSettings(t *testing.T, store store.Store) {
@@ -62,3 +69,13 @@ func testSetGetSystemSettings(t *testing.T, store store.Store) {
 		require.Equal(t, "test-value-1", value)
 	})
 }
+
+func testGetMigrationStatus(t *testing.T, store store.Store) {
+	t.Run("No pending migrations after the setup", func(t *testing.T) {
+		status, err := store.GetMigrationStatus(true)
+		require.NoError(t, err)
+		require.NotZero(t, status.LatestVersion)
+		require.Equal(t, status.LatestVersion, status.CurrentVersion)
+		require.Empty(t, status.Pending)
+	})
+}
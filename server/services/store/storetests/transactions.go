@@ -0,0 +1,74 @@
+package storetests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestTransactions(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("RunInTransaction", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRunInTransaction(t, store)
+	})
+}
+
+func testRunInTransaction(t *testing.T, st store.Store) {
+	t.Run("changes are committed when the function succeeds", func(t *testing.T) {
+		err := st.RunInTransaction(func(tx store.Store) error {
+			if err := tx.SetSystemSetting("tx-commit-1", "one"); err != nil {
+				return err
+			}
+			return tx.SetSystemSetting("tx-commit-2", "two")
+		})
+		require.NoError(t, err)
+
+		value, err := st.GetSystemSetting("tx-commit-1")
+		require.NoError(t, err)
+		require.Equal(t, "one", value)
+
+		value, err = st.GetSystemSetting("tx-commit-2")
+		require.NoError(t, err)
+		require.Equal(t, "two", value)
+	})
+
+	t.Run("changes are rolled back when the function fails", func(t *testing.T) {
+		errFailed := errors.New("failed")
+		err := st.RunInTransaction(func(tx store.Store) error {
+			if err := tx.SetSystemSetting("tx-rollback", "value"); err != nil {
+				return err
+			}
+
+			// changes are visible inside the transaction.
+			value, err := tx.GetSystemSetting("tx-rollback")
+			require.NoError(t, err)
+			require.Equal(t, "value", value)
+
+			return errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+
+		settings, err := st.GetSystemSettings()
+		require.NoError(t, err)
+		require.NotContains(t, settings, "tx-rollback")
+	})
+
+	t.Run("nested calls join the transaction", func(t *testing.T) {
+		errFailed := errors.New("failed")
+		err := st.RunInTransaction(func(tx store.Store) error {
+			err := tx.RunInTransaction(func(nested store.Store) error {
+				return nested.SetSystemSetting("tx-nested", "value")
+			})
+			require.NoError(t, err)
+			return errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+
+		settings, err := st.GetSystemSettings()
+		require.NoError(t, err)
+		require.NotContains(t, settings, "tx-nested")
+	})
+}
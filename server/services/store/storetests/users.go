@@ -44,6 +44,36 @@ func StoreTestUserStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 		defer tearDown()
 		testPatchUserProps(t, store)
 	})
+	t.Run("GetUsersByIDs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetUsersByIDs(t, store)
+	})
+}
+
+func testGetUsersByIDs(t *testing.T, store store.Store) {
+	users, err := store.GetUsersByIDs(nil)
+	require.NoError(t, err)
+	require.Empty(t, users)
+
+	users, err = store.GetUsersByIDs([]string{"unknown-user"})
+	require.NoError(t, err)
+	require.Empty(t, users)
+
+	userIDs := []string{}
+	for _, username := range []string{"alice", "bob", "carol"} {
+		user := &model.User{ID: utils.NewID(utils.IDTypeUser), Username: username}
+		require.NoError(t, store.CreateUser(user))
+		userIDs = append(userIDs, user.ID)
+	}
+
+	users, err = store.GetUsersByIDs([]string{userIDs[0], userIDs[2], "unknown-user"})
+	require.NoError(t, err)
+	usernames := []string{}
+	for _, user := range users {
+		usernames = append(usernames, user.Username)
+	}
+	require.ElementsMatch(t, []string{"alice", "carol"}, usernames)
 }
 
 func testGetTeamUsers(t *testing.T, store store.Store) {
@@ -154,6 +184,25 @@ func testCreateAndUpdateUser(t *testing.T, store store.Store) {
 		require.Equal(t, user.ID, got.ID)
 		require.Equal(t, newPassword, got.Password)
 	})
+
+	t.Run("UpdateUserDeleteAt", func(t *testing.T) {
+		err := store.UpdateUserDeleteAt(user.Username, utils.GetMillis())
+		require.NoError(t, err)
+
+		_, err = store.GetUserByID(user.ID)
+		require.True(t, store.IsErrNotFound(err))
+
+		err = store.UpdateUserDeleteAt(user.Username, 0)
+		require.NoError(t, err)
+
+		got, err := store.GetUserByID(user.ID)
+		require.NoError(t, err)
+		require.Equal(t, user.ID, got.ID)
+		require.Zero(t, got.DeleteAt)
+
+		err = store.UpdateUserDeleteAt("nonexistent-username", 0)
+		require.True(t, store.IsErrNotFound(err))
+	})
 }
 
 func testCreateAndGetRegisteredUserCount(t *testing.T, store store.Store) {
@@ -0,0 +1,170 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestViewCardsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("GetViewCards", func(t *testing.T) {
+		st, tearDown := setup(t)
+		defer tearDown()
+		testGetViewCards(t, st)
+	})
+	t.Run("GetCardUserIDs", func(t *testing.T) {
+		st, tearDown := setup(t)
+		defer tearDown()
+		testGetCardUserIDs(t, st)
+	})
+}
+
+func testGetViewCards(t *testing.T, st store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	schema := model.PropSchema{
+		"status": {ID: "status", Type: "select", Options: map[string]model.PropDefOption{
+			"opt-a": {ID: "opt-a", Value: "Backlog"},
+			"opt-b": {ID: "opt-b", Value: "active"},
+		}},
+		"tags":    {ID: "tags", Type: "multiSelect", Options: map[string]model.PropDefOption{"tag-x": {ID: "tag-x", Value: "x"}}},
+		"points":  {ID: "points", Type: "number"},
+		"due":     {ID: "due", Type: "date"},
+		"fruit":   {ID: "fruit", Type: "text"},
+		"created": {ID: "created", Type: "createdBy"},
+		"time":    {ID: "time", Type: "createdTime"},
+	}
+	usernames := map[string]string{"user-a": "alice", "user-b": "Bob", "user-c": ""}
+
+	newCard := func(title, userID string, props map[string]interface{}, template bool) {
+		fields := map[string]interface{}{"properties": props}
+		if template {
+			fields["isTemplate"] = true
+		}
+		card := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: boardID,
+			Type:    model.TypeCard,
+			Title:   title,
+			Fields:  fields,
+		}
+		require.NoError(t, st.InsertBlock(card, userID))
+		// the untitled cards sort by creation time
+		time.Sleep(2 * time.Millisecond)
+	}
+	newCard("banana", "user-b", map[string]interface{}{"status": "opt-b", "points": "10", "due": `{"from":300}`, "tags": []interface{}{"tag-x"}}, false)
+	newCard("", "user-a", map[string]interface{}{"status": "opt-a", "due": `{"from":100}`}, false)
+	newCard("Apple", "user-a", map[string]interface{}{"points": "2", "fruit": "pear"}, false)
+	newCard("", "user-c", map[string]interface{}{"points": "2", "status": "unknown-option", "fruit": "Fig"}, false)
+	newCard("cherry", "user-b", map[string]interface{}{"points": "many", "due": "", "fruit": "apple"}, false)
+	newCard("aardvark", "user-a", map[string]interface{}{"status": "opt-a"}, true)
+
+	blocks, err := st.GetBlocksWithType(boardID, model.TypeCard)
+	require.NoError(t, err)
+	cards := []model.Block{}
+	for _, block := range blocks {
+		if isTemplate, _ := block.Fields["isTemplate"].(bool); !isTemplate {
+			cards = append(cards, block)
+		}
+	}
+	require.Len(t, cards, 5)
+
+	ids := func(cards []model.Block) []string {
+		result := []string{}
+		for _, card := range cards {
+			result = append(result, card.ID)
+		}
+		return result
+	}
+	sortOptions := func(propertyID string, reversed bool) map[string]interface{} {
+		return map[string]interface{}{"sortOptions": []interface{}{
+			map[string]interface{}{"propertyId": propertyID, "reversed": reversed},
+		}}
+	}
+
+	views := map[string]map[string]interface{}{
+		"manual order":          {"cardOrder": []interface{}{cards[3].ID, cards[1].ID, cards[3].ID, "unknown-card"}},
+		"title":                 sortOptions(model.TitleColumnID, false),
+		"title reversed":        sortOptions(model.TitleColumnID, true),
+		"select":                sortOptions("status", false),
+		"select reversed":       sortOptions("status", true),
+		"multi select":          sortOptions("tags", false),
+		"number":                sortOptions("points", false),
+		"number reversed":       sortOptions("points", true),
+		"date":                  sortOptions("due", false),
+		"text":                  sortOptions("fruit", false),
+		"text reversed":         sortOptions("fruit", true),
+		"created by":            sortOptions("created", false),
+		"created time reversed": sortOptions("time", true),
+		"unknown property":      sortOptions("unknown", false),
+		"several sort options": {"sortOptions": []interface{}{
+			map[string]interface{}{"propertyId": "points", "reversed": false},
+			map[string]interface{}{"propertyId": model.TitleColumnID, "reversed": true},
+		}},
+	}
+
+	for name, fields := range views {
+		t.Run(name, func(t *testing.T) {
+			view := &model.Block{ID: "view-id", BoardID: boardID, Type: model.TypeView, Fields: fields}
+			// the store orders the cards the view doesn't by title
+			expected := append([]model.Block{}, cards...)
+			model.SortCardsForView(expected, schema, &model.Block{Type: model.TypeView}, usernames)
+			model.SortCardsForView(expected, schema, view, usernames)
+
+			opts := model.NewQueryViewCardsOptions(view, schema, usernames, 0, 100)
+			result, total, err := st.GetViewCards(boardID, opts)
+			require.NoError(t, err)
+			require.Equal(t, 5, total, "card templates are left out")
+			require.Equal(t, ids(expected), ids(result))
+		})
+	}
+
+	t.Run("window", func(t *testing.T) {
+		view := &model.Block{ID: "view-id", BoardID: boardID, Type: model.TypeView, Fields: sortOptions("points", false)}
+		expected := append([]model.Block{}, cards...)
+		model.SortCardsForView(expected, schema, view, usernames)
+
+		result, total, err := st.GetViewCards(boardID, model.NewQueryViewCardsOptions(view, schema, usernames, 1, 2))
+		require.NoError(t, err)
+		require.Equal(t, 5, total)
+		require.Equal(t, ids(expected[1:3]), ids(result))
+
+		result, total, err = st.GetViewCards(boardID, model.NewQueryViewCardsOptions(view, schema, usernames, 10, 2))
+		require.NoError(t, err)
+		require.Equal(t, 5, total)
+		require.Empty(t, result)
+	})
+
+	t.Run("invalid property ID", func(t *testing.T) {
+		opts := model.QueryViewCardsOptions{Sorts: []model.ViewCardsSort{{Kind: model.ViewCardsSortByText, PropertyID: "'; --"}}}
+		_, _, err := st.GetViewCards(boardID, opts)
+		require.Error(t, err)
+	})
+}
+
+func testGetCardUserIDs(t *testing.T, st store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	userIDs, err := st.GetCardUserIDs(boardID)
+	require.NoError(t, err)
+	require.Empty(t, userIDs)
+
+	card := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: boardID, Type: model.TypeCard}
+	require.NoError(t, st.InsertBlock(card, "user-a"))
+	require.NoError(t, st.InsertBlock(card, "user-b"))
+	other := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: boardID, Type: model.TypeCard}
+	require.NoError(t, st.InsertBlock(other, "user-a"))
+	view := &model.Block{ID: utils.NewID(utils.IDTypeView), BoardID: boardID, Type: model.TypeView}
+	require.NoError(t, st.InsertBlock(view, "user-c"))
+
+	userIDs, err = st.GetCardUserIDs(boardID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"user-a", "user-b"}, userIDs, "the creators and last modifiers of the cards")
+}
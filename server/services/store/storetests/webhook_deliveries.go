@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+func StoreTestWebhookDeliveriesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("InsertAndUpdateWebhookDelivery", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testInsertAndUpdateWebhookDelivery(t, store)
+	})
+
+	t.Run("GetDueWebhookDeliveries", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDueWebhookDeliveries(t, store)
+	})
+
+	t.Run("GetDeadWebhookDeliveries", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDeadWebhookDeliveries(t, store)
+	})
+}
+
+func newTestWebhookDelivery(status string, nextAttemptAt int64) *model.WebhookDelivery {
+	return &model.WebhookDelivery{
+		URL:           "http://example.com/hook",
+		Payload:       `{"id":"block-id"}`,
+		Attempts:      1,
+		Status:        status,
+		NextAttemptAt: nextAttemptAt,
+	}
+}
+
+func testInsertAndUpdateWebhookDelivery(t *testing.T, store store.Store) {
+	delivery := newTestWebhookDelivery(model.WebhookDeliveryPending, 100)
+	require.NoError(t, store.InsertWebhookDelivery(delivery))
+	require.NotEmpty(t, delivery.ID)
+	require.NotZero(t, delivery.CreateAt)
+
+	fetched, err := store.GetWebhookDelivery(delivery.ID)
+	require.NoError(t, err)
+	require.Equal(t, delivery.URL, fetched.URL)
+	require.Equal(t, delivery.Payload, fetched.Payload)
+	require.Empty(t, fetched.LastError)
+	require.Empty(t, fetched.WebhookID)
+
+	delivery.RecordFailure(errors.New("connection refused"), 200)
+	require.NoError(t, store.UpdateWebhookDelivery(delivery))
+
+	fetched, err = store.GetWebhookDelivery(delivery.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, fetched.Attempts)
+	require.Equal(t, "connection refused", fetched.LastError)
+
+	require.NoError(t, store.DeleteWebhookDelivery(delivery.ID))
+	_, err = store.GetWebhookDelivery(delivery.ID)
+	require.True(t, store.IsErrNotFound(err))
+}
+
+func testGetDueWebhookDeliveries(t *testing.T, store store.Store) {
+	due := newTestWebhookDelivery(model.WebhookDeliveryPending, 100)
+	require.NoError(t, store.InsertWebhookDelivery(due))
+	later := newTestWebhookDelivery(model.WebhookDeliveryPending, 1000)
+	require.NoError(t, store.InsertWebhookDelivery(later))
+	dead := newTestWebhookDelivery(model.WebhookDeliveryDead, 0)
+	require.NoError(t, store.InsertWebhookDelivery(dead))
+
+	deliveries, err := store.GetDueWebhookDeliveries(500, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, due.ID, deliveries[0].ID)
+
+	deliveries, err = store.GetDueWebhookDeliveries(1000, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+
+	deliveries, err = store.GetDueWebhookDeliveries(1000, 1)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+}
+
+func testGetDeadWebhookDeliveries(t *testing.T, store store.Store) {
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.InsertWebhookDelivery(newTestWebhookDelivery(model.WebhookDeliveryDead, 0)))
+	}
+	require.NoError(t, store.InsertWebhookDelivery(newTestWebhookDelivery(model.WebhookDeliveryPending, 100)))
+
+	deliveries, err := store.GetDeadWebhookDeliveries(0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+
+	deliveries, err = store.GetDeadWebhookDeliveries(1, 2)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+}
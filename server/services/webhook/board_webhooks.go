@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// NotifyBlockChange calls the webhooks of the configuration, for the
+// blocks added and updated, and the enabled webhooks of the board of the
+// block subscribed to the event.
+func (wh *Client) NotifyBlockChange(event string, block model.Block) {
+	if event != model.BoardWebhookEventBlockDeleted {
+		wh.NotifyUpdate(block)
+	}
+
+	if wh.store == nil {
+		return
+	}
+
+	webhooks, err := wh.store.GetBoardWebhooksForBoard(block.BoardID)
+	if err != nil {
+		wh.logger.Error("Cannot fetch the board webhooks", mlog.String("board_id", block.BoardID), mlog.Err(err))
+		return
+	}
+
+	var body []byte
+	for _, webhook := range webhooks {
+		if !webhook.Enabled || !webhook.HasEvent(event) {
+			continue
+		}
+
+		if body == nil {
+			body, err = json.Marshal(model.BoardWebhookPayload{
+				Event:     event,
+				BoardID:   block.BoardID,
+				Block:     block,
+				Timestamp: model.GetMillis(),
+			})
+			if err != nil {
+				wh.logger.Error("Cannot marshal the board webhook payload", mlog.String("block_id", block.ID), mlog.Err(err))
+				return
+			}
+		}
+
+		if err := wh.deliverOrQueue(webhook.URL, webhook.ID, webhook.Secret, body); err != nil {
+			wh.logger.Error("webhook.NotifyBlockChange",
+				mlog.String("webhook_id", webhook.ID),
+				mlog.String("url", webhook.URL),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		wh.logger.Debug("webhook.NotifyBlockChange", mlog.String("webhook_id", webhook.ID), mlog.String("event", event))
+	}
+}
@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const boardWebhookDialTimeout = 30 * time.Second
+
+var errAddressNotAllowed = errors.New("webhook address not allowed")
+
+// reservedNetworks are the networks the webhooks of the boards can't
+// call, unless allowed by the configuration: the loopback, private,
+// shared and link-local addresses, where the internal services of the
+// server usually are.
+var reservedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// parseAllowedNetworks parses the networks of the configuration, in CIDR
+// notation or as single addresses. The invalid ones are returned as an
+// error, along with the valid ones.
+func parseAllowedNetworks(entries []string) ([]*net.IPNet, error) {
+	networks := []*net.IPNet{}
+	invalid := []string{}
+	for _, entry := range entries {
+		if ip := net.ParseIP(entry); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			invalid = append(invalid, entry)
+			continue
+		}
+		networks = append(networks, network)
+	}
+	if len(invalid) != 0 {
+		return networks, fmt.Errorf("invalid board webhook allowed networks: %v", invalid)
+	}
+	return networks, nil
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// newBoardWebhookTransport returns the transport the webhooks of the
+// boards are called with. Their URLs are set by the board admins, so the
+// connections to the reserved networks are refused, except to the
+// allowed networks. The address is checked once resolved, right before
+// connecting, so the host name can't be used to get around the check.
+//
+// The connections don't go through the proxy of the environment, as the
+// address checked would be the proxy's.
+func newBoardWebhookTransport(allowed []*net.IPNet) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: boardWebhookDialTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("%w: %s", errAddressNotAllowed, address)
+			}
+			if containsIP(reservedNetworks, ip) && !containsIP(allowed, ip) {
+				return fmt.Errorf("%w: %s", errAddressNotAllowed, address)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
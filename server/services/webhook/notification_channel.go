@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// notificationPayload is the body posted to the notification webhooks.
+type notificationPayload struct {
+	EventType string `json:"eventType"`
+	UserID    string `json:"userId"`
+	Message   string `json:"message"`
+	Link      string `json:"link"`
+}
+
+// NotificationChannel delivers user notifications to the webhooks in the
+// NotifyWebhooks setting.
+type NotificationChannel struct {
+	client *Client
+}
+
+// NotificationChannel returns the notification channel for the client, or
+// nil if no notification webhooks are configured.
+func (wh *Client) NotificationChannel() *NotificationChannel {
+	if len(wh.config.NotifyWebhooks) == 0 {
+		return nil
+	}
+	return &NotificationChannel{client: wh}
+}
+
+func (nc *NotificationChannel) Name() string {
+	return model.NotifyChannelWebhook
+}
+
+func (nc *NotificationChannel) Deliver(n *notify.Notification) error {
+	body, err := json.Marshal(notificationPayload{
+		EventType: n.EventType,
+		UserID:    n.UserID,
+		Message:   n.Message,
+		Link:      n.Link,
+	})
+	if err != nil {
+		return err
+	}
+
+	merr := merror.New()
+	for _, url := range nc.client.config.NotifyWebhooks {
+		if err := nc.client.deliverOrQueue(url, "", nc.client.config.WebhookSecrets[url], body); err != nil {
+			merr.Append(err)
+			continue
+		}
+		nc.client.logger.Debug("webhook.NotificationChannel.Deliver", mlog.String("url", url))
+	}
+	return merr.ErrorOrNil()
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const retryBatchSize = 100
+
+// deliver posts the body to the webhook, failing on error responses. The
+// webhook ID is set for the webhooks of the boards.
+func (wh *Client) deliver(url string, webhookID string, secret string, body []byte) error {
+	resp, err := wh.postWithClient(wh.httpClient(webhookID), url, secret, body)
+	if err != nil {
+		return err
+	}
+	_, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook %s responded with status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// deliverOrQueue delivers the body to the webhook, and keeps it to be
+// retried later if the delivery fails. The webhook ID is set for the
+// webhooks of the boards.
+func (wh *Client) deliverOrQueue(url string, webhookID string, secret string, body []byte) error {
+	err := wh.deliver(url, webhookID, secret, body)
+	if err == nil || wh.store == nil {
+		return err
+	}
+
+	delivery := &model.WebhookDelivery{
+		WebhookID: webhookID,
+		URL:       url,
+		Payload:   string(body),
+	}
+	delivery.RecordFailure(err, model.GetMillis())
+	if insertErr := wh.store.InsertWebhookDelivery(delivery); insertErr != nil {
+		wh.logger.Error("Cannot queue the failed webhook delivery", mlog.String("url", url), mlog.Err(insertErr))
+	}
+	return err
+}
+
+// RetryDeliveries retries the failed deliveries that are due. The ones
+// that fail too many times are moved to the dead-letter queue.
+func (wh *Client) RetryDeliveries() {
+	if wh.store == nil {
+		return
+	}
+
+	deliveries, err := wh.store.GetDueWebhookDeliveries(model.GetMillis(), retryBatchSize)
+	if err != nil {
+		wh.logger.Error("Cannot fetch the webhook deliveries to retry", mlog.Err(err))
+		return
+	}
+
+	for _, delivery := range deliveries {
+		if err := wh.retry(delivery); err != nil {
+			wh.logger.Debug("Webhook delivery retry failed",
+				mlog.String("id", delivery.ID),
+				mlog.String("url", delivery.URL),
+				mlog.Int("attempts", delivery.Attempts),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// RetryDelivery retries a failed delivery right away, including the ones
+// in the dead-letter queue.
+func (wh *Client) RetryDelivery(id string) error {
+	if wh.store == nil {
+		return errNoDeliveryStore
+	}
+
+	delivery, err := wh.store.GetWebhookDelivery(id)
+	if err != nil {
+		return err
+	}
+	return wh.retry(delivery)
+}
+
+// retry delivers the payload again, removing the delivery on success and
+// recording the failure otherwise. The deliveries of board webhooks that
+// were deleted are removed.
+func (wh *Client) retry(delivery *model.WebhookDelivery) error {
+	secret := wh.config.WebhookSecrets[delivery.URL]
+	if delivery.WebhookID != "" {
+		webhook, err := wh.store.GetBoardWebhook(delivery.WebhookID)
+		if err != nil {
+			if wh.store.IsErrNotFound(err) {
+				return wh.store.DeleteWebhookDelivery(delivery.ID)
+			}
+			return err
+		}
+		secret = webhook.Secret
+	}
+
+	err := wh.deliver(delivery.URL, delivery.WebhookID, secret, []byte(delivery.Payload))
+	if err == nil {
+		return wh.store.DeleteWebhookDelivery(delivery.ID)
+	}
+
+	delivery.RecordFailure(err, model.GetMillis())
+	if updateErr := wh.store.UpdateWebhookDelivery(delivery); updateErr != nil {
+		wh.logger.Error("Cannot update the webhook delivery", mlog.String("id", delivery.ID), mlog.Err(updateErr))
+	}
+	return err
+}
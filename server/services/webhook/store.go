@@ -0,0 +1,25 @@
+package webhook
+
+import "github.com/mattermost/focalboard/server/model"
+
+// DeliveryStore keeps the failed deliveries to be retried.
+type DeliveryStore interface {
+	InsertWebhookDelivery(delivery *model.WebhookDelivery) error
+	UpdateWebhookDelivery(delivery *model.WebhookDelivery) error
+	DeleteWebhookDelivery(id string) error
+	GetWebhookDelivery(id string) (*model.WebhookDelivery, error)
+	GetDueWebhookDeliveries(now int64, limit uint64) ([]*model.WebhookDelivery, error)
+}
+
+// BoardWebhookStore provides the webhooks configured on the boards.
+type BoardWebhookStore interface {
+	GetBoardWebhook(id string) (*model.BoardWebhook, error)
+	GetBoardWebhooksForBoard(boardID string) ([]*model.BoardWebhook, error)
+	IsErrNotFound(err error) bool
+}
+
+// Store provides the failed deliveries and the board webhooks.
+type Store interface {
+	DeliveryStore
+	BoardWebhookStore
+}
@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// maxValidationResponseSize is the largest response of a validation
+// webhook that is read.
+const maxValidationResponseSize = 64 * 1024
+
+// ValidatePropertyChanges calls the validation webhooks of the board that
+// validate one of the changed properties of the cards, one after the
+// other, before the changes are saved. The old cards are matched by index,
+// and are nil for new cards. It returns a bad request error with the
+// message of the first webhook that rejects a change. The webhooks that
+// can't validate a change in time reject it, unless they fail open.
+func (wh *Client) ValidatePropertyChanges(boardID string, cards []model.Block, oldCards []*model.Block) error {
+	if wh.store == nil {
+		return nil
+	}
+
+	var webhooks []*model.BoardWebhook
+	fetched := false
+	for i := range cards {
+		if cards[i].Type != model.TypeCard {
+			continue
+		}
+		var oldCard *model.Block
+		if i < len(oldCards) {
+			oldCard = oldCards[i]
+		}
+		changes := model.ChangedProperties(&cards[i], oldCard)
+		if len(changes) == 0 {
+			continue
+		}
+
+		if !fetched {
+			var err error
+			if webhooks, err = wh.store.GetBoardWebhooksForBoard(boardID); err != nil {
+				return err
+			}
+			fetched = true
+		}
+		if err := wh.validatePropertyChange(webhooks, cards[i], changes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePropertyChange calls the webhooks that validate one of the
+// changed properties of the card.
+func (wh *Client) validatePropertyChange(webhooks []*model.BoardWebhook, card model.Block, changes map[string]model.PropertyChange) error {
+	var body []byte
+	for _, webhook := range webhooks {
+		if !webhook.ValidatesChange(changes) {
+			continue
+		}
+
+		if body == nil {
+			var err error
+			body, err = json.Marshal(model.PropertyValidationPayload{
+				Event:     model.BoardWebhookEventPropertyValidation,
+				BoardID:   card.BoardID,
+				Card:      card,
+				Changes:   changes,
+				Timestamp: model.GetMillis(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		params := map[string]interface{}{"webhookId": webhook.ID, "blockId": card.ID}
+		rejected, message, err := wh.validate(webhook, body)
+		if err != nil {
+			if webhook.FailOpen {
+				wh.logger.Warn("Validation webhook failed, the change is accepted",
+					mlog.String("webhook_id", webhook.ID),
+					mlog.String("url", webhook.URL),
+					mlog.Err(err),
+				)
+				continue
+			}
+			wh.logger.Error("Validation webhook failed, the change is rejected",
+				mlog.String("webhook_id", webhook.ID),
+				mlog.String("url", webhook.URL),
+				mlog.Err(err),
+			)
+			return model.NewCodedError(model.ErrCodeBadRequest, "the change could not be validated", params)
+		}
+		if rejected {
+			if message == "" {
+				message = "the change was rejected by a validation webhook"
+			}
+			return model.NewCodedError(model.ErrCodeBadRequest, message, params)
+		}
+
+		wh.logger.Debug("webhook.ValidatePropertyChanges", mlog.String("webhook_id", webhook.ID), mlog.String("block_id", card.ID))
+	}
+	return nil
+}
+
+// validate posts the change to the validation webhook. The change is
+// accepted on success responses and rejected on client error responses,
+// with the message of the response if any. Other responses are errors.
+func (wh *Client) validate(webhook *model.BoardWebhook, body []byte) (rejected bool, message string, err error) {
+	client := wh.httpClient(webhook.ID)
+	client.Timeout = webhook.ValidationTimeout()
+	resp, err := wh.postWithClient(client, webhook.URL, webhook.Secret, body)
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxValidationResponseSize))
+	if err != nil {
+		return false, "", err
+	}
+
+	switch {
+	case resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices:
+		return false, "", nil
+	case resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError:
+		var response model.PropertyValidationResponse
+		_ = json.Unmarshal(data, &response)
+		return true, response.Message, nil
+	}
+	return false, "", fmt.Errorf("validation webhook %s responded with status %d", webhook.URL, resp.StatusCode)
+}
@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/store/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func TestValidatePropertyChanges(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	var payloads []model.PropertyValidationPayload
+	accepting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload model.PropertyValidationPayload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads = append(payloads, payload)
+	}))
+	defer accepting.Close()
+
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"the release checklist is not complete"}`))
+	}))
+	defer rejecting.Close()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockstore.NewMockStore(ctrl)
+	client := NewClient(&config.Configuration{BoardWebhookAllowedNetworks: testAllowedNetworks}, store, logger)
+
+	card := func(status string) model.Block {
+		return model.Block{
+			ID:      "card-id",
+			BoardID: "board-id",
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{"status": status}},
+		}
+	}
+	oldCard := card("in-progress")
+	validate := func() error {
+		return client.ValidatePropertyChanges("board-id", []model.Block{card("released")}, []*model.Block{&oldCard})
+	}
+	validationWebhook := func(url string, propertyIDs ...string) *model.BoardWebhook {
+		return &model.BoardWebhook{
+			ID:          "webhook-id",
+			URL:         url,
+			Events:      []string{model.BoardWebhookEventPropertyValidation},
+			PropertyIDs: propertyIDs,
+			Enabled:     true,
+		}
+	}
+
+	t.Run("accepted change", func(t *testing.T) {
+		webhooks := []*model.BoardWebhook{
+			validationWebhook(accepting.URL, "status"),
+			validationWebhook(rejecting.URL, "estimate"),
+			{ID: "async", URL: rejecting.URL, Events: []string{model.BoardWebhookEventBlockUpdated}, Enabled: true},
+		}
+		store.EXPECT().GetBoardWebhooksForBoard("board-id").Return(webhooks, nil)
+
+		require.NoError(t, validate())
+		require.Len(t, payloads, 1)
+		require.Equal(t, model.BoardWebhookEventPropertyValidation, payloads[0].Event)
+		require.Equal(t, "card-id", payloads[0].Card.ID)
+		require.Equal(t, model.PropertyChange{Old: "in-progress", New: "released"}, payloads[0].Changes["status"])
+	})
+
+	t.Run("rejected change", func(t *testing.T) {
+		store.EXPECT().GetBoardWebhooksForBoard("board-id").Return([]*model.BoardWebhook{validationWebhook(rejecting.URL)}, nil)
+
+		err := validate()
+		require.True(t, model.IsErrBadRequest(err))
+		require.Contains(t, err.Error(), "the release checklist is not complete")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		webhook := validationWebhook(slow.URL)
+		webhook.TimeoutMs = 50
+
+		store.EXPECT().GetBoardWebhooksForBoard("board-id").Return([]*model.BoardWebhook{webhook}, nil)
+		require.True(t, model.IsErrBadRequest(validate()))
+
+		webhook.FailOpen = true
+		store.EXPECT().GetBoardWebhooksForBoard("board-id").Return([]*model.BoardWebhook{webhook}, nil)
+		require.NoError(t, validate())
+	})
+
+	t.Run("unchanged cards are not validated", func(t *testing.T) {
+		require.NoError(t, client.ValidatePropertyChanges("board-id", []model.Block{card("in-progress")}, []*model.Block{&oldCard}))
+	})
+}
@@ -3,8 +3,10 @@ package webhook
 import (
 	"bytes"
 	"encoding/json"
-	"io/ioutil"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
@@ -23,24 +25,66 @@ func (wh *Client) NotifyUpdate(block model.Block) {
 		wh.logger.Fatal("NotifyUpdate: json.Marshal", mlog.Err(err))
 	}
 	for _, url := range wh.config.WebhookUpdate {
-		resp, _ := http.Post(url, "application/json", bytes.NewBuffer(json)) //nolint:gosec
-		_, _ = ioutil.ReadAll(resp.Body)
-		resp.Body.Close()
+		if err := wh.deliverOrQueue(url, "", wh.config.WebhookSecrets[url], json); err != nil {
+			wh.logger.Error("webhook.NotifyUpdate", mlog.String("url", url), mlog.Err(err))
+			continue
+		}
 
 		wh.logger.Debug("webhook.NotifyUpdate", mlog.String("url", url))
 	}
 }
 
+// httpClient returns the HTTP client the webhook is called with. The
+// webhooks of the boards, with an ID, can't call the reserved networks,
+// the ones of the configuration are trusted.
+func (wh *Client) httpClient(webhookID string) *http.Client {
+	if webhookID == "" {
+		return http.DefaultClient
+	}
+	return &http.Client{Transport: wh.boardWebhookTransport}
+}
+
+// postWithClient sends the body to the webhook with the HTTP client,
+// signed with the secret when there is one.
+func (wh *Client) postWithClient(client *http.Client, url string, secret string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if secret != "" {
+		timestamp := time.Now().Unix()
+		req.Header.Set(model.HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(model.HeaderWebhookSignature, model.SignWebhookPayload(secret, timestamp, body))
+	}
+
+	return client.Do(req) //nolint:gosec
+}
+
+var errNoDeliveryStore = errors.New("webhook deliveries are not stored")
+
 // Client is a webhook client.
 type Client struct {
-	config *config.Configuration
-	logger *mlog.Logger
+	config                *config.Configuration
+	store                 Store
+	logger                *mlog.Logger
+	boardWebhookTransport *http.Transport
 }
 
-// NewClient creates a new Client.
-func NewClient(config *config.Configuration, logger *mlog.Logger) *Client {
+// NewClient creates a new Client. The failed deliveries are kept in the
+// store to be retried; without a store they are only logged and the
+// webhooks of the boards aren't called.
+func NewClient(config *config.Configuration, store Store, logger *mlog.Logger) *Client {
+	allowed, err := parseAllowedNetworks(config.BoardWebhookAllowedNetworks)
+	if err != nil {
+		logger.Error("Ignoring the invalid board webhook allowed networks", mlog.Err(err))
+	}
+
 	return &Client{
-		config: config,
-		logger: logger,
+		config:                config,
+		store:                 store,
+		logger:                logger,
+		boardWebhookTransport: newBoardWebhookTransport(allowed),
 	}
 }
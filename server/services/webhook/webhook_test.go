@@ -1,17 +1,29 @@
 package webhook
 
 import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/store/mockstore"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+// testAllowedNetworks let the webhooks of the boards call the test
+// servers.
+var testAllowedNetworks = []string{"127.0.0.0/8", "::1"}
+
 func TestClientUpdateNotify(t *testing.T) {
 	var isNotified bool
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -29,7 +41,7 @@ func TestClientUpdateNotify(t *testing.T) {
 		assert.NoError(t, err)
 	}()
 
-	client := NewClient(cfg, logger)
+	client := NewClient(cfg, nil, logger)
 
 	client.NotifyUpdate(model.Block{})
 
@@ -37,3 +49,204 @@ func TestClientUpdateNotify(t *testing.T) {
 		t.Error("webhook url not be notified")
 	}
 }
+
+func TestNotificationChannel(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	t.Run("not available without webhooks", func(t *testing.T) {
+		client := NewClient(&config.Configuration{}, nil, logger)
+		assert.Nil(t, client.NotificationChannel())
+	})
+
+	t.Run("notification is posted", func(t *testing.T) {
+		var payload notificationPayload
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+		}))
+		defer ts.Close()
+
+		client := NewClient(&config.Configuration{NotifyWebhooks: []string{ts.URL}}, nil, logger)
+		channel := client.NotificationChannel()
+		require.NotNil(t, channel)
+
+		err := channel.Deliver(&notify.Notification{
+			EventType: model.NotifyEventMention,
+			UserID:    "user-id",
+			Message:   "hello",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "user-id", payload.UserID)
+		assert.Equal(t, "hello", payload.Message)
+	})
+
+	t.Run("failed webhook", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+
+		client := NewClient(&config.Configuration{NotifyWebhooks: []string{ts.URL}}, nil, logger)
+		err := client.NotificationChannel().Deliver(&notify.Notification{UserID: "user-id"})
+		require.Error(t, err)
+	})
+}
+
+func TestSignedWebhook(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	var verifyErr error
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		verifyErr = model.VerifyWebhookSignature("secret",
+			r.Header.Get(model.HeaderWebhookSignature),
+			r.Header.Get(model.HeaderWebhookTimestamp),
+			body, 0, time.Now())
+	}))
+	defer ts.Close()
+
+	t.Run("webhook with a secret is signed", func(t *testing.T) {
+		client := NewClient(&config.Configuration{
+			WebhookUpdate:  []string{ts.URL},
+			WebhookSecrets: map[string]string{ts.URL: "secret"},
+		}, nil, logger)
+		client.NotifyUpdate(model.Block{ID: "block-id"})
+		require.NoError(t, verifyErr)
+	})
+
+	t.Run("webhook without a secret is not signed", func(t *testing.T) {
+		client := NewClient(&config.Configuration{NotifyWebhooks: []string{ts.URL}}, nil, logger)
+		err := client.NotificationChannel().Deliver(&notify.Notification{UserID: "user-id"})
+		require.NoError(t, err)
+		require.ErrorIs(t, verifyErr, model.ErrWebhookSignatureMissing)
+	})
+}
+
+func TestDeliveryRetry(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	failing := true
+	var received int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockstore.NewMockStore(ctrl)
+	client := NewClient(&config.Configuration{WebhookUpdate: []string{ts.URL}}, store, logger)
+
+	t.Run("failed delivery is queued", func(t *testing.T) {
+		failing = true
+		store.EXPECT().InsertWebhookDelivery(gomock.Any()).DoAndReturn(func(delivery *model.WebhookDelivery) error {
+			require.Equal(t, ts.URL, delivery.URL)
+			require.Equal(t, 1, delivery.Attempts)
+			require.Equal(t, model.WebhookDeliveryPending, delivery.Status)
+			require.NotEmpty(t, delivery.Payload)
+			return nil
+		})
+		client.NotifyUpdate(model.Block{ID: "block-id"})
+	})
+
+	t.Run("failed retry is rescheduled", func(t *testing.T) {
+		failing = true
+		delivery := &model.WebhookDelivery{ID: "delivery-id", URL: ts.URL, Payload: "{}", Attempts: 1, Status: model.WebhookDeliveryPending}
+		store.EXPECT().GetDueWebhookDeliveries(gomock.Any(), gomock.Any()).Return([]*model.WebhookDelivery{delivery}, nil)
+		store.EXPECT().UpdateWebhookDelivery(delivery).Return(nil)
+
+		client.RetryDeliveries()
+		require.Equal(t, 2, delivery.Attempts)
+		require.Equal(t, model.WebhookDeliveryPending, delivery.Status)
+	})
+
+	t.Run("successful retry removes the delivery", func(t *testing.T) {
+		failing = false
+		received = 0
+		delivery := &model.WebhookDelivery{ID: "delivery-id", URL: ts.URL, Payload: "{}", Attempts: 8, Status: model.WebhookDeliveryDead}
+		store.EXPECT().GetWebhookDelivery("delivery-id").Return(delivery, nil)
+		store.EXPECT().DeleteWebhookDelivery("delivery-id").Return(nil)
+
+		require.NoError(t, client.RetryDelivery("delivery-id"))
+		require.Equal(t, 1, received)
+	})
+}
+
+func TestNotifyBlockChange(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	var received []*http.Request
+	var payloads []model.BoardWebhookPayload
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		var payload model.BoardWebhookPayload
+		require.NoError(t, json.Unmarshal(body, &payload))
+		received = append(received, r)
+		payloads = append(payloads, payload)
+	}))
+	defer ts.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockstore.NewMockStore(ctrl)
+	client := NewClient(&config.Configuration{BoardWebhookAllowedNetworks: testAllowedNetworks}, store, logger)
+
+	webhooks := []*model.BoardWebhook{
+		{ID: "created", URL: ts.URL, Events: []string{model.BoardWebhookEventBlockCreated}, Secret: "secret", Enabled: true},
+		{ID: "deleted", URL: ts.URL, Events: []string{model.BoardWebhookEventBlockDeleted}, Enabled: true},
+		{ID: "disabled", URL: ts.URL, Events: []string{model.BoardWebhookEventBlockCreated}, Enabled: false},
+	}
+	store.EXPECT().GetBoardWebhooksForBoard("board-id").Return(webhooks, nil)
+
+	client.NotifyBlockChange(model.BoardWebhookEventBlockCreated, model.Block{ID: "block-id", BoardID: "board-id"})
+
+	require.Len(t, received, 1)
+	require.NotEmpty(t, received[0].Header.Get(model.HeaderWebhookSignature))
+	require.Equal(t, model.BoardWebhookEventBlockCreated, payloads[0].Event)
+	require.Equal(t, "board-id", payloads[0].BoardID)
+	require.Equal(t, "block-id", payloads[0].Block.ID)
+
+	t.Run("retries of deleted board webhooks are dropped", func(t *testing.T) {
+		delivery := &model.WebhookDelivery{ID: "delivery-id", WebhookID: "gone", URL: ts.URL, Payload: "{}", Attempts: 1, Status: model.WebhookDeliveryPending}
+		notFound := errors.New("not found")
+		store.EXPECT().GetWebhookDelivery("delivery-id").Return(delivery, nil)
+		store.EXPECT().GetBoardWebhook("gone").Return(nil, notFound)
+		store.EXPECT().IsErrNotFound(notFound).Return(true)
+		store.EXPECT().DeleteWebhookDelivery("delivery-id").Return(nil)
+
+		require.NoError(t, client.RetryDelivery("delivery-id"))
+		require.Len(t, received, 1)
+	})
+
+	t.Run("board webhooks can't call the reserved networks", func(t *testing.T) {
+		guarded := NewClient(&config.Configuration{}, store, logger)
+		store.EXPECT().GetBoardWebhooksForBoard("board-id").Return(webhooks, nil)
+		store.EXPECT().InsertWebhookDelivery(gomock.Any()).Return(nil)
+
+		guarded.NotifyBlockChange(model.BoardWebhookEventBlockCreated, model.Block{ID: "block-id", BoardID: "board-id"})
+		require.Len(t, received, 1)
+
+		err := guarded.deliver(ts.URL, "created", "", []byte("{}"))
+		require.ErrorIs(t, err, errAddressNotAllowed)
+		require.NoError(t, guarded.deliver(ts.URL, "", "", []byte("{}")), "the webhooks of the configuration are trusted")
+	})
+}
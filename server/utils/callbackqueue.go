@@ -100,6 +100,11 @@ func (cn *CallbackQueue) Enqueue(f CallbackFunc) {
 	}
 }
 
+// Len returns the number of callbacks waiting in the queue.
+func (cn *CallbackQueue) Len() int {
+	return len(cn.queue)
+}
+
 func (cn *CallbackQueue) loop(id int) {
 	defer func() {
 		cn.logger.Trace("CallbackQueue thread exited", mlog.String("name", cn.name), mlog.Int("id", id))
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"time"
+)
+
+// StartOfDay returns the midnight that starts the day t falls on in loc.
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// IsSameDay returns true if the time in milliseconds falls on the same
+// calendar day as now in loc.
+func IsSameDay(millis int64, now time.Time, loc *time.Location) bool {
+	start := StartOfDay(now, loc)
+	end := start.AddDate(0, 0, 1)
+	t := GetTimeForMillis(millis)
+	return !t.Before(start) && t.Before(end)
+}
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSameDay(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	// 2022-03-01 20:00 UTC is already 2022-03-02 in Tokyo.
+	now := time.Date(2022, 3, 1, 20, 0, 0, 0, time.UTC)
+	due := GetMillisForTime(time.Date(2022, 3, 2, 10, 0, 0, 0, tokyo))
+
+	require.True(t, IsSameDay(due, now, tokyo))
+	require.False(t, IsSameDay(due, now, time.UTC))
+}
@@ -15,10 +15,14 @@ const (
 	websocketActionUpdateMember        = "UPDATE_MEMBER"
 	websocketActionDeleteMember        = "DELETE_MEMBER"
 	websocketActionUpdateBlock         = "UPDATE_BLOCK"
+	websocketActionUpdateBlocks        = "UPDATE_BLOCKS"
 	websocketActionUpdateConfig        = "UPDATE_CLIENT_CONFIG"
 	websocketActionUpdateCategory      = "UPDATE_CATEGORY"
 	websocketActionUpdateCategoryBoard = "UPDATE_BOARD_CATEGORY"
+	websocketActionReorderCategories   = "REORDER_CATEGORIES"
 	websocketActionUpdateSubscription  = "UPDATE_SUBSCRIPTION"
+	websocketActionUpdateBlockLock     = "UPDATE_BLOCK_LOCK"
+	websocketActionUpdateBlockText     = "UPDATE_BLOCK_TEXT"
 )
 
 type Store interface {
@@ -28,6 +32,7 @@ type Store interface {
 
 type Adapter interface {
 	BroadcastBlockChange(teamID string, block model.Block)
+	BroadcastBlocksChange(teamID, boardID string, blocks []model.Block)
 	BroadcastBlockDelete(teamID, blockID, boardID string)
 	BroadcastBoardChange(teamID string, board *model.Board)
 	BroadcastBoardDelete(teamID, boardID string)
@@ -35,6 +40,26 @@ type Adapter interface {
 	BroadcastMemberDelete(teamID, boardID, userID string)
 	BroadcastConfigChange(clientConfig model.ClientConfig)
 	BroadcastCategoryChange(category model.Category)
-	BroadcastCategoryBoardChange(teamID, userID string, blockCategory model.BoardCategoryWebsocketData)
+	BroadcastCategoryBoardChange(teamID, userID string, boardCategories []*model.BoardCategoryWebsocketData)
+	BroadcastCategoryReorder(teamID, userID string, categoryOrder []string)
 	BroadcastSubscriptionChange(teamID string, subscription *model.Subscription)
 }
+
+// ConnectionCounter is implemented by the adapters that can report how
+// many websocket connections they are currently serving.
+type ConnectionCounter interface {
+	ConnectionCount() int
+}
+
+// BlockLockBroadcaster is implemented by the adapters that can notify the
+// members of a board when the edit lock of one of its blocks is acquired
+// or released. A nil lock means the block was released.
+type BlockLockBroadcaster interface {
+	BroadcastBlockLockChange(teamID, boardID, blockID string, lock *model.BlockLock)
+}
+
+// TextEditBroadcaster is implemented by the adapters that can relay the
+// deltas applied to the text of a block to its other editors.
+type TextEditBroadcaster interface {
+	BroadcastTextEdit(teamID string, edit *model.TextEdit)
+}
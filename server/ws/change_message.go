@@ -0,0 +1,62 @@
+package ws
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// MessageForChange returns the websocket message that the clients
+// subscribed to the board of a change receive for it, so the transports
+// that don't use websockets can send the same messages.
+func MessageForChange(change *model.ChangeEvent) interface{} {
+	switch change.Type {
+	case model.ChangeEventBlockChanged:
+		return UpdateBlockMsg{
+			Action: websocketActionUpdateBlock,
+			TeamID: change.TeamID,
+			Block:  *change.Block,
+		}
+	case model.ChangeEventBlockDeleted:
+		block := model.Block{}
+		block.ID = change.BlockID
+		block.BoardID = change.BoardID
+		block.UpdateAt = change.CreateAt
+		block.DeleteAt = change.CreateAt
+
+		return UpdateBlockMsg{
+			Action: websocketActionUpdateBlock,
+			TeamID: change.TeamID,
+			Block:  block,
+		}
+	case model.ChangeEventBoardChanged:
+		return UpdateBoardMsg{
+			Action: websocketActionUpdateBoard,
+			TeamID: change.TeamID,
+			Board:  change.Board,
+		}
+	case model.ChangeEventBoardDeleted:
+		board := &model.Board{}
+		board.ID = change.BoardID
+		board.TeamID = change.TeamID
+		board.UpdateAt = change.CreateAt
+		board.DeleteAt = change.CreateAt
+
+		return UpdateBoardMsg{
+			Action: websocketActionUpdateBoard,
+			TeamID: change.TeamID,
+			Board:  board,
+		}
+	case model.ChangeEventMemberChanged:
+		return UpdateMemberMsg{
+			Action: websocketActionUpdateMember,
+			TeamID: change.TeamID,
+			Member: change.Member,
+		}
+	case model.ChangeEventMemberDeleted:
+		return UpdateMemberMsg{
+			Action: websocketActionDeleteMember,
+			TeamID: change.TeamID,
+			Member: &model.BoardMember{UserID: change.UserID, BoardID: change.BoardID},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageForChange(t *testing.T) {
+	t.Run("block changes", func(t *testing.T) {
+		block := &model.Block{ID: "block-id", BoardID: "board-id"}
+		msg := MessageForChange(&model.ChangeEvent{Type: model.ChangeEventBlockChanged, TeamID: "team-id", Block: block})
+
+		require.Equal(t, UpdateBlockMsg{Action: websocketActionUpdateBlock, TeamID: "team-id", Block: *block}, msg)
+	})
+
+	t.Run("deleted blocks are sent as updates", func(t *testing.T) {
+		msg := MessageForChange(&model.ChangeEvent{
+			Type:     model.ChangeEventBlockDeleted,
+			TeamID:   "team-id",
+			BoardID:  "board-id",
+			BlockID:  "block-id",
+			CreateAt: 100,
+		})
+
+		blockMsg, ok := msg.(UpdateBlockMsg)
+		require.True(t, ok)
+		require.Equal(t, websocketActionUpdateBlock, blockMsg.Action)
+		require.Equal(t, "block-id", blockMsg.Block.ID)
+		require.Equal(t, "board-id", blockMsg.Block.BoardID)
+		require.EqualValues(t, 100, blockMsg.Block.DeleteAt)
+	})
+
+	t.Run("deleted boards are sent as updates", func(t *testing.T) {
+		msg := MessageForChange(&model.ChangeEvent{Type: model.ChangeEventBoardDeleted, TeamID: "team-id", BoardID: "board-id", CreateAt: 100})
+
+		boardMsg, ok := msg.(UpdateBoardMsg)
+		require.True(t, ok)
+		require.Equal(t, "board-id", boardMsg.Board.ID)
+		require.EqualValues(t, 100, boardMsg.Board.DeleteAt)
+	})
+
+	t.Run("member deletions", func(t *testing.T) {
+		msg := MessageForChange(&model.ChangeEvent{Type: model.ChangeEventMemberDeleted, TeamID: "team-id", BoardID: "board-id", UserID: "user-id"})
+
+		memberMsg, ok := msg.(UpdateMemberMsg)
+		require.True(t, ok)
+		require.Equal(t, websocketActionDeleteMember, memberMsg.Action)
+		require.Equal(t, "user-id", memberMsg.Member.UserID)
+	})
+}
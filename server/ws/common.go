@@ -6,31 +6,65 @@ import (
 
 // UpdateCategoryMessage is sent on block updates.
 type UpdateCategoryMessage struct {
-	Action          string                            `json:"action"`
-	TeamID          string                            `json:"teamId"`
-	Category        *model.Category                   `json:"category,omitempty"`
-	BoardCategories *model.BoardCategoryWebsocketData `json:"blockCategories,omitempty"`
+	Action          string                              `json:"action"`
+	TeamID          string                              `json:"teamId"`
+	Category        *model.Category                     `json:"category,omitempty"`
+	BoardCategories []*model.BoardCategoryWebsocketData `json:"blockCategories,omitempty"`
+	CategoryOrder   []string                            `json:"categoryOrder,omitempty"`
 }
 
 // UpdateBlockMsg is sent on block updates.
 type UpdateBlockMsg struct {
-	Action string      `json:"action"`
-	TeamID string      `json:"teamId"`
-	Block  model.Block `json:"block"`
+	Action    string      `json:"action"`
+	TeamID    string      `json:"teamId"`
+	Block     model.Block `json:"block"`
+	RequestID string      `json:"requestId,omitempty"`
+}
+
+// UpdateBlocksMsg is sent when several blocks of a board are updated at
+// once.
+type UpdateBlocksMsg struct {
+	Action    string        `json:"action"`
+	TeamID    string        `json:"teamId"`
+	BoardID   string        `json:"boardId"`
+	Blocks    []model.Block `json:"blocks"`
+	RequestID string        `json:"requestId,omitempty"`
 }
 
 // UpdateBoardMsg is sent on block updates.
 type UpdateBoardMsg struct {
-	Action string       `json:"action"`
-	TeamID string       `json:"teamId"`
-	Board  *model.Board `json:"board"`
+	Action    string       `json:"action"`
+	TeamID    string       `json:"teamId"`
+	Board     *model.Board `json:"board"`
+	RequestID string       `json:"requestId,omitempty"`
 }
 
 // UpdateMemberMsg is sent on membership updates.
 type UpdateMemberMsg struct {
-	Action string             `json:"action"`
-	TeamID string             `json:"teamId"`
-	Member *model.BoardMember `json:"member"`
+	Action    string             `json:"action"`
+	TeamID    string             `json:"teamId"`
+	Member    *model.BoardMember `json:"member"`
+	RequestID string             `json:"requestId,omitempty"`
+}
+
+// UpdateBlockLockMsg is sent when the edit lock of a block is acquired,
+// renewed or released. The lock is empty once the block is released.
+type UpdateBlockLockMsg struct {
+	Action  string           `json:"action"`
+	TeamID  string           `json:"teamId"`
+	BoardID string           `json:"boardId"`
+	BlockID string           `json:"blockId"`
+	Lock    *model.BlockLock `json:"lock,omitempty"`
+}
+
+// UpdateBlockTextMsg is sent when a delta is applied to the text of a
+// block. The block change is sent as well, for the clients that don't
+// edit the text collaboratively.
+type UpdateBlockTextMsg struct {
+	Action  string          `json:"action"`
+	TeamID  string          `json:"teamId"`
+	BoardID string          `json:"boardId"`
+	Edit    *model.TextEdit `json:"edit"`
 }
 
 // UpdateSubscription is sent on subscription updates.
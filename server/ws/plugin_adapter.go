@@ -94,6 +94,15 @@ func (pa *PluginAdapter) GetListenersByBlock(blockID string) []*PluginAdapterCli
 	return pa.listenersByBlock[blockID]
 }
 
+// ConnectionCount returns the number of websocket connections served
+// by this node of the cluster.
+func (pa *PluginAdapter) ConnectionCount() int {
+	pa.listenersMU.RLock()
+	defer pa.listenersMU.RUnlock()
+
+	return len(pa.listeners)
+}
+
 func (pa *PluginAdapter) addListener(pac *PluginAdapterClient) {
 	pa.listenersMU.Lock()
 	defer pa.listenersMU.Unlock()
@@ -410,6 +419,21 @@ func (pa *PluginAdapter) sendUserMessageSkipCluster(event string, payload map[st
 	}
 }
 
+// sendUserMessage sends and propagates a message that is aimed
+// for the given users only.
+func (pa *PluginAdapter) sendUserMessage(event string, payload map[string]interface{}, userIDs ...string) {
+	go func() {
+		clusterMessage := &ClusterMessage{
+			Payload:     payload,
+			EnsureUsers: userIDs,
+		}
+
+		pa.sendMessageToCluster("websocket_message", clusterMessage)
+	}()
+
+	pa.sendUserMessageSkipCluster(event, payload, userIDs...)
+}
+
 // sendTeamMessage sends and propagates a message that is aimed
 // for all the users that are subscribed to a given team.
 func (pa *PluginAdapter) sendTeamMessage(event, teamID string, payload map[string]interface{}, ensureUserIDs ...string) {
@@ -452,6 +476,10 @@ func (pa *PluginAdapter) sendBoardMessage(teamID, boardID string, payload map[st
 }
 
 func (pa *PluginAdapter) BroadcastBlockChange(teamID string, block model.Block) {
+	pa.broadcastBlockChange("", teamID, block)
+}
+
+func (pa *PluginAdapter) broadcastBlockChange(requestID, teamID string, block model.Block) {
 	pa.logger.Debug("BroadcastingBlockChange",
 		mlog.String("teamID", teamID),
 		mlog.String("boardID", block.BoardID),
@@ -459,14 +487,39 @@ func (pa *PluginAdapter) BroadcastBlockChange(teamID string, block model.Block)
 	)
 
 	message := UpdateBlockMsg{
-		Action: websocketActionUpdateBlock,
-		TeamID: teamID,
-		Block:  block,
+		Action:    websocketActionUpdateBlock,
+		TeamID:    teamID,
+		Block:     block,
+		RequestID: requestID,
 	}
 
 	pa.sendBoardMessage(teamID, block.BoardID, utils.StructToMap(message))
 }
 
+// BroadcastBlocksChange sends the changes of several blocks of a board
+// in a single message.
+func (pa *PluginAdapter) BroadcastBlocksChange(teamID, boardID string, blocks []model.Block) {
+	pa.broadcastBlocksChange("", teamID, boardID, blocks)
+}
+
+func (pa *PluginAdapter) broadcastBlocksChange(requestID, teamID, boardID string, blocks []model.Block) {
+	pa.logger.Debug("BroadcastingBlocksChange",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", boardID),
+		mlog.Int("blockCount", len(blocks)),
+	)
+
+	message := UpdateBlocksMsg{
+		Action:    websocketActionUpdateBlocks,
+		TeamID:    teamID,
+		BoardID:   boardID,
+		Blocks:    blocks,
+		RequestID: requestID,
+	}
+
+	pa.sendBoardMessage(teamID, boardID, utils.StructToMap(message))
+}
+
 func (pa *PluginAdapter) BroadcastCategoryChange(category model.Category) {
 	pa.logger.Debug("BroadcastCategoryChange",
 		mlog.String("userID", category.UserID),
@@ -480,39 +533,49 @@ func (pa *PluginAdapter) BroadcastCategoryChange(category model.Category) {
 		Category: &category,
 	}
 
-	payload := utils.StructToMap(message)
-
-	go func() {
-		clusterMessage := &ClusterMessage{
-			Payload:     payload,
-			EnsureUsers: []string{category.UserID},
-		}
-
-		pa.sendMessageToCluster("websocket_message", clusterMessage)
-	}()
-
-	pa.sendUserMessageSkipCluster(websocketActionUpdateCategory, payload, category.UserID)
+	pa.sendUserMessage(websocketActionUpdateCategory, utils.StructToMap(message), category.UserID)
 }
 
-func (pa *PluginAdapter) BroadcastCategoryBoardChange(teamID, userID string, boardCategory model.BoardCategoryWebsocketData) {
+func (pa *PluginAdapter) BroadcastCategoryBoardChange(teamID, userID string, boardCategories []*model.BoardCategoryWebsocketData) {
 	pa.logger.Debug(
 		"BroadcastCategoryBoardChange",
 		mlog.String("userID", userID),
 		mlog.String("teamID", teamID),
-		mlog.String("categoryID", boardCategory.CategoryID),
-		mlog.String("blockID", boardCategory.BoardID),
+		mlog.Int("boardCount", len(boardCategories)),
 	)
 
 	message := UpdateCategoryMessage{
 		Action:          websocketActionUpdateCategoryBoard,
 		TeamID:          teamID,
-		BoardCategories: &boardCategory,
+		BoardCategories: boardCategories,
 	}
 
-	pa.sendTeamMessage(websocketActionUpdateCategoryBoard, teamID, utils.StructToMap(message))
+	// the categories are personal, so only the sessions of their owner
+	// are updated
+	pa.sendUserMessage(websocketActionUpdateCategoryBoard, utils.StructToMap(message), userID)
+}
+
+func (pa *PluginAdapter) BroadcastCategoryReorder(teamID, userID string, categoryOrder []string) {
+	pa.logger.Debug(
+		"BroadcastCategoryReorder",
+		mlog.String("userID", userID),
+		mlog.String("teamID", teamID),
+	)
+
+	message := UpdateCategoryMessage{
+		Action:        websocketActionReorderCategories,
+		TeamID:        teamID,
+		CategoryOrder: categoryOrder,
+	}
+
+	pa.sendUserMessage(websocketActionReorderCategories, utils.StructToMap(message), userID)
 }
 
 func (pa *PluginAdapter) BroadcastBlockDelete(teamID, blockID, boardID string) {
+	pa.broadcastBlockDelete("", teamID, blockID, boardID)
+}
+
+func (pa *PluginAdapter) broadcastBlockDelete(requestID, teamID, blockID, boardID string) {
 	now := utils.GetMillis()
 	block := model.Block{}
 	block.ID = blockID
@@ -520,25 +583,34 @@ func (pa *PluginAdapter) BroadcastBlockDelete(teamID, blockID, boardID string) {
 	block.UpdateAt = now
 	block.DeleteAt = now
 
-	pa.BroadcastBlockChange(teamID, block)
+	pa.broadcastBlockChange(requestID, teamID, block)
 }
 
 func (pa *PluginAdapter) BroadcastBoardChange(teamID string, board *model.Board) {
+	pa.broadcastBoardChange("", teamID, board)
+}
+
+func (pa *PluginAdapter) broadcastBoardChange(requestID, teamID string, board *model.Board) {
 	pa.logger.Debug("BroadcastingBoardChange",
 		mlog.String("teamID", teamID),
 		mlog.String("boardID", board.ID),
 	)
 
 	message := UpdateBoardMsg{
-		Action: websocketActionUpdateBoard,
-		TeamID: teamID,
-		Board:  board,
+		Action:    websocketActionUpdateBoard,
+		TeamID:    teamID,
+		Board:     board,
+		RequestID: requestID,
 	}
 
 	pa.sendBoardMessage(teamID, board.ID, utils.StructToMap(message))
 }
 
 func (pa *PluginAdapter) BroadcastBoardDelete(teamID, boardID string) {
+	pa.broadcastBoardDelete("", teamID, boardID)
+}
+
+func (pa *PluginAdapter) broadcastBoardDelete(requestID, teamID, boardID string) {
 	now := utils.GetMillis()
 	board := &model.Board{}
 	board.ID = boardID
@@ -546,10 +618,14 @@ func (pa *PluginAdapter) BroadcastBoardDelete(teamID, boardID string) {
 	board.UpdateAt = now
 	board.DeleteAt = now
 
-	pa.BroadcastBoardChange(teamID, board)
+	pa.broadcastBoardChange(requestID, teamID, board)
 }
 
 func (pa *PluginAdapter) BroadcastMemberChange(teamID, boardID string, member *model.BoardMember) {
+	pa.broadcastMemberChange("", teamID, boardID, member)
+}
+
+func (pa *PluginAdapter) broadcastMemberChange(requestID, teamID, boardID string, member *model.BoardMember) {
 	pa.logger.Debug("BroadcastingMemberChange",
 		mlog.String("teamID", teamID),
 		mlog.String("boardID", boardID),
@@ -557,15 +633,20 @@ func (pa *PluginAdapter) BroadcastMemberChange(teamID, boardID string, member *m
 	)
 
 	message := UpdateMemberMsg{
-		Action: websocketActionUpdateMember,
-		TeamID: teamID,
-		Member: member,
+		Action:    websocketActionUpdateMember,
+		TeamID:    teamID,
+		Member:    member,
+		RequestID: requestID,
 	}
 
 	pa.sendBoardMessage(teamID, boardID, utils.StructToMap(message))
 }
 
 func (pa *PluginAdapter) BroadcastMemberDelete(teamID, boardID, userID string) {
+	pa.broadcastMemberDelete("", teamID, boardID, userID)
+}
+
+func (pa *PluginAdapter) broadcastMemberDelete(requestID, teamID, boardID, userID string) {
 	pa.logger.Debug("BroadcastingMemberDelete",
 		mlog.String("teamID", teamID),
 		mlog.String("boardID", boardID),
@@ -573,9 +654,10 @@ func (pa *PluginAdapter) BroadcastMemberDelete(teamID, boardID, userID string) {
 	)
 
 	message := UpdateMemberMsg{
-		Action: websocketActionDeleteMember,
-		TeamID: teamID,
-		Member: &model.BoardMember{UserID: userID, BoardID: boardID},
+		Action:    websocketActionDeleteMember,
+		TeamID:    teamID,
+		Member:    &model.BoardMember{UserID: userID, BoardID: boardID},
+		RequestID: requestID,
 	}
 
 	// when fetching the members of the board that should receive the
@@ -584,6 +666,46 @@ func (pa *PluginAdapter) BroadcastMemberDelete(teamID, boardID, userID string) {
 	pa.sendBoardMessage(teamID, boardID, utils.StructToMap(message), userID)
 }
 
+// BroadcastBlockLockChange notifies the members of the board that the
+// edit lock of the block was acquired, renewed or, if lock is nil,
+// released.
+func (pa *PluginAdapter) BroadcastBlockLockChange(teamID, boardID, blockID string, lock *model.BlockLock) {
+	pa.logger.Debug("BroadcastingBlockLockChange",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", boardID),
+		mlog.String("blockID", blockID),
+	)
+
+	message := UpdateBlockLockMsg{
+		Action:  websocketActionUpdateBlockLock,
+		TeamID:  teamID,
+		BoardID: boardID,
+		BlockID: blockID,
+		Lock:    lock,
+	}
+
+	pa.sendBoardMessage(teamID, boardID, utils.StructToMap(message))
+}
+
+// BroadcastTextEdit relays a delta applied to the text of a block to the
+// members of its board.
+func (pa *PluginAdapter) BroadcastTextEdit(teamID string, edit *model.TextEdit) {
+	pa.logger.Debug("BroadcastingTextEdit",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", edit.BoardID),
+		mlog.String("blockID", edit.BlockID),
+	)
+
+	message := UpdateBlockTextMsg{
+		Action:  websocketActionUpdateBlockText,
+		TeamID:  teamID,
+		BoardID: edit.BoardID,
+		Edit:    edit,
+	}
+
+	pa.sendBoardMessage(teamID, edit.BoardID, utils.StructToMap(message))
+}
+
 func (pa *PluginAdapter) BroadcastSubscriptionChange(teamID string, subscription *model.Subscription) {
 	pa.logger.Debug("BroadcastingSubscriptionChange",
 		mlog.String("TeamID", teamID),
@@ -60,6 +60,12 @@ func (pa *PluginAdapter) HandleClusterEvent(ev mmModel.PluginClusterEvent) {
 			action = s
 		}
 	}
+	if clusterMessage.TeamID == "" && len(clusterMessage.EnsureUsers) != 0 {
+		// a message for the given users only, such as a category change
+		pa.sendUserMessageSkipCluster(action, clusterMessage.Payload, clusterMessage.EnsureUsers...)
+		return
+	}
+
 	if action == "" {
 		// no action was specified in the event; assume block change and warn.
 		pa.api.LogWarn("cannot determine action from cluster message data",
@@ -1,6 +1,7 @@
 package ws
 
 import (
+	"encoding/json"
 	"sync"
 	"testing"
 
@@ -447,3 +448,18 @@ func TestParallelSubscriptionsOnMultipleConnections(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestHandleClusterEventForUsers(t *testing.T) {
+	th := SetupTestHelper(t)
+
+	userID := mmModel.NewId()
+	payload := map[string]interface{}{"action": websocketActionUpdateCategoryBoard}
+	data, err := json.Marshal(&ClusterMessage{Payload: payload, EnsureUsers: []string{userID}})
+	require.NoError(t, err)
+
+	th.api.EXPECT().
+		PublishWebSocketEvent(websocketActionUpdateCategoryBoard, payload, &mmModel.WebsocketBroadcast{UserId: userID}).
+		Times(1)
+
+	th.pa.HandleClusterEvent(mmModel.PluginClusterEvent{Id: "websocket_message", Data: data})
+}
@@ -0,0 +1,180 @@
+package ws
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// Publisher receives the block, board and member changes broadcast
+// through an adapter, to forward them outside of the server.
+type Publisher interface {
+	Publish(event *model.ChangeEvent)
+}
+
+// publishingAdapter wraps an adapter so the block, board and member
+// changes it broadcasts are also sent to a publisher.
+type publishingAdapter struct {
+	Adapter
+	publisher Publisher
+}
+
+// WithPublisher returns an adapter that sends the block, board and
+// member changes to the publisher, besides broadcasting them.
+func WithPublisher(adapter Adapter, publisher Publisher) Adapter {
+	return &publishingAdapter{
+		Adapter:   adapter,
+		publisher: publisher,
+	}
+}
+
+func (pa *publishingAdapter) publish(event *model.ChangeEvent) {
+	event.CreateAt = model.GetMillis()
+	pa.publisher.Publish(event)
+}
+
+func (pa *publishingAdapter) publishBlockChange(teamID string, block model.Block) {
+	pa.publish(&model.ChangeEvent{Type: model.ChangeEventBlockChanged, TeamID: teamID, BoardID: block.BoardID, BlockID: block.ID, Block: &block})
+}
+
+func (pa *publishingAdapter) publishBlockDelete(teamID, blockID, boardID string) {
+	pa.publish(&model.ChangeEvent{Type: model.ChangeEventBlockDeleted, TeamID: teamID, BoardID: boardID, BlockID: blockID})
+}
+
+func (pa *publishingAdapter) publishBoardChange(teamID string, board *model.Board) {
+	pa.publish(&model.ChangeEvent{Type: model.ChangeEventBoardChanged, TeamID: teamID, BoardID: board.ID, Board: board})
+}
+
+func (pa *publishingAdapter) publishBoardDelete(teamID, boardID string) {
+	pa.publish(&model.ChangeEvent{Type: model.ChangeEventBoardDeleted, TeamID: teamID, BoardID: boardID})
+}
+
+func (pa *publishingAdapter) publishMemberChange(teamID, boardID string, member *model.BoardMember) {
+	pa.publish(&model.ChangeEvent{Type: model.ChangeEventMemberChanged, TeamID: teamID, BoardID: boardID, UserID: member.UserID, Member: member})
+}
+
+func (pa *publishingAdapter) publishMemberDelete(teamID, boardID, userID string) {
+	pa.publish(&model.ChangeEvent{Type: model.ChangeEventMemberDeleted, TeamID: teamID, BoardID: boardID, UserID: userID})
+}
+
+func (pa *publishingAdapter) BroadcastBlockChange(teamID string, block model.Block) {
+	pa.publishBlockChange(teamID, block)
+	pa.Adapter.BroadcastBlockChange(teamID, block)
+}
+
+func (pa *publishingAdapter) BroadcastBlocksChange(teamID, boardID string, blocks []model.Block) {
+	for _, block := range blocks {
+		pa.publishBlockChange(teamID, block)
+	}
+	pa.Adapter.BroadcastBlocksChange(teamID, boardID, blocks)
+}
+
+func (pa *publishingAdapter) BroadcastBlockDelete(teamID, blockID, boardID string) {
+	pa.publishBlockDelete(teamID, blockID, boardID)
+	pa.Adapter.BroadcastBlockDelete(teamID, blockID, boardID)
+}
+
+func (pa *publishingAdapter) BroadcastBoardChange(teamID string, board *model.Board) {
+	pa.publishBoardChange(teamID, board)
+	pa.Adapter.BroadcastBoardChange(teamID, board)
+}
+
+func (pa *publishingAdapter) BroadcastBoardDelete(teamID, boardID string) {
+	pa.publishBoardDelete(teamID, boardID)
+	pa.Adapter.BroadcastBoardDelete(teamID, boardID)
+}
+
+func (pa *publishingAdapter) BroadcastMemberChange(teamID, boardID string, member *model.BoardMember) {
+	pa.publishMemberChange(teamID, boardID, member)
+	pa.Adapter.BroadcastMemberChange(teamID, boardID, member)
+}
+
+func (pa *publishingAdapter) BroadcastMemberDelete(teamID, boardID, userID string) {
+	pa.publishMemberDelete(teamID, boardID, userID)
+	pa.Adapter.BroadcastMemberDelete(teamID, boardID, userID)
+}
+
+// The request broadcaster methods keep WithRequestID working on top of
+// the publishing adapter.
+
+func (pa *publishingAdapter) broadcastBlockChange(requestID, teamID string, block model.Block) {
+	pa.publishBlockChange(teamID, block)
+	if rb, ok := pa.Adapter.(requestBroadcaster); ok {
+		rb.broadcastBlockChange(requestID, teamID, block)
+		return
+	}
+	pa.Adapter.BroadcastBlockChange(teamID, block)
+}
+
+func (pa *publishingAdapter) broadcastBlocksChange(requestID, teamID, boardID string, blocks []model.Block) {
+	for _, block := range blocks {
+		pa.publishBlockChange(teamID, block)
+	}
+	if rb, ok := pa.Adapter.(requestBroadcaster); ok {
+		rb.broadcastBlocksChange(requestID, teamID, boardID, blocks)
+		return
+	}
+	pa.Adapter.BroadcastBlocksChange(teamID, boardID, blocks)
+}
+
+func (pa *publishingAdapter) broadcastBlockDelete(requestID, teamID, blockID, boardID string) {
+	pa.publishBlockDelete(teamID, blockID, boardID)
+	if rb, ok := pa.Adapter.(requestBroadcaster); ok {
+		rb.broadcastBlockDelete(requestID, teamID, blockID, boardID)
+		return
+	}
+	pa.Adapter.BroadcastBlockDelete(teamID, blockID, boardID)
+}
+
+func (pa *publishingAdapter) broadcastBoardChange(requestID, teamID string, board *model.Board) {
+	pa.publishBoardChange(teamID, board)
+	if rb, ok := pa.Adapter.(requestBroadcaster); ok {
+		rb.broadcastBoardChange(requestID, teamID, board)
+		return
+	}
+	pa.Adapter.BroadcastBoardChange(teamID, board)
+}
+
+func (pa *publishingAdapter) broadcastBoardDelete(requestID, teamID, boardID string) {
+	pa.publishBoardDelete(teamID, boardID)
+	if rb, ok := pa.Adapter.(requestBroadcaster); ok {
+		rb.broadcastBoardDelete(requestID, teamID, boardID)
+		return
+	}
+	pa.Adapter.BroadcastBoardDelete(teamID, boardID)
+}
+
+func (pa *publishingAdapter) broadcastMemberChange(requestID, teamID, boardID string, member *model.BoardMember) {
+	pa.publishMemberChange(teamID, boardID, member)
+	if rb, ok := pa.Adapter.(requestBroadcaster); ok {
+		rb.broadcastMemberChange(requestID, teamID, boardID, member)
+		return
+	}
+	pa.Adapter.BroadcastMemberChange(teamID, boardID, member)
+}
+
+func (pa *publishingAdapter) broadcastMemberDelete(requestID, teamID, boardID, userID string) {
+	pa.publishMemberDelete(teamID, boardID, userID)
+	if rb, ok := pa.Adapter.(requestBroadcaster); ok {
+		rb.broadcastMemberDelete(requestID, teamID, boardID, userID)
+		return
+	}
+	pa.Adapter.BroadcastMemberDelete(teamID, boardID, userID)
+}
+
+func (pa *publishingAdapter) ConnectionCount() int {
+	if counter, ok := pa.Adapter.(ConnectionCounter); ok {
+		return counter.ConnectionCount()
+	}
+	return 0
+}
+
+func (pa *publishingAdapter) BroadcastBlockLockChange(teamID, boardID, blockID string, lock *model.BlockLock) {
+	if broadcaster, ok := pa.Adapter.(BlockLockBroadcaster); ok {
+		broadcaster.BroadcastBlockLockChange(teamID, boardID, blockID, lock)
+	}
+}
+
+func (pa *publishingAdapter) BroadcastTextEdit(teamID string, edit *model.TextEdit) {
+	if broadcaster, ok := pa.Adapter.(TextEditBroadcaster); ok {
+		broadcaster.BroadcastTextEdit(teamID, edit)
+	}
+}
@@ -0,0 +1,89 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPublisher struct {
+	events []*model.ChangeEvent
+}
+
+func (rp *recordingPublisher) Publish(event *model.ChangeEvent) {
+	rp.events = append(rp.events, event)
+}
+
+type recordingAdapter struct {
+	Adapter
+	broadcasts []string
+}
+
+func (ra *recordingAdapter) BroadcastBlockChange(teamID string, block model.Block) {
+	ra.broadcasts = append(ra.broadcasts, "block:"+block.ID)
+}
+
+func (ra *recordingAdapter) BroadcastBlocksChange(teamID, boardID string, blocks []model.Block) {
+	ra.broadcasts = append(ra.broadcasts, "blocks:"+boardID)
+}
+
+func (ra *recordingAdapter) BroadcastBoardDelete(teamID, boardID string) {
+	ra.broadcasts = append(ra.broadcasts, "delete-board:"+boardID)
+}
+
+func (ra *recordingAdapter) BroadcastMemberChange(teamID, boardID string, member *model.BoardMember) {
+	ra.broadcasts = append(ra.broadcasts, "member:"+member.UserID)
+}
+
+func TestWithPublisher(t *testing.T) {
+	t.Run("changes are published and broadcast", func(t *testing.T) {
+		adapter := &recordingAdapter{}
+		publisher := &recordingPublisher{}
+		wrapped := WithPublisher(adapter, publisher)
+
+		wrapped.BroadcastBlockChange("team-id", model.Block{ID: "block-id", BoardID: "board-id"})
+		wrapped.BroadcastBoardDelete("team-id", "board-id")
+		wrapped.BroadcastMemberChange("team-id", "board-id", &model.BoardMember{UserID: "user-id"})
+
+		require.Equal(t, []string{"block:block-id", "delete-board:board-id", "member:user-id"}, adapter.broadcasts)
+		require.Len(t, publisher.events, 3)
+
+		require.Equal(t, model.ChangeEventBlockChanged, publisher.events[0].Type)
+		require.Equal(t, "team-id", publisher.events[0].TeamID)
+		require.Equal(t, "board-id", publisher.events[0].BoardID)
+		require.Equal(t, "block-id", publisher.events[0].Block.ID)
+		require.NotZero(t, publisher.events[0].CreateAt)
+
+		require.Equal(t, model.ChangeEventBoardDeleted, publisher.events[1].Type)
+		require.Equal(t, model.ChangeEventMemberChanged, publisher.events[2].Type)
+		require.Equal(t, "user-id", publisher.events[2].UserID)
+	})
+
+	t.Run("bulk changes are broadcast once and published per block", func(t *testing.T) {
+		adapter := &recordingAdapter{}
+		publisher := &recordingPublisher{}
+		wrapped := WithPublisher(adapter, publisher)
+
+		blocks := []model.Block{{ID: "block-1", BoardID: "board-id"}, {ID: "block-2", BoardID: "board-id"}}
+		wrapped.BroadcastBlocksChange("team-id", "board-id", blocks)
+
+		require.Equal(t, []string{"blocks:board-id"}, adapter.broadcasts)
+		require.Len(t, publisher.events, 2)
+		require.Equal(t, "block-1", publisher.events[0].BlockID)
+		require.Equal(t, "block-2", publisher.events[1].BlockID)
+	})
+
+	t.Run("request IDs work on top of the publisher", func(t *testing.T) {
+		adapter := &recordingAdapter{}
+		publisher := &recordingPublisher{}
+		wrapped := WithRequestID(WithPublisher(adapter, publisher), "request-id")
+		_, ok := wrapped.(*requestIDAdapter)
+		require.True(t, ok)
+
+		wrapped.BroadcastBlockChange("team-id", model.Block{ID: "block-id"})
+		require.Equal(t, []string{"block:block-id"}, adapter.broadcasts)
+		require.Len(t, publisher.events, 1)
+	})
+}
@@ -0,0 +1,92 @@
+package ws
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// requestBroadcaster is implemented by the adapters that are able to
+// stamp their messages with the ID of the request that caused them.
+type requestBroadcaster interface {
+	broadcastBlockChange(requestID, teamID string, block model.Block)
+	broadcastBlocksChange(requestID, teamID, boardID string, blocks []model.Block)
+	broadcastBlockDelete(requestID, teamID, blockID, boardID string)
+	broadcastBoardChange(requestID, teamID string, board *model.Board)
+	broadcastBoardDelete(requestID, teamID, boardID string)
+	broadcastMemberChange(requestID, teamID, boardID string, member *model.BoardMember)
+	broadcastMemberDelete(requestID, teamID, boardID, userID string)
+}
+
+// requestIDAdapter wraps an adapter so the block, board and member
+// messages it broadcasts carry the ID of the originating API request.
+type requestIDAdapter struct {
+	Adapter
+	broadcaster requestBroadcaster
+	requestID   string
+}
+
+// WithRequestID returns an adapter that echoes requestID in the
+// messages broadcast through it. If the adapter doesn't support
+// request IDs, it is returned unchanged.
+func WithRequestID(adapter Adapter, requestID string) Adapter {
+	if requestID == "" {
+		return adapter
+	}
+
+	broadcaster, ok := adapter.(requestBroadcaster)
+	if !ok {
+		return adapter
+	}
+
+	return &requestIDAdapter{
+		Adapter:     adapter,
+		broadcaster: broadcaster,
+		requestID:   requestID,
+	}
+}
+
+func (ra *requestIDAdapter) BroadcastBlockChange(teamID string, block model.Block) {
+	ra.broadcaster.broadcastBlockChange(ra.requestID, teamID, block)
+}
+
+func (ra *requestIDAdapter) BroadcastBlocksChange(teamID, boardID string, blocks []model.Block) {
+	ra.broadcaster.broadcastBlocksChange(ra.requestID, teamID, boardID, blocks)
+}
+
+func (ra *requestIDAdapter) BroadcastBlockDelete(teamID, blockID, boardID string) {
+	ra.broadcaster.broadcastBlockDelete(ra.requestID, teamID, blockID, boardID)
+}
+
+func (ra *requestIDAdapter) BroadcastBoardChange(teamID string, board *model.Board) {
+	ra.broadcaster.broadcastBoardChange(ra.requestID, teamID, board)
+}
+
+func (ra *requestIDAdapter) BroadcastBoardDelete(teamID, boardID string) {
+	ra.broadcaster.broadcastBoardDelete(ra.requestID, teamID, boardID)
+}
+
+func (ra *requestIDAdapter) BroadcastMemberChange(teamID, boardID string, member *model.BoardMember) {
+	ra.broadcaster.broadcastMemberChange(ra.requestID, teamID, boardID, member)
+}
+
+func (ra *requestIDAdapter) BroadcastMemberDelete(teamID, boardID, userID string) {
+	ra.broadcaster.broadcastMemberDelete(ra.requestID, teamID, boardID, userID)
+}
+
+func (ra *requestIDAdapter) ConnectionCount() int {
+	if counter, ok := ra.Adapter.(ConnectionCounter); ok {
+		return counter.ConnectionCount()
+	}
+	return 0
+}
+
+func (ra *requestIDAdapter) BroadcastBlockLockChange(teamID, boardID, blockID string, lock *model.BlockLock) {
+	if broadcaster, ok := ra.Adapter.(BlockLockBroadcaster); ok {
+		broadcaster.BroadcastBlockLockChange(teamID, boardID, blockID, lock)
+	}
+}
+
+func (ra *requestIDAdapter) BroadcastTextEdit(teamID string, edit *model.TextEdit) {
+	if broadcaster, ok := ra.Adapter.(TextEditBroadcaster); ok {
+		broadcaster.BroadcastTextEdit(teamID, edit)
+	}
+}
@@ -0,0 +1,36 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/auth"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+
+	"github.com/stretchr/testify/require"
+)
+
+type noRequestIDAdapter struct {
+	Adapter
+}
+
+func TestWithRequestID(t *testing.T) {
+	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil)
+
+	t.Run("empty request ID returns the same adapter", func(t *testing.T) {
+		require.Equal(t, Adapter(server), WithRequestID(server, ""))
+	})
+
+	t.Run("adapters without request ID support are not wrapped", func(t *testing.T) {
+		adapter := &noRequestIDAdapter{}
+		require.Equal(t, Adapter(adapter), WithRequestID(adapter, "request-id"))
+	})
+
+	t.Run("supported adapters are wrapped", func(t *testing.T) {
+		adapter := WithRequestID(server, "request-id")
+		wrapped, ok := adapter.(*requestIDAdapter)
+		require.True(t, ok)
+		require.Equal(t, "request-id", wrapped.requestID)
+		require.Equal(t, Adapter(server), wrapped.Adapter)
+	})
+}
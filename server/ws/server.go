@@ -319,6 +319,13 @@ func (ws *Server) removeListener(listener *websocketSession) {
 	delete(ws.listeners, listener)
 }
 
+// ConnectionCount returns the number of open websocket connections.
+func (ws *Server) ConnectionCount() int {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	return len(ws.listeners)
+}
+
 // subscribeListenerToTeam safely modifies the listener and the
 // server to subscribe the listener to a given team updates.
 func (ws *Server) subscribeListenerToTeam(listener *websocketSession, teamID string) {
@@ -477,6 +484,18 @@ func (ws *Server) getListenersForTeam(teamID string) []*websocketSession {
 	return ws.listenersByTeam[teamID]
 }
 
+// getListenersForTeamAndUser returns the listeners of a user subscribed
+// to a team changes.
+func (ws *Server) getListenersForTeamAndUser(teamID, userID string) []*websocketSession {
+	listeners := []*websocketSession{}
+	for _, listener := range ws.listenersByTeam[teamID] {
+		if listener.userID == userID {
+			listeners = append(listeners, listener)
+		}
+	}
+	return listeners
+}
+
 // getListenersForTeamAndBoard returns the listeners subscribed to a
 // team changes and members of a given board.
 func (ws *Server) getListenersForTeamAndBoard(teamID, boardID string, ensureUsers ...string) []*websocketSession {
@@ -516,6 +535,10 @@ func (ws *Server) getListenersForTeamAndBoard(teamID, boardID string, ensureUser
 
 // BroadcastBlockDelete broadcasts delete messages to clients.
 func (ws *Server) BroadcastBlockDelete(teamID, blockID, boardID string) {
+	ws.broadcastBlockDelete("", teamID, blockID, boardID)
+}
+
+func (ws *Server) broadcastBlockDelete(requestID, teamID, blockID, boardID string) {
 	now := utils.GetMillis()
 	block := model.Block{}
 	block.ID = blockID
@@ -523,17 +546,22 @@ func (ws *Server) BroadcastBlockDelete(teamID, blockID, boardID string) {
 	block.UpdateAt = now
 	block.DeleteAt = now
 
-	ws.BroadcastBlockChange(teamID, block)
+	ws.broadcastBlockChange(requestID, teamID, block)
 }
 
 // BroadcastBlockChange broadcasts update messages to clients.
 func (ws *Server) BroadcastBlockChange(teamID string, block model.Block) {
+	ws.broadcastBlockChange("", teamID, block)
+}
+
+func (ws *Server) broadcastBlockChange(requestID, teamID string, block model.Block) {
 	blockIDsToNotify := []string{block.ID, block.ParentID}
 
 	message := UpdateBlockMsg{
-		Action: websocketActionUpdateBlock,
-		TeamID: teamID,
-		Block:  block,
+		Action:    websocketActionUpdateBlock,
+		TeamID:    teamID,
+		Block:     block,
+		RequestID: requestID,
 	}
 
 	listeners := ws.getListenersForTeamAndBoard(teamID, block.BoardID)
@@ -566,6 +594,51 @@ func (ws *Server) BroadcastBlockChange(teamID string, block model.Block) {
 	}
 }
 
+// BroadcastBlocksChange broadcasts the changes of several blocks of a
+// board in a single message.
+func (ws *Server) BroadcastBlocksChange(teamID, boardID string, blocks []model.Block) {
+	ws.broadcastBlocksChange("", teamID, boardID, blocks)
+}
+
+func (ws *Server) broadcastBlocksChange(requestID, teamID, boardID string, blocks []model.Block) {
+	message := UpdateBlocksMsg{
+		Action:    websocketActionUpdateBlocks,
+		TeamID:    teamID,
+		BoardID:   boardID,
+		Blocks:    blocks,
+		RequestID: requestID,
+	}
+
+	// every listener gets the message once, even if it is subscribed
+	// to the board and to some of its blocks
+	listeners := ws.getListenersForTeamAndBoard(teamID, boardID)
+	for _, block := range blocks {
+		listeners = append(listeners, ws.getListenersForBlock(block.ID)...)
+		listeners = append(listeners, ws.getListenersForBlock(block.ParentID)...)
+	}
+
+	notified := map[*websocketSession]bool{}
+	for _, listener := range listeners {
+		if notified[listener] {
+			continue
+		}
+		notified[listener] = true
+
+		ws.logger.Debug("Broadcast blocks change",
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", boardID),
+			mlog.Int("blockCount", len(blocks)),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		err := listener.WriteJSON(message)
+		if err != nil {
+			ws.logger.Error("broadcast error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
+
 func (ws *Server) BroadcastCategoryChange(category model.Category) {
 	message := UpdateCategoryMessage{
 		Action:   websocketActionUpdateCategory,
@@ -573,7 +646,9 @@ func (ws *Server) BroadcastCategoryChange(category model.Category) {
 		Category: &category,
 	}
 
-	listeners := ws.getListenersForTeam(category.TeamID)
+	// the categories are personal, so only the sessions of their owner
+	// are updated
+	listeners := ws.getListenersForTeamAndUser(category.TeamID, category.UserID)
 	ws.logger.Debug("listener(s) for teamID",
 		mlog.Int("listener_count", len(listeners)),
 		mlog.String("teamID", category.TeamID),
@@ -595,27 +670,41 @@ func (ws *Server) BroadcastCategoryChange(category model.Category) {
 	}
 }
 
-func (ws *Server) BroadcastCategoryBoardChange(teamID, userID string, boardCategory model.BoardCategoryWebsocketData) {
+func (ws *Server) BroadcastCategoryBoardChange(teamID, userID string, boardCategories []*model.BoardCategoryWebsocketData) {
 	message := UpdateCategoryMessage{
 		Action:          websocketActionUpdateCategoryBoard,
 		TeamID:          teamID,
-		BoardCategories: &boardCategory,
+		BoardCategories: boardCategories,
+	}
+
+	ws.broadcastUserCategoryMessage(teamID, userID, message)
+}
+
+func (ws *Server) BroadcastCategoryReorder(teamID, userID string, categoryOrder []string) {
+	message := UpdateCategoryMessage{
+		Action:        websocketActionReorderCategories,
+		TeamID:        teamID,
+		CategoryOrder: categoryOrder,
 	}
 
-	listeners := ws.getListenersForTeam(teamID)
+	ws.broadcastUserCategoryMessage(teamID, userID, message)
+}
+
+// broadcastUserCategoryMessage sends the message to the sessions of the
+// user on the team.
+func (ws *Server) broadcastUserCategoryMessage(teamID, userID string, message UpdateCategoryMessage) {
+	listeners := ws.getListenersForTeamAndUser(teamID, userID)
 	ws.logger.Debug("listener(s) for teamID",
 		mlog.Int("listener_count", len(listeners)),
 		mlog.String("teamID", teamID),
-		mlog.String("categoryID", boardCategory.CategoryID),
-		mlog.String("blockID", boardCategory.BoardID),
+		mlog.String("action", message.Action),
 	)
 
 	for _, listener := range listeners {
-		ws.logger.Debug("Broadcast block change",
+		ws.logger.Debug("Broadcast category change",
 			mlog.Int("listener_count", len(listeners)),
 			mlog.String("teamID", teamID),
-			mlog.String("categoryID", boardCategory.CategoryID),
-			mlog.String("blockID", boardCategory.BoardID),
+			mlog.String("action", message.Action),
 			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
 		)
 
@@ -651,10 +740,15 @@ func (ws *Server) BroadcastConfigChange(clientConfig model.ClientConfig) {
 }
 
 func (ws *Server) BroadcastBoardChange(teamID string, board *model.Board) {
+	ws.broadcastBoardChange("", teamID, board)
+}
+
+func (ws *Server) broadcastBoardChange(requestID, teamID string, board *model.Board) {
 	message := UpdateBoardMsg{
-		Action: websocketActionUpdateBoard,
-		TeamID: teamID,
-		Board:  board,
+		Action:    websocketActionUpdateBoard,
+		TeamID:    teamID,
+		Board:     board,
+		RequestID: requestID,
 	}
 
 	listeners := ws.getListenersForTeamAndBoard(teamID, board.ID)
@@ -680,6 +774,10 @@ func (ws *Server) BroadcastBoardChange(teamID string, board *model.Board) {
 }
 
 func (ws *Server) BroadcastBoardDelete(teamID, boardID string) {
+	ws.broadcastBoardDelete("", teamID, boardID)
+}
+
+func (ws *Server) broadcastBoardDelete(requestID, teamID, boardID string) {
 	now := utils.GetMillis()
 	board := &model.Board{}
 	board.ID = boardID
@@ -687,14 +785,19 @@ func (ws *Server) BroadcastBoardDelete(teamID, boardID string) {
 	board.UpdateAt = now
 	board.DeleteAt = now
 
-	ws.BroadcastBoardChange(teamID, board)
+	ws.broadcastBoardChange(requestID, teamID, board)
 }
 
 func (ws *Server) BroadcastMemberChange(teamID, boardID string, member *model.BoardMember) {
+	ws.broadcastMemberChange("", teamID, boardID, member)
+}
+
+func (ws *Server) broadcastMemberChange(requestID, teamID, boardID string, member *model.BoardMember) {
 	message := UpdateMemberMsg{
-		Action: websocketActionUpdateMember,
-		TeamID: teamID,
-		Member: member,
+		Action:    websocketActionUpdateMember,
+		TeamID:    teamID,
+		Member:    member,
+		RequestID: requestID,
 	}
 
 	listeners := ws.getListenersForTeamAndBoard(teamID, boardID)
@@ -720,10 +823,15 @@ func (ws *Server) BroadcastMemberChange(teamID, boardID string, member *model.Bo
 }
 
 func (ws *Server) BroadcastMemberDelete(teamID, boardID, userID string) {
+	ws.broadcastMemberDelete("", teamID, boardID, userID)
+}
+
+func (ws *Server) broadcastMemberDelete(requestID, teamID, boardID, userID string) {
 	message := UpdateMemberMsg{
-		Action: websocketActionDeleteMember,
-		TeamID: teamID,
-		Member: &model.BoardMember{UserID: userID, BoardID: boardID},
+		Action:    websocketActionDeleteMember,
+		TeamID:    teamID,
+		Member:    &model.BoardMember{UserID: userID, BoardID: boardID},
+		RequestID: requestID,
 	}
 
 	// when fetching the members of the board that should receive the
@@ -751,6 +859,62 @@ func (ws *Server) BroadcastMemberDelete(teamID, boardID, userID string) {
 	}
 }
 
+// BroadcastBlockLockChange notifies the members of the board that the
+// edit lock of the block was acquired, renewed or, if lock is nil,
+// released.
+func (ws *Server) BroadcastBlockLockChange(teamID, boardID, blockID string, lock *model.BlockLock) {
+	message := UpdateBlockLockMsg{
+		Action:  websocketActionUpdateBlockLock,
+		TeamID:  teamID,
+		BoardID: boardID,
+		BlockID: blockID,
+		Lock:    lock,
+	}
+
+	listeners := ws.getListenersForTeamAndBoard(teamID, boardID)
+	for _, listener := range listeners {
+		ws.logger.Debug("Broadcast block lock change",
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", boardID),
+			mlog.String("blockID", blockID),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		err := listener.WriteJSON(message)
+		if err != nil {
+			ws.logger.Error("broadcast error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
+
+// BroadcastTextEdit relays a delta applied to the text of a block to the
+// members of its board.
+func (ws *Server) BroadcastTextEdit(teamID string, edit *model.TextEdit) {
+	message := UpdateBlockTextMsg{
+		Action:  websocketActionUpdateBlockText,
+		TeamID:  teamID,
+		BoardID: edit.BoardID,
+		Edit:    edit,
+	}
+
+	listeners := ws.getListenersForTeamAndBoard(teamID, edit.BoardID)
+	for _, listener := range listeners {
+		ws.logger.Debug("Broadcast text edit",
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", edit.BoardID),
+			mlog.String("blockID", edit.BlockID),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		err := listener.WriteJSON(message)
+		if err != nil {
+			ws.logger.Error("broadcast error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
+
 func (ws *Server) BroadcastSubscriptionChange(workspaceID string, subscription *model.Subscription) {
 	// not implemented for standalone server.
 }
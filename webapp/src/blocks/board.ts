@@ -57,6 +57,7 @@ type BoardMember = {
     schemeEditor: boolean
     schemeCommenter: boolean
     schemeViewer: boolean
+    inactive?: boolean
 }
 
 type BoardsAndBlocks = {
@@ -14,6 +14,21 @@ type Card = Block & {
     fields: CardFields
 }
 
+type CardPreview = {
+    cardId: string
+    boardId: string
+    teamId: string
+    title: string
+    icon?: string
+    boardTitle: string
+    statusName?: string
+    status?: string
+    statusColor?: string
+    assignees: string[]
+    createdBy: string
+    updateAt: number
+}
+
 function createCard(block?: Block): Card {
     const contentOrder: Array<string|string[]> = []
     const contentIds = block?.fields?.contentOrder?.filter((id: any) => id !== null)
@@ -39,4 +54,4 @@ function createCard(block?: Block): Card {
     }
 }
 
-export {Card, createCard}
+export {Card, CardPreview, createCard}
@@ -66,6 +66,10 @@ const Sidebar = (props: Props) => {
         wsClient.addOnChange((_: WSClient, blockCategories: Array<BoardCategoryWebsocketData>) => {
             dispatch(updateBoardCategories(blockCategories))
         }, 'blockCategories')
+
+        wsClient.addOnChange((_: WSClient, categoryOrder: string[]) => {
+            dispatch(reorderCategories(categoryOrder))
+        }, 'categoryOrder')
     }, [])
 
     const team = useAppSelector(getCurrentTeam)
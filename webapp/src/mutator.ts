@@ -931,6 +931,14 @@ class Mutator {
         await octoClient.moveBoardToCategory(teamID, blockID, toCategoryID, fromCategoryID)
     }
 
+    async moveBoardsToCategory(teamID: string, boardIDs: string[], toCategoryID: string): Promise<void> {
+        await octoClient.moveBoardsToCategory(teamID, boardIDs, toCategoryID)
+    }
+
+    async reorderCategories(teamID: string, newCategoryOrder: string[]): Promise<void> {
+        await octoClient.reorderSidebarCategories(teamID, newCategoryOrder)
+    }
+
     async followBlock(blockId: string, blockType: string, userId: string) {
         await undoManager.perform(
             async () => {
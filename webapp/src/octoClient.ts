@@ -1,6 +1,7 @@
 // Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
 // See LICENSE.txt for license information.
 import {Block, BlockPatch} from './blocks/block'
+import {CardPreview} from './blocks/card'
 import {Board, BoardsAndBlocks, BoardsAndBlocksPatch, BoardPatch, BoardMember} from './blocks/board'
 import {ISharing} from './blocks/sharing'
 import {OctoUtils} from './octoUtils'
@@ -621,8 +622,8 @@ class OctoClient {
     // - ops to add/delete a board, add/delete board members, change roles? .
     // - WS definition and implementation
 
-    async getBoards(): Promise<Board[]> {
-        const path = this.teamPath() + '/boards'
+    async getBoards(teamId?: string): Promise<Board[]> {
+        const path = this.teamPath(teamId) + '/boards'
         return this.getBoardsWithPath(path)
     }
 
@@ -684,6 +685,35 @@ class OctoClient {
         return this.getJson<Block[]>(response, [] as Block[])
     }
 
+    async getCardPreview(cardID: string, optionalReadToken?: string): Promise<CardPreview | undefined> {
+        let path = `/api/v2/cards/${cardID}/preview`
+        const readToken = optionalReadToken || Utils.getReadToken()
+        if (readToken) {
+            path += `?read_token=${readToken}`
+        }
+        const response = await fetch(this.getBaseURL() + path, {headers: this.headers()})
+        if (response.status !== 200) {
+            return undefined
+        }
+
+        return this.getJson<CardPreview>(response, {} as CardPreview)
+    }
+
+    // Only available when running as a plugin
+    async createCardFromPost(postId: string, boardId: string): Promise<{card: Block, link: string} | undefined> {
+        const path = `/api/v2/posts/${postId}/card`
+        const response = await fetch(this.getBaseURL() + path, {
+            method: 'POST',
+            headers: this.headers(),
+            body: JSON.stringify({boardId}),
+        })
+        if (response.status !== 200) {
+            return undefined
+        }
+
+        return this.getJson<{card: Block, link: string}>(response, {} as {card: Block, link: string})
+    }
+
     async getBlocksForBoard(teamId: string, boardId: string): Promise<Board[]> {
         const path = this.teamPath(teamId) + `/boards/${boardId}`
         return this.getBoardsWithPath(path)
@@ -773,6 +803,28 @@ class OctoClient {
         })
     }
 
+    async moveBoardsToCategory(teamID: string, boardIDs: string[], toCategoryID: string): Promise<Response> {
+        const url = `/api/v2/teams/${teamID}/categories/${toCategoryID || '0'}/boards`
+        const body = JSON.stringify({boardIDs})
+
+        return fetch(this.getBaseURL() + url, {
+            method: 'POST',
+            headers: this.headers(),
+            body,
+        })
+    }
+
+    async reorderSidebarCategories(teamID: string, newCategoryOrder: string[]): Promise<Response> {
+        const url = `/api/v2/teams/${teamID}/categories/reorder`
+        const body = JSON.stringify(newCategoryOrder)
+
+        return fetch(this.getBaseURL() + url, {
+            method: 'PUT',
+            headers: this.headers(),
+            body,
+        })
+    }
+
     async search(teamID: string, query: string): Promise<Array<Board>> {
         const url = `${this.teamPath()}/boards/search?q=${encodeURIComponent(query)}`
         const response = await fetch(this.getBaseURL() + url, {
@@ -15,6 +15,7 @@ interface Category {
     createAt: number
     updateAt: number
     deleteAt: number
+    sortOrder?: number
 }
 
 interface CategoryBoards extends Category {
@@ -34,8 +35,8 @@ export const DefaultCategory: CategoryBoards = {
 export const fetchSidebarCategories = createAsyncThunk(
     'sidebarCategories/fetch',
     async (teamID: string) => {
-        const categories = await client.getSidebarCategories(teamID)
-        return categories.sort((a, b) => a.name.localeCompare(b.name))
+        // the categories come in the order the user sorted them in
+        return client.getSidebarCategories(teamID)
     },
 )
 
@@ -66,6 +67,7 @@ const sidebarSlice = createSlice({
                         ...state.categoryAttributes[index],
                         name: updatedCategory.name,
                         updateAt: updatedCategory.updateAt,
+                        sortOrder: updatedCategory.sortOrder,
                     }
                 }
             })
@@ -85,6 +87,13 @@ const sidebarSlice = createSlice({
                 }
             })
         },
+        reorderCategories: (state, action: PayloadAction<Array<string>>) => {
+            const positions = new Map(action.payload.map((categoryID, index) => [categoryID, index]))
+            state.categoryAttributes.forEach((category) => {
+                category.sortOrder = positions.get(category.id) ?? category.sortOrder
+            })
+            state.categoryAttributes.sort((a, b) => (a.sortOrder ?? 0) - (b.sortOrder ?? 0))
+        },
     },
     extraReducers: (builder) => {
         builder.addCase(fetchSidebarCategories.fulfilled, (state, action) => {
@@ -100,7 +109,7 @@ export const getSidebarCategories = createSelector(
 
 export const {reducer} = sidebarSlice
 
-export const {updateCategories, updateBoardCategories} = sidebarSlice.actions
+export const {updateCategories, updateBoardCategories, reorderCategories} = sidebarSlice.actions
 
 export {Category, CategoryBoards, BoardCategoryWebsocketData}
 
@@ -26,7 +26,7 @@ const SpacerClass = 'octo-spacer'
 const HorizontalGripClass = 'HorizontalGrip'
 const base32Alphabet = 'ybndrfg8ejkmcpqxot1uwisza345h769'
 
-export type WSMessagePayloads = Block | Category | BoardCategoryWebsocketData | BoardType | BoardMember | null
+export type WSMessagePayloads = Block | Category | Array<BoardCategoryWebsocketData> | string[] | BoardType | BoardMember | null
 
 // eslint-disable-next-line no-shadow
 enum IDType {
@@ -567,6 +567,8 @@ class Utils {
             return [message.category, 'category']
         } else if (message.blockCategories) {
             return [message.blockCategories, 'blockCategories']
+        } else if (message.categoryOrder) {
+            return [message.categoryOrder, 'categoryOrder']
         } else if (message.member) {
             return [message.member, 'boardMembers']
         }
@@ -21,9 +21,11 @@ type WSCommand = {
 export type WSMessage = {
     action?: string
     block?: Block
+    blocks?: Block[]
     board?: Board
     category?: Category
-    blockCategories?: BoardCategoryWebsocketData
+    blockCategories?: Array<BoardCategoryWebsocketData>
+    categoryOrder?: string[]
     error?: string
     teamId?: string
     member?: BoardMember
@@ -33,6 +35,7 @@ export const ACTION_UPDATE_BOARD = 'UPDATE_BOARD'
 export const ACTION_UPDATE_MEMBER = 'UPDATE_MEMBER'
 export const ACTION_DELETE_MEMBER = 'DELETE_MEMBER'
 export const ACTION_UPDATE_BLOCK = 'UPDATE_BLOCK'
+export const ACTION_UPDATE_BLOCKS = 'UPDATE_BLOCKS'
 export const ACTION_AUTH = 'AUTH'
 export const ACTION_SUBSCRIBE_BLOCKS = 'SUBSCRIBE_BLOCKS'
 export const ACTION_SUBSCRIBE_TEAM = 'SUBSCRIBE_TEAM'
@@ -41,6 +44,7 @@ export const ACTION_UNSUBSCRIBE_BLOCKS = 'UNSUBSCRIBE_BLOCKS'
 export const ACTION_UPDATE_CLIENT_CONFIG = 'UPDATE_CLIENT_CONFIG'
 export const ACTION_UPDATE_CATEGORY = 'UPDATE_CATEGORY'
 export const ACTION_UPDATE_BOARD_CATEGORY = 'UPDATE_BOARD_CATEGORY'
+export const ACTION_REORDER_CATEGORIES = 'REORDER_CATEGORIES'
 export const ACTION_UPDATE_SUBSCRIPTION = 'UPDATE_SUBSCRIPTION'
 
 type WSSubscriptionMsg = {
@@ -76,12 +80,13 @@ type OnErrorHandler = (client: WSClient, e: Event) => void
 type OnConfigChangeHandler = (client: WSClient, clientConfig: ClientConfig) => void
 type FollowChangeHandler = (client: WSClient, subscription: Subscription) => void
 
-export type ChangeHandlerType = 'block' | 'category' | 'blockCategories' | 'board' | 'boardMembers'
+export type ChangeHandlerType = 'block' | 'category' | 'blockCategories' | 'categoryOrder' | 'board' | 'boardMembers'
 
 type UpdatedData = {
     Blocks: Block[]
     Categories: Category[]
     BoardCategories: Array<BoardCategoryWebsocketData>
+    CategoryOrder: string[]
     Boards: Board[]
     BoardMembers: BoardMember[]
 }
@@ -90,6 +95,7 @@ type ChangeHandlers = {
     Block: OnChangeHandler[]
     Category: OnChangeHandler[]
     BoardCategory: OnChangeHandler[]
+    CategoryOrder: OnChangeHandler[]
     Board: OnChangeHandler[]
     BoardMember: OnChangeHandler[]
 }
@@ -107,14 +113,14 @@ class WSClient {
     state: 'init'|'open'|'close' = 'init'
     onStateChange: OnStateChangeHandler[] = []
     onReconnect: OnReconnectHandler[] = []
-    onChange: ChangeHandlers = {Block: [], Category: [], BoardCategory: [], Board: [], BoardMember: []}
+    onChange: ChangeHandlers = {Block: [], Category: [], BoardCategory: [], CategoryOrder: [], Board: [], BoardMember: []}
     onError: OnErrorHandler[] = []
     onConfigChange: OnConfigChangeHandler[] = []
     onFollowBlock: FollowChangeHandler = () => {}
     onUnfollowBlock: FollowChangeHandler = () => {}
     private notificationDelay = 100
     private reopenDelay = 3000
-    private updatedData: UpdatedData = {Blocks: [], Categories: [], BoardCategories: [], Boards: [], BoardMembers: []}
+    private updatedData: UpdatedData = {Blocks: [], Categories: [], BoardCategories: [], CategoryOrder: [], Boards: [], BoardMembers: []}
     private updateTimeout?: NodeJS.Timeout
     private errorPollId?: NodeJS.Timeout
 
@@ -171,6 +177,9 @@ class WSClient {
         case 'blockCategories':
             this.onChange.BoardCategory.push(handler)
             break
+        case 'categoryOrder':
+            this.onChange.CategoryOrder.push(handler)
+            break
         case 'board':
             this.onChange.Board.push(handler)
             break
@@ -189,6 +198,9 @@ class WSClient {
         case 'blockCategories':
             haystack = this.onChange.BoardCategory
             break
+        case 'categoryOrder':
+            haystack = this.onChange.CategoryOrder
+            break
         case 'board':
             haystack = this.onChange.Board
             break
@@ -382,12 +394,18 @@ class WSClient {
                 case ACTION_UPDATE_BLOCK:
                     this.updateHandler(message)
                     break
+                case ACTION_UPDATE_BLOCKS:
+                    this.updateHandler(message)
+                    break
                 case ACTION_UPDATE_CATEGORY:
                     this.updateHandler(message)
                     break
                 case ACTION_UPDATE_BOARD_CATEGORY:
                     this.updateHandler(message)
                     break
+                case ACTION_REORDER_CATEGORIES:
+                    this.updateHandler(message)
+                    break
                 case ACTION_UPDATE_SUBSCRIPTION:
                     this.updateSubscriptionHandler(message)
                     break
@@ -411,6 +429,14 @@ class WSClient {
             return
         }
 
+        // bulk changes carry several blocks in the same message
+        if (message.blocks) {
+            for (const block of message.blocks) {
+                this.queueUpdateNotification(Utils.fixBlock(block), 'block')
+            }
+            return
+        }
+
         const [data, type] = Utils.fixWSData(message)
         if (data) {
             this.queueUpdateNotification(data, type)
@@ -568,8 +594,14 @@ class WSClient {
             this.updatedData.Categories = this.updatedData.Categories.filter((c) => c.id !== (data as Category).id)
             this.updatedData.Categories.push(data as Category)
         } else if (type === 'blockCategories') {
-            this.updatedData.BoardCategories = this.updatedData.BoardCategories.filter((b) => b.boardID === (data as BoardCategoryWebsocketData).boardID)
-            this.updatedData.BoardCategories.push(data as BoardCategoryWebsocketData)
+            // a single message can move several boards
+            for (const boardCategory of data as Array<BoardCategoryWebsocketData>) {
+                this.updatedData.BoardCategories = this.updatedData.BoardCategories.filter((b) => b.boardID !== boardCategory.boardID)
+                this.updatedData.BoardCategories.push(boardCategory)
+            }
+        } else if (type === 'categoryOrder') {
+            // only the latest order matters
+            this.updatedData.CategoryOrder = data as string[]
         } else if (type === 'board') {
             this.updatedData.Boards = this.updatedData.Boards.filter((b) => b.id !== (data as Board).id)
             this.updatedData.Boards.push(data as Board)
@@ -640,6 +672,12 @@ class WSClient {
             handler(this, this.updatedData.BoardCategories)
         }
 
+        if (this.updatedData.CategoryOrder.length > 0) {
+            for (const handler of this.onChange.CategoryOrder) {
+                handler(this, this.updatedData.CategoryOrder)
+            }
+        }
+
         for (const handler of this.onChange.Board) {
             handler(this, this.updatedData.Boards)
         }
@@ -652,6 +690,7 @@ class WSClient {
             Blocks: [],
             Categories: [],
             BoardCategories: [],
+            CategoryOrder: [],
             Boards: [],
             BoardMembers: [],
         }
@@ -667,7 +706,7 @@ class WSClient {
         // Use this sequence so the onclose method doesn't try to re-open
         const ws = this.ws
         this.ws = null
-        this.onChange = {Block: [], Category: [], BoardCategory: [], Board: [], BoardMember: []}
+        this.onChange = {Block: [], Category: [], BoardCategory: [], CategoryOrder: [], Board: [], BoardMember: []}
         this.onReconnect = []
         this.onStateChange = []
         this.onError = []